@@ -27,6 +27,7 @@ const (
 	Coupon_ReceiveCoupon_FullMethodName                 = "/Coupon/ReceiveCoupon"
 	Coupon_GetUserCoupons_FullMethodName                = "/Coupon/GetUserCoupons"
 	Coupon_GetAvailableCoupons_FullMethodName           = "/Coupon/GetAvailableCoupons"
+	Coupon_ListCouponReminders_FullMethodName           = "/Coupon/ListCouponReminders"
 	Coupon_CalculateCouponDiscount_FullMethodName       = "/Coupon/CalculateCouponDiscount"
 	Coupon_UseCoupons_FullMethodName                    = "/Coupon/UseCoupons"
 	Coupon_ReleaseCoupons_FullMethodName                = "/Coupon/ReleaseCoupons"
@@ -36,13 +37,39 @@ const (
 	Coupon_GetActiveFlashSales_FullMethodName           = "/Coupon/GetActiveFlashSales"
 	Coupon_ParticipateFlashSale_FullMethodName          = "/Coupon/ParticipateFlashSale"
 	Coupon_GetFlashSaleStock_FullMethodName             = "/Coupon/GetFlashSaleStock"
+	Coupon_EnterFlashSaleQueue_FullMethodName           = "/Coupon/EnterFlashSaleQueue"
+	Coupon_GetFlashSaleQueueStatus_FullMethodName       = "/Coupon/GetFlashSaleQueueStatus"
 	Coupon_GetUserFlashSaleRecord_FullMethodName        = "/Coupon/GetUserFlashSaleRecord"
+	Coupon_ReserveFlashSaleGoods_FullMethodName         = "/Coupon/ReserveFlashSaleGoods"
+	Coupon_SettleFlashSaleReservation_FullMethodName    = "/Coupon/SettleFlashSaleReservation"
 	Coupon_SubmitOrderWithCoupons_FullMethodName        = "/Coupon/SubmitOrderWithCoupons"
 	Coupon_ProcessFlashSaleWithInventory_FullMethodName = "/Coupon/ProcessFlashSaleWithInventory"
 	Coupon_GetTransactionStatus_FullMethodName          = "/Coupon/GetTransactionStatus"
 	Coupon_TryFlashSale_FullMethodName                  = "/Coupon/TryFlashSale"
 	Coupon_ConfirmFlashSale_FullMethodName              = "/Coupon/ConfirmFlashSale"
 	Coupon_CancelFlashSale_FullMethodName               = "/Coupon/CancelFlashSale"
+	Coupon_GetCouponRuleConfig_FullMethodName           = "/Coupon/GetCouponRuleConfig"
+	Coupon_UpdateCouponRuleConfig_FullMethodName        = "/Coupon/UpdateCouponRuleConfig"
+	Coupon_DryRunCouponRules_FullMethodName             = "/Coupon/DryRunCouponRules"
+	Coupon_GeneratePromoCodes_FullMethodName            = "/Coupon/GeneratePromoCodes"
+	Coupon_CreatePublicPromoCode_FullMethodName         = "/Coupon/CreatePublicPromoCode"
+	Coupon_RedeemPromoCode_FullMethodName               = "/Coupon/RedeemPromoCode"
+	Coupon_ListPromoCodes_FullMethodName                = "/Coupon/ListPromoCodes"
+	Coupon_DisablePromoCode_FullMethodName              = "/Coupon/DisablePromoCode"
+	Coupon_CreateCouponCampaign_FullMethodName          = "/Coupon/CreateCouponCampaign"
+	Coupon_AddCouponCampaignTargets_FullMethodName      = "/Coupon/AddCouponCampaignTargets"
+	Coupon_GetCouponCampaign_FullMethodName             = "/Coupon/GetCouponCampaign"
+	Coupon_ListCouponCampaigns_FullMethodName           = "/Coupon/ListCouponCampaigns"
+	Coupon_StartCouponCampaign_FullMethodName           = "/Coupon/StartCouponCampaign"
+	Coupon_PauseCouponCampaign_FullMethodName           = "/Coupon/PauseCouponCampaign"
+	Coupon_CancelCouponCampaign_FullMethodName          = "/Coupon/CancelCouponCampaign"
+	Coupon_RerunCouponCampaign_FullMethodName           = "/Coupon/RerunCouponCampaign"
+	Coupon_DeleteCouponCampaign_FullMethodName          = "/Coupon/DeleteCouponCampaign"
+	Coupon_GetCouponReport_FullMethodName               = "/Coupon/GetCouponReport"
+	Coupon_GetFlashSaleReport_FullMethodName            = "/Coupon/GetFlashSaleReport"
+	Coupon_RebuildCouponReport_FullMethodName           = "/Coupon/RebuildCouponReport"
+	Coupon_EvaluateRisk_FullMethodName                  = "/Coupon/EvaluateRisk"
+	Coupon_ListRiskDecisions_FullMethodName             = "/Coupon/ListRiskDecisions"
 )
 
 // CouponClient is the client API for Coupon service.
@@ -58,6 +85,7 @@ type CouponClient interface {
 	ReceiveCoupon(ctx context.Context, in *ReceiveCouponRequest, opts ...grpc.CallOption) (*UserCouponResponse, error)
 	GetUserCoupons(ctx context.Context, in *GetUserCouponsRequest, opts ...grpc.CallOption) (*ListUserCouponsResponse, error)
 	GetAvailableCoupons(ctx context.Context, in *GetAvailableCouponsRequest, opts ...grpc.CallOption) (*ListUserCouponsResponse, error)
+	ListCouponReminders(ctx context.Context, in *ListCouponRemindersRequest, opts ...grpc.CallOption) (*ListCouponRemindersResponse, error)
 	// 优惠券使用（支付时调用）
 	CalculateCouponDiscount(ctx context.Context, in *CalculateCouponDiscountRequest, opts ...grpc.CallOption) (*CalculateCouponDiscountResponse, error)
 	UseCoupons(ctx context.Context, in *UseCouponsRequest, opts ...grpc.CallOption) (*UseCouponsResponse, error)
@@ -70,7 +98,11 @@ type CouponClient interface {
 	// 秒杀参与
 	ParticipateFlashSale(ctx context.Context, in *ParticipateFlashSaleRequest, opts ...grpc.CallOption) (*ParticipateFlashSaleResponse, error)
 	GetFlashSaleStock(ctx context.Context, in *GetFlashSaleStockRequest, opts ...grpc.CallOption) (*FlashSaleStockResponse, error)
+	EnterFlashSaleQueue(ctx context.Context, in *FlashSaleQueueRequest, opts ...grpc.CallOption) (*FlashSaleQueueStatusResponse, error)
+	GetFlashSaleQueueStatus(ctx context.Context, in *FlashSaleQueueRequest, opts ...grpc.CallOption) (*FlashSaleQueueStatusResponse, error)
 	GetUserFlashSaleRecord(ctx context.Context, in *GetUserFlashSaleRecordRequest, opts ...grpc.CallOption) (*ListFlashSaleRecordsResponse, error)
+	ReserveFlashSaleGoods(ctx context.Context, in *ParticipateFlashSaleRequest, opts ...grpc.CallOption) (*FlashSaleReservationResponse, error)
+	SettleFlashSaleReservation(ctx context.Context, in *SettleFlashSaleReservationRequest, opts ...grpc.CallOption) (*FlashSaleReservationResponse, error)
 	// DTM分布式事务接口
 	SubmitOrderWithCoupons(ctx context.Context, in *SubmitOrderWithCouponsRequest, opts ...grpc.CallOption) (*SubmitOrderWithCouponsResponse, error)
 	ProcessFlashSaleWithInventory(ctx context.Context, in *ProcessFlashSaleWithInventoryRequest, opts ...grpc.CallOption) (*ProcessFlashSaleWithInventoryResponse, error)
@@ -79,6 +111,33 @@ type CouponClient interface {
 	TryFlashSale(ctx context.Context, in *ParticipateFlashSaleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	ConfirmFlashSale(ctx context.Context, in *ParticipateFlashSaleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	CancelFlashSale(ctx context.Context, in *ParticipateFlashSaleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// 业务规则配置
+	GetCouponRuleConfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CouponRuleConfigResponse, error)
+	UpdateCouponRuleConfig(ctx context.Context, in *UpdateCouponRuleConfigRequest, opts ...grpc.CallOption) (*CouponRuleConfigResponse, error)
+	DryRunCouponRules(ctx context.Context, in *DryRunCouponRulesRequest, opts ...grpc.CallOption) (*DryRunCouponRulesResponse, error)
+	// 兑换码
+	GeneratePromoCodes(ctx context.Context, in *GeneratePromoCodesRequest, opts ...grpc.CallOption) (*GeneratePromoCodesResponse, error)
+	CreatePublicPromoCode(ctx context.Context, in *CreatePublicPromoCodeRequest, opts ...grpc.CallOption) (*PromoCodeResponse, error)
+	RedeemPromoCode(ctx context.Context, in *RedeemPromoCodeRequest, opts ...grpc.CallOption) (*UserCouponResponse, error)
+	ListPromoCodes(ctx context.Context, in *ListPromoCodesRequest, opts ...grpc.CallOption) (*ListPromoCodesResponse, error)
+	DisablePromoCode(ctx context.Context, in *DisablePromoCodeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// 定向发券活动
+	CreateCouponCampaign(ctx context.Context, in *CreateCouponCampaignRequest, opts ...grpc.CallOption) (*CouponCampaignResponse, error)
+	AddCouponCampaignTargets(ctx context.Context, in *AddCouponCampaignTargetsRequest, opts ...grpc.CallOption) (*AddCouponCampaignTargetsResponse, error)
+	GetCouponCampaign(ctx context.Context, in *CouponCampaignIdRequest, opts ...grpc.CallOption) (*CouponCampaignResponse, error)
+	ListCouponCampaigns(ctx context.Context, in *ListCouponCampaignsRequest, opts ...grpc.CallOption) (*ListCouponCampaignsResponse, error)
+	StartCouponCampaign(ctx context.Context, in *CouponCampaignIdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	PauseCouponCampaign(ctx context.Context, in *CouponCampaignIdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CancelCouponCampaign(ctx context.Context, in *CouponCampaignIdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RerunCouponCampaign(ctx context.Context, in *CouponCampaignIdRequest, opts ...grpc.CallOption) (*RerunCouponCampaignResponse, error)
+	DeleteCouponCampaign(ctx context.Context, in *CouponCampaignIdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// 效果报表
+	GetCouponReport(ctx context.Context, in *CouponReportRequest, opts ...grpc.CallOption) (*CouponReportResponse, error)
+	GetFlashSaleReport(ctx context.Context, in *FlashSaleReportRequest, opts ...grpc.CallOption) (*FlashSaleReportResponse, error)
+	RebuildCouponReport(ctx context.Context, in *RebuildCouponReportRequest, opts ...grpc.CallOption) (*RebuildCouponReportResponse, error)
+	// 风控
+	EvaluateRisk(ctx context.Context, in *EvaluateRiskRequest, opts ...grpc.CallOption) (*RiskDecisionResponse, error)
+	ListRiskDecisions(ctx context.Context, in *ListRiskDecisionsRequest, opts ...grpc.CallOption) (*ListRiskDecisionsResponse, error)
 }
 
 type couponClient struct {
@@ -159,6 +218,16 @@ func (c *couponClient) GetAvailableCoupons(ctx context.Context, in *GetAvailable
 	return out, nil
 }
 
+func (c *couponClient) ListCouponReminders(ctx context.Context, in *ListCouponRemindersRequest, opts ...grpc.CallOption) (*ListCouponRemindersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCouponRemindersResponse)
+	err := c.cc.Invoke(ctx, Coupon_ListCouponReminders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *couponClient) CalculateCouponDiscount(ctx context.Context, in *CalculateCouponDiscountRequest, opts ...grpc.CallOption) (*CalculateCouponDiscountResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CalculateCouponDiscountResponse)
@@ -249,6 +318,26 @@ func (c *couponClient) GetFlashSaleStock(ctx context.Context, in *GetFlashSaleSt
 	return out, nil
 }
 
+func (c *couponClient) EnterFlashSaleQueue(ctx context.Context, in *FlashSaleQueueRequest, opts ...grpc.CallOption) (*FlashSaleQueueStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FlashSaleQueueStatusResponse)
+	err := c.cc.Invoke(ctx, Coupon_EnterFlashSaleQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *couponClient) GetFlashSaleQueueStatus(ctx context.Context, in *FlashSaleQueueRequest, opts ...grpc.CallOption) (*FlashSaleQueueStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FlashSaleQueueStatusResponse)
+	err := c.cc.Invoke(ctx, Coupon_GetFlashSaleQueueStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *couponClient) GetUserFlashSaleRecord(ctx context.Context, in *GetUserFlashSaleRecordRequest, opts ...grpc.CallOption) (*ListFlashSaleRecordsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListFlashSaleRecordsResponse)
@@ -259,6 +348,26 @@ func (c *couponClient) GetUserFlashSaleRecord(ctx context.Context, in *GetUserFl
 	return out, nil
 }
 
+func (c *couponClient) ReserveFlashSaleGoods(ctx context.Context, in *ParticipateFlashSaleRequest, opts ...grpc.CallOption) (*FlashSaleReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FlashSaleReservationResponse)
+	err := c.cc.Invoke(ctx, Coupon_ReserveFlashSaleGoods_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *couponClient) SettleFlashSaleReservation(ctx context.Context, in *SettleFlashSaleReservationRequest, opts ...grpc.CallOption) (*FlashSaleReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FlashSaleReservationResponse)
+	err := c.cc.Invoke(ctx, Coupon_SettleFlashSaleReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *couponClient) SubmitOrderWithCoupons(ctx context.Context, in *SubmitOrderWithCouponsRequest, opts ...grpc.CallOption) (*SubmitOrderWithCouponsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SubmitOrderWithCouponsResponse)
@@ -319,6 +428,226 @@ func (c *couponClient) CancelFlashSale(ctx context.Context, in *ParticipateFlash
 	return out, nil
 }
 
+func (c *couponClient) GetCouponRuleConfig(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CouponRuleConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CouponRuleConfigResponse)
+	err := c.cc.Invoke(ctx, Coupon_GetCouponRuleConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *couponClient) UpdateCouponRuleConfig(ctx context.Context, in *UpdateCouponRuleConfigRequest, opts ...grpc.CallOption) (*CouponRuleConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CouponRuleConfigResponse)
+	err := c.cc.Invoke(ctx, Coupon_UpdateCouponRuleConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *couponClient) DryRunCouponRules(ctx context.Context, in *DryRunCouponRulesRequest, opts ...grpc.CallOption) (*DryRunCouponRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DryRunCouponRulesResponse)
+	err := c.cc.Invoke(ctx, Coupon_DryRunCouponRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *couponClient) GeneratePromoCodes(ctx context.Context, in *GeneratePromoCodesRequest, opts ...grpc.CallOption) (*GeneratePromoCodesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GeneratePromoCodesResponse)
+	err := c.cc.Invoke(ctx, Coupon_GeneratePromoCodes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *couponClient) CreatePublicPromoCode(ctx context.Context, in *CreatePublicPromoCodeRequest, opts ...grpc.CallOption) (*PromoCodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromoCodeResponse)
+	err := c.cc.Invoke(ctx, Coupon_CreatePublicPromoCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *couponClient) RedeemPromoCode(ctx context.Context, in *RedeemPromoCodeRequest, opts ...grpc.CallOption) (*UserCouponResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserCouponResponse)
+	err := c.cc.Invoke(ctx, Coupon_RedeemPromoCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *couponClient) ListPromoCodes(ctx context.Context, in *ListPromoCodesRequest, opts ...grpc.CallOption) (*ListPromoCodesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPromoCodesResponse)
+	err := c.cc.Invoke(ctx, Coupon_ListPromoCodes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *couponClient) DisablePromoCode(ctx context.Context, in *DisablePromoCodeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Coupon_DisablePromoCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *couponClient) CreateCouponCampaign(ctx context.Context, in *CreateCouponCampaignRequest, opts ...grpc.CallOption) (*CouponCampaignResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CouponCampaignResponse)
+	err := c.cc.Invoke(ctx, Coupon_CreateCouponCampaign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *couponClient) AddCouponCampaignTargets(ctx context.Context, in *AddCouponCampaignTargetsRequest, opts ...grpc.CallOption) (*AddCouponCampaignTargetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddCouponCampaignTargetsResponse)
+	err := c.cc.Invoke(ctx, Coupon_AddCouponCampaignTargets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *couponClient) GetCouponCampaign(ctx context.Context, in *CouponCampaignIdRequest, opts ...grpc.CallOption) (*CouponCampaignResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CouponCampaignResponse)
+	err := c.cc.Invoke(ctx, Coupon_GetCouponCampaign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *couponClient) ListCouponCampaigns(ctx context.Context, in *ListCouponCampaignsRequest, opts ...grpc.CallOption) (*ListCouponCampaignsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCouponCampaignsResponse)
+	err := c.cc.Invoke(ctx, Coupon_ListCouponCampaigns_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *couponClient) StartCouponCampaign(ctx context.Context, in *CouponCampaignIdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Coupon_StartCouponCampaign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *couponClient) PauseCouponCampaign(ctx context.Context, in *CouponCampaignIdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Coupon_PauseCouponCampaign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *couponClient) CancelCouponCampaign(ctx context.Context, in *CouponCampaignIdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Coupon_CancelCouponCampaign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *couponClient) RerunCouponCampaign(ctx context.Context, in *CouponCampaignIdRequest, opts ...grpc.CallOption) (*RerunCouponCampaignResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RerunCouponCampaignResponse)
+	err := c.cc.Invoke(ctx, Coupon_RerunCouponCampaign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *couponClient) DeleteCouponCampaign(ctx context.Context, in *CouponCampaignIdRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Coupon_DeleteCouponCampaign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *couponClient) GetCouponReport(ctx context.Context, in *CouponReportRequest, opts ...grpc.CallOption) (*CouponReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CouponReportResponse)
+	err := c.cc.Invoke(ctx, Coupon_GetCouponReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *couponClient) GetFlashSaleReport(ctx context.Context, in *FlashSaleReportRequest, opts ...grpc.CallOption) (*FlashSaleReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FlashSaleReportResponse)
+	err := c.cc.Invoke(ctx, Coupon_GetFlashSaleReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *couponClient) RebuildCouponReport(ctx context.Context, in *RebuildCouponReportRequest, opts ...grpc.CallOption) (*RebuildCouponReportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RebuildCouponReportResponse)
+	err := c.cc.Invoke(ctx, Coupon_RebuildCouponReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *couponClient) EvaluateRisk(ctx context.Context, in *EvaluateRiskRequest, opts ...grpc.CallOption) (*RiskDecisionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RiskDecisionResponse)
+	err := c.cc.Invoke(ctx, Coupon_EvaluateRisk_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *couponClient) ListRiskDecisions(ctx context.Context, in *ListRiskDecisionsRequest, opts ...grpc.CallOption) (*ListRiskDecisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRiskDecisionsResponse)
+	err := c.cc.Invoke(ctx, Coupon_ListRiskDecisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CouponServer is the server API for Coupon service.
 // All implementations must embed UnimplementedCouponServer
 // for forward compatibility.
@@ -332,6 +661,7 @@ type CouponServer interface {
 	ReceiveCoupon(context.Context, *ReceiveCouponRequest) (*UserCouponResponse, error)
 	GetUserCoupons(context.Context, *GetUserCouponsRequest) (*ListUserCouponsResponse, error)
 	GetAvailableCoupons(context.Context, *GetAvailableCouponsRequest) (*ListUserCouponsResponse, error)
+	ListCouponReminders(context.Context, *ListCouponRemindersRequest) (*ListCouponRemindersResponse, error)
 	// 优惠券使用（支付时调用）
 	CalculateCouponDiscount(context.Context, *CalculateCouponDiscountRequest) (*CalculateCouponDiscountResponse, error)
 	UseCoupons(context.Context, *UseCouponsRequest) (*UseCouponsResponse, error)
@@ -344,7 +674,11 @@ type CouponServer interface {
 	// 秒杀参与
 	ParticipateFlashSale(context.Context, *ParticipateFlashSaleRequest) (*ParticipateFlashSaleResponse, error)
 	GetFlashSaleStock(context.Context, *GetFlashSaleStockRequest) (*FlashSaleStockResponse, error)
+	EnterFlashSaleQueue(context.Context, *FlashSaleQueueRequest) (*FlashSaleQueueStatusResponse, error)
+	GetFlashSaleQueueStatus(context.Context, *FlashSaleQueueRequest) (*FlashSaleQueueStatusResponse, error)
 	GetUserFlashSaleRecord(context.Context, *GetUserFlashSaleRecordRequest) (*ListFlashSaleRecordsResponse, error)
+	ReserveFlashSaleGoods(context.Context, *ParticipateFlashSaleRequest) (*FlashSaleReservationResponse, error)
+	SettleFlashSaleReservation(context.Context, *SettleFlashSaleReservationRequest) (*FlashSaleReservationResponse, error)
 	// DTM分布式事务接口
 	SubmitOrderWithCoupons(context.Context, *SubmitOrderWithCouponsRequest) (*SubmitOrderWithCouponsResponse, error)
 	ProcessFlashSaleWithInventory(context.Context, *ProcessFlashSaleWithInventoryRequest) (*ProcessFlashSaleWithInventoryResponse, error)
@@ -353,6 +687,33 @@ type CouponServer interface {
 	TryFlashSale(context.Context, *ParticipateFlashSaleRequest) (*emptypb.Empty, error)
 	ConfirmFlashSale(context.Context, *ParticipateFlashSaleRequest) (*emptypb.Empty, error)
 	CancelFlashSale(context.Context, *ParticipateFlashSaleRequest) (*emptypb.Empty, error)
+	// 业务规则配置
+	GetCouponRuleConfig(context.Context, *emptypb.Empty) (*CouponRuleConfigResponse, error)
+	UpdateCouponRuleConfig(context.Context, *UpdateCouponRuleConfigRequest) (*CouponRuleConfigResponse, error)
+	DryRunCouponRules(context.Context, *DryRunCouponRulesRequest) (*DryRunCouponRulesResponse, error)
+	// 兑换码
+	GeneratePromoCodes(context.Context, *GeneratePromoCodesRequest) (*GeneratePromoCodesResponse, error)
+	CreatePublicPromoCode(context.Context, *CreatePublicPromoCodeRequest) (*PromoCodeResponse, error)
+	RedeemPromoCode(context.Context, *RedeemPromoCodeRequest) (*UserCouponResponse, error)
+	ListPromoCodes(context.Context, *ListPromoCodesRequest) (*ListPromoCodesResponse, error)
+	DisablePromoCode(context.Context, *DisablePromoCodeRequest) (*emptypb.Empty, error)
+	// 定向发券活动
+	CreateCouponCampaign(context.Context, *CreateCouponCampaignRequest) (*CouponCampaignResponse, error)
+	AddCouponCampaignTargets(context.Context, *AddCouponCampaignTargetsRequest) (*AddCouponCampaignTargetsResponse, error)
+	GetCouponCampaign(context.Context, *CouponCampaignIdRequest) (*CouponCampaignResponse, error)
+	ListCouponCampaigns(context.Context, *ListCouponCampaignsRequest) (*ListCouponCampaignsResponse, error)
+	StartCouponCampaign(context.Context, *CouponCampaignIdRequest) (*emptypb.Empty, error)
+	PauseCouponCampaign(context.Context, *CouponCampaignIdRequest) (*emptypb.Empty, error)
+	CancelCouponCampaign(context.Context, *CouponCampaignIdRequest) (*emptypb.Empty, error)
+	RerunCouponCampaign(context.Context, *CouponCampaignIdRequest) (*RerunCouponCampaignResponse, error)
+	DeleteCouponCampaign(context.Context, *CouponCampaignIdRequest) (*emptypb.Empty, error)
+	// 效果报表
+	GetCouponReport(context.Context, *CouponReportRequest) (*CouponReportResponse, error)
+	GetFlashSaleReport(context.Context, *FlashSaleReportRequest) (*FlashSaleReportResponse, error)
+	RebuildCouponReport(context.Context, *RebuildCouponReportRequest) (*RebuildCouponReportResponse, error)
+	// 风控
+	EvaluateRisk(context.Context, *EvaluateRiskRequest) (*RiskDecisionResponse, error)
+	ListRiskDecisions(context.Context, *ListRiskDecisionsRequest) (*ListRiskDecisionsResponse, error)
 	mustEmbedUnimplementedCouponServer()
 }
 
@@ -384,6 +745,9 @@ func (UnimplementedCouponServer) GetUserCoupons(context.Context, *GetUserCoupons
 func (UnimplementedCouponServer) GetAvailableCoupons(context.Context, *GetAvailableCouponsRequest) (*ListUserCouponsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAvailableCoupons not implemented")
 }
+func (UnimplementedCouponServer) ListCouponReminders(context.Context, *ListCouponRemindersRequest) (*ListCouponRemindersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCouponReminders not implemented")
+}
 func (UnimplementedCouponServer) CalculateCouponDiscount(context.Context, *CalculateCouponDiscountRequest) (*CalculateCouponDiscountResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CalculateCouponDiscount not implemented")
 }
@@ -411,9 +775,21 @@ func (UnimplementedCouponServer) ParticipateFlashSale(context.Context, *Particip
 func (UnimplementedCouponServer) GetFlashSaleStock(context.Context, *GetFlashSaleStockRequest) (*FlashSaleStockResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFlashSaleStock not implemented")
 }
+func (UnimplementedCouponServer) EnterFlashSaleQueue(context.Context, *FlashSaleQueueRequest) (*FlashSaleQueueStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnterFlashSaleQueue not implemented")
+}
+func (UnimplementedCouponServer) GetFlashSaleQueueStatus(context.Context, *FlashSaleQueueRequest) (*FlashSaleQueueStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFlashSaleQueueStatus not implemented")
+}
 func (UnimplementedCouponServer) GetUserFlashSaleRecord(context.Context, *GetUserFlashSaleRecordRequest) (*ListFlashSaleRecordsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserFlashSaleRecord not implemented")
 }
+func (UnimplementedCouponServer) ReserveFlashSaleGoods(context.Context, *ParticipateFlashSaleRequest) (*FlashSaleReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveFlashSaleGoods not implemented")
+}
+func (UnimplementedCouponServer) SettleFlashSaleReservation(context.Context, *SettleFlashSaleReservationRequest) (*FlashSaleReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SettleFlashSaleReservation not implemented")
+}
 func (UnimplementedCouponServer) SubmitOrderWithCoupons(context.Context, *SubmitOrderWithCouponsRequest) (*SubmitOrderWithCouponsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SubmitOrderWithCoupons not implemented")
 }
@@ -432,6 +808,72 @@ func (UnimplementedCouponServer) ConfirmFlashSale(context.Context, *ParticipateF
 func (UnimplementedCouponServer) CancelFlashSale(context.Context, *ParticipateFlashSaleRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelFlashSale not implemented")
 }
+func (UnimplementedCouponServer) GetCouponRuleConfig(context.Context, *emptypb.Empty) (*CouponRuleConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCouponRuleConfig not implemented")
+}
+func (UnimplementedCouponServer) UpdateCouponRuleConfig(context.Context, *UpdateCouponRuleConfigRequest) (*CouponRuleConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCouponRuleConfig not implemented")
+}
+func (UnimplementedCouponServer) DryRunCouponRules(context.Context, *DryRunCouponRulesRequest) (*DryRunCouponRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DryRunCouponRules not implemented")
+}
+func (UnimplementedCouponServer) GeneratePromoCodes(context.Context, *GeneratePromoCodesRequest) (*GeneratePromoCodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GeneratePromoCodes not implemented")
+}
+func (UnimplementedCouponServer) CreatePublicPromoCode(context.Context, *CreatePublicPromoCodeRequest) (*PromoCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePublicPromoCode not implemented")
+}
+func (UnimplementedCouponServer) RedeemPromoCode(context.Context, *RedeemPromoCodeRequest) (*UserCouponResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeemPromoCode not implemented")
+}
+func (UnimplementedCouponServer) ListPromoCodes(context.Context, *ListPromoCodesRequest) (*ListPromoCodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPromoCodes not implemented")
+}
+func (UnimplementedCouponServer) DisablePromoCode(context.Context, *DisablePromoCodeRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisablePromoCode not implemented")
+}
+func (UnimplementedCouponServer) CreateCouponCampaign(context.Context, *CreateCouponCampaignRequest) (*CouponCampaignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCouponCampaign not implemented")
+}
+func (UnimplementedCouponServer) AddCouponCampaignTargets(context.Context, *AddCouponCampaignTargetsRequest) (*AddCouponCampaignTargetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCouponCampaignTargets not implemented")
+}
+func (UnimplementedCouponServer) GetCouponCampaign(context.Context, *CouponCampaignIdRequest) (*CouponCampaignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCouponCampaign not implemented")
+}
+func (UnimplementedCouponServer) ListCouponCampaigns(context.Context, *ListCouponCampaignsRequest) (*ListCouponCampaignsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCouponCampaigns not implemented")
+}
+func (UnimplementedCouponServer) StartCouponCampaign(context.Context, *CouponCampaignIdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartCouponCampaign not implemented")
+}
+func (UnimplementedCouponServer) PauseCouponCampaign(context.Context, *CouponCampaignIdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseCouponCampaign not implemented")
+}
+func (UnimplementedCouponServer) CancelCouponCampaign(context.Context, *CouponCampaignIdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelCouponCampaign not implemented")
+}
+func (UnimplementedCouponServer) RerunCouponCampaign(context.Context, *CouponCampaignIdRequest) (*RerunCouponCampaignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RerunCouponCampaign not implemented")
+}
+func (UnimplementedCouponServer) DeleteCouponCampaign(context.Context, *CouponCampaignIdRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCouponCampaign not implemented")
+}
+func (UnimplementedCouponServer) GetCouponReport(context.Context, *CouponReportRequest) (*CouponReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCouponReport not implemented")
+}
+func (UnimplementedCouponServer) GetFlashSaleReport(context.Context, *FlashSaleReportRequest) (*FlashSaleReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFlashSaleReport not implemented")
+}
+func (UnimplementedCouponServer) RebuildCouponReport(context.Context, *RebuildCouponReportRequest) (*RebuildCouponReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RebuildCouponReport not implemented")
+}
+func (UnimplementedCouponServer) EvaluateRisk(context.Context, *EvaluateRiskRequest) (*RiskDecisionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EvaluateRisk not implemented")
+}
+func (UnimplementedCouponServer) ListRiskDecisions(context.Context, *ListRiskDecisionsRequest) (*ListRiskDecisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRiskDecisions not implemented")
+}
 func (UnimplementedCouponServer) mustEmbedUnimplementedCouponServer() {}
 func (UnimplementedCouponServer) testEmbeddedByValue()                {}
 
@@ -579,6 +1021,24 @@ func _Coupon_GetAvailableCoupons_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Coupon_ListCouponReminders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCouponRemindersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).ListCouponReminders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_ListCouponReminders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).ListCouponReminders(ctx, req.(*ListCouponRemindersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Coupon_CalculateCouponDiscount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CalculateCouponDiscountRequest)
 	if err := dec(in); err != nil {
@@ -741,61 +1201,133 @@ func _Coupon_GetFlashSaleStock_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Coupon_GetUserFlashSaleRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetUserFlashSaleRecordRequest)
+func _Coupon_EnterFlashSaleQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlashSaleQueueRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CouponServer).GetUserFlashSaleRecord(ctx, in)
+		return srv.(CouponServer).EnterFlashSaleQueue(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Coupon_GetUserFlashSaleRecord_FullMethodName,
+		FullMethod: Coupon_EnterFlashSaleQueue_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CouponServer).GetUserFlashSaleRecord(ctx, req.(*GetUserFlashSaleRecordRequest))
+		return srv.(CouponServer).EnterFlashSaleQueue(ctx, req.(*FlashSaleQueueRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Coupon_SubmitOrderWithCoupons_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SubmitOrderWithCouponsRequest)
+func _Coupon_GetFlashSaleQueueStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlashSaleQueueRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CouponServer).SubmitOrderWithCoupons(ctx, in)
+		return srv.(CouponServer).GetFlashSaleQueueStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Coupon_SubmitOrderWithCoupons_FullMethodName,
+		FullMethod: Coupon_GetFlashSaleQueueStatus_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CouponServer).SubmitOrderWithCoupons(ctx, req.(*SubmitOrderWithCouponsRequest))
+		return srv.(CouponServer).GetFlashSaleQueueStatus(ctx, req.(*FlashSaleQueueRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Coupon_ProcessFlashSaleWithInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ProcessFlashSaleWithInventoryRequest)
+func _Coupon_GetUserFlashSaleRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserFlashSaleRecordRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CouponServer).ProcessFlashSaleWithInventory(ctx, in)
+		return srv.(CouponServer).GetUserFlashSaleRecord(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Coupon_ProcessFlashSaleWithInventory_FullMethodName,
+		FullMethod: Coupon_GetUserFlashSaleRecord_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CouponServer).ProcessFlashSaleWithInventory(ctx, req.(*ProcessFlashSaleWithInventoryRequest))
+		return srv.(CouponServer).GetUserFlashSaleRecord(ctx, req.(*GetUserFlashSaleRecordRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Coupon_GetTransactionStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _Coupon_ReserveFlashSaleGoods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParticipateFlashSaleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).ReserveFlashSaleGoods(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_ReserveFlashSaleGoods_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).ReserveFlashSaleGoods(ctx, req.(*ParticipateFlashSaleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coupon_SettleFlashSaleReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SettleFlashSaleReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).SettleFlashSaleReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_SettleFlashSaleReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).SettleFlashSaleReservation(ctx, req.(*SettleFlashSaleReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coupon_SubmitOrderWithCoupons_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitOrderWithCouponsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).SubmitOrderWithCoupons(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_SubmitOrderWithCoupons_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).SubmitOrderWithCoupons(ctx, req.(*SubmitOrderWithCouponsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coupon_ProcessFlashSaleWithInventory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessFlashSaleWithInventoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).ProcessFlashSaleWithInventory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_ProcessFlashSaleWithInventory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).ProcessFlashSaleWithInventory(ctx, req.(*ProcessFlashSaleWithInventoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coupon_GetTransactionStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetTransactionStatusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
@@ -867,6 +1399,402 @@ func _Coupon_CancelFlashSale_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Coupon_GetCouponRuleConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).GetCouponRuleConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_GetCouponRuleConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).GetCouponRuleConfig(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coupon_UpdateCouponRuleConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCouponRuleConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).UpdateCouponRuleConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_UpdateCouponRuleConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).UpdateCouponRuleConfig(ctx, req.(*UpdateCouponRuleConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coupon_DryRunCouponRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DryRunCouponRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).DryRunCouponRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_DryRunCouponRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).DryRunCouponRules(ctx, req.(*DryRunCouponRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coupon_GeneratePromoCodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GeneratePromoCodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).GeneratePromoCodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_GeneratePromoCodes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).GeneratePromoCodes(ctx, req.(*GeneratePromoCodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coupon_CreatePublicPromoCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePublicPromoCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).CreatePublicPromoCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_CreatePublicPromoCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).CreatePublicPromoCode(ctx, req.(*CreatePublicPromoCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coupon_RedeemPromoCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeemPromoCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).RedeemPromoCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_RedeemPromoCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).RedeemPromoCode(ctx, req.(*RedeemPromoCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coupon_ListPromoCodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPromoCodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).ListPromoCodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_ListPromoCodes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).ListPromoCodes(ctx, req.(*ListPromoCodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coupon_DisablePromoCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisablePromoCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).DisablePromoCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_DisablePromoCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).DisablePromoCode(ctx, req.(*DisablePromoCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coupon_CreateCouponCampaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCouponCampaignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).CreateCouponCampaign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_CreateCouponCampaign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).CreateCouponCampaign(ctx, req.(*CreateCouponCampaignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coupon_AddCouponCampaignTargets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCouponCampaignTargetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).AddCouponCampaignTargets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_AddCouponCampaignTargets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).AddCouponCampaignTargets(ctx, req.(*AddCouponCampaignTargetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coupon_GetCouponCampaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CouponCampaignIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).GetCouponCampaign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_GetCouponCampaign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).GetCouponCampaign(ctx, req.(*CouponCampaignIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coupon_ListCouponCampaigns_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCouponCampaignsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).ListCouponCampaigns(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_ListCouponCampaigns_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).ListCouponCampaigns(ctx, req.(*ListCouponCampaignsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coupon_StartCouponCampaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CouponCampaignIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).StartCouponCampaign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_StartCouponCampaign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).StartCouponCampaign(ctx, req.(*CouponCampaignIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coupon_PauseCouponCampaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CouponCampaignIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).PauseCouponCampaign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_PauseCouponCampaign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).PauseCouponCampaign(ctx, req.(*CouponCampaignIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coupon_CancelCouponCampaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CouponCampaignIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).CancelCouponCampaign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_CancelCouponCampaign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).CancelCouponCampaign(ctx, req.(*CouponCampaignIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coupon_RerunCouponCampaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CouponCampaignIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).RerunCouponCampaign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_RerunCouponCampaign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).RerunCouponCampaign(ctx, req.(*CouponCampaignIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coupon_DeleteCouponCampaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CouponCampaignIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).DeleteCouponCampaign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_DeleteCouponCampaign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).DeleteCouponCampaign(ctx, req.(*CouponCampaignIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coupon_GetCouponReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CouponReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).GetCouponReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_GetCouponReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).GetCouponReport(ctx, req.(*CouponReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coupon_GetFlashSaleReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlashSaleReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).GetFlashSaleReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_GetFlashSaleReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).GetFlashSaleReport(ctx, req.(*FlashSaleReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coupon_RebuildCouponReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebuildCouponReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).RebuildCouponReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_RebuildCouponReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).RebuildCouponReport(ctx, req.(*RebuildCouponReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coupon_EvaluateRisk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateRiskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).EvaluateRisk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_EvaluateRisk_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).EvaluateRisk(ctx, req.(*EvaluateRiskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Coupon_ListRiskDecisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRiskDecisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CouponServer).ListRiskDecisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Coupon_ListRiskDecisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CouponServer).ListRiskDecisions(ctx, req.(*ListRiskDecisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Coupon_ServiceDesc is the grpc.ServiceDesc for Coupon service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -902,6 +1830,10 @@ var Coupon_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAvailableCoupons",
 			Handler:    _Coupon_GetAvailableCoupons_Handler,
 		},
+		{
+			MethodName: "ListCouponReminders",
+			Handler:    _Coupon_ListCouponReminders_Handler,
+		},
 		{
 			MethodName: "CalculateCouponDiscount",
 			Handler:    _Coupon_CalculateCouponDiscount_Handler,
@@ -938,10 +1870,26 @@ var Coupon_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetFlashSaleStock",
 			Handler:    _Coupon_GetFlashSaleStock_Handler,
 		},
+		{
+			MethodName: "EnterFlashSaleQueue",
+			Handler:    _Coupon_EnterFlashSaleQueue_Handler,
+		},
+		{
+			MethodName: "GetFlashSaleQueueStatus",
+			Handler:    _Coupon_GetFlashSaleQueueStatus_Handler,
+		},
 		{
 			MethodName: "GetUserFlashSaleRecord",
 			Handler:    _Coupon_GetUserFlashSaleRecord_Handler,
 		},
+		{
+			MethodName: "ReserveFlashSaleGoods",
+			Handler:    _Coupon_ReserveFlashSaleGoods_Handler,
+		},
+		{
+			MethodName: "SettleFlashSaleReservation",
+			Handler:    _Coupon_SettleFlashSaleReservation_Handler,
+		},
 		{
 			MethodName: "SubmitOrderWithCoupons",
 			Handler:    _Coupon_SubmitOrderWithCoupons_Handler,
@@ -966,6 +1914,94 @@ var Coupon_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CancelFlashSale",
 			Handler:    _Coupon_CancelFlashSale_Handler,
 		},
+		{
+			MethodName: "GetCouponRuleConfig",
+			Handler:    _Coupon_GetCouponRuleConfig_Handler,
+		},
+		{
+			MethodName: "UpdateCouponRuleConfig",
+			Handler:    _Coupon_UpdateCouponRuleConfig_Handler,
+		},
+		{
+			MethodName: "DryRunCouponRules",
+			Handler:    _Coupon_DryRunCouponRules_Handler,
+		},
+		{
+			MethodName: "GeneratePromoCodes",
+			Handler:    _Coupon_GeneratePromoCodes_Handler,
+		},
+		{
+			MethodName: "CreatePublicPromoCode",
+			Handler:    _Coupon_CreatePublicPromoCode_Handler,
+		},
+		{
+			MethodName: "RedeemPromoCode",
+			Handler:    _Coupon_RedeemPromoCode_Handler,
+		},
+		{
+			MethodName: "ListPromoCodes",
+			Handler:    _Coupon_ListPromoCodes_Handler,
+		},
+		{
+			MethodName: "DisablePromoCode",
+			Handler:    _Coupon_DisablePromoCode_Handler,
+		},
+		{
+			MethodName: "CreateCouponCampaign",
+			Handler:    _Coupon_CreateCouponCampaign_Handler,
+		},
+		{
+			MethodName: "AddCouponCampaignTargets",
+			Handler:    _Coupon_AddCouponCampaignTargets_Handler,
+		},
+		{
+			MethodName: "GetCouponCampaign",
+			Handler:    _Coupon_GetCouponCampaign_Handler,
+		},
+		{
+			MethodName: "ListCouponCampaigns",
+			Handler:    _Coupon_ListCouponCampaigns_Handler,
+		},
+		{
+			MethodName: "StartCouponCampaign",
+			Handler:    _Coupon_StartCouponCampaign_Handler,
+		},
+		{
+			MethodName: "PauseCouponCampaign",
+			Handler:    _Coupon_PauseCouponCampaign_Handler,
+		},
+		{
+			MethodName: "CancelCouponCampaign",
+			Handler:    _Coupon_CancelCouponCampaign_Handler,
+		},
+		{
+			MethodName: "RerunCouponCampaign",
+			Handler:    _Coupon_RerunCouponCampaign_Handler,
+		},
+		{
+			MethodName: "DeleteCouponCampaign",
+			Handler:    _Coupon_DeleteCouponCampaign_Handler,
+		},
+		{
+			MethodName: "GetCouponReport",
+			Handler:    _Coupon_GetCouponReport_Handler,
+		},
+		{
+			MethodName: "GetFlashSaleReport",
+			Handler:    _Coupon_GetFlashSaleReport_Handler,
+		},
+		{
+			MethodName: "RebuildCouponReport",
+			Handler:    _Coupon_RebuildCouponReport_Handler,
+		},
+		{
+			MethodName: "EvaluateRisk",
+			Handler:    _Coupon_EvaluateRisk_Handler,
+		},
+		{
+			MethodName: "ListRiskDecisions",
+			Handler:    _Coupon_ListRiskDecisions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "coupon.proto",
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
-// - protoc-gen-go-grpc v1.2.0
-// - protoc             v3.17.3
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
 // source: inventory.proto
 
 package proto
@@ -16,8 +16,18 @@ import (
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the grpc package it is being compiled against.
-// Requires gRPC-Go v1.32.0 or later.
-const _ = grpc.SupportPackageIsVersion7
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Inventory_SetInv_FullMethodName      = "/Inventory/SetInv"
+	Inventory_InvDetail_FullMethodName   = "/Inventory/InvDetail"
+	Inventory_Sell_FullMethodName        = "/Inventory/Sell"
+	Inventory_Reback_FullMethodName      = "/Inventory/Reback"
+	Inventory_TrySell_FullMethodName     = "/Inventory/TrySell"
+	Inventory_ConfirmSell_FullMethodName = "/Inventory/ConfirmSell"
+	Inventory_CancelSell_FullMethodName  = "/Inventory/CancelSell"
+)
 
 // InventoryClient is the client API for Inventory service.
 //
@@ -27,6 +37,10 @@ type InventoryClient interface {
 	InvDetail(ctx context.Context, in *GoodsInvInfo, opts ...grpc.CallOption) (*GoodsInvInfo, error)
 	Sell(ctx context.Context, in *SellInfo, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Reback(ctx context.Context, in *SellInfo, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// TCC 分布式事务方法
+	TrySell(ctx context.Context, in *SellInfo, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ConfirmSell(ctx context.Context, in *SellInfo, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CancelSell(ctx context.Context, in *SellInfo, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type inventoryClient struct {
@@ -38,8 +52,9 @@ func NewInventoryClient(cc grpc.ClientConnInterface) InventoryClient {
 }
 
 func (c *inventoryClient) SetInv(ctx context.Context, in *GoodsInvInfo, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, "/Inventory/SetInv", in, out, opts...)
+	err := c.cc.Invoke(ctx, Inventory_SetInv_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -47,8 +62,9 @@ func (c *inventoryClient) SetInv(ctx context.Context, in *GoodsInvInfo, opts ...
 }
 
 func (c *inventoryClient) InvDetail(ctx context.Context, in *GoodsInvInfo, opts ...grpc.CallOption) (*GoodsInvInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GoodsInvInfo)
-	err := c.cc.Invoke(ctx, "/Inventory/InvDetail", in, out, opts...)
+	err := c.cc.Invoke(ctx, Inventory_InvDetail_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -56,8 +72,9 @@ func (c *inventoryClient) InvDetail(ctx context.Context, in *GoodsInvInfo, opts
 }
 
 func (c *inventoryClient) Sell(ctx context.Context, in *SellInfo, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, "/Inventory/Sell", in, out, opts...)
+	err := c.cc.Invoke(ctx, Inventory_Sell_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -65,8 +82,39 @@ func (c *inventoryClient) Sell(ctx context.Context, in *SellInfo, opts ...grpc.C
 }
 
 func (c *inventoryClient) Reback(ctx context.Context, in *SellInfo, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Inventory_Reback_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) TrySell(ctx context.Context, in *SellInfo, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Inventory_TrySell_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) ConfirmSell(ctx context.Context, in *SellInfo, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, "/Inventory/Reback", in, out, opts...)
+	err := c.cc.Invoke(ctx, Inventory_ConfirmSell_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inventoryClient) CancelSell(ctx context.Context, in *SellInfo, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Inventory_CancelSell_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -75,18 +123,25 @@ func (c *inventoryClient) Reback(ctx context.Context, in *SellInfo, opts ...grpc
 
 // InventoryServer is the server API for Inventory service.
 // All implementations must embed UnimplementedInventoryServer
-// for forward compatibility
+// for forward compatibility.
 type InventoryServer interface {
 	SetInv(context.Context, *GoodsInvInfo) (*emptypb.Empty, error)
 	InvDetail(context.Context, *GoodsInvInfo) (*GoodsInvInfo, error)
 	Sell(context.Context, *SellInfo) (*emptypb.Empty, error)
 	Reback(context.Context, *SellInfo) (*emptypb.Empty, error)
+	// TCC 分布式事务方法
+	TrySell(context.Context, *SellInfo) (*emptypb.Empty, error)
+	ConfirmSell(context.Context, *SellInfo) (*emptypb.Empty, error)
+	CancelSell(context.Context, *SellInfo) (*emptypb.Empty, error)
 	mustEmbedUnimplementedInventoryServer()
 }
 
-// UnimplementedInventoryServer must be embedded to have forward compatible implementations.
-type UnimplementedInventoryServer struct {
-}
+// UnimplementedInventoryServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedInventoryServer struct{}
 
 func (UnimplementedInventoryServer) SetInv(context.Context, *GoodsInvInfo) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetInv not implemented")
@@ -100,7 +155,17 @@ func (UnimplementedInventoryServer) Sell(context.Context, *SellInfo) (*emptypb.E
 func (UnimplementedInventoryServer) Reback(context.Context, *SellInfo) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Reback not implemented")
 }
+func (UnimplementedInventoryServer) TrySell(context.Context, *SellInfo) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TrySell not implemented")
+}
+func (UnimplementedInventoryServer) ConfirmSell(context.Context, *SellInfo) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmSell not implemented")
+}
+func (UnimplementedInventoryServer) CancelSell(context.Context, *SellInfo) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelSell not implemented")
+}
 func (UnimplementedInventoryServer) mustEmbedUnimplementedInventoryServer() {}
+func (UnimplementedInventoryServer) testEmbeddedByValue()                   {}
 
 // UnsafeInventoryServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to InventoryServer will
@@ -110,6 +175,13 @@ type UnsafeInventoryServer interface {
 }
 
 func RegisterInventoryServer(s grpc.ServiceRegistrar, srv InventoryServer) {
+	// If the following call pancis, it indicates UnimplementedInventoryServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
 	s.RegisterService(&Inventory_ServiceDesc, srv)
 }
 
@@ -123,7 +195,7 @@ func _Inventory_SetInv_Handler(srv interface{}, ctx context.Context, dec func(in
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Inventory/SetInv",
+		FullMethod: Inventory_SetInv_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(InventoryServer).SetInv(ctx, req.(*GoodsInvInfo))
@@ -141,7 +213,7 @@ func _Inventory_InvDetail_Handler(srv interface{}, ctx context.Context, dec func
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Inventory/InvDetail",
+		FullMethod: Inventory_InvDetail_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(InventoryServer).InvDetail(ctx, req.(*GoodsInvInfo))
@@ -159,7 +231,7 @@ func _Inventory_Sell_Handler(srv interface{}, ctx context.Context, dec func(inte
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Inventory/Sell",
+		FullMethod: Inventory_Sell_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(InventoryServer).Sell(ctx, req.(*SellInfo))
@@ -177,7 +249,7 @@ func _Inventory_Reback_Handler(srv interface{}, ctx context.Context, dec func(in
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/Inventory/Reback",
+		FullMethod: Inventory_Reback_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(InventoryServer).Reback(ctx, req.(*SellInfo))
@@ -185,6 +257,60 @@ func _Inventory_Reback_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Inventory_TrySell_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SellInfo)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).TrySell(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_TrySell_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).TrySell(ctx, req.(*SellInfo))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_ConfirmSell_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SellInfo)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).ConfirmSell(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_ConfirmSell_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).ConfirmSell(ctx, req.(*SellInfo))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inventory_CancelSell_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SellInfo)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServer).CancelSell(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Inventory_CancelSell_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServer).CancelSell(ctx, req.(*SellInfo))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Inventory_ServiceDesc is the grpc.ServiceDesc for Inventory service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -208,6 +334,18 @@ var Inventory_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Reback",
 			Handler:    _Inventory_Reback_Handler,
 		},
+		{
+			MethodName: "TrySell",
+			Handler:    _Inventory_TrySell_Handler,
+		},
+		{
+			MethodName: "ConfirmSell",
+			Handler:    _Inventory_ConfirmSell_Handler,
+		},
+		{
+			MethodName: "CancelSell",
+			Handler:    _Inventory_CancelSell_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "inventory.proto",
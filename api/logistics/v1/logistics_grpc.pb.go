@@ -20,16 +20,20 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Logistics_CreateLogisticsOrder_FullMethodName  = "/Logistics/CreateLogisticsOrder"
-	Logistics_GetLogisticsInfo_FullMethodName      = "/Logistics/GetLogisticsInfo"
-	Logistics_GetLogisticsTracks_FullMethodName    = "/Logistics/GetLogisticsTracks"
-	Logistics_UpdateLogisticsStatus_FullMethodName = "/Logistics/UpdateLogisticsStatus"
-	Logistics_SimulateShipment_FullMethodName      = "/Logistics/SimulateShipment"
-	Logistics_SimulateDelivery_FullMethodName      = "/Logistics/SimulateDelivery"
-	Logistics_CalculateShippingFee_FullMethodName  = "/Logistics/CalculateShippingFee"
-	Logistics_GetLogisticsCompanies_FullMethodName = "/Logistics/GetLogisticsCompanies"
-	Logistics_GetCouriers_FullMethodName           = "/Logistics/GetCouriers"
-	Logistics_CancelLogisticsOrder_FullMethodName  = "/Logistics/CancelLogisticsOrder"
+	Logistics_CreateLogisticsOrder_FullMethodName       = "/Logistics/CreateLogisticsOrder"
+	Logistics_GetLogisticsInfo_FullMethodName           = "/Logistics/GetLogisticsInfo"
+	Logistics_GetLogisticsTracks_FullMethodName         = "/Logistics/GetLogisticsTracks"
+	Logistics_UpdateLogisticsStatus_FullMethodName      = "/Logistics/UpdateLogisticsStatus"
+	Logistics_SimulateShipment_FullMethodName           = "/Logistics/SimulateShipment"
+	Logistics_SimulateDelivery_FullMethodName           = "/Logistics/SimulateDelivery"
+	Logistics_CalculateShippingFee_FullMethodName       = "/Logistics/CalculateShippingFee"
+	Logistics_GetLogisticsCompanies_FullMethodName      = "/Logistics/GetLogisticsCompanies"
+	Logistics_GetCouriers_FullMethodName                = "/Logistics/GetCouriers"
+	Logistics_CancelLogisticsOrder_FullMethodName       = "/Logistics/CancelLogisticsOrder"
+	Logistics_ListShippingRateConfigs_FullMethodName    = "/Logistics/ListShippingRateConfigs"
+	Logistics_GetShippingRateConfig_FullMethodName      = "/Logistics/GetShippingRateConfig"
+	Logistics_CreateShippingRateConfig_FullMethodName   = "/Logistics/CreateShippingRateConfig"
+	Logistics_ActivateShippingRateConfig_FullMethodName = "/Logistics/ActivateShippingRateConfig"
 )
 
 // LogisticsClient is the client API for Logistics service.
@@ -56,6 +60,11 @@ type LogisticsClient interface {
 	GetCouriers(ctx context.Context, in *GetCouriersRequest, opts ...grpc.CallOption) (*GetCouriersResponse, error)
 	// DTM分布式事务补偿方法
 	CancelLogisticsOrder(ctx context.Context, in *CancelLogisticsOrderRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// 运费规则配置管理（管理后台）
+	ListShippingRateConfigs(ctx context.Context, in *ListShippingRateConfigsRequest, opts ...grpc.CallOption) (*ListShippingRateConfigsResponse, error)
+	GetShippingRateConfig(ctx context.Context, in *GetShippingRateConfigRequest, opts ...grpc.CallOption) (*ShippingRateConfig, error)
+	CreateShippingRateConfig(ctx context.Context, in *CreateShippingRateConfigRequest, opts ...grpc.CallOption) (*ShippingRateConfig, error)
+	ActivateShippingRateConfig(ctx context.Context, in *ActivateShippingRateConfigRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type logisticsClient struct {
@@ -166,6 +175,46 @@ func (c *logisticsClient) CancelLogisticsOrder(ctx context.Context, in *CancelLo
 	return out, nil
 }
 
+func (c *logisticsClient) ListShippingRateConfigs(ctx context.Context, in *ListShippingRateConfigsRequest, opts ...grpc.CallOption) (*ListShippingRateConfigsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListShippingRateConfigsResponse)
+	err := c.cc.Invoke(ctx, Logistics_ListShippingRateConfigs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logisticsClient) GetShippingRateConfig(ctx context.Context, in *GetShippingRateConfigRequest, opts ...grpc.CallOption) (*ShippingRateConfig, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShippingRateConfig)
+	err := c.cc.Invoke(ctx, Logistics_GetShippingRateConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logisticsClient) CreateShippingRateConfig(ctx context.Context, in *CreateShippingRateConfigRequest, opts ...grpc.CallOption) (*ShippingRateConfig, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShippingRateConfig)
+	err := c.cc.Invoke(ctx, Logistics_CreateShippingRateConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logisticsClient) ActivateShippingRateConfig(ctx context.Context, in *ActivateShippingRateConfigRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Logistics_ActivateShippingRateConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LogisticsServer is the server API for Logistics service.
 // All implementations must embed UnimplementedLogisticsServer
 // for forward compatibility.
@@ -190,6 +239,11 @@ type LogisticsServer interface {
 	GetCouriers(context.Context, *GetCouriersRequest) (*GetCouriersResponse, error)
 	// DTM分布式事务补偿方法
 	CancelLogisticsOrder(context.Context, *CancelLogisticsOrderRequest) (*emptypb.Empty, error)
+	// 运费规则配置管理（管理后台）
+	ListShippingRateConfigs(context.Context, *ListShippingRateConfigsRequest) (*ListShippingRateConfigsResponse, error)
+	GetShippingRateConfig(context.Context, *GetShippingRateConfigRequest) (*ShippingRateConfig, error)
+	CreateShippingRateConfig(context.Context, *CreateShippingRateConfigRequest) (*ShippingRateConfig, error)
+	ActivateShippingRateConfig(context.Context, *ActivateShippingRateConfigRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedLogisticsServer()
 }
 
@@ -230,6 +284,18 @@ func (UnimplementedLogisticsServer) GetCouriers(context.Context, *GetCouriersReq
 func (UnimplementedLogisticsServer) CancelLogisticsOrder(context.Context, *CancelLogisticsOrderRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelLogisticsOrder not implemented")
 }
+func (UnimplementedLogisticsServer) ListShippingRateConfigs(context.Context, *ListShippingRateConfigsRequest) (*ListShippingRateConfigsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShippingRateConfigs not implemented")
+}
+func (UnimplementedLogisticsServer) GetShippingRateConfig(context.Context, *GetShippingRateConfigRequest) (*ShippingRateConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShippingRateConfig not implemented")
+}
+func (UnimplementedLogisticsServer) CreateShippingRateConfig(context.Context, *CreateShippingRateConfigRequest) (*ShippingRateConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateShippingRateConfig not implemented")
+}
+func (UnimplementedLogisticsServer) ActivateShippingRateConfig(context.Context, *ActivateShippingRateConfigRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ActivateShippingRateConfig not implemented")
+}
 func (UnimplementedLogisticsServer) mustEmbedUnimplementedLogisticsServer() {}
 func (UnimplementedLogisticsServer) testEmbeddedByValue()                   {}
 
@@ -431,6 +497,78 @@ func _Logistics_CancelLogisticsOrder_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Logistics_ListShippingRateConfigs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListShippingRateConfigsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogisticsServer).ListShippingRateConfigs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Logistics_ListShippingRateConfigs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogisticsServer).ListShippingRateConfigs(ctx, req.(*ListShippingRateConfigsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Logistics_GetShippingRateConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShippingRateConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogisticsServer).GetShippingRateConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Logistics_GetShippingRateConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogisticsServer).GetShippingRateConfig(ctx, req.(*GetShippingRateConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Logistics_CreateShippingRateConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateShippingRateConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogisticsServer).CreateShippingRateConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Logistics_CreateShippingRateConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogisticsServer).CreateShippingRateConfig(ctx, req.(*CreateShippingRateConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Logistics_ActivateShippingRateConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivateShippingRateConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogisticsServer).ActivateShippingRateConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Logistics_ActivateShippingRateConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogisticsServer).ActivateShippingRateConfig(ctx, req.(*ActivateShippingRateConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Logistics_ServiceDesc is the grpc.ServiceDesc for Logistics service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -478,6 +616,22 @@ var Logistics_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CancelLogisticsOrder",
 			Handler:    _Logistics_CancelLogisticsOrder_Handler,
 		},
+		{
+			MethodName: "ListShippingRateConfigs",
+			Handler:    _Logistics_ListShippingRateConfigs_Handler,
+		},
+		{
+			MethodName: "GetShippingRateConfig",
+			Handler:    _Logistics_GetShippingRateConfig_Handler,
+		},
+		{
+			MethodName: "CreateShippingRateConfig",
+			Handler:    _Logistics_CreateShippingRateConfig_Handler,
+		},
+		{
+			MethodName: "ActivateShippingRateConfig",
+			Handler:    _Logistics_ActivateShippingRateConfig_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "logistics.proto",
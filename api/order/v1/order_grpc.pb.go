@@ -2,7 +2,7 @@
 // versions:
 // - protoc-gen-go-grpc v1.5.1
 // - protoc             v5.29.3
-// source: api/order/v1/order.proto
+// source: order.proto
 
 package proto
 
@@ -20,39 +20,48 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Order_CartItemList_FullMethodName        = "/Order/CartItemList"
-	Order_CreateCartItem_FullMethodName      = "/Order/CreateCartItem"
-	Order_UpdateCartItem_FullMethodName      = "/Order/UpdateCartItem"
-	Order_DeleteCartItem_FullMethodName      = "/Order/DeleteCartItem"
-	Order_CreateOrder_FullMethodName         = "/Order/CreateOrder"
-	Order_CreateOrderCom_FullMethodName      = "/Order/CreateOrderCom"
-	Order_SubmitOrder_FullMethodName         = "/Order/SubmitOrder"
-	Order_OrderList_FullMethodName           = "/Order/OrderList"
-	Order_OrderDetail_FullMethodName         = "/Order/OrderDetail"
-	Order_UpdateOrderStatus_FullMethodName   = "/Order/UpdateOrderStatus"
-	Order_UpdatePaymentStatus_FullMethodName = "/Order/UpdatePaymentStatus"
-	Order_RevertPaymentStatus_FullMethodName = "/Order/RevertPaymentStatus"
+	Order_CartItemList_FullMethodName         = "/Order/CartItemList"
+	Order_CreateCartItem_FullMethodName       = "/Order/CreateCartItem"
+	Order_UpdateCartItem_FullMethodName       = "/Order/UpdateCartItem"
+	Order_DeleteCartItem_FullMethodName       = "/Order/DeleteCartItem"
+	Order_CreateOrder_FullMethodName          = "/Order/CreateOrder"
+	Order_CreateOrderCom_FullMethodName       = "/Order/CreateOrderCom"
+	Order_SubmitOrder_FullMethodName          = "/Order/SubmitOrder"
+	Order_OrderList_FullMethodName            = "/Order/OrderList"
+	Order_OrderDetail_FullMethodName          = "/Order/OrderDetail"
+	Order_ListOrderedUserIds_FullMethodName   = "/Order/ListOrderedUserIds"
+	Order_GetDailyOrderStats_FullMethodName   = "/Order/GetDailyOrderStats"
+	Order_UpdateOrderStatus_FullMethodName    = "/Order/UpdateOrderStatus"
+	Order_UpdatePaymentStatus_FullMethodName  = "/Order/UpdatePaymentStatus"
+	Order_RevertPaymentStatus_FullMethodName  = "/Order/RevertPaymentStatus"
+	Order_CreateFlashSaleOrder_FullMethodName = "/Order/CreateFlashSaleOrder"
+	Order_CloseFlashSaleOrder_FullMethodName  = "/Order/CloseFlashSaleOrder"
 )
 
 // OrderClient is the client API for Order service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type OrderClient interface {
-	// 购物车
+	//购物车
 	CartItemList(ctx context.Context, in *UserInfo, opts ...grpc.CallOption) (*CartItemListResponse, error)
 	CreateCartItem(ctx context.Context, in *CartItemRequest, opts ...grpc.CallOption) (*ShopCartInfoResponse, error)
 	UpdateCartItem(ctx context.Context, in *CartItemRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	DeleteCartItem(ctx context.Context, in *CartItemRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	// 订单
+	//订单
 	CreateOrder(ctx context.Context, in *OrderRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	CreateOrderCom(ctx context.Context, in *OrderRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	SubmitOrder(ctx context.Context, in *OrderRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	OrderList(ctx context.Context, in *OrderFilterRequest, opts ...grpc.CallOption) (*OrderListResponse, error)
 	OrderDetail(ctx context.Context, in *OrderRequest, opts ...grpc.CallOption) (*OrderInfoDetailResponse, error)
+	ListOrderedUserIds(ctx context.Context, in *ListOrderedUserIdsRequest, opts ...grpc.CallOption) (*OrderedUserIdsResponse, error)
+	GetDailyOrderStats(ctx context.Context, in *DailyOrderStatsRequest, opts ...grpc.CallOption) (*DailyOrderStatsResponse, error)
 	UpdateOrderStatus(ctx context.Context, in *OrderStatus, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// 支付相关接口（用于分布式事务）
 	UpdatePaymentStatus(ctx context.Context, in *UpdatePaymentStatusRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	RevertPaymentStatus(ctx context.Context, in *RevertPaymentStatusRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// 商品秒杀
+	CreateFlashSaleOrder(ctx context.Context, in *FlashSaleOrderRequest, opts ...grpc.CallOption) (*OrderInfoResponse, error)
+	CloseFlashSaleOrder(ctx context.Context, in *CloseFlashSaleOrderRequest, opts ...grpc.CallOption) (*CloseFlashSaleOrderResponse, error)
 }
 
 type orderClient struct {
@@ -153,6 +162,26 @@ func (c *orderClient) OrderDetail(ctx context.Context, in *OrderRequest, opts ..
 	return out, nil
 }
 
+func (c *orderClient) ListOrderedUserIds(ctx context.Context, in *ListOrderedUserIdsRequest, opts ...grpc.CallOption) (*OrderedUserIdsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderedUserIdsResponse)
+	err := c.cc.Invoke(ctx, Order_ListOrderedUserIds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderClient) GetDailyOrderStats(ctx context.Context, in *DailyOrderStatsRequest, opts ...grpc.CallOption) (*DailyOrderStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DailyOrderStatsResponse)
+	err := c.cc.Invoke(ctx, Order_GetDailyOrderStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *orderClient) UpdateOrderStatus(ctx context.Context, in *OrderStatus, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -183,25 +212,50 @@ func (c *orderClient) RevertPaymentStatus(ctx context.Context, in *RevertPayment
 	return out, nil
 }
 
+func (c *orderClient) CreateFlashSaleOrder(ctx context.Context, in *FlashSaleOrderRequest, opts ...grpc.CallOption) (*OrderInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OrderInfoResponse)
+	err := c.cc.Invoke(ctx, Order_CreateFlashSaleOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderClient) CloseFlashSaleOrder(ctx context.Context, in *CloseFlashSaleOrderRequest, opts ...grpc.CallOption) (*CloseFlashSaleOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CloseFlashSaleOrderResponse)
+	err := c.cc.Invoke(ctx, Order_CloseFlashSaleOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrderServer is the server API for Order service.
 // All implementations must embed UnimplementedOrderServer
 // for forward compatibility.
 type OrderServer interface {
-	// 购物车
+	//购物车
 	CartItemList(context.Context, *UserInfo) (*CartItemListResponse, error)
 	CreateCartItem(context.Context, *CartItemRequest) (*ShopCartInfoResponse, error)
 	UpdateCartItem(context.Context, *CartItemRequest) (*emptypb.Empty, error)
 	DeleteCartItem(context.Context, *CartItemRequest) (*emptypb.Empty, error)
-	// 订单
+	//订单
 	CreateOrder(context.Context, *OrderRequest) (*emptypb.Empty, error)
 	CreateOrderCom(context.Context, *OrderRequest) (*emptypb.Empty, error)
 	SubmitOrder(context.Context, *OrderRequest) (*emptypb.Empty, error)
 	OrderList(context.Context, *OrderFilterRequest) (*OrderListResponse, error)
 	OrderDetail(context.Context, *OrderRequest) (*OrderInfoDetailResponse, error)
+	ListOrderedUserIds(context.Context, *ListOrderedUserIdsRequest) (*OrderedUserIdsResponse, error)
+	GetDailyOrderStats(context.Context, *DailyOrderStatsRequest) (*DailyOrderStatsResponse, error)
 	UpdateOrderStatus(context.Context, *OrderStatus) (*emptypb.Empty, error)
 	// 支付相关接口（用于分布式事务）
 	UpdatePaymentStatus(context.Context, *UpdatePaymentStatusRequest) (*emptypb.Empty, error)
 	RevertPaymentStatus(context.Context, *RevertPaymentStatusRequest) (*emptypb.Empty, error)
+	// 商品秒杀
+	CreateFlashSaleOrder(context.Context, *FlashSaleOrderRequest) (*OrderInfoResponse, error)
+	CloseFlashSaleOrder(context.Context, *CloseFlashSaleOrderRequest) (*CloseFlashSaleOrderResponse, error)
 	mustEmbedUnimplementedOrderServer()
 }
 
@@ -239,6 +293,12 @@ func (UnimplementedOrderServer) OrderList(context.Context, *OrderFilterRequest)
 func (UnimplementedOrderServer) OrderDetail(context.Context, *OrderRequest) (*OrderInfoDetailResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method OrderDetail not implemented")
 }
+func (UnimplementedOrderServer) ListOrderedUserIds(context.Context, *ListOrderedUserIdsRequest) (*OrderedUserIdsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrderedUserIds not implemented")
+}
+func (UnimplementedOrderServer) GetDailyOrderStats(context.Context, *DailyOrderStatsRequest) (*DailyOrderStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDailyOrderStats not implemented")
+}
 func (UnimplementedOrderServer) UpdateOrderStatus(context.Context, *OrderStatus) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateOrderStatus not implemented")
 }
@@ -248,6 +308,12 @@ func (UnimplementedOrderServer) UpdatePaymentStatus(context.Context, *UpdatePaym
 func (UnimplementedOrderServer) RevertPaymentStatus(context.Context, *RevertPaymentStatusRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RevertPaymentStatus not implemented")
 }
+func (UnimplementedOrderServer) CreateFlashSaleOrder(context.Context, *FlashSaleOrderRequest) (*OrderInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFlashSaleOrder not implemented")
+}
+func (UnimplementedOrderServer) CloseFlashSaleOrder(context.Context, *CloseFlashSaleOrderRequest) (*CloseFlashSaleOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseFlashSaleOrder not implemented")
+}
 func (UnimplementedOrderServer) mustEmbedUnimplementedOrderServer() {}
 func (UnimplementedOrderServer) testEmbeddedByValue()               {}
 
@@ -431,6 +497,42 @@ func _Order_OrderDetail_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Order_ListOrderedUserIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrderedUserIdsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServer).ListOrderedUserIds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Order_ListOrderedUserIds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServer).ListOrderedUserIds(ctx, req.(*ListOrderedUserIdsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Order_GetDailyOrderStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DailyOrderStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServer).GetDailyOrderStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Order_GetDailyOrderStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServer).GetDailyOrderStats(ctx, req.(*DailyOrderStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Order_UpdateOrderStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(OrderStatus)
 	if err := dec(in); err != nil {
@@ -485,6 +587,42 @@ func _Order_RevertPaymentStatus_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Order_CreateFlashSaleOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlashSaleOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServer).CreateFlashSaleOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Order_CreateFlashSaleOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServer).CreateFlashSaleOrder(ctx, req.(*FlashSaleOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Order_CloseFlashSaleOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseFlashSaleOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServer).CloseFlashSaleOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Order_CloseFlashSaleOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServer).CloseFlashSaleOrder(ctx, req.(*CloseFlashSaleOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Order_ServiceDesc is the grpc.ServiceDesc for Order service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -528,6 +666,14 @@ var Order_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "OrderDetail",
 			Handler:    _Order_OrderDetail_Handler,
 		},
+		{
+			MethodName: "ListOrderedUserIds",
+			Handler:    _Order_ListOrderedUserIds_Handler,
+		},
+		{
+			MethodName: "GetDailyOrderStats",
+			Handler:    _Order_GetDailyOrderStats_Handler,
+		},
 		{
 			MethodName: "UpdateOrderStatus",
 			Handler:    _Order_UpdateOrderStatus_Handler,
@@ -540,7 +686,15 @@ var Order_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RevertPaymentStatus",
 			Handler:    _Order_RevertPaymentStatus_Handler,
 		},
+		{
+			MethodName: "CreateFlashSaleOrder",
+			Handler:    _Order_CreateFlashSaleOrder_Handler,
+		},
+		{
+			MethodName: "CloseFlashSaleOrder",
+			Handler:    _Order_CloseFlashSaleOrder_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
-	Metadata: "api/order/v1/order.proto",
+	Metadata: "order.proto",
 }
@@ -29,6 +29,7 @@ const (
 	User_AdminLogin_FullMethodName      = "/User/AdminLogin"
 	User_UserLogin_FullMethodName       = "/User/UserLogin"
 	User_SendSms_FullMethodName         = "/User/SendSms"
+	User_ListUserIds_FullMethodName     = "/User/ListUserIds"
 )
 
 // UserClient is the client API for User service.
@@ -44,6 +45,7 @@ type UserClient interface {
 	AdminLogin(ctx context.Context, in *AdminLoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
 	UserLogin(ctx context.Context, in *UserLoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
 	SendSms(ctx context.Context, in *SendSmsRequest, opts ...grpc.CallOption) (*SendSmsResponse, error)
+	ListUserIds(ctx context.Context, in *ListUserIdsRequest, opts ...grpc.CallOption) (*UserIdsResponse, error)
 }
 
 type userClient struct {
@@ -144,6 +146,16 @@ func (c *userClient) SendSms(ctx context.Context, in *SendSmsRequest, opts ...gr
 	return out, nil
 }
 
+func (c *userClient) ListUserIds(ctx context.Context, in *ListUserIdsRequest, opts ...grpc.CallOption) (*UserIdsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserIdsResponse)
+	err := c.cc.Invoke(ctx, User_ListUserIds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServer is the server API for User service.
 // All implementations must embed UnimplementedUserServer
 // for forward compatibility.
@@ -157,6 +169,7 @@ type UserServer interface {
 	AdminLogin(context.Context, *AdminLoginRequest) (*LoginResponse, error)
 	UserLogin(context.Context, *UserLoginRequest) (*LoginResponse, error)
 	SendSms(context.Context, *SendSmsRequest) (*SendSmsResponse, error)
+	ListUserIds(context.Context, *ListUserIdsRequest) (*UserIdsResponse, error)
 	mustEmbedUnimplementedUserServer()
 }
 
@@ -194,6 +207,9 @@ func (UnimplementedUserServer) UserLogin(context.Context, *UserLoginRequest) (*L
 func (UnimplementedUserServer) SendSms(context.Context, *SendSmsRequest) (*SendSmsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendSms not implemented")
 }
+func (UnimplementedUserServer) ListUserIds(context.Context, *ListUserIdsRequest) (*UserIdsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserIds not implemented")
+}
 func (UnimplementedUserServer) mustEmbedUnimplementedUserServer() {}
 func (UnimplementedUserServer) testEmbeddedByValue()              {}
 
@@ -377,6 +393,24 @@ func _User_SendSms_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _User_ListUserIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserIdsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).ListUserIds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: User_ListUserIds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).ListUserIds(ctx, req.(*ListUserIdsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // User_ServiceDesc is the grpc.ServiceDesc for User service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -420,6 +454,10 @@ var User_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SendSms",
 			Handler:    _User_SendSms_Handler,
 		},
+		{
+			MethodName: "ListUserIds",
+			Handler:    _User_ListUserIds_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "user.proto",
@@ -20,16 +20,18 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserOp_UserFavList_FullMethodName      = "/UserOp/UserFavList"
-	UserOp_CreateUserFav_FullMethodName    = "/UserOp/CreateUserFav"
-	UserOp_DeleteUserFav_FullMethodName    = "/UserOp/DeleteUserFav"
-	UserOp_GetUserFavDetail_FullMethodName = "/UserOp/GetUserFavDetail"
-	UserOp_GetAddressList_FullMethodName   = "/UserOp/GetAddressList"
-	UserOp_CreateAddress_FullMethodName    = "/UserOp/CreateAddress"
-	UserOp_UpdateAddress_FullMethodName    = "/UserOp/UpdateAddress"
-	UserOp_DeleteAddress_FullMethodName    = "/UserOp/DeleteAddress"
-	UserOp_MessageList_FullMethodName      = "/UserOp/MessageList"
-	UserOp_CreateMessage_FullMethodName    = "/UserOp/CreateMessage"
+	UserOp_UserFavList_FullMethodName             = "/UserOp/UserFavList"
+	UserOp_CreateUserFav_FullMethodName           = "/UserOp/CreateUserFav"
+	UserOp_DeleteUserFav_FullMethodName           = "/UserOp/DeleteUserFav"
+	UserOp_GetUserFavDetail_FullMethodName        = "/UserOp/GetUserFavDetail"
+	UserOp_ListFavUserIds_FullMethodName          = "/UserOp/ListFavUserIds"
+	UserOp_GetAddressList_FullMethodName          = "/UserOp/GetAddressList"
+	UserOp_CreateAddress_FullMethodName           = "/UserOp/CreateAddress"
+	UserOp_UpdateAddress_FullMethodName           = "/UserOp/UpdateAddress"
+	UserOp_DeleteAddress_FullMethodName           = "/UserOp/DeleteAddress"
+	UserOp_CountAddressSharedUsers_FullMethodName = "/UserOp/CountAddressSharedUsers"
+	UserOp_MessageList_FullMethodName             = "/UserOp/MessageList"
+	UserOp_CreateMessage_FullMethodName           = "/UserOp/CreateMessage"
 )
 
 // UserOpClient is the client API for UserOp service.
@@ -41,11 +43,13 @@ type UserOpClient interface {
 	CreateUserFav(ctx context.Context, in *UserFavRequest, opts ...grpc.CallOption) (*UserFavResponse, error)
 	DeleteUserFav(ctx context.Context, in *UserFavRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetUserFavDetail(ctx context.Context, in *UserFavRequest, opts ...grpc.CallOption) (*UserFavResponse, error)
+	ListFavUserIds(ctx context.Context, in *ListFavUserIdsRequest, opts ...grpc.CallOption) (*FavUserIdsResponse, error)
 	// 用户地址
 	GetAddressList(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*AddressListResponse, error)
 	CreateAddress(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*AddressResponse, error)
 	UpdateAddress(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	DeleteAddress(ctx context.Context, in *DeleteAddressRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CountAddressSharedUsers(ctx context.Context, in *AddressSharedUsersRequest, opts ...grpc.CallOption) (*AddressSharedUsersResponse, error)
 	// 用户留言
 	MessageList(ctx context.Context, in *MessageRequest, opts ...grpc.CallOption) (*MessageListResponse, error)
 	CreateMessage(ctx context.Context, in *MessageRequest, opts ...grpc.CallOption) (*MessageResponse, error)
@@ -99,6 +103,16 @@ func (c *userOpClient) GetUserFavDetail(ctx context.Context, in *UserFavRequest,
 	return out, nil
 }
 
+func (c *userOpClient) ListFavUserIds(ctx context.Context, in *ListFavUserIdsRequest, opts ...grpc.CallOption) (*FavUserIdsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FavUserIdsResponse)
+	err := c.cc.Invoke(ctx, UserOp_ListFavUserIds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userOpClient) GetAddressList(ctx context.Context, in *AddressRequest, opts ...grpc.CallOption) (*AddressListResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AddressListResponse)
@@ -139,6 +153,16 @@ func (c *userOpClient) DeleteAddress(ctx context.Context, in *DeleteAddressReque
 	return out, nil
 }
 
+func (c *userOpClient) CountAddressSharedUsers(ctx context.Context, in *AddressSharedUsersRequest, opts ...grpc.CallOption) (*AddressSharedUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddressSharedUsersResponse)
+	err := c.cc.Invoke(ctx, UserOp_CountAddressSharedUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userOpClient) MessageList(ctx context.Context, in *MessageRequest, opts ...grpc.CallOption) (*MessageListResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(MessageListResponse)
@@ -168,11 +192,13 @@ type UserOpServer interface {
 	CreateUserFav(context.Context, *UserFavRequest) (*UserFavResponse, error)
 	DeleteUserFav(context.Context, *UserFavRequest) (*emptypb.Empty, error)
 	GetUserFavDetail(context.Context, *UserFavRequest) (*UserFavResponse, error)
+	ListFavUserIds(context.Context, *ListFavUserIdsRequest) (*FavUserIdsResponse, error)
 	// 用户地址
 	GetAddressList(context.Context, *AddressRequest) (*AddressListResponse, error)
 	CreateAddress(context.Context, *AddressRequest) (*AddressResponse, error)
 	UpdateAddress(context.Context, *AddressRequest) (*emptypb.Empty, error)
 	DeleteAddress(context.Context, *DeleteAddressRequest) (*emptypb.Empty, error)
+	CountAddressSharedUsers(context.Context, *AddressSharedUsersRequest) (*AddressSharedUsersResponse, error)
 	// 用户留言
 	MessageList(context.Context, *MessageRequest) (*MessageListResponse, error)
 	CreateMessage(context.Context, *MessageRequest) (*MessageResponse, error)
@@ -198,6 +224,9 @@ func (UnimplementedUserOpServer) DeleteUserFav(context.Context, *UserFavRequest)
 func (UnimplementedUserOpServer) GetUserFavDetail(context.Context, *UserFavRequest) (*UserFavResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserFavDetail not implemented")
 }
+func (UnimplementedUserOpServer) ListFavUserIds(context.Context, *ListFavUserIdsRequest) (*FavUserIdsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFavUserIds not implemented")
+}
 func (UnimplementedUserOpServer) GetAddressList(context.Context, *AddressRequest) (*AddressListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAddressList not implemented")
 }
@@ -210,6 +239,9 @@ func (UnimplementedUserOpServer) UpdateAddress(context.Context, *AddressRequest)
 func (UnimplementedUserOpServer) DeleteAddress(context.Context, *DeleteAddressRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteAddress not implemented")
 }
+func (UnimplementedUserOpServer) CountAddressSharedUsers(context.Context, *AddressSharedUsersRequest) (*AddressSharedUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountAddressSharedUsers not implemented")
+}
 func (UnimplementedUserOpServer) MessageList(context.Context, *MessageRequest) (*MessageListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method MessageList not implemented")
 }
@@ -309,6 +341,24 @@ func _UserOp_GetUserFavDetail_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserOp_ListFavUserIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFavUserIdsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserOpServer).ListFavUserIds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserOp_ListFavUserIds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserOpServer).ListFavUserIds(ctx, req.(*ListFavUserIdsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserOp_GetAddressList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddressRequest)
 	if err := dec(in); err != nil {
@@ -381,6 +431,24 @@ func _UserOp_DeleteAddress_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserOp_CountAddressSharedUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddressSharedUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserOpServer).CountAddressSharedUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserOp_CountAddressSharedUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserOpServer).CountAddressSharedUsers(ctx, req.(*AddressSharedUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserOp_MessageList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(MessageRequest)
 	if err := dec(in); err != nil {
@@ -440,6 +508,10 @@ var UserOp_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetUserFavDetail",
 			Handler:    _UserOp_GetUserFavDetail_Handler,
 		},
+		{
+			MethodName: "ListFavUserIds",
+			Handler:    _UserOp_ListFavUserIds_Handler,
+		},
 		{
 			MethodName: "GetAddressList",
 			Handler:    _UserOp_GetAddressList_Handler,
@@ -456,6 +528,10 @@ var UserOp_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteAddress",
 			Handler:    _UserOp_DeleteAddress_Handler,
 		},
+		{
+			MethodName: "CountAddressSharedUsers",
+			Handler:    _UserOp_CountAddressSharedUsers_Handler,
+		},
 		{
 			MethodName: "MessageList",
 			Handler:    _UserOp_MessageList_Handler,
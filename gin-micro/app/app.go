@@ -77,6 +77,8 @@ func (a *App) buildInstance() (*registry.ServiceInstance, error) {
 	return &registry.ServiceInstance{
 		ID: 	   	a.opts.id,
 		Name:     	a.opts.name,
+		Version:  	a.opts.version,
+		Metadata: 	a.opts.metadata,
 		Endpoints: 	endpoints,
 	}, nil
 }
@@ -118,6 +120,7 @@ func (a *App) Run() error {
 	if a.opts.rpcServer != nil {
 		servers = append(servers, a.opts.rpcServer)
 	}
+	servers = append(servers, a.opts.servers...)
 	
 	ctx, cancel := context.WithCancel(context.Background())
 	a.cancel = cancel	// 保存取消函数,后续的 Stop() 方法可以调用它来取消所有的操作
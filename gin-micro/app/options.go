@@ -3,6 +3,7 @@ package app
 import (
 	// "emshop/gin-micro/registry" // Replace with the actual path to the registry package
 	"emshop/gin-micro/registry"
+	gs "emshop/gin-micro/server"
 	restserver "emshop/gin-micro/server/rest-server"
 	rpcserver "emshop/gin-micro/server/rpc-server"
 	"net/url"
@@ -13,6 +14,8 @@ import (
 type options struct {
 	id 	  string    // 服务的唯一标识
 	name string    // 服务的名称
+	version  string            // 服务的版本，客户端可按版本灰度路由
+	metadata map[string]string // 服务的元数据，如 zone、tenant
 	endpoints []url.URL // 服务的地址列表
 
 	sigs []os.Signal // 监听的信号
@@ -25,6 +28,7 @@ type options struct {
 
 	restServer *restserver.Server
 	rpcServer  *rpcserver.Server
+	servers    []gs.Server // 随应用启停的后台任务，如延时任务调度器、发件箱投递器
 }
 
 
@@ -46,6 +50,18 @@ func WithName(name string) Option {
 	}
 }
 
+func WithVersion(version string) Option {
+	return func(o *options) {
+		o.version = version
+	}
+}
+
+func WithMetadata(md map[string]string) Option {
+	return func(o *options) {
+		o.metadata = md
+	}
+}
+
 func WithEndpoints(endpoints []url.URL) Option {
 	return func(o *options) {
 		o.endpoints = endpoints
@@ -74,4 +90,11 @@ func WithRestServer(server *restserver.Server) Option {
 	return func(o *options) {
 		o.restServer = server
 	}
-}
\ No newline at end of file
+}
+
+// WithServer 添加随应用启停的后台任务，Start阻塞运行，应用退出时调用Stop
+func WithServer(servers ...gs.Server) Option {
+	return func(o *options) {
+		o.servers = append(o.servers, servers...)
+	}
+}
@@ -0,0 +1,55 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Pinger 可以 ping 的连接，如 *sql.DB
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// PingCheck 通过 PingContext 检查连接，用于 MySQL
+func PingCheck(p Pinger) CheckFunc {
+	return p.PingContext
+}
+
+// TCPCheck 检查地址是否可以建立 TCP 连接，任意一个地址可用即为可用，用于 RocketMQ NameServer 等没有 ping 接口的依赖
+func TCPCheck(addrs ...string) CheckFunc {
+	return func(ctx context.Context) error {
+		if len(addrs) == 0 {
+			return errors.New("no address")
+		}
+		var d net.Dialer
+		var errs []error
+		for _, addr := range addrs {
+			conn, err := d.DialContext(ctx, "tcp", addr)
+			if err == nil {
+				return conn.Close()
+			}
+			errs = append(errs, err)
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// GRPCCheck 调用 grpc health 服务检查下游服务，service 为空时检查服务整体状态
+func GRPCCheck(conn grpc.ClientConnInterface, service string) CheckFunc {
+	client := grpc_health_v1.NewHealthClient(conn)
+	return func(ctx context.Context) error {
+		resp, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: service})
+		if err != nil {
+			return err
+		}
+		if resp.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+			return fmt.Errorf("status %s", resp.GetStatus())
+		}
+		return nil
+	}
+}
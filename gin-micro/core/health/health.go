@@ -0,0 +1,265 @@
+// Package health 服务的存活和就绪检查
+//
+// 数据层在创建 MySQL、Redis、Elasticsearch、RocketMQ 连接和下游 gRPC 客户端时向默认注册表登记检查项，
+// REST 服务通过 /healthz、/readyz 输出检查结果，gRPC 服务按就绪状态切换 grpc health 的
+// SERVING/NOT_SERVING，Consul 心跳按就绪状态上报 passing/critical。
+package health
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+
+	// DefaultTimeout 单个检查项的默认超时时间
+	DefaultTimeout = 2 * time.Second
+)
+
+// CheckFunc 检查依赖是否可用，返回 nil 表示可用
+type CheckFunc func(ctx context.Context) error
+
+type check struct {
+	name     string
+	fn       CheckFunc
+	timeout  time.Duration
+	optional bool
+	liveness bool
+}
+
+// CheckOption 检查项选项
+type CheckOption func(*check)
+
+// Optional 检查失败只在报告中体现，不影响就绪状态，用于有降级方案的依赖
+func Optional() CheckOption {
+	return func(c *check) {
+		c.optional = true
+	}
+}
+
+// Liveness 同时参与存活检查，失败时进程应当被重启，只用于进程自身无法恢复的问题
+func Liveness() CheckOption {
+	return func(c *check) {
+		c.liveness = true
+	}
+}
+
+// WithTimeout 设置检查项的超时时间
+func WithTimeout(timeout time.Duration) CheckOption {
+	return func(c *check) {
+		c.timeout = timeout
+	}
+}
+
+// Result 单个检查项的结果
+type Result struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Optional bool   `json:"optional,omitempty"`
+	// LatencyMs 检查耗时，单位毫秒
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report 检查报告，非可选的检查项全部可用时状态为 up
+type Report struct {
+	Status    string    `json:"status"`
+	CheckedAt time.Time `json:"checked_at"`
+	Checks    []Result  `json:"checks"`
+}
+
+// Ready 所有非可选的检查项是否可用
+func (r *Report) Ready() bool {
+	return r.Status == StatusUp
+}
+
+// Error 汇总失败的非可选检查项，全部可用时返回空
+func (r *Report) Error() string {
+	var failed []string
+	for _, c := range r.Checks {
+		if c.Status == StatusDown && !c.Optional {
+			failed = append(failed, fmt.Sprintf("%s: %s", c.Name, c.Error))
+		}
+	}
+	return strings.Join(failed, "; ")
+}
+
+// Redacted 返回去掉错误详情的副本，对外公开的探活接口使用，避免泄露依赖地址等内部信息
+func (r *Report) Redacted() *Report {
+	redacted := &Report{Status: r.Status, CheckedAt: r.CheckedAt, Checks: make([]Result, len(r.Checks))}
+	for i, c := range r.Checks {
+		c.Error = ""
+		redacted.Checks[i] = c
+	}
+	return redacted
+}
+
+// Registry 检查项注册表，同名检查项后注册的覆盖先注册的
+type Registry struct {
+	mu     sync.RWMutex
+	checks []*check
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+var defaultRegistry = NewRegistry()
+
+// Default 返回默认注册表，rpcserver、restserver 和 Consul 注册中心默认使用
+func Default() *Registry {
+	return defaultRegistry
+}
+
+// Register 在默认注册表中登记检查项
+func Register(name string, fn CheckFunc, opts ...CheckOption) {
+	defaultRegistry.Register(name, fn, opts...)
+}
+
+// Register 登记检查项，默认参与就绪检查
+func (r *Registry) Register(name string, fn CheckFunc, opts ...CheckOption) {
+	c := &check{name: name, fn: fn, timeout: DefaultTimeout}
+	for _, o := range opts {
+		o(c)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, old := range r.checks {
+		if old.name == name {
+			r.checks[i] = c
+			return
+		}
+	}
+	r.checks = append(r.checks, c)
+}
+
+// Unregister 移除检查项
+func (r *Registry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, c := range r.checks {
+		if c.name == name {
+			r.checks = append(r.checks[:i:i], r.checks[i+1:]...)
+			return
+		}
+	}
+}
+
+// Liveness 执行存活检查，没有存活检查项时进程能响应即为存活
+func (r *Registry) Liveness(ctx context.Context) *Report {
+	return r.run(ctx, true)
+}
+
+// Readiness 并发执行所有检查项
+func (r *Registry) Readiness(ctx context.Context) *Report {
+	return r.run(ctx, false)
+}
+
+func (r *Registry) run(ctx context.Context, livenessOnly bool) *Report {
+	r.mu.RLock()
+	checks := make([]*check, 0, len(r.checks))
+	for _, c := range r.checks {
+		if !livenessOnly || c.liveness {
+			checks = append(checks, c)
+		}
+	}
+	r.mu.RUnlock()
+
+	report := &Report{Status: StatusUp, CheckedAt: time.Now(), Checks: make([]Result, len(checks))}
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c *check) {
+			defer wg.Done()
+			report.Checks[i] = c.run(ctx)
+		}(i, c)
+	}
+	wg.Wait()
+
+	for _, res := range report.Checks {
+		if res.Status == StatusDown && !res.Optional {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+func (c *check) run(ctx context.Context) (res Result) {
+	res = Result{Name: c.name, Status: StatusUp, Optional: c.optional}
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	defer func() {
+		res.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+	}()
+
+	// 检查函数不响应 context 时按超时处理
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- fmt.Errorf("panic: %v", p)
+			}
+		}()
+		done <- c.fn(ctx)
+	}()
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	if err != nil {
+		res.Status, res.Error = StatusDown, err.Error()
+	}
+	return res
+}
+
+// Watch 每隔 interval 执行一次就绪检查，首次检查和就绪状态变化时调用 fn，ctx 取消后返回
+func (r *Registry) Watch(ctx context.Context, interval time.Duration, fn func(*Report)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last *bool
+	for {
+		report := r.Readiness(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if ready := report.Ready(); last == nil || *last != ready {
+			last = &ready
+			fn(report)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CachedReadiness 返回带缓存的就绪检查，距上次检查不到 ttl 时直接返回上次的报告，
+// 并发请求只执行一次检查，探活请求频繁时不会放大为对依赖的检查
+func (r *Registry) CachedReadiness(ttl time.Duration) func(ctx context.Context) *Report {
+	var (
+		mu   sync.Mutex
+		last *Report
+	)
+	return func(ctx context.Context) *Report {
+		mu.Lock()
+		defer mu.Unlock()
+		if last != nil && time.Since(last.CheckedAt) < ttl {
+			return last
+		}
+		// 报告供后续请求复用，不随发起检查的请求取消
+		last = r.Readiness(context.WithoutCancel(ctx))
+		return last
+	}
+}
@@ -0,0 +1,101 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	r.Register("mysql", func(ctx context.Context) error { return nil })
+	r.Register("redis", func(ctx context.Context) error { return errors.New("connection refused") })
+	r.Register("grpc:emshop-user-srv", func(ctx context.Context) error { return errors.New("unavailable") }, Optional())
+	r.Register("slow", func(ctx context.Context) error { time.Sleep(time.Second); return nil }, WithTimeout(20*time.Millisecond))
+	r.Register("panic", func(ctx context.Context) error { panic("boom") })
+
+	report := r.Readiness(context.Background())
+	require.False(t, report.Ready())
+	require.Len(t, report.Checks, 5)
+	require.Equal(t, StatusUp, report.Checks[0].Status)
+	require.Equal(t, "connection refused", report.Checks[1].Error)
+	require.True(t, report.Checks[2].Optional)
+	require.Equal(t, context.DeadlineExceeded.Error(), report.Checks[3].Error)
+	require.Equal(t, "panic: boom", report.Checks[4].Error)
+	require.Equal(t, "redis: connection refused; slow: context deadline exceeded; panic: panic: boom", report.Error())
+
+	// 存活检查只执行标记为 Liveness 的检查项
+	require.True(t, r.Liveness(context.Background()).Ready())
+	require.Empty(t, r.Liveness(context.Background()).Checks)
+
+	// 同名覆盖，可选检查项失败不影响就绪
+	r.Register("redis", func(ctx context.Context) error { return nil })
+	r.Unregister("slow")
+	r.Unregister("panic")
+	report = r.Readiness(context.Background())
+	require.True(t, report.Ready())
+	require.Len(t, report.Checks, 3)
+}
+
+func TestWatch(t *testing.T) {
+	r := NewRegistry()
+	var healthy atomic.Bool
+	r.Register("mysql", func(ctx context.Context) error {
+		if healthy.Load() {
+			return nil
+		}
+		return errors.New("down")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := make(chan bool, 10)
+	go r.Watch(ctx, 10*time.Millisecond, func(report *Report) { changes <- report.Ready() })
+
+	require.False(t, <-changes)
+	healthy.Store(true)
+	require.True(t, <-changes)
+	// 状态不变时不通知
+	select {
+	case v := <-changes:
+		t.Fatalf("unexpected notification %v", v)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestCachedReadiness(t *testing.T) {
+	r := NewRegistry()
+	var calls atomic.Int32
+	r.Register("mysql", func(ctx context.Context) error {
+		calls.Add(1)
+		return errors.New("dial tcp 10.0.0.1:3306: connection refused")
+	})
+
+	readiness := r.CachedReadiness(50 * time.Millisecond)
+	first := readiness(context.Background())
+	require.Same(t, first, readiness(context.Background()))
+	require.Equal(t, int32(1), calls.Load())
+
+	time.Sleep(60 * time.Millisecond)
+	readiness(context.Background())
+	require.Equal(t, int32(2), calls.Load())
+
+	redacted := first.Redacted()
+	require.False(t, redacted.Ready())
+	require.Empty(t, redacted.Checks[0].Error)
+	require.NotEmpty(t, first.Checks[0].Error)
+}
+
+func TestTCPCheck(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := lis.Addr().String()
+	require.NoError(t, TCPCheck("127.0.0.1:1", addr)(context.Background()))
+	require.NoError(t, lis.Close())
+	require.Error(t, TCPCheck(addr)(context.Background()))
+}
@@ -0,0 +1,225 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"emshop/pkg/storage"
+
+	redis "github.com/redis/go-redis/v9"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// HeaderKey HTTP 请求携带幂等键的请求头
+	HeaderKey = "Idempotency-Key"
+	// ReplayedHeader 重放已保存响应时添加的响应头
+	ReplayedHeader = "Idempotent-Replayed"
+	// MetadataKey gRPC 请求携带幂等键的元数据
+	MetadataKey = "idempotency-key"
+	// ScopeMetadataKey gRPC 请求携带幂等键所属调用方的元数据，同一个键按调用方隔离
+	ScopeMetadataKey = "idempotency-scope"
+	// MaxKeyLength 幂等键的最大长度
+	MaxKeyLength = 255
+)
+
+// Record 幂等键对应的请求记录
+type Record struct {
+	// Fingerprint 首次请求的指纹，相同的键携带不同请求内容时拒绝
+	Fingerprint string `json:"fingerprint"`
+	// Done 为 false 表示首次请求仍在处理中
+	Done bool `json:"done"`
+	// 保存的响应，HTTP 为状态码、响应头和响应体，gRPC 为序列化后的响应消息
+	Status int               `json:"status,omitempty"`
+	Header map[string]string `json:"header,omitempty"`
+	Body   []byte            `json:"body,omitempty"`
+}
+
+// Store 保存幂等键的处理状态和响应
+type Store interface {
+	// Begin 占用幂等键并写入处理中记录，lockTTL 后记录过期以免实例崩溃后键一直被占用。
+	// 成功占用时返回 nil，键已存在时返回已有记录
+	Begin(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (*Record, error)
+	// Complete 保存处理完成的响应，ttl 内的重复请求直接重放该响应
+	Complete(ctx context.Context, key string, rec *Record, ttl time.Duration) error
+	// Release 释放处理失败的幂等键，客户端可以使用同一个键重试
+	Release(ctx context.Context, key string) error
+}
+
+// 编译时检查接口实现
+var (
+	_ Store = &RedisStore{}
+	_ Store = &MemoryStore{}
+)
+
+// Fingerprint 计算请求指纹
+func Fingerprint(parts ...[]byte) string {
+	h := sha256.New()
+	for _, p := range parts {
+		h.Write(p)
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// FromIncomingContext 读取 gRPC 请求携带的幂等键
+func FromIncomingContext(ctx context.Context) string {
+	if vals := metadata.ValueFromIncomingContext(ctx, MetadataKey); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
+// ScopeFromIncomingContext 读取 gRPC 请求携带的幂等键所属调用方
+func ScopeFromIncomingContext(ctx context.Context) string {
+	if vals := metadata.ValueFromIncomingContext(ctx, ScopeMetadataKey); len(vals) > 0 {
+		return vals[0]
+	}
+	return ""
+}
+
+// NewOutgoingContext 在 gRPC 调用的元数据中携带幂等键及其所属调用方（如 user:1），
+// 客户端重试时使用同一个键
+func NewOutgoingContext(ctx context.Context, scope, key string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, key, ScopeMetadataKey, scope)
+}
+
+// RedisStore 基于 Redis 的幂等记录存储，多个实例共享
+type RedisStore struct {
+	store     *storage.RedisCluster
+	keyPrefix string
+}
+
+// NewRedisStore 创建 Redis 幂等记录存储，使用 storage.ConnectToRedis 建立的连接
+func NewRedisStore(keyPrefix string) *RedisStore {
+	return &RedisStore{store: &storage.RedisCluster{}, keyPrefix: keyPrefix}
+}
+
+func (s *RedisStore) client() (redis.UniversalClient, error) {
+	if !storage.Connected() {
+		return nil, storage.ErrRedisIsDown
+	}
+	c := s.store.GetClient()
+	if c == nil {
+		return nil, storage.ErrRedisIsDown
+	}
+	return c, nil
+}
+
+// Begin 使用 SETNX 占用幂等键，Redis 不可用时返回 storage.ErrRedisIsDown
+func (s *RedisStore) Begin(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (*Record, error) {
+	c, err := s.client()
+	if err != nil {
+		return nil, err
+	}
+	pending, err := json.Marshal(&Record{Fingerprint: fingerprint})
+	if err != nil {
+		return nil, err
+	}
+	// 已有记录恰好在两条命令之间过期时重新占用
+	for i := 0; i < 2; i++ {
+		ok, err := c.SetNX(ctx, s.keyPrefix+key, pending, lockTTL).Result()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return nil, nil
+		}
+		data, err := c.Get(ctx, s.keyPrefix+key).Bytes()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var rec Record
+		if err := json.Unmarshal(data, &rec); err != nil {
+			return nil, err
+		}
+		return &rec, nil
+	}
+	return nil, errors.New("idempotency key changed concurrently")
+}
+
+// Complete 覆盖处理中记录，保存 ttl
+func (s *RedisStore) Complete(ctx context.Context, key string, rec *Record, ttl time.Duration) error {
+	c, err := s.client()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return c.Set(ctx, s.keyPrefix+key, data, ttl).Err()
+}
+
+// Release 删除幂等键
+func (s *RedisStore) Release(ctx context.Context, key string) error {
+	c, err := s.client()
+	if err != nil {
+		return err
+	}
+	return c.Del(ctx, s.keyPrefix+key).Err()
+}
+
+// MemoryStore 进程内的幂等记录存储，只对当前实例生效，用于测试和单实例部署
+type MemoryStore struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	records map[string]memoryRecord
+}
+
+type memoryRecord struct {
+	rec      Record
+	expireAt time.Time
+}
+
+// NewMemoryStore 创建进程内幂等记录存储
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{now: time.Now, records: make(map[string]memoryRecord)}
+}
+
+// Begin 占用幂等键，已过期的记录视为不存在
+func (s *MemoryStore) Begin(_ context.Context, key, fingerprint string, lockTTL time.Duration) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if r, ok := s.records[key]; ok && now.Before(r.expireAt) {
+		rec := r.rec
+		return &rec, nil
+	}
+	s.records[key] = memoryRecord{rec: Record{Fingerprint: fingerprint}, expireAt: now.Add(lockTTL)}
+	return nil, nil
+}
+
+// Complete 保存响应，同时清理已过期的记录
+func (s *MemoryStore) Complete(_ context.Context, key string, rec *Record, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for k, r := range s.records {
+		if !now.Before(r.expireAt) {
+			delete(s.records, k)
+		}
+	}
+	s.records[key] = memoryRecord{rec: *rec, expireAt: now.Add(ttl)}
+	return nil
+}
+
+// Release 删除幂等键
+func (s *MemoryStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
@@ -0,0 +1,75 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"emshop/pkg/storage"
+)
+
+func testStore(t *testing.T, s Store, key string, expire func(time.Duration)) {
+	ctx := context.Background()
+
+	rec, err := s.Begin(ctx, key, "fp1", time.Second)
+	if err != nil || rec != nil {
+		t.Fatalf("first begin should acquire the key: %+v %v", rec, err)
+	}
+	rec, err = s.Begin(ctx, key, "fp2", time.Second)
+	if err != nil || rec == nil || rec.Done || rec.Fingerprint != "fp1" {
+		t.Fatalf("duplicate begin should see the in-flight record: %+v %v", rec, err)
+	}
+
+	// 处理失败释放后可以重新占用
+	if err := s.Release(ctx, key); err != nil {
+		t.Fatal(err)
+	}
+	if rec, err := s.Begin(ctx, key, "fp1", time.Second); err != nil || rec != nil {
+		t.Fatalf("released key should be acquirable: %+v %v", rec, err)
+	}
+
+	done := &Record{Fingerprint: "fp1", Done: true, Status: 201, Header: map[string]string{"Content-Type": "application/json"}, Body: []byte(`{"id":1}`)}
+	if err := s.Complete(ctx, key, done, 2*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	rec, err = s.Begin(ctx, key, "fp1", time.Second)
+	if err != nil || rec == nil || !rec.Done || rec.Status != 201 || string(rec.Body) != `{"id":1}` ||
+		rec.Header["Content-Type"] != "application/json" {
+		t.Fatalf("completed record should be returned: %+v %v", rec, err)
+	}
+
+	expire(3 * time.Second)
+	if rec, err := s.Begin(ctx, key, "fp3", time.Second); err != nil || rec != nil {
+		t.Fatalf("expired record should be replaced: %+v %v", rec, err)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	now := time.Unix(0, 0)
+	s := NewMemoryStore()
+	s.now = func() time.Time { return now }
+	testStore(t, s, "k", func(d time.Duration) { now = now.Add(d) })
+}
+
+func TestRedisStore(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go storage.ConnectToRedis(ctx, &storage.Config{Host: "localhost", Port: 6379, Database: 15})
+	deadline := time.Now().Add(time.Second)
+	for !storage.Connected() {
+		if time.Now().After(deadline) {
+			t.Skip("redis not available")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	s := NewRedisStore("test:idempotency:")
+	testStore(t, s, time.Now().Format("150405.000000000"), time.Sleep)
+
+	storage.DisableRedis(true)
+	defer storage.DisableRedis(false)
+	if _, err := s.Begin(ctx, "k", "fp", time.Second); !errors.Is(err, storage.ErrRedisIsDown) {
+		t.Fatalf("expected ErrRedisIsDown, got %v", err)
+	}
+}
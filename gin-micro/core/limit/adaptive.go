@@ -0,0 +1,243 @@
+// Package limit 实现了不依赖外部规则的自适应并发限流
+//
+// 限流器按 Gradient2 算法根据请求耗时动态调整并发上限：短期平均耗时相对长期基线变长说明
+// 服务开始排队，上限随之收缩；耗时恢复后上限按 sqrt(limit) 的排队余量逐步放大。
+// 请求按优先级分级，低优先级在并发接近上限时最先被拒绝，Critical 级（如健康检查）永不拒绝。
+package limit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Priority 请求优先级，值越大越晚被拒绝
+type Priority int
+
+const (
+	// PriorityLow 可降级的请求，如列表、推荐，并发达到上限的 75% 即开始拒绝
+	PriorityLow Priority = iota
+	// PriorityNormal 普通请求，并发达到上限时拒绝
+	PriorityNormal
+	// PriorityHigh 重要请求，如支付回调，可以超出上限 50%
+	PriorityHigh
+	// PriorityCritical 健康检查等必须放行的请求，只计入并发不做拒绝
+	PriorityCritical
+)
+
+// String 返回优先级名称
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityNormal:
+		return "normal"
+	case PriorityHigh:
+		return "high"
+	case PriorityCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+// ratio 各优先级可使用的并发上限比例
+func (p Priority) ratio() float64 {
+	switch {
+	case p <= PriorityLow:
+		return 0.75
+	case p == PriorityNormal:
+		return 1
+	case p == PriorityHigh:
+		return 1.5
+	default:
+		return math.Inf(1)
+	}
+}
+
+// Options 限流器配置
+type Options struct {
+	// InitialLimit 初始并发上限
+	InitialLimit int
+	// MinLimit、MaxLimit 并发上限的调整范围
+	MinLimit int
+	MaxLimit int
+	// Tolerance 允许短期耗时超过长期基线的倍数，超过后开始收缩上限
+	Tolerance float64
+	// Smoothing 每次调整时新上限所占的权重，越小变化越平缓
+	Smoothing float64
+	// Window 采样窗口，窗口结束且样本数不少于 MinSamples 时调整一次上限
+	Window     time.Duration
+	MinSamples int
+	// LongWindow 长期耗时基线的指数平均样本数
+	LongWindow int
+}
+
+// DefaultOptions 返回默认配置
+func DefaultOptions() Options {
+	return Options{
+		InitialLimit: 50,
+		MinLimit:     10,
+		MaxLimit:     1000,
+		Tolerance:    1.5,
+		Smoothing:    0.2,
+		Window:       time.Second,
+		MinSamples:   10,
+		LongWindow:   600,
+	}
+}
+
+// Limiter 自适应并发限流器，并发安全
+type Limiter struct {
+	opts Options
+	now  func() time.Time
+
+	mu       sync.Mutex
+	limit    float64
+	inflight int
+	longRtt  float64 // 长期耗时基线（纳秒）
+
+	// 当前采样窗口
+	windowStart time.Time
+	rttSum      time.Duration
+	samples     int
+	maxInflight int
+	dropped     bool
+}
+
+// NewLimiter 创建限流器，未设置的字段使用默认值
+func NewLimiter(opts Options) *Limiter {
+	def := DefaultOptions()
+	if opts.MinLimit <= 0 {
+		opts.MinLimit = def.MinLimit
+	}
+	if opts.MaxLimit <= 0 {
+		opts.MaxLimit = def.MaxLimit
+	}
+	if opts.MaxLimit < opts.MinLimit {
+		opts.MaxLimit = opts.MinLimit
+	}
+	if opts.InitialLimit <= 0 {
+		opts.InitialLimit = def.InitialLimit
+	}
+	if opts.Tolerance < 1 {
+		opts.Tolerance = def.Tolerance
+	}
+	if opts.Smoothing <= 0 || opts.Smoothing > 1 {
+		opts.Smoothing = def.Smoothing
+	}
+	if opts.Window <= 0 {
+		opts.Window = def.Window
+	}
+	if opts.MinSamples <= 0 {
+		opts.MinSamples = def.MinSamples
+	}
+	if opts.LongWindow <= 0 {
+		opts.LongWindow = def.LongWindow
+	}
+	l := &Limiter{opts: opts, now: time.Now}
+	l.limit = l.clamp(float64(opts.InitialLimit))
+	l.windowStart = l.now()
+	return l
+}
+
+// Token 一次获准执行的请求，执行结束后必须调用 Done 或 Ignore 之一
+type Token struct {
+	l     *Limiter
+	start time.Time
+	once  sync.Once
+}
+
+// Acquire 按优先级申请执行，超出该优先级可用的并发时返回 false
+func (l *Limiter) Acquire(p Priority) (*Token, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if float64(l.inflight) >= l.limit*p.ratio() {
+		return nil, false
+	}
+	l.inflight++
+	return &Token{l: l, start: l.now()}, true
+}
+
+// Done 请求结束并记录耗时，dropped 表示请求因超时或下游过载失败，会使上限立即收缩
+func (t *Token) Done(dropped bool) {
+	t.once.Do(func() { t.l.release(t.start, dropped, true) })
+}
+
+// Ignore 请求结束但不计入耗时采样，用于流式请求等耗时不代表负载的场景
+func (t *Token) Ignore() {
+	t.once.Do(func() { t.l.release(t.start, false, false) })
+}
+
+// Limit 返回当前并发上限
+func (l *Limiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return int(l.limit)
+}
+
+// Inflight 返回当前正在执行的请求数
+func (l *Limiter) Inflight() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.inflight
+}
+
+func (l *Limiter) release(start time.Time, dropped, sample bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	inflight := l.inflight
+	l.inflight--
+	if !sample {
+		return
+	}
+
+	now := l.now()
+	l.rttSum += now.Sub(start)
+	l.samples++
+	if inflight > l.maxInflight {
+		l.maxInflight = inflight
+	}
+	l.dropped = l.dropped || dropped
+	if now.Sub(l.windowStart) < l.opts.Window || (l.samples < l.opts.MinSamples && !l.dropped) {
+		return
+	}
+
+	l.update(float64(l.rttSum)/float64(l.samples), l.maxInflight, l.dropped)
+	l.windowStart = now
+	l.rttSum, l.samples, l.maxInflight, l.dropped = 0, 0, 0, false
+}
+
+// update 根据一个窗口的平均耗时调整并发上限
+func (l *Limiter) update(rtt float64, inflight int, dropped bool) {
+	if rtt <= 0 {
+		rtt = 1
+	}
+	if l.longRtt == 0 {
+		l.longRtt = rtt
+	} else {
+		l.longRtt += (rtt - l.longRtt) * 2 / float64(l.opts.LongWindow+1)
+	}
+	// 负载下降后耗时明显变短，加速基线回落，避免旧基线让上限长期偏高
+	if l.longRtt/rtt > 2 {
+		l.longRtt *= 0.95
+	}
+
+	if dropped {
+		l.limit = l.clamp(l.limit * 0.9)
+		return
+	}
+	// 并发远未达到上限时耗时不能说明容量，保持上限不变
+	if float64(inflight) < l.limit/2 {
+		return
+	}
+
+	gradient := math.Max(0.5, math.Min(1, l.opts.Tolerance*l.longRtt/rtt))
+	next := l.limit*gradient + math.Sqrt(l.limit)
+	next = l.limit*(1-l.opts.Smoothing) + next*l.opts.Smoothing
+	l.limit = l.clamp(next)
+}
+
+func (l *Limiter) clamp(v float64) float64 {
+	return math.Max(float64(l.opts.MinLimit), math.Min(float64(l.opts.MaxLimit), v))
+}
@@ -0,0 +1,116 @@
+package limit
+
+import (
+	"testing"
+	"time"
+)
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func newTestLimiter(opts Options) (*Limiter, *fakeClock) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	l := NewLimiter(opts)
+	l.now = clock.now
+	l.windowStart = clock.t
+	return l, clock
+}
+
+// round 以 n 个并发请求跑完一个采样窗口，每个请求耗时 rtt
+func round(t *testing.T, l *Limiter, clock *fakeClock, n int, rtt time.Duration, dropped bool) {
+	t.Helper()
+	tokens := make([]*Token, 0, n)
+	for i := 0; i < n; i++ {
+		tk, ok := l.Acquire(PriorityCritical)
+		if !ok {
+			t.Fatalf("critical request rejected")
+		}
+		tokens = append(tokens, tk)
+	}
+	clock.t = clock.t.Add(rtt)
+	for _, tk := range tokens {
+		tk.Done(dropped)
+	}
+}
+
+func TestPriorityShedding(t *testing.T) {
+	l, _ := newTestLimiter(Options{InitialLimit: 10, MinLimit: 10, MaxLimit: 10})
+
+	admitted := func(p Priority) int {
+		n := 0
+		for {
+			if _, ok := l.Acquire(p); !ok {
+				return n
+			}
+			n++
+			if n > 100 {
+				return n
+			}
+		}
+	}
+
+	if n := admitted(PriorityLow); n != 8 {
+		t.Fatalf("low priority admitted %d, want 8", n)
+	}
+	if n := admitted(PriorityNormal); n != 2 {
+		t.Fatalf("normal priority admitted %d more, want 2", n)
+	}
+	if n := admitted(PriorityHigh); n != 5 {
+		t.Fatalf("high priority admitted %d more, want 5", n)
+	}
+	if _, ok := l.Acquire(PriorityCritical); !ok {
+		t.Fatalf("critical request must never be rejected")
+	}
+	if got := l.Inflight(); got != 16 {
+		t.Fatalf("inflight = %d, want 16", got)
+	}
+}
+
+func TestLimitGrowsAndShrinks(t *testing.T) {
+	l, clock := newTestLimiter(Options{InitialLimit: 20, MinLimit: 5, MaxLimit: 200, Window: 10 * time.Millisecond, MinSamples: 1})
+
+	for i := 0; i < 20; i++ {
+		round(t, l, clock, l.Limit(), 20*time.Millisecond, false)
+	}
+	grown := l.Limit()
+	if grown <= 20 {
+		t.Fatalf("limit should grow under stable latency, got %d", grown)
+	}
+
+	for i := 0; i < 5; i++ {
+		round(t, l, clock, l.Limit(), 200*time.Millisecond, false)
+	}
+	if got := l.Limit(); got >= grown {
+		t.Fatalf("limit should shrink when latency rises, got %d (was %d)", got, grown)
+	}
+	if got := l.Inflight(); got != 0 {
+		t.Fatalf("inflight = %d after all requests finished", got)
+	}
+}
+
+func TestAppLimitedAndDrops(t *testing.T) {
+	l, clock := newTestLimiter(Options{InitialLimit: 100, MinLimit: 5, MaxLimit: 200, Window: 10 * time.Millisecond, MinSamples: 1})
+
+	// 并发远低于上限时不调整
+	for i := 0; i < 5; i++ {
+		round(t, l, clock, 10, 20*time.Millisecond, false)
+	}
+	if got := l.Limit(); got != 100 {
+		t.Fatalf("app-limited traffic changed limit to %d", got)
+	}
+
+	round(t, l, clock, 10, 20*time.Millisecond, true)
+	if got := l.Limit(); got != 90 {
+		t.Fatalf("limit after drop = %d, want 90", got)
+	}
+
+	// Ignore 只释放并发不参与采样
+	tk, _ := l.Acquire(PriorityNormal)
+	clock.t = clock.t.Add(time.Minute)
+	tk.Ignore()
+	tk.Done(true)
+	if got := l.Limit(); got != 90 || l.Inflight() != 0 {
+		t.Fatalf("ignored token affected limiter: limit=%d inflight=%d", got, l.Inflight())
+	}
+}
@@ -0,0 +1,178 @@
+package limit
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"emshop/pkg/log"
+	"emshop/pkg/storage"
+)
+
+// RateResult 一次限流判断的结果
+type RateResult struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset 距离窗口内额度完全恢复的时间
+	Reset time.Duration
+	// RetryAfter 被拒绝时距离下一次可以请求的时间
+	RetryAfter time.Duration
+}
+
+// RateStore 按 key 统计请求频率，每次调用记为一次请求
+type RateStore interface {
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (RateResult, error)
+}
+
+// 编译时检查接口实现
+var (
+	_ RateStore = &RedisRateStore{}
+	_ RateStore = &LocalRateStore{}
+	_ RateStore = &FallbackRateStore{}
+)
+
+// RedisRateStore 基于 Redis 有序集合的滑动窗口，多个网关实例共享计数
+//
+// 被拒绝的请求同样写入窗口，持续超限的调用方需要停止请求一个完整窗口后才能恢复。
+// 窗口按秒计算，不足一秒的窗口按一秒处理。
+type RedisRateStore struct {
+	store     *storage.RedisCluster
+	keyPrefix string
+}
+
+// NewRedisRateStore 创建 Redis 滑动窗口存储，使用 storage.ConnectToRedis 建立的连接
+func NewRedisRateStore(keyPrefix string) *RedisRateStore {
+	return &RedisRateStore{store: &storage.RedisCluster{}, keyPrefix: keyPrefix}
+}
+
+// Allow 记录一次请求并判断窗口内的请求数是否超限，Redis 不可用时返回 storage.ErrRedisIsDown
+func (s *RedisRateStore) Allow(ctx context.Context, key string, limit int, window time.Duration) (RateResult, error) {
+	if !storage.Connected() {
+		return RateResult{}, storage.ErrRedisIsDown
+	}
+	per := int64(math.Ceil(window.Seconds()))
+	if per < 1 {
+		per = 1
+	}
+	now := time.Now()
+	// 返回本次请求之前窗口内的请求，成员为按时间排序的纳秒时间戳；命令执行失败时返回 nil
+	count, values := s.store.SetRollingWindow(ctx, s.keyPrefix+key, per, "-1", false)
+	if values == nil {
+		return RateResult{}, storage.ErrRedisIsDown
+	}
+
+	res := RateResult{Allowed: count < limit, Limit: limit}
+	if res.Allowed {
+		res.Remaining = limit - count - 1
+	}
+	period := time.Duration(per) * time.Second
+	if count == 0 {
+		res.Reset = period
+		return res, nil
+	}
+	res.Reset = expireAt(values[0], period).Sub(now)
+	if !res.Allowed {
+		// 窗口内请求数降到 limit 以下时才能再次请求，即第 count-limit+1 早的请求过期
+		res.RetryAfter = expireAt(values[count-limit], period).Sub(now)
+	}
+	return res, nil
+}
+
+// expireAt 解析窗口成员的时间戳，返回它移出窗口的时间
+func expireAt(member interface{}, period time.Duration) time.Time {
+	s, _ := member.(string)
+	ns, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Now().Add(period)
+	}
+	return time.Unix(0, ns).Add(period)
+}
+
+// LocalRateStore 进程内的 GCRA 限流，每个 key 只保存一个时间点，只对当前实例生效
+type LocalRateStore struct {
+	now func() time.Time
+
+	mu    sync.Mutex
+	tats  map[string]time.Time // 理论到达时间
+	calls int
+}
+
+// NewLocalRateStore 创建进程内限流存储
+func NewLocalRateStore() *LocalRateStore {
+	return &LocalRateStore{now: time.Now, tats: make(map[string]time.Time)}
+}
+
+// localSweepEvery 每处理多少次请求清理一次已经恢复满额度的 key
+const localSweepEvery = 1024
+
+// Allow 按 GCRA 算法判断请求是否超限，limit 次请求可以在窗口内任意分布
+func (s *LocalRateStore) Allow(_ context.Context, key string, limit int, window time.Duration) (RateResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.calls++
+	if s.calls%localSweepEvery == 0 {
+		for k, tat := range s.tats {
+			if !tat.After(now) {
+				delete(s.tats, k)
+			}
+		}
+	}
+
+	interval := window / time.Duration(limit)
+	if interval <= 0 {
+		interval = 1
+	}
+	tat, ok := s.tats[key]
+	if !ok || tat.Before(now) {
+		tat = now
+	}
+	next := tat.Add(interval)
+	allowAt := next.Add(-window)
+	if now.Before(allowAt) {
+		return RateResult{
+			Limit:      limit,
+			Reset:      tat.Sub(now),
+			RetryAfter: allowAt.Sub(now),
+		}, nil
+	}
+	s.tats[key] = next
+	return RateResult{
+		Allowed:   true,
+		Limit:     limit,
+		Remaining: int(now.Sub(allowAt) / interval),
+		Reset:     next.Sub(now),
+	}, nil
+}
+
+// FallbackRateStore 优先使用主存储，主存储出错时降级到备用存储
+type FallbackRateStore struct {
+	primary  RateStore
+	fallback RateStore
+	degraded atomic.Bool
+}
+
+// NewFallbackRateStore 创建带降级的限流存储，通常为 Redis 存储加进程内存储
+func NewFallbackRateStore(primary, fallback RateStore) *FallbackRateStore {
+	return &FallbackRateStore{primary: primary, fallback: fallback}
+}
+
+// Allow 主存储不可用期间使用备用存储计数，恢复后自动切回
+func (s *FallbackRateStore) Allow(ctx context.Context, key string, limit int, window time.Duration) (RateResult, error) {
+	res, err := s.primary.Allow(ctx, key, limit, window)
+	if err == nil {
+		if s.degraded.CompareAndSwap(true, false) {
+			log.Info("[ratelimit] primary store recovered")
+		}
+		return res, nil
+	}
+	if s.degraded.CompareAndSwap(false, true) {
+		log.Warnf("[ratelimit] primary store unavailable, falling back to in-process limiter: %v", err)
+	}
+	return s.fallback.Allow(ctx, key, limit, window)
+}
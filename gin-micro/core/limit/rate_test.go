@@ -0,0 +1,100 @@
+package limit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"emshop/pkg/storage"
+)
+
+func TestLocalRateStore(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	s := NewLocalRateStore()
+	s.now = clock.now
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		res, _ := s.Allow(ctx, "ip:1", 3, time.Minute)
+		if !res.Allowed || res.Remaining != 2-i {
+			t.Fatalf("request %d: %+v", i, res)
+		}
+	}
+	res, _ := s.Allow(ctx, "ip:1", 3, time.Minute)
+	if res.Allowed || res.RetryAfter != 20*time.Second || res.Reset != time.Minute {
+		t.Fatalf("4th request should wait one emission interval: %+v", res)
+	}
+	// 其他 key 不受影响
+	if res, _ := s.Allow(ctx, "ip:2", 3, time.Minute); !res.Allowed {
+		t.Fatalf("independent key rejected: %+v", res)
+	}
+
+	clock.t = clock.t.Add(20 * time.Second)
+	if res, _ := s.Allow(ctx, "ip:1", 3, time.Minute); !res.Allowed || res.Remaining != 0 {
+		t.Fatalf("request after retry-after: %+v", res)
+	}
+	clock.t = clock.t.Add(2 * time.Minute)
+	if res, _ := s.Allow(ctx, "ip:1", 3, time.Minute); !res.Allowed || res.Remaining != 2 {
+		t.Fatalf("quota should fully recover after the window: %+v", res)
+	}
+}
+
+type failingStore struct{ err error }
+
+func (s *failingStore) Allow(context.Context, string, int, time.Duration) (RateResult, error) {
+	if s.err != nil {
+		return RateResult{}, s.err
+	}
+	return RateResult{Allowed: true, Limit: 1, Remaining: 42}, nil
+}
+
+func TestFallbackRateStore(t *testing.T) {
+	primary := &failingStore{err: storage.ErrRedisIsDown}
+	s := NewFallbackRateStore(primary, NewLocalRateStore())
+	ctx := context.Background()
+
+	if res, err := s.Allow(ctx, "k", 1, time.Minute); err != nil || !res.Allowed {
+		t.Fatalf("fallback should admit the first request: %+v %v", res, err)
+	}
+	if res, _ := s.Allow(ctx, "k", 1, time.Minute); res.Allowed {
+		t.Fatalf("fallback should enforce the limit: %+v", res)
+	}
+
+	primary.err = nil
+	if res, _ := s.Allow(ctx, "k", 1, time.Minute); !res.Allowed || res.Remaining != 42 {
+		t.Fatalf("recovered primary should be used again: %+v", res)
+	}
+}
+
+func TestRedisRateStore(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go storage.ConnectToRedis(ctx, &storage.Config{Host: "localhost", Port: 6379, Database: 15})
+	deadline := time.Now().Add(time.Second)
+	for !storage.Connected() {
+		if time.Now().After(deadline) {
+			t.Skip("redis not available")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	s := NewRedisRateStore("test:ratelimit:")
+	key := time.Now().Format("150405.000000000")
+	for i := 0; i < 2; i++ {
+		res, err := s.Allow(ctx, key, 2, 2*time.Second)
+		if err != nil || !res.Allowed || res.Remaining != 1-i {
+			t.Fatalf("request %d: %+v %v", i, res, err)
+		}
+	}
+	res, err := s.Allow(ctx, key, 2, 2*time.Second)
+	if err != nil || res.Allowed || res.RetryAfter <= 0 || res.RetryAfter > 2*time.Second {
+		t.Fatalf("3rd request should be rejected with retry-after: %+v %v", res, err)
+	}
+
+	storage.DisableRedis(true)
+	defer storage.DisableRedis(false)
+	if _, err := s.Allow(ctx, key, 2, 2*time.Second); !errors.Is(err, storage.ErrRedisIsDown) {
+		t.Fatalf("expected ErrRedisIsDown, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+// Package logadmin 运行时修改日志级别和签发调试令牌，REST 和 gRPC 管理接口共用
+package logadmin
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"time"
+
+	"emshop/pkg/log"
+)
+
+const (
+	// HeaderAdminToken REST 管理接口的密钥请求头
+	HeaderAdminToken = "X-Admin-Token"
+	// MetadataAdminToken gRPC 管理接口的密钥元数据
+	MetadataAdminToken = "x-admin-token"
+	// HeaderDebugToken 调试令牌请求头，令牌有效时只为该请求开启调试日志
+	HeaderDebugToken = "X-Debug-Token"
+
+	// DefaultTTL 未指定 ttl 时运行时级别的生效时间，避免忘记恢复
+	DefaultTTL = 10 * time.Minute
+	// MaxTokenTTL 调试令牌的最长有效期
+	MaxTokenTTL = time.Hour
+)
+
+// LevelRequest 修改日志级别的请求
+type LevelRequest struct {
+	// Logger logger 名称，为空表示根 logger
+	Logger string `json:"logger"`
+	Level  string `json:"level"`
+	// TTL 到期自动恢复，如 30m，为空时使用 DefaultTTL，为 0 时不自动恢复
+	TTL string `json:"ttl"`
+}
+
+// Token 调试令牌，请求携带 Header: Token 时只为该请求开启调试日志
+type Token struct {
+	Header   string    `json:"header"`
+	Token    string    `json:"token"`
+	ExpireAt time.Time `json:"expire_at"`
+}
+
+// Authorized 校验管理密钥，未配置密钥时拒绝所有请求
+func Authorized(secret, token string) bool {
+	return secret != "" && subtle.ConstantTimeCompare([]byte(secret), []byte(token)) == 1
+}
+
+// SetLevel 按请求修改日志级别，返回修改后的全部设置
+func SetLevel(req *LevelRequest) ([]log.LevelSetting, error) {
+	level, err := log.ParseLevel(req.Level)
+	if err != nil {
+		return nil, err
+	}
+	ttl, err := parseTTL(req.TTL, DefaultTTL)
+	if err != nil {
+		return nil, err
+	}
+	log.SetLevel(req.Logger, level, ttl)
+	log.Infof("[logadmin] set level of logger %q to %s, ttl %s", req.Logger, level, ttl)
+	return log.Levels(), nil
+}
+
+// ResetLevel 恢复 logger 的配置级别，返回恢复后的全部设置
+func ResetLevel(name string) []log.LevelSetting {
+	log.ResetLevel(name)
+	log.Infof("[logadmin] reset level of logger %q", name)
+	return log.Levels()
+}
+
+// IssueToken 签发调试令牌，ttl 为空时使用 DefaultTTL，不超过 MaxTokenTTL
+func IssueToken(secret, ttl string) (*Token, error) {
+	d, err := parseTTL(ttl, DefaultTTL)
+	if err != nil {
+		return nil, err
+	}
+	if d <= 0 || d > MaxTokenTTL {
+		return nil, fmt.Errorf("ttl must be in (0, %s]", MaxTokenTTL)
+	}
+	expireAt := time.Now().Add(d)
+	return &Token{Header: HeaderDebugToken, Token: log.SignDebugToken(secret, expireAt), ExpireAt: expireAt}, nil
+}
+
+func parseTTL(ttl string, def time.Duration) (time.Duration, error) {
+	if ttl == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(ttl)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ttl %q: %v", ttl, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("ttl must not be negative")
+	}
+	return d, nil
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"emshop/gin-micro/core/health"
 	"emshop/gin-micro/registry"
 	"emshop/pkg/log"
 
@@ -26,6 +27,7 @@ type Client struct {
     deregisterCriticalServiceAfter int // 严重错误服务自动注销时间间隔(秒)
     serviceChecks api.AgentServiceChecks 	// 用户自定义检查项: 内置了TCP检查和TTL检查, 可以额外添加http或者grpc
     checkTimeout int // 健康检查超时时间(秒)
+	readiness *health.Registry // 依赖检查, 心跳按就绪状态上报, 为空时一直上报passing
 }
 
 // 创建Consul客户端
@@ -37,6 +39,7 @@ func NewClient(cli *api.Client) *Client {
         heartbeat:                      true,
         deregisterCriticalServiceAfter: 600,
         checkTimeout:                   5,
+        readiness:                      health.Default(),
     }
     c.ctx, c.cancel = context.WithCancel(context.Background())
     return c
@@ -211,20 +214,14 @@ func (c *Client) Register(_ context.Context, svc *registry.ServiceInstance, enab
 			// 避免心跳比注册更糟到达consul
 			time.Sleep(time.Second)
 			// 立即心跳,使得服务注册后立马能健康检查通过
-			err = c.cli.Agent().UpdateTTL("service:"+svc.ID, "pass", "pass")
-			if err != nil {
-				log.Errorf("[Consul]update ttl heartbeat to consul failed!err:=%v", err)
-			}
+			c.updateTTL(svc.ID)
 			// 定时发送心跳
 			ticker := time.NewTicker(time.Second * time.Duration(c.healthcheckInterval))
 			defer ticker.Stop()
 			for {
 				select {
 				case <-ticker.C:
-					err = c.cli.Agent().UpdateTTL("service:"+svc.ID, "pass", "pass")
-					if err != nil {
-						log.Errorf("[Consul]update ttl heartbeat to consul failed!err:=%v", err)
-					}
+					c.updateTTL(svc.ID)
 				case <-c.ctx.Done():
 					return
 				}
@@ -234,6 +231,22 @@ func (c *Client) Register(_ context.Context, svc *registry.ServiceInstance, enab
 	return nil
 }
 
+// updateTTL 上报心跳，依赖未就绪时上报critical，Consul把实例从健康列表中摘除，依赖恢复后自动恢复
+func (c *Client) updateTTL(serviceID string) {
+	status, output := api.HealthPassing, "pass"
+	if c.readiness != nil {
+		ctx, cancel := context.WithTimeout(c.ctx, time.Duration(c.checkTimeout)*time.Second)
+		report := c.readiness.Readiness(ctx)
+		cancel()
+		if !report.Ready() {
+			status, output = api.HealthCritical, report.Error()
+		}
+	}
+	if err := c.cli.Agent().UpdateTTL("service:"+serviceID, output, status); err != nil {
+		log.Errorf("[Consul]update ttl heartbeat to consul failed!err:=%v", err)
+	}
+}
+
 // Deregister 根据服务ID从Consul注销服务
 func (c *Client) Deregister(_ context.Context, serviceID string) error {
 	c.cancel()
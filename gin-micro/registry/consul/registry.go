@@ -7,6 +7,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"emshop/gin-micro/core/health"
 	"emshop/gin-micro/registry"
 
 	"github.com/hashicorp/consul/api"
@@ -73,6 +74,15 @@ func WithCheckTimeout(seconds int) Option {
     }
 }
 
+// 设置心跳使用的依赖检查，默认使用 health.Default()，为空时心跳一直上报passing
+func WithReadiness(r *health.Registry) Option {
+	return func(o *Registry) {
+		if o.cli != nil {
+			o.cli.readiness = r
+		}
+	}
+}
+
 // 设置自定义服务检查
 func WithServiceCheck(checks ...*api.AgentServiceCheck) Option {
 	return func(o *Registry) {
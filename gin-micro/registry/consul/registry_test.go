@@ -11,6 +11,7 @@ import (
 
 	"github.com/hashicorp/consul/api"
 	"emshop/gin-micro/registry"
+	"emshop/gin-micro/registry/registrytest"
 )
 
 // tcpServer 模拟TCP服务器，用于测试健康检查
@@ -375,6 +376,20 @@ func TestRegistry_Watch(t *testing.T) {
 	}
 }
 
+// TestConformance 执行注册中心一致性测试，本地没有Consul agent时跳过
+func TestConformance(t *testing.T) {
+	cli, err := api.NewClient(&api.Config{Address: "127.0.0.1:8500", WaitTime: 2 * time.Second})
+	if err != nil {
+		t.Fatalf("create consul client failed: %v", err)
+	}
+	if _, err := cli.Agent().Self(); err != nil {
+		t.Skipf("consul agent unavailable: %v", err)
+	}
+	registrytest.Run(t, func(t *testing.T) registrytest.Registry {
+		return New(cli, WithHealthCheck(false))
+	})
+}
+
 // getIntranetIP 获取内网IP地址
 func getIntranetIP() string {
 	addrs, err := net.InterfaceAddrs()
@@ -0,0 +1,213 @@
+// Package etcd 实现了基于etcd的服务注册与发现
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"emshop/gin-micro/registry"
+	"emshop/pkg/log"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// 编译时检查接口实现
+var (
+	_ registry.Registrar = &Registry{}
+	_ registry.Discovery = &Registry{}
+)
+
+// etcd注册器选项
+type Option func(*Registry)
+
+// 设置服务键的命名空间前缀
+func WithNamespace(ns string) Option {
+	return func(r *Registry) {
+		r.namespace = ns
+	}
+}
+
+// 设置注册租约的过期时间，实例进程退出后超过该时间自动剔除
+func WithRegisterTTL(ttl time.Duration) Option {
+	return func(r *Registry) {
+		r.ttl = ttl
+	}
+}
+
+// 设置租约续期失败后重新注册的最大次数
+func WithMaxRetry(n int) Option {
+	return func(r *Registry) {
+		r.maxRetry = n
+	}
+}
+
+// Registry etcd注册器实现
+type Registry struct {
+	client    *clientv3.Client
+	namespace string
+	ttl       time.Duration
+	maxRetry  int
+
+	lock   sync.Mutex
+	leases map[string]context.CancelFunc // 服务键 -> 停止续期
+}
+
+// New 创建etcd注册器实例
+func New(client *clientv3.Client, opts ...Option) *Registry {
+	r := &Registry{
+		client:    client,
+		namespace: "/microservices",
+		ttl:       15 * time.Second,
+		maxRetry:  5,
+		leases:    make(map[string]context.CancelFunc),
+	}
+	for _, o := range opts {
+		o(r)
+	}
+	return r
+}
+
+// Register 注册服务实例，实例绑定租约并在后台持续续期
+func (r *Registry) Register(ctx context.Context, svc *registry.ServiceInstance) error {
+	if svc == nil || svc.Name == "" || svc.ID == "" {
+		return fmt.Errorf("service name and id are required")
+	}
+	key := r.serviceKey(svc.Name, svc.ID)
+	value, err := json.Marshal(svc)
+	if err != nil {
+		return err
+	}
+	leaseID, err := r.put(ctx, key, string(value))
+	if err != nil {
+		return err
+	}
+
+	r.lock.Lock()
+	// 相同ID重复注册时停止旧的续期协程，以新注册的实例为准
+	if cancel, ok := r.leases[key]; ok {
+		cancel()
+	}
+	kctx, cancel := context.WithCancel(context.Background())
+	r.leases[key] = cancel
+	r.lock.Unlock()
+
+	go r.keepAlive(kctx, leaseID, key, string(value))
+	return nil
+}
+
+// Deregister 注销服务实例并停止续期
+func (r *Registry) Deregister(ctx context.Context, svc *registry.ServiceInstance) error {
+	key := r.serviceKey(svc.Name, svc.ID)
+	r.lock.Lock()
+	if cancel, ok := r.leases[key]; ok {
+		cancel()
+		delete(r.leases, key)
+	}
+	r.lock.Unlock()
+	_, err := r.client.Delete(ctx, key)
+	return err
+}
+
+// GetService 根据服务名称获取服务实例列表
+func (r *Registry) GetService(ctx context.Context, name string) ([]*registry.ServiceInstance, error) {
+	services, err := r.list(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(services) == 0 {
+		return nil, fmt.Errorf("service %s not found in registry", name)
+	}
+	return services, nil
+}
+
+// Watch 监听指定服务名称的服务变化
+func (r *Registry) Watch(ctx context.Context, name string) (registry.Watcher, error) {
+	return newWatcher(ctx, r, name), nil
+}
+
+// put 创建租约并写入服务键
+func (r *Registry) put(ctx context.Context, key, value string) (clientv3.LeaseID, error) {
+	grant, err := r.client.Grant(ctx, int64(r.ttl.Seconds()))
+	if err != nil {
+		return 0, err
+	}
+	if _, err := r.client.Put(ctx, key, value, clientv3.WithLease(grant.ID)); err != nil {
+		return 0, err
+	}
+	return grant.ID, nil
+}
+
+// keepAlive 持续续期租约，租约丢失（如etcd重启或网络中断超过TTL）时按退避重新注册
+func (r *Registry) keepAlive(ctx context.Context, leaseID clientv3.LeaseID, key, value string) {
+	for {
+		ch, err := r.client.KeepAlive(ctx, leaseID)
+		if err == nil {
+			for range ch {
+			}
+		}
+		// 被注销或重新注册时退出，并撤销旧租约让实例立即下线
+		if ctx.Err() != nil {
+			rctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+			_, _ = r.client.Revoke(rctx, leaseID)
+			cancel()
+			return
+		}
+
+		retry := 0
+		for {
+			if retry >= r.maxRetry {
+				log.Errorf("[registry] re-register %s failed after %d retries", key, retry)
+				return
+			}
+			// 指数退避加随机抖动，避免大量实例同时重新注册
+			backoff := time.Duration(1<<retry)*time.Second + time.Duration(rand.Intn(1000))*time.Millisecond
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			pctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+			leaseID, err = r.put(pctx, key, value)
+			cancel()
+			if err == nil {
+				break
+			}
+			retry++
+		}
+	}
+}
+
+// list 按实例ID排序返回服务的所有实例，没有实例时返回空列表
+func (r *Registry) list(ctx context.Context, name string) ([]*registry.ServiceInstance, error) {
+	resp, err := r.client.Get(ctx, r.servicePrefix(name), clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	services := make([]*registry.ServiceInstance, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		svc := &registry.ServiceInstance{}
+		if err := json.Unmarshal(kv.Value, svc); err != nil {
+			log.Warnf("[registry] invalid service instance %s: %v", kv.Key, err)
+			continue
+		}
+		if svc.Name != name {
+			continue
+		}
+		services = append(services, svc)
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].ID < services[j].ID })
+	return services, nil
+}
+
+func (r *Registry) servicePrefix(name string) string {
+	return fmt.Sprintf("%s/%s/", r.namespace, name)
+}
+
+func (r *Registry) serviceKey(name, id string) string {
+	return r.servicePrefix(name) + id
+}
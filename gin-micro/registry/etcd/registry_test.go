@@ -0,0 +1,37 @@
+package etcd
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"emshop/gin-micro/registry/registrytest"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// TestConformance 需要可访问的etcd，通过 ETCD_ENDPOINT 指定地址，默认 127.0.0.1:2379，连接失败时跳过
+func TestConformance(t *testing.T) {
+	endpoint := os.Getenv("ETCD_ENDPOINT")
+	if endpoint == "" {
+		endpoint = "127.0.0.1:2379"
+	}
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   []string{endpoint},
+		DialTimeout: time.Second,
+	})
+	if err != nil {
+		t.Skipf("etcd unavailable: %v", err)
+	}
+	defer client.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := client.Status(ctx, endpoint); err != nil {
+		t.Skipf("etcd unavailable: %v", err)
+	}
+
+	registrytest.Run(t, func(t *testing.T) registrytest.Registry {
+		return New(client, WithNamespace("/registrytest"), WithRegisterTTL(5*time.Second))
+	})
+}
@@ -0,0 +1,67 @@
+package etcd
+
+import (
+	"context"
+
+	"emshop/gin-micro/registry"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// watcher etcd服务监听器，基于前缀监听服务键的变化
+type watcher struct {
+	r       *Registry
+	name    string
+	first   bool
+	watchCh clientv3.WatchChan
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newWatcher(ctx context.Context, r *Registry, name string) *watcher {
+	w := &watcher{
+		r:     r,
+		name:  name,
+		first: true,
+	}
+	w.ctx, w.cancel = context.WithCancel(ctx)
+	// RequireLeader 保证连接的节点与集群失联时监听会被关闭，而不是一直阻塞
+	w.watchCh = r.client.Watch(clientv3.WithRequireLeader(w.ctx), r.servicePrefix(name), clientv3.WithPrefix())
+	return w
+}
+
+// Next 等待并返回下一个服务实例列表变化，首次调用时如果已有实例则直接返回
+func (w *watcher) Next() ([]*registry.ServiceInstance, error) {
+	if w.first {
+		w.first = false
+		if services, err := w.r.list(w.ctx, w.name); err == nil && len(services) > 0 {
+			return services, nil
+		}
+	}
+	for {
+		select {
+		case <-w.ctx.Done():
+			return nil, w.ctx.Err()
+		case resp, ok := <-w.watchCh:
+			if !ok {
+				if w.ctx.Err() != nil {
+					return nil, w.ctx.Err()
+				}
+				// 监听通道被关闭（如leader丢失）时重新建立监听
+				w.watchCh = w.r.client.Watch(clientv3.WithRequireLeader(w.ctx), w.r.servicePrefix(w.name), clientv3.WithPrefix())
+				continue
+			}
+			if err := resp.Err(); err != nil {
+				return nil, err
+			}
+			return w.r.list(w.ctx, w.name)
+		}
+	}
+}
+
+// Stop 停止监听
+func (w *watcher) Stop() error {
+	w.cancel()
+	return nil
+}
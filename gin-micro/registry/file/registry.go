@@ -0,0 +1,245 @@
+// Package file 实现了基于 YAML 文件的服务注册与发现
+//
+// 文件格式如下，实例未填写 name 时使用所在的服务名，未填写 id 时按服务名和序号生成：
+//
+//	services:
+//	  emshop-goods-srv:
+//	    - id: goods-1
+//	      version: v1
+//	      endpoints:
+//	        - grpc://127.0.0.1:50052
+//
+// 文件可以手工维护作为静态注册中心，也可以由本地启动的多个服务通过 Register/Deregister 共同写入，
+// 文件变化后所有监听器都会收到最新的实例列表。多进程同时写入时不加锁，只适合本地开发和测试。
+package file
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"emshop/gin-micro/registry"
+	"emshop/gin-micro/registry/memory"
+	"emshop/pkg/log"
+
+	"github.com/fsnotify/fsnotify"
+	"gopkg.in/yaml.v3"
+)
+
+// 编译时检查接口实现
+var (
+	_ registry.Registrar = &Registry{}
+	_ registry.Discovery = &Registry{}
+)
+
+// document 注册文件的内容
+type document struct {
+	Services map[string][]*registry.ServiceInstance `yaml:"services"`
+}
+
+// Registry 文件注册器实现，实例数据同步到内存注册器后对外提供查询和监听
+type Registry struct {
+	path  string
+	cache *memory.Registry
+
+	lock    sync.Mutex // 保护文件的读改写
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+}
+
+// New 创建文件注册器实例，文件不存在时按空注册中心处理，首次注册时创建
+func New(path string) (*Registry, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	// 监听所在目录而不是文件本身，文件被编辑器或原子替换重建后仍能收到事件
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := fw.Add(filepath.Dir(path)); err != nil {
+		_ = fw.Close()
+		return nil, err
+	}
+	r := &Registry{
+		path:    path,
+		cache:   memory.New(),
+		watcher: fw,
+		done:    make(chan struct{}),
+	}
+	if err := r.reload(); err != nil {
+		_ = fw.Close()
+		return nil, err
+	}
+	go r.run()
+	return r, nil
+}
+
+// Register 将服务实例写入注册文件，相同ID的实例会被替换
+func (r *Registry) Register(ctx context.Context, svc *registry.ServiceInstance) error {
+	if svc == nil || svc.Name == "" || svc.ID == "" {
+		return fmt.Errorf("service name and id are required")
+	}
+	return r.update(func(doc *document) {
+		services := doc.Services[svc.Name]
+		for i, s := range services {
+			if s.ID == svc.ID {
+				services[i] = svc
+				return
+			}
+		}
+		doc.Services[svc.Name] = append(services, svc)
+	})
+}
+
+// Deregister 从注册文件中删除服务实例
+func (r *Registry) Deregister(ctx context.Context, svc *registry.ServiceInstance) error {
+	return r.update(func(doc *document) {
+		services := doc.Services[svc.Name]
+		for i, s := range services {
+			if s.ID == svc.ID {
+				services = append(services[:i], services[i+1:]...)
+				break
+			}
+		}
+		if len(services) == 0 {
+			delete(doc.Services, svc.Name)
+			return
+		}
+		doc.Services[svc.Name] = services
+	})
+}
+
+// GetService 根据服务名称获取服务实例列表
+func (r *Registry) GetService(ctx context.Context, name string) ([]*registry.ServiceInstance, error) {
+	return r.cache.GetService(ctx, name)
+}
+
+// Watch 监听指定服务名称的服务变化
+func (r *Registry) Watch(ctx context.Context, name string) (registry.Watcher, error) {
+	return r.cache.Watch(ctx, name)
+}
+
+// Close 停止监听注册文件
+func (r *Registry) Close() error {
+	select {
+	case <-r.done:
+		return nil
+	default:
+		close(r.done)
+	}
+	return r.watcher.Close()
+}
+
+// run 监听注册文件变化并重新加载
+func (r *Registry) run() {
+	for {
+		select {
+		case <-r.done:
+			return
+		case ev, ok := <-r.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(ev.Name) != r.path {
+				continue
+			}
+			if err := r.reload(); err != nil {
+				log.Errorf("[registry] reload %s failed: %v", r.path, err)
+			}
+		case err, ok := <-r.watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Errorf("[registry] watch %s failed: %v", r.path, err)
+		}
+	}
+}
+
+// update 读取注册文件，修改后原子替换并立即同步到内存
+func (r *Registry) update(fn func(doc *document)) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	doc, err := r.read()
+	if err != nil {
+		return err
+	}
+	fn(doc)
+	data, err := yaml.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(r.path), "."+filepath.Base(r.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), r.path); err != nil {
+		return err
+	}
+	r.sync(doc)
+	return nil
+}
+
+// reload 重新读取注册文件并同步到内存
+func (r *Registry) reload() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	doc, err := r.read()
+	if err != nil {
+		return err
+	}
+	r.sync(doc)
+	return nil
+}
+
+// read 读取并解析注册文件，补全实例的名称和ID
+func (r *Registry) read() (*document, error) {
+	doc := &document{}
+	data, err := os.ReadFile(r.path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return nil, fmt.Errorf("parse registry file %s: %w", r.path, err)
+	}
+	if doc.Services == nil {
+		doc.Services = make(map[string][]*registry.ServiceInstance)
+	}
+	for name, services := range doc.Services {
+		for i, svc := range services {
+			if svc.Name == "" {
+				svc.Name = name
+			}
+			if svc.ID == "" {
+				svc.ID = fmt.Sprintf("%s-%d", name, i)
+			}
+		}
+	}
+	return doc, nil
+}
+
+// sync 用文件内容整体替换内存中的实例，调用方需持有锁
+func (r *Registry) sync(doc *document) {
+	for _, name := range r.cache.Names() {
+		if _, ok := doc.Services[name]; !ok {
+			r.cache.Set(name, nil)
+		}
+	}
+	for name, services := range doc.Services {
+		r.cache.Set(name, services)
+	}
+}
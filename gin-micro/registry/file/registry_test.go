@@ -0,0 +1,93 @@
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"emshop/gin-micro/registry"
+	"emshop/gin-micro/registry/registrytest"
+)
+
+func newRegistry(t *testing.T, path string) *Registry {
+	r, err := New(path)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() { _ = r.Close() })
+	return r
+}
+
+func TestConformance(t *testing.T) {
+	registrytest.Run(t, func(t *testing.T) registrytest.Registry {
+		return newRegistry(t, filepath.Join(t.TempDir(), "registry.yaml"))
+	})
+}
+
+// TestStaticFile 手工维护的文件无需填写实例名称和ID，修改文件后监听器收到新的实例
+func TestStaticFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry.yaml")
+	write := func(content string) {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("services:\n  goods:\n    - endpoints: [\"grpc://127.0.0.1:50052\"]\n")
+
+	r := newRegistry(t, path)
+	got, err := r.GetService(context.Background(), "goods")
+	if err != nil {
+		t.Fatalf("GetService() error = %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "goods-0" || got[0].Name != "goods" {
+		t.Fatalf("GetService() got = %+v", got[0])
+	}
+
+	w, err := r.Watch(context.Background(), "goods")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+	if _, err := w.Next(); err != nil {
+		t.Fatal(err)
+	}
+
+	write("services:\n  goods:\n    - id: g1\n      endpoints: [\"grpc://127.0.0.1:50053\"]\n")
+	done := make(chan []*registry.ServiceInstance, 1)
+	go func() {
+		services, _ := w.Next()
+		done <- services
+	}()
+	select {
+	case services := <-done:
+		if len(services) != 1 || services[0].ID != "g1" {
+			t.Fatalf("Next() got = %v", services)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("file change not observed")
+	}
+}
+
+// TestSharedFile 多个注册器共用同一个文件时可以互相发现
+func TestSharedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry.yaml")
+	a := newRegistry(t, path)
+	b := newRegistry(t, path)
+
+	ins := &registry.ServiceInstance{ID: "1", Name: "order", Endpoints: []string{"grpc://127.0.0.1:50051"}}
+	if err := a.Register(context.Background(), ins); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if got, err := b.GetService(context.Background(), "order"); err == nil && len(got) == 1 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("instance registered by another registry not discovered")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
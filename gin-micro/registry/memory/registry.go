@@ -0,0 +1,200 @@
+// Package memory 实现了基于进程内存的服务注册与发现，主要用于测试和单进程运行
+package memory
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"emshop/gin-micro/registry"
+)
+
+// 编译时检查接口实现
+var (
+	_ registry.Registrar = &Registry{}
+	_ registry.Discovery = &Registry{}
+)
+
+// Registry 内存注册器实现
+type Registry struct {
+	lock     sync.RWMutex
+	services map[string]map[string]*registry.ServiceInstance // 服务名 -> 实例ID -> 实例
+	watchers map[string]map[*watcher]struct{}                // 服务名 -> 监听器集合
+}
+
+// New 创建内存注册器实例
+func New() *Registry {
+	return &Registry{
+		services: make(map[string]map[string]*registry.ServiceInstance),
+		watchers: make(map[string]map[*watcher]struct{}),
+	}
+}
+
+// Register 注册服务实例，相同ID的实例会被替换
+func (r *Registry) Register(ctx context.Context, svc *registry.ServiceInstance) error {
+	if svc == nil || svc.Name == "" || svc.ID == "" {
+		return fmt.Errorf("service name and id are required")
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	set, ok := r.services[svc.Name]
+	if !ok {
+		set = make(map[string]*registry.ServiceInstance)
+		r.services[svc.Name] = set
+	}
+	set[svc.ID] = svc
+	r.notify(svc.Name)
+	return nil
+}
+
+// Deregister 注销服务实例
+func (r *Registry) Deregister(ctx context.Context, svc *registry.ServiceInstance) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	set, ok := r.services[svc.Name]
+	if !ok {
+		return nil
+	}
+	if _, ok := set[svc.ID]; !ok {
+		return nil
+	}
+	delete(set, svc.ID)
+	if len(set) == 0 {
+		delete(r.services, svc.Name)
+	}
+	r.notify(svc.Name)
+	return nil
+}
+
+// Set 整体替换服务的实例列表，实例列表没有变化时不会通知监听器，
+// 供静态配置等外部数据源同步使用
+func (r *Registry) Set(name string, services []*registry.ServiceInstance) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if reflect.DeepEqual(r.list(name), sortInstances(services)) {
+		return
+	}
+	if len(services) == 0 {
+		delete(r.services, name)
+	} else {
+		set := make(map[string]*registry.ServiceInstance, len(services))
+		for _, svc := range services {
+			set[svc.ID] = svc
+		}
+		r.services[name] = set
+	}
+	r.notify(name)
+}
+
+// Names 返回当前所有有实例的服务名称
+func (r *Registry) Names() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	names := make([]string, 0, len(r.services))
+	for name := range r.services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetService 根据服务名称获取服务实例列表
+func (r *Registry) GetService(ctx context.Context, name string) ([]*registry.ServiceInstance, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	services := r.list(name)
+	if len(services) == 0 {
+		return nil, fmt.Errorf("service %s not found in registry", name)
+	}
+	return services, nil
+}
+
+// Watch 监听指定服务名称的服务变化
+func (r *Registry) Watch(ctx context.Context, name string) (registry.Watcher, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	w := &watcher{
+		name:  name,
+		r:     r,
+		event: make(chan struct{}, 1),
+	}
+	w.ctx, w.cancel = context.WithCancel(ctx)
+	if _, ok := r.watchers[name]; !ok {
+		r.watchers[name] = make(map[*watcher]struct{})
+	}
+	r.watchers[name][w] = struct{}{}
+	// 已有实例时立即推送给监听器，否则初始数据会一直阻塞
+	if len(r.services[name]) > 0 {
+		w.event <- struct{}{}
+	}
+	return w, nil
+}
+
+// list 按实例ID排序返回服务实例列表，调用方需持有锁
+func (r *Registry) list(name string) []*registry.ServiceInstance {
+	set := r.services[name]
+	if len(set) == 0 {
+		return nil
+	}
+	services := make([]*registry.ServiceInstance, 0, len(set))
+	for _, svc := range set {
+		services = append(services, svc)
+	}
+	return sortInstances(services)
+}
+
+// notify 通知服务的所有监听器，调用方需持有锁
+func (r *Registry) notify(name string) {
+	for w := range r.watchers[name] {
+		select {
+		case w.event <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func sortInstances(services []*registry.ServiceInstance) []*registry.ServiceInstance {
+	if len(services) == 0 {
+		return nil
+	}
+	sorted := make([]*registry.ServiceInstance, len(services))
+	copy(sorted, services)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	return sorted
+}
+
+// watcher 内存注册器的服务监听器
+type watcher struct {
+	name  string
+	r     *Registry
+	event chan struct{}
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// Next 等待并返回下一个服务实例列表变化
+func (w *watcher) Next() ([]*registry.ServiceInstance, error) {
+	select {
+	case <-w.ctx.Done():
+		return nil, w.ctx.Err()
+	case <-w.event:
+	}
+	w.r.lock.RLock()
+	defer w.r.lock.RUnlock()
+	return w.r.list(w.name), nil
+}
+
+// Stop 停止监听并从注册器中移除自身
+func (w *watcher) Stop() error {
+	w.cancel()
+	w.r.lock.Lock()
+	defer w.r.lock.Unlock()
+	delete(w.r.watchers[w.name], w)
+	if len(w.r.watchers[w.name]) == 0 {
+		delete(w.r.watchers, w.name)
+	}
+	return nil
+}
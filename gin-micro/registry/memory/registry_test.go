@@ -0,0 +1,13 @@
+package memory
+
+import (
+	"testing"
+
+	"emshop/gin-micro/registry/registrytest"
+)
+
+func TestConformance(t *testing.T) {
+	registrytest.Run(t, func(t *testing.T) registrytest.Registry {
+		return New()
+	})
+}
@@ -31,13 +31,13 @@ type Watcher interface {
 // 服务器实例数据结构
 type ServiceInstance struct {
 	// 注册到服务中心的服务id
-	ID string `json:"id"`
+	ID string `json:"id" yaml:"id"`
 	// 服务名称
-	Name string `json:"name"`
+	Name string `json:"name" yaml:"name"`
 	// 服务版本
-	Version string `json:"version"`
+	Version string `json:"version" yaml:"version,omitempty"`
 	// 服务源数据
-	Metadata map[string]string `json:"metadata"`
+	Metadata map[string]string `json:"metadata" yaml:"metadata,omitempty"`
 	// 服务地址类似: http://127.0.1:8080 grpc://127.0.1:8080
-	Endpoints []string `json:"endpoints"`
+	Endpoints []string `json:"endpoints" yaml:"endpoints"`
 }
\ No newline at end of file
@@ -0,0 +1,206 @@
+// Package registrytest 提供注册中心实现共用的一致性测试，
+// 新的 Registrar/Discovery 实现应当在自己的测试中调用 Run
+package registrytest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"emshop/gin-micro/registry"
+)
+
+// Registry 同时实现服务注册和服务发现的注册中心
+type Registry interface {
+	registry.Registrar
+	registry.Discovery
+}
+
+// Factory 为每个测试用例创建注册中心实例
+type Factory func(t *testing.T) Registry
+
+// 等待监听器推送的超时时间，轮询实现（如consul）需要一定的传播延迟
+const waitTimeout = 10 * time.Second
+
+// Run 执行一致性测试
+func Run(t *testing.T, newRegistry Factory) {
+	t.Run("Register", func(t *testing.T) { testRegister(t, newRegistry(t)) })
+	t.Run("ReplaceByID", func(t *testing.T) { testReplace(t, newRegistry(t)) })
+	t.Run("GetServiceNotFound", func(t *testing.T) { testNotFound(t, newRegistry(t)) })
+	t.Run("WatchChanges", func(t *testing.T) { testWatchChanges(t, newRegistry(t)) })
+	t.Run("WatchStop", func(t *testing.T) { testWatchStop(t, newRegistry(t)) })
+}
+
+// serviceName 生成测试用例独占的服务名，避免共享的注册中心之间互相干扰
+func serviceName(t *testing.T) string {
+	name := strings.NewReplacer("/", "-", "_", "-").Replace(strings.ToLower(t.Name()))
+	return fmt.Sprintf("%s-%d", name, time.Now().UnixNano())
+}
+
+func instance(name, id, version string) *registry.ServiceInstance {
+	return &registry.ServiceInstance{
+		ID:        id,
+		Name:      name,
+		Version:   version,
+		Metadata:  map[string]string{"zone": "zone-a"},
+		Endpoints: []string{"grpc://127.0.0.1:9000?isSecure=false"},
+	}
+}
+
+func register(t *testing.T, r Registry, services ...*registry.ServiceInstance) {
+	t.Helper()
+	for _, svc := range services {
+		if err := r.Register(context.Background(), svc); err != nil {
+			t.Fatalf("Register(%s) error = %v", svc.ID, err)
+		}
+		svc := svc
+		t.Cleanup(func() { _ = r.Deregister(context.Background(), svc) })
+	}
+}
+
+func testRegister(t *testing.T, r Registry) {
+	name := serviceName(t)
+	ins := instance(name, "1", "v0.0.1")
+	register(t, r, ins)
+
+	w, err := r.Watch(context.Background(), name)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer w.Stop()
+	waitFor(t, w, []*registry.ServiceInstance{ins})
+
+	got, err := r.GetService(context.Background(), name)
+	if err != nil {
+		t.Fatalf("GetService() error = %v", err)
+	}
+	assertInstances(t, got, []*registry.ServiceInstance{ins})
+}
+
+func testReplace(t *testing.T, r Registry) {
+	name := serviceName(t)
+	register(t, r, instance(name, "1", "v0.0.1"))
+	latest := instance(name, "1", "v0.0.2")
+	register(t, r, latest)
+
+	w, err := r.Watch(context.Background(), name)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer w.Stop()
+	waitFor(t, w, []*registry.ServiceInstance{latest})
+}
+
+func testNotFound(t *testing.T, r Registry) {
+	got, err := r.GetService(context.Background(), serviceName(t))
+	if err == nil {
+		t.Fatalf("GetService() got = %v, want error", got)
+	}
+}
+
+func testWatchChanges(t *testing.T, r Registry) {
+	name := serviceName(t)
+	first := instance(name, "1", "v0.0.1")
+	register(t, r, first)
+
+	w, err := r.Watch(context.Background(), name)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer w.Stop()
+	waitFor(t, w, []*registry.ServiceInstance{first})
+
+	second := instance(name, "2", "v0.0.1")
+	register(t, r, second)
+	waitFor(t, w, []*registry.ServiceInstance{first, second})
+
+	if err := r.Deregister(context.Background(), first); err != nil {
+		t.Fatalf("Deregister() error = %v", err)
+	}
+	waitFor(t, w, []*registry.ServiceInstance{second})
+}
+
+func testWatchStop(t *testing.T, r Registry) {
+	w, err := r.Watch(context.Background(), serviceName(t))
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	if err := w.Stop(); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := w.Next()
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("Next() after Stop() want error")
+		}
+	case <-time.After(waitTimeout):
+		t.Fatal("Next() after Stop() blocked")
+	}
+}
+
+// waitFor 持续读取监听器直到收到期望的实例列表，多次变化可能被合并为一次推送
+func waitFor(t *testing.T, w registry.Watcher, want []*registry.ServiceInstance) {
+	t.Helper()
+	type result struct {
+		services []*registry.ServiceInstance
+		err      error
+	}
+	deadline := time.After(waitTimeout)
+	var last []*registry.ServiceInstance
+	for {
+		ch := make(chan result, 1)
+		go func() {
+			services, err := w.Next()
+			ch <- result{services, err}
+		}()
+		select {
+		case res := <-ch:
+			if res.err != nil {
+				t.Fatalf("Next() error = %v", res.err)
+			}
+			last = res.services
+			if equalInstances(last, want) {
+				return
+			}
+		case <-deadline:
+			t.Fatalf("Next() got = %v, want %v", format(last), format(want))
+		}
+	}
+}
+
+func assertInstances(t *testing.T, got, want []*registry.ServiceInstance) {
+	t.Helper()
+	if !equalInstances(got, want) {
+		t.Errorf("got = %v, want %v", format(got), format(want))
+	}
+}
+
+func equalInstances(got, want []*registry.ServiceInstance) bool {
+	return reflect.DeepEqual(sorted(got), sorted(want))
+}
+
+func sorted(services []*registry.ServiceInstance) []registry.ServiceInstance {
+	out := make([]registry.ServiceInstance, 0, len(services))
+	for _, svc := range services {
+		out = append(out, *svc)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+func format(services []*registry.ServiceInstance) string {
+	parts := make([]string, 0, len(services))
+	for _, svc := range services {
+		parts = append(parts, fmt.Sprintf("%+v", *svc))
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
@@ -0,0 +1,37 @@
+package restserver
+
+import (
+	"context"
+	"net/http"
+
+	"emshop/gin-micro/core/health"
+	"emshop/gin-micro/core/logadmin"
+
+	"github.com/gin-gonic/gin"
+)
+
+// registerHealth 注册存活检查 /healthz 和就绪检查 /readyz，未就绪时返回 503 和每个依赖的检查结果
+//
+// /readyz 在 healthInterval 内复用上次的检查报告；错误详情只在请求携带日志管理密钥(X-Admin-Token)时返回
+func (s *Server) registerHealth() {
+	reg := s.healthRegistry
+	if reg == nil {
+		reg = health.NewRegistry()
+	}
+	s.GET("/healthz", s.healthHandler(reg.Liveness))
+	s.GET("/readyz", s.healthHandler(reg.CachedReadiness(s.healthInterval)))
+}
+
+func (s *Server) healthHandler(run func(ctx context.Context) *health.Report) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report := run(c.Request.Context())
+		code := http.StatusOK
+		if !report.Ready() {
+			code = http.StatusServiceUnavailable
+		}
+		if !logadmin.Authorized(s.logAdminSecret, c.GetHeader(logadmin.HeaderAdminToken)) {
+			report = report.Redacted()
+		}
+		c.JSON(code, report)
+	}
+}
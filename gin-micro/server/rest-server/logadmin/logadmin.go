@@ -0,0 +1,73 @@
+package logadmin
+
+import (
+	"net/http"
+
+	"emshop/gin-micro/core/logadmin"
+	"emshop/pkg/log"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultPrefix url prefix of log admin
+	DefaultPrefix = "/debug/log"
+)
+
+// Register 注册日志管理接口，请求需要在 X-Admin-Token 请求头中携带 secret
+//
+//	GET    /debug/log/levels               查看日志级别
+//	PUT    /debug/log/levels               修改日志级别 {"logger":"","level":"debug","ttl":"10m"}
+//	DELETE /debug/log/levels?logger=name   恢复配置的日志级别
+//	POST   /debug/log/token                签发调试令牌 {"ttl":"10m"}
+func Register(r *gin.Engine, secret string) {
+	rg := r.Group(DefaultPrefix, authorize(secret))
+	{
+		rg.GET("/levels", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"levels": log.Levels()})
+		})
+		rg.PUT("/levels", func(c *gin.Context) {
+			var req logadmin.LevelRequest
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": http.StatusBadRequest, "message": err.Error()})
+				return
+			}
+			levels, err := logadmin.SetLevel(&req)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": http.StatusBadRequest, "message": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"levels": levels})
+		})
+		rg.DELETE("/levels", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"levels": logadmin.ResetLevel(c.Query("logger"))})
+		})
+		rg.POST("/token", func(c *gin.Context) {
+			var req struct {
+				TTL string `json:"ttl"`
+			}
+			if c.Request.ContentLength != 0 {
+				if err := c.ShouldBindJSON(&req); err != nil {
+					c.JSON(http.StatusBadRequest, gin.H{"code": http.StatusBadRequest, "message": err.Error()})
+					return
+				}
+			}
+			token, err := logadmin.IssueToken(secret, req.TTL)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"code": http.StatusBadRequest, "message": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, token)
+		})
+	}
+}
+
+func authorize(secret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !logadmin.Authorized(secret, c.GetHeader(logadmin.HeaderAdminToken)) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"code": http.StatusUnauthorized, "message": "invalid admin token"})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,49 @@
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+
+	"emshop/gin-micro/core/limit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HTTPPriorityFunc 根据请求判断优先级
+type HTTPPriorityFunc func(c *gin.Context) limit.Priority
+
+// DefaultHTTPPriority 健康检查和监控接口永不拒绝，支付等回调接口最后拒绝
+func DefaultHTTPPriority(c *gin.Context) limit.Priority {
+	path := c.Request.URL.Path
+	switch path {
+	case "/health", "/healthz", "/ready", "/readyz", "/metrics":
+		return limit.PriorityCritical
+	}
+	if strings.Contains(path, "callback") || strings.Contains(path, "notify") {
+		return limit.PriorityHigh
+	}
+	return limit.PriorityNormal
+}
+
+// AdaptiveLimit 自适应并发限流中间件，超出当前并发上限的请求返回 503
+func AdaptiveLimit(l *limit.Limiter, priority HTTPPriorityFunc) gin.HandlerFunc {
+	if priority == nil {
+		priority = DefaultHTTPPriority
+	}
+	return func(c *gin.Context) {
+		p := priority(c)
+		token, ok := l.Acquire(p)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+				"code":    http.StatusServiceUnavailable,
+				"message": "Service Overloaded",
+			})
+			return
+		}
+
+		c.Next()
+		// 超时和下游过载说明服务已经过载，需要立即收缩并发上限
+		status := c.Writer.Status()
+		token.Done(status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout || status == http.StatusTooManyRequests)
+	}
+}
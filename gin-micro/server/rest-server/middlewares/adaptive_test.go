@@ -0,0 +1,48 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"emshop/gin-micro/core/limit"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAdaptiveLimitSheddingOrder(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	l := limit.NewLimiter(limit.Options{InitialLimit: 2, MinLimit: 2, MaxLimit: 2})
+	// 占满普通请求的并发
+	for i := 0; i < 2; i++ {
+		if _, ok := l.Acquire(limit.PriorityNormal); !ok {
+			t.Fatalf("acquire %d failed", i)
+		}
+	}
+
+	r := gin.New()
+	r.Use(AdaptiveLimit(l, nil))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/v1/goods", ok)
+	r.POST("/v1/payments/callback", ok)
+	r.GET("/health", ok)
+
+	cases := []struct {
+		method, path string
+		want         int
+	}{
+		{http.MethodGet, "/v1/goods", http.StatusServiceUnavailable},
+		{http.MethodPost, "/v1/payments/callback", http.StatusOK},
+		{http.MethodGet, "/health", http.StatusOK},
+	}
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, nil))
+		if w.Code != tc.want {
+			t.Errorf("%s %s = %d, want %d", tc.method, tc.path, w.Code, tc.want)
+		}
+	}
+	if got := l.Inflight(); got != 2 {
+		t.Fatalf("inflight = %d, want 2", got)
+	}
+}
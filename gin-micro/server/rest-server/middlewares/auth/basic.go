@@ -7,6 +7,7 @@ import (
 
 	"emshop/gin-micro/code"
 	"emshop/gin-micro/server/rest-server/middlewares"
+	"emshop/pkg/log"
 
 	"github.com/gin-gonic/gin"
 	"emshop/pkg/errors"
@@ -57,6 +58,7 @@ func (b BasicStrategy) AuthFunc() gin.HandlerFunc {
 		}
 
 		c.Set(middlewares.UsernameKey, pair[0])
+		middlewares.SetLogValues(c, log.KeyUsername, pair[0])
 
 		c.Next()
 	}
@@ -6,6 +6,7 @@ import (
 
 	"emshop/gin-micro/code"
 	"emshop/gin-micro/server/rest-server/middlewares"
+	"emshop/pkg/log"
 	"emshop/pkg/common/core"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -98,6 +99,7 @@ func (cache CacheStrategy) AuthFunc() gin.HandlerFunc {
 		}
 
 		c.Set(middlewares.UsernameKey, secret.Username)
+		middlewares.SetLogValues(c, log.KeyUsername, secret.Username)
 		c.Next()
 	}
 }
@@ -1,6 +1,13 @@
 package middlewares
 
-import "github.com/gin-gonic/gin"
+import (
+	"fmt"
+
+	"emshop/pkg/log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
 
 const (
 	UsernameKey = "username"
@@ -9,10 +16,35 @@ const (
 	UserIP      = "ip"
 )
 
-// 为每个请求添加上下文, django
+// HeaderRequestID 请求ID头，调用方未携带时由网关生成，并在响应中返回
+const HeaderRequestID = "X-Request-ID"
+
+// Context 为每个请求生成请求ID，并把请求ID和已认证的用户写入日志上下文
+//
+// 处理函数使用 log.InfoC、log.ErrorfC 等方法记录日志时自动带上这些字段和链路ID，
+// 通过 gRPC 客户端调用其他服务时字段随元数据传递。
 func Context() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		//TODO 扩展
+		requestID := c.GetHeader(HeaderRequestID)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+		c.Set(log.KeyRequestID, requestID)
+		c.Header(HeaderRequestID, requestID)
+
+		keysAndValues := []string{log.KeyRequestID, requestID}
+		if v, ok := c.Get(KeyUserID); ok {
+			keysAndValues = append(keysAndValues, log.KeyUserID, fmt.Sprint(v))
+		}
+		if v, ok := c.Get(UsernameKey); ok {
+			keysAndValues = append(keysAndValues, log.KeyUsername, fmt.Sprint(v))
+		}
+		SetLogValues(c, keysAndValues...)
 		c.Next()
 	}
 }
+
+// SetLogValues 在请求的日志上下文中附加字段，认证中间件识别出用户后调用
+func SetLogValues(c *gin.Context, keysAndValues ...string) {
+	c.Request = c.Request.WithContext(log.WithValues(c.Request.Context(), keysAndValues...))
+}
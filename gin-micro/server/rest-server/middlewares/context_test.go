@@ -0,0 +1,69 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"emshop/gin-micro/core/logadmin"
+	"emshop/pkg/log"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestContext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Context())
+	var requestID, userID string
+	r.GET("/", func(c *gin.Context) {
+		// 认证中间件识别出用户后写入日志上下文
+		SetLogValues(c, log.KeyUserID, "7")
+		requestID = log.Value(c.Request.Context(), log.KeyRequestID)
+		userID = log.Value(c.Request.Context(), log.KeyUserID)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if requestID == "" || w.Header().Get(HeaderRequestID) != requestID || userID != "7" {
+		t.Fatalf("generated request id = %q, header = %q, user = %q", requestID, w.Header().Get(HeaderRequestID), userID)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(HeaderRequestID, "from-caller")
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if requestID != "from-caller" || w.Header().Get(HeaderRequestID) != "from-caller" {
+		t.Fatalf("caller request id not kept: %q", requestID)
+	}
+}
+
+func TestDebugLog(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(DebugLog("secret"))
+	var forced bool
+	r.GET("/", func(c *gin.Context) {
+		forced = log.DebugForced(c.Request.Context())
+	})
+
+	for _, tc := range []struct {
+		token string
+		want  bool
+	}{
+		{"", false},
+		{log.SignDebugToken("secret", time.Now().Add(time.Minute)), true},
+		{log.SignDebugToken("other", time.Now().Add(time.Minute)), false},
+		{log.SignDebugToken("secret", time.Now().Add(-time.Minute)), false},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tc.token != "" {
+			req.Header.Set(logadmin.HeaderDebugToken, tc.token)
+		}
+		r.ServeHTTP(httptest.NewRecorder(), req)
+		if forced != tc.want {
+			t.Fatalf("token %q: forced = %v, want %v", tc.token, forced, tc.want)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package middlewares
+
+import (
+	"time"
+
+	"emshop/gin-micro/core/logadmin"
+	"emshop/pkg/log"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DebugLog 校验调试令牌，令牌有效时在日志上下文中开启调试日志
+//
+// 调试标记随 gRPC 元数据传递，下游服务处理该请求时同样输出调试日志。令牌无效时忽略，不影响请求。
+func DebugLog(secret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token := c.GetHeader(logadmin.HeaderDebugToken); token != "" && log.VerifyDebugToken(secret, token, time.Now()) {
+			SetLogValues(c, log.KeyDebug, "true")
+		}
+		c.Next()
+	}
+}
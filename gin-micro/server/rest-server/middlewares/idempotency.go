@@ -0,0 +1,178 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"emshop/gin-micro/core/idempotency"
+	"emshop/pkg/log"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IdempotencyRoute 需要支持幂等键的路由
+type IdempotencyRoute struct {
+	Method string
+	// Path 路由模板，与 gin 注册的路径一致，如 /v1/orders
+	Path string
+}
+
+// IdempotencyConfig 幂等键中间件配置
+type IdempotencyConfig struct {
+	Store  idempotency.Store
+	Routes []IdempotencyRoute
+	// TTL 已完成响应的保存时间，LockTTL 处理中记录的过期时间
+	TTL     time.Duration
+	LockTTL time.Duration
+	// Scope 返回调用方标识，不同调用方使用相同的幂等键互不影响；为空时使用登录用户ID，未登录时使用客户端IP
+	Scope RateLimitKeyFunc
+}
+
+// replayHeaders 重放响应时恢复的响应头
+var replayHeaders = []string{"Content-Type", "Location"}
+
+// Idempotency 按 Idempotency-Key 请求头对写接口去重
+//
+// 首次请求的响应保存 TTL，期间携带相同键和相同请求内容的重复请求直接返回保存的响应；
+// 请求内容不同时返回 409，首次请求仍在处理时也返回 409，客户端稍后重试即可拿到结果。
+// 5xx 响应不保存，客户端可以使用同一个键重试。存储不可用时不做去重。
+func Idempotency(cfg *IdempotencyConfig) gin.HandlerFunc {
+	scope := cfg.Scope
+	if scope == nil {
+		scope = func(c *gin.Context) string {
+			if id := contextUserID(c); id != "" {
+				return id
+			}
+			return c.ClientIP()
+		}
+	}
+	routes := make(map[string]bool, len(cfg.Routes))
+	for _, r := range cfg.Routes {
+		routes[strings.ToUpper(r.Method)+" "+r.Path] = true
+	}
+
+	return func(c *gin.Context) {
+		key := c.GetHeader(idempotency.HeaderKey)
+		if key == "" || !routes[c.Request.Method+" "+c.FullPath()] {
+			c.Next()
+			return
+		}
+		if len(key) > idempotency.MaxKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"code":    http.StatusBadRequest,
+				"message": fmt.Sprintf("%s must not exceed %d characters", idempotency.HeaderKey, idempotency.MaxKeyLength),
+			})
+			return
+		}
+
+		var body []byte
+		if c.Request.Body != nil {
+			var err error
+			body, err = io.ReadAll(c.Request.Body)
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"code": http.StatusBadRequest, "message": "read request body failed"})
+				return
+			}
+		}
+		fingerprint := idempotency.Fingerprint([]byte(c.Request.Method), []byte(c.Request.URL.RequestURI()), body)
+		storeKey := fmt.Sprintf("%s:%s:%s:%s", c.Request.Method, c.FullPath(), scope(c), key)
+
+		// 客户端断开不影响保存结果
+		ctx := context.WithoutCancel(c.Request.Context())
+		rec, err := cfg.Store.Begin(ctx, storeKey, fingerprint, cfg.LockTTL)
+		if err != nil {
+			log.Errorf("[idempotency] begin %s failed: %v", storeKey, err)
+			c.Next()
+			return
+		}
+		if rec != nil {
+			replayIdempotent(c, rec, fingerprint)
+			return
+		}
+
+		w := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = w
+		completed := false
+		defer func() {
+			if completed {
+				return
+			}
+			if err := cfg.Store.Release(ctx, storeKey); err != nil {
+				log.Errorf("[idempotency] release %s failed: %v", storeKey, err)
+			}
+		}()
+
+		c.Next()
+
+		status := w.Status()
+		if status >= http.StatusInternalServerError {
+			return
+		}
+		header := make(map[string]string, len(replayHeaders))
+		for _, h := range replayHeaders {
+			if v := w.Header().Get(h); v != "" {
+				header[h] = v
+			}
+		}
+		done := &idempotency.Record{
+			Fingerprint: fingerprint,
+			Done:        true,
+			Status:      status,
+			Header:      header,
+			Body:        w.body.Bytes(),
+		}
+		if err := cfg.Store.Complete(ctx, storeKey, done, cfg.TTL); err != nil {
+			log.Errorf("[idempotency] save response of %s failed: %v", storeKey, err)
+			return
+		}
+		completed = true
+	}
+}
+
+// replayIdempotent 处理幂等键已存在的请求
+func replayIdempotent(c *gin.Context, rec *idempotency.Record, fingerprint string) {
+	if rec.Fingerprint != fingerprint {
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{
+			"code":    http.StatusConflict,
+			"message": idempotency.HeaderKey + " has already been used with a different request",
+		})
+		return
+	}
+	if !rec.Done {
+		c.Header("Retry-After", "1")
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{
+			"code":    http.StatusConflict,
+			"message": "a request with the same " + idempotency.HeaderKey + " is still in progress",
+		})
+		return
+	}
+	for k, v := range rec.Header {
+		c.Header(k, v)
+	}
+	c.Header(idempotency.ReplayedHeader, "true")
+	c.Status(rec.Status)
+	_, _ = c.Writer.Write(rec.Body)
+	c.Abort()
+}
+
+// responseRecorder 写出响应的同时保留响应体
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,101 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"emshop/gin-micro/core/idempotency"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestIdempotency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var created atomic.Int32
+	entered, release := make(chan struct{}), make(chan struct{})
+	r := gin.New()
+	r.Use(Idempotency(&IdempotencyConfig{
+		Store:   idempotency.NewMemoryStore(),
+		Routes:  []IdempotencyRoute{{Method: http.MethodPost, Path: "/v1/orders"}},
+		TTL:     time.Hour,
+		LockTTL: time.Minute,
+		Scope:   func(c *gin.Context) string { return c.GetHeader("X-User") },
+	}))
+	r.POST("/v1/orders", func(c *gin.Context) {
+		if c.Query("wait") != "" {
+			close(entered)
+			<-release
+		}
+		if c.Query("fail") != "" {
+			c.Status(http.StatusServiceUnavailable)
+			return
+		}
+		n := created.Add(1)
+		c.JSON(http.StatusCreated, gin.H{"id": n})
+	})
+
+	post := func(key, user, query, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/orders"+query, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-User", user)
+		if key != "" {
+			req.Header.Set(idempotency.HeaderKey, key)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	first := post("k1", "u1", "", `{"goods":1}`)
+	if first.Code != http.StatusCreated || first.Body.String() != `{"id":1}` {
+		t.Fatalf("first request = %d %s", first.Code, first.Body)
+	}
+	replay := post("k1", "u1", "", `{"goods":1}`)
+	if replay.Code != http.StatusCreated || replay.Body.String() != `{"id":1}` ||
+		replay.Header().Get(idempotency.ReplayedHeader) != "true" ||
+		!strings.HasPrefix(replay.Header().Get("Content-Type"), "application/json") {
+		t.Fatalf("replay = %d %s %v", replay.Code, replay.Body, replay.Header())
+	}
+	if created.Load() != 1 {
+		t.Fatalf("handler executed %d times", created.Load())
+	}
+
+	if w := post("k1", "u1", "", `{"goods":2}`); w.Code != http.StatusConflict {
+		t.Fatalf("mismatched payload = %d, want 409", w.Code)
+	}
+	// 不同调用方的相同幂等键互不影响，未携带幂等键的请求不去重
+	if w := post("k1", "u2", "", `{"goods":1}`); w.Code != http.StatusCreated || w.Body.String() != `{"id":2}` {
+		t.Fatalf("other user = %d %s", w.Code, w.Body)
+	}
+	if w := post("", "u1", "", `{"goods":1}`); w.Code != http.StatusCreated {
+		t.Fatalf("request without key = %d", w.Code)
+	}
+
+	// 首次请求处理中时拒绝重复请求
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- post("k2", "u1", "?wait=1", `{}`) }()
+	<-entered
+	if w := post("k2", "u1", "?wait=1", `{}`); w.Code != http.StatusConflict || w.Header().Get("Retry-After") != "1" {
+		t.Fatalf("concurrent duplicate = %d, want 409", w.Code)
+	}
+	close(release)
+	if w := <-done; w.Code != http.StatusCreated {
+		t.Fatalf("in-flight request = %d", w.Code)
+	}
+
+	// 5xx 不保存，同一个键可以重试
+	if w := post("k3", "u1", "?fail=1", `{}`); w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("failed request = %d", w.Code)
+	}
+	if w := post("k3", "u1", "?fail=1", `{}`); w.Header().Get(idempotency.ReplayedHeader) != "" {
+		t.Fatalf("5xx response should not be replayed")
+	}
+
+	if w := post(strings.Repeat("x", idempotency.MaxKeyLength+1), "u1", "", `{}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("long key = %d, want 400", w.Code)
+	}
+}
@@ -0,0 +1,158 @@
+package middlewares
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"emshop/gin-micro/core/limit"
+	"emshop/pkg/log"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 限流维度
+const (
+	RateLimitKeyIP     = "ip"     // 客户端IP
+	RateLimitKeyUser   = "user"   // 登录用户ID，未登录的请求不受该策略限制
+	RateLimitKeyMobile = "mobile" // 请求体、表单或查询参数中的 mobile 字段
+	RateLimitKeyAPIKey = "apikey" // X-API-Key 请求头
+)
+
+// RateLimitPolicy 路由级限流策略，同一路由可以配置多条策略，全部通过才放行
+type RateLimitPolicy struct {
+	// Method 为空时匹配所有方法
+	Method string
+	// Path 路由模板，与 gin 注册的路径一致，如 /v1/user/pwd_login、/v1/goods/:id
+	Path string
+	// Key 限流维度，ip、user、mobile、apikey 或 RateLimitConfig.KeyFuncs 中注册的名称
+	Key string
+	// 每个维度取值在 Window 内最多请求 Limit 次
+	Limit  int
+	Window time.Duration
+}
+
+// RateLimitKeyFunc 从请求中取出限流维度的值，返回空字符串时跳过该策略
+type RateLimitKeyFunc func(c *gin.Context) string
+
+// RateLimitConfig 调用方限流中间件配置
+type RateLimitConfig struct {
+	Store    limit.RateStore
+	Policies []RateLimitPolicy
+	// KeyFuncs 注册自定义维度或覆盖默认维度的取值方式
+	KeyFuncs map[string]RateLimitKeyFunc
+}
+
+// RateLimit 按路由策略限制单个调用方的请求频率
+//
+// 响应带有 RateLimit-Limit、RateLimit-Remaining、RateLimit-Reset 头，多条策略时取剩余次数最少的一条；
+// 超限时返回 429 和 Retry-After。计数存储出错时放行请求。
+func RateLimit(cfg *RateLimitConfig) gin.HandlerFunc {
+	keyFuncs := map[string]RateLimitKeyFunc{
+		RateLimitKeyIP:     func(c *gin.Context) string { return c.ClientIP() },
+		RateLimitKeyUser:   contextUserID,
+		RateLimitKeyMobile: requestMobile,
+		RateLimitKeyAPIKey: func(c *gin.Context) string { return c.GetHeader("X-API-Key") },
+	}
+	for name, fn := range cfg.KeyFuncs {
+		keyFuncs[name] = fn
+	}
+	policies := make(map[string][]RateLimitPolicy)
+	for _, p := range cfg.Policies {
+		if _, ok := keyFuncs[p.Key]; !ok {
+			log.Warnf("[ratelimit] unknown key %q in policy for %s, skipped", p.Key, p.Path)
+			continue
+		}
+		policies[p.Path] = append(policies[p.Path], p)
+	}
+
+	return func(c *gin.Context) {
+		matched := policies[c.FullPath()]
+		if len(matched) == 0 {
+			c.Next()
+			return
+		}
+
+		var tightest *limit.RateResult
+		for _, p := range matched {
+			if p.Method != "" && !strings.EqualFold(p.Method, c.Request.Method) {
+				continue
+			}
+			value := keyFuncs[p.Key](c)
+			if value == "" {
+				continue
+			}
+			key := fmt.Sprintf("%s:%s:%s:%s", strings.ToUpper(p.Method), p.Path, p.Key, value)
+			res, err := cfg.Store.Allow(c.Request.Context(), key, p.Limit, p.Window)
+			if err != nil {
+				log.Errorf("[ratelimit] check %s failed: %v", key, err)
+				continue
+			}
+			if !res.Allowed {
+				writeRateLimitHeaders(c, res)
+				c.Header("Retry-After", strconv.Itoa(ceilSeconds(res.RetryAfter)))
+				c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+					"code":    http.StatusTooManyRequests,
+					"message": "Too Many Requests",
+				})
+				return
+			}
+			if tightest == nil || res.Remaining < tightest.Remaining {
+				r := res
+				tightest = &r
+			}
+		}
+		if tightest != nil {
+			writeRateLimitHeaders(c, *tightest)
+		}
+		c.Next()
+	}
+}
+
+func writeRateLimitHeaders(c *gin.Context, res limit.RateResult) {
+	c.Header("RateLimit-Limit", strconv.Itoa(res.Limit))
+	c.Header("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.Reset)))
+}
+
+func ceilSeconds(d time.Duration) int {
+	if d <= 0 {
+		return 0
+	}
+	return int(math.Ceil(d.Seconds()))
+}
+
+// contextUserID 读取认证中间件写入上下文的用户ID
+func contextUserID(c *gin.Context) string {
+	if v, ok := c.Get(KeyUserID); ok {
+		return fmt.Sprint(v)
+	}
+	return ""
+}
+
+// requestMobile 依次从 JSON 请求体、表单和查询参数中读取 mobile，读取后恢复请求体供后续绑定使用
+func requestMobile(c *gin.Context) string {
+	if c.Request.Body != nil && strings.HasPrefix(c.ContentType(), gin.MIMEJSON) {
+		body, err := io.ReadAll(c.Request.Body)
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		if err == nil {
+			var fields struct {
+				Mobile string `json:"mobile"`
+			}
+			if json.Unmarshal(body, &fields) == nil && fields.Mobile != "" {
+				return fields.Mobile
+			}
+		}
+		return c.Query("mobile")
+	}
+	if mobile := c.PostForm("mobile"); mobile != "" {
+		return mobile
+	}
+	return c.Query("mobile")
+}
@@ -0,0 +1,100 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"emshop/gin-micro/core/limit"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRateLimitPolicies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RateLimit(&RateLimitConfig{
+		Store: limit.NewLocalRateStore(),
+		Policies: []RateLimitPolicy{
+			{Method: http.MethodPost, Path: "/v1/base/send_sms", Key: RateLimitKeyMobile, Limit: 1, Window: time.Minute},
+			{Method: http.MethodPost, Path: "/v1/base/send_sms", Key: RateLimitKeyIP, Limit: 3, Window: time.Minute},
+			{Path: "/v1/coupons/receive", Key: RateLimitKeyUser, Limit: 1, Window: time.Minute},
+		},
+		KeyFuncs: map[string]RateLimitKeyFunc{
+			RateLimitKeyUser: func(c *gin.Context) string { return c.GetHeader("X-User") },
+		},
+	}))
+	r.POST("/v1/base/send_sms", func(c *gin.Context) {
+		var req struct {
+			Mobile string `json:"mobile"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil || req.Mobile == "" {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+	r.POST("/v1/coupons/receive", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/v1/goods", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	sendSms := func(mobile string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/base/send_sms", strings.NewReader(`{"mobile":"`+mobile+`","type":1}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// 请求体被读取后仍能被处理函数绑定
+	w := sendSms("13800000001")
+	if w.Code != http.StatusOK {
+		t.Fatalf("first sms = %d", w.Code)
+	}
+	if got := w.Header().Get("RateLimit-Remaining"); got != "0" {
+		t.Fatalf("RateLimit-Remaining = %q, want the tightest policy", got)
+	}
+	if got := w.Header().Get("RateLimit-Limit"); got != "1" {
+		t.Fatalf("RateLimit-Limit = %q", got)
+	}
+
+	w = sendSms("13800000001")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("same mobile = %d, want 429", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "60" {
+		t.Fatalf("Retry-After = %q, want 60", got)
+	}
+
+	// 换手机号仍受同一IP的限制，被手机号策略拒绝的请求不占用IP额度
+	for _, mobile := range []string{"13800000002", "13800000003"} {
+		if w := sendSms(mobile); w.Code != http.StatusOK {
+			t.Fatalf("sms to %s = %d", mobile, w.Code)
+		}
+	}
+	if w := sendSms("13800000004"); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("ip limit not applied: %d", w.Code)
+	}
+
+	receive := func(user string) int {
+		req := httptest.NewRequest(http.MethodPost, "/v1/coupons/receive", nil)
+		req.Header.Set("X-User", user)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+	if receive("1") != http.StatusOK || receive("1") != http.StatusTooManyRequests || receive("2") != http.StatusOK {
+		t.Fatalf("per-user policy not applied")
+	}
+	// 未识别用户时不限流，交给认证中间件处理
+	if receive("") != http.StatusOK || receive("") != http.StatusOK {
+		t.Fatalf("anonymous request should skip the user policy")
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/goods", nil))
+	if w.Code != http.StatusOK || w.Header().Get("RateLimit-Limit") != "" {
+		t.Fatalf("route without policy should not be limited: %d %v", w.Code, w.Header())
+	}
+}
@@ -1,7 +1,7 @@
 package middlewares
 
 import (
-    "fmt"
+    "errors"
     "strings"
     "time"
 
@@ -63,7 +63,7 @@ func Sentinel(cfg *HTTPConfig) gin.HandlerFunc {
         c.Next()
         // record handler error if any
         if len(c.Errors) > 0 {
-            api.TraceError(entry, fmt.Errorf(c.Errors.String()))
+            api.TraceError(entry, errors.New(c.Errors.String()))
         }
         // exit and finalize
         entry.Exit()
@@ -1,5 +1,13 @@
 package restserver
 
+import (
+	"time"
+
+	"emshop/gin-micro/core/health"
+	"emshop/gin-micro/core/limit"
+	mws "emshop/gin-micro/server/rest-server/middlewares"
+)
+
 type ServerOption func(*Server)
 
 func WithEnableProfiling(profiling bool) ServerOption {
@@ -32,12 +40,43 @@ func WithMiddlewares(middlewares []string) ServerOption {
 	}
 }
 
+// WithAdaptiveLimit 开启自适应并发限流，priority 为空时健康检查永不拒绝、回调接口最后拒绝
+func WithAdaptiveLimit(l *limit.Limiter, priority mws.HTTPPriorityFunc) ServerOption {
+	return func(s *Server) {
+		s.limiter = l
+		s.priority = priority
+	}
+}
+
+// WithLogAdmin 开启日志管理接口和调试令牌，secret 为空时不开启
+func WithLogAdmin(secret string) ServerOption {
+	return func(s *Server) {
+		s.logAdminSecret = secret
+	}
+}
+
 func WithHealthz(healthz bool) ServerOption {
 	return func(s *Server) {
 		s.healthz = healthz
 	}
 }
 
+// WithHealthRegistry 设置 /readyz 使用的依赖检查注册表，默认使用 health.Default()
+func WithHealthRegistry(r *health.Registry) ServerOption {
+	return func(s *Server) {
+		s.healthRegistry = r
+	}
+}
+
+// WithHealthInterval 设置 /readyz 检查报告的缓存时间，默认 5 秒
+func WithHealthInterval(interval time.Duration) ServerOption {
+	return func(s *Server) {
+		if interval > 0 {
+			s.healthInterval = interval
+		}
+	}
+}
+
 func WithJwt(jwt *JwtInfo) ServerOption {
 	return func(s *Server) {
 		s.jwt = jwt
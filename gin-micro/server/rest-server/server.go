@@ -10,6 +10,9 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"emshop/gin-micro/core/health"
+	"emshop/gin-micro/core/limit"
+	"emshop/gin-micro/server/rest-server/logadmin"
 	mws "emshop/gin-micro/server/rest-server/middlewares"
 	"emshop/gin-micro/server/rest-server/pprof"
 	"emshop/gin-micro/server/rest-server/validation"
@@ -89,8 +92,12 @@ type Server struct {
 	//开发日志模式， 默认值 debug
 	mode string
 
-	//是否开启健康检查接口， 默认开启， 如果开启会自动添加 /health 接口
+	//是否开启健康检查接口， 默认开启， 如果开启会自动添加 /healthz 和 /readyz 接口
 	healthz bool
+	//依赖检查, 默认使用 health.Default()
+	healthRegistry *health.Registry
+	//就绪检查报告的缓存时间, 默认 5 秒
+	healthInterval time.Duration
 
 	//是否开启pprof接口， 默认开启， 如果开启会自动添加 /debug/pprof 接口
 	enableProfiling bool
@@ -98,9 +105,16 @@ type Server struct {
 	//是否开启metrics接口， 默认开启， 如果开启会自动添加 /metrics 接口
 	enableMetrics bool
 
+	//日志管理密钥，不为空时添加 /debug/log 接口，并支持通过调试令牌为单个请求开启调试日志
+	logAdminSecret string
+
 	//中间件
 	middlewares []string
 
+	//自适应并发限流器, 为空时不限流
+	limiter  *limit.Limiter
+	priority mws.HTTPPriorityFunc
+
 	//jwt配置信息
 	jwt *JwtInfo
 
@@ -128,6 +142,8 @@ func NewServer(opts ...ServerOption) *Server {
 		port:            8080,
 		mode:            "debug",
 		healthz:         true,
+		healthRegistry:  health.Default(),
+		healthInterval:  5 * time.Second,
 		enableProfiling: true,
 		jwt: &JwtInfo{
 			"JWT",
@@ -144,9 +160,22 @@ func NewServer(opts ...ServerOption) *Server {
 		o(srv)
 	}
 
+	// 处理函数直接把 *gin.Context 作为 context 传给下游，取值时需要回退到请求的 context，
+	// 链路信息和日志字段才能随 gRPC 调用传递
+	srv.Engine.ContextWithFallback = true
+
 	// gin集成链路追踪
 	srv.Use(mws.TracingHandler(srv.serviceName))
 
+	// 自适应限流放在其他中间件之前，过载时尽早拒绝
+	if srv.limiter != nil {
+		srv.Use(mws.AdaptiveLimit(srv.limiter, srv.priority))
+	}
+
+	if srv.logAdminSecret != "" {
+		srv.Use(mws.DebugLog(srv.logAdminSecret))
+	}
+
 
 	for _, m := range srv.middlewares {
 		mw, ok := mws.Middlewares[m]
@@ -228,11 +257,19 @@ func (s *Server) Start(ctx context.Context) error {
     //注册mobile验证码
     validation.RegisterMobile(s.localizer)
 
+    if s.healthz {
+        s.registerHealth()
+    }
+
     //根据配置初始化pprof路由
     if s.enableProfiling {
         pprof.Register(s.Engine)
     }
 
+    if s.logAdminSecret != "" {
+        logadmin.Register(s.Engine, s.logAdminSecret)
+    }
+
 	log.Infof("rest server is running on port: %d", s.port)
 	address := fmt.Sprintf(":%d", s.port)
 	// 使用http的server 优雅退出, 自己维护
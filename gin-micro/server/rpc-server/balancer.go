@@ -1,17 +1,23 @@
 package rpcserver
 
 import (
+	"context"
+	"errors"
 	"sync"
 
 	"emshop/gin-micro/registry"
 	"emshop/gin-micro/server/rpc-server/selector"
+	"emshop/gin-micro/server/rpc-server/selector/filter"
 	"emshop/gin-micro/server/rpc-server/selector/p2c"
 	"emshop/gin-micro/server/rpc-server/selector/random"
 	"emshop/gin-micro/server/rpc-server/selector/wrr"
+	"emshop/pkg/log"
 
 	"google.golang.org/grpc/balancer"
 	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -25,6 +31,13 @@ const (
 	randomName = "random"
 )
 
+const (
+	// CanaryHeader 请求级灰度版本，出站元数据中携带时只路由到该版本的节点，覆盖客户端配置的过滤器
+	CanaryHeader = "x-emshop-canary"
+	// RouteKeyHeader 请求级路由键，灰度分流据此保持粘性；未设置时使用日志上下文中的用户ID
+	RouteKeyHeader = "x-emshop-route-key"
+)
+
 var (
 	// 编译时接口检查
 	_ base.PickerBuilder = &balancerBuilder{}
@@ -117,9 +130,13 @@ type balancerPicker struct {
 
 // Pick 选择服务实例
 func (p *balancerPicker) Pick(info balancer.PickInfo) (balancer.PickResult, error) {
+	ctx, filters := pickFilters(info.Ctx)
 	// 使用 selector 选择节点
-	n, done, err := p.selector.Select(info.Ctx)
+	n, done, err := p.selector.Select(ctx, selector.WithNodeFilter(filters...))
 	if err != nil {
+		if errors.Is(err, selector.ErrCircuitOpen) {
+			return balancer.PickResult{}, status.Error(codes.Unavailable, err.Error())
+		}
 		return balancer.PickResult{}, err
 	}
 
@@ -138,6 +155,40 @@ func (p *balancerPicker) Pick(info balancer.PickInfo) (balancer.PickResult, erro
 	}, nil
 }
 
+// pickFilters 返回本次请求使用的节点过滤器：默认为客户端配置的过滤器，
+// 出站元数据携带灰度版本时改为只选择该版本；同时确定本次请求的路由键放入上下文，
+// 依次取上下文、出站元数据中的路由键，都没有时使用日志上下文中的用户ID(网关由JWT中间件写入)
+func pickFilters(ctx context.Context) (context.Context, []selector.NodeFilter) {
+	filters := selector.FiltersFromContext(ctx)
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if v := md.Get(CanaryHeader); len(v) > 0 && v[0] != "" {
+		filters = []selector.NodeFilter{filter.Version(v[0])}
+	}
+	if _, ok := selector.RouteKeyFromContext(ctx); !ok {
+		var key string
+		if v := md.Get(RouteKeyHeader); len(v) > 0 {
+			key = v[0]
+		}
+		if key == "" {
+			key = log.Value(ctx, log.KeyUserID)
+		}
+		if key != "" {
+			ctx = selector.NewRouteKeyContext(ctx, key)
+		}
+	}
+	return ctx, filters
+}
+
+// WithCanary 在出站元数据中指定本次请求的灰度版本
+func WithCanary(ctx context.Context, version string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, CanaryHeader, version)
+}
+
+// WithRouteKey 在出站元数据中设置本次请求的路由键
+func WithRouteKey(ctx context.Context, key string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, RouteKeyHeader, key)
+}
+
 // Trailer 是 gRPC 响应尾部元数据
 type Trailer metadata.MD
 
@@ -0,0 +1,32 @@
+package rpcserver
+
+import (
+	"context"
+	"testing"
+
+	"emshop/gin-micro/server/rpc-server/selector"
+	"emshop/pkg/log"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickFiltersRouteKey(t *testing.T) {
+	routeKey := func(ctx context.Context) string {
+		ctx, _ = pickFilters(ctx)
+		key, _ := selector.RouteKeyFromContext(ctx)
+		return key
+	}
+
+	// 网关请求没有显式路由键时使用JWT中间件写入日志上下文的用户ID
+	ctx := log.WithValues(context.Background(), log.KeyUserID, "42")
+	assert.Equal(t, "42", routeKey(ctx))
+
+	// 显式设置的路由键优先
+	assert.Equal(t, "tenant-a", routeKey(WithRouteKey(ctx, "tenant-a")))
+	assert.Equal(t, "k", routeKey(selector.NewRouteKeyContext(ctx, "k")))
+
+	assert.Equal(t, "", routeKey(context.Background()))
+
+	_, filters := pickFilters(WithCanary(ctx, "v2"))
+	assert.Len(t, filters, 1)
+}
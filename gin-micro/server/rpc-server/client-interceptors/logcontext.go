@@ -0,0 +1,35 @@
+package clientinterceptors
+
+import (
+	"context"
+
+	"emshop/pkg/log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryLogContextInterceptor 把上下文中的日志字段放入元数据，下游服务的日志带有相同的请求ID、用户和单号
+func UnaryLogContextInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(outgoingLogContext(ctx), method, req, reply, cc, opts...)
+}
+
+// StreamLogContextInterceptor 流式调用同样传递日志字段
+func StreamLogContextInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+	streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(outgoingLogContext(ctx), desc, cc, method, opts...)
+}
+
+func outgoingLogContext(ctx context.Context) context.Context {
+	kv := make([]string, 0, len(log.PropagatedKeys)*2)
+	for _, key := range log.PropagatedKeys {
+		if v := log.Value(ctx, key); v != "" {
+			kv = append(kv, log.MetadataKey(key), v)
+		}
+	}
+	if len(kv) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, kv...)
+}
@@ -0,0 +1,270 @@
+package clientinterceptors
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"emshop/gin-micro/server/rpc-server/selector"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// RetryPolicy 重试策略
+type RetryPolicy struct {
+	// MaxAttempts 最大尝试次数，包含首次调用
+	MaxAttempts int
+	// RetryableCodes 可重试的错误码，为空时使用 Unavailable
+	RetryableCodes []codes.Code
+	// InitialBackoff 首次重试前的等待时间
+	InitialBackoff time.Duration
+	// MaxBackoff 重试等待时间上限
+	MaxBackoff time.Duration
+	// Multiplier 每次重试等待时间的增长倍数
+	Multiplier float64
+}
+
+// HedgePolicy 对冲策略：首个请求在 Delay 内未返回时向其他节点再发一个请求，取最先成功的结果
+type HedgePolicy struct {
+	// MaxAttempts 最多同时发出的请求数，包含首次调用
+	MaxAttempts int
+	// Delay 发出下一个对冲请求前等待的时间
+	Delay time.Duration
+	// NonFatalCodes 返回这些错误码时立即发出下一个请求，其余错误直接返回
+	NonFatalCodes []codes.Code
+}
+
+// MethodPolicy 方法的调用策略
+type MethodPolicy struct {
+	// Idempotent 幂等方法才会重试，非幂等的写操作即使配置了 Retry 也不会重试
+	Idempotent bool
+	// ReadOnly 只读方法，可以对冲，只读方法视为幂等
+	ReadOnly bool
+	Retry    *RetryPolicy
+	Hedge    *HedgePolicy
+}
+
+// CallPolicies 客户端调用策略
+type CallPolicies struct {
+	// Methods 按方法配置策略，key 为完整方法名（/Goods/BatchGetGoods）或服务前缀（/Goods/）
+	Methods map[string]*MethodPolicy
+	// Budget 重试预算，重试和对冲请求共用，为空时不限制
+	Budget *RetryBudget
+}
+
+// lookup 先按完整方法名、再按服务前缀查找策略
+func (p *CallPolicies) lookup(method string) *MethodPolicy {
+	if mp, ok := p.Methods[method]; ok {
+		return mp
+	}
+	if i := strings.LastIndex(method, "/"); i > 0 {
+		if mp, ok := p.Methods[method[:i+1]]; ok {
+			return mp
+		}
+	}
+	return nil
+}
+
+// UnaryRetryInterceptor 按方法策略重试或对冲的客户端拦截器
+// 同一次调用的各次尝试会避开已经选过的节点
+func UnaryRetryInterceptor(policies *CallPolicies) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if policies == nil {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		mp := policies.lookup(method)
+		if mp == nil {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		policies.Budget.deposit()
+		ctx, _ = selector.NewPickedContext(ctx)
+
+		if mp.ReadOnly && mp.Hedge != nil && mp.Hedge.MaxAttempts > 1 {
+			if msg, ok := reply.(proto.Message); ok {
+				return hedge(ctx, mp.Hedge, policies.Budget, method, req, msg, cc, invoker, opts...)
+			}
+		}
+		if (mp.Idempotent || mp.ReadOnly) && mp.Retry != nil && mp.Retry.MaxAttempts > 1 {
+			return retry(ctx, mp.Retry, policies.Budget, method, req, reply, cc, invoker, opts...)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func retry(ctx context.Context, policy *RetryPolicy, budget *RetryBudget, method string, req, reply interface{},
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	retryable := policy.RetryableCodes
+	if len(retryable) == 0 {
+		retryable = []codes.Code{codes.Unavailable}
+	}
+	for attempt := 1; ; attempt++ {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil || attempt >= policy.MaxAttempts || !hasCode(retryable, status.Code(err)) {
+			return err
+		}
+		if ctx.Err() != nil || !budget.withdraw() {
+			return err
+		}
+		timer := time.NewTimer(policy.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff 指数退避，加入随机抖动避免重试同时到达
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	base := p.InitialBackoff
+	if base <= 0 {
+		base = 50 * time.Millisecond
+	}
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	d := float64(base) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	return time.Duration(d/2 + rand.Float64()*d/2)
+}
+
+type hedgeResult struct {
+	reply proto.Message
+	err   error
+}
+
+func hedge(ctx context.Context, policy *HedgePolicy, budget *RetryBudget, method string, req interface{}, reply proto.Message,
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan hedgeResult, policy.MaxAttempts)
+	launched, inflight := 0, 0
+	launch := func() {
+		launched++
+		inflight++
+		r := reply.ProtoReflect().New().Interface()
+		go func() {
+			results <- hedgeResult{reply: r, err: invoker(ctx, method, req, r, cc, opts...)}
+		}()
+	}
+	launch()
+
+	delay := policy.Delay
+	if delay <= 0 {
+		delay = 100 * time.Millisecond
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	var lastErr error
+	for {
+		select {
+		case <-timer.C:
+			if launched < policy.MaxAttempts && budget.withdraw() {
+				launch()
+				timer.Reset(delay)
+			}
+		case res := <-results:
+			inflight--
+			if res.err == nil {
+				proto.Reset(reply)
+				proto.Merge(reply, res.reply)
+				return nil
+			}
+			lastErr = res.err
+			if !hasCode(policy.NonFatalCodes, status.Code(res.err)) {
+				return res.err
+			}
+			if launched < policy.MaxAttempts && budget.withdraw() {
+				launch()
+				if !timer.Stop() {
+					select {
+					case <-timer.C:
+					default:
+					}
+				}
+				timer.Reset(delay)
+			} else if inflight == 0 {
+				return lastErr
+			}
+		}
+	}
+}
+
+func hasCode(list []codes.Code, c codes.Code) bool {
+	for _, code := range list {
+		if code == c {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryBudget 重试预算：每个请求存入 ratio 个令牌，每次重试或对冲消耗一个令牌，
+// 另外每秒补充 minPerSecond 个保底令牌，防止下游故障时重试放大流量
+type RetryBudget struct {
+	ratio        float64
+	minPerSecond float64
+	max          float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewRetryBudget 创建重试预算，ratio 为重试占请求的比例，minPerSecond 为每秒保底重试次数
+func NewRetryBudget(ratio float64, minPerSecond int) *RetryBudget {
+	// 令牌上限为 10 秒保底额度加 100 个请求的比例额度，避免空闲后突发大量重试
+	max := float64(minPerSecond)*10 + ratio*100
+	return &RetryBudget{
+		ratio:        ratio,
+		minPerSecond: float64(minPerSecond),
+		max:          max,
+		tokens:       max,
+		last:         time.Now(),
+	}
+}
+
+func (b *RetryBudget) deposit() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(time.Now())
+	b.tokens = math.Min(b.max, b.tokens+b.ratio)
+}
+
+func (b *RetryBudget) withdraw() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(time.Now())
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func (b *RetryBudget) refill(now time.Time) {
+	elapsed := now.Sub(b.last).Seconds()
+	b.last = now
+	if elapsed > 0 {
+		b.tokens = math.Min(b.max, b.tokens+elapsed*b.minPerSecond)
+	}
+}
@@ -0,0 +1,98 @@
+package clientinterceptors
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	readMethod  = "/Goods/BatchGetGoods"
+	writeMethod = "/Inventory/Sell"
+)
+
+func testPolicies(budget *RetryBudget) *CallPolicies {
+	retry := &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+	return &CallPolicies{
+		Methods: map[string]*MethodPolicy{
+			readMethod: {ReadOnly: true, Retry: retry},
+			// 非幂等写操作即使配置了重试也不会重试
+			writeMethod: {Retry: retry},
+		},
+		Budget: budget,
+	}
+}
+
+func failingInvoker(failures int32, code codes.Code, calls *int32) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		if atomic.AddInt32(calls, 1) <= failures {
+			return status.Error(code, "boom")
+		}
+		return nil
+	}
+}
+
+func TestRetryIdempotentOnly(t *testing.T) {
+	interceptor := UnaryRetryInterceptor(testPolicies(nil))
+
+	var calls int32
+	err := interceptor(context.Background(), readMethod, nil, nil, nil, failingInvoker(2, codes.Unavailable, &calls))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 3, calls)
+
+	calls = 0
+	err = interceptor(context.Background(), writeMethod, nil, nil, nil, failingInvoker(1, codes.Unavailable, &calls))
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.EqualValues(t, 1, calls)
+
+	// 不可重试的错误码直接返回
+	calls = 0
+	err = interceptor(context.Background(), readMethod, nil, nil, nil, failingInvoker(1, codes.InvalidArgument, &calls))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.EqualValues(t, 1, calls)
+}
+
+func TestRetryBudget(t *testing.T) {
+	budget := NewRetryBudget(0, 0)
+	budget.max, budget.tokens = 1, 1
+	interceptor := UnaryRetryInterceptor(testPolicies(budget))
+
+	var calls int32
+	err := interceptor(context.Background(), readMethod, nil, nil, nil, failingInvoker(10, codes.Unavailable, &calls))
+	assert.Error(t, err)
+	// 预算只够一次重试
+	assert.EqualValues(t, 2, calls)
+}
+
+func TestHedgeTakesFastestReply(t *testing.T) {
+	policies := &CallPolicies{Methods: map[string]*MethodPolicy{
+		"/Goods/": {ReadOnly: true, Hedge: &HedgePolicy{MaxAttempts: 2, Delay: 10 * time.Millisecond}},
+	}}
+	interceptor := UnaryRetryInterceptor(policies)
+
+	var calls int32
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			// 第一个请求很慢，直到被取消
+			<-ctx.Done()
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		reply.(*wrapperspb.StringValue).Value = "hedged"
+		return nil
+	}
+
+	reply := &wrapperspb.StringValue{}
+	start := time.Now()
+	err := interceptor(context.Background(), readMethod, nil, reply, nil, invoker)
+	assert.NoError(t, err)
+	assert.Equal(t, "hedged", reply.Value)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&calls))
+	assert.Less(t, time.Since(start), time.Second)
+}
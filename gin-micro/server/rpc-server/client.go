@@ -3,15 +3,18 @@ package rpcserver
 import (
 	"context"
 
+	"strings"
 	"time"
 
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	grpcinsecure "google.golang.org/grpc/credentials/insecure"
 
+	"emshop/gin-micro/core/health"
 	"emshop/gin-micro/registry"
 	clientinterceptors "emshop/gin-micro/server/rpc-server/client-interceptors"
 	"emshop/gin-micro/server/rpc-server/resolver/discovery"
+	"emshop/gin-micro/server/rpc-server/selector"
 	"emshop/pkg/log"
 )
 
@@ -25,9 +28,13 @@ type clientOptions struct {
 	streamInts    []grpc.StreamClientInterceptor	// Stream拦截器
 	rpcOpts       []grpc.DialOption	// gRPC客户端选项
 	balancerName  string
+	filters       []selector.NodeFilter	// 节点过滤器
+	policies      *clientinterceptors.CallPolicies	// 重试、对冲策略
+	breakers      *selector.BreakerGroup	// 节点熔断器
 	log           log.LogHelper
 	enableTracing bool		// 是否启用Tracing
 	enableMetrics bool		// 是否启用Metrics
+	healthCheck   bool		// 是否登记为依赖检查项
 }
 
 type ClientOption func(o *clientOptions)
@@ -94,6 +101,37 @@ func WithBalancerName(name string) ClientOption {
 	}
 }
 
+// 设置节点过滤器，按版本、元数据或灰度比例筛选节点
+// 仅对 selector/p2c/wrr/random 等本框架注册的负载均衡器生效
+func WithNodeFilter(filters ...selector.NodeFilter) ClientOption {
+	return func(o *clientOptions) {
+		o.filters = filters
+	}
+}
+
+// 设置按方法的重试、对冲策略，只有标记为幂等或只读的方法才会重试
+func WithCallPolicies(policies *clientinterceptors.CallPolicies) ClientOption {
+	return func(o *clientOptions) {
+		o.policies = policies
+	}
+}
+
+// 开启节点熔断，熔断中的节点不会被负载均衡器选中
+// 未设置 IsFailure 时使用 selector.IsNodeFailure，只有节点不可用、超时、过载和内部错误计入失败
+func WithCircuitBreaker(opts selector.BreakerOptions) ClientOption {
+	return func(o *clientOptions) {
+		o.breakers = selector.NewBreakerGroup(opts)
+	}
+}
+
+// 把下游服务登记为可选的依赖检查项，检查结果出现在 /readyz 报告中，
+// 下游不可用时不影响本服务的就绪状态，避免级联摘除
+func WithHealthCheck() ClientOption {
+	return func(o *clientOptions) {
+		o.healthCheck = true
+	}
+}
+
 func DialInsecure(ctx context.Context, opts ...ClientOption) (*grpc.ClientConn, error) {
 	return dial(ctx, true, opts...)
 }
@@ -116,6 +154,11 @@ func dial(ctx context.Context, insecure bool, opts ...ClientOption) (*grpc.Clien
 	//TODO 客户端默认拦截器
 	ints := []grpc.UnaryClientInterceptor{
 		clientinterceptors.TimeoutInterceptor(options.timeout),
+		clientinterceptors.UnaryLogContextInterceptor,
+	}
+	// 重试在超时之内进行，每次尝试都经过后续的链路追踪和指标拦截器
+	if options.policies != nil {
+		ints = append(ints, clientinterceptors.UnaryRetryInterceptor(options.policies))
 	}
 	if options.enableTracing {
 		ints = append(ints, otelgrpc.UnaryClientInterceptor())
@@ -125,7 +168,14 @@ func dial(ctx context.Context, insecure bool, opts ...ClientOption) (*grpc.Clien
 		ints = append(ints, clientinterceptors.PrometheusInterceptor())
 	}
 
-	streamInts := []grpc.StreamClientInterceptor{}
+	streamInts := []grpc.StreamClientInterceptor{
+		clientinterceptors.StreamLogContextInterceptor,
+	}
+
+	if len(options.filters) > 0 || options.breakers != nil {
+		ints = append(ints, selectorUnaryInterceptor(options.filters, options.breakers))
+		streamInts = append(streamInts, selectorStreamInterceptor(options.filters, options.breakers))
+	}
 
 	if len(options.unaryInts) > 0 {
 		ints = append(ints, options.unaryInts...)
@@ -161,5 +211,43 @@ func dial(ctx context.Context, insecure bool, opts ...ClientOption) (*grpc.Clien
 		grpcOpts = append(grpcOpts, options.rpcOpts...)
 	}
 
-	return grpc.DialContext(ctx, options.endpoint, grpcOpts...)
+	conn, err := grpc.DialContext(ctx, options.endpoint, grpcOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if options.healthCheck {
+		health.Register("grpc:"+healthCheckName(options.endpoint), health.GRPCCheck(conn, ""), health.Optional())
+	}
+	return conn, nil
+}
+
+// healthCheckName 依赖检查项名称，discovery:///emshop-user-srv 取服务名
+func healthCheckName(endpoint string) string {
+	if i := strings.LastIndex(endpoint, "///"); i >= 0 {
+		return endpoint[i+3:]
+	}
+	return endpoint
+}
+
+// selectorUnaryInterceptor 把节点过滤器和熔断器组放入上下文，供 balancerPicker 选择节点时使用
+func selectorUnaryInterceptor(filters []selector.NodeFilter, breakers *selector.BreakerGroup) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(selectorContext(ctx, filters, breakers), method, req, reply, cc, opts...)
+	}
+}
+
+func selectorStreamInterceptor(filters []selector.NodeFilter, breakers *selector.BreakerGroup) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(selectorContext(ctx, filters, breakers), desc, cc, method, opts...)
+	}
+}
+
+func selectorContext(ctx context.Context, filters []selector.NodeFilter, breakers *selector.BreakerGroup) context.Context {
+	if len(filters) > 0 {
+		ctx = selector.NewFilterContext(ctx, filters)
+	}
+	if breakers != nil {
+		ctx = selector.NewBreakerContext(ctx, breakers)
+	}
+	return ctx
 }
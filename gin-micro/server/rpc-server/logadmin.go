@@ -0,0 +1,126 @@
+package rpcserver
+
+import (
+	"context"
+	"encoding/json"
+
+	"emshop/gin-micro/core/logadmin"
+	"emshop/pkg/log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// LogAdminServiceName 日志管理服务名
+//
+// 请求和响应使用 protobuf 内置的 Struct，不需要 proto 文件，可以用 grpcurl 直接调用：
+//
+//	grpcurl -plaintext -H 'x-admin-token: <secret>' -d '{"logger":"flashsale","level":"debug","ttl":"10m"}' \
+//	  host:port emshop.admin.LogAdmin/SetLevel
+const LogAdminServiceName = "emshop.admin.LogAdmin"
+
+// logAdminServer 日志管理服务，调用方需要在 x-admin-token 元数据中携带密钥
+type logAdminServer struct {
+	secret string
+}
+
+func (s *logAdminServer) authorize(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var token string
+	if v := md.Get(logadmin.MetadataAdminToken); len(v) > 0 {
+		token = v[0]
+	}
+	if !logadmin.Authorized(s.secret, token) {
+		return status.Errorf(codes.PermissionDenied, "invalid %s", logadmin.MetadataAdminToken)
+	}
+	return nil
+}
+
+// GetLevels 查看日志级别
+func (s *logAdminServer) GetLevels(ctx context.Context, _ *emptypb.Empty) (*structpb.Struct, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	return levelsStruct(log.Levels())
+}
+
+// SetLevel 修改日志级别，请求为 {"logger":"","level":"debug","ttl":"10m"}
+func (s *logAdminServer) SetLevel(ctx context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	var req logadmin.LevelRequest
+	if err := fromStruct(in, &req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	levels, err := logadmin.SetLevel(&req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return levelsStruct(levels)
+}
+
+// ResetLevel 恢复配置的日志级别，请求为 {"logger":""}
+func (s *logAdminServer) ResetLevel(ctx context.Context, in *structpb.Struct) (*structpb.Struct, error) {
+	if err := s.authorize(ctx); err != nil {
+		return nil, err
+	}
+	var req logadmin.LevelRequest
+	if err := fromStruct(in, &req); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return levelsStruct(logadmin.ResetLevel(req.Logger))
+}
+
+func fromStruct(in *structpb.Struct, v interface{}) error {
+	data, err := protojson.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func levelsStruct(levels []log.LevelSetting) (*structpb.Struct, error) {
+	data, err := json.Marshal(map[string]interface{}{"levels": levels})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	out := &structpb.Struct{}
+	if err := protojson.Unmarshal(data, out); err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	return out, nil
+}
+
+func logAdminHandler[Req any](call func(*logAdminServer, context.Context, *Req) (*structpb.Struct, error), method string) grpc.MethodHandler {
+	return func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+		in := new(Req)
+		if err := dec(in); err != nil {
+			return nil, err
+		}
+		s := srv.(*logAdminServer)
+		if interceptor == nil {
+			return call(s, ctx, in)
+		}
+		info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/" + LogAdminServiceName + "/" + method}
+		return interceptor(ctx, in, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return call(s, ctx, req.(*Req))
+		})
+	}
+}
+
+var logAdminServiceDesc = grpc.ServiceDesc{
+	ServiceName: LogAdminServiceName,
+	HandlerType: (*interface{})(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "GetLevels", Handler: logAdminHandler((*logAdminServer).GetLevels, "GetLevels")},
+		{MethodName: "SetLevel", Handler: logAdminHandler((*logAdminServer).SetLevel, "SetLevel")},
+		{MethodName: "ResetLevel", Handler: logAdminHandler((*logAdminServer).ResetLevel, "ResetLevel")},
+	},
+	Metadata: "emshop/admin/logadmin",
+}
@@ -0,0 +1,47 @@
+package rpcserver
+
+import (
+	"context"
+	"testing"
+
+	"emshop/pkg/log"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestLogAdmin(t *testing.T) {
+	conn := newStreamServer(t, 0, WithLogAdmin("secret"))
+	t.Cleanup(func() { log.ResetLevel("logadmin.test") })
+	invoke := func(ctx context.Context, method string, in interface{}) (*structpb.Struct, error) {
+		out := &structpb.Struct{}
+		err := conn.Invoke(ctx, "/"+LogAdminServiceName+"/"+method, in, out)
+		return out, err
+	}
+
+	_, err := invoke(context.Background(), "GetLevels", &emptypb.Empty{})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-admin-token", "secret")
+	req, _ := structpb.NewStruct(map[string]interface{}{"logger": "logadmin.test", "level": "nope"})
+	_, err = invoke(ctx, "SetLevel", req)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	req, _ = structpb.NewStruct(map[string]interface{}{"logger": "logadmin.test", "level": "debug", "ttl": "1m"})
+	out, err := invoke(ctx, "SetLevel", req)
+	require.NoError(t, err)
+	levels := out.Fields["levels"].GetListValue().GetValues()
+	require.Len(t, levels, 2)
+	setting := levels[1].GetStructValue().AsMap()
+	require.Equal(t, "logadmin.test", setting["name"])
+	require.Equal(t, "debug", setting["level"])
+	require.NotEmpty(t, setting["expire_at"])
+
+	out, err = invoke(ctx, "ResetLevel", req)
+	require.NoError(t, err)
+	require.Len(t, out.Fields["levels"].GetListValue().GetValues(), 1)
+}
@@ -0,0 +1,47 @@
+package selector
+
+import (
+	"context"
+	"sync"
+)
+
+// PickedNodes 记录同一次调用的多次尝试已选过的节点，重试和对冲时优先避开这些节点
+type PickedNodes struct {
+	mu    sync.Mutex
+	addrs map[string]struct{}
+}
+
+// Add 记录已选节点
+func (p *PickedNodes) Add(addr string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.addrs == nil {
+		p.addrs = make(map[string]struct{})
+	}
+	p.addrs[addr] = struct{}{}
+}
+
+// Has 节点是否已被选过
+func (p *PickedNodes) Has(addr string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, ok := p.addrs[addr]
+	return ok
+}
+
+type pickedKey struct{}
+
+// NewPickedContext 为一次调用创建已选节点记录，同一上下文上的多次尝试共享该记录
+func NewPickedContext(ctx context.Context) (context.Context, *PickedNodes) {
+	if p, ok := PickedFromContext(ctx); ok {
+		return ctx, p
+	}
+	p := &PickedNodes{}
+	return context.WithValue(ctx, pickedKey{}, p), p
+}
+
+// PickedFromContext 从上下文中获取已选节点记录
+func PickedFromContext(ctx context.Context) (*PickedNodes, bool) {
+	p, ok := ctx.Value(pickedKey{}).(*PickedNodes)
+	return p, ok
+}
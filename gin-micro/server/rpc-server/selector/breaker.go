@@ -0,0 +1,241 @@
+package selector
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"emshop/pkg/errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrCircuitOpen 表示所有候选节点的熔断器都处于打开状态
+var ErrCircuitOpen = errors.New("circuit_breaker_open")
+
+// BreakerState 熔断器状态
+type BreakerState int32
+
+const (
+	BreakerClosed   BreakerState = iota // 关闭，正常放行
+	BreakerOpen                         // 打开，跳过该节点
+	BreakerHalfOpen                     // 半开，放行少量探测请求
+)
+
+// BreakerOptions 节点熔断配置
+type BreakerOptions struct {
+	// Window 失败率统计窗口
+	Window time.Duration
+	// MinRequests 窗口内请求数达到该值才判断是否熔断
+	MinRequests int
+	// FailureRatio 失败率达到该值时打开熔断
+	FailureRatio float64
+	// OpenTimeout 打开后经过该时间进入半开
+	OpenTimeout time.Duration
+	// HalfOpenProbes 半开时放行的探测请求数，全部成功后关闭熔断
+	HalfOpenProbes int
+	// IsFailure 判断调用错误是否计入失败，为空时使用IsNodeFailure
+	IsFailure func(err error) bool
+}
+
+// DefaultBreakerOptions 返回默认熔断配置
+func DefaultBreakerOptions() BreakerOptions {
+	return BreakerOptions{
+		Window:         10 * time.Second,
+		MinRequests:    20,
+		FailureRatio:   0.5,
+		OpenTimeout:    5 * time.Second,
+		HalfOpenProbes: 3,
+		IsFailure:      IsNodeFailure,
+	}
+}
+
+// IsNodeFailure 只有节点不可用、超时、过载和内部错误计入熔断失败，业务错误不影响节点健康
+func IsNodeFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal:
+		return true
+	}
+	return false
+}
+
+// Breaker 单个节点的熔断器
+type Breaker struct {
+	opts BreakerOptions
+
+	mu           sync.Mutex
+	state        BreakerState
+	windowStart  time.Time
+	total        int
+	failures     int
+	openedAt     time.Time
+	probes       int
+	probeSuccess int
+}
+
+// NewBreaker 创建熔断器
+func NewBreaker(opts BreakerOptions) *Breaker {
+	def := DefaultBreakerOptions()
+	if opts.Window <= 0 {
+		opts.Window = def.Window
+	}
+	if opts.MinRequests <= 0 {
+		opts.MinRequests = def.MinRequests
+	}
+	if opts.FailureRatio <= 0 || opts.FailureRatio > 1 {
+		opts.FailureRatio = def.FailureRatio
+	}
+	if opts.OpenTimeout <= 0 {
+		opts.OpenTimeout = def.OpenTimeout
+	}
+	if opts.HalfOpenProbes <= 0 {
+		opts.HalfOpenProbes = def.HalfOpenProbes
+	}
+	if opts.IsFailure == nil {
+		opts.IsFailure = def.IsFailure
+	}
+	return &Breaker{opts: opts, windowStart: time.Now()}
+}
+
+// State 返回当前状态
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance(time.Now())
+	return b.state
+}
+
+// Ready 节点当前是否可以被选择，不占用半开探测名额
+func (b *Breaker) Ready() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance(time.Now())
+	switch b.state {
+	case BreakerOpen:
+		return false
+	case BreakerHalfOpen:
+		return b.probes < b.opts.HalfOpenProbes
+	}
+	return true
+}
+
+// Allow 申请放行一次请求，半开时占用一个探测名额
+func (b *Breaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance(time.Now())
+	switch b.state {
+	case BreakerOpen:
+		return false
+	case BreakerHalfOpen:
+		if b.probes >= b.opts.HalfOpenProbes {
+			return false
+		}
+		b.probes++
+	}
+	return true
+}
+
+// Done 记录一次调用结果
+func (b *Breaker) Done(err error) {
+	if err != nil && b.opts.IsFailure(err) {
+		b.MarkFailed()
+		return
+	}
+	b.MarkSuccess()
+}
+
+// MarkSuccess 记录一次成功
+func (b *Breaker) MarkSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	b.advance(now)
+	switch b.state {
+	case BreakerClosed:
+		b.total++
+	case BreakerHalfOpen:
+		b.probeSuccess++
+		if b.probeSuccess >= b.opts.HalfOpenProbes {
+			b.reset(now)
+		}
+	}
+}
+
+// MarkFailed 记录一次失败
+func (b *Breaker) MarkFailed() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	b.advance(now)
+	switch b.state {
+	case BreakerClosed:
+		b.total++
+		b.failures++
+		if b.total >= b.opts.MinRequests && float64(b.failures)/float64(b.total) >= b.opts.FailureRatio {
+			b.open(now)
+		}
+	case BreakerHalfOpen:
+		b.open(now)
+	}
+}
+
+// advance 按时间推进状态：统计窗口过期后清零，打开超时后进入半开
+func (b *Breaker) advance(now time.Time) {
+	switch b.state {
+	case BreakerClosed:
+		if now.Sub(b.windowStart) >= b.opts.Window {
+			b.reset(now)
+		}
+	case BreakerOpen:
+		if now.Sub(b.openedAt) >= b.opts.OpenTimeout {
+			b.state = BreakerHalfOpen
+			b.probes, b.probeSuccess = 0, 0
+		}
+	}
+}
+
+func (b *Breaker) open(now time.Time) {
+	b.state = BreakerOpen
+	b.openedAt = now
+}
+
+func (b *Breaker) reset(now time.Time) {
+	b.state = BreakerClosed
+	b.windowStart = now
+	b.total, b.failures = 0, 0
+}
+
+// BreakerGroup 按节点地址维护熔断器，生命周期跟随客户端连接，节点列表刷新时熔断状态不丢失
+type BreakerGroup struct {
+	opts     BreakerOptions
+	breakers sync.Map // address -> *Breaker
+}
+
+// NewBreakerGroup 创建熔断器组
+func NewBreakerGroup(opts BreakerOptions) *BreakerGroup {
+	return &BreakerGroup{opts: opts}
+}
+
+// Get 返回节点的熔断器，不存在时创建
+func (g *BreakerGroup) Get(addr string) *Breaker {
+	if b, ok := g.breakers.Load(addr); ok {
+		return b.(*Breaker)
+	}
+	b, _ := g.breakers.LoadOrStore(addr, NewBreaker(g.opts))
+	return b.(*Breaker)
+}
+
+type breakerKey struct{}
+
+// NewBreakerContext 将客户端的熔断器组放入上下文，选择器据此跳过熔断中的节点
+func NewBreakerContext(ctx context.Context, g *BreakerGroup) context.Context {
+	return context.WithValue(ctx, breakerKey{}, g)
+}
+
+// BreakersFromContext 从上下文中获取熔断器组
+func BreakersFromContext(ctx context.Context) (*BreakerGroup, bool) {
+	g, ok := ctx.Value(breakerKey{}).(*BreakerGroup)
+	return g, ok && g != nil
+}
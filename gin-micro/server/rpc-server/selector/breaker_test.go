@@ -0,0 +1,110 @@
+package selector_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"emshop/gin-micro/registry"
+	"emshop/gin-micro/server/rpc-server/selector"
+	"emshop/gin-micro/server/rpc-server/selector/random"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errUnavailable = status.Error(codes.Unavailable, "unavailable")
+
+func TestBreakerTransitions(t *testing.T) {
+	b := selector.NewBreaker(selector.BreakerOptions{
+		MinRequests:    4,
+		FailureRatio:   0.5,
+		OpenTimeout:    50 * time.Millisecond,
+		HalfOpenProbes: 2,
+	})
+
+	b.MarkSuccess()
+	b.MarkSuccess()
+	b.MarkFailed()
+	assert.Equal(t, selector.BreakerClosed, b.State())
+	b.MarkFailed()
+	assert.Equal(t, selector.BreakerOpen, b.State())
+	assert.False(t, b.Allow())
+
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, selector.BreakerHalfOpen, b.State())
+	assert.True(t, b.Allow())
+	assert.True(t, b.Allow())
+	// 探测名额用完
+	assert.False(t, b.Ready())
+	b.MarkSuccess()
+	b.MarkSuccess()
+	assert.Equal(t, selector.BreakerClosed, b.State())
+
+	// 半开时探测失败重新打开
+	for i := 0; i < 4; i++ {
+		b.MarkFailed()
+	}
+	time.Sleep(60 * time.Millisecond)
+	require.True(t, b.Allow())
+	b.MarkFailed()
+	assert.Equal(t, selector.BreakerOpen, b.State())
+}
+
+func TestSelectSkipsBrokenAndPickedNodes(t *testing.T) {
+	s := random.New()
+	s.Apply([]selector.Node{
+		selector.NewNode("grpc", "10.0.0.1:80", &registry.ServiceInstance{}),
+		selector.NewNode("grpc", "10.0.0.2:80", &registry.ServiceInstance{}),
+	})
+
+	group := selector.NewBreakerGroup(selector.BreakerOptions{MinRequests: 1, OpenTimeout: time.Minute})
+	ctx := selector.NewBreakerContext(context.Background(), group)
+	group.Get("10.0.0.1:80").MarkFailed()
+
+	for i := 0; i < 10; i++ {
+		n, done, err := s.Select(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "10.0.0.2:80", n.Address())
+		done(ctx, selector.DoneInfo{})
+	}
+
+	// 选中节点的调用失败反馈给熔断器，失败率达到阈值后熔断，全部熔断后快速失败
+	for i := 0; i < 10; i++ {
+		n, done, err := s.Select(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "10.0.0.2:80", n.Address())
+		done(ctx, selector.DoneInfo{Err: errUnavailable})
+	}
+	assert.Equal(t, selector.BreakerOpen, group.Get("10.0.0.2:80").State())
+	_, _, err := s.Select(ctx)
+	assert.ErrorIs(t, err, selector.ErrCircuitOpen)
+
+	// 同一次调用的重试避开已选节点，全部选过后可以重复选择
+	pctx, _ := selector.NewPickedContext(context.Background())
+	first, done, err := s.Select(pctx)
+	require.NoError(t, err)
+	done(pctx, selector.DoneInfo{})
+	second, done, err := s.Select(pctx)
+	require.NoError(t, err)
+	done(pctx, selector.DoneInfo{})
+	assert.NotEqual(t, first.Address(), second.Address())
+	_, _, err = s.Select(pctx)
+	assert.NoError(t, err)
+}
+
+func TestBreakerDefaultFailures(t *testing.T) {
+	b := selector.NewBreaker(selector.BreakerOptions{MinRequests: 1, FailureRatio: 0.2, OpenTimeout: time.Minute})
+
+	// 业务错误不计入失败
+	b.Done(status.Error(codes.NotFound, "goods not found"))
+	b.Done(status.Error(codes.InvalidArgument, "bad request"))
+	b.Done(errors.New("plain"))
+	assert.Equal(t, selector.BreakerClosed, b.State())
+
+	b.Done(status.Error(codes.Internal, "panic"))
+	assert.Equal(t, selector.BreakerOpen, b.State())
+}
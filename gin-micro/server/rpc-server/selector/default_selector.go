@@ -14,10 +14,14 @@ type Default struct {
 }
 
 // Select 选择一个节点
-func (d *Default) Select(ctx context.Context) (selected Node, done DoneFunc, err error) {
+func (d *Default) Select(ctx context.Context, opts ...SelectOption) (selected Node, done DoneFunc, err error) {
 	var (
+		options    SelectOptions
 		candidates []WeightedNode // 候选节点列表
 	)
+	for _, o := range opts {
+		o(&options)
+	}
 	// 从原子值中加载节点列表
 	nodes, ok := d.nodes.Load().([]WeightedNode)
 	if !ok {
@@ -25,15 +29,68 @@ func (d *Default) Select(ctx context.Context) (selected Node, done DoneFunc, err
 	}
 	candidates = nodes
 
+	// 依次执行节点过滤器，过滤器只会返回传入节点的子集
+	if len(options.NodeFilters) > 0 {
+		filtered := make([]Node, len(nodes))
+		for i, wn := range nodes {
+			filtered[i] = wn
+		}
+		for _, filter := range options.NodeFilters {
+			filtered = filter(ctx, filtered)
+		}
+		candidates = make([]WeightedNode, len(filtered))
+		for i, n := range filtered {
+			candidates[i] = n.(WeightedNode)
+		}
+	}
+
 	// 检查是否有可用节点
 	if len(candidates) == 0 {
 		return nil, nil, ErrNoAvailable
 	}
+
+	// 跳过熔断中的节点，全部熔断时快速失败
+	breakers, hasBreakers := BreakersFromContext(ctx)
+	if hasBreakers {
+		available := make([]WeightedNode, 0, len(candidates))
+		for _, wn := range candidates {
+			if breakers.Get(wn.Address()).Ready() {
+				available = append(available, wn)
+			}
+		}
+		if len(available) == 0 {
+			return nil, nil, ErrCircuitOpen
+		}
+		candidates = available
+	}
+
+	// 重试和对冲时优先避开本次调用已经尝试过的节点
+	picked, hasPicked := PickedFromContext(ctx)
+	if hasPicked {
+		candidates = excludeNodes(candidates, func(wn WeightedNode) bool { return picked.Has(wn.Address()) })
+	}
+
 	// 使用负载均衡器选择节点
 	wn, done, err := d.Balancer.Pick(ctx, candidates)
 	if err != nil {
 		return nil, nil, err
 	}
+	if hasPicked {
+		picked.Add(wn.Address())
+	}
+	if hasBreakers {
+		br := breakers.Get(wn.Address())
+		if !br.Allow() {
+			done(ctx, DoneInfo{Err: ErrCircuitOpen})
+			return nil, nil, ErrCircuitOpen
+		}
+		// 调用结果反馈给熔断器
+		balancerDone := done
+		done = func(ctx context.Context, di DoneInfo) {
+			br.Done(di.Err)
+			balancerDone(ctx, di)
+		}
+	}
 	// 将选中的节点信息存储到上下文中
 	p, ok := FromPeerContext(ctx)
 	if ok {
@@ -42,6 +99,20 @@ func (d *Default) Select(ctx context.Context) (selected Node, done DoneFunc, err
 	return wn.Raw(), done, nil
 }
 
+// excludeNodes 去掉满足条件的节点，全部被去掉时保留原列表，保证重试时仍有节点可选
+func excludeNodes(nodes []WeightedNode, exclude func(WeightedNode) bool) []WeightedNode {
+	kept := make([]WeightedNode, 0, len(nodes))
+	for _, wn := range nodes {
+		if !exclude(wn) {
+			kept = append(kept, wn)
+		}
+	}
+	if len(kept) == 0 {
+		return nodes
+	}
+	return kept
+}
+
 // Apply 更新节点信息
 func (d *Default) Apply(nodes []Node) {
 	// 将普通节点转换为加权节点
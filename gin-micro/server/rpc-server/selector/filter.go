@@ -0,0 +1,47 @@
+package selector
+
+import "context"
+
+// NodeFilter 节点过滤器，在负载均衡前按版本、元数据等条件筛选候选节点
+type NodeFilter func(ctx context.Context, nodes []Node) []Node
+
+// SelectOptions 单次选择的选项
+type SelectOptions struct {
+	NodeFilters []NodeFilter
+}
+
+// SelectOption 单次选择的选项函数
+type SelectOption func(*SelectOptions)
+
+// WithNodeFilter 设置本次选择的节点过滤器，按顺序依次执行
+func WithNodeFilter(fn ...NodeFilter) SelectOption {
+	return func(opts *SelectOptions) {
+		opts.NodeFilters = fn
+	}
+}
+
+type filterKey struct{}
+
+// NewFilterContext 将客户端配置的节点过滤器放入上下文，由 picker 在选择时取出
+func NewFilterContext(ctx context.Context, filters []NodeFilter) context.Context {
+	return context.WithValue(ctx, filterKey{}, filters)
+}
+
+// FiltersFromContext 从上下文中获取节点过滤器
+func FiltersFromContext(ctx context.Context) []NodeFilter {
+	filters, _ := ctx.Value(filterKey{}).([]NodeFilter)
+	return filters
+}
+
+type routeKey struct{}
+
+// NewRouteKeyContext 设置请求的路由键（通常是用户ID），灰度分流据此保持同一用户落在同一分组
+func NewRouteKeyContext(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, routeKey{}, key)
+}
+
+// RouteKeyFromContext 从上下文中获取路由键
+func RouteKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(routeKey{}).(string)
+	return key, ok && key != ""
+}
@@ -0,0 +1,60 @@
+package filter
+
+import (
+	"context"
+	"hash/fnv"
+	"math/rand"
+	"sort"
+
+	"emshop/gin-micro/server/rpc-server/selector"
+)
+
+// Canary 按百分比把流量分给灰度版本，weights 为版本到流量百分比的映射，
+// 剩余流量进入不在 weights 中的稳定版本节点。
+//
+// 请求携带路由键（见 selector.NewRouteKeyContext）时按路由键哈希分桶，同一用户始终落在同一版本，
+// 且调大百分比时已进入灰度的用户不会被切回；没有路由键时随机分桶。
+// 选中的灰度版本没有节点时退回稳定版本，稳定版本也没有节点时不做过滤。
+func Canary(weights map[string]int) selector.NodeFilter {
+	versions := make([]string, 0, len(weights))
+	for v, w := range weights {
+		if w > 0 {
+			versions = append(versions, v)
+		}
+	}
+	sort.Strings(versions)
+
+	return func(ctx context.Context, nodes []selector.Node) []selector.Node {
+		bucket := canaryBucket(ctx)
+		acc := 0
+		for _, v := range versions {
+			acc += weights[v]
+			if bucket < acc {
+				if matched := Version(v)(ctx, nodes); len(matched) > 0 {
+					return matched
+				}
+				break
+			}
+		}
+
+		stable := filterNodes(nodes, func(n selector.Node) bool {
+			_, ok := weights[n.Version()]
+			return !ok
+		})
+		if len(stable) == 0 {
+			return nodes
+		}
+		return stable
+	}
+}
+
+// canaryBucket 返回 [0, 100) 的分桶号
+func canaryBucket(ctx context.Context) int {
+	key, ok := selector.RouteKeyFromContext(ctx)
+	if !ok {
+		return rand.Intn(100)
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % 100)
+}
@@ -0,0 +1,100 @@
+package filter
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"emshop/gin-micro/registry"
+	"emshop/gin-micro/server/rpc-server/selector"
+	"emshop/gin-micro/server/rpc-server/selector/random"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newNode(addr, version string, md map[string]string) selector.Node {
+	return selector.NewNode("grpc", addr, &registry.ServiceInstance{
+		Name:     "emshop-order-srv",
+		Version:  version,
+		Metadata: md,
+	})
+}
+
+func addrs(nodes []selector.Node) []string {
+	out := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		out = append(out, n.Address())
+	}
+	return out
+}
+
+var testNodes = []selector.Node{
+	newNode("10.0.0.1:80", "v1", map[string]string{"zone": "sh-a", "tenant": "t1"}),
+	newNode("10.0.0.2:80", "v1", map[string]string{"zone": "sh-b", "tenant": "t2"}),
+	newNode("10.0.0.3:80", "v2", map[string]string{"zone": "sh-a", "tenant": "t1"}),
+}
+
+func TestVersionAndMetadata(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, []string{"10.0.0.3:80"}, addrs(Version("v2")(ctx, testNodes)))
+	assert.Empty(t, Version("v3")(ctx, testNodes))
+
+	assert.Equal(t, []string{"10.0.0.2:80"}, addrs(Metadata("tenant", "t2")(ctx, testNodes)))
+	assert.Len(t, Metadata("tenant", "t1", "t2")(ctx, testNodes), 3)
+
+	assert.Equal(t, []string{"10.0.0.2:80"}, addrs(Zone("sh-b")(ctx, testNodes)))
+	// 本区没有节点时退回全部节点
+	assert.Len(t, Zone("bj-a")(ctx, testNodes), 3)
+}
+
+func TestCanarySticky(t *testing.T) {
+	canary := Canary(map[string]int{"v2": 30})
+
+	hits := 0
+	for i := 0; i < 1000; i++ {
+		ctx := selector.NewRouteKeyContext(context.Background(), fmt.Sprintf("user-%d", i))
+		first := addrs(canary(ctx, testNodes))
+		// 同一路由键多次选择结果一致
+		assert.Equal(t, first, addrs(canary(ctx, testNodes)))
+		if len(first) == 1 && first[0] == "10.0.0.3:80" {
+			hits++
+		} else {
+			assert.Equal(t, []string{"10.0.0.1:80", "10.0.0.2:80"}, first)
+		}
+	}
+	assert.InDelta(t, 300, hits, 60)
+
+	// 调大比例时已进入灰度的用户保持在灰度
+	wider := Canary(map[string]int{"v2": 60})
+	for i := 0; i < 200; i++ {
+		ctx := selector.NewRouteKeyContext(context.Background(), fmt.Sprintf("user-%d", i))
+		if addrs(canary(ctx, testNodes))[0] == "10.0.0.3:80" {
+			assert.Equal(t, []string{"10.0.0.3:80"}, addrs(wider(ctx, testNodes)))
+		}
+	}
+}
+
+func TestCanaryFallback(t *testing.T) {
+	ctx := selector.NewRouteKeyContext(context.Background(), "user-1")
+	// 灰度版本没有节点时退回稳定版本
+	assert.Len(t, Canary(map[string]int{"v3": 100})(ctx, testNodes), 3)
+	// 全部节点都是灰度版本时不做过滤
+	assert.Len(t, Canary(map[string]int{"v1": 0, "v2": 0})(ctx, testNodes), 3)
+	assert.Equal(t, []string{"10.0.0.3:80"}, addrs(Canary(map[string]int{"v2": 100})(ctx, testNodes)))
+}
+
+func TestSelectWithFilters(t *testing.T) {
+	s := random.New()
+	s.Apply(testNodes)
+
+	for i := 0; i < 20; i++ {
+		n, done, err := s.Select(context.Background(), selector.WithNodeFilter(Zone("sh-a"), Version("v1")))
+		require.NoError(t, err)
+		assert.Equal(t, "10.0.0.1:80", n.Address())
+		done(context.Background(), selector.DoneInfo{})
+	}
+
+	_, _, err := s.Select(context.Background(), selector.WithNodeFilter(Version("v3")))
+	assert.ErrorIs(t, err, selector.ErrNoAvailable)
+}
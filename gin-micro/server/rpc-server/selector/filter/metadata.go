@@ -0,0 +1,42 @@
+package filter
+
+import (
+	"context"
+
+	"emshop/gin-micro/server/rpc-server/selector"
+)
+
+// ZoneKey 节点所在可用区的元数据键
+const ZoneKey = "zone"
+
+// Metadata 只保留元数据 key 的值在 values 中的节点，例如按租户隔离
+func Metadata(key string, values ...string) selector.NodeFilter {
+	allowed := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		allowed[v] = struct{}{}
+	}
+	return func(_ context.Context, nodes []selector.Node) []selector.Node {
+		return filterNodes(nodes, func(n selector.Node) bool {
+			_, ok := allowed[n.Metadata()[key]]
+			return ok
+		})
+	}
+}
+
+// PreferMetadata 优先选择元数据匹配的节点，没有匹配节点时退回全部节点
+func PreferMetadata(key, value string) selector.NodeFilter {
+	return func(_ context.Context, nodes []selector.Node) []selector.Node {
+		matched := filterNodes(nodes, func(n selector.Node) bool {
+			return n.Metadata()[key] == value
+		})
+		if len(matched) == 0 {
+			return nodes
+		}
+		return matched
+	}
+}
+
+// Zone 同可用区优先路由，本区没有可用节点时跨区调用
+func Zone(zone string) selector.NodeFilter {
+	return PreferMetadata(ZoneKey, zone)
+}
@@ -0,0 +1,27 @@
+package filter
+
+import (
+	"context"
+
+	"emshop/gin-micro/server/rpc-server/selector"
+)
+
+// Version 只保留指定版本的节点，没有匹配节点时返回空列表
+func Version(version string) selector.NodeFilter {
+	return func(_ context.Context, nodes []selector.Node) []selector.Node {
+		return filterNodes(nodes, func(n selector.Node) bool {
+			return n.Version() == version
+		})
+	}
+}
+
+// filterNodes 返回满足条件的节点，不修改传入的切片
+func filterNodes(nodes []selector.Node, match func(selector.Node) bool) []selector.Node {
+	matched := make([]selector.Node, 0, len(nodes))
+	for _, n := range nodes {
+		if match(n) {
+			matched = append(matched, n)
+		}
+	}
+	return matched
+}
@@ -15,7 +15,7 @@ type Selector interface {
 
 	// Select 选择节点
 	// 如果 err == nil，则 selected 和 done 必须不为空
-	Select(ctx context.Context) (selected Node, done DoneFunc, err error)
+	Select(ctx context.Context, opts ...SelectOption) (selected Node, done DoneFunc, err error)
 }
 
 // Rebalancer 是节点重平衡器接口
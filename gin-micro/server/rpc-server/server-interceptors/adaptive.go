@@ -0,0 +1,79 @@
+package serverinterceptors
+
+import (
+	"context"
+	"strings"
+
+	"emshop/gin-micro/core/limit"
+	"emshop/gin-micro/core/metric"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PriorityFunc 根据方法名判断请求的优先级
+type PriorityFunc func(ctx context.Context, fullMethod string) limit.Priority
+
+var metricServerShedTotal = metric.NewCounterVec(&metric.CounterVecOpts{
+	Namespace: serverNamespace,
+	Subsystem: "requests",
+	Name:      "emshop_shed_total",
+	Help:      "rpc server requests rejected by the adaptive limiter.",
+	Labels:    []string{"method", "priority"},
+})
+
+// DefaultPriority 健康检查永不拒绝，其他请求按普通优先级处理
+func DefaultPriority(ctx context.Context, fullMethod string) limit.Priority {
+	if strings.HasPrefix(fullMethod, "/grpc.health.v1.Health/") {
+		return limit.PriorityCritical
+	}
+	return limit.PriorityNormal
+}
+
+// UnaryAdaptiveLimitInterceptor 自适应并发限流，超出当前并发上限的请求返回 ResourceExhausted
+func UnaryAdaptiveLimitInterceptor(l *limit.Limiter, priority PriorityFunc) grpc.UnaryServerInterceptor {
+	if priority == nil {
+		priority = DefaultPriority
+	}
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		p := priority(ctx, info.FullMethod)
+		token, ok := l.Acquire(p)
+		if !ok {
+			metricServerShedTotal.Inc(info.FullMethod, p.String())
+			return nil, status.Errorf(codes.ResourceExhausted, "server overloaded, %s request to %s rejected", p, info.FullMethod)
+		}
+
+		resp, err := handler(ctx, req)
+		token.Done(isOverloadErr(err))
+		return resp, err
+	}
+}
+
+// StreamAdaptiveLimitInterceptor 流式请求只参与并发计数，流的持续时间不作为耗时样本
+func StreamAdaptiveLimitInterceptor(l *limit.Limiter, priority PriorityFunc) grpc.StreamServerInterceptor {
+	if priority == nil {
+		priority = DefaultPriority
+	}
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		p := priority(stream.Context(), info.FullMethod)
+		token, ok := l.Acquire(p)
+		if !ok {
+			metricServerShedTotal.Inc(info.FullMethod, p.String())
+			return status.Errorf(codes.ResourceExhausted, "server overloaded, %s stream to %s rejected", p, info.FullMethod)
+		}
+		defer token.Ignore()
+		return handler(srv, stream)
+	}
+}
+
+// isOverloadErr 超时和资源耗尽说明服务已经过载，需要立即收缩并发上限
+func isOverloadErr(err error) bool {
+	switch status.Code(err) {
+	case codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	}
+	return false
+}
@@ -3,6 +3,8 @@ package serverinterceptors
 import (
 	"context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"runtime/debug"
 
 	"emshop/pkg/log"
@@ -12,6 +14,7 @@ func StreamCrashInterceptor(svr interface{}, stream grpc.ServerStream, _ *grpc.S
 	handler grpc.StreamHandler) (err error) {
 	defer handleCrash(func(r interface{}) {
 		log.Errorf("%+v\n \n %s", r, debug.Stack())
+		err = status.Errorf(codes.Internal, "panic: %v", r)
 	})
 
 	return handler(svr, stream)
@@ -21,6 +24,7 @@ func UnaryCrashInterceptor(ctx context.Context, req interface{}, info *grpc.Unar
 	handler grpc.UnaryHandler) (resp interface{}, err error) {
 	defer handleCrash(func(r interface{}) {
 		log.Errorf("%+v\n \n %s", r, debug.Stack())
+		err = status.Errorf(codes.Internal, "panic: %v", r)
 	})
 
 	return handler(ctx, req)
@@ -0,0 +1,132 @@
+package serverinterceptors
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"emshop/gin-micro/core/idempotency"
+	"emshop/pkg/log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// IdempotencyConfig 幂等键拦截器配置
+type IdempotencyConfig struct {
+	Store idempotency.Store
+	// Methods 需要支持幂等键的完整方法名，如 /Payment/CreatePayment
+	Methods []string
+	// TTL 已完成响应的保存时间，LockTTL 处理中记录的过期时间
+	TTL     time.Duration
+	LockTTL time.Duration
+	// Scope 返回幂等键所属的调用方，不同调用方的相同键互不影响；
+	// 为空时使用元数据 idempotency-scope，未携带时归入 anonymous
+	Scope func(ctx context.Context) string
+}
+
+// UnaryIdempotencyInterceptor 按元数据 idempotency-key 对写方法去重
+//
+// 成功的响应保存 TTL，期间携带相同键和相同请求的重复调用直接返回保存的响应；
+// 请求不同时返回 AlreadyExists，首次调用仍在处理时返回 Aborted。
+// 返回错误的调用不保存，调用方可以使用同一个键重试。存储不可用时不做去重。
+func UnaryIdempotencyInterceptor(cfg *IdempotencyConfig) grpc.UnaryServerInterceptor {
+	scopeOf := cfg.Scope
+	if scopeOf == nil {
+		scopeOf = idempotency.ScopeFromIncomingContext
+	}
+	methods := make(map[string]bool, len(cfg.Methods))
+	for _, m := range cfg.Methods {
+		methods[m] = true
+	}
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		key := idempotency.FromIncomingContext(ctx)
+		msg, ok := req.(proto.Message)
+		if key == "" || !ok || !methods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+		if len(key) > idempotency.MaxKeyLength {
+			return nil, status.Errorf(codes.InvalidArgument, "%s must not exceed %d characters", idempotency.MetadataKey, idempotency.MaxKeyLength)
+		}
+
+		data, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+		if err != nil {
+			return handler(ctx, req)
+		}
+		fingerprint := idempotency.Fingerprint([]byte(info.FullMethod), data)
+		scope := scopeOf(ctx)
+		if scope == "" {
+			scope = "anonymous"
+		}
+		storeKey := fmt.Sprintf("%s:%s:%s", info.FullMethod, scope, key)
+
+		// 调用方取消不影响保存结果
+		storeCtx := context.WithoutCancel(ctx)
+		rec, err := cfg.Store.Begin(storeCtx, storeKey, fingerprint, cfg.LockTTL)
+		if err != nil {
+			log.Errorf("[idempotency] begin %s failed: %v", storeKey, err)
+			return handler(ctx, req)
+		}
+		if rec != nil {
+			return replayIdempotent(rec, fingerprint)
+		}
+
+		resp, err := handler(ctx, req)
+		if err == nil {
+			saveErr := completeIdempotent(storeCtx, cfg, storeKey, fingerprint, resp)
+			if saveErr == nil {
+				return resp, nil
+			}
+			log.Errorf("[idempotency] save response of %s failed: %v", storeKey, saveErr)
+		}
+		if relErr := cfg.Store.Release(storeCtx, storeKey); relErr != nil {
+			log.Errorf("[idempotency] release %s failed: %v", storeKey, relErr)
+		}
+		return resp, err
+	}
+}
+
+// completeIdempotent 以 Any 保存响应，重放时不需要知道响应类型
+func completeIdempotent(ctx context.Context, cfg *IdempotencyConfig, key, fingerprint string, resp interface{}) error {
+	msg, ok := resp.(proto.Message)
+	if !ok {
+		return fmt.Errorf("response %T is not a proto message", resp)
+	}
+	a, err := anypb.New(msg)
+	if err != nil {
+		return err
+	}
+	body, err := proto.Marshal(a)
+	if err != nil {
+		return err
+	}
+	return cfg.Store.Complete(ctx, key, &idempotency.Record{
+		Fingerprint: fingerprint,
+		Done:        true,
+		Status:      int(codes.OK),
+		Body:        body,
+	}, cfg.TTL)
+}
+
+// replayIdempotent 处理幂等键已存在的调用
+func replayIdempotent(rec *idempotency.Record, fingerprint string) (interface{}, error) {
+	if rec.Fingerprint != fingerprint {
+		return nil, status.Errorf(codes.AlreadyExists, "%s has already been used with a different request", idempotency.MetadataKey)
+	}
+	if !rec.Done {
+		return nil, status.Errorf(codes.Aborted, "a request with the same %s is still in progress", idempotency.MetadataKey)
+	}
+	var a anypb.Any
+	if err := proto.Unmarshal(rec.Body, &a); err != nil {
+		return nil, status.Errorf(codes.Internal, "decode saved response failed: %v", err)
+	}
+	resp, err := a.UnmarshalNew()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "decode saved response failed: %v", err)
+	}
+	return resp, nil
+}
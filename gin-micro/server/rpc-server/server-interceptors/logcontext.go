@@ -0,0 +1,84 @@
+package serverinterceptors
+
+import (
+	"context"
+
+	"emshop/pkg/log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// snFields 请求中自动写入日志上下文的单号字段，兼容两种命名方式
+var snFields = []struct {
+	key   string
+	names []protoreflect.Name
+}{
+	{log.KeyOrderSN, []protoreflect.Name{"order_sn", "orderSn"}},
+	{log.KeyPaymentSN, []protoreflect.Name{"payment_sn", "paymentSn"}},
+}
+
+// UnaryLogContextInterceptor 恢复调用方通过元数据传递的日志字段，并把请求中的订单号、支付单号写入日志上下文
+func UnaryLogContextInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	ctx = logContext(ctx)
+	if msg, ok := req.(proto.Message); ok {
+		ctx = log.WithValues(ctx, requestSNs(msg)...)
+	}
+	return handler(ctx, req)
+}
+
+// StreamLogContextInterceptor 恢复调用方通过元数据传递的日志字段
+func StreamLogContextInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	return handler(srv, &contextStream{ServerStream: stream, ctx: logContext(stream.Context())})
+}
+
+func logContext(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	keysAndValues := make([]string, 0, len(log.PropagatedKeys)*2)
+	for _, key := range log.PropagatedKeys {
+		if vals := md.Get(log.MetadataKey(key)); len(vals) > 0 {
+			keysAndValues = append(keysAndValues, key, vals[0])
+		}
+	}
+	if len(keysAndValues) == 0 {
+		return ctx
+	}
+	return log.WithValues(ctx, keysAndValues...)
+}
+
+// requestSNs 读取请求顶层的单号字段
+func requestSNs(msg proto.Message) []string {
+	m := msg.ProtoReflect()
+	fields := m.Descriptor().Fields()
+	var keysAndValues []string
+	for _, sn := range snFields {
+		for _, name := range sn.names {
+			fd := fields.ByName(name)
+			if fd == nil || fd.Kind() != protoreflect.StringKind || fd.IsList() {
+				continue
+			}
+			if v := m.Get(fd).String(); v != "" {
+				keysAndValues = append(keysAndValues, sn.key, v)
+			}
+			break
+		}
+	}
+	return keysAndValues
+}
+
+// contextStream 替换流的 context
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -34,6 +34,14 @@ var (
 		Help:      "rpc server requests code count.",
 		Labels:    []string{"method", "code"},
 	})
+
+	metricServerStreamMsgTotal = metric.NewCounterVec(&metric.CounterVecOpts{
+		Namespace: serverNamespace,
+		Subsystem: "streams",
+		Name:      "emshop_msg_total",
+		Help:      "rpc server stream messages count.",
+		Labels:    []string{"method", "direction"},
+	})
 )
 
 func UnaryPrometheusInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
@@ -49,3 +57,37 @@ func UnaryPrometheusInterceptor(ctx context.Context, req interface{}, info *grpc
 	metricServerReqCodeTotal.Inc(info.FullMethod, strconv.Itoa(int(status.Code(err))))
 	return resp, err 
 }
+
+// StreamPrometheusInterceptor 记录流式调用的整体耗时、状态码以及收发的消息数
+func StreamPrometheusInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+
+	startTime := time.Now()
+	err := handler(srv, &countingStream{ServerStream: stream, method: info.FullMethod})
+
+	metricServerReqDur.Observe(int64(time.Since(startTime)/time.Millisecond), info.FullMethod)
+	metricServerReqCodeTotal.Inc(info.FullMethod, strconv.Itoa(int(status.Code(err))))
+	return err
+}
+
+// countingStream 统计流上收发的消息数
+type countingStream struct {
+	grpc.ServerStream
+	method string
+}
+
+func (s *countingStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		metricServerStreamMsgTotal.Inc(s.method, "sent")
+	}
+	return err
+}
+
+func (s *countingStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		metricServerStreamMsgTotal.Inc(s.method, "received")
+	}
+	return err
+}
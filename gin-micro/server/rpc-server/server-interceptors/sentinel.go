@@ -160,17 +160,39 @@ func StreamSentinelInterceptor(cfg *SentinelConfig) grpc.StreamServerInterceptor
     if cfg == nil {
         cfg = &SentinelConfig{}
     }
+    if cfg.EnableMetrics && globalSentinelMetrics == nil {
+        initSentinelMetrics(cfg.ResourcePrefix)
+    }
     return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
         if cfg.ShouldProtect != nil && !cfg.ShouldProtect(info.FullMethod) {
             return handler(srv, stream)
         }
         resourceName := buildResourceName(cfg, info.FullMethod)
+
+        if globalSentinelMetrics != nil {
+            globalSentinelMetrics.totalCounter.Inc()
+        }
+        start := time.Now()
+
         entry, err := api.Entry(resourceName, api.WithTrafficType(base.Inbound))
         if err != nil {
+            if globalSentinelMetrics != nil {
+                globalSentinelMetrics.blockedCounter.Inc()
+            }
             log.Warnf("stream blocked by Sentinel: resource=%s, err=%v", resourceName, err)
             return status.Error(codes.ResourceExhausted, "服务繁忙，请稍后重试")
         }
-        defer entry.Exit()
+        defer func() {
+            entry.Exit()
+            if globalSentinelMetrics != nil {
+                globalSentinelMetrics.latencyHistogram.Observe(time.Since(start).Seconds())
+            }
+        }()
+
+        if globalSentinelMetrics != nil {
+            globalSentinelMetrics.passedCounter.Inc()
+        }
+
         hErr := handler(srv, stream)
         if hErr != nil {
             api.TraceError(entry, hErr)
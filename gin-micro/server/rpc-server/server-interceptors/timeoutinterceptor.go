@@ -59,3 +59,40 @@ func UnaryTimeoutInterceptor(timeout time.Duration) grpc.UnaryServerInterceptor
 		}
 	}
 }
+
+// StreamTimeoutInterceptor returns a func that sets timeout to incoming stream requests.
+// 流式调用的处理函数与流绑定在同一个协程，不能像 unary 一样在超时后提前返回，
+// 这里只给流的 context 设置截止时间，超时后收发消息直接返回 DeadlineExceeded，
+// 长时间运行的订阅类接口不应开启该拦截器
+func StreamTimeoutInterceptor(timeout time.Duration) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		ctx, cancel := context.WithTimeout(stream.Context(), timeout)
+		defer cancel()
+		return handler(srv, &timeoutStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// timeoutStream 替换流的 context，并在 context 结束后拒绝继续收发消息
+type timeoutStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *timeoutStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *timeoutStream) SendMsg(m interface{}) error {
+	if err := s.ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	return s.ServerStream.SendMsg(m)
+}
+
+func (s *timeoutStream) RecvMsg(m interface{}) error {
+	if err := s.ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	return s.ServerStream.RecvMsg(m)
+}
@@ -8,11 +8,13 @@ import (
 
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/health"
+	grpchealth "google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 
 	apimd "emshop/api/metadata"
+	"emshop/gin-micro/core/health"
+	"emshop/gin-micro/core/limit"
 	srvintc "emshop/gin-micro/server/rpc-server/server-interceptors"
 	"emshop/pkg/host"
 	"emshop/pkg/log"
@@ -27,13 +29,24 @@ type Server struct {
 	grpcOpts   []grpc.ServerOption            // gRPC服务器选项
 	lis        net.Listener                   // 监听器
 
-	timeout time.Duration // 超时时间, 用于设置请求的超时时间
+	timeout       time.Duration // 超时时间, 用于设置请求的超时时间
+	streamTimeout time.Duration // 流式请求的超时时间, 默认不限制
 
-	health   *health.Server // 健康检查服务
-	metadata *apimd.Server  // 元数据服务
-	endpoint *url.URL       // 服务地址
+	health   *grpchealth.Server // 健康检查服务
+	metadata *apimd.Server      // 元数据服务
+	endpoint *url.URL           // 服务地址
 
 	enableMetrics bool // 是否开启prometheus
+
+	limiter  *limit.Limiter       // 自适应并发限流器, 为空时不限流
+	priority srvintc.PriorityFunc // 请求优先级, 决定过载时的拒绝顺序
+
+	logAdminSecret string // 日志管理密钥, 不为空时注册日志管理服务
+
+	healthRegistry *health.Registry   // 依赖检查, 就绪状态决定grpc health的状态, 为空时一直是SERVING
+	healthInterval time.Duration      // 依赖检查的间隔
+	stopWatch      context.CancelFunc // 停止依赖检查
+	watchCtx       context.Context
 }
 
 // 函数选项模式
@@ -42,18 +55,23 @@ type ServerOption func(o *Server)
 func NewServer(opts ...ServerOption) *Server {
 	srv := &Server{
 		address: ":0",
-		health:  health.NewServer(),
+		health:  grpchealth.NewServer(),
 		timeout: 1 * time.Second,
+
+		healthRegistry: health.Default(),
+		healthInterval: 5 * time.Second,
 	}
 	// 根据传入函数设置参数
 	for _, o := range opts {
 		o(srv)
 	}
+	srv.watchCtx, srv.stopWatch = context.WithCancel(context.Background())
 
 	//不设置拦截器的情况下，自动默认加上一些必须的拦截器，如 crash，tracing
 	unaryInts := []grpc.UnaryServerInterceptor{
 		srvintc.UnaryCrashInterceptor,
 		otelgrpc.UnaryServerInterceptor(),
+		srvintc.UnaryLogContextInterceptor,
 	}
 	// 如果用户传入了自定义的Unary拦截器，则添加到unaryInts中
 
@@ -62,6 +80,11 @@ func NewServer(opts ...ServerOption) *Server {
 		unaryInts = append(unaryInts, srvintc.UnaryPrometheusInterceptor)
 	}
 
+	// 自适应限流放在超时之前，被拒绝的请求也会计入prometheus
+	if srv.limiter != nil {
+		unaryInts = append(unaryInts, srvintc.UnaryAdaptiveLimitInterceptor(srv.limiter, srv.priority))
+	}
+
 	if srv.timeout > 0 {
 		unaryInts = append(unaryInts, srvintc.UnaryTimeoutInterceptor(srv.timeout))
 	}
@@ -73,10 +96,40 @@ func NewServer(opts ...ServerOption) *Server {
 	// 保存最终拦截器链，供后续链式调用
 	srv.unaryInts = unaryInts
 
+	// Stream拦截器链，顺序与Unary保持一致
+	streamInts := []grpc.StreamServerInterceptor{
+		srvintc.StreamCrashInterceptor,
+		otelgrpc.StreamServerInterceptor(),
+		srvintc.StreamLogContextInterceptor,
+	}
+
+	if srv.enableMetrics {
+		streamInts = append(streamInts, srvintc.StreamPrometheusInterceptor)
+	}
+
+	if srv.limiter != nil {
+		streamInts = append(streamInts, srvintc.StreamAdaptiveLimitInterceptor(srv.limiter, srv.priority))
+	}
+
+	// 流式请求通常是长连接，只有显式设置时才限制时长
+	if srv.streamTimeout > 0 {
+		streamInts = append(streamInts, srvintc.StreamTimeoutInterceptor(srv.streamTimeout))
+	}
+
+	if len(srv.streamInts) > 0 {
+		streamInts = append(streamInts, srv.streamInts...)
+	}
+
+	srv.streamInts = streamInts
+
 	//把传入的拦截器转换成grpc的ServerOption
-	grpcOpts := []grpc.ServerOption{grpc.ChainUnaryInterceptor(srv.unaryInts...)}
+	grpcOpts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(srv.unaryInts...),
+		grpc.ChainStreamInterceptor(srv.streamInts...),
+	}
 
-	//把用户自己传入的grpc.ServerOption放在一起
+	//把用户自己传入的grpc.ServerOption放在一起，如keepalive、消息大小限制、TLS证书等
+	grpcOpts = append(grpcOpts, srv.grpcOpts...)
 	srv.Server = grpc.NewServer(grpcOpts...)
 
 	//注册metadata的Server
@@ -95,6 +148,11 @@ func NewServer(opts ...ServerOption) *Server {
 	// 这个服务会自动注册到grpc的Server中
 	// 可以支持用户直接通过grpc的一个接口查看当前支持的所有的rpc服务
 	apimd.RegisterMetadataServer(srv.Server, srv.metadata)
+
+	// 注册日志管理服务，运行时修改日志级别
+	if srv.logAdminSecret != "" {
+		srv.Server.RegisterService(&logAdminServiceDesc, &logAdminServer{secret: srv.logAdminSecret})
+	}
 	// 注册反射服务,允许客户端（如 grpcurl、Postman、grpcui 等工具）在不知道 proto 文件的情况下，动态查询服务支持的所有 RPC 方法和消息类型
 	reflection.Register(srv.Server)
 
@@ -119,27 +177,62 @@ func WithTimeout(timeout time.Duration) ServerOption {
 	}
 }
 
+// WithStreamTimeout 设置流式请求的超时时间，处理函数需要响应 context 的取消
+func WithStreamTimeout(timeout time.Duration) ServerOption {
+	return func(s *Server) {
+		s.streamTimeout = timeout
+	}
+}
+
+// WithAdaptiveLimit 开启自适应并发限流，priority 为空时只有健康检查不受限流影响
+func WithAdaptiveLimit(l *limit.Limiter, priority srvintc.PriorityFunc) ServerOption {
+	return func(s *Server) {
+		s.limiter = l
+		s.priority = priority
+	}
+}
+
+// WithHealthRegistry 设置依赖检查的注册表和检查间隔，默认使用 health.Default()，为空时不检查依赖
+func WithHealthRegistry(r *health.Registry, interval time.Duration) ServerOption {
+	return func(s *Server) {
+		s.healthRegistry = r
+		if interval > 0 {
+			s.healthInterval = interval
+		}
+	}
+}
+
+// WithLogAdmin 注册日志管理服务，secret 为空时不注册
+func WithLogAdmin(secret string) ServerOption {
+	return func(s *Server) {
+		s.logAdminSecret = secret
+	}
+}
+
 func WithLis(lis net.Listener) ServerOption {
 	return func(s *Server) {
 		s.lis = lis
 	}
 }
 
+// WithUnaryInterceptor 追加自定义Unary拦截器，在默认拦截器之后执行
 func WithUnaryInterceptor(in ...grpc.UnaryServerInterceptor) ServerOption {
 	return func(s *Server) {
-		s.unaryInts = in
+		s.unaryInts = append(s.unaryInts, in...)
 	}
 }
 
+// WithStreamInterceptor 追加自定义Stream拦截器，在默认拦截器之后执行
 func WithStreamInterceptor(in ...grpc.StreamServerInterceptor) ServerOption {
 	return func(s *Server) {
-		s.streamInts = in
+		s.streamInts = append(s.streamInts, in...)
 	}
 }
 
+// WithOptions 追加gRPC服务器选项，如 grpc.KeepaliveParams、grpc.MaxRecvMsgSize、grpc.Creds
 func WithOptions(opts ...grpc.ServerOption) ServerOption {
 	return func(s *Server) {
-		s.grpcOpts = opts
+		s.grpcOpts = append(s.grpcOpts, opts...)
 	}
 }
 
@@ -173,10 +266,27 @@ func (s *Server) Address() string {
 func (s *Server) Start(ctx context.Context) error {
 	log.Infof("[grpc] server listening on: %s", s.lis.Addr().String())
 	s.health.Resume()
+	if s.healthRegistry != nil {
+		go s.watchReadiness()
+	}
 	return s.Serve(s.lis)
 }
 
+// watchReadiness 按依赖的就绪状态切换grpc health的状态，Consul的gRPC检查和调用方据此摘除实例
+func (s *Server) watchReadiness() {
+	s.healthRegistry.Watch(s.watchCtx, s.healthInterval, func(report *health.Report) {
+		if report.Ready() {
+			log.Infof("[grpc] dependencies are ready, health status SERVING")
+			s.health.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+			return
+		}
+		log.Warnf("[grpc] dependencies are not ready, health status NOT_SERVING: %s", report.Error())
+		s.health.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	})
+}
+
 func (s *Server) Stop(ctx context.Context) error {
+	s.stopWatch()
 	//设置服务的状态为not_serving，防止接收新的请求过来
 	s.health.Shutdown()
 	s.GracefulStop()
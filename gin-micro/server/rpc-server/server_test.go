@@ -0,0 +1,189 @@
+package rpcserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"emshop/gin-micro/core/health"
+	"emshop/gin-micro/core/limit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// countHandler 按请求字符串的长度连续推送消息，请求以 panic 开头时触发 panic，每条消息间隔 interval
+func countHandler(interval time.Duration) grpc.StreamHandler {
+	return func(srv interface{}, stream grpc.ServerStream) error {
+		req := &wrapperspb.StringValue{}
+		if err := stream.RecvMsg(req); err != nil {
+			return err
+		}
+		n := len(req.Value)
+		if strings.HasPrefix(req.Value, "panic") {
+			panic("boom")
+		}
+		for i := 0; i < n; i++ {
+			if i > 0 {
+				time.Sleep(interval)
+			}
+			if err := stream.SendMsg(wrapperspb.Int32(int32(i))); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func newStreamServer(t *testing.T, interval time.Duration, opts ...ServerOption) *grpc.ClientConn {
+	t.Helper()
+	opts = append([]ServerOption{WithAddress("127.0.0.1:0"), WithMetrics(true)}, opts...)
+	srv := NewServer(opts...)
+	srv.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "test.Counter",
+		HandlerType: (*interface{})(nil),
+		Streams: []grpc.StreamDesc{{
+			StreamName:    "Count",
+			Handler:       countHandler(interval),
+			ServerStreams: true,
+		}},
+	}, struct{}{})
+	go func() { _ = srv.Start(context.Background()) }()
+	t.Cleanup(func() { _ = srv.Stop(context.Background()) })
+
+	conn, err := grpc.NewClient(srv.lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+// count 发起流式调用并读取所有消息
+func count(conn *grpc.ClientConn, value string) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, "/test.Counter/Count")
+	if err != nil {
+		return 0, err
+	}
+	if err := stream.SendMsg(wrapperspb.String(value)); err != nil {
+		return 0, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return 0, err
+	}
+	received := 0
+	for {
+		if err := stream.RecvMsg(&wrapperspb.Int32Value{}); err != nil {
+			if err == io.EOF {
+				return received, nil
+			}
+			return received, err
+		}
+		received++
+	}
+}
+
+func TestServerStreamChain(t *testing.T) {
+	var calls int32
+	custom := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		atomic.AddInt32(&calls, 1)
+		assert.Equal(t, "/test.Counter/Count", info.FullMethod)
+		return handler(srv, ss)
+	}
+	conn := newStreamServer(t, 0, WithStreamInterceptor(custom))
+
+	n, err := count(conn, "abc")
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+
+	// panic 被 crash 拦截器转换为 Internal，服务继续可用
+	_, err = count(conn, "panic")
+	assert.Equal(t, codes.Internal, status.Code(err))
+	n, err = count(conn, "ab")
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+}
+
+func TestServerStreamTimeout(t *testing.T) {
+	conn := newStreamServer(t, 50*time.Millisecond, WithStreamTimeout(120*time.Millisecond))
+
+	n, err := count(conn, "abcdefgh")
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Less(t, n, 8)
+
+	// 在超时前完成的流不受影响
+	n, err = count(conn, "ab")
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+}
+
+func TestServerOptionsApplied(t *testing.T) {
+	conn := newStreamServer(t, 0, WithOptions(grpc.MaxRecvMsgSize(16)))
+
+	_, err := count(conn, strings.Repeat("x", 64))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	n, err := count(conn, "abc")
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+}
+
+func TestServerAdaptiveLimit(t *testing.T) {
+	l := limit.NewLimiter(limit.Options{InitialLimit: 1, MinLimit: 1, MaxLimit: 1})
+	conn := newStreamServer(t, 50*time.Millisecond, WithAdaptiveLimit(l, nil))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := count(conn, "abcd")
+		done <- err
+	}()
+	require.Eventually(t, func() bool { return l.Inflight() == 1 }, time.Second, 5*time.Millisecond)
+
+	// 并发已满，普通请求被拒绝，健康检查不受影响
+	_, err := count(conn, "a")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.Status)
+
+	require.NoError(t, <-done)
+	assert.Equal(t, 0, l.Inflight())
+	n, err := count(conn, "ab")
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+}
+
+func TestServerReadiness(t *testing.T) {
+	reg := health.NewRegistry()
+	var healthy atomic.Bool
+	reg.Register("mysql", func(ctx context.Context) error {
+		if healthy.Load() {
+			return nil
+		}
+		return errors.New("connection refused")
+	})
+	conn := newStreamServer(t, 0, WithHealthRegistry(reg, 10*time.Millisecond))
+	client := grpc_health_v1.NewHealthClient(conn)
+	status := func() grpc_health_v1.HealthCheckResponse_ServingStatus {
+		resp, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+		if err != nil {
+			return grpc_health_v1.HealthCheckResponse_UNKNOWN
+		}
+		return resp.GetStatus()
+	}
+
+	assert.Eventually(t, func() bool { return status() == grpc_health_v1.HealthCheckResponse_NOT_SERVING }, time.Second, 10*time.Millisecond)
+	healthy.Store(true)
+	assert.Eventually(t, func() bool { return status() == grpc_health_v1.HealthCheckResponse_SERVING }, time.Second, 10*time.Millisecond)
+}
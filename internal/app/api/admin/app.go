@@ -8,13 +8,12 @@ import (
     "emshop/gin-micro/server/rpc-server/selector/p2c"
     "emshop/internal/app/api/admin/config"
     "emshop/internal/app/pkg/options"
+    "emshop/internal/app/pkg/discovery"
     "emshop/pkg/app"
     "emshop/pkg/log"
 
-	"github.com/hashicorp/consul/api"
 
 	"emshop/gin-micro/registry"
-	"emshop/gin-micro/registry/consul"
 )
 
 func NewApp(basename string) *app.App {
@@ -28,26 +27,10 @@ func NewApp(basename string) *app.App {
 }
 
 func NewRegistrar(registry *options.RegistryOptions, dev bool) registry.Registrar {
-    c := api.DefaultConfig()
-    c.Address = registry.Address
-    c.Scheme = registry.Scheme
-    cli, err := api.NewClient(c)
+    r, err := discovery.NewRegistrar(registry, dev)
     if err != nil {
         panic(err)
     }
-    opts := []consul.Option{consul.WithHealthCheck(true)}
-    if registry.HealthCheckInterval > 0 {
-        opts = append(opts, consul.WithHealthCheckInterval(registry.HealthCheckInterval))
-    }
-    if registry.CheckTimeout > 0 {
-        opts = append(opts, consul.WithCheckTimeout(registry.CheckTimeout))
-    }
-    if dev {
-        opts = append(opts, consul.WithDeregisterCriticalServiceAfter(60))
-    } else if registry.DeregisterCriticalAfter > 0 {
-        opts = append(opts, consul.WithDeregisterCriticalServiceAfter(registry.DeregisterCriticalAfter))
-    }
-    r := consul.New(cli, opts...)
     return r
 }
 
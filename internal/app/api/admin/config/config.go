@@ -14,6 +14,9 @@ type Config struct {
 	MySQL     *options.MySQLOptions     `json:"mysql" mapstructure:"mysql"`
 	Jwt       *options.JwtOptions       `json:"jwt" mapstructure:"jwt"`
 	Telemetry *options.TelemetryOptions `json:"telemetry" mapstructure:"telemetry"`
+	Redis     *options.RedisOptions     `json:"redis" mapstructure:"redis"`
+	DelayJob  *options.DelayJobOptions  `json:"delayjob" mapstructure:"delayjob"`
+	DLQ       *options.DLQOptions       `json:"dlq" mapstructure:"dlq"`
 }
 
 func (c *Config) Validate() []error {
@@ -24,6 +27,9 @@ func (c *Config) Validate() []error {
 	errors = append(errors, c.MySQL.Validate()...)
 	errors = append(errors, c.Jwt.Validate()...)
 	errors = append(errors, c.Telemetry.Validate()...)
+	errors = append(errors, c.Redis.Validate()...)
+	errors = append(errors, c.DelayJob.Validate()...)
+	errors = append(errors, c.DLQ.Validate()...)
 	return errors
 }
 
@@ -34,6 +40,9 @@ func (c *Config) Flags() (fss cliflag.NamedFlagSets) {
 	c.MySQL.AddFlags(fss.FlagSet("mysql"))
 	c.Jwt.AddFlags(fss.FlagSet("jwt"))
 	c.Telemetry.AddFlags(fss.FlagSet("telemetry"))
+	c.Redis.AddFlags(fss.FlagSet("redis"))
+	c.DelayJob.AddFlags(fss.FlagSet("delayjob"))
+	c.DLQ.AddFlags(fss.FlagSet("dlq"))
 	return fss
 }
 
@@ -46,5 +55,8 @@ func New() *Config {
 		MySQL:     options.NewMySQLOptions(),
 		Jwt:       options.NewJwtOptions(),
 		Telemetry: options.NewTelemetryOptions(),
+		Redis:     options.NewRedisOptions(),
+		DelayJob:  options.NewDelayJobOptions(),
+		DLQ:       options.NewDLQOptions(),
 	}
 }
@@ -37,7 +37,7 @@ func (ac *analyticsController) GetGoodsOverview(ctx *gin.Context) {
 
 	response, err := ac.sf.Goods().GetGoodsList(ctx, request)
 	if err != nil {
-		log.Errorf("Failed to get goods list for analytics: %v", err)
+		log.ErrorfC(ctx, "Failed to get goods list for analytics: %v", err)
 		core.WriteResponse(ctx, err, nil)
 		return
 	}
@@ -126,7 +126,7 @@ func (ac *analyticsController) GetTopSellingGoods(ctx *gin.Context) {
 
 	response, err := ac.sf.Goods().GetGoodsList(ctx, request)
 	if err != nil {
-		log.Errorf("Failed to get goods list for top selling analytics: %v", err)
+		log.ErrorfC(ctx, "Failed to get goods list for top selling analytics: %v", err)
 		core.WriteResponse(ctx, err, nil)
 		return
 	}
@@ -175,7 +175,7 @@ func (ac *analyticsController) GetCategoryStats(ctx *gin.Context) {
 
 	goodsResponse, err := ac.sf.Goods().GetGoodsList(ctx, goodsRequest)
 	if err != nil {
-		log.Errorf("Failed to get goods list for category analytics: %v", err)
+		log.ErrorfC(ctx, "Failed to get goods list for category analytics: %v", err)
 		core.WriteResponse(ctx, err, nil)
 		return
 	}
@@ -267,7 +267,7 @@ func (ac *analyticsController) GetInventoryAlerts(ctx *gin.Context) {
 
 	response, err := ac.sf.Goods().GetGoodsList(ctx, request)
 	if err != nil {
-		log.Errorf("Failed to get goods list for inventory alerts: %v", err)
+		log.ErrorfC(ctx, "Failed to get goods list for inventory alerts: %v", err)
 		core.WriteResponse(ctx, err, nil)
 		return
 	}
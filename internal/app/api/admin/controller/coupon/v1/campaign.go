@@ -0,0 +1,197 @@
+package coupon
+
+import (
+    "strconv"
+
+    cpbv1 "emshop/api/coupon/v1"
+    "emshop/gin-micro/code"
+    admincontroller "emshop/internal/app/api/admin/controller"
+    "emshop/internal/app/api/admin/domain/dto/request"
+    couponsrv "emshop/internal/app/api/admin/service/coupon/v1"
+    gin2 "emshop/internal/app/pkg/translator/gin"
+    "emshop/pkg/common/core"
+    "emshop/pkg/errors"
+
+    "github.com/gin-gonic/gin"
+)
+
+// CreateCampaign 创建定向发券活动并圈选目标用户
+func (cc *couponController) CreateCampaign(ctx *gin.Context) {
+    var r request.CreateCouponCampaignRequest
+    if err := ctx.ShouldBindJSON(&r); err != nil {
+        gin2.HandleValidatorError(ctx, err, cc.trans)
+        return
+    }
+
+    segment := &couponsrv.CampaignSegment{
+        Type:           r.SegmentType,
+        UserIDs:        r.UserIDs,
+        RegisteredFrom: r.RegisteredFrom,
+        RegisteredTo:   r.RegisteredTo,
+        InactiveDays:   r.InactiveDays,
+        CategoryID:     r.CategoryID,
+    }
+    resp, err := cc.srv.Coupon().CreateCampaign(ctx, &cpbv1.CreateCouponCampaignRequest{
+        Name:                 r.Name,
+        CouponTemplateId:     r.CouponTemplateID,
+        BatchSize:            r.BatchSize,
+        BatchIntervalSeconds: r.BatchIntervalSeconds,
+        Operator:             admincontroller.Operator(ctx),
+    }, segment)
+    if err != nil {
+        core.WriteResponse(ctx, err, nil)
+        return
+    }
+    core.WriteResponse(ctx, nil, campaignToMap(resp))
+}
+
+// UploadCampaignTargets 上传CSV追加目标用户，每行第一列为用户ID
+func (cc *couponController) UploadCampaignTargets(ctx *gin.Context) {
+    id, ok := campaignIDOf(ctx)
+    if !ok {
+        return
+    }
+    file, _, err := ctx.Request.FormFile("file")
+    if err != nil {
+        core.WriteResponse(ctx, errors.WithCode(code.ErrValidation, "请上传用户ID文件"), nil)
+        return
+    }
+    defer file.Close()
+
+    userIDs, err := couponsrv.ParseUserIDCSV(file)
+    if err != nil {
+        core.WriteResponse(ctx, err, nil)
+        return
+    }
+    added, err := cc.srv.Coupon().AddCampaignTargets(ctx, id, userIDs)
+    if err != nil {
+        core.WriteResponse(ctx, err, nil)
+        return
+    }
+    core.WriteResponse(ctx, nil, gin.H{
+        "parsed": len(userIDs),
+        "added":  added,
+    })
+}
+
+// ListCampaigns 分页查询发券活动
+func (cc *couponController) ListCampaigns(ctx *gin.Context) {
+    var r request.ListCouponCampaignsRequest
+    if err := ctx.ShouldBindQuery(&r); err != nil {
+        gin2.HandleValidatorError(ctx, err, cc.trans)
+        return
+    }
+    if r.Page <= 0 {
+        r.Page = 1
+    }
+    if r.PageSize <= 0 {
+        r.PageSize = 20
+    }
+
+    resp, err := cc.srv.Coupon().ListCampaigns(ctx, &cpbv1.ListCouponCampaignsRequest{
+        Status:   r.Status,
+        Page:     r.Page,
+        PageSize: r.PageSize,
+    })
+    if err != nil {
+        core.WriteResponse(ctx, err, nil)
+        return
+    }
+
+    items := make([]gin.H, 0, len(resp.Items))
+    for _, item := range resp.Items {
+        items = append(items, campaignToMap(item))
+    }
+    core.WriteResponse(ctx, nil, gin.H{
+        "total": resp.TotalCount,
+        "items": items,
+    })
+}
+
+// GetCampaign 查询活动及发放进度
+func (cc *couponController) GetCampaign(ctx *gin.Context) {
+    id, ok := campaignIDOf(ctx)
+    if !ok {
+        return
+    }
+    resp, err := cc.srv.Coupon().GetCampaign(ctx, id)
+    if err != nil {
+        core.WriteResponse(ctx, err, nil)
+        return
+    }
+    core.WriteResponse(ctx, nil, campaignToMap(resp))
+}
+
+// StartCampaign 开始发放，也用于恢复已暂停的活动
+func (cc *couponController) StartCampaign(ctx *gin.Context) {
+    id, ok := campaignIDOf(ctx)
+    if !ok {
+        return
+    }
+    core.WriteResponse(ctx, cc.srv.Coupon().StartCampaign(ctx, id), nil)
+}
+
+// PauseCampaign 暂停发放
+func (cc *couponController) PauseCampaign(ctx *gin.Context) {
+    id, ok := campaignIDOf(ctx)
+    if !ok {
+        return
+    }
+    core.WriteResponse(ctx, cc.srv.Coupon().PauseCampaign(ctx, id), nil)
+}
+
+// CancelCampaign 取消活动
+func (cc *couponController) CancelCampaign(ctx *gin.Context) {
+    id, ok := campaignIDOf(ctx)
+    if !ok {
+        return
+    }
+    core.WriteResponse(ctx, cc.srv.Coupon().CancelCampaign(ctx, id), nil)
+}
+
+// RerunCampaign 重新发放失败的目标
+func (cc *couponController) RerunCampaign(ctx *gin.Context) {
+    id, ok := campaignIDOf(ctx)
+    if !ok {
+        return
+    }
+    reset, err := cc.srv.Coupon().RerunCampaign(ctx, id)
+    if err != nil {
+        core.WriteResponse(ctx, err, nil)
+        return
+    }
+    core.WriteResponse(ctx, nil, gin.H{"reset": reset})
+}
+
+// campaignIDOf 解析路径中的活动ID，无效时直接写入错误响应
+func campaignIDOf(ctx *gin.Context) (int64, bool) {
+    id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+    if err != nil || id <= 0 {
+        core.WriteResponse(ctx, errors.WithCode(code.ErrValidation, "活动ID无效"), nil)
+        return 0, false
+    }
+    return id, true
+}
+
+func campaignToMap(c *cpbv1.CouponCampaignResponse) gin.H {
+    return gin.H{
+        "id":                     c.Id,
+        "name":                   c.Name,
+        "coupon_template_id":     c.CouponTemplateId,
+        "segment_type":           c.SegmentType,
+        "segment_params":         c.SegmentParams,
+        "status":                 c.Status,
+        "batch_size":             c.BatchSize,
+        "batch_interval_seconds": c.BatchIntervalSeconds,
+        "total_targets":          c.TotalTargets,
+        "pending_count":          c.PendingCount,
+        "dispatched_count":       c.DispatchedCount,
+        "issued_count":           c.IssuedCount,
+        "skipped_count":          c.SkippedCount,
+        "failed_count":           c.FailedCount,
+        "operator":               c.Operator,
+        "started_at":             c.StartedAt,
+        "finished_at":            c.FinishedAt,
+        "created_at":             c.CreatedAt,
+    }
+}
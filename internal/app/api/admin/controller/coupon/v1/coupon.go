@@ -1,12 +1,15 @@
 package coupon
 
 import (
+    "encoding/json"
     "net/http"
     "time"
 
     restserver "emshop/gin-micro/server/rest-server"
     cpbv1 "emshop/api/coupon/v1"
+    "emshop/internal/app/api/admin/domain/dto/request"
     "emshop/internal/app/api/admin/service"
+    gin2 "emshop/internal/app/pkg/translator/gin"
     "emshop/pkg/common/core"
 
     "github.com/gin-gonic/gin"
@@ -76,3 +79,62 @@ func (cc *couponController) EnsureDefaultTemplate(ctx *gin.Context) {
     })
 }
 
+// GetRules 获取当前生效的优惠券业务规则参数
+func (cc *couponController) GetRules(ctx *gin.Context) {
+    rules, err := cc.srv.Coupon().GetRuleConfig(ctx)
+    if err != nil {
+        core.WriteResponse(ctx, err, nil)
+        return
+    }
+    core.WriteResponse(ctx, nil, gin.H{"rules": json.RawMessage(rules)})
+}
+
+// UpdateRules 更新优惠券业务规则参数，保存后各副本通过coupon_configs变更热加载
+func (cc *couponController) UpdateRules(ctx *gin.Context) {
+    var r request.UpdateCouponRulesRequest
+    if err := ctx.ShouldBindJSON(&r); err != nil {
+        gin2.HandleValidatorError(ctx, err, cc.trans)
+        return
+    }
+
+    rules, err := cc.srv.Coupon().UpdateRuleConfig(ctx, string(r.Rules))
+    if err != nil {
+        core.WriteResponse(ctx, err, nil)
+        return
+    }
+    core.WriteResponse(ctx, nil, gin.H{"rules": json.RawMessage(rules)})
+}
+
+// DryRunRules 使用候选规则参数试算样例订单，不影响生效中的规则
+func (cc *couponController) DryRunRules(ctx *gin.Context) {
+    var r request.DryRunCouponRulesRequest
+    if err := ctx.ShouldBindJSON(&r); err != nil {
+        gin2.HandleValidatorError(ctx, err, cc.trans)
+        return
+    }
+
+    resp, err := cc.srv.Coupon().DryRunRules(ctx, &cpbv1.DryRunCouponRulesRequest{
+        Rules:             string(r.Rules),
+        UserId:            r.UserID,
+        OrderAmount:       r.OrderAmount,
+        CouponTemplateIds: r.CouponTemplateIDs,
+        At:                r.At,
+    })
+    if err != nil {
+        core.WriteResponse(ctx, err, nil)
+        return
+    }
+
+    results := make([]gin.H, 0, len(resp.Results))
+    for _, item := range resp.Results {
+        results = append(results, gin.H{
+            "rule":   item.Rule,
+            "passed": item.Passed,
+            "reason": item.Reason,
+        })
+    }
+    core.WriteResponse(ctx, nil, gin.H{
+        "passed":  resp.Passed,
+        "results": results,
+    })
+}
@@ -0,0 +1,206 @@
+package coupon
+
+import (
+    "encoding/csv"
+    "fmt"
+    "strconv"
+    "time"
+
+    cpbv1 "emshop/api/coupon/v1"
+    "emshop/gin-micro/code"
+    admincontroller "emshop/internal/app/api/admin/controller"
+    "emshop/internal/app/api/admin/domain/dto/request"
+    gin2 "emshop/internal/app/pkg/translator/gin"
+    "emshop/pkg/common/core"
+    "emshop/pkg/errors"
+    "emshop/pkg/log"
+
+    "github.com/gin-gonic/gin"
+)
+
+// 导出时每次拉取的兑换码数量
+const promoCodeExportPageSize = 1000
+
+// GeneratePromoCodes 为模板批量生成一次性兑换码
+func (cc *couponController) GeneratePromoCodes(ctx *gin.Context) {
+    var r request.GeneratePromoCodesRequest
+    if err := ctx.ShouldBindJSON(&r); err != nil {
+        gin2.HandleValidatorError(ctx, err, cc.trans)
+        return
+    }
+
+    resp, err := cc.srv.Coupon().GeneratePromoCodes(ctx, &cpbv1.GeneratePromoCodesRequest{
+        CouponTemplateId: r.CouponTemplateID,
+        Count:            r.Count,
+        Prefix:           r.Prefix,
+        Operator:         admincontroller.Operator(ctx),
+    })
+    if err != nil {
+        core.WriteResponse(ctx, err, nil)
+        return
+    }
+    core.WriteResponse(ctx, nil, gin.H{
+        "batch_no": resp.BatchNo,
+        "count":    len(resp.Codes),
+        "codes":    resp.Codes,
+    })
+}
+
+// CreatePublicPromoCode 创建公开兑换码
+func (cc *couponController) CreatePublicPromoCode(ctx *gin.Context) {
+    var r request.CreatePublicPromoCodeRequest
+    if err := ctx.ShouldBindJSON(&r); err != nil {
+        gin2.HandleValidatorError(ctx, err, cc.trans)
+        return
+    }
+
+    resp, err := cc.srv.Coupon().CreatePublicPromoCode(ctx, &cpbv1.CreatePublicPromoCodeRequest{
+        CouponTemplateId: r.CouponTemplateID,
+        Code:             r.Code,
+        MaxRedemptions:   r.MaxRedemptions,
+        Operator:         admincontroller.Operator(ctx),
+    })
+    if err != nil {
+        core.WriteResponse(ctx, err, nil)
+        return
+    }
+    core.WriteResponse(ctx, nil, promoCodeToMap(resp))
+}
+
+// ListPromoCodes 分页查询兑换码
+func (cc *couponController) ListPromoCodes(ctx *gin.Context) {
+    var r request.ListPromoCodesRequest
+    if err := ctx.ShouldBindQuery(&r); err != nil {
+        gin2.HandleValidatorError(ctx, err, cc.trans)
+        return
+    }
+    if r.Page <= 0 {
+        r.Page = 1
+    }
+    if r.PageSize <= 0 {
+        r.PageSize = 20
+    }
+
+    resp, err := cc.srv.Coupon().ListPromoCodes(ctx, &cpbv1.ListPromoCodesRequest{
+        CouponTemplateId: r.CouponTemplateID,
+        BatchNo:          r.BatchNo,
+        Page:             r.Page,
+        PageSize:         r.PageSize,
+    })
+    if err != nil {
+        core.WriteResponse(ctx, err, nil)
+        return
+    }
+
+    items := make([]gin.H, 0, len(resp.Items))
+    for _, item := range resp.Items {
+        items = append(items, promoCodeToMap(item))
+    }
+    core.WriteResponse(ctx, nil, gin.H{
+        "total": resp.TotalCount,
+        "items": items,
+    })
+}
+
+// ExportPromoCodes 按模板或批次导出兑换码CSV
+func (cc *couponController) ExportPromoCodes(ctx *gin.Context) {
+    var r request.ListPromoCodesRequest
+    if err := ctx.ShouldBindQuery(&r); err != nil {
+        gin2.HandleValidatorError(ctx, err, cc.trans)
+        return
+    }
+    if r.CouponTemplateID <= 0 && r.BatchNo == "" {
+        core.WriteResponse(ctx, errors.WithCode(code.ErrValidation, "coupon_template_id和batch_no至少指定一个"), nil)
+        return
+    }
+
+    // 先取第一页，出错时还能返回JSON错误
+    listReq := &cpbv1.ListPromoCodesRequest{
+        CouponTemplateId: r.CouponTemplateID,
+        BatchNo:          r.BatchNo,
+        Page:             1,
+        PageSize:         promoCodeExportPageSize,
+    }
+    resp, err := cc.srv.Coupon().ListPromoCodes(ctx, listReq)
+    if err != nil {
+        core.WriteResponse(ctx, err, nil)
+        return
+    }
+
+    filename := fmt.Sprintf("promo_codes_%s.csv", time.Now().Format("20060102_150405"))
+    if r.BatchNo != "" {
+        filename = fmt.Sprintf("promo_codes_%s.csv", r.BatchNo)
+    }
+    ctx.Header("Content-Type", "text/csv")
+    ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+
+    writer := csv.NewWriter(ctx.Writer)
+    defer writer.Flush()
+
+    headers := []string{"兑换码", "优惠券模板ID", "批次号", "类型", "最大兑换次数", "已兑换次数", "状态", "创建人", "创建时间"}
+    if err := writer.Write(headers); err != nil {
+        log.ErrorfC(ctx, "Failed to write CSV headers: %v", err)
+        return
+    }
+
+    written := 0
+    for {
+        for _, item := range resp.Items {
+            record := []string{
+                item.Code,
+                strconv.FormatInt(item.CouponTemplateId, 10),
+                item.BatchNo,
+                strconv.Itoa(int(item.Kind)),
+                strconv.Itoa(int(item.MaxRedemptions)),
+                strconv.Itoa(int(item.RedeemedCount)),
+                strconv.Itoa(int(item.Status)),
+                item.Operator,
+                time.Unix(item.CreatedAt, 0).Format("2006-01-02 15:04:05"),
+            }
+            if err := writer.Write(record); err != nil {
+                log.ErrorfC(ctx, "Failed to write CSV record: %v", err)
+                return
+            }
+        }
+        written += len(resp.Items)
+        if len(resp.Items) < promoCodeExportPageSize || int64(written) >= resp.TotalCount {
+            break
+        }
+
+        writer.Flush()
+        listReq.Page++
+        resp, err = cc.srv.Coupon().ListPromoCodes(ctx, listReq)
+        if err != nil {
+            // 响应头已发送，只能中断输出
+            log.ErrorfC(ctx, "[admin] Export promo codes aborted at page %d: %v", listReq.Page, err)
+            return
+        }
+    }
+
+    log.InfofC(ctx, "Exported %d promo codes to CSV", written)
+}
+
+// DisablePromoCode 停用兑换码
+func (cc *couponController) DisablePromoCode(ctx *gin.Context) {
+    if err := cc.srv.Coupon().DisablePromoCode(ctx, ctx.Param("code")); err != nil {
+        core.WriteResponse(ctx, err, nil)
+        return
+    }
+    core.WriteResponse(ctx, nil, nil)
+}
+
+
+func promoCodeToMap(p *cpbv1.PromoCodeResponse) gin.H {
+    return gin.H{
+        "id":                 p.Id,
+        "code":               p.Code,
+        "coupon_template_id": p.CouponTemplateId,
+        "batch_no":           p.BatchNo,
+        "kind":               p.Kind,
+        "max_redemptions":    p.MaxRedemptions,
+        "redeemed_count":     p.RedeemedCount,
+        "status":             p.Status,
+        "operator":           p.Operator,
+        "created_at":         p.CreatedAt,
+    }
+}
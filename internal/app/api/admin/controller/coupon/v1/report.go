@@ -0,0 +1,210 @@
+package coupon
+
+import (
+    "encoding/csv"
+    "fmt"
+    "strconv"
+
+    cpbv1 "emshop/api/coupon/v1"
+    "emshop/internal/app/api/admin/domain/dto/request"
+    couponsrv "emshop/internal/app/api/admin/service/coupon/v1"
+    gin2 "emshop/internal/app/pkg/translator/gin"
+    "emshop/pkg/common/core"
+    "emshop/pkg/log"
+
+    "github.com/gin-gonic/gin"
+)
+
+// GetCouponReport 优惠券模板效果报表：发放、核销、过期、核销率、优惠金额、GMV与客单价
+func (cc *couponController) GetCouponReport(ctx *gin.Context) {
+    var r request.CouponReportRequest
+    if err := ctx.ShouldBindQuery(&r); err != nil {
+        gin2.HandleValidatorError(ctx, err, cc.trans)
+        return
+    }
+    report, err := cc.srv.Coupon().GetCouponReport(ctx, r.CouponTemplateID, r.StartDate, r.EndDate)
+    if err != nil {
+        core.WriteResponse(ctx, err, nil)
+        return
+    }
+
+    days := make([]gin.H, 0, len(report.Days))
+    for _, day := range report.Days {
+        days = append(days, reportDayToMap(day))
+    }
+    templates := make([]gin.H, 0, len(report.Templates))
+    for _, t := range report.Templates {
+        templates = append(templates, couponStatToMap(t))
+    }
+    core.WriteResponse(ctx, nil, gin.H{
+        "days":      days,
+        "templates": templates,
+        "total":     reportDayToMap(report.Total),
+    })
+}
+
+// ExportCouponReport 导出优惠券效果报表CSV，依次为每日明细、区间合计和各模板合计
+func (cc *couponController) ExportCouponReport(ctx *gin.Context) {
+    var r request.CouponReportRequest
+    if err := ctx.ShouldBindQuery(&r); err != nil {
+        gin2.HandleValidatorError(ctx, err, cc.trans)
+        return
+    }
+    report, err := cc.srv.Coupon().GetCouponReport(ctx, r.CouponTemplateID, r.StartDate, r.EndDate)
+    if err != nil {
+        core.WriteResponse(ctx, err, nil)
+        return
+    }
+
+    writer := startCSV(ctx, fmt.Sprintf("coupon_report_%s_%s.csv", r.StartDate, r.EndDate))
+    defer writer.Flush()
+
+    headers := []string{"日期", "优惠券模板ID", "发放数", "核销数", "过期数", "核销率", "用券订单数", "用券订单原价",
+        "优惠金额", "用券GMV", "用券客单价", "全部订单数", "全部订单金额", "未用券客单价"}
+    rows := make([][]string, 0, len(report.Days)+len(report.Templates)+2)
+    rows = append(rows, headers)
+    for _, day := range report.Days {
+        rows = append(rows, reportDayRecord(day.Stat.Date, day))
+    }
+    rows = append(rows, reportDayRecord("合计", report.Total))
+    for _, t := range report.Templates {
+        rows = append(rows, reportDayRecord("合计", &couponsrv.CouponReportDay{Stat: t}))
+    }
+    if err := writer.WriteAll(rows); err != nil {
+        log.ErrorfC(ctx, "Failed to write CSV records: %v", err)
+    }
+}
+
+// GetFlashSaleReport 秒杀每日售出与累计售罄率
+func (cc *couponController) GetFlashSaleReport(ctx *gin.Context) {
+    var r request.FlashSaleReportRequest
+    if err := ctx.ShouldBindQuery(&r); err != nil {
+        gin2.HandleValidatorError(ctx, err, cc.trans)
+        return
+    }
+    stats, err := cc.srv.Coupon().GetFlashSaleReport(ctx, r.FlashSaleID, r.StartDate, r.EndDate)
+    if err != nil {
+        core.WriteResponse(ctx, err, nil)
+        return
+    }
+
+    items := make([]gin.H, 0, len(stats))
+    for _, s := range stats {
+        items = append(items, gin.H{
+            "date":               s.Date,
+            "flash_sale_id":      s.FlashSaleId,
+            "coupon_template_id": s.CouponTemplateId,
+            "total_stock":        s.TotalStock,
+            "sold_count":         s.SoldCount,
+            "failed_count":       s.FailedCount,
+            "cumulative_sold":    s.CumulativeSold,
+            "sell_through_rate":  s.SellThroughRate,
+        })
+    }
+    core.WriteResponse(ctx, nil, gin.H{"items": items})
+}
+
+// ExportFlashSaleReport 导出秒杀售罄报表CSV
+func (cc *couponController) ExportFlashSaleReport(ctx *gin.Context) {
+    var r request.FlashSaleReportRequest
+    if err := ctx.ShouldBindQuery(&r); err != nil {
+        gin2.HandleValidatorError(ctx, err, cc.trans)
+        return
+    }
+    stats, err := cc.srv.Coupon().GetFlashSaleReport(ctx, r.FlashSaleID, r.StartDate, r.EndDate)
+    if err != nil {
+        core.WriteResponse(ctx, err, nil)
+        return
+    }
+
+    writer := startCSV(ctx, fmt.Sprintf("flash_sale_report_%s_%s.csv", r.StartDate, r.EndDate))
+    defer writer.Flush()
+
+    rows := make([][]string, 0, len(stats)+1)
+    rows = append(rows, []string{"日期", "秒杀活动ID", "优惠券模板ID", "总库存", "当天售出", "当天失败", "累计售出", "售罄率"})
+    for _, s := range stats {
+        rows = append(rows, []string{
+            s.Date,
+            strconv.FormatInt(s.FlashSaleId, 10),
+            strconv.FormatInt(s.CouponTemplateId, 10),
+            strconv.Itoa(int(s.TotalStock)),
+            strconv.FormatInt(s.SoldCount, 10),
+            strconv.FormatInt(s.FailedCount, 10),
+            strconv.FormatInt(s.CumulativeSold, 10),
+            formatFloat(s.SellThroughRate),
+        })
+    }
+    if err := writer.WriteAll(rows); err != nil {
+        log.ErrorfC(ctx, "Failed to write CSV records: %v", err)
+    }
+}
+
+// RebuildCouponReport 重建日期范围内的每日统计，用于历史数据回填
+func (cc *couponController) RebuildCouponReport(ctx *gin.Context) {
+    var r request.RebuildCouponReportRequest
+    if err := ctx.ShouldBindJSON(&r); err != nil {
+        gin2.HandleValidatorError(ctx, err, cc.trans)
+        return
+    }
+    days, err := cc.srv.Coupon().RebuildReport(ctx, r.StartDate, r.EndDate)
+    if err != nil {
+        core.WriteResponse(ctx, err, nil)
+        return
+    }
+    core.WriteResponse(ctx, nil, gin.H{"days": days})
+}
+
+// startCSV 写入下载响应头并返回CSV writer
+func startCSV(ctx *gin.Context, filename string) *csv.Writer {
+    ctx.Header("Content-Type", "text/csv")
+    ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+    return csv.NewWriter(ctx.Writer)
+}
+
+func formatFloat(v float64) string {
+    return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func couponStatToMap(s *cpbv1.CouponStat) gin.H {
+    return gin.H{
+        "date":               s.Date,
+        "coupon_template_id": s.CouponTemplateId,
+        "issued_count":       s.IssuedCount,
+        "redeemed_count":     s.RedeemedCount,
+        "expired_count":      s.ExpiredCount,
+        "redemption_rate":    s.RedemptionRate,
+        "order_count":        s.OrderCount,
+        "original_amount":    s.OriginalAmount,
+        "discount_amount":    s.DiscountAmount,
+        "gmv":                s.Gmv,
+        "avg_order_amount":   s.AvgOrderAmount,
+    }
+}
+
+func reportDayToMap(day *couponsrv.CouponReportDay) gin.H {
+    m := couponStatToMap(day.Stat)
+    m["total_order_count"] = day.TotalOrderCount
+    m["total_order_amount"] = day.TotalOrderAmount
+    m["avg_order_amount_without_coupon"] = day.AvgOrderAmountWithoutCoupon
+    return m
+}
+
+func reportDayRecord(date string, day *couponsrv.CouponReportDay) []string {
+    s := day.Stat
+    return []string{
+        date,
+        strconv.FormatInt(s.CouponTemplateId, 10),
+        strconv.FormatInt(s.IssuedCount, 10),
+        strconv.FormatInt(s.RedeemedCount, 10),
+        strconv.FormatInt(s.ExpiredCount, 10),
+        formatFloat(s.RedemptionRate),
+        strconv.FormatInt(s.OrderCount, 10),
+        formatFloat(s.OriginalAmount),
+        formatFloat(s.DiscountAmount),
+        formatFloat(s.Gmv),
+        formatFloat(s.AvgOrderAmount),
+        strconv.FormatInt(day.TotalOrderCount, 10),
+        formatFloat(day.TotalOrderAmount),
+        formatFloat(day.AvgOrderAmountWithoutCoupon),
+    }
+}
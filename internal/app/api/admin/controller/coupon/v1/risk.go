@@ -0,0 +1,73 @@
+package coupon
+
+import (
+    cpbv1 "emshop/api/coupon/v1"
+    "emshop/internal/app/api/admin/domain/dto/request"
+    gin2 "emshop/internal/app/pkg/translator/gin"
+    "emshop/pkg/common/core"
+
+    "github.com/gin-gonic/gin"
+)
+
+// ListRiskDecisions 查询领券、秒杀与短信的风控决策记录，用于复核误拦截与调整阈值
+func (cc *couponController) ListRiskDecisions(ctx *gin.Context) {
+    var r request.ListRiskDecisionsRequest
+    if err := ctx.ShouldBindQuery(&r); err != nil {
+        gin2.HandleValidatorError(ctx, err, cc.trans)
+        return
+    }
+    if r.Page <= 0 {
+        r.Page = 1
+    }
+    if r.PageSize <= 0 {
+        r.PageSize = 20
+    }
+
+    req := &cpbv1.ListRiskDecisionsRequest{
+        Page:     r.Page,
+        PageSize: r.PageSize,
+    }
+    if r.Scene != "" {
+        req.Scene = &r.Scene
+    }
+    if r.Action != "" {
+        req.Action = &r.Action
+    }
+    if r.UserID > 0 {
+        req.UserId = &r.UserID
+    }
+    if r.DeviceID != "" {
+        req.DeviceId = &r.DeviceID
+    }
+    if r.ClientIP != "" {
+        req.ClientIp = &r.ClientIP
+    }
+
+    resp, err := cc.srv.Coupon().ListRiskDecisions(ctx, req, r.StartDate, r.EndDate)
+    if err != nil {
+        core.WriteResponse(ctx, err, nil)
+        return
+    }
+
+    items := make([]gin.H, 0, len(resp.Items))
+    for _, item := range resp.Items {
+        items = append(items, gin.H{
+            "id":         item.Id,
+            "scene":      item.Scene,
+            "target_id":  item.TargetId,
+            "user_id":    item.UserId,
+            "mobile":     item.Mobile,
+            "device_id":  item.DeviceId,
+            "client_ip":  item.ClientIp,
+            "score":      item.Score,
+            "action":     item.Action,
+            "reasons":    item.Reasons,
+            "signals":    item.Signals,
+            "created_at": item.CreatedAt,
+        })
+    }
+    core.WriteResponse(ctx, nil, gin.H{
+        "total": resp.TotalCount,
+        "items": items,
+    })
+}
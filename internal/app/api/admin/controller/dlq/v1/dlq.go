@@ -0,0 +1,113 @@
+package dlq
+
+import (
+	restserver "emshop/gin-micro/server/rest-server"
+	admincontroller "emshop/internal/app/api/admin/controller"
+	"emshop/internal/app/api/admin/domain/dto/request"
+	"emshop/internal/app/api/admin/service"
+	gin2 "emshop/internal/app/pkg/translator/gin"
+	"emshop/pkg/common/core"
+	"emshop/pkg/dlq"
+
+	"github.com/gin-gonic/gin"
+)
+
+type dlqController struct {
+	trans restserver.I18nTranslator
+	sf    service.ServiceFactory
+}
+
+func NewDLQController(sf service.ServiceFactory, trans restserver.I18nTranslator) *dlqController {
+	return &dlqController{
+		sf:    sf,
+		trans: trans,
+	}
+}
+
+// Namespaces 可查看的死信命名空间
+func (dc *dlqController) Namespaces(ctx *gin.Context) {
+	core.WriteResponse(ctx, nil, gin.H{
+		"data": dc.sf.DeadLetters().Namespaces(),
+	})
+}
+
+// List 死信列表
+func (dc *dlqController) List(ctx *gin.Context) {
+	var r request.DLQListRequest
+	if err := ctx.ShouldBindQuery(&r); err != nil {
+		gin2.HandleValidatorError(ctx, err, dc.trans)
+		return
+	}
+	if r.Page <= 0 {
+		r.Page = 1
+	}
+	if r.PageSize <= 0 {
+		r.PageSize = 20
+	}
+
+	msgs, total, err := dc.sf.DeadLetters().List(ctx, ctx.Param("namespace"), dlq.ListOptions{
+		Group:  r.Group,
+		Topic:  r.Topic,
+		Class:  r.Class,
+		Status: dlq.Status(r.Status),
+		Offset: (r.Page - 1) * r.PageSize,
+		Limit:  r.PageSize,
+	})
+	if err != nil {
+		core.WriteResponse(ctx, err, nil)
+		return
+	}
+	core.WriteResponse(ctx, nil, gin.H{
+		"total": total,
+		"data":  msgs,
+	})
+}
+
+// Stats 按错误分类统计死信
+func (dc *dlqController) Stats(ctx *gin.Context) {
+	var r request.DLQStatsRequest
+	if err := ctx.ShouldBindQuery(&r); err != nil {
+		gin2.HandleValidatorError(ctx, err, dc.trans)
+		return
+	}
+
+	stats, err := dc.sf.DeadLetters().Stats(ctx, ctx.Param("namespace"), dlq.ListOptions{
+		Group: r.Group,
+		Topic: r.Topic,
+	})
+	if err != nil {
+		core.WriteResponse(ctx, err, nil)
+		return
+	}
+	core.WriteResponse(ctx, nil, gin.H{
+		"data": stats,
+	})
+}
+
+// Detail 死信详情及消息体
+func (dc *dlqController) Detail(ctx *gin.Context) {
+	msg, err := dc.sf.DeadLetters().Get(ctx, ctx.Param("namespace"), ctx.Param("id"))
+	if err != nil {
+		core.WriteResponse(ctx, err, nil)
+		return
+	}
+	core.WriteResponse(ctx, nil, msg)
+}
+
+// Replay 将死信重新投递给原消费者组
+func (dc *dlqController) Replay(ctx *gin.Context) {
+	if err := dc.sf.DeadLetters().Replay(ctx, ctx.Param("namespace"), ctx.Param("id"), admincontroller.Operator(ctx)); err != nil {
+		core.WriteResponse(ctx, err, nil)
+		return
+	}
+	core.WriteResponse(ctx, nil, gin.H{"msg": "死信已重放"})
+}
+
+// Discard 丢弃死信
+func (dc *dlqController) Discard(ctx *gin.Context) {
+	if err := dc.sf.DeadLetters().Discard(ctx, ctx.Param("namespace"), ctx.Param("id"), admincontroller.Operator(ctx)); err != nil {
+		core.WriteResponse(ctx, err, nil)
+		return
+	}
+	core.WriteResponse(ctx, nil, gin.H{"msg": "死信已丢弃"})
+}
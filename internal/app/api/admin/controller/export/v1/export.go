@@ -79,7 +79,7 @@ func (ec *exportController) ExportGoods(ctx *gin.Context) {
 	// 获取商品数据
 	response, err := ec.sf.Goods().GetGoodsList(ctx, request)
 	if err != nil {
-		log.Errorf("Failed to get goods list for export: %v", err)
+		log.ErrorfC(ctx, "Failed to get goods list for export: %v", err)
 		core.WriteResponse(ctx, err, nil)
 		return
 	}
@@ -100,7 +100,7 @@ func (ec *exportController) ExportGoods(ctx *gin.Context) {
 		"是否免邮", "商品简介", "创建时间",
 	}
 	if err := writer.Write(headers); err != nil {
-		log.Errorf("Failed to write CSV headers: %v", err)
+		log.ErrorfC(ctx, "Failed to write CSV headers: %v", err)
 		return
 	}
 
@@ -127,12 +127,12 @@ func (ec *exportController) ExportGoods(ctx *gin.Context) {
 		}
 
 		if err := writer.Write(record); err != nil {
-			log.Errorf("Failed to write CSV record: %v", err)
+			log.ErrorfC(ctx, "Failed to write CSV record: %v", err)
 			return
 		}
 	}
 
-	log.Infof("Exported %d goods records to CSV", len(response.Data))
+	log.InfofC(ctx, "Exported %d goods records to CSV", len(response.Data))
 }
 
 // ExportGoodsTemplate 导出商品模板文件（管理员专用）
@@ -153,7 +153,7 @@ func (ec *exportController) ExportGoodsTemplate(ctx *gin.Context) {
 		"是否免邮(true/false)", "商品简介", "商品描述", "商品主图URL", "商品图片URLs(逗号分隔)",
 	}
 	if err := writer.Write(headers); err != nil {
-		log.Errorf("Failed to write template headers: %v", err)
+		log.ErrorfC(ctx, "Failed to write template headers: %v", err)
 		return
 	}
 
@@ -177,10 +177,10 @@ func (ec *exportController) ExportGoodsTemplate(ctx *gin.Context) {
 
 	for _, example := range examples {
 		if err := writer.Write(example); err != nil {
-			log.Errorf("Failed to write template example: %v", err)
+			log.ErrorfC(ctx, "Failed to write template example: %v", err)
 			return
 		}
 	}
 
-	log.Info("Exported goods import template")
+	log.InfoC(ctx, "Exported goods import template")
 }
\ No newline at end of file
@@ -48,7 +48,7 @@ func (ic *importController) ImportGoods(ctx *gin.Context) {
 	reader := csv.NewReader(file)
 	records, err := reader.ReadAll()
 	if err != nil {
-		log.Errorf("Failed to parse CSV file: %v", err)
+		log.ErrorfC(ctx, "Failed to parse CSV file: %v", err)
 		ctx.JSON(http.StatusBadRequest, gin.H{"msg": "failed to parse CSV file"})
 		return
 	}
@@ -101,7 +101,7 @@ func (ic *importController) ImportGoods(ctx *gin.Context) {
 					Num:     int32(stocks),
 				}
 				if err := ic.sf.Goods().SetGoodsInventory(ctx, invInfo); err != nil {
-					log.Warnf("Failed to set inventory for goods %d: %v", goodsResp.Id, err)
+					log.WarnfC(ctx, "Failed to set inventory for goods %d: %v", goodsResp.Id, err)
 				}
 			}
 		}
@@ -123,7 +123,7 @@ func (ic *importController) ImportGoods(ctx *gin.Context) {
 	}
 
 	core.WriteResponse(ctx, nil, response)
-	log.Infof("Imported %d goods successfully, %d errors", successCount, len(errors))
+	log.InfofC(ctx, "Imported %d goods successfully, %d errors", successCount, len(errors))
 }
 
 // parseGoodsRecord 解析CSV记录为商品信息
@@ -0,0 +1,93 @@
+package job
+
+import (
+	restserver "emshop/gin-micro/server/rest-server"
+	admincontroller "emshop/internal/app/api/admin/controller"
+	"emshop/internal/app/api/admin/domain/dto/request"
+	"emshop/internal/app/api/admin/service"
+	gin2 "emshop/internal/app/pkg/translator/gin"
+	"emshop/pkg/common/core"
+	"emshop/pkg/delayjob"
+
+	"github.com/gin-gonic/gin"
+)
+
+type jobController struct {
+	trans restserver.I18nTranslator
+	sf    service.ServiceFactory
+}
+
+func NewJobController(sf service.ServiceFactory, trans restserver.I18nTranslator) *jobController {
+	return &jobController{
+		sf:    sf,
+		trans: trans,
+	}
+}
+
+// Namespaces 可查看的任务命名空间
+func (jc *jobController) Namespaces(ctx *gin.Context) {
+	core.WriteResponse(ctx, nil, gin.H{
+		"data": jc.sf.Jobs().Namespaces(),
+	})
+}
+
+// List 延时任务列表
+func (jc *jobController) List(ctx *gin.Context) {
+	var r request.JobListRequest
+	if err := ctx.ShouldBindQuery(&r); err != nil {
+		gin2.HandleValidatorError(ctx, err, jc.trans)
+		return
+	}
+	if r.Page <= 0 {
+		r.Page = 1
+	}
+	if r.PageSize <= 0 {
+		r.PageSize = 20
+	}
+
+	jobs, total, err := jc.sf.Jobs().List(ctx, ctx.Param("namespace"), delayjob.ListOptions{
+		Type:   r.Type,
+		Status: delayjob.Status(r.Status),
+		Offset: (r.Page - 1) * r.PageSize,
+		Limit:  r.PageSize,
+	})
+	if err != nil {
+		core.WriteResponse(ctx, err, nil)
+		return
+	}
+	core.WriteResponse(ctx, nil, gin.H{
+		"total": total,
+		"data":  jobs,
+	})
+}
+
+// Detail 任务详情及执行记录
+func (jc *jobController) Detail(ctx *gin.Context) {
+	job, history, err := jc.sf.Jobs().Get(ctx, ctx.Param("namespace"), ctx.Param("id"))
+	if err != nil {
+		core.WriteResponse(ctx, err, nil)
+		return
+	}
+	core.WriteResponse(ctx, nil, gin.H{
+		"job":     job,
+		"history": history,
+	})
+}
+
+// Retry 失败或已取消的任务立即重新执行
+func (jc *jobController) Retry(ctx *gin.Context) {
+	if err := jc.sf.Jobs().Retry(ctx, ctx.Param("namespace"), ctx.Param("id"), admincontroller.Operator(ctx)); err != nil {
+		core.WriteResponse(ctx, err, nil)
+		return
+	}
+	core.WriteResponse(ctx, nil, gin.H{"msg": "任务已重新入队"})
+}
+
+// Cancel 取消等待中的任务
+func (jc *jobController) Cancel(ctx *gin.Context) {
+	if err := jc.sf.Jobs().Cancel(ctx, ctx.Param("namespace"), ctx.Param("id"), admincontroller.Operator(ctx)); err != nil {
+		core.WriteResponse(ctx, err, nil)
+		return
+	}
+	core.WriteResponse(ctx, nil, gin.H{"msg": "任务已取消"})
+}
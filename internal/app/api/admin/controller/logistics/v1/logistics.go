@@ -0,0 +1,184 @@
+package logistics
+
+import (
+	"strconv"
+
+	lpbv1 "emshop/api/logistics/v1"
+	"emshop/gin-micro/code"
+	restserver "emshop/gin-micro/server/rest-server"
+	admincontroller "emshop/internal/app/api/admin/controller"
+	"emshop/internal/app/api/admin/domain/dto/request"
+	"emshop/internal/app/api/admin/service"
+	gin2 "emshop/internal/app/pkg/translator/gin"
+	"emshop/pkg/common/core"
+	"emshop/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+type logisticsController struct {
+	trans restserver.I18nTranslator
+	sf    service.ServiceFactory
+}
+
+func NewLogisticsController(sf service.ServiceFactory, trans restserver.I18nTranslator) *logisticsController {
+	return &logisticsController{
+		sf:    sf,
+		trans: trans,
+	}
+}
+
+// ListRateConfigs 运费配置版本列表
+func (lc *logisticsController) ListRateConfigs(ctx *gin.Context) {
+	var r request.ShippingRateConfigListRequest
+	if err := ctx.ShouldBindQuery(&r); err != nil {
+		gin2.HandleValidatorError(ctx, err, lc.trans)
+		return
+	}
+
+	resp, err := lc.sf.Logistics().ListRateConfigs(ctx, r.Page, r.PageSize)
+	if err != nil {
+		core.WriteResponse(ctx, err, nil)
+		return
+	}
+
+	items := make([]gin.H, 0, len(resp.Items))
+	for _, item := range resp.Items {
+		items = append(items, rateConfigToMap(item, false))
+	}
+	core.WriteResponse(ctx, nil, gin.H{
+		"total": resp.Total,
+		"data":  items,
+	})
+}
+
+// GetActiveRateConfig 当前生效的运费配置
+func (lc *logisticsController) GetActiveRateConfig(ctx *gin.Context) {
+	cfg, err := lc.sf.Logistics().GetRateConfig(ctx, 0)
+	if err != nil {
+		core.WriteResponse(ctx, err, nil)
+		return
+	}
+	core.WriteResponse(ctx, nil, rateConfigToMap(cfg, true))
+}
+
+// GetRateConfig 指定版本的运费配置
+func (lc *logisticsController) GetRateConfig(ctx *gin.Context) {
+	version, ok := parseVersion(ctx)
+	if !ok {
+		return
+	}
+	cfg, err := lc.sf.Logistics().GetRateConfig(ctx, version)
+	if err != nil {
+		core.WriteResponse(ctx, err, nil)
+		return
+	}
+	core.WriteResponse(ctx, nil, rateConfigToMap(cfg, true))
+}
+
+// CreateRateConfig 创建运费配置版本
+func (lc *logisticsController) CreateRateConfig(ctx *gin.Context) {
+	var r request.CreateShippingRateConfigRequest
+	if err := ctx.ShouldBindJSON(&r); err != nil {
+		gin2.HandleValidatorError(ctx, err, lc.trans)
+		return
+	}
+
+	cfg, err := lc.sf.Logistics().CreateRateConfig(ctx, &lpbv1.CreateShippingRateConfigRequest{
+		Content:  r.Content,
+		Operator: admincontroller.Operator(ctx),
+		Remark:   r.Remark,
+		Activate: r.Activate,
+	})
+	if err != nil {
+		core.WriteResponse(ctx, err, nil)
+		return
+	}
+	core.WriteResponse(ctx, nil, rateConfigToMap(cfg, true))
+}
+
+// ActivateRateConfig 生效（或回滚到）指定版本
+func (lc *logisticsController) ActivateRateConfig(ctx *gin.Context) {
+	version, ok := parseVersion(ctx)
+	if !ok {
+		return
+	}
+	if err := lc.sf.Logistics().ActivateRateConfig(ctx, version, admincontroller.Operator(ctx)); err != nil {
+		core.WriteResponse(ctx, err, nil)
+		return
+	}
+	core.WriteResponse(ctx, nil, gin.H{"version": version})
+}
+
+// QuoteShippingFee 按生效配置试算运费
+func (lc *logisticsController) QuoteShippingFee(ctx *gin.Context) {
+	var r request.QuoteShippingFeeRequest
+	if err := ctx.ShouldBindJSON(&r); err != nil {
+		gin2.HandleValidatorError(ctx, err, lc.trans)
+		return
+	}
+
+	req := &lpbv1.CalculateShippingFeeRequest{
+		LogisticsCompany: r.LogisticsCompany,
+		ShippingMethod:   r.ShippingMethod,
+		SenderAddress:    r.SenderAddress,
+		ReceiverAddress:  r.ReceiverAddress,
+		TotalWeight:      r.TotalWeight,
+		TotalVolume:      r.TotalVolume,
+		OrderAmount:      r.OrderAmount,
+		FreeShipCoupon:   r.FreeShipCoupon,
+	}
+	for _, item := range r.Items {
+		req.Items = append(req.Items, &lpbv1.ShippingFeeItem{
+			GoodsId:  item.GoodsID,
+			Quantity: item.Quantity,
+			Weight:   item.Weight,
+			Volume:   item.Volume,
+			ShipFree: item.ShipFree,
+		})
+	}
+
+	resp, err := lc.sf.Logistics().QuoteShippingFee(ctx, req)
+	if err != nil {
+		core.WriteResponse(ctx, err, nil)
+		return
+	}
+	core.WriteResponse(ctx, nil, gin.H{
+		"shippingFee":      resp.ShippingFee,
+		"baseFee":          resp.BaseFee,
+		"remoteSurcharge":  resp.RemoteSurcharge,
+		"chargeableWeight": resp.ChargeableWeight,
+		"fromZone":         resp.FromZone,
+		"toZone":           resp.ToZone,
+		"freeShipping":     resp.FreeShipping,
+		"freeReason":       resp.FreeReason,
+		"estimatedDays":    resp.EstimatedDays,
+		"rateVersion":      resp.RateVersion,
+	})
+}
+
+func parseVersion(ctx *gin.Context) (int32, bool) {
+	v, err := strconv.ParseInt(ctx.Param("version"), 10, 32)
+	if err != nil || v <= 0 {
+		core.WriteResponse(ctx, errors.WithCode(code.ErrBind, "配置版本号格式不正确"), nil)
+		return 0, false
+	}
+	return int32(v), true
+}
+
+
+func rateConfigToMap(cfg *lpbv1.ShippingRateConfig, withContent bool) gin.H {
+	m := gin.H{
+		"id":          cfg.Id,
+		"version":     cfg.Version,
+		"status":      cfg.Status,
+		"operator":    cfg.Operator,
+		"remark":      cfg.Remark,
+		"createdAt":   cfg.CreatedAt,
+		"activatedAt": cfg.ActivatedAt,
+	}
+	if withContent {
+		m["content"] = cfg.Content
+	}
+	return m
+}
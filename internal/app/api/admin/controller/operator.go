@@ -0,0 +1,19 @@
+package controller
+
+import (
+	"strconv"
+
+	jwtpkg "emshop/internal/app/pkg/jwt"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Operator 返回当前请求的管理员标识，用于记录操作人，未登录时返回空
+func Operator(ctx *gin.Context) string {
+	if uid, ok := ctx.Get(jwtpkg.KeyUserID); ok {
+		if id, ok := uid.(int); ok {
+			return "admin:" + strconv.Itoa(id)
+		}
+	}
+	return ""
+}
@@ -33,7 +33,7 @@ func NewOrderController(srv service.ServiceFactory, trans restserver.I18nTransla
 
 // AdminOrderList 管理员订单列表（支持多维度筛选）
 func (oc *orderController) AdminOrderList(ctx *gin.Context) {
-	log.Info("admin order list function called ...")
+	log.InfoC(ctx, "admin order list function called ...")
 	
 	var r request.AdminOrderFilter
 	
@@ -88,7 +88,7 @@ func (oc *orderController) AdminOrderList(ctx *gin.Context) {
 
 // AdminOrderDetail 管理员查看订单详情
 func (oc *orderController) AdminOrderDetail(ctx *gin.Context) {
-	log.Info("admin order detail function called ...")
+	log.InfoC(ctx, "admin order detail function called ...")
 	
 	id := ctx.Param("id")
 	if id == "" {
@@ -144,7 +144,7 @@ func (oc *orderController) AdminOrderDetail(ctx *gin.Context) {
 
 // UpdateOrderStatus 更新订单状态
 func (oc *orderController) UpdateOrderStatus(ctx *gin.Context) {
-	log.Info("admin update order status function called ...")
+	log.InfoC(ctx, "admin update order status function called ...")
 	
 	id := ctx.Param("id")
 	if id == "" {
@@ -197,7 +197,7 @@ func (oc *orderController) UpdateOrderStatus(ctx *gin.Context) {
 
 // GetOrderByOrderSn 按订单号查询订单
 func (oc *orderController) GetOrderByOrderSn(ctx *gin.Context) {
-	log.Info("admin get order by order sn function called ...")
+	log.InfoC(ctx, "admin get order by order sn function called ...")
 	
 	orderSn := ctx.Param("order_sn")
 	if orderSn == "" {
@@ -243,7 +243,7 @@ func (oc *orderController) GetOrderByOrderSn(ctx *gin.Context) {
 
 // GetOrdersByUserId 按用户ID查询订单列表
 func (oc *orderController) GetOrdersByUserId(ctx *gin.Context) {
-	log.Info("admin get orders by user id function called ...")
+	log.InfoC(ctx, "admin get orders by user id function called ...")
 	
 	userIdStr := ctx.Param("user_id")
 	if userIdStr == "" {
@@ -60,7 +60,7 @@ func (uc *uploadController) UploadImage(ctx *gin.Context) {
 	// 创建上传目录
 	uploadDir := "./uploads/goods/images/" + time.Now().Format("2006/01/02")
 	if err := os.MkdirAll(uploadDir, 0755); err != nil {
-		log.Errorf("Failed to create upload directory: %v", err)
+		log.ErrorfC(ctx, "Failed to create upload directory: %v", err)
 		core.WriteResponse(ctx, err, nil)
 		return
 	}
@@ -71,7 +71,7 @@ func (uc *uploadController) UploadImage(ctx *gin.Context) {
 
 	// 保存文件
 	if err := uc.saveUploadedFile(file, filePath); err != nil {
-		log.Errorf("Failed to save uploaded file: %v", err)
+		log.ErrorfC(ctx, "Failed to save uploaded file: %v", err)
 		core.WriteResponse(ctx, err, nil)
 		return
 	}
@@ -115,7 +115,7 @@ func (uc *uploadController) BatchUploadImages(ctx *gin.Context) {
 	// 创建上传目录
 	uploadDir := "./uploads/goods/images/" + time.Now().Format("2006/01/02")
 	if err := os.MkdirAll(uploadDir, 0755); err != nil {
-		log.Errorf("Failed to create upload directory: %v", err)
+		log.ErrorfC(ctx, "Failed to create upload directory: %v", err)
 		core.WriteResponse(ctx, err, nil)
 		return
 	}
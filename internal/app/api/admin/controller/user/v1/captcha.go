@@ -15,7 +15,7 @@ func GetCaptcha(ctx *gin.Context) {
 	cp := base64Captcha.NewCaptcha(driver, store)
 	id, b64s, answer, err := cp.Generate()
 	if err != nil {
-		log.Errorf("生成验证码错误,: ", err.Error())
+		log.ErrorfC(ctx, "生成验证码错误: %v", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"msg": "生成验证码错误",
 		})
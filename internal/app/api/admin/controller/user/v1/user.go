@@ -203,7 +203,7 @@ func (uc *userController) UpdateUser(ctx *gin.Context) {
 
 // AdminLogin 管理员登录（管理员专用）
 func (uc *userController) AdminLogin(ctx *gin.Context) {
-	log.Info("admin login function called...")
+	log.InfoC(ctx, "admin login function called...")
 
 	var req request.AdminLoginRequest
 
@@ -224,19 +224,19 @@ func (uc *userController) AdminLogin(ctx *gin.Context) {
 	// 直接通过登录服务验证用户和生成token
 	loginResult, err := uc.sf.Users().MobileLogin(ctx, loginReq.Mobile, loginReq.Password)
 	if err != nil {
-		log.Errorf("Admin login failed: %v", err)
+		log.ErrorfC(ctx, "Admin login failed: %v", err)
 		core.WriteResponse(ctx, errors.WithCode(appcode.ErrUserNotFound, "管理员登录失败"), nil)
 		return
 	}
 
 	// 验证用户角色：必须是管理员
 	if loginResult.Role < 2 { // RoleAdmin = 2
-		log.Warnf("Admin login denied: insufficient privileges - userID: %d, role: %d", loginResult.ID, loginResult.Role)
+		log.WarnfC(ctx, "Admin login denied: insufficient privileges - userID: %d, role: %d", loginResult.ID, loginResult.Role)
 		core.WriteResponse(ctx, errors.WithCode(code.ErrPermissionDenied, "权限不足：仅限管理员登录"), nil)
 		return
 	}
 
-	log.Infof("Admin login successful - userID: %d, role: %d", loginResult.ID, loginResult.Role)
+	log.InfofC(ctx, "Admin login successful - userID: %d, role: %d", loginResult.ID, loginResult.Role)
 
 	// 返回管理员登录结果
 	core.WriteResponse(ctx, nil, gin.H{
@@ -7,6 +7,8 @@ import (
     ipbv1 "emshop/api/inventory/v1"
     opbv1 "emshop/api/order/v1"
     cpbv1 "emshop/api/coupon/v1"
+    lpbv1 "emshop/api/logistics/v1"
+    uoppbv1 "emshop/api/userop/v1"
 )
 
 // DataFactory 数据访问工厂接口
@@ -17,6 +19,7 @@ type DataFactory interface {
     Order() OrderData
     UserOp() UserOpData
     Coupon() CouponData
+    Logistics() LogisticsData
 }
 
 // UserData 用户数据访问接口
@@ -27,6 +30,8 @@ type UserData interface {
 	GetUserById(ctx context.Context, request *upbv1.IdRequest) (*upbv1.UserInfoResponse, error)
 	GetUserByMobile(ctx context.Context, request *upbv1.MobileRequest) (*upbv1.UserInfoResponse, error)
 	GetUserList(ctx context.Context, request *upbv1.PageInfo) (*upbv1.UserListResponse, error)
+	// 按注册时间分页查询用户ID，用于定向发券圈选
+	ListUserIds(ctx context.Context, request *upbv1.ListUserIdsRequest) (*upbv1.UserIdsResponse, error)
 }
 
 // GoodsData 商品数据访问接口
@@ -89,11 +94,16 @@ type OrderData interface {
 	GetOrderByOrderSn(ctx context.Context, orderSn string) (*opbv1.OrderInfoDetailResponse, error)
 	// 按用户ID查询订单列表
 	GetOrdersByUserId(ctx context.Context, userId int32, pages, pagePerNums int32) (*opbv1.OrderListResponse, error)
+	// 查询指定时间后下过单的用户ID
+	ListOrderedUserIds(ctx context.Context, request *opbv1.ListOrderedUserIdsRequest) (*opbv1.OrderedUserIdsResponse, error)
+	// 按天统计订单数与金额
+	GetDailyOrderStats(ctx context.Context, request *opbv1.DailyOrderStatsRequest) (*opbv1.DailyOrderStatsResponse, error)
 }
 
 // UserOpData 用户操作数据访问接口
 type UserOpData interface {
-    // 管理员查看用户操作相关方法
+    // 查询收藏了指定商品的用户ID
+    ListFavUserIds(ctx context.Context, request *uoppbv1.ListFavUserIdsRequest) (*uoppbv1.FavUserIdsResponse, error)
 }
 
 // CouponData 优惠券数据访问接口
@@ -101,4 +111,44 @@ type CouponData interface {
     // 优惠券模板
     ListCouponTemplates(ctx context.Context, req *cpbv1.ListCouponTemplatesRequest) (*cpbv1.ListCouponTemplatesResponse, error)
     CreateCouponTemplate(ctx context.Context, req *cpbv1.CreateCouponTemplateRequest) (*cpbv1.CouponTemplateResponse, error)
+
+    // 业务规则配置
+    GetCouponRuleConfig(ctx context.Context) (*cpbv1.CouponRuleConfigResponse, error)
+    UpdateCouponRuleConfig(ctx context.Context, req *cpbv1.UpdateCouponRuleConfigRequest) (*cpbv1.CouponRuleConfigResponse, error)
+    DryRunCouponRules(ctx context.Context, req *cpbv1.DryRunCouponRulesRequest) (*cpbv1.DryRunCouponRulesResponse, error)
+
+    // 兑换码
+    GeneratePromoCodes(ctx context.Context, req *cpbv1.GeneratePromoCodesRequest) (*cpbv1.GeneratePromoCodesResponse, error)
+    CreatePublicPromoCode(ctx context.Context, req *cpbv1.CreatePublicPromoCodeRequest) (*cpbv1.PromoCodeResponse, error)
+    ListPromoCodes(ctx context.Context, req *cpbv1.ListPromoCodesRequest) (*cpbv1.ListPromoCodesResponse, error)
+    DisablePromoCode(ctx context.Context, code string) error
+
+    // 定向发券活动
+    CreateCouponCampaign(ctx context.Context, req *cpbv1.CreateCouponCampaignRequest) (*cpbv1.CouponCampaignResponse, error)
+    AddCouponCampaignTargets(ctx context.Context, campaignID int64, userIDs []int64) (int64, error)
+    GetCouponCampaign(ctx context.Context, id int64) (*cpbv1.CouponCampaignResponse, error)
+    ListCouponCampaigns(ctx context.Context, req *cpbv1.ListCouponCampaignsRequest) (*cpbv1.ListCouponCampaignsResponse, error)
+    StartCouponCampaign(ctx context.Context, id int64) error
+    PauseCouponCampaign(ctx context.Context, id int64) error
+    CancelCouponCampaign(ctx context.Context, id int64) error
+    RerunCouponCampaign(ctx context.Context, id int64) (int64, error)
+    DeleteCouponCampaign(ctx context.Context, id int64) error
+
+    // 效果报表
+    GetCouponReport(ctx context.Context, req *cpbv1.CouponReportRequest) (*cpbv1.CouponReportResponse, error)
+    GetFlashSaleReport(ctx context.Context, req *cpbv1.FlashSaleReportRequest) (*cpbv1.FlashSaleReportResponse, error)
+    RebuildCouponReport(ctx context.Context, startDate, endDate string) (int32, error)
+
+    // 风控决策
+    ListRiskDecisions(ctx context.Context, req *cpbv1.ListRiskDecisionsRequest) (*cpbv1.ListRiskDecisionsResponse, error)
+}
+
+// LogisticsData 物流数据访问接口
+type LogisticsData interface {
+    // 运费规则配置
+    ListShippingRateConfigs(ctx context.Context, req *lpbv1.ListShippingRateConfigsRequest) (*lpbv1.ListShippingRateConfigsResponse, error)
+    GetShippingRateConfig(ctx context.Context, req *lpbv1.GetShippingRateConfigRequest) (*lpbv1.ShippingRateConfig, error)
+    CreateShippingRateConfig(ctx context.Context, req *lpbv1.CreateShippingRateConfigRequest) (*lpbv1.ShippingRateConfig, error)
+    ActivateShippingRateConfig(ctx context.Context, req *lpbv1.ActivateShippingRateConfigRequest) error
+    CalculateShippingFee(ctx context.Context, req *lpbv1.CalculateShippingFeeRequest) (*lpbv1.CalculateShippingFeeResponse, error)
 }
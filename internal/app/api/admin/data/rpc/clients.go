@@ -7,12 +7,14 @@ import (
 	cpbv1 "emshop/api/coupon/v1"
 	gpbv1 "emshop/api/goods/v1"
 	ipbv1 "emshop/api/inventory/v1"
+	lpbv1 "emshop/api/logistics/v1"
 	opbv1 "emshop/api/order/v1"
 	upbv1 "emshop/api/user/v1"
 	uoppbv1 "emshop/api/userop/v1"
 	"emshop/gin-micro/registry"
 	rpcserver "emshop/gin-micro/server/rpc-server"
 	clientinterceptors "emshop/gin-micro/server/rpc-server/client-interceptors"
+	"emshop/gin-micro/server/rpc-server/selector"
 	"emshop/pkg/log"
 	"google.golang.org/grpc"
 )
@@ -25,17 +27,19 @@ type grpcClients struct {
 	orderClient     opbv1.OrderClient
 	userOpClient    uoppbv1.UserOpClient
 	couponClient    cpbv1.CouponClient
+	logisticsClient lpbv1.LogisticsClient
 }
 
 // newGrpcClients 创建并初始化所有 gRPC 客户端
-func newGrpcClients(discovery registry.Discovery) *grpcClients {
+func newGrpcClients(discovery registry.Discovery, filters ...selector.NodeFilter) *grpcClients {
 	return &grpcClients{
-		userClient:      NewUserServiceClient(discovery),
-		goodsClient:     NewGoodsServiceClient(discovery),
-		inventoryClient: NewInventoryServiceClient(discovery),
-		orderClient:     NewOrderServiceClient(discovery),
-		userOpClient:    NewUserOpServiceClient(discovery),
-		couponClient:    NewCouponServiceClient(discovery),
+		userClient:      NewUserServiceClient(discovery, filters...),
+		goodsClient:     NewGoodsServiceClient(discovery, filters...),
+		inventoryClient: NewInventoryServiceClient(discovery, filters...),
+		orderClient:     NewOrderServiceClient(discovery, filters...),
+		userOpClient:    NewUserOpServiceClient(discovery, filters...),
+		couponClient:    NewCouponServiceClient(discovery, filters...),
+		logisticsClient: NewLogisticsServiceClient(discovery, filters...),
 	}
 }
 
@@ -47,16 +51,19 @@ const (
 	clientOrderServiceName     = "discovery:///emshop-order-srv"
 	clientUseropServiceName    = "discovery:///emshop-userop-srv"
 	clientCouponServiceName    = "discovery:///emshop-coupon-srv"
+	clientLogisticsServiceName = "discovery:///emshop-logistics-srv"
 )
 
 // NewUserServiceClient 创建用户服务的 gRPC 客户端
-func NewUserServiceClient(r registry.Discovery) upbv1.UserClient {
+func NewUserServiceClient(r registry.Discovery, filters ...selector.NodeFilter) upbv1.UserClient {
 	log.Infof("Initializing gRPC connection to service: %s", clientUserServiceName)
 	conn, err := rpcserver.DialInsecure(
 		context.Background(),
 		rpcserver.WithBalancerName("p2c"),
 		rpcserver.WithEndpoint(clientUserServiceName),
+		rpcserver.WithHealthCheck(),
 		rpcserver.WithDiscovery(r),
+		rpcserver.WithNodeFilter(filters...),
 		rpcserver.WithClientTimeout(10*time.Second),
 		rpcserver.WithClientOptions(grpc.WithNoProxy()),
 		rpcserver.WithClientUnaryInterceptor(clientinterceptors.UnaryTracingInterceptor),
@@ -71,13 +78,15 @@ func NewUserServiceClient(r registry.Discovery) upbv1.UserClient {
 }
 
 // NewGoodsServiceClient 创建商品服务的 gRPC 客户端
-func NewGoodsServiceClient(r registry.Discovery) gpbv1.GoodsClient {
+func NewGoodsServiceClient(r registry.Discovery, filters ...selector.NodeFilter) gpbv1.GoodsClient {
 	log.Infof("Initializing gRPC connection to service: %s", clientGoodsServiceName)
 	conn, err := rpcserver.DialInsecure(
 		context.Background(),
 		rpcserver.WithBalancerName("p2c"),
 		rpcserver.WithEndpoint(clientGoodsServiceName),
+		rpcserver.WithHealthCheck(),
 		rpcserver.WithDiscovery(r),
+		rpcserver.WithNodeFilter(filters...),
 		rpcserver.WithClientTimeout(10*time.Second),
 		rpcserver.WithClientOptions(grpc.WithNoProxy()),
 		rpcserver.WithClientUnaryInterceptor(clientinterceptors.UnaryTracingInterceptor),
@@ -92,13 +101,15 @@ func NewGoodsServiceClient(r registry.Discovery) gpbv1.GoodsClient {
 }
 
 // NewInventoryServiceClient 创建库存服务的 gRPC 客户端
-func NewInventoryServiceClient(r registry.Discovery) ipbv1.InventoryClient {
+func NewInventoryServiceClient(r registry.Discovery, filters ...selector.NodeFilter) ipbv1.InventoryClient {
 	log.Infof("Initializing gRPC connection to service: %s", clientInventoryServiceName)
 	conn, err := rpcserver.DialInsecure(
 		context.Background(),
 		rpcserver.WithBalancerName("p2c"),
 		rpcserver.WithEndpoint(clientInventoryServiceName),
+		rpcserver.WithHealthCheck(),
 		rpcserver.WithDiscovery(r),
+		rpcserver.WithNodeFilter(filters...),
 		rpcserver.WithClientTimeout(10*time.Second),
 		rpcserver.WithClientOptions(grpc.WithNoProxy()),
 		rpcserver.WithClientUnaryInterceptor(clientinterceptors.UnaryTracingInterceptor),
@@ -113,13 +124,15 @@ func NewInventoryServiceClient(r registry.Discovery) ipbv1.InventoryClient {
 }
 
 // NewOrderServiceClient 创建订单服务的 gRPC 客户端
-func NewOrderServiceClient(r registry.Discovery) opbv1.OrderClient {
+func NewOrderServiceClient(r registry.Discovery, filters ...selector.NodeFilter) opbv1.OrderClient {
 	log.Infof("Initializing gRPC connection to service: %s", clientOrderServiceName)
 	conn, err := rpcserver.DialInsecure(
 		context.Background(),
 		rpcserver.WithBalancerName("p2c"),
 		rpcserver.WithEndpoint(clientOrderServiceName),
+		rpcserver.WithHealthCheck(),
 		rpcserver.WithDiscovery(r),
+		rpcserver.WithNodeFilter(filters...),
 		rpcserver.WithClientTimeout(10*time.Second),
 		rpcserver.WithClientOptions(grpc.WithNoProxy()),
 		rpcserver.WithClientUnaryInterceptor(clientinterceptors.UnaryTracingInterceptor),
@@ -134,13 +147,15 @@ func NewOrderServiceClient(r registry.Discovery) opbv1.OrderClient {
 }
 
 // NewUserOpServiceClient 创建用户操作服务的 gRPC 客户端
-func NewUserOpServiceClient(r registry.Discovery) uoppbv1.UserOpClient {
+func NewUserOpServiceClient(r registry.Discovery, filters ...selector.NodeFilter) uoppbv1.UserOpClient {
 	log.Infof("Initializing gRPC connection to service: %s", clientUseropServiceName)
 	conn, err := rpcserver.DialInsecure(
 		context.Background(),
 		rpcserver.WithBalancerName("p2c"),
 		rpcserver.WithEndpoint(clientUseropServiceName),
+		rpcserver.WithHealthCheck(),
 		rpcserver.WithDiscovery(r),
+		rpcserver.WithNodeFilter(filters...),
 		rpcserver.WithClientTimeout(10*time.Second),
 		rpcserver.WithClientOptions(grpc.WithNoProxy()),
 		rpcserver.WithClientUnaryInterceptor(clientinterceptors.UnaryTracingInterceptor),
@@ -155,13 +170,15 @@ func NewUserOpServiceClient(r registry.Discovery) uoppbv1.UserOpClient {
 }
 
 // NewCouponServiceClient 创建优惠券服务的 gRPC 客户端
-func NewCouponServiceClient(r registry.Discovery) cpbv1.CouponClient {
+func NewCouponServiceClient(r registry.Discovery, filters ...selector.NodeFilter) cpbv1.CouponClient {
 	log.Infof("Initializing gRPC connection to service: %s", clientCouponServiceName)
 	conn, err := rpcserver.DialInsecure(
 		context.Background(),
 		rpcserver.WithBalancerName("p2c"),
 		rpcserver.WithEndpoint(clientCouponServiceName),
+		rpcserver.WithHealthCheck(),
 		rpcserver.WithDiscovery(r),
+		rpcserver.WithNodeFilter(filters...),
 		rpcserver.WithClientTimeout(10*time.Second),
 		rpcserver.WithClientOptions(grpc.WithNoProxy()),
 		rpcserver.WithClientUnaryInterceptor(clientinterceptors.UnaryTracingInterceptor),
@@ -174,3 +191,26 @@ func NewCouponServiceClient(r registry.Discovery) cpbv1.CouponClient {
 	c := cpbv1.NewCouponClient(conn)
 	return c
 }
+
+// NewLogisticsServiceClient 创建物流服务的 gRPC 客户端
+func NewLogisticsServiceClient(r registry.Discovery, filters ...selector.NodeFilter) lpbv1.LogisticsClient {
+	log.Infof("Initializing gRPC connection to service: %s", clientLogisticsServiceName)
+	conn, err := rpcserver.DialInsecure(
+		context.Background(),
+		rpcserver.WithBalancerName("p2c"),
+		rpcserver.WithEndpoint(clientLogisticsServiceName),
+		rpcserver.WithHealthCheck(),
+		rpcserver.WithDiscovery(r),
+		rpcserver.WithNodeFilter(filters...),
+		rpcserver.WithClientTimeout(10*time.Second),
+		rpcserver.WithClientOptions(grpc.WithNoProxy()),
+		rpcserver.WithClientUnaryInterceptor(clientinterceptors.UnaryTracingInterceptor),
+	)
+	if err != nil {
+		log.Errorf("Failed to create gRPC connection: %v", err)
+		panic(err)
+	}
+	log.Info("gRPC connection established successfully")
+	c := lpbv1.NewLogisticsClient(conn)
+	return c
+}
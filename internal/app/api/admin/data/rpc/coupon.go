@@ -5,6 +5,8 @@ import (
     cpbv1 "emshop/api/coupon/v1"
     "emshop/internal/app/api/admin/data"
     "emshop/pkg/log"
+
+    "google.golang.org/protobuf/types/known/emptypb"
 )
 
 type coupon struct {
@@ -16,24 +18,218 @@ func NewCoupon(cc cpbv1.CouponClient) data.CouponData {
 }
 
 func (c *coupon) ListCouponTemplates(ctx context.Context, req *cpbv1.ListCouponTemplatesRequest) (*cpbv1.ListCouponTemplatesResponse, error) {
-    log.Infof("[admin] ListCouponTemplates with status=%v page=%d pageSize=%d", req.Status, req.Page, req.PageSize)
+    log.InfofC(ctx, "[admin] ListCouponTemplates with status=%v page=%d pageSize=%d", req.Status, req.Page, req.PageSize)
     resp, err := c.cc.ListCouponTemplates(ctx, req)
     if err != nil {
-        log.Errorf("[admin] ListCouponTemplates failed: %v", err)
+        log.ErrorfC(ctx, "[admin] ListCouponTemplates failed: %v", err)
         return nil, err
     }
-    log.Infof("[admin] ListCouponTemplates success, total=%d", resp.TotalCount)
+    log.InfofC(ctx, "[admin] ListCouponTemplates success, total=%d", resp.TotalCount)
     return resp, nil
 }
 
 func (c *coupon) CreateCouponTemplate(ctx context.Context, req *cpbv1.CreateCouponTemplateRequest) (*cpbv1.CouponTemplateResponse, error) {
-    log.Infof("[admin] CreateCouponTemplate: name=%s type=%d discountType=%d", req.Name, req.Type, req.DiscountType)
+    log.InfofC(ctx, "[admin] CreateCouponTemplate: name=%s type=%d discountType=%d", req.Name, req.Type, req.DiscountType)
     resp, err := c.cc.CreateCouponTemplate(ctx, req)
     if err != nil {
-        log.Errorf("[admin] CreateCouponTemplate failed: %v", err)
+        log.ErrorfC(ctx, "[admin] CreateCouponTemplate failed: %v", err)
+        return nil, err
+    }
+    log.InfofC(ctx, "[admin] CreateCouponTemplate success, id=%d", resp.Id)
+    return resp, nil
+}
+
+func (c *coupon) GetCouponRuleConfig(ctx context.Context) (*cpbv1.CouponRuleConfigResponse, error) {
+    resp, err := c.cc.GetCouponRuleConfig(ctx, &emptypb.Empty{})
+    if err != nil {
+        log.ErrorfC(ctx, "[admin] GetCouponRuleConfig failed: %v", err)
+        return nil, err
+    }
+    return resp, nil
+}
+
+func (c *coupon) UpdateCouponRuleConfig(ctx context.Context, req *cpbv1.UpdateCouponRuleConfigRequest) (*cpbv1.CouponRuleConfigResponse, error) {
+    log.InfofC(ctx, "[admin] UpdateCouponRuleConfig: size=%d", len(req.Rules))
+    resp, err := c.cc.UpdateCouponRuleConfig(ctx, req)
+    if err != nil {
+        log.ErrorfC(ctx, "[admin] UpdateCouponRuleConfig failed: %v", err)
+        return nil, err
+    }
+    log.InfoC(ctx, "[admin] UpdateCouponRuleConfig success")
+    return resp, nil
+}
+
+func (c *coupon) DryRunCouponRules(ctx context.Context, req *cpbv1.DryRunCouponRulesRequest) (*cpbv1.DryRunCouponRulesResponse, error) {
+    log.InfofC(ctx, "[admin] DryRunCouponRules: userId=%d orderAmount=%.2f coupons=%v", req.UserId, req.OrderAmount, req.CouponTemplateIds)
+    resp, err := c.cc.DryRunCouponRules(ctx, req)
+    if err != nil {
+        log.ErrorfC(ctx, "[admin] DryRunCouponRules failed: %v", err)
+        return nil, err
+    }
+    log.InfofC(ctx, "[admin] DryRunCouponRules success, passed=%v", resp.Passed)
+    return resp, nil
+}
+
+func (c *coupon) GeneratePromoCodes(ctx context.Context, req *cpbv1.GeneratePromoCodesRequest) (*cpbv1.GeneratePromoCodesResponse, error) {
+    log.InfofC(ctx, "[admin] GeneratePromoCodes: templateId=%d count=%d prefix=%s", req.CouponTemplateId, req.Count, req.Prefix)
+    resp, err := c.cc.GeneratePromoCodes(ctx, req)
+    if err != nil {
+        log.ErrorfC(ctx, "[admin] GeneratePromoCodes failed: %v", err)
         return nil, err
     }
-    log.Infof("[admin] CreateCouponTemplate success, id=%d", resp.Id)
+    log.InfofC(ctx, "[admin] GeneratePromoCodes success, batch=%s count=%d", resp.BatchNo, len(resp.Codes))
     return resp, nil
 }
 
+func (c *coupon) CreatePublicPromoCode(ctx context.Context, req *cpbv1.CreatePublicPromoCodeRequest) (*cpbv1.PromoCodeResponse, error) {
+    log.InfofC(ctx, "[admin] CreatePublicPromoCode: templateId=%d code=%s", req.CouponTemplateId, req.Code)
+    resp, err := c.cc.CreatePublicPromoCode(ctx, req)
+    if err != nil {
+        log.ErrorfC(ctx, "[admin] CreatePublicPromoCode failed: %v", err)
+        return nil, err
+    }
+    log.InfofC(ctx, "[admin] CreatePublicPromoCode success, id=%d", resp.Id)
+    return resp, nil
+}
+
+func (c *coupon) ListPromoCodes(ctx context.Context, req *cpbv1.ListPromoCodesRequest) (*cpbv1.ListPromoCodesResponse, error) {
+    resp, err := c.cc.ListPromoCodes(ctx, req)
+    if err != nil {
+        log.ErrorfC(ctx, "[admin] ListPromoCodes failed: %v", err)
+        return nil, err
+    }
+    return resp, nil
+}
+
+func (c *coupon) DisablePromoCode(ctx context.Context, code string) error {
+    log.InfofC(ctx, "[admin] DisablePromoCode: code=%s", code)
+    if _, err := c.cc.DisablePromoCode(ctx, &cpbv1.DisablePromoCodeRequest{Code: code}); err != nil {
+        log.ErrorfC(ctx, "[admin] DisablePromoCode failed: %v", err)
+        return err
+    }
+    return nil
+}
+
+func (c *coupon) CreateCouponCampaign(ctx context.Context, req *cpbv1.CreateCouponCampaignRequest) (*cpbv1.CouponCampaignResponse, error) {
+    log.InfofC(ctx, "[admin] CreateCouponCampaign: name=%s templateId=%d segment=%s", req.Name, req.CouponTemplateId, req.SegmentType)
+    resp, err := c.cc.CreateCouponCampaign(ctx, req)
+    if err != nil {
+        log.ErrorfC(ctx, "[admin] CreateCouponCampaign failed: %v", err)
+        return nil, err
+    }
+    log.InfofC(ctx, "[admin] CreateCouponCampaign success, id=%d", resp.Id)
+    return resp, nil
+}
+
+func (c *coupon) AddCouponCampaignTargets(ctx context.Context, campaignID int64, userIDs []int64) (int64, error) {
+    resp, err := c.cc.AddCouponCampaignTargets(ctx, &cpbv1.AddCouponCampaignTargetsRequest{
+        CampaignId: campaignID,
+        UserIds:    userIDs,
+    })
+    if err != nil {
+        log.ErrorfC(ctx, "[admin] AddCouponCampaignTargets failed: campaignId=%d, err=%v", campaignID, err)
+        return 0, err
+    }
+    return resp.Added, nil
+}
+
+func (c *coupon) GetCouponCampaign(ctx context.Context, id int64) (*cpbv1.CouponCampaignResponse, error) {
+    resp, err := c.cc.GetCouponCampaign(ctx, &cpbv1.CouponCampaignIdRequest{Id: id})
+    if err != nil {
+        log.ErrorfC(ctx, "[admin] GetCouponCampaign failed: %v", err)
+        return nil, err
+    }
+    return resp, nil
+}
+
+func (c *coupon) ListCouponCampaigns(ctx context.Context, req *cpbv1.ListCouponCampaignsRequest) (*cpbv1.ListCouponCampaignsResponse, error) {
+    resp, err := c.cc.ListCouponCampaigns(ctx, req)
+    if err != nil {
+        log.ErrorfC(ctx, "[admin] ListCouponCampaigns failed: %v", err)
+        return nil, err
+    }
+    return resp, nil
+}
+
+func (c *coupon) StartCouponCampaign(ctx context.Context, id int64) error {
+    log.InfofC(ctx, "[admin] StartCouponCampaign: id=%d", id)
+    if _, err := c.cc.StartCouponCampaign(ctx, &cpbv1.CouponCampaignIdRequest{Id: id}); err != nil {
+        log.ErrorfC(ctx, "[admin] StartCouponCampaign failed: %v", err)
+        return err
+    }
+    return nil
+}
+
+func (c *coupon) PauseCouponCampaign(ctx context.Context, id int64) error {
+    log.InfofC(ctx, "[admin] PauseCouponCampaign: id=%d", id)
+    if _, err := c.cc.PauseCouponCampaign(ctx, &cpbv1.CouponCampaignIdRequest{Id: id}); err != nil {
+        log.ErrorfC(ctx, "[admin] PauseCouponCampaign failed: %v", err)
+        return err
+    }
+    return nil
+}
+
+func (c *coupon) CancelCouponCampaign(ctx context.Context, id int64) error {
+    log.InfofC(ctx, "[admin] CancelCouponCampaign: id=%d", id)
+    if _, err := c.cc.CancelCouponCampaign(ctx, &cpbv1.CouponCampaignIdRequest{Id: id}); err != nil {
+        log.ErrorfC(ctx, "[admin] CancelCouponCampaign failed: %v", err)
+        return err
+    }
+    return nil
+}
+
+func (c *coupon) DeleteCouponCampaign(ctx context.Context, id int64) error {
+    log.InfofC(ctx, "[admin] DeleteCouponCampaign: id=%d", id)
+    if _, err := c.cc.DeleteCouponCampaign(ctx, &cpbv1.CouponCampaignIdRequest{Id: id}); err != nil {
+        log.ErrorfC(ctx, "[admin] DeleteCouponCampaign failed: %v", err)
+        return err
+    }
+    return nil
+}
+
+func (c *coupon) RerunCouponCampaign(ctx context.Context, id int64) (int64, error) {
+    log.InfofC(ctx, "[admin] RerunCouponCampaign: id=%d", id)
+    resp, err := c.cc.RerunCouponCampaign(ctx, &cpbv1.CouponCampaignIdRequest{Id: id})
+    if err != nil {
+        log.ErrorfC(ctx, "[admin] RerunCouponCampaign failed: %v", err)
+        return 0, err
+    }
+    return resp.ResetCount, nil
+}
+
+func (c *coupon) GetCouponReport(ctx context.Context, req *cpbv1.CouponReportRequest) (*cpbv1.CouponReportResponse, error) {
+    resp, err := c.cc.GetCouponReport(ctx, req)
+    if err != nil {
+        log.ErrorfC(ctx, "[admin] GetCouponReport failed: %v", err)
+        return nil, err
+    }
+    return resp, nil
+}
+
+func (c *coupon) GetFlashSaleReport(ctx context.Context, req *cpbv1.FlashSaleReportRequest) (*cpbv1.FlashSaleReportResponse, error) {
+    resp, err := c.cc.GetFlashSaleReport(ctx, req)
+    if err != nil {
+        log.ErrorfC(ctx, "[admin] GetFlashSaleReport failed: %v", err)
+        return nil, err
+    }
+    return resp, nil
+}
+
+func (c *coupon) RebuildCouponReport(ctx context.Context, startDate, endDate string) (int32, error) {
+    log.InfofC(ctx, "[admin] RebuildCouponReport: start=%s, end=%s", startDate, endDate)
+    resp, err := c.cc.RebuildCouponReport(ctx, &cpbv1.RebuildCouponReportRequest{StartDate: startDate, EndDate: endDate})
+    if err != nil {
+        log.ErrorfC(ctx, "[admin] RebuildCouponReport failed: %v", err)
+        return 0, err
+    }
+    return resp.Days, nil
+}
+
+func (c *coupon) ListRiskDecisions(ctx context.Context, req *cpbv1.ListRiskDecisionsRequest) (*cpbv1.ListRiskDecisionsResponse, error) {
+    resp, err := c.cc.ListRiskDecisions(ctx, req)
+    if err != nil {
+        log.ErrorfC(ctx, "[admin] ListRiskDecisions failed: %v", err)
+        return nil, err
+    }
+    return resp, nil
+}
@@ -19,14 +19,14 @@ func NewInventory(ic ipbv1.InventoryClient) *inventory {
 
 // GetInventory 获取商品库存信息
 func (i *inventory) GetInventory(ctx context.Context, goodsId int32) (*ipbv1.GoodsInvInfo, error) {
-	log.Infof("Calling InvDetail gRPC for goods ID: %d", goodsId)
+	log.InfofC(ctx, "Calling InvDetail gRPC for goods ID: %d", goodsId)
 	request := &ipbv1.GoodsInvInfo{GoodsId: goodsId}
 	response, err := i.ic.InvDetail(ctx, request)
 	if err != nil {
-		log.Errorf("InvDetail gRPC call failed for goods %d: %v", goodsId, err)
+		log.ErrorfC(ctx, "InvDetail gRPC call failed for goods %d: %v", goodsId, err)
 		return nil, err
 	}
-	log.Infof("InvDetail gRPC call successful for goods %d: stocks=%d", goodsId, response.Num)
+	log.InfofC(ctx, "InvDetail gRPC call successful for goods %d: stocks=%d", goodsId, response.Num)
 	return response, nil
 }
 
@@ -38,7 +38,7 @@ func (i *inventory) BatchGetInventory(ctx context.Context, goodsIds []int32) (ma
 	for _, goodsId := range goodsIds {
 		inv, err := i.GetInventory(ctx, goodsId)
 		if err != nil {
-			log.Errorf("Failed to get inventory for goods %d: %v", goodsId, err)
+			log.ErrorfC(ctx, "Failed to get inventory for goods %d: %v", goodsId, err)
 			// 库存获取失败时，设置默认值
 			result[goodsId] = &ipbv1.GoodsInvInfo{GoodsId: goodsId, Num: 0}
 			continue
@@ -59,7 +59,7 @@ func (i *inventory) SetInventory(ctx context.Context, request *ipbv1.GoodsInvInf
 func (i *inventory) BatchSetInventory(ctx context.Context, inventories []*ipbv1.GoodsInvInfo) error {
 	for _, inv := range inventories {
 		if err := i.SetInventory(ctx, inv); err != nil {
-			log.Errorf("Failed to set inventory for goods %d: %v", inv.GoodsId, err)
+			log.ErrorfC(ctx, "Failed to set inventory for goods %d: %v", inv.GoodsId, err)
 			return err
 		}
 	}
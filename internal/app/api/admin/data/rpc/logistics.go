@@ -0,0 +1,63 @@
+package rpc
+
+import (
+    "context"
+    lpbv1 "emshop/api/logistics/v1"
+    "emshop/internal/app/api/admin/data"
+    "emshop/pkg/log"
+)
+
+type logistics struct {
+    lc lpbv1.LogisticsClient
+}
+
+func NewLogistics(lc lpbv1.LogisticsClient) data.LogisticsData {
+    return &logistics{lc: lc}
+}
+
+func (l *logistics) ListShippingRateConfigs(ctx context.Context, req *lpbv1.ListShippingRateConfigsRequest) (*lpbv1.ListShippingRateConfigsResponse, error) {
+    resp, err := l.lc.ListShippingRateConfigs(ctx, req)
+    if err != nil {
+        log.ErrorfC(ctx, "[admin] ListShippingRateConfigs failed: %v", err)
+        return nil, err
+    }
+    return resp, nil
+}
+
+func (l *logistics) GetShippingRateConfig(ctx context.Context, req *lpbv1.GetShippingRateConfigRequest) (*lpbv1.ShippingRateConfig, error) {
+    resp, err := l.lc.GetShippingRateConfig(ctx, req)
+    if err != nil {
+        log.ErrorfC(ctx, "[admin] GetShippingRateConfig version=%d failed: %v", req.Version, err)
+        return nil, err
+    }
+    return resp, nil
+}
+
+func (l *logistics) CreateShippingRateConfig(ctx context.Context, req *lpbv1.CreateShippingRateConfigRequest) (*lpbv1.ShippingRateConfig, error) {
+    log.InfofC(ctx, "[admin] CreateShippingRateConfig: operator=%s activate=%v", req.Operator, req.Activate)
+    resp, err := l.lc.CreateShippingRateConfig(ctx, req)
+    if err != nil {
+        log.ErrorfC(ctx, "[admin] CreateShippingRateConfig failed: %v", err)
+        return nil, err
+    }
+    log.InfofC(ctx, "[admin] CreateShippingRateConfig success, version=%d", resp.Version)
+    return resp, nil
+}
+
+func (l *logistics) ActivateShippingRateConfig(ctx context.Context, req *lpbv1.ActivateShippingRateConfigRequest) error {
+    log.InfofC(ctx, "[admin] ActivateShippingRateConfig: version=%d operator=%s", req.Version, req.Operator)
+    if _, err := l.lc.ActivateShippingRateConfig(ctx, req); err != nil {
+        log.ErrorfC(ctx, "[admin] ActivateShippingRateConfig failed: %v", err)
+        return err
+    }
+    return nil
+}
+
+func (l *logistics) CalculateShippingFee(ctx context.Context, req *lpbv1.CalculateShippingFeeRequest) (*lpbv1.CalculateShippingFeeResponse, error) {
+    resp, err := l.lc.CalculateShippingFee(ctx, req)
+    if err != nil {
+        log.ErrorfC(ctx, "[admin] CalculateShippingFee failed: %v", err)
+        return nil, err
+    }
+    return resp, nil
+}
@@ -19,51 +19,51 @@ func NewOrder(oc opbv1.OrderClient) *order {
 
 // 管理员查看所有订单列表（支持多维度筛选）
 func (o *order) AdminOrderList(ctx context.Context, request *opbv1.OrderFilterRequest) (*opbv1.OrderListResponse, error) {
-	log.Infof("Calling AdminOrderList gRPC for request: userId=%d, pages=%d, pagePerNums=%d", 
+	log.InfofC(ctx, "Calling AdminOrderList gRPC for request: userId=%d, pages=%d, pagePerNums=%d", 
 		request.UserId, request.Pages, request.PagePerNums)
 	
 	// 对于管理员，如果没有指定用户ID，则查看所有订单
 	response, err := o.oc.OrderList(ctx, request)
 	if err != nil {
-		log.Errorf("AdminOrderList gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "AdminOrderList gRPC call failed: %v", err)
 		return nil, err
 	}
 	
-	log.Infof("AdminOrderList gRPC call successful, returned %d orders", len(response.Data))
+	log.InfofC(ctx, "AdminOrderList gRPC call successful, returned %d orders", len(response.Data))
 	return response, nil
 }
 
 // 管理员查看订单详情
 func (o *order) AdminOrderDetail(ctx context.Context, request *opbv1.OrderRequest) (*opbv1.OrderInfoDetailResponse, error) {
-	log.Infof("Calling AdminOrderDetail gRPC for order ID: %d, OrderSn: %s", request.Id, request.OrderSn)
+	log.InfofC(ctx, "Calling AdminOrderDetail gRPC for order ID: %d, OrderSn: %s", request.Id, request.GetOrderSn())
 	
 	response, err := o.oc.OrderDetail(ctx, request)
 	if err != nil {
-		log.Errorf("AdminOrderDetail gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "AdminOrderDetail gRPC call failed: %v", err)
 		return nil, err
 	}
 	
-	log.Infof("AdminOrderDetail gRPC call successful")
+	log.InfofC(ctx, "AdminOrderDetail gRPC call successful")
 	return response, nil
 }
 
 // 管理员更新订单状态
 func (o *order) UpdateOrderStatus(ctx context.Context, request *opbv1.OrderStatus) error {
-	log.Infof("Calling UpdateOrderStatus gRPC for order: %s, status: %s", request.OrderSn, request.Status)
+	log.InfofC(ctx, "Calling UpdateOrderStatus gRPC for order: %s, status: %s", request.OrderSn, request.Status)
 	
 	_, err := o.oc.UpdateOrderStatus(ctx, request)
 	if err != nil {
-		log.Errorf("UpdateOrderStatus gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "UpdateOrderStatus gRPC call failed: %v", err)
 		return err
 	}
 	
-	log.Infof("UpdateOrderStatus gRPC call successful")
+	log.InfofC(ctx, "UpdateOrderStatus gRPC call successful")
 	return nil
 }
 
 // 按订单号查询订单
 func (o *order) GetOrderByOrderSn(ctx context.Context, orderSn string) (*opbv1.OrderInfoDetailResponse, error) {
-	log.Infof("Calling GetOrderByOrderSn gRPC for orderSn: %s", orderSn)
+	log.InfofC(ctx, "Calling GetOrderByOrderSn gRPC for orderSn: %s", orderSn)
 	
 	request := &opbv1.OrderRequest{
 		OrderSn: &orderSn,
@@ -71,17 +71,17 @@ func (o *order) GetOrderByOrderSn(ctx context.Context, orderSn string) (*opbv1.O
 	
 	response, err := o.oc.OrderDetail(ctx, request)
 	if err != nil {
-		log.Errorf("GetOrderByOrderSn gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "GetOrderByOrderSn gRPC call failed: %v", err)
 		return nil, err
 	}
 	
-	log.Infof("GetOrderByOrderSn gRPC call successful")
+	log.InfofC(ctx, "GetOrderByOrderSn gRPC call successful")
 	return response, nil
 }
 
 // 按用户ID查询订单列表
 func (o *order) GetOrdersByUserId(ctx context.Context, userId int32, pages, pagePerNums int32) (*opbv1.OrderListResponse, error) {
-	log.Infof("Calling GetOrdersByUserId gRPC for userId: %d, pages: %d, pagePerNums: %d", 
+	log.InfofC(ctx, "Calling GetOrdersByUserId gRPC for userId: %d, pages: %d, pagePerNums: %d", 
 		userId, pages, pagePerNums)
 	
 	request := &opbv1.OrderFilterRequest{
@@ -92,11 +92,30 @@ func (o *order) GetOrdersByUserId(ctx context.Context, userId int32, pages, page
 	
 	response, err := o.oc.OrderList(ctx, request)
 	if err != nil {
-		log.Errorf("GetOrdersByUserId gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "GetOrdersByUserId gRPC call failed: %v", err)
 		return nil, err
 	}
 	
-	log.Infof("GetOrdersByUserId gRPC call successful, returned %d orders", len(response.Data))
+	log.InfofC(ctx, "GetOrdersByUserId gRPC call successful, returned %d orders", len(response.Data))
+	return response, nil
+}
+
+// 查询指定时间后下过单的用户ID
+func (o *order) ListOrderedUserIds(ctx context.Context, request *opbv1.ListOrderedUserIdsRequest) (*opbv1.OrderedUserIdsResponse, error) {
+	response, err := o.oc.ListOrderedUserIds(ctx, request)
+	if err != nil {
+		log.ErrorfC(ctx, "ListOrderedUserIds gRPC call failed: %v", err)
+		return nil, err
+	}
+	return response, nil
+}
+
+func (o *order) GetDailyOrderStats(ctx context.Context, request *opbv1.DailyOrderStatsRequest) (*opbv1.DailyOrderStatsResponse, error) {
+	response, err := o.oc.GetDailyOrderStats(ctx, request)
+	if err != nil {
+		log.ErrorfC(ctx, "GetDailyOrderStats gRPC call failed: %v", err)
+		return nil, err
+	}
 	return response, nil
 }
 
@@ -2,15 +2,14 @@ package rpc
 
 import (
 	"emshop/gin-micro/registry"
-	"emshop/gin-micro/registry/consul"
 	"emshop/internal/app/api/admin/data"
 	"emshop/internal/app/pkg/code"
 	"emshop/internal/app/pkg/options"
+	"emshop/internal/app/pkg/discovery"
 	errors2 "emshop/pkg/errors"
 	"fmt"
 	"sync"
 
-	cosulAPI "github.com/hashicorp/consul/api"
 )
 
 type grpcData struct {
@@ -20,6 +19,7 @@ type grpcData struct {
     od data.OrderData
     uopd data.UserOpData
     cd data.CouponData
+    ld data.LogisticsData
 }
 
 func (g grpcData) Goods() data.GoodsData {
@@ -46,15 +46,15 @@ func (g grpcData) Coupon() data.CouponData {
     return g.cd
 }
 
+func (g grpcData) Logistics() data.LogisticsData {
+    return g.ld
+}
+
 func NewDiscovery(opts *options.RegistryOptions) registry.Discovery {
-	c := cosulAPI.DefaultConfig()
-	c.Address = opts.Address
-	c.Scheme = opts.Scheme
-	cli, err := cosulAPI.NewClient(c)
+	r, err := discovery.NewDiscovery(opts)
 	if err != nil {
 		panic(err)
 	}
-	r := consul.New(cli, consul.WithHealthCheck(true))
 	return r
 }
 
@@ -74,7 +74,7 @@ func GetDataFactoryOr(options *options.RegistryOptions) (data.DataFactory, error
         discovery := NewDiscovery(options)
 
 		// 创建客户端管理器，统一管理所有gRPC客户端
-		clients := newGrpcClients(discovery)
+		clients := newGrpcClients(discovery, options.Routing.NodeFilters()...)
 
         // 创建数据层实例，使用客户端管理器
         userData := NewUsers(clients.userClient)
@@ -83,6 +83,7 @@ func GetDataFactoryOr(options *options.RegistryOptions) (data.DataFactory, error
         orderData := NewOrder(clients.orderClient)
         userOpData := NewUserOp(clients.userOpClient)
         couponData := NewCoupon(clients.couponClient)
+        logisticsData := NewLogistics(clients.logisticsClient)
 
         dbFactory = &grpcData{
             ud: userData,
@@ -91,6 +92,7 @@ func GetDataFactoryOr(options *options.RegistryOptions) (data.DataFactory, error
             od: orderData,
             uopd: userOpData,
             cd: couponData,
+            ld: logisticsData,
         }
     })
 
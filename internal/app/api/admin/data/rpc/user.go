@@ -45,4 +45,8 @@ func (u *users) GetUserList(ctx context.Context, request *upbv1.PageInfo) (*upbv
 	return u.uc.GetUserList(ctx, request)
 }
 
+func (u *users) ListUserIds(ctx context.Context, request *upbv1.ListUserIdsRequest) (*upbv1.UserIdsResponse, error) {
+	return u.uc.ListUserIds(ctx, request)
+}
+
 var _ data.UserData = &users{}
\ No newline at end of file
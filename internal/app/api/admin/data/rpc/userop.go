@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"context"
 	uoppbv1 "emshop/api/userop/v1"
 	"emshop/internal/app/api/admin/data"
 )
@@ -15,6 +16,9 @@ func NewUserOp(uopc uoppbv1.UserOpClient) *userop {
 }
 
 
-// 用户操作相关方法可以根据需要添加
+// 查询收藏了指定商品的用户ID
+func (u *userop) ListFavUserIds(ctx context.Context, request *uoppbv1.ListFavUserIdsRequest) (*uoppbv1.FavUserIdsResponse, error) {
+	return u.uopc.ListFavUserIds(ctx, request)
+}
 
 var _ data.UserOpData = &userop{}
\ No newline at end of file
@@ -0,0 +1,49 @@
+package admin
+
+import (
+	"fmt"
+
+	"emshop/internal/app/api/admin/config"
+	"emshop/pkg/dlq"
+	"emshop/pkg/log"
+
+	rocketmq "github.com/apache/rocketmq-client-go/v2"
+	"github.com/apache/rocketmq-client-go/v2/producer"
+	"github.com/go-redis/redis/v8"
+)
+
+// NewDLQManagers 按配置的命名空间创建死信管理，未配置RocketMQ地址时只能查看不能重放
+func NewDLQManagers(cfg *config.Config) map[string]*dlq.Manager {
+	managers := make(map[string]*dlq.Manager, len(cfg.DLQ.Namespaces))
+	if len(cfg.DLQ.Namespaces) == 0 {
+		return managers
+	}
+
+	var sender dlq.Sender
+	if len(cfg.DLQ.NameServers) > 0 {
+		p, err := rocketmq.NewProducer(
+			producer.WithNameServer(cfg.DLQ.NameServers),
+			producer.WithRetry(2),
+			producer.WithGroupName("emshop-admin-dlq-replay"),
+		)
+		if err == nil {
+			err = p.Start()
+		}
+		if err != nil {
+			log.Errorf("创建死信重放生产者失败，死信只读: %v", err)
+		} else {
+			sender = p
+		}
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port),
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.Database,
+	})
+	for _, ns := range cfg.DLQ.Namespaces {
+		managers[ns] = dlq.NewManager(dlq.NewRedisStore(client, cfg.DLQ.StoreOptions(ns)), sender)
+	}
+	log.Infof("死信管理已启用, namespaces: %v, replay: %t", cfg.DLQ.Namespaces, sender != nil)
+	return managers
+}
@@ -0,0 +1,93 @@
+package request
+
+import "encoding/json"
+
+// UpdateCouponRulesRequest 更新优惠券业务规则请求
+type UpdateCouponRulesRequest struct {
+	Rules json.RawMessage `json:"rules" binding:"required"` // 规则参数，缺失的字段使用默认值
+}
+
+// DryRunCouponRulesRequest 优惠券业务规则试算请求
+type DryRunCouponRulesRequest struct {
+	Rules             json.RawMessage `json:"rules"`                                // 候选规则参数，为空时使用当前生效的规则
+	UserID            int64           `json:"user_id"`                              // 样例用户
+	OrderAmount       float64         `json:"order_amount" binding:"required,gt=0"` // 样例订单金额
+	CouponTemplateIDs []int64         `json:"coupon_template_ids"`                  // 样例使用的优惠券模板
+	At                int64           `json:"at"`                                   // 试算时间(Unix秒)，为0时使用当前时间
+}
+
+// GeneratePromoCodesRequest 批量生成兑换码请求
+type GeneratePromoCodesRequest struct {
+	CouponTemplateID int64  `json:"coupon_template_id" binding:"required,min=1"` // 优惠券模板
+	Count            int32  `json:"count" binding:"required,min=1"`              // 生成数量
+	Prefix           string `json:"prefix" binding:"max=16"`                     // 兑换码前缀
+}
+
+// CreatePublicPromoCodeRequest 创建公开兑换码请求
+type CreatePublicPromoCodeRequest struct {
+	CouponTemplateID int64  `json:"coupon_template_id" binding:"required,min=1"` // 优惠券模板
+	Code             string `json:"code" binding:"required,min=4,max=32"`        // 兑换码
+	MaxRedemptions   int32  `json:"max_redemptions" binding:"min=0"`             // 最大兑换次数，0表示不限
+}
+
+// ListPromoCodesRequest 兑换码列表请求
+type ListPromoCodesRequest struct {
+	CouponTemplateID int64  `form:"coupon_template_id"`
+	BatchNo          string `form:"batch_no"`
+	Page             int32  `form:"page"`
+	PageSize         int32  `form:"page_size" binding:"max=500"`
+}
+
+// CreateCouponCampaignRequest 创建定向发券活动请求
+type CreateCouponCampaignRequest struct {
+	Name                 string  `json:"name" binding:"required,max=100"`                                                   // 活动名称
+	CouponTemplateID     int64   `json:"coupon_template_id" binding:"required,min=1"`                                       // 优惠券模板
+	SegmentType          string  `json:"segment_type" binding:"required,oneof=user_ids registered inactive fav_category"` // 圈选方式
+	UserIDs              []int64 `json:"user_ids" binding:"max=10000"`                                                      // user_ids: 用户ID，大名单通过CSV上传追加
+	RegisteredFrom       int64   `json:"registered_from"`                                                                   // registered: 注册时间起(Unix秒)
+	RegisteredTo         int64   `json:"registered_to"`                                                                     // registered: 注册时间止(Unix秒)
+	InactiveDays         int32   `json:"inactive_days"`                                                                     // inactive: 最近N天未下单
+	CategoryID           int32   `json:"category_id"`                                                                       // fav_category: 一级分类
+	BatchSize            int32   `json:"batch_size" binding:"min=0"`                                                        // 每批发放数量，0使用默认值
+	BatchIntervalSeconds int32   `json:"batch_interval_seconds" binding:"min=0"`                                            // 批次间隔(秒)
+}
+
+// ListCouponCampaignsRequest 发券活动列表请求
+type ListCouponCampaignsRequest struct {
+	Status   int32 `form:"status"`
+	Page     int32 `form:"page"`
+	PageSize int32 `form:"page_size" binding:"max=100"`
+}
+
+// CouponReportRequest 优惠券效果报表请求，日期格式2006-01-02，结束日期包含在内
+type CouponReportRequest struct {
+	CouponTemplateID int64  `form:"coupon_template_id" binding:"min=0"` // 0表示全部模板
+	StartDate        string `form:"start_date" binding:"required"`
+	EndDate          string `form:"end_date" binding:"required"`
+}
+
+// FlashSaleReportRequest 秒杀售罄报表请求
+type FlashSaleReportRequest struct {
+	FlashSaleID int64  `form:"flash_sale_id" binding:"min=0"` // 0表示全部活动
+	StartDate   string `form:"start_date" binding:"required"`
+	EndDate     string `form:"end_date" binding:"required"`
+}
+
+// RebuildCouponReportRequest 重建每日统计请求
+type RebuildCouponReportRequest struct {
+	StartDate string `json:"start_date" binding:"required"`
+	EndDate   string `json:"end_date" binding:"required"`
+}
+
+// ListRiskDecisionsRequest 风控决策记录查询请求，日期格式2006-01-02，结束日期包含在内
+type ListRiskDecisionsRequest struct {
+	Scene     string `form:"scene" binding:"omitempty,oneof=receive_coupon flash_sale sms"`
+	Action    string `form:"action" binding:"omitempty,oneof=allow captcha block"`
+	UserID    int64  `form:"user_id" binding:"min=0"`
+	DeviceID  string `form:"device_id"`
+	ClientIP  string `form:"client_ip"`
+	StartDate string `form:"start_date"`
+	EndDate   string `form:"end_date"`
+	Page      int32  `form:"page"`
+	PageSize  int32  `form:"page_size" binding:"max=100"`
+}
@@ -0,0 +1,17 @@
+package request
+
+// DLQListRequest 死信列表查询参数
+type DLQListRequest struct {
+	Group    string `form:"group"`                                                       // 消费组
+	Topic    string `form:"topic"`                                                       // 原主题
+	Class    string `form:"class"`                                                       // 错误分类
+	Status   string `form:"status" binding:"omitempty,oneof=pending replayed discarded"` // 死信状态
+	Page     int    `form:"page" binding:"omitempty,min=1"`                              // 页码
+	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=100"`                 // 每页数量
+}
+
+// DLQStatsRequest 死信统计查询参数
+type DLQStatsRequest struct {
+	Group string `form:"group"` // 消费组
+	Topic string `form:"topic"` // 原主题
+}
@@ -0,0 +1,9 @@
+package request
+
+// JobListRequest 延时任务列表查询参数
+type JobListRequest struct {
+	Type     string `form:"type"`                                                                       // 任务类型
+	Status   string `form:"status" binding:"omitempty,oneof=pending running succeeded failed canceled"` // 任务状态
+	Page     int    `form:"page" binding:"omitempty,min=1"`                                             // 页码
+	PageSize int    `form:"page_size" binding:"omitempty,min=1,max=100"`                                // 每页数量
+}
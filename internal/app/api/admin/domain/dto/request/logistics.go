@@ -0,0 +1,37 @@
+package request
+
+// ShippingRateConfigListRequest 运费配置版本列表查询参数
+type ShippingRateConfigListRequest struct {
+	Page     int32 `form:"page"`      // 页码
+	PageSize int32 `form:"page_size"` // 每页数量
+}
+
+// CreateShippingRateConfigRequest 创建运费配置请求
+type CreateShippingRateConfigRequest struct {
+	Content  string `json:"content" binding:"required"` // 配置内容(JSON)
+	Remark   string `json:"remark"`                     // 备注
+	Activate bool   `json:"activate"`                   // 是否立即生效
+}
+
+// QuoteShippingFeeRequest 运费试算请求
+type QuoteShippingFeeRequest struct {
+	LogisticsCompany int32               `json:"logistics_company"`
+	ShippingMethod   int32               `json:"shipping_method" binding:"required,min=1"`
+	SenderAddress    string              `json:"sender_address"`
+	ReceiverAddress  string              `json:"receiver_address" binding:"required"`
+	TotalWeight      float64             `json:"total_weight" binding:"min=0"`
+	TotalVolume      float64             `json:"total_volume" binding:"min=0"`
+	OrderAmount      float64             `json:"order_amount" binding:"min=0"`
+	// FreeShipCoupon 试算使用包邮券后的运费，仅用于后台试算，不核销任何优惠券
+	FreeShipCoupon   bool                `json:"free_ship_coupon"`
+	Items            []QuoteShippingItem `json:"items" binding:"dive"`
+}
+
+// QuoteShippingItem 运费试算商品项
+type QuoteShippingItem struct {
+	GoodsID  int32   `json:"goods_id"`
+	Quantity int32   `json:"quantity" binding:"min=1"`
+	Weight   float64 `json:"weight" binding:"min=0"`
+	Volume   float64 `json:"volume" binding:"min=0"`
+	ShipFree bool    `json:"ship_free"`
+}
@@ -9,6 +9,9 @@ func NewAdminHTTPServer(cfg *config.Config) (*restserver.Server, error) {
 	urestServer := restserver.NewServer(restserver.WithPort(cfg.Server.HttpPort),
 		restserver.WithMiddlewares(cfg.Server.Middlewares),
 		restserver.WithMetrics(cfg.Server.EnableMetrics),
+		restserver.WithAdaptiveLimit(cfg.Server.Limiter.AdaptiveLimiter(), nil),
+		restserver.WithLogAdmin(cfg.Server.LogAdminSecret),
+		restserver.WithHealthz(cfg.Server.EnableHealthCheck),
 	)
 
 	//配置好路由
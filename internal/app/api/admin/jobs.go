@@ -0,0 +1,30 @@
+package admin
+
+import (
+	"fmt"
+
+	"emshop/internal/app/api/admin/config"
+	"emshop/pkg/delayjob"
+	"emshop/pkg/log"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// NewJobStores 按配置的命名空间创建延时任务存储，未配置命名空间时不连接Redis
+func NewJobStores(cfg *config.Config) map[string]delayjob.Store {
+	stores := make(map[string]delayjob.Store, len(cfg.DelayJob.Namespaces))
+	if len(cfg.DelayJob.Namespaces) == 0 {
+		return stores
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port),
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.Database,
+	})
+	for _, ns := range cfg.DelayJob.Namespaces {
+		stores[ns] = delayjob.NewRedisStore(client, cfg.DelayJob.StoreOptions(ns))
+	}
+	log.Infof("延时任务管理已启用, namespaces: %v", cfg.DelayJob.Namespaces)
+	return stores
+}
@@ -7,6 +7,9 @@ import (
     "emshop/internal/app/api/admin/controller/export/v1"
     "emshop/internal/app/api/admin/controller/goods/v1"
     "emshop/internal/app/api/admin/controller/coupon/v1"
+    "emshop/internal/app/api/admin/controller/logistics/v1"
+    "emshop/internal/app/api/admin/controller/job/v1"
+    deadletter "emshop/internal/app/api/admin/controller/dlq/v1"
     import_controller "emshop/internal/app/api/admin/controller/import/v1"
     "emshop/internal/app/api/admin/controller/order/v1"
     "emshop/internal/app/api/admin/controller/upload/v1"
@@ -29,7 +32,7 @@ func initRouter(g *restserver.Server, cfg *config.Config) {
 	}
 	
 	// 创建服务工厂
-	serviceFactory := service.NewService(data, cfg.Jwt)
+	serviceFactory := service.NewService(data, cfg.Jwt, NewJobStores(cfg), NewDLQManagers(cfg))
 	
 	// 创建管理员认证中间件
 	adminAuth := middleware.AdminAuth(cfg.Jwt)
@@ -154,12 +157,70 @@ func initRouter(g *restserver.Server, cfg *config.Config) {
 			ordersGroup.GET("/by-user/:user_id", orderController.GetOrdersByUserId) // GET /v1/admin/orders/by-user/:user_id 按用户ID查询
 		}
 
-		// 优惠券管理（模板）
+		// 优惠券管理（模板、业务规则、兑换码）
 		couponController := coupon.NewCouponController(g.Translator(), serviceFactory)
 		couponsGroup := adminGroup.Group("/coupons")
 		{
 			// 确保存在一个可用模板：没有则创建默认模板
 			couponsGroup.POST("/templates/ensure-default", couponController.EnsureDefaultTemplate)
+			couponsGroup.GET("/rules", couponController.GetRules)               // GET /v1/admin/coupons/rules 当前生效的业务规则参数
+			couponsGroup.PUT("/rules", couponController.UpdateRules)            // PUT /v1/admin/coupons/rules 更新业务规则参数
+			couponsGroup.POST("/rules/dry-run", couponController.DryRunRules)   // POST /v1/admin/coupons/rules/dry-run 候选规则试算
+			couponsGroup.POST("/promo-codes/generate", couponController.GeneratePromoCodes)    // POST /v1/admin/coupons/promo-codes/generate 批量生成兑换码
+			couponsGroup.POST("/promo-codes/public", couponController.CreatePublicPromoCode)   // POST /v1/admin/coupons/promo-codes/public 创建公开兑换码
+			couponsGroup.GET("/promo-codes", couponController.ListPromoCodes)                  // GET /v1/admin/coupons/promo-codes 兑换码列表
+			couponsGroup.GET("/promo-codes/export", couponController.ExportPromoCodes)         // GET /v1/admin/coupons/promo-codes/export 导出兑换码CSV
+			couponsGroup.POST("/promo-codes/:code/disable", couponController.DisablePromoCode) // POST /v1/admin/coupons/promo-codes/:code/disable 停用兑换码
+			couponsGroup.POST("/campaigns", couponController.CreateCampaign)                   // POST /v1/admin/coupons/campaigns 创建定向发券活动
+			couponsGroup.GET("/campaigns", couponController.ListCampaigns)                     // GET /v1/admin/coupons/campaigns 活动列表
+			couponsGroup.GET("/campaigns/:id", couponController.GetCampaign)                   // GET /v1/admin/coupons/campaigns/:id 活动详情及发放进度
+			couponsGroup.POST("/campaigns/:id/targets", couponController.UploadCampaignTargets) // POST /v1/admin/coupons/campaigns/:id/targets 上传CSV追加目标用户
+			couponsGroup.POST("/campaigns/:id/start", couponController.StartCampaign)          // POST /v1/admin/coupons/campaigns/:id/start 开始发放
+			couponsGroup.POST("/campaigns/:id/pause", couponController.PauseCampaign)          // POST /v1/admin/coupons/campaigns/:id/pause 暂停发放
+			couponsGroup.POST("/campaigns/:id/resume", couponController.StartCampaign)         // POST /v1/admin/coupons/campaigns/:id/resume 恢复发放
+			couponsGroup.POST("/campaigns/:id/cancel", couponController.CancelCampaign)        // POST /v1/admin/coupons/campaigns/:id/cancel 取消活动
+			couponsGroup.POST("/campaigns/:id/rerun", couponController.RerunCampaign)          // POST /v1/admin/coupons/campaigns/:id/rerun 重新发放失败目标
+			couponsGroup.GET("/reports/templates", couponController.GetCouponReport)             // GET /v1/admin/coupons/reports/templates 优惠券模板效果报表
+			couponsGroup.GET("/reports/templates/export", couponController.ExportCouponReport)   // GET /v1/admin/coupons/reports/templates/export 导出模板效果报表CSV
+			couponsGroup.GET("/reports/flash-sales", couponController.GetFlashSaleReport)        // GET /v1/admin/coupons/reports/flash-sales 秒杀售罄报表
+			couponsGroup.GET("/reports/flash-sales/export", couponController.ExportFlashSaleReport) // GET /v1/admin/coupons/reports/flash-sales/export 导出秒杀售罄报表CSV
+			couponsGroup.POST("/reports/rebuild", couponController.RebuildCouponReport)          // POST /v1/admin/coupons/reports/rebuild 重建每日统计
+			couponsGroup.GET("/risk/decisions", couponController.ListRiskDecisions)              // GET /v1/admin/coupons/risk/decisions 风控决策记录
+		}
+
+		// 物流运费规则管理
+		logisticsController := logistics.NewLogisticsController(serviceFactory, g.Translator())
+		logisticsGroup := adminGroup.Group("/logistics")
+		{
+			logisticsGroup.GET("/rate-configs", logisticsController.ListRateConfigs)                        // GET /v1/admin/logistics/rate-configs 运费配置版本列表
+			logisticsGroup.GET("/rate-configs/active", logisticsController.GetActiveRateConfig)             // GET /v1/admin/logistics/rate-configs/active 当前生效配置
+			logisticsGroup.GET("/rate-configs/:version", logisticsController.GetRateConfig)                 // GET /v1/admin/logistics/rate-configs/:version 指定版本配置
+			logisticsGroup.POST("/rate-configs", logisticsController.CreateRateConfig)                      // POST /v1/admin/logistics/rate-configs 创建配置版本
+			logisticsGroup.POST("/rate-configs/:version/activate", logisticsController.ActivateRateConfig) // POST /v1/admin/logistics/rate-configs/:version/activate 生效/回滚版本
+			logisticsGroup.POST("/shipping-fee/quote", logisticsController.QuoteShippingFee)               // POST /v1/admin/logistics/shipping-fee/quote 运费试算
+		}
+
+		// 延时任务管理
+		jobController := job.NewJobController(serviceFactory, g.Translator())
+		jobsGroup := adminGroup.Group("/jobs")
+		{
+			jobsGroup.GET("", jobController.Namespaces)                  // GET /v1/admin/jobs 可查看的命名空间
+			jobsGroup.GET("/:namespace", jobController.List)             // GET /v1/admin/jobs/:namespace?type=&status= 任务列表
+			jobsGroup.GET("/:namespace/:id", jobController.Detail)       // GET /v1/admin/jobs/:namespace/:id 任务详情及执行记录
+			jobsGroup.POST("/:namespace/:id/retry", jobController.Retry) // POST /v1/admin/jobs/:namespace/:id/retry 重新执行
+			jobsGroup.POST("/:namespace/:id/cancel", jobController.Cancel) // POST /v1/admin/jobs/:namespace/:id/cancel 取消任务
+		}
+
+		// 消息死信管理
+		dlqController := deadletter.NewDLQController(serviceFactory, g.Translator())
+		dlqGroup := adminGroup.Group("/dlq")
+		{
+			dlqGroup.GET("", dlqController.Namespaces)                      // GET /v1/admin/dlq 可查看的命名空间
+			dlqGroup.GET("/:namespace", dlqController.List)                 // GET /v1/admin/dlq/:namespace?group=&topic=&class=&status= 死信列表
+			dlqGroup.GET("/:namespace/stats", dlqController.Stats)          // GET /v1/admin/dlq/:namespace/stats 按错误分类统计
+			dlqGroup.GET("/:namespace/:id", dlqController.Detail)           // GET /v1/admin/dlq/:namespace/:id 死信详情及消息体
+			dlqGroup.POST("/:namespace/:id/replay", dlqController.Replay)   // POST /v1/admin/dlq/:namespace/:id/replay 重放给原消费者组
+			dlqGroup.POST("/:namespace/:id/discard", dlqController.Discard) // POST /v1/admin/dlq/:namespace/:id/discard 丢弃死信
 		}
 	}
 }
@@ -0,0 +1,367 @@
+package coupon
+
+import (
+    "context"
+    "encoding/csv"
+    "encoding/json"
+    "io"
+    "strconv"
+    "strings"
+    "time"
+
+    cpbv1 "emshop/api/coupon/v1"
+    gpbv1 "emshop/api/goods/v1"
+    opbv1 "emshop/api/order/v1"
+    upbv1 "emshop/api/user/v1"
+    uoppbv1 "emshop/api/userop/v1"
+    "emshop/gin-micro/code"
+    "emshop/pkg/errors"
+    "emshop/pkg/log"
+)
+
+// 目标用户圈选方式，与优惠券服务保持一致
+const (
+    SegmentUserIDs     = "user_ids"
+    SegmentRegistered  = "registered"
+    SegmentInactive    = "inactive"
+    SegmentFavCategory = "fav_category"
+)
+
+const (
+    // segmentPageSize 圈选时每次从下游服务拉取的ID数量
+    segmentPageSize = 5000
+    // targetChunkSize 每次向活动追加的目标用户数量
+    targetChunkSize = 5000
+    // favGoodsChunkSize 按分类圈选时每次查询收藏的商品数量
+    favGoodsChunkSize = 500
+    goodsPageSize     = 100
+    // rollbackTimeout 圈选失败后删除草稿活动的超时时间，不受已取消的请求上下文影响
+    rollbackTimeout = 5 * time.Second
+)
+
+// CampaignSegment 活动目标用户圈选条件
+type CampaignSegment struct {
+    Type           string  `json:"type"`
+    UserIDs        []int64 `json:"-"`                         // user_ids: 上传的用户ID
+    RegisteredFrom int64   `json:"registered_from,omitempty"` // registered: 注册时间起(Unix秒)
+    RegisteredTo   int64   `json:"registered_to,omitempty"`   // registered: 注册时间止(Unix秒，不含)
+    InactiveDays   int32   `json:"inactive_days,omitempty"`   // inactive: 最近N天未下单
+    CategoryID     int32   `json:"category_id,omitempty"`     // fav_category: 收藏过该一级分类下的商品
+}
+
+// Validate 检查圈选条件
+func (s *CampaignSegment) Validate() error {
+    switch s.Type {
+    case SegmentUserIDs:
+        return nil
+    case SegmentRegistered:
+        if s.RegisteredFrom <= 0 && s.RegisteredTo <= 0 {
+            return errors.WithCode(code.ErrValidation, "registered_from和registered_to至少指定一个")
+        }
+        if s.RegisteredFrom > 0 && s.RegisteredTo > 0 && s.RegisteredFrom >= s.RegisteredTo {
+            return errors.WithCode(code.ErrValidation, "registered_from必须早于registered_to")
+        }
+    case SegmentInactive:
+        if s.InactiveDays <= 0 {
+            return errors.WithCode(code.ErrValidation, "inactive_days必须大于0")
+        }
+    case SegmentFavCategory:
+        if s.CategoryID <= 0 {
+            return errors.WithCode(code.ErrValidation, "category_id必须大于0")
+        }
+    default:
+        return errors.WithCode(code.ErrValidation, "不支持的圈选方式: %s", s.Type)
+    }
+    return nil
+}
+
+// ParseUserIDCSV 解析上传的用户ID文件，取每行第一列，忽略表头和空行
+func ParseUserIDCSV(r io.Reader) ([]int64, error) {
+    reader := csv.NewReader(r)
+    reader.FieldsPerRecord = -1
+    reader.TrimLeadingSpace = true
+
+    var ids []int64
+    line := 0
+    for {
+        record, err := reader.Read()
+        if err == io.EOF {
+            break
+        }
+        if err != nil {
+            return nil, errors.WithCode(code.ErrValidation, "解析CSV失败: %v", err)
+        }
+        line++
+        if len(record) == 0 {
+            continue
+        }
+        field := strings.TrimSpace(strings.TrimPrefix(record[0], "\ufeff"))
+        if field == "" {
+            continue
+        }
+        id, err := strconv.ParseInt(field, 10, 64)
+        if err != nil {
+            if line == 1 {
+                continue
+            }
+            return nil, errors.WithCode(code.ErrValidation, "第%d行用户ID无效: %s", line, field)
+        }
+        if id > 0 {
+            ids = append(ids, id)
+        }
+    }
+    return ids, nil
+}
+
+// CreateCampaign 创建活动并按圈选条件追加目标用户，返回带发放进度的活动
+//
+// 圈选失败时删除已创建的草稿活动及已追加的目标，不留下目标不完整的活动
+func (s *couponService) CreateCampaign(ctx context.Context, req *cpbv1.CreateCouponCampaignRequest, segment *CampaignSegment) (*cpbv1.CouponCampaignResponse, error) {
+    if err := segment.Validate(); err != nil {
+        return nil, err
+    }
+    params, _ := json.Marshal(segment)
+    req.SegmentType = segment.Type
+    req.SegmentParams = string(params)
+
+    campaign, err := s.data.Coupon().CreateCouponCampaign(ctx, req)
+    if err != nil {
+        return nil, err
+    }
+
+    added, err := s.appendSegment(ctx, campaign.Id, segment)
+    if err != nil {
+        log.ErrorfC(ctx, "[admin] Resolve campaign segment failed: campaignId=%d, segment=%s, added=%d, err=%v",
+            campaign.Id, segment.Type, added, err)
+        rollbackCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), rollbackTimeout)
+        defer cancel()
+        if derr := s.data.Coupon().DeleteCouponCampaign(rollbackCtx, campaign.Id); derr != nil {
+            log.ErrorfC(ctx, "[admin] Rollback campaign draft failed, cancel it manually: campaignId=%d, err=%v", campaign.Id, derr)
+        }
+        return nil, err
+    }
+    log.InfofC(ctx, "[admin] Campaign %d created with %d targets (segment=%s)", campaign.Id, added, segment.Type)
+    return s.data.Coupon().GetCouponCampaign(ctx, campaign.Id)
+}
+
+// AddCampaignTargets 分批向活动追加目标用户，返回新增数量
+func (s *couponService) AddCampaignTargets(ctx context.Context, campaignID int64, userIDs []int64) (int64, error) {
+    var added int64
+    for start := 0; start < len(userIDs); start += targetChunkSize {
+        end := start + targetChunkSize
+        if end > len(userIDs) {
+            end = len(userIDs)
+        }
+        n, err := s.data.Coupon().AddCouponCampaignTargets(ctx, campaignID, userIDs[start:end])
+        if err != nil {
+            return added, err
+        }
+        added += n
+    }
+    return added, nil
+}
+
+func (s *couponService) GetCampaign(ctx context.Context, id int64) (*cpbv1.CouponCampaignResponse, error) {
+    return s.data.Coupon().GetCouponCampaign(ctx, id)
+}
+
+func (s *couponService) ListCampaigns(ctx context.Context, req *cpbv1.ListCouponCampaignsRequest) (*cpbv1.ListCouponCampaignsResponse, error) {
+    return s.data.Coupon().ListCouponCampaigns(ctx, req)
+}
+
+func (s *couponService) StartCampaign(ctx context.Context, id int64) error {
+    return s.data.Coupon().StartCouponCampaign(ctx, id)
+}
+
+func (s *couponService) PauseCampaign(ctx context.Context, id int64) error {
+    return s.data.Coupon().PauseCouponCampaign(ctx, id)
+}
+
+func (s *couponService) CancelCampaign(ctx context.Context, id int64) error {
+    return s.data.Coupon().CancelCouponCampaign(ctx, id)
+}
+
+func (s *couponService) RerunCampaign(ctx context.Context, id int64) (int64, error) {
+    return s.data.Coupon().RerunCouponCampaign(ctx, id)
+}
+
+// appendSegment 将圈选结果逐页追加到活动，不在网关内存中保存完整名单
+func (s *couponService) appendSegment(ctx context.Context, campaignID int64, segment *CampaignSegment) (int64, error) {
+    var added int64
+    emit := func(ids []int32) error {
+        if len(ids) == 0 {
+            return nil
+        }
+        userIDs := make([]int64, 0, len(ids))
+        for _, id := range ids {
+            userIDs = append(userIDs, int64(id))
+        }
+        n, err := s.AddCampaignTargets(ctx, campaignID, userIDs)
+        added += n
+        return err
+    }
+
+    var err error
+    switch segment.Type {
+    case SegmentUserIDs:
+        added, err = s.AddCampaignTargets(ctx, campaignID, segment.UserIDs)
+    case SegmentRegistered:
+        err = s.eachPage(s.registeredPager(ctx, segment.RegisteredFrom, segment.RegisteredTo), emit)
+    case SegmentInactive:
+        since := time.Now().AddDate(0, 0, -int(segment.InactiveDays)).Unix()
+        err = excludeSorted(s.registeredPager(ctx, 0, 0), s.orderedPager(ctx, since), emit)
+    case SegmentFavCategory:
+        err = s.appendFavCategory(ctx, segment.CategoryID, emit)
+    }
+    return added, err
+}
+
+// idPager 按ID升序分页拉取，after为上一页最后一个ID，返回空页表示结束
+type idPager func(after int32) ([]int32, error)
+
+func (s *couponService) registeredPager(ctx context.Context, from, to int64) idPager {
+    return func(after int32) ([]int32, error) {
+        resp, err := s.data.Users().ListUserIds(ctx, &upbv1.ListUserIdsRequest{
+            RegisteredFrom: from,
+            RegisteredTo:   to,
+            AfterId:        after,
+            Limit:          segmentPageSize,
+        })
+        if err != nil {
+            return nil, err
+        }
+        return resp.Ids, nil
+    }
+}
+
+func (s *couponService) orderedPager(ctx context.Context, since int64) idPager {
+    return func(after int32) ([]int32, error) {
+        resp, err := s.data.Order().ListOrderedUserIds(ctx, &opbv1.ListOrderedUserIdsRequest{
+            Since:       since,
+            AfterUserId: after,
+            Limit:       segmentPageSize,
+        })
+        if err != nil {
+            return nil, err
+        }
+        return resp.UserIds, nil
+    }
+}
+
+func (s *couponService) favPager(ctx context.Context, goodsIDs []int32) idPager {
+    return func(after int32) ([]int32, error) {
+        resp, err := s.data.UserOp().ListFavUserIds(ctx, &uoppbv1.ListFavUserIdsRequest{
+            GoodsIds:    goodsIDs,
+            AfterUserId: after,
+            Limit:       segmentPageSize,
+        })
+        if err != nil {
+            return nil, err
+        }
+        return resp.UserIds, nil
+    }
+}
+
+func (s *couponService) eachPage(pager idPager, emit func([]int32) error) error {
+    var after int32
+    for {
+        ids, err := pager(after)
+        if err != nil {
+            return err
+        }
+        if len(ids) == 0 {
+            return nil
+        }
+        if err := emit(ids); err != nil {
+            return err
+        }
+        after = ids[len(ids)-1]
+    }
+}
+
+// excludeSorted 输出all中不在exclude中的ID，两个序列均按ID升序，归并过程中只保留当前页
+func excludeSorted(all, exclude idPager, emit func([]int32) error) error {
+    var excludeBuf []int32
+    var excludeAfter int32
+    excludeDone := false
+
+    var after int32
+    for {
+        ids, err := all(after)
+        if err != nil {
+            return err
+        }
+        if len(ids) == 0 {
+            return nil
+        }
+
+        kept := make([]int32, 0, len(ids))
+        for _, id := range ids {
+            for {
+                // 丢弃已越过的排除ID，缓冲区耗尽时拉取下一页
+                for len(excludeBuf) > 0 && excludeBuf[0] < id {
+                    excludeBuf = excludeBuf[1:]
+                }
+                if len(excludeBuf) > 0 || excludeDone {
+                    break
+                }
+                page, err := exclude(excludeAfter)
+                if err != nil {
+                    return err
+                }
+                if len(page) == 0 {
+                    excludeDone = true
+                    break
+                }
+                excludeBuf = page
+                excludeAfter = page[len(page)-1]
+            }
+            if len(excludeBuf) > 0 && excludeBuf[0] == id {
+                continue
+            }
+            kept = append(kept, id)
+        }
+        if err := emit(kept); err != nil {
+            return err
+        }
+        after = ids[len(ids)-1]
+    }
+}
+
+// appendFavCategory 拉取分类下的商品，分组查询收藏过这些商品的用户
+//
+// 不同商品组可能返回相同用户，重复的目标由优惠券服务按(活动, 用户)去重
+func (s *couponService) appendFavCategory(ctx context.Context, categoryID int32, emit func([]int32) error) error {
+    var goodsIDs []int32
+    for page := int32(1); ; page++ {
+        pages, perNums := page, int32(goodsPageSize)
+        resp, err := s.data.Goods().GoodsList(ctx, &gpbv1.GoodsFilterRequest{
+            TopCategory: &categoryID,
+            Pages:       &pages,
+            PagePerNums: &perNums,
+        })
+        if err != nil {
+            return err
+        }
+        for _, g := range resp.Data {
+            goodsIDs = append(goodsIDs, g.Id)
+        }
+        if len(resp.Data) < goodsPageSize || int32(len(goodsIDs)) >= resp.Total {
+            break
+        }
+    }
+    if len(goodsIDs) == 0 {
+        return errors.WithCode(code.ErrValidation, "分类%d下没有商品", categoryID)
+    }
+
+    for start := 0; start < len(goodsIDs); start += favGoodsChunkSize {
+        end := start + favGoodsChunkSize
+        if end > len(goodsIDs) {
+            end = len(goodsIDs)
+        }
+        if err := s.eachPage(s.favPager(ctx, goodsIDs[start:end]), emit); err != nil {
+            return err
+        }
+    }
+    return nil
+}
@@ -0,0 +1,56 @@
+package coupon
+
+import (
+    "strings"
+    "testing"
+
+    "github.com/stretchr/testify/assert"
+)
+
+// slicePager 将有序切片按pageSize分页
+func slicePager(ids []int32, pageSize int) idPager {
+    return func(after int32) ([]int32, error) {
+        start := 0
+        for start < len(ids) && ids[start] <= after {
+            start++
+        }
+        end := start + pageSize
+        if end > len(ids) {
+            end = len(ids)
+        }
+        return ids[start:end], nil
+    }
+}
+
+func TestExcludeSorted(t *testing.T) {
+    all := []int32{1, 2, 3, 5, 8, 9, 10, 12, 15}
+    exclude := []int32{2, 4, 8, 10, 11, 15, 20}
+
+    for _, pageSize := range []int{1, 2, 3, 100} {
+        var got []int32
+        err := excludeSorted(slicePager(all, pageSize), slicePager(exclude, pageSize+1), func(ids []int32) error {
+            got = append(got, ids...)
+            return nil
+        })
+        assert.NoError(t, err)
+        assert.Equal(t, []int32{1, 3, 5, 9, 12}, got, "pageSize=%d", pageSize)
+    }
+}
+
+func TestParseUserIDCSV(t *testing.T) {
+    ids, err := ParseUserIDCSV(strings.NewReader("\ufeffuser_id,name\n1001,a\n\n 1002 \n1001\n"))
+    assert.NoError(t, err)
+    assert.Equal(t, []int64{1001, 1002, 1001}, ids)
+
+    _, err = ParseUserIDCSV(strings.NewReader("1001\nabc\n"))
+    assert.Error(t, err)
+}
+
+func TestCampaignSegmentValidate(t *testing.T) {
+    assert.NoError(t, (&CampaignSegment{Type: SegmentUserIDs}).Validate())
+    assert.NoError(t, (&CampaignSegment{Type: SegmentInactive, InactiveDays: 30}).Validate())
+    assert.Error(t, (&CampaignSegment{Type: SegmentInactive}).Validate())
+    assert.Error(t, (&CampaignSegment{Type: SegmentRegistered, RegisteredFrom: 200, RegisteredTo: 100}).Validate())
+    assert.Error(t, (&CampaignSegment{Type: SegmentFavCategory}).Validate())
+    assert.Error(t, (&CampaignSegment{Type: "vip"}).Validate())
+}
@@ -13,6 +13,49 @@ import (
 type CouponSrv interface {
     // EnsureDefaultTemplate 检查是否存在可用模板，不存在则创建一个默认模板并返回
     EnsureDefaultTemplate(ctx context.Context) (*cpbv1.CouponTemplateResponse, error)
+
+    // GetRuleConfig 获取当前生效的业务规则参数(JSON)
+    GetRuleConfig(ctx context.Context) (string, error)
+    // UpdateRuleConfig 更新业务规则参数，返回保存后的完整参数(JSON)
+    UpdateRuleConfig(ctx context.Context, rules string) (string, error)
+    // DryRunRules 使用候选规则参数试算样例订单
+    DryRunRules(ctx context.Context, req *cpbv1.DryRunCouponRulesRequest) (*cpbv1.DryRunCouponRulesResponse, error)
+
+    // GeneratePromoCodes 为模板批量生成一次性兑换码
+    GeneratePromoCodes(ctx context.Context, req *cpbv1.GeneratePromoCodesRequest) (*cpbv1.GeneratePromoCodesResponse, error)
+    // CreatePublicPromoCode 创建公开兑换码
+    CreatePublicPromoCode(ctx context.Context, req *cpbv1.CreatePublicPromoCodeRequest) (*cpbv1.PromoCodeResponse, error)
+    // ListPromoCodes 分页查询兑换码
+    ListPromoCodes(ctx context.Context, req *cpbv1.ListPromoCodesRequest) (*cpbv1.ListPromoCodesResponse, error)
+    // DisablePromoCode 停用兑换码
+    DisablePromoCode(ctx context.Context, code string) error
+
+    // CreateCampaign 创建定向发券活动，并将圈选出的用户追加为目标
+    CreateCampaign(ctx context.Context, req *cpbv1.CreateCouponCampaignRequest, segment *CampaignSegment) (*cpbv1.CouponCampaignResponse, error)
+    // AddCampaignTargets 追加目标用户，返回新增数量
+    AddCampaignTargets(ctx context.Context, campaignID int64, userIDs []int64) (int64, error)
+    // GetCampaign 查询活动及发放进度
+    GetCampaign(ctx context.Context, id int64) (*cpbv1.CouponCampaignResponse, error)
+    // ListCampaigns 分页查询活动
+    ListCampaigns(ctx context.Context, req *cpbv1.ListCouponCampaignsRequest) (*cpbv1.ListCouponCampaignsResponse, error)
+    // StartCampaign 开始或恢复发放
+    StartCampaign(ctx context.Context, id int64) error
+    // PauseCampaign 暂停发放
+    PauseCampaign(ctx context.Context, id int64) error
+    // CancelCampaign 取消活动
+    CancelCampaign(ctx context.Context, id int64) error
+    // RerunCampaign 重新发放失败的目标，返回恢复数量
+    RerunCampaign(ctx context.Context, id int64) (int64, error)
+
+    // GetCouponReport 查询优惠券效果报表，日期格式2006-01-02，templateID为0时统计全部模板并对比未用券客单价
+    GetCouponReport(ctx context.Context, templateID int64, startDate, endDate string) (*CouponReport, error)
+    // GetFlashSaleReport 查询秒杀每日售罄情况，flashSaleID为0时查询全部活动
+    GetFlashSaleReport(ctx context.Context, flashSaleID int64, startDate, endDate string) ([]*cpbv1.FlashSaleStat, error)
+    // RebuildReport 重建日期范围内的每日统计，返回重建天数
+    RebuildReport(ctx context.Context, startDate, endDate string) (int32, error)
+
+    // ListRiskDecisions 查询风控决策记录，日期格式2006-01-02，为空时不限，结束日期包含在内
+    ListRiskDecisions(ctx context.Context, req *cpbv1.ListRiskDecisionsRequest, startDate, endDate string) (*cpbv1.ListRiskDecisionsResponse, error)
 }
 
 type couponService struct {
@@ -37,7 +80,7 @@ func (s *couponService) EnsureDefaultTemplate(ctx context.Context) (*cpbv1.Coupo
     }
     if listResp != nil && listResp.TotalCount > 0 && len(listResp.Items) > 0 {
         // 已有可用模板，返回第一条
-        log.Infof("[admin] Found existing coupon template id=%d", listResp.Items[0].Id)
+        log.InfofC(ctx, "[admin] Found existing coupon template id=%d", listResp.Items[0].Id)
         return listResp.Items[0], nil
     }
 
@@ -60,3 +103,38 @@ func (s *couponService) EnsureDefaultTemplate(ctx context.Context) (*cpbv1.Coupo
     return s.data.Coupon().CreateCouponTemplate(ctx, createReq)
 }
 
+func (s *couponService) GetRuleConfig(ctx context.Context) (string, error) {
+    resp, err := s.data.Coupon().GetCouponRuleConfig(ctx)
+    if err != nil {
+        return "", err
+    }
+    return resp.Rules, nil
+}
+
+func (s *couponService) UpdateRuleConfig(ctx context.Context, rules string) (string, error) {
+    resp, err := s.data.Coupon().UpdateCouponRuleConfig(ctx, &cpbv1.UpdateCouponRuleConfigRequest{Rules: rules})
+    if err != nil {
+        return "", err
+    }
+    return resp.Rules, nil
+}
+
+func (s *couponService) DryRunRules(ctx context.Context, req *cpbv1.DryRunCouponRulesRequest) (*cpbv1.DryRunCouponRulesResponse, error) {
+    return s.data.Coupon().DryRunCouponRules(ctx, req)
+}
+
+func (s *couponService) GeneratePromoCodes(ctx context.Context, req *cpbv1.GeneratePromoCodesRequest) (*cpbv1.GeneratePromoCodesResponse, error) {
+    return s.data.Coupon().GeneratePromoCodes(ctx, req)
+}
+
+func (s *couponService) CreatePublicPromoCode(ctx context.Context, req *cpbv1.CreatePublicPromoCodeRequest) (*cpbv1.PromoCodeResponse, error) {
+    return s.data.Coupon().CreatePublicPromoCode(ctx, req)
+}
+
+func (s *couponService) ListPromoCodes(ctx context.Context, req *cpbv1.ListPromoCodesRequest) (*cpbv1.ListPromoCodesResponse, error) {
+    return s.data.Coupon().ListPromoCodes(ctx, req)
+}
+
+func (s *couponService) DisablePromoCode(ctx context.Context, code string) error {
+    return s.data.Coupon().DisablePromoCode(ctx, code)
+}
@@ -0,0 +1,137 @@
+package coupon
+
+import (
+    "context"
+    "math"
+    "time"
+
+    cpbv1 "emshop/api/coupon/v1"
+    opbv1 "emshop/api/order/v1"
+    "emshop/gin-micro/code"
+    "emshop/pkg/errors"
+)
+
+// ReportDateLayout 报表日期参数格式
+const ReportDateLayout = "2006-01-02"
+
+// CouponReportDay 优惠券效果统计，全部模板的报表附带同期全部订单用于对比用券与未用券的客单价
+type CouponReportDay struct {
+    Stat                        *cpbv1.CouponStat
+    TotalOrderCount             int64   // 同期全部订单数(不含已关闭)
+    TotalOrderAmount            float64 // 同期全部订单金额
+    AvgOrderAmountWithoutCoupon float64 // 未用券订单平均金额
+}
+
+// CouponReport 优惠券效果报表
+type CouponReport struct {
+    Days      []*CouponReportDay
+    Templates []*cpbv1.CouponStat
+    Total     *CouponReportDay
+}
+
+// parseReportDates 校验日期参数，返回[start, end+1天)的时间范围
+func parseReportDates(startDate, endDate string) (time.Time, time.Time, error) {
+    start, err := time.ParseInLocation(ReportDateLayout, startDate, time.Local)
+    if err != nil {
+        return time.Time{}, time.Time{}, errors.WithCode(code.ErrValidation, "开始日期格式错误，应为2006-01-02")
+    }
+    end, err := time.ParseInLocation(ReportDateLayout, endDate, time.Local)
+    if err != nil {
+        return time.Time{}, time.Time{}, errors.WithCode(code.ErrValidation, "结束日期格式错误，应为2006-01-02")
+    }
+    if end.Before(start) {
+        return time.Time{}, time.Time{}, errors.WithCode(code.ErrValidation, "结束日期不能早于开始日期")
+    }
+    return start, end.AddDate(0, 0, 1), nil
+}
+
+func (s *couponService) GetCouponReport(ctx context.Context, templateID int64, startDate, endDate string) (*CouponReport, error) {
+    start, end, err := parseReportDates(startDate, endDate)
+    if err != nil {
+        return nil, err
+    }
+
+    resp, err := s.data.Coupon().GetCouponReport(ctx, &cpbv1.CouponReportRequest{
+        CouponTemplateId: templateID,
+        StartDate:        startDate,
+        EndDate:          endDate,
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    report := &CouponReport{
+        Days:      make([]*CouponReportDay, 0, len(resp.Days)),
+        Templates: resp.Templates,
+        Total:     &CouponReportDay{Stat: resp.Total},
+    }
+    for _, day := range resp.Days {
+        report.Days = append(report.Days, &CouponReportDay{Stat: day})
+    }
+    if report.Total.Stat == nil {
+        report.Total.Stat = &cpbv1.CouponStat{}
+    }
+
+    // 单个模板的用券订单与全部订单口径不同，只有全部模板时对比未用券客单价
+    if templateID > 0 {
+        return report, nil
+    }
+    orderStats, err := s.data.Order().GetDailyOrderStats(ctx, &opbv1.DailyOrderStatsRequest{
+        Start: start.Unix(),
+        End:   end.Unix(),
+    })
+    if err != nil {
+        return nil, err
+    }
+    applyOrderStats(report, orderStats.Items)
+    return report, nil
+}
+
+// applyOrderStats 将每日全部订单合入报表，并计算未用券订单的平均金额
+func applyOrderStats(report *CouponReport, orders []*opbv1.DailyOrderStat) {
+    byDate := make(map[string]*opbv1.DailyOrderStat, len(orders))
+    for _, o := range orders {
+        byDate[o.Date] = o
+        report.Total.TotalOrderCount += o.OrderCount
+        report.Total.TotalOrderAmount += o.Amount
+    }
+    for _, day := range report.Days {
+        if o, ok := byDate[day.Stat.Date]; ok {
+            day.TotalOrderCount = o.OrderCount
+            day.TotalOrderAmount = o.Amount
+        }
+        day.AvgOrderAmountWithoutCoupon = avgWithoutCoupon(day)
+    }
+    report.Total.AvgOrderAmountWithoutCoupon = avgWithoutCoupon(report.Total)
+}
+
+func avgWithoutCoupon(day *CouponReportDay) float64 {
+    orders := day.TotalOrderCount - day.Stat.OrderCount
+    amount := day.TotalOrderAmount - day.Stat.Gmv
+    if orders <= 0 || amount <= 0 {
+        return 0
+    }
+    return math.Round(amount/float64(orders)*100) / 100
+}
+
+func (s *couponService) GetFlashSaleReport(ctx context.Context, flashSaleID int64, startDate, endDate string) ([]*cpbv1.FlashSaleStat, error) {
+    if _, _, err := parseReportDates(startDate, endDate); err != nil {
+        return nil, err
+    }
+    resp, err := s.data.Coupon().GetFlashSaleReport(ctx, &cpbv1.FlashSaleReportRequest{
+        FlashSaleId: flashSaleID,
+        StartDate:   startDate,
+        EndDate:     endDate,
+    })
+    if err != nil {
+        return nil, err
+    }
+    return resp.Items, nil
+}
+
+func (s *couponService) RebuildReport(ctx context.Context, startDate, endDate string) (int32, error) {
+    if _, _, err := parseReportDates(startDate, endDate); err != nil {
+        return 0, err
+    }
+    return s.data.Coupon().RebuildCouponReport(ctx, startDate, endDate)
+}
@@ -0,0 +1,42 @@
+package coupon
+
+import (
+    "testing"
+
+    cpbv1 "emshop/api/coupon/v1"
+    opbv1 "emshop/api/order/v1"
+
+    "github.com/stretchr/testify/assert"
+)
+
+func TestParseReportDates(t *testing.T) {
+    start, end, err := parseReportDates("2024-03-01", "2024-03-01")
+    assert.NoError(t, err)
+    assert.Equal(t, 24.0, end.Sub(start).Hours())
+
+    for _, c := range [][2]string{{"", "2024-03-01"}, {"2024-03-01", "03/02"}, {"2024-03-02", "2024-03-01"}} {
+        _, _, err := parseReportDates(c[0], c[1])
+        assert.Error(t, err, "dates=%v", c)
+    }
+}
+
+func TestApplyOrderStats(t *testing.T) {
+    report := &CouponReport{
+        Days: []*CouponReportDay{
+            {Stat: &cpbv1.CouponStat{Date: "2024-03-01", OrderCount: 2, Gmv: 300}},
+            {Stat: &cpbv1.CouponStat{Date: "2024-03-02", OrderCount: 1, Gmv: 100}},
+        },
+        Total: &CouponReportDay{Stat: &cpbv1.CouponStat{OrderCount: 3, Gmv: 400}},
+    }
+    applyOrderStats(report, []*opbv1.DailyOrderStat{
+        {Date: "2024-03-01", OrderCount: 5, Amount: 600},
+        {Date: "2024-03-02", OrderCount: 1, Amount: 100},
+    })
+
+    assert.Equal(t, int64(5), report.Days[0].TotalOrderCount)
+    assert.Equal(t, 100.0, report.Days[0].AvgOrderAmountWithoutCoupon)
+    // 当天全部订单都用了券
+    assert.Zero(t, report.Days[1].AvgOrderAmountWithoutCoupon)
+    assert.Equal(t, int64(6), report.Total.TotalOrderCount)
+    assert.Equal(t, 100.0, report.Total.AvgOrderAmountWithoutCoupon)
+}
@@ -0,0 +1,31 @@
+package coupon
+
+import (
+    "context"
+    "time"
+
+    cpbv1 "emshop/api/coupon/v1"
+    "emshop/gin-micro/code"
+    "emshop/pkg/errors"
+)
+
+func (s *couponService) ListRiskDecisions(ctx context.Context, req *cpbv1.ListRiskDecisionsRequest, startDate, endDate string) (*cpbv1.ListRiskDecisionsResponse, error) {
+    if startDate != "" {
+        start, err := time.ParseInLocation(ReportDateLayout, startDate, time.Local)
+        if err != nil {
+            return nil, errors.WithCode(code.ErrValidation, "开始日期格式错误，应为2006-01-02")
+        }
+        req.StartTime = start.Unix()
+    }
+    if endDate != "" {
+        end, err := time.ParseInLocation(ReportDateLayout, endDate, time.Local)
+        if err != nil {
+            return nil, errors.WithCode(code.ErrValidation, "结束日期格式错误，应为2006-01-02")
+        }
+        req.EndTime = end.AddDate(0, 0, 1).Unix()
+    }
+    if req.StartTime > 0 && req.EndTime > 0 && req.EndTime <= req.StartTime {
+        return nil, errors.WithCode(code.ErrValidation, "结束日期不能早于开始日期")
+    }
+    return s.data.Coupon().ListRiskDecisions(ctx, req)
+}
@@ -0,0 +1,126 @@
+package dlq
+
+import (
+	"context"
+	stderrors "errors"
+	"sort"
+
+	"emshop/internal/app/pkg/code"
+	"emshop/pkg/dlq"
+	"emshop/pkg/errors"
+	"emshop/pkg/log"
+)
+
+// DLQSrv 管理端死信服务
+type DLQSrv interface {
+	// Namespaces 可查看的命名空间
+	Namespaces() []string
+	// List 分页查询死信
+	List(ctx context.Context, namespace string, opts dlq.ListOptions) ([]*dlq.Message, int64, error)
+	// Get 查询死信详情及消息体
+	Get(ctx context.Context, namespace, id string) (*dlq.Message, error)
+	// Stats 按错误分类统计
+	Stats(ctx context.Context, namespace string, opts dlq.ListOptions) ([]dlq.ClassStats, error)
+	// Replay 将待处理的死信重新投递给原消费者组
+	Replay(ctx context.Context, namespace, id, operator string) error
+	// Discard 丢弃待处理的死信
+	Discard(ctx context.Context, namespace, id, operator string) error
+}
+
+type dlqService struct {
+	managers map[string]*dlq.Manager
+}
+
+func NewDLQService(managers map[string]*dlq.Manager) DLQSrv {
+	return &dlqService{managers: managers}
+}
+
+func (s *dlqService) Namespaces() []string {
+	names := make([]string, 0, len(s.managers))
+	for name := range s.managers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *dlqService) List(ctx context.Context, namespace string, opts dlq.ListOptions) ([]*dlq.Message, int64, error) {
+	manager, err := s.manager(namespace)
+	if err != nil {
+		return nil, 0, err
+	}
+	msgs, total, err := manager.List(ctx, opts)
+	if err != nil {
+		return nil, 0, convertErr(err)
+	}
+	return msgs, total, nil
+}
+
+func (s *dlqService) Get(ctx context.Context, namespace, id string) (*dlq.Message, error) {
+	manager, err := s.manager(namespace)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := manager.Get(ctx, id)
+	if err != nil {
+		return nil, convertErr(err)
+	}
+	return msg, nil
+}
+
+func (s *dlqService) Stats(ctx context.Context, namespace string, opts dlq.ListOptions) ([]dlq.ClassStats, error) {
+	manager, err := s.manager(namespace)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := manager.Stats(ctx, opts)
+	if err != nil {
+		return nil, convertErr(err)
+	}
+	return stats, nil
+}
+
+func (s *dlqService) Replay(ctx context.Context, namespace, id, operator string) error {
+	manager, err := s.manager(namespace)
+	if err != nil {
+		return err
+	}
+	if err := manager.Replay(ctx, id, operator); err != nil {
+		return convertErr(err)
+	}
+	log.InfofC(ctx, "[admin] 死信已重放: namespace=%s, id=%s, operator=%s", namespace, id, operator)
+	return nil
+}
+
+func (s *dlqService) Discard(ctx context.Context, namespace, id, operator string) error {
+	manager, err := s.manager(namespace)
+	if err != nil {
+		return err
+	}
+	if err := manager.Discard(ctx, id, operator); err != nil {
+		return convertErr(err)
+	}
+	log.InfofC(ctx, "[admin] 死信已丢弃: namespace=%s, id=%s, operator=%s", namespace, id, operator)
+	return nil
+}
+
+func (s *dlqService) manager(namespace string) (*dlq.Manager, error) {
+	manager, ok := s.managers[namespace]
+	if !ok {
+		return nil, errors.WithCode(code.ErrDeadLetterNamespaceNotFound, "命名空间不存在: %s", namespace)
+	}
+	return manager, nil
+}
+
+func convertErr(err error) error {
+	switch {
+	case stderrors.Is(err, dlq.ErrMessageNotFound):
+		return errors.WithCode(code.ErrDeadLetterNotFound, "%s", err.Error())
+	case stderrors.Is(err, dlq.ErrInvalidState):
+		return errors.WithCode(code.ErrDeadLetterStateInvalid, "%s", err.Error())
+	case stderrors.Is(err, dlq.ErrReplayDisabled):
+		return errors.WithCode(code.ErrDeadLetterReplayDisabled, "%s", err.Error())
+	default:
+		return errors.WithCode(code.ErrDeadLetterStoreUnavailable, "%s", err.Error())
+	}
+}
@@ -59,7 +59,7 @@ func NewGoodsService(data data.DataFactory) GoodsSrv {
 // ==================== 商品管理 ====================
 
 func (g *goodsService) GetGoodsList(ctx context.Context, request *gpbv1.GoodsFilterRequest) (*gpbv1.GoodsListResponse, error) {
-	log.Infof("Admin GetGoodsList called")
+	log.InfofC(ctx, "Admin GetGoodsList called")
 	
 	// 获取商品列表
 	goodsResp, err := g.data.Goods().GoodsList(ctx, request)
@@ -76,7 +76,7 @@ func (g *goodsService) GetGoodsList(ctx context.Context, request *gpbv1.GoodsFil
 		
 		inventoryMap, err := g.data.Inventory().BatchGetInventory(ctx, goodsIds)
 		if err != nil {
-			log.Errorf("Failed to get inventory info: %v", err)
+			log.ErrorfC(ctx, "Failed to get inventory info: %v", err)
 			// 库存获取失败不影响商品列表返回，设置默认库存为0
 			for _, goods := range goodsResp.Data {
 				goods.Stocks = 0
@@ -97,85 +97,85 @@ func (g *goodsService) GetGoodsList(ctx context.Context, request *gpbv1.GoodsFil
 }
 
 func (g *goodsService) CreateGoods(ctx context.Context, info *gpbv1.CreateGoodsInfo) (*gpbv1.GoodsInfoResponse, error) {
-	log.Infof("Admin CreateGoods called for: %s", info.Name)
+	log.InfofC(ctx, "Admin CreateGoods called for: %s", info.Name)
 	// 管理员创建商品可以添加额外的业务逻辑，如审核流程、权限检查等
 	return g.data.Goods().CreateGoods(ctx, info)
 }
 
 func (g *goodsService) UpdateGoods(ctx context.Context, info *gpbv1.CreateGoodsInfo) (*gpbv1.GoodsInfoResponse, error) {
-	log.Infof("Admin UpdateGoods called for ID: %d", info.Id)
+	log.InfofC(ctx, "Admin UpdateGoods called for ID: %d", info.Id)
 	return g.data.Goods().UpdateGoods(ctx, info)
 }
 
 func (g *goodsService) DeleteGoods(ctx context.Context, id uint64) (*gpbv1.GoodsInfoResponse, error) {
-	log.Infof("Admin DeleteGoods called for ID: %d", id)
+	log.InfofC(ctx, "Admin DeleteGoods called for ID: %d", id)
 	deleteInfo := &gpbv1.DeleteGoodsInfo{Id: int32(id)}
 	return g.data.Goods().DeleteGoods(ctx, deleteInfo)
 }
 
 func (g *goodsService) GetGoodsDetail(ctx context.Context, id uint64) (*gpbv1.GoodsInfoResponse, error) {
-	log.Infof("Admin GetGoodsDetail called for ID: %d", id)
+	log.InfofC(ctx, "Admin GetGoodsDetail called for ID: %d", id)
 	
 	// 获取商品详情
 	request := &gpbv1.GoodInfoRequest{Id: int32(id)}
 	goodsResp, err := g.data.Goods().GetGoodsDetail(ctx, request)
 	if err != nil {
-		log.Errorf("Failed to get goods detail for ID %d: %v", id, err)
+		log.ErrorfC(ctx, "Failed to get goods detail for ID %d: %v", id, err)
 		return nil, err
 	}
-	log.Infof("Goods detail retrieved successfully for ID %d, name: %s", id, goodsResp.Name)
+	log.InfofC(ctx, "Goods detail retrieved successfully for ID %d, name: %s", id, goodsResp.Name)
 	
 	// 获取库存信息
-	log.Infof("Calling inventory service for goods ID: %d", id)
+	log.InfofC(ctx, "Calling inventory service for goods ID: %d", id)
 	inv, err := g.data.Inventory().GetInventory(ctx, int32(id))
 	if err != nil {
-		log.Errorf("Failed to get inventory for goods %d: %v", id, err)
+		log.ErrorfC(ctx, "Failed to get inventory for goods %d: %v", id, err)
 		goodsResp.Stocks = 0 // 库存获取失败设置为0
-		log.Warnf("Set stocks to 0 for goods %d due to inventory error", id)
+		log.WarnfC(ctx, "Set stocks to 0 for goods %d due to inventory error", id)
 	} else {
-		log.Infof("Inventory retrieved successfully for goods %d: %d stocks", id, inv.Num)
+		log.InfofC(ctx, "Inventory retrieved successfully for goods %d: %d stocks", id, inv.Num)
 		goodsResp.Stocks = inv.Num
-		log.Infof("Set goods %d stocks to %d", id, goodsResp.Stocks)
+		log.InfofC(ctx, "Set goods %d stocks to %d", id, goodsResp.Stocks)
 	}
 	
-	log.Infof("Final response for goods %d: stocks=%d", id, goodsResp.Stocks)
+	log.InfofC(ctx, "Final response for goods %d: stocks=%d", id, goodsResp.Stocks)
 	return goodsResp, nil
 }
 
 func (g *goodsService) SyncGoodsData(ctx context.Context, request *gpbv1.SyncDataRequest) (*gpbv1.SyncDataResponse, error) {
-	log.Infof("Admin SyncGoodsData called")
+	log.InfofC(ctx, "Admin SyncGoodsData called")
 	return g.data.Goods().SyncGoodsData(ctx, request)
 }
 
 // ==================== 分类管理 ====================
 
 func (g *goodsService) GetCategoriesList(ctx context.Context) (*gpbv1.CategoryListResponse, error) {
-	log.Infof("Admin GetCategoriesList called")
+	log.InfofC(ctx, "Admin GetCategoriesList called")
 	return g.data.Goods().GetCategoriesList(ctx)
 }
 
 func (g *goodsService) GetCategoriesByLevel(ctx context.Context, level int32) (*gpbv1.CategoryListResponse, error) {
-	log.Infof("Admin GetCategoriesByLevel called with level: %d", level)
+	log.InfofC(ctx, "Admin GetCategoriesByLevel called with level: %d", level)
 	return g.data.Goods().GetCategoriesByLevel(ctx, level)
 }
 
 func (g *goodsService) GetCategoryTree(ctx context.Context) (*gpbv1.CategoryTreeResponse, error) {
-	log.Infof("Admin GetCategoryTree called")
+	log.InfofC(ctx, "Admin GetCategoryTree called")
 	return g.data.Goods().GetCategoryTree(ctx)
 }
 
 func (g *goodsService) CreateCategory(ctx context.Context, request *gpbv1.CategoryInfoRequest) (*gpbv1.CategoryInfoResponse, error) {
-	log.Infof("Admin CreateCategory called: %s", request.Name)
+	log.InfofC(ctx, "Admin CreateCategory called: %s", request.Name)
 	return g.data.Goods().CreateCategory(ctx, request)
 }
 
 func (g *goodsService) UpdateCategory(ctx context.Context, request *gpbv1.CategoryInfoRequest) (*gpbv1.CategoryInfoResponse, error) {
-	log.Infof("Admin UpdateCategory called for ID: %d", request.Id)
+	log.InfofC(ctx, "Admin UpdateCategory called for ID: %d", request.Id)
 	return g.data.Goods().UpdateCategory(ctx, request)
 }
 
 func (g *goodsService) DeleteCategory(ctx context.Context, id uint64) (*gpbv1.CategoryInfoResponse, error) {
-	log.Infof("Admin DeleteCategory called for ID: %d", id)
+	log.InfofC(ctx, "Admin DeleteCategory called for ID: %d", id)
 	request := &gpbv1.DeleteCategoryRequest{Id: int32(id)}
 	return g.data.Goods().DeleteCategory(ctx, request)
 }
@@ -183,22 +183,22 @@ func (g *goodsService) DeleteCategory(ctx context.Context, id uint64) (*gpbv1.Ca
 // ==================== 品牌管理 ====================
 
 func (g *goodsService) GetBrandsList(ctx context.Context, request *gpbv1.BrandFilterRequest) (*gpbv1.BrandListResponse, error) {
-	log.Infof("Admin GetBrandsList called")
+	log.InfofC(ctx, "Admin GetBrandsList called")
 	return g.data.Goods().BrandList(ctx, request)
 }
 
 func (g *goodsService) CreateBrand(ctx context.Context, request *gpbv1.BrandRequest) (*gpbv1.BrandInfoResponse, error) {
-	log.Infof("Admin CreateBrand called: %s", request.Name)
+	log.InfofC(ctx, "Admin CreateBrand called: %s", request.Name)
 	return g.data.Goods().CreateBrand(ctx, request)
 }
 
 func (g *goodsService) UpdateBrand(ctx context.Context, request *gpbv1.BrandRequest) (*gpbv1.BrandInfoResponse, error) {
-	log.Infof("Admin UpdateBrand called for ID: %d", request.Id)
+	log.InfofC(ctx, "Admin UpdateBrand called for ID: %d", request.Id)
 	return g.data.Goods().UpdateBrand(ctx, request)
 }
 
 func (g *goodsService) DeleteBrand(ctx context.Context, id uint64) (*gpbv1.BrandInfoResponse, error) {
-	log.Infof("Admin DeleteBrand called for ID: %d", id)
+	log.InfofC(ctx, "Admin DeleteBrand called for ID: %d", id)
 	request := &gpbv1.BrandRequest{Id: int32(id)}
 	return g.data.Goods().DeleteBrand(ctx, request)
 }
@@ -206,22 +206,22 @@ func (g *goodsService) DeleteBrand(ctx context.Context, id uint64) (*gpbv1.Brand
 // ==================== 轮播图管理 ====================
 
 func (g *goodsService) GetBannersList(ctx context.Context) (*gpbv1.BannerListResponse, error) {
-	log.Infof("Admin GetBannersList called")
+	log.InfofC(ctx, "Admin GetBannersList called")
 	return g.data.Goods().BannerList(ctx)
 }
 
 func (g *goodsService) CreateBanner(ctx context.Context, request *gpbv1.BannerRequest) (*gpbv1.BannerResponse, error) {
-	log.Infof("Admin CreateBanner called")
+	log.InfofC(ctx, "Admin CreateBanner called")
 	return g.data.Goods().CreateBanner(ctx, request)
 }
 
 func (g *goodsService) UpdateBanner(ctx context.Context, request *gpbv1.BannerRequest) (*gpbv1.BannerResponse, error) {
-	log.Infof("Admin UpdateBanner called for ID: %d", request.Id)
+	log.InfofC(ctx, "Admin UpdateBanner called for ID: %d", request.Id)
 	return g.data.Goods().UpdateBanner(ctx, request)
 }
 
 func (g *goodsService) DeleteBanner(ctx context.Context, id uint64) (*gpbv1.BannerResponse, error) {
-	log.Infof("Admin DeleteBanner called for ID: %d", id)
+	log.InfofC(ctx, "Admin DeleteBanner called for ID: %d", id)
 	request := &gpbv1.BannerRequest{Id: int32(id)}
 	return g.data.Goods().DeleteBanner(ctx, request)
 }
@@ -229,28 +229,28 @@ func (g *goodsService) DeleteBanner(ctx context.Context, id uint64) (*gpbv1.Bann
 // ==================== 库存管理 ====================
 
 func (g *goodsService) GetGoodsInventory(ctx context.Context, goodsId int32) (*ipbv1.GoodsInvInfo, error) {
-	log.Infof("Admin GetGoodsInventory called for goods ID: %d", goodsId)
+	log.InfofC(ctx, "Admin GetGoodsInventory called for goods ID: %d", goodsId)
 	return g.data.Inventory().GetInventory(ctx, goodsId)
 }
 
 func (g *goodsService) SetGoodsInventory(ctx context.Context, request *ipbv1.GoodsInvInfo) error {
-	log.Infof("Admin SetGoodsInventory called for goods ID: %d, num: %d", request.GoodsId, request.Num)
+	log.InfofC(ctx, "Admin SetGoodsInventory called for goods ID: %d, num: %d", request.GoodsId, request.Num)
 	return g.data.Inventory().SetInventory(ctx, request)
 }
 
 func (g *goodsService) BatchSetGoodsInventory(ctx context.Context, inventories []*ipbv1.GoodsInvInfo) error {
-	log.Infof("Admin BatchSetGoodsInventory called for %d items", len(inventories))
+	log.InfofC(ctx, "Admin BatchSetGoodsInventory called for %d items", len(inventories))
 	return g.data.Inventory().BatchSetInventory(ctx, inventories)
 }
 
 // ==================== 批量操作 ====================
 
 func (g *goodsService) BatchDeleteGoods(ctx context.Context, request *gpbv1.BatchDeleteGoodsRequest) (*gpbv1.BatchOperationResponse, error) {
-	log.Infof("Admin BatchDeleteGoods called for %d items", len(request.Ids))
+	log.InfofC(ctx, "Admin BatchDeleteGoods called for %d items", len(request.Ids))
 	return g.data.Goods().BatchDeleteGoods(ctx, request)
 }
 
 func (g *goodsService) BatchUpdateGoodsStatus(ctx context.Context, request *gpbv1.BatchUpdateGoodsStatusRequest) (*gpbv1.BatchOperationResponse, error) {
-	log.Infof("Admin BatchUpdateGoodsStatus called for %d items", len(request.Ids))
+	log.InfofC(ctx, "Admin BatchUpdateGoodsStatus called for %d items", len(request.Ids))
 	return g.data.Goods().BatchUpdateGoodsStatus(ctx, request)
 }
\ No newline at end of file
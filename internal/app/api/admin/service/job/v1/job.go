@@ -0,0 +1,115 @@
+package job
+
+import (
+	"context"
+	stderrors "errors"
+	"sort"
+	"time"
+
+	"emshop/internal/app/pkg/code"
+	"emshop/pkg/delayjob"
+	"emshop/pkg/errors"
+	"emshop/pkg/log"
+)
+
+// JobSrv 管理端延时任务服务
+type JobSrv interface {
+	// Namespaces 可查看的命名空间
+	Namespaces() []string
+	// List 分页查询任务
+	List(ctx context.Context, namespace string, opts delayjob.ListOptions) ([]*delayjob.Job, int64, error)
+	// Get 查询任务详情及执行记录
+	Get(ctx context.Context, namespace, id string) (*delayjob.Job, []delayjob.Attempt, error)
+	// Retry 将失败或已取消的任务立即重新入队
+	Retry(ctx context.Context, namespace, id, operator string) error
+	// Cancel 取消等待中的任务
+	Cancel(ctx context.Context, namespace, id, operator string) error
+}
+
+type jobService struct {
+	stores map[string]delayjob.Store
+}
+
+func NewJobService(stores map[string]delayjob.Store) JobSrv {
+	return &jobService{stores: stores}
+}
+
+func (s *jobService) Namespaces() []string {
+	names := make([]string, 0, len(s.stores))
+	for name := range s.stores {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *jobService) List(ctx context.Context, namespace string, opts delayjob.ListOptions) ([]*delayjob.Job, int64, error) {
+	store, err := s.store(namespace)
+	if err != nil {
+		return nil, 0, err
+	}
+	jobs, total, err := store.List(ctx, opts)
+	if err != nil {
+		return nil, 0, convertErr(err)
+	}
+	return jobs, total, nil
+}
+
+func (s *jobService) Get(ctx context.Context, namespace, id string) (*delayjob.Job, []delayjob.Attempt, error) {
+	store, err := s.store(namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	job, err := store.Get(ctx, id)
+	if err != nil {
+		return nil, nil, convertErr(err)
+	}
+	history, err := store.History(ctx, id)
+	if err != nil {
+		return nil, nil, convertErr(err)
+	}
+	return job, history, nil
+}
+
+func (s *jobService) Retry(ctx context.Context, namespace, id, operator string) error {
+	store, err := s.store(namespace)
+	if err != nil {
+		return err
+	}
+	if err := store.Retry(ctx, id, time.Now()); err != nil {
+		return convertErr(err)
+	}
+	log.InfofC(ctx, "[admin] 延时任务已重新入队: namespace=%s, id=%s, operator=%s", namespace, id, operator)
+	return nil
+}
+
+func (s *jobService) Cancel(ctx context.Context, namespace, id, operator string) error {
+	store, err := s.store(namespace)
+	if err != nil {
+		return err
+	}
+	if err := store.Cancel(ctx, id); err != nil {
+		return convertErr(err)
+	}
+	log.InfofC(ctx, "[admin] 延时任务已取消: namespace=%s, id=%s, operator=%s", namespace, id, operator)
+	return nil
+}
+
+func (s *jobService) store(namespace string) (delayjob.Store, error) {
+	store, ok := s.stores[namespace]
+	if !ok {
+		return nil, errors.WithCode(code.ErrJobNamespaceNotFound, "命名空间不存在: %s", namespace)
+	}
+	return store, nil
+}
+
+func convertErr(err error) error {
+	switch {
+	case stderrors.Is(err, delayjob.ErrJobNotFound):
+		return errors.WithCode(code.ErrJobNotFound, "%s", err.Error())
+	case stderrors.Is(err, delayjob.ErrInvalidState):
+		return errors.WithCode(code.ErrJobStateInvalid, "%s", err.Error())
+	default:
+		return errors.WithCode(code.ErrJobStoreUnavailable, "%s", err.Error())
+	}
+}
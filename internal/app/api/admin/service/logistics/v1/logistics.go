@@ -0,0 +1,56 @@
+package logistics
+
+import (
+    "context"
+
+    lpbv1 "emshop/api/logistics/v1"
+    "emshop/internal/app/api/admin/data"
+)
+
+// LogisticsSrv 管理端物流服务
+type LogisticsSrv interface {
+    // ListRateConfigs 分页查询运费配置版本
+    ListRateConfigs(ctx context.Context, page, pageSize int32) (*lpbv1.ListShippingRateConfigsResponse, error)
+    // GetRateConfig 查询运费配置，version为0时返回生效版本
+    GetRateConfig(ctx context.Context, version int32) (*lpbv1.ShippingRateConfig, error)
+    // CreateRateConfig 创建新的运费配置版本
+    CreateRateConfig(ctx context.Context, req *lpbv1.CreateShippingRateConfigRequest) (*lpbv1.ShippingRateConfig, error)
+    // ActivateRateConfig 生效（或回滚到）指定版本
+    ActivateRateConfig(ctx context.Context, version int32, operator string) error
+    // QuoteShippingFee 按当前生效配置试算运费
+    QuoteShippingFee(ctx context.Context, req *lpbv1.CalculateShippingFeeRequest) (*lpbv1.CalculateShippingFeeResponse, error)
+}
+
+type logisticsService struct {
+    data data.DataFactory
+}
+
+func NewLogisticsService(d data.DataFactory) LogisticsSrv {
+    return &logisticsService{data: d}
+}
+
+func (s *logisticsService) ListRateConfigs(ctx context.Context, page, pageSize int32) (*lpbv1.ListShippingRateConfigsResponse, error) {
+    return s.data.Logistics().ListShippingRateConfigs(ctx, &lpbv1.ListShippingRateConfigsRequest{
+        Page:     page,
+        PageSize: pageSize,
+    })
+}
+
+func (s *logisticsService) GetRateConfig(ctx context.Context, version int32) (*lpbv1.ShippingRateConfig, error) {
+    return s.data.Logistics().GetShippingRateConfig(ctx, &lpbv1.GetShippingRateConfigRequest{Version: version})
+}
+
+func (s *logisticsService) CreateRateConfig(ctx context.Context, req *lpbv1.CreateShippingRateConfigRequest) (*lpbv1.ShippingRateConfig, error) {
+    return s.data.Logistics().CreateShippingRateConfig(ctx, req)
+}
+
+func (s *logisticsService) ActivateRateConfig(ctx context.Context, version int32, operator string) error {
+    return s.data.Logistics().ActivateShippingRateConfig(ctx, &lpbv1.ActivateShippingRateConfigRequest{
+        Version:  version,
+        Operator: operator,
+    })
+}
+
+func (s *logisticsService) QuoteShippingFee(ctx context.Context, req *lpbv1.CalculateShippingFeeRequest) (*lpbv1.CalculateShippingFeeResponse, error) {
+    return s.data.Logistics().CalculateShippingFee(ctx, req)
+}
@@ -35,7 +35,7 @@ func NewOrderService(data data.DataFactory) OrderSrv {
 
 // AdminOrderList 管理员查看所有订单列表
 func (os *orderService) AdminOrderList(ctx context.Context, request *proto.OrderFilterRequest) (*proto.OrderListResponse, error) {
-	log.Infof("Admin order service: AdminOrderList called with userId=%d, pages=%d, pagePerNums=%d", 
+	log.InfofC(ctx, "Admin order service: AdminOrderList called with userId=%d, pages=%d, pagePerNums=%d", 
 		request.UserId, request.Pages, request.PagePerNums)
 	
 	// 验证分页参数
@@ -54,18 +54,18 @@ func (os *orderService) AdminOrderList(ctx context.Context, request *proto.Order
 	
 	response, err := os.data.Order().AdminOrderList(ctx, request)
 	if err != nil {
-		log.Errorf("Admin order service: AdminOrderList failed: %v", err)
+		log.ErrorfC(ctx, "Admin order service: AdminOrderList failed: %v", err)
 		return nil, err
 	}
 	
-	log.Infof("Admin order service: AdminOrderList successful, returned %d orders", len(response.Data))
+	log.InfofC(ctx, "Admin order service: AdminOrderList successful, returned %d orders", len(response.Data))
 	return response, nil
 }
 
 // AdminOrderDetail 管理员查看订单详情
 func (os *orderService) AdminOrderDetail(ctx context.Context, request *proto.OrderRequest) (*proto.OrderInfoDetailResponse, error) {
-	log.Infof("Admin order service: AdminOrderDetail called with ID=%d, OrderSn=%s", 
-		request.Id, request.OrderSn)
+	log.InfofC(ctx, "Admin order service: AdminOrderDetail called with ID=%d, OrderSn=%s", 
+		request.Id, request.GetOrderSn())
 	
 	// 参数验证
 	if request.Id <= 0 && (request.OrderSn == nil || *request.OrderSn == "") {
@@ -74,17 +74,17 @@ func (os *orderService) AdminOrderDetail(ctx context.Context, request *proto.Ord
 	
 	response, err := os.data.Order().AdminOrderDetail(ctx, request)
 	if err != nil {
-		log.Errorf("Admin order service: AdminOrderDetail failed: %v", err)
+		log.ErrorfC(ctx, "Admin order service: AdminOrderDetail failed: %v", err)
 		return nil, err
 	}
 	
-	log.Info("Admin order service: AdminOrderDetail successful")
+	log.InfoC(ctx, "Admin order service: AdminOrderDetail successful")
 	return response, nil
 }
 
 // UpdateOrderStatus 更新订单状态
 func (os *orderService) UpdateOrderStatus(ctx context.Context, request *proto.OrderStatus) error {
-	log.Infof("Admin order service: UpdateOrderStatus called for order %s to status %s", 
+	log.InfofC(ctx, "Admin order service: UpdateOrderStatus called for order %s to status %s", 
 		request.OrderSn, request.Status)
 	
 	// 参数验证
@@ -111,17 +111,17 @@ func (os *orderService) UpdateOrderStatus(ctx context.Context, request *proto.Or
 	
 	err := os.data.Order().UpdateOrderStatus(ctx, request)
 	if err != nil {
-		log.Errorf("Admin order service: UpdateOrderStatus failed: %v", err)
+		log.ErrorfC(ctx, "Admin order service: UpdateOrderStatus failed: %v", err)
 		return err
 	}
 	
-	log.Info("Admin order service: UpdateOrderStatus successful")
+	log.InfoC(ctx, "Admin order service: UpdateOrderStatus successful")
 	return nil
 }
 
 // GetOrderByOrderSn 按订单号查询订单
 func (os *orderService) GetOrderByOrderSn(ctx context.Context, orderSn string) (*proto.OrderInfoDetailResponse, error) {
-	log.Infof("Admin order service: GetOrderByOrderSn called with orderSn=%s", orderSn)
+	log.InfofC(ctx, "Admin order service: GetOrderByOrderSn called with orderSn=%s", orderSn)
 	
 	if orderSn == "" {
 		return nil, fmt.Errorf("订单号不能为空")
@@ -129,17 +129,17 @@ func (os *orderService) GetOrderByOrderSn(ctx context.Context, orderSn string) (
 	
 	response, err := os.data.Order().GetOrderByOrderSn(ctx, orderSn)
 	if err != nil {
-		log.Errorf("Admin order service: GetOrderByOrderSn failed: %v", err)
+		log.ErrorfC(ctx, "Admin order service: GetOrderByOrderSn failed: %v", err)
 		return nil, err
 	}
 	
-	log.Info("Admin order service: GetOrderByOrderSn successful")
+	log.InfoC(ctx, "Admin order service: GetOrderByOrderSn successful")
 	return response, nil
 }
 
 // GetOrdersByUserId 按用户ID查询订单列表
 func (os *orderService) GetOrdersByUserId(ctx context.Context, userId int32, pages, pagePerNums int32) (*proto.OrderListResponse, error) {
-	log.Infof("Admin order service: GetOrdersByUserId called with userId=%d, pages=%d, pagePerNums=%d", 
+	log.InfofC(ctx, "Admin order service: GetOrdersByUserId called with userId=%d, pages=%d, pagePerNums=%d", 
 		userId, pages, pagePerNums)
 	
 	if userId <= 0 {
@@ -159,10 +159,10 @@ func (os *orderService) GetOrdersByUserId(ctx context.Context, userId int32, pag
 	
 	response, err := os.data.Order().GetOrdersByUserId(ctx, userId, pages, pagePerNums)
 	if err != nil {
-		log.Errorf("Admin order service: GetOrdersByUserId failed: %v", err)
+		log.ErrorfC(ctx, "Admin order service: GetOrdersByUserId failed: %v", err)
 		return nil, err
 	}
 	
-	log.Infof("Admin order service: GetOrdersByUserId successful, returned %d orders", len(response.Data))
+	log.InfofC(ctx, "Admin order service: GetOrdersByUserId successful, returned %d orders", len(response.Data))
 	return response, nil
 }
\ No newline at end of file
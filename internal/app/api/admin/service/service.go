@@ -6,7 +6,12 @@ import (
     "emshop/internal/app/api/admin/service/order/v1"
     "emshop/internal/app/api/admin/service/user/v1"
     coupon "emshop/internal/app/api/admin/service/coupon/v1"
+    logistics "emshop/internal/app/api/admin/service/logistics/v1"
+    job "emshop/internal/app/api/admin/service/job/v1"
+    deadletter "emshop/internal/app/api/admin/service/dlq/v1"
     "emshop/internal/app/pkg/options"
+    "emshop/pkg/delayjob"
+    "emshop/pkg/dlq"
 )
 
 // ServiceFactory 服务工厂接口
@@ -15,17 +20,24 @@ type ServiceFactory interface {
     Goods() goods.GoodsSrv
     Order() order.OrderSrv
     Coupon() coupon.CouponSrv
+    Logistics() logistics.LogisticsSrv
+    Jobs() job.JobSrv
+    DeadLetters() deadletter.DLQSrv
 }
 
 type serviceFactory struct {
 	data data.DataFactory
 	jwt  *options.JwtOptions
+	jobs map[string]delayjob.Store
+	dlqs map[string]*dlq.Manager
 }
 
-func NewService(data data.DataFactory, jwt *options.JwtOptions) ServiceFactory {
+func NewService(data data.DataFactory, jwt *options.JwtOptions, jobs map[string]delayjob.Store, dlqs map[string]*dlq.Manager) ServiceFactory {
 	return &serviceFactory{
 		data: data,
 		jwt:  jwt,
+		jobs: jobs,
+		dlqs: dlqs,
 	}
 }
 
@@ -44,3 +56,15 @@ func (s *serviceFactory) Order() order.OrderSrv {
 func (s *serviceFactory) Coupon() coupon.CouponSrv {
     return coupon.NewCouponService(s.data)
 }
+
+func (s *serviceFactory) Logistics() logistics.LogisticsSrv {
+    return logistics.NewLogisticsService(s.data)
+}
+
+func (s *serviceFactory) Jobs() job.JobSrv {
+    return job.NewJobService(s.jobs)
+}
+
+func (s *serviceFactory) DeadLetters() deadletter.DLQSrv {
+    return deadletter.NewDLQService(s.dlqs)
+}
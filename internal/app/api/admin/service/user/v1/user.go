@@ -48,16 +48,16 @@ func NewUserService(data data.DataFactory, jwt *options.JwtOptions) UserSrv {
 
 func (u *userService) GetUserList(ctx context.Context, pageInfo *upbv1.PageInfo) (*upbv1.UserListResponse, error) {
 	if pageInfo.Pn != nil && pageInfo.PSize != nil {
-		log.Infof("Admin GetUserList called with page: %d, pageSize: %d", *pageInfo.Pn, *pageInfo.PSize)
+		log.InfofC(ctx, "Admin GetUserList called with page: %d, pageSize: %d", *pageInfo.Pn, *pageInfo.PSize)
 	} else {
-		log.Infof("Admin GetUserList called with no pagination (return all data)")
+		log.InfofC(ctx, "Admin GetUserList called with no pagination (return all data)")
 	}
 	
 	return u.data.Users().GetUserList(ctx, pageInfo)
 }
 
 func (u *userService) GetUserById(ctx context.Context, id uint64) (*upbv1.UserInfoResponse, error) {
-	log.Infof("Admin GetUserById called with id: %d", id)
+	log.InfofC(ctx, "Admin GetUserById called with id: %d", id)
 	
 	request := &upbv1.IdRequest{
 		Id: int32(id),
@@ -67,7 +67,7 @@ func (u *userService) GetUserById(ctx context.Context, id uint64) (*upbv1.UserIn
 }
 
 func (u *userService) GetUserByMobile(ctx context.Context, mobile string) (*upbv1.UserInfoResponse, error) {
-	log.Infof("Admin GetUserByMobile called with mobile: %s", mobile)
+	log.InfofC(ctx, "Admin GetUserByMobile called with mobile: %s", mobile)
 	
 	request := &upbv1.MobileRequest{
 		Mobile: mobile,
@@ -77,7 +77,7 @@ func (u *userService) GetUserByMobile(ctx context.Context, mobile string) (*upbv
 }
 
 func (u *userService) UpdateUserStatus(ctx context.Context, id uint64, status int32) error {
-	log.Infof("Admin UpdateUserStatus called with id: %d, status: %d", id, status)
+	log.InfofC(ctx, "Admin UpdateUserStatus called with id: %d, status: %d", id, status)
 	
 	// 这里可以添加更多管理员特有的业务逻辑，比如权限检查、审计日志等
 	request := &upbv1.UpdateUserInfo{
@@ -90,7 +90,7 @@ func (u *userService) UpdateUserStatus(ctx context.Context, id uint64, status in
 }
 
 func (u *userService) UpdateUser(ctx context.Context, user *upbv1.UserInfoResponse) error {
-	log.Infof("Admin UpdateUser called with id: %d", user.Id)
+	log.InfofC(ctx, "Admin UpdateUser called with id: %d", user.Id)
 	
 	request := &upbv1.UpdateUserInfo{
 		Id:       user.Id,
@@ -104,7 +104,7 @@ func (u *userService) UpdateUser(ctx context.Context, user *upbv1.UserInfoRespon
 }
 
 func (u *userService) UpdateUserInfo(ctx context.Context, id uint64, nickName, gender string, birthday uint64) error {
-	log.Infof("Admin UpdateUserInfo called with id: %d, nickName: %s, gender: %s, birthday: %d", id, nickName, gender, birthday)
+	log.InfofC(ctx, "Admin UpdateUserInfo called with id: %d, nickName: %s, gender: %s, birthday: %d", id, nickName, gender, birthday)
 	
 	request := &upbv1.UpdateUserInfo{
 		Id:       int32(id),
@@ -119,24 +119,24 @@ func (u *userService) UpdateUserInfo(ctx context.Context, id uint64, nickName, g
 
 // MobileLogin 管理员手机号登录
 func (u *userService) MobileLogin(ctx context.Context, mobile, password string) (*AdminUserDTO, error) {
-	log.Infof("Admin MobileLogin called with mobile: %s", mobile)
+	log.InfofC(ctx, "Admin MobileLogin called with mobile: %s", mobile)
 	
 	// 获取用户信息
 	userResp, err := u.GetUserByMobile(ctx, mobile)
 	if err != nil {
-		log.Errorf("Admin login failed: user not found - %v", err)
+		log.ErrorfC(ctx, "Admin login failed: user not found - %v", err)
 		return nil, err
 	}
 	
 	// 验证密码
 	isValid, err := u.CheckPassWord(ctx, password, userResp.PassWord)
 	if err != nil {
-		log.Errorf("Admin login failed: password check error - %v", err)
+		log.ErrorfC(ctx, "Admin login failed: password check error - %v", err)
 		return nil, err
 	}
 	
 	if !isValid {
-		log.Warnf("Admin login failed: incorrect password for user ID: %d", userResp.Id)
+		log.WarnfC(ctx, "Admin login failed: incorrect password for user ID: %d", userResp.Id)
 		return nil, err
 	}
 	
@@ -149,7 +149,7 @@ func (u *userService) MobileLogin(ctx context.Context, mobile, password string)
 		u.jwt.Timeout,
 	)
 	if err != nil {
-		log.Errorf("Admin login failed: token generation error - %v", err)
+		log.ErrorfC(ctx, "Admin login failed: token generation error - %v", err)
 		return nil, err
 	}
 	
@@ -166,7 +166,7 @@ func (u *userService) MobileLogin(ctx context.Context, mobile, password string)
 
 // CheckPassWord 验证密码
 func (u *userService) CheckPassWord(ctx context.Context, password, encryptedPassword string) (bool, error) {
-	log.Infof("Admin CheckPassWord called")
+	log.InfofC(ctx, "Admin CheckPassWord called")
 	
 	request := &upbv1.PasswordCheckInfo{
 		Password:          password,
@@ -175,7 +175,7 @@ func (u *userService) CheckPassWord(ctx context.Context, password, encryptedPass
 	
 	response, err := u.data.Users().CheckPassWord(ctx, request)
 	if err != nil {
-		log.Errorf("Admin password check failed: %v", err)
+		log.ErrorfC(ctx, "Admin password check failed: %v", err)
 		return false, err
 	}
 	
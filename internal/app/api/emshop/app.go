@@ -3,20 +3,20 @@ package admin
 import (
     "context"
     gapp "emshop/gin-micro/app"
+    "emshop/gin-micro/core/health"
     "emshop/gin-micro/core/trace"
     rpcserver "emshop/gin-micro/server/rpc-server"
     "emshop/gin-micro/server/rpc-server/selector"
     "emshop/gin-micro/server/rpc-server/selector/p2c"
     "emshop/internal/app/api/emshop/config"
     "emshop/internal/app/pkg/options"
+    "emshop/internal/app/pkg/discovery"
     "emshop/pkg/app"
     "emshop/pkg/log"
     "emshop/pkg/storage"
 
-	"github.com/hashicorp/consul/api"
 
 	"emshop/gin-micro/registry"
-	"emshop/gin-micro/registry/consul"
 )
 
 // 创建基础应用程序
@@ -51,27 +51,10 @@ func run(cfg *config.Config) app.RunFunc {
 //	@param registry
 //	@return registry.Registrar
 func NewRegistrar(registry *options.RegistryOptions, dev bool) registry.Registrar {
-    c := api.DefaultConfig()
-    c.Address = registry.Address
-    c.Scheme = registry.Scheme
-    cli, err := api.NewClient(c)
+    r, err := discovery.NewRegistrar(registry, dev)
     if err != nil {
         panic(err)
     }
-    // 创建自定义consul注册器实例
-    opts := []consul.Option{consul.WithHealthCheck(true)}
-    if registry.HealthCheckInterval > 0 {
-        opts = append(opts, consul.WithHealthCheckInterval(registry.HealthCheckInterval))
-    }
-    if registry.CheckTimeout > 0 {
-        opts = append(opts, consul.WithCheckTimeout(registry.CheckTimeout))
-    }
-    if dev {
-        opts = append(opts, consul.WithDeregisterCriticalServiceAfter(60))
-    } else if registry.DeregisterCriticalAfter > 0 {
-        opts = append(opts, consul.WithDeregisterCriticalServiceAfter(registry.DeregisterCriticalAfter))
-    }
-    r := consul.New(cli, opts...)
     return r
 }
 
@@ -96,23 +79,9 @@ func NewAPIApp(cfg *config.Config) (*gapp.App, error) {
 	})
 
 	//连接redis
-	redisConfig := &storage.Config{
-		Host:                  cfg.Redis.Host,
-		Port:                  cfg.Redis.Port,
-		Addrs:                 cfg.Redis.Addrs,
-		MasterName:            cfg.Redis.MasterName,
-		Username:              cfg.Redis.Username,
-		Password:              cfg.Redis.Password,
-		Database:              cfg.Redis.Database,
-		MaxIdle:               cfg.Redis.MaxIdle,
-		MaxActive:             cfg.Redis.MaxActive,
-		Timeout:               cfg.Redis.Timeout,
-		EnableCluster:         cfg.Redis.EnableCluster,
-		UseSSL:                cfg.Redis.UseSSL,
-		SSLInsecureSkipVerify: cfg.Redis.SSLInsecureSkipVerify,
-		EnableTracing:         cfg.Redis.EnableTracing,
-	}
-	go storage.ConnectToRedis(context.Background(), redisConfig)
+	go storage.ConnectToRedis(context.Background(), cfg.Redis.StorageConfig())
+	// 限流和幂等在Redis不可用时降级，不影响就绪状态
+	health.Register("redis", storage.Ping, health.Optional())
 
 	//生成http服务
 	rpcServer, err := NewAPIHTTPServer(cfg)
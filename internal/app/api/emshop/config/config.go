@@ -16,6 +16,9 @@ type Config struct {
 	Redis     *options.RedisOptions     `json:"redis" mapstructure:"redis"`
 	I18n      *options.I18nOptions      `json:"i18n" mapstructure:"i18n"`
 	Telemetry *options.TelemetryOptions `json:"telemetry" mapstructure:"telemetry"`
+	RateLimit *options.RateLimitOptions `json:"ratelimit" mapstructure:"ratelimit"`
+
+	Idempotency *options.IdempotencyOptions `json:"idempotency" mapstructure:"idempotency"`
 }
 
 func (c *Config) Validate() []error {
@@ -28,6 +31,8 @@ func (c *Config) Validate() []error {
 	errors = append(errors, c.Redis.Validate()...)
 	errors = append(errors, c.I18n.Validate()...)
 	errors = append(errors, c.Telemetry.Validate()...)
+	errors = append(errors, c.RateLimit.Validate()...)
+	errors = append(errors, c.Idempotency.Validate()...)
 	return errors
 }
 
@@ -40,6 +45,8 @@ func (c *Config) Flags() (fss cliflag.NamedFlagSets) {
 	c.Redis.AddFlags(fss.FlagSet("redis"))
 	c.I18n.AddFlags(fss.FlagSet("i18n"))
 	c.Telemetry.AddFlags(fss.FlagSet("telemetry"))
+	c.RateLimit.AddFlags(fss.FlagSet("ratelimit"))
+	c.Idempotency.AddFlags(fss.FlagSet("idempotency"))
 	return fss
 }
 
@@ -54,5 +61,8 @@ func New() *Config {
 		Redis:     options.NewRedisOptions(),
 		I18n:      options.NewI18nOptions(),
 		Telemetry: options.NewTelemetryOptions(),
+		RateLimit: options.NewRateLimitOptions(),
+
+		Idempotency: options.NewIdempotencyOptions(),
 	}
 }
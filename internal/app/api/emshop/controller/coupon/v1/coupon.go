@@ -6,6 +6,7 @@ import (
     restserver "emshop/gin-micro/server/rest-server"
     "emshop/internal/app/api/emshop/domain/dto/request"
     "emshop/internal/app/api/emshop/service"
+    rv1 "emshop/internal/app/api/emshop/service/risk/v1"
     "emshop/internal/app/pkg/middleware"
     "emshop/pkg/common/core"
 
@@ -60,7 +61,8 @@ func (cc *couponController) ReceiveCoupon(ctx *gin.Context) {
     }
 
 	// 调用服务层
-    resp, err := cc.sf.Coupon().ReceiveCoupon(ctx, int64(uid), &req)
+    risk := cc.sf.Risk().Context(ctx, int64(uid), rv1.ClientInfoFromHeader(ctx.Request.Header, ctx.ClientIP()))
+    resp, err := cc.sf.Coupon().ReceiveCoupon(ctx, int64(uid), &req, risk)
     if err != nil {
         core.WriteResponse(ctx, err, nil)
         return
@@ -69,6 +71,33 @@ func (cc *couponController) ReceiveCoupon(ctx *gin.Context) {
 	core.WriteResponse(ctx, nil, resp)
 }
 
+// RedeemCode 用户使用兑换码领取优惠券
+func (cc *couponController) RedeemCode(ctx *gin.Context) {
+	var req request.RedeemPromoCodeRequest
+
+	// 绑定请求参数
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		core.WriteResponse(ctx, err, nil)
+		return
+	}
+
+	// 获取用户ID（统一使用中间件助手）
+	uid, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok || uid <= 0 {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"code": 401, "message": "用户未登录"})
+		return
+	}
+
+	// 调用服务层
+	resp, err := cc.sf.Coupon().RedeemCode(ctx, int64(uid), &req)
+	if err != nil {
+		core.WriteResponse(ctx, err, nil)
+		return
+	}
+
+	core.WriteResponse(ctx, nil, resp)
+}
+
 // GetUserCoupons 获取用户优惠券列表
 func (cc *couponController) GetUserCoupons(ctx *gin.Context) {
 	var req request.GetUserCouponsRequest
@@ -96,6 +125,33 @@ func (cc *couponController) GetUserCoupons(ctx *gin.Context) {
 	core.WriteResponse(ctx, nil, resp)
 }
 
+// ListReminders 获取用户优惠券即将过期提醒
+func (cc *couponController) ListReminders(ctx *gin.Context) {
+	var req request.ListCouponRemindersRequest
+
+	// 绑定查询参数
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		core.WriteResponse(ctx, err, nil)
+		return
+	}
+
+    // 获取用户ID（统一使用中间件助手）
+    uid, ok := middleware.GetUserIDFromContext(ctx)
+    if !ok || uid <= 0 {
+        ctx.JSON(http.StatusUnauthorized, gin.H{"code": 401, "message": "用户未登录"})
+        return
+    }
+
+	// 调用服务层
+    resp, err := cc.sf.Coupon().ListReminders(ctx, int64(uid), &req)
+    if err != nil {
+        core.WriteResponse(ctx, err, nil)
+        return
+    }
+
+	core.WriteResponse(ctx, nil, resp)
+}
+
 // GetAvailableCoupons 获取用户可用优惠券
 func (cc *couponController) GetAvailableCoupons(ctx *gin.Context) {
 	var req request.GetAvailableCouponsRequest
@@ -0,0 +1,102 @@
+package coupon
+
+import (
+	"net/http"
+	"strconv"
+
+	"emshop/gin-micro/code"
+	"emshop/internal/app/api/emshop/domain/dto/request"
+	rv1 "emshop/internal/app/api/emshop/service/risk/v1"
+	"emshop/internal/app/pkg/middleware"
+	"emshop/pkg/common/core"
+	"emshop/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EnterFlashSaleQueue 秒杀排队入队，返回排位与预计等待时间
+func (cc *couponController) EnterFlashSaleQueue(ctx *gin.Context) {
+	uid, flashSaleID, ok := flashSaleUserOf(ctx)
+	if !ok {
+		return
+	}
+
+	resp, err := cc.sf.Coupon().EnterFlashSaleQueue(ctx, uid, flashSaleID)
+	if err != nil {
+		core.WriteResponse(ctx, err, nil)
+		return
+	}
+	core.WriteResponse(ctx, nil, resp)
+}
+
+// GetFlashSaleQueueStatus 轮询排队状态，放行后返回放行令牌
+func (cc *couponController) GetFlashSaleQueueStatus(ctx *gin.Context) {
+	uid, flashSaleID, ok := flashSaleUserOf(ctx)
+	if !ok {
+		return
+	}
+
+	resp, err := cc.sf.Coupon().GetFlashSaleQueueStatus(ctx, uid, flashSaleID)
+	if err != nil {
+		core.WriteResponse(ctx, err, nil)
+		return
+	}
+	core.WriteResponse(ctx, nil, resp)
+}
+
+// ParticipateFlashSale 携带放行令牌参与秒杀，风控要求验证码时需在请求头携带验证码重试
+func (cc *couponController) ParticipateFlashSale(ctx *gin.Context) {
+	var req request.ParticipateFlashSaleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		core.WriteResponse(ctx, err, nil)
+		return
+	}
+	uid, flashSaleID, ok := flashSaleUserOf(ctx)
+	if !ok {
+		return
+	}
+
+	risk := cc.sf.Risk().Context(ctx, uid, rv1.ClientInfoFromHeader(ctx.Request.Header, ctx.ClientIP()))
+	resp, err := cc.sf.Coupon().ParticipateFlashSale(ctx, uid, flashSaleID, &req, risk)
+	if err != nil {
+		core.WriteResponse(ctx, err, nil)
+		return
+	}
+	core.WriteResponse(ctx, nil, resp)
+}
+
+// BuyFlashSaleGoods 抢购秒杀商品，成功后返回待支付订单，需在支付截止时间前完成支付
+func (cc *couponController) BuyFlashSaleGoods(ctx *gin.Context) {
+	var req request.BuyFlashSaleGoodsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		core.WriteResponse(ctx, err, nil)
+		return
+	}
+	uid, flashSaleID, ok := flashSaleUserOf(ctx)
+	if !ok {
+		return
+	}
+
+	risk := cc.sf.Risk().Context(ctx, uid, rv1.ClientInfoFromHeader(ctx.Request.Header, ctx.ClientIP()))
+	resp, err := cc.sf.Coupon().BuyFlashSaleGoods(ctx, uid, flashSaleID, &req, risk)
+	if err != nil {
+		core.WriteResponse(ctx, err, nil)
+		return
+	}
+	core.WriteResponse(ctx, nil, resp)
+}
+
+// flashSaleUserOf 解析登录用户与路径中的秒杀活动ID，失败时直接写入错误响应
+func flashSaleUserOf(ctx *gin.Context) (int64, int64, bool) {
+	uid, ok := middleware.GetUserIDFromContext(ctx)
+	if !ok || uid <= 0 {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"code": 401, "message": "用户未登录"})
+		return 0, 0, false
+	}
+	flashSaleID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || flashSaleID <= 0 {
+		core.WriteResponse(ctx, errors.WithCode(code.ErrBind, "秒杀活动ID格式不正确"), nil)
+		return 0, 0, false
+	}
+	return int64(uid), flashSaleID, true
+}
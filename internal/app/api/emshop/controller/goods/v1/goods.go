@@ -28,7 +28,7 @@ func NewGoodsController(srv service.ServiceFactory, trans restserver.I18nTransla
 }
 
 func (gc *goodsController) List(ctx *gin.Context) {
-	log.Info("goods list function called ...")
+	log.InfoC(ctx, "goods list function called ...")
 
 	var r request.GoodsFilter
 
@@ -121,7 +121,7 @@ func (gc *goodsController) List(ctx *gin.Context) {
 }
 
 func (gc *goodsController) New(ctx *gin.Context) {
-	log.Info("goods new function called ...")
+	log.InfoC(ctx, "goods new function called ...")
 
 	var r request.CreateGoods
 
@@ -190,7 +190,7 @@ func (gc *goodsController) New(ctx *gin.Context) {
 }
 
 func (gc *goodsController) Sync(ctx *gin.Context) {
-	log.Info("goods sync function called ...")
+	log.InfoC(ctx, "goods sync function called ...")
 
 	var r request.SyncData
 
@@ -222,7 +222,7 @@ func (gc *goodsController) Sync(ctx *gin.Context) {
 }
 
 func (gc *goodsController) Detail(ctx *gin.Context) {
-	log.Info("goods detail function called ...")
+	log.InfoC(ctx, "goods detail function called ...")
 
 	id := ctx.Param("id")
 	if id == "" {
@@ -274,7 +274,7 @@ func (gc *goodsController) Detail(ctx *gin.Context) {
 }
 
 func (gc *goodsController) Delete(ctx *gin.Context) {
-	log.Info("goods delete function called ...")
+	log.InfoC(ctx, "goods delete function called ...")
 
 	id := ctx.Param("id")
 	if id == "" {
@@ -304,7 +304,7 @@ func (gc *goodsController) Delete(ctx *gin.Context) {
 }
 
 func (gc *goodsController) Update(ctx *gin.Context) {
-	log.Info("goods update function called ...")
+	log.InfoC(ctx, "goods update function called ...")
 
 	id := ctx.Param("id")
 	if id == "" {
@@ -356,7 +356,7 @@ func (gc *goodsController) Update(ctx *gin.Context) {
 }
 
 func (gc *goodsController) UpdateStatus(ctx *gin.Context) {
-	log.Info("goods update status function called ...")
+	log.InfoC(ctx, "goods update status function called ...")
 
 	id := ctx.Param("id")
 	if id == "" {
@@ -418,7 +418,7 @@ func (gc *goodsController) UpdateStatus(ctx *gin.Context) {
 }
 
 func (gc *goodsController) Stocks(ctx *gin.Context) {
-	log.Info("goods stocks function called ...")
+	log.InfoC(ctx, "goods stocks function called ...")
 
 	id := ctx.Param("id")
 	if id == "" {
@@ -444,7 +444,7 @@ func (gc *goodsController) Stocks(ctx *gin.Context) {
 // ==================== 分类管理 ====================
 
 func (gc *goodsController) CategoryList(ctx *gin.Context) {
-	log.Info("category list function called ...")
+	log.InfoC(ctx, "category list function called ...")
 
 	categoriesResponse, err := gc.srv.Goods().CategoryList(ctx)
 	if err != nil {
@@ -457,7 +457,7 @@ func (gc *goodsController) CategoryList(ctx *gin.Context) {
 }
 
 func (gc *goodsController) CategoryDetail(ctx *gin.Context) {
-	log.Info("category detail function called ...")
+	log.InfoC(ctx, "category detail function called ...")
 
 	id := ctx.Param("id")
 	if id == "" {
@@ -506,7 +506,7 @@ func (gc *goodsController) CategoryDetail(ctx *gin.Context) {
 }
 
 func (gc *goodsController) CreateCategory(ctx *gin.Context) {
-	log.Info("create category function called ...")
+	log.InfoC(ctx, "create category function called ...")
 
 	var r request.CreateCategory
 
@@ -540,7 +540,7 @@ func (gc *goodsController) CreateCategory(ctx *gin.Context) {
 }
 
 func (gc *goodsController) UpdateCategory(ctx *gin.Context) {
-	log.Info("update category function called ...")
+	log.InfoC(ctx, "update category function called ...")
 
 	id := ctx.Param("id")
 	if id == "" {
@@ -581,7 +581,7 @@ func (gc *goodsController) UpdateCategory(ctx *gin.Context) {
 }
 
 func (gc *goodsController) DeleteCategory(ctx *gin.Context) {
-	log.Info("delete category function called ...")
+	log.InfoC(ctx, "delete category function called ...")
 
 	id := ctx.Param("id")
 	if id == "" {
@@ -613,7 +613,7 @@ func (gc *goodsController) DeleteCategory(ctx *gin.Context) {
 // ==================== 品牌管理 ====================
 
 func (gc *goodsController) BrandList(ctx *gin.Context) {
-	log.Info("brand list function called ...")
+	log.InfoC(ctx, "brand list function called ...")
 
 	var r request.BrandFilter
 
@@ -663,7 +663,7 @@ func (gc *goodsController) BrandList(ctx *gin.Context) {
 }
 
 func (gc *goodsController) CreateBrand(ctx *gin.Context) {
-	log.Info("create brand function called ...")
+	log.InfoC(ctx, "create brand function called ...")
 
 	var r request.CreateBrand
 
@@ -693,7 +693,7 @@ func (gc *goodsController) CreateBrand(ctx *gin.Context) {
 }
 
 func (gc *goodsController) UpdateBrand(ctx *gin.Context) {
-	log.Info("update brand function called ...")
+	log.InfoC(ctx, "update brand function called ...")
 
 	id := ctx.Param("id")
 	if id == "" {
@@ -732,7 +732,7 @@ func (gc *goodsController) UpdateBrand(ctx *gin.Context) {
 }
 
 func (gc *goodsController) DeleteBrand(ctx *gin.Context) {
-	log.Info("delete brand function called ...")
+	log.InfoC(ctx, "delete brand function called ...")
 
 	id := ctx.Param("id")
 	if id == "" {
@@ -764,7 +764,7 @@ func (gc *goodsController) DeleteBrand(ctx *gin.Context) {
 // ==================== 轮播图管理 ====================
 
 func (gc *goodsController) BannerList(ctx *gin.Context) {
-	log.Info("banner list function called ...")
+	log.InfoC(ctx, "banner list function called ...")
 
 	bannersResponse, err := gc.srv.Goods().BannerList(ctx)
 	if err != nil {
@@ -791,7 +791,7 @@ func (gc *goodsController) BannerList(ctx *gin.Context) {
 }
 
 func (gc *goodsController) CreateBanner(ctx *gin.Context) {
-	log.Info("create banner function called ...")
+	log.InfoC(ctx, "create banner function called ...")
 
 	var r request.CreateBanner
 
@@ -823,7 +823,7 @@ func (gc *goodsController) CreateBanner(ctx *gin.Context) {
 }
 
 func (gc *goodsController) UpdateBanner(ctx *gin.Context) {
-	log.Info("update banner function called ...")
+	log.InfoC(ctx, "update banner function called ...")
 
 	id := ctx.Param("id")
 	if id == "" {
@@ -863,7 +863,7 @@ func (gc *goodsController) UpdateBanner(ctx *gin.Context) {
 }
 
 func (gc *goodsController) DeleteBanner(ctx *gin.Context) {
-	log.Info("delete banner function called ...")
+	log.InfoC(ctx, "delete banner function called ...")
 
 	id := ctx.Param("id")
 	if id == "" {
@@ -34,7 +34,7 @@ func NewOrderController(srv service.ServiceFactory, trans restserver.I18nTransla
 // ==================== 订单管理 ====================
 
 func (oc *orderController) OrderList(ctx *gin.Context) {
-	log.Info("order list function called ...")
+	log.InfoC(ctx, "order list function called ...")
 
 	var r request.OrderFilter
 
@@ -99,7 +99,7 @@ func (oc *orderController) OrderList(ctx *gin.Context) {
 }
 
 func (oc *orderController) CreateOrder(ctx *gin.Context) {
-	log.Info("create order function called ...")
+	log.InfoC(ctx, "create order function called ...")
 
 	var r request.CreateOrder
 
@@ -127,7 +127,8 @@ func (oc *orderController) CreateOrder(ctx *gin.Context) {
 		OrderSn: &orderSn,
 	}
 
-	_, err := oc.srv.Order().CreateOrder(ctx, &orderRequest)
+	// 幂等键随 gRPC 元数据转发给订单服务
+	_, err := oc.srv.Order().CreateOrder(middleware.IdempotentContext(ctx), &orderRequest)
 	if err != nil {
 		core.WriteResponse(ctx, err, nil)
 		return
@@ -149,7 +150,7 @@ func generateOrderSn(userId int32) string {
 }
 
 func (oc *orderController) OrderDetail(ctx *gin.Context) {
-	log.Info("order detail function called ...")
+	log.InfoC(ctx, "order detail function called ...")
 
 	id := ctx.Param("id")
 	if id == "" {
@@ -213,7 +214,7 @@ func (oc *orderController) OrderDetail(ctx *gin.Context) {
 // ==================== 购物车管理 ====================
 
 func (oc *orderController) CartList(ctx *gin.Context) {
-	log.Info("cart list function called ...")
+	log.InfoC(ctx, "cart list function called ...")
 
 	// 从上下文获取用户ID（统一使用中间件助手）
 	uid, ok := middleware.GetUserIDFromContext(ctx)
@@ -251,7 +252,7 @@ func (oc *orderController) CartList(ctx *gin.Context) {
 }
 
 func (oc *orderController) AddToCart(ctx *gin.Context) {
-	log.Info("add to cart function called ...")
+	log.InfoC(ctx, "add to cart function called ...")
 
 	var r request.AddToCart
 
@@ -292,7 +293,7 @@ func (oc *orderController) AddToCart(ctx *gin.Context) {
 }
 
 func (oc *orderController) UpdateCartItem(ctx *gin.Context) {
-	log.Info("update cart item function called ...")
+	log.InfoC(ctx, "update cart item function called ...")
 
 	id := ctx.Param("id")
 	if id == "" {
@@ -341,7 +342,7 @@ func (oc *orderController) UpdateCartItem(ctx *gin.Context) {
 }
 
 func (oc *orderController) DeleteCartItem(ctx *gin.Context) {
-	log.Info("delete cart item function called ...")
+	log.InfoC(ctx, "delete cart item function called ...")
 
 	id := ctx.Param("id")
 	if id == "" {
@@ -39,8 +39,8 @@ func (pc *paymentController) CreatePayment(ctx *gin.Context) {
         return
     }
 
-    // 调用服务层
-    resp, err := pc.sf.Payment().CreatePayment(ctx, int32(uid), &req)
+    // 调用服务层，幂等键随 gRPC 元数据转发给支付服务
+    resp, err := pc.sf.Payment().CreatePayment(middleware.IdempotentContext(ctx), int32(uid), &req)
     if err != nil {
         core.WriteResponse(ctx, err, nil)
         return
@@ -7,6 +7,7 @@ import (
 	upbv1 "emshop/api/user/v1"
 	restserver "emshop/gin-micro/server/rest-server"
 	"emshop/internal/app/api/emshop/service"
+	rv1 "emshop/internal/app/api/emshop/service/risk/v1"
 	v1 "emshop/internal/app/api/emshop/service/sms/v1"
 	"emshop/internal/app/pkg/code"
 	gin2 "emshop/internal/app/pkg/translator/gin"
@@ -44,6 +45,13 @@ func (sc *SmsController) SendSms(c *gin.Context) {
 		return
 	}
 
+	// 风控要求验证码时客户端需在请求头携带图形验证码重试
+	client := rv1.ClientInfoFromHeader(c.Request.Header, c.ClientIP())
+	if err := sc.sf.Risk().CheckSms(c, smsReq.Mobile, client); err != nil {
+		core.WriteResponse(c, err, nil)
+		return
+	}
+
 	smsCode := v1.GenerateSmsCode(6)
 
 	// 开发环境：跳过实际短信发送，但保持完整的验证码生成和存储逻辑
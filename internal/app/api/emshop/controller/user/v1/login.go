@@ -14,7 +14,7 @@ import (
 // var store = base64Captcha.DefaultMemStore
 
 func (us *userServer) Login(ctx *gin.Context) {
-	log.Info("login is called")
+	log.InfoC(ctx, "login is called")
 
 	var loginReq upbv1.UserLoginRequest
 
@@ -76,7 +76,7 @@ func (us *userServer) Login(ctx *gin.Context) {
 		}
 
 		// 其他未知错误返回内部服务器错误
-		log.Errorf("login failed with unknown error: %v", err)
+		log.ErrorfC(ctx, "login failed with unknown error: %v", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{
 			"msg": us.trans.T("business.login_failed"),
 		})
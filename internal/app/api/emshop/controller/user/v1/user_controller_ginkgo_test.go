@@ -17,6 +17,7 @@ import (
 	lv1 "emshop/internal/app/api/emshop/service/logistics/v1"
 	ov1 "emshop/internal/app/api/emshop/service/order/v1"
 	pv1 "emshop/internal/app/api/emshop/service/payment/v1"
+	rv1 "emshop/internal/app/api/emshop/service/risk/v1"
 	sv1 "emshop/internal/app/api/emshop/service/sms/v1"
 	uv1 "emshop/internal/app/api/emshop/service/user/v1"
 	uopv1 "emshop/internal/app/api/emshop/service/userop/v1"
@@ -473,6 +474,7 @@ func (f *fakeServiceFactory) UserOp() uopv1.UserOpSrv     { return nil }
 func (f *fakeServiceFactory) Coupon() cv1.CouponSrv       { return nil }
 func (f *fakeServiceFactory) Payment() pv1.PaymentSrv     { return nil }
 func (f *fakeServiceFactory) Logistics() lv1.LogisticsSrv { return nil }
+func (f *fakeServiceFactory) Risk() rv1.RiskSrv           { return nil }
 
 type captchaStore interface {
 	Set(id string, value string) error
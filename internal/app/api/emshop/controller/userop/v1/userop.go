@@ -30,7 +30,7 @@ func NewUserOpController(srv service.ServiceFactory, trans restserver.I18nTransl
 // ==================== 用户收藏管理 ====================
 
 func (uoc *userOpController) UserFavList(ctx *gin.Context) {
-	log.Info("user fav list function called ...")
+	log.InfoC(ctx, "user fav list function called ...")
 
 	// 从JWT中获取用户ID
 	userId, exists := ctx.Get("user_id")
@@ -66,7 +66,7 @@ func (uoc *userOpController) UserFavList(ctx *gin.Context) {
 }
 
 func (uoc *userOpController) CreateUserFav(ctx *gin.Context) {
-	log.Info("create user fav function called ...")
+	log.InfoC(ctx, "create user fav function called ...")
 
 	var r request.UserFav
 
@@ -102,7 +102,7 @@ func (uoc *userOpController) CreateUserFav(ctx *gin.Context) {
 }
 
 func (uoc *userOpController) DeleteUserFav(ctx *gin.Context) {
-	log.Info("delete user fav function called ...")
+	log.InfoC(ctx, "delete user fav function called ...")
 
 	goodsId := ctx.Param("id")
 	if goodsId == "" {
@@ -140,7 +140,7 @@ func (uoc *userOpController) DeleteUserFav(ctx *gin.Context) {
 }
 
 func (uoc *userOpController) GetUserFavDetail(ctx *gin.Context) {
-	log.Info("get user fav detail function called ...")
+	log.InfoC(ctx, "get user fav detail function called ...")
 
 	goodsId := ctx.Param("id")
 	if goodsId == "" {
@@ -183,7 +183,7 @@ func (uoc *userOpController) GetUserFavDetail(ctx *gin.Context) {
 // ==================== 用户地址管理 ====================
 
 func (uoc *userOpController) GetAddressList(ctx *gin.Context) {
-	log.Info("get address list function called ...")
+	log.InfoC(ctx, "get address list function called ...")
 
 	// 从JWT中获取用户ID
 	userId, exists := ctx.Get("user_id")
@@ -224,7 +224,7 @@ func (uoc *userOpController) GetAddressList(ctx *gin.Context) {
 }
 
 func (uoc *userOpController) CreateAddress(ctx *gin.Context) {
-	log.Info("create address function called ...")
+	log.InfoC(ctx, "create address function called ...")
 
 	var r request.CreateAddress
 
@@ -270,7 +270,7 @@ func (uoc *userOpController) CreateAddress(ctx *gin.Context) {
 }
 
 func (uoc *userOpController) UpdateAddress(ctx *gin.Context) {
-	log.Info("update address function called ...")
+	log.InfoC(ctx, "update address function called ...")
 
 	id := ctx.Param("id")
 	if id == "" {
@@ -321,7 +321,7 @@ func (uoc *userOpController) UpdateAddress(ctx *gin.Context) {
 }
 
 func (uoc *userOpController) DeleteAddress(ctx *gin.Context) {
-	log.Info("delete address function called ...")
+	log.InfoC(ctx, "delete address function called ...")
 
 	id := ctx.Param("id")
 	if id == "" {
@@ -361,7 +361,7 @@ func (uoc *userOpController) DeleteAddress(ctx *gin.Context) {
 // ==================== 用户留言管理 ====================
 
 func (uoc *userOpController) MessageList(ctx *gin.Context) {
-	log.Info("message list function called ...")
+	log.InfoC(ctx, "message list function called ...")
 
 	// 从JWT中获取用户ID
 	userId, exists := ctx.Get("user_id")
@@ -400,7 +400,7 @@ func (uoc *userOpController) MessageList(ctx *gin.Context) {
 }
 
 func (uoc *userOpController) CreateMessage(ctx *gin.Context) {
-	log.Info("create message function called ...")
+	log.InfoC(ctx, "create message function called ...")
 
 	var r request.CreateMessage
 
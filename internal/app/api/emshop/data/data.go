@@ -17,6 +17,7 @@ type GoodsData interface {
 	CreateGoods(ctx context.Context, info *gpb.CreateGoodsInfo) (*gpb.GoodsInfoResponse, error)
 	SyncGoodsData(ctx context.Context, request *gpb.SyncDataRequest) (*gpb.SyncDataResponse, error)
 	GetGoodsDetail(ctx context.Context, request *gpb.GoodInfoRequest) (*gpb.GoodsInfoResponse, error)
+	BatchGetGoods(ctx context.Context, request *gpb.BatchGoodsIdInfo) (*gpb.GoodsListResponse, error)
 	DeleteGoods(ctx context.Context, info *gpb.DeleteGoodsInfo) (*gpb.GoodsInfoResponse, error)
 	UpdateGoods(ctx context.Context, info *gpb.CreateGoodsInfo) (*gpb.GoodsInfoResponse, error)
 
@@ -46,6 +47,7 @@ type OrderData interface {
 	CreateOrder(ctx context.Context, request *opb.OrderRequest) (*opb.OrderInfoResponse, error)
 	OrderDetail(ctx context.Context, request *opb.OrderRequest) (*opb.OrderInfoDetailResponse, error)
 	UpdateOrderStatus(ctx context.Context, request *opb.OrderStatus) (*opb.OrderInfoResponse, error)
+	CreateFlashSaleOrder(ctx context.Context, request *opb.FlashSaleOrderRequest) (*opb.OrderInfoResponse, error)
 
 	// 购物车管理
 	CartItemList(ctx context.Context, request *opb.UserInfo) (*opb.CartItemListResponse, error)
@@ -66,6 +68,7 @@ type UserOpData interface {
 	CreateAddress(ctx context.Context, request *uoppb.AddressRequest) (*uoppb.AddressResponse, error)
 	UpdateAddress(ctx context.Context, request *uoppb.AddressRequest) (*uoppb.AddressResponse, error)
 	DeleteAddress(ctx context.Context, request *uoppb.DeleteAddressRequest) (*uoppb.AddressResponse, error)
+	CountAddressSharedUsers(ctx context.Context, request *uoppb.AddressSharedUsersRequest) (*uoppb.AddressSharedUsersResponse, error)
 
 	// 用户留言管理
 	MessageList(ctx context.Context, request *uoppb.MessageRequest) (*uoppb.MessageListResponse, error)
@@ -94,11 +97,25 @@ type CouponData interface {
 
 	// 用户优惠券操作
 	ReceiveCoupon(ctx context.Context, request *cpb.ReceiveCouponRequest) (*cpb.UserCouponResponse, error)
+	RedeemPromoCode(ctx context.Context, request *cpb.RedeemPromoCodeRequest) (*cpb.UserCouponResponse, error)
 	GetUserCoupons(ctx context.Context, request *cpb.GetUserCouponsRequest) (*cpb.ListUserCouponsResponse, error)
 	GetAvailableCoupons(ctx context.Context, request *cpb.GetAvailableCouponsRequest) (*cpb.ListUserCouponsResponse, error)
+	ListCouponReminders(ctx context.Context, request *cpb.ListCouponRemindersRequest) (*cpb.ListCouponRemindersResponse, error)
 
 	// 优惠券计算和使用
 	CalculateCouponDiscount(ctx context.Context, request *cpb.CalculateCouponDiscountRequest) (*cpb.CalculateCouponDiscountResponse, error)
+
+	// 秒杀排队与参与
+	EnterFlashSaleQueue(ctx context.Context, request *cpb.FlashSaleQueueRequest) (*cpb.FlashSaleQueueStatusResponse, error)
+	GetFlashSaleQueueStatus(ctx context.Context, request *cpb.FlashSaleQueueRequest) (*cpb.FlashSaleQueueStatusResponse, error)
+	ParticipateFlashSale(ctx context.Context, request *cpb.ParticipateFlashSaleRequest) (*cpb.ParticipateFlashSaleResponse, error)
+
+	// 商品秒杀预占与结算
+	ReserveFlashSaleGoods(ctx context.Context, request *cpb.ParticipateFlashSaleRequest) (*cpb.FlashSaleReservationResponse, error)
+	SettleFlashSaleReservation(ctx context.Context, request *cpb.SettleFlashSaleReservationRequest) (*cpb.FlashSaleReservationResponse, error)
+
+	// 风控评分
+	EvaluateRisk(ctx context.Context, request *cpb.EvaluateRiskRequest) (*cpb.RiskDecisionResponse, error)
 }
 
 type PaymentData interface {
@@ -15,6 +15,7 @@ import (
 	"emshop/gin-micro/registry"
 	rpcserver "emshop/gin-micro/server/rpc-server"
 	clientinterceptors "emshop/gin-micro/server/rpc-server/client-interceptors"
+	"emshop/gin-micro/server/rpc-server/selector"
 	"emshop/pkg/log"
 	"google.golang.org/grpc"
 )
@@ -32,16 +33,16 @@ type grpcClients struct {
 }
 
 // newGrpcClients 创建并初始化所有 gRPC 客户端
-func newGrpcClients(discovery registry.Discovery) *grpcClients {
+func newGrpcClients(discovery registry.Discovery, filters ...selector.NodeFilter) *grpcClients {
 	return &grpcClients{
-		userClient:      NewUserServiceClient(discovery),
-		goodsClient:     NewGoodsServiceClient(discovery),
-		inventoryClient: NewInventoryServiceClient(discovery),
-		orderClient:     NewOrderServiceClient(discovery),
-		userOpClient:    NewUserOpServiceClient(discovery),
-		couponClient:    NewCouponServiceClient(discovery),
-		paymentClient:   NewPaymentServiceClient(discovery),
-		logisticsClient: NewLogisticsServiceClient(discovery),
+		userClient:      NewUserServiceClient(discovery, filters...),
+		goodsClient:     NewGoodsServiceClient(discovery, filters...),
+		inventoryClient: NewInventoryServiceClient(discovery, filters...),
+		orderClient:     NewOrderServiceClient(discovery, filters...),
+		userOpClient:    NewUserOpServiceClient(discovery, filters...),
+		couponClient:    NewCouponServiceClient(discovery, filters...),
+		paymentClient:   NewPaymentServiceClient(discovery, filters...),
+		logisticsClient: NewLogisticsServiceClient(discovery, filters...),
 	}
 }
 
@@ -60,13 +61,15 @@ const (
 // 移除直连fallback逻辑，统一通过服务发现
 
 // NewUserServiceClient 创建用户服务的 gRPC 客户端
-func NewUserServiceClient(r registry.Discovery) upbv1.UserClient {
+func NewUserServiceClient(r registry.Discovery, filters ...selector.NodeFilter) upbv1.UserClient {
 	log.Infof("Initializing gRPC connection to service: %s", clientUserServiceName)
 	conn, err := rpcserver.DialInsecure(
 		context.Background(),
 		rpcserver.WithBalancerName("p2c"),
 		rpcserver.WithEndpoint(clientUserServiceName),
+		rpcserver.WithHealthCheck(),
 		rpcserver.WithDiscovery(r),
+		rpcserver.WithNodeFilter(filters...),
 		rpcserver.WithClientTimeout(10*time.Second),
 		rpcserver.WithClientOptions(grpc.WithNoProxy()),
 		rpcserver.WithClientUnaryInterceptor(clientinterceptors.UnaryTracingInterceptor),
@@ -81,13 +84,15 @@ func NewUserServiceClient(r registry.Discovery) upbv1.UserClient {
 }
 
 // NewGoodsServiceClient 创建商品服务的 gRPC 客户端
-func NewGoodsServiceClient(r registry.Discovery) gpbv1.GoodsClient {
+func NewGoodsServiceClient(r registry.Discovery, filters ...selector.NodeFilter) gpbv1.GoodsClient {
 	log.Infof("Initializing gRPC connection to service: %s", clientGoodsServiceName)
 	conn, err := rpcserver.DialInsecure(
 		context.Background(),
 		rpcserver.WithBalancerName("p2c"),
 		rpcserver.WithEndpoint(clientGoodsServiceName),
+		rpcserver.WithHealthCheck(),
 		rpcserver.WithDiscovery(r),
+		rpcserver.WithNodeFilter(filters...),
 		rpcserver.WithClientTimeout(10*time.Second),
 		rpcserver.WithClientOptions(grpc.WithNoProxy()),
 		rpcserver.WithClientUnaryInterceptor(clientinterceptors.UnaryTracingInterceptor),
@@ -102,13 +107,15 @@ func NewGoodsServiceClient(r registry.Discovery) gpbv1.GoodsClient {
 }
 
 // NewInventoryServiceClient 创建库存服务的 gRPC 客户端，仅使用 Consul 服务发现
-func NewInventoryServiceClient(r registry.Discovery) ipb.InventoryClient {
+func NewInventoryServiceClient(r registry.Discovery, filters ...selector.NodeFilter) ipb.InventoryClient {
 	log.Infof("Initializing gRPC connection to service: %s", clientInventoryServiceName)
 	conn, err := rpcserver.DialInsecure(
 		context.Background(),
 		rpcserver.WithBalancerName("p2c"),
 		rpcserver.WithEndpoint(clientInventoryServiceName),
+		rpcserver.WithHealthCheck(),
 		rpcserver.WithDiscovery(r),
+		rpcserver.WithNodeFilter(filters...),
 		rpcserver.WithClientTimeout(10*time.Second),
 		rpcserver.WithClientOptions(grpc.WithNoProxy()),
 		rpcserver.WithClientUnaryInterceptor(clientinterceptors.UnaryTracingInterceptor),
@@ -122,13 +129,15 @@ func NewInventoryServiceClient(r registry.Discovery) ipb.InventoryClient {
 }
 
 // NewOrderServiceClient 创建订单服务的 gRPC 客户端
-func NewOrderServiceClient(r registry.Discovery) opbv1.OrderClient {
+func NewOrderServiceClient(r registry.Discovery, filters ...selector.NodeFilter) opbv1.OrderClient {
 	log.Infof("Initializing gRPC connection to service: %s", clientOrderServiceName)
 	conn, err := rpcserver.DialInsecure(
 		context.Background(),
 		rpcserver.WithBalancerName("p2c"),
 		rpcserver.WithEndpoint(clientOrderServiceName),
+		rpcserver.WithHealthCheck(),
 		rpcserver.WithDiscovery(r),
+		rpcserver.WithNodeFilter(filters...),
 		rpcserver.WithClientTimeout(10*time.Second),
 		rpcserver.WithClientOptions(grpc.WithNoProxy()),
 		rpcserver.WithClientUnaryInterceptor(clientinterceptors.UnaryTracingInterceptor),
@@ -143,13 +152,15 @@ func NewOrderServiceClient(r registry.Discovery) opbv1.OrderClient {
 }
 
 // NewUserOpServiceClient 创建用户操作服务的 gRPC 客户端
-func NewUserOpServiceClient(r registry.Discovery) uoppbv1.UserOpClient {
+func NewUserOpServiceClient(r registry.Discovery, filters ...selector.NodeFilter) uoppbv1.UserOpClient {
 	log.Infof("Initializing gRPC connection to service: %s", clientUseropServiceName)
 	conn, err := rpcserver.DialInsecure(
 		context.Background(),
 		rpcserver.WithBalancerName("p2c"),
 		rpcserver.WithEndpoint(clientUseropServiceName),
+		rpcserver.WithHealthCheck(),
 		rpcserver.WithDiscovery(r),
+		rpcserver.WithNodeFilter(filters...),
 		rpcserver.WithClientTimeout(10*time.Second),
 		rpcserver.WithClientOptions(grpc.WithNoProxy()),
 		rpcserver.WithClientUnaryInterceptor(clientinterceptors.UnaryTracingInterceptor),
@@ -164,13 +175,15 @@ func NewUserOpServiceClient(r registry.Discovery) uoppbv1.UserOpClient {
 }
 
 // NewCouponServiceClient 创建优惠券服务的 gRPC 客户端
-func NewCouponServiceClient(r registry.Discovery) cpbv1.CouponClient {
+func NewCouponServiceClient(r registry.Discovery, filters ...selector.NodeFilter) cpbv1.CouponClient {
 	log.Infof("Initializing gRPC connection to service: %s", clientCouponServiceName)
 	conn, err := rpcserver.DialInsecure(
 		context.Background(),
 		rpcserver.WithBalancerName("p2c"),
 		rpcserver.WithEndpoint(clientCouponServiceName),
+		rpcserver.WithHealthCheck(),
 		rpcserver.WithDiscovery(r),
+		rpcserver.WithNodeFilter(filters...),
 		rpcserver.WithClientTimeout(10*time.Second),
 		rpcserver.WithClientOptions(grpc.WithNoProxy()),
 		rpcserver.WithClientUnaryInterceptor(clientinterceptors.UnaryTracingInterceptor),
@@ -185,12 +198,14 @@ func NewCouponServiceClient(r registry.Discovery) cpbv1.CouponClient {
 }
 
 // NewPaymentServiceClient 创建支付服务的 gRPC 客户端
-func NewPaymentServiceClient(r registry.Discovery) ppbv1.PaymentClient {
+func NewPaymentServiceClient(r registry.Discovery, filters ...selector.NodeFilter) ppbv1.PaymentClient {
 	log.Infof("Initializing gRPC connection to service: %s", clientPaymentServiceName)
 	conn, err := rpcserver.DialInsecure(
 		context.Background(),
 		rpcserver.WithEndpoint(clientPaymentServiceName),
+		rpcserver.WithHealthCheck(),
 		rpcserver.WithDiscovery(r),
+		rpcserver.WithNodeFilter(filters...),
 		rpcserver.WithClientTimeout(10*time.Second),
 		rpcserver.WithClientOptions(grpc.WithNoProxy()),
 		rpcserver.WithClientUnaryInterceptor(clientinterceptors.UnaryTracingInterceptor),
@@ -205,13 +220,15 @@ func NewPaymentServiceClient(r registry.Discovery) ppbv1.PaymentClient {
 }
 
 // NewLogisticsServiceClient 创建物流服务的 gRPC 客户端
-func NewLogisticsServiceClient(r registry.Discovery) lpbv1.LogisticsClient {
+func NewLogisticsServiceClient(r registry.Discovery, filters ...selector.NodeFilter) lpbv1.LogisticsClient {
 	log.Infof("Initializing gRPC connection to service: %s", clientLogisticsServiceName)
 	conn, err := rpcserver.DialInsecure(
 		context.Background(),
 		rpcserver.WithBalancerName("p2c"),
 		rpcserver.WithEndpoint(clientLogisticsServiceName),
+		rpcserver.WithHealthCheck(),
 		rpcserver.WithDiscovery(r),
+		rpcserver.WithNodeFilter(filters...),
 		rpcserver.WithClientTimeout(10*time.Second),
 		rpcserver.WithClientOptions(grpc.WithNoProxy()),
 		rpcserver.WithClientUnaryInterceptor(clientinterceptors.UnaryTracingInterceptor),
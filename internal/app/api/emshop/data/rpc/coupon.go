@@ -4,7 +4,12 @@ import (
 	"context"
 	cpbv1 "emshop/api/coupon/v1"
 	"emshop/internal/app/api/emshop/data"
+	"emshop/internal/app/pkg/code"
+	"emshop/pkg/errors"
 	"emshop/pkg/log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type coupon struct {
@@ -17,76 +22,170 @@ func NewCoupon(cc cpbv1.CouponClient) *coupon {
 
 // ListCouponTemplates 获取优惠券模板列表
 func (c *coupon) ListCouponTemplates(ctx context.Context, request *cpbv1.ListCouponTemplatesRequest) (*cpbv1.ListCouponTemplatesResponse, error) {
-	log.Infof("Calling ListCouponTemplates gRPC with page: %d, pageSize: %d", request.Page, request.PageSize)
+	log.InfofC(ctx, "Calling ListCouponTemplates gRPC with page: %d, pageSize: %d", request.Page, request.PageSize)
 	response, err := c.cc.ListCouponTemplates(ctx, request)
 	if err != nil {
-		log.Errorf("ListCouponTemplates gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "ListCouponTemplates gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("ListCouponTemplates gRPC call successful, total: %d", response.TotalCount)
+	log.InfofC(ctx, "ListCouponTemplates gRPC call successful, total: %d", response.TotalCount)
 	return response, nil
 }
 
 // GetCouponTemplate 获取优惠券模板详情
 func (c *coupon) GetCouponTemplate(ctx context.Context, request *cpbv1.GetCouponTemplateRequest) (*cpbv1.CouponTemplateResponse, error) {
-	log.Infof("Calling GetCouponTemplate gRPC with ID: %d", request.Id)
+	log.InfofC(ctx, "Calling GetCouponTemplate gRPC with ID: %d", request.Id)
 	response, err := c.cc.GetCouponTemplate(ctx, request)
 	if err != nil {
-		log.Errorf("GetCouponTemplate gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "GetCouponTemplate gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("GetCouponTemplate gRPC call successful, template name: %s", response.Name)
+	log.InfofC(ctx, "GetCouponTemplate gRPC call successful, template name: %s", response.Name)
 	return response, nil
 }
 
 // ReceiveCoupon 领取优惠券
 func (c *coupon) ReceiveCoupon(ctx context.Context, request *cpbv1.ReceiveCouponRequest) (*cpbv1.UserCouponResponse, error) {
-	log.Infof("Calling ReceiveCoupon gRPC for user: %d, template: %d", request.UserId, request.CouponTemplateId)
+	log.InfofC(ctx, "Calling ReceiveCoupon gRPC for user: %d, template: %d", request.UserId, request.CouponTemplateId)
 	response, err := c.cc.ReceiveCoupon(ctx, request)
 	if err != nil {
-		log.Errorf("ReceiveCoupon gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "ReceiveCoupon gRPC call failed: %v", err)
+		return nil, riskError(err)
+	}
+	log.InfofC(ctx, "ReceiveCoupon gRPC call successful, coupon ID: %d, code: %s", response.Id, response.CouponCode)
+	return response, nil
+}
+
+// RedeemPromoCode 兑换码领取优惠券
+func (c *coupon) RedeemPromoCode(ctx context.Context, request *cpbv1.RedeemPromoCodeRequest) (*cpbv1.UserCouponResponse, error) {
+	log.InfofC(ctx, "Calling RedeemPromoCode gRPC for user: %d", request.UserId)
+	response, err := c.cc.RedeemPromoCode(ctx, request)
+	if err != nil {
+		log.ErrorfC(ctx, "RedeemPromoCode gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("ReceiveCoupon gRPC call successful, coupon ID: %d, code: %s", response.Id, response.CouponCode)
+	log.InfofC(ctx, "RedeemPromoCode gRPC call successful, coupon ID: %d, code: %s", response.Id, response.CouponCode)
 	return response, nil
 }
 
 // GetUserCoupons 获取用户优惠券列表
 func (c *coupon) GetUserCoupons(ctx context.Context, request *cpbv1.GetUserCouponsRequest) (*cpbv1.ListUserCouponsResponse, error) {
-	log.Infof("Calling GetUserCoupons gRPC for user: %d, page: %d, pageSize: %d", request.UserId, request.Page, request.PageSize)
+	log.InfofC(ctx, "Calling GetUserCoupons gRPC for user: %d, page: %d, pageSize: %d", request.UserId, request.Page, request.PageSize)
 	response, err := c.cc.GetUserCoupons(ctx, request)
 	if err != nil {
-		log.Errorf("GetUserCoupons gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "GetUserCoupons gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("GetUserCoupons gRPC call successful, total: %d", response.TotalCount)
+	log.InfofC(ctx, "GetUserCoupons gRPC call successful, total: %d", response.TotalCount)
+	return response, nil
+}
+
+// ListCouponReminders 获取用户优惠券即将过期提醒
+func (c *coupon) ListCouponReminders(ctx context.Context, request *cpbv1.ListCouponRemindersRequest) (*cpbv1.ListCouponRemindersResponse, error) {
+	log.InfofC(ctx, "Calling ListCouponReminders gRPC for user: %d, page: %d, pageSize: %d", request.UserId, request.Page, request.PageSize)
+	response, err := c.cc.ListCouponReminders(ctx, request)
+	if err != nil {
+		log.ErrorfC(ctx, "ListCouponReminders gRPC call failed: %v", err)
+		return nil, err
+	}
+	log.InfofC(ctx, "ListCouponReminders gRPC call successful, total: %d", response.TotalCount)
 	return response, nil
 }
 
 // GetAvailableCoupons 获取用户可用优惠券
 func (c *coupon) GetAvailableCoupons(ctx context.Context, request *cpbv1.GetAvailableCouponsRequest) (*cpbv1.ListUserCouponsResponse, error) {
-	log.Infof("Calling GetAvailableCoupons gRPC for user: %d, orderAmount: %.2f", request.UserId, request.OrderAmount)
+	log.InfofC(ctx, "Calling GetAvailableCoupons gRPC for user: %d, orderAmount: %.2f", request.UserId, request.OrderAmount)
 	response, err := c.cc.GetAvailableCoupons(ctx, request)
 	if err != nil {
-		log.Errorf("GetAvailableCoupons gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "GetAvailableCoupons gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("GetAvailableCoupons gRPC call successful, available coupons: %d", response.TotalCount)
+	log.InfofC(ctx, "GetAvailableCoupons gRPC call successful, available coupons: %d", response.TotalCount)
 	return response, nil
 }
 
 // CalculateCouponDiscount 计算优惠券折扣
 func (c *coupon) CalculateCouponDiscount(ctx context.Context, request *cpbv1.CalculateCouponDiscountRequest) (*cpbv1.CalculateCouponDiscountResponse, error) {
-	log.Infof("Calling CalculateCouponDiscount gRPC for user: %d, orderAmount: %.2f, coupons: %v",
+	log.InfofC(ctx, "Calling CalculateCouponDiscount gRPC for user: %d, orderAmount: %.2f, coupons: %v",
 		request.UserId, request.OrderAmount, request.CouponIds)
 	response, err := c.cc.CalculateCouponDiscount(ctx, request)
 	if err != nil {
-		log.Errorf("CalculateCouponDiscount gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "CalculateCouponDiscount gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("CalculateCouponDiscount gRPC call successful, discountAmount: %.2f, finalAmount: %.2f",
+	log.InfofC(ctx, "CalculateCouponDiscount gRPC call successful, discountAmount: %.2f, finalAmount: %.2f",
 		response.DiscountAmount, response.FinalAmount)
 	return response, nil
 }
 
+// EnterFlashSaleQueue 秒杀排队入队
+func (c *coupon) EnterFlashSaleQueue(ctx context.Context, request *cpbv1.FlashSaleQueueRequest) (*cpbv1.FlashSaleQueueStatusResponse, error) {
+	response, err := c.cc.EnterFlashSaleQueue(ctx, request)
+	if err != nil {
+		log.ErrorfC(ctx, "EnterFlashSaleQueue gRPC call failed: %v", err)
+		return nil, err
+	}
+	return response, nil
+}
+
+// GetFlashSaleQueueStatus 查询秒杀排队状态
+func (c *coupon) GetFlashSaleQueueStatus(ctx context.Context, request *cpbv1.FlashSaleQueueRequest) (*cpbv1.FlashSaleQueueStatusResponse, error) {
+	response, err := c.cc.GetFlashSaleQueueStatus(ctx, request)
+	if err != nil {
+		log.ErrorfC(ctx, "GetFlashSaleQueueStatus gRPC call failed: %v", err)
+		return nil, err
+	}
+	return response, nil
+}
+
+// ParticipateFlashSale 参与秒杀
+func (c *coupon) ParticipateFlashSale(ctx context.Context, request *cpbv1.ParticipateFlashSaleRequest) (*cpbv1.ParticipateFlashSaleResponse, error) {
+	log.InfofC(ctx, "Calling ParticipateFlashSale gRPC for user: %d, flashSale: %d", request.UserId, request.FlashSaleId)
+	response, err := c.cc.ParticipateFlashSale(ctx, request)
+	if err != nil {
+		log.ErrorfC(ctx, "ParticipateFlashSale gRPC call failed: %v", err)
+		return nil, riskError(err)
+	}
+	return response, nil
+}
+
+// ReserveFlashSaleGoods 抢购商品秒杀名额
+func (c *coupon) ReserveFlashSaleGoods(ctx context.Context, request *cpbv1.ParticipateFlashSaleRequest) (*cpbv1.FlashSaleReservationResponse, error) {
+	log.InfofC(ctx, "Calling ReserveFlashSaleGoods gRPC for user: %d, flashSale: %d", request.UserId, request.FlashSaleId)
+	response, err := c.cc.ReserveFlashSaleGoods(ctx, request)
+	if err != nil {
+		log.ErrorfC(ctx, "ReserveFlashSaleGoods gRPC call failed: %v", err)
+		return nil, riskError(err)
+	}
+	return response, nil
+}
+
+// SettleFlashSaleReservation 结算商品秒杀预占单
+func (c *coupon) SettleFlashSaleReservation(ctx context.Context, request *cpbv1.SettleFlashSaleReservationRequest) (*cpbv1.FlashSaleReservationResponse, error) {
+	response, err := c.cc.SettleFlashSaleReservation(ctx, request)
+	if err != nil {
+		log.ErrorfC(ctx, "SettleFlashSaleReservation gRPC call failed: %v", err)
+		return nil, err
+	}
+	return response, nil
+}
+
+// EvaluateRisk 风控评分
+func (c *coupon) EvaluateRisk(ctx context.Context, request *cpbv1.EvaluateRiskRequest) (*cpbv1.RiskDecisionResponse, error) {
+	response, err := c.cc.EvaluateRisk(ctx, request)
+	if err != nil {
+		log.ErrorfC(ctx, "EvaluateRisk gRPC call failed: %v", err)
+		return nil, err
+	}
+	return response, nil
+}
+
+// riskError 风控要求验证码时优惠券服务返回Unauthenticated，转换为业务错误码便于客户端弹出验证码
+func riskError(err error) error {
+	if st, ok := status.FromError(err); ok && st.Code() == codes.Unauthenticated {
+		return errors.WithCode(code.ErrRiskCaptchaRequired, "%s", st.Message())
+	}
+	return err
+}
+
 var _ data.CouponData = &coupon{}
@@ -18,217 +18,228 @@ func NewGoods(gc gpbv1.GoodsClient) *goods {
 }
 
 func (g *goods) GoodsList(ctx context.Context, request *gpbv1.GoodsFilterRequest) (*gpbv1.GoodsListResponse, error) {
-	log.Infof("Calling GoodsList gRPC with filter: %+v", request)
+	log.InfofC(ctx, "Calling GoodsList gRPC with filter: %+v", request)
 	response, err := g.gc.GoodsList(ctx, request)
 	if err != nil {
-		log.Errorf("GoodsList gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "GoodsList gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("GoodsList gRPC call successful, total: %d", response.Total)
+	log.InfofC(ctx, "GoodsList gRPC call successful, total: %d", response.Total)
 	return response, nil
 }
 
 func (g *goods) CreateGoods(ctx context.Context, info *gpbv1.CreateGoodsInfo) (*gpbv1.GoodsInfoResponse, error) {
-	log.Infof("Calling CreateGoods gRPC for goods: %s", info.Name)
+	log.InfofC(ctx, "Calling CreateGoods gRPC for goods: %s", info.Name)
 	response, err := g.gc.CreateGoods(ctx, info)
 	if err != nil {
-		log.Errorf("CreateGoods gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "CreateGoods gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("CreateGoods gRPC call successful, goods ID: %d", response.Id)
+	log.InfofC(ctx, "CreateGoods gRPC call successful, goods ID: %d", response.Id)
 	return response, nil
 }
 
 func (g *goods) SyncGoodsData(ctx context.Context, request *gpbv1.SyncDataRequest) (*gpbv1.SyncDataResponse, error) {
-	log.Infof("Calling SyncGoodsData gRPC with request: forceSync=%v, goodsIds=%v", request.ForceSync, request.GoodsIds)
+	log.InfofC(ctx, "Calling SyncGoodsData gRPC with request: forceSync=%v, goodsIds=%v", request.ForceSync, request.GoodsIds)
 	response, err := g.gc.SyncGoodsData(ctx, request)
 	if err != nil {
-		log.Errorf("SyncGoodsData gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "SyncGoodsData gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("SyncGoodsData gRPC call successful, synced=%d, failed=%d", response.SyncedCount, response.FailedCount)
+	log.InfofC(ctx, "SyncGoodsData gRPC call successful, synced=%d, failed=%d", response.SyncedCount, response.FailedCount)
 	return response, nil
 }
 
 func (g *goods) GetGoodsDetail(ctx context.Context, request *gpbv1.GoodInfoRequest) (*gpbv1.GoodsInfoResponse, error) {
-	log.Infof("Calling GetGoodsDetail gRPC for goods ID: %d", request.Id)
+	log.InfofC(ctx, "Calling GetGoodsDetail gRPC for goods ID: %d", request.Id)
 	response, err := g.gc.GetGoodsDetail(ctx, request)
 	if err != nil {
-		log.Errorf("GetGoodsDetail gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "GetGoodsDetail gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("GetGoodsDetail gRPC call successful, goods name: %s", response.Name)
+	log.InfofC(ctx, "GetGoodsDetail gRPC call successful, goods name: %s", response.Name)
+	return response, nil
+}
+
+func (g *goods) BatchGetGoods(ctx context.Context, request *gpbv1.BatchGoodsIdInfo) (*gpbv1.GoodsListResponse, error) {
+	log.InfofC(ctx, "Calling BatchGetGoods gRPC for goods IDs: %v", request.Id)
+	response, err := g.gc.BatchGetGoods(ctx, request)
+	if err != nil {
+		log.ErrorfC(ctx, "BatchGetGoods gRPC call failed: %v", err)
+		return nil, err
+	}
+	log.InfofC(ctx, "BatchGetGoods gRPC call successful, total: %d", response.Total)
 	return response, nil
 }
 
 func (g *goods) DeleteGoods(ctx context.Context, info *gpbv1.DeleteGoodsInfo) (*gpbv1.GoodsInfoResponse, error) {
-	log.Infof("Calling DeleteGoods gRPC for goods ID: %d", info.Id)
+	log.InfofC(ctx, "Calling DeleteGoods gRPC for goods ID: %d", info.Id)
 	_, err := g.gc.DeleteGoods(ctx, info)
 	if err != nil {
-		log.Errorf("DeleteGoods gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "DeleteGoods gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("DeleteGoods gRPC call successful, goods ID: %d", info.Id)
+	log.InfofC(ctx, "DeleteGoods gRPC call successful, goods ID: %d", info.Id)
 	return &gpbv1.GoodsInfoResponse{}, nil
 }
 
 func (g *goods) UpdateGoods(ctx context.Context, info *gpbv1.CreateGoodsInfo) (*gpbv1.GoodsInfoResponse, error) {
-	log.Infof("Calling UpdateGoods gRPC for goods ID: %d", info.Id)
+	log.InfofC(ctx, "Calling UpdateGoods gRPC for goods ID: %d", info.Id)
 	_, err := g.gc.UpdateGoods(ctx, info)
 	if err != nil {
-		log.Errorf("UpdateGoods gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "UpdateGoods gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("UpdateGoods gRPC call successful, goods ID: %d", info.Id)
+	log.InfofC(ctx, "UpdateGoods gRPC call successful, goods ID: %d", info.Id)
 	return &gpbv1.GoodsInfoResponse{}, nil
 }
 
 // ==================== 分类管理 ====================
 
 func (g *goods) GetAllCategorysList(ctx context.Context) (*gpbv1.CategoryListResponse, error) {
-	log.Infof("Calling GetAllCategorysList gRPC")
+	log.InfofC(ctx, "Calling GetAllCategorysList gRPC")
 	response, err := g.gc.GetAllCategorysList(ctx, &emptypb.Empty{})
 	if err != nil {
-		log.Errorf("GetAllCategorysList gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "GetAllCategorysList gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("GetAllCategorysList gRPC call successful, total: %d", response.Total)
+	log.InfofC(ctx, "GetAllCategorysList gRPC call successful, total: %d", response.Total)
 	return response, nil
 }
 
 func (g *goods) GetSubCategory(ctx context.Context, request *gpbv1.CategoryListRequest) (*gpbv1.SubCategoryListResponse, error) {
-	log.Infof("Calling GetSubCategory gRPC for category ID: %d", request.Id)
+	log.InfofC(ctx, "Calling GetSubCategory gRPC for category ID: %d", request.Id)
 	response, err := g.gc.GetSubCategory(ctx, request)
 	if err != nil {
-		log.Errorf("GetSubCategory gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "GetSubCategory gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("GetSubCategory gRPC call successful")
+	log.InfofC(ctx, "GetSubCategory gRPC call successful")
 	return response, nil
 }
 
 func (g *goods) CreateCategory(ctx context.Context, request *gpbv1.CategoryInfoRequest) (*gpbv1.CategoryInfoResponse, error) {
-	log.Infof("Calling CreateCategory gRPC: %s", request.Name)
+	log.InfofC(ctx, "Calling CreateCategory gRPC: %s", request.Name)
 	response, err := g.gc.CreateCategory(ctx, request)
 	if err != nil {
-		log.Errorf("CreateCategory gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "CreateCategory gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("CreateCategory gRPC call successful, category ID: %d", response.Id)
+	log.InfofC(ctx, "CreateCategory gRPC call successful, category ID: %d", response.Id)
 	return response, nil
 }
 
 func (g *goods) UpdateCategory(ctx context.Context, request *gpbv1.CategoryInfoRequest) (*gpbv1.CategoryInfoResponse, error) {
-	log.Infof("Calling UpdateCategory gRPC for category ID: %d", request.Id)
+	log.InfofC(ctx, "Calling UpdateCategory gRPC for category ID: %d", request.Id)
 	_, err := g.gc.UpdateCategory(ctx, request)
 	if err != nil {
-		log.Errorf("UpdateCategory gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "UpdateCategory gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("UpdateCategory gRPC call successful, category ID: %d", request.Id)
+	log.InfofC(ctx, "UpdateCategory gRPC call successful, category ID: %d", request.Id)
 	return &gpbv1.CategoryInfoResponse{}, nil
 }
 
 func (g *goods) DeleteCategory(ctx context.Context, request *gpbv1.DeleteCategoryRequest) (*gpbv1.CategoryInfoResponse, error) {
-	log.Infof("Calling DeleteCategory gRPC for category ID: %d", request.Id)
+	log.InfofC(ctx, "Calling DeleteCategory gRPC for category ID: %d", request.Id)
 	_, err := g.gc.DeleteCategory(ctx, request)
 	if err != nil {
-		log.Errorf("DeleteCategory gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "DeleteCategory gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("DeleteCategory gRPC call successful, category ID: %d", request.Id)
+	log.InfofC(ctx, "DeleteCategory gRPC call successful, category ID: %d", request.Id)
 	return &gpbv1.CategoryInfoResponse{}, nil
 }
 
 // ==================== 品牌管理 ====================
 
 func (g *goods) BrandList(ctx context.Context, request *gpbv1.BrandFilterRequest) (*gpbv1.BrandListResponse, error) {
-	log.Infof("Calling BrandList gRPC with pages: %d", request.Pages)
+	log.InfofC(ctx, "Calling BrandList gRPC with pages: %d", request.Pages)
 	response, err := g.gc.BrandList(ctx, request)
 	if err != nil {
-		log.Errorf("BrandList gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "BrandList gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("BrandList gRPC call successful, total: %d", response.Total)
+	log.InfofC(ctx, "BrandList gRPC call successful, total: %d", response.Total)
 	return response, nil
 }
 
 func (g *goods) CreateBrand(ctx context.Context, request *gpbv1.BrandRequest) (*gpbv1.BrandInfoResponse, error) {
-	log.Infof("Calling CreateBrand gRPC: %s", request.Name)
+	log.InfofC(ctx, "Calling CreateBrand gRPC: %s", request.Name)
 	response, err := g.gc.CreateBrand(ctx, request)
 	if err != nil {
-		log.Errorf("CreateBrand gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "CreateBrand gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("CreateBrand gRPC call successful, brand ID: %d", response.Id)
+	log.InfofC(ctx, "CreateBrand gRPC call successful, brand ID: %d", response.Id)
 	return response, nil
 }
 
 func (g *goods) UpdateBrand(ctx context.Context, request *gpbv1.BrandRequest) (*gpbv1.BrandInfoResponse, error) {
-	log.Infof("Calling UpdateBrand gRPC for brand ID: %d", request.Id)
+	log.InfofC(ctx, "Calling UpdateBrand gRPC for brand ID: %d", request.Id)
 	_, err := g.gc.UpdateBrand(ctx, request)
 	if err != nil {
-		log.Errorf("UpdateBrand gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "UpdateBrand gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("UpdateBrand gRPC call successful, brand ID: %d", request.Id)
+	log.InfofC(ctx, "UpdateBrand gRPC call successful, brand ID: %d", request.Id)
 	return &gpbv1.BrandInfoResponse{}, nil
 }
 
 func (g *goods) DeleteBrand(ctx context.Context, request *gpbv1.BrandRequest) (*gpbv1.BrandInfoResponse, error) {
-	log.Infof("Calling DeleteBrand gRPC for brand ID: %d", request.Id)
+	log.InfofC(ctx, "Calling DeleteBrand gRPC for brand ID: %d", request.Id)
 	_, err := g.gc.DeleteBrand(ctx, request)
 	if err != nil {
-		log.Errorf("DeleteBrand gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "DeleteBrand gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("DeleteBrand gRPC call successful, brand ID: %d", request.Id)
+	log.InfofC(ctx, "DeleteBrand gRPC call successful, brand ID: %d", request.Id)
 	return &gpbv1.BrandInfoResponse{}, nil
 }
 
 // ==================== 轮播图管理 ====================
 
 func (g *goods) BannerList(ctx context.Context) (*gpbv1.BannerListResponse, error) {
-	log.Infof("Calling BannerList gRPC")
+	log.InfofC(ctx, "Calling BannerList gRPC")
 	response, err := g.gc.BannerList(ctx, &emptypb.Empty{})
 	if err != nil {
-		log.Errorf("BannerList gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "BannerList gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("BannerList gRPC call successful, total: %d", response.Total)
+	log.InfofC(ctx, "BannerList gRPC call successful, total: %d", response.Total)
 	return response, nil
 }
 
 func (g *goods) CreateBanner(ctx context.Context, request *gpbv1.BannerRequest) (*gpbv1.BannerResponse, error) {
-	log.Infof("Calling CreateBanner gRPC: %s", request.Url)
+	log.InfofC(ctx, "Calling CreateBanner gRPC: %s", request.Url)
 	response, err := g.gc.CreateBanner(ctx, request)
 	if err != nil {
-		log.Errorf("CreateBanner gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "CreateBanner gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("CreateBanner gRPC call successful, banner ID: %d", response.Id)
+	log.InfofC(ctx, "CreateBanner gRPC call successful, banner ID: %d", response.Id)
 	return response, nil
 }
 
 func (g *goods) UpdateBanner(ctx context.Context, request *gpbv1.BannerRequest) (*gpbv1.BannerResponse, error) {
-	log.Infof("Calling UpdateBanner gRPC for banner ID: %d", request.Id)
+	log.InfofC(ctx, "Calling UpdateBanner gRPC for banner ID: %d", request.Id)
 	_, err := g.gc.UpdateBanner(ctx, request)
 	if err != nil {
-		log.Errorf("UpdateBanner gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "UpdateBanner gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("UpdateBanner gRPC call successful, banner ID: %d", request.Id)
+	log.InfofC(ctx, "UpdateBanner gRPC call successful, banner ID: %d", request.Id)
 	return &gpbv1.BannerResponse{}, nil
 }
 
 func (g *goods) DeleteBanner(ctx context.Context, request *gpbv1.BannerRequest) (*gpbv1.BannerResponse, error) {
-	log.Infof("Calling DeleteBanner gRPC for banner ID: %d", request.Id)
+	log.InfofC(ctx, "Calling DeleteBanner gRPC for banner ID: %d", request.Id)
 	_, err := g.gc.DeleteBanner(ctx, request)
 	if err != nil {
-		log.Errorf("DeleteBanner gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "DeleteBanner gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("DeleteBanner gRPC call successful, banner ID: %d", request.Id)
+	log.InfofC(ctx, "DeleteBanner gRPC call successful, banner ID: %d", request.Id)
 	return &gpbv1.BannerResponse{}, nil
 }
 
@@ -37,7 +37,7 @@ func (i *inventory) InvDetail(ctx context.Context, request *ipb.GoodsInvInfo) (*
 			case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
 				if attempt < maxRetries-1 {
 					waitTime := time.Duration(attempt+1) * 100 * time.Millisecond
-					log.Warnf("Inventory service call failed (attempt %d/%d): %v, retrying in %v",
+					log.WarnfC(ctx, "Inventory service call failed (attempt %d/%d): %v, retrying in %v",
 						attempt+1, maxRetries, err, waitTime)
 					time.Sleep(waitTime)
 					continue
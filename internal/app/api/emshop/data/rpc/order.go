@@ -18,93 +18,104 @@ func NewOrder(oc opbv1.OrderClient) *order {
 // ==================== 订单管理 ====================
 
 func (o *order) OrderList(ctx context.Context, request *opbv1.OrderFilterRequest) (*opbv1.OrderListResponse, error) {
-	log.Infof("Calling OrderList gRPC for user: %d", request.UserId)
+	log.InfofC(ctx, "Calling OrderList gRPC for user: %d", request.UserId)
 	response, err := o.oc.OrderList(ctx, request)
 	if err != nil {
-		log.Errorf("OrderList gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "OrderList gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("OrderList gRPC call successful, total: %d", response.Total)
+	log.InfofC(ctx, "OrderList gRPC call successful, total: %d", response.Total)
 	return response, nil
 }
 
 func (o *order) CreateOrder(ctx context.Context, request *opbv1.OrderRequest) (*opbv1.OrderInfoResponse, error) {
     // For API order creation, we should submit based on checked cart items
-    log.Infof("Calling SubmitOrder gRPC for user: %d", request.UserId)
+    log.InfofC(ctx, "Calling SubmitOrder gRPC for user: %d", request.UserId)
     _, err := o.oc.SubmitOrder(ctx, request)
     if err != nil {
-        log.Errorf("SubmitOrder gRPC call failed: %v", err)
+        log.ErrorfC(ctx, "SubmitOrder gRPC call failed: %v", err)
         return nil, err
     }
-    log.Infof("SubmitOrder gRPC call successful")
+    log.InfofC(ctx, "SubmitOrder gRPC call successful")
     return &opbv1.OrderInfoResponse{}, nil
 }
 
+// CreateFlashSaleOrder 以预占单号和秒杀价创建待支付订单
+func (o *order) CreateFlashSaleOrder(ctx context.Context, request *opbv1.FlashSaleOrderRequest) (*opbv1.OrderInfoResponse, error) {
+	log.InfofC(ctx, "Calling CreateFlashSaleOrder gRPC for flashSale: %d, order: %s", request.FlashSaleId, request.GetOrder().GetOrderSn())
+	response, err := o.oc.CreateFlashSaleOrder(ctx, request)
+	if err != nil {
+		log.ErrorfC(ctx, "CreateFlashSaleOrder gRPC call failed: %v", err)
+		return nil, err
+	}
+	return response, nil
+}
+
 func (o *order) OrderDetail(ctx context.Context, request *opbv1.OrderRequest) (*opbv1.OrderInfoDetailResponse, error) {
-	log.Infof("Calling OrderDetail gRPC for order: %d", request.Id)
+	log.InfofC(ctx, "Calling OrderDetail gRPC for order: %d", request.Id)
 	response, err := o.oc.OrderDetail(ctx, request)
 	if err != nil {
-		log.Errorf("OrderDetail gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "OrderDetail gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("OrderDetail gRPC call successful")
+	log.InfofC(ctx, "OrderDetail gRPC call successful")
 	return response, nil
 }
 
 func (o *order) UpdateOrderStatus(ctx context.Context, request *opbv1.OrderStatus) (*opbv1.OrderInfoResponse, error) {
-	log.Infof("Calling UpdateOrderStatus gRPC for order: %s", request.OrderSn)
+	log.InfofC(ctx, "Calling UpdateOrderStatus gRPC for order: %s", request.OrderSn)
 	_, err := o.oc.UpdateOrderStatus(ctx, request)
 	if err != nil {
-		log.Errorf("UpdateOrderStatus gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "UpdateOrderStatus gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("UpdateOrderStatus gRPC call successful")
+	log.InfofC(ctx, "UpdateOrderStatus gRPC call successful")
 	return &opbv1.OrderInfoResponse{}, nil
 }
 
 // ==================== 购物车管理 ====================
 
 func (o *order) CartItemList(ctx context.Context, request *opbv1.UserInfo) (*opbv1.CartItemListResponse, error) {
-	log.Infof("Calling CartItemList gRPC for user: %d", request.Id)
+	log.InfofC(ctx, "Calling CartItemList gRPC for user: %d", request.Id)
 	response, err := o.oc.CartItemList(ctx, request)
 	if err != nil {
-		log.Errorf("CartItemList gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "CartItemList gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("CartItemList gRPC call successful, total: %d", response.Total)
+	log.InfofC(ctx, "CartItemList gRPC call successful, total: %d", response.Total)
 	return response, nil
 }
 
 func (o *order) CreateCartItem(ctx context.Context, request *opbv1.CartItemRequest) (*opbv1.ShopCartInfoResponse, error) {
-	log.Infof("Calling CreateCartItem gRPC for user: %d, goods: %d", request.UserId, request.GoodsId)
+	log.InfofC(ctx, "Calling CreateCartItem gRPC for user: %d, goods: %d", request.UserId, request.GoodsId)
 	response, err := o.oc.CreateCartItem(ctx, request)
 	if err != nil {
-		log.Errorf("CreateCartItem gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "CreateCartItem gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("CreateCartItem gRPC call successful, cart item ID: %d", response.Id)
+	log.InfofC(ctx, "CreateCartItem gRPC call successful, cart item ID: %d", response.Id)
 	return response, nil
 }
 
 func (o *order) UpdateCartItem(ctx context.Context, request *opbv1.CartItemRequest) (*opbv1.ShopCartInfoResponse, error) {
-	log.Infof("Calling UpdateCartItem gRPC for cart item: %d", request.Id)
+	log.InfofC(ctx, "Calling UpdateCartItem gRPC for cart item: %d", request.Id)
 	_, err := o.oc.UpdateCartItem(ctx, request)
 	if err != nil {
-		log.Errorf("UpdateCartItem gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "UpdateCartItem gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("UpdateCartItem gRPC call successful")
+	log.InfofC(ctx, "UpdateCartItem gRPC call successful")
 	return &opbv1.ShopCartInfoResponse{}, nil
 }
 
 func (o *order) DeleteCartItem(ctx context.Context, request *opbv1.CartItemRequest) (*opbv1.ShopCartInfoResponse, error) {
-	log.Infof("Calling DeleteCartItem gRPC for cart item: %d", request.Id)
+	log.InfofC(ctx, "Calling DeleteCartItem gRPC for cart item: %d", request.Id)
 	_, err := o.oc.DeleteCartItem(ctx, request)
 	if err != nil {
-		log.Errorf("DeleteCartItem gRPC call failed: %v", err)
+		log.ErrorfC(ctx, "DeleteCartItem gRPC call failed: %v", err)
 		return nil, err
 	}
-	log.Infof("DeleteCartItem gRPC call successful")
+	log.InfofC(ctx, "DeleteCartItem gRPC call successful")
 	return &opbv1.ShopCartInfoResponse{}, nil
 }
 
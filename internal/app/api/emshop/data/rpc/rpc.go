@@ -2,15 +2,14 @@ package rpc
 
 import (
 	"emshop/gin-micro/registry"
-	"emshop/gin-micro/registry/consul"
 	"emshop/internal/app/api/emshop/data"
 	"emshop/internal/app/pkg/code"
 	"emshop/internal/app/pkg/options"
+	"emshop/internal/app/pkg/discovery"
 	errors2 "emshop/pkg/errors"
 	"fmt"
 	"sync"
 
-	cosulAPI "github.com/hashicorp/consul/api"
 )
 
 type grpcData struct {
@@ -57,14 +56,10 @@ func (g grpcData) Logistics() data.LogisticsData {
 }
 
 func NewDiscovery(opts *options.RegistryOptions) registry.Discovery {
-	c := cosulAPI.DefaultConfig()
-	c.Address = opts.Address
-	c.Scheme = opts.Scheme
-	cli, err := cosulAPI.NewClient(c)
+	r, err := discovery.NewDiscovery(opts)
 	if err != nil {
 		panic(err)
 	}
-	r := consul.New(cli, consul.WithHealthCheck(true))
 	return r
 }
 
@@ -84,7 +79,7 @@ func GetDataFactoryOr(options *options.RegistryOptions) (data.DataFactory, error
 		discovery := NewDiscovery(options)
 
 		// 创建客户端管理器，统一管理所有gRPC客户端
-		clients := newGrpcClients(discovery)
+		clients := newGrpcClients(discovery, options.Routing.NodeFilters()...)
 
 		// 创建数据层实例，使用客户端管理器
 		userData := NewUsers(clients.userClient)
@@ -3,6 +3,7 @@ package request
 import (
 	cpbv1 "emshop/api/coupon/v1"
 	"fmt"
+	"strings"
 )
 
 // ReceiveCouponRequest 领取优惠券请求
@@ -26,6 +27,27 @@ func (r *ReceiveCouponRequest) Validate() error {
 	return nil
 }
 
+// RedeemPromoCodeRequest 兑换码领取优惠券请求
+type RedeemPromoCodeRequest struct {
+	Code string `json:"code" binding:"required,max=64" form:"code"`
+}
+
+// ToProto 转换为protobuf请求
+func (r *RedeemPromoCodeRequest) ToProto(userID int64) *cpbv1.RedeemPromoCodeRequest {
+	return &cpbv1.RedeemPromoCodeRequest{
+		UserId: userID,
+		Code:   r.Code,
+	}
+}
+
+// Validate 验证请求参数
+func (r *RedeemPromoCodeRequest) Validate() error {
+	if strings.TrimSpace(r.Code) == "" {
+		return &ValidationError{Field: "code", Message: "兑换码不能为空"}
+	}
+	return nil
+}
+
 // GetUserCouponsRequest 获取用户优惠券列表请求
 type GetUserCouponsRequest struct {
 	Status   *int32 `form:"status" json:"status"`                                     // 状态筛选，可选
@@ -54,6 +76,32 @@ func (r *GetUserCouponsRequest) Validate() error {
 	return nil
 }
 
+// ListCouponRemindersRequest 获取优惠券到期提醒请求
+type ListCouponRemindersRequest struct {
+	Page     int32 `form:"page" json:"page" binding:"required,min=1"`                // 页码
+	PageSize int32 `form:"pageSize" json:"pageSize" binding:"required,min=1,max=50"` // 页大小
+}
+
+// ToProto 转换为protobuf请求
+func (r *ListCouponRemindersRequest) ToProto(userID int64) *cpbv1.ListCouponRemindersRequest {
+	return &cpbv1.ListCouponRemindersRequest{
+		UserId:   userID,
+		Page:     r.Page,
+		PageSize: r.PageSize,
+	}
+}
+
+// Validate 验证请求参数
+func (r *ListCouponRemindersRequest) Validate() error {
+	if r.Page <= 0 {
+		return &ValidationError{Field: "page", Message: "页码必须大于0"}
+	}
+	if r.PageSize <= 0 || r.PageSize > 50 {
+		return &ValidationError{Field: "pageSize", Message: "页大小必须在1-50之间"}
+	}
+	return nil
+}
+
 // GetAvailableCouponsRequest 获取可用优惠券请求
 type GetAvailableCouponsRequest struct {
 	OrderAmount float64 `form:"order_amount" json:"order_amount" binding:"required,min=0.01"` // 订单金额
@@ -160,3 +208,26 @@ func (r *ListCouponTemplatesRequest) Validate() error {
 	}
 	return nil
 }
+
+// ParticipateFlashSaleRequest 参与秒杀请求，开启排队时需携带排队放行令牌
+type ParticipateFlashSaleRequest struct {
+	AdmissionToken string `json:"admission_token" form:"admission_token"`
+}
+
+// BuyFlashSaleGoodsRequest 商品秒杀抢购请求，抢到名额后直接以收货信息创建待支付订单
+type BuyFlashSaleGoodsRequest struct {
+	AdmissionToken string `json:"admission_token"`
+	Address        string `json:"address" binding:"required"`
+	Name           string `json:"name" binding:"required"`
+	Mobile         string `json:"mobile" binding:"required"`
+	Post           string `json:"post"`
+}
+
+// ToProto 转换为protobuf请求
+func (r *ParticipateFlashSaleRequest) ToProto(userID, flashSaleID int64) *cpbv1.ParticipateFlashSaleRequest {
+	return &cpbv1.ParticipateFlashSaleRequest{
+		UserId:         userID,
+		FlashSaleId:    flashSaleID,
+		AdmissionToken: r.AdmissionToken,
+	}
+}
@@ -91,20 +91,28 @@ type LogisticsItem struct {
 func (r *CalculateShippingFeeRequest) ToProto() *lpbv1.CalculateShippingFeeRequest {
 	// 计算总重量和体积
 	var totalWeight, totalVolume, goodsValue float64
+	items := make([]*lpbv1.ShippingFeeItem, 0, len(r.Items))
 	for _, item := range r.Items {
 		totalWeight += item.Weight * float64(item.Quantity)
 		totalVolume += item.Volume * float64(item.Quantity)
-		// 这里可能需要从商品服务获取价格来计算goodsValue
+		items = append(items, &lpbv1.ShippingFeeItem{
+			GoodsId:  item.GoodsID,
+			Quantity: item.Quantity,
+			Weight:   item.Weight,
+			Volume:   item.Volume,
+		})
 	}
 
 	return &lpbv1.CalculateShippingFeeRequest{
-		SenderAddress:   "", // 需要从配置或请求中获取
-		ReceiverAddress: r.ReceiverAddress,
-		ShippingMethod:  r.ShippingMethod,
-		TotalWeight:     totalWeight,
-		TotalVolume:     totalVolume,
-		GoodsValue:      goodsValue,
-		NeedInsurance:   false, // 可以作为参数传入
+		SenderAddress:    "", // 需要从配置或请求中获取
+		ReceiverAddress:  r.ReceiverAddress,
+		ShippingMethod:   r.ShippingMethod,
+		TotalWeight:      totalWeight,
+		TotalVolume:      totalVolume,
+		GoodsValue:       goodsValue,
+		NeedInsurance:    false, // 可以作为参数传入
+		LogisticsCompany: r.LogisticsCompany,
+		Items:            items,
 	}
 }
 
@@ -102,6 +102,46 @@ func (r *UserCouponListResponse) FromProto(pb *cpbv1.ListUserCouponsResponse) {
 	}
 }
 
+// CouponReminderResponse 优惠券到期提醒响应
+type CouponReminderResponse struct {
+	ID            int64     `json:"id"`             // 提醒ID
+	UserCouponID  int64     `json:"user_coupon_id"` // 用户优惠券ID
+	TemplateID    int64     `json:"template_id"`    // 模板ID
+	CouponName    string    `json:"coupon_name"`    // 优惠券名称
+	CouponCode    string    `json:"coupon_code"`    // 优惠券码
+	ExpiredAt     time.Time `json:"expired_at"`     // 过期时间
+	RemainingDays int32     `json:"remaining_days"` // 剩余天数
+	CreatedAt     time.Time `json:"created_at"`     // 提醒时间
+}
+
+// FromProto 从protobuf转换
+func (r *CouponReminderResponse) FromProto(pb *cpbv1.CouponReminderResponse) {
+	r.ID = pb.Id
+	r.UserCouponID = pb.UserCouponId
+	r.TemplateID = pb.CouponTemplateId
+	r.CouponName = pb.CouponName
+	r.CouponCode = pb.CouponCode
+	r.ExpiredAt = time.Unix(pb.ExpiredAt, 0)
+	r.RemainingDays = pb.RemainingDays
+	r.CreatedAt = time.Unix(pb.CreatedAt, 0)
+}
+
+// CouponReminderListResponse 优惠券到期提醒列表响应
+type CouponReminderListResponse struct {
+	Total int64                     `json:"total"` // 总数量
+	Items []*CouponReminderResponse `json:"items"` // 提醒列表
+}
+
+// FromProto 从protobuf转换
+func (r *CouponReminderListResponse) FromProto(pb *cpbv1.ListCouponRemindersResponse) {
+	r.Total = pb.TotalCount
+	r.Items = make([]*CouponReminderResponse, len(pb.Items))
+	for i, item := range pb.Items {
+		r.Items[i] = &CouponReminderResponse{}
+		r.Items[i].FromProto(item)
+	}
+}
+
 // ReceiveCouponResponse 领取优惠券响应
 type ReceiveCouponResponse struct {
 	ID            int64     `json:"id"`             // 用户优惠券ID
@@ -192,3 +232,59 @@ func (r *CouponDiscountResponse) FromProto(pb *cpbv1.CalculateCouponDiscountResp
 		}
 	}
 }
+
+// FlashSaleQueueStatusResponse 秒杀排队状态响应
+type FlashSaleQueueStatusResponse struct {
+	FlashSaleID          int64  `json:"flash_sale_id"`          // 秒杀活动ID
+	Status               int32  `json:"status"`                 // 1:排队中 2:已放行 3:未排队或放行已过期 4:已结束或售罄
+	Position             int64  `json:"position"`               // 当前排位
+	QueueLength          int64  `json:"queue_length"`           // 排队总人数
+	EstimatedWaitSeconds int64  `json:"estimated_wait_seconds"` // 预计等待秒数
+	AdmissionToken       string `json:"admission_token"`        // 放行令牌，参与秒杀时携带
+	TokenExpiresAt       int64  `json:"token_expires_at"`       // 令牌过期时间
+	Message              string `json:"message"`                // 说明
+}
+
+// FromProto 从protobuf转换
+func (r *FlashSaleQueueStatusResponse) FromProto(pb *cpbv1.FlashSaleQueueStatusResponse) {
+	r.FlashSaleID = pb.FlashSaleId
+	r.Status = pb.Status
+	r.Position = pb.Position
+	r.QueueLength = pb.QueueLength
+	r.EstimatedWaitSeconds = pb.EstimatedWaitSeconds
+	r.AdmissionToken = pb.AdmissionToken
+	r.TokenExpiresAt = pb.TokenExpiresAt
+	r.Message = pb.Message
+}
+
+// ParticipateFlashSaleResponse 参与秒杀响应
+type ParticipateFlashSaleResponse struct {
+	Success      bool   `json:"success"`                  // 是否抢到
+	FailReason   string `json:"fail_reason,omitempty"`    // 失败原因
+	UserCouponID int64  `json:"user_coupon_id,omitempty"` // 抢到的用户优惠券ID
+}
+
+// FlashSaleOrderResponse 商品秒杀下单响应
+type FlashSaleOrderResponse struct {
+	OrderSn     string  `json:"order_sn"`     // 订单号
+	GoodsID     int32   `json:"goods_id"`     // 商品ID
+	Quantity    int32   `json:"quantity"`     // 数量
+	SalePrice   float32 `json:"sale_price"`   // 秒杀价
+	PayDeadline int64   `json:"pay_deadline"` // 支付截止时间(Unix秒)，超时订单自动关闭
+}
+
+// FromProto 从protobuf转换
+func (r *FlashSaleOrderResponse) FromProto(pb *cpbv1.FlashSaleReservationResponse) {
+	r.OrderSn = pb.OrderSn
+	r.GoodsID = pb.GoodsId
+	r.Quantity = pb.Quantity
+	r.SalePrice = pb.SalePrice
+	r.PayDeadline = pb.ExpiresAt
+}
+
+// FromProto 从protobuf转换
+func (r *ParticipateFlashSaleResponse) FromProto(pb *cpbv1.ParticipateFlashSaleResponse) {
+	r.Success = pb.Status == 1
+	r.FailReason = pb.GetFailReason()
+	r.UserCouponID = pb.GetUserCouponId()
+}
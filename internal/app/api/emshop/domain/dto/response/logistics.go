@@ -103,11 +103,17 @@ type ShippingFeeResponse struct {
 	EstimatedDeliveryAt *time.Time `json:"estimated_delivery_at"` // 预计送达时间
 	CompanyName         string     `json:"company_name"`          // 物流公司名称
 	ShippingMethodName  string     `json:"shipping_method_name"`  // 配送方式名称
+	RemoteSurcharge     float64    `json:"remote_surcharge"`      // 偏远地区附加费
+	FreeShipping        bool       `json:"free_shipping"`         // 是否包邮
+	FreeReason          string     `json:"free_reason,omitempty"` // 包邮原因
 }
 
 // FromProto 从protobuf转换
 func (r *ShippingFeeResponse) FromProto(pb *lpbv1.CalculateShippingFeeResponse) {
 	r.ShippingFee = pb.ShippingFee
+	r.RemoteSurcharge = pb.RemoteSurcharge
+	r.FreeShipping = pb.FreeShipping
+	r.FreeReason = pb.FreeReason
 
 	// 根据EstimatedDays计算预计送达时间
 	if pb.EstimatedDays > 0 {
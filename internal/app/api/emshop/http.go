@@ -1,8 +1,15 @@
 package admin
 
 import (
+	"net/http"
+	"strings"
+
+	"emshop/gin-micro/core/limit"
 	restserver "emshop/gin-micro/server/rest-server"
+	mws "emshop/gin-micro/server/rest-server/middlewares"
 	"emshop/internal/app/api/emshop/config"
+
+	"github.com/gin-gonic/gin"
 )
 
 func NewAPIHTTPServer(cfg *config.Config) (*restserver.Server, error) {
@@ -11,6 +18,9 @@ func NewAPIHTTPServer(cfg *config.Config) (*restserver.Server, error) {
         restserver.WithServiceName(cfg.Server.Name),
         restserver.WithMiddlewares(cfg.Server.Middlewares),
         restserver.WithMetrics(cfg.Server.EnableMetrics),
+        restserver.WithAdaptiveLimit(cfg.Server.Limiter.AdaptiveLimiter(), apiPriority),
+        restserver.WithLogAdmin(cfg.Server.LogAdminSecret),
+        restserver.WithHealthz(cfg.Server.EnableHealthCheck),
         restserver.WithTransNames(cfg.I18n.Locale),
         restserver.WithLocalesDir(cfg.I18n.LocalesDir),
         restserver.WithRouterInit(func(server *restserver.Server, configInterface interface{}) {
@@ -20,3 +30,12 @@ func NewAPIHTTPServer(cfg *config.Config) (*restserver.Server, error) {
 
     return aRestServer, nil
 }
+
+// apiPriority 支付结果通知在过载时最后被拒绝
+func apiPriority(c *gin.Context) limit.Priority {
+    if c.Request.Method == http.MethodPost && strings.HasPrefix(c.Request.URL.Path, "/v1/payments/") &&
+        strings.HasSuffix(c.Request.URL.Path, "/simulate") {
+        return limit.PriorityHigh
+    }
+    return mws.DefaultHTTPPriority(c)
+}
@@ -17,6 +17,14 @@ import (
 )
 
 func initRouter(g *restserver.Server, cfg *config.Config) {
+	// 调用方限流需要在注册路由前安装才能作用于所有路由
+	if cfg.RateLimit.Enabled {
+		g.Use(middleware.RateLimit(cfg.RateLimit, cfg.Jwt))
+	}
+	// 幂等键去重放在限流之后，被限流的请求不占用幂等键
+	if cfg.Idempotency.Enabled {
+		g.Use(middleware.Idempotency(cfg.Idempotency, cfg.Jwt))
+	}
 
 	v1 := g.Group("/v1")
 
@@ -140,9 +148,15 @@ func initRouter(g *restserver.Server, cfg *config.Config) {
 		couponController := coupon.NewCouponController(g.Translator(), serviceFactory)
 		couponRouter.GET("templates", couponController.ListTemplates)                        // 获取优惠券模板列表
 		couponRouter.POST("receive", jwtAuth, couponController.ReceiveCoupon)                // 用户领取优惠券
+		couponRouter.POST("redeem", jwtAuth, couponController.RedeemCode)                    // 兑换码领取优惠券
 		couponRouter.GET("user", jwtAuth, couponController.GetUserCoupons)                   // 获取用户优惠券列表
 		couponRouter.GET("available", jwtAuth, couponController.GetAvailableCoupons)         // 获取用户可用优惠券
+		couponRouter.GET("reminders", jwtAuth, couponController.ListReminders)               // 获取即将过期提醒
 		couponRouter.POST("calculate-discount", jwtAuth, couponController.CalculateDiscount) // 计算优惠券折扣
+		couponRouter.POST("flash-sales/:id/queue", jwtAuth, couponController.EnterFlashSaleQueue)       // 秒杀排队入队
+		couponRouter.GET("flash-sales/:id/queue", jwtAuth, couponController.GetFlashSaleQueueStatus)    // 轮询排队状态及放行令牌
+		couponRouter.POST("flash-sales/:id/participate", jwtAuth, couponController.ParticipateFlashSale) // 携带放行令牌参与秒杀
+		couponRouter.POST("flash-sales/:id/buy", jwtAuth, couponController.BuyFlashSaleGoods)           // 商品秒杀抢购下单
 	}
 
 	//支付管理api
@@ -3,9 +3,12 @@ package v1
 import (
 	"context"
 
+	cpbv1 "emshop/api/coupon/v1"
+	opbv1 "emshop/api/order/v1"
 	"emshop/internal/app/api/emshop/data"
 	"emshop/internal/app/api/emshop/domain/dto/request"
 	"emshop/internal/app/api/emshop/domain/dto/response"
+	"emshop/pkg/log"
 )
 
 // CouponSrv 优惠券服务接口
@@ -13,8 +16,11 @@ type CouponSrv interface {
 	// ListTemplates 获取优惠券模板列表
 	ListTemplates(ctx context.Context, req *request.ListCouponTemplatesRequest) (*response.CouponTemplateListResponse, error)
 
-	// ReceiveCoupon 用户领取优惠券
-	ReceiveCoupon(ctx context.Context, userID int64, req *request.ReceiveCouponRequest) (*response.ReceiveCouponResponse, error)
+	// ReceiveCoupon 用户领取优惠券，risk为网关采集的风控信号
+	ReceiveCoupon(ctx context.Context, userID int64, req *request.ReceiveCouponRequest, risk *cpbv1.RiskContext) (*response.ReceiveCouponResponse, error)
+
+	// RedeemCode 用户使用兑换码领取优惠券
+	RedeemCode(ctx context.Context, userID int64, req *request.RedeemPromoCodeRequest) (*response.ReceiveCouponResponse, error)
 
 	// GetUserCoupons 获取用户优惠券列表
 	GetUserCoupons(ctx context.Context, userID int64, req *request.GetUserCouponsRequest) (*response.UserCouponListResponse, error)
@@ -22,8 +28,23 @@ type CouponSrv interface {
 	// GetAvailableCoupons 获取用户可用优惠券
 	GetAvailableCoupons(ctx context.Context, userID int64, req *request.GetAvailableCouponsRequest) (*response.AvailableCouponsResponse, error)
 
+	// ListReminders 获取用户优惠券即将过期提醒
+	ListReminders(ctx context.Context, userID int64, req *request.ListCouponRemindersRequest) (*response.CouponReminderListResponse, error)
+
 	// CalculateDiscount 计算优惠券折扣
 	CalculateDiscount(ctx context.Context, userID int64, req *request.CalculateCouponDiscountRequest) (*response.CouponDiscountResponse, error)
+
+	// EnterFlashSaleQueue 秒杀排队入队
+	EnterFlashSaleQueue(ctx context.Context, userID, flashSaleID int64) (*response.FlashSaleQueueStatusResponse, error)
+
+	// GetFlashSaleQueueStatus 轮询排队状态，放行后返回放行令牌
+	GetFlashSaleQueueStatus(ctx context.Context, userID, flashSaleID int64) (*response.FlashSaleQueueStatusResponse, error)
+
+	// ParticipateFlashSale 携带放行令牌参与秒杀
+	ParticipateFlashSale(ctx context.Context, userID, flashSaleID int64, req *request.ParticipateFlashSaleRequest, risk *cpbv1.RiskContext) (*response.ParticipateFlashSaleResponse, error)
+
+	// BuyFlashSaleGoods 抢购秒杀商品并创建待支付订单
+	BuyFlashSaleGoods(ctx context.Context, userID, flashSaleID int64, req *request.BuyFlashSaleGoodsRequest, risk *cpbv1.RiskContext) (*response.FlashSaleOrderResponse, error)
 }
 
 // couponService 优惠券服务实现
@@ -60,7 +81,7 @@ func (s *couponService) ListTemplates(ctx context.Context, req *request.ListCoup
 }
 
 // ReceiveCoupon 用户领取优惠券
-func (s *couponService) ReceiveCoupon(ctx context.Context, userID int64, req *request.ReceiveCouponRequest) (*response.ReceiveCouponResponse, error) {
+func (s *couponService) ReceiveCoupon(ctx context.Context, userID int64, req *request.ReceiveCouponRequest, risk *cpbv1.RiskContext) (*response.ReceiveCouponResponse, error) {
 	// 参数验证
 	if err := req.Validate(); err != nil {
 		return nil, err
@@ -68,6 +89,7 @@ func (s *couponService) ReceiveCoupon(ctx context.Context, userID int64, req *re
 
 	// 调用RPC服务
 	rpcReq := req.ToProto(userID)
+	rpcReq.Risk = risk
 	rpcResp, err := s.data.Coupon().ReceiveCoupon(ctx, rpcReq)
 	if err != nil {
 		return nil, err
@@ -80,6 +102,27 @@ func (s *couponService) ReceiveCoupon(ctx context.Context, userID int64, req *re
 	return resp, nil
 }
 
+// RedeemCode 用户使用兑换码领取优惠券
+func (s *couponService) RedeemCode(ctx context.Context, userID int64, req *request.RedeemPromoCodeRequest) (*response.ReceiveCouponResponse, error) {
+	// 参数验证
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	// 调用RPC服务
+	rpcReq := req.ToProto(userID)
+	rpcResp, err := s.data.Coupon().RedeemPromoCode(ctx, rpcReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// 转换响应
+	resp := &response.ReceiveCouponResponse{}
+	resp.FromProto(rpcResp)
+
+	return resp, nil
+}
+
 // GetUserCoupons 获取用户优惠券列表
 func (s *couponService) GetUserCoupons(ctx context.Context, userID int64, req *request.GetUserCouponsRequest) (*response.UserCouponListResponse, error) {
 	// 参数验证
@@ -101,6 +144,27 @@ func (s *couponService) GetUserCoupons(ctx context.Context, userID int64, req *r
 	return resp, nil
 }
 
+// ListReminders 获取用户优惠券即将过期提醒
+func (s *couponService) ListReminders(ctx context.Context, userID int64, req *request.ListCouponRemindersRequest) (*response.CouponReminderListResponse, error) {
+	// 参数验证
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	// 调用RPC服务
+	rpcReq := req.ToProto(userID)
+	rpcResp, err := s.data.Coupon().ListCouponReminders(ctx, rpcReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// 转换响应
+	resp := &response.CouponReminderListResponse{}
+	resp.FromProto(rpcResp)
+
+	return resp, nil
+}
+
 // GetAvailableCoupons 获取用户可用优惠券
 func (s *couponService) GetAvailableCoupons(ctx context.Context, userID int64, req *request.GetAvailableCouponsRequest) (*response.AvailableCouponsResponse, error) {
 	// 参数验证
@@ -143,5 +207,87 @@ func (s *couponService) CalculateDiscount(ctx context.Context, userID int64, req
 	return resp, nil
 }
 
+// EnterFlashSaleQueue 秒杀排队入队
+func (s *couponService) EnterFlashSaleQueue(ctx context.Context, userID, flashSaleID int64) (*response.FlashSaleQueueStatusResponse, error) {
+	rpcResp, err := s.data.Coupon().EnterFlashSaleQueue(ctx, &cpbv1.FlashSaleQueueRequest{UserId: userID, FlashSaleId: flashSaleID})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &response.FlashSaleQueueStatusResponse{}
+	resp.FromProto(rpcResp)
+	return resp, nil
+}
+
+// GetFlashSaleQueueStatus 查询排队状态
+func (s *couponService) GetFlashSaleQueueStatus(ctx context.Context, userID, flashSaleID int64) (*response.FlashSaleQueueStatusResponse, error) {
+	rpcResp, err := s.data.Coupon().GetFlashSaleQueueStatus(ctx, &cpbv1.FlashSaleQueueRequest{UserId: userID, FlashSaleId: flashSaleID})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &response.FlashSaleQueueStatusResponse{}
+	resp.FromProto(rpcResp)
+	return resp, nil
+}
+
+// ParticipateFlashSale 参与秒杀
+func (s *couponService) ParticipateFlashSale(ctx context.Context, userID, flashSaleID int64, req *request.ParticipateFlashSaleRequest, risk *cpbv1.RiskContext) (*response.ParticipateFlashSaleResponse, error) {
+	rpcReq := req.ToProto(userID, flashSaleID)
+	rpcReq.Risk = risk
+	rpcResp, err := s.data.Coupon().ParticipateFlashSale(ctx, rpcReq)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &response.ParticipateFlashSaleResponse{}
+	resp.FromProto(rpcResp)
+	return resp, nil
+}
+
+// BuyFlashSaleGoods 抢购秒杀商品并创建待支付订单
+//
+// 先在优惠券服务预占名额，再以预占单号创建订单；下单失败时立即结算预占单，
+// 结算会关闭可能已创建的订单并归还名额，结算失败则由到期结算任务兜底
+func (s *couponService) BuyFlashSaleGoods(ctx context.Context, userID, flashSaleID int64, req *request.BuyFlashSaleGoodsRequest, risk *cpbv1.RiskContext) (*response.FlashSaleOrderResponse, error) {
+	reservation, err := s.data.Coupon().ReserveFlashSaleGoods(ctx, &cpbv1.ParticipateFlashSaleRequest{
+		UserId:         userID,
+		FlashSaleId:    flashSaleID,
+		AdmissionToken: req.AdmissionToken,
+		Risk:           risk,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	orderSn := reservation.OrderSn
+	_, err = s.data.Order().CreateFlashSaleOrder(ctx, &opbv1.FlashSaleOrderRequest{
+		Order: &opbv1.OrderRequest{
+			UserId:  int32(userID),
+			Address: &req.Address,
+			Name:    &req.Name,
+			Mobile:  &req.Mobile,
+			Post:    &req.Post,
+			OrderSn: &orderSn,
+			OrderItems: []*opbv1.OrderItemResponse{
+				{GoodsId: reservation.GoodsId, Nums: reservation.Quantity},
+			},
+		},
+		FlashSaleId: flashSaleID,
+		SalePrice:   reservation.SalePrice,
+		PayDeadline: reservation.ExpiresAt,
+	})
+	if err != nil {
+		if _, settleErr := s.data.Coupon().SettleFlashSaleReservation(ctx, &cpbv1.SettleFlashSaleReservationRequest{OrderSn: orderSn}); settleErr != nil {
+			log.WarnfC(ctx, "秒杀下单失败后归还名额失败，等待到期结算: orderSn=%s, err=%v", orderSn, settleErr)
+		}
+		return nil, err
+	}
+
+	resp := &response.FlashSaleOrderResponse{}
+	resp.FromProto(reservation)
+	return resp, nil
+}
+
 // 编译时检查接口实现
 var _ CouponSrv = (*couponService)(nil)
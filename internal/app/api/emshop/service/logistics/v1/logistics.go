@@ -3,9 +3,13 @@ package v1
 import (
 	"context"
 
+	gpb "emshop/api/goods/v1"
+	lpbv1 "emshop/api/logistics/v1"
+
 	"emshop/internal/app/api/emshop/data"
 	"emshop/internal/app/api/emshop/domain/dto/request"
 	"emshop/internal/app/api/emshop/domain/dto/response"
+	"emshop/pkg/log"
 )
 
 // LogisticsSrv 物流服务接口
@@ -81,6 +85,7 @@ func (s *logisticsService) GetLogisticsTracks(ctx context.Context, req *request.
 func (s *logisticsService) CalculateShippingFee(ctx context.Context, req *request.CalculateShippingFeeRequest) (*response.ShippingFeeResponse, error) {
 	// 调用RPC服务
 	rpcReq := req.ToProto()
+	s.fillGoodsInfo(ctx, rpcReq)
 	rpcResp, err := s.data.Logistics().CalculateShippingFee(ctx, rpcReq)
 	if err != nil {
 		return nil, err
@@ -154,3 +159,36 @@ func getShippingMethodName(method int32) string {
 
 // 编译时检查接口实现
 var _ LogisticsSrv = (*logisticsService)(nil)
+
+// fillGoodsInfo 从商品服务批量补全包邮标记和订单金额，供运费引擎判断包邮
+func (s *logisticsService) fillGoodsInfo(ctx context.Context, req *lpbv1.CalculateShippingFeeRequest) {
+	if len(req.Items) == 0 {
+		return
+	}
+	ids := make([]int32, 0, len(req.Items))
+	for _, item := range req.Items {
+		ids = append(ids, item.GoodsId)
+	}
+	goodsList, err := s.data.Goods().BatchGetGoods(ctx, &gpb.BatchGoodsIdInfo{Id: ids})
+	if err != nil {
+		// 查询失败时按不包邮处理，不影响运费计算
+		log.WarnfC(ctx, "批量查询商品信息失败，按不包邮计算运费: %v", err)
+		return
+	}
+	goodsMap := make(map[int32]*gpb.GoodsInfoResponse, len(goodsList.Data))
+	for _, goods := range goodsList.Data {
+		goodsMap[goods.Id] = goods
+	}
+
+	var orderAmount float64
+	for _, item := range req.Items {
+		goods, ok := goodsMap[item.GoodsId]
+		if !ok {
+			log.WarnfC(ctx, "商品%d不存在，按不包邮计算运费", item.GoodsId)
+			continue
+		}
+		item.ShipFree = goods.ShipFree
+		orderAmount += float64(goods.ShopPrice) * float64(item.Quantity)
+	}
+	req.OrderAmount = orderAmount
+}
@@ -0,0 +1,173 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	cpbv1 "emshop/api/coupon/v1"
+	upbv1 "emshop/api/user/v1"
+	uoppbv1 "emshop/api/userop/v1"
+	"emshop/internal/app/api/emshop/data"
+	"emshop/internal/app/pkg/code"
+	"emshop/pkg/errors"
+	"emshop/pkg/log"
+	"emshop/pkg/storage"
+
+	"github.com/mojocn/base64Captcha"
+)
+
+// 客户端通过请求头传递设备ID，风控要求验证码时先调用/captcha获取图片，再携带验证码重试原请求
+const (
+	HeaderDeviceID  = "X-Device-Id"
+	HeaderCaptchaID = "X-Captcha-Id"
+	HeaderCaptcha   = "X-Captcha"
+)
+
+const (
+	// SceneSms 短信发送风控场景，与优惠券服务一致
+	SceneSms = "sms"
+
+	// accountSignalTTL 注册时间与共用地址账号数变化很慢，缓存后避免秒杀时每次请求都查询用户服务
+	accountSignalTTL = 10 * time.Minute
+)
+
+// 验证码结果，与优惠券服务RiskContext.captcha_result一致
+const (
+	captchaNotSubmitted int32 = 0
+	captchaPassed       int32 = 1
+	captchaFailed       int32 = 2
+)
+
+// ClientInfo 请求来源与验证码
+type ClientInfo struct {
+	DeviceID  string
+	ClientIP  string
+	CaptchaID string
+	Captcha   string
+}
+
+// ClientInfoFromHeader 从请求头读取设备ID与验证码，clientIP由调用方按可信代理解析
+func ClientInfoFromHeader(header http.Header, clientIP string) *ClientInfo {
+	return &ClientInfo{
+		DeviceID:  header.Get(HeaderDeviceID),
+		ClientIP:  clientIP,
+		CaptchaID: header.Get(HeaderCaptchaID),
+		Captcha:   header.Get(HeaderCaptcha),
+	}
+}
+
+type RiskSrv interface {
+	// Context 采集风控信号，校验请求携带的验证码，userID为0时只包含设备与IP
+	Context(ctx context.Context, userID int64, client *ClientInfo) *cpbv1.RiskContext
+	// CheckSms 发送短信前评分，要求验证码或拒绝时返回对应错误，风控服务不可用时放行
+	CheckSms(ctx context.Context, mobile string, client *ClientInfo) error
+}
+
+type riskService struct {
+	data data.DataFactory
+}
+
+func NewRiskService(data data.DataFactory) RiskSrv {
+	return &riskService{data: data}
+}
+
+// accountSignals 账号维度信号缓存
+type accountSignals struct {
+	Mobile             string `json:"mobile"`
+	RegisteredAt       int64  `json:"registered_at"`
+	SharedAddressUsers int32  `json:"shared_address_users"`
+}
+
+func (rs *riskService) Context(ctx context.Context, userID int64, client *ClientInfo) *cpbv1.RiskContext {
+	if client == nil {
+		client = &ClientInfo{}
+	}
+	rc := &cpbv1.RiskContext{
+		DeviceId:      client.DeviceID,
+		ClientIp:      client.ClientIP,
+		CaptchaResult: verifyCaptcha(client),
+	}
+	if userID > 0 {
+		signals := rs.accountSignals(ctx, userID)
+		rc.Mobile = signals.Mobile
+		rc.RegisteredAt = signals.RegisteredAt
+		rc.SharedAddressUsers = signals.SharedAddressUsers
+	}
+	return rc
+}
+
+func (rs *riskService) CheckSms(ctx context.Context, mobile string, client *ClientInfo) error {
+	rc := rs.Context(ctx, 0, client)
+	rc.Mobile = mobile
+
+	decision, err := rs.data.Coupon().EvaluateRisk(ctx, &cpbv1.EvaluateRiskRequest{
+		Scene: SceneSms,
+		Risk:  rc,
+	})
+	if err != nil {
+		// 风控不可用时不影响登录注册
+		log.WarnfC(ctx, "短信风控评分失败，放行: mobile=%s, err=%v", mobile, err)
+		return nil
+	}
+
+	switch decision.Action {
+	case "captcha":
+		return errors.WithCode(code.ErrRiskCaptchaRequired, "发送过于频繁，请完成验证码后重试")
+	case "block":
+		return errors.WithCode(code.ErrRiskBlocked, "发送请求存在风险，已被拒绝")
+	}
+	return nil
+}
+
+// accountSignals 读取注册时间、手机号与共用地址账号数，查询失败的信号按未知处理且不缓存
+func (rs *riskService) accountSignals(ctx context.Context, userID int64) *accountSignals {
+	rstore := storage.RedisCluster{}
+	key := fmt.Sprintf("risk:account:%d", userID)
+	if raw, err := rstore.GetKey(ctx, key); err == nil {
+		var cached accountSignals
+		if json.Unmarshal([]byte(raw), &cached) == nil {
+			return &cached
+		}
+	}
+
+	signals := &accountSignals{}
+	complete := true
+	user, err := rs.data.Users().GetUserById(ctx, &upbv1.IdRequest{Id: int32(userID)})
+	if err != nil {
+		log.WarnfC(ctx, "风控查询用户信息失败: userID=%d, err=%v", userID, err)
+		complete = false
+	} else {
+		signals.Mobile = user.Mobile
+		signals.RegisteredAt = user.CreatedAt
+	}
+	shared, err := rs.data.UserOp().CountAddressSharedUsers(ctx, &uoppbv1.AddressSharedUsersRequest{UserId: int32(userID)})
+	if err != nil {
+		log.WarnfC(ctx, "风控统计共用地址账号失败: userID=%d, err=%v", userID, err)
+		complete = false
+	} else {
+		signals.SharedAddressUsers = shared.Count
+	}
+
+	if complete {
+		if raw, err := json.Marshal(signals); err == nil {
+			if err := rstore.SetKey(ctx, key, string(raw), accountSignalTTL); err != nil {
+				log.DebugfC(ctx, "缓存风控账号信号失败: userID=%d, err=%v", userID, err)
+			}
+		}
+	}
+	return signals
+}
+
+// verifyCaptcha 校验请求携带的验证码，与登录共用同一个验证码存储，校验后即失效
+func verifyCaptcha(client *ClientInfo) int32 {
+	if client.CaptchaID == "" {
+		return captchaNotSubmitted
+	}
+	if base64Captcha.DefaultMemStore.Verify(client.CaptchaID, client.Captcha, true) {
+		return captchaPassed
+	}
+	return captchaFailed
+}
@@ -8,6 +8,7 @@ import (
 	lv1 "emshop/internal/app/api/emshop/service/logistics/v1"
 	ov1 "emshop/internal/app/api/emshop/service/order/v1"
 	pv1 "emshop/internal/app/api/emshop/service/payment/v1"
+	rv1 "emshop/internal/app/api/emshop/service/risk/v1"
 	sv1 "emshop/internal/app/api/emshop/service/sms/v1"
 	uv1 "emshop/internal/app/api/emshop/service/user/v1"
 	uopv1 "emshop/internal/app/api/emshop/service/userop/v1"
@@ -24,6 +25,7 @@ type ServiceFactory interface {
 	Coupon() cv1.CouponSrv
 	Payment() pv1.PaymentSrv
 	Logistics() lv1.LogisticsSrv
+	Risk() rv1.RiskSrv
 }
 
 type service struct {
@@ -70,6 +72,10 @@ func (s *service) Logistics() lv1.LogisticsSrv {
 	return lv1.NewLogisticsService(s.data)
 }
 
+func (s *service) Risk() rv1.RiskSrv {
+	return rv1.NewRiskService(s.data)
+}
+
 func NewService(store data.DataFactory, smsOpts *options.SmsOptions, jwtOpts *options.JwtOptions) *service {
 	return &service{data: store,
 		smsOpts: smsOpts,
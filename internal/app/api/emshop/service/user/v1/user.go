@@ -130,7 +130,7 @@ func (us *userService) Register(ctx context.Context, mobile, password, codes str
 		PassWord: password,
 	})
 	if err != nil {
-		log.Errorf("user register failed: %v", err)
+		log.ErrorfC(ctx, "user register failed: %v", err)
 		return nil, err
 	}
 
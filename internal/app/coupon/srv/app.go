@@ -10,24 +10,28 @@ import (
 	"time"
 
 	couponpb "emshop/api/coupon/v1"
+	"emshop/gin-micro/core/health"
 	"emshop/gin-micro/core/trace"
 	"emshop/gin-micro/registry"
-	"emshop/gin-micro/registry/consul"
 	rpcserver "emshop/gin-micro/server/rpc-server"
 	"emshop/internal/app/coupon/srv/config"
 	"emshop/internal/app/coupon/srv/consumer"
 	controllerv1 "emshop/internal/app/coupon/srv/controller/v1"
 	datav1 "emshop/internal/app/coupon/srv/data/v1"
+	couponrpc "emshop/internal/app/coupon/srv/data/v1/rpc"
 	"emshop/internal/app/coupon/srv/data/v1/interfaces"
 	"emshop/internal/app/coupon/srv/pkg/cache"
 	servicev1 "emshop/internal/app/coupon/srv/service/v1"
+	"emshop/internal/app/pkg/discovery"
 	"emshop/internal/app/pkg/options"
 	appframework "emshop/pkg/app"
+	"emshop/pkg/delayjob"
+	"emshop/pkg/dlq"
 	"emshop/pkg/log"
+	"emshop/pkg/sentinel"
 
 	redis "github.com/go-redis/redis/v8"
 	"github.com/google/uuid"
-	"github.com/hashicorp/consul/api"
 )
 
 // NewApp returns a CLI application wired for the coupon service.
@@ -80,10 +84,14 @@ type CouponApp struct {
 	canalConsumer   *consumer.CouponCanalConsumer
 	flashSaleConfig *consumer.FlashSaleConsumerConfig
 	flashSaleConsumer *consumer.FlashSaleConsumer
+	campaignConfig   *consumer.CampaignConsumerConfig
+	campaignConsumer *consumer.CampaignConsumer
 	redisClient     *redis.Client
 	dataFactory     interfaces.DataFactory
 	factoryManager  *datav1.FactoryManager
+	scheduler       *delayjob.Scheduler
 	rpcServer       *rpcserver.Server
+	ruleSource      sentinel.RuleSource
 	service         *servicev1.Service
 	registrar       registry.Registrar
 	serviceInstance *registry.ServiceInstance
@@ -115,6 +123,9 @@ func NewCouponApp(cfg *config.Config) (*CouponApp, error) {
 	}
 	cancel()
 	log.Infof("Redis连接测试成功, addr: %s", addr)
+	health.Register("redis", func(ctx context.Context) error {
+		return redisClient.Ping(ctx).Err()
+	})
 
 	// 创建数据层工厂管理器
 	factoryManager, err := datav1.NewFactoryManager(cfg.MySQL)
@@ -124,8 +135,18 @@ func NewCouponApp(cfg *config.Config) (*CouponApp, error) {
 
 	dataFactory := factoryManager.GetDataFactory()
 
+	// 商品秒杀预占单结算需要关闭订单服务中超时未支付的订单
+	var orderCloser servicev1.FlashSaleOrderCloser
+	if cfg.Registry != nil {
+		closer, err := couponrpc.NewOrderCloser(cfg.Registry)
+		if err != nil {
+			return nil, fmt.Errorf("创建订单服务客户端失败: %v", err)
+		}
+		orderCloser = closer
+	}
+
 	// 创建服务层，注入Redis与RocketMQ依赖
-	service := servicev1.NewService(dataFactory, redisClient, cfg.DTM, cfg.RocketMQ, cfg.ToCacheConfig(), cfg.Business)
+	service := servicev1.NewService(dataFactory, redisClient, cfg.DTM, cfg.RocketMQ, cfg.ToCacheConfig(), cfg.Business, orderCloser)
 	cacheManager := service.CacheManager
 	if cacheManager == nil {
 		return nil, fmt.Errorf("初始化缓存管理器失败")
@@ -136,14 +157,23 @@ func NewCouponApp(cfg *config.Config) (*CouponApp, error) {
 	}
 
 	canalConfig := &consumer.CanalConsumerConfig{
-		NameServers:   cfg.RocketMQ.NameServers,
-		ConsumerGroup: cfg.Canal.ConsumerGroup,
-		Topic:         cfg.Canal.Topic,
-		WatchTables:   cfg.Canal.WatchTables,
-		BatchSize:     cfg.Canal.BatchSize,
+		NameServers:     cfg.RocketMQ.NameServers,
+		ConsumerGroup:   cfg.Canal.ConsumerGroup,
+		Topic:           cfg.Canal.Topic,
+		WatchTables:     cfg.Canal.WatchTables,
+		BatchSize:       cfg.Canal.BatchSize,
+		RuleReloadGroup: cfg.Canal.RuleReloadGroup,
 	}
 
 	canalConsumer := consumer.NewCouponCanalConsumer(canalConfig, cacheManager)
+	canalConsumer.SetRuleReloader(service.RuleSrv)
+
+	// 加载coupon_configs中的业务规则参数，失败时使用默认规则
+	loadCtx, loadCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if err := service.RuleSrv.Reload(loadCtx); err != nil {
+		log.Warnf("加载业务规则配置失败，使用默认规则: %v", err)
+	}
+	loadCancel()
 
 	var flashSaleConsumer *consumer.FlashSaleConsumer
 	var flashSaleCfg *consumer.FlashSaleConsumerConfig
@@ -164,6 +194,54 @@ func NewCouponApp(cfg *config.Config) (*CouponApp, error) {
 		flashSaleConsumer = consumer.NewFlashSaleConsumer(dataFactory, redisClient, service.RetryManager)
 	}
 
+	// 定向发券活动批次消费者
+	var campaignConsumer *consumer.CampaignConsumer
+	var campaignCfg *consumer.CampaignConsumerConfig
+	if cfg.RocketMQ != nil && cfg.Business != nil && cfg.Business.Campaign != nil && cfg.Business.Campaign.Topic != "" {
+		campaignCfg = &consumer.CampaignConsumerConfig{
+			NameServers:   cfg.RocketMQ.NameServers,
+			ConsumerGroup: cfg.Business.Campaign.ConsumerGroup,
+			Topic:         cfg.Business.Campaign.Topic,
+		}
+		campaignConsumer = consumer.NewCampaignConsumer(service.CampaignSrv)
+	}
+
+	// 消费失败的消息写入共享死信存储，由管理端查看与重放
+	if cfg.DLQ != nil {
+		namespace := cfg.DLQ.Namespace
+		if namespace == "" {
+			namespace = "coupon"
+		}
+		deadLetters := dlq.NewRedisStore(redisClient, cfg.DLQ.StoreOptions(namespace))
+		canalConsumer.SetDeadLetter(dlq.NewRecorder(deadLetters, cfg.Canal.ConsumerGroup, 0))
+		if campaignConsumer != nil {
+			campaignConsumer.SetDeadLetter(dlq.NewRecorder(deadLetters, campaignCfg.ConsumerGroup, 0))
+		}
+		if service.RetryManager != nil {
+			service.RetryManager.SetDeadLetter(dlq.NewRecorder(deadLetters, cfg.RocketMQ.ConsumerGroup, 0))
+		}
+	}
+
+	// 创建延时任务调度器，承载优惠券过期扫描、秒杀活动自动启停等任务
+	var scheduler *delayjob.Scheduler
+	if cfg.DelayJob != nil {
+		namespace := cfg.DelayJob.Namespace
+		if namespace == "" {
+			namespace = "coupon"
+		}
+		store := delayjob.NewRedisStore(redisClient, cfg.DelayJob.StoreOptions(namespace))
+		scheduler = delayjob.New(store, cfg.DelayJob.SchedulerOptions()...)
+		service.ExpirySrv.RegisterJobs(scheduler)
+		service.FlashSaleLifecycle.RegisterJobs(scheduler)
+		service.FlashSaleGoods.RegisterJobs(scheduler)
+		service.PromoCodeSrv.RegisterJobs(scheduler)
+		service.CampaignSrv.RegisterJobs(scheduler)
+		service.ReportSrv.RegisterJobs(scheduler)
+		service.WaitingRoom.RegisterJobs(scheduler)
+	} else if service.WaitingRoom.Enabled() {
+		log.Warn("未配置延时任务调度器，秒杀排队不会放行用户")
+	}
+
 	// 初始化链路追踪
 	if cfg.Telemetry != nil {
 		trace.InitAgent(trace.Options{
@@ -176,10 +254,35 @@ func NewCouponApp(cfg *config.Config) (*CouponApp, error) {
 
 	// 创建gRPC服务器
 	rpcAddr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
-	rpcSrv := rpcserver.NewServer(
+	grpcOpts, err := cfg.Server.GRPCServerOptions()
+	if err != nil {
+		return nil, err
+	}
+	rpcOpts := []rpcserver.ServerOption{
 		rpcserver.WithAddress(rpcAddr),
 		rpcserver.WithMetrics(cfg.Server.EnableMetrics),
-	)
+		rpcserver.WithOptions(grpcOpts...),
+		rpcserver.WithAdaptiveLimit(cfg.Server.Limiter.AdaptiveLimiter(), nil),
+		rpcserver.WithLogAdmin(cfg.Server.LogAdminSecret),
+	}
+
+	// Sentinel限流，优惠券服务未接入Nacos，规则从本地文件 configs/sentinel/coupon-*.json 加载并热更新
+	var ruleSource sentinel.RuleSource
+	if cfg.Server.EnableLimit {
+		fallbackHandler := sentinel.NewBusinessFallbackHandler("emshop-coupon-srv", true)
+		interceptorConfig := sentinel.DefaultServerInterceptorConfig("coupon-srv")
+		interceptorConfig.FallbackFunc = fallbackHandler.Handle
+		rpcOpts = append(rpcOpts,
+			rpcserver.WithUnaryInterceptor(sentinel.NewUnaryServerInterceptor(interceptorConfig)),
+			rpcserver.WithStreamInterceptor(sentinel.NewStreamServerInterceptor(interceptorConfig)),
+		)
+
+		ruleSource = cfg.Server.Limiter.FileRuleSource("coupon")
+		if err := ruleSource.Initialize(); err != nil {
+			return nil, fmt.Errorf("加载限流规则失败: %v", err)
+		}
+	}
+	rpcSrv := rpcserver.NewServer(rpcOpts...)
 
 	// 注册优惠券服务
 	couponServer := controllerv1.NewCouponServer(service)
@@ -188,16 +291,16 @@ func NewCouponApp(cfg *config.Config) (*CouponApp, error) {
     var registrar registry.Registrar
 	var serviceInstance *registry.ServiceInstance
     if cfg.Registry != nil {
-        registrar, err = newConsulRegistrar(cfg.Registry, cfg.Log.Development)
+        registrar, err = discovery.NewRegistrar(cfg.Registry, cfg.Log.Development)
         if err != nil {
-            return nil, fmt.Errorf("创建Consul注册器失败: %v", err)
+            return nil, fmt.Errorf("创建服务注册器失败: %v", err)
         }
 		serviceInstance, err = buildServiceInstance(cfg.Server, rpcSrv)
 		if err != nil {
 			return nil, fmt.Errorf("构建服务实例失败: %v", err)
 		}
 	} else {
-		log.Warn("未配置服务注册信息，将跳过Consul服务注册")
+		log.Warn("未配置服务注册信息，将跳过服务注册")
 	}
 
 	log.Info("优惠券应用初始化成功")
@@ -208,10 +311,14 @@ func NewCouponApp(cfg *config.Config) (*CouponApp, error) {
 		canalConsumer:   canalConsumer,
 		flashSaleConfig: flashSaleCfg,
 		flashSaleConsumer: flashSaleConsumer,
+		campaignConfig:  campaignCfg,
+		campaignConsumer: campaignConsumer,
 		redisClient:     redisClient,
 		dataFactory:     dataFactory,
 		factoryManager:  factoryManager,
+		scheduler:       scheduler,
 		rpcServer:       rpcSrv,
+		ruleSource:      ruleSource,
 		service:         service,
 		registrar:       registrar,
 		serviceInstance: serviceInstance,
@@ -220,22 +327,37 @@ func NewCouponApp(cfg *config.Config) (*CouponApp, error) {
 
 // Run 运行应用
 func (app *CouponApp) Run(ctx context.Context) error {
-	log.Info("启动优惠券服务...")
+	log.InfoC(ctx, "启动优惠券服务...")
 
 	if app.flashSaleConsumer != nil && app.flashSaleConfig != nil {
 		if err := app.flashSaleConsumer.Start(app.flashSaleConfig); err != nil {
 			return fmt.Errorf("启动秒杀事件消费者失败: %v", err)
 		}
-		log.Info("秒杀事件消费者启动成功，已开启异步落库")
+		log.InfoC(ctx, "秒杀事件消费者启动成功，已开启异步落库")
+	}
+
+	if app.campaignConsumer != nil && app.campaignConfig != nil {
+		if err := app.campaignConsumer.Start(app.campaignConfig); err != nil {
+			return fmt.Errorf("启动发券批次消费者失败: %v", err)
+		}
+		log.InfoC(ctx, "发券批次消费者启动成功")
 	}
 
 	if app.canalConsumer != nil {
 		if err := app.canalConsumer.Start(); err != nil {
 			return fmt.Errorf("启动Canal消费者失败: %v", err)
 		}
-		log.Info("Canal消费者启动成功，已开启缓存同步")
+		log.InfoC(ctx, "Canal消费者启动成功，已开启缓存同步")
 	} else {
-		log.Warn("Canal消费者未初始化，跳过缓存同步")
+		log.WarnC(ctx, "Canal消费者未初始化，跳过缓存同步")
+	}
+
+	if app.scheduler != nil {
+		go func() {
+			if err := app.scheduler.Start(context.Background()); err != nil {
+				log.ErrorfC(ctx, "延时任务调度器退出: %v", err)
+			}
+		}()
 	}
 
 	// 启动gRPC服务器
@@ -250,18 +372,18 @@ func (app *CouponApp) Run(ctx context.Context) error {
 		err := app.registrar.Register(regCtx, app.serviceInstance)
 		cancel()
 		if err != nil {
-			log.Errorf("注册优惠券服务到Consul失败: %v", err)
+			log.ErrorfC(ctx, "注册优惠券服务到Consul失败: %v", err)
 			if app.rpcServer != nil {
 				_ = app.rpcServer.Stop(context.Background())
 			}
 			return fmt.Errorf("注册优惠券服务失败: %w", err)
 		}
-		log.Infof("优惠券服务已注册到Consul (serviceID=%s)", app.serviceInstance.ID)
+		log.InfofC(ctx, "优惠券服务已注册到Consul (serviceID=%s)", app.serviceInstance.ID)
 	} else {
-		log.Warn("Consul注册器未初始化，跳过服务注册")
+		log.WarnC(ctx, "Consul注册器未初始化，跳过服务注册")
 	}
 
-	log.Infof("优惠券服务启动成功 (gRPC: %s, HTTP: %d)", app.rpcServer.Address(), app.config.Server.HttpPort)
+	log.InfofC(ctx, "优惠券服务启动成功 (gRPC: %s, HTTP: %d)", app.rpcServer.Address(), app.config.Server.HttpPort)
 	return nil
 }
 
@@ -286,6 +408,20 @@ func (app *CouponApp) Stop() error {
 		log.Info("gRPC服务器已停止")
 	}
 
+	// 停止监听限流规则文件
+	if app.ruleSource != nil {
+		_ = app.ruleSource.Close()
+	}
+
+	// 停止延时任务调度器，等待执行中的任务结束
+	if app.scheduler != nil {
+		stopCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		if err := app.scheduler.Stop(stopCtx); err != nil {
+			log.Errorf("停止延时任务调度器失败: %v", err)
+		}
+		cancel()
+	}
+
 	// 关闭服务层（包括RocketMQ生产者）
 	if app.service != nil {
 		if err := app.service.Shutdown(); err != nil {
@@ -299,6 +435,12 @@ func (app *CouponApp) Stop() error {
 		}
 	}
 
+	if app.campaignConsumer != nil {
+		if err := app.campaignConsumer.Stop(); err != nil {
+			log.Errorf("停止发券批次消费者失败: %v", err)
+		}
+	}
+
 	// 停止Canal消费者
 	if app.canalConsumer != nil {
 		if err := app.canalConsumer.Stop(); err != nil {
@@ -339,39 +481,6 @@ func initLogger(logOpts *log.Options) error {
 	return nil
 }
 
-func newConsulRegistrar(registryOpts *options.RegistryOptions, dev bool) (registry.Registrar, error) {
-    if registryOpts == nil {
-        return nil, fmt.Errorf("registry配置为空")
-    }
-
-	cfg := api.DefaultConfig()
-	if registryOpts.Address != "" {
-		cfg.Address = registryOpts.Address
-	}
-	if registryOpts.Scheme != "" {
-		cfg.Scheme = registryOpts.Scheme
-	}
-
-	cli, err := api.NewClient(cfg)
-	if err != nil {
-		return nil, fmt.Errorf("创建Consul客户端失败: %w", err)
-	}
-
-    opts := []consul.Option{consul.WithHealthCheck(true)}
-    if registryOpts.HealthCheckInterval > 0 {
-        opts = append(opts, consul.WithHealthCheckInterval(registryOpts.HealthCheckInterval))
-    }
-    if registryOpts.CheckTimeout > 0 {
-        opts = append(opts, consul.WithCheckTimeout(registryOpts.CheckTimeout))
-    }
-    if dev {
-        opts = append(opts, consul.WithDeregisterCriticalServiceAfter(60))
-    } else if registryOpts.DeregisterCriticalAfter > 0 {
-        opts = append(opts, consul.WithDeregisterCriticalServiceAfter(registryOpts.DeregisterCriticalAfter))
-    }
-    return consul.New(cli, opts...), nil
-}
-
 func buildServiceInstance(serverOpts *options.ServerOptions, rpcSrv *rpcserver.Server) (*registry.ServiceInstance, error) {
 	if serverOpts == nil {
 		return nil, fmt.Errorf("server配置为空")
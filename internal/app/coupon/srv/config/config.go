@@ -25,6 +25,8 @@ type Config struct {
 	Ristretto *RistrettoOptions         `yaml:"ristretto"`
 	Canal     *CanalOptions             `yaml:"canal"`
 	Business  *BusinessOptions          `yaml:"business"`
+	DelayJob  *options.DelayJobOptions  `yaml:"delayjob"`
+	DLQ       *options.DLQOptions       `yaml:"dlq"`
 }
 
 // New 创建具有合理默认值的配置对象，保证YAML加载前的字段完整性
@@ -54,18 +56,25 @@ func New() *Config {
 			Metrics:     true,
 		},
 		Canal: &CanalOptions{
-			ConsumerGroup: "coupon-cache-sync-consumer",
-			Topic:         "coupon-binlog-topic",
-			WatchTables:   []string{"coupon_templates", "user_coupons", "flash_sale_activities"},
-			BatchSize:     32,
+			ConsumerGroup:   "coupon-cache-sync-consumer",
+			Topic:           "coupon-binlog-topic",
+			WatchTables:     []string{"coupon_templates", "user_coupons", "flash_sale_activities", "coupon_configs"},
+			BatchSize:       32,
+			RuleReloadGroup: "coupon-rule-reload-consumer",
 		},
 		Business: &BusinessOptions{
 			FlashSale: &FlashSaleOptions{
-				MaxQpsPerUser: 5,
-				StockCacheTTL: 300 * time.Second,
-				UserLimitTTL:  1800 * time.Second,
-				BatchSize:     100,
-				EnableAsync:   false,
+				MaxQpsPerUser:  5,
+				StockCacheTTL:  300 * time.Second,
+				UserLimitTTL:   1800 * time.Second,
+				BatchSize:      100,
+				EnableAsync:    false,
+				PlanInterval:   time.Minute,
+				PrewarmLead:    5 * time.Minute,
+				ReconcileDelay: time.Minute,
+				StockShards:    1,
+				PayWindow:      10 * time.Minute,
+				SettleInterval: 30 * time.Second,
 			},
 			Coupon: &CouponOptions{
 				MaxStackCount: 5,
@@ -78,7 +87,71 @@ func New() *Config {
 				WarmupCount:  100,
 				EnableWarmup: false,
 			},
+			Expiry: &ExpiryOptions{
+				SweepInterval:  5 * time.Minute,
+				RemindInterval: time.Hour,
+				BatchSize:      500,
+				RemindDays:     3,
+			},
+			PromoCode: &PromoCodeOptions{
+				CodeLength:       12,
+				MaxBatchSize:     100000,
+				RedeemRateLimit:  10,
+				RedeemRateWindow: time.Minute,
+				RedeemFailLimit:  5,
+				RedeemFailWindow: 10 * time.Minute,
+				ReindexInterval:  30 * time.Minute,
+			},
+			Campaign: &CampaignOptions{
+				Topic:            "coupon-campaign",
+				ConsumerGroup:    "coupon-campaign-consumer-group",
+				DispatchInterval: 5 * time.Second,
+				DefaultBatchSize: 500,
+				MaxBatchSize:     5000,
+				DispatchTimeout:  10 * time.Minute,
+			},
+			Report: &ReportOptions{
+				AggregateInterval: 10 * time.Minute,
+				LookbackDays:      1,
+				MaxRangeDays:      366,
+			},
+			WaitingRoom: &WaitingRoomOptions{
+				AdmitRate:      200,
+				AdmitInterval:  time.Second,
+				MaxQueueLength: 200000,
+				AdmissionTTL:   2 * time.Minute,
+			},
+			Risk: &RiskOptions{
+				Window:               time.Minute,
+				DeviceLimit:          5,
+				DeviceWeight:         30,
+				IPLimit:              20,
+				IPWeight:             20,
+				MobilePrefixLength:   7,
+				MobilePrefixLimit:    50,
+				MobilePrefixWeight:   15,
+				NewAccountAge:        30 * time.Minute,
+				NewAccountWeight:     30,
+				SharedAddressLimit:   3,
+				SharedAddressWeight:  30,
+				CaptchaFailureWindow: time.Hour,
+				CaptchaFailureLimit:  3,
+				CaptchaFailureWeight: 30,
+				CaptchaScore:         40,
+				BlockScore:           80,
+				AllowSampleRate:      0.01,
+			},
 		},
+		DelayJob: func() *options.DelayJobOptions {
+			opt := options.NewDelayJobOptions()
+			opt.Namespace = "coupon"
+			return opt
+		}(),
+		DLQ: func() *options.DLQOptions {
+			opt := options.NewDLQOptions()
+			opt.Namespace = "coupon"
+			return opt
+		}(),
 	}
 }
 
@@ -108,6 +181,12 @@ func (c *Config) Flags() (fss cliflag.NamedFlagSets) {
 	if c.DTM != nil {
 		c.DTM.AddFlags(fss.FlagSet("dtm"))
 	}
+	if c.DelayJob != nil {
+		c.DelayJob.AddFlags(fss.FlagSet("delayjob"))
+	}
+	if c.DLQ != nil {
+		c.DLQ.AddFlags(fss.FlagSet("dlq"))
+	}
 	return fss
 }
 
@@ -160,6 +239,14 @@ func (c *Config) Validate() []error {
 	}
 	if c.Business == nil {
 		errs = append(errs, fmt.Errorf("business configuration is required"))
+	} else if wr := c.Business.WaitingRoom; wr != nil && wr.Enabled && wr.TokenSecret == "" {
+		errs = append(errs, fmt.Errorf("business.waiting_room.token_secret is required when the waiting room is enabled"))
+	}
+	if c.DelayJob != nil {
+		errs = append(errs, c.DelayJob.Validate()...)
+	}
+	if c.DLQ != nil {
+		errs = append(errs, c.DLQ.Validate()...)
 	}
 
 	return errs
@@ -185,6 +272,8 @@ type CanalOptions struct {
 	Topic         string   `yaml:"topic"`
 	WatchTables   []string `yaml:"watch_tables"`
 	BatchSize     int32    `yaml:"batch_size"`
+	// RuleReloadGroup 广播消费coupon_configs变更的消费者组，每个副本都重新加载业务规则
+	RuleReloadGroup string `yaml:"rule_reload_group"`
 }
 
 // BusinessOptions 业务配置
@@ -192,6 +281,13 @@ type BusinessOptions struct {
 	FlashSale *FlashSaleOptions `yaml:"flashsale"`
 	Coupon    *CouponOptions    `yaml:"coupon"`
 	Cache     *CacheOptions     `yaml:"cache"`
+	Expiry    *ExpiryOptions    `yaml:"expiry"`
+	PromoCode *PromoCodeOptions `yaml:"promo_code"`
+	Campaign  *CampaignOptions  `yaml:"campaign"`
+	Report    *ReportOptions    `yaml:"report"`
+
+	WaitingRoom *WaitingRoomOptions `yaml:"waiting_room"`
+	Risk        *RiskOptions        `yaml:"risk"`
 }
 
 // FlashSaleOptions 秒杀配置
@@ -201,6 +297,15 @@ type FlashSaleOptions struct {
 	UserLimitTTL  time.Duration `yaml:"user_limit_ttl"`
 	BatchSize     int           `yaml:"batch_size"`
 	EnableAsync   bool          `yaml:"enable_async"`
+	// 活动自动启停，PlanInterval为0时关闭
+	PlanInterval   time.Duration `yaml:"plan_interval"`   // 扫描待开始/结束活动的间隔
+	PrewarmLead    time.Duration `yaml:"prewarm_lead"`    // 开始前多久预热库存
+	ReconcileDelay time.Duration `yaml:"reconcile_delay"` // 结束后等待异步落库完成再对账
+	// StockShards 库存分片数，大于1时新活动的库存拆分到多个key分散热点，只影响新初始化的活动
+	StockShards int `yaml:"stock_shards"`
+	// 商品秒杀，SettleInterval为0时不自动结算到期预占单
+	PayWindow      time.Duration `yaml:"pay_window"`      // 抢到名额后的支付时限
+	SettleInterval time.Duration `yaml:"settle_interval"` // 扫描到期预占单的间隔
 }
 
 // CouponOptions 优惠券配置
@@ -218,6 +323,91 @@ type CacheOptions struct {
 	EnableWarmup bool          `yaml:"enable_warmup"`
 }
 
+// ExpiryOptions 优惠券到期处理配置
+type ExpiryOptions struct {
+	SweepInterval  time.Duration `yaml:"sweep_interval"`
+	RemindInterval time.Duration `yaml:"remind_interval"`
+	BatchSize      int           `yaml:"batch_size"`
+	RemindDays     int           `yaml:"remind_days"` // 提前多少天生成到期提醒，0表示不提醒
+}
+
+// PromoCodeOptions 兑换码配置
+type PromoCodeOptions struct {
+	CodeLength   int `yaml:"code_length"`    // 批量生成的兑换码长度(不含前缀)
+	MaxBatchSize int `yaml:"max_batch_size"` // 单次最多生成数量
+	// 兑换限流，防止枚举兑换码；Limit为0时不限制
+	RedeemRateLimit  int           `yaml:"redeem_rate_limit"`  // 窗口内每个用户最多兑换请求数
+	RedeemRateWindow time.Duration `yaml:"redeem_rate_window"` // 兑换请求计数窗口
+	RedeemFailLimit  int           `yaml:"redeem_fail_limit"`  // 窗口内每个用户最多无效兑换码次数
+	RedeemFailWindow time.Duration `yaml:"redeem_fail_window"` // 无效兑换码计数窗口
+	// 兑换码Redis集合的周期重建间隔，为0时不使用集合前置校验
+	ReindexInterval time.Duration `yaml:"reindex_interval"`
+}
+
+// CampaignOptions 定向发券活动配置
+type CampaignOptions struct {
+	Topic            string        `yaml:"topic"`              // 发券批次消息主题
+	ConsumerGroup    string        `yaml:"consumer_group"`     // 发券批次消费者组
+	DispatchInterval time.Duration `yaml:"dispatch_interval"`  // 扫描发放中活动的间隔，为0时关闭投递
+	DefaultBatchSize int           `yaml:"default_batch_size"` // 活动未指定时的每批发放数量
+	MaxBatchSize     int           `yaml:"max_batch_size"`     // 每批发放数量上限
+	DispatchTimeout  time.Duration `yaml:"dispatch_timeout"`   // 已投递目标超过该时间未处理则重新投递
+}
+
+// ReportOptions 优惠券报表配置
+type ReportOptions struct {
+	AggregateInterval time.Duration `yaml:"aggregate_interval"` // 预聚合每日统计的间隔，为0时关闭
+	LookbackDays      int           `yaml:"lookback_days"`      // 每次除当天外重新聚合的天数，覆盖跨天取消的订单
+	MaxRangeDays      int           `yaml:"max_range_days"`     // 单次查询或重建的最大天数
+}
+
+// WaitingRoomOptions 秒杀排队配置
+//
+// 开启后用户先入队，按AdmitRate放行并签发放行令牌，参与秒杀必须携带有效令牌
+type WaitingRoomOptions struct {
+	Enabled        bool          `yaml:"enabled"`
+	AdmitRate      int           `yaml:"admit_rate"`       // 每个活动每秒放行人数
+	AdmitInterval  time.Duration `yaml:"admit_interval"`   // 放行批次间隔
+	MaxQueueLength int           `yaml:"max_queue_length"` // 每个活动最多排队人数，超出时拒绝入队
+	AdmissionTTL   time.Duration `yaml:"admission_ttl"`    // 放行令牌有效期
+	TokenSecret    string        `yaml:"token_secret"`     // 令牌签名密钥，开启时必须配置且各副本一致，不要写入配置文件
+}
+
+// RiskOptions 领券、秒杀与短信发送的风控评分配置
+//
+// 各信号超过阈值时累加权重，总分达到CaptchaScore要求验证码，达到BlockScore直接拒绝，
+// 阈值或权重为0的信号不参与评分；Scenes按场景覆盖动作分数
+type RiskOptions struct {
+	Enabled              bool                         `yaml:"enabled"`
+	Window               time.Duration                `yaml:"window"`       // 设备、IP与号段请求数统计窗口
+	DeviceLimit          int64                        `yaml:"device_limit"` // 窗口内同设备请求数上限
+	DeviceWeight         int                          `yaml:"device_weight"`
+	IPLimit              int64                        `yaml:"ip_limit"` // 窗口内同IP请求数上限
+	IPWeight             int                          `yaml:"ip_weight"`
+	MobilePrefixLength   int                          `yaml:"mobile_prefix_length"` // 号段取手机号前几位
+	MobilePrefixLimit    int64                        `yaml:"mobile_prefix_limit"`  // 窗口内同号段请求数上限
+	MobilePrefixWeight   int                          `yaml:"mobile_prefix_weight"`
+	NewAccountAge        time.Duration                `yaml:"new_account_age"` // 注册不足该时长视为新账号，秒杀以活动开始时间计算
+	NewAccountWeight     int                          `yaml:"new_account_weight"`
+	SharedAddressLimit   int32                        `yaml:"shared_address_limit"` // 与其他账号共用收货地址的账号数上限
+	SharedAddressWeight  int                          `yaml:"shared_address_weight"`
+	CaptchaFailureWindow time.Duration                `yaml:"captcha_failure_window"` // 验证码失败次数统计窗口
+	CaptchaFailureLimit  int64                        `yaml:"captcha_failure_limit"`
+	CaptchaFailureWeight int                          `yaml:"captcha_failure_weight"`
+	CaptchaScore         int                          `yaml:"captcha_score"` // 要求验证码的分数，0为不触发
+	BlockScore           int                          `yaml:"block_score"`   // 直接拒绝的分数，0为不拒绝
+	Scenes               map[string]*RiskSceneOptions `yaml:"scenes"`        // 按场景覆盖，key为receive_coupon、flash_sale或sms
+	// 未命中任何信号的放行决策的记录比例，0为不记录；验证码与拒绝决策总是记录
+	AllowSampleRate float64 `yaml:"allow_sample_rate"`
+}
+
+// RiskSceneOptions 场景动作配置，分数为0时沿用全局配置
+type RiskSceneOptions struct {
+	CaptchaScore int    `yaml:"captcha_score"`
+	BlockScore   int    `yaml:"block_score"`
+	MaxAction    string `yaml:"max_action"` // 场景最严厉的动作: allow、captcha或block，allow时只记录不拦截
+}
+
 // ToCacheConfig 转换为缓存配置
 func (c *Config) ToCacheConfig() *cache.CacheConfig {
 	if c.Ristretto == nil {
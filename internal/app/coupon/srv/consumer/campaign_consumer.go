@@ -0,0 +1,188 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"emshop/pkg/dlq"
+	"emshop/pkg/log"
+
+	rocketmq "github.com/apache/rocketmq-client-go/v2"
+	"github.com/apache/rocketmq-client-go/v2/consumer"
+	"github.com/apache/rocketmq-client-go/v2/primitive"
+	"github.com/apache/rocketmq-client-go/v2/producer"
+)
+
+// CampaignBatchEvent 定向发券批次事件，一条消息对应活动的一批目标用户
+type CampaignBatchEvent struct {
+	CampaignID int64   `json:"campaign_id"`
+	TargetIDs  []int64 `json:"target_ids"`
+	Timestamp  int64   `json:"timestamp"`
+}
+
+// CampaignBatchProducer 发券批次消息生产者
+type CampaignBatchProducer interface {
+	SendCampaignBatch(ctx context.Context, event *CampaignBatchEvent) error
+	Shutdown() error
+}
+
+// CampaignBatchHandler 发券批次处理器，由服务层实现
+type CampaignBatchHandler interface {
+	IssueBatch(ctx context.Context, campaignID int64, targetIDs []int64) error
+}
+
+// campaignBatchProducer RocketMQ发券批次生产者实现
+type campaignBatchProducer struct {
+	producer rocketmq.Producer
+	topic    string
+}
+
+// NewCampaignBatchProducer 创建发券批次消息生产者
+func NewCampaignBatchProducer(nameServers []string, groupName, topic string) (CampaignBatchProducer, error) {
+	p, err := rocketmq.NewProducer(
+		producer.WithNameServer(nameServers),
+		producer.WithRetry(3),
+		producer.WithGroupName(groupName),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("创建RocketMQ Producer失败: %v", err)
+	}
+
+	if err := p.Start(); err != nil {
+		return nil, fmt.Errorf("启动RocketMQ Producer失败: %v", err)
+	}
+
+	log.Infof("发券批次Producer启动成功, nameServers: %v, group: %s, topic: %s",
+		nameServers, groupName, topic)
+
+	return &campaignBatchProducer{
+		producer: p,
+		topic:    topic,
+	}, nil
+}
+
+// SendCampaignBatch 同步发送发券批次消息
+func (p *campaignBatchProducer) SendCampaignBatch(ctx context.Context, event *CampaignBatchEvent) error {
+	if event.CampaignID <= 0 || len(event.TargetIDs) == 0 {
+		return fmt.Errorf("invalid campaign batch: campaignID=%d, targets=%d", event.CampaignID, len(event.TargetIDs))
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("序列化发券批次事件失败: %v", err)
+	}
+
+	msg := &primitive.Message{
+		Topic: p.topic,
+		Body:  body,
+	}
+	msg.WithTag("COUPON_CAMPAIGN_BATCH")
+	msg.WithKeys([]string{fmt.Sprintf("campaign_%d_target_%d", event.CampaignID, event.TargetIDs[0])})
+	msg.WithProperty("event_type", "coupon_campaign_batch")
+	msg.WithProperty("campaign_id", fmt.Sprintf("%d", event.CampaignID))
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := p.producer.SendSync(ctx, msg)
+	if err != nil {
+		log.ErrorfC(ctx, "发送发券批次事件失败: %v, campaignID=%d", err, event.CampaignID)
+		return fmt.Errorf("发送发券批次事件失败: %v", err)
+	}
+
+	log.InfofC(ctx, "发送发券批次事件成功: campaignID=%d, targets=%d, msgID=%s",
+		event.CampaignID, len(event.TargetIDs), result.MsgID)
+	return nil
+}
+
+// Shutdown 关闭生产者
+func (p *campaignBatchProducer) Shutdown() error {
+	return p.producer.Shutdown()
+}
+
+// CampaignConsumer 发券批次消费者
+type CampaignConsumer struct {
+	handler    CampaignBatchHandler
+	recorder   *dlq.Recorder
+	mqConsumer rocketmq.PushConsumer
+}
+
+// CampaignConsumerConfig 发券批次消费者配置
+type CampaignConsumerConfig struct {
+	NameServers   []string `json:"name_servers"`
+	ConsumerGroup string   `json:"consumer_group"`
+	Topic         string   `json:"topic"`
+}
+
+// NewCampaignConsumer 创建发券批次消费者
+func NewCampaignConsumer(handler CampaignBatchHandler) *CampaignConsumer {
+	return &CampaignConsumer{
+		handler: handler,
+	}
+}
+
+// SetDeadLetter 设置多次重投仍失败时写入的死信记录器
+func (cc *CampaignConsumer) SetDeadLetter(recorder *dlq.Recorder) {
+	cc.recorder = recorder
+}
+
+// Start 启动发券批次消费者
+func (cc *CampaignConsumer) Start(config *CampaignConsumerConfig) error {
+	if cc == nil || cc.handler == nil {
+		return fmt.Errorf("campaign consumer is nil")
+	}
+	if config == nil {
+		return fmt.Errorf("campaign consumer config is nil")
+	}
+	if cc.mqConsumer != nil {
+		return nil
+	}
+
+	pushConsumer, err := rocketmq.NewPushConsumer(
+		consumer.WithNameServer(config.NameServers),
+		consumer.WithGroupName(config.ConsumerGroup),
+		consumer.WithConsumeFromWhere(consumer.ConsumeFromLastOffset),
+		consumer.WithConsumeMessageBatchMaxSize(1),
+	)
+	if err != nil {
+		return fmt.Errorf("创建RocketMQ消费者失败: %w", err)
+	}
+
+	if err := pushConsumer.Subscribe(config.Topic, consumer.MessageSelector{}, cc.recorder.Wrap(cc.handle)); err != nil {
+		pushConsumer.Shutdown()
+		return fmt.Errorf("订阅发券批次事件失败: %w", err)
+	}
+
+	if err := pushConsumer.Start(); err != nil {
+		pushConsumer.Shutdown()
+		return fmt.Errorf("启动发券批次消费者失败: %w", err)
+	}
+
+	cc.mqConsumer = pushConsumer
+	return nil
+}
+
+// Stop 停止发券批次消费者
+func (cc *CampaignConsumer) Stop() error {
+	if cc == nil || cc.mqConsumer == nil {
+		return nil
+	}
+	defer func() { cc.mqConsumer = nil }()
+	return cc.mqConsumer.Shutdown()
+}
+
+// handle 处理发券批次消息，处理失败时交由RocketMQ重试，已发放的目标在重试时会被跳过
+func (cc *CampaignConsumer) handle(ctx context.Context, msg *primitive.MessageExt) error {
+	var event CampaignBatchEvent
+	if err := json.Unmarshal(msg.Body, &event); err != nil {
+		log.ErrorfC(ctx, "解析发券批次事件失败: msgID=%s, err=%v", msg.MsgId, err)
+		return dlq.Permanent(err)
+	}
+	if err := cc.handler.IssueBatch(ctx, event.CampaignID, event.TargetIDs); err != nil {
+		log.ErrorfC(ctx, "处理发券批次失败: campaignID=%d, msgID=%s, err=%v", event.CampaignID, msg.MsgId, err)
+		return err
+	}
+	return nil
+}
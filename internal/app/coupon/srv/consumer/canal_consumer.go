@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/apache/rocketmq-client-go/v2"
 	"github.com/apache/rocketmq-client-go/v2/consumer"
@@ -12,6 +14,8 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"emshop/internal/app/coupon/srv/pkg/cache"
+	"emshop/internal/app/coupon/srv/pkg/calculator"
+	"emshop/pkg/dlq"
 	"emshop/pkg/log"
 )
 
@@ -35,14 +39,24 @@ type CanalConsumerConfig struct {
 	Topic         string   `yaml:"topic"`
 	WatchTables   []string `yaml:"watch_tables"`
 	BatchSize     int32    `yaml:"batch_size"`
+	// RuleReloadGroup 广播消费业务规则变更的消费者组，为空时使用ConsumerGroup加后缀
+	RuleReloadGroup string `yaml:"rule_reload_group"`
+}
+
+// ConfigReloader 配置重新加载接口，coupon_configs变更时调用
+type ConfigReloader interface {
+	Reload(ctx context.Context) error
 }
 
 // CouponCanalConsumer 优惠券Canal消费者
 type CouponCanalConsumer struct {
 	config       *CanalConsumerConfig
 	consumer     rocketmq.PushConsumer
+	ruleConsumer rocketmq.PushConsumer
 	cacheManager cache.CacheManager
 	watchTables  map[string]bool
+	ruleReloader ConfigReloader
+	recorder     *dlq.Recorder
 
 	// 监控指标
 	messageTotal prometheus.Counter
@@ -88,6 +102,16 @@ func NewCouponCanalConsumer(config *CanalConsumerConfig, cacheManager cache.Cach
 	}
 }
 
+// SetRuleReloader 设置业务规则配置变更时的重新加载器
+func (ccc *CouponCanalConsumer) SetRuleReloader(reloader ConfigReloader) {
+	ccc.ruleReloader = reloader
+}
+
+// SetDeadLetter 设置多次重投仍失败时写入的死信记录器
+func (ccc *CouponCanalConsumer) SetDeadLetter(recorder *dlq.Recorder) {
+	ccc.recorder = recorder
+}
+
 // Start 启动Canal消费者
 func (ccc *CouponCanalConsumer) Start() error {
 	// 创建RocketMQ消费者
@@ -117,11 +141,66 @@ func (ccc *CouponCanalConsumer) Start() error {
 	}
 
 	log.Infof("Canal消费者启动成功, topic: %s, group: %s", ccc.config.Topic, ccc.config.ConsumerGroup)
+
+	if ccc.ruleReloader != nil && ccc.watchTables["coupon_configs"] {
+		if err := ccc.startRuleConsumer(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// startRuleConsumer 以广播模式订阅Canal主题，每个副本都收到coupon_configs变更并重新加载本地业务规则；
+// 缓存失效只需一个副本处理，仍由集群模式的消费者负责
+func (ccc *CouponCanalConsumer) startRuleConsumer() error {
+	group := ccc.config.RuleReloadGroup
+	if group == "" {
+		group = ccc.config.ConsumerGroup + "-rule-reload"
+	}
+	c, err := rocketmq.NewPushConsumer(
+		consumer.WithNameServer(ccc.config.NameServers),
+		consumer.WithGroupName(group),
+		consumer.WithConsumeFromWhere(consumer.ConsumeFromLastOffset),
+		consumer.WithConsumerModel(consumer.BroadCasting),
+	)
+	if err != nil {
+		return fmt.Errorf("创建业务规则广播消费者失败: %v", err)
+	}
+	if err := c.Subscribe(ccc.config.Topic, consumer.MessageSelector{}, ccc.ConsumeRuleChange); err != nil {
+		return fmt.Errorf("订阅业务规则变更失败: %v", err)
+	}
+	if err := c.Start(); err != nil {
+		return fmt.Errorf("启动业务规则广播消费者失败: %v", err)
+	}
+	ccc.ruleConsumer = c
+	log.Infof("业务规则广播消费者启动成功, topic: %s, group: %s", ccc.config.Topic, group)
+	return nil
+}
+
+// ConsumeRuleChange 广播消费coupon_configs变更
+//
+// 广播模式下消费失败不会重投，重新加载失败只记录日志，由下一次配置变更或重启恢复
+func (ccc *CouponCanalConsumer) ConsumeRuleChange(ctx context.Context, msgs ...*primitive.MessageExt) (consumer.ConsumeResult, error) {
+	for _, msg := range msgs {
+		var canalMsg CanalMessage
+		if err := json.Unmarshal(msg.Body, &canalMsg); err != nil || canalMsg.Table != "coupon_configs" {
+			continue
+		}
+		if err := ccc.handleCouponConfigChange(&canalMsg); err != nil {
+			log.ErrorfC(ctx, "处理业务规则变更失败: %v", err)
+			ccc.errorTotal.Inc()
+		}
+	}
+	return consumer.ConsumeSuccess, nil
+}
+
 // Stop 停止Canal消费者
 func (ccc *CouponCanalConsumer) Stop() error {
+	if ccc.ruleConsumer != nil {
+		if err := ccc.ruleConsumer.Shutdown(); err != nil {
+			return fmt.Errorf("停止业务规则广播消费者失败: %v", err)
+		}
+	}
 	if ccc.consumer != nil {
 		err := ccc.consumer.Shutdown()
 		if err != nil {
@@ -133,37 +212,41 @@ func (ccc *CouponCanalConsumer) Stop() error {
 }
 
 // ConsumeCanalMessage 消费Canal消息，实现缓存一致性
+//
+// 缓存更新失败时交由RocketMQ重投，多次重投仍失败或消息无法解析时写入死信
 func (ccc *CouponCanalConsumer) ConsumeCanalMessage(ctx context.Context, msgs ...*primitive.MessageExt) (consumer.ConsumeResult, error) {
 	timer := prometheus.NewTimer(ccc.syncLatency)
 	defer timer.ObserveDuration()
 
-	for _, msg := range msgs {
-		ccc.messageTotal.Inc()
-
-		var canalMsg CanalMessage
-		if err := json.Unmarshal(msg.Body, &canalMsg); err != nil {
-			log.Errorf("Canal消息解析失败: %v", err)
-			ccc.errorTotal.Inc()
-			continue
-		}
+	return ccc.recorder.Wrap(ccc.handleMessage)(ctx, msgs...)
+}
 
-		// 只处理优惠券相关表
-		if !ccc.watchTables[canalMsg.Table] {
-			continue
-		}
+// handleMessage 处理单条Canal消息
+func (ccc *CouponCanalConsumer) handleMessage(ctx context.Context, msg *primitive.MessageExt) error {
+	ccc.messageTotal.Inc()
 
-		log.Infof("收到Canal消息: database=%s, table=%s, type=%s, dataCount=%d",
-			canalMsg.Database, canalMsg.Table, canalMsg.Type, len(canalMsg.Data))
+	var canalMsg CanalMessage
+	if err := json.Unmarshal(msg.Body, &canalMsg); err != nil {
+		log.ErrorfC(ctx, "Canal消息解析失败: %v", err)
+		ccc.errorTotal.Inc()
+		return dlq.Permanent(err)
+	}
 
-		// 根据表名和操作类型处理缓存更新
-		if err := ccc.handleTableChange(&canalMsg); err != nil {
-			log.Errorf("处理Canal消息失败: %v", err)
-			ccc.errorTotal.Inc()
-			// 继续处理下一条消息，不返回错误
-		}
+	// 只处理优惠券相关表
+	if !ccc.watchTables[canalMsg.Table] {
+		return nil
 	}
 
-	return consumer.ConsumeSuccess, nil
+	log.InfofC(ctx, "收到Canal消息: database=%s, table=%s, type=%s, dataCount=%d",
+		canalMsg.Database, canalMsg.Table, canalMsg.Type, len(canalMsg.Data))
+
+	// 根据表名和操作类型处理缓存更新
+	if err := ccc.handleTableChange(&canalMsg); err != nil {
+		log.ErrorfC(ctx, "处理Canal消息失败: %v", err)
+		ccc.errorTotal.Inc()
+		return err
+	}
+	return nil
 }
 
 // handleTableChange 根据表变更处理缓存
@@ -175,6 +258,9 @@ func (ccc *CouponCanalConsumer) handleTableChange(msg *CanalMessage) error {
 		return ccc.handleUserCouponChange(msg)
 	case "flash_sale_activities":
 		return ccc.handleFlashSaleChange(msg)
+	case "coupon_configs":
+		// 业务规则由广播消费者在每个副本上重新加载
+		return nil
 	default:
 		log.Warnf("未处理的表变更: %s", msg.Table)
 		return nil
@@ -300,4 +386,30 @@ func (ccc *CouponCanalConsumer) handleFlashSaleChange(msg *CanalMessage) error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// handleCouponConfigChange 处理优惠券配置变更，业务规则配置项变更时重新加载规则
+func (ccc *CouponCanalConsumer) handleCouponConfigChange(msg *CanalMessage) error {
+	if ccc.ruleReloader == nil {
+		return nil
+	}
+
+	changed := false
+	for _, data := range msg.Data {
+		if key, ok := data["config_key"].(string); ok && strings.HasPrefix(key, calculator.RuleConfigKeyPrefix) {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := ccc.ruleReloader.Reload(ctx); err != nil {
+		return fmt.Errorf("重新加载业务规则配置失败: %v", err)
+	}
+	log.Infof("业务规则配置已重新加载: type=%s", msg.Type)
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"emshop/gin-micro/core/health"
 	"emshop/internal/app/coupon/srv/data/v1/interfaces"
 	"emshop/internal/app/coupon/srv/data/v1/redis"
 	"emshop/internal/app/coupon/srv/domain/do"
@@ -140,7 +141,7 @@ func (fsc *FlashSaleConsumer) dispatchConsumeMessage(ctx context.Context, msgs .
 		case "flash_sale_failure":
 			failureMsgs = append(failureMsgs, msg)
 		default:
-			log.Warnf("收到未知类型秒杀事件，跳过: msgID=%s, eventType=%s", msg.MsgId, eventType)
+			log.WarnfC(ctx, "收到未知类型秒杀事件，跳过: msgID=%s, eventType=%s", msg.MsgId, eventType)
 		}
 	}
 
@@ -163,17 +164,22 @@ func (fsc *FlashSaleConsumer) dispatchConsumeMessage(ctx context.Context, msgs .
 
 // ConsumeFlashSaleSuccessMessage 消费秒杀成功消息
 func (fsc *FlashSaleConsumer) ConsumeFlashSaleSuccessMessage(ctx context.Context, msgs ...*primitive.MessageExt) (consumer.ConsumeResult, error) {
-	log.Infof("收到秒杀成功消息，消息数量: %d", len(msgs))
+	log.InfofC(ctx, "收到秒杀成功消息，消息数量: %d", len(msgs))
 
 	for _, msg := range msgs {
 		// 解析消息
 		var event FlashSaleSuccessEvent
 		if err := json.Unmarshal(msg.Body, &event); err != nil {
-			log.Errorf("解析秒杀成功消息失败: %v, msgID: %s", err, msg.MsgId)
+			log.ErrorfC(ctx, "解析秒杀成功消息失败: %v, msgID: %s", err, msg.MsgId)
+			if fsc.retryManager != nil {
+				if dlqErr := fsc.retryManager.DeadLetter(ctx, msg, err); dlqErr != nil {
+					log.ErrorfC(ctx, "秒杀成功消息写入死信失败: %v, msgID: %s", dlqErr, msg.MsgId)
+				}
+			}
 			continue
 		}
 
-		log.Infof("处理秒杀成功事件: userID=%d, activityID=%d, couponSn=%s", 
+		log.InfofC(ctx, "处理秒杀成功事件: userID=%d, activityID=%d, couponSn=%s", 
 			event.UserID, event.ActivityID, event.CouponSn)
 
 		// 处理秒杀成功事件
@@ -185,15 +191,15 @@ func (fsc *FlashSaleConsumer) ConsumeFlashSaleSuccessMessage(ctx context.Context
 					retryCfg = fsc.buildRetryConfig(nil)
 				}
 				if retryErr := fsc.retryManager.ScheduleRetry(ctx, msg, err, retryCfg); retryErr != nil {
-					log.Errorf("调度秒杀成功事件重试失败: %v", retryErr)
+					log.ErrorfC(ctx, "调度秒杀成功事件重试失败: %v", retryErr)
 				} else {
 					handled = true
-					log.Infof("秒杀成功事件已加入重试队列: msgID=%s", msg.MsgId)
+					log.InfofC(ctx, "秒杀成功事件已加入重试队列: msgID=%s", msg.MsgId)
 				}
 			}
 
 			if !handled {
-				log.Errorf("处理秒杀成功事件失败: %v, 将由RocketMQ重试", err)
+				log.ErrorfC(ctx, "处理秒杀成功事件失败: %v, 将由RocketMQ重试", err)
 				return consumer.ConsumeRetryLater, err
 			}
 		}
@@ -208,9 +214,9 @@ func (fsc *FlashSaleConsumer) handleFlashSaleSuccess(ctx context.Context, event
 	idempotentKey := fmt.Sprintf("flashsale:processed:%s", msgID)
 	exists, err := fsc.redisClient.Exists(ctx, idempotentKey).Result()
 	if err != nil {
-		log.Errorf("检查幂等性失败: %v", err)
+		log.ErrorfC(ctx, "检查幂等性失败: %v", err)
 	} else if exists > 0 {
-		log.Infof("消息已处理过，跳过: msgID=%s", msgID)
+		log.InfofC(ctx, "消息已处理过，跳过: msgID=%s", msgID)
 		return nil
 	}
 
@@ -256,7 +262,7 @@ func (fsc *FlashSaleConsumer) handleFlashSaleSuccess(ctx context.Context, event
 		tx.Rollback()
 		// 如果是因为重复创建导致的错误，可能是并发问题，进行库存回滚
 		if fsc.isDuplicateError(err) {
-			log.Warnf("用户优惠券可能已存在，进行库存回滚: userID=%d, couponSn=%s", 
+			log.WarnfC(ctx, "用户优惠券可能已存在，进行库存回滚: userID=%d, couponSn=%s", 
 				event.UserID, event.CouponSn)
 			fsc.rollbackStockIfNeeded(ctx, event)
 			return nil
@@ -267,14 +273,14 @@ func (fsc *FlashSaleConsumer) handleFlashSaleSuccess(ctx context.Context, event
 	// 7. 更新优惠券模板使用统计
 	if err := fsc.updateCouponTemplateStats(ctx, tx, event.CouponID); err != nil {
 		tx.Rollback()
-		log.Errorf("更新优惠券模板统计失败: %v", err)
+		log.ErrorfC(ctx, "更新优惠券模板统计失败: %v", err)
 		// 统计更新失败不影响主流程，记录日志即可
 	}
 
 	// 8. 更新活动统计
 	if err := fsc.updateFlashSaleStats(ctx, tx, event.ActivityID); err != nil {
 		tx.Rollback()
-		log.Errorf("更新活动统计失败: %v", err)
+		log.ErrorfC(ctx, "更新活动统计失败: %v", err)
 		// 统计更新失败不影响主流程，记录日志即可
 	}
 
@@ -287,7 +293,7 @@ func (fsc *FlashSaleConsumer) handleFlashSaleSuccess(ctx context.Context, event
 	// 10. 设置幂等性标记（7天过期）
 	fsc.redisClient.SetEX(ctx, idempotentKey, "1", 7*24*time.Hour)
 
-	log.Infof("秒杀成功事件处理完成: userID=%d, userCouponID=%d, couponSn=%s", 
+	log.InfofC(ctx, "秒杀成功事件处理完成: userID=%d, userCouponID=%d, couponSn=%s", 
 		event.UserID, userCouponDO.ID, event.CouponSn)
 	
 	return nil
@@ -311,17 +317,17 @@ func (fsc *FlashSaleConsumer) isDuplicateError(err error) bool {
 func (fsc *FlashSaleConsumer) rollbackStockIfNeeded(ctx context.Context, event *FlashSaleSuccessEvent) {
 	// 简化处理：直接回滚库存
 	// 在实际项目中，这里应该检查用户优惠券是否真的已存在
-	log.Warnf("检测到重复创建错误，直接进行库存回滚: userID=%d, couponSn=%s", 
+	log.WarnfC(ctx, "检测到重复创建错误，直接进行库存回滚: userID=%d, couponSn=%s", 
 		event.UserID, event.CouponSn)
 		
 	if true { // 简化处理
 		// 如果确实不存在，说明可能需要回滚库存
-		log.Warnf("用户优惠券不存在但创建失败，回滚库存: userID=%d, couponSn=%s", 
+		log.WarnfC(ctx, "用户优惠券不存在但创建失败，回滚库存: userID=%d, couponSn=%s", 
 			event.UserID, event.CouponSn)
 		
 		err := fsc.stockManager.RollbackStock(ctx, event.ActivityID, event.UserID, event.CouponID, 1)
 		if err != nil {
-			log.Errorf("回滚库存失败: %v", err)
+			log.ErrorfC(ctx, "回滚库存失败: %v", err)
 		}
 	}
 }
@@ -340,11 +346,11 @@ func (fsc *FlashSaleConsumer) updateFlashSaleStats(ctx context.Context, tx *gorm
 
 // ConsumeFlashSaleFailureMessage 消费秒杀失败消息（可选）
 func (fsc *FlashSaleConsumer) ConsumeFlashSaleFailureMessage(ctx context.Context, msgs ...*primitive.MessageExt) (consumer.ConsumeResult, error) {
-	log.Infof("收到秒杀失败消息，消息数量: %d", len(msgs))
+	log.InfofC(ctx, "收到秒杀失败消息，消息数量: %d", len(msgs))
 
 	// 这里可以处理秒杀失败的统计和监控
 	for _, msg := range msgs {
-		log.Infof("秒杀失败消息: msgID=%s, body=%s", msg.MsgId, string(msg.Body))
+		log.InfofC(ctx, "秒杀失败消息: msgID=%s, body=%s", msg.MsgId, string(msg.Body))
 	}
 
 	return consumer.ConsumeSuccess, nil
@@ -413,6 +419,7 @@ func NewFlashSaleEventProducer(nameServers []string, groupName, topic string) (F
 
 	log.Infof("RocketMQ Producer启动成功, nameServers: %v, group: %s, topic: %s", 
 		nameServers, groupName, topic)
+	health.Register("rocketmq", health.TCPCheck(nameServers...))
 
 	return &flashSaleEventProducer{
 		producer: p,
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
+	"emshop/pkg/dlq"
 	"emshop/pkg/log"
 
 	"github.com/apache/rocketmq-client-go/v2/primitive"
@@ -20,8 +22,12 @@ type RetryManager struct {
 	redisClient *redisClient.Client
 	topic       string
 	maxRetries  int
+	recorder    *dlq.Recorder
 }
 
+// retryProperties 延迟重试附加的属性，写入死信时去掉
+var retryProperties = []string{"retry_count", "retry_reason", "retry_timestamp", "original_msg_id"}
+
 // RetryConfig 重试配置
 type RetryConfig struct {
 	MaxRetries    int           `json:"max_retries"`
@@ -46,19 +52,6 @@ type RetryRecord struct {
 	Status        string            `json:"status"` // "retrying", "failed", "dead_letter"
 }
 
-// MessageFailureRecord 消息失败记录
-type MessageFailureRecord struct {
-	MessageID      string    `json:"message_id"`
-	Topic          string    `json:"topic"`
-	Tag            string    `json:"tag"`
-	Body           string    `json:"body"`
-	FailureReason  string    `json:"failure_reason"`
-	FailureTime    time.Time `json:"failure_time"`
-	RetryAttempts  int       `json:"retry_attempts"`
-	LastRetryTime  time.Time `json:"last_retry_time"`
-	IsDeadLetter   bool      `json:"is_dead_letter"`
-}
-
 // NewRetryManager 创建重试管理器
 func NewRetryManager(nameServers []string, groupName, topic string, redisClient *redisClient.Client, maxRetries int) (*RetryManager, error) {
 	// 创建RocketMQ Producer
@@ -84,6 +77,11 @@ func NewRetryManager(nameServers []string, groupName, topic string, redisClient
 	}, nil
 }
 
+// SetDeadLetter 设置超过重试次数后写入的死信记录器
+func (rm *RetryManager) SetDeadLetter(recorder *dlq.Recorder) {
+	rm.recorder = recorder
+}
+
 // ScheduleRetry 调度重试
 func (rm *RetryManager) ScheduleRetry(ctx context.Context, originalMsg *primitive.MessageExt, err error, config *RetryConfig) error {
 	// 获取当前重试次数
@@ -93,8 +91,8 @@ func (rm *RetryManager) ScheduleRetry(ctx context.Context, originalMsg *primitiv
 		fmt.Sscanf(retryCountStr, "%d", &retryCount)
 	}
 
-	// 检查是否超过最大重试次数
-	if retryCount >= config.MaxRetries {
+	// 超过最大重试次数或错误不可重试时进入死信
+	if retryCount >= config.MaxRetries || dlq.IsPermanent(err) {
 		return rm.sendToDeadLetterQueue(ctx, originalMsg, err)
 	}
 
@@ -119,7 +117,7 @@ func (rm *RetryManager) ScheduleRetry(ctx context.Context, originalMsg *primitiv
 
 	// 保存重试记录到Redis
 	if err := rm.saveRetryRecord(ctx, retryRecord); err != nil {
-		log.Errorf("保存重试记录失败: %v", err)
+		log.ErrorfC(ctx, "保存重试记录失败: %v", err)
 	}
 
 	// 构建重试消息
@@ -140,18 +138,22 @@ func (rm *RetryManager) ScheduleRetry(ctx context.Context, originalMsg *primitiv
 		retryMsg.WithProperty(key, value)
 	}
 	retryMsg.WithProperty("retry_count", fmt.Sprintf("%d", retryRecord.RetryCount))
-	retryMsg.WithProperty("original_msg_id", originalMsg.MsgId)
+	originalID := originalMsg.GetProperty("original_msg_id")
+	if originalID == "" {
+		originalID = originalMsg.MsgId
+	}
+	retryMsg.WithProperty("original_msg_id", originalID)
 	retryMsg.WithProperty("retry_reason", err.Error())
 	retryMsg.WithProperty("retry_timestamp", fmt.Sprintf("%d", time.Now().Unix()))
 
 	// 发送延迟消息
 	result, err := rm.producer.SendSync(ctx, retryMsg)
 	if err != nil {
-		log.Errorf("发送重试消息失败: %v", err)
+		log.ErrorfC(ctx, "发送重试消息失败: %v", err)
 		return err
 	}
 
-	log.Infof("消息重试调度成功: originalMsgID=%s, retryCount=%d, delayLevel=%d, newMsgID=%s",
+	log.InfofC(ctx, "消息重试调度成功: originalMsgID=%s, retryCount=%d, delayLevel=%d, newMsgID=%s",
 		originalMsg.MsgId, retryRecord.RetryCount, delayLevel, result.MsgID)
 
 	return nil
@@ -223,55 +225,31 @@ func (rm *RetryManager) calculateDelayLevel(retryCount int, config *RetryConfig)
 	return len(delayLevels)
 }
 
-// sendToDeadLetterQueue 发送到死信队列
+// sendToDeadLetterQueue 写入共享死信存储，由管理端查看与重放
 func (rm *RetryManager) sendToDeadLetterQueue(ctx context.Context, originalMsg *primitive.MessageExt, err error) error {
-	// 创建死信队列消息
-	deadLetterMsg := &primitive.Message{
-		Topic: rm.topic + "_DLQ", // 死信队列主题
-		Body:  originalMsg.Body,
-	}
-	deadLetterMsg.WithTag("DEAD_LETTER")
-	// GetKeys() 返回字符串，需要转换为字符串数组
-	keys := originalMsg.GetKeys()
-	if keys != "" {
-		deadLetterMsg.WithKeys([]string{keys})
+	if rm.recorder == nil {
+		return fmt.Errorf("未配置死信存储: %w", err)
 	}
 
-	// 复制原消息的属性
-	for key, value := range originalMsg.GetProperties() {
-		deadLetterMsg.WithProperty(key, value)
-	}
-	deadLetterMsg.WithProperty("original_msg_id", originalMsg.MsgId)
-	deadLetterMsg.WithProperty("dead_letter_reason", err.Error())
-	deadLetterMsg.WithProperty("dead_letter_timestamp", fmt.Sprintf("%d", time.Now().Unix()))
-	deadLetterMsg.WithProperty("original_topic", originalMsg.Topic)
-
-	// 发送死信消息
-	result, sendErr := rm.producer.SendSync(ctx, deadLetterMsg)
-	if sendErr != nil {
-		log.Errorf("发送死信消息失败: %v", sendErr)
-		return sendErr
+	record := dlq.NewMessage("", originalMsg, err)
+	// 延迟重试消息使用原始消息ID，重放时重新计数
+	if originalMsg.GetProperty(dlq.PropertyReplayOf) == "" {
+		if originalID := originalMsg.GetProperty("original_msg_id"); originalID != "" {
+			record.ID = originalID
+		}
 	}
-
-	// 记录失败记录
-	failureRecord := &MessageFailureRecord{
-		MessageID:     originalMsg.MsgId,
-		Topic:         originalMsg.Topic,
-		Tag:           originalMsg.GetTags(),
-		Body:          string(originalMsg.Body),
-		FailureReason: err.Error(),
-		FailureTime:   time.Now(),
-		IsDeadLetter:  true,
+	if retryCount, convErr := strconv.Atoi(originalMsg.GetProperty("retry_count")); convErr == nil {
+		record.ReconsumeTimes = int32(retryCount)
 	}
-
-	if err := rm.saveFailureRecord(ctx, failureRecord); err != nil {
-		log.Errorf("保存失败记录失败: %v", err)
+	for _, key := range retryProperties {
+		delete(record.Properties, key)
 	}
+	return rm.recorder.Save(ctx, record)
+}
 
-	log.Errorf("消息发送到死信队列: originalMsgID=%s, deadLetterMsgID=%s, reason=%s",
-		originalMsg.MsgId, result.MsgID, err.Error())
-
-	return nil
+// DeadLetter 不可重试的消息直接进入死信
+func (rm *RetryManager) DeadLetter(ctx context.Context, originalMsg *primitive.MessageExt, err error) error {
+	return rm.sendToDeadLetterQueue(ctx, originalMsg, err)
 }
 
 // saveRetryRecord 保存重试记录到Redis
@@ -286,18 +264,6 @@ func (rm *RetryManager) saveRetryRecord(ctx context.Context, record *RetryRecord
 	return rm.redisClient.SetEX(ctx, key, data, 24*time.Hour).Err()
 }
 
-// saveFailureRecord 保存失败记录到Redis
-func (rm *RetryManager) saveFailureRecord(ctx context.Context, record *MessageFailureRecord) error {
-	key := fmt.Sprintf("failure:record:%s", record.MessageID)
-	data, err := json.Marshal(record)
-	if err != nil {
-		return err
-	}
-
-	// 保存7天
-	return rm.redisClient.SetEX(ctx, key, data, 7*24*time.Hour).Err()
-}
-
 // GetRetryRecord 获取重试记录
 func (rm *RetryManager) GetRetryRecord(ctx context.Context, messageID string) (*RetryRecord, error) {
 	key := fmt.Sprintf("retry:record:%s", messageID)
@@ -314,22 +280,6 @@ func (rm *RetryManager) GetRetryRecord(ctx context.Context, messageID string) (*
 	return &record, nil
 }
 
-// GetFailureRecord 获取失败记录
-func (rm *RetryManager) GetFailureRecord(ctx context.Context, messageID string) (*MessageFailureRecord, error) {
-	key := fmt.Sprintf("failure:record:%s", messageID)
-	data := rm.redisClient.Get(ctx, key).Val()
-	if data == "" {
-		return nil, fmt.Errorf("失败记录不存在: %s", messageID)
-	}
-
-	var record MessageFailureRecord
-	if err := json.Unmarshal([]byte(data), &record); err != nil {
-		return nil, err
-	}
-
-	return &record, nil
-}
-
 // ListRetryRecords 列出重试记录
 func (rm *RetryManager) ListRetryRecords(ctx context.Context, limit int) ([]*RetryRecord, error) {
 	keys, err := rm.redisClient.Keys(ctx, "retry:record:*").Result()
@@ -350,37 +300,7 @@ func (rm *RetryManager) ListRetryRecords(ctx context.Context, limit int) ([]*Ret
 
 		var record RetryRecord
 		if err := json.Unmarshal([]byte(data), &record); err != nil {
-			log.Errorf("解析重试记录失败: %v", err)
-			continue
-		}
-
-		records = append(records, &record)
-	}
-
-	return records, nil
-}
-
-// ListFailureRecords 列出失败记录
-func (rm *RetryManager) ListFailureRecords(ctx context.Context, limit int) ([]*MessageFailureRecord, error) {
-	keys, err := rm.redisClient.Keys(ctx, "failure:record:*").Result()
-	if err != nil {
-		return nil, err
-	}
-
-	var records []*MessageFailureRecord
-	for i, key := range keys {
-		if limit > 0 && i >= limit {
-			break
-		}
-
-		data := rm.redisClient.Get(ctx, key).Val()
-		if data == "" {
-			continue
-		}
-
-		var record MessageFailureRecord
-		if err := json.Unmarshal([]byte(data), &record); err != nil {
-			log.Errorf("解析失败记录失败: %v", err)
+			log.ErrorfC(ctx, "解析重试记录失败: %v", err)
 			continue
 		}
 
@@ -405,20 +325,7 @@ func (rm *RetryManager) CleanupExpiredRecords(ctx context.Context) error {
 		}
 	}
 
-	// 清理过期的失败记录
-	failureKeys, err := rm.redisClient.Keys(ctx, "failure:record:*").Result()
-	if err != nil {
-		return err
-	}
-
-	for _, key := range failureKeys {
-		ttl := rm.redisClient.TTL(ctx, key).Val()
-		if ttl < 0 { // 已过期但未被删除
-			rm.redisClient.Del(ctx, key)
-		}
-	}
-
-	log.Infof("清理过期记录完成: retry=%d, failure=%d", len(retryKeys), len(failureKeys))
+	log.InfofC(ctx, "清理过期记录完成: retry=%d", len(retryKeys))
 	return nil
 }
 
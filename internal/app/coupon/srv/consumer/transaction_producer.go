@@ -112,12 +112,12 @@ func (tp *TransactionProducer) SendTransactionMessage(ctx context.Context, event
 	// 发送事务消息
 	result, err := tp.producer.SendMessageInTransaction(ctx, msg)
 	if err != nil {
-		log.Errorf("发送事务消息失败: %v, userID=%d, activityID=%d", 
+		log.ErrorfC(ctx, "发送事务消息失败: %v, userID=%d, activityID=%d", 
 			err, event.UserID, event.ActivityID)
 		return fmt.Errorf("发送事务消息失败: %v", err)
 	}
 
-	log.Infof("发送事务消息成功: userID=%d, activityID=%d, msgID=%s, txnID=%s",
+	log.InfofC(ctx, "发送事务消息成功: userID=%d, activityID=%d, msgID=%s, txnID=%s",
 		event.UserID, event.ActivityID, result.MsgID, result.TransactionID)
 
 	return nil
@@ -165,17 +165,17 @@ func (tp *TransactionProducer) executeCreateUserCouponTransaction(ctx context.Co
 	idempotentKey := fmt.Sprintf("txn:executed:%s", txnID)
 	exists, err := tp.redisClient.Exists(ctx, idempotentKey).Result()
 	if err != nil {
-		log.Errorf("检查事务幂等性失败: %v, txnID=%s", err, txnID)
+		log.ErrorfC(ctx, "检查事务幂等性失败: %v, txnID=%s", err, txnID)
 		return primitive.UnknowState
 	}
 	if exists > 0 {
 		// 事务已执行过，检查结果
 		result := tp.redisClient.Get(ctx, idempotentKey).Val()
 		if result == "committed" {
-			log.Infof("事务已提交: txnID=%s", txnID)
+			log.InfofC(ctx, "事务已提交: txnID=%s", txnID)
 			return primitive.CommitMessageState
 		} else {
-			log.Infof("事务已回滚: txnID=%s", txnID)
+			log.InfofC(ctx, "事务已回滚: txnID=%s", txnID)
 			return primitive.RollbackMessageState
 		}
 	}
@@ -183,24 +183,24 @@ func (tp *TransactionProducer) executeCreateUserCouponTransaction(ctx context.Co
 	// 2. 获取活动和优惠券模板信息
 	activityDO, err := tp.data.FlashSales().Get(ctx, tp.data.DB(), txnContext.ActivityID)
 	if err != nil {
-		log.Errorf("获取活动信息失败: %v, txnID=%s", err, txnID)
+		log.ErrorfC(ctx, "获取活动信息失败: %v, txnID=%s", err, txnID)
 		tp.redisClient.SetEX(ctx, idempotentKey, "rollback", time.Hour)
 		return primitive.RollbackMessageState
 	}
 	if activityDO == nil {
-		log.Errorf("活动不存在: activityID=%d, txnID=%s", txnContext.ActivityID, txnID)
+		log.ErrorfC(ctx, "活动不存在: activityID=%d, txnID=%s", txnContext.ActivityID, txnID)
 		tp.redisClient.SetEX(ctx, idempotentKey, "rollback", time.Hour)
 		return primitive.RollbackMessageState
 	}
 
 	templateDO, err := tp.data.CouponTemplates().Get(ctx, tp.data.DB(), txnContext.CouponID)
 	if err != nil {
-		log.Errorf("获取优惠券模板失败: %v, txnID=%s", err, txnID)
+		log.ErrorfC(ctx, "获取优惠券模板失败: %v, txnID=%s", err, txnID)
 		tp.redisClient.SetEX(ctx, idempotentKey, "rollback", time.Hour)
 		return primitive.RollbackMessageState
 	}
 	if templateDO == nil {
-		log.Errorf("优惠券模板不存在: couponID=%d, txnID=%s", txnContext.CouponID, txnID)
+		log.ErrorfC(ctx, "优惠券模板不存在: couponID=%d, txnID=%s", txnContext.CouponID, txnID)
 		tp.redisClient.SetEX(ctx, idempotentKey, "rollback", time.Hour)
 		return primitive.RollbackMessageState
 	}
@@ -208,7 +208,7 @@ func (tp *TransactionProducer) executeCreateUserCouponTransaction(ctx context.Co
 	// 3. 开始数据库事务
 	tx := tp.data.DB().Begin()
 	if tx.Error != nil {
-		log.Errorf("开始数据库事务失败: %v, txnID=%s", tx.Error, txnID)
+		log.ErrorfC(ctx, "开始数据库事务失败: %v, txnID=%s", tx.Error, txnID)
 		tp.redisClient.SetEX(ctx, idempotentKey, "rollback", time.Hour)
 		return primitive.RollbackMessageState
 	}
@@ -217,7 +217,7 @@ func (tp *TransactionProducer) executeCreateUserCouponTransaction(ctx context.Co
 		if r := recover(); r != nil {
 			tx.Rollback()
 			tp.redisClient.SetEX(ctx, idempotentKey, "rollback", time.Hour)
-			log.Errorf("本地事务执行异常: %v, txnID=%s", r, txnID)
+			log.ErrorfC(ctx, "本地事务执行异常: %v, txnID=%s", r, txnID)
 		}
 	}()
 
@@ -233,7 +233,7 @@ func (tp *TransactionProducer) executeCreateUserCouponTransaction(ctx context.Co
 
 	if err := tp.data.UserCoupons().Create(ctx, tx, userCouponDO); err != nil {
 		tx.Rollback()
-		log.Errorf("创建用户优惠券失败: %v, txnID=%s", err, txnID)
+		log.ErrorfC(ctx, "创建用户优惠券失败: %v, txnID=%s", err, txnID)
 		tp.redisClient.SetEX(ctx, idempotentKey, "rollback", time.Hour)
 		return primitive.RollbackMessageState
 	}
@@ -241,14 +241,14 @@ func (tp *TransactionProducer) executeCreateUserCouponTransaction(ctx context.Co
 	// 5. 更新统计信息
 	if err := tp.updateCouponTemplateStats(ctx, tx, txnContext.CouponID); err != nil {
 		tx.Rollback()
-		log.Errorf("更新优惠券模板统计失败: %v, txnID=%s", err, txnID)
+		log.ErrorfC(ctx, "更新优惠券模板统计失败: %v, txnID=%s", err, txnID)
 		tp.redisClient.SetEX(ctx, idempotentKey, "rollback", time.Hour)
 		return primitive.RollbackMessageState
 	}
 
 	if err := tp.updateFlashSaleStats(ctx, tx, txnContext.ActivityID); err != nil {
 		tx.Rollback()
-		log.Errorf("更新活动统计失败: %v, txnID=%s", err, txnID)
+		log.ErrorfC(ctx, "更新活动统计失败: %v, txnID=%s", err, txnID)
 		tp.redisClient.SetEX(ctx, idempotentKey, "rollback", time.Hour)
 		return primitive.RollbackMessageState
 	}
@@ -256,7 +256,7 @@ func (tp *TransactionProducer) executeCreateUserCouponTransaction(ctx context.Co
 	// 6. 提交数据库事务
 	if err := tx.Commit(); err != nil {
 		tx.Rollback()
-		log.Errorf("提交数据库事务失败: %v, txnID=%s", err, txnID)
+		log.ErrorfC(ctx, "提交数据库事务失败: %v, txnID=%s", err, txnID)
 		tp.redisClient.SetEX(ctx, idempotentKey, "rollback", time.Hour)
 		return primitive.RollbackMessageState
 	}
@@ -264,7 +264,7 @@ func (tp *TransactionProducer) executeCreateUserCouponTransaction(ctx context.Co
 	// 7. 标记事务成功
 	tp.redisClient.SetEX(ctx, idempotentKey, "committed", time.Hour)
 
-	log.Infof("本地事务执行成功: userID=%d, activityID=%d, userCouponID=%d, txnID=%s",
+	log.InfofC(ctx, "本地事务执行成功: userID=%d, activityID=%d, userCouponID=%d, txnID=%s",
 		txnContext.UserID, txnContext.ActivityID, userCouponDO.ID, txnID)
 
 	return primitive.CommitMessageState
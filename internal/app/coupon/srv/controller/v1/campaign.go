@@ -0,0 +1,153 @@
+package v1
+
+import (
+	"context"
+
+	couponpb "emshop/api/coupon/v1"
+	"emshop/internal/app/coupon/srv/domain/dto"
+	"emshop/pkg/log"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// CreateCouponCampaign 创建定向发券活动
+func (cs *couponServer) CreateCouponCampaign(ctx context.Context, req *couponpb.CreateCouponCampaignRequest) (*couponpb.CouponCampaignResponse, error) {
+	log.InfofC(ctx, "CreateCouponCampaign: name=%s, templateID=%d, segment=%s", req.Name, req.CouponTemplateId, req.SegmentType)
+
+	result, err := cs.srv.CampaignSrv.Create(ctx, &dto.CreateCouponCampaignDTO{
+		Name:                 req.Name,
+		CouponTemplateID:     req.CouponTemplateId,
+		SegmentType:          req.SegmentType,
+		SegmentParams:        req.SegmentParams,
+		BatchSize:            req.BatchSize,
+		BatchIntervalSeconds: req.BatchIntervalSeconds,
+		Operator:             req.Operator,
+	})
+	if err != nil {
+		return nil, cs.handleError(err)
+	}
+	return cs.convertCampaignToProto(result), nil
+}
+
+// AddCouponCampaignTargets 追加活动目标用户
+func (cs *couponServer) AddCouponCampaignTargets(ctx context.Context, req *couponpb.AddCouponCampaignTargetsRequest) (*couponpb.AddCouponCampaignTargetsResponse, error) {
+	log.InfofC(ctx, "AddCouponCampaignTargets: campaignID=%d, users=%d", req.CampaignId, len(req.UserIds))
+
+	added, err := cs.srv.CampaignSrv.AddTargets(ctx, &dto.AddCampaignTargetsDTO{
+		CampaignID: req.CampaignId,
+		UserIDs:    req.UserIds,
+	})
+	if err != nil {
+		return nil, cs.handleError(err)
+	}
+	return &couponpb.AddCouponCampaignTargetsResponse{Added: added}, nil
+}
+
+// GetCouponCampaign 查询活动及发放进度
+func (cs *couponServer) GetCouponCampaign(ctx context.Context, req *couponpb.CouponCampaignIdRequest) (*couponpb.CouponCampaignResponse, error) {
+	result, err := cs.srv.CampaignSrv.Get(ctx, req.Id)
+	if err != nil {
+		return nil, cs.handleError(err)
+	}
+	return cs.convertCampaignToProto(result), nil
+}
+
+// ListCouponCampaigns 分页查询活动
+func (cs *couponServer) ListCouponCampaigns(ctx context.Context, req *couponpb.ListCouponCampaignsRequest) (*couponpb.ListCouponCampaignsResponse, error) {
+	result, err := cs.srv.CampaignSrv.List(ctx, &dto.ListCouponCampaignsDTO{
+		Status:   req.Status,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	})
+	if err != nil {
+		return nil, cs.handleError(err)
+	}
+
+	items := make([]*couponpb.CouponCampaignResponse, 0, len(result.Items))
+	for _, item := range result.Items {
+		items = append(items, cs.convertCampaignToProto(item))
+	}
+	return &couponpb.ListCouponCampaignsResponse{
+		TotalCount: result.TotalCount,
+		Items:      items,
+	}, nil
+}
+
+// StartCouponCampaign 开始或恢复发放
+func (cs *couponServer) StartCouponCampaign(ctx context.Context, req *couponpb.CouponCampaignIdRequest) (*emptypb.Empty, error) {
+	log.InfofC(ctx, "StartCouponCampaign: id=%d", req.Id)
+
+	if err := cs.srv.CampaignSrv.Start(ctx, req.Id); err != nil {
+		return nil, cs.handleError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// PauseCouponCampaign 暂停发放
+func (cs *couponServer) PauseCouponCampaign(ctx context.Context, req *couponpb.CouponCampaignIdRequest) (*emptypb.Empty, error) {
+	log.InfofC(ctx, "PauseCouponCampaign: id=%d", req.Id)
+
+	if err := cs.srv.CampaignSrv.Pause(ctx, req.Id); err != nil {
+		return nil, cs.handleError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// CancelCouponCampaign 取消活动
+func (cs *couponServer) CancelCouponCampaign(ctx context.Context, req *couponpb.CouponCampaignIdRequest) (*emptypb.Empty, error) {
+	log.InfofC(ctx, "CancelCouponCampaign: id=%d", req.Id)
+
+	if err := cs.srv.CampaignSrv.Cancel(ctx, req.Id); err != nil {
+		return nil, cs.handleError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// RerunCouponCampaign 重新发放失败的目标
+func (cs *couponServer) RerunCouponCampaign(ctx context.Context, req *couponpb.CouponCampaignIdRequest) (*couponpb.RerunCouponCampaignResponse, error) {
+	log.InfofC(ctx, "RerunCouponCampaign: id=%d", req.Id)
+
+	reset, err := cs.srv.CampaignSrv.Rerun(ctx, req.Id)
+	if err != nil {
+		return nil, cs.handleError(err)
+	}
+	return &couponpb.RerunCouponCampaignResponse{ResetCount: reset}, nil
+}
+
+// DeleteCouponCampaign 删除草稿活动及其目标用户
+func (cs *couponServer) DeleteCouponCampaign(ctx context.Context, req *couponpb.CouponCampaignIdRequest) (*emptypb.Empty, error) {
+	log.InfofC(ctx, "DeleteCouponCampaign: id=%d", req.Id)
+
+	if err := cs.srv.CampaignSrv.Delete(ctx, req.Id); err != nil {
+		return nil, cs.handleError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (cs *couponServer) convertCampaignToProto(dto *dto.CouponCampaignDTO) *couponpb.CouponCampaignResponse {
+	resp := &couponpb.CouponCampaignResponse{
+		Id:                   dto.ID,
+		Name:                 dto.Name,
+		CouponTemplateId:     dto.CouponTemplateID,
+		SegmentType:          dto.SegmentType,
+		SegmentParams:        dto.SegmentParams,
+		Status:               dto.Status,
+		BatchSize:            dto.BatchSize,
+		BatchIntervalSeconds: dto.BatchIntervalSeconds,
+		TotalTargets:         dto.TotalTargets,
+		PendingCount:         dto.PendingCount,
+		DispatchedCount:      dto.DispatchedCount,
+		IssuedCount:          dto.IssuedCount,
+		SkippedCount:         dto.SkippedCount,
+		FailedCount:          dto.FailedCount,
+		Operator:             dto.Operator,
+		CreatedAt:            dto.CreatedAt.Unix(),
+	}
+	if dto.StartedAt != nil {
+		resp.StartedAt = dto.StartedAt.Unix()
+	}
+	if dto.FinishedAt != nil {
+		resp.FinishedAt = dto.FinishedAt.Unix()
+	}
+	return resp
+}
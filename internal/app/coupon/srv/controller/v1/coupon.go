@@ -5,6 +5,7 @@ import (
 	"time"
 
 	couponpb "emshop/api/coupon/v1"
+	"emshop/internal/app/coupon/srv/domain/do"
 	"emshop/internal/app/coupon/srv/domain/dto"
 	v1 "emshop/internal/app/coupon/srv/service/v1"
 	"emshop/internal/app/pkg/code"
@@ -30,7 +31,7 @@ func NewCouponServer(srv *v1.Service) couponpb.CouponServer {
 
 // CreateCouponTemplate 创建优惠券模板
 func (cs *couponServer) CreateCouponTemplate(ctx context.Context, req *couponpb.CreateCouponTemplateRequest) (*couponpb.CouponTemplateResponse, error) {
-	log.Infof("CreateCouponTemplate: %s", req.Name)
+	log.InfofC(ctx, "CreateCouponTemplate: %s", req.Name)
 
 	// 转换请求
 	dto := &dto.CreateCouponTemplateDTO{
@@ -120,7 +121,18 @@ func (cs *couponServer) ListCouponTemplates(ctx context.Context, req *couponpb.L
 
 // ReceiveCoupon 领取优惠券
 func (cs *couponServer) ReceiveCoupon(ctx context.Context, req *couponpb.ReceiveCouponRequest) (*couponpb.UserCouponResponse, error) {
-	log.Infof("ReceiveCoupon: userID=%d, templateID=%d", req.UserId, req.CouponTemplateId)
+	log.InfofC(ctx, "ReceiveCoupon: userID=%d, templateID=%d", req.UserId, req.CouponTemplateId)
+
+	if cs.srv.RiskSrv != nil {
+		if err := cs.srv.RiskSrv.Check(ctx, &dto.RiskEvaluateDTO{
+			Scene:    do.RiskSceneReceiveCoupon,
+			UserID:   req.UserId,
+			TargetID: req.CouponTemplateId,
+			Context:  riskContextFromProto(req.Risk),
+		}); err != nil {
+			return nil, cs.handleError(err)
+		}
+	}
 
 	dto := &dto.ReceiveCouponDTO{
 		UserID:           req.UserId,
@@ -188,6 +200,39 @@ func (cs *couponServer) GetAvailableCoupons(ctx context.Context, req *couponpb.G
 	}, nil
 }
 
+// ListCouponReminders 获取用户优惠券即将过期提醒
+func (cs *couponServer) ListCouponReminders(ctx context.Context, req *couponpb.ListCouponRemindersRequest) (*couponpb.ListCouponRemindersResponse, error) {
+	dto := &dto.ListCouponRemindersDTO{
+		UserID:   req.UserId,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+
+	result, err := cs.srv.ExpirySrv.ListCouponReminders(ctx, dto)
+	if err != nil {
+		return nil, cs.handleError(err)
+	}
+
+	items := make([]*couponpb.CouponReminderResponse, 0, len(result.Items))
+	for _, item := range result.Items {
+		items = append(items, &couponpb.CouponReminderResponse{
+			Id:               item.ID,
+			UserCouponId:     item.UserCouponID,
+			CouponTemplateId: item.CouponTemplateID,
+			CouponName:       item.CouponName,
+			CouponCode:       item.CouponCode,
+			ExpiredAt:        item.ExpiredAt.Unix(),
+			RemainingDays:    item.RemainingDays,
+			CreatedAt:        item.CreatedAt.Unix(),
+		})
+	}
+
+	return &couponpb.ListCouponRemindersResponse{
+		TotalCount: result.TotalCount,
+		Items:      items,
+	}, nil
+}
+
 // CalculateCouponDiscount 计算优惠券折扣
 func (cs *couponServer) CalculateCouponDiscount(ctx context.Context, req *couponpb.CalculateCouponDiscountRequest) (*couponpb.CalculateCouponDiscountResponse, error) {
 	// 转换订单项
@@ -232,7 +277,7 @@ func (cs *couponServer) CalculateCouponDiscount(ctx context.Context, req *coupon
 
 // UseCoupons 使用优惠券
 func (cs *couponServer) UseCoupons(ctx context.Context, req *couponpb.UseCouponsRequest) (*couponpb.UseCouponsResponse, error) {
-	log.Infof("UseCoupons: orderSn=%s, userID=%d", req.OrderSn, req.UserId)
+	log.InfofC(ctx, "UseCoupons: orderSn=%s, userID=%d", req.OrderSn, req.UserId)
 
 	dto := &dto.UseCouponsDTO{
 		UserID:      req.UserId,
@@ -254,7 +299,7 @@ func (cs *couponServer) UseCoupons(ctx context.Context, req *couponpb.UseCoupons
 
 // ReleaseCoupons 释放优惠券
 func (cs *couponServer) ReleaseCoupons(ctx context.Context, req *couponpb.ReleaseCouponsRequest) (*emptypb.Empty, error) {
-	log.Infof("ReleaseCoupons: orderSn=%s", req.OrderSn)
+	log.InfofC(ctx, "ReleaseCoupons: orderSn=%s", req.OrderSn)
 
 	dto := &dto.ReleaseCouponsDTO{
 		OrderSn: req.OrderSn,
@@ -337,6 +382,46 @@ func (cs *couponServer) handleError(err error) error {
 	if errors.IsCode(err, code.ErrResourceLimitExceeded) {
 		return status.Error(codes.ResourceExhausted, err.Error())
 	}
+	if errors.IsCode(err, code.ErrPromoCodeInvalid) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if errors.IsCode(err, code.ErrPromoCodeExhausted) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.IsCode(err, code.ErrPromoCodeRedeemed) {
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+	if errors.IsCode(err, code.ErrPromoCodeRateLimited) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	if errors.IsCode(err, code.ErrCampaignStateConflict) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.IsCode(err, code.ErrFlashSaleAdmissionRequired) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	if errors.IsCode(err, code.ErrFlashSaleQueueFull) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	if errors.IsCode(err, code.ErrRiskCaptchaRequired) {
+		// 网关据此提示用户完成验证码后重试
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	if errors.IsCode(err, code.ErrRiskBlocked) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	if errors.IsCode(err, code.ErrFlashSaleNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if errors.IsCode(err, code.ErrFlashSaleReservationNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	if errors.IsCode(err, code.ErrFlashSaleNotActive) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.IsCode(err, code.ErrFlashSaleStockEmpty) || errors.IsCode(err, code.ErrFlashSaleUserLimitExceeded) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
 	if errors.IsCode(err, code.ErrDatabase) {
 		return status.Errorf(codes.Internal, "数据库错误")
 	}
@@ -0,0 +1,74 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	couponpb "emshop/api/coupon/v1"
+	"emshop/internal/app/coupon/srv/domain/dto"
+	"emshop/internal/app/coupon/srv/pkg/calculator"
+	"emshop/internal/app/pkg/code"
+	"emshop/pkg/errors"
+	"emshop/pkg/log"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GetCouponRuleConfig 获取当前生效的业务规则参数
+func (cs *couponServer) GetCouponRuleConfig(ctx context.Context, _ *emptypb.Empty) (*couponpb.CouponRuleConfigResponse, error) {
+	return cs.ruleConfigResponse(cs.srv.RuleSrv.GetRuleSet(ctx))
+}
+
+// UpdateCouponRuleConfig 更新业务规则参数
+func (cs *couponServer) UpdateCouponRuleConfig(ctx context.Context, req *couponpb.UpdateCouponRuleConfigRequest) (*couponpb.CouponRuleConfigResponse, error) {
+	log.InfoC(ctx, "UpdateCouponRuleConfig")
+
+	ruleSet, err := calculator.DecodeRuleSet([]byte(req.Rules))
+	if err != nil {
+		return nil, cs.handleError(errors.WithCode(code.ErrInvalidRequest, "%s", err.Error()))
+	}
+	if err := cs.srv.RuleSrv.UpdateRuleSet(ctx, ruleSet); err != nil {
+		return nil, cs.handleError(err)
+	}
+	return cs.ruleConfigResponse(ruleSet)
+}
+
+func (cs *couponServer) ruleConfigResponse(ruleSet *calculator.RuleSet) (*couponpb.CouponRuleConfigResponse, error) {
+	raw, err := json.Marshal(ruleSet)
+	if err != nil {
+		return nil, cs.handleError(err)
+	}
+	return &couponpb.CouponRuleConfigResponse{Rules: string(raw)}, nil
+}
+
+// DryRunCouponRules 使用候选规则参数试算样例订单
+func (cs *couponServer) DryRunCouponRules(ctx context.Context, req *couponpb.DryRunCouponRulesRequest) (*couponpb.DryRunCouponRulesResponse, error) {
+	dto := &dto.DryRunCouponRulesDTO{
+		Rules:             req.Rules,
+		UserID:            req.UserId,
+		OrderAmount:       req.OrderAmount,
+		CouponTemplateIDs: req.CouponTemplateIds,
+	}
+	if req.At > 0 {
+		dto.At = time.Unix(req.At, 0)
+	}
+
+	result, err := cs.srv.RuleSrv.DryRun(ctx, dto)
+	if err != nil {
+		return nil, cs.handleError(err)
+	}
+
+	results := make([]*couponpb.CouponRuleResult, 0, len(result.Results))
+	for _, r := range result.Results {
+		results = append(results, &couponpb.CouponRuleResult{
+			Rule:   r.Rule,
+			Passed: r.Passed,
+			Reason: r.Reason,
+		})
+	}
+	return &couponpb.DryRunCouponRulesResponse{
+		Passed:  result.Passed,
+		Results: results,
+	}, nil
+}
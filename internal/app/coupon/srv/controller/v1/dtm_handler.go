@@ -13,7 +13,7 @@ import (
 
 // SubmitOrderWithCoupons 提交订单使用优惠券的分布式事务
 func (cs *couponServer) SubmitOrderWithCoupons(ctx context.Context, req *couponpb.SubmitOrderWithCouponsRequest) (*couponpb.SubmitOrderWithCouponsResponse, error) {
-	log.Infof("SubmitOrderWithCoupons: 订单=%s, 用户=%d, 优惠券数量=%d", req.OrderSn, req.UserId, len(req.CouponIds))
+	log.InfofC(ctx, "SubmitOrderWithCoupons: 订单=%s, 用户=%d, 优惠券数量=%d", req.OrderSn, req.UserId, len(req.CouponIds))
 
 	// 转换请求
 	dtmReq := &v1.OrderCouponSubmissionRequest{
@@ -39,7 +39,7 @@ func (cs *couponServer) SubmitOrderWithCoupons(ctx context.Context, req *couponp
 	// 调用DTM管理器
 	err := cs.srv.DTMManager.SubmitOrderWithCoupons(ctx, dtmReq)
 	if err != nil {
-		log.Errorf("分布式事务失败: %v", err)
+		log.ErrorfC(ctx, "分布式事务失败: %v", err)
 		return nil, cs.handleError(err)
 	}
 
@@ -51,7 +51,7 @@ func (cs *couponServer) SubmitOrderWithCoupons(ctx context.Context, req *couponp
 
 // ProcessFlashSaleWithInventory 秒杀优惠券与库存协调的分布式事务
 func (cs *couponServer) ProcessFlashSaleWithInventory(ctx context.Context, req *couponpb.ProcessFlashSaleWithInventoryRequest) (*couponpb.ProcessFlashSaleWithInventoryResponse, error) {
-	log.Infof("ProcessFlashSaleWithInventory: 用户=%d, 秒杀ID=%d, 商品ID=%d", req.UserId, req.FlashSaleId, req.GoodsId)
+	log.InfofC(ctx, "ProcessFlashSaleWithInventory: 用户=%d, 秒杀ID=%d, 商品ID=%d", req.UserId, req.FlashSaleId, req.GoodsId)
 
 	// 转换请求
 	dtmReq := &v1.FlashSaleInventoryRequest{
@@ -64,7 +64,7 @@ func (cs *couponServer) ProcessFlashSaleWithInventory(ctx context.Context, req *
 	// 调用DTM管理器
 	err := cs.srv.DTMManager.ProcessFlashSaleWithInventory(ctx, dtmReq)
 	if err != nil {
-		log.Errorf("秒杀分布式事务失败: %v", err)
+		log.ErrorfC(ctx, "秒杀分布式事务失败: %v", err)
 		return nil, cs.handleError(err)
 	}
 
@@ -76,7 +76,7 @@ func (cs *couponServer) ProcessFlashSaleWithInventory(ctx context.Context, req *
 
 // TryFlashSale TCC Try阶段：预占秒杀优惠券 (DTM回调)
 func (cs *couponServer) TryFlashSale(ctx context.Context, req *couponpb.ParticipateFlashSaleRequest) (*emptypb.Empty, error) {
-	log.Infof("DTM TryFlashSale: 用户=%d, 秒杀ID=%d", req.UserId, req.FlashSaleId)
+	log.InfofC(ctx, "DTM TryFlashSale: 用户=%d, 秒杀ID=%d", req.UserId, req.FlashSaleId)
 
 	dto := &dto.ParticipateFlashSaleDTO{
 		UserID:      req.UserId,
@@ -88,7 +88,7 @@ func (cs *couponServer) TryFlashSale(ctx context.Context, req *couponpb.Particip
 
 // ConfirmFlashSale TCC Confirm阶段：确认秒杀优惠券扣减 (DTM回调)
 func (cs *couponServer) ConfirmFlashSale(ctx context.Context, req *couponpb.ParticipateFlashSaleRequest) (*emptypb.Empty, error) {
-	log.Infof("DTM ConfirmFlashSale: 用户=%d, 秒杀ID=%d", req.UserId, req.FlashSaleId)
+	log.InfofC(ctx, "DTM ConfirmFlashSale: 用户=%d, 秒杀ID=%d", req.UserId, req.FlashSaleId)
 
 	dto := &dto.ParticipateFlashSaleDTO{
 		UserID:      req.UserId,
@@ -100,7 +100,7 @@ func (cs *couponServer) ConfirmFlashSale(ctx context.Context, req *couponpb.Part
 
 // CancelFlashSale TCC Cancel阶段：取消秒杀优惠券预占 (DTM回调)
 func (cs *couponServer) CancelFlashSale(ctx context.Context, req *couponpb.ParticipateFlashSaleRequest) (*emptypb.Empty, error) {
-	log.Infof("DTM CancelFlashSale: 用户=%d, 秒杀ID=%d", req.UserId, req.FlashSaleId)
+	log.InfofC(ctx, "DTM CancelFlashSale: 用户=%d, 秒杀ID=%d", req.UserId, req.FlashSaleId)
 
 	dto := &dto.ParticipateFlashSaleDTO{
 		UserID:      req.UserId,
@@ -112,7 +112,7 @@ func (cs *couponServer) CancelFlashSale(ctx context.Context, req *couponpb.Parti
 
 // GetTransactionStatus 获取分布式事务状态
 func (cs *couponServer) GetTransactionStatus(ctx context.Context, req *couponpb.GetTransactionStatusRequest) (*couponpb.GetTransactionStatusResponse, error) {
-	log.Infof("GetTransactionStatus: GID=%s", req.Gid)
+	log.InfofC(ctx, "GetTransactionStatus: GID=%s", req.Gid)
 
 	status, err := cs.srv.DTMManager.GetTransactionStatus(ctx, req.Gid)
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	couponpb "emshop/api/coupon/v1"
+	"emshop/internal/app/coupon/srv/domain/do"
 	"emshop/internal/app/coupon/srv/domain/dto"
 	"emshop/pkg/log"
 	
@@ -13,10 +14,13 @@ import (
 
 // CreateFlashSaleActivity 创建秒杀活动
 func (cs *couponServer) CreateFlashSaleActivity(ctx context.Context, req *couponpb.CreateFlashSaleActivityRequest) (*couponpb.FlashSaleActivityResponse, error) {
-	log.Infof("CreateFlashSaleActivity: %s", req.Name)
+	log.InfofC(ctx, "CreateFlashSaleActivity: %s", req.Name)
 
 	dto := &dto.CreateFlashSaleActivityDTO{
+		ActivityType:     req.ActivityType,
 		CouponTemplateID: req.CouponTemplateId,
+		GoodsID:          req.GoodsId,
+		SalePrice:        req.SalePrice,
 		Name:             req.Name,
 		StartTime:        time.Unix(req.StartTime, 0),
 		EndTime:          time.Unix(req.EndTime, 0),
@@ -91,7 +95,25 @@ func (cs *couponServer) GetActiveFlashSales(ctx context.Context, req *emptypb.Em
 
 // ParticipateFlashSale 参与秒杀
 func (cs *couponServer) ParticipateFlashSale(ctx context.Context, req *couponpb.ParticipateFlashSaleRequest) (*couponpb.ParticipateFlashSaleResponse, error) {
-	log.Infof("ParticipateFlashSale: userID=%d, flashSaleID=%d", req.UserId, req.FlashSaleId)
+	log.InfofC(ctx, "ParticipateFlashSale: userID=%d, flashSaleID=%d", req.UserId, req.FlashSaleId)
+
+	// 开启排队时只有持有效放行令牌的请求才会进入库存扣减
+	if cs.srv.WaitingRoom != nil {
+		if err := cs.srv.WaitingRoom.CheckAdmission(req.FlashSaleId, req.UserId, req.AdmissionToken); err != nil {
+			return nil, cs.handleError(err)
+		}
+	}
+
+	if cs.srv.RiskSrv != nil {
+		if err := cs.srv.RiskSrv.Check(ctx, &dto.RiskEvaluateDTO{
+			Scene:    do.RiskSceneFlashSale,
+			UserID:   req.UserId,
+			TargetID: req.FlashSaleId,
+			Context:  riskContextFromProto(req.Risk),
+		}); err != nil {
+			return nil, cs.handleError(err)
+		}
+	}
 
 	if cs.srv.AsyncFlashSaleEnabled() && cs.srv.FlashSaleCore != nil {
 		flashReq := &dto.FlashSaleRequestDTO{
@@ -140,6 +162,43 @@ func (cs *couponServer) ParticipateFlashSale(ctx context.Context, req *couponpb.
 	return resp, nil
 }
 
+// ReserveFlashSaleGoods 抢购商品秒杀名额，成功后返回预占单，网关以预占单号创建订单
+func (cs *couponServer) ReserveFlashSaleGoods(ctx context.Context, req *couponpb.ParticipateFlashSaleRequest) (*couponpb.FlashSaleReservationResponse, error) {
+	log.InfofC(ctx, "ReserveFlashSaleGoods: userID=%d, flashSaleID=%d", req.UserId, req.FlashSaleId)
+
+	if cs.srv.WaitingRoom != nil {
+		if err := cs.srv.WaitingRoom.CheckAdmission(req.FlashSaleId, req.UserId, req.AdmissionToken); err != nil {
+			return nil, cs.handleError(err)
+		}
+	}
+
+	if cs.srv.RiskSrv != nil {
+		if err := cs.srv.RiskSrv.Check(ctx, &dto.RiskEvaluateDTO{
+			Scene:    do.RiskSceneFlashSale,
+			UserID:   req.UserId,
+			TargetID: req.FlashSaleId,
+			Context:  riskContextFromProto(req.Risk),
+		}); err != nil {
+			return nil, cs.handleError(err)
+		}
+	}
+
+	result, err := cs.srv.FlashSaleGoods.Reserve(ctx, req.FlashSaleId, req.UserId)
+	if err != nil {
+		return nil, cs.handleError(err)
+	}
+	return convertReservationToProto(result), nil
+}
+
+// SettleFlashSaleReservation 结算商品秒杀预占单，订单已支付则成交，否则关闭订单并归还名额
+func (cs *couponServer) SettleFlashSaleReservation(ctx context.Context, req *couponpb.SettleFlashSaleReservationRequest) (*couponpb.FlashSaleReservationResponse, error) {
+	result, err := cs.srv.FlashSaleGoods.Settle(ctx, req.OrderSn)
+	if err != nil {
+		return nil, cs.handleError(err)
+	}
+	return convertReservationToProto(result), nil
+}
+
 // GetFlashSaleStock 获取秒杀库存
 func (cs *couponServer) GetFlashSaleStock(ctx context.Context, req *couponpb.GetFlashSaleStockRequest) (*couponpb.FlashSaleStockResponse, error) {
 	result, err := cs.srv.FlashSaleSrv.GetFlashSaleStock(ctx, req.FlashSaleId)
@@ -197,6 +256,9 @@ func (cs *couponServer) convertFlashSaleToProto(dto *dto.FlashSaleActivityDTO) *
 		PerUserLimit:     dto.PerUserLimit,
 		Status:           dto.Status,
 		CreatedAt:        dto.CreatedAt.Unix(),
+		ActivityType:     dto.ActivityType,
+		GoodsId:          dto.GoodsID,
+		SalePrice:        dto.SalePrice,
 	}
 
 	if dto.Template != nil {
@@ -231,3 +293,16 @@ func (cs *couponServer) convertFlashSaleRecordToProto(dto *dto.FlashSaleRecordDT
 
 // 注意：RocketMQ生产者已经在service层集成完毕
 // 现有的ParticipateFlashSale方法可以通过FlashSaleCore替换实现更高性能的秒杀功能
+
+func convertReservationToProto(r *dto.FlashSaleReservationDTO) *couponpb.FlashSaleReservationResponse {
+	return &couponpb.FlashSaleReservationResponse{
+		OrderSn:     r.OrderSn,
+		FlashSaleId: r.FlashSaleID,
+		UserId:      r.UserID,
+		GoodsId:     r.GoodsID,
+		Quantity:    r.Quantity,
+		SalePrice:   r.SalePrice,
+		Status:      r.Status,
+		ExpiresAt:   r.ExpiresAt.Unix(),
+	}
+}
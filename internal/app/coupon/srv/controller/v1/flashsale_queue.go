@@ -0,0 +1,39 @@
+package v1
+
+import (
+	"context"
+
+	couponpb "emshop/api/coupon/v1"
+	"emshop/internal/app/coupon/srv/domain/dto"
+)
+
+// EnterFlashSaleQueue 秒杀排队入队
+func (cs *couponServer) EnterFlashSaleQueue(ctx context.Context, req *couponpb.FlashSaleQueueRequest) (*couponpb.FlashSaleQueueStatusResponse, error) {
+	result, err := cs.srv.WaitingRoom.Enter(ctx, req.FlashSaleId, req.UserId)
+	if err != nil {
+		return nil, cs.handleError(err)
+	}
+	return convertQueueStatusToProto(result), nil
+}
+
+// GetFlashSaleQueueStatus 查询排队状态，已放行时返回放行令牌
+func (cs *couponServer) GetFlashSaleQueueStatus(ctx context.Context, req *couponpb.FlashSaleQueueRequest) (*couponpb.FlashSaleQueueStatusResponse, error) {
+	result, err := cs.srv.WaitingRoom.Status(ctx, req.FlashSaleId, req.UserId)
+	if err != nil {
+		return nil, cs.handleError(err)
+	}
+	return convertQueueStatusToProto(result), nil
+}
+
+func convertQueueStatusToProto(s *dto.FlashSaleQueueStatusDTO) *couponpb.FlashSaleQueueStatusResponse {
+	return &couponpb.FlashSaleQueueStatusResponse{
+		FlashSaleId:          s.FlashSaleID,
+		Status:               s.Status,
+		Position:             s.Position,
+		QueueLength:          s.QueueLength,
+		EstimatedWaitSeconds: s.EstimatedWaitSeconds,
+		AdmissionToken:       s.AdmissionToken,
+		TokenExpiresAt:       s.TokenExpiresAt,
+		Message:              s.Message,
+	}
+}
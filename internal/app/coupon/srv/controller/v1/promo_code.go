@@ -0,0 +1,107 @@
+package v1
+
+import (
+	"context"
+
+	couponpb "emshop/api/coupon/v1"
+	"emshop/internal/app/coupon/srv/domain/dto"
+	"emshop/pkg/log"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GeneratePromoCodes 批量生成一次性兑换码
+func (cs *couponServer) GeneratePromoCodes(ctx context.Context, req *couponpb.GeneratePromoCodesRequest) (*couponpb.GeneratePromoCodesResponse, error) {
+	log.InfofC(ctx, "GeneratePromoCodes: templateID=%d, count=%d", req.CouponTemplateId, req.Count)
+
+	result, err := cs.srv.PromoCodeSrv.GenerateCodes(ctx, &dto.GeneratePromoCodesDTO{
+		CouponTemplateID: req.CouponTemplateId,
+		Count:            req.Count,
+		Prefix:           req.Prefix,
+		Operator:         req.Operator,
+	})
+	if err != nil {
+		return nil, cs.handleError(err)
+	}
+	return &couponpb.GeneratePromoCodesResponse{
+		BatchNo: result.BatchNo,
+		Codes:   result.Codes,
+	}, nil
+}
+
+// CreatePublicPromoCode 创建公开兑换码
+func (cs *couponServer) CreatePublicPromoCode(ctx context.Context, req *couponpb.CreatePublicPromoCodeRequest) (*couponpb.PromoCodeResponse, error) {
+	log.InfofC(ctx, "CreatePublicPromoCode: templateID=%d, code=%s", req.CouponTemplateId, req.Code)
+
+	result, err := cs.srv.PromoCodeSrv.CreatePublicCode(ctx, &dto.CreatePublicPromoCodeDTO{
+		CouponTemplateID: req.CouponTemplateId,
+		Code:             req.Code,
+		MaxRedemptions:   req.MaxRedemptions,
+		Operator:         req.Operator,
+	})
+	if err != nil {
+		return nil, cs.handleError(err)
+	}
+	return cs.convertPromoCodeToProto(result), nil
+}
+
+// RedeemPromoCode 兑换码领取优惠券
+func (cs *couponServer) RedeemPromoCode(ctx context.Context, req *couponpb.RedeemPromoCodeRequest) (*couponpb.UserCouponResponse, error) {
+	log.InfofC(ctx, "RedeemPromoCode: userID=%d", req.UserId)
+
+	result, err := cs.srv.PromoCodeSrv.RedeemCode(ctx, &dto.RedeemPromoCodeDTO{
+		UserID: req.UserId,
+		Code:   req.Code,
+	})
+	if err != nil {
+		return nil, cs.handleError(err)
+	}
+	return cs.convertUserCouponToProto(result), nil
+}
+
+// ListPromoCodes 分页查询兑换码
+func (cs *couponServer) ListPromoCodes(ctx context.Context, req *couponpb.ListPromoCodesRequest) (*couponpb.ListPromoCodesResponse, error) {
+	result, err := cs.srv.PromoCodeSrv.ListCodes(ctx, &dto.ListPromoCodesDTO{
+		CouponTemplateID: req.CouponTemplateId,
+		BatchNo:          req.BatchNo,
+		Page:             req.Page,
+		PageSize:         req.PageSize,
+	})
+	if err != nil {
+		return nil, cs.handleError(err)
+	}
+
+	items := make([]*couponpb.PromoCodeResponse, 0, len(result.Items))
+	for _, item := range result.Items {
+		items = append(items, cs.convertPromoCodeToProto(item))
+	}
+	return &couponpb.ListPromoCodesResponse{
+		TotalCount: result.TotalCount,
+		Items:      items,
+	}, nil
+}
+
+// DisablePromoCode 停用兑换码
+func (cs *couponServer) DisablePromoCode(ctx context.Context, req *couponpb.DisablePromoCodeRequest) (*emptypb.Empty, error) {
+	log.InfofC(ctx, "DisablePromoCode: code=%s", req.Code)
+
+	if err := cs.srv.PromoCodeSrv.DisableCode(ctx, req.Code); err != nil {
+		return nil, cs.handleError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (cs *couponServer) convertPromoCodeToProto(dto *dto.PromoCodeDTO) *couponpb.PromoCodeResponse {
+	return &couponpb.PromoCodeResponse{
+		Id:               dto.ID,
+		Code:             dto.Code,
+		CouponTemplateId: dto.CouponTemplateID,
+		BatchNo:          dto.BatchNo,
+		Kind:             dto.Kind,
+		MaxRedemptions:   dto.MaxRedemptions,
+		RedeemedCount:    dto.RedeemedCount,
+		Status:           dto.Status,
+		Operator:         dto.Operator,
+		CreatedAt:        dto.CreatedAt.Unix(),
+	}
+}
@@ -0,0 +1,124 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	couponpb "emshop/api/coupon/v1"
+	"emshop/internal/app/coupon/srv/domain/dto"
+	"emshop/internal/app/pkg/code"
+	"emshop/pkg/errors"
+	"emshop/pkg/log"
+)
+
+// parseReportDates 解析报表日期参数，日期按服务本地时区解释
+func parseReportDates(startDate, endDate string) (time.Time, time.Time, error) {
+	from, err := time.ParseInLocation("2006-01-02", startDate, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.WithCode(code.ErrInvalidRequest, "开始日期格式错误: %s", startDate)
+	}
+	to, err := time.ParseInLocation("2006-01-02", endDate, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.WithCode(code.ErrInvalidRequest, "结束日期格式错误: %s", endDate)
+	}
+	return from, to, nil
+}
+
+// GetCouponReport 优惠券模板效果报表
+func (cs *couponServer) GetCouponReport(ctx context.Context, req *couponpb.CouponReportRequest) (*couponpb.CouponReportResponse, error) {
+	from, to, err := parseReportDates(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, cs.handleError(err)
+	}
+
+	result, err := cs.srv.ReportSrv.CouponReport(ctx, &dto.CouponReportQueryDTO{
+		CouponTemplateID: req.CouponTemplateId,
+		From:             from,
+		To:               to,
+	})
+	if err != nil {
+		return nil, cs.handleError(err)
+	}
+
+	resp := &couponpb.CouponReportResponse{
+		Days:      make([]*couponpb.CouponStat, 0, len(result.Days)),
+		Templates: make([]*couponpb.CouponStat, 0, len(result.Templates)),
+		Total:     convertCouponStatToProto(result.Total),
+	}
+	for _, s := range result.Days {
+		resp.Days = append(resp.Days, convertCouponStatToProto(s))
+	}
+	for _, s := range result.Templates {
+		resp.Templates = append(resp.Templates, convertCouponStatToProto(s))
+	}
+	return resp, nil
+}
+
+// GetFlashSaleReport 秒杀售罄报表
+func (cs *couponServer) GetFlashSaleReport(ctx context.Context, req *couponpb.FlashSaleReportRequest) (*couponpb.FlashSaleReportResponse, error) {
+	from, to, err := parseReportDates(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, cs.handleError(err)
+	}
+
+	result, err := cs.srv.ReportSrv.FlashSaleReport(ctx, &dto.FlashSaleReportQueryDTO{
+		FlashSaleID: req.FlashSaleId,
+		From:        from,
+		To:          to,
+	})
+	if err != nil {
+		return nil, cs.handleError(err)
+	}
+
+	resp := &couponpb.FlashSaleReportResponse{
+		Items: make([]*couponpb.FlashSaleStat, 0, len(result)),
+	}
+	for _, s := range result {
+		resp.Items = append(resp.Items, &couponpb.FlashSaleStat{
+			Date:             s.Date,
+			FlashSaleId:      s.FlashSaleID,
+			CouponTemplateId: s.CouponTemplateID,
+			TotalStock:       s.TotalStock,
+			SoldCount:        s.SoldCount,
+			FailedCount:      s.FailedCount,
+			CumulativeSold:   s.CumulativeSold,
+			SellThroughRate:  s.SellThroughRate,
+		})
+	}
+	return resp, nil
+}
+
+// RebuildCouponReport 重建日期范围内的每日统计
+func (cs *couponServer) RebuildCouponReport(ctx context.Context, req *couponpb.RebuildCouponReportRequest) (*couponpb.RebuildCouponReportResponse, error) {
+	log.InfofC(ctx, "RebuildCouponReport: start=%s, end=%s", req.StartDate, req.EndDate)
+
+	from, to, err := parseReportDates(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, cs.handleError(err)
+	}
+
+	days, err := cs.srv.ReportSrv.Rebuild(ctx, from, to)
+	if err != nil {
+		return nil, cs.handleError(err)
+	}
+	return &couponpb.RebuildCouponReportResponse{Days: int32(days)}, nil
+}
+
+func convertCouponStatToProto(s *dto.CouponStatDTO) *couponpb.CouponStat {
+	if s == nil {
+		return nil
+	}
+	return &couponpb.CouponStat{
+		Date:             s.Date,
+		CouponTemplateId: s.CouponTemplateID,
+		IssuedCount:      s.IssuedCount,
+		RedeemedCount:    s.RedeemedCount,
+		ExpiredCount:     s.ExpiredCount,
+		RedemptionRate:   s.RedemptionRate,
+		OrderCount:       s.OrderCount,
+		OriginalAmount:   s.OriginalAmount,
+		DiscountAmount:   s.DiscountAmount,
+		Gmv:              s.GMV,
+		AvgOrderAmount:   s.AvgOrderAmount,
+	}
+}
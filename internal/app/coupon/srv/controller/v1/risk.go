@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	couponpb "emshop/api/coupon/v1"
+	"emshop/internal/app/coupon/srv/domain/dto"
+)
+
+// EvaluateRisk 对短信发送等不经过优惠券业务的场景评分，决策由调用方执行
+func (cs *couponServer) EvaluateRisk(ctx context.Context, req *couponpb.EvaluateRiskRequest) (*couponpb.RiskDecisionResponse, error) {
+	result, err := cs.srv.RiskSrv.Evaluate(ctx, &dto.RiskEvaluateDTO{
+		Scene:   req.Scene,
+		UserID:  req.UserId,
+		Context: riskContextFromProto(req.Risk),
+	})
+	if err != nil {
+		return nil, cs.handleError(err)
+	}
+
+	return &couponpb.RiskDecisionResponse{
+		DecisionId: result.ID,
+		Action:     result.Action,
+		Score:      int32(result.Score),
+		Reasons:    result.Reasons,
+	}, nil
+}
+
+// ListRiskDecisions 分页查询风控决策记录
+func (cs *couponServer) ListRiskDecisions(ctx context.Context, req *couponpb.ListRiskDecisionsRequest) (*couponpb.ListRiskDecisionsResponse, error) {
+	query := &dto.ListRiskDecisionsDTO{
+		Scene:    req.GetScene(),
+		Action:   req.GetAction(),
+		UserID:   req.GetUserId(),
+		DeviceID: req.GetDeviceId(),
+		ClientIP: req.GetClientIp(),
+		Page:     req.Page,
+		PageSize: req.PageSize,
+	}
+	if req.StartTime > 0 {
+		query.From = time.Unix(req.StartTime, 0)
+	}
+	if req.EndTime > 0 {
+		query.To = time.Unix(req.EndTime, 0)
+	}
+
+	result, err := cs.srv.RiskSrv.ListDecisions(ctx, query)
+	if err != nil {
+		return nil, cs.handleError(err)
+	}
+
+	items := make([]*couponpb.RiskDecisionItem, 0, len(result.Items))
+	for _, item := range result.Items {
+		items = append(items, &couponpb.RiskDecisionItem{
+			Id:        item.ID,
+			Scene:     item.Scene,
+			TargetId:  item.TargetID,
+			UserId:    item.UserID,
+			Mobile:    item.Mobile,
+			DeviceId:  item.DeviceID,
+			ClientIp:  item.ClientIP,
+			Score:     int32(item.Score),
+			Action:    item.Action,
+			Reasons:   item.Reasons,
+			Signals:   item.Signals,
+			CreatedAt: item.CreatedAt.Unix(),
+		})
+	}
+	return &couponpb.ListRiskDecisionsResponse{
+		TotalCount: result.TotalCount,
+		Items:      items,
+	}, nil
+}
+
+// riskContextFromProto 转换网关采集的风控信号，未携带时返回空信号
+func riskContextFromProto(rc *couponpb.RiskContext) *dto.RiskContextDTO {
+	if rc == nil {
+		return &dto.RiskContextDTO{}
+	}
+	result := &dto.RiskContextDTO{
+		DeviceID:           rc.DeviceId,
+		ClientIP:           rc.ClientIp,
+		Mobile:             rc.Mobile,
+		SharedAddressUsers: rc.SharedAddressUsers,
+		CaptchaResult:      rc.CaptchaResult,
+	}
+	if rc.RegisteredAt > 0 {
+		result.RegisteredAt = time.Unix(rc.RegisteredAt, 0)
+	}
+	return result
+}
@@ -23,6 +23,8 @@ type CouponTemplateDataInterface interface {
 	UpdateUsedCount(ctx context.Context, db *gorm.DB, templateID int64, increment int32) error
 	GetAvailableTemplates(ctx context.Context, db *gorm.DB, userID int64, currentTime time.Time) ([]*do.CouponTemplateDO, error)
 	CheckTemplateAvailability(ctx context.Context, db *gorm.DB, templateID int64, currentTime time.Time) (bool, error)
+	// IncrUsedCountIfAvailable 未超过发放总量时已发放数量加1，返回是否成功；事务中执行时同时锁定模板行
+	IncrUsedCountIfAvailable(ctx context.Context, db *gorm.DB, templateID int64) (bool, error)
 }
 
 // UserCouponDataInterface 用户优惠券数据接口
@@ -45,6 +47,11 @@ type UserCouponDataInterface interface {
 	BatchCreate(ctx context.Context, db *gorm.DB, userCoupons []*do.UserCouponDO) error
 	CountUserCouponsByTemplate(ctx context.Context, db *gorm.DB, userID int64, templateID int64) (int64, error)
 	FindExpiredCoupons(ctx context.Context, db *gorm.DB, beforeTime time.Time) ([]*do.UserCouponDO, error)
+	
+	// 到期处理，按主键游标分批扫描
+	FindExpiredBatch(ctx context.Context, db *gorm.DB, beforeTime time.Time, afterID int64, limit int) ([]*do.UserCouponDO, error)
+	ExpireCoupons(ctx context.Context, db *gorm.DB, ids []int64, beforeTime time.Time) (int64, error)
+	FindExpiringWithoutReminder(ctx context.Context, db *gorm.DB, from, to time.Time, afterID int64, limit int) ([]*do.UserCouponDO, error)
 }
 
 // CouponUsageLogDataInterface 优惠券使用记录数据接口
@@ -65,14 +72,114 @@ type CouponConfigDataInterface interface {
 	Delete(ctx context.Context, db *gorm.DB, configKey string) error
 }
 
+// CouponReminderDataInterface 优惠券到期提醒数据接口
+type CouponReminderDataInterface interface {
+	BatchCreate(ctx context.Context, db *gorm.DB, reminders []*do.CouponReminderDO) error
+	ListByUser(ctx context.Context, db *gorm.DB, userID int64, meta v1.ListMeta) (*do.CouponReminderDOList, error)
+}
+
+// PromoCodeDataInterface 优惠券兑换码数据接口
+type PromoCodeDataInterface interface {
+	Create(ctx context.Context, db *gorm.DB, promoCode *do.PromoCodeDO) error
+	// BatchCreate 批量写入兑换码，已存在的兑换码被忽略，返回实际写入数量
+	BatchCreate(ctx context.Context, db *gorm.DB, promoCodes []*do.PromoCodeDO) (int64, error)
+	GetByCode(ctx context.Context, db *gorm.DB, code string) (*do.PromoCodeDO, error)
+	List(ctx context.Context, db *gorm.DB, templateID int64, batchNo string, meta v1.ListMeta) (*do.PromoCodeDOList, error)
+	UpdateStatus(ctx context.Context, db *gorm.DB, id int64, status do.PromoCodeStatus) error
+	// IncrRedeemed 兑换码可用且未用完时兑换次数加1，返回是否成功
+	IncrRedeemed(ctx context.Context, db *gorm.DB, id int64) (bool, error)
+	// CreateRedemption 写入兑换记录，用户已兑换过该兑换码时返回false
+	CreateRedemption(ctx context.Context, db *gorm.DB, redemption *do.PromoCodeRedemptionDO) (bool, error)
+}
+
+// CouponCampaignDataInterface 定向发券活动数据接口
+type CouponCampaignDataInterface interface {
+	Create(ctx context.Context, db *gorm.DB, campaign *do.CouponCampaignDO) error
+	Get(ctx context.Context, db *gorm.DB, id int64) (*do.CouponCampaignDO, error)
+	List(ctx context.Context, db *gorm.DB, status do.CampaignStatus, meta v1.ListMeta) (*do.CouponCampaignDOList, error)
+	ListByStatus(ctx context.Context, db *gorm.DB, status do.CampaignStatus) ([]*do.CouponCampaignDO, error)
+	// UpdateStatus 仅当活动处于from中的状态时更新为to，返回是否更新成功
+	UpdateStatus(ctx context.Context, db *gorm.DB, id int64, from []do.CampaignStatus, to do.CampaignStatus) (bool, error)
+	IncrTotalTargets(ctx context.Context, db *gorm.DB, id int64, delta int64) error
+	SetLastDispatched(ctx context.Context, db *gorm.DB, id int64, at time.Time) error
+	// DeleteDraft 仅删除草稿状态的活动，返回是否删除成功
+	DeleteDraft(ctx context.Context, db *gorm.DB, id int64) (bool, error)
+}
+
+// CampaignTargetDataInterface 发券活动目标用户数据接口
+type CampaignTargetDataInterface interface {
+	// BatchCreate 批量写入目标用户，活动中已存在的用户被忽略，返回实际写入数量
+	BatchCreate(ctx context.Context, db *gorm.DB, targets []*do.CampaignTargetDO) (int64, error)
+	// ClaimPending 领取一批待发放目标并标记为已投递
+	ClaimPending(ctx context.Context, db *gorm.DB, campaignID int64, limit int) ([]*do.CampaignTargetDO, error)
+	// ListDispatched 查询指定ID中仍处于已投递状态的目标
+	ListDispatched(ctx context.Context, db *gorm.DB, campaignID int64, ids []int64) ([]*do.CampaignTargetDO, error)
+	// ResetDispatched 将已投递的目标恢复为待发放
+	ResetDispatched(ctx context.Context, db *gorm.DB, campaignID int64, ids []int64) (int64, error)
+	// ResetStale 将投递时间早于before仍未处理的目标恢复为待发放
+	ResetStale(ctx context.Context, db *gorm.DB, campaignID int64, before time.Time) (int64, error)
+	// ResetFailed 将发放失败的目标恢复为待发放
+	ResetFailed(ctx context.Context, db *gorm.DB, campaignID int64) (int64, error)
+	// MarkIssued 已投递的目标标记为已发放，返回是否更新成功
+	MarkIssued(ctx context.Context, db *gorm.DB, id int64, userCouponID int64) (bool, error)
+	// MarkResult 已投递的目标标记为跳过或失败，返回是否更新成功
+	MarkResult(ctx context.Context, db *gorm.DB, id int64, status do.CampaignTargetStatus, reason string) (bool, error)
+	CountByStatus(ctx context.Context, db *gorm.DB, campaignID int64) (map[do.CampaignTargetStatus]int64, error)
+	// DeleteByCampaign 删除活动的全部目标用户
+	DeleteByCampaign(ctx context.Context, db *gorm.DB, campaignID int64) (int64, error)
+}
+
+// CouponReportDataInterface 优惠券与秒杀报表数据接口
+type CouponReportDataInterface interface {
+	// AggregateCouponDay 从领取、使用与过期记录聚合[start, end)内各模板及汇总统计
+	AggregateCouponDay(ctx context.Context, db *gorm.DB, start, end time.Time) ([]*do.CouponDailyStatDO, error)
+	// AggregateFlashSaleDay 从秒杀记录聚合[start, end)内各活动统计
+	AggregateFlashSaleDay(ctx context.Context, db *gorm.DB, start, end time.Time) ([]*do.FlashSaleDailyStatDO, error)
+	// ReplaceCouponStats 覆盖写入某天的模板统计
+	ReplaceCouponStats(ctx context.Context, db *gorm.DB, day time.Time, stats []*do.CouponDailyStatDO) error
+	// ReplaceFlashSaleStats 覆盖写入某天的秒杀统计
+	ReplaceFlashSaleStats(ctx context.Context, db *gorm.DB, day time.Time, stats []*do.FlashSaleDailyStatDO) error
+	// ListCouponStats 按日期升序查询模板统计，templateID为0时返回汇总记录
+	ListCouponStats(ctx context.Context, db *gorm.DB, templateID int64, from, to time.Time) ([]*do.CouponDailyStatDO, error)
+	// SumCouponStatsByTemplate 按模板汇总日期范围内的统计，不含汇总记录
+	SumCouponStatsByTemplate(ctx context.Context, db *gorm.DB, from, to time.Time) ([]*do.CouponDailyStatDO, error)
+	// ListFlashSaleStats 按日期升序查询秒杀统计，flashSaleID为0时返回全部活动
+	ListFlashSaleStats(ctx context.Context, db *gorm.DB, flashSaleID int64, from, to time.Time) ([]*do.FlashSaleDailyStatDO, error)
+}
+
+// RiskDecisionDataInterface 风控决策记录数据接口
+type RiskDecisionDataInterface interface {
+	Create(ctx context.Context, db *gorm.DB, decision *do.RiskDecisionDO) error
+	// List 按创建时间倒序分页查询决策记录
+	List(ctx context.Context, db *gorm.DB, filter *do.RiskDecisionFilter, meta v1.ListMeta) (*do.RiskDecisionDOList, error)
+}
+
+// FlashSaleReservationDataInterface 商品秒杀预占单数据接口
+type FlashSaleReservationDataInterface interface {
+	Create(ctx context.Context, db *gorm.DB, reservation *do.FlashSaleReservationDO) error
+	// GetByOrderSn 按预占单号查询，不存在时返回nil
+	GetByOrderSn(ctx context.Context, db *gorm.DB, orderSn string) (*do.FlashSaleReservationDO, error)
+	// ListExpired 查询before之前到期仍处于预占状态的记录，按到期时间升序
+	ListExpired(ctx context.Context, db *gorm.DB, before time.Time, limit int) ([]*do.FlashSaleReservationDO, error)
+	// TransitStatus 仅当状态为from时更新为to，返回是否发生了状态变更
+	TransitStatus(ctx context.Context, db *gorm.DB, orderSn string, from, to do.FlashSaleReservationStatus) (bool, error)
+}
+
 // DataFactory 优惠券服务数据工厂接口
 type DataFactory interface {
 	CouponTemplates() CouponTemplateDataInterface
 	UserCoupons() UserCouponDataInterface
 	CouponUsageLogs() CouponUsageLogDataInterface
 	CouponConfigs() CouponConfigDataInterface
+	CouponReminders() CouponReminderDataInterface
+	PromoCodes() PromoCodeDataInterface
+	CouponCampaigns() CouponCampaignDataInterface
+	CampaignTargets() CampaignTargetDataInterface
+	CouponReports() CouponReportDataInterface
+	RiskDecisions() RiskDecisionDataInterface
 	FlashSales() FlashSaleDataInterface
 	FlashSaleRecords() FlashSaleRecordDataInterface
+	FlashSaleReservations() FlashSaleReservationDataInterface
 	
 	// 数据库操作
 	DB() *gorm.DB
@@ -29,6 +29,11 @@ type FlashSaleDataInterface interface {
 	CheckStock(ctx context.Context, db *gorm.DB, id int64) (*do.FlashSaleStockInfo, error)
 	IncrementSoldCount(ctx context.Context, db *gorm.DB, id int64) error
 	DecrementSoldCount(ctx context.Context, db *gorm.DB, id int64) error
+	SetSoldCount(ctx context.Context, db *gorm.DB, id int64, soldCount int32) error
+	
+	// 生命周期调度
+	TransitStatus(ctx context.Context, db *gorm.DB, id int64, from []do.FlashSaleStatus, to do.FlashSaleStatus) (bool, error)
+	FindLifecycleDue(ctx context.Context, db *gorm.DB, before time.Time) ([]*do.FlashSaleActivityDO, error)
 }
 
 // FlashSaleRecordDataInterface 秒杀记录数据接口
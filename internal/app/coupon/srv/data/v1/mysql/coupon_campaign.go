@@ -0,0 +1,411 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"emshop/internal/app/coupon/srv/domain/do"
+	v1 "emshop/pkg/common/meta/v1"
+	"emshop/pkg/log"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type couponCampaignData struct {
+	db *gorm.DB
+}
+
+// NewCouponCampaignData 创建定向发券活动数据访问对象
+func NewCouponCampaignData(db *gorm.DB) *couponCampaignData {
+	return &couponCampaignData{
+		db: db,
+	}
+}
+
+// Create 创建发券活动
+func (ccd *couponCampaignData) Create(ctx context.Context, db *gorm.DB, campaign *do.CouponCampaignDO) error {
+	if db == nil {
+		db = ccd.db
+	}
+
+	if err := db.WithContext(ctx).Create(campaign).Error; err != nil {
+		log.ErrorfC(ctx, "创建发券活动失败: %v", err)
+		return err
+	}
+	return nil
+}
+
+// Get 根据ID查询发券活动
+func (ccd *couponCampaignData) Get(ctx context.Context, db *gorm.DB, id int64) (*do.CouponCampaignDO, error) {
+	if db == nil {
+		db = ccd.db
+	}
+
+	var campaign do.CouponCampaignDO
+	if err := db.WithContext(ctx).Where("id = ?", id).First(&campaign).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		log.ErrorfC(ctx, "查询发券活动失败: %v", err)
+		return nil, err
+	}
+	return &campaign, nil
+}
+
+// List 分页查询发券活动，status为0时不过滤状态
+func (ccd *couponCampaignData) List(ctx context.Context, db *gorm.DB, status do.CampaignStatus, meta v1.ListMeta) (*do.CouponCampaignDOList, error) {
+	if db == nil {
+		db = ccd.db
+	}
+
+	query := db.WithContext(ctx).Model(&do.CouponCampaignDO{})
+	if status > 0 {
+		query = query.Where("status = ?", status)
+	}
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		log.ErrorfC(ctx, "统计发券活动总数失败: %v", err)
+		return nil, err
+	}
+
+	if meta.Page > 0 {
+		query = query.Offset((meta.Page - 1) * meta.PageSize)
+	}
+	if meta.PageSize > 0 {
+		query = query.Limit(meta.PageSize)
+	}
+
+	var items []*do.CouponCampaignDO
+	if err := query.Order("id DESC").Find(&items).Error; err != nil {
+		log.ErrorfC(ctx, "查询发券活动列表失败: %v", err)
+		return nil, err
+	}
+
+	return &do.CouponCampaignDOList{
+		TotalCount: totalCount,
+		Items:      items,
+	}, nil
+}
+
+// ListByStatus 查询指定状态的全部发券活动
+func (ccd *couponCampaignData) ListByStatus(ctx context.Context, db *gorm.DB, status do.CampaignStatus) ([]*do.CouponCampaignDO, error) {
+	if db == nil {
+		db = ccd.db
+	}
+
+	var items []*do.CouponCampaignDO
+	if err := db.WithContext(ctx).Where("status = ?", status).Order("id ASC").Find(&items).Error; err != nil {
+		log.ErrorfC(ctx, "按状态查询发券活动失败: %v", err)
+		return nil, err
+	}
+	return items, nil
+}
+
+// UpdateStatus 条件更新活动状态，首次进入发放中时记录开始时间，结束时记录完成时间
+func (ccd *couponCampaignData) UpdateStatus(ctx context.Context, db *gorm.DB, id int64, from []do.CampaignStatus, to do.CampaignStatus) (bool, error) {
+	if db == nil {
+		db = ccd.db
+	}
+
+	now := time.Now()
+	updates := map[string]interface{}{"status": to}
+	switch to {
+	case do.CampaignStatusRunning:
+		updates["started_at"] = gorm.Expr("COALESCE(started_at, ?)", now)
+	case do.CampaignStatusCompleted, do.CampaignStatusCancelled:
+		updates["finished_at"] = now
+	}
+
+	result := db.WithContext(ctx).Model(&do.CouponCampaignDO{}).
+		Where("id = ? AND status IN ?", id, from).
+		Updates(updates)
+	if result.Error != nil {
+		log.ErrorfC(ctx, "更新发券活动状态失败: %v", result.Error)
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// IncrTotalTargets 累加活动目标用户数
+func (ccd *couponCampaignData) IncrTotalTargets(ctx context.Context, db *gorm.DB, id int64, delta int64) error {
+	if db == nil {
+		db = ccd.db
+	}
+
+	if err := db.WithContext(ctx).Model(&do.CouponCampaignDO{}).
+		Where("id = ?", id).
+		UpdateColumn("total_targets", gorm.Expr("total_targets + ?", delta)).Error; err != nil {
+		log.ErrorfC(ctx, "更新发券活动目标用户数失败: %v", err)
+		return err
+	}
+	return nil
+}
+
+// SetLastDispatched 记录最近一次投递时间
+func (ccd *couponCampaignData) SetLastDispatched(ctx context.Context, db *gorm.DB, id int64, at time.Time) error {
+	if db == nil {
+		db = ccd.db
+	}
+
+	if err := db.WithContext(ctx).Model(&do.CouponCampaignDO{}).
+		Where("id = ?", id).
+		UpdateColumn("last_dispatched_at", at).Error; err != nil {
+		log.ErrorfC(ctx, "更新发券活动投递时间失败: %v", err)
+		return err
+	}
+	return nil
+}
+
+// DeleteDraft 删除草稿状态的活动，已开始发放的活动只能取消
+func (ccd *couponCampaignData) DeleteDraft(ctx context.Context, db *gorm.DB, id int64) (bool, error) {
+	if db == nil {
+		db = ccd.db
+	}
+
+	result := db.WithContext(ctx).
+		Where("id = ? AND status = ?", id, do.CampaignStatusDraft).
+		Delete(&do.CouponCampaignDO{})
+	if result.Error != nil {
+		log.ErrorfC(ctx, "删除发券活动失败: %v", result.Error)
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+type campaignTargetData struct {
+	db *gorm.DB
+}
+
+// NewCampaignTargetData 创建发券活动目标用户数据访问对象
+func NewCampaignTargetData(db *gorm.DB) *campaignTargetData {
+	return &campaignTargetData{
+		db: db,
+	}
+}
+
+// BatchCreate 批量写入目标用户，依赖(campaign_id, user_id)唯一索引去重
+func (ctd *campaignTargetData) BatchCreate(ctx context.Context, db *gorm.DB, targets []*do.CampaignTargetDO) (int64, error) {
+	if db == nil {
+		db = ctd.db
+	}
+	if len(targets) == 0 {
+		return 0, nil
+	}
+
+	result := db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		CreateInBatches(targets, 500)
+	if result.Error != nil {
+		log.ErrorfC(ctx, "批量写入活动目标用户失败: %v", result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// ClaimPending 锁定一批待发放目标并标记为已投递
+func (ctd *campaignTargetData) ClaimPending(ctx context.Context, db *gorm.DB, campaignID int64, limit int) ([]*do.CampaignTargetDO, error) {
+	if db == nil {
+		db = ctd.db
+	}
+
+	var targets []*do.CampaignTargetDO
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("campaign_id = ? AND status = ?", campaignID, do.CampaignTargetPending).
+			Order("id ASC").
+			Limit(limit).
+			Find(&targets).Error; err != nil {
+			return err
+		}
+		if len(targets) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, 0, len(targets))
+		for _, target := range targets {
+			ids = append(ids, target.ID)
+		}
+		now := time.Now()
+		if err := tx.Model(&do.CampaignTargetDO{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"status":        do.CampaignTargetDispatched,
+				"dispatched_at": now,
+			}).Error; err != nil {
+			return err
+		}
+		for _, target := range targets {
+			target.Status = do.CampaignTargetDispatched
+			target.DispatchedAt = &now
+		}
+		return nil
+	})
+	if err != nil {
+		log.ErrorfC(ctx, "领取活动待发放目标失败: %v", err)
+		return nil, err
+	}
+	return targets, nil
+}
+
+// ListDispatched 查询指定ID中仍处于已投递状态的目标
+func (ctd *campaignTargetData) ListDispatched(ctx context.Context, db *gorm.DB, campaignID int64, ids []int64) ([]*do.CampaignTargetDO, error) {
+	if db == nil {
+		db = ctd.db
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var targets []*do.CampaignTargetDO
+	if err := db.WithContext(ctx).
+		Where("campaign_id = ? AND id IN ? AND status = ?", campaignID, ids, do.CampaignTargetDispatched).
+		Order("id ASC").
+		Find(&targets).Error; err != nil {
+		log.ErrorfC(ctx, "查询活动已投递目标失败: %v", err)
+		return nil, err
+	}
+	return targets, nil
+}
+
+// ResetDispatched 将已投递的目标恢复为待发放，用于消息投递失败或活动暂停
+func (ctd *campaignTargetData) ResetDispatched(ctx context.Context, db *gorm.DB, campaignID int64, ids []int64) (int64, error) {
+	if db == nil {
+		db = ctd.db
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	result := db.WithContext(ctx).Model(&do.CampaignTargetDO{}).
+		Where("campaign_id = ? AND id IN ? AND status = ?", campaignID, ids, do.CampaignTargetDispatched).
+		Updates(map[string]interface{}{
+			"status":        do.CampaignTargetPending,
+			"dispatched_at": nil,
+		})
+	if result.Error != nil {
+		log.ErrorfC(ctx, "恢复活动已投递目标失败: %v", result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// ResetStale 将长时间未被消费的已投递目标恢复为待发放，防止消息丢失导致活动无法完成
+func (ctd *campaignTargetData) ResetStale(ctx context.Context, db *gorm.DB, campaignID int64, before time.Time) (int64, error) {
+	if db == nil {
+		db = ctd.db
+	}
+
+	result := db.WithContext(ctx).Model(&do.CampaignTargetDO{}).
+		Where("campaign_id = ? AND status = ? AND dispatched_at < ?", campaignID, do.CampaignTargetDispatched, before).
+		Updates(map[string]interface{}{
+			"status":        do.CampaignTargetPending,
+			"dispatched_at": nil,
+		})
+	if result.Error != nil {
+		log.ErrorfC(ctx, "恢复超时投递目标失败: %v", result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// ResetFailed 将发放失败的目标恢复为待发放，已发放的目标不受影响
+func (ctd *campaignTargetData) ResetFailed(ctx context.Context, db *gorm.DB, campaignID int64) (int64, error) {
+	if db == nil {
+		db = ctd.db
+	}
+
+	result := db.WithContext(ctx).Model(&do.CampaignTargetDO{}).
+		Where("campaign_id = ? AND status = ?", campaignID, do.CampaignTargetFailed).
+		Updates(map[string]interface{}{
+			"status":        do.CampaignTargetPending,
+			"reason":        "",
+			"dispatched_at": nil,
+		})
+	if result.Error != nil {
+		log.ErrorfC(ctx, "恢复发放失败目标失败: %v", result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// MarkIssued 条件更新为已发放，重复消费同一批消息时只有一次能更新成功
+func (ctd *campaignTargetData) MarkIssued(ctx context.Context, db *gorm.DB, id int64, userCouponID int64) (bool, error) {
+	if db == nil {
+		db = ctd.db
+	}
+
+	result := db.WithContext(ctx).Model(&do.CampaignTargetDO{}).
+		Where("id = ? AND status = ?", id, do.CampaignTargetDispatched).
+		Updates(map[string]interface{}{
+			"status":         do.CampaignTargetIssued,
+			"user_coupon_id": userCouponID,
+		})
+	if result.Error != nil {
+		log.ErrorfC(ctx, "更新活动目标发放结果失败: %v", result.Error)
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// MarkResult 条件更新为跳过或失败
+func (ctd *campaignTargetData) MarkResult(ctx context.Context, db *gorm.DB, id int64, status do.CampaignTargetStatus, reason string) (bool, error) {
+	if db == nil {
+		db = ctd.db
+	}
+
+	result := db.WithContext(ctx).Model(&do.CampaignTargetDO{}).
+		Where("id = ? AND status = ?", id, do.CampaignTargetDispatched).
+		Updates(map[string]interface{}{
+			"status": status,
+			"reason": reason,
+		})
+	if result.Error != nil {
+		log.ErrorfC(ctx, "更新活动目标发放结果失败: %v", result.Error)
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CountByStatus 按状态统计活动目标数量
+func (ctd *campaignTargetData) CountByStatus(ctx context.Context, db *gorm.DB, campaignID int64) (map[do.CampaignTargetStatus]int64, error) {
+	if db == nil {
+		db = ctd.db
+	}
+
+	var rows []struct {
+		Status do.CampaignTargetStatus
+		Total  int64
+	}
+	if err := db.WithContext(ctx).Model(&do.CampaignTargetDO{}).
+		Select("status, COUNT(*) AS total").
+		Where("campaign_id = ?", campaignID).
+		Group("status").
+		Scan(&rows).Error; err != nil {
+		log.ErrorfC(ctx, "统计活动目标状态失败: %v", err)
+		return nil, err
+	}
+
+	counts := make(map[do.CampaignTargetStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Total
+	}
+	return counts, nil
+}
+
+// DeleteByCampaign 删除活动的全部目标用户
+func (ctd *campaignTargetData) DeleteByCampaign(ctx context.Context, db *gorm.DB, campaignID int64) (int64, error) {
+	if db == nil {
+		db = ctd.db
+	}
+
+	result := db.WithContext(ctx).
+		Where("campaign_id = ?", campaignID).
+		Delete(&do.CampaignTargetDO{})
+	if result.Error != nil {
+		log.ErrorfC(ctx, "删除活动目标用户失败: %v", result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
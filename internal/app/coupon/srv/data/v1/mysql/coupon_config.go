@@ -29,7 +29,7 @@ func (ccd *couponConfigData) Get(ctx context.Context, db *gorm.DB, configKey str
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
-		log.Errorf("获取优惠券配置失败: %v", err)
+		log.ErrorfC(ctx, "获取优惠券配置失败: %v", err)
 		return nil, err
 	}
 	return &config, nil
@@ -55,7 +55,7 @@ func (ccd *couponConfigData) Set(ctx context.Context, db *gorm.DB, configKey, co
 			"description":  description,
 		}).
 		FirstOrCreate(config).Error; err != nil {
-		log.Errorf("设置优惠券配置失败: %v", err)
+		log.ErrorfC(ctx, "设置优惠券配置失败: %v", err)
 		return err
 	}
 	return nil
@@ -69,7 +69,7 @@ func (ccd *couponConfigData) GetAll(ctx context.Context, db *gorm.DB) ([]*do.Cou
 	
 	var configs []*do.CouponConfigDO
 	if err := db.WithContext(ctx).Find(&configs).Error; err != nil {
-		log.Errorf("获取所有优惠券配置失败: %v", err)
+		log.ErrorfC(ctx, "获取所有优惠券配置失败: %v", err)
 		return nil, err
 	}
 	return configs, nil
@@ -84,7 +84,7 @@ func (ccd *couponConfigData) Update(ctx context.Context, db *gorm.DB, configKey,
 	if err := db.WithContext(ctx).Model(&do.CouponConfigDO{}).
 		Where("config_key = ?", configKey).
 		Update("config_value", configValue).Error; err != nil {
-		log.Errorf("更新优惠券配置失败: %v", err)
+		log.ErrorfC(ctx, "更新优惠券配置失败: %v", err)
 		return err
 	}
 	return nil
@@ -97,7 +97,7 @@ func (ccd *couponConfigData) Delete(ctx context.Context, db *gorm.DB, configKey
 	}
 	
 	if err := db.WithContext(ctx).Where("config_key = ?", configKey).Delete(&do.CouponConfigDO{}).Error; err != nil {
-		log.Errorf("删除优惠券配置失败: %v", err)
+		log.ErrorfC(ctx, "删除优惠券配置失败: %v", err)
 		return err
 	}
 	return nil
@@ -0,0 +1,72 @@
+package mysql
+
+import (
+	"context"
+	"emshop/internal/app/coupon/srv/domain/do"
+	v1 "emshop/pkg/common/meta/v1"
+	"emshop/pkg/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type couponReminderData struct {
+	db *gorm.DB
+}
+
+// NewCouponReminderData 创建优惠券到期提醒数据访问对象
+func NewCouponReminderData(db *gorm.DB) *couponReminderData {
+	return &couponReminderData{
+		db: db,
+	}
+}
+
+// BatchCreate 批量写入提醒，同一张用户优惠券只保留首条提醒
+func (crd *couponReminderData) BatchCreate(ctx context.Context, db *gorm.DB, reminders []*do.CouponReminderDO) error {
+	if db == nil {
+		db = crd.db
+	}
+	if len(reminders) == 0 {
+		return nil
+	}
+
+	if err := db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		CreateInBatches(reminders, 100).Error; err != nil {
+		log.ErrorfC(ctx, "批量创建优惠券到期提醒失败: %v", err)
+		return err
+	}
+	return nil
+}
+
+// ListByUser 获取用户的到期提醒列表
+func (crd *couponReminderData) ListByUser(ctx context.Context, db *gorm.DB, userID int64, meta v1.ListMeta) (*do.CouponReminderDOList, error) {
+	if db == nil {
+		db = crd.db
+	}
+
+	query := db.WithContext(ctx).Model(&do.CouponReminderDO{}).Where("user_id = ?", userID)
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		log.ErrorfC(ctx, "统计优惠券到期提醒总数失败: %v", err)
+		return nil, err
+	}
+
+	if meta.Page > 0 {
+		query = query.Offset((meta.Page - 1) * meta.PageSize)
+	}
+	if meta.PageSize > 0 {
+		query = query.Limit(meta.PageSize)
+	}
+
+	var reminders []*do.CouponReminderDO
+	if err := query.Order("created_at DESC, id DESC").Find(&reminders).Error; err != nil {
+		log.ErrorfC(ctx, "查询优惠券到期提醒列表失败: %v", err)
+		return nil, err
+	}
+
+	return &do.CouponReminderDOList{
+		TotalCount: totalCount,
+		Items:      reminders,
+	}, nil
+}
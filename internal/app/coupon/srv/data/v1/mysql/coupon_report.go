@@ -0,0 +1,298 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"emshop/internal/app/coupon/srv/domain/do"
+	"emshop/pkg/log"
+
+	"gorm.io/gorm"
+)
+
+type couponReportData struct {
+	db *gorm.DB
+}
+
+// NewCouponReportData 创建优惠券报表数据访问对象
+func NewCouponReportData(db *gorm.DB) *couponReportData {
+	return &couponReportData{
+		db: db,
+	}
+}
+
+type templateCountRow struct {
+	TemplateID int64
+	Total      int64
+}
+
+type couponOrderRow struct {
+	TemplateID     int64
+	OrderSn        string
+	Redeemed       int64
+	OriginalAmount float64
+	DiscountAmount float64
+	FinalAmount    float64
+}
+
+// AggregateCouponDay 聚合一天的模板统计
+//
+// 核销只统计优惠券仍处于已使用且订单号一致的使用记录，订单取消释放的优惠券在重新聚合时被剔除；
+// 使用记录中的原始金额与实付金额是整单金额，按订单去重后再累加
+func (crd *couponReportData) AggregateCouponDay(ctx context.Context, db *gorm.DB, start, end time.Time) ([]*do.CouponDailyStatDO, error) {
+	if db == nil {
+		db = crd.db
+	}
+	db = db.WithContext(ctx)
+
+	stats := make(map[int64]*do.CouponDailyStatDO)
+	statOf := func(templateID int64) *do.CouponDailyStatDO {
+		s, ok := stats[templateID]
+		if !ok {
+			s = &do.CouponDailyStatDO{StatDate: start, CouponTemplateID: templateID}
+			stats[templateID] = s
+		}
+		return s
+	}
+	total := statOf(0)
+
+	var issued []templateCountRow
+	if err := db.Table("user_coupons").
+		Select("coupon_template_id AS template_id, COUNT(*) AS total").
+		Where("received_at >= ? AND received_at < ?", start, end).
+		Group("coupon_template_id").
+		Scan(&issued).Error; err != nil {
+		log.ErrorfC(ctx, "聚合优惠券发放数量失败: %v", err)
+		return nil, err
+	}
+	for _, row := range issued {
+		statOf(row.TemplateID).IssuedCount = row.Total
+		total.IssuedCount += row.Total
+	}
+
+	var expired []templateCountRow
+	if err := db.Table("user_coupons").
+		Select("coupon_template_id AS template_id, COUNT(*) AS total").
+		Where("status = ? AND expired_at >= ? AND expired_at < ?", do.UserCouponStatusExpired, start, end).
+		Group("coupon_template_id").
+		Scan(&expired).Error; err != nil {
+		log.ErrorfC(ctx, "聚合优惠券过期数量失败: %v", err)
+		return nil, err
+	}
+	for _, row := range expired {
+		statOf(row.TemplateID).ExpiredCount = row.Total
+		total.ExpiredCount += row.Total
+	}
+
+	var orders []couponOrderRow
+	if err := db.Table("coupon_usage_logs AS l").
+		Select("uc.coupon_template_id AS template_id, l.order_sn, COUNT(*) AS redeemed, "+
+			"MAX(l.original_amount) AS original_amount, SUM(l.discount_amount) AS discount_amount, MAX(l.final_amount) AS final_amount").
+		Joins("JOIN user_coupons uc ON uc.id = l.user_coupon_id AND uc.order_sn = l.order_sn AND uc.status = ?", do.UserCouponStatusUsed).
+		Where("l.action = ? AND l.created_at >= ? AND l.created_at < ?", do.CouponUsageActionUse, start, end).
+		Group("uc.coupon_template_id, l.order_sn").
+		Scan(&orders).Error; err != nil {
+		log.ErrorfC(ctx, "聚合优惠券核销记录失败: %v", err)
+		return nil, err
+	}
+	seen := make(map[string]struct{}, len(orders))
+	for _, row := range orders {
+		s := statOf(row.TemplateID)
+		s.RedeemedCount += row.Redeemed
+		s.OrderCount++
+		s.OriginalAmount += row.OriginalAmount
+		s.DiscountAmount += row.DiscountAmount
+		s.GMV += row.FinalAmount
+
+		total.RedeemedCount += row.Redeemed
+		total.DiscountAmount += row.DiscountAmount
+		if _, ok := seen[row.OrderSn]; !ok {
+			seen[row.OrderSn] = struct{}{}
+			total.OrderCount++
+			total.OriginalAmount += row.OriginalAmount
+			total.GMV += row.FinalAmount
+		}
+	}
+
+	result := make([]*do.CouponDailyStatDO, 0, len(stats))
+	for _, s := range stats {
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+type flashSaleCountRow struct {
+	FlashSaleID int64
+	Sold        int64
+	Failed      int64
+}
+
+// AggregateFlashSaleDay 聚合一天内有参与记录的秒杀活动，累计售出用于计算售罄率
+func (crd *couponReportData) AggregateFlashSaleDay(ctx context.Context, db *gorm.DB, start, end time.Time) ([]*do.FlashSaleDailyStatDO, error) {
+	if db == nil {
+		db = crd.db
+	}
+	db = db.WithContext(ctx)
+
+	var daily []flashSaleCountRow
+	if err := db.Table("flash_sale_records").
+		Select("flash_sale_id, SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) AS sold, SUM(CASE WHEN status = ? THEN 0 ELSE 1 END) AS failed",
+			do.FlashSaleRecordStatusSuccess, do.FlashSaleRecordStatusSuccess).
+		Where("created_at >= ? AND created_at < ?", start, end).
+		Group("flash_sale_id").
+		Scan(&daily).Error; err != nil {
+		log.ErrorfC(ctx, "聚合秒杀记录失败: %v", err)
+		return nil, err
+	}
+	if len(daily) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int64, 0, len(daily))
+	for _, row := range daily {
+		ids = append(ids, row.FlashSaleID)
+	}
+
+	var cumulative []flashSaleCountRow
+	if err := db.Table("flash_sale_records").
+		Select("flash_sale_id, COUNT(*) AS sold").
+		Where("flash_sale_id IN ? AND status = ? AND created_at < ?", ids, do.FlashSaleRecordStatusSuccess, end).
+		Group("flash_sale_id").
+		Scan(&cumulative).Error; err != nil {
+		log.ErrorfC(ctx, "聚合秒杀累计售出失败: %v", err)
+		return nil, err
+	}
+	cumulativeByID := make(map[int64]int64, len(cumulative))
+	for _, row := range cumulative {
+		cumulativeByID[row.FlashSaleID] = row.Sold
+	}
+
+	var activities []*do.FlashSaleActivityDO
+	if err := db.Unscoped().Where("id IN ?", ids).Find(&activities).Error; err != nil {
+		log.ErrorfC(ctx, "查询秒杀活动失败: %v", err)
+		return nil, err
+	}
+	activityByID := make(map[int64]*do.FlashSaleActivityDO, len(activities))
+	for _, a := range activities {
+		activityByID[a.ID] = a
+	}
+
+	result := make([]*do.FlashSaleDailyStatDO, 0, len(daily))
+	for _, row := range daily {
+		stat := &do.FlashSaleDailyStatDO{
+			StatDate:       start,
+			FlashSaleID:    row.FlashSaleID,
+			SoldCount:      row.Sold,
+			FailedCount:    row.Failed,
+			CumulativeSold: cumulativeByID[row.FlashSaleID],
+		}
+		if a, ok := activityByID[row.FlashSaleID]; ok {
+			stat.CouponTemplateID = a.CouponTemplateID
+			stat.TotalStock = a.FlashSaleCount
+		}
+		result = append(result, stat)
+	}
+	return result, nil
+}
+
+// ReplaceCouponStats 删除当天已有统计后重新写入，重复聚合同一天结果一致
+func (crd *couponReportData) ReplaceCouponStats(ctx context.Context, db *gorm.DB, day time.Time, stats []*do.CouponDailyStatDO) error {
+	if db == nil {
+		db = crd.db
+	}
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("stat_date = ?", day.Format("2006-01-02")).Delete(&do.CouponDailyStatDO{}).Error; err != nil {
+			return err
+		}
+		if len(stats) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(stats, 500).Error
+	})
+	if err != nil {
+		log.ErrorfC(ctx, "写入优惠券每日统计失败: day=%s, err=%v", day.Format("2006-01-02"), err)
+		return err
+	}
+	return nil
+}
+
+// ReplaceFlashSaleStats 删除当天已有统计后重新写入
+func (crd *couponReportData) ReplaceFlashSaleStats(ctx context.Context, db *gorm.DB, day time.Time, stats []*do.FlashSaleDailyStatDO) error {
+	if db == nil {
+		db = crd.db
+	}
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("stat_date = ?", day.Format("2006-01-02")).Delete(&do.FlashSaleDailyStatDO{}).Error; err != nil {
+			return err
+		}
+		if len(stats) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(stats, 500).Error
+	})
+	if err != nil {
+		log.ErrorfC(ctx, "写入秒杀每日统计失败: day=%s, err=%v", day.Format("2006-01-02"), err)
+		return err
+	}
+	return nil
+}
+
+// ListCouponStats 查询[from, to]日期范围内的模板统计
+func (crd *couponReportData) ListCouponStats(ctx context.Context, db *gorm.DB, templateID int64, from, to time.Time) ([]*do.CouponDailyStatDO, error) {
+	if db == nil {
+		db = crd.db
+	}
+
+	var stats []*do.CouponDailyStatDO
+	if err := db.WithContext(ctx).
+		Where("coupon_template_id = ? AND stat_date >= ? AND stat_date <= ?", templateID, from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Order("stat_date ASC").
+		Find(&stats).Error; err != nil {
+		log.ErrorfC(ctx, "查询优惠券每日统计失败: %v", err)
+		return nil, err
+	}
+	return stats, nil
+}
+
+// SumCouponStatsByTemplate 按模板汇总[from, to]日期范围内的统计
+func (crd *couponReportData) SumCouponStatsByTemplate(ctx context.Context, db *gorm.DB, from, to time.Time) ([]*do.CouponDailyStatDO, error) {
+	if db == nil {
+		db = crd.db
+	}
+
+	var stats []*do.CouponDailyStatDO
+	if err := db.WithContext(ctx).Model(&do.CouponDailyStatDO{}).
+		Select("coupon_template_id, SUM(issued_count) AS issued_count, SUM(redeemed_count) AS redeemed_count, "+
+			"SUM(expired_count) AS expired_count, SUM(order_count) AS order_count, SUM(original_amount) AS original_amount, "+
+			"SUM(discount_amount) AS discount_amount, SUM(gmv) AS gmv").
+		Where("coupon_template_id > 0 AND stat_date >= ? AND stat_date <= ?", from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Group("coupon_template_id").
+		Order("coupon_template_id ASC").
+		Scan(&stats).Error; err != nil {
+		log.ErrorfC(ctx, "按模板汇总优惠券统计失败: %v", err)
+		return nil, err
+	}
+	return stats, nil
+}
+
+// ListFlashSaleStats 查询[from, to]日期范围内的秒杀统计
+func (crd *couponReportData) ListFlashSaleStats(ctx context.Context, db *gorm.DB, flashSaleID int64, from, to time.Time) ([]*do.FlashSaleDailyStatDO, error) {
+	if db == nil {
+		db = crd.db
+	}
+
+	query := db.WithContext(ctx).
+		Where("stat_date >= ? AND stat_date <= ?", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if flashSaleID > 0 {
+		query = query.Where("flash_sale_id = ?", flashSaleID)
+	}
+
+	var stats []*do.FlashSaleDailyStatDO
+	if err := query.Order("stat_date ASC, flash_sale_id ASC").Find(&stats).Error; err != nil {
+		log.ErrorfC(ctx, "查询秒杀每日统计失败: %v", err)
+		return nil, err
+	}
+	return stats, nil
+}
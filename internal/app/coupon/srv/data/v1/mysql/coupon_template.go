@@ -27,7 +27,7 @@ func (ctd *couponTemplateData) Create(ctx context.Context, db *gorm.DB, template
 	}
 	
 	if err := db.WithContext(ctx).Create(template).Error; err != nil {
-		log.Errorf("创建优惠券模板失败: %v", err)
+		log.ErrorfC(ctx, "创建优惠券模板失败: %v", err)
 		return err
 	}
 	return nil
@@ -40,7 +40,7 @@ func (ctd *couponTemplateData) Update(ctx context.Context, db *gorm.DB, template
 	}
 	
 	if err := db.WithContext(ctx).Save(template).Error; err != nil {
-		log.Errorf("更新优惠券模板失败: %v", err)
+		log.ErrorfC(ctx, "更新优惠券模板失败: %v", err)
 		return err
 	}
 	return nil
@@ -53,7 +53,7 @@ func (ctd *couponTemplateData) Delete(ctx context.Context, db *gorm.DB, id int64
 	}
 	
 	if err := db.WithContext(ctx).Where("id = ?", id).Delete(&do.CouponTemplateDO{}).Error; err != nil {
-		log.Errorf("删除优惠券模板失败: %v", err)
+		log.ErrorfC(ctx, "删除优惠券模板失败: %v", err)
 		return err
 	}
 	return nil
@@ -70,7 +70,7 @@ func (ctd *couponTemplateData) Get(ctx context.Context, db *gorm.DB, id int64) (
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
-		log.Errorf("获取优惠券模板失败: %v", err)
+		log.ErrorfC(ctx, "获取优惠券模板失败: %v", err)
 		return nil, err
 	}
 	return &template, nil
@@ -91,7 +91,7 @@ func (ctd *couponTemplateData) List(ctx context.Context, db *gorm.DB, status do.
 	// 计算总数
 	var totalCount int64
 	if err := query.Count(&totalCount).Error; err != nil {
-		log.Errorf("统计优惠券模板总数失败: %v", err)
+		log.ErrorfC(ctx, "统计优惠券模板总数失败: %v", err)
 		return nil, err
 	}
 	
@@ -110,7 +110,7 @@ func (ctd *couponTemplateData) List(ctx context.Context, db *gorm.DB, status do.
 	
 	var templates []*do.CouponTemplateDO
 	if err := query.Find(&templates).Error; err != nil {
-		log.Errorf("查询优惠券模板列表失败: %v", err)
+		log.ErrorfC(ctx, "查询优惠券模板列表失败: %v", err)
 		return nil, err
 	}
 	
@@ -128,7 +128,7 @@ func (ctd *couponTemplateData) GetByType(ctx context.Context, db *gorm.DB, coupo
 	
 	var templates []*do.CouponTemplateDO
 	if err := db.WithContext(ctx).Where("type = ? AND status = ?", couponType, do.CouponStatusActive).Find(&templates).Error; err != nil {
-		log.Errorf("根据类型查询优惠券模板失败: %v", err)
+		log.ErrorfC(ctx, "根据类型查询优惠券模板失败: %v", err)
 		return nil, err
 	}
 	return templates, nil
@@ -143,7 +143,7 @@ func (ctd *couponTemplateData) GetActiveTemplates(ctx context.Context, db *gorm.
 	var templates []*do.CouponTemplateDO
 	if err := db.WithContext(ctx).Where("status = ? AND valid_start_time <= ? AND valid_end_time >= ?", 
 		do.CouponStatusActive, currentTime, currentTime).Find(&templates).Error; err != nil {
-		log.Errorf("查询当前有效优惠券模板失败: %v", err)
+		log.ErrorfC(ctx, "查询当前有效优惠券模板失败: %v", err)
 		return nil, err
 	}
 	return templates, nil
@@ -158,7 +158,7 @@ func (ctd *couponTemplateData) UpdateUsedCount(ctx context.Context, db *gorm.DB,
 	if err := db.WithContext(ctx).Model(&do.CouponTemplateDO{}).
 		Where("id = ?", templateID).
 		UpdateColumn("used_count", gorm.Expr("used_count + ?", increment)).Error; err != nil {
-		log.Errorf("更新优惠券模板已使用数量失败: %v", err)
+		log.ErrorfC(ctx, "更新优惠券模板已使用数量失败: %v", err)
 		return err
 	}
 	return nil
@@ -183,7 +183,7 @@ func (ctd *couponTemplateData) GetAvailableTemplates(ctx context.Context, db *go
 			HAVING COUNT(*) >= (SELECT per_user_limit FROM coupon_templates WHERE id = coupon_template_id)
 		)
 	`, do.CouponStatusActive, currentTime, currentTime, userID).Find(&templates).Error; err != nil {
-		log.Errorf("查询用户可领取优惠券模板失败: %v", err)
+		log.ErrorfC(ctx, "查询用户可领取优惠券模板失败: %v", err)
 		return nil, err
 	}
 	return templates, nil
@@ -199,8 +199,24 @@ func (ctd *couponTemplateData) CheckTemplateAvailability(ctx context.Context, db
     if err := db.WithContext(ctx).Model(&do.CouponTemplateDO{}).
         Where("id = ? AND status = ? AND valid_start_time <= ? AND valid_end_time >= ? AND (total_count = 0 OR used_count < total_count)",
         templateID, do.CouponStatusActive, currentTime, currentTime).Count(&count).Error; err != nil {
-        log.Errorf("检查优惠券模板可用性失败: %v", err)
+        log.ErrorfC(ctx, "检查优惠券模板可用性失败: %v", err)
         return false, err
     }
 	return count > 0, nil
 }
+
+// IncrUsedCountIfAvailable 未超过发放总量时已发放数量加1，total_count为0表示不限量
+func (ctd *couponTemplateData) IncrUsedCountIfAvailable(ctx context.Context, db *gorm.DB, templateID int64) (bool, error) {
+	if db == nil {
+		db = ctd.db
+	}
+
+	result := db.WithContext(ctx).Model(&do.CouponTemplateDO{}).
+		Where("id = ? AND (total_count = 0 OR used_count < total_count)", templateID).
+		UpdateColumn("used_count", gorm.Expr("used_count + 1"))
+	if result.Error != nil {
+		log.ErrorfC(ctx, "更新优惠券模板已发放数量失败: %v", result.Error)
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
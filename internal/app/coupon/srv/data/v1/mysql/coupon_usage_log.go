@@ -27,7 +27,7 @@ func (culd *couponUsageLogData) Create(ctx context.Context, db *gorm.DB, log *do
 	}
 	
 	if err := db.WithContext(ctx).Create(log).Error; err != nil {
-		pkglog.Errorf("创建优惠券使用记录失败: %v", err)
+		pkglog.ErrorfC(ctx, "创建优惠券使用记录失败: %v", err)
 		return err
 	}
 	return nil
@@ -44,7 +44,7 @@ func (culd *couponUsageLogData) GetByUserCoupon(ctx context.Context, db *gorm.DB
 		Where("user_coupon_id = ?", userCouponID).
 		Order("created_at DESC").
 		Find(&logs).Error; err != nil {
-		pkglog.Errorf("根据用户优惠券ID查询使用记录失败: %v", err)
+		pkglog.ErrorfC(ctx, "根据用户优惠券ID查询使用记录失败: %v", err)
 		return nil, err
 	}
 	return logs, nil
@@ -61,7 +61,7 @@ func (culd *couponUsageLogData) GetByOrderSn(ctx context.Context, db *gorm.DB, o
 		Where("order_sn = ?", orderSn).
 		Order("created_at DESC").
 		Find(&logs).Error; err != nil {
-		pkglog.Errorf("根据订单号查询优惠券使用记录失败: %v", err)
+		pkglog.ErrorfC(ctx, "根据订单号查询优惠券使用记录失败: %v", err)
 		return nil, err
 	}
 	return logs, nil
@@ -82,7 +82,7 @@ func (culd *couponUsageLogData) List(ctx context.Context, db *gorm.DB, userID in
 	// 计算总数
 	var totalCount int64
 	if err := query.Count(&totalCount).Error; err != nil {
-		pkglog.Errorf("统计优惠券使用记录总数失败: %v", err)
+		pkglog.ErrorfC(ctx, "统计优惠券使用记录总数失败: %v", err)
 		return nil, err
 	}
 	
@@ -98,7 +98,7 @@ func (culd *couponUsageLogData) List(ctx context.Context, db *gorm.DB, userID in
 	
 	var logs []*do.CouponUsageLogDO
 	if err := query.Find(&logs).Error; err != nil {
-		pkglog.Errorf("查询优惠券使用记录列表失败: %v", err)
+		pkglog.ErrorfC(ctx, "查询优惠券使用记录列表失败: %v", err)
 		return nil, err
 	}
 	
@@ -124,7 +124,7 @@ func (culd *couponUsageLogData) GetUserUsageStats(ctx context.Context, db *gorm.
 		Select("COUNT(*) as total_count, SUM(discount_amount) as total_discount, AVG(discount_amount) as avg_discount").
 		Where("user_id = ? AND created_at BETWEEN ? AND ?", userID, startTime, endTime).
 		Find(&stats).Error; err != nil {
-		pkglog.Errorf("查询用户优惠券使用统计失败: %v", err)
+		pkglog.ErrorfC(ctx, "查询用户优惠券使用统计失败: %v", err)
 		return nil, err
 	}
 	
@@ -1,6 +1,7 @@
 package mysql
 
 import (
+	"emshop/gin-micro/core/health"
 	"emshop/internal/app/coupon/srv/data/v1/interfaces"
 	"emshop/internal/app/pkg/options"
 	gormtrace "emshop/pkg/observability/gormtrace"
@@ -24,8 +25,15 @@ type dataFactory struct {
 	userCouponData         interfaces.UserCouponDataInterface
 	couponUsageLogData     interfaces.CouponUsageLogDataInterface
 	couponConfigData       interfaces.CouponConfigDataInterface
+	couponReminderData     interfaces.CouponReminderDataInterface
+	promoCodeData          interfaces.PromoCodeDataInterface
+	couponCampaignData     interfaces.CouponCampaignDataInterface
+	campaignTargetData     interfaces.CampaignTargetDataInterface
+	couponReportData       interfaces.CouponReportDataInterface
+	riskDecisionData       interfaces.RiskDecisionDataInterface
 	flashSaleData          interfaces.FlashSaleDataInterface
 	flashSaleRecordData    interfaces.FlashSaleRecordDataInterface
+	flashSaleReservationData interfaces.FlashSaleReservationDataInterface
 }
 
 // NewDataFactory 创建优惠券服务数据工厂
@@ -42,8 +50,15 @@ func NewDataFactory(mysqlOpts *options.MySQLOptions) (interfaces.DataFactory, er
 		factory.userCouponData = NewUserCouponData(factory.db)
 		factory.couponUsageLogData = NewCouponUsageLogData(factory.db)
 		factory.couponConfigData = NewCouponConfigData(factory.db)
+		factory.couponReminderData = NewCouponReminderData(factory.db)
+		factory.promoCodeData = NewPromoCodeData(factory.db)
+		factory.couponCampaignData = NewCouponCampaignData(factory.db)
+		factory.campaignTargetData = NewCampaignTargetData(factory.db)
+		factory.couponReportData = NewCouponReportData(factory.db)
+		factory.riskDecisionData = NewRiskDecisionData(factory.db)
 		factory.flashSaleData = NewFlashSaleData(factory.db)
 		factory.flashSaleRecordData = NewFlashSaleRecordData(factory.db)
+		factory.flashSaleReservationData = NewFlashSaleReservationData(factory.db)
 	})
 	
 	return factory, err
@@ -78,6 +93,7 @@ func (f *dataFactory) initMySQL(mysqlOpts *options.MySQLOptions) error {
 	sqlDB.SetMaxIdleConns(mysqlOpts.MaxIdleConnections)
 	sqlDB.SetMaxOpenConns(mysqlOpts.MaxOpenConnections)
 	sqlDB.SetConnMaxLifetime(mysqlOpts.MaxConnectionLifetime)
+	health.Register("mysql", health.PingCheck(sqlDB))
 	
 	f.db = db
 	
@@ -105,6 +121,36 @@ func (f *dataFactory) CouponConfigs() interfaces.CouponConfigDataInterface {
 	return f.couponConfigData
 }
 
+// CouponReminders 获取优惠券到期提醒数据访问对象
+func (f *dataFactory) CouponReminders() interfaces.CouponReminderDataInterface {
+	return f.couponReminderData
+}
+
+// PromoCodes 获取优惠券兑换码数据访问对象
+func (f *dataFactory) PromoCodes() interfaces.PromoCodeDataInterface {
+	return f.promoCodeData
+}
+
+// CouponCampaigns 获取定向发券活动数据访问对象
+func (f *dataFactory) CouponCampaigns() interfaces.CouponCampaignDataInterface {
+	return f.couponCampaignData
+}
+
+// CampaignTargets 获取发券活动目标用户数据访问对象
+func (f *dataFactory) CampaignTargets() interfaces.CampaignTargetDataInterface {
+	return f.campaignTargetData
+}
+
+// CouponReports 获取优惠券报表数据访问对象
+func (f *dataFactory) CouponReports() interfaces.CouponReportDataInterface {
+	return f.couponReportData
+}
+
+// RiskDecisions 获取风控决策记录数据访问对象
+func (f *dataFactory) RiskDecisions() interfaces.RiskDecisionDataInterface {
+	return f.riskDecisionData
+}
+
 // FlashSales 获取秒杀活动数据访问对象
 func (f *dataFactory) FlashSales() interfaces.FlashSaleDataInterface {
 	return f.flashSaleData
@@ -115,6 +161,11 @@ func (f *dataFactory) FlashSaleRecords() interfaces.FlashSaleRecordDataInterface
 	return f.flashSaleRecordData
 }
 
+// FlashSaleReservations 获取商品秒杀预占单数据访问对象
+func (f *dataFactory) FlashSaleReservations() interfaces.FlashSaleReservationDataInterface {
+	return f.flashSaleReservationData
+}
+
 // DB 获取数据库连接
 func (f *dataFactory) DB() *gorm.DB {
 	return f.db
@@ -27,7 +27,7 @@ func (fsd *flashSaleData) Create(ctx context.Context, db *gorm.DB, activity *do.
 	}
 	
 	if err := db.WithContext(ctx).Create(activity).Error; err != nil {
-		log.Errorf("创建秒杀活动失败: %v", err)
+		log.ErrorfC(ctx, "创建秒杀活动失败: %v", err)
 		return err
 	}
 	return nil
@@ -40,7 +40,7 @@ func (fsd *flashSaleData) Update(ctx context.Context, db *gorm.DB, activity *do.
 	}
 	
 	if err := db.WithContext(ctx).Save(activity).Error; err != nil {
-		log.Errorf("更新秒杀活动失败: %v", err)
+		log.ErrorfC(ctx, "更新秒杀活动失败: %v", err)
 		return err
 	}
 	return nil
@@ -53,7 +53,7 @@ func (fsd *flashSaleData) Delete(ctx context.Context, db *gorm.DB, id int64) err
 	}
 	
 	if err := db.WithContext(ctx).Where("id = ?", id).Delete(&do.FlashSaleActivityDO{}).Error; err != nil {
-		log.Errorf("删除秒杀活动失败: %v", err)
+		log.ErrorfC(ctx, "删除秒杀活动失败: %v", err)
 		return err
 	}
 	return nil
@@ -70,7 +70,7 @@ func (fsd *flashSaleData) Get(ctx context.Context, db *gorm.DB, id int64) (*do.F
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
-		log.Errorf("获取秒杀活动失败: %v", err)
+		log.ErrorfC(ctx, "获取秒杀活动失败: %v", err)
 		return nil, err
 	}
 	return &activity, nil
@@ -91,7 +91,7 @@ func (fsd *flashSaleData) List(ctx context.Context, db *gorm.DB, status do.Flash
 	// 计算总数
 	var totalCount int64
 	if err := query.Count(&totalCount).Error; err != nil {
-		log.Errorf("统计秒杀活动总数失败: %v", err)
+		log.ErrorfC(ctx, "统计秒杀活动总数失败: %v", err)
 		return nil, err
 	}
 	
@@ -110,7 +110,7 @@ func (fsd *flashSaleData) List(ctx context.Context, db *gorm.DB, status do.Flash
 	
 	var activities []*do.FlashSaleActivityDO
 	if err := query.Find(&activities).Error; err != nil {
-		log.Errorf("查询秒杀活动列表失败: %v", err)
+		log.ErrorfC(ctx, "查询秒杀活动列表失败: %v", err)
 		return nil, err
 	}
 	
@@ -128,7 +128,7 @@ func (fsd *flashSaleData) GetByStatus(ctx context.Context, db *gorm.DB, status d
 	
 	var activities []*do.FlashSaleActivityDO
 	if err := db.WithContext(ctx).Where("status = ?", status).Find(&activities).Error; err != nil {
-		log.Errorf("根据状态查询秒杀活动失败: %v", err)
+		log.ErrorfC(ctx, "根据状态查询秒杀活动失败: %v", err)
 		return nil, err
 	}
 	return activities, nil
@@ -143,7 +143,7 @@ func (fsd *flashSaleData) GetActiveActivities(ctx context.Context, db *gorm.DB,
 	var activities []*do.FlashSaleActivityDO
 	if err := db.WithContext(ctx).Where("status = ? AND start_time <= ? AND end_time >= ?", 
 		do.FlashSaleStatusActive, currentTime, currentTime).Find(&activities).Error; err != nil {
-		log.Errorf("查询当前进行中的秒杀活动失败: %v", err)
+		log.ErrorfC(ctx, "查询当前进行中的秒杀活动失败: %v", err)
 		return nil, err
 	}
 	return activities, nil
@@ -164,7 +164,7 @@ func (fsd *flashSaleData) GetUpcomingActivities(ctx context.Context, db *gorm.DB
 	}
 	
 	if err := query.Find(&activities).Error; err != nil {
-		log.Errorf("查询即将开始的秒杀活动失败: %v", err)
+		log.ErrorfC(ctx, "查询即将开始的秒杀活动失败: %v", err)
 		return nil, err
 	}
 	return activities, nil
@@ -178,7 +178,7 @@ func (fsd *flashSaleData) GetByCouponTemplate(ctx context.Context, db *gorm.DB,
 	
 	var activities []*do.FlashSaleActivityDO
 	if err := db.WithContext(ctx).Where("coupon_template_id = ?", templateID).Find(&activities).Error; err != nil {
-		log.Errorf("根据优惠券模板查询秒杀活动失败: %v", err)
+		log.ErrorfC(ctx, "根据优惠券模板查询秒杀活动失败: %v", err)
 		return nil, err
 	}
 	return activities, nil
@@ -193,7 +193,7 @@ func (fsd *flashSaleData) UpdateSoldCount(ctx context.Context, db *gorm.DB, id i
 	if err := db.WithContext(ctx).Model(&do.FlashSaleActivityDO{}).
 		Where("id = ?", id).
 		UpdateColumn("sold_count", gorm.Expr("sold_count + ?", increment)).Error; err != nil {
-		log.Errorf("更新秒杀活动已售数量失败: %v", err)
+		log.ErrorfC(ctx, "更新秒杀活动已售数量失败: %v", err)
 		return err
 	}
 	return nil
@@ -207,7 +207,7 @@ func (fsd *flashSaleData) UpdateStatus(ctx context.Context, db *gorm.DB, id int6
 	
 	if err := db.WithContext(ctx).Model(&do.FlashSaleActivityDO{}).
 		Where("id = ?", id).Update("status", status).Error; err != nil {
-		log.Errorf("更新秒杀活动状态失败: %v", err)
+		log.ErrorfC(ctx, "更新秒杀活动状态失败: %v", err)
 		return err
 	}
 	return nil
@@ -221,7 +221,7 @@ func (fsd *flashSaleData) CheckStock(ctx context.Context, db *gorm.DB, id int64)
 	
 	var activity do.FlashSaleActivityDO
 	if err := db.WithContext(ctx).Where("id = ?", id).First(&activity).Error; err != nil {
-		log.Errorf("查询秒杀活动库存失败: %v", err)
+		log.ErrorfC(ctx, "查询秒杀活动库存失败: %v", err)
 		return nil, err
 	}
 	
@@ -241,4 +241,52 @@ func (fsd *flashSaleData) IncrementSoldCount(ctx context.Context, db *gorm.DB, i
 // DecrementSoldCount 减少已售数量
 func (fsd *flashSaleData) DecrementSoldCount(ctx context.Context, db *gorm.DB, id int64) error {
 	return fsd.UpdateSoldCount(ctx, db, id, -1)
-}
\ No newline at end of file
+}
+
+// SetSoldCount 以对账结果覆盖已售数量
+func (fsd *flashSaleData) SetSoldCount(ctx context.Context, db *gorm.DB, id int64, soldCount int32) error {
+	if db == nil {
+		db = fsd.db
+	}
+	
+	if err := db.WithContext(ctx).Model(&do.FlashSaleActivityDO{}).
+		Where("id = ?", id).UpdateColumn("sold_count", soldCount).Error; err != nil {
+		log.ErrorfC(ctx, "设置秒杀活动已售数量失败: %v", err)
+		return err
+	}
+	return nil
+}
+
+// TransitStatus 仅当当前状态属于from时更新为to，返回是否发生了状态变更
+func (fsd *flashSaleData) TransitStatus(ctx context.Context, db *gorm.DB, id int64, from []do.FlashSaleStatus, to do.FlashSaleStatus) (bool, error) {
+	if db == nil {
+		db = fsd.db
+	}
+	
+	result := db.WithContext(ctx).Model(&do.FlashSaleActivityDO{}).
+		Where("id = ? AND status IN ?", id, from).Update("status", to)
+	if result.Error != nil {
+		log.ErrorfC(ctx, "变更秒杀活动状态失败: %v", result.Error)
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// FindLifecycleDue 查找在before之前需要开始或结束的秒杀活动
+func (fsd *flashSaleData) FindLifecycleDue(ctx context.Context, db *gorm.DB, before time.Time) ([]*do.FlashSaleActivityDO, error) {
+	if db == nil {
+		db = fsd.db
+	}
+	
+	var activities []*do.FlashSaleActivityDO
+	if err := db.WithContext(ctx).
+		Where("(status = ? AND start_time <= ?) OR (status IN ? AND end_time <= ?)",
+			do.FlashSaleStatusPending, before,
+			[]do.FlashSaleStatus{do.FlashSaleStatusPending, do.FlashSaleStatusActive}, before).
+		Order("start_time ASC").
+		Find(&activities).Error; err != nil {
+		log.ErrorfC(ctx, "查询待调度的秒杀活动失败: %v", err)
+		return nil, err
+	}
+	return activities, nil
+}
@@ -26,7 +26,7 @@ func (fsrd *flashSaleRecordData) Create(ctx context.Context, db *gorm.DB, record
 	}
 	
 	if err := db.WithContext(ctx).Create(record).Error; err != nil {
-		log.Errorf("创建秒杀记录失败: %v", err)
+		log.ErrorfC(ctx, "创建秒杀记录失败: %v", err)
 		return err
 	}
 	return nil
@@ -39,7 +39,7 @@ func (fsrd *flashSaleRecordData) Update(ctx context.Context, db *gorm.DB, record
 	}
 	
 	if err := db.WithContext(ctx).Save(record).Error; err != nil {
-		log.Errorf("更新秒杀记录失败: %v", err)
+		log.ErrorfC(ctx, "更新秒杀记录失败: %v", err)
 		return err
 	}
 	return nil
@@ -56,7 +56,7 @@ func (fsrd *flashSaleRecordData) Get(ctx context.Context, db *gorm.DB, id int64)
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
-		log.Errorf("获取秒杀记录失败: %v", err)
+		log.ErrorfC(ctx, "获取秒杀记录失败: %v", err)
 		return nil, err
 	}
 	return &record, nil
@@ -73,7 +73,7 @@ func (fsrd *flashSaleRecordData) GetByFlashSaleAndUser(ctx context.Context, db *
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
-		log.Errorf("根据秒杀活动和用户获取记录失败: %v", err)
+		log.ErrorfC(ctx, "根据秒杀活动和用户获取记录失败: %v", err)
 		return nil, err
 	}
 	return &record, nil
@@ -90,7 +90,7 @@ func (fsrd *flashSaleRecordData) GetUserRecords(ctx context.Context, db *gorm.DB
 	// 计算总数
 	var totalCount int64
 	if err := query.Count(&totalCount).Error; err != nil {
-		log.Errorf("统计用户秒杀记录总数失败: %v", err)
+		log.ErrorfC(ctx, "统计用户秒杀记录总数失败: %v", err)
 		return nil, err
 	}
 	
@@ -106,7 +106,7 @@ func (fsrd *flashSaleRecordData) GetUserRecords(ctx context.Context, db *gorm.DB
 	
 	var records []*do.FlashSaleRecordDO
 	if err := query.Find(&records).Error; err != nil {
-		log.Errorf("查询用户秒杀记录列表失败: %v", err)
+		log.ErrorfC(ctx, "查询用户秒杀记录列表失败: %v", err)
 		return nil, err
 	}
 	
@@ -127,7 +127,7 @@ func (fsrd *flashSaleRecordData) GetFlashSaleRecords(ctx context.Context, db *go
 	// 计算总数
 	var totalCount int64
 	if err := query.Count(&totalCount).Error; err != nil {
-		log.Errorf("统计秒杀活动记录总数失败: %v", err)
+		log.ErrorfC(ctx, "统计秒杀活动记录总数失败: %v", err)
 		return nil, err
 	}
 	
@@ -143,7 +143,7 @@ func (fsrd *flashSaleRecordData) GetFlashSaleRecords(ctx context.Context, db *go
 	
 	var records []*do.FlashSaleRecordDO
 	if err := query.Find(&records).Error; err != nil {
-		log.Errorf("查询秒杀活动记录列表失败: %v", err)
+		log.ErrorfC(ctx, "查询秒杀活动记录列表失败: %v", err)
 		return nil, err
 	}
 	
@@ -164,7 +164,7 @@ func (fsrd *flashSaleRecordData) GetUserFlashSaleHistory(ctx context.Context, db
 		Where("user_id = ? AND flash_sale_id = ?", userID, flashSaleID).
 		Order("created_at DESC").
 		Find(&records).Error; err != nil {
-		log.Errorf("获取用户秒杀历史记录失败: %v", err)
+		log.ErrorfC(ctx, "获取用户秒杀历史记录失败: %v", err)
 		return nil, err
 	}
 	return records, nil
@@ -180,7 +180,7 @@ func (fsrd *flashSaleRecordData) CountUserParticipation(ctx context.Context, db
 	if err := db.WithContext(ctx).Model(&do.FlashSaleRecordDO{}).
 		Where("user_id = ? AND flash_sale_id = ?", userID, flashSaleID).
 		Count(&count).Error; err != nil {
-		log.Errorf("统计用户参与次数失败: %v", err)
+		log.ErrorfC(ctx, "统计用户参与次数失败: %v", err)
 		return 0, err
 	}
 	return count, nil
@@ -196,7 +196,7 @@ func (fsrd *flashSaleRecordData) CountSuccessfulParticipation(ctx context.Contex
 	if err := db.WithContext(ctx).Model(&do.FlashSaleRecordDO{}).
 		Where("flash_sale_id = ? AND status = ?", flashSaleID, do.FlashSaleRecordStatusSuccess).
 		Count(&count).Error; err != nil {
-		log.Errorf("统计成功参与次数失败: %v", err)
+		log.ErrorfC(ctx, "统计成功参与次数失败: %v", err)
 		return 0, err
 	}
 	return count, nil
@@ -217,7 +217,7 @@ func (fsrd *flashSaleRecordData) GetFlashSaleStatistics(ctx context.Context, db
 		Select("COUNT(DISTINCT user_id) as total_participants, COUNT(CASE WHEN status = ? THEN 1 END) as success_participants", do.FlashSaleRecordStatusSuccess).
 		Where("flash_sale_id = ?", flashSaleID).
 		Find(&stats).Error; err != nil {
-		log.Errorf("获取秒杀统计信息失败: %v", err)
+		log.ErrorfC(ctx, "获取秒杀统计信息失败: %v", err)
 		return nil, err
 	}
 	
@@ -244,7 +244,7 @@ func (fsrd *flashSaleRecordData) UpdateStatus(ctx context.Context, db *gorm.DB,
 	
 	if err := db.WithContext(ctx).Model(&do.FlashSaleRecordDO{}).
 		Where("id = ?", id).Update("status", status).Error; err != nil {
-		log.Errorf("更新秒杀记录状态失败: %v", err)
+		log.ErrorfC(ctx, "更新秒杀记录状态失败: %v", err)
 		return err
 	}
 	return nil
@@ -258,7 +258,7 @@ func (fsrd *flashSaleRecordData) UpdateUserCouponID(ctx context.Context, db *gor
 	
 	if err := db.WithContext(ctx).Model(&do.FlashSaleRecordDO{}).
 		Where("id = ?", id).Update("user_coupon_id", userCouponID).Error; err != nil {
-		log.Errorf("更新秒杀记录用户优惠券ID失败: %v", err)
+		log.ErrorfC(ctx, "更新秒杀记录用户优惠券ID失败: %v", err)
 		return err
 	}
 	return nil
@@ -271,7 +271,7 @@ func (fsrd *flashSaleRecordData) BatchCreate(ctx context.Context, db *gorm.DB, r
 	}
 	
 	if err := db.WithContext(ctx).CreateInBatches(records, 100).Error; err != nil {
-		log.Errorf("批量创建秒杀记录失败: %v", err)
+		log.ErrorfC(ctx, "批量创建秒杀记录失败: %v", err)
 		return err
 	}
 	return nil
@@ -0,0 +1,90 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"emshop/internal/app/coupon/srv/domain/do"
+	"emshop/pkg/log"
+
+	"gorm.io/gorm"
+)
+
+type flashSaleReservationData struct {
+	db *gorm.DB
+}
+
+// NewFlashSaleReservationData 创建商品秒杀预占单数据访问对象
+func NewFlashSaleReservationData(db *gorm.DB) *flashSaleReservationData {
+	return &flashSaleReservationData{
+		db: db,
+	}
+}
+
+// Create 写入预占单
+func (frd *flashSaleReservationData) Create(ctx context.Context, db *gorm.DB, reservation *do.FlashSaleReservationDO) error {
+	if db == nil {
+		db = frd.db
+	}
+
+	if err := db.WithContext(ctx).Create(reservation).Error; err != nil {
+		log.ErrorfC(ctx, "写入秒杀预占单失败: %v", err)
+		return err
+	}
+	return nil
+}
+
+// GetByOrderSn 按预占单号查询，不存在时返回nil
+func (frd *flashSaleReservationData) GetByOrderSn(ctx context.Context, db *gorm.DB, orderSn string) (*do.FlashSaleReservationDO, error) {
+	if db == nil {
+		db = frd.db
+	}
+
+	var reservation do.FlashSaleReservationDO
+	if err := db.WithContext(ctx).Where("order_sn = ?", orderSn).First(&reservation).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		log.ErrorfC(ctx, "查询秒杀预占单失败: %v", err)
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// ListExpired 查询before之前到期仍处于预占状态的记录，按到期时间升序
+func (frd *flashSaleReservationData) ListExpired(ctx context.Context, db *gorm.DB, before time.Time, limit int) ([]*do.FlashSaleReservationDO, error) {
+	if db == nil {
+		db = frd.db
+	}
+
+	var items []*do.FlashSaleReservationDO
+	if err := db.WithContext(ctx).
+		Where("status = ? AND expires_at < ?", do.FlashSaleReservationReserved, before).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&items).Error; err != nil {
+		log.ErrorfC(ctx, "查询到期秒杀预占单失败: %v", err)
+		return nil, err
+	}
+	return items, nil
+}
+
+// TransitStatus 仅当状态为from时更新为to，返回是否发生了状态变更
+func (frd *flashSaleReservationData) TransitStatus(ctx context.Context, db *gorm.DB, orderSn string, from, to do.FlashSaleReservationStatus) (bool, error) {
+	if db == nil {
+		db = frd.db
+	}
+
+	now := time.Now()
+	result := db.WithContext(ctx).Model(&do.FlashSaleReservationDO{}).
+		Where("order_sn = ? AND status = ?", orderSn, from).
+		Updates(map[string]interface{}{
+			"status":     to,
+			"settled_at": &now,
+		})
+	if result.Error != nil {
+		log.ErrorfC(ctx, "变更秒杀预占单状态失败: %v", result.Error)
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
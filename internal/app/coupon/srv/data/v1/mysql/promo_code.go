@@ -0,0 +1,158 @@
+package mysql
+
+import (
+	"context"
+
+	"emshop/internal/app/coupon/srv/domain/do"
+	v1 "emshop/pkg/common/meta/v1"
+	"emshop/pkg/log"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type promoCodeData struct {
+	db *gorm.DB
+}
+
+// NewPromoCodeData 创建优惠券兑换码数据访问对象
+func NewPromoCodeData(db *gorm.DB) *promoCodeData {
+	return &promoCodeData{
+		db: db,
+	}
+}
+
+// Create 创建兑换码
+func (pcd *promoCodeData) Create(ctx context.Context, db *gorm.DB, promoCode *do.PromoCodeDO) error {
+	if db == nil {
+		db = pcd.db
+	}
+
+	if err := db.WithContext(ctx).Create(promoCode).Error; err != nil {
+		log.ErrorfC(ctx, "创建兑换码失败: %v", err)
+		return err
+	}
+	return nil
+}
+
+// BatchCreate 批量写入兑换码，与已有兑换码冲突的记录被忽略
+func (pcd *promoCodeData) BatchCreate(ctx context.Context, db *gorm.DB, promoCodes []*do.PromoCodeDO) (int64, error) {
+	if db == nil {
+		db = pcd.db
+	}
+	if len(promoCodes) == 0 {
+		return 0, nil
+	}
+
+	result := db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		CreateInBatches(promoCodes, 500)
+	if result.Error != nil {
+		log.ErrorfC(ctx, "批量创建兑换码失败: %v", result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// GetByCode 根据兑换码查询
+func (pcd *promoCodeData) GetByCode(ctx context.Context, db *gorm.DB, code string) (*do.PromoCodeDO, error) {
+	if db == nil {
+		db = pcd.db
+	}
+
+	var promoCode do.PromoCodeDO
+	if err := db.WithContext(ctx).Where("code = ?", code).First(&promoCode).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		log.ErrorfC(ctx, "查询兑换码失败: %v", err)
+		return nil, err
+	}
+	return &promoCode, nil
+}
+
+// List 按模板和批次分页查询兑换码，按ID升序便于导出
+func (pcd *promoCodeData) List(ctx context.Context, db *gorm.DB, templateID int64, batchNo string, meta v1.ListMeta) (*do.PromoCodeDOList, error) {
+	if db == nil {
+		db = pcd.db
+	}
+
+	query := db.WithContext(ctx).Model(&do.PromoCodeDO{})
+	if templateID > 0 {
+		query = query.Where("coupon_template_id = ?", templateID)
+	}
+	if batchNo != "" {
+		query = query.Where("batch_no = ?", batchNo)
+	}
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		log.ErrorfC(ctx, "统计兑换码总数失败: %v", err)
+		return nil, err
+	}
+
+	if meta.Page > 0 {
+		query = query.Offset((meta.Page - 1) * meta.PageSize)
+	}
+	if meta.PageSize > 0 {
+		query = query.Limit(meta.PageSize)
+	}
+
+	var items []*do.PromoCodeDO
+	if err := query.Order("id ASC").Find(&items).Error; err != nil {
+		log.ErrorfC(ctx, "查询兑换码列表失败: %v", err)
+		return nil, err
+	}
+
+	return &do.PromoCodeDOList{
+		TotalCount: totalCount,
+		Items:      items,
+	}, nil
+}
+
+// UpdateStatus 更新兑换码状态
+func (pcd *promoCodeData) UpdateStatus(ctx context.Context, db *gorm.DB, id int64, status do.PromoCodeStatus) error {
+	if db == nil {
+		db = pcd.db
+	}
+
+	if err := db.WithContext(ctx).Model(&do.PromoCodeDO{}).
+		Where("id = ?", id).
+		Update("status", status).Error; err != nil {
+		log.ErrorfC(ctx, "更新兑换码状态失败: %v", err)
+		return err
+	}
+	return nil
+}
+
+// IncrRedeemed 条件更新兑换次数，并发兑换同一兑换码时不会超出最大兑换次数
+func (pcd *promoCodeData) IncrRedeemed(ctx context.Context, db *gorm.DB, id int64) (bool, error) {
+	if db == nil {
+		db = pcd.db
+	}
+
+	result := db.WithContext(ctx).Model(&do.PromoCodeDO{}).
+		Where("id = ? AND status = ? AND (max_redemptions = 0 OR redeemed_count < max_redemptions)", id, do.PromoCodeStatusActive).
+		UpdateColumn("redeemed_count", gorm.Expr("redeemed_count + 1"))
+	if result.Error != nil {
+		log.ErrorfC(ctx, "更新兑换码兑换次数失败: %v", result.Error)
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CreateRedemption 写入兑换记录，依赖(promo_code_id, user_id)唯一索引防止重复兑换
+func (pcd *promoCodeData) CreateRedemption(ctx context.Context, db *gorm.DB, redemption *do.PromoCodeRedemptionDO) (bool, error) {
+	if db == nil {
+		db = pcd.db
+	}
+
+	result := db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(redemption)
+	if result.Error != nil {
+		log.ErrorfC(ctx, "创建兑换记录失败: %v", result.Error)
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
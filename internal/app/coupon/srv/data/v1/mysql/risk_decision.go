@@ -0,0 +1,91 @@
+package mysql
+
+import (
+	"context"
+
+	"emshop/internal/app/coupon/srv/domain/do"
+	v1 "emshop/pkg/common/meta/v1"
+	"emshop/pkg/log"
+
+	"gorm.io/gorm"
+)
+
+type riskDecisionData struct {
+	db *gorm.DB
+}
+
+// NewRiskDecisionData 创建风控决策记录数据访问对象
+func NewRiskDecisionData(db *gorm.DB) *riskDecisionData {
+	return &riskDecisionData{
+		db: db,
+	}
+}
+
+// Create 写入决策记录
+func (rdd *riskDecisionData) Create(ctx context.Context, db *gorm.DB, decision *do.RiskDecisionDO) error {
+	if db == nil {
+		db = rdd.db
+	}
+
+	if err := db.WithContext(ctx).Create(decision).Error; err != nil {
+		log.ErrorfC(ctx, "写入风控决策记录失败: %v", err)
+		return err
+	}
+	return nil
+}
+
+// List 按创建时间倒序分页查询决策记录
+func (rdd *riskDecisionData) List(ctx context.Context, db *gorm.DB, filter *do.RiskDecisionFilter, meta v1.ListMeta) (*do.RiskDecisionDOList, error) {
+	if db == nil {
+		db = rdd.db
+	}
+
+	query := db.WithContext(ctx).Model(&do.RiskDecisionDO{})
+	if filter != nil {
+		if filter.Scene != "" {
+			query = query.Where("scene = ?", filter.Scene)
+		}
+		if filter.Action != "" {
+			query = query.Where("action = ?", filter.Action)
+		}
+		if filter.UserID > 0 {
+			query = query.Where("user_id = ?", filter.UserID)
+		}
+		if filter.DeviceID != "" {
+			query = query.Where("device_id = ?", filter.DeviceID)
+		}
+		if filter.ClientIP != "" {
+			query = query.Where("client_ip = ?", filter.ClientIP)
+		}
+		if !filter.From.IsZero() {
+			query = query.Where("created_at >= ?", filter.From)
+		}
+		if !filter.To.IsZero() {
+			query = query.Where("created_at < ?", filter.To)
+		}
+	}
+
+	var totalCount int64
+	if err := query.Count(&totalCount).Error; err != nil {
+		log.ErrorfC(ctx, "统计风控决策记录失败: %v", err)
+		return nil, err
+	}
+
+	if meta.Page > 0 {
+		query = query.Offset((meta.Page - 1) * meta.PageSize)
+	}
+	if meta.PageSize > 0 {
+		query = query.Limit(meta.PageSize)
+	}
+
+	var items []*do.RiskDecisionDO
+	if err := query.Order("id DESC").Find(&items).Error; err != nil {
+		log.ErrorfC(ctx, "查询风控决策记录失败: %v", err)
+		return nil, err
+	}
+
+	return &do.RiskDecisionDOList{
+		TotalCount: totalCount,
+		Items:      items,
+	}, nil
+}
@@ -27,7 +27,7 @@ func (ucd *userCouponData) Create(ctx context.Context, db *gorm.DB, userCoupon *
 	}
 	
 	if err := db.WithContext(ctx).Create(userCoupon).Error; err != nil {
-		log.Errorf("创建用户优惠券失败: %v", err)
+		log.ErrorfC(ctx, "创建用户优惠券失败: %v", err)
 		return err
 	}
 	return nil
@@ -40,7 +40,7 @@ func (ucd *userCouponData) Update(ctx context.Context, db *gorm.DB, userCoupon *
 	}
 	
 	if err := db.WithContext(ctx).Save(userCoupon).Error; err != nil {
-		log.Errorf("更新用户优惠券失败: %v", err)
+		log.ErrorfC(ctx, "更新用户优惠券失败: %v", err)
 		return err
 	}
 	return nil
@@ -53,7 +53,7 @@ func (ucd *userCouponData) Delete(ctx context.Context, db *gorm.DB, id int64) er
 	}
 	
 	if err := db.WithContext(ctx).Where("id = ?", id).Delete(&do.UserCouponDO{}).Error; err != nil {
-		log.Errorf("删除用户优惠券失败: %v", err)
+		log.ErrorfC(ctx, "删除用户优惠券失败: %v", err)
 		return err
 	}
 	return nil
@@ -70,7 +70,7 @@ func (ucd *userCouponData) Get(ctx context.Context, db *gorm.DB, id int64) (*do.
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
-		log.Errorf("获取用户优惠券失败: %v", err)
+		log.ErrorfC(ctx, "获取用户优惠券失败: %v", err)
 		return nil, err
 	}
 	return &userCoupon, nil
@@ -87,7 +87,7 @@ func (ucd *userCouponData) GetByCouponCode(ctx context.Context, db *gorm.DB, cou
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
-		log.Errorf("根据优惠券码获取用户优惠券失败: %v", err)
+		log.ErrorfC(ctx, "根据优惠券码获取用户优惠券失败: %v", err)
 		return nil, err
 	}
 	return &userCoupon, nil
@@ -108,7 +108,7 @@ func (ucd *userCouponData) GetUserCoupons(ctx context.Context, db *gorm.DB, user
 	// 计算总数
 	var totalCount int64
 	if err := query.Count(&totalCount).Error; err != nil {
-		log.Errorf("统计用户优惠券总数失败: %v", err)
+		log.ErrorfC(ctx, "统计用户优惠券总数失败: %v", err)
 		return nil, err
 	}
 	
@@ -124,7 +124,7 @@ func (ucd *userCouponData) GetUserCoupons(ctx context.Context, db *gorm.DB, user
 	
 	var userCoupons []*do.UserCouponDO
 	if err := query.Find(&userCoupons).Error; err != nil {
-		log.Errorf("查询用户优惠券列表失败: %v", err)
+		log.ErrorfC(ctx, "查询用户优惠券列表失败: %v", err)
 		return nil, err
 	}
 	
@@ -146,7 +146,7 @@ func (ucd *userCouponData) GetUserAvailableCoupons(ctx context.Context, db *gorm
 		Where("user_coupons.user_id = ? AND user_coupons.status = ? AND user_coupons.expired_at > ? AND coupon_templates.min_order_amount <= ?",
 			userID, do.UserCouponStatusUnused, currentTime, orderAmount).
 		Find(&userCoupons).Error; err != nil {
-		log.Errorf("查询用户可用优惠券失败: %v", err)
+		log.ErrorfC(ctx, "查询用户可用优惠券失败: %v", err)
 		return nil, err
 	}
 	return userCoupons, nil
@@ -162,7 +162,7 @@ func (ucd *userCouponData) GetUserCouponsByTemplate(ctx context.Context, db *gor
 	if err := db.WithContext(ctx).
 		Where("user_id = ? AND coupon_template_id = ?", userID, templateID).
 		Find(&userCoupons).Error; err != nil {
-		log.Errorf("根据模板查询用户优惠券失败: %v", err)
+		log.ErrorfC(ctx, "根据模板查询用户优惠券失败: %v", err)
 		return nil, err
 	}
 	return userCoupons, nil
@@ -176,7 +176,7 @@ func (ucd *userCouponData) UpdateStatus(ctx context.Context, db *gorm.DB, id int
 	
 	if err := db.WithContext(ctx).Model(&do.UserCouponDO{}).
 		Where("id = ?", id).Update("status", status).Error; err != nil {
-		log.Errorf("更新用户优惠券状态失败: %v", err)
+		log.ErrorfC(ctx, "更新用户优惠券状态失败: %v", err)
 		return err
 	}
 	return nil
@@ -195,7 +195,7 @@ func (ucd *userCouponData) UseCoupon(ctx context.Context, db *gorm.DB, id int64,
 			"order_sn": orderSn,
 			"used_at":  usedAt,
 		}).Error; err != nil {
-		log.Errorf("使用优惠券失败: %v", err)
+		log.ErrorfC(ctx, "使用优惠券失败: %v", err)
 		return err
 	}
 	return nil
@@ -208,7 +208,7 @@ func (ucd *userCouponData) BatchCreate(ctx context.Context, db *gorm.DB, userCou
 	}
 	
 	if err := db.WithContext(ctx).CreateInBatches(userCoupons, 100).Error; err != nil {
-		log.Errorf("批量创建用户优惠券失败: %v", err)
+		log.ErrorfC(ctx, "批量创建用户优惠券失败: %v", err)
 		return err
 	}
 	return nil
@@ -224,7 +224,7 @@ func (ucd *userCouponData) CountUserCouponsByTemplate(ctx context.Context, db *g
 	if err := db.WithContext(ctx).Model(&do.UserCouponDO{}).
 		Where("user_id = ? AND coupon_template_id = ?", userID, templateID).
 		Count(&count).Error; err != nil {
-		log.Errorf("统计用户优惠券模板数量失败: %v", err)
+		log.ErrorfC(ctx, "统计用户优惠券模板数量失败: %v", err)
 		return 0, err
 	}
 	return count, nil
@@ -240,8 +240,65 @@ func (ucd *userCouponData) FindExpiredCoupons(ctx context.Context, db *gorm.DB,
 	if err := db.WithContext(ctx).
 		Where("status = ? AND expired_at < ?", do.UserCouponStatusUnused, beforeTime).
 		Find(&userCoupons).Error; err != nil {
-		log.Errorf("查找过期优惠券失败: %v", err)
+		log.ErrorfC(ctx, "查找过期优惠券失败: %v", err)
 		return nil, err
 	}
 	return userCoupons, nil
-}
\ No newline at end of file
+}
+
+// FindExpiredBatch 按主键顺序分批查找已过期但仍未使用的优惠券
+func (ucd *userCouponData) FindExpiredBatch(ctx context.Context, db *gorm.DB, beforeTime time.Time, afterID int64, limit int) ([]*do.UserCouponDO, error) {
+	if db == nil {
+		db = ucd.db
+	}
+	
+	var userCoupons []*do.UserCouponDO
+	if err := db.WithContext(ctx).
+		Where("id > ? AND status = ? AND expired_at < ?", afterID, do.UserCouponStatusUnused, beforeTime).
+		Order("id ASC").
+		Limit(limit).
+		Find(&userCoupons).Error; err != nil {
+		log.ErrorfC(ctx, "分批查找过期优惠券失败: %v", err)
+		return nil, err
+	}
+	return userCoupons, nil
+}
+
+// ExpireCoupons 将过期的未使用优惠券标记为已过期，条件更新避免覆盖并发核销
+func (ucd *userCouponData) ExpireCoupons(ctx context.Context, db *gorm.DB, ids []int64, beforeTime time.Time) (int64, error) {
+	if db == nil {
+		db = ucd.db
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	
+	result := db.WithContext(ctx).Model(&do.UserCouponDO{}).
+		Where("id IN ? AND status = ? AND expired_at < ?", ids, do.UserCouponStatusUnused, beforeTime).
+		Update("status", do.UserCouponStatusExpired)
+	if result.Error != nil {
+		log.ErrorfC(ctx, "批量过期优惠券失败: %v", result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// FindExpiringWithoutReminder 查找在时间窗口内即将过期且尚未生成提醒的优惠券
+func (ucd *userCouponData) FindExpiringWithoutReminder(ctx context.Context, db *gorm.DB, from, to time.Time, afterID int64, limit int) ([]*do.UserCouponDO, error) {
+	if db == nil {
+		db = ucd.db
+	}
+	
+	var userCoupons []*do.UserCouponDO
+	if err := db.WithContext(ctx).
+		Where("user_coupons.id > ? AND user_coupons.status = ? AND user_coupons.expired_at >= ? AND user_coupons.expired_at < ?",
+			afterID, do.UserCouponStatusUnused, from, to).
+		Where("NOT EXISTS (SELECT 1 FROM coupon_reminders WHERE coupon_reminders.user_coupon_id = user_coupons.id)").
+		Order("user_coupons.id ASC").
+		Limit(limit).
+		Find(&userCoupons).Error; err != nil {
+		log.ErrorfC(ctx, "查找即将过期优惠券失败: %v", err)
+		return nil, err
+	}
+	return userCoupons, nil
+}
@@ -234,4 +234,85 @@ end
 redis.call('HSET', activityKey, 'updated_at', currentTime)
 
 return {1, oldStatus, "状态更新成功"}
-`
\ No newline at end of file
+`
+// ShardReserveLuaScript 分片库存预占脚本
+// 在用户归属分片上先预占个人限购额度再扣减库存，归属分片库存不足时保留预占交由调用方到其他分片扣减
+const ShardReserveLuaScript = `
+-- 分片库存预占脚本，两个key使用相同的hash tag
+-- KEYS[1]: 用户归属分片库存key
+-- KEYS[2]: 用户限购key
+-- ARGV[1]: 扣减数量
+-- ARGV[2]: 每用户限购数量，0表示不限
+-- ARGV[3]: 用户限购key的TTL秒数
+
+local stockKey = KEYS[1]
+local userKey = KEYS[2]
+local num = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+local ttl = tonumber(ARGV[3])
+
+local stock = redis.call('GET', stockKey)
+if not stock then
+    return {-1, 0}
+end
+
+local bought = tonumber(redis.call('GET', userKey) or '0')
+if limit > 0 and bought + num > limit then
+    return {-2, bought}
+end
+
+redis.call('INCRBY', userKey, num)
+redis.call('EXPIRE', userKey, ttl)
+
+stock = tonumber(stock)
+if stock >= num then
+    return {1, redis.call('DECRBY', stockKey, num)}
+end
+
+-- 归属分片已空，额度保持预占
+return {0, stock}
+`
+
+// ShardDeductLuaScript 分片库存扣减脚本
+const ShardDeductLuaScript = `
+-- KEYS[1]: 分片库存key
+-- ARGV[1]: 扣减数量
+
+local stock = tonumber(redis.call('GET', KEYS[1]) or '0')
+local num = tonumber(ARGV[1])
+if stock < num then
+    return {0, stock}
+end
+return {1, redis.call('DECRBY', KEYS[1], num)}
+`
+
+// ShardReleaseLuaScript 分片库存释放脚本
+// 释放用户预占的额度，KEYS[2]存在时同时把库存归还到该分片
+const ShardReleaseLuaScript = `
+-- KEYS[1]: 用户限购key
+-- KEYS[2]: 归还库存的分片key，可选，与KEYS[1]使用相同的hash tag
+-- ARGV[1]: 释放数量
+-- ARGV[2]: 分片库存key不存在时的TTL秒数
+
+local userKey = KEYS[1]
+local num = tonumber(ARGV[1])
+
+local bought = tonumber(redis.call('GET', userKey) or '0')
+if bought < num then
+    return {0, bought}
+end
+
+local left = redis.call('DECRBY', userKey, num)
+if left <= 0 then
+    redis.call('DEL', userKey)
+end
+
+if KEYS[2] then
+    redis.call('INCRBY', KEYS[2], num)
+    if redis.call('TTL', KEYS[2]) < 0 then
+        redis.call('EXPIRE', KEYS[2], tonumber(ARGV[2]))
+    end
+end
+
+return {1, left}
+`
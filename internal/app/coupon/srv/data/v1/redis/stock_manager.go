@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	"emshop/pkg/log"
@@ -19,6 +20,13 @@ type StockManager struct {
 	userLimitScript       *redis.Script
 	rollbackScript        *redis.Script
 	activityStatusScript  *redis.Script
+	shardReserveScript    *redis.Script
+	shardDeductScript     *redis.Script
+	shardReleaseScript    *redis.Script
+
+	// shards 新活动初始化库存时的分片数，已初始化的活动以Redis中记录的分片数为准
+	shards      int
+	shardCounts sync.Map // 库存key -> shardCountEntry
 }
 
 // FlashSaleRequest 秒杀请求
@@ -53,8 +61,19 @@ type ActivityInfo struct {
 	PerUserLimit int32     `json:"per_user_limit"`
 }
 
-// NewStockManager 创建库存管理器
+// NewStockManager 创建库存管理器，新活动的库存不分片
 func NewStockManager(redisClient *redis.Client) *StockManager {
+	return NewShardedStockManager(redisClient, 1)
+}
+
+// NewShardedStockManager 创建库存管理器，新活动的库存拆分为shards个分片
+func NewShardedStockManager(redisClient *redis.Client, shards int) *StockManager {
+	if shards < 1 {
+		shards = 1
+	}
+	if shards > MaxStockShards {
+		shards = MaxStockShards
+	}
 	return &StockManager{
 		redis:                redisClient,
 		flashSaleScript:      redis.NewScript(FlashSaleLuaScript),
@@ -62,17 +81,53 @@ func NewStockManager(redisClient *redis.Client) *StockManager {
 		userLimitScript:      redis.NewScript(UserLimitCheckLuaScript),
 		rollbackScript:       redis.NewScript(StockRollbackLuaScript),
 		activityStatusScript: redis.NewScript(ActivityStatusLuaScript),
+		shardReserveScript:   redis.NewScript(ShardReserveLuaScript),
+		shardDeductScript:    redis.NewScript(ShardDeductLuaScript),
+		shardReleaseScript:   redis.NewScript(ShardReleaseLuaScript),
+		shards:               shards,
 	}
 }
 
 // FlashSale 执行秒杀操作
 func (sm *StockManager) FlashSale(ctx context.Context, req *FlashSaleRequest) (*FlashSaleResult, error) {
-	log.Infof("开始执行秒杀: activityID=%d, userID=%d, couponID=%d", 
+	log.InfofC(ctx, "开始执行秒杀: activityID=%d, userID=%d, couponID=%d", 
 		req.ActivityID, req.UserID, req.CouponID)
 
+	stockKey := fmt.Sprintf("coupon:stock:%d", req.CouponID)
+	shards, err := sm.StockShards(ctx, stockKey)
+	if err != nil {
+		log.ErrorfC(ctx, "秒杀查询库存分片失败: %v", err)
+		return nil, fmt.Errorf("秒杀执行失败: %v", err)
+	}
+
+	var flashSaleResult *FlashSaleResult
+	if shards > 1 {
+		flashSaleResult, err = sm.flashSaleSharded(ctx, req, stockKey, shards)
+	} else {
+		flashSaleResult, err = sm.flashSaleSingle(ctx, req, stockKey)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 如果秒杀成功，生成优惠券编号
+	if flashSaleResult.Success {
+		flashSaleResult.CouponSn = sm.generateCouponSn(req.ActivityID, req.UserID, flashSaleResult.Timestamp)
+		log.InfofC(ctx, "秒杀成功: userID=%d, couponSn=%s, remainStock=%d", 
+			req.UserID, flashSaleResult.CouponSn, flashSaleResult.RemainStock)
+	} else {
+		log.WarnfC(ctx, "秒杀失败: userID=%d, code=%d, message=%s", 
+			req.UserID, flashSaleResult.Code, flashSaleResult.Message)
+	}
+
+	return flashSaleResult, nil
+}
+
+// flashSaleSingle 未分片库存的秒杀，由单个Lua脚本完成全部校验与扣减
+func (sm *StockManager) flashSaleSingle(ctx context.Context, req *FlashSaleRequest, stockKey string) (*FlashSaleResult, error) {
 	// 构建Redis keys
 	keys := []string{
-		fmt.Sprintf("coupon:stock:%d", req.CouponID),           // 库存key
+		stockKey,                                                    // 库存key
 		fmt.Sprintf("coupon:user:%d:%d", req.ActivityID, req.UserID), // 用户参与记录key
 		fmt.Sprintf("coupon:log:%d", req.ActivityID),          // 日志key
 		fmt.Sprintf("coupon:activity:%d", req.ActivityID),     // 活动信息key
@@ -91,7 +146,7 @@ func (sm *StockManager) FlashSale(ctx context.Context, req *FlashSaleRequest) (*
 	// 执行Lua脚本
 	result, err := sm.flashSaleScript.Run(ctx, sm.redis, keys, args...).Result()
 	if err != nil {
-		log.Errorf("秒杀脚本执行失败: %v", err)
+		log.ErrorfC(ctx, "秒杀脚本执行失败: %v", err)
 		return nil, fmt.Errorf("秒杀执行失败: %v", err)
 	}
 
@@ -105,34 +160,115 @@ func (sm *StockManager) FlashSale(ctx context.Context, req *FlashSaleRequest) (*
 	stock := resultSlice[1].(int64)
 	message := resultSlice[2].(string)
 
-	flashSaleResult := &FlashSaleResult{
+	return &FlashSaleResult{
 		Code:        int(code),
 		Success:     code == 1,
 		Message:     message,
 		RemainStock: int(stock),
 		Timestamp:   currentTime,
+	}, nil
+}
+
+// flashSaleSharded 分片库存的秒杀
+// 活动状态与时间在扣减前读取校验，个人限购与库存扣减由分片脚本保证原子性
+func (sm *StockManager) flashSaleSharded(ctx context.Context, req *FlashSaleRequest, stockKey string, shards int) (*FlashSaleResult, error) {
+	activityKey := fmt.Sprintf("coupon:activity:%d", req.ActivityID)
+	currentTime := time.Now().Unix()
+	result := &FlashSaleResult{Timestamp: currentTime}
+
+	info, err := sm.redis.HMGet(ctx, activityKey, "status", "start_time", "end_time", "per_user_limit").Result()
+	if err != nil {
+		log.ErrorfC(ctx, "秒杀读取活动信息失败: %v", err)
+		return nil, fmt.Errorf("秒杀执行失败: %v", err)
+	}
+	if info[0] == nil {
+		result.Code, result.Message = -3, "活动不存在"
+		return result, nil
+	}
+	status, _ := strconv.ParseInt(fmt.Sprint(info[0]), 10, 64)
+	startTime, _ := strconv.ParseInt(fmt.Sprint(info[1]), 10, 64)
+	endTime, _ := strconv.ParseInt(fmt.Sprint(info[2]), 10, 64)
+	perUserLimit, err := strconv.ParseInt(fmt.Sprint(info[3]), 10, 64)
+	if err != nil {
+		perUserLimit = 1
+	}
+	if status != 2 {
+		result.Code, result.Message = -3, "活动未开始或已结束"
+		return result, nil
+	}
+	if currentTime < startTime || currentTime > endTime {
+		result.Code, result.Message = -3, "不在活动时间内"
+		return result, nil
 	}
 
-	// 如果秒杀成功，生成优惠券编号
-	if flashSaleResult.Success {
-		flashSaleResult.CouponSn = sm.generateCouponSn(req.ActivityID, req.UserID, currentTime)
-		log.Infof("秒杀成功: userID=%d, couponSn=%s, remainStock=%d", 
-			req.UserID, flashSaleResult.CouponSn, flashSaleResult.RemainStock)
-	} else {
-		log.Warnf("秒杀失败: userID=%d, code=%d, message=%s", 
-			req.UserID, flashSaleResult.Code, flashSaleResult.Message)
+	count := int64(req.RequestCount)
+	if count <= 0 {
+		count = 1
+	}
+	deduct, err := sm.DeductShardedStock(ctx, &StockDeduction{
+		StockKey:     stockKey,
+		UserKey:      fmt.Sprintf("coupon:user:%d:%d", req.ActivityID, req.UserID),
+		UserID:       req.UserID,
+		Count:        count,
+		PerUserLimit: perUserLimit,
+		UserKeyTTL:   1800 * time.Second,
+	}, shards)
+	if err != nil {
+		log.ErrorfC(ctx, "秒杀扣减分片库存失败: %v", err)
+		return nil, fmt.Errorf("秒杀执行失败: %v", err)
 	}
 
-	return flashSaleResult, nil
+	switch deduct.Status {
+	case DeductUserLimitExceeded:
+		result.Code, result.Message = -2, "用户已达到参与上限"
+	case DeductStockMissing:
+		result.Code, result.Message = -1, "库存信息不存在"
+	case DeductOutOfStock:
+		result.Code, result.Message = -1, "库存不足"
+		// 全部分片售罄，设置活动状态为已结束
+		if err := sm.redis.HSet(ctx, activityKey, "status", 3).Err(); err != nil {
+			log.WarnfC(ctx, "更新售罄活动状态失败: activityID=%d, err=%v", req.ActivityID, err)
+		}
+	default:
+		result.Code, result.Success, result.Message = 1, true, "秒杀成功"
+		result.RemainStock = int(deduct.Remain)
+
+		// 日志与统计不影响扣减结果
+		logKey := fmt.Sprintf("coupon:log:%d", req.ActivityID)
+		pipe := sm.redis.Pipeline()
+		pipe.LPush(ctx, logKey, fmt.Sprintf("%d:%d:%d:%d", req.UserID, req.ActivityID, count, currentTime))
+		pipe.Expire(ctx, logKey, 1800*time.Second)
+		pipe.HIncrBy(ctx, activityKey, "success_count", count)
+		if _, err := pipe.Exec(ctx); err != nil {
+			log.WarnfC(ctx, "记录秒杀日志失败: activityID=%d, err=%v", req.ActivityID, err)
+		}
+	}
+	return result, nil
 }
 
 // PrewarmStock 预热库存到Redis
 func (sm *StockManager) PrewarmStock(ctx context.Context, stockMaps map[int64]int32) error {
+	return sm.PrewarmStockWithTTL(ctx, stockMaps, time.Hour)
+}
+
+// PrewarmStockWithTTL 预热库存到Redis并指定过期时间，已存在的库存不会被覆盖
+func (sm *StockManager) PrewarmStockWithTTL(ctx context.Context, stockMaps map[int64]int32, ttl time.Duration) error {
 	if len(stockMaps) == 0 {
 		return nil
 	}
 
-	log.Infof("开始库存预热，预热%d个优惠券", len(stockMaps))
+	log.InfofC(ctx, "开始库存预热，预热%d个优惠券", len(stockMaps))
+
+	if sm.shards > 1 {
+		for couponID, stock := range stockMaps {
+			if _, err := sm.InitShardedStock(ctx, fmt.Sprintf("coupon:stock:%d", couponID), int64(stock), ttl); err != nil {
+				log.ErrorfC(ctx, "库存预热失败: couponID=%d, err=%v", couponID, err)
+				return fmt.Errorf("库存预热失败: %v", err)
+			}
+		}
+		log.InfofC(ctx, "库存预热完成，分片数%d", sm.shards)
+		return nil
+	}
 
 	// 构建keys数组（key, value交替）
 	keys := make([]string, 0, len(stockMaps)*2)
@@ -141,11 +277,11 @@ func (sm *StockManager) PrewarmStock(ctx context.Context, stockMaps map[int64]in
 		keys = append(keys, stockKey, strconv.Itoa(int(stock)))
 	}
 
-	// 执行预热脚本（TTL 1小时）
-	args := []interface{}{3600}
+	// 执行预热脚本
+	args := []interface{}{int64(ttl / time.Second)}
 	result, err := sm.prewarmScript.Run(ctx, sm.redis, keys, args...).Result()
 	if err != nil {
-		log.Errorf("库存预热失败: %v", err)
+		log.ErrorfC(ctx, "库存预热失败: %v", err)
 		return fmt.Errorf("库存预热失败: %v", err)
 	}
 
@@ -155,7 +291,7 @@ func (sm *StockManager) PrewarmStock(ctx context.Context, stockMaps map[int64]in
 	successCount := resultSlice[1].(int64)
 	
 	if code == 1 {
-		log.Infof("库存预热成功，成功设置%d个库存", successCount)
+		log.InfofC(ctx, "库存预热成功，成功设置%d个库存", successCount)
 	}
 
 	return nil
@@ -184,19 +320,36 @@ func (sm *StockManager) CheckUserLimit(ctx context.Context, userID int64, timeWi
 
 // RollbackStock 回滚库存（用于异步处理失败）
 func (sm *StockManager) RollbackStock(ctx context.Context, activityID, userID int64, couponID int64, rollbackCount int32) error {
-	log.Infof("开始回滚库存: activityID=%d, userID=%d, couponID=%d, count=%d", 
+	log.InfofC(ctx, "开始回滚库存: activityID=%d, userID=%d, couponID=%d, count=%d", 
 		activityID, userID, couponID, rollbackCount)
 
-	keys := []string{
-		fmt.Sprintf("coupon:stock:%d", couponID),
-		fmt.Sprintf("coupon:user:%d:%d", activityID, userID),
+	stockKey := fmt.Sprintf("coupon:stock:%d", couponID)
+	userKey := fmt.Sprintf("coupon:user:%d:%d", activityID, userID)
+	shards, err := sm.StockShards(ctx, stockKey)
+	if err != nil {
+		log.ErrorfC(ctx, "库存回滚失败: %v", err)
+		return fmt.Errorf("库存回滚失败: %v", err)
+	}
+	if shards > 1 {
+		restored, err := sm.RestoreShardedStock(ctx, stockKey, userKey, userID, int64(rollbackCount), time.Hour, shards)
+		if err != nil {
+			log.ErrorfC(ctx, "库存回滚失败: %v", err)
+			return fmt.Errorf("库存回滚失败: %v", err)
+		}
+		if restored {
+			log.InfofC(ctx, "库存回滚成功: couponID=%d, userID=%d", couponID, userID)
+		} else {
+			log.WarnfC(ctx, "库存回滚跳过: 用户记录不存在，无需回滚")
+		}
+		return nil
 	}
 
+	keys := []string{stockKey, userKey}
 	args := []interface{}{rollbackCount, userID}
 
 	result, err := sm.rollbackScript.Run(ctx, sm.redis, keys, args...).Result()
 	if err != nil {
-		log.Errorf("库存回滚失败: %v", err)
+		log.ErrorfC(ctx, "库存回滚失败: %v", err)
 		return fmt.Errorf("库存回滚失败: %v", err)
 	}
 
@@ -205,17 +358,16 @@ func (sm *StockManager) RollbackStock(ctx context.Context, activityID, userID in
 	message := resultSlice[2].(string)
 
 	if code == 1 {
-		log.Infof("库存回滚成功: %s", message)
+		log.InfofC(ctx, "库存回滚成功: %s", message)
 	} else {
-		log.Warnf("库存回滚跳过: %s", message)
+		log.WarnfC(ctx, "库存回滚跳过: %s", message)
 	}
 
 	return nil
 }
 
-// StartActivity 启动秒杀活动
-func (sm *StockManager) StartActivity(ctx context.Context, activityInfo *ActivityInfo) error {
-	// 先预设活动信息到Redis
+// PrepareActivity 写入待开始的活动信息并预热库存，活动开始前调用
+func (sm *StockManager) PrepareActivity(ctx context.Context, activityInfo *ActivityInfo) error {
 	activityKey := fmt.Sprintf("coupon:activity:%d", activityInfo.ID)
 	
 	activityData := map[string]interface{}{
@@ -235,24 +387,54 @@ func (sm *StockManager) StartActivity(ctx context.Context, activityInfo *Activit
 		return fmt.Errorf("设置活动信息失败: %v", err)
 	}
 
-	// 预热库存
+	// 预热库存，过期时间覆盖整个活动周期
 	stockMaps := map[int64]int32{
 		activityInfo.CouponID: activityInfo.TotalCount,
 	}
-	if err := sm.PrewarmStock(ctx, stockMaps); err != nil {
+	if err := sm.PrewarmStockWithTTL(ctx, stockMaps, activityTTL(activityInfo)); err != nil {
 		return fmt.Errorf("预热库存失败: %v", err)
 	}
 
+	log.InfofC(ctx, "秒杀活动预热完成: activityID=%d, couponID=%d, totalCount=%d",
+		activityInfo.ID, activityInfo.CouponID, activityInfo.TotalCount)
+	return nil
+}
+
+// IsActivityPrepared 活动信息是否已写入Redis
+func (sm *StockManager) IsActivityPrepared(ctx context.Context, activityID int64) (bool, error) {
+	n, err := sm.redis.Exists(ctx, fmt.Sprintf("coupon:activity:%d", activityID)).Result()
+	if err != nil {
+		return false, fmt.Errorf("查询活动信息失败: %v", err)
+	}
+	return n > 0, nil
+}
+
+// activityTTL 库存保留到活动结束后一小时，至少一小时
+func activityTTL(activityInfo *ActivityInfo) time.Duration {
+	ttl := time.Until(activityInfo.EndTime) + time.Hour
+	if ttl < time.Hour {
+		ttl = time.Hour
+	}
+	return ttl
+}
+
+// StartActivity 启动秒杀活动
+func (sm *StockManager) StartActivity(ctx context.Context, activityInfo *ActivityInfo) error {
+	// 先预设活动信息并预热库存（已预热的库存不会被覆盖）
+	if err := sm.PrepareActivity(ctx, activityInfo); err != nil {
+		return err
+	}
+
 	// 更新活动状态为进行中
-	keys := []string{activityKey}
+	keys := []string{fmt.Sprintf("coupon:activity:%d", activityInfo.ID)}
 	args := []interface{}{2, "start", time.Now().Unix()} // 状态2表示进行中
 
-	_, err = sm.activityStatusScript.Run(ctx, sm.redis, keys, args...).Result()
+	_, err := sm.activityStatusScript.Run(ctx, sm.redis, keys, args...).Result()
 	if err != nil {
 		return fmt.Errorf("启动活动失败: %v", err)
 	}
 
-	log.Infof("秒杀活动启动成功: activityID=%d, couponID=%d, totalCount=%d", 
+	log.InfofC(ctx, "秒杀活动启动成功: activityID=%d, couponID=%d, totalCount=%d", 
 		activityInfo.ID, activityInfo.CouponID, activityInfo.TotalCount)
 
 	return nil
@@ -272,7 +454,7 @@ func (sm *StockManager) StopActivity(ctx context.Context, activityID int64) erro
 	code := resultSlice[0].(int64)
 	
 	if code == 1 {
-		log.Infof("秒杀活动停止成功: activityID=%d", activityID)
+		log.InfofC(ctx, "秒杀活动停止成功: activityID=%d", activityID)
 	}
 
 	return nil
@@ -330,22 +512,12 @@ func (sm *StockManager) GetActivityStatus(ctx context.Context, activityID int64)
 	return activityInfo, nil
 }
 
-// GetCurrentStock 获取当前库存
+// GetCurrentStock 获取当前库存，分片库存返回各分片之和
 func (sm *StockManager) GetCurrentStock(ctx context.Context, couponID int64) (int32, error) {
-	stockKey := fmt.Sprintf("coupon:stock:%d", couponID)
-	result, err := sm.redis.Get(ctx, stockKey).Result()
+	stock, _, err := sm.SumStock(ctx, fmt.Sprintf("coupon:stock:%d", couponID))
 	if err != nil {
-		if err == redis.Nil {
-			return 0, nil
-		}
-		return 0, fmt.Errorf("获取库存失败: %v", err)
-	}
-
-	stock, err := strconv.ParseInt(result, 10, 32)
-	if err != nil {
-		return 0, fmt.Errorf("库存格式错误: %v", err)
+		return 0, err
 	}
-
 	return int32(stock), nil
 }
 
@@ -358,9 +530,17 @@ func (sm *StockManager) generateCouponSn(activityID, userID, timestamp int64) st
 		timestamp%1000000)
 }
 
-// GetUserParticipationCount 获取用户参与次数
-func (sm *StockManager) GetUserParticipationCount(ctx context.Context, activityID, userID int64) (int32, error) {
+// GetUserParticipationCount 获取用户参与次数，分片库存的参与记录位于用户归属分片
+func (sm *StockManager) GetUserParticipationCount(ctx context.Context, activityID, couponID, userID int64) (int32, error) {
 	userKey := fmt.Sprintf("coupon:user:%d:%d", activityID, userID)
+	stockKey := fmt.Sprintf("coupon:stock:%d", couponID)
+	shards, err := sm.StockShards(ctx, stockKey)
+	if err != nil {
+		return 0, err
+	}
+	if shards > 1 {
+		userKey = shardedUserKey(userKey, stockKey, homeShard(userID, shards))
+	}
 	result, err := sm.redis.Get(ctx, userKey).Result()
 	if err != nil {
 		if err == redis.Nil {
@@ -379,11 +559,11 @@ func (sm *StockManager) GetUserParticipationCount(ctx context.Context, activityI
 
 // ClearActivityData 清理活动数据（活动结束后调用）
 func (sm *StockManager) ClearActivityData(ctx context.Context, activityID int64, couponID int64) error {
-	keys := []string{
-		fmt.Sprintf("coupon:stock:%d", couponID),
+	keys := sm.stockKeysOf(ctx, fmt.Sprintf("coupon:stock:%d", couponID))
+	keys = append(keys,
 		fmt.Sprintf("coupon:log:%d", activityID),
 		fmt.Sprintf("coupon:activity:%d", activityID),
-	}
+	)
 
 	// 删除用户参与记录（使用模式匹配）
 	userPattern := fmt.Sprintf("coupon:user:%d:*", activityID)
@@ -395,11 +575,11 @@ func (sm *StockManager) ClearActivityData(ctx context.Context, activityID int64,
 	if len(keys) > 0 {
 		_, err := sm.redis.Del(ctx, keys...).Result()
 		if err != nil {
-			log.Errorf("清理活动数据失败: %v", err)
+			log.ErrorfC(ctx, "清理活动数据失败: %v", err)
 			return fmt.Errorf("清理活动数据失败: %v", err)
 		}
 	}
 
-	log.Infof("活动数据清理完成: activityID=%d, 清理key数量=%d", activityID, len(keys))
+	log.InfofC(ctx, "活动数据清理完成: activityID=%d, 清理key数量=%d", activityID, len(keys))
 	return nil
 }
\ No newline at end of file
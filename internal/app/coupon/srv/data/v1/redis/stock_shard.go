@@ -0,0 +1,297 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"time"
+
+	"emshop/pkg/log"
+	"github.com/go-redis/redis/v8"
+)
+
+// 库存分片
+//
+// 单个库存key在秒杀瞬间集中到同一个集群slot，开启分片后初始库存平均拆到N个子key，
+// 每个子key使用各自的hash tag分布到不同slot。用户按ID哈希固定归属一个分片，个人限购计数
+// 与归属分片使用相同的hash tag，预占额度与扣减库存在同一个脚本内完成；归属分片售罄时
+// 保留预占依次到其他分片扣减，全部售罄再释放预占，因此跨分片扣减时个人限购依然准确。
+//
+// 分片数在初始化库存时写入<库存key>:shards，之后的扣减、回滚与查询都以该记录为准，
+// 修改配置只影响新初始化的活动；没有该记录时沿用未分片的单个库存key。
+
+const (
+	// MaxStockShards 库存分片数上限
+	MaxStockShards = 64
+
+	// shardCountCacheTTL 本地缓存活动分片数的时间
+	shardCountCacheTTL = time.Minute
+)
+
+// DeductStatus 分片库存扣减结果
+type DeductStatus int
+
+const (
+	// DeductSuccess 扣减成功
+	DeductSuccess DeductStatus = iota
+	// DeductOutOfStock 全部分片库存不足
+	DeductOutOfStock
+	// DeductUserLimitExceeded 超出个人限购
+	DeductUserLimitExceeded
+	// DeductStockMissing 库存未初始化
+	DeductStockMissing
+)
+
+// StockDeduction 分片库存扣减请求
+type StockDeduction struct {
+	StockKey     string        // 未分片时的库存key，分片key由其派生
+	UserKey      string        // 未分片时的个人限购key
+	UserID       int64         // 决定归属分片
+	Count        int64         // 扣减数量
+	PerUserLimit int64         // 每用户限购数量，0表示不限
+	UserKeyTTL   time.Duration // 个人限购key的过期时间
+}
+
+// StockDeductResult 分片库存扣减结果
+type StockDeductResult struct {
+	Status DeductStatus
+	Shard  int   // 实际扣减的分片
+	Remain int64 // 该分片剩余库存
+}
+
+type shardCountEntry struct {
+	shards   int
+	expireAt time.Time
+}
+
+// stockShardKey 分片库存key，整个key作为hash tag使各分片落在不同slot
+func stockShardKey(stockKey string, shard int) string {
+	return fmt.Sprintf("{%s:%d}", stockKey, shard)
+}
+
+// shardedUserKey 个人限购key，与归属分片使用相同的hash tag
+func shardedUserKey(userKey, stockKey string, shard int) string {
+	return fmt.Sprintf("%s:{%s:%d}", userKey, stockKey, shard)
+}
+
+// stockShardsKey 记录活动库存分片数
+func stockShardsKey(stockKey string) string {
+	return stockKey + ":shards"
+}
+
+// homeShard 用户归属分片
+func homeShard(userID int64, shards int) int {
+	h := fnv.New32a()
+	h.Write([]byte(strconv.FormatInt(userID, 10)))
+	return int(h.Sum32() % uint32(shards))
+}
+
+// splitStock 将库存平均拆分到各分片，余数分给前面的分片
+func splitStock(total int64, shards int) []int64 {
+	parts := make([]int64, shards)
+	for i := range parts {
+		parts[i] = total / int64(shards)
+		if int64(i) < total%int64(shards) {
+			parts[i]++
+		}
+	}
+	return parts
+}
+
+// ShardCount 新活动初始化库存时使用的分片数
+func (sm *StockManager) ShardCount() int {
+	return sm.shards
+}
+
+// StockShards 查询库存实际的分片数，未分片时返回1
+func (sm *StockManager) StockShards(ctx context.Context, stockKey string) (int, error) {
+	if v, ok := sm.shardCounts.Load(stockKey); ok {
+		if entry := v.(shardCountEntry); time.Now().Before(entry.expireAt) {
+			return entry.shards, nil
+		}
+	}
+
+	shards, err := sm.redis.Get(ctx, stockShardsKey(stockKey)).Int()
+	if err == redis.Nil {
+		// 未分片不缓存，避免错过随后完成的分片初始化
+		return 1, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("获取库存分片数失败: %v", err)
+	}
+	if shards < 1 {
+		shards = 1
+	}
+	sm.shardCounts.Store(stockKey, shardCountEntry{shards: shards, expireAt: time.Now().Add(shardCountCacheTTL)})
+	return shards, nil
+}
+
+// InitShardedStock 按配置的分片数拆分初始库存，库存已初始化(含未分片的旧库存)时跳过并返回false
+func (sm *StockManager) InitShardedStock(ctx context.Context, stockKey string, total int64, ttl time.Duration) (bool, error) {
+	// 本进程已知的分片库存无需再次检查
+	if v, ok := sm.shardCounts.Load(stockKey); ok && time.Now().Before(v.(shardCountEntry).expireAt) {
+		return false, nil
+	}
+
+	exists, err := sm.redis.Exists(ctx, stockKey).Result()
+	if err != nil {
+		return false, fmt.Errorf("检查库存失败: %v", err)
+	}
+	if exists > 0 {
+		return false, nil
+	}
+
+	// 分片数记录作为初始化标记，保证只初始化一次
+	ok, err := sm.redis.SetNX(ctx, stockShardsKey(stockKey), sm.shards, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("写入库存分片数失败: %v", err)
+	}
+	if !ok {
+		return false, nil
+	}
+
+	pipe := sm.redis.Pipeline()
+	for i, part := range splitStock(total, sm.shards) {
+		pipe.Set(ctx, stockShardKey(stockKey, i), part, ttl)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, fmt.Errorf("写入分片库存失败: %v", err)
+	}
+	sm.shardCounts.Store(stockKey, shardCountEntry{shards: sm.shards, expireAt: time.Now().Add(shardCountCacheTTL)})
+	return true, nil
+}
+
+// DeductShardedStock 从用户归属分片扣减库存，归属分片售罄时依次到其他分片扣减
+func (sm *StockManager) DeductShardedStock(ctx context.Context, d *StockDeduction, shards int) (*StockDeductResult, error) {
+	home := homeShard(d.UserID, shards)
+	userKey := shardedUserKey(d.UserKey, d.StockKey, home)
+
+	result, err := sm.shardReserveScript.Run(ctx, sm.redis,
+		[]string{stockShardKey(d.StockKey, home), userKey},
+		d.Count, d.PerUserLimit, int64(d.UserKeyTTL/time.Second)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("预占分片库存失败: %v", err)
+	}
+	code, value, err := parseShardResult(result)
+	if err != nil {
+		return nil, err
+	}
+	switch code {
+	case 1:
+		return &StockDeductResult{Status: DeductSuccess, Shard: home, Remain: value}, nil
+	case -1:
+		return &StockDeductResult{Status: DeductStockMissing, Shard: home}, nil
+	case -2:
+		return &StockDeductResult{Status: DeductUserLimitExceeded, Shard: home}, nil
+	}
+
+	// 归属分片已空，额度已预占，到其他分片扣减
+	for i := 1; i < shards; i++ {
+		shard := (home + i) % shards
+		result, err := sm.shardDeductScript.Run(ctx, sm.redis, []string{stockShardKey(d.StockKey, shard)}, d.Count).Result()
+		if err != nil {
+			sm.releaseReservation(ctx, userKey, d.Count)
+			return nil, fmt.Errorf("扣减分片库存失败: %v", err)
+		}
+		code, value, err := parseShardResult(result)
+		if err != nil {
+			sm.releaseReservation(ctx, userKey, d.Count)
+			return nil, err
+		}
+		if code == 1 {
+			return &StockDeductResult{Status: DeductSuccess, Shard: shard, Remain: value}, nil
+		}
+	}
+
+	sm.releaseReservation(ctx, userKey, d.Count)
+	return &StockDeductResult{Status: DeductOutOfStock, Shard: home}, nil
+}
+
+// RestoreShardedStock 回滚一次成功的扣减：释放个人限购额度并把库存归还到用户归属分片，用户没有可回滚的额度时返回false
+func (sm *StockManager) RestoreShardedStock(ctx context.Context, stockKey, userKey string, userID, count int64, ttl time.Duration, shards int) (bool, error) {
+	home := homeShard(userID, shards)
+	result, err := sm.shardReleaseScript.Run(ctx, sm.redis,
+		[]string{shardedUserKey(userKey, stockKey, home), stockShardKey(stockKey, home)},
+		count, int64(ttl/time.Second)).Result()
+	if err != nil {
+		return false, fmt.Errorf("回滚分片库存失败: %v", err)
+	}
+	code, _, err := parseShardResult(result)
+	if err != nil {
+		return false, err
+	}
+	return code == 1, nil
+}
+
+// SumStock 汇总各分片的剩余库存，库存未初始化时found为false
+func (sm *StockManager) SumStock(ctx context.Context, stockKey string) (stock int64, found bool, err error) {
+	shards, err := sm.StockShards(ctx, stockKey)
+	if err != nil {
+		return 0, false, err
+	}
+	if shards <= 1 {
+		stock, err = sm.redis.Get(ctx, stockKey).Int64()
+		if err == redis.Nil {
+			return 0, false, nil
+		}
+		if err != nil {
+			return 0, false, fmt.Errorf("获取库存失败: %v", err)
+		}
+		return stock, true, nil
+	}
+
+	pipe := sm.redis.Pipeline()
+	cmds := make([]*redis.StringCmd, shards)
+	for i := range cmds {
+		cmds[i] = pipe.Get(ctx, stockShardKey(stockKey, i))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return 0, false, fmt.Errorf("获取分片库存失败: %v", err)
+	}
+	for _, cmd := range cmds {
+		n, err := cmd.Int64()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return 0, false, fmt.Errorf("分片库存格式错误: %v", err)
+		}
+		stock += n
+		found = true
+	}
+	return stock, found, nil
+}
+
+// stockKeysOf 库存相关的全部key，用于清理活动数据
+func (sm *StockManager) stockKeysOf(ctx context.Context, stockKey string) []string {
+	keys := []string{stockKey}
+	if shards, err := sm.StockShards(ctx, stockKey); err == nil && shards > 1 {
+		keys = append(keys, stockShardsKey(stockKey))
+		for i := 0; i < shards; i++ {
+			keys = append(keys, stockShardKey(stockKey, i))
+		}
+	}
+	sm.shardCounts.Delete(stockKey)
+	return keys
+}
+
+// releaseReservation 释放预占的个人限购额度，失败时只记录日志，额度随key过期恢复
+func (sm *StockManager) releaseReservation(ctx context.Context, userKey string, count int64) {
+	if err := sm.shardReleaseScript.Run(ctx, sm.redis, []string{userKey}, count, 0).Err(); err != nil {
+		log.ErrorfC(ctx, "释放个人限购预占失败: userKey=%s, err=%v", userKey, err)
+	}
+}
+
+func parseShardResult(result interface{}) (int64, int64, error) {
+	values, ok := result.([]interface{})
+	if !ok || len(values) < 2 {
+		return 0, 0, fmt.Errorf("分片库存脚本返回格式错误")
+	}
+	code, ok1 := values[0].(int64)
+	value, ok2 := values[1].(int64)
+	if !ok1 || !ok2 {
+		return 0, 0, fmt.Errorf("分片库存脚本返回格式错误")
+	}
+	return code, value, nil
+}
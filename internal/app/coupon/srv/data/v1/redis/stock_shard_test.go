@@ -0,0 +1,218 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRedisClient 连接本地Redis测试库，不可用时跳过
+func newTestRedisClient(tb testing.TB) *redis.Client {
+	client := redis.NewClient(&redis.Options{
+		Addr:     "localhost:6379",
+		DB:       15, // 使用测试数据库
+		PoolSize: 64,
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		tb.Skipf("redis not available: %v", err)
+	}
+	tb.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+// testStockKey 每个用例独立的库存key，用例结束后清理
+func testStockKey(tb testing.TB, client *redis.Client) string {
+	prefix := "test:stock:" + uuid.NewString()[:8]
+	tb.Cleanup(func() {
+		ctx := context.Background()
+		for _, pattern := range []string{prefix + "*", "{" + prefix + "*"} {
+			keys, _ := client.Keys(ctx, pattern).Result()
+			if len(keys) > 0 {
+				client.Del(ctx, keys...)
+			}
+		}
+	})
+	return prefix
+}
+
+func TestSplitStock(t *testing.T) {
+	assert.Equal(t, []int64{4, 3, 3}, splitStock(10, 3))
+	assert.Equal(t, []int64{1, 1, 0, 0}, splitStock(2, 4))
+
+	var sum int64
+	for _, part := range splitStock(1001, 8) {
+		sum += part
+	}
+	assert.Equal(t, int64(1001), sum)
+}
+
+func TestShardKeysShareHashTag(t *testing.T) {
+	stockKey := "flashsale:stock:7"
+	assert.Equal(t, "{flashsale:stock:7:3}", stockShardKey(stockKey, 3))
+	// 个人限购key与归属分片的hash tag一致，才能在同一个脚本中访问
+	assert.Equal(t, "flashsale:user_limit:7:42:{flashsale:stock:7:3}",
+		shardedUserKey("flashsale:user_limit:7:42", stockKey, 3))
+
+	home := homeShard(42, 8)
+	assert.True(t, home >= 0 && home < 8)
+	assert.Equal(t, home, homeShard(42, 8))
+}
+
+func TestNewShardedStockManagerClampsShards(t *testing.T) {
+	assert.Equal(t, 1, NewShardedStockManager(nil, 0).ShardCount())
+	assert.Equal(t, MaxStockShards, NewShardedStockManager(nil, MaxStockShards+1).ShardCount())
+}
+
+func TestShardedStockFallover(t *testing.T) {
+	client := newTestRedisClient(t)
+	ctx := context.Background()
+	stockKey := testStockKey(t, client)
+	sm := NewShardedStockManager(client, 4)
+
+	initialized, err := sm.InitShardedStock(ctx, stockKey, 10, time.Minute)
+	require.NoError(t, err)
+	require.True(t, initialized)
+	initialized, err = sm.InitShardedStock(ctx, stockKey, 10, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, initialized)
+
+	shards, err := sm.StockShards(ctx, stockKey)
+	require.NoError(t, err)
+	require.Equal(t, 4, shards)
+
+	// 同一个用户连续抢购，归属分片售罄后从其他分片扣减，直到全部售罄
+	deduction := &StockDeduction{
+		StockKey:   stockKey,
+		UserKey:    stockKey + ":user:1",
+		UserID:     1,
+		Count:      1,
+		UserKeyTTL: time.Minute,
+	}
+	used := map[int]bool{}
+	for i := 0; i < 10; i++ {
+		result, err := sm.DeductShardedStock(ctx, deduction, shards)
+		require.NoError(t, err)
+		require.Equal(t, DeductSuccess, result.Status)
+		used[result.Shard] = true
+	}
+	assert.Len(t, used, 4)
+
+	result, err := sm.DeductShardedStock(ctx, deduction, shards)
+	require.NoError(t, err)
+	assert.Equal(t, DeductOutOfStock, result.Status)
+
+	stock, found, err := sm.SumStock(ctx, stockKey)
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, int64(0), stock)
+
+	// 售罄失败释放了预占，用户计数等于成功次数
+	bought, err := client.Get(ctx, shardedUserKey(deduction.UserKey, stockKey, homeShard(1, shards))).Int64()
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), bought)
+
+	restored, err := sm.RestoreShardedStock(ctx, stockKey, deduction.UserKey, 1, 1, time.Minute, shards)
+	require.NoError(t, err)
+	assert.True(t, restored)
+	stock, _, err = sm.SumStock(ctx, stockKey)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), stock)
+}
+
+func TestShardedStockKeepsPerUserLimit(t *testing.T) {
+	client := newTestRedisClient(t)
+	ctx := context.Background()
+	stockKey := testStockKey(t, client)
+	sm := NewShardedStockManager(client, 8)
+
+	const total, users, perUser = 200, 50, 3
+	_, err := sm.InitShardedStock(ctx, stockKey, total, time.Minute)
+	require.NoError(t, err)
+
+	// 每个用户并发抢购远超限购次数，库存与限购都不能超
+	var success int64
+	perUserSuccess := make([]int64, users)
+	var wg sync.WaitGroup
+	for u := 0; u < users; u++ {
+		for i := 0; i < perUser*3; i++ {
+			wg.Add(1)
+			go func(u int) {
+				defer wg.Done()
+				result, err := sm.DeductShardedStock(ctx, &StockDeduction{
+					StockKey:     stockKey,
+					UserKey:      fmt.Sprintf("%s:user:%d", stockKey, u),
+					UserID:       int64(u),
+					Count:        1,
+					PerUserLimit: perUser,
+					UserKeyTTL:   time.Minute,
+				}, 8)
+				if err == nil && result.Status == DeductSuccess {
+					atomic.AddInt64(&success, 1)
+					atomic.AddInt64(&perUserSuccess[u], 1)
+				}
+			}(u)
+		}
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(users*perUser), success)
+	for u, n := range perUserSuccess {
+		assert.Equal(t, int64(perUser), n, "user %d", u)
+	}
+	stock, _, err := sm.SumStock(ctx, stockKey)
+	require.NoError(t, err)
+	assert.Equal(t, int64(total-users*perUser), stock)
+}
+
+// BenchmarkStockDeduct 对比单个库存key与分片库存的扣减吞吐，需要本地Redis
+// shards=1即所有请求集中在一个key上；单机Redis只能体现分片带来的额外开销，
+// 集群下各分片落在不同slot，吞吐随分片所在节点数增加
+//
+//	go test -run=^$ -bench=BenchmarkStockDeduct ./internal/app/coupon/srv/data/v1/redis/
+func BenchmarkStockDeduct(b *testing.B) {
+	for _, shards := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("shards=%d", shards), func(b *testing.B) {
+			benchmarkStockDeduct(b, shards)
+		})
+	}
+}
+
+func benchmarkStockDeduct(b *testing.B, shards int) {
+	client := newTestRedisClient(b)
+	ctx := context.Background()
+	stockKey := testStockKey(b, client)
+	sm := NewShardedStockManager(client, shards)
+
+	// 库存足够大，只比较扣减路径本身
+	_, err := sm.InitShardedStock(ctx, stockKey, int64(b.N)*2+int64(shards), time.Minute)
+	require.NoError(b, err)
+
+	var userID int64
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			uid := atomic.AddInt64(&userID, 1)
+			_, err := sm.DeductShardedStock(ctx, &StockDeduction{
+				StockKey:     stockKey,
+				UserKey:      fmt.Sprintf("%s:user:%d", stockKey, uid),
+				UserID:       uid,
+				Count:        1,
+				PerUserLimit: 1,
+				UserKeyTTL:   time.Minute,
+			}, shards)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
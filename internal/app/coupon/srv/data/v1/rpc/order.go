@@ -0,0 +1,54 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	orderpb "emshop/api/order/v1"
+	"emshop/gin-micro/server/rpc-server"
+	"emshop/gin-micro/server/rpc-server/client-interceptors"
+	"emshop/internal/app/pkg/discovery"
+	"emshop/internal/app/pkg/options"
+
+	"google.golang.org/grpc"
+)
+
+const orderServiceName = "discovery:///emshop-order-srv"
+
+// OrderCloser 通过订单服务关闭超时未支付的秒杀订单
+type OrderCloser struct {
+	client orderpb.OrderClient
+}
+
+// NewOrderCloser 通过注册中心发现订单服务并创建客户端
+func NewOrderCloser(opts *options.RegistryOptions) (*OrderCloser, error) {
+	r, err := discovery.NewDiscovery(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := rpcserver.DialInsecure(
+		context.Background(),
+		rpcserver.WithBalancerName("p2c"),
+		rpcserver.WithEndpoint(orderServiceName),
+		rpcserver.WithHealthCheck(),
+		rpcserver.WithDiscovery(r),
+		rpcserver.WithNodeFilter(opts.Routing.NodeFilters()...),
+		rpcserver.WithClientTimeout(10*time.Second),
+		rpcserver.WithClientOptions(grpc.WithNoProxy()),
+		rpcserver.WithClientUnaryInterceptor(clientinterceptors.UnaryTracingInterceptor),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &OrderCloser{client: orderpb.NewOrderClient(conn)}, nil
+}
+
+// CloseFlashSaleOrder 关闭未支付的秒杀订单，订单已支付时返回paid=true
+func (oc *OrderCloser) CloseFlashSaleOrder(ctx context.Context, orderSn string) (bool, error) {
+	rsp, err := oc.client.CloseFlashSaleOrder(ctx, &orderpb.CloseFlashSaleOrderRequest{OrderSn: orderSn})
+	if err != nil {
+		return false, err
+	}
+	return rsp.Paid, nil
+}
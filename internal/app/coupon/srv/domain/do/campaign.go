@@ -0,0 +1,81 @@
+package do
+
+import "time"
+
+// CampaignStatus 发券活动状态
+type CampaignStatus int32
+
+const (
+	CampaignStatusDraft     CampaignStatus = 1 // 草稿，可追加目标用户
+	CampaignStatusRunning   CampaignStatus = 2 // 发放中
+	CampaignStatusPaused    CampaignStatus = 3 // 已暂停
+	CampaignStatusCompleted CampaignStatus = 4 // 已完成
+	CampaignStatusCancelled CampaignStatus = 5 // 已取消
+)
+
+// 目标用户圈选方式，由管理端解析为用户ID后追加到活动
+const (
+	CampaignSegmentUserIDs     = "user_ids"     // 上传的用户ID列表
+	CampaignSegmentRegistered  = "registered"   // 指定时间段内注册的用户
+	CampaignSegmentInactive    = "inactive"     // N天内未下单的用户
+	CampaignSegmentFavCategory = "fav_category" // 收藏过指定分类商品的用户
+)
+
+// CampaignTargetStatus 目标用户发放状态
+type CampaignTargetStatus int32
+
+const (
+	CampaignTargetPending    CampaignTargetStatus = 1 // 待发放
+	CampaignTargetDispatched CampaignTargetStatus = 2 // 已投递消息，等待发放
+	CampaignTargetIssued     CampaignTargetStatus = 3 // 已发放
+	CampaignTargetSkipped    CampaignTargetStatus = 4 // 跳过(如超出个人限领)
+	CampaignTargetFailed     CampaignTargetStatus = 5 // 发放失败，可重新执行
+)
+
+// CouponCampaignDO 定向发券活动
+type CouponCampaignDO struct {
+	ID                   int64          `gorm:"primarykey" json:"id"`
+	Name                 string         `json:"name" gorm:"column:name;type:varchar(100);not null;comment:活动名称"`
+	CouponTemplateID     int64          `json:"coupon_template_id" gorm:"column:coupon_template_id;type:bigint;not null;index:idx_template_id;comment:优惠券模板ID"`
+	SegmentType          string         `json:"segment_type" gorm:"column:segment_type;type:varchar(32);not null;comment:目标用户圈选方式"`
+	SegmentParams        string         `json:"segment_params" gorm:"column:segment_params;type:text;comment:圈选参数(JSON)"`
+	Status               CampaignStatus `json:"status" gorm:"column:status;type:tinyint;not null;default:1;index:idx_status;comment:状态"`
+	BatchSize            int32          `json:"batch_size" gorm:"column:batch_size;type:int;not null;comment:每批发放数量"`
+	BatchIntervalSeconds int32          `json:"batch_interval_seconds" gorm:"column:batch_interval_seconds;type:int;not null;default:0;comment:批次间隔(秒)"`
+	TotalTargets         int64          `json:"total_targets" gorm:"column:total_targets;type:bigint;not null;default:0;comment:目标用户数"`
+	Operator             string         `json:"operator" gorm:"column:operator;type:varchar(64);not null;default:'';comment:创建人"`
+	LastDispatchedAt     *time.Time     `json:"last_dispatched_at" gorm:"column:last_dispatched_at;type:timestamp NULL;comment:最近一次投递时间"`
+	StartedAt            *time.Time     `json:"started_at" gorm:"column:started_at;type:timestamp NULL;comment:首次开始时间"`
+	FinishedAt           *time.Time     `json:"finished_at" gorm:"column:finished_at;type:timestamp NULL;comment:完成或取消时间"`
+	CreatedAt            time.Time      `json:"created_at" gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+	UpdatedAt            time.Time      `json:"updated_at" gorm:"column:updated_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+// TableName 指定表名
+func (CouponCampaignDO) TableName() string {
+	return "coupon_campaigns"
+}
+
+// CouponCampaignDOList 发券活动列表
+type CouponCampaignDOList struct {
+	TotalCount int64               `json:"totalCount"`
+	Items      []*CouponCampaignDO `json:"items"`
+}
+
+// CampaignTargetDO 活动目标用户，同一活动中每个用户只有一条记录，保证重复执行不会重复发券
+type CampaignTargetDO struct {
+	ID           int64                `gorm:"primarykey" json:"id"`
+	CampaignID   int64                `json:"campaign_id" gorm:"column:campaign_id;type:bigint;not null;uniqueIndex:idx_campaign_user;index:idx_campaign_status,priority:1;comment:活动ID"`
+	UserID       int64                `json:"user_id" gorm:"column:user_id;type:bigint;not null;uniqueIndex:idx_campaign_user;comment:用户ID"`
+	Status       CampaignTargetStatus `json:"status" gorm:"column:status;type:tinyint;not null;default:1;index:idx_campaign_status,priority:2;comment:发放状态"`
+	UserCouponID int64                `json:"user_coupon_id" gorm:"column:user_coupon_id;type:bigint;not null;default:0;comment:发放的用户优惠券ID"`
+	Reason       string               `json:"reason" gorm:"column:reason;type:varchar(255);not null;default:'';comment:跳过或失败原因"`
+	DispatchedAt *time.Time           `json:"dispatched_at" gorm:"column:dispatched_at;type:timestamp NULL;comment:投递时间"`
+	CreatedAt    time.Time            `json:"created_at" gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+	UpdatedAt    time.Time            `json:"updated_at" gorm:"column:updated_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+// TableName 指定表名
+func (CampaignTargetDO) TableName() string {
+	return "coupon_campaign_targets"
+}
@@ -118,6 +118,24 @@ func (CouponConfigDO) TableName() string {
 	return "coupon_configs"
 }
 
+// CouponReminderDO 优惠券即将过期提醒数据对象
+type CouponReminderDO struct {
+	ID               int64     `gorm:"primarykey" json:"id"`
+	UserCouponID     int64     `json:"user_coupon_id" gorm:"column:user_coupon_id;type:bigint;not null;uniqueIndex:idx_user_coupon_id;comment:用户优惠券ID"`
+	UserID           int64     `json:"user_id" gorm:"column:user_id;type:bigint;not null;index:idx_user_id;comment:用户ID"`
+	CouponTemplateID int64     `json:"coupon_template_id" gorm:"column:coupon_template_id;type:bigint;not null;comment:优惠券模板ID"`
+	CouponName       string    `json:"coupon_name" gorm:"column:coupon_name;type:varchar(100);not null;default:'';comment:优惠券名称"`
+	CouponCode       string    `json:"coupon_code" gorm:"column:coupon_code;type:varchar(32);not null;comment:优惠券码"`
+	ExpiredAt        time.Time `json:"expired_at" gorm:"column:expired_at;type:timestamp;not null;comment:过期时间"`
+	RemainingDays    int32     `json:"remaining_days" gorm:"column:remaining_days;type:int;not null;comment:生成提醒时剩余天数"`
+	CreatedAt        time.Time `json:"created_at" gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP;index:idx_created_at"`
+}
+
+// TableName 指定表名
+func (CouponReminderDO) TableName() string {
+	return "coupon_reminders"
+}
+
 // CouponTemplateDOList 优惠券模板列表
 type CouponTemplateDOList struct {
 	TotalCount int64               `json:"totalCount"`
@@ -136,6 +154,12 @@ type CouponUsageLogDOList struct {
 	Items      []*CouponUsageLogDO `json:"items"`
 }
 
+// CouponReminderDOList 优惠券到期提醒列表
+type CouponReminderDOList struct {
+	TotalCount int64               `json:"totalCount"`
+	Items      []*CouponReminderDO `json:"items"`
+}
+
 // CouponCalculationResult 优惠券计算结果
 type CouponCalculationResult struct {
 	CouponID         int64   `json:"coupon_id"`
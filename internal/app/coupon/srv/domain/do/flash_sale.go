@@ -15,6 +15,23 @@ const (
 	FlashSaleStatusPaused  FlashSaleStatus = 4 // 已暂停
 )
 
+// FlashSaleType 秒杀活动类型
+type FlashSaleType int32
+
+const (
+	FlashSaleTypeCoupon FlashSaleType = 1 // 秒杀优惠券
+	FlashSaleTypeGoods  FlashSaleType = 2 // 商品限时秒杀价
+)
+
+// FlashSaleReservationStatus 商品秒杀预占单状态
+type FlashSaleReservationStatus int32
+
+const (
+	FlashSaleReservationReserved  FlashSaleReservationStatus = 1 // 已预占名额，等待支付
+	FlashSaleReservationConfirmed FlashSaleReservationStatus = 2 // 订单已支付，名额成交
+	FlashSaleReservationReleased  FlashSaleReservationStatus = 3 // 未支付或下单失败，名额已归还
+)
+
 // FlashSaleRecordStatus 秒杀记录状态定义
 type FlashSaleRecordStatus int32
 
@@ -28,7 +45,10 @@ const (
 type FlashSaleActivityDO struct {
 	db.BaseModel
 	ID        int64     `gorm:"primarykey" json:"id"`
-	CouponTemplateID int64           `json:"coupon_template_id" gorm:"column:coupon_template_id;type:bigint;not null;index:idx_coupon_template_id;comment:关联的优惠券模板ID"`
+	ActivityType     FlashSaleType   `json:"activity_type" gorm:"column:activity_type;type:tinyint;not null;default:1;comment:活动类型"`
+	CouponTemplateID int64           `json:"coupon_template_id" gorm:"column:coupon_template_id;type:bigint;not null;index:idx_coupon_template_id;comment:关联的优惠券模板ID,商品秒杀为0"`
+	GoodsID          int32           `json:"goods_id" gorm:"column:goods_id;type:int;not null;default:0;index:idx_goods_id;comment:秒杀商品ID"`
+	SalePrice        float32         `json:"sale_price" gorm:"column:sale_price;type:decimal(10,2);not null;default:0;comment:秒杀价"`
 	Name             string          `json:"name" gorm:"column:name;type:varchar(100);not null;comment:秒杀活动名称"`
 	StartTime        time.Time       `json:"start_time" gorm:"column:start_time;type:timestamp;not null;index:idx_flash_sale_time;comment:秒杀开始时间"`
 	EndTime          time.Time       `json:"end_time" gorm:"column:end_time;type:timestamp;not null;index:idx_flash_sale_time;comment:秒杀结束时间"`
@@ -61,6 +81,32 @@ func (FlashSaleRecordDO) TableName() string {
 	return "flash_sale_records"
 }
 
+// IsGoods 是否为商品秒杀
+func (a *FlashSaleActivityDO) IsGoods() bool {
+	return a.ActivityType == FlashSaleTypeGoods
+}
+
+// FlashSaleReservationDO 商品秒杀预占单，秒杀成功后在支付时限内保留名额
+type FlashSaleReservationDO struct {
+	ID          int64                      `gorm:"primarykey" json:"id"`
+	OrderSn     string                     `json:"order_sn" gorm:"column:order_sn;type:varchar(30);not null;uniqueIndex:uk_order_sn;comment:预占单号,即订单号"`
+	FlashSaleID int64                      `json:"flash_sale_id" gorm:"column:flash_sale_id;type:bigint;not null;index:idx_flash_sale_id;comment:秒杀活动ID"`
+	UserID      int64                      `json:"user_id" gorm:"column:user_id;type:bigint;not null;index:idx_user_id;comment:用户ID"`
+	GoodsID     int32                      `json:"goods_id" gorm:"column:goods_id;type:int;not null;comment:商品ID"`
+	Quantity    int32                      `json:"quantity" gorm:"column:quantity;type:int;not null;default:1;comment:数量"`
+	SalePrice   float32                    `json:"sale_price" gorm:"column:sale_price;type:decimal(10,2);not null;comment:秒杀价"`
+	Status      FlashSaleReservationStatus `json:"status" gorm:"column:status;type:tinyint;not null;index:idx_status_expires,priority:1;comment:状态"`
+	ExpiresAt   time.Time                  `json:"expires_at" gorm:"column:expires_at;type:timestamp;not null;index:idx_status_expires,priority:2;comment:支付截止时间"`
+	SettledAt   *time.Time                 `json:"settled_at" gorm:"column:settled_at;type:timestamp;comment:确认或归还时间"`
+	CreatedAt   time.Time                  `json:"created_at" gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+	UpdatedAt   time.Time                  `json:"updated_at" gorm:"column:updated_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+// TableName 指定表名
+func (FlashSaleReservationDO) TableName() string {
+	return "flash_sale_reservations"
+}
+
 // FlashSaleActivityDOList 秒杀活动列表
 type FlashSaleActivityDOList struct {
 	TotalCount int64                  `json:"totalCount"`
@@ -0,0 +1,64 @@
+package do
+
+import "time"
+
+// PromoCodeKind 兑换码类型
+type PromoCodeKind int32
+
+const (
+	PromoCodeKindUnique PromoCodeKind = 1 // 批量生成的一次性兑换码
+	PromoCodeKindPublic PromoCodeKind = 2 // 公开兑换码，每个用户可兑换一次
+)
+
+// PromoCodeStatus 兑换码状态
+type PromoCodeStatus int32
+
+const (
+	PromoCodeStatusActive   PromoCodeStatus = 1 // 可兑换
+	PromoCodeStatusDisabled PromoCodeStatus = 2 // 已停用
+)
+
+// PromoCodeDO 优惠券兑换码数据对象
+type PromoCodeDO struct {
+	ID               int64           `gorm:"primarykey" json:"id"`
+	Code             string          `json:"code" gorm:"column:code;type:varchar(32);not null;uniqueIndex:idx_code;comment:兑换码"`
+	CouponTemplateID int64           `json:"coupon_template_id" gorm:"column:coupon_template_id;type:bigint;not null;index:idx_template_batch;comment:优惠券模板ID"`
+	BatchNo          string          `json:"batch_no" gorm:"column:batch_no;type:varchar(32);not null;default:'';index:idx_template_batch;comment:生成批次号"`
+	Kind             PromoCodeKind   `json:"kind" gorm:"column:kind;type:tinyint;not null;comment:兑换码类型"`
+	MaxRedemptions   int32           `json:"max_redemptions" gorm:"column:max_redemptions;type:int;not null;default:1;comment:最大兑换次数,0表示不限"`
+	RedeemedCount    int32           `json:"redeemed_count" gorm:"column:redeemed_count;type:int;not null;default:0;comment:已兑换次数"`
+	Status           PromoCodeStatus `json:"status" gorm:"column:status;type:tinyint;not null;default:1;comment:状态"`
+	Operator         string          `json:"operator" gorm:"column:operator;type:varchar(64);not null;default:'';comment:创建人"`
+	CreatedAt        time.Time       `json:"created_at" gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+	UpdatedAt        time.Time       `json:"updated_at" gorm:"column:updated_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+// TableName 指定表名
+func (PromoCodeDO) TableName() string {
+	return "coupon_promo_codes"
+}
+
+// Exhausted 兑换次数是否已用完
+func (p *PromoCodeDO) Exhausted() bool {
+	return p.MaxRedemptions > 0 && p.RedeemedCount >= p.MaxRedemptions
+}
+
+// PromoCodeRedemptionDO 兑换码兑换记录，同一兑换码每个用户只能兑换一次
+type PromoCodeRedemptionDO struct {
+	ID           int64     `gorm:"primarykey" json:"id"`
+	PromoCodeID  int64     `json:"promo_code_id" gorm:"column:promo_code_id;type:bigint;not null;uniqueIndex:idx_code_user;comment:兑换码ID"`
+	UserID       int64     `json:"user_id" gorm:"column:user_id;type:bigint;not null;uniqueIndex:idx_code_user;index:idx_user_id;comment:用户ID"`
+	UserCouponID int64     `json:"user_coupon_id" gorm:"column:user_coupon_id;type:bigint;not null;comment:发放的用户优惠券ID"`
+	CreatedAt    time.Time `json:"created_at" gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+// TableName 指定表名
+func (PromoCodeRedemptionDO) TableName() string {
+	return "coupon_promo_code_redemptions"
+}
+
+// PromoCodeDOList 兑换码列表
+type PromoCodeDOList struct {
+	TotalCount int64          `json:"totalCount"`
+	Items      []*PromoCodeDO `json:"items"`
+}
@@ -0,0 +1,47 @@
+package do
+
+import "time"
+
+// CouponUsageActionUse 使用记录操作类型：下单使用
+const CouponUsageActionUse = "use"
+
+// CouponDailyStatDO 优惠券模板每日统计，由定时任务从领取、使用和过期记录聚合
+//
+// CouponTemplateID为0的记录是全部模板的汇总，订单数与GMV按订单去重，
+// 一个订单叠加多张优惠券时在各模板记录中都会计入该订单
+type CouponDailyStatDO struct {
+	ID               int64     `gorm:"primarykey" json:"id"`
+	StatDate         time.Time `json:"stat_date" gorm:"column:stat_date;type:date;not null;uniqueIndex:uk_date_template;comment:统计日期"`
+	CouponTemplateID int64     `json:"coupon_template_id" gorm:"column:coupon_template_id;type:bigint;not null;uniqueIndex:uk_date_template;index:idx_template_id;comment:优惠券模板ID，0为汇总"`
+	IssuedCount      int64     `json:"issued_count" gorm:"column:issued_count;type:bigint;not null;default:0;comment:发放数量"`
+	RedeemedCount    int64     `json:"redeemed_count" gorm:"column:redeemed_count;type:bigint;not null;default:0;comment:核销数量"`
+	ExpiredCount     int64     `json:"expired_count" gorm:"column:expired_count;type:bigint;not null;default:0;comment:过期数量"`
+	OrderCount       int64     `json:"order_count" gorm:"column:order_count;type:bigint;not null;default:0;comment:用券订单数"`
+	OriginalAmount   float64   `json:"original_amount" gorm:"column:original_amount;type:decimal(14,2);not null;default:0;comment:用券订单原始金额"`
+	DiscountAmount   float64   `json:"discount_amount" gorm:"column:discount_amount;type:decimal(14,2);not null;default:0;comment:优惠金额"`
+	GMV              float64   `json:"gmv" gorm:"column:gmv;type:decimal(14,2);not null;default:0;comment:用券订单实付金额"`
+	UpdatedAt        time.Time `json:"updated_at" gorm:"column:updated_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+// TableName 指定表名
+func (CouponDailyStatDO) TableName() string {
+	return "coupon_daily_stats"
+}
+
+// FlashSaleDailyStatDO 秒杀活动每日统计
+type FlashSaleDailyStatDO struct {
+	ID               int64     `gorm:"primarykey" json:"id"`
+	StatDate         time.Time `json:"stat_date" gorm:"column:stat_date;type:date;not null;uniqueIndex:uk_date_flash_sale;comment:统计日期"`
+	FlashSaleID      int64     `json:"flash_sale_id" gorm:"column:flash_sale_id;type:bigint;not null;uniqueIndex:uk_date_flash_sale;index:idx_flash_sale_id;comment:秒杀活动ID"`
+	CouponTemplateID int64     `json:"coupon_template_id" gorm:"column:coupon_template_id;type:bigint;not null;default:0;comment:优惠券模板ID"`
+	TotalStock       int32     `json:"total_stock" gorm:"column:total_stock;type:int;not null;default:0;comment:秒杀总库存"`
+	SoldCount        int64     `json:"sold_count" gorm:"column:sold_count;type:bigint;not null;default:0;comment:当日抢购成功数"`
+	FailedCount      int64     `json:"failed_count" gorm:"column:failed_count;type:bigint;not null;default:0;comment:当日抢购失败数"`
+	CumulativeSold   int64     `json:"cumulative_sold" gorm:"column:cumulative_sold;type:bigint;not null;default:0;comment:截至当日累计售出"`
+	UpdatedAt        time.Time `json:"updated_at" gorm:"column:updated_at;type:timestamp;default:CURRENT_TIMESTAMP"`
+}
+
+// TableName 指定表名
+func (FlashSaleDailyStatDO) TableName() string {
+	return "flash_sale_daily_stats"
+}
@@ -0,0 +1,48 @@
+package do
+
+import "time"
+
+// 风控场景
+const (
+	RiskSceneReceiveCoupon = "receive_coupon"
+	RiskSceneFlashSale     = "flash_sale"
+	RiskSceneSms           = "sms"
+)
+
+// RiskDecisionDO 风控决策记录，每次评分都会写入，供后台复核
+type RiskDecisionDO struct {
+	ID        int64     `gorm:"primarykey" json:"id"`
+	Scene     string    `json:"scene" gorm:"column:scene;type:varchar(32);not null;index:idx_scene_action;comment:场景"`
+	TargetID  int64     `json:"target_id" gorm:"column:target_id;type:bigint;not null;default:0;comment:优惠券模板ID或秒杀活动ID"`
+	UserID    int64     `json:"user_id" gorm:"column:user_id;type:bigint;not null;default:0;index:idx_user_id;comment:用户ID"`
+	Mobile    string    `json:"mobile" gorm:"column:mobile;type:varchar(20);not null;default:'';comment:手机号"`
+	DeviceID  string    `json:"device_id" gorm:"column:device_id;type:varchar(64);not null;default:'';comment:设备ID"`
+	ClientIP  string    `json:"client_ip" gorm:"column:client_ip;type:varchar(64);not null;default:'';comment:客户端IP"`
+	Score     int       `json:"score" gorm:"column:score;type:int;not null;default:0;comment:风险分"`
+	Action    string    `json:"action" gorm:"column:action;type:varchar(16);not null;index:idx_scene_action;comment:动作"`
+	Reasons   string    `json:"reasons" gorm:"column:reasons;type:varchar(255);not null;default:'';comment:命中信号，逗号分隔"`
+	Signals   string    `json:"signals" gorm:"column:signals;type:varchar(1024);not null;default:'';comment:信号快照JSON"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at;type:timestamp;default:CURRENT_TIMESTAMP;index:idx_created_at"`
+}
+
+// TableName 指定表名
+func (RiskDecisionDO) TableName() string {
+	return "coupon_risk_decisions"
+}
+
+// RiskDecisionFilter 决策记录查询条件，零值字段不参与过滤
+type RiskDecisionFilter struct {
+	Scene    string
+	Action   string
+	UserID   int64
+	DeviceID string
+	ClientIP string
+	From     time.Time
+	To       time.Time
+}
+
+// RiskDecisionDOList 决策记录列表
+type RiskDecisionDOList struct {
+	TotalCount int64             `json:"totalCount"`
+	Items      []*RiskDecisionDO `json:"items"`
+}
@@ -93,6 +93,53 @@ type UserCouponListDTO struct {
 	Items      []*UserCouponDTO `json:"items"`
 }
 
+// ListCouponRemindersDTO 获取用户到期提醒列表DTO
+type ListCouponRemindersDTO struct {
+	UserID   int64 `json:"user_id" validate:"required"`
+	Page     int32 `json:"page" validate:"required,min=1"`
+	PageSize int32 `json:"page_size" validate:"required,min=1,max=100"`
+}
+
+// CouponReminderDTO 优惠券到期提醒DTO
+type CouponReminderDTO struct {
+	ID               int64     `json:"id"`
+	UserCouponID     int64     `json:"user_coupon_id"`
+	CouponTemplateID int64     `json:"coupon_template_id"`
+	CouponName       string    `json:"coupon_name"`
+	CouponCode       string    `json:"coupon_code"`
+	ExpiredAt        time.Time `json:"expired_at"`
+	RemainingDays    int32     `json:"remaining_days"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// CouponReminderListDTO 优惠券到期提醒列表响应DTO
+type CouponReminderListDTO struct {
+	TotalCount int64                `json:"total_count"`
+	Items      []*CouponReminderDTO `json:"items"`
+}
+
+// DryRunCouponRulesDTO 业务规则试算DTO
+type DryRunCouponRulesDTO struct {
+	Rules             string    `json:"rules"` // 候选规则参数JSON，为空时使用当前生效的规则
+	UserID            int64     `json:"user_id"`
+	OrderAmount       float64   `json:"order_amount" validate:"required,gt=0"`
+	CouponTemplateIDs []int64   `json:"coupon_template_ids"`
+	At                time.Time `json:"at"` // 试算时间，为零值时使用当前时间
+}
+
+// CouponRuleResultDTO 单条业务规则试算结果DTO
+type CouponRuleResultDTO struct {
+	Rule   string `json:"rule"`
+	Passed bool   `json:"passed"`
+	Reason string `json:"reason"`
+}
+
+// CouponRuleDryRunResultDTO 业务规则试算结果DTO
+type CouponRuleDryRunResultDTO struct {
+	Passed  bool                   `json:"passed"`
+	Results []*CouponRuleResultDTO `json:"results"`
+}
+
 // GetAvailableCouponsDTO 获取可用优惠券DTO
 type GetAvailableCouponsDTO struct {
 	UserID      int64   `json:"user_id" validate:"required"`
@@ -146,4 +193,219 @@ type UseCouponsResultDTO struct {
 // ReleaseCouponsDTO 释放优惠券DTO
 type ReleaseCouponsDTO struct {
 	OrderSn string `json:"order_sn" validate:"required"`
-}
\ No newline at end of file
+}
+// GeneratePromoCodesDTO 批量生成兑换码DTO
+type GeneratePromoCodesDTO struct {
+	CouponTemplateID int64  `json:"coupon_template_id" validate:"required"`
+	Count            int32  `json:"count" validate:"required,min=1"`
+	Prefix           string `json:"prefix"`
+	Operator         string `json:"operator"`
+}
+
+// GeneratePromoCodesResultDTO 批量生成兑换码结果DTO
+type GeneratePromoCodesResultDTO struct {
+	BatchNo string   `json:"batch_no"`
+	Codes   []string `json:"codes"`
+}
+
+// CreatePublicPromoCodeDTO 创建公开兑换码DTO
+type CreatePublicPromoCodeDTO struct {
+	CouponTemplateID int64  `json:"coupon_template_id" validate:"required"`
+	Code             string `json:"code" validate:"required"`
+	MaxRedemptions   int32  `json:"max_redemptions"` // 0表示不限，仍受模板总量限制
+	Operator         string `json:"operator"`
+}
+
+// RedeemPromoCodeDTO 兑换码兑换DTO
+type RedeemPromoCodeDTO struct {
+	UserID int64  `json:"user_id" validate:"required"`
+	Code   string `json:"code" validate:"required"`
+}
+
+// ListPromoCodesDTO 兑换码列表查询DTO
+type ListPromoCodesDTO struct {
+	CouponTemplateID int64  `json:"coupon_template_id"`
+	BatchNo          string `json:"batch_no"`
+	Page             int32  `json:"page"`
+	PageSize         int32  `json:"page_size"`
+}
+
+// PromoCodeDTO 兑换码DTO
+type PromoCodeDTO struct {
+	ID               int64     `json:"id"`
+	Code             string    `json:"code"`
+	CouponTemplateID int64     `json:"coupon_template_id"`
+	BatchNo          string    `json:"batch_no"`
+	Kind             int32     `json:"kind"`
+	MaxRedemptions   int32     `json:"max_redemptions"`
+	RedeemedCount    int32     `json:"redeemed_count"`
+	Status           int32     `json:"status"`
+	Operator         string    `json:"operator"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// PromoCodeListDTO 兑换码列表DTO
+type PromoCodeListDTO struct {
+	TotalCount int64           `json:"total_count"`
+	Items      []*PromoCodeDTO `json:"items"`
+}
+
+// CreateCouponCampaignDTO 创建定向发券活动DTO
+type CreateCouponCampaignDTO struct {
+	Name                 string `json:"name" validate:"required"`
+	CouponTemplateID     int64  `json:"coupon_template_id" validate:"required"`
+	SegmentType          string `json:"segment_type" validate:"required"`
+	SegmentParams        string `json:"segment_params"`
+	BatchSize            int32  `json:"batch_size"`
+	BatchIntervalSeconds int32  `json:"batch_interval_seconds"`
+	Operator             string `json:"operator"`
+}
+
+// AddCampaignTargetsDTO 追加活动目标用户DTO
+type AddCampaignTargetsDTO struct {
+	CampaignID int64   `json:"campaign_id" validate:"required"`
+	UserIDs    []int64 `json:"user_ids"`
+}
+
+// ListCouponCampaignsDTO 发券活动列表查询DTO
+type ListCouponCampaignsDTO struct {
+	Status   int32 `json:"status"`
+	Page     int32 `json:"page"`
+	PageSize int32 `json:"page_size"`
+}
+
+// CouponCampaignDTO 发券活动及发放进度DTO
+type CouponCampaignDTO struct {
+	ID                   int64      `json:"id"`
+	Name                 string     `json:"name"`
+	CouponTemplateID     int64      `json:"coupon_template_id"`
+	SegmentType          string     `json:"segment_type"`
+	SegmentParams        string     `json:"segment_params"`
+	Status               int32      `json:"status"`
+	BatchSize            int32      `json:"batch_size"`
+	BatchIntervalSeconds int32      `json:"batch_interval_seconds"`
+	TotalTargets         int64      `json:"total_targets"`
+	PendingCount         int64      `json:"pending_count"`
+	DispatchedCount      int64      `json:"dispatched_count"`
+	IssuedCount          int64      `json:"issued_count"`
+	SkippedCount         int64      `json:"skipped_count"`
+	FailedCount          int64      `json:"failed_count"`
+	Operator             string     `json:"operator"`
+	StartedAt            *time.Time `json:"started_at"`
+	FinishedAt           *time.Time `json:"finished_at"`
+	CreatedAt            time.Time  `json:"created_at"`
+}
+
+// CouponCampaignListDTO 发券活动列表DTO
+type CouponCampaignListDTO struct {
+	TotalCount int64                `json:"total_count"`
+	Items      []*CouponCampaignDTO `json:"items"`
+}
+
+// CouponReportQueryDTO 优惠券报表查询DTO，日期范围为闭区间
+type CouponReportQueryDTO struct {
+	CouponTemplateID int64     `json:"coupon_template_id"` // 0表示全部模板
+	From             time.Time `json:"from"`
+	To               time.Time `json:"to"`
+}
+
+// CouponStatDTO 优惠券效果统计DTO，Date为空表示区间汇总
+type CouponStatDTO struct {
+	Date             string  `json:"date"`
+	CouponTemplateID int64   `json:"coupon_template_id"`
+	IssuedCount      int64   `json:"issued_count"`
+	RedeemedCount    int64   `json:"redeemed_count"`
+	ExpiredCount     int64   `json:"expired_count"`
+	RedemptionRate   float64 `json:"redemption_rate"` // 核销数/发放数
+	OrderCount       int64   `json:"order_count"`
+	OriginalAmount   float64 `json:"original_amount"`
+	DiscountAmount   float64 `json:"discount_amount"`
+	GMV              float64 `json:"gmv"`              // 用券订单实付金额
+	AvgOrderAmount   float64 `json:"avg_order_amount"` // 用券订单平均实付金额
+}
+
+// CouponReportDTO 优惠券效果报表DTO
+type CouponReportDTO struct {
+	Days      []*CouponStatDTO `json:"days"`      // 按天统计
+	Templates []*CouponStatDTO `json:"templates"` // 按模板汇总，查询指定模板时只有该模板
+	Total     *CouponStatDTO   `json:"total"`     // 区间汇总
+}
+
+// FlashSaleReportQueryDTO 秒杀报表查询DTO，日期范围为闭区间
+type FlashSaleReportQueryDTO struct {
+	FlashSaleID int64     `json:"flash_sale_id"` // 0表示全部活动
+	From        time.Time `json:"from"`
+	To          time.Time `json:"to"`
+}
+
+// FlashSaleStatDTO 秒杀每日售罄统计DTO
+type FlashSaleStatDTO struct {
+	Date             string  `json:"date"`
+	FlashSaleID      int64   `json:"flash_sale_id"`
+	CouponTemplateID int64   `json:"coupon_template_id"`
+	TotalStock       int32   `json:"total_stock"`
+	SoldCount        int64   `json:"sold_count"`
+	FailedCount      int64   `json:"failed_count"`
+	CumulativeSold   int64   `json:"cumulative_sold"`
+	SellThroughRate  float64 `json:"sell_through_rate"` // 截至当天累计售出/总库存
+}
+
+// 验证码结果
+const (
+	CaptchaNotSubmitted int32 = 0
+	CaptchaPassed       int32 = 1
+	CaptchaFailed       int32 = 2
+)
+
+// RiskContextDTO 网关采集的风控信号
+type RiskContextDTO struct {
+	DeviceID           string    `json:"device_id"`
+	ClientIP           string    `json:"client_ip"`
+	Mobile             string    `json:"mobile"`
+	RegisteredAt       time.Time `json:"registered_at"` // 零值表示未知
+	SharedAddressUsers int32     `json:"shared_address_users"`
+	CaptchaResult      int32     `json:"captcha_result"`
+}
+
+// RiskEvaluateDTO 风控评分请求DTO
+type RiskEvaluateDTO struct {
+	Scene    string          `json:"scene"`
+	UserID   int64           `json:"user_id"`
+	TargetID int64           `json:"target_id"` // 优惠券模板ID或秒杀活动ID
+	Context  *RiskContextDTO `json:"context"`
+}
+
+// RiskDecisionDTO 风控决策DTO
+type RiskDecisionDTO struct {
+	ID        int64     `json:"id"`
+	Scene     string    `json:"scene"`
+	TargetID  int64     `json:"target_id"`
+	UserID    int64     `json:"user_id"`
+	Mobile    string    `json:"mobile"`
+	DeviceID  string    `json:"device_id"`
+	ClientIP  string    `json:"client_ip"`
+	Score     int       `json:"score"`
+	Action    string    `json:"action"`
+	Reasons   []string  `json:"reasons"`
+	Signals   string    `json:"signals"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ListRiskDecisionsDTO 风控决策记录查询DTO，时间范围左闭右开
+type ListRiskDecisionsDTO struct {
+	Scene    string    `json:"scene"`
+	Action   string    `json:"action"`
+	UserID   int64     `json:"user_id"`
+	DeviceID string    `json:"device_id"`
+	ClientIP string    `json:"client_ip"`
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	Page     int32     `json:"page"`
+	PageSize int32     `json:"page_size"`
+}
+
+// RiskDecisionListDTO 风控决策记录列表DTO
+type RiskDecisionListDTO struct {
+	TotalCount int64              `json:"total_count"`
+	Items      []*RiskDecisionDTO `json:"items"`
+}
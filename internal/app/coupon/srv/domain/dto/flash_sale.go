@@ -7,7 +7,10 @@ import (
 
 // CreateFlashSaleActivityDTO 创建秒杀活动DTO
 type CreateFlashSaleActivityDTO struct {
-	CouponTemplateID int64     `json:"coupon_template_id" validate:"required"`
+	ActivityType     int32     `json:"activity_type" validate:"omitempty,oneof=1 2"`
+	CouponTemplateID int64     `json:"coupon_template_id" validate:"required_unless=ActivityType 2"`
+	GoodsID          int32     `json:"goods_id" validate:"required_if=ActivityType 2"`
+	SalePrice        float32   `json:"sale_price" validate:"required_if=ActivityType 2"`
 	Name             string    `json:"name" validate:"required,max=100"`
 	StartTime        time.Time `json:"start_time" validate:"required"`
 	EndTime          time.Time `json:"end_time" validate:"required"`
@@ -18,7 +21,10 @@ type CreateFlashSaleActivityDTO struct {
 // FlashSaleActivityDTO 秒杀活动DTO
 type FlashSaleActivityDTO struct {
 	ID               int64              `json:"id"`
+	ActivityType     int32              `json:"activity_type"`
 	CouponTemplateID int64              `json:"coupon_template_id"`
+	GoodsID          int32              `json:"goods_id,omitempty"`
+	SalePrice        float32            `json:"sale_price,omitempty"`
 	CouponID         int64              `json:"coupon_id"`         // 兼容字段
 	Name             string             `json:"name"`
 	StartTime        time.Time          `json:"start_time"`
@@ -68,6 +74,18 @@ type ParticipateFlashSaleResultDTO struct {
 	UserCouponID  *int64  `json:"user_coupon_id,omitempty"`
 }
 
+// FlashSaleReservationDTO 商品秒杀预占单DTO
+type FlashSaleReservationDTO struct {
+	OrderSn     string    `json:"order_sn"`
+	FlashSaleID int64     `json:"flash_sale_id"`
+	UserID      int64     `json:"user_id"`
+	GoodsID     int32     `json:"goods_id"`
+	Quantity    int32     `json:"quantity"`
+	SalePrice   float32   `json:"sale_price"`
+	Status      int32     `json:"status"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
 // FlashSaleStockDTO 秒杀库存信息DTO
 type FlashSaleStockDTO struct {
 	FlashSaleID    int64 `json:"flash_sale_id"`
@@ -161,4 +179,23 @@ type UpdateFlashSaleActivityDTO struct {
 	EndTime        time.Time `json:"end_time,omitempty"`
 	FlashSaleCount int32     `json:"flash_sale_count,omitempty" validate:"omitempty,min=1"`
 	PerUserLimit   int32     `json:"per_user_limit,omitempty" validate:"omitempty,min=1"`
-}
\ No newline at end of file
+}
+// 秒杀排队状态
+const (
+	FlashSaleQueueWaiting   int32 = 1 // 排队中
+	FlashSaleQueueAdmitted  int32 = 2 // 已放行，可凭令牌参与秒杀
+	FlashSaleQueueNotQueued int32 = 3 // 未排队或放行已过期
+	FlashSaleQueueClosed    int32 = 4 // 活动已结束或已售罄
+)
+
+// FlashSaleQueueStatusDTO 秒杀排队状态DTO
+type FlashSaleQueueStatusDTO struct {
+	FlashSaleID          int64  `json:"flash_sale_id"`
+	Status               int32  `json:"status"`
+	Position             int64  `json:"position"`               // 排队中时前面还有多少人(含自己)
+	QueueLength          int64  `json:"queue_length"`           // 当前排队总人数
+	EstimatedWaitSeconds int64  `json:"estimated_wait_seconds"` // 预计等待秒数
+	AdmissionToken       string `json:"admission_token"`        // 已放行时的放行令牌
+	TokenExpiresAt       int64  `json:"token_expires_at"`       // 令牌过期时间(Unix秒)
+	Message              string `json:"message"`
+}
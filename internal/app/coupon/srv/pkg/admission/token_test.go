@@ -0,0 +1,32 @@
+package admission
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	signer := NewSigner([]byte("secret"))
+	now := time.Now()
+	token := signer.Sign(7, 1001, now.Add(time.Minute))
+
+	assert.NoError(t, signer.Verify(token, 7, 1001, now))
+	assert.Equal(t, ErrMismatch, signer.Verify(token, 7, 1002, now))
+	assert.Equal(t, ErrMismatch, signer.Verify(token, 8, 1001, now))
+	assert.Equal(t, ErrExpired, signer.Verify(token, 7, 1001, now.Add(time.Minute)))
+}
+
+func TestVerifyRejectsTampered(t *testing.T) {
+	signer := NewSigner([]byte("secret"))
+	token := signer.Sign(7, 1001, time.Now().Add(time.Minute))
+	payload, sig, _ := strings.Cut(token, ".")
+
+	forged := encoding.EncodeToString([]byte("7:1002:99999999999999")) + "." + sig
+	assert.Equal(t, ErrMalformed, signer.Verify(forged, 7, 1002, time.Now()))
+	assert.Equal(t, ErrMalformed, NewSigner([]byte("other")).Verify(token, 7, 1001, time.Now()))
+	assert.Equal(t, ErrMalformed, signer.Verify(payload, 7, 1001, time.Now()))
+	assert.Equal(t, ErrMalformed, signer.Verify("", 7, 1001, time.Now()))
+}
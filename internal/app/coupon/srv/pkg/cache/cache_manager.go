@@ -127,7 +127,7 @@ func (ccm *CouponCacheManager) GetCouponTemplate(ctx context.Context, couponID i
 	// L1: Ristretto本地缓存查询
 	if value, found := ccm.localCache.Get(key); found {
 		template := value.(*CouponTemplate)
-		log.Debugf("命中L1缓存, couponID: %d", couponID)
+		log.DebugfC(ctx, "命中L1缓存, couponID: %d", couponID)
 		return template, nil
 	}
 
@@ -137,7 +137,7 @@ func (ccm *CouponCacheManager) GetCouponTemplate(ctx context.Context, couponID i
 		if err := json.Unmarshal([]byte(data), &template); err == nil {
 			// 回写L1缓存 (成本为1，TTL从配置读取)
 			ccm.localCache.SetWithTTL(key, &template, 1, ccm.config.L1TTL)
-			log.Debugf("命中L2缓存, couponID: %d", couponID)
+			log.DebugfC(ctx, "命中L2缓存, couponID: %d", couponID)
 			return &template, nil
 		}
 	}
@@ -155,7 +155,7 @@ func (ccm *CouponCacheManager) GetCouponTemplate(ctx context.Context, couponID i
 	// 回写L1缓存
 	ccm.localCache.SetWithTTL(key, template, 1, ccm.config.L1TTL)
 
-	log.Debugf("命中L3数据库, couponID: %d", couponID)
+	log.DebugfC(ctx, "命中L3数据库, couponID: %d", couponID)
 	return template, nil
 }
 
@@ -166,7 +166,7 @@ func (ccm *CouponCacheManager) GetUserCoupon(ctx context.Context, userCouponID i
 	// L1: 本地缓存查询
 	if value, found := ccm.localCache.Get(key); found {
 		userCoupon := value.(*UserCoupon)
-		log.Debugf("命中L1缓存, userCouponID: %d", userCouponID)
+		log.DebugfC(ctx, "命中L1缓存, userCouponID: %d", userCouponID)
 		return userCoupon, nil
 	}
 
@@ -175,7 +175,7 @@ func (ccm *CouponCacheManager) GetUserCoupon(ctx context.Context, userCouponID i
 		var userCoupon UserCoupon
 		if err := json.Unmarshal([]byte(data), &userCoupon); err == nil {
 			ccm.localCache.SetWithTTL(key, &userCoupon, 1, ccm.config.L1TTL)
-			log.Debugf("命中L2缓存, userCouponID: %d", userCouponID)
+			log.DebugfC(ctx, "命中L2缓存, userCouponID: %d", userCouponID)
 			return &userCoupon, nil
 		}
 	}
@@ -191,7 +191,7 @@ func (ccm *CouponCacheManager) GetUserCoupon(ctx context.Context, userCouponID i
 	ccm.redis.SetEX(ctx, key, data, ccm.config.L2TTL)
 	ccm.localCache.SetWithTTL(key, userCoupon, 1, ccm.config.L1TTL)
 
-	log.Debugf("命中L3数据库, userCouponID: %d", userCouponID)
+	log.DebugfC(ctx, "命中L3数据库, userCouponID: %d", userCouponID)
 	return userCoupon, nil
 }
 
@@ -202,7 +202,7 @@ func (ccm *CouponCacheManager) GetFlashSaleActivity(ctx context.Context, activit
 	// L1: 本地缓存查询
 	if value, found := ccm.localCache.Get(key); found {
 		activity := value.(*FlashSaleActivity)
-		log.Debugf("命中L1缓存, activityID: %d", activityID)
+		log.DebugfC(ctx, "命中L1缓存, activityID: %d", activityID)
 		return activity, nil
 	}
 
@@ -211,7 +211,7 @@ func (ccm *CouponCacheManager) GetFlashSaleActivity(ctx context.Context, activit
 		var activity FlashSaleActivity
 		if err := json.Unmarshal([]byte(data), &activity); err == nil {
 			ccm.localCache.SetWithTTL(key, &activity, 1, ccm.config.L1TTL)
-			log.Debugf("命中L2缓存, activityID: %d", activityID)
+			log.DebugfC(ctx, "命中L2缓存, activityID: %d", activityID)
 			return &activity, nil
 		}
 	}
@@ -227,7 +227,7 @@ func (ccm *CouponCacheManager) GetFlashSaleActivity(ctx context.Context, activit
 	ccm.redis.SetEX(ctx, key, data, ccm.config.L2TTL)
 	ccm.localCache.SetWithTTL(key, activity, 1, ccm.config.L1TTL)
 
-	log.Debugf("命中L3数据库, activityID: %d", activityID)
+	log.DebugfC(ctx, "命中L3数据库, activityID: %d", activityID)
 	return activity, nil
 }
 
@@ -253,18 +253,18 @@ func (ccm *CouponCacheManager) InvalidateCacheByPattern(ctx context.Context, pat
 	if len(keys) > 0 {
 		// 批量删除Redis缓存
 		ccm.redis.Del(ctx, keys...)
-		log.Infof("批量失效Redis缓存: %d个键, pattern: %s", len(keys), pattern)
+		log.InfofC(ctx, "批量失效Redis缓存: %d个键, pattern: %s", len(keys), pattern)
 	}
 
 	// 本地缓存只能逐个删除，这里简化处理
 	// 在实际场景中，可以考虑增加本地缓存的标记机制
-	log.Infof("模式失效缓存: %s", pattern)
+	log.InfofC(ctx, "模式失效缓存: %s", pattern)
 	return nil
 }
 
 // WarmupCache 缓存预热
 func (ccm *CouponCacheManager) WarmupCache(ctx context.Context) error {
-	log.Info("开始缓存预热...")
+	log.InfoC(ctx, "开始缓存预热...")
 
 	// 查询热门优惠券模板
 	hotCoupons, err := ccm.repository.GetHotCouponTemplates(ctx, ccm.config.WarmupCount)
@@ -284,7 +284,7 @@ func (ccm *CouponCacheManager) WarmupCache(ctx context.Context) error {
 		ccm.localCache.SetWithTTL(key, coupon, 10, ccm.config.L1TTL)
 	}
 
-	log.Infof("缓存预热完成，预热%d个优惠券模板", len(hotCoupons))
+	log.InfofC(ctx, "缓存预热完成，预热%d个优惠券模板", len(hotCoupons))
 	return nil
 }
 
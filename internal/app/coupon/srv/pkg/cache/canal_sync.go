@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"emshop/pkg/dlq"
 	"emshop/pkg/log"
 	"github.com/apache/rocketmq-client-go/v2"
 	"github.com/apache/rocketmq-client-go/v2/consumer"
@@ -37,6 +38,7 @@ type CanalSyncManager struct {
 	watchTables  []string
 	batchSize    int
 	database     string
+	recorder     *dlq.Recorder
 }
 
 // CanalSyncConfig Canal同步配置
@@ -80,6 +82,11 @@ func NewCanalSyncManager(cacheManager CacheManager, config *CanalSyncConfig) (*C
 	return manager, nil
 }
 
+// SetDeadLetter 设置多次重投仍失败时写入的死信记录器
+func (csm *CanalSyncManager) SetDeadLetter(recorder *dlq.Recorder) {
+	csm.recorder = recorder
+}
+
 // Start 启动Canal同步
 func (csm *CanalSyncManager) Start() error {
 	err := csm.consumer.Start()
@@ -102,23 +109,23 @@ func (csm *CanalSyncManager) Stop() error {
 }
 
 // handleCanalMessage 处理Canal消息
+//
+// 缓存失效失败时交由RocketMQ重投，避免旧缓存一直有效；多次重投仍失败或消息无法解析时写入死信
 func (csm *CanalSyncManager) handleCanalMessage(ctx context.Context, msgs ...*primitive.MessageExt) (consumer.ConsumeResult, error) {
-	for _, msg := range msgs {
+	return csm.recorder.Wrap(func(ctx context.Context, msg *primitive.MessageExt) error {
 		if err := csm.processCanalMessage(msg); err != nil {
-			log.Errorf("处理Canal消息失败: %v, msgId: %s", err, msg.MsgId)
-			// 对于缓存同步失败，通常选择继续处理其他消息，而不是重试
-			// 因为缓存可以通过其他方式恢复（如查询数据库）
-			continue
+			log.ErrorfC(ctx, "处理Canal消息失败: %v, msgId: %s", err, msg.MsgId)
+			return err
 		}
-	}
-	return consumer.ConsumeSuccess, nil
+		return nil
+	})(ctx, msgs...)
 }
 
 // processCanalMessage 处理单个Canal消息
 func (csm *CanalSyncManager) processCanalMessage(msg *primitive.MessageExt) error {
 	var canalMsg CanalMessage
 	if err := json.Unmarshal(msg.Body, &canalMsg); err != nil {
-		return fmt.Errorf("解析Canal消息失败: %v", err)
+		return dlq.Permanent(fmt.Errorf("解析Canal消息失败: %v", err))
 	}
 
 	// 检查数据库是否匹配
@@ -186,11 +193,18 @@ func (csm *CanalSyncManager) handleDataChange(canalMsg CanalMessage) error {
 func (csm *CanalSyncManager) handleDDLChange(canalMsg CanalMessage) error {
 	// DDL变更通常需要清空相关表的所有缓存
 	patterns := csm.getDDLCachePatterns(canalMsg.Table)
+	var firstErr error
 	for _, pattern := range patterns {
 		if err := csm.cacheManager.InvalidateCacheByPattern(context.Background(), pattern); err != nil {
 			log.Errorf("DDL缓存失效失败: pattern=%s, err=%v", pattern, err)
+			if firstErr == nil {
+				firstErr = fmt.Errorf("DDL缓存失效失败: pattern=%s, err=%v", pattern, err)
+			}
 		}
 	}
+	if firstErr != nil {
+		return firstErr
+	}
 	log.Infof("DDL操作缓存清理完成: table=%s", canalMsg.Table)
 	return nil
 }
@@ -2,7 +2,8 @@ package calculator
 
 import (
 	"fmt"
-	"time"
+	"sort"
+	"sync"
 
 	"emshop/internal/app/coupon/srv/domain/do"
 	"emshop/pkg/log"
@@ -16,72 +17,142 @@ type BusinessRule interface {
 }
 
 // BusinessRuleEngine 业务规则引擎
+//
+// 内置规则的参数来自RuleSet，可通过ApplyRuleSet在运行时整体替换；AddRule添加的自定义规则在替换后保留
 type BusinessRuleEngine struct {
-	rules []BusinessRule
+	mu      sync.RWMutex
+	ruleSet *RuleSet
+	rules   []BusinessRule
+	custom  []BusinessRule
 }
 
-// NewBusinessRuleEngine 创建业务规则引擎
+// RuleResult 单条规则的执行结果
+type RuleResult struct {
+	Rule   string `json:"rule"`
+	Passed bool   `json:"passed"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// NewBusinessRuleEngine 使用默认规则参数创建业务规则引擎
 func NewBusinessRuleEngine() *BusinessRuleEngine {
-	engine := &BusinessRuleEngine{
-		rules: make([]BusinessRule, 0),
+	engine := &BusinessRuleEngine{}
+	engine.install(DefaultRuleSet())
+	return engine
+}
+
+// NewBusinessRuleEngineWithRuleSet 使用指定规则参数创建业务规则引擎
+func NewBusinessRuleEngineWithRuleSet(rs *RuleSet) (*BusinessRuleEngine, error) {
+	if err := rs.Validate(); err != nil {
+		return nil, err
+	}
+	engine := &BusinessRuleEngine{}
+	engine.install(rs)
+	return engine, nil
+}
+
+// buildRules 根据规则参数构建内置规则
+func buildRules(rs *RuleSet) []BusinessRule {
+	return []BusinessRule{
+		&OrderAmountRule{maxAmount: rs.MaxOrderAmount},
+		&CouponCountRule{maxCount: rs.MaxCouponCount, holidays: rs.Holidays},
+		&TimePeriodRule{windows: rs.BlackoutWindows},
+		&UserTypeRule{userTypes: userTypeIndex(rs.UserTypes.Members), caps: rs.UserTypes.Caps},
+		&CombinationRule{limits: rs.Combination},
+		&RiskControlRule{thresholds: rs.Risk},
 	}
+}
 
-	// 注册默认规则
-	engine.registerDefaultRules()
-	
-	return engine
+// ApplyRuleSet 替换规则参数，执行中的规则检查不受影响
+func (bre *BusinessRuleEngine) ApplyRuleSet(rs *RuleSet) error {
+	if err := rs.Validate(); err != nil {
+		return err
+	}
+	bre.install(rs)
+	return nil
+}
+
+func (bre *BusinessRuleEngine) install(rs *RuleSet) {
+	bre.mu.Lock()
+	defer bre.mu.Unlock()
+	bre.ruleSet = rs
+	bre.rebuild()
+}
+
+// rebuild 合并内置规则与自定义规则并按优先级排序，调用方需持有写锁
+func (bre *BusinessRuleEngine) rebuild() {
+	rules := append(buildRules(bre.ruleSet), bre.custom...)
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].GetPriority() > rules[j].GetPriority()
+	})
+	bre.rules = rules
 }
 
-// registerDefaultRules 注册默认规则
-func (bre *BusinessRuleEngine) registerDefaultRules() {
-	bre.AddRule(&OrderAmountRule{})
-	bre.AddRule(&CouponCountRule{maxCount: 5})
-	bre.AddRule(&TimePeriodRule{})
-	bre.AddRule(&UserTypeRule{})
-	bre.AddRule(&CombinationRule{})
+// RuleSet 返回当前生效的规则参数，调用方不应修改返回值
+func (bre *BusinessRuleEngine) RuleSet() *RuleSet {
+	bre.mu.RLock()
+	defer bre.mu.RUnlock()
+	return bre.ruleSet
 }
 
-// AddRule 添加规则
+// AddRule 添加自定义规则
 func (bre *BusinessRuleEngine) AddRule(rule BusinessRule) {
-	bre.rules = append(bre.rules, rule)
-	
-	// 按优先级排序
-	for i := len(bre.rules) - 1; i > 0; i-- {
-		if bre.rules[i].GetPriority() > bre.rules[i-1].GetPriority() {
-			bre.rules[i], bre.rules[i-1] = bre.rules[i-1], bre.rules[i]
-		} else {
-			break
-		}
-	}
+	bre.mu.Lock()
+	defer bre.mu.Unlock()
+	bre.custom = append(bre.custom, rule)
+	bre.rebuild()
+}
+
+func (bre *BusinessRuleEngine) snapshot() []BusinessRule {
+	bre.mu.RLock()
+	defer bre.mu.RUnlock()
+	return bre.rules
 }
 
-// ExecuteRules 执行所有规则
+// ExecuteRules 执行所有规则，遇到第一条失败的规则即返回
 func (bre *BusinessRuleEngine) ExecuteRules(ctx *CalculationContext) error {
-	log.Infof("开始执行业务规则检查，规则数量: %d", len(bre.rules))
-	
-	for _, rule := range bre.rules {
+	rules := bre.snapshot()
+	log.Infof("开始执行业务规则检查，规则数量: %d", len(rules))
+
+	for _, rule := range rules {
 		if err := rule.Execute(ctx); err != nil {
 			log.Warnf("业务规则 %s 执行失败: %v", rule.GetRuleName(), err)
 			return fmt.Errorf("业务规则 %s 执行失败: %v", rule.GetRuleName(), err)
 		}
 	}
-	
+
 	log.Info("业务规则检查完成")
 	return nil
 }
 
+// Evaluate 执行所有规则并返回每条规则的结果，不在失败时中断，用于规则试算
+func (bre *BusinessRuleEngine) Evaluate(ctx *CalculationContext) []RuleResult {
+	rules := bre.snapshot()
+	results := make([]RuleResult, 0, len(rules))
+	for _, rule := range rules {
+		result := RuleResult{Rule: rule.GetRuleName(), Passed: true}
+		if err := rule.Execute(ctx); err != nil {
+			result.Passed = false
+			result.Reason = err.Error()
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
 // OrderAmountRule 订单金额规则
-type OrderAmountRule struct{}
+type OrderAmountRule struct {
+	maxAmount float64
+}
 
 func (r *OrderAmountRule) Execute(ctx *CalculationContext) error {
 	if ctx.OrderAmount <= 0 {
 		return fmt.Errorf("订单金额必须大于0")
 	}
-	
-	if ctx.OrderAmount > 100000 { // 10万元限制
+
+	if r.maxAmount > 0 && ctx.OrderAmount > r.maxAmount {
 		return fmt.Errorf("订单金额超过系统限制")
 	}
-	
+
 	return nil
 }
 
@@ -93,19 +164,34 @@ func (r *OrderAmountRule) GetRuleName() string {
 	return "OrderAmountRule"
 }
 
-// CouponCountRule 优惠券数量规则
+// CouponCountRule 优惠券数量规则，节假日可单独配置上限
 type CouponCountRule struct {
 	maxCount int
+	holidays []Holiday
 }
 
 func (r *CouponCountRule) Execute(ctx *CalculationContext) error {
+	if h := matchHoliday(r.holidays, ctx.CurrentTime); h != nil && h.MaxCouponCount > 0 {
+		if len(ctx.CouponIDs) > h.MaxCouponCount {
+			return fmt.Errorf("%s期间每次最多使用%d张优惠券", holidayName(h), h.MaxCouponCount)
+		}
+		return nil
+	}
+
 	if len(ctx.CouponIDs) > r.maxCount {
 		return fmt.Errorf("单次最多使用%d张优惠券", r.maxCount)
 	}
-	
+
 	return nil
 }
 
+func holidayName(h *Holiday) string {
+	if h.Name != "" {
+		return h.Name
+	}
+	return "节假日"
+}
+
 func (r *CouponCountRule) GetPriority() int {
 	return 90
 }
@@ -114,34 +200,23 @@ func (r *CouponCountRule) GetRuleName() string {
 	return "CouponCountRule"
 }
 
-// TimePeriodRule 时间周期规则
-type TimePeriodRule struct{}
+// TimePeriodRule 时间周期规则，禁用时间段内不能使用优惠券
+type TimePeriodRule struct {
+	windows []TimeWindow
+}
 
 func (r *TimePeriodRule) Execute(ctx *CalculationContext) error {
-	currentTime := ctx.CurrentTime
-	
-	// 检查是否在禁用时间段（例如：系统维护时间）
-	if r.isMaintenanceTime(currentTime) {
-		return fmt.Errorf("系统维护中，暂时无法使用优惠券")
-	}
-	
-	// 检查是否在活动时间段
-	if r.isRestrictedTime(currentTime) {
-		log.Warnf("当前时间段优惠券使用受限: %v", currentTime)
+	for _, w := range r.windows {
+		if w.contains(ctx.CurrentTime) {
+			reason := w.Reason
+			if reason == "" {
+				reason = "当前时间段"
+			}
+			return fmt.Errorf("%s，暂时无法使用优惠券", reason)
+		}
 	}
-	
-	return nil
-}
 
-func (r *TimePeriodRule) isMaintenanceTime(currentTime time.Time) bool {
-	// 例如：每天凌晨2-4点为维护时间
-	hour := currentTime.Hour()
-	return hour >= 2 && hour < 4
-}
-
-func (r *TimePeriodRule) isRestrictedTime(currentTime time.Time) bool {
-	// 例如：周末的某些时间段限制使用
-	return false
+	return nil
 }
 
 func (r *TimePeriodRule) GetPriority() int {
@@ -153,27 +228,32 @@ func (r *TimePeriodRule) GetRuleName() string {
 }
 
 // UserTypeRule 用户类型规则
-type UserTypeRule struct{}
+type UserTypeRule struct {
+	userTypes map[int64]string
+	caps      map[string]int
+}
 
 func (r *UserTypeRule) Execute(ctx *CalculationContext) error {
-	// 这里可以根据用户ID查询用户类型
-	// 然后应用相应的规则
 	userType := r.getUserType(ctx.UserID)
-	
-	switch userType {
-	case "blacklist":
+
+	max, ok := r.caps[userType]
+	if !ok {
+		return nil
+	}
+	if max == 0 {
 		return fmt.Errorf("用户被限制使用优惠券")
-	case "restricted":
-		if len(ctx.CouponIDs) > 1 {
-			return fmt.Errorf("受限用户每次只能使用1张优惠券")
-		}
 	}
-	
+	if len(ctx.CouponIDs) > max {
+		return fmt.Errorf("%s用户每次最多使用%d张优惠券", userType, max)
+	}
+
 	return nil
 }
 
 func (r *UserTypeRule) getUserType(userID int64) string {
-	// 简化实现：实际应该查询用户服务
+	if userType, ok := r.userTypes[userID]; ok {
+		return userType
+	}
 	return "normal"
 }
 
@@ -185,52 +265,60 @@ func (r *UserTypeRule) GetRuleName() string {
 	return "UserTypeRule"
 }
 
+// couponTypeLabels 优惠券类型在提示信息中的名称
+var couponTypeLabels = map[string]string{
+	"threshold": "满减券",
+	"discount":  "折扣券",
+	"instant":   "立减券",
+	"free_ship": "包邮券",
+}
+
 // CombinationRule 组合规则
-type CombinationRule struct{}
+type CombinationRule struct {
+	limits CombinationLimits
+}
 
 func (r *CombinationRule) Execute(ctx *CalculationContext) error {
 	if len(ctx.CouponIDs) <= 1 {
 		return nil // 单张优惠券无需检查组合规则
 	}
-	
+
 	// 检查优惠券类型组合是否合法
-	typeCount := make(map[do.CouponType]int)
+	typeCount := make(map[string]int)
 	for _, coupon := range ctx.UserCoupons {
 		if coupon.Template != nil {
-			couponType := do.CouponType(coupon.Template.Type)
-			typeCount[couponType]++
+			typeCount[couponTypeNames[do.CouponType(coupon.Template.Type)]]++
 		}
 	}
-	
-	// 应用组合规则
-	if err := r.validateCombination(typeCount); err != nil {
-		return err
-	}
-	
-	return nil
+
+	return r.validateCombination(typeCount)
 }
 
-func (r *CombinationRule) validateCombination(typeCount map[do.CouponType]int) error {
-	// 规则1：最多只能有一张折扣券
-	if typeCount[do.CouponTypeDiscount] > 1 {
-		return fmt.Errorf("不能同时使用多张折扣券")
+func (r *CombinationRule) validateCombination(typeCount map[string]int) error {
+	// 按类型名排序，保证多条限制同时不满足时提示稳定
+	names := make([]string, 0, len(r.limits.MaxPerType))
+	for name := range r.limits.MaxPerType {
+		names = append(names, name)
 	}
-	
-	// 规则2：最多只能有一张满减券
-	if typeCount[do.CouponTypeThreshold] > 1 {
-		return fmt.Errorf("不能同时使用多张满减券")
-	}
-	
-	// 规则3：折扣券和满减券不能同时使用
-	if typeCount[do.CouponTypeDiscount] > 0 && typeCount[do.CouponTypeThreshold] > 0 {
-		return fmt.Errorf("折扣券和满减券不能同时使用")
+	sort.Strings(names)
+
+	for _, name := range names {
+		max := r.limits.MaxPerType[name]
+		if typeCount[name] <= max {
+			continue
+		}
+		if max == 1 {
+			return fmt.Errorf("不能同时使用多张%s", couponTypeLabels[name])
+		}
+		return fmt.Errorf("%s最多使用%d张", couponTypeLabels[name], max)
 	}
-	
-	// 规则4：立减券最多使用2张
-	if typeCount[do.CouponTypeInstant] > 2 {
-		return fmt.Errorf("立减券最多使用2张")
+
+	for _, pair := range r.limits.Exclusive {
+		if typeCount[pair[0]] > 0 && typeCount[pair[1]] > 0 {
+			return fmt.Errorf("%s和%s不能同时使用", couponTypeLabels[pair[0]], couponTypeLabels[pair[1]])
+		}
 	}
-	
+
 	return nil
 }
 
@@ -242,98 +330,31 @@ func (r *CombinationRule) GetRuleName() string {
 	return "CombinationRule"
 }
 
-// SeasonalRule 季节性规则
-type SeasonalRule struct{}
-
-func (r *SeasonalRule) Execute(ctx *CalculationContext) error {
-	currentTime := ctx.CurrentTime
-	
-	// 检查是否在特殊节假日期间
-	if r.isSpecialHoliday(currentTime) {
-		// 节假日期间可能有特殊规则
-		return r.applyHolidayRules(ctx)
-	}
-	
-	// 检查是否在促销季
-	if r.isPromotionSeason(currentTime) {
-		// 促销季可能有额外限制或优惠
-		return r.applySeasonalRules(ctx)
-	}
-	
-	return nil
+// RiskControlRule 风控规则
+type RiskControlRule struct {
+	thresholds RiskThresholds
 }
 
-func (r *SeasonalRule) isSpecialHoliday(t time.Time) bool {
-	// 检查是否在双11、618等特殊日期
-	month := t.Month()
-	day := t.Day()
-	
-	// 双11
-	if month == 11 && day == 11 {
-		return true
-	}
-	
-	// 618
-	if month == 6 && day == 18 {
-		return true
+func (r *RiskControlRule) Execute(ctx *CalculationContext) error {
+	if ctx.OrderAmount <= 0 {
+		return nil
 	}
-	
-	return false
-}
-
-func (r *SeasonalRule) isPromotionSeason(t time.Time) bool {
-	month := t.Month()
-	// 年末促销季
-	return month == 11 || month == 12
-}
+	ratio := r.couponValue(ctx) / ctx.OrderAmount
 
-func (r *SeasonalRule) applyHolidayRules(ctx *CalculationContext) error {
-	// 节假日特殊规则：可能允许使用更多优惠券
-	if len(ctx.CouponIDs) > 8 {
-		return fmt.Errorf("节假日期间每次最多使用8张优惠券")
+	if r.thresholds.BlockRatio > 0 && ratio > r.thresholds.BlockRatio {
+		return fmt.Errorf("优惠金额占比过高，请稍后重试")
 	}
-	return nil
-}
-
-func (r *SeasonalRule) applySeasonalRules(ctx *CalculationContext) error {
-	// 促销季规则：可能有额外的限制
-	return nil
-}
 
-func (r *SeasonalRule) GetPriority() int {
-	return 50
-}
-
-func (r *SeasonalRule) GetRuleName() string {
-	return "SeasonalRule"
-}
-
-// RiskControlRule 风控规则
-type RiskControlRule struct{}
-
-func (r *RiskControlRule) Execute(ctx *CalculationContext) error {
-	// 检查是否存在异常行为
-	if r.isAbnormalBehavior(ctx) {
-		return fmt.Errorf("检测到异常行为，请稍后重试")
+	// 高价值优惠券使用只告警，不直接拒绝
+	if r.thresholds.WarnRatio > 0 && ratio > r.thresholds.WarnRatio {
+		log.Warnf("检测到高价值优惠券使用，用户: %d, 优惠占比: %.2f", ctx.UserID, ratio)
 	}
-	
-	// 检查优惠券价值是否异常
-	if r.isAbnormalCouponValue(ctx) {
-		log.Warnf("检测到高价值优惠券使用，用户: %d", ctx.UserID)
-		// 可能需要额外验证，但不直接拒绝
-	}
-	
-	return nil
-}
 
-func (r *RiskControlRule) isAbnormalBehavior(ctx *CalculationContext) bool {
-	// 检查是否在短时间内多次计算
-	// 检查优惠券组合是否异常
-	// 简化实现
-	return false
+	return nil
 }
 
-func (r *RiskControlRule) isAbnormalCouponValue(ctx *CalculationContext) bool {
+// couponValue 估算优惠券总价值
+func (r *RiskControlRule) couponValue(ctx *CalculationContext) float64 {
 	totalValue := 0.0
 	for _, coupon := range ctx.UserCoupons {
 		if coupon.Template != nil {
@@ -349,9 +370,7 @@ func (r *RiskControlRule) isAbnormalCouponValue(ctx *CalculationContext) bool {
 			}
 		}
 	}
-	
-	// 如果总价值超过订单金额的50%，认为异常
-	return totalValue > ctx.OrderAmount*0.5
+	return totalValue
 }
 
 func (r *RiskControlRule) GetPriority() int {
@@ -360,4 +379,4 @@ func (r *RiskControlRule) GetPriority() int {
 
 func (r *RiskControlRule) GetRuleName() string {
 	return "RiskControlRule"
-}
\ No newline at end of file
+}
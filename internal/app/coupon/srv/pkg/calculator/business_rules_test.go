@@ -0,0 +1,146 @@
+package calculator
+
+import (
+	"testing"
+	"time"
+
+	"emshop/internal/app/coupon/srv/domain/do"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func couponsOf(types ...do.CouponType) []*EnhancedUserCoupon {
+	coupons := make([]*EnhancedUserCoupon, 0, len(types))
+	for _, t := range types {
+		coupons = append(coupons, &EnhancedUserCoupon{Template: &CouponTemplate{
+			Type:          int32(t),
+			DiscountType:  int32(do.DiscountTypeFixed),
+			DiscountValue: 10,
+		}})
+	}
+	return coupons
+}
+
+func calcContext(userID int64, at time.Time, types ...do.CouponType) *CalculationContext {
+	ids := make([]int64, len(types))
+	for i := range ids {
+		ids[i] = int64(i + 1)
+	}
+	return &CalculationContext{
+		UserID:      userID,
+		OrderAmount: 200,
+		CouponIDs:   ids,
+		UserCoupons: couponsOf(types...),
+		CurrentTime: at,
+	}
+}
+
+func TestDefaultRuleSetKeepsBuiltinBehavior(t *testing.T) {
+	engine := NewBusinessRuleEngine()
+	noon := time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local)
+
+	assert.NoError(t, engine.ExecuteRules(calcContext(1, noon, do.CouponTypeInstant, do.CouponTypeInstant)))
+	assert.Error(t, engine.ExecuteRules(calcContext(1, noon.Add(-9*time.Hour), do.CouponTypeInstant)), "03:00处于默认维护时间")
+	assert.Error(t, engine.ExecuteRules(calcContext(1, noon, do.CouponTypeDiscount, do.CouponTypeThreshold)))
+	assert.Error(t, engine.ExecuteRules(calcContext(1, noon, do.CouponTypeInstant, do.CouponTypeInstant, do.CouponTypeInstant)))
+}
+
+func TestParseRuleSetFromConfigs(t *testing.T) {
+	rs, err := ParseRuleSet(map[string]string{
+		RuleKeyMaxCouponCount:  "2",
+		RuleKeyBlackoutWindows: `[{"start":"23:00","end":"01:00","weekdays":[5],"reason":"周五结算"}]`,
+		RuleKeyHolidays:        `[{"date":"11-11","name":"双11","max_coupon_count":4}]`,
+		RuleKeyUserTypes:       `{"members":{"blacklist":[7],"restricted":[8]},"caps":{"blacklist":0,"restricted":1}}`,
+		"max_stack_coupons":    "3",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, rs.MaxCouponCount)
+	assert.Equal(t, float64(100000), rs.MaxOrderAmount, "未配置的键使用默认值")
+
+	engine, err := NewBusinessRuleEngineWithRuleSet(rs)
+	require.NoError(t, err)
+
+	friday := time.Date(2024, 3, 1, 23, 30, 0, 0, time.Local) // 周五
+	saturdayEarly := friday.Add(time.Hour)                     // 跨天窗口的后半段
+	saturdayNoon := time.Date(2024, 3, 2, 12, 0, 0, 0, time.Local)
+	assert.Error(t, engine.ExecuteRules(calcContext(1, friday, do.CouponTypeInstant)))
+	assert.Error(t, engine.ExecuteRules(calcContext(1, saturdayEarly, do.CouponTypeInstant)))
+	assert.NoError(t, engine.ExecuteRules(calcContext(1, saturdayNoon, do.CouponTypeInstant)))
+
+	// 节假日上限覆盖默认上限
+	assert.Error(t, engine.ExecuteRules(calcContext(1, saturdayNoon, do.CouponTypeInstant, do.CouponTypeInstant, do.CouponTypeFreeShip)))
+	singlesDay := time.Date(2024, 11, 11, 12, 0, 0, 0, time.Local)
+	assert.NoError(t, engine.ExecuteRules(calcContext(1, singlesDay, do.CouponTypeInstant, do.CouponTypeInstant, do.CouponTypeFreeShip)))
+
+	// 用户类型
+	assert.Error(t, engine.ExecuteRules(calcContext(7, saturdayNoon, do.CouponTypeInstant)))
+	assert.Error(t, engine.ExecuteRules(calcContext(8, saturdayNoon, do.CouponTypeInstant, do.CouponTypeFreeShip)))
+	assert.NoError(t, engine.ExecuteRules(calcContext(8, saturdayNoon, do.CouponTypeInstant)))
+}
+
+func TestRuleSetConfigReplacesDefaultMaps(t *testing.T) {
+	rs, err := ParseRuleSet(map[string]string{
+		RuleKeyCombination: `{"max_per_type":{"discount":1}}`,
+		RuleKeyUserTypes:   `{"caps":{"blacklist":0}}`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"discount": 1}, rs.Combination.MaxPerType, "默认的instant上限被移除")
+	assert.Equal(t, map[string]int{"blacklist": 0}, rs.UserTypes.Caps, "默认的restricted上限被移除")
+
+	rs, err = DecodeRuleSet([]byte(`{"combination":{"max_per_type":{}},"max_coupon_count":3}`))
+	require.NoError(t, err)
+	assert.Empty(t, rs.Combination.MaxPerType)
+	assert.Equal(t, map[string]int{"blacklist": 0, "restricted": 1}, rs.UserTypes.Caps, "未配置的部分使用默认值")
+
+	noon := time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local)
+	engine, err := NewBusinessRuleEngineWithRuleSet(rs)
+	require.NoError(t, err)
+	assert.NoError(t, engine.ExecuteRules(calcContext(1, noon, do.CouponTypeInstant, do.CouponTypeInstant, do.CouponTypeInstant)))
+}
+
+func TestParseRuleSetRejectsInvalidConfig(t *testing.T) {
+	_, err := ParseRuleSet(map[string]string{RuleKeyBlackoutWindows: `[{"start":"25:00","end":"01:00"}]`})
+	assert.Error(t, err)
+	_, err = ParseRuleSet(map[string]string{RuleKeyCombination: `{"max_per_type":{"gift":1}}`})
+	assert.Error(t, err)
+	_, err = ParseRuleSet(map[string]string{RuleKeyMaxCouponCount: `"five"`})
+	assert.Error(t, err)
+}
+
+func TestApplyRuleSetAndEvaluate(t *testing.T) {
+	engine := NewBusinessRuleEngine()
+	noon := time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local)
+	ctx := calcContext(1, noon, do.CouponTypeInstant, do.CouponTypeInstant)
+
+	rs := DefaultRuleSet()
+	rs.Risk.BlockRatio = 0.05
+	rs.Combination.MaxPerType["instant"] = 1
+	require.NoError(t, engine.ApplyRuleSet(rs))
+
+	results := engine.Evaluate(ctx)
+	failed := map[string]bool{}
+	for _, r := range results {
+		if !r.Passed {
+			failed[r.Rule] = true
+		}
+	}
+	assert.Len(t, results, 6)
+	assert.Equal(t, map[string]bool{"CombinationRule": true, "RiskControlRule": true}, failed)
+
+	// 无效参数不会替换当前规则
+	bad := DefaultRuleSet()
+	bad.MaxCouponCount = 0
+	assert.Error(t, engine.ApplyRuleSet(bad))
+	assert.Same(t, rs, engine.RuleSet())
+
+	entries, err := rs.ConfigEntries()
+	require.NoError(t, err)
+	values := make(map[string]string, len(entries))
+	for _, e := range entries {
+		values[e.Key] = e.Value
+	}
+	roundTrip, err := ParseRuleSet(values)
+	require.NoError(t, err)
+	assert.Equal(t, rs, roundTrip)
+}
@@ -109,12 +109,15 @@ type CouponValidator interface {
 	Validate(ctx *CalculationContext, coupon *EnhancedUserCoupon) *ValidationResult
 }
 
-// NewCalculationEngine 创建计算引擎
-func NewCalculationEngine(cacheManager CacheManager) *CalculationEngine {
+// NewCalculationEngine 创建计算引擎，ruleEngine为nil时使用默认规则参数
+func NewCalculationEngine(cacheManager CacheManager, ruleEngine *BusinessRuleEngine) *CalculationEngine {
+	if ruleEngine == nil {
+		ruleEngine = NewBusinessRuleEngine()
+	}
 	engine := &CalculationEngine{
 		strategies:   make(map[do.CouponType]CalculationStrategy),
 		validators:   make([]CouponValidator, 0),
-		ruleEngine:   NewBusinessRuleEngine(),
+		ruleEngine:   ruleEngine,
 		optimizer:    NewCombinationOptimizer(),
 		cacheManager: cacheManager,
 	}
@@ -149,7 +152,7 @@ func (e *CalculationEngine) registerValidators() {
 
 // Calculate 主计算方法
 func (e *CalculationEngine) Calculate(ctx context.Context, req *dto.CalculateCouponDiscountDTO) (*dto.CouponDiscountResultDTO, error) {
-	log.Infof("开始计算优惠券折扣: userID=%d, coupons=%v, amount=%.2f", 
+	log.InfofC(ctx, "开始计算优惠券折扣: userID=%d, coupons=%v, amount=%.2f", 
 		req.UserID, req.CouponIDs, req.OrderAmount)
 
 	// 创建计算上下文
@@ -182,7 +185,7 @@ func (e *CalculationEngine) Calculate(ctx context.Context, req *dto.CalculateCou
 	if e.optimizer != nil {
 		optimizedResults, err := e.optimizer.OptimizeCombination(calcCtx, calculationResults)
 		if err != nil {
-			log.Warnf("优化组合失败，使用原结果: %v", err)
+			log.WarnfC(ctx, "优化组合失败，使用原结果: %v", err)
 		} else {
 			calculationResults = optimizedResults
 		}
@@ -205,13 +208,13 @@ func (e *CalculationEngine) loadUserCoupons(ctx context.Context, calcCtx *Calcul
 		if e.cacheManager != nil {
 			userCoupon, err = e.cacheManager.GetUserCoupon(ctx, couponID)
 			if err != nil {
-				log.Warnf("从缓存获取用户优惠券失败: %v", err)
+				log.WarnfC(ctx, "从缓存获取用户优惠券失败: %v", err)
 				continue
 			}
 
 			template, err = e.cacheManager.GetCouponTemplate(ctx, userCoupon.CouponID)
 			if err != nil {
-				log.Warnf("从缓存获取优惠券模板失败: %v", err)
+				log.WarnfC(ctx, "从缓存获取优惠券模板失败: %v", err)
 				continue
 			}
 		}
@@ -238,7 +241,7 @@ func (e *CalculationEngine) loadUserCoupons(ctx context.Context, calcCtx *Calcul
 		return calcCtx.UserCoupons[i].Priority > calcCtx.UserCoupons[j].Priority
 	})
 
-	log.Infof("加载了%d个有效优惠券", len(calcCtx.UserCoupons))
+	log.InfofC(ctx, "加载了%d个有效优惠券", len(calcCtx.UserCoupons))
 	return nil
 }
 
@@ -0,0 +1,341 @@
+package calculator
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"emshop/internal/app/coupon/srv/domain/do"
+)
+
+// coupon_configs 中业务规则参数的配置键，每个键对应RuleSet的一部分，值为JSON
+const (
+	RuleConfigKeyPrefix = "rule."
+
+	RuleKeyMaxOrderAmount  = "rule.max_order_amount"
+	RuleKeyMaxCouponCount  = "rule.max_coupon_count"
+	RuleKeyBlackoutWindows = "rule.blackout_windows"
+	RuleKeyHolidays        = "rule.holidays"
+	RuleKeyCombination     = "rule.combination"
+	RuleKeyUserTypes       = "rule.user_types"
+	RuleKeyRisk            = "rule.risk"
+)
+
+// 组合限制中使用的优惠券类型名称
+var couponTypeNames = map[do.CouponType]string{
+	do.CouponTypeThreshold: "threshold",
+	do.CouponTypeDiscount:  "discount",
+	do.CouponTypeInstant:   "instant",
+	do.CouponTypeFreeShip:  "free_ship",
+}
+
+// RuleSet 业务规则参数集合
+type RuleSet struct {
+	MaxOrderAmount  float64           `json:"max_order_amount"`
+	MaxCouponCount  int               `json:"max_coupon_count"`
+	BlackoutWindows []TimeWindow      `json:"blackout_windows"`
+	Holidays        []Holiday         `json:"holidays"`
+	Combination     CombinationLimits `json:"combination"`
+	UserTypes       UserTypeLimits    `json:"user_types"`
+	Risk            RiskThresholds    `json:"risk"`
+}
+
+// TimeWindow 禁用时间段，Start/End为 "HH:MM"，End小于Start表示跨天
+type TimeWindow struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Weekdays []int  `json:"weekdays,omitempty"` // 0=周日，为空表示每天
+	Reason   string `json:"reason,omitempty"`
+}
+
+// Holiday 节假日，Date为 "MM-DD"(每年) 或 "YYYY-MM-DD"
+type Holiday struct {
+	Date           string `json:"date"`
+	Name           string `json:"name,omitempty"`
+	MaxCouponCount int    `json:"max_coupon_count,omitempty"` // 节假日单次最多用券数，0表示沿用默认
+}
+
+// CombinationLimits 优惠券组合限制
+type CombinationLimits struct {
+	MaxPerType map[string]int `json:"max_per_type,omitempty"` // 类型名 -> 单次最多张数
+	Exclusive  [][2]string    `json:"exclusive,omitempty"`    // 不能同时使用的类型对
+}
+
+// UserTypeLimits 用户类型限制
+type UserTypeLimits struct {
+	Members map[string][]int64 `json:"members,omitempty"` // 用户类型 -> 用户ID，未列出的用户为normal
+	Caps    map[string]int     `json:"caps,omitempty"`    // 用户类型 -> 单次最多用券数，0表示禁止用券
+}
+
+// RiskThresholds 风控阈值，按优惠总价值占订单金额的比例判断
+type RiskThresholds struct {
+	WarnRatio  float64 `json:"warn_ratio,omitempty"`  // 超过时记录告警
+	BlockRatio float64 `json:"block_ratio,omitempty"` // 超过时拒绝，0表示不拒绝
+}
+
+// DefaultRuleSet 默认规则参数，coupon_configs中未配置的部分使用此默认值
+func DefaultRuleSet() *RuleSet {
+	return &RuleSet{
+		MaxOrderAmount: 100000,
+		MaxCouponCount: 5,
+		BlackoutWindows: []TimeWindow{
+			{Start: "02:00", End: "04:00", Reason: "系统维护中"},
+		},
+		Combination: CombinationLimits{
+			MaxPerType: map[string]int{"discount": 1, "threshold": 1, "instant": 2},
+			Exclusive:  [][2]string{{"discount", "threshold"}},
+		},
+		UserTypes: UserTypeLimits{
+			Caps: map[string]int{"blacklist": 0, "restricted": 1},
+		},
+		Risk: RiskThresholds{WarnRatio: 0.5},
+	}
+}
+
+// sections 配置键与RuleSet字段的对应关系
+func (rs *RuleSet) sections() []struct {
+	key  string
+	desc string
+	ptr  interface{}
+} {
+	return []struct {
+		key  string
+		desc string
+		ptr  interface{}
+	}{
+		{RuleKeyMaxOrderAmount, "单笔订单金额上限", &rs.MaxOrderAmount},
+		{RuleKeyMaxCouponCount, "单次最多使用优惠券数量", &rs.MaxCouponCount},
+		{RuleKeyBlackoutWindows, "优惠券禁用时间段", &rs.BlackoutWindows},
+		{RuleKeyHolidays, "节假日日历", &rs.Holidays},
+		{RuleKeyCombination, "优惠券组合限制", &rs.Combination},
+		{RuleKeyUserTypes, "用户类型用券限制", &rs.UserTypes},
+		{RuleKeyRisk, "风控阈值", &rs.Risk},
+	}
+}
+
+// ParseRuleSet 从coupon_configs的键值解析规则参数，未配置的键使用默认值
+func ParseRuleSet(values map[string]string) (*RuleSet, error) {
+	rs := DefaultRuleSet()
+	for _, s := range rs.sections() {
+		raw, ok := values[s.key]
+		if !ok || strings.TrimSpace(raw) == "" {
+			continue
+		}
+		rs.clearDefaultMaps(s.key)
+		if err := json.Unmarshal([]byte(raw), s.ptr); err != nil {
+			return nil, fmt.Errorf("解析规则配置%s失败: %v", s.key, err)
+		}
+	}
+	if err := rs.Validate(); err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+// DecodeRuleSet 解析JSON格式的完整规则参数，缺失的字段使用默认值
+func DecodeRuleSet(data []byte) (*RuleSet, error) {
+	var present map[string]json.RawMessage
+	if err := json.Unmarshal(data, &present); err != nil {
+		return nil, fmt.Errorf("解析规则参数失败: %v", err)
+	}
+	rs := DefaultRuleSet()
+	for name := range present {
+		rs.clearDefaultMaps(RuleConfigKeyPrefix + name)
+	}
+	if err := json.Unmarshal(data, rs); err != nil {
+		return nil, fmt.Errorf("解析规则参数失败: %v", err)
+	}
+	if err := rs.Validate(); err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+// clearDefaultMaps 清空配置键对应部分中的默认map
+//
+// json.Unmarshal会把JSON合并进已有的map，不清空时配置无法删除默认的类型上限或用户类型限制
+func (rs *RuleSet) clearDefaultMaps(key string) {
+	switch key {
+	case RuleKeyCombination:
+		rs.Combination.MaxPerType = nil
+	case RuleKeyUserTypes:
+		rs.UserTypes.Members = nil
+		rs.UserTypes.Caps = nil
+	}
+}
+
+// ConfigEntry 规则参数对应的一条coupon_configs记录
+type ConfigEntry struct {
+	Key         string
+	Value       string
+	Description string
+}
+
+// ConfigEntries 将规则参数拆分为coupon_configs记录
+func (rs *RuleSet) ConfigEntries() ([]ConfigEntry, error) {
+	sections := rs.sections()
+	entries := make([]ConfigEntry, 0, len(sections))
+	for _, s := range sections {
+		raw, err := json.Marshal(s.ptr)
+		if err != nil {
+			return nil, fmt.Errorf("序列化规则配置%s失败: %v", s.key, err)
+		}
+		entries = append(entries, ConfigEntry{Key: s.key, Value: string(raw), Description: s.desc})
+	}
+	return entries, nil
+}
+
+// Validate 校验规则参数
+func (rs *RuleSet) Validate() error {
+	if rs.MaxOrderAmount <= 0 {
+		return fmt.Errorf("max_order_amount必须大于0")
+	}
+	if rs.MaxCouponCount <= 0 {
+		return fmt.Errorf("max_coupon_count必须大于0")
+	}
+	for i, w := range rs.BlackoutWindows {
+		if _, err := parseClock(w.Start); err != nil {
+			return fmt.Errorf("blackout_windows[%d].start: %v", i, err)
+		}
+		if _, err := parseClock(w.End); err != nil {
+			return fmt.Errorf("blackout_windows[%d].end: %v", i, err)
+		}
+		for _, d := range w.Weekdays {
+			if d < 0 || d > 6 {
+				return fmt.Errorf("blackout_windows[%d].weekdays取值范围为0-6", i)
+			}
+		}
+	}
+	for i, h := range rs.Holidays {
+		if _, _, _, err := parseHolidayDate(h.Date); err != nil {
+			return fmt.Errorf("holidays[%d].date: %v", i, err)
+		}
+		if h.MaxCouponCount < 0 {
+			return fmt.Errorf("holidays[%d].max_coupon_count不能为负数", i)
+		}
+	}
+	for name, max := range rs.Combination.MaxPerType {
+		if !isCouponTypeName(name) {
+			return fmt.Errorf("combination.max_per_type包含未知优惠券类型: %s", name)
+		}
+		if max < 0 {
+			return fmt.Errorf("combination.max_per_type[%s]不能为负数", name)
+		}
+	}
+	for _, pair := range rs.Combination.Exclusive {
+		if !isCouponTypeName(pair[0]) || !isCouponTypeName(pair[1]) {
+			return fmt.Errorf("combination.exclusive包含未知优惠券类型: %v", pair)
+		}
+	}
+	for userType, max := range rs.UserTypes.Caps {
+		if max < 0 {
+			return fmt.Errorf("user_types.caps[%s]不能为负数", userType)
+		}
+	}
+	if rs.Risk.WarnRatio < 0 || rs.Risk.BlockRatio < 0 {
+		return fmt.Errorf("risk阈值不能为负数")
+	}
+	return nil
+}
+
+func isCouponTypeName(name string) bool {
+	for _, n := range couponTypeNames {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// parseClock 解析 "HH:MM"，返回当天的分钟数
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("时间格式应为HH:MM: %q", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// parseHolidayDate 解析节假日日期，year为0表示每年
+func parseHolidayDate(s string) (year int, month time.Month, day int, err error) {
+	if t, e := time.Parse("2006-01-02", s); e == nil {
+		return t.Year(), t.Month(), t.Day(), nil
+	}
+	parts := strings.Split(s, "-")
+	if len(parts) == 2 {
+		m, e1 := strconv.Atoi(parts[0])
+		d, e2 := strconv.Atoi(parts[1])
+		if e1 == nil && e2 == nil && m >= 1 && m <= 12 && d >= 1 && d <= 31 {
+			return 0, time.Month(m), d, nil
+		}
+	}
+	return 0, 0, 0, fmt.Errorf("日期格式应为MM-DD或YYYY-MM-DD: %q", s)
+}
+
+// contains 判断t是否落在禁用时间段内
+func (w TimeWindow) contains(t time.Time) bool {
+	start, err1 := parseClock(w.Start)
+	end, err2 := parseClock(w.End)
+	if err1 != nil || err2 != nil || start == end {
+		return false
+	}
+
+	minute := t.Hour()*60 + t.Minute()
+	weekday := int(t.Weekday())
+	if end < start {
+		// 跨天窗口：后半段属于前一天的窗口
+		if minute < end {
+			weekday = (weekday + 6) % 7
+		} else if minute < start {
+			return false
+		}
+	} else if minute < start || minute >= end {
+		return false
+	}
+
+	if len(w.Weekdays) == 0 {
+		return true
+	}
+	for _, d := range w.Weekdays {
+		if d == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// matchHoliday 返回t当天对应的节假日
+func matchHoliday(holidays []Holiday, t time.Time) *Holiday {
+	for i := range holidays {
+		year, month, day, err := parseHolidayDate(holidays[i].Date)
+		if err != nil {
+			continue
+		}
+		if (year == 0 || year == t.Year()) && month == t.Month() && day == t.Day() {
+			return &holidays[i]
+		}
+	}
+	return nil
+}
+
+// userTypeIndex 构建用户ID到用户类型的索引，同一用户出现在多个类型时按类型名排序取第一个
+func userTypeIndex(members map[string][]int64) map[int64]string {
+	types := make([]string, 0, len(members))
+	for userType := range members {
+		types = append(types, userType)
+	}
+	sort.Strings(types)
+
+	index := make(map[int64]string)
+	for _, userType := range types {
+		for _, userID := range members[userType] {
+			if _, ok := index[userID]; !ok {
+				index[userID] = userType
+			}
+		}
+	}
+	return index
+}
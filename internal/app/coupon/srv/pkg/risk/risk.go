@@ -0,0 +1,144 @@
+// Package risk 领券、秒杀与短信发送的风险评分
+//
+// 评分只依赖调用方采集好的信号，不访问存储；各信号超过阈值时累加权重，
+// 按总分决定放行、要求验证码或拒绝
+package risk
+
+import (
+	"strings"
+	"time"
+)
+
+// Action 风控动作
+type Action string
+
+const (
+	// ActionAllow 放行
+	ActionAllow Action = "allow"
+	// ActionCaptcha 要求完成图形验证码后重试
+	ActionCaptcha Action = "captcha"
+	// ActionBlock 直接拒绝
+	ActionBlock Action = "block"
+)
+
+// 命中的信号名称，同时作为决策原因记录
+const (
+	SignalDeviceVelocity       = "device_velocity"
+	SignalIPVelocity           = "ip_velocity"
+	SignalMobilePrefixVelocity = "mobile_prefix_velocity"
+	SignalNewAccount           = "new_account"
+	SignalSharedAddress        = "shared_address"
+	SignalCaptchaFailures      = "captcha_failures"
+	// ReasonCaptchaPassed 已通过验证码，验证码动作降为放行
+	ReasonCaptchaPassed = "captcha_passed"
+)
+
+// ParseAction 解析配置中的动作名称，不区分大小写
+func ParseAction(s string) (Action, bool) {
+	switch Action(strings.ToLower(strings.TrimSpace(s))) {
+	case ActionAllow:
+		return ActionAllow, true
+	case ActionCaptcha:
+		return ActionCaptcha, true
+	case ActionBlock:
+		return ActionBlock, true
+	}
+	return "", false
+}
+
+// severity 动作严厉程度，用于与场景允许的最严厉动作比较
+func (a Action) severity() int {
+	switch a {
+	case ActionCaptcha:
+		return 1
+	case ActionBlock:
+		return 2
+	}
+	return 0
+}
+
+// Signals 一次请求采集到的风险信号
+type Signals struct {
+	DeviceCount        int64     // 统计窗口内同设备请求数，含本次
+	IPCount            int64     // 统计窗口内同IP请求数，含本次
+	MobilePrefixCount  int64     // 统计窗口内同号段请求数，含本次
+	RegisteredAt       time.Time // 账号注册时间，零值表示未知
+	Reference          time.Time // 判断新账号的参考时间，秒杀为活动开始时间，其他场景为当前时间
+	SharedAddressUsers int32     // 与该账号使用相同收货地址的其他账号数
+	CaptchaFailures    int64     // 近期验证码失败次数，含本次
+	CaptchaPassed      bool      // 本次请求已通过验证码
+}
+
+// Policy 评分策略，阈值或权重为0的信号不参与评分
+type Policy struct {
+	DeviceLimit          int64
+	DeviceWeight         int
+	IPLimit              int64
+	IPWeight             int
+	MobilePrefixLimit    int64
+	MobilePrefixWeight   int
+	NewAccountAge        time.Duration
+	NewAccountWeight     int
+	SharedAddressLimit   int32
+	SharedAddressWeight  int
+	CaptchaFailureLimit  int64
+	CaptchaFailureWeight int
+	// CaptchaScore 总分达到该值要求验证码，为0时不触发验证码
+	CaptchaScore int
+	// BlockScore 总分达到该值直接拒绝，为0时不拒绝
+	BlockScore int
+	// MaxAction 场景允许的最严厉动作，为空时不限制
+	MaxAction Action
+}
+
+// Decision 评分结果
+type Decision struct {
+	Score   int
+	Action  Action
+	Reasons []string
+}
+
+// Evaluate 根据信号计算总分与动作
+//
+// 已通过验证码的请求只能抵消验证码动作，达到拒绝分数时仍然拒绝
+func (p *Policy) Evaluate(s Signals) Decision {
+	d := Decision{Action: ActionAllow}
+	hit := func(matched bool, weight int, reason string) {
+		if matched && weight > 0 {
+			d.Score += weight
+			d.Reasons = append(d.Reasons, reason)
+		}
+	}
+
+	hit(p.DeviceLimit > 0 && s.DeviceCount > p.DeviceLimit, p.DeviceWeight, SignalDeviceVelocity)
+	hit(p.IPLimit > 0 && s.IPCount > p.IPLimit, p.IPWeight, SignalIPVelocity)
+	hit(p.MobilePrefixLimit > 0 && s.MobilePrefixCount > p.MobilePrefixLimit, p.MobilePrefixWeight, SignalMobilePrefixVelocity)
+	hit(p.NewAccountAge > 0 && !s.RegisteredAt.IsZero() && s.Reference.Sub(s.RegisteredAt) < p.NewAccountAge,
+		p.NewAccountWeight, SignalNewAccount)
+	hit(p.SharedAddressLimit > 0 && s.SharedAddressUsers >= p.SharedAddressLimit, p.SharedAddressWeight, SignalSharedAddress)
+	hit(p.CaptchaFailureLimit > 0 && s.CaptchaFailures >= p.CaptchaFailureLimit, p.CaptchaFailureWeight, SignalCaptchaFailures)
+
+	switch {
+	case p.BlockScore > 0 && d.Score >= p.BlockScore:
+		d.Action = ActionBlock
+	case p.CaptchaScore > 0 && d.Score >= p.CaptchaScore:
+		d.Action = ActionCaptcha
+	}
+	if p.MaxAction != "" && d.Action.severity() > p.MaxAction.severity() {
+		d.Action = p.MaxAction
+	}
+	if d.Action == ActionCaptcha && s.CaptchaPassed {
+		d.Action = ActionAllow
+		d.Reasons = append(d.Reasons, ReasonCaptchaPassed)
+	}
+	return d
+}
+
+// MobilePrefix 取手机号前n位作为号段，号码不足n位时返回空
+func MobilePrefix(mobile string, n int) string {
+	mobile = strings.TrimPrefix(strings.TrimSpace(mobile), "+86")
+	if n <= 0 || len(mobile) < n {
+		return ""
+	}
+	return mobile[:n]
+}
@@ -0,0 +1,96 @@
+package risk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testPolicy() *Policy {
+	return &Policy{
+		DeviceLimit:          5,
+		DeviceWeight:         30,
+		IPLimit:              20,
+		IPWeight:             20,
+		MobilePrefixLimit:    50,
+		MobilePrefixWeight:   15,
+		NewAccountAge:        30 * time.Minute,
+		NewAccountWeight:     30,
+		SharedAddressLimit:   3,
+		SharedAddressWeight:  30,
+		CaptchaFailureLimit:  3,
+		CaptchaFailureWeight: 30,
+		CaptchaScore:         40,
+		BlockScore:           80,
+	}
+}
+
+func TestEvaluateAllowsQuietTraffic(t *testing.T) {
+	now := time.Now()
+	d := testPolicy().Evaluate(Signals{
+		DeviceCount:  1,
+		IPCount:      1,
+		RegisteredAt: now.Add(-24 * time.Hour),
+		Reference:    now,
+	})
+	assert.Equal(t, ActionAllow, d.Action)
+	assert.Equal(t, 0, d.Score)
+	assert.Empty(t, d.Reasons)
+}
+
+func TestEvaluateEscalatesWithScore(t *testing.T) {
+	start := time.Now()
+	p := testPolicy()
+
+	// 活动开始前10分钟注册且设备请求过快
+	d := p.Evaluate(Signals{DeviceCount: 6, RegisteredAt: start.Add(-10 * time.Minute), Reference: start})
+	assert.Equal(t, ActionCaptcha, d.Action)
+	assert.Equal(t, 60, d.Score)
+	assert.Equal(t, []string{SignalDeviceVelocity, SignalNewAccount}, d.Reasons)
+
+	d = p.Evaluate(Signals{DeviceCount: 6, SharedAddressUsers: 3, CaptchaFailures: 3})
+	assert.Equal(t, ActionBlock, d.Action)
+	assert.Equal(t, 90, d.Score)
+}
+
+func TestEvaluateCaptchaPassed(t *testing.T) {
+	p := testPolicy()
+
+	d := p.Evaluate(Signals{DeviceCount: 6, IPCount: 21, CaptchaPassed: true})
+	assert.Equal(t, ActionAllow, d.Action)
+	assert.Contains(t, d.Reasons, ReasonCaptchaPassed)
+
+	// 通过验证码不能抵消拒绝
+	d = p.Evaluate(Signals{DeviceCount: 6, IPCount: 21, SharedAddressUsers: 5, CaptchaPassed: true})
+	assert.Equal(t, ActionBlock, d.Action)
+}
+
+func TestEvaluateMaxAction(t *testing.T) {
+	p := testPolicy()
+	p.MaxAction = ActionCaptcha
+	d := p.Evaluate(Signals{DeviceCount: 6, SharedAddressUsers: 3, CaptchaFailures: 3})
+	assert.Equal(t, ActionCaptcha, d.Action)
+
+	p.MaxAction = ActionAllow
+	d = p.Evaluate(Signals{DeviceCount: 6, SharedAddressUsers: 3, CaptchaFailures: 3})
+	assert.Equal(t, ActionAllow, d.Action)
+	assert.Equal(t, 90, d.Score)
+}
+
+func TestEvaluateUnknownRegistration(t *testing.T) {
+	d := testPolicy().Evaluate(Signals{Reference: time.Now()})
+	assert.NotContains(t, d.Reasons, SignalNewAccount)
+}
+
+func TestParseActionAndMobilePrefix(t *testing.T) {
+	a, ok := ParseAction(" Block ")
+	assert.True(t, ok)
+	assert.Equal(t, ActionBlock, a)
+	_, ok = ParseAction("deny")
+	assert.False(t, ok)
+
+	assert.Equal(t, "1380013", MobilePrefix("13800138000", 7))
+	assert.Equal(t, "1380013", MobilePrefix("+8613800138000", 7))
+	assert.Equal(t, "", MobilePrefix("138", 7))
+}
@@ -0,0 +1,39 @@
+-- 秒杀排队放行Redis Lua脚本
+-- 按入队顺序放行一批用户并清理已过期的放行记录，队列为空时由调用方从有排队的活动集合中移除
+--
+-- 参数说明:
+-- KEYS[1]: 排队队列key，格式: flashsale:queue:{flash_sale_id}
+-- KEYS[2]: 已放行用户key，格式: flashsale:admitted:{flash_sale_id}
+-- ARGV[1]: 本批放行数量
+-- ARGV[2]: 当前毫秒时间戳
+-- ARGV[3]: 放行有效期(毫秒)
+-- ARGV[4]: 已放行用户key过期时间(秒)
+--
+-- 返回: {本批放行数量, 剩余排队人数}
+
+local queue_key = KEYS[1]
+local admitted_key = KEYS[2]
+
+local batch = tonumber(ARGV[1])
+local now_ms = tonumber(ARGV[2])
+local admit_ttl_ms = tonumber(ARGV[3])
+local ttl = tonumber(ARGV[4])
+
+redis.call('ZREMRANGEBYSCORE', admitted_key, '-inf', now_ms)
+
+local admitted = 0
+if batch > 0 then
+    local users = redis.call('ZRANGE', queue_key, 0, batch - 1)
+    for _, user_id in ipairs(users) do
+        redis.call('ZADD', admitted_key, now_ms + admit_ttl_ms, user_id)
+    end
+    admitted = #users
+    if admitted > 0 then
+        redis.call('ZREMRANGEBYRANK', queue_key, 0, admitted - 1)
+        redis.call('EXPIRE', admitted_key, ttl)
+    end
+end
+
+local remaining = redis.call('ZCARD', queue_key)
+
+return {admitted, remaining}
@@ -0,0 +1,48 @@
+-- 秒杀排队入队Redis Lua脚本
+-- 重复入队返回原排位，已放行且未过期的用户不再入队
+-- 所有key带相同的hash tag，有排队的活动集合由调用方在脚本外维护
+--
+-- 参数说明:
+-- KEYS[1]: 排队队列key(ZSET，score为入队序号)，格式: flashsale:queue:{flash_sale_id}
+-- KEYS[2]: 入队序号key，格式: flashsale:queue:seq:{flash_sale_id}
+-- KEYS[3]: 已放行用户key(ZSET，score为放行过期毫秒时间戳)，格式: flashsale:admitted:{flash_sale_id}
+-- ARGV[1]: 用户ID
+-- ARGV[2]: 队列最大长度
+-- ARGV[3]: 当前毫秒时间戳
+-- ARGV[4]: 队列key过期时间(秒)
+
+local queue_key = KEYS[1]
+local seq_key = KEYS[2]
+local admitted_key = KEYS[3]
+
+local user_id = ARGV[1]
+local max_length = tonumber(ARGV[2])
+local now_ms = tonumber(ARGV[3])
+local ttl = tonumber(ARGV[4])
+
+-- 返回: {结果码, 排队人数}
+-- 结果码>=0: 排队中，值为0起始的排位
+-- 结果码-1: 队列已满
+-- 结果码-2: 已放行
+
+local admitted_until = redis.call('ZSCORE', admitted_key, user_id)
+if admitted_until and tonumber(admitted_until) > now_ms then
+    return {-2, 0}  -- 已放行
+end
+
+local rank = redis.call('ZRANK', queue_key, user_id)
+local length = redis.call('ZCARD', queue_key)
+if rank then
+    return {rank, length}  -- 重复入队
+end
+
+if length >= max_length then
+    return {-1, length}  -- 队列已满
+end
+
+local seq = redis.call('INCR', seq_key)
+redis.call('ZADD', queue_key, seq, user_id)
+redis.call('EXPIRE', queue_key, ttl)
+redis.call('EXPIRE', seq_key, ttl)
+
+return {length, length + 1}
@@ -21,6 +21,12 @@ var CheckCouponUsageLua string
 //go:embed release_coupon_lock.lua
 var ReleaseCouponLockLua string
 
+//go:embed flash_sale_queue_enter.lua
+var FlashSaleQueueEnterLua string
+
+//go:embed flash_sale_queue_admit.lua
+var FlashSaleQueueAdmitLua string
+
 // 脚本返回值常量
 const (
 	// 秒杀脚本返回码
@@ -37,6 +43,10 @@ const (
 	CouponUsed         = -2
 	CouponLocked       = -3
 
+	// 秒杀排队入队脚本返回码，非负数为排位
+	FlashSaleQueueFull     = -1
+	FlashSaleQueueAdmitted = -2
+
 	// 通用返回码
 	OperationSuccess = 1
 	OperationSkipped = 0
@@ -98,6 +108,38 @@ func (r *RedisKeyFormatter) FlashSaleStatusKey(flashSaleID int64) string {
 	return fmt.Sprintf("flashsale:status:%d", flashSaleID)
 }
 
+// 同一活动的排队相关key使用相同的hash tag，保证在Redis Cluster中落在同一slot，可在一个脚本中操作
+
+// FlashSaleQueueKey 秒杀排队队列
+func (r *RedisKeyFormatter) FlashSaleQueueKey(flashSaleID int64) string {
+	return fmt.Sprintf("flashsale:queue:{%d}", flashSaleID)
+}
+
+// FlashSaleQueueSeqKey 秒杀排队入队序号
+func (r *RedisKeyFormatter) FlashSaleQueueSeqKey(flashSaleID int64) string {
+	return fmt.Sprintf("flashsale:queue:seq:{%d}", flashSaleID)
+}
+
+// FlashSaleAdmittedKey 秒杀已放行用户
+func (r *RedisKeyFormatter) FlashSaleAdmittedKey(flashSaleID int64) string {
+	return fmt.Sprintf("flashsale:admitted:{%d}", flashSaleID)
+}
+
+// FlashSaleQueueActiveKey 有用户排队的秒杀活动集合，与活动key不在同一slot，不能在排队脚本中访问
+func (r *RedisKeyFormatter) FlashSaleQueueActiveKey() string {
+	return "flashsale:queue:active"
+}
+
+// RiskVelocityKey 风控请求计数，bucket为统计窗口序号，过期即翻窗
+func (r *RedisKeyFormatter) RiskVelocityKey(scene, dimension, value string, bucket int64) string {
+	return fmt.Sprintf("risk:velocity:%s:%s:%s:%d", scene, dimension, value, bucket)
+}
+
+// RiskCaptchaFailureKey 验证码失败次数，subject形如u:用户ID、d:设备ID或m:手机号
+func (r *RedisKeyFormatter) RiskCaptchaFailureKey(subject string) string {
+	return fmt.Sprintf("risk:captcha_fail:%s", subject)
+}
+
 // 优惠券相关键格式
 func (r *RedisKeyFormatter) CouponLockKey(userCouponID int64) string {
 	return fmt.Sprintf("coupon:lock:%d", userCouponID)
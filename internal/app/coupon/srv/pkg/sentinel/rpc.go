@@ -70,8 +70,13 @@ func NewCouponRPCServer(
 
 	rpcAddr := fmt.Sprintf("%s:%d", serverOpts.Host, serverOpts.Port)
 
+	grpcOpts, err := serverOpts.GRPCServerOptions()
+	if err != nil {
+		return nil, err
+	}
+
 	var opts []rpcserver.ServerOption
-	opts = append(opts, rpcserver.WithAddress(rpcAddr))
+	opts = append(opts, rpcserver.WithAddress(rpcAddr), rpcserver.WithOptions(grpcOpts...), rpcserver.WithLogAdmin(serverOpts.LogAdminSecret))
 	
 	if serverOpts.EnableLimit {
 		// 创建优惠券服务专用的降级处理器
@@ -84,6 +89,7 @@ func NewCouponRPCServer(
 		// 创建Sentinel拦截器
 		sentinelInterceptor := sentinel.NewUnaryServerInterceptor(interceptorConfig)
 		opts = append(opts, rpcserver.WithUnaryInterceptor(sentinelInterceptor))
+		opts = append(opts, rpcserver.WithStreamInterceptor(sentinel.NewStreamServerInterceptor(interceptorConfig)))
 		
 		// 初始化Nacos数据源
 		err := dataNacos.Initialize()
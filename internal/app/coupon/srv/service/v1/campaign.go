@@ -0,0 +1,634 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	"emshop/internal/app/coupon/srv/config"
+	"emshop/internal/app/coupon/srv/consumer"
+	"emshop/internal/app/coupon/srv/data/v1/interfaces"
+	"emshop/internal/app/coupon/srv/domain/do"
+	"emshop/internal/app/coupon/srv/domain/dto"
+	"emshop/internal/app/coupon/srv/pkg/cache"
+	"emshop/internal/app/pkg/code"
+	v1 "emshop/pkg/common/meta/v1"
+	"emshop/pkg/delayjob"
+	"emshop/pkg/errors"
+	"emshop/pkg/log"
+)
+
+const (
+	// JobTypeCampaignDispatch 周期扫描发放中的活动并投递下一批目标用户
+	JobTypeCampaignDispatch = "coupon.campaign.dispatch"
+
+	defaultCampaignBatchSize       = 500
+	defaultCampaignMaxBatchSize    = 5000
+	defaultCampaignDispatchTimeout = 10 * time.Minute
+	// campaignMaxTargetsPerCall 单次追加目标用户上限，大名单由调用方分批追加
+	campaignMaxTargetsPerCall = 10000
+)
+
+// CouponCampaignSrv 定向发券活动服务
+//
+// 目标用户在活动内唯一，发放状态从已投递到已发放的条件更新与发券在同一事务内完成，
+// 消息重复消费或重新执行活动都不会给同一用户重复发券
+type CouponCampaignSrv interface {
+	// Create 创建草稿状态的发券活动
+	Create(ctx context.Context, req *dto.CreateCouponCampaignDTO) (*dto.CouponCampaignDTO, error)
+	// AddTargets 追加目标用户，已在活动中的用户被忽略，返回新增数量
+	AddTargets(ctx context.Context, req *dto.AddCampaignTargetsDTO) (int64, error)
+	// Get 查询活动及发放进度
+	Get(ctx context.Context, id int64) (*dto.CouponCampaignDTO, error)
+	// List 分页查询活动
+	List(ctx context.Context, req *dto.ListCouponCampaignsDTO) (*dto.CouponCampaignListDTO, error)
+	// Start 开始或恢复发放
+	Start(ctx context.Context, id int64) error
+	// Pause 暂停发放，已投递未处理的目标在消费时恢复为待发放
+	Pause(ctx context.Context, id int64) error
+	// Cancel 取消活动，剩余目标不再发放
+	Cancel(ctx context.Context, id int64) error
+	// Rerun 将发放失败的目标恢复为待发放并继续发放，返回恢复数量
+	Rerun(ctx context.Context, id int64) (int64, error)
+	// Delete 删除草稿活动及其目标用户，用于创建时圈选失败的回滚
+	Delete(ctx context.Context, id int64) error
+	// DispatchDue 为到达批次间隔的活动投递下一批目标
+	DispatchDue(ctx context.Context, now time.Time) (int, error)
+	// IssueBatch 为一批已投递的目标发放优惠券，由发券批次消费者调用
+	IssueBatch(ctx context.Context, campaignID int64, targetIDs []int64) error
+	// RegisterJobs 注册活动投递任务
+	RegisterJobs(scheduler *delayjob.Scheduler)
+}
+
+type couponCampaignService struct {
+	data         interfaces.DataFactory
+	producer     consumer.CampaignBatchProducer
+	opts         *config.CampaignOptions
+	cacheManager interface {
+		InvalidateCache(keys ...string)
+	}
+}
+
+// NewCouponCampaignService 创建定向发券活动服务
+func NewCouponCampaignService(data interfaces.DataFactory, producer consumer.CampaignBatchProducer, opts *config.CampaignOptions, cacheManager interface {
+	InvalidateCache(keys ...string)
+}) CouponCampaignSrv {
+	if opts == nil {
+		opts = &config.CampaignOptions{}
+	}
+	return &couponCampaignService{
+		data:         data,
+		producer:     producer,
+		opts:         opts,
+		cacheManager: cacheManager,
+	}
+}
+
+// validCampaignSegment 检查圈选方式是否受支持
+func validCampaignSegment(segmentType string) bool {
+	switch segmentType {
+	case do.CampaignSegmentUserIDs, do.CampaignSegmentRegistered,
+		do.CampaignSegmentInactive, do.CampaignSegmentFavCategory:
+		return true
+	}
+	return false
+}
+
+func (cs *couponCampaignService) maxBatchSize() int32 {
+	if cs.opts.MaxBatchSize > 0 {
+		return int32(cs.opts.MaxBatchSize)
+	}
+	return defaultCampaignMaxBatchSize
+}
+
+func (cs *couponCampaignService) defaultBatchSize() int32 {
+	if cs.opts.DefaultBatchSize > 0 {
+		return int32(cs.opts.DefaultBatchSize)
+	}
+	return defaultCampaignBatchSize
+}
+
+func (cs *couponCampaignService) dispatchTimeout() time.Duration {
+	if cs.opts.DispatchTimeout > 0 {
+		return cs.opts.DispatchTimeout
+	}
+	return defaultCampaignDispatchTimeout
+}
+
+// Create 创建发券活动
+func (cs *couponCampaignService) Create(ctx context.Context, req *dto.CreateCouponCampaignDTO) (*dto.CouponCampaignDTO, error) {
+	if req.Name == "" {
+		return nil, errors.WithCode(code.ErrInvalidRequest, "活动名称不能为空")
+	}
+	if !validCampaignSegment(req.SegmentType) {
+		return nil, errors.WithCode(code.ErrInvalidRequest, "不支持的圈选方式: %s", req.SegmentType)
+	}
+	if req.BatchIntervalSeconds < 0 {
+		return nil, errors.WithCode(code.ErrInvalidRequest, "批次间隔不能为负数")
+	}
+	batchSize := req.BatchSize
+	if batchSize <= 0 {
+		batchSize = cs.defaultBatchSize()
+	}
+	if batchSize > cs.maxBatchSize() {
+		return nil, errors.WithCode(code.ErrInvalidRequest, "每批发放数量不能超过%d", cs.maxBatchSize())
+	}
+
+	template, err := cs.data.CouponTemplates().Get(ctx, cs.data.DB(), req.CouponTemplateID)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, "获取优惠券模板失败")
+	}
+	if template == nil {
+		return nil, errors.WithCode(code.ErrResourceNotFound, "优惠券模板不存在: %d", req.CouponTemplateID)
+	}
+
+	campaign := &do.CouponCampaignDO{
+		Name:                 req.Name,
+		CouponTemplateID:     req.CouponTemplateID,
+		SegmentType:          req.SegmentType,
+		SegmentParams:        req.SegmentParams,
+		Status:               do.CampaignStatusDraft,
+		BatchSize:            batchSize,
+		BatchIntervalSeconds: req.BatchIntervalSeconds,
+		Operator:             req.Operator,
+	}
+	if err := cs.data.CouponCampaigns().Create(ctx, cs.data.DB(), campaign); err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, "创建发券活动失败")
+	}
+
+	log.InfofC(ctx, "创建发券活动: id=%d, name=%s, templateID=%d, segment=%s, operator=%s",
+		campaign.ID, campaign.Name, campaign.CouponTemplateID, campaign.SegmentType, campaign.Operator)
+	return campaignToDTO(campaign, nil), nil
+}
+
+// AddTargets 追加目标用户，已完成或已取消的活动不能追加
+func (cs *couponCampaignService) AddTargets(ctx context.Context, req *dto.AddCampaignTargetsDTO) (int64, error) {
+	if len(req.UserIDs) > campaignMaxTargetsPerCall {
+		return 0, errors.WithCode(code.ErrInvalidRequest, "单次最多追加%d个用户", campaignMaxTargetsPerCall)
+	}
+	campaign, err := cs.getCampaign(ctx, req.CampaignID)
+	if err != nil {
+		return 0, err
+	}
+	if campaign.Status == do.CampaignStatusCompleted || campaign.Status == do.CampaignStatusCancelled {
+		return 0, errors.WithCode(code.ErrCampaignStateConflict, "活动已结束，不能追加目标用户")
+	}
+
+	targets := make([]*do.CampaignTargetDO, 0, len(req.UserIDs))
+	seen := make(map[int64]struct{}, len(req.UserIDs))
+	for _, userID := range req.UserIDs {
+		if userID <= 0 {
+			continue
+		}
+		if _, ok := seen[userID]; ok {
+			continue
+		}
+		seen[userID] = struct{}{}
+		targets = append(targets, &do.CampaignTargetDO{
+			CampaignID: campaign.ID,
+			UserID:     userID,
+			Status:     do.CampaignTargetPending,
+		})
+	}
+	if len(targets) == 0 {
+		return 0, nil
+	}
+
+	tx := cs.data.Begin()
+	added, err := cs.data.CampaignTargets().BatchCreate(ctx, tx, targets)
+	if err != nil {
+		tx.Rollback()
+		return 0, errors.WithCode(code.ErrDatabase, "追加目标用户失败")
+	}
+	if added > 0 {
+		if err := cs.data.CouponCampaigns().IncrTotalTargets(ctx, tx, campaign.ID, added); err != nil {
+			tx.Rollback()
+			return 0, errors.WithCode(code.ErrDatabase, "追加目标用户失败")
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.ErrorfC(ctx, "提交追加目标用户事务失败: %v", err)
+		return 0, errors.WithCode(code.ErrDatabase, "追加目标用户失败")
+	}
+
+	log.InfofC(ctx, "发券活动追加目标用户: campaignID=%d, requested=%d, added=%d", campaign.ID, len(req.UserIDs), added)
+	return added, nil
+}
+
+// Get 查询活动及各状态目标数量
+func (cs *couponCampaignService) Get(ctx context.Context, id int64) (*dto.CouponCampaignDTO, error) {
+	campaign, err := cs.getCampaign(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := cs.data.CampaignTargets().CountByStatus(ctx, cs.data.DB(), id)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, "统计活动发放进度失败")
+	}
+	return campaignToDTO(campaign, counts), nil
+}
+
+// List 分页查询活动，列表不包含发放进度
+func (cs *couponCampaignService) List(ctx context.Context, req *dto.ListCouponCampaignsDTO) (*dto.CouponCampaignListDTO, error) {
+	page, pageSize := req.Page, req.PageSize
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	list, err := cs.data.CouponCampaigns().List(ctx, cs.data.DB(), do.CampaignStatus(req.Status), v1.ListMeta{
+		Page:     int(page),
+		PageSize: int(pageSize),
+	})
+	if err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, "查询发券活动列表失败")
+	}
+
+	items := make([]*dto.CouponCampaignDTO, 0, len(list.Items))
+	for _, c := range list.Items {
+		items = append(items, campaignToDTO(c, nil))
+	}
+	return &dto.CouponCampaignListDTO{
+		TotalCount: list.TotalCount,
+		Items:      items,
+	}, nil
+}
+
+// Start 草稿或暂停的活动进入发放中
+func (cs *couponCampaignService) Start(ctx context.Context, id int64) error {
+	campaign, err := cs.getCampaign(ctx, id)
+	if err != nil {
+		return err
+	}
+	if campaign.Status == do.CampaignStatusRunning {
+		return nil
+	}
+	if campaign.Status == do.CampaignStatusDraft && campaign.TotalTargets == 0 {
+		return errors.WithCode(code.ErrCampaignStateConflict, "活动没有目标用户")
+	}
+	return cs.transition(ctx, id, []do.CampaignStatus{do.CampaignStatusDraft, do.CampaignStatusPaused}, do.CampaignStatusRunning)
+}
+
+// Pause 暂停发放中的活动
+func (cs *couponCampaignService) Pause(ctx context.Context, id int64) error {
+	return cs.transition(ctx, id, []do.CampaignStatus{do.CampaignStatusRunning}, do.CampaignStatusPaused)
+}
+
+// Cancel 取消未结束的活动
+func (cs *couponCampaignService) Cancel(ctx context.Context, id int64) error {
+	return cs.transition(ctx, id, []do.CampaignStatus{
+		do.CampaignStatusDraft, do.CampaignStatusRunning, do.CampaignStatusPaused,
+	}, do.CampaignStatusCancelled)
+}
+
+// Rerun 重新发放失败的目标，已完成的活动重新进入发放中
+func (cs *couponCampaignService) Rerun(ctx context.Context, id int64) (int64, error) {
+	campaign, err := cs.getCampaign(ctx, id)
+	if err != nil {
+		return 0, err
+	}
+	if campaign.Status == do.CampaignStatusDraft || campaign.Status == do.CampaignStatusCancelled {
+		return 0, errors.WithCode(code.ErrCampaignStateConflict, "活动未开始或已取消，不能重新执行")
+	}
+
+	reset, err := cs.data.CampaignTargets().ResetFailed(ctx, cs.data.DB(), id)
+	if err != nil {
+		return 0, errors.WithCode(code.ErrDatabase, "重置失败目标失败")
+	}
+	if reset > 0 && campaign.Status == do.CampaignStatusCompleted {
+		if _, err := cs.data.CouponCampaigns().UpdateStatus(ctx, cs.data.DB(), id,
+			[]do.CampaignStatus{do.CampaignStatusCompleted}, do.CampaignStatusRunning); err != nil {
+			return 0, errors.WithCode(code.ErrDatabase, "更新发券活动状态失败")
+		}
+	}
+
+	log.InfofC(ctx, "发券活动重新执行: campaignID=%d, reset=%d", id, reset)
+	return reset, nil
+}
+
+// Delete 在同一事务内删除草稿活动及其目标用户，活动已开始发放时返回状态冲突
+func (cs *couponCampaignService) Delete(ctx context.Context, id int64) error {
+	if _, err := cs.getCampaign(ctx, id); err != nil {
+		return err
+	}
+
+	tx := cs.data.Begin()
+	ok, err := cs.data.CouponCampaigns().DeleteDraft(ctx, tx, id)
+	if err != nil {
+		tx.Rollback()
+		return errors.WithCode(code.ErrDatabase, "删除发券活动失败")
+	}
+	if !ok {
+		tx.Rollback()
+		return errors.WithCode(code.ErrCampaignStateConflict, "只能删除草稿状态的活动")
+	}
+	removed, err := cs.data.CampaignTargets().DeleteByCampaign(ctx, tx, id)
+	if err != nil {
+		tx.Rollback()
+		return errors.WithCode(code.ErrDatabase, "删除发券活动失败")
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.ErrorfC(ctx, "提交删除发券活动事务失败: %v", err)
+		return errors.WithCode(code.ErrDatabase, "删除发券活动失败")
+	}
+
+	log.InfofC(ctx, "发券活动已删除: campaignID=%d, targets=%d", id, removed)
+	return nil
+}
+
+// transition 条件更新活动状态，当前状态不在from中时返回状态冲突
+func (cs *couponCampaignService) transition(ctx context.Context, id int64, from []do.CampaignStatus, to do.CampaignStatus) error {
+	if _, err := cs.getCampaign(ctx, id); err != nil {
+		return err
+	}
+	ok, err := cs.data.CouponCampaigns().UpdateStatus(ctx, cs.data.DB(), id, from, to)
+	if err != nil {
+		return errors.WithCode(code.ErrDatabase, "更新发券活动状态失败")
+	}
+	if !ok {
+		return errors.WithCode(code.ErrCampaignStateConflict, "活动当前状态不允许该操作")
+	}
+	log.InfofC(ctx, "发券活动状态变更: campaignID=%d, status=%d", id, to)
+	return nil
+}
+
+func (cs *couponCampaignService) getCampaign(ctx context.Context, id int64) (*do.CouponCampaignDO, error) {
+	campaign, err := cs.data.CouponCampaigns().Get(ctx, cs.data.DB(), id)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, "查询发券活动失败")
+	}
+	if campaign == nil {
+		return nil, errors.WithCode(code.ErrResourceNotFound, "发券活动不存在: %d", id)
+	}
+	return campaign, nil
+}
+
+// RegisterJobs 注册活动投递任务，未配置投递间隔或消息生产者不可用时不启用
+func (cs *couponCampaignService) RegisterJobs(scheduler *delayjob.Scheduler) {
+	if cs.opts.DispatchInterval <= 0 {
+		return
+	}
+	if cs.producer == nil {
+		log.Warn("发券批次生产者未初始化，定向发券活动不会投递")
+		return
+	}
+	scheduler.Every(JobTypeCampaignDispatch, cs.opts.DispatchInterval, func(ctx context.Context, job *delayjob.Job) error {
+		_, err := cs.DispatchDue(ctx, time.Now())
+		return err
+	})
+}
+
+// DispatchDue 每个发放中的活动每次最多投递一批，批次间隔由活动的BatchIntervalSeconds控制
+//
+// 投递前先回收超时未处理的目标，消息丢失时这些目标会被重新投递；
+// 待发放与已投递的目标都为空时活动完成
+func (cs *couponCampaignService) DispatchDue(ctx context.Context, now time.Time) (int, error) {
+	if cs.producer == nil {
+		return 0, nil
+	}
+	campaigns, err := cs.data.CouponCampaigns().ListByStatus(ctx, cs.data.DB(), do.CampaignStatusRunning)
+	if err != nil {
+		return 0, err
+	}
+
+	dispatched := 0
+	for _, campaign := range campaigns {
+		interval := time.Duration(campaign.BatchIntervalSeconds) * time.Second
+		if campaign.LastDispatchedAt != nil && now.Sub(*campaign.LastDispatchedAt) < interval {
+			continue
+		}
+		ok, err := cs.dispatchBatch(ctx, campaign, now)
+		if err != nil {
+			return dispatched, err
+		}
+		if ok {
+			dispatched++
+		}
+	}
+	return dispatched, nil
+}
+
+func (cs *couponCampaignService) dispatchBatch(ctx context.Context, campaign *do.CouponCampaignDO, now time.Time) (bool, error) {
+	targetData := cs.data.CampaignTargets()
+	if reset, err := targetData.ResetStale(ctx, cs.data.DB(), campaign.ID, now.Add(-cs.dispatchTimeout())); err != nil {
+		return false, err
+	} else if reset > 0 {
+		log.WarnfC(ctx, "发券活动回收超时未处理目标: campaignID=%d, count=%d", campaign.ID, reset)
+	}
+
+	targets, err := targetData.ClaimPending(ctx, cs.data.DB(), campaign.ID, int(campaign.BatchSize))
+	if err != nil {
+		return false, err
+	}
+	if len(targets) == 0 {
+		return false, cs.completeIfDone(ctx, campaign.ID)
+	}
+
+	ids := make([]int64, 0, len(targets))
+	for _, target := range targets {
+		ids = append(ids, target.ID)
+	}
+	event := &consumer.CampaignBatchEvent{
+		CampaignID: campaign.ID,
+		TargetIDs:  ids,
+		Timestamp:  now.Unix(),
+	}
+	if err := cs.producer.SendCampaignBatch(ctx, event); err != nil {
+		// 投递失败的目标立即恢复，下个周期重新投递
+		if _, resetErr := targetData.ResetDispatched(ctx, cs.data.DB(), campaign.ID, ids); resetErr != nil {
+			log.ErrorfC(ctx, "恢复投递失败目标失败，等待超时回收: campaignID=%d, err=%v", campaign.ID, resetErr)
+		}
+		return false, nil
+	}
+
+	if err := cs.data.CouponCampaigns().SetLastDispatched(ctx, cs.data.DB(), campaign.ID, now); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+// completeIfDone 没有待发放和已投递目标时将活动标记为完成
+func (cs *couponCampaignService) completeIfDone(ctx context.Context, campaignID int64) error {
+	counts, err := cs.data.CampaignTargets().CountByStatus(ctx, cs.data.DB(), campaignID)
+	if err != nil {
+		return err
+	}
+	if counts[do.CampaignTargetPending] > 0 || counts[do.CampaignTargetDispatched] > 0 {
+		return nil
+	}
+	ok, err := cs.data.CouponCampaigns().UpdateStatus(ctx, cs.data.DB(), campaignID,
+		[]do.CampaignStatus{do.CampaignStatusRunning}, do.CampaignStatusCompleted)
+	if err != nil {
+		return err
+	}
+	if ok {
+		log.InfofC(ctx, "发券活动完成: campaignID=%d, issued=%d, skipped=%d, failed=%d", campaignID,
+			counts[do.CampaignTargetIssued], counts[do.CampaignTargetSkipped], counts[do.CampaignTargetFailed])
+	}
+	return nil
+}
+
+// IssueBatch 发放一批目标，返回错误时消息会被重新消费，已处理的目标不会重复发放
+func (cs *couponCampaignService) IssueBatch(ctx context.Context, campaignID int64, targetIDs []int64) error {
+	campaign, err := cs.data.CouponCampaigns().Get(ctx, cs.data.DB(), campaignID)
+	if err != nil {
+		return err
+	}
+	if campaign == nil {
+		log.WarnfC(ctx, "发券活动不存在，丢弃批次: campaignID=%d", campaignID)
+		return nil
+	}
+
+	targetData := cs.data.CampaignTargets()
+	switch campaign.Status {
+	case do.CampaignStatusDraft, do.CampaignStatusPaused:
+		_, err := targetData.ResetDispatched(ctx, cs.data.DB(), campaignID, targetIDs)
+		return err
+	}
+
+	targets, err := targetData.ListDispatched(ctx, cs.data.DB(), campaignID, targetIDs)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	if campaign.Status == do.CampaignStatusCancelled {
+		return cs.markAll(ctx, targets, do.CampaignTargetSkipped, "活动已取消")
+	}
+
+	now := time.Now()
+	template, err := cs.data.CouponTemplates().Get(ctx, cs.data.DB(), campaign.CouponTemplateID)
+	if err != nil {
+		return err
+	}
+	if template == nil {
+		return cs.markAll(ctx, targets, do.CampaignTargetFailed, "优惠券模板不存在")
+	}
+	available, err := cs.data.CouponTemplates().CheckTemplateAvailability(ctx, cs.data.DB(), template.ID, now)
+	if err != nil {
+		return err
+	}
+	if !available {
+		return cs.markAll(ctx, targets, do.CampaignTargetFailed, "优惠券模板不可用或已过期")
+	}
+
+	issued := 0
+	for _, target := range targets {
+		ok, err := cs.issueOne(ctx, template, target, now)
+		if err != nil {
+			return err
+		}
+		if ok {
+			issued++
+		}
+	}
+	log.InfofC(ctx, "发券活动批次处理完成: campaignID=%d, targets=%d, issued=%d", campaignID, len(targets), issued)
+	return nil
+}
+
+// issueOne 为单个目标发券，模板总量与个人限领的校验方式与兑换码兑换一致
+func (cs *couponCampaignService) issueOne(ctx context.Context, template *do.CouponTemplateDO, target *do.CampaignTargetDO, now time.Time) (bool, error) {
+	targetData := cs.data.CampaignTargets()
+	tx := cs.data.Begin()
+
+	ok, err := cs.data.CouponTemplates().IncrUsedCountIfAvailable(ctx, tx, template.ID)
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	if !ok {
+		tx.Rollback()
+		_, err := targetData.MarkResult(ctx, cs.data.DB(), target.ID, do.CampaignTargetFailed, "优惠券已发放完毕")
+		return false, err
+	}
+
+	count, err := cs.data.UserCoupons().CountUserCouponsByTemplate(ctx, tx, target.UserID, template.ID)
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	if count >= int64(template.PerUserLimit) {
+		tx.Rollback()
+		_, err := targetData.MarkResult(ctx, cs.data.DB(), target.ID, do.CampaignTargetSkipped, "超出个人限领数量")
+		return false, err
+	}
+
+	expiredAt := template.ValidEndTime
+	if template.ValidDays > 0 {
+		expiredAt = now.AddDate(0, 0, int(template.ValidDays))
+	}
+	userCoupon := &do.UserCouponDO{
+		CouponTemplateID: template.ID,
+		UserID:           target.UserID,
+		CouponCode:       newUserCouponCode(),
+		Status:           do.UserCouponStatusUnused,
+		ReceivedAt:       now,
+		ExpiredAt:        expiredAt,
+	}
+	if err := cs.data.UserCoupons().Create(ctx, tx, userCoupon); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	// 目标状态的条件更新放在事务最后：并发消费同一目标时后提交的一方更新失败并回滚整笔发券
+	ok, err = targetData.MarkIssued(ctx, tx, target.ID, userCoupon.ID)
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	if !ok {
+		tx.Rollback()
+		return false, nil
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.ErrorfC(ctx, "提交活动发券事务失败: %v", err)
+		return false, err
+	}
+
+	if cs.cacheManager != nil {
+		cs.cacheManager.InvalidateCache(
+			cache.CacheKeys.UserCouponList(target.UserID),
+			cache.CacheKeys.UserAvailableCoupons(target.UserID),
+		)
+	}
+	return true, nil
+}
+
+func (cs *couponCampaignService) markAll(ctx context.Context, targets []*do.CampaignTargetDO, status do.CampaignTargetStatus, reason string) error {
+	for _, target := range targets {
+		if _, err := cs.data.CampaignTargets().MarkResult(ctx, cs.data.DB(), target.ID, status, reason); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// campaignToDTO 转换活动DTO，counts为nil时不填充发放进度
+func campaignToDTO(c *do.CouponCampaignDO, counts map[do.CampaignTargetStatus]int64) *dto.CouponCampaignDTO {
+	result := &dto.CouponCampaignDTO{
+		ID:                   c.ID,
+		Name:                 c.Name,
+		CouponTemplateID:     c.CouponTemplateID,
+		SegmentType:          c.SegmentType,
+		SegmentParams:        c.SegmentParams,
+		Status:               int32(c.Status),
+		BatchSize:            c.BatchSize,
+		BatchIntervalSeconds: c.BatchIntervalSeconds,
+		TotalTargets:         c.TotalTargets,
+		Operator:             c.Operator,
+		StartedAt:            c.StartedAt,
+		FinishedAt:           c.FinishedAt,
+		CreatedAt:            c.CreatedAt,
+	}
+	if counts != nil {
+		result.PendingCount = counts[do.CampaignTargetPending]
+		result.DispatchedCount = counts[do.CampaignTargetDispatched]
+		result.IssuedCount = counts[do.CampaignTargetIssued]
+		result.SkippedCount = counts[do.CampaignTargetSkipped]
+		result.FailedCount = counts[do.CampaignTargetFailed]
+	}
+	return result
+}
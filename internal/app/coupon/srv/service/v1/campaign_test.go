@@ -0,0 +1,53 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"emshop/internal/app/coupon/srv/config"
+	"emshop/internal/app/coupon/srv/domain/do"
+	"emshop/internal/app/coupon/srv/domain/dto"
+	"emshop/internal/app/pkg/code"
+	"emshop/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaignCreateValidation(t *testing.T) {
+	mockData := new(MockDataFactory)
+	srv := NewCouponCampaignService(mockData, nil, &config.CampaignOptions{MaxBatchSize: 1000}, nil)
+
+	cases := []*dto.CreateCouponCampaignDTO{
+		{Name: "", CouponTemplateID: 1, SegmentType: do.CampaignSegmentUserIDs},
+		{Name: "回流", CouponTemplateID: 1, SegmentType: "vip"},
+		{Name: "回流", CouponTemplateID: 1, SegmentType: do.CampaignSegmentInactive, BatchSize: 2000},
+		{Name: "回流", CouponTemplateID: 1, SegmentType: do.CampaignSegmentInactive, BatchIntervalSeconds: -1},
+	}
+	for _, req := range cases {
+		_, err := srv.Create(context.Background(), req)
+		assert.True(t, errors.IsCode(err, code.ErrInvalidRequest), "req=%+v, err=%v", req, err)
+	}
+	mockData.AssertNotCalled(t, "CouponTemplates")
+}
+
+func TestCampaignToDTOProgress(t *testing.T) {
+	c := &do.CouponCampaignDO{ID: 7, Status: do.CampaignStatusRunning, TotalTargets: 10}
+
+	withoutProgress := campaignToDTO(c, nil)
+	assert.Equal(t, int64(10), withoutProgress.TotalTargets)
+	assert.Zero(t, withoutProgress.PendingCount)
+
+	withProgress := campaignToDTO(c, map[do.CampaignTargetStatus]int64{
+		do.CampaignTargetPending:    4,
+		do.CampaignTargetDispatched: 1,
+		do.CampaignTargetIssued:     3,
+		do.CampaignTargetSkipped:    1,
+		do.CampaignTargetFailed:     1,
+	})
+	assert.Equal(t, int32(do.CampaignStatusRunning), withProgress.Status)
+	assert.Equal(t, int64(4), withProgress.PendingCount)
+	assert.Equal(t, int64(1), withProgress.DispatchedCount)
+	assert.Equal(t, int64(3), withProgress.IssuedCount)
+	assert.Equal(t, int64(1), withProgress.SkippedCount)
+	assert.Equal(t, int64(1), withProgress.FailedCount)
+}
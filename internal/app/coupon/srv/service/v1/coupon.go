@@ -58,13 +58,13 @@ func NewCouponService(data interfaces.DataFactory, redisClient *redis.Client, dt
 	GetCouponTemplate(ctx context.Context, couponID int64) (*cache.CouponTemplate, error)
 	GetUserCoupon(ctx context.Context, userCouponID int64) (*cache.UserCoupon, error)
 	InvalidateCache(keys ...string)
-}) CouponSrv {
+}, ruleEngine *calculator.BusinessRuleEngine) CouponSrv {
 	// 创建增强计算引擎
 	var calculationEngine *calculator.CalculationEngine
 	if cacheManager != nil {
 		// 创建缓存管理器适配器
 		cacheAdapter := &cacheManagerAdapter{cacheManager: cacheManager}
-		calculationEngine = calculator.NewCalculationEngine(cacheAdapter)
+		calculationEngine = calculator.NewCalculationEngine(cacheAdapter, ruleEngine)
 	}
 
 	return &couponService{
@@ -129,6 +129,11 @@ func (c *cacheManagerAdapter) GetUserCoupon(ctx context.Context, userCouponID in
 
 // generateCouponCode 生成优惠券码
 func (cs *couponService) generateCouponCode() string {
+	return newUserCouponCode()
+}
+
+// newUserCouponCode 生成用户优惠券码
+func newUserCouponCode() string {
 	timestamp := time.Now().Format("20060102150405")
 	n, _ := rand.Int(rand.Reader, big.NewInt(9999))
 	return fmt.Sprintf("CPN%s%04d", timestamp, n.Int64())
@@ -136,7 +141,7 @@ func (cs *couponService) generateCouponCode() string {
 
 // CreateCouponTemplate 创建优惠券模板
 func (cs *couponService) CreateCouponTemplate(ctx context.Context, req *dto.CreateCouponTemplateDTO) (*dto.CouponTemplateDTO, error) {
-	log.Infof("创建优惠券模板: %s", req.Name)
+	log.InfofC(ctx, "创建优惠券模板: %s", req.Name)
 	
 	// 验证时间范围
 	if req.ValidEndTime.Before(req.ValidStartTime) {
@@ -163,7 +168,7 @@ func (cs *couponService) CreateCouponTemplate(ctx context.Context, req *dto.Crea
 	
 	// 保存到数据库
 	if err := cs.data.CouponTemplates().Create(ctx, cs.data.DB(), templateDO); err != nil {
-		log.Errorf("创建优惠券模板失败: %v", err)
+		log.ErrorfC(ctx, "创建优惠券模板失败: %v", err)
 		return nil, errors.WithCode(code.ErrDatabase, "创建优惠券模板失败")
 	}
 	
@@ -177,7 +182,7 @@ func (cs *couponService) GetCouponTemplate(ctx context.Context, id int64) (*dto.
 	if cs.cacheManager != nil {
 		cacheTemplate, err := cs.cacheManager.GetCouponTemplate(ctx, id)
 		if err != nil {
-			log.Warnf("缓存获取优惠券模板失败，回退到数据库查询: %v", err)
+			log.WarnfC(ctx, "缓存获取优惠券模板失败，回退到数据库查询: %v", err)
 		} else if cacheTemplate != nil {
 			// 转换缓存数据为DTO
 			return &dto.CouponTemplateDTO{
@@ -199,7 +204,7 @@ func (cs *couponService) GetCouponTemplate(ctx context.Context, id int64) (*dto.
 	// 缓存失败或为空时回退到数据库查询
 	templateDO, err := cs.data.CouponTemplates().Get(ctx, cs.data.DB(), id)
 	if err != nil {
-		log.Errorf("获取优惠券模板失败: %v", err)
+		log.ErrorfC(ctx, "获取优惠券模板失败: %v", err)
 		return nil, errors.WithCode(code.ErrDatabase, "获取优惠券模板失败")
 	}
 	
@@ -235,7 +240,7 @@ func (cs *couponService) UpdateCouponTemplate(ctx context.Context, req *dto.Upda
 	
 	// 保存更新
 	if err := cs.data.CouponTemplates().Update(ctx, cs.data.DB(), templateDO); err != nil {
-		log.Errorf("更新优惠券模板失败: %v", err)
+		log.ErrorfC(ctx, "更新优惠券模板失败: %v", err)
 		return nil, errors.WithCode(code.ErrDatabase, "更新优惠券模板失败")
 	}
 	
@@ -243,7 +248,7 @@ func (cs *couponService) UpdateCouponTemplate(ctx context.Context, req *dto.Upda
 	if cs.cacheManager != nil {
 		cacheKey := fmt.Sprintf("coupon:template:%d", req.ID)
 		cs.cacheManager.InvalidateCache(cacheKey)
-		log.Debugf("失效优惠券模板缓存: %s", cacheKey)
+		log.DebugfC(ctx, "失效优惠券模板缓存: %s", cacheKey)
 	}
 	
 	return cs.convertTemplateToDTO(templateDO), nil
@@ -263,7 +268,7 @@ func (cs *couponService) ListCouponTemplates(ctx context.Context, req *dto.ListC
 	
 	templateListDO, err := cs.data.CouponTemplates().List(ctx, cs.data.DB(), status, meta, []string{"created_at DESC"})
 	if err != nil {
-		log.Errorf("获取优惠券模板列表失败: %v", err)
+		log.ErrorfC(ctx, "获取优惠券模板列表失败: %v", err)
 		return nil, errors.WithCode(code.ErrDatabase, "获取优惠券模板列表失败")
 	}
 	
@@ -281,7 +286,7 @@ func (cs *couponService) ListCouponTemplates(ctx context.Context, req *dto.ListC
 
 // ReceiveCoupon 领取优惠券
 func (cs *couponService) ReceiveCoupon(ctx context.Context, req *dto.ReceiveCouponDTO) (*dto.UserCouponDTO, error) {
-	log.Infof("用户领取优惠券: userID=%d, templateID=%d", req.UserID, req.CouponTemplateID)
+	log.InfofC(ctx, "用户领取优惠券: userID=%d, templateID=%d", req.UserID, req.CouponTemplateID)
 	
 	// 获取优惠券模板
 	templateDO, err := cs.data.CouponTemplates().Get(ctx, cs.data.DB(), req.CouponTemplateID)
@@ -329,19 +334,19 @@ func (cs *couponService) ReceiveCoupon(ctx context.Context, req *dto.ReceiveCoup
 	
 	if err := cs.data.UserCoupons().Create(ctx, tx, userCouponDO); err != nil {
 		tx.Rollback()
-		log.Errorf("创建用户优惠券失败: %v", err)
+		log.ErrorfC(ctx, "创建用户优惠券失败: %v", err)
 		return nil, errors.WithCode(code.ErrDatabase, "领取优惠券失败")
 	}
 	
 	// 更新模板使用数量
 	if err := cs.data.CouponTemplates().UpdateUsedCount(ctx, tx, req.CouponTemplateID, 1); err != nil {
 		tx.Rollback()
-		log.Errorf("更新优惠券模板使用数量失败: %v", err)
+		log.ErrorfC(ctx, "更新优惠券模板使用数量失败: %v", err)
 		return nil, errors.WithCode(code.ErrDatabase, "领取优惠券失败")
 	}
 	
 	if err := tx.Commit().Error; err != nil {
-		log.Errorf("提交事务失败: %v", err)
+		log.ErrorfC(ctx, "提交事务失败: %v", err)
 		return nil, errors.WithCode(code.ErrDatabase, "领取优惠券失败")
 	}
 	
@@ -363,7 +368,7 @@ func (cs *couponService) GetUserCoupons(ctx context.Context, req *dto.GetUserCou
 	
 	userCouponListDO, err := cs.data.UserCoupons().GetUserCoupons(ctx, cs.data.DB(), req.UserID, status, meta)
 	if err != nil {
-		log.Errorf("获取用户优惠券列表失败: %v", err)
+		log.ErrorfC(ctx, "获取用户优惠券列表失败: %v", err)
 		return nil, errors.WithCode(code.ErrDatabase, "获取用户优惠券列表失败")
 	}
 	
@@ -385,7 +390,7 @@ func (cs *couponService) GetAvailableCoupons(ctx context.Context, req *dto.GetAv
 	
 	userCouponDOs, err := cs.data.UserCoupons().GetUserAvailableCoupons(ctx, cs.data.DB(), req.UserID, req.OrderAmount, currentTime)
 	if err != nil {
-		log.Errorf("获取用户可用优惠券失败: %v", err)
+		log.ErrorfC(ctx, "获取用户可用优惠券失败: %v", err)
 		return nil, errors.WithCode(code.ErrDatabase, "获取用户可用优惠券失败")
 	}
 	
@@ -403,25 +408,25 @@ func (cs *couponService) GetAvailableCoupons(ctx context.Context, req *dto.GetAv
 
 // CalculateCouponDiscount 计算优惠券折扣 - 使用增强计算引擎
 func (cs *couponService) CalculateCouponDiscount(ctx context.Context, req *dto.CalculateCouponDiscountDTO) (*dto.CouponDiscountResultDTO, error) {
-	log.Infof("开始计算优惠券折扣: userID=%d, coupons=%v, amount=%.2f", 
+	log.InfofC(ctx, "开始计算优惠券折扣: userID=%d, coupons=%v, amount=%.2f", 
 		req.UserID, req.CouponIDs, req.OrderAmount)
 
 	// 使用增强的计算引擎
 	if cs.calculationEngine != nil {
 		result, err := cs.calculationEngine.Calculate(ctx, req)
 		if err != nil {
-			log.Errorf("使用增强计算引擎失败，回退到简单模式: %v", err)
+			log.ErrorfC(ctx, "使用增强计算引擎失败，回退到简单模式: %v", err)
 			return cs.fallbackCalculation(ctx, req)
 		}
 		
-		log.Infof("增强计算引擎计算完成: 原始金额=%.2f, 折扣金额=%.2f, 最终金额=%.2f, 应用优惠券=%v",
+		log.InfofC(ctx, "增强计算引擎计算完成: 原始金额=%.2f, 折扣金额=%.2f, 最终金额=%.2f, 应用优惠券=%v",
 			result.OriginalAmount, result.DiscountAmount, result.FinalAmount, result.AppliedCoupons)
 		
 		return result, nil
 	}
 
 	// 回退到简单计算模式
-	log.Warn("增强计算引擎未初始化，使用简单计算模式")
+	log.WarnC(ctx, "增强计算引擎未初始化，使用简单计算模式")
 	return cs.fallbackCalculation(ctx, req)
 }
 
@@ -512,7 +517,7 @@ func (cs *couponService) fallbackCalculation(ctx context.Context, req *dto.Calcu
 
 // UseCoupons 使用优惠券 (DTM事务用)
 func (cs *couponService) UseCoupons(ctx context.Context, req *dto.UseCouponsDTO) (*dto.UseCouponsResultDTO, error) {
-	log.Infof("使用优惠券: orderSn=%s, userID=%d, coupons=%v", req.OrderSn, req.UserID, req.CouponIDs)
+	log.InfofC(ctx, "使用优惠券: orderSn=%s, userID=%d, coupons=%v", req.OrderSn, req.UserID, req.CouponIDs)
 	
 	// 先计算折扣
 	calcReq := &dto.CalculateCouponDiscountDTO{
@@ -537,8 +542,9 @@ func (cs *couponService) UseCoupons(ctx context.Context, req *dto.UseCouponsDTO)
 	
 	tx := cs.data.Begin()
 	usedCoupons := make([]int64, 0)
+	discountShares := splitDiscount(calcResult.DiscountAmount, len(calcResult.AppliedCoupons))
 	
-	for _, couponID := range calcResult.AppliedCoupons {
+	for i, couponID := range calcResult.AppliedCoupons {
 		userCouponDO, err := cs.data.UserCoupons().Get(ctx, cs.data.DB(), couponID)
 		if err != nil || userCouponDO == nil {
 			tx.Rollback()
@@ -570,13 +576,30 @@ func (cs *couponService) UseCoupons(ctx context.Context, req *dto.UseCouponsDTO)
 			return nil, errors.WithCode(code.ErrDatabase, "使用优惠券失败")
 		}
 		
+		// 记录使用日志，报表聚合与订单取消释放都依赖这条记录
+		usageLog := &do.CouponUsageLogDO{
+			UserCouponID:   couponID,
+			UserID:         req.UserID,
+			OrderSn:        req.OrderSn,
+			OriginalAmount: req.OrderAmount,
+			DiscountAmount: discountShares[i],
+			FinalAmount:    calcResult.FinalAmount,
+			Action:         do.CouponUsageActionUse,
+			CreatedAt:      time.Now(),
+		}
+		if err := cs.data.CouponUsageLogs().Create(ctx, tx, usageLog); err != nil {
+			tx.Rollback()
+			cs.redisClient.Eval(ctx, scripts.ReleaseCouponLockLua, []string{lockKey, statusKey}, req.UserID, "release")
+			return nil, errors.WithCode(code.ErrDatabase, "记录优惠券使用日志失败")
+		}
+		
 		// 释放Redis锁并标记已使用
 		cs.redisClient.Eval(ctx, scripts.ReleaseCouponLockLua, []string{lockKey, statusKey}, req.UserID, "use")
 		usedCoupons = append(usedCoupons, couponID)
 	}
 	
 	if err := tx.Commit().Error; err != nil {
-		log.Errorf("提交使用优惠券事务失败: %v", err)
+		log.ErrorfC(ctx, "提交使用优惠券事务失败: %v", err)
 		return nil, errors.WithCode(code.ErrDatabase, "使用优惠券失败")
 	}
 	
@@ -588,12 +611,12 @@ func (cs *couponService) UseCoupons(ctx context.Context, req *dto.UseCouponsDTO)
 
 // ReleaseCoupons 释放优惠券 (DTM补偿用)
 func (cs *couponService) ReleaseCoupons(ctx context.Context, req *dto.ReleaseCouponsDTO) error {
-	log.Infof("释放优惠券: orderSn=%s", req.OrderSn)
+	log.InfofC(ctx, "释放优惠券: orderSn=%s", req.OrderSn)
 	
 	// 获取该订单的优惠券使用记录
 	usageLogs, err := cs.data.CouponUsageLogs().GetByOrderSn(ctx, cs.data.DB(), req.OrderSn)
 	if err != nil {
-		log.Errorf("获取优惠券使用记录失败: %v", err)
+		log.ErrorfC(ctx, "获取优惠券使用记录失败: %v", err)
 		return errors.WithCode(code.ErrDatabase, "获取优惠券使用记录失败")
 	}
 	
@@ -612,7 +635,7 @@ func (cs *couponService) ReleaseCoupons(ctx context.Context, req *dto.ReleaseCou
 	}
 	
 	if err := tx.Commit().Error; err != nil {
-		log.Errorf("提交释放优惠券事务失败: %v", err)
+		log.ErrorfC(ctx, "提交释放优惠券事务失败: %v", err)
 		return errors.WithCode(code.ErrDatabase, "释放优惠券失败")
 	}
 	
@@ -621,6 +644,11 @@ func (cs *couponService) ReleaseCoupons(ctx context.Context, req *dto.ReleaseCou
 
 // convertTemplateToDTO 转换模板DO为DTO
 func (cs *couponService) convertTemplateToDTO(templateDO *do.CouponTemplateDO) *dto.CouponTemplateDTO {
+	return templateToDTO(templateDO)
+}
+
+// templateToDTO 转换模板DO为DTO
+func templateToDTO(templateDO *do.CouponTemplateDO) *dto.CouponTemplateDTO {
 	return &dto.CouponTemplateDTO{
 		ID:                templateDO.ID,
 		Name:              templateDO.Name,
@@ -643,6 +671,11 @@ func (cs *couponService) convertTemplateToDTO(templateDO *do.CouponTemplateDO) *
 
 // convertUserCouponToDTO 转换用户优惠券DO为DTO
 func (cs *couponService) convertUserCouponToDTO(userCouponDO *do.UserCouponDO, templateDO *do.CouponTemplateDO) *dto.UserCouponDTO {
+	return userCouponToDTO(userCouponDO, templateDO)
+}
+
+// userCouponToDTO 转换用户优惠券DO为DTO
+func userCouponToDTO(userCouponDO *do.UserCouponDO, templateDO *do.CouponTemplateDO) *dto.UserCouponDTO {
 	dto := &dto.UserCouponDTO{
 		ID:               userCouponDO.ID,
 		CouponTemplateID: userCouponDO.CouponTemplateID,
@@ -656,7 +689,7 @@ func (cs *couponService) convertUserCouponToDTO(userCouponDO *do.UserCouponDO, t
 	}
 	
 	if templateDO != nil {
-		dto.Template = cs.convertTemplateToDTO(templateDO)
+		dto.Template = templateToDTO(templateDO)
 	}
 	
 	return dto
@@ -0,0 +1,263 @@
+package v1
+
+import (
+	"context"
+	"time"
+
+	"emshop/internal/app/coupon/srv/config"
+	"emshop/internal/app/coupon/srv/data/v1/interfaces"
+	"emshop/internal/app/coupon/srv/domain/do"
+	"emshop/internal/app/coupon/srv/domain/dto"
+	"emshop/internal/app/coupon/srv/pkg/cache"
+	"emshop/internal/app/pkg/code"
+	v1 "emshop/pkg/common/meta/v1"
+	"emshop/pkg/delayjob"
+	"emshop/pkg/errors"
+	"emshop/pkg/log"
+)
+
+const (
+	// JobTypeExpireSweep 过期优惠券扫描任务
+	JobTypeExpireSweep = "coupon.expire.sweep"
+	// JobTypeExpireRemind 即将过期提醒任务
+	JobTypeExpireRemind = "coupon.expire.remind"
+
+	defaultExpiryBatchSize = 500
+)
+
+// CouponExpirySrv 优惠券到期处理服务
+type CouponExpirySrv interface {
+	// ExpireCoupons 将now之前过期的未使用优惠券分批标记为已过期，返回处理数量
+	ExpireCoupons(ctx context.Context, now time.Time) (int64, error)
+	// RemindExpiringCoupons 为remindDays天内即将过期的优惠券生成提醒，返回新增提醒数量
+	RemindExpiringCoupons(ctx context.Context, now time.Time) (int64, error)
+	// ListCouponReminders 获取用户到期提醒列表
+	ListCouponReminders(ctx context.Context, req *dto.ListCouponRemindersDTO) (*dto.CouponReminderListDTO, error)
+	// RegisterJobs 注册周期任务
+	RegisterJobs(scheduler *delayjob.Scheduler)
+}
+
+type couponExpiryService struct {
+	data         interfaces.DataFactory
+	opts         *config.ExpiryOptions
+	cacheManager interface {
+		InvalidateCache(keys ...string)
+	}
+}
+
+// NewCouponExpiryService 创建优惠券到期处理服务
+func NewCouponExpiryService(data interfaces.DataFactory, opts *config.ExpiryOptions, cacheManager interface {
+	InvalidateCache(keys ...string)
+}) CouponExpirySrv {
+	if opts == nil {
+		opts = &config.ExpiryOptions{}
+	}
+	return &couponExpiryService{
+		data:         data,
+		opts:         opts,
+		cacheManager: cacheManager,
+	}
+}
+
+func (es *couponExpiryService) batchSize() int {
+	if es.opts.BatchSize > 0 {
+		return es.opts.BatchSize
+	}
+	return defaultExpiryBatchSize
+}
+
+// ExpireCoupons 分批过期优惠券，每批独立提交，单批失败不影响已处理的批次
+func (es *couponExpiryService) ExpireCoupons(ctx context.Context, now time.Time) (int64, error) {
+	limit := es.batchSize()
+	var afterID, total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		batch, err := es.data.UserCoupons().FindExpiredBatch(ctx, es.data.DB(), now, afterID, limit)
+		if err != nil {
+			return total, errors.WithCode(code.ErrDatabase, "查询过期优惠券失败")
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		ids := make([]int64, 0, len(batch))
+		for _, uc := range batch {
+			ids = append(ids, uc.ID)
+		}
+		afterID = ids[len(ids)-1]
+
+		affected, err := es.data.UserCoupons().ExpireCoupons(ctx, es.data.DB(), ids, now)
+		if err != nil {
+			return total, errors.WithCode(code.ErrDatabase, "批量过期优惠券失败")
+		}
+		total += affected
+		es.invalidateUserCoupons(batch)
+
+		if len(batch) < limit {
+			break
+		}
+	}
+
+	if total > 0 {
+		log.InfofC(ctx, "优惠券过期处理完成: expired=%d, before=%s", total, now.Format(time.RFC3339))
+	}
+	return total, nil
+}
+
+// invalidateUserCoupons 失效受影响用户的优惠券列表与可用券缓存
+func (es *couponExpiryService) invalidateUserCoupons(coupons []*do.UserCouponDO) {
+	if es.cacheManager == nil || len(coupons) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(coupons)+2)
+	users := make(map[int64]struct{})
+	for _, uc := range coupons {
+		keys = append(keys, cache.CacheKeys.UserCoupon(uc.ID))
+		if _, ok := users[uc.UserID]; ok {
+			continue
+		}
+		users[uc.UserID] = struct{}{}
+		keys = append(keys,
+			cache.CacheKeys.UserCouponList(uc.UserID),
+			cache.CacheKeys.UserAvailableCoupons(uc.UserID),
+		)
+	}
+	es.cacheManager.InvalidateCache(keys...)
+}
+
+// RemindExpiringCoupons 生成即将过期提醒，每张用户优惠券只提醒一次
+func (es *couponExpiryService) RemindExpiringCoupons(ctx context.Context, now time.Time) (int64, error) {
+	if es.opts.RemindDays <= 0 {
+		return 0, nil
+	}
+
+	limit := es.batchSize()
+	to := now.Add(time.Duration(es.opts.RemindDays) * 24 * time.Hour)
+	names := make(map[int64]string)
+	var afterID, total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		batch, err := es.data.UserCoupons().FindExpiringWithoutReminder(ctx, es.data.DB(), now, to, afterID, limit)
+		if err != nil {
+			return total, errors.WithCode(code.ErrDatabase, "查询即将过期优惠券失败")
+		}
+		if len(batch) == 0 {
+			break
+		}
+		afterID = batch[len(batch)-1].ID
+
+		reminders := make([]*do.CouponReminderDO, 0, len(batch))
+		for _, uc := range batch {
+			reminders = append(reminders, &do.CouponReminderDO{
+				UserCouponID:     uc.ID,
+				UserID:           uc.UserID,
+				CouponTemplateID: uc.CouponTemplateID,
+				CouponName:       es.templateName(ctx, names, uc.CouponTemplateID),
+				CouponCode:       uc.CouponCode,
+				ExpiredAt:        uc.ExpiredAt,
+				RemainingDays:    remainingDays(now, uc.ExpiredAt),
+				CreatedAt:        now,
+			})
+		}
+		if err := es.data.CouponReminders().BatchCreate(ctx, es.data.DB(), reminders); err != nil {
+			return total, errors.WithCode(code.ErrDatabase, "创建优惠券到期提醒失败")
+		}
+		total += int64(len(reminders))
+
+		if len(batch) < limit {
+			break
+		}
+	}
+
+	if total > 0 {
+		log.InfofC(ctx, "优惠券到期提醒生成完成: reminders=%d, within_days=%d", total, es.opts.RemindDays)
+	}
+	return total, nil
+}
+
+// templateName 获取模板名称，同一轮扫描内复用查询结果
+func (es *couponExpiryService) templateName(ctx context.Context, names map[int64]string, templateID int64) string {
+	if name, ok := names[templateID]; ok {
+		return name
+	}
+	template, err := es.data.CouponTemplates().Get(ctx, es.data.DB(), templateID)
+	if err != nil {
+		log.WarnfC(ctx, "获取优惠券模板名称失败: template_id=%d, err=%v", templateID, err)
+		return ""
+	}
+	name := ""
+	if template != nil {
+		name = template.Name
+	}
+	names[templateID] = name
+	return name
+}
+
+// remainingDays 计算剩余天数，不足一天按一天计
+func remainingDays(now, expiredAt time.Time) int32 {
+	d := expiredAt.Sub(now)
+	if d <= 0 {
+		return 0
+	}
+	days := d / (24 * time.Hour)
+	if d%(24*time.Hour) != 0 {
+		days++
+	}
+	return int32(days)
+}
+
+// ListCouponReminders 获取用户到期提醒列表
+func (es *couponExpiryService) ListCouponReminders(ctx context.Context, req *dto.ListCouponRemindersDTO) (*dto.CouponReminderListDTO, error) {
+	meta := v1.ListMeta{
+		Page:     int(req.Page),
+		PageSize: int(req.PageSize),
+	}
+
+	listDO, err := es.data.CouponReminders().ListByUser(ctx, es.data.DB(), req.UserID, meta)
+	if err != nil {
+		log.ErrorfC(ctx, "获取优惠券到期提醒失败: %v", err)
+		return nil, errors.WithCode(code.ErrDatabase, "获取优惠券到期提醒失败")
+	}
+
+	items := make([]*dto.CouponReminderDTO, 0, len(listDO.Items))
+	for _, r := range listDO.Items {
+		items = append(items, &dto.CouponReminderDTO{
+			ID:               r.ID,
+			UserCouponID:     r.UserCouponID,
+			CouponTemplateID: r.CouponTemplateID,
+			CouponName:       r.CouponName,
+			CouponCode:       r.CouponCode,
+			ExpiredAt:        r.ExpiredAt,
+			RemainingDays:    r.RemainingDays,
+			CreatedAt:        r.CreatedAt,
+		})
+	}
+
+	return &dto.CouponReminderListDTO{
+		TotalCount: listDO.TotalCount,
+		Items:      items,
+	}, nil
+}
+
+// RegisterJobs 注册过期扫描与到期提醒周期任务，多副本间同一时间点只执行一次
+func (es *couponExpiryService) RegisterJobs(scheduler *delayjob.Scheduler) {
+	if es.opts.SweepInterval > 0 {
+		scheduler.Every(JobTypeExpireSweep, es.opts.SweepInterval, func(ctx context.Context, job *delayjob.Job) error {
+			_, err := es.ExpireCoupons(ctx, time.Now())
+			return err
+		})
+	}
+	if es.opts.RemindInterval > 0 && es.opts.RemindDays > 0 {
+		scheduler.Every(JobTypeExpireRemind, es.opts.RemindInterval, func(ctx context.Context, job *delayjob.Job) error {
+			_, err := es.RemindExpiringCoupons(ctx, time.Now())
+			return err
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package v1
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"emshop/internal/app/coupon/srv/config"
+	"emshop/internal/app/coupon/srv/data/v1/interfaces"
+	"emshop/internal/app/coupon/srv/domain/do"
+	"emshop/internal/app/coupon/srv/pkg/cache"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// stubExpiryUserCouponData 按 mysql 实现的查询条件在内存中筛选用户优惠券
+type stubExpiryUserCouponData struct {
+	interfaces.UserCouponDataInterface
+	coupons   []*do.UserCouponDO
+	reminders *stubExpiryReminderData
+	// usedBeforeExpire 模拟查询与更新之间被核销的优惠券
+	usedBeforeExpire map[int64]bool
+	cursors          []int64
+}
+
+func (s *stubExpiryUserCouponData) FindExpiredBatch(ctx context.Context, db *gorm.DB, beforeTime time.Time, afterID int64, limit int) ([]*do.UserCouponDO, error) {
+	s.cursors = append(s.cursors, afterID)
+	var batch []*do.UserCouponDO
+	for _, uc := range s.coupons {
+		if len(batch) == limit {
+			break
+		}
+		if uc.ID > afterID && uc.Status == do.UserCouponStatusUnused && uc.ExpiredAt.Before(beforeTime) {
+			batch = append(batch, uc)
+		}
+	}
+	return batch, nil
+}
+
+func (s *stubExpiryUserCouponData) ExpireCoupons(ctx context.Context, db *gorm.DB, ids []int64, beforeTime time.Time) (int64, error) {
+	var affected int64
+	for _, id := range ids {
+		uc := s.get(id)
+		if s.usedBeforeExpire[id] {
+			uc.Status = do.UserCouponStatusUsed
+		}
+		if uc.Status == do.UserCouponStatusUnused && uc.ExpiredAt.Before(beforeTime) {
+			uc.Status = do.UserCouponStatusExpired
+			affected++
+		}
+	}
+	return affected, nil
+}
+
+func (s *stubExpiryUserCouponData) FindExpiringWithoutReminder(ctx context.Context, db *gorm.DB, from, to time.Time, afterID int64, limit int) ([]*do.UserCouponDO, error) {
+	var batch []*do.UserCouponDO
+	for _, uc := range s.coupons {
+		if len(batch) == limit {
+			break
+		}
+		if uc.ID > afterID && uc.Status == do.UserCouponStatusUnused &&
+			!uc.ExpiredAt.Before(from) && uc.ExpiredAt.Before(to) && s.reminders.byCoupon[uc.ID] == nil {
+			batch = append(batch, uc)
+		}
+	}
+	return batch, nil
+}
+
+func (s *stubExpiryUserCouponData) get(id int64) *do.UserCouponDO {
+	for _, uc := range s.coupons {
+		if uc.ID == id {
+			return uc
+		}
+	}
+	return nil
+}
+
+// stubExpiryReminderData 同一张用户优惠券只保留首条提醒，与唯一索引一致
+type stubExpiryReminderData struct {
+	interfaces.CouponReminderDataInterface
+	byCoupon map[int64]*do.CouponReminderDO
+}
+
+func (s *stubExpiryReminderData) BatchCreate(ctx context.Context, db *gorm.DB, reminders []*do.CouponReminderDO) error {
+	for _, r := range reminders {
+		if s.byCoupon[r.UserCouponID] == nil {
+			s.byCoupon[r.UserCouponID] = r
+		}
+	}
+	return nil
+}
+
+type stubExpiryTemplateData struct {
+	interfaces.CouponTemplateDataInterface
+	gets int
+}
+
+func (s *stubExpiryTemplateData) Get(ctx context.Context, db *gorm.DB, id int64) (*do.CouponTemplateDO, error) {
+	s.gets++
+	return &do.CouponTemplateDO{ID: id, Name: "满100减10"}, nil
+}
+
+type recordingCacheInvalidator struct {
+	keys []string
+}
+
+func (r *recordingCacheInvalidator) InvalidateCache(keys ...string) {
+	r.keys = append(r.keys, keys...)
+}
+
+type expiryFixture struct {
+	srv         CouponExpirySrv
+	userCoupons *stubExpiryUserCouponData
+	reminders   *stubExpiryReminderData
+	templates   *stubExpiryTemplateData
+	cache       *recordingCacheInvalidator
+}
+
+func newExpiryFixture(opts *config.ExpiryOptions, coupons ...*do.UserCouponDO) *expiryFixture {
+	sort.Slice(coupons, func(i, j int) bool { return coupons[i].ID < coupons[j].ID })
+	f := &expiryFixture{
+		reminders: &stubExpiryReminderData{byCoupon: map[int64]*do.CouponReminderDO{}},
+		templates: &stubExpiryTemplateData{},
+		cache:     &recordingCacheInvalidator{},
+	}
+	f.userCoupons = &stubExpiryUserCouponData{coupons: coupons, reminders: f.reminders}
+
+	mockData := new(MockDataFactory)
+	mockData.On("DB").Return((*gorm.DB)(nil))
+	mockData.On("UserCoupons").Return(f.userCoupons)
+	mockData.On("CouponReminders").Return(f.reminders)
+	mockData.On("CouponTemplates").Return(f.templates)
+	f.srv = NewCouponExpiryService(mockData, opts, f.cache)
+	return f
+}
+
+func expiryCoupon(id, userID int64, status do.UserCouponStatus, expiredAt time.Time) *do.UserCouponDO {
+	return &do.UserCouponDO{ID: id, UserID: userID, CouponTemplateID: 10, Status: status, ExpiredAt: expiredAt}
+}
+
+func TestExpireCouponsPagesByCursor(t *testing.T) {
+	now := time.Date(2024, 8, 1, 10, 0, 0, 0, time.Local)
+	past := now.Add(-time.Hour)
+	f := newExpiryFixture(&config.ExpiryOptions{BatchSize: 2},
+		expiryCoupon(1, 100, do.UserCouponStatusUnused, past),
+		expiryCoupon(2, 100, do.UserCouponStatusUsed, past),
+		expiryCoupon(3, 200, do.UserCouponStatusUnused, past),
+		expiryCoupon(4, 200, do.UserCouponStatusUnused, now.Add(time.Hour)),
+		expiryCoupon(5, 300, do.UserCouponStatusUnused, past),
+		expiryCoupon(6, 300, do.UserCouponStatusUnused, past),
+		expiryCoupon(7, 300, do.UserCouponStatusUnused, past),
+	)
+
+	total, err := f.srv.ExpireCoupons(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	// 每批从上一批最后一个主键继续，不足一批时结束
+	assert.Equal(t, []int64{0, 3, 6}, f.userCoupons.cursors)
+
+	want := map[int64]do.UserCouponStatus{
+		1: do.UserCouponStatusExpired,
+		2: do.UserCouponStatusUsed,
+		3: do.UserCouponStatusExpired,
+		4: do.UserCouponStatusUnused,
+		5: do.UserCouponStatusExpired,
+		6: do.UserCouponStatusExpired,
+		7: do.UserCouponStatusExpired,
+	}
+	for id, status := range want {
+		assert.Equal(t, status, f.userCoupons.get(id).Status, "user coupon %d", id)
+	}
+	assert.Contains(t, f.cache.keys, cache.CacheKeys.UserCoupon(7))
+	assert.Contains(t, f.cache.keys, cache.CacheKeys.UserAvailableCoupons(300))
+	assert.NotContains(t, f.cache.keys, cache.CacheKeys.UserCoupon(4))
+}
+
+func TestExpireCouponsSkipsCouponsUsedConcurrently(t *testing.T) {
+	now := time.Date(2024, 8, 1, 10, 0, 0, 0, time.Local)
+	f := newExpiryFixture(&config.ExpiryOptions{BatchSize: 10},
+		expiryCoupon(1, 100, do.UserCouponStatusUnused, now.Add(-time.Hour)),
+		expiryCoupon(2, 100, do.UserCouponStatusUnused, now.Add(-time.Hour)),
+	)
+	f.userCoupons.usedBeforeExpire = map[int64]bool{2: true}
+
+	total, err := f.srv.ExpireCoupons(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, do.UserCouponStatusUsed, f.userCoupons.get(2).Status)
+}
+
+func TestExpireCouponsRerunIsIdempotent(t *testing.T) {
+	now := time.Date(2024, 8, 1, 10, 0, 0, 0, time.Local)
+	f := newExpiryFixture(&config.ExpiryOptions{BatchSize: 2},
+		expiryCoupon(1, 100, do.UserCouponStatusUnused, now.Add(-time.Hour)),
+		expiryCoupon(2, 100, do.UserCouponStatusUnused, now.Add(-time.Hour)),
+		expiryCoupon(3, 100, do.UserCouponStatusUnused, now.Add(-time.Hour)),
+	)
+
+	total, err := f.srv.ExpireCoupons(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+
+	f.cache.keys = nil
+	total, err = f.srv.ExpireCoupons(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+	assert.Empty(t, f.cache.keys)
+	for _, uc := range f.userCoupons.coupons {
+		assert.Equal(t, do.UserCouponStatusExpired, uc.Status)
+	}
+}
+
+func TestRemindExpiringCouponsOncePerCoupon(t *testing.T) {
+	now := time.Date(2024, 8, 1, 10, 0, 0, 0, time.Local)
+	f := newExpiryFixture(&config.ExpiryOptions{BatchSize: 2, RemindDays: 3},
+		expiryCoupon(1, 100, do.UserCouponStatusUnused, now.Add(12*time.Hour)),
+		expiryCoupon(2, 100, do.UserCouponStatusUnused, now.Add(50*time.Hour)),
+		expiryCoupon(3, 200, do.UserCouponStatusUnused, now.Add(60*time.Hour)),
+		expiryCoupon(4, 200, do.UserCouponStatusUsed, now.Add(12*time.Hour)),
+		expiryCoupon(5, 200, do.UserCouponStatusUnused, now.Add(10*24*time.Hour)),
+		expiryCoupon(6, 300, do.UserCouponStatusUnused, now.Add(-time.Hour)),
+	)
+
+	created, err := f.srv.RemindExpiringCoupons(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), created)
+	require.Len(t, f.reminders.byCoupon, 3)
+	assert.Equal(t, int32(1), f.reminders.byCoupon[1].RemainingDays)
+	assert.Equal(t, int32(3), f.reminders.byCoupon[2].RemainingDays)
+	assert.Equal(t, "满100减10", f.reminders.byCoupon[3].CouponName)
+	// 同一轮扫描内模板名称只查询一次
+	assert.Equal(t, 1, f.templates.gets)
+
+	created, err = f.srv.RemindExpiringCoupons(context.Background(), now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), created)
+	assert.Len(t, f.reminders.byCoupon, 3)
+}
+
+func TestRemindExpiringCouponsDisabled(t *testing.T) {
+	now := time.Date(2024, 8, 1, 10, 0, 0, 0, time.Local)
+	f := newExpiryFixture(&config.ExpiryOptions{},
+		expiryCoupon(1, 100, do.UserCouponStatusUnused, now.Add(time.Hour)),
+	)
+
+	created, err := f.srv.RemindExpiringCoupons(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), created)
+	assert.Empty(t, f.reminders.byCoupon)
+}
+
+func TestRemainingDays(t *testing.T) {
+	now := time.Date(2024, 8, 1, 10, 0, 0, 0, time.Local)
+
+	assert.Equal(t, int32(0), remainingDays(now, now))
+	assert.Equal(t, int32(0), remainingDays(now, now.Add(-time.Hour)))
+	assert.Equal(t, int32(1), remainingDays(now, now.Add(time.Minute)))
+	assert.Equal(t, int32(1), remainingDays(now, now.Add(24*time.Hour)))
+	assert.Equal(t, int32(3), remainingDays(now, now.Add(48*time.Hour+time.Second)))
+}
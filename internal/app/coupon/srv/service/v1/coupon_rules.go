@@ -0,0 +1,161 @@
+package v1
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"emshop/internal/app/coupon/srv/data/v1/interfaces"
+	"emshop/internal/app/coupon/srv/domain/dto"
+	"emshop/internal/app/coupon/srv/pkg/calculator"
+	"emshop/internal/app/pkg/code"
+	"emshop/pkg/errors"
+	"emshop/pkg/log"
+)
+
+// CouponRuleSrv 优惠券业务规则配置服务
+//
+// 规则参数保存在coupon_configs中(键前缀rule.)，本副本修改后立即生效，
+// 其他副本通过Canal监听coupon_configs变更后调用Reload重新加载
+type CouponRuleSrv interface {
+	// GetRuleSet 获取当前生效的规则参数
+	GetRuleSet(ctx context.Context) *calculator.RuleSet
+	// UpdateRuleSet 校验并保存规则参数，保存成功后立即生效
+	UpdateRuleSet(ctx context.Context, rs *calculator.RuleSet) error
+	// Reload 从coupon_configs重新加载规则参数，加载失败时保留当前规则
+	Reload(ctx context.Context) error
+	// DryRun 使用候选规则参数对样例订单试算，不影响生效中的规则
+	DryRun(ctx context.Context, req *dto.DryRunCouponRulesDTO) (*dto.CouponRuleDryRunResultDTO, error)
+}
+
+type couponRuleService struct {
+	data       interfaces.DataFactory
+	ruleEngine *calculator.BusinessRuleEngine
+}
+
+// NewCouponRuleService 创建优惠券业务规则配置服务
+func NewCouponRuleService(data interfaces.DataFactory, ruleEngine *calculator.BusinessRuleEngine) CouponRuleSrv {
+	return &couponRuleService{
+		data:       data,
+		ruleEngine: ruleEngine,
+	}
+}
+
+// GetRuleSet 获取当前生效的规则参数
+func (rs *couponRuleService) GetRuleSet(ctx context.Context) *calculator.RuleSet {
+	return rs.ruleEngine.RuleSet()
+}
+
+// UpdateRuleSet 在一个事务中写入全部规则配置项
+func (rs *couponRuleService) UpdateRuleSet(ctx context.Context, ruleSet *calculator.RuleSet) error {
+	if err := ruleSet.Validate(); err != nil {
+		return errors.WithCode(code.ErrInvalidRequest, "%s", err.Error())
+	}
+	entries, err := ruleSet.ConfigEntries()
+	if err != nil {
+		return errors.WithCode(code.ErrInvalidRequest, "%s", err.Error())
+	}
+
+	tx := rs.data.Begin()
+	for _, entry := range entries {
+		if err := rs.data.CouponConfigs().Set(ctx, tx, entry.Key, entry.Value, entry.Description); err != nil {
+			tx.Rollback()
+			return errors.WithCode(code.ErrDatabase, "保存业务规则配置失败")
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		log.ErrorfC(ctx, "提交业务规则配置事务失败: %v", err)
+		return errors.WithCode(code.ErrDatabase, "保存业务规则配置失败")
+	}
+
+	if err := rs.ruleEngine.ApplyRuleSet(ruleSet); err != nil {
+		return errors.WithCode(code.ErrInvalidRequest, "%s", err.Error())
+	}
+	log.InfoC(ctx, "业务规则配置已更新并生效")
+	return nil
+}
+
+// Reload 从coupon_configs重新加载规则参数
+func (rs *couponRuleService) Reload(ctx context.Context) error {
+	configs, err := rs.data.CouponConfigs().GetAll(ctx, rs.data.DB())
+	if err != nil {
+		return errors.WithCode(code.ErrDatabase, "加载业务规则配置失败")
+	}
+
+	values := make(map[string]string)
+	for _, c := range configs {
+		if strings.HasPrefix(c.ConfigKey, calculator.RuleConfigKeyPrefix) {
+			values[c.ConfigKey] = c.ConfigValue
+		}
+	}
+
+	ruleSet, err := calculator.ParseRuleSet(values)
+	if err != nil {
+		log.ErrorfC(ctx, "业务规则配置无效，继续使用当前规则: %v", err)
+		return errors.WithCode(code.ErrInvalidRequest, "%s", err.Error())
+	}
+	if err := rs.ruleEngine.ApplyRuleSet(ruleSet); err != nil {
+		return errors.WithCode(code.ErrInvalidRequest, "%s", err.Error())
+	}
+	log.InfofC(ctx, "业务规则配置加载完成: 配置项=%d", len(values))
+	return nil
+}
+
+// DryRun 对样例订单执行全部规则并返回每条规则的结果
+func (rs *couponRuleService) DryRun(ctx context.Context, req *dto.DryRunCouponRulesDTO) (*dto.CouponRuleDryRunResultDTO, error) {
+	engine := rs.ruleEngine
+	if raw := strings.TrimSpace(req.Rules); raw != "" && raw != "null" {
+		candidate, err := calculator.DecodeRuleSet([]byte(raw))
+		if err != nil {
+			return nil, errors.WithCode(code.ErrInvalidRequest, "%s", err.Error())
+		}
+		engine, _ = calculator.NewBusinessRuleEngineWithRuleSet(candidate)
+	}
+
+	at := req.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+	calcCtx := &calculator.CalculationContext{
+		UserID:      req.UserID,
+		OrderAmount: req.OrderAmount,
+		CouponIDs:   req.CouponTemplateIDs,
+		CurrentTime: at,
+	}
+	for _, templateID := range req.CouponTemplateIDs {
+		template, err := rs.data.CouponTemplates().Get(ctx, rs.data.DB(), templateID)
+		if err != nil {
+			return nil, errors.WithCode(code.ErrDatabase, "获取优惠券模板失败")
+		}
+		if template == nil {
+			return nil, errors.WithCode(code.ErrResourceNotFound, "优惠券模板不存在: %d", templateID)
+		}
+		calcCtx.UserCoupons = append(calcCtx.UserCoupons, &calculator.EnhancedUserCoupon{
+			Template: &calculator.CouponTemplate{
+				ID:                template.ID,
+				Name:              template.Name,
+				Type:              int32(template.Type),
+				DiscountType:      int32(template.DiscountType),
+				DiscountValue:     template.DiscountValue,
+				MinAmount:         template.MinOrderAmount,
+				MaxDiscountAmount: template.MaxDiscountAmount,
+				ValidStart:        template.ValidStartTime,
+				ValidEnd:          template.ValidEndTime,
+				Status:            int32(template.Status),
+			},
+		})
+	}
+
+	result := &dto.CouponRuleDryRunResultDTO{Passed: true}
+	for _, r := range engine.Evaluate(calcCtx) {
+		if !r.Passed {
+			result.Passed = false
+		}
+		result.Results = append(result.Results, &dto.CouponRuleResultDTO{
+			Rule:   r.Rule,
+			Passed: r.Passed,
+			Reason: r.Reason,
+		})
+	}
+	return result, nil
+}
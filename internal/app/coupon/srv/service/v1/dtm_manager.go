@@ -38,15 +38,15 @@ func NewCouponDTMManager(dtmOpts *options.DtmOptions, service *Service) *CouponD
 
 // SubmitOrderWithCoupons 提交订单使用优惠券的分布式事务（简化版 - 记录日志）
 func (dm *CouponDTMManager) SubmitOrderWithCoupons(ctx context.Context, req *OrderCouponSubmissionRequest) error {
-	log.Infof("开始订单-优惠券分布式事务, 订单号: %s", req.OrderSn)
+	log.InfofC(ctx, "开始订单-优惠券分布式事务, 订单号: %s", req.OrderSn)
 
 	// 简化版：仅记录事务步骤，不实际调用DTM
 	// 在生产环境中，这里会实际调用DTM Saga事务
 	
-	log.Infof("步骤1: 使用优惠券 - 用户ID: %d, 优惠券数量: %d", req.UserID, len(req.CouponIDs))
-	log.Infof("步骤2: 创建订单 - 订单号: %s, 金额: %.2f", req.OrderSn, req.FinalAmount)
-	log.Infof("步骤3: 创建支付单 - 支付方式: %d", req.PaymentMethod)
-	log.Infof("步骤4: 预留库存 - 商品数量: %d", len(req.GoodsDetails))
+	log.InfofC(ctx, "步骤1: 使用优惠券 - 用户ID: %d, 优惠券数量: %d", req.UserID, len(req.CouponIDs))
+	log.InfofC(ctx, "步骤2: 创建订单 - 订单号: %s, 金额: %.2f", req.OrderSn, req.FinalAmount)
+	log.InfofC(ctx, "步骤3: 创建支付单 - 支付方式: %d", req.PaymentMethod)
+	log.InfofC(ctx, "步骤4: 预留库存 - 商品数量: %d", len(req.GoodsDetails))
 
 	// 模拟实际调用优惠券使用
 	useCouponDTO := &dto.UseCouponsDTO{
@@ -58,17 +58,17 @@ func (dm *CouponDTMManager) SubmitOrderWithCoupons(ctx context.Context, req *Ord
 	
 	_, err := dm.service.CouponSrv.UseCoupons(ctx, useCouponDTO)
 	if err != nil {
-		log.Errorf("使用优惠券失败: %v", err)
+		log.ErrorfC(ctx, "使用优惠券失败: %v", err)
 		return fmt.Errorf("使用优惠券失败: %w", err)
 	}
 
-	log.Infof("订单-优惠券分布式事务模拟成功, 订单号: %s", req.OrderSn)
+	log.InfofC(ctx, "订单-优惠券分布式事务模拟成功, 订单号: %s", req.OrderSn)
 	return nil
 }
 
 // ProcessFlashSaleWithInventory 秒杀优惠券与库存协调的分布式事务（简化版）
 func (dm *CouponDTMManager) ProcessFlashSaleWithInventory(ctx context.Context, req *FlashSaleInventoryRequest) error {
-	log.Infof("开始秒杀-库存分布式事务, 用户: %d, 秒杀ID: %d", req.UserID, req.FlashSaleID)
+	log.InfofC(ctx, "开始秒杀-库存分布式事务, 用户: %d, 秒杀ID: %d", req.UserID, req.FlashSaleID)
 
 	if dm.service.AsyncFlashSaleEnabled() && dm.service.FlashSaleCore != nil {
 		coreReq := &dto.FlashSaleRequestDTO{
@@ -77,7 +77,7 @@ func (dm *CouponDTMManager) ProcessFlashSaleWithInventory(ctx context.Context, r
 		}
 		coreResult, err := dm.service.FlashSaleCore.FlashSaleCoupon(ctx, coreReq)
 		if err != nil {
-			log.Errorf("异步秒杀预扣失败: %v", err)
+			log.ErrorfC(ctx, "异步秒杀预扣失败: %v", err)
 			return fmt.Errorf("秒杀参与失败: %w", err)
 		}
 		if coreResult == nil || !coreResult.Success {
@@ -85,9 +85,9 @@ func (dm *CouponDTMManager) ProcessFlashSaleWithInventory(ctx context.Context, r
 			if coreResult != nil {
 				failReason = coreResult.Message
 			}
-			return fmt.Errorf(failReason)
+			return fmt.Errorf("%s", failReason)
 		}
-		log.Infof("秒杀-库存分布式事务异步预扣成功, 用户: %d", req.UserID)
+		log.InfofC(ctx, "秒杀-库存分布式事务异步预扣成功, 用户: %d", req.UserID)
 		return nil
 	}
 
@@ -98,7 +98,7 @@ func (dm *CouponDTMManager) ProcessFlashSaleWithInventory(ctx context.Context, r
 
 	result, err := dm.service.FlashSaleSrv.ParticipateFlashSale(ctx, flashSaleDTO)
 	if err != nil {
-		log.Errorf("秒杀参与失败: %v", err)
+		log.ErrorfC(ctx, "秒杀参与失败: %v", err)
 		return fmt.Errorf("秒杀参与失败: %w", err)
 	}
 
@@ -110,13 +110,13 @@ func (dm *CouponDTMManager) ProcessFlashSaleWithInventory(ctx context.Context, r
 		return fmt.Errorf("秒杀失败: %s", failReason)
 	}
 
-	log.Infof("秒杀-库存分布式事务模拟成功, 用户: %d", req.UserID)
+	log.InfofC(ctx, "秒杀-库存分布式事务模拟成功, 用户: %d", req.UserID)
 	return nil
 }
 
 // TryFlashSale TCC Try阶段：预占秒杀优惠券
 func (dm *CouponDTMManager) TryFlashSale(ctx context.Context, req *dto.ParticipateFlashSaleDTO) (*emptypb.Empty, error) {
-	log.Infof("TCC Try: 预占秒杀优惠券, 用户: %d, 秒杀ID: %d", req.UserID, req.FlashSaleID)
+	log.InfofC(ctx, "TCC Try: 预占秒杀优惠券, 用户: %d, 秒杀ID: %d", req.UserID, req.FlashSaleID)
 
 	if dm.service.AsyncFlashSaleEnabled() && dm.service.FlashSaleCore != nil {
 		coreReq := &dto.FlashSaleRequestDTO{
@@ -125,7 +125,7 @@ func (dm *CouponDTMManager) TryFlashSale(ctx context.Context, req *dto.Participa
 		}
 		coreResult, err := dm.service.FlashSaleCore.FlashSaleCoupon(ctx, coreReq)
 		if err != nil {
-			log.Errorf("TCC Try 异步预占失败: %v", err)
+			log.ErrorfC(ctx, "TCC Try 异步预占失败: %v", err)
 			return nil, status.Errorf(codes.Aborted, "%s", err.Error())
 		}
 		if coreResult == nil || !coreResult.Success {
@@ -140,7 +140,7 @@ func (dm *CouponDTMManager) TryFlashSale(ctx context.Context, req *dto.Participa
 
 	result, err := dm.service.FlashSaleSrv.ParticipateFlashSale(ctx, req)
 	if err != nil {
-		log.Errorf("TCC Try 预占秒杀失败: %v", err)
+		log.ErrorfC(ctx, "TCC Try 预占秒杀失败: %v", err)
 		return nil, status.Errorf(codes.Aborted, "%s", err.Error())
 	}
 
@@ -157,23 +157,23 @@ func (dm *CouponDTMManager) TryFlashSale(ctx context.Context, req *dto.Participa
 
 // ConfirmFlashSale TCC Confirm阶段：确认秒杀优惠券扣减
 func (dm *CouponDTMManager) ConfirmFlashSale(ctx context.Context, req *dto.ParticipateFlashSaleDTO) (*emptypb.Empty, error) {
-	log.Infof("TCC Confirm: 确认秒杀优惠券扣减, 用户: %d, 秒杀ID: %d", req.UserID, req.FlashSaleID)
+	log.InfofC(ctx, "TCC Confirm: 确认秒杀优惠券扣减, 用户: %d, 秒杀ID: %d", req.UserID, req.FlashSaleID)
 	// 确认操作（在Try阶段已经完成了实际扣减）
-	log.Infof("秒杀优惠券确认成功")
+	log.InfofC(ctx, "秒杀优惠券确认成功")
 	return &emptypb.Empty{}, nil
 }
 
 // CancelFlashSale TCC Cancel阶段：取消秒杀优惠券预占
 func (dm *CouponDTMManager) CancelFlashSale(ctx context.Context, req *dto.ParticipateFlashSaleDTO) (*emptypb.Empty, error) {
-	log.Infof("TCC Cancel: 取消秒杀优惠券预占, 用户: %d, 秒杀ID: %d", req.UserID, req.FlashSaleID)
+	log.InfofC(ctx, "TCC Cancel: 取消秒杀优惠券预占, 用户: %d, 秒杀ID: %d", req.UserID, req.FlashSaleID)
 	// 补偿逻辑（如果需要）
-	log.Infof("秒杀优惠券预占已释放")
+	log.InfofC(ctx, "秒杀优惠券预占已释放")
 	return &emptypb.Empty{}, nil
 }
 
 // GetTransactionStatus 获取分布式事务状态
 func (dm *CouponDTMManager) GetTransactionStatus(ctx context.Context, gid string) (*TransactionStatus, error) {
-	log.Infof("查询事务状态, GID: %s", gid)
+	log.InfofC(ctx, "查询事务状态, GID: %s", gid)
 	
 	// 模拟返回事务状态
 	return &TransactionStatus{
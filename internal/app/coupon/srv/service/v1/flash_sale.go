@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"emshop/internal/app/coupon/srv/data/v1/interfaces"
+	stockredis "emshop/internal/app/coupon/srv/data/v1/redis"
 	"emshop/internal/app/coupon/srv/domain/do"
 	"emshop/internal/app/coupon/srv/domain/dto"
 	"emshop/internal/app/coupon/srv/pkg/cache"
@@ -35,43 +36,80 @@ type flashSaleService struct {
 	data         interfaces.DataFactory
 	redisClient  *redis.Client
 	keyFormatter *scripts.RedisKeyFormatter
+	stockManager *stockredis.StockManager
 	cacheManager interface {
 		GetFlashSaleActivity(ctx context.Context, activityID int64) (*cache.FlashSaleActivity, error)
 		InvalidateCache(keys ...string)
 	}
+	logger *log.Logger
 }
 
-// NewFlashSaleService 创建秒杀服务实例
+// newFlashSaleLogger 秒杀请求路径的日志，每秒每个级别输出前100条，之后每100条输出一条，
+// 通过日志管理接口可以单独修改 flashsale 的日志级别
+func newFlashSaleLogger() *log.Logger {
+	return log.Named("flashsale").Sampled(time.Second, 100, 100)
+}
+
+// NewFlashSaleService 创建秒杀服务实例，stockShards为新活动库存的分片数
 func NewFlashSaleService(data interfaces.DataFactory, redisClient *redis.Client, cacheManager interface {
 	GetFlashSaleActivity(ctx context.Context, activityID int64) (*cache.FlashSaleActivity, error)
 	InvalidateCache(keys ...string)
-}) FlashSaleSrv {
+}, stockShards int) FlashSaleSrv {
+	return newFlashSaleService(data, redisClient, cacheManager, stockShards)
+}
+
+// newFlashSaleService 创建秒杀服务，返回具体类型供商品秒杀复用库存操作
+func newFlashSaleService(data interfaces.DataFactory, redisClient *redis.Client, cacheManager interface {
+	GetFlashSaleActivity(ctx context.Context, activityID int64) (*cache.FlashSaleActivity, error)
+	InvalidateCache(keys ...string)
+}, stockShards int) *flashSaleService {
 	return &flashSaleService{
 		data:         data,
 		redisClient:  redisClient,
 		keyFormatter: scripts.NewRedisKeyFormatter(),
+		stockManager: stockredis.NewShardedStockManager(redisClient, stockShards),
 		cacheManager: cacheManager,
+		logger:       newFlashSaleLogger(),
 	}
 }
 
 // CreateFlashSaleActivity 创建秒杀活动
 func (fss *flashSaleService) CreateFlashSaleActivity(ctx context.Context, req *dto.CreateFlashSaleActivityDTO) (*dto.FlashSaleActivityDTO, error) {
-	log.Infof("创建秒杀活动: %s", req.Name)
+	log.InfofC(ctx, "创建秒杀活动: %s", req.Name)
 	
 	// 验证时间范围
 	if req.EndTime.Before(req.StartTime) {
 		return nil, errors.WithCode(code.ErrInvalidRequest, "结束时间不能早于开始时间")
 	}
 	
-	// 验证优惠券模板存在
-	templateDO, err := fss.data.CouponTemplates().Get(ctx, fss.data.DB(), req.CouponTemplateID)
-	if err != nil || templateDO == nil {
-		return nil, errors.WithCode(code.ErrResourceNotFound, "优惠券模板不存在")
+	activityType := do.FlashSaleType(req.ActivityType)
+	if activityType == 0 {
+		activityType = do.FlashSaleTypeCoupon
+	}
+	
+	var templateDO *do.CouponTemplateDO
+	if activityType == do.FlashSaleTypeGoods {
+		// 商品秒杀不关联优惠券，以秒杀价下单
+		if req.GoodsID <= 0 || req.SalePrice <= 0 {
+			return nil, errors.WithCode(code.ErrInvalidRequest, "商品秒杀需要指定商品和秒杀价")
+		}
+		req.CouponTemplateID = 0
+	} else {
+		// 验证优惠券模板存在
+		var err error
+		templateDO, err = fss.data.CouponTemplates().Get(ctx, fss.data.DB(), req.CouponTemplateID)
+		if err != nil || templateDO == nil {
+			return nil, errors.WithCode(code.ErrResourceNotFound, "优惠券模板不存在")
+		}
+		req.GoodsID, req.SalePrice = 0, 0
 	}
 	
 	// 构建DO对象
 	activityDO := &do.FlashSaleActivityDO{
+		ActivityType:     activityType,
 		CouponTemplateID: req.CouponTemplateID,
+		GoodsID:          req.GoodsID,
+		SalePrice:        req.SalePrice,
 		Name:             req.Name,
 		StartTime:        req.StartTime,
 		EndTime:          req.EndTime,
@@ -84,7 +122,7 @@ func (fss *flashSaleService) CreateFlashSaleActivity(ctx context.Context, req *d
 	
 	// 保存到数据库
 	if err := fss.data.FlashSales().Create(ctx, fss.data.DB(), activityDO); err != nil {
-		log.Errorf("创建秒杀活动失败: %v", err)
+		log.ErrorfC(ctx, "创建秒杀活动失败: %v", err)
 		return nil, errors.WithCode(code.ErrDatabase, "创建秒杀活动失败")
 	}
 	
@@ -92,7 +130,7 @@ func (fss *flashSaleService) CreateFlashSaleActivity(ctx context.Context, req *d
 	currentTime := time.Now()
 	if req.StartTime.Before(currentTime) || req.StartTime.Sub(currentTime) < time.Minute*10 {
 		if err := fss.initFlashSaleRedis(ctx, activityDO); err != nil {
-			log.Warnf("初始化秒杀活动Redis失败: %v", err)
+			log.WarnfC(ctx, "初始化秒杀活动Redis失败: %v", err)
 		}
 	}
 	
@@ -104,7 +142,7 @@ func (fss *flashSaleService) CreateFlashSaleActivity(ctx context.Context, req *d
 func (fss *flashSaleService) GetFlashSaleActivity(ctx context.Context, id int64) (*dto.FlashSaleActivityDTO, error) {
 	activityDO, err := fss.data.FlashSales().Get(ctx, fss.data.DB(), id)
 	if err != nil {
-		log.Errorf("获取秒杀活动失败: %v", err)
+		log.ErrorfC(ctx, "获取秒杀活动失败: %v", err)
 		return nil, errors.WithCode(code.ErrDatabase, "获取秒杀活动失败")
 	}
 	
@@ -112,10 +150,14 @@ func (fss *flashSaleService) GetFlashSaleActivity(ctx context.Context, id int64)
 		return nil, errors.WithCode(code.ErrResourceNotFound, "秒杀活动不存在")
 	}
 	
+	if activityDO.IsGoods() {
+		return fss.convertFlashSaleToDTO(activityDO, nil), nil
+	}
+	
 	// 获取关联的优惠券模板
 	templateDO, err := fss.data.CouponTemplates().Get(ctx, fss.data.DB(), activityDO.CouponTemplateID)
 	if err != nil {
-		log.Warnf("获取秒杀活动关联的优惠券模板失败: %v", err)
+		log.WarnfC(ctx, "获取秒杀活动关联的优惠券模板失败: %v", err)
 	}
 	
 	return fss.convertFlashSaleToDTO(activityDO, templateDO), nil
@@ -135,7 +177,7 @@ func (fss *flashSaleService) ListFlashSaleActivities(ctx context.Context, req *d
 	
 	activityListDO, err := fss.data.FlashSales().List(ctx, fss.data.DB(), status, meta, []string{"sort_order DESC", "start_time DESC"})
 	if err != nil {
-		log.Errorf("获取秒杀活动列表失败: %v", err)
+		log.ErrorfC(ctx, "获取秒杀活动列表失败: %v", err)
 		return nil, errors.WithCode(code.ErrDatabase, "获取秒杀活动列表失败")
 	}
 	
@@ -157,7 +199,7 @@ func (fss *flashSaleService) GetActiveFlashSales(ctx context.Context) (*dto.Flas
 	
 	activityDOs, err := fss.data.FlashSales().GetActiveActivities(ctx, fss.data.DB(), currentTime)
 	if err != nil {
-		log.Errorf("获取进行中的秒杀活动失败: %v", err)
+		log.ErrorfC(ctx, "获取进行中的秒杀活动失败: %v", err)
 		return nil, errors.WithCode(code.ErrDatabase, "获取进行中的秒杀活动失败")
 	}
 	
@@ -166,7 +208,7 @@ func (fss *flashSaleService) GetActiveFlashSales(ctx context.Context) (*dto.Flas
 	for _, activityDO := range activityDOs {
 		// 确保Redis状态已初始化
 		if err := fss.initFlashSaleRedis(ctx, activityDO); err != nil {
-			log.Warnf("初始化秒杀活动Redis失败: %v", err)
+			log.WarnfC(ctx, "初始化秒杀活动Redis失败: %v", err)
 		}
 		items = append(items, fss.convertFlashSaleToDTO(activityDO, nil))
 	}
@@ -179,50 +221,38 @@ func (fss *flashSaleService) GetActiveFlashSales(ctx context.Context) (*dto.Flas
 
 // ParticipateFlashSale 参与秒杀
 func (fss *flashSaleService) ParticipateFlashSale(ctx context.Context, req *dto.ParticipateFlashSaleDTO) (*dto.ParticipateFlashSaleResultDTO, error) {
-	log.Infof("用户参与秒杀: userID=%d, flashSaleID=%d", req.UserID, req.FlashSaleID)
+	fss.logger.InfofContext(ctx, "用户参与秒杀: userID=%d, flashSaleID=%d", req.UserID, req.FlashSaleID)
 	
 	// 获取秒杀活动
 	activityDO, err := fss.data.FlashSales().Get(ctx, fss.data.DB(), req.FlashSaleID)
 	if err != nil || activityDO == nil {
 		return nil, errors.WithCode(code.ErrResourceNotFound, "秒杀活动不存在")
 	}
+	if activityDO.IsGoods() {
+		return nil, errors.WithCode(code.ErrInvalidRequest, "商品秒杀请通过抢购下单参与")
+	}
 	
 	currentTime := time.Now()
 	
 	// 确保Redis状态已初始化
 	if err := fss.initFlashSaleRedis(ctx, activityDO); err != nil {
-		log.Errorf("初始化秒杀活动Redis失败: %v", err)
+		log.ErrorfC(ctx, "初始化秒杀活动Redis失败: %v", err)
 		return &dto.ParticipateFlashSaleResultDTO{
 			Status:     2,
 			FailReason: stringPtr("系统错误，请稍后重试"),
 		}, nil
 	}
 	
-	// 使用Redis Lua脚本进行原子性秒杀
-	stockKey := fss.keyFormatter.FlashSaleStockKey(req.FlashSaleID)
-	userLimitKey := fss.keyFormatter.FlashSaleUserLimitKey(req.FlashSaleID, req.UserID)
-	statusKey := fss.keyFormatter.FlashSaleStatusKey(req.FlashSaleID)
-	
-	result, err := fss.redisClient.Eval(ctx, scripts.FlashSaleLua, 
-		[]string{stockKey, userLimitKey, statusKey},
-		req.UserID, 
-		currentTime.Unix(),
-		activityDO.StartTime.Unix(),
-		activityDO.EndTime.Unix(),
-		activityDO.PerUserLimit,
-		int(do.FlashSaleStatusActive),
-	).Result()
-	
+	// 原子性扣减库存
+	flashSaleResult, err := fss.deductFlashSaleStock(ctx, activityDO, req.UserID, currentTime)
 	if err != nil {
-		log.Errorf("执行秒杀Lua脚本失败: %v", err)
+		log.ErrorfC(ctx, "执行秒杀Lua脚本失败: %v", err)
 		return &dto.ParticipateFlashSaleResultDTO{
 			Status:     2,
 			FailReason: stringPtr("系统繁忙，请稍后重试"),
 		}, nil
 	}
 	
-	flashSaleResult := result.(int64)
-	
 	// 秒杀失败
 	if flashSaleResult != scripts.FlashSaleSuccess {
 		reason := scripts.GetFlashSaleResultMessage(flashSaleResult)
@@ -248,7 +278,7 @@ func (fss *flashSaleService) ParticipateFlashSale(ctx context.Context, req *dto.
 		// 回滚Redis状态
 		fss.rollbackFlashSaleRedis(ctx, req.FlashSaleID, req.UserID)
 		
-		log.Errorf("创建秒杀记录失败: %v", err)
+		log.ErrorfC(ctx, "创建秒杀记录失败: %v", err)
 		return &dto.ParticipateFlashSaleResultDTO{
 			Status:     2,
 			FailReason: stringPtr("创建记录失败"),
@@ -261,7 +291,7 @@ func (fss *flashSaleService) ParticipateFlashSale(ctx context.Context, req *dto.
 		tx.Rollback()
 		fss.rollbackFlashSaleRedis(ctx, req.FlashSaleID, req.UserID)
 		
-		log.Errorf("获取优惠券模板失败: %v", err)
+		log.ErrorfC(ctx, "获取优惠券模板失败: %v", err)
 		return &dto.ParticipateFlashSaleResultDTO{
 			Status:     2,
 			FailReason: stringPtr("优惠券模板不存在"),
@@ -287,7 +317,7 @@ func (fss *flashSaleService) ParticipateFlashSale(ctx context.Context, req *dto.
 		tx.Rollback()
 		fss.rollbackFlashSaleRedis(ctx, req.FlashSaleID, req.UserID)
 		
-		log.Errorf("创建用户优惠券失败: %v", err)
+		log.ErrorfC(ctx, "创建用户优惠券失败: %v", err)
 		return &dto.ParticipateFlashSaleResultDTO{
 			Status:     2,
 			FailReason: stringPtr("创建优惠券失败"),
@@ -297,16 +327,16 @@ func (fss *flashSaleService) ParticipateFlashSale(ctx context.Context, req *dto.
 	// 更新秒杀记录的优惠券ID
 	recordDO.UserCouponID = &userCouponDO.ID
 	if err := fss.data.FlashSaleRecords().UpdateUserCouponID(ctx, tx, recordDO.ID, userCouponDO.ID); err != nil {
-		log.Warnf("更新秒杀记录优惠券ID失败: %v", err)
+		log.WarnfC(ctx, "更新秒杀记录优惠券ID失败: %v", err)
 	}
 	
 	// 更新活动已售数量
 	if err := fss.data.FlashSales().IncrementSoldCount(ctx, tx, req.FlashSaleID); err != nil {
-		log.Warnf("更新秒杀活动已售数量失败: %v", err)
+		log.WarnfC(ctx, "更新秒杀活动已售数量失败: %v", err)
 	}
 	
 	if err := tx.Commit().Error; err != nil {
-		log.Errorf("提交秒杀事务失败: %v", err)
+		log.ErrorfC(ctx, "提交秒杀事务失败: %v", err)
 		fss.rollbackFlashSaleRedis(ctx, req.FlashSaleID, req.UserID)
 		
 		return &dto.ParticipateFlashSaleResultDTO{
@@ -321,14 +351,14 @@ func (fss *flashSaleService) ParticipateFlashSale(ctx context.Context, req *dto.
 	}, nil
 }
 
-// GetFlashSaleStock 获取秒杀库存
+// GetFlashSaleStock 获取秒杀库存，分片库存返回各分片之和
 func (fss *flashSaleService) GetFlashSaleStock(ctx context.Context, flashSaleID int64) (*dto.FlashSaleStockDTO, error) {
 	// 先从Redis获取
 	stockKey := fss.keyFormatter.FlashSaleStockKey(flashSaleID)
-	stockInt, err := fss.redisClient.Get(ctx, stockKey).Int()
+	stock, found, err := fss.stockManager.SumStock(ctx, stockKey)
 	
 	var remainingStock int32
-	if err == redis.Nil {
+	if err == nil && !found {
 		// Redis中没有，从数据库获取
 		stockInfo, err := fss.data.FlashSales().CheckStock(ctx, fss.data.DB(), flashSaleID)
 		if err != nil {
@@ -339,7 +369,7 @@ func (fss *flashSaleService) GetFlashSaleStock(ctx context.Context, flashSaleID
 		return nil, errors.WithCode(code.ErrRedis, "获取Redis库存失败")
 	} else {
 		// 从Redis获取库存
-		remainingStock = int32(stockInt)
+		remainingStock = int32(stock)
 	}
 	
 	// 获取活动信息
@@ -365,7 +395,7 @@ func (fss *flashSaleService) GetUserFlashSaleRecords(ctx context.Context, req *d
 	
 	recordListDO, err := fss.data.FlashSaleRecords().GetUserRecords(ctx, fss.data.DB(), req.UserID, meta)
 	if err != nil {
-		log.Errorf("获取用户秒杀记录失败: %v", err)
+		log.ErrorfC(ctx, "获取用户秒杀记录失败: %v", err)
 		return nil, errors.WithCode(code.ErrDatabase, "获取用户秒杀记录失败")
 	}
 	
@@ -389,6 +419,18 @@ func (fss *flashSaleService) initFlashSaleRedis(ctx context.Context, activityDO
 	currentTime := time.Now()
 	remainingStock := activityDO.FlashSaleCount - activityDO.SoldCount
 	
+	if fss.stockManager.ShardCount() > 1 {
+		ttl := activityDO.EndTime.Sub(currentTime) + time.Hour
+		if ttl < time.Hour {
+			ttl = time.Hour
+		}
+		initialized, err := fss.stockManager.InitShardedStock(ctx, stockKey, int64(remainingStock), ttl)
+		if err != nil || !initialized {
+			return err
+		}
+		return fss.redisClient.Set(ctx, statusKey, int(do.FlashSaleStatusActive), ttl).Err()
+	}
+	
 	_, err := fss.redisClient.Eval(ctx, scripts.InitFlashSaleLua,
 		[]string{stockKey, statusKey},
 		activityDO.ID,
@@ -406,13 +448,76 @@ func (fss *flashSaleService) rollbackFlashSaleRedis(ctx context.Context, flashSa
 	stockKey := fss.keyFormatter.FlashSaleStockKey(flashSaleID)
 	userLimitKey := fss.keyFormatter.FlashSaleUserLimitKey(flashSaleID, userID)
 	
-	_, err := fss.redisClient.Eval(ctx, scripts.RollbackFlashSaleLua,
-		[]string{stockKey, userLimitKey},
-		userID,
-	).Result()
+	shards, err := fss.stockManager.StockShards(ctx, stockKey)
+	if err == nil && shards > 1 {
+		_, err = fss.stockManager.RestoreShardedStock(ctx, stockKey, userLimitKey, userID, 1, time.Hour, shards)
+	} else if err == nil {
+		_, err = fss.redisClient.Eval(ctx, scripts.RollbackFlashSaleLua,
+			[]string{stockKey, userLimitKey},
+			userID,
+		).Result()
+	}
 	
 	if err != nil {
-		log.Errorf("回滚秒杀Redis状态失败: %v", err)
+		log.ErrorfC(ctx, "回滚秒杀Redis状态失败: %v", err)
+	}
+}
+
+// deductFlashSaleStock 扣减秒杀库存，返回scripts中的秒杀脚本返回码
+func (fss *flashSaleService) deductFlashSaleStock(ctx context.Context, activityDO *do.FlashSaleActivityDO, userID int64, now time.Time) (int64, error) {
+	stockKey := fss.keyFormatter.FlashSaleStockKey(activityDO.ID)
+	userLimitKey := fss.keyFormatter.FlashSaleUserLimitKey(activityDO.ID, userID)
+	statusKey := fss.keyFormatter.FlashSaleStatusKey(activityDO.ID)
+
+	shards, err := fss.stockManager.StockShards(ctx, stockKey)
+	if err != nil {
+		return 0, err
+	}
+	if shards <= 1 {
+		return fss.redisClient.Eval(ctx, scripts.FlashSaleLua,
+			[]string{stockKey, userLimitKey, statusKey},
+			userID,
+			now.Unix(),
+			activityDO.StartTime.Unix(),
+			activityDO.EndTime.Unix(),
+			activityDO.PerUserLimit,
+			int(do.FlashSaleStatusActive),
+		).Int64()
+	}
+
+	// 分片库存：活动时间与状态先行校验，个人限购与库存扣减由分片脚本保证原子性
+	if now.Before(activityDO.StartTime) {
+		return scripts.FlashSaleNotStarted, nil
+	}
+	if now.After(activityDO.EndTime) {
+		return scripts.FlashSaleEnded, nil
+	}
+	status, err := fss.redisClient.Get(ctx, statusKey).Int()
+	if err != nil && err != redis.Nil {
+		return 0, err
+	}
+	if err == redis.Nil || status != int(do.FlashSaleStatusActive) {
+		return scripts.FlashSaleInactive, nil
+	}
+
+	deduct, err := fss.stockManager.DeductShardedStock(ctx, &stockredis.StockDeduction{
+		StockKey:     stockKey,
+		UserKey:      userLimitKey,
+		UserID:       userID,
+		Count:        1,
+		PerUserLimit: int64(activityDO.PerUserLimit),
+		UserKeyTTL:   activityDO.EndTime.Sub(now) + time.Hour,
+	}, shards)
+	if err != nil {
+		return 0, err
+	}
+	switch deduct.Status {
+	case stockredis.DeductSuccess:
+		return scripts.FlashSaleSuccess, nil
+	case stockredis.DeductUserLimitExceeded:
+		return scripts.FlashSaleUserLimitExceed, nil
+	default:
+		return scripts.FlashSaleOutOfStock, nil
 	}
 }
 
@@ -420,7 +525,10 @@ func (fss *flashSaleService) rollbackFlashSaleRedis(ctx context.Context, flashSa
 func (fss *flashSaleService) convertFlashSaleToDTO(activityDO *do.FlashSaleActivityDO, templateDO *do.CouponTemplateDO) *dto.FlashSaleActivityDTO {
 	result := &dto.FlashSaleActivityDTO{
 		ID:               activityDO.ID,
+		ActivityType:     int32(activityDO.ActivityType),
 		CouponTemplateID: activityDO.CouponTemplateID,
+		GoodsID:          activityDO.GoodsID,
+		SalePrice:        activityDO.SalePrice,
 		Name:             activityDO.Name,
 		StartTime:        activityDO.StartTime,
 		EndTime:          activityDO.EndTime,
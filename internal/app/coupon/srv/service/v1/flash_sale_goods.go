@@ -0,0 +1,272 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"emshop/internal/app/coupon/srv/config"
+	"emshop/internal/app/coupon/srv/data/v1/interfaces"
+	"emshop/internal/app/coupon/srv/domain/do"
+	"emshop/internal/app/coupon/srv/domain/dto"
+	"emshop/internal/app/coupon/srv/pkg/scripts"
+	"emshop/internal/app/pkg/code"
+	"emshop/pkg/delayjob"
+	"emshop/pkg/errors"
+	"emshop/pkg/log"
+)
+
+// JobTypeFlashSaleSettle 结算到期的商品秒杀预占单
+const JobTypeFlashSaleSettle = "flashsale.settle"
+
+// settleBatchSize 每次扫描结算的预占单数量
+const settleBatchSize = 200
+
+// FlashSaleOrderCloser 关闭超时未支付的秒杀订单，订单已支付时返回paid=true，由订单服务实现
+type FlashSaleOrderCloser interface {
+	CloseFlashSaleOrder(ctx context.Context, orderSn string) (paid bool, err error)
+}
+
+// FlashSaleGoodsSrv 商品秒杀服务
+//
+// 秒杀名额复用秒杀活动的Redis库存预扣，抢到名额即生成预占单，预占单号作为订单号由网关创建订单。
+// 预占单在支付时限后结算：订单已支付则名额成交并累加已售数量，否则关闭订单并把名额归还到Redis库存。
+type FlashSaleGoodsSrv interface {
+	// Reserve 抢购名额，成功后返回预占单
+	Reserve(ctx context.Context, flashSaleID, userID int64) (*dto.FlashSaleReservationDTO, error)
+	// Settle 按订单支付状态确认或归还名额，已结算的预占单直接返回
+	Settle(ctx context.Context, orderSn string) (*dto.FlashSaleReservationDTO, error)
+	// SettleExpired 结算now之前到期的预占单
+	SettleExpired(ctx context.Context, now time.Time) (int, error)
+	// RegisterJobs 注册到期预占单结算任务
+	RegisterJobs(scheduler *delayjob.Scheduler)
+}
+
+// flashSaleStock 商品秒杀复用的秒杀活动Redis库存操作，由flashSaleService实现
+type flashSaleStock interface {
+	initFlashSaleRedis(ctx context.Context, activityDO *do.FlashSaleActivityDO) error
+	deductFlashSaleStock(ctx context.Context, activityDO *do.FlashSaleActivityDO, userID int64, now time.Time) (int64, error)
+	rollbackFlashSaleRedis(ctx context.Context, flashSaleID, userID int64)
+}
+
+type flashSaleGoodsService struct {
+	data      interfaces.DataFactory
+	flashSale flashSaleStock
+	orders    FlashSaleOrderCloser
+	opts      *config.FlashSaleOptions
+	logger    *log.Logger
+}
+
+// NewFlashSaleGoodsService 创建商品秒杀服务，orders为nil时到期预占单只能在订单服务可用后结算
+func NewFlashSaleGoodsService(data interfaces.DataFactory, flashSale flashSaleStock, orders FlashSaleOrderCloser, opts *config.FlashSaleOptions) FlashSaleGoodsSrv {
+	if opts == nil {
+		opts = &config.FlashSaleOptions{}
+	}
+	return &flashSaleGoodsService{
+		data:      data,
+		flashSale: flashSale,
+		orders:    orders,
+		opts:      opts,
+		logger:    newFlashSaleLogger(),
+	}
+}
+
+// RegisterJobs 注册到期预占单结算任务，SettleInterval为0时不启用
+func (fgs *flashSaleGoodsService) RegisterJobs(scheduler *delayjob.Scheduler) {
+	if fgs.opts.SettleInterval <= 0 {
+		return
+	}
+	scheduler.Every(JobTypeFlashSaleSettle, fgs.opts.SettleInterval, func(ctx context.Context, job *delayjob.Job) error {
+		_, err := fgs.SettleExpired(ctx, time.Now())
+		return err
+	})
+}
+
+// Reserve 抢购名额，成功后返回预占单
+func (fgs *flashSaleGoodsService) Reserve(ctx context.Context, flashSaleID, userID int64) (*dto.FlashSaleReservationDTO, error) {
+	activity, err := fgs.data.FlashSales().Get(ctx, fgs.data.DB(), flashSaleID)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, "获取秒杀活动失败")
+	}
+	if activity == nil {
+		return nil, errors.WithCode(code.ErrFlashSaleNotFound, "秒杀活动不存在")
+	}
+	if !activity.IsGoods() {
+		return nil, errors.WithCode(code.ErrInvalidRequest, "该活动不是商品秒杀")
+	}
+	if activity.Status == do.FlashSaleStatusFinished || activity.Status == do.FlashSaleStatusPaused {
+		return nil, errors.WithCode(code.ErrFlashSaleNotActive, "秒杀活动已结束或已暂停")
+	}
+
+	now := time.Now()
+	if err := fgs.flashSale.initFlashSaleRedis(ctx, activity); err != nil {
+		log.ErrorfC(ctx, "初始化秒杀活动Redis失败: flashSaleID=%d, err=%v", flashSaleID, err)
+		return nil, errors.WithCode(code.ErrRedis, "系统繁忙，请稍后重试")
+	}
+	result, err := fgs.flashSale.deductFlashSaleStock(ctx, activity, userID, now)
+	if err != nil {
+		log.ErrorfC(ctx, "预扣商品秒杀名额失败: flashSaleID=%d, userID=%d, err=%v", flashSaleID, userID, err)
+		return nil, errors.WithCode(code.ErrRedis, "系统繁忙，请稍后重试")
+	}
+	if result != scripts.FlashSaleSuccess {
+		return nil, flashSaleResultError(result)
+	}
+
+	reservation := &do.FlashSaleReservationDO{
+		OrderSn:     generateReservationSn(userID, now),
+		FlashSaleID: activity.ID,
+		UserID:      userID,
+		GoodsID:     activity.GoodsID,
+		Quantity:    1,
+		SalePrice:   activity.SalePrice,
+		Status:      do.FlashSaleReservationReserved,
+		ExpiresAt:   now.Add(fgs.payWindow()),
+	}
+	if err := fgs.data.FlashSaleReservations().Create(ctx, fgs.data.DB(), reservation); err != nil {
+		fgs.flashSale.rollbackFlashSaleRedis(ctx, activity.ID, userID)
+		return nil, errors.WithCode(code.ErrDatabase, "创建秒杀预占单失败")
+	}
+
+	fgs.logger.InfofContext(ctx, "商品秒杀预占成功: orderSn=%s, flashSaleID=%d, userID=%d, expiresAt=%v",
+		reservation.OrderSn, activity.ID, userID, reservation.ExpiresAt)
+	return convertReservationToDTO(reservation), nil
+}
+
+// Settle 按订单支付状态确认或归还名额
+//
+// 先让订单服务关闭未支付订单，关闭与支付由订单状态的条件更新互斥，因此关闭成功后归还名额不会超卖；
+// 下单失败时网关也通过该方法提前归还名额
+func (fgs *flashSaleGoodsService) Settle(ctx context.Context, orderSn string) (*dto.FlashSaleReservationDTO, error) {
+	reservation, err := fgs.data.FlashSaleReservations().GetByOrderSn(ctx, fgs.data.DB(), orderSn)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, "查询秒杀预占单失败")
+	}
+	if reservation == nil {
+		return nil, errors.WithCode(code.ErrFlashSaleReservationNotFound, "秒杀预占单不存在")
+	}
+	if reservation.Status != do.FlashSaleReservationReserved {
+		return convertReservationToDTO(reservation), nil
+	}
+	if fgs.orders == nil {
+		return nil, errors.WithCode(code.ErrResourceNotAvailable, "订单服务不可用，暂不能结算")
+	}
+
+	paid, err := fgs.orders.CloseFlashSaleOrder(ctx, orderSn)
+	if err != nil {
+		log.ErrorfC(ctx, "关闭秒杀订单失败: orderSn=%s, err=%v", orderSn, err)
+		return nil, errors.WithCode(code.ErrResourceNotAvailable, "关闭秒杀订单失败")
+	}
+
+	if paid {
+		err = fgs.confirm(ctx, reservation)
+	} else {
+		err = fgs.release(ctx, reservation)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return convertReservationToDTO(reservation), nil
+}
+
+// SettleExpired 结算now之前到期的预占单，单个失败不影响其余预占单，下个周期重试
+func (fgs *flashSaleGoodsService) SettleExpired(ctx context.Context, now time.Time) (int, error) {
+	reservations, err := fgs.data.FlashSaleReservations().ListExpired(ctx, fgs.data.DB(), now, settleBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	settled := 0
+	for _, r := range reservations {
+		if _, err := fgs.Settle(ctx, r.OrderSn); err != nil {
+			log.WarnfC(ctx, "结算秒杀预占单失败: orderSn=%s, err=%v", r.OrderSn, err)
+			continue
+		}
+		settled++
+	}
+	if settled > 0 {
+		log.InfofC(ctx, "到期秒杀预占单结算完成: %d/%d", settled, len(reservations))
+	}
+	return settled, nil
+}
+
+// confirm 订单已支付，名额成交并累加已售数量
+func (fgs *flashSaleGoodsService) confirm(ctx context.Context, reservation *do.FlashSaleReservationDO) error {
+	tx := fgs.data.Begin()
+	changed, err := fgs.data.FlashSaleReservations().TransitStatus(ctx, tx, reservation.OrderSn,
+		do.FlashSaleReservationReserved, do.FlashSaleReservationConfirmed)
+	if err != nil {
+		tx.Rollback()
+		return errors.WithCode(code.ErrDatabase, "确认秒杀预占单失败")
+	}
+	if changed {
+		if err := fgs.data.FlashSales().UpdateSoldCount(ctx, tx, reservation.FlashSaleID, reservation.Quantity); err != nil {
+			tx.Rollback()
+			return errors.WithCode(code.ErrDatabase, "更新秒杀活动已售数量失败")
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		return errors.WithCode(code.ErrDatabase, "确认秒杀预占单失败")
+	}
+
+	reservation.Status = do.FlashSaleReservationConfirmed
+	log.InfofC(ctx, "秒杀预占单已成交: orderSn=%s", reservation.OrderSn)
+	return nil
+}
+
+// release 订单未支付已关闭，归还名额与个人限购额度
+func (fgs *flashSaleGoodsService) release(ctx context.Context, reservation *do.FlashSaleReservationDO) error {
+	changed, err := fgs.data.FlashSaleReservations().TransitStatus(ctx, fgs.data.DB(), reservation.OrderSn,
+		do.FlashSaleReservationReserved, do.FlashSaleReservationReleased)
+	if err != nil {
+		return errors.WithCode(code.ErrDatabase, "归还秒杀预占单失败")
+	}
+	// 只有完成状态变更的一方归还Redis库存，避免并发结算重复归还
+	if changed {
+		fgs.flashSale.rollbackFlashSaleRedis(ctx, reservation.FlashSaleID, reservation.UserID)
+		log.InfofC(ctx, "秒杀预占单已归还名额: orderSn=%s", reservation.OrderSn)
+	}
+
+	reservation.Status = do.FlashSaleReservationReleased
+	return nil
+}
+
+func (fgs *flashSaleGoodsService) payWindow() time.Duration {
+	if fgs.opts.PayWindow > 0 {
+		return fgs.opts.PayWindow
+	}
+	return 10 * time.Minute
+}
+
+// flashSaleResultError 将秒杀脚本返回码转换为业务错误
+func flashSaleResultError(result int64) error {
+	msg := scripts.GetFlashSaleResultMessage(result)
+	switch result {
+	case scripts.FlashSaleOutOfStock:
+		return errors.WithCode(code.ErrFlashSaleStockEmpty, "%s", msg)
+	case scripts.FlashSaleUserLimitExceed:
+		return errors.WithCode(code.ErrFlashSaleUserLimitExceeded, "%s", msg)
+	default:
+		return errors.WithCode(code.ErrFlashSaleNotActive, "%s", msg)
+	}
+}
+
+// generateReservationSn 生成预占单号，与网关订单号同样以时间和用户ID构成，精确到毫秒
+func generateReservationSn(userID int64, now time.Time) string {
+	return fmt.Sprintf("%s%d%02d",
+		strings.Replace(now.Format("20060102150405.000"), ".", "", 1), userID, rand.Intn(90)+10)
+}
+
+func convertReservationToDTO(r *do.FlashSaleReservationDO) *dto.FlashSaleReservationDTO {
+	return &dto.FlashSaleReservationDTO{
+		OrderSn:     r.OrderSn,
+		FlashSaleID: r.FlashSaleID,
+		UserID:      r.UserID,
+		GoodsID:     r.GoodsID,
+		Quantity:    r.Quantity,
+		SalePrice:   r.SalePrice,
+		Status:      int32(r.Status),
+		ExpiresAt:   r.ExpiresAt,
+	}
+}
@@ -0,0 +1,35 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"emshop/internal/app/coupon/srv/pkg/scripts"
+	"emshop/internal/app/pkg/code"
+	"emshop/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlashSaleResultError(t *testing.T) {
+	cases := map[int64]int{
+		scripts.FlashSaleNotStarted:      code.ErrFlashSaleNotActive,
+		scripts.FlashSaleEnded:           code.ErrFlashSaleNotActive,
+		scripts.FlashSaleInactive:        code.ErrFlashSaleNotActive,
+		scripts.FlashSaleOutOfStock:      code.ErrFlashSaleStockEmpty,
+		scripts.FlashSaleUserLimitExceed: code.ErrFlashSaleUserLimitExceeded,
+	}
+	for result, want := range cases {
+		err := flashSaleResultError(result)
+		assert.True(t, errors.IsCode(err, want), "result=%d, err=%v", result, err)
+	}
+}
+
+func TestGenerateReservationSn(t *testing.T) {
+	now := time.Date(2024, 6, 18, 10, 0, 0, 123e6, time.Local)
+	sn := generateReservationSn(1001, now)
+
+	// 订单表order_sn为varchar(30)
+	assert.LessOrEqual(t, len(sn), 30)
+	assert.Equal(t, "20240618100000123"+"1001", sn[:21])
+}
@@ -37,22 +37,24 @@ type flashSaleSrvCore struct {
 	cacheManager  cache.CacheManager
 	stockManager  *redis.StockManager
 	eventProducer consumer.FlashSaleEventProducer
+	logger        *log.Logger
 }
 
-// NewFlashSaleSrvCore 创建秒杀服务核心
-func NewFlashSaleSrvCore(data interfaces.DataFactory, redisClient *redisClient.Client, cacheManager cache.CacheManager, eventProducer consumer.FlashSaleEventProducer) FlashSaleSrvCore {
+// NewFlashSaleSrvCore 创建秒杀服务核心，stockShards为新活动库存的分片数
+func NewFlashSaleSrvCore(data interfaces.DataFactory, redisClient *redisClient.Client, cacheManager cache.CacheManager, eventProducer consumer.FlashSaleEventProducer, stockShards int) FlashSaleSrvCore {
 	return &flashSaleSrvCore{
 		data:          data,
 		redisClient:   redisClient,
 		cacheManager:  cacheManager,
-		stockManager:  redis.NewStockManager(redisClient),
+		stockManager:  redis.NewShardedStockManager(redisClient, stockShards),
 		eventProducer: eventProducer,
+		logger:        newFlashSaleLogger(),
 	}
 }
 
 // StartFlashSaleActivity 启动秒杀活动
 func (fss *flashSaleSrvCore) StartFlashSaleActivity(ctx context.Context, req *dto.StartFlashSaleDTO) error {
-	log.Infof("启动秒杀活动: activityID=%d", req.ActivityID)
+	log.InfofC(ctx, "启动秒杀活动: activityID=%d", req.ActivityID)
 
 	// 获取活动信息
 	activityDO, err := fss.data.FlashSales().Get(ctx, fss.data.DB(), req.ActivityID)
@@ -94,24 +96,25 @@ func (fss *flashSaleSrvCore) StartFlashSaleActivity(ctx context.Context, req *dt
 		return fmt.Errorf("启动Redis活动失败: %v", err)
 	}
 
-	// 更新数据库活动状态
-	updateData := &do.FlashSaleActivityDO{
-		ID:     activityDO.ID,
-		Status: do.FlashSaleStatusActive,
-	}
-	if err := fss.data.FlashSales().Update(ctx, fss.data.DB(), updateData); err != nil {
+	// 更新数据库活动状态，仅允许从待开始变更，避免并发启动相互覆盖
+	changed, err := fss.data.FlashSales().TransitStatus(ctx, fss.data.DB(), activityDO.ID,
+		[]do.FlashSaleStatus{do.FlashSaleStatusPending}, do.FlashSaleStatusActive)
+	if err != nil || !changed {
 		// 如果数据库更新失败，回滚Redis状态
 		fss.stockManager.StopActivity(ctx, activityDO.ID)
+		if err == nil {
+			err = fmt.Errorf("活动状态已变更")
+		}
 		return fmt.Errorf("更新活动状态失败: %v", err)
 	}
 
-	log.Infof("秒杀活动启动成功: activityID=%d, couponID=%d", req.ActivityID, activityDO.CouponTemplateID)
+	log.InfofC(ctx, "秒杀活动启动成功: activityID=%d, couponID=%d", req.ActivityID, activityDO.CouponTemplateID)
 	return nil
 }
 
 // StopFlashSaleActivity 停止秒杀活动
 func (fss *flashSaleSrvCore) StopFlashSaleActivity(ctx context.Context, req *dto.StopFlashSaleDTO) error {
-	log.Infof("停止秒杀活动: activityID=%d", req.ActivityID)
+	log.InfofC(ctx, "停止秒杀活动: activityID=%d", req.ActivityID)
 
 	// 获取活动信息
 	activityDO, err := fss.data.FlashSales().Get(ctx, fss.data.DB(), req.ActivityID)
@@ -124,15 +127,13 @@ func (fss *flashSaleSrvCore) StopFlashSaleActivity(ctx context.Context, req *dto
 
 	// 停止Redis活动
 	if err := fss.stockManager.StopActivity(ctx, req.ActivityID); err != nil {
-		log.Errorf("停止Redis活动失败: %v", err)
+		log.ErrorfC(ctx, "停止Redis活动失败: %v", err)
 	}
 
 	// 更新数据库活动状态
-	updateData := &do.FlashSaleActivityDO{
-		ID:     activityDO.ID,
-		Status: do.FlashSaleStatusFinished,
-	}
-	if err := fss.data.FlashSales().Update(ctx, fss.data.DB(), updateData); err != nil {
+	if _, err := fss.data.FlashSales().TransitStatus(ctx, fss.data.DB(), activityDO.ID,
+		[]do.FlashSaleStatus{do.FlashSaleStatusPending, do.FlashSaleStatusActive, do.FlashSaleStatusPaused},
+		do.FlashSaleStatusFinished); err != nil {
 		return fmt.Errorf("更新活动状态失败: %v", err)
 	}
 
@@ -145,13 +146,13 @@ func (fss *flashSaleSrvCore) StopFlashSaleActivity(ctx context.Context, req *dto
 		}()
 	}
 
-	log.Infof("秒杀活动停止成功: activityID=%d", req.ActivityID)
+	log.InfofC(ctx, "秒杀活动停止成功: activityID=%d", req.ActivityID)
 	return nil
 }
 
 // FlashSaleCoupon 执行秒杀
 func (fss *flashSaleSrvCore) FlashSaleCoupon(ctx context.Context, req *dto.FlashSaleRequestDTO) (*dto.FlashSaleResultDTO, error) {
-	log.Infof("执行秒杀请求: activityID=%d, userID=%d", req.ActivityID, req.UserID)
+	fss.logger.InfofContext(ctx, "执行秒杀请求: activityID=%d, userID=%d", req.ActivityID, req.UserID)
 
 	if fss.eventProducer == nil {
 		return nil, fmt.Errorf("秒杀事件生产者未配置，无法执行异步落库")
@@ -180,7 +181,7 @@ func (fss *flashSaleSrvCore) FlashSaleCoupon(ctx context.Context, req *dto.Flash
 		}
 
 		// 如果Redis中没有活动，可能需要重新启动
-		log.Warnf("Redis中无活动信息，尝试恢复: activityID=%d", req.ActivityID)
+		log.WarnfC(ctx, "Redis中无活动信息，尝试恢复: activityID=%d", req.ActivityID)
 		return &dto.FlashSaleResultDTO{
 			Success: false,
 			Message: "活动数据异常，请稍后重试",
@@ -201,7 +202,7 @@ func (fss *flashSaleSrvCore) FlashSaleCoupon(ctx context.Context, req *dto.Flash
 	// 执行Redis秒杀
 	result, err := fss.stockManager.FlashSale(ctx, flashSaleReq)
 	if err != nil {
-		log.Errorf("秒杀执行失败: %v", err)
+		log.ErrorfC(ctx, "秒杀执行失败: %v", err)
 		return &dto.FlashSaleResultDTO{
 			Success: false,
 			Message: "系统繁忙，请稍后重试",
@@ -236,7 +237,7 @@ func (fss *flashSaleSrvCore) FlashSaleCoupon(ctx context.Context, req *dto.Flash
 		// 异步发送，避免影响响应时间
 		go func() {
 			if err := fss.eventProducer.SendFlashSaleSuccessEvent(successEvent); err != nil {
-				log.Errorf("发送秒杀成功事件失败: %v, userID=%d, activityID=%d", 
+				log.ErrorfC(ctx, "发送秒杀成功事件失败: %v, userID=%d, activityID=%d", 
 					err, req.UserID, req.ActivityID)
 				
 				// 发送失败事件
@@ -253,7 +254,7 @@ func (fss *flashSaleSrvCore) FlashSaleCoupon(ctx context.Context, req *dto.Flash
 			}
 		}()
 		
-		log.Infof("秒杀成功，已发送异步消息: userID=%d, activityID=%d, couponSn=%s", 
+		fss.logger.InfofContext(ctx, "秒杀成功，已发送异步消息: userID=%d, activityID=%d, couponSn=%s", 
 			req.UserID, req.ActivityID, result.CouponSn)
 	} else {
 		// 秒杀失败，发送失败事件用于统计和监控
@@ -270,7 +271,7 @@ func (fss *flashSaleSrvCore) FlashSaleCoupon(ctx context.Context, req *dto.Flash
 		// 异步发送失败事件
 		go func() {
 			if err := fss.eventProducer.SendFlashSaleFailureEvent(failureEvent); err != nil {
-				log.Errorf("发送秒杀失败事件失败: %v, userID=%d, activityID=%d", 
+				log.ErrorfC(ctx, "发送秒杀失败事件失败: %v, userID=%d, activityID=%d", 
 					err, req.UserID, req.ActivityID)
 			}
 		}()
@@ -315,7 +316,7 @@ func (fss *flashSaleSrvCore) GetFlashSaleStatus(ctx context.Context, req *dto.Fl
 	// 获取实时库存
 	currentStock, err := fss.stockManager.GetCurrentStock(ctx, activityInfo.CouponID)
 	if err != nil {
-		log.Errorf("获取实时库存失败: %v", err)
+		log.ErrorfC(ctx, "获取实时库存失败: %v", err)
 		currentStock = 0
 	}
 
@@ -323,7 +324,7 @@ func (fss *flashSaleSrvCore) GetFlashSaleStatus(ctx context.Context, req *dto.Fl
 	var userParticipated bool
 	var userParticipationCount int32
 	if req.UserID > 0 {
-		count, err := fss.stockManager.GetUserParticipationCount(ctx, req.ActivityID, req.UserID)
+		count, err := fss.stockManager.GetUserParticipationCount(ctx, req.ActivityID, activityInfo.CouponID, req.UserID)
 		if err == nil {
 			userParticipationCount = count
 			userParticipated = count > 0
@@ -346,7 +347,7 @@ func (fss *flashSaleSrvCore) GetFlashSaleStatus(ctx context.Context, req *dto.Fl
 
 // CreateFlashSaleActivity 创建秒杀活动
 func (fss *flashSaleSrvCore) CreateFlashSaleActivity(ctx context.Context, req *dto.CreateFlashSaleActivityDTO) (*dto.FlashSaleActivityDTO, error) {
-	log.Infof("创建秒杀活动: couponID=%d, name=%s", req.CouponTemplateID, req.Name)
+	log.InfofC(ctx, "创建秒杀活动: couponID=%d, name=%s", req.CouponTemplateID, req.Name)
 
 	// 验证优惠券模板是否存在
 	templateDO, err := fss.data.CouponTemplates().Get(ctx, fss.data.DB(), req.CouponTemplateID)
@@ -395,7 +396,7 @@ func (fss *flashSaleSrvCore) CreateFlashSaleActivity(ctx context.Context, req *d
 	result.CouponType = int32(templateDO.Type)
 	result.DiscountValue = templateDO.DiscountValue
 
-	log.Infof("秒杀活动创建成功: activityID=%d", activityDO.ID)
+	log.InfofC(ctx, "秒杀活动创建成功: activityID=%d", activityDO.ID)
 	return result, nil
 }
 
@@ -442,7 +443,7 @@ func (fss *flashSaleSrvCore) UpdateFlashSaleActivity(ctx context.Context, req *d
 		return fmt.Errorf("更新活动失败: %v", err)
 	}
 
-	log.Infof("秒杀活动更新成功: activityID=%d", req.ID)
+	log.InfofC(ctx, "秒杀活动更新成功: activityID=%d", req.ID)
 	return nil
 }
 
@@ -460,7 +461,7 @@ func (fss *flashSaleSrvCore) GetFlashSaleActivity(ctx context.Context, activityI
 	// 获取优惠券模板信息
 	templateDO, err := fss.data.CouponTemplates().Get(ctx, fss.data.DB(), activityDO.CouponTemplateID)
 	if err != nil {
-		log.Errorf("获取优惠券模板失败: %v", err)
+		log.ErrorfC(ctx, "获取优惠券模板失败: %v", err)
 	}
 
 	// 构建返回结果
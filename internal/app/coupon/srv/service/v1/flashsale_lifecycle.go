@@ -0,0 +1,273 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"emshop/internal/app/coupon/srv/config"
+	"emshop/internal/app/coupon/srv/data/v1/interfaces"
+	"emshop/internal/app/coupon/srv/data/v1/redis"
+	"emshop/internal/app/coupon/srv/domain/do"
+	"emshop/internal/app/coupon/srv/domain/dto"
+	"emshop/pkg/delayjob"
+	"emshop/pkg/log"
+	redisClient "github.com/go-redis/redis/v8"
+)
+
+const (
+	// JobTypeFlashSalePlan 扫描即将开始/结束的活动并投递生命周期任务
+	JobTypeFlashSalePlan = "flashsale.plan"
+	// JobTypeFlashSalePrewarm 开始前预热Redis库存
+	JobTypeFlashSalePrewarm = "flashsale.prewarm"
+	// JobTypeFlashSaleStart 到点开始活动
+	JobTypeFlashSaleStart = "flashsale.start"
+	// JobTypeFlashSaleStop 到点结束活动
+	JobTypeFlashSaleStop = "flashsale.stop"
+	// JobTypeFlashSaleReconcile 结束后将Redis库存与秒杀记录对账回MySQL
+	JobTypeFlashSaleReconcile = "flashsale.reconcile"
+)
+
+// flashSaleLifecyclePayload 生命周期任务参数，At用于识别活动时间被修改后的过期任务
+type flashSaleLifecyclePayload struct {
+	ActivityID int64 `json:"activity_id"`
+	At         int64 `json:"at"`
+}
+
+// FlashSaleLifecycleSrv 秒杀活动自动启停服务
+//
+// 任务存放在延时任务存储中，重启后可继续执行；同一任务由租约保证只在一个副本上运行
+type FlashSaleLifecycleSrv interface {
+	// Plan 为before之前需要预热、开始或结束的活动投递任务
+	Plan(ctx context.Context, now time.Time) (int, error)
+	// Reconcile 用秒杀记录校正活动已售数量并清理Redis数据
+	Reconcile(ctx context.Context, activityID int64) error
+	// RegisterJobs 注册生命周期任务
+	RegisterJobs(scheduler *delayjob.Scheduler)
+}
+
+type flashSaleLifecycle struct {
+	data         interfaces.DataFactory
+	core         FlashSaleSrvCore
+	stockManager *redis.StockManager
+	opts         *config.FlashSaleOptions
+	scheduler    *delayjob.Scheduler
+}
+
+// NewFlashSaleLifecycle 创建秒杀活动自动启停服务
+func NewFlashSaleLifecycle(data interfaces.DataFactory, rdb *redisClient.Client, core FlashSaleSrvCore, opts *config.FlashSaleOptions) FlashSaleLifecycleSrv {
+	if opts == nil {
+		opts = &config.FlashSaleOptions{}
+	}
+	return &flashSaleLifecycle{
+		data:         data,
+		core:         core,
+		stockManager: redis.NewShardedStockManager(rdb, opts.StockShards),
+		opts:         opts,
+	}
+}
+
+// RegisterJobs 注册生命周期任务，PlanInterval为0时不启用自动启停
+func (fl *flashSaleLifecycle) RegisterJobs(scheduler *delayjob.Scheduler) {
+	if fl.opts.PlanInterval <= 0 {
+		return
+	}
+	fl.scheduler = scheduler
+
+	scheduler.Every(JobTypeFlashSalePlan, fl.opts.PlanInterval, func(ctx context.Context, job *delayjob.Job) error {
+		_, err := fl.Plan(ctx, time.Now())
+		return err
+	})
+	scheduler.Register(JobTypeFlashSalePrewarm, fl.handlePrewarm)
+	scheduler.Register(JobTypeFlashSaleStart, fl.handleStart)
+	scheduler.Register(JobTypeFlashSaleStop, fl.handleStop)
+	scheduler.Register(JobTypeFlashSaleReconcile, func(ctx context.Context, job *delayjob.Job) error {
+		var p flashSaleLifecyclePayload
+		if err := job.Bind(&p); err != nil {
+			return delayjob.Permanent(err)
+		}
+		return fl.Reconcile(ctx, p.ActivityID)
+	})
+}
+
+// Plan 投递下一个扫描周期内到期的预热/开始/结束任务
+//
+// 任务ID包含活动时间，同一时间点重复投递会被去重；活动时间被修改后旧任务在执行时识别为过期并跳过
+func (fl *flashSaleLifecycle) Plan(ctx context.Context, now time.Time) (int, error) {
+	if fl.scheduler == nil {
+		return 0, nil
+	}
+
+	horizon := now.Add(fl.opts.PlanInterval + fl.opts.PrewarmLead)
+	activities, err := fl.data.FlashSales().FindLifecycleDue(ctx, fl.data.DB(), horizon)
+	if err != nil {
+		return 0, err
+	}
+
+	planned := 0
+	for _, a := range activities {
+		if a.Status == do.FlashSaleStatusPending && a.EndTime.After(now) {
+			if err := fl.schedule(ctx, JobTypeFlashSalePrewarm, a.ID, a.StartTime, a.StartTime.Add(-fl.opts.PrewarmLead)); err != nil {
+				return planned, err
+			}
+			if err := fl.schedule(ctx, JobTypeFlashSaleStart, a.ID, a.StartTime, a.StartTime); err != nil {
+				return planned, err
+			}
+			planned++
+		}
+		if !a.EndTime.After(horizon) {
+			if err := fl.schedule(ctx, JobTypeFlashSaleStop, a.ID, a.EndTime, a.EndTime); err != nil {
+				return planned, err
+			}
+			planned++
+		}
+	}
+	return planned, nil
+}
+
+func (fl *flashSaleLifecycle) schedule(ctx context.Context, jobType string, activityID int64, at, runAt time.Time) error {
+	payload := flashSaleLifecyclePayload{ActivityID: activityID, At: at.Unix()}
+	_, err := fl.scheduler.Schedule(ctx, jobType, payload,
+		delayjob.At(runAt),
+		delayjob.WithJobID(fmt.Sprintf("%s:%d:%d", jobType, activityID, payload.At)))
+	if err != nil {
+		log.ErrorfC(ctx, "投递秒杀生命周期任务失败: type=%s, activityID=%d, err=%v", jobType, activityID, err)
+	}
+	return err
+}
+
+// loadActivity 加载任务对应的活动，活动已删除或时间已变更时返回nil
+func (fl *flashSaleLifecycle) loadActivity(ctx context.Context, job *delayjob.Job, at func(*do.FlashSaleActivityDO) time.Time) (*do.FlashSaleActivityDO, error) {
+	var p flashSaleLifecyclePayload
+	if err := job.Bind(&p); err != nil {
+		return nil, delayjob.Permanent(err)
+	}
+	activity, err := fl.data.FlashSales().Get(ctx, fl.data.DB(), p.ActivityID)
+	if err != nil {
+		return nil, err
+	}
+	if activity == nil {
+		log.WarnfC(ctx, "秒杀活动不存在，跳过任务: type=%s, activityID=%d", job.Type, p.ActivityID)
+		return nil, nil
+	}
+	if at(activity).Unix() != p.At {
+		log.InfofC(ctx, "秒杀活动时间已变更，跳过过期任务: type=%s, activityID=%d", job.Type, p.ActivityID)
+		return nil, nil
+	}
+	return activity, nil
+}
+
+func startTimeOf(a *do.FlashSaleActivityDO) time.Time { return a.StartTime }
+func endTimeOf(a *do.FlashSaleActivityDO) time.Time   { return a.EndTime }
+
+func (fl *flashSaleLifecycle) handlePrewarm(ctx context.Context, job *delayjob.Job) error {
+	activity, err := fl.loadActivity(ctx, job, startTimeOf)
+	if err != nil || activity == nil || activity.Status != do.FlashSaleStatusPending {
+		return err
+	}
+	// 商品秒杀在首次抢购时初始化名额，不使用优惠券秒杀的库存预热
+	if activity.IsGoods() {
+		return nil
+	}
+
+	prepared, err := fl.stockManager.IsActivityPrepared(ctx, activity.ID)
+	if err != nil || prepared {
+		return err
+	}
+	return fl.stockManager.PrepareActivity(ctx, &redis.ActivityInfo{
+		ID:           activity.ID,
+		CouponID:     activity.CouponTemplateID,
+		StartTime:    activity.StartTime,
+		EndTime:      activity.EndTime,
+		TotalCount:   activity.FlashSaleCount,
+		PerUserLimit: activity.PerUserLimit,
+	})
+}
+
+func (fl *flashSaleLifecycle) handleStart(ctx context.Context, job *delayjob.Job) error {
+	activity, err := fl.loadActivity(ctx, job, startTimeOf)
+	if err != nil || activity == nil || activity.Status != do.FlashSaleStatusPending {
+		return err
+	}
+	if !activity.EndTime.After(time.Now()) {
+		// 错过了整个活动时间段，由结束任务收尾
+		return nil
+	}
+	if activity.IsGoods() {
+		_, err = fl.data.FlashSales().TransitStatus(ctx, fl.data.DB(), activity.ID,
+			[]do.FlashSaleStatus{do.FlashSaleStatusPending}, do.FlashSaleStatusActive)
+		return err
+	}
+	return fl.core.StartFlashSaleActivity(ctx, &dto.StartFlashSaleDTO{ActivityID: activity.ID})
+}
+
+func (fl *flashSaleLifecycle) handleStop(ctx context.Context, job *delayjob.Job) error {
+	activity, err := fl.loadActivity(ctx, job, endTimeOf)
+	if err != nil || activity == nil {
+		return err
+	}
+	if activity.Status != do.FlashSaleStatusPending && activity.Status != do.FlashSaleStatusActive {
+		return nil
+	}
+	// 商品秒杀的已售数量由预占单结算累加，无需对账
+	if activity.IsGoods() {
+		_, err = fl.data.FlashSales().TransitStatus(ctx, fl.data.DB(), activity.ID,
+			[]do.FlashSaleStatus{do.FlashSaleStatusPending, do.FlashSaleStatusActive}, do.FlashSaleStatusFinished)
+		return err
+	}
+	if err := fl.core.StopFlashSaleActivity(ctx, &dto.StopFlashSaleDTO{ActivityID: activity.ID}); err != nil {
+		return err
+	}
+
+	// 等待异步落库完成后再对账
+	_, err = fl.scheduler.Schedule(ctx, JobTypeFlashSaleReconcile,
+		flashSaleLifecyclePayload{ActivityID: activity.ID, At: activity.EndTime.Unix()},
+		delayjob.After(fl.opts.ReconcileDelay),
+		delayjob.WithJobID(fmt.Sprintf("%s:%d", JobTypeFlashSaleReconcile, activity.ID)))
+	return err
+}
+
+// Reconcile 以成功的秒杀记录为准校正已售数量，并与Redis剩余库存比对后清理活动数据
+func (fl *flashSaleLifecycle) Reconcile(ctx context.Context, activityID int64) error {
+	activity, err := fl.data.FlashSales().Get(ctx, fl.data.DB(), activityID)
+	if err != nil {
+		return err
+	}
+	if activity == nil || activity.IsGoods() {
+		return nil
+	}
+
+	succeeded, err := fl.data.FlashSaleRecords().CountSuccessfulParticipation(ctx, fl.data.DB(), activityID)
+	if err != nil {
+		return err
+	}
+	// 从未预热过的活动(如错过开始时间)没有Redis库存可比对
+	prepared, err := fl.stockManager.IsActivityPrepared(ctx, activityID)
+	if err != nil {
+		return err
+	}
+	var remaining int32
+	if prepared {
+		remaining, err = fl.stockManager.GetCurrentStock(ctx, activity.CouponTemplateID)
+		if err != nil {
+			return err
+		}
+		if redisSold := int64(activity.FlashSaleCount - remaining); redisSold != succeeded {
+			log.WarnfC(ctx, "秒杀活动对账不一致: activityID=%d, redis已扣减=%d, 成功记录=%d, MySQL已售=%d",
+				activityID, redisSold, succeeded, activity.SoldCount)
+		}
+	}
+	if int64(activity.SoldCount) != succeeded {
+		if err := fl.data.FlashSales().SetSoldCount(ctx, fl.data.DB(), activityID, int32(succeeded)); err != nil {
+			return err
+		}
+	}
+
+	if prepared {
+		if err := fl.stockManager.ClearActivityData(ctx, activityID, activity.CouponTemplateID); err != nil {
+			return err
+		}
+	}
+	log.InfofC(ctx, "秒杀活动对账完成: activityID=%d, 已售=%d, 剩余库存=%d", activityID, succeeded, remaining)
+	return nil
+}
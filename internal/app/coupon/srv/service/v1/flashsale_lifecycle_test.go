@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emshop/internal/app/coupon/srv/config"
+	"emshop/internal/app/coupon/srv/data/v1/interfaces"
+	"emshop/internal/app/coupon/srv/domain/do"
+	"emshop/pkg/delayjob"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// stubFlashSaleData 仅实现生命周期扫描用到的方法
+type stubFlashSaleData struct {
+	interfaces.FlashSaleDataInterface
+	due []*do.FlashSaleActivityDO
+}
+
+func (s *stubFlashSaleData) FindLifecycleDue(ctx context.Context, db *gorm.DB, before time.Time) ([]*do.FlashSaleActivityDO, error) {
+	return s.due, nil
+}
+
+func TestFlashSaleLifecycle_Plan(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	mockData := &MockDataFactory{}
+	mockData.On("FlashSales").Return(&stubFlashSaleData{due: []*do.FlashSaleActivityDO{
+		{ID: 1, Status: do.FlashSaleStatusPending, StartTime: now.Add(2 * time.Minute), EndTime: now.Add(time.Hour)},
+		{ID: 2, Status: do.FlashSaleStatusActive, StartTime: now.Add(-time.Hour), EndTime: now.Add(30 * time.Second)},
+		{ID: 3, Status: do.FlashSaleStatusPending, StartTime: now.Add(-2 * time.Hour), EndTime: now.Add(-time.Hour)},
+	}})
+	mockData.On("DB").Return((*gorm.DB)(nil))
+
+	store := delayjob.NewMemoryStore()
+	lifecycle := NewFlashSaleLifecycle(mockData, nil, nil, &config.FlashSaleOptions{
+		PlanInterval: time.Minute,
+		PrewarmLead:  5 * time.Minute,
+	})
+	lifecycle.RegisterJobs(delayjob.New(store))
+
+	_, err := lifecycle.Plan(ctx, now)
+	require.NoError(t, err)
+	// 重复扫描不会重复投递
+	_, err = lifecycle.Plan(ctx, now)
+	require.NoError(t, err)
+
+	jobs, total, err := store.List(ctx, delayjob.ListOptions{Status: delayjob.StatusPending})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+
+	byType := map[string][]*delayjob.Job{}
+	for _, job := range jobs {
+		byType[job.Type] = append(byType[job.Type], job)
+	}
+	require.Len(t, byType[JobTypeFlashSalePrewarm], 1)
+	require.Len(t, byType[JobTypeFlashSaleStart], 1)
+	require.Len(t, byType[JobTypeFlashSaleStop], 2)
+
+	// 预热时间已过，立即执行；开始任务在开始时间执行
+	assert.True(t, byType[JobTypeFlashSalePrewarm][0].RunAt.Before(now))
+	assert.WithinDuration(t, now.Add(2*time.Minute), byType[JobTypeFlashSaleStart][0].RunAt, time.Second)
+}
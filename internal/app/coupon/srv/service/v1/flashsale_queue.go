@@ -0,0 +1,413 @@
+package v1
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"emshop/internal/app/coupon/srv/config"
+	"emshop/internal/app/coupon/srv/data/v1/interfaces"
+	stockredis "emshop/internal/app/coupon/srv/data/v1/redis"
+	"emshop/internal/app/coupon/srv/domain/do"
+	"emshop/internal/app/coupon/srv/domain/dto"
+	"emshop/internal/app/coupon/srv/pkg/admission"
+	"emshop/internal/app/coupon/srv/pkg/cache"
+	"emshop/internal/app/coupon/srv/pkg/scripts"
+	"emshop/internal/app/pkg/code"
+	"emshop/pkg/delayjob"
+	"emshop/pkg/errors"
+	"emshop/pkg/log"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	// JobTypeFlashSaleAdmit 周期性按入队顺序放行排队用户
+	JobTypeFlashSaleAdmit = "coupon.flashsale.admit"
+
+	defaultAdmitRate      = 200
+	defaultAdmitInterval  = time.Second
+	defaultMaxQueueLength = 200000
+	defaultAdmissionTTL   = 2 * time.Minute
+	// queueKeyGrace 排队相关key在活动结束后保留的时间
+	queueKeyGrace = time.Hour
+)
+
+// FlashSaleWaitingRoom 秒杀排队服务
+//
+// 用户先入队，放行任务按固定速率从队首放行并记录放行有效期，用户轮询到已放行后获得签名令牌，
+// 参与秒杀时只校验令牌签名，不再访问排队队列；队列满时直接拒绝入队而不是排到无法放行的位置
+type FlashSaleWaitingRoom interface {
+	// Enabled 是否开启排队，关闭时参与秒杀不需要令牌
+	Enabled() bool
+	// Enter 入队，重复入队返回当前排位，已放行时直接返回令牌
+	Enter(ctx context.Context, flashSaleID, userID int64) (*dto.FlashSaleQueueStatusDTO, error)
+	// Status 查询排位、预计等待时间，已放行时返回令牌
+	Status(ctx context.Context, flashSaleID, userID int64) (*dto.FlashSaleQueueStatusDTO, error)
+	// CheckAdmission 校验参与秒杀携带的放行令牌
+	CheckAdmission(flashSaleID, userID int64, token string) error
+	// AdmitDue 为所有有排队的活动放行一批用户，返回放行人数
+	AdmitDue(ctx context.Context, now time.Time) (int64, error)
+	// RegisterJobs 注册放行任务
+	RegisterJobs(scheduler *delayjob.Scheduler)
+}
+
+type flashSaleWaitingRoom struct {
+	data         interfaces.DataFactory
+	redisClient  *redis.Client
+	keyFormatter *scripts.RedisKeyFormatter
+	stockManager *stockredis.StockManager
+	signer       *admission.Signer
+	opts         *config.WaitingRoomOptions
+	activities   interface {
+		GetFlashSaleActivity(ctx context.Context, activityID int64) (*cache.FlashSaleActivity, error)
+	}
+}
+
+// NewFlashSaleWaitingRoom 创建秒杀排队服务，activities为空时从数据库读取活动
+func NewFlashSaleWaitingRoom(data interfaces.DataFactory, redisClient *redis.Client, opts *config.WaitingRoomOptions, activities interface {
+	GetFlashSaleActivity(ctx context.Context, activityID int64) (*cache.FlashSaleActivity, error)
+}) FlashSaleWaitingRoom {
+	if opts == nil {
+		opts = &config.WaitingRoomOptions{}
+	}
+	return &flashSaleWaitingRoom{
+		data:         data,
+		redisClient:  redisClient,
+		keyFormatter: scripts.NewRedisKeyFormatter(),
+		stockManager: stockredis.NewStockManager(redisClient),
+		signer:       admission.NewSigner([]byte(opts.TokenSecret)),
+		opts:         opts,
+		activities:   activities,
+	}
+}
+
+func (wr *flashSaleWaitingRoom) Enabled() bool {
+	return wr.opts.Enabled
+}
+
+func (wr *flashSaleWaitingRoom) admitRate() int {
+	if wr.opts.AdmitRate > 0 {
+		return wr.opts.AdmitRate
+	}
+	return defaultAdmitRate
+}
+
+func (wr *flashSaleWaitingRoom) admitInterval() time.Duration {
+	if wr.opts.AdmitInterval > 0 {
+		return wr.opts.AdmitInterval
+	}
+	return defaultAdmitInterval
+}
+
+func (wr *flashSaleWaitingRoom) maxQueueLength() int {
+	if wr.opts.MaxQueueLength > 0 {
+		return wr.opts.MaxQueueLength
+	}
+	return defaultMaxQueueLength
+}
+
+func (wr *flashSaleWaitingRoom) admissionTTL() time.Duration {
+	if wr.opts.AdmissionTTL > 0 {
+		return wr.opts.AdmissionTTL
+	}
+	return defaultAdmissionTTL
+}
+
+// admitBatchSize 每个放行批次的人数，与批次间隔一起决定放行速率
+func admitBatchSize(rate int, interval time.Duration) int64 {
+	n := int64(math.Ceil(float64(rate) * interval.Seconds()))
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
+// estimateWait 预计等待秒数：距活动开始的时间加上按放行速率排到自己的时间
+func estimateWait(position int64, rate int, start, now time.Time) int64 {
+	var wait int64
+	if start.After(now) {
+		wait = int64(math.Ceil(start.Sub(now).Seconds()))
+	}
+	if rate > 0 {
+		wait += int64(math.Ceil(float64(position) / float64(rate)))
+	}
+	return wait
+}
+
+// closedReason 活动已结束或库存已售罄时返回原因，stock为负数表示库存未初始化
+func closedReason(activity *cache.FlashSaleActivity, stock int64, now time.Time) string {
+	if activity.Status == int32(do.FlashSaleStatusFinished) || now.After(activity.EndTime) {
+		return "秒杀活动已结束"
+	}
+	if stock == 0 {
+		return "秒杀已售罄"
+	}
+	return ""
+}
+
+// queueKeyTTL 排队相关key保留到活动结束后一段时间
+func queueKeyTTL(activity *cache.FlashSaleActivity, now time.Time) time.Duration {
+	if activity.EndTime.After(now) {
+		return activity.EndTime.Sub(now) + queueKeyGrace
+	}
+	return queueKeyGrace
+}
+
+func (wr *flashSaleWaitingRoom) activity(ctx context.Context, flashSaleID int64) (*cache.FlashSaleActivity, error) {
+	if wr.activities != nil {
+		activity, err := wr.activities.GetFlashSaleActivity(ctx, flashSaleID)
+		if err == nil && activity != nil {
+			return activity, nil
+		}
+		log.WarnfC(ctx, "从缓存读取秒杀活动失败，回退数据库: id=%d, err=%v", flashSaleID, err)
+	}
+	activityDO, err := wr.data.FlashSales().Get(ctx, wr.data.DB(), flashSaleID)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, "获取秒杀活动失败")
+	}
+	if activityDO == nil {
+		return nil, errors.WithCode(code.ErrFlashSaleNotFound, "秒杀活动不存在")
+	}
+	return &cache.FlashSaleActivity{
+		ID:           activityDO.ID,
+		CouponID:     activityDO.CouponTemplateID,
+		Name:         activityDO.Name,
+		TotalCount:   activityDO.FlashSaleCount,
+		SuccessCount: activityDO.SoldCount,
+		StartTime:    activityDO.StartTime,
+		EndTime:      activityDO.EndTime,
+		Status:       int32(activityDO.Status),
+	}, nil
+}
+
+// stock 读取Redis剩余库存，分片库存返回各分片之和，未初始化时返回-1
+func (wr *flashSaleWaitingRoom) stock(ctx context.Context, flashSaleID int64) (int64, error) {
+	stock, found, err := wr.stockManager.SumStock(ctx, wr.keyFormatter.FlashSaleStockKey(flashSaleID))
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return -1, nil
+	}
+	return stock, nil
+}
+
+// notQueuingStatus 未开启排队时直接视为已放行，客户端可以使用同一套流程
+func notQueuingStatus(flashSaleID int64) *dto.FlashSaleQueueStatusDTO {
+	return &dto.FlashSaleQueueStatusDTO{
+		FlashSaleID: flashSaleID,
+		Status:      dto.FlashSaleQueueAdmitted,
+		Message:     "未开启排队，可直接参与",
+	}
+}
+
+func (wr *flashSaleWaitingRoom) Enter(ctx context.Context, flashSaleID, userID int64) (*dto.FlashSaleQueueStatusDTO, error) {
+	if !wr.opts.Enabled {
+		return notQueuingStatus(flashSaleID), nil
+	}
+	activity, err := wr.activity(ctx, flashSaleID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	stock, err := wr.stock(ctx, flashSaleID)
+	if err != nil {
+		log.ErrorfC(ctx, "读取秒杀库存失败: id=%d, err=%v", flashSaleID, err)
+		return nil, errors.WithCode(code.ErrRedis, "排队失败，请稍后重试")
+	}
+	if reason := closedReason(activity, stock, now); reason != "" {
+		return &dto.FlashSaleQueueStatusDTO{FlashSaleID: flashSaleID, Status: dto.FlashSaleQueueClosed, Message: reason}, nil
+	}
+
+	result, err := wr.redisClient.Eval(ctx, scripts.FlashSaleQueueEnterLua,
+		[]string{
+			wr.keyFormatter.FlashSaleQueueKey(flashSaleID),
+			wr.keyFormatter.FlashSaleQueueSeqKey(flashSaleID),
+			wr.keyFormatter.FlashSaleAdmittedKey(flashSaleID),
+		},
+		userID,
+		wr.maxQueueLength(),
+		now.UnixMilli(),
+		int64(queueKeyTTL(activity, now).Seconds()),
+	).Int64Slice()
+	if err != nil || len(result) != 2 {
+		log.ErrorfC(ctx, "执行秒杀排队脚本失败: id=%d, userID=%d, err=%v", flashSaleID, userID, err)
+		return nil, errors.WithCode(code.ErrRedis, "排队失败，请稍后重试")
+	}
+
+	switch result[0] {
+	case scripts.FlashSaleQueueFull:
+		return nil, errors.WithCode(code.ErrFlashSaleQueueFull, "排队人数已满，请稍后再试")
+	case scripts.FlashSaleQueueAdmitted:
+		return wr.Status(ctx, flashSaleID, userID)
+	}
+	// 活动集合与队列不在同一slot，入队后单独登记，重复入队时也补登记以修复放行任务的误删
+	if err := wr.redisClient.SAdd(ctx, wr.keyFormatter.FlashSaleQueueActiveKey(), flashSaleID).Err(); err != nil {
+		log.ErrorfC(ctx, "登记排队活动失败: id=%d, err=%v", flashSaleID, err)
+		return nil, errors.WithCode(code.ErrRedis, "排队失败，请稍后重试")
+	}
+	position := result[0] + 1
+	return &dto.FlashSaleQueueStatusDTO{
+		FlashSaleID:          flashSaleID,
+		Status:               dto.FlashSaleQueueWaiting,
+		Position:             position,
+		QueueLength:          result[1],
+		EstimatedWaitSeconds: estimateWait(position, wr.admitRate(), activity.StartTime, now),
+	}, nil
+}
+
+func (wr *flashSaleWaitingRoom) Status(ctx context.Context, flashSaleID, userID int64) (*dto.FlashSaleQueueStatusDTO, error) {
+	if !wr.opts.Enabled {
+		return notQueuingStatus(flashSaleID), nil
+	}
+	activity, err := wr.activity(ctx, flashSaleID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	member := strconv.FormatInt(userID, 10)
+	pipe := wr.redisClient.Pipeline()
+	rankCmd := pipe.ZRank(ctx, wr.keyFormatter.FlashSaleQueueKey(flashSaleID), member)
+	lengthCmd := pipe.ZCard(ctx, wr.keyFormatter.FlashSaleQueueKey(flashSaleID))
+	admittedCmd := pipe.ZScore(ctx, wr.keyFormatter.FlashSaleAdmittedKey(flashSaleID), member)
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		log.ErrorfC(ctx, "查询秒杀排队状态失败: id=%d, userID=%d, err=%v", flashSaleID, userID, err)
+		return nil, errors.WithCode(code.ErrRedis, "查询排队状态失败")
+	}
+
+	status := &dto.FlashSaleQueueStatusDTO{
+		FlashSaleID: flashSaleID,
+		QueueLength: lengthCmd.Val(),
+	}
+	// 已放行的用户即使活动售罄也返回令牌，由参与秒杀给出最终结果
+	if admittedUntil, err := admittedCmd.Result(); err == nil && int64(admittedUntil) > now.UnixMilli() {
+		expiresAt := time.UnixMilli(int64(admittedUntil))
+		status.Status = dto.FlashSaleQueueAdmitted
+		status.AdmissionToken = wr.signer.Sign(flashSaleID, userID, expiresAt)
+		status.TokenExpiresAt = expiresAt.Unix()
+		return status, nil
+	}
+
+	stock, err := wr.stock(ctx, flashSaleID)
+	if err != nil {
+		log.ErrorfC(ctx, "读取秒杀库存失败: id=%d, err=%v", flashSaleID, err)
+		return nil, errors.WithCode(code.ErrRedis, "查询排队状态失败")
+	}
+	if reason := closedReason(activity, stock, now); reason != "" {
+		status.Status = dto.FlashSaleQueueClosed
+		status.Message = reason
+		return status, nil
+	}
+
+	rank, err := rankCmd.Result()
+	if err != nil {
+		status.Status = dto.FlashSaleQueueNotQueued
+		return status, nil
+	}
+	status.Status = dto.FlashSaleQueueWaiting
+	status.Position = rank + 1
+	status.EstimatedWaitSeconds = estimateWait(status.Position, wr.admitRate(), activity.StartTime, now)
+	return status, nil
+}
+
+func (wr *flashSaleWaitingRoom) CheckAdmission(flashSaleID, userID int64, token string) error {
+	if !wr.opts.Enabled {
+		return nil
+	}
+	if token == "" {
+		return errors.WithCode(code.ErrFlashSaleAdmissionRequired, "请先排队获取参与资格")
+	}
+	if err := wr.signer.Verify(token, flashSaleID, userID, time.Now()); err != nil {
+		return errors.WithCode(code.ErrFlashSaleAdmissionRequired, "参与资格无效或已过期，请重新排队: %v", err)
+	}
+	return nil
+}
+
+// AdmitDue 活动开始前只排队不放行，活动结束或售罄时清空队列
+func (wr *flashSaleWaitingRoom) AdmitDue(ctx context.Context, now time.Time) (int64, error) {
+	activeKey := wr.keyFormatter.FlashSaleQueueActiveKey()
+	ids, err := wr.redisClient.SMembers(ctx, activeKey).Result()
+	if err != nil {
+		return 0, errors.WithCode(code.ErrRedis, "读取排队活动失败: %v", err)
+	}
+
+	batch := admitBatchSize(wr.admitRate(), wr.admitInterval())
+	ttl := wr.admissionTTL()
+	var admitted int64
+	for _, raw := range ids {
+		flashSaleID, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			wr.redisClient.SRem(ctx, activeKey, raw)
+			continue
+		}
+		activity, err := wr.activity(ctx, flashSaleID)
+		if err != nil {
+			log.WarnfC(ctx, "放行排队用户时读取秒杀活动失败: id=%d, err=%v", flashSaleID, err)
+			continue
+		}
+		stock, err := wr.stock(ctx, flashSaleID)
+		if err != nil {
+			log.WarnfC(ctx, "放行排队用户时读取库存失败: id=%d, err=%v", flashSaleID, err)
+			continue
+		}
+		if reason := closedReason(activity, stock, now); reason != "" {
+			log.InfofC(ctx, "秒杀活动不再放行，清空排队: id=%d, reason=%s", flashSaleID, reason)
+			wr.redisClient.Del(ctx, wr.keyFormatter.FlashSaleQueueKey(flashSaleID))
+			wr.redisClient.SRem(ctx, activeKey, raw)
+			continue
+		}
+		if now.Before(activity.StartTime) {
+			continue
+		}
+
+		result, err := wr.redisClient.Eval(ctx, scripts.FlashSaleQueueAdmitLua,
+			[]string{
+				wr.keyFormatter.FlashSaleQueueKey(flashSaleID),
+				wr.keyFormatter.FlashSaleAdmittedKey(flashSaleID),
+			},
+			batch,
+			now.UnixMilli(),
+			ttl.Milliseconds(),
+			int64(queueKeyTTL(activity, now).Seconds()),
+		).Int64Slice()
+		if err != nil || len(result) != 2 {
+			log.ErrorfC(ctx, "执行秒杀放行脚本失败: id=%d, err=%v", flashSaleID, err)
+			continue
+		}
+		admitted += result[0]
+		if result[0] > 0 {
+			log.DebugfC(ctx, "秒杀排队放行: id=%d, admitted=%d, remaining=%d", flashSaleID, result[0], result[1])
+		}
+		if result[1] == 0 {
+			wr.deactivate(ctx, flashSaleID)
+		}
+	}
+	return admitted, nil
+}
+
+// deactivate 队列已空时移出有排队的活动集合，移出后若有并发入队则重新登记，避免排队用户无人放行
+func (wr *flashSaleWaitingRoom) deactivate(ctx context.Context, flashSaleID int64) {
+	activeKey := wr.keyFormatter.FlashSaleQueueActiveKey()
+	if err := wr.redisClient.SRem(ctx, activeKey, flashSaleID).Err(); err != nil {
+		log.WarnfC(ctx, "移出排队活动失败: id=%d, err=%v", flashSaleID, err)
+		return
+	}
+	length, err := wr.redisClient.ZCard(ctx, wr.keyFormatter.FlashSaleQueueKey(flashSaleID)).Result()
+	if err != nil || length == 0 {
+		return
+	}
+	wr.redisClient.SAdd(ctx, activeKey, flashSaleID)
+}
+
+// RegisterJobs 注册放行任务，未开启排队时不启用
+func (wr *flashSaleWaitingRoom) RegisterJobs(scheduler *delayjob.Scheduler) {
+	if !wr.opts.Enabled {
+		return
+	}
+	scheduler.Every(JobTypeFlashSaleAdmit, wr.admitInterval(), func(ctx context.Context, job *delayjob.Job) error {
+		_, err := wr.AdmitDue(ctx, time.Now())
+		return err
+	})
+}
@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"emshop/internal/app/coupon/srv/config"
+	"emshop/internal/app/coupon/srv/domain/do"
+	"emshop/internal/app/coupon/srv/domain/dto"
+	"emshop/internal/app/coupon/srv/pkg/admission"
+	"emshop/internal/app/coupon/srv/pkg/cache"
+	"emshop/internal/app/coupon/srv/pkg/scripts"
+	"emshop/internal/app/pkg/code"
+	"emshop/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdmitBatchSize(t *testing.T) {
+	assert.Equal(t, int64(200), admitBatchSize(200, time.Second))
+	assert.Equal(t, int64(50), admitBatchSize(100, 500*time.Millisecond))
+	assert.Equal(t, int64(1), admitBatchSize(1, 100*time.Millisecond))
+}
+
+func TestEstimateWait(t *testing.T) {
+	now := time.Now()
+	assert.Equal(t, int64(3), estimateWait(250, 100, now.Add(-time.Minute), now))
+	assert.Equal(t, int64(11), estimateWait(100, 100, now.Add(10*time.Second), now))
+}
+
+func TestClosedReason(t *testing.T) {
+	now := time.Now()
+	running := &cache.FlashSaleActivity{Status: int32(do.FlashSaleStatusActive), StartTime: now.Add(-time.Minute), EndTime: now.Add(time.Hour)}
+	assert.Empty(t, closedReason(running, 10, now))
+	assert.Empty(t, closedReason(running, -1, now), "库存未初始化时不视为售罄")
+	assert.NotEmpty(t, closedReason(running, 0, now))
+
+	ended := &cache.FlashSaleActivity{Status: int32(do.FlashSaleStatusActive), EndTime: now.Add(-time.Second)}
+	assert.NotEmpty(t, closedReason(ended, 10, now))
+	finished := &cache.FlashSaleActivity{Status: int32(do.FlashSaleStatusFinished), EndTime: now.Add(time.Hour)}
+	assert.NotEmpty(t, closedReason(finished, 10, now))
+}
+
+func TestCheckAdmission(t *testing.T) {
+	opts := &config.WaitingRoomOptions{Enabled: true, TokenSecret: "secret"}
+	wr := NewFlashSaleWaitingRoom(nil, nil, opts, nil)
+
+	err := wr.CheckAdmission(1, 1001, "")
+	assert.True(t, errors.IsCode(err, code.ErrFlashSaleAdmissionRequired))
+
+	token := admission.NewSigner([]byte("secret")).Sign(1, 1001, time.Now().Add(time.Minute))
+	assert.NoError(t, wr.CheckAdmission(1, 1001, token))
+	assert.True(t, errors.IsCode(wr.CheckAdmission(1, 1002, token), code.ErrFlashSaleAdmissionRequired))
+
+	disabled := NewFlashSaleWaitingRoom(nil, nil, &config.WaitingRoomOptions{}, nil)
+	assert.NoError(t, disabled.CheckAdmission(1, 1001, ""))
+	status, err := disabled.Enter(context.Background(), 1, 1001)
+	assert.NoError(t, err)
+	assert.Equal(t, dto.FlashSaleQueueAdmitted, status.Status)
+}
+
+func TestFlashSaleQueueKeysShareHashSlot(t *testing.T) {
+	f := scripts.NewRedisKeyFormatter()
+	tag := func(key string) string {
+		return key[strings.Index(key, "{") : strings.Index(key, "}")+1]
+	}
+	assert.Equal(t, "{42}", tag(f.FlashSaleQueueKey(42)))
+	assert.Equal(t, "{42}", tag(f.FlashSaleQueueSeqKey(42)))
+	assert.Equal(t, "{42}", tag(f.FlashSaleAdmittedKey(42)))
+}
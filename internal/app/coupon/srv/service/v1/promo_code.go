@@ -0,0 +1,599 @@
+package v1
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"emshop/internal/app/coupon/srv/config"
+	"emshop/internal/app/coupon/srv/data/v1/interfaces"
+	"emshop/internal/app/coupon/srv/domain/do"
+	"emshop/internal/app/coupon/srv/domain/dto"
+	"emshop/internal/app/coupon/srv/pkg/cache"
+	"emshop/internal/app/pkg/code"
+	v1 "emshop/pkg/common/meta/v1"
+	"emshop/pkg/delayjob"
+	"emshop/pkg/errors"
+	"emshop/pkg/log"
+	redisClient "github.com/go-redis/redis/v8"
+)
+
+const (
+	// JobTypePromoCodeReindex 将MySQL中的兑换码重建到Redis集合
+	JobTypePromoCodeReindex = "coupon.promo.reindex"
+
+	// promoCodeSetKey 兑换码前置集合，仅用于快速拒绝不存在的兑换码，以MySQL为准
+	promoCodeSetKey = "coupon:promo:codes"
+	// promoCodeSetReadyKey 集合重建完成标记，标记存在时才使用集合拒绝兑换
+	promoCodeSetReadyKey = "coupon:promo:codes:ready"
+
+	promoCodeRateKeyPrefix = "coupon:promo:redeem:rate:"
+	promoCodeFailKeyPrefix = "coupon:promo:redeem:fail:"
+
+	// 兑换码字符集，去掉了易混淆的0/O、1/I
+	promoCodeAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+	defaultPromoCodeLength    = 12
+	defaultPromoCodeMaxBatch  = 100000
+	promoCodeInsertChunkSize  = 1000
+	promoCodeReindexPageSize  = 5000
+	promoCodeMaxGenerateRound = 10
+)
+
+var promoCodePattern = regexp.MustCompile(`^[A-Z0-9]{4,32}$`)
+
+// PromoCodeSrv 优惠券兑换码服务
+type PromoCodeSrv interface {
+	// GenerateCodes 为模板批量生成一次性兑换码
+	GenerateCodes(ctx context.Context, req *dto.GeneratePromoCodesDTO) (*dto.GeneratePromoCodesResultDTO, error)
+	// CreatePublicCode 创建公开兑换码，每个用户可兑换一次
+	CreatePublicCode(ctx context.Context, req *dto.CreatePublicPromoCodeDTO) (*dto.PromoCodeDTO, error)
+	// RedeemCode 使用兑换码领取优惠券
+	RedeemCode(ctx context.Context, req *dto.RedeemPromoCodeDTO) (*dto.UserCouponDTO, error)
+	// ListCodes 分页查询兑换码
+	ListCodes(ctx context.Context, req *dto.ListPromoCodesDTO) (*dto.PromoCodeListDTO, error)
+	// DisableCode 停用兑换码
+	DisableCode(ctx context.Context, promoCode string) error
+	// RebuildIndex 将兑换码重建到Redis集合
+	RebuildIndex(ctx context.Context) (int64, error)
+	// RegisterJobs 注册兑换码相关任务
+	RegisterJobs(scheduler *delayjob.Scheduler)
+}
+
+type promoCodeService struct {
+	data         interfaces.DataFactory
+	rdb          *redisClient.Client
+	opts         *config.PromoCodeOptions
+	cacheManager interface {
+		InvalidateCache(keys ...string)
+	}
+}
+
+// NewPromoCodeService 创建优惠券兑换码服务
+func NewPromoCodeService(data interfaces.DataFactory, rdb *redisClient.Client, opts *config.PromoCodeOptions, cacheManager interface {
+	InvalidateCache(keys ...string)
+}) PromoCodeSrv {
+	if opts == nil {
+		opts = &config.PromoCodeOptions{}
+	}
+	return &promoCodeService{
+		data:         data,
+		rdb:          rdb,
+		opts:         opts,
+		cacheManager: cacheManager,
+	}
+}
+
+// NormalizePromoCode 规范化用户输入的兑换码
+func NormalizePromoCode(s string) string {
+	return strings.ToUpper(strings.TrimSpace(s))
+}
+
+// newPromoCode 生成指定长度的随机兑换码
+func newPromoCode(prefix string, length int) (string, error) {
+	var sb strings.Builder
+	sb.Grow(len(prefix) + length)
+	sb.WriteString(prefix)
+	max := big.NewInt(int64(len(promoCodeAlphabet)))
+	for i := 0; i < length; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteByte(promoCodeAlphabet[n.Int64()])
+	}
+	return sb.String(), nil
+}
+
+// newPromoBatchNo 生成兑换码批次号
+func newPromoBatchNo() string {
+	n, _ := rand.Int(rand.Reader, big.NewInt(10000))
+	return fmt.Sprintf("PB%s%04d", time.Now().Format("20060102150405"), n.Int64())
+}
+
+func (ps *promoCodeService) codeLength() int {
+	if ps.opts.CodeLength > 0 {
+		return ps.opts.CodeLength
+	}
+	return defaultPromoCodeLength
+}
+
+func (ps *promoCodeService) maxBatchSize() int {
+	if ps.opts.MaxBatchSize > 0 {
+		return ps.opts.MaxBatchSize
+	}
+	return defaultPromoCodeMaxBatch
+}
+
+// getTemplate 获取兑换码对应的优惠券模板
+func (ps *promoCodeService) getTemplate(ctx context.Context, templateID int64) (*do.CouponTemplateDO, error) {
+	template, err := ps.data.CouponTemplates().Get(ctx, ps.data.DB(), templateID)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, "获取优惠券模板失败")
+	}
+	if template == nil {
+		return nil, errors.WithCode(code.ErrResourceNotFound, "优惠券模板不存在: %d", templateID)
+	}
+	return template, nil
+}
+
+// GenerateCodes 批量生成兑换码，与已有兑换码冲突的部分重新生成直到数量满足
+func (ps *promoCodeService) GenerateCodes(ctx context.Context, req *dto.GeneratePromoCodesDTO) (*dto.GeneratePromoCodesResultDTO, error) {
+	prefix := NormalizePromoCode(req.Prefix)
+	length := ps.codeLength()
+	if req.Count <= 0 || int(req.Count) > ps.maxBatchSize() {
+		return nil, errors.WithCode(code.ErrInvalidRequest, "生成数量必须在1-%d之间", ps.maxBatchSize())
+	}
+	if prefix != "" && !promoCodePattern.MatchString(prefix+strings.Repeat("A", length)) {
+		return nil, errors.WithCode(code.ErrInvalidRequest, "兑换码前缀只能包含字母和数字，且总长度不超过32")
+	}
+	if _, err := ps.getTemplate(ctx, req.CouponTemplateID); err != nil {
+		return nil, err
+	}
+
+	batchNo := newPromoBatchNo()
+	codes := make([]string, 0, req.Count)
+	conflicts := 0
+	for len(codes) < int(req.Count) {
+		if conflicts >= promoCodeMaxGenerateRound {
+			log.ErrorfC(ctx, "兑换码冲突过多，生成中止: templateID=%d, batch=%s, generated=%d", req.CouponTemplateID, batchNo, len(codes))
+			return nil, errors.WithCode(code.ErrInvalidRequest, "兑换码空间不足，请增加长度或更换前缀")
+		}
+
+		need := int(req.Count) - len(codes)
+		if need > promoCodeInsertChunkSize {
+			need = promoCodeInsertChunkSize
+		}
+		seen := make(map[string]struct{}, need)
+		chunk := make([]*do.PromoCodeDO, 0, need)
+		for len(chunk) < need {
+			c, err := newPromoCode(prefix, length)
+			if err != nil {
+				return nil, errors.WithCode(code.ErrInvalidRequest, "生成兑换码失败: %s", err.Error())
+			}
+			if _, ok := seen[c]; ok {
+				continue
+			}
+			seen[c] = struct{}{}
+			chunk = append(chunk, &do.PromoCodeDO{
+				Code:             c,
+				CouponTemplateID: req.CouponTemplateID,
+				BatchNo:          batchNo,
+				Kind:             do.PromoCodeKindUnique,
+				MaxRedemptions:   1,
+				Status:           do.PromoCodeStatusActive,
+				Operator:         req.Operator,
+			})
+		}
+
+		inserted, err := ps.data.PromoCodes().BatchCreate(ctx, ps.data.DB(), chunk)
+		if err != nil {
+			return nil, errors.WithCode(code.ErrDatabase, "保存兑换码失败")
+		}
+		if inserted == int64(len(chunk)) {
+			for _, p := range chunk {
+				codes = append(codes, p.Code)
+			}
+			conflicts = 0
+			continue
+		}
+
+		// 部分兑换码与已有数据冲突，以批次实际写入的数据为准
+		conflicts++
+		codes, err = ps.batchCodes(ctx, req.CouponTemplateID, batchNo)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_ = ps.indexCodes(ctx, codes...)
+	log.InfofC(ctx, "批量生成兑换码完成: templateID=%d, batch=%s, count=%d", req.CouponTemplateID, batchNo, len(codes))
+	return &dto.GeneratePromoCodesResultDTO{BatchNo: batchNo, Codes: codes}, nil
+}
+
+// batchCodes 查询批次内已写入的全部兑换码
+func (ps *promoCodeService) batchCodes(ctx context.Context, templateID int64, batchNo string) ([]string, error) {
+	list, err := ps.data.PromoCodes().List(ctx, ps.data.DB(), templateID, batchNo, v1.ListMeta{})
+	if err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, "查询兑换码失败")
+	}
+	codes := make([]string, 0, len(list.Items))
+	for _, p := range list.Items {
+		codes = append(codes, p.Code)
+	}
+	return codes, nil
+}
+
+// CreatePublicCode 创建公开兑换码
+func (ps *promoCodeService) CreatePublicCode(ctx context.Context, req *dto.CreatePublicPromoCodeDTO) (*dto.PromoCodeDTO, error) {
+	promoCode := NormalizePromoCode(req.Code)
+	if !promoCodePattern.MatchString(promoCode) {
+		return nil, errors.WithCode(code.ErrInvalidRequest, "兑换码只能包含4-32位字母和数字")
+	}
+	if req.MaxRedemptions < 0 {
+		return nil, errors.WithCode(code.ErrInvalidRequest, "最大兑换次数不能为负数")
+	}
+	if _, err := ps.getTemplate(ctx, req.CouponTemplateID); err != nil {
+		return nil, err
+	}
+
+	existing, err := ps.data.PromoCodes().GetByCode(ctx, ps.data.DB(), promoCode)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, "查询兑换码失败")
+	}
+	if existing != nil {
+		return nil, errors.WithCode(code.ErrInvalidRequest, "兑换码已存在: %s", promoCode)
+	}
+
+	promoCodeDO := &do.PromoCodeDO{
+		Code:             promoCode,
+		CouponTemplateID: req.CouponTemplateID,
+		Kind:             do.PromoCodeKindPublic,
+		MaxRedemptions:   req.MaxRedemptions,
+		Status:           do.PromoCodeStatusActive,
+		Operator:         req.Operator,
+	}
+	if err := ps.data.PromoCodes().Create(ctx, ps.data.DB(), promoCodeDO); err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, "创建兑换码失败")
+	}
+
+	_ = ps.indexCodes(ctx, promoCode)
+	return promoCodeToDTO(promoCodeDO), nil
+}
+
+// RedeemCode 兑换优惠券
+//
+// 兑换码次数、模板总量与个人限领在同一事务内校验：模板的条件更新持有行锁，
+// 同一模板的并发兑换在此串行，随后的个人领取数量检查不会被并发请求绕过
+func (ps *promoCodeService) RedeemCode(ctx context.Context, req *dto.RedeemPromoCodeDTO) (*dto.UserCouponDTO, error) {
+	promoCode := NormalizePromoCode(req.Code)
+	if err := ps.checkRateLimit(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+	if !promoCodePattern.MatchString(promoCode) {
+		ps.recordFailure(ctx, req.UserID)
+		return nil, errors.WithCode(code.ErrPromoCodeInvalid, "兑换码无效")
+	}
+	if !ps.mayExist(ctx, promoCode) {
+		ps.recordFailure(ctx, req.UserID)
+		return nil, errors.WithCode(code.ErrPromoCodeInvalid, "兑换码无效")
+	}
+
+	promoCodeDO, err := ps.data.PromoCodes().GetByCode(ctx, ps.data.DB(), promoCode)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, "查询兑换码失败")
+	}
+	if promoCodeDO == nil || promoCodeDO.Status != do.PromoCodeStatusActive {
+		ps.recordFailure(ctx, req.UserID)
+		return nil, errors.WithCode(code.ErrPromoCodeInvalid, "兑换码无效")
+	}
+	if promoCodeDO.Exhausted() {
+		return nil, errors.WithCode(code.ErrPromoCodeExhausted, "兑换码已被使用")
+	}
+
+	templateDO, err := ps.getTemplate(ctx, promoCodeDO.CouponTemplateID)
+	if err != nil {
+		return nil, err
+	}
+	currentTime := time.Now()
+	available, err := ps.data.CouponTemplates().CheckTemplateAvailability(ctx, ps.data.DB(), templateDO.ID, currentTime)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, "检查优惠券模板可用性失败")
+	}
+	if !available {
+		return nil, errors.WithCode(code.ErrResourceNotAvailable, "优惠券模板不可用或已过期")
+	}
+
+	tx := ps.data.Begin()
+
+	ok, err := ps.data.PromoCodes().IncrRedeemed(ctx, tx, promoCodeDO.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, errors.WithCode(code.ErrDatabase, "兑换优惠券失败")
+	}
+	if !ok {
+		tx.Rollback()
+		return nil, errors.WithCode(code.ErrPromoCodeExhausted, "兑换码已被使用")
+	}
+
+	ok, err = ps.data.CouponTemplates().IncrUsedCountIfAvailable(ctx, tx, templateDO.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, errors.WithCode(code.ErrDatabase, "兑换优惠券失败")
+	}
+	if !ok {
+		tx.Rollback()
+		return nil, errors.WithCode(code.ErrResourceNotAvailable, "优惠券已发放完毕")
+	}
+
+	userCouponCount, err := ps.data.UserCoupons().CountUserCouponsByTemplate(ctx, tx, req.UserID, templateDO.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, errors.WithCode(code.ErrDatabase, "检查用户优惠券数量失败")
+	}
+	if userCouponCount >= int64(templateDO.PerUserLimit) {
+		tx.Rollback()
+		return nil, errors.WithCode(code.ErrResourceLimitExceeded, "超出个人限领数量")
+	}
+
+	expiredAt := templateDO.ValidEndTime
+	if templateDO.ValidDays > 0 {
+		expiredAt = currentTime.AddDate(0, 0, int(templateDO.ValidDays))
+	}
+	userCouponDO := &do.UserCouponDO{
+		CouponTemplateID: templateDO.ID,
+		UserID:           req.UserID,
+		CouponCode:       newUserCouponCode(),
+		Status:           do.UserCouponStatusUnused,
+		ReceivedAt:       currentTime,
+		ExpiredAt:        expiredAt,
+	}
+	if err := ps.data.UserCoupons().Create(ctx, tx, userCouponDO); err != nil {
+		tx.Rollback()
+		log.ErrorfC(ctx, "创建用户优惠券失败: %v", err)
+		return nil, errors.WithCode(code.ErrDatabase, "兑换优惠券失败")
+	}
+
+	ok, err = ps.data.PromoCodes().CreateRedemption(ctx, tx, &do.PromoCodeRedemptionDO{
+		PromoCodeID:  promoCodeDO.ID,
+		UserID:       req.UserID,
+		UserCouponID: userCouponDO.ID,
+	})
+	if err != nil {
+		tx.Rollback()
+		return nil, errors.WithCode(code.ErrDatabase, "兑换优惠券失败")
+	}
+	if !ok {
+		tx.Rollback()
+		return nil, errors.WithCode(code.ErrPromoCodeRedeemed, "已兑换过该兑换码")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		log.ErrorfC(ctx, "提交兑换事务失败: %v", err)
+		return nil, errors.WithCode(code.ErrDatabase, "兑换优惠券失败")
+	}
+
+	if promoCodeDO.Kind == do.PromoCodeKindUnique {
+		ps.unindexCode(ctx, promoCode)
+	}
+	if ps.cacheManager != nil {
+		ps.cacheManager.InvalidateCache(
+			cache.CacheKeys.UserCouponList(req.UserID),
+			cache.CacheKeys.UserAvailableCoupons(req.UserID),
+		)
+	}
+	log.InfofC(ctx, "兑换码兑换成功: userID=%d, code=%s, templateID=%d, userCouponID=%d",
+		req.UserID, promoCode, templateDO.ID, userCouponDO.ID)
+	return userCouponToDTO(userCouponDO, templateDO), nil
+}
+
+// checkRateLimit 按用户限制兑换请求频率与无效兑换码次数，Redis不可用时放行
+func (ps *promoCodeService) checkRateLimit(ctx context.Context, userID int64) error {
+	if ps.rdb == nil {
+		return nil
+	}
+	if ps.opts.RedeemFailLimit > 0 {
+		failures, err := ps.rdb.Get(ctx, fmt.Sprintf("%s%d", promoCodeFailKeyPrefix, userID)).Int()
+		if err != nil && err != redisClient.Nil {
+			log.WarnfC(ctx, "读取兑换失败次数失败: userID=%d, err=%v", userID, err)
+		} else if failures >= ps.opts.RedeemFailLimit {
+			return errors.WithCode(code.ErrPromoCodeRateLimited, "无效兑换次数过多，请稍后再试")
+		}
+	}
+	if ps.opts.RedeemRateLimit > 0 {
+		count, err := ps.incrWindow(ctx, fmt.Sprintf("%s%d", promoCodeRateKeyPrefix, userID), ps.opts.RedeemRateWindow)
+		if err != nil {
+			log.WarnfC(ctx, "兑换限流计数失败: userID=%d, err=%v", userID, err)
+		} else if count > int64(ps.opts.RedeemRateLimit) {
+			return errors.WithCode(code.ErrPromoCodeRateLimited, "兑换过于频繁，请稍后再试")
+		}
+	}
+	return nil
+}
+
+// recordFailure 记录一次无效兑换码
+func (ps *promoCodeService) recordFailure(ctx context.Context, userID int64) {
+	if ps.rdb == nil || ps.opts.RedeemFailLimit <= 0 {
+		return
+	}
+	if _, err := ps.incrWindow(ctx, fmt.Sprintf("%s%d", promoCodeFailKeyPrefix, userID), ps.opts.RedeemFailWindow); err != nil {
+		log.WarnfC(ctx, "记录兑换失败次数失败: userID=%d, err=%v", userID, err)
+	}
+}
+
+// incrWindow 固定窗口计数，窗口内首次计数时设置过期时间
+func (ps *promoCodeService) incrWindow(ctx context.Context, key string, window time.Duration) (int64, error) {
+	if window <= 0 {
+		window = time.Minute
+	}
+	count, err := ps.rdb.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+	if count == 1 {
+		if err := ps.rdb.Expire(ctx, key, window).Err(); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// mayExist 通过Redis集合判断兑换码是否可能存在，集合未就绪或Redis不可用时返回true
+func (ps *promoCodeService) mayExist(ctx context.Context, promoCode string) bool {
+	if ps.rdb == nil {
+		return true
+	}
+	ready, err := ps.rdb.Exists(ctx, promoCodeSetReadyKey).Result()
+	if err != nil || ready == 0 {
+		return true
+	}
+	member, err := ps.rdb.SIsMember(ctx, promoCodeSetKey, promoCode).Result()
+	if err != nil {
+		log.WarnfC(ctx, "查询兑换码集合失败: %v", err)
+		return true
+	}
+	return member
+}
+
+// indexCodes 将兑换码加入Redis集合
+func (ps *promoCodeService) indexCodes(ctx context.Context, codes ...string) error {
+	if ps.rdb == nil || len(codes) == 0 {
+		return nil
+	}
+	for start := 0; start < len(codes); start += promoCodeInsertChunkSize {
+		end := start + promoCodeInsertChunkSize
+		if end > len(codes) {
+			end = len(codes)
+		}
+		members := make([]interface{}, 0, end-start)
+		for _, c := range codes[start:end] {
+			members = append(members, c)
+		}
+		if err := ps.rdb.SAdd(ctx, promoCodeSetKey, members...).Err(); err != nil {
+			// 集合缺失兑换码会误拒绝兑换，移除就绪标记退回到直接查询MySQL；
+			// Redis故障时移除也可能失败，由周期重建任务补齐集合
+			log.ErrorfC(ctx, "写入兑换码集合失败，停用集合前置校验: %v", err)
+			ps.rdb.Del(ctx, promoCodeSetReadyKey)
+			return err
+		}
+	}
+	return nil
+}
+
+// unindexCode 将已用完的一次性兑换码移出集合
+func (ps *promoCodeService) unindexCode(ctx context.Context, promoCode string) {
+	if ps.rdb == nil {
+		return
+	}
+	if err := ps.rdb.SRem(ctx, promoCodeSetKey, promoCode).Err(); err != nil {
+		log.WarnfC(ctx, "移除兑换码集合成员失败: code=%s, err=%v", promoCode, err)
+	}
+}
+
+// ListCodes 分页查询兑换码
+func (ps *promoCodeService) ListCodes(ctx context.Context, req *dto.ListPromoCodesDTO) (*dto.PromoCodeListDTO, error) {
+	meta := v1.ListMeta{
+		Page:     int(req.Page),
+		PageSize: int(req.PageSize),
+	}
+	list, err := ps.data.PromoCodes().List(ctx, ps.data.DB(), req.CouponTemplateID, req.BatchNo, meta)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, "查询兑换码失败")
+	}
+
+	items := make([]*dto.PromoCodeDTO, 0, len(list.Items))
+	for _, p := range list.Items {
+		items = append(items, promoCodeToDTO(p))
+	}
+	return &dto.PromoCodeListDTO{
+		TotalCount: list.TotalCount,
+		Items:      items,
+	}, nil
+}
+
+// DisableCode 停用兑换码
+func (ps *promoCodeService) DisableCode(ctx context.Context, promoCode string) error {
+	promoCode = NormalizePromoCode(promoCode)
+	promoCodeDO, err := ps.data.PromoCodes().GetByCode(ctx, ps.data.DB(), promoCode)
+	if err != nil {
+		return errors.WithCode(code.ErrDatabase, "查询兑换码失败")
+	}
+	if promoCodeDO == nil {
+		return errors.WithCode(code.ErrResourceNotFound, "兑换码不存在")
+	}
+	if err := ps.data.PromoCodes().UpdateStatus(ctx, ps.data.DB(), promoCodeDO.ID, do.PromoCodeStatusDisabled); err != nil {
+		return errors.WithCode(code.ErrDatabase, "停用兑换码失败")
+	}
+	log.InfofC(ctx, "兑换码已停用: code=%s", promoCode)
+	return nil
+}
+
+// RebuildIndex 分页读取兑换码写入Redis集合，全部写入后设置就绪标记
+//
+// 重建只追加不删除，已失效的兑换码留在集合中也只会落到MySQL校验
+func (ps *promoCodeService) RebuildIndex(ctx context.Context) (int64, error) {
+	if ps.rdb == nil {
+		return 0, nil
+	}
+
+	var total int64
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		list, err := ps.data.PromoCodes().List(ctx, ps.data.DB(), 0, "", v1.ListMeta{Page: page, PageSize: promoCodeReindexPageSize})
+		if err != nil {
+			return total, err
+		}
+		codes := make([]string, 0, len(list.Items))
+		for _, p := range list.Items {
+			if p.Status == do.PromoCodeStatusActive && !p.Exhausted() {
+				codes = append(codes, p.Code)
+			}
+		}
+		if err := ps.indexCodes(ctx, codes...); err != nil {
+			return total, err
+		}
+		total += int64(len(codes))
+		if len(list.Items) < promoCodeReindexPageSize {
+			break
+		}
+	}
+
+	if err := ps.rdb.Set(ctx, promoCodeSetReadyKey, time.Now().Unix(), 0).Err(); err != nil {
+		return total, err
+	}
+	log.InfofC(ctx, "兑换码集合重建完成: count=%d", total)
+	return total, nil
+}
+
+// RegisterJobs 注册兑换码集合周期重建任务，补齐Redis故障期间写入失败的兑换码；
+// 重建间隔为0时不建立集合，兑换码直接查询MySQL
+func (ps *promoCodeService) RegisterJobs(scheduler *delayjob.Scheduler) {
+	if ps.opts.ReindexInterval <= 0 {
+		return
+	}
+	scheduler.Every(JobTypePromoCodeReindex, ps.opts.ReindexInterval, func(ctx context.Context, job *delayjob.Job) error {
+		_, err := ps.RebuildIndex(ctx)
+		return err
+	})
+}
+
+func promoCodeToDTO(p *do.PromoCodeDO) *dto.PromoCodeDTO {
+	return &dto.PromoCodeDTO{
+		ID:               p.ID,
+		Code:             p.Code,
+		CouponTemplateID: p.CouponTemplateID,
+		BatchNo:          p.BatchNo,
+		Kind:             int32(p.Kind),
+		MaxRedemptions:   p.MaxRedemptions,
+		RedeemedCount:    p.RedeemedCount,
+		Status:           int32(p.Status),
+		Operator:         p.Operator,
+		CreatedAt:        p.CreatedAt,
+	}
+}
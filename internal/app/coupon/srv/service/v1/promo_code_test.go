@@ -0,0 +1,309 @@
+package v1
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"emshop/internal/app/coupon/srv/config"
+	"emshop/internal/app/coupon/srv/data/v1/interfaces"
+	"emshop/internal/app/coupon/srv/domain/do"
+	"emshop/internal/app/coupon/srv/domain/dto"
+	"emshop/internal/app/pkg/code"
+	"emshop/pkg/errors"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// fakeTx 记录事务提交与回滚，不执行SQL
+type fakeTx struct {
+	committed  int
+	rolledBack int
+}
+
+func (f *fakeTx) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return nil, fmt.Errorf("fakeTx: unexpected query")
+}
+
+func (f *fakeTx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return nil, fmt.Errorf("fakeTx: unexpected query")
+}
+
+func (f *fakeTx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, fmt.Errorf("fakeTx: unexpected query")
+}
+
+func (f *fakeTx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return nil
+}
+
+func (f *fakeTx) Commit() error {
+	f.committed++
+	return nil
+}
+
+func (f *fakeTx) Rollback() error {
+	f.rolledBack++
+	return nil
+}
+
+// db 每次返回新的事务句柄，避免上一次的错误影响下一次提交
+func (f *fakeTx) db() *gorm.DB {
+	return &gorm.DB{Config: &gorm.Config{}, Statement: &gorm.Statement{ConnPool: f}}
+}
+
+// stubPromoCodeData 内存中的兑换码与兑换记录
+type stubPromoCodeData struct {
+	interfaces.PromoCodeDataInterface
+	code        *do.PromoCodeDO
+	redemptions map[int64]bool
+}
+
+func (s *stubPromoCodeData) GetByCode(ctx context.Context, db *gorm.DB, c string) (*do.PromoCodeDO, error) {
+	if s.code == nil || s.code.Code != c {
+		return nil, nil
+	}
+	cp := *s.code
+	return &cp, nil
+}
+
+func (s *stubPromoCodeData) IncrRedeemed(ctx context.Context, db *gorm.DB, id int64) (bool, error) {
+	if s.code.Exhausted() {
+		return false, nil
+	}
+	s.code.RedeemedCount++
+	return true, nil
+}
+
+func (s *stubPromoCodeData) CreateRedemption(ctx context.Context, db *gorm.DB, r *do.PromoCodeRedemptionDO) (bool, error) {
+	if s.redemptions[r.UserID] {
+		return false, nil
+	}
+	s.redemptions[r.UserID] = true
+	return true, nil
+}
+
+// stubRedeemUserCouponData 按用户记录已领取的优惠券数量
+type stubRedeemUserCouponData struct {
+	interfaces.UserCouponDataInterface
+	owned  map[int64]int64
+	nextID int64
+}
+
+func (s *stubRedeemUserCouponData) CountUserCouponsByTemplate(ctx context.Context, db *gorm.DB, userID int64, templateID int64) (int64, error) {
+	return s.owned[userID], nil
+}
+
+func (s *stubRedeemUserCouponData) Create(ctx context.Context, db *gorm.DB, uc *do.UserCouponDO) error {
+	s.nextID++
+	uc.ID = s.nextID
+	s.owned[uc.UserID]++
+	return nil
+}
+
+// stubRedeemTemplateData 单个模板，soldOut为true时发放总量已满
+type stubRedeemTemplateData struct {
+	interfaces.CouponTemplateDataInterface
+	template *do.CouponTemplateDO
+	soldOut  bool
+}
+
+func (s *stubRedeemTemplateData) Get(ctx context.Context, db *gorm.DB, id int64) (*do.CouponTemplateDO, error) {
+	return s.template, nil
+}
+
+func (s *stubRedeemTemplateData) CheckTemplateAvailability(ctx context.Context, db *gorm.DB, templateID int64, currentTime time.Time) (bool, error) {
+	return true, nil
+}
+
+func (s *stubRedeemTemplateData) IncrUsedCountIfAvailable(ctx context.Context, db *gorm.DB, templateID int64) (bool, error) {
+	return !s.soldOut, nil
+}
+
+type redeemFixture struct {
+	srv         PromoCodeSrv
+	tx          *fakeTx
+	codes       *stubPromoCodeData
+	userCoupons *stubRedeemUserCouponData
+	templates   *stubRedeemTemplateData
+}
+
+func newRedeemFixture(promo *do.PromoCodeDO, template *do.CouponTemplateDO, rdb *redis.Client, opts *config.PromoCodeOptions) *redeemFixture {
+	f := &redeemFixture{
+		tx:          &fakeTx{},
+		codes:       &stubPromoCodeData{code: promo, redemptions: map[int64]bool{}},
+		userCoupons: &stubRedeemUserCouponData{owned: map[int64]int64{}},
+		templates:   &stubRedeemTemplateData{template: template},
+	}
+
+	mockData := new(MockDataFactory)
+	mockData.On("DB").Return((*gorm.DB)(nil))
+	mockData.On("Begin").Return(f.tx.db())
+	mockData.On("PromoCodes").Return(f.codes)
+	mockData.On("UserCoupons").Return(f.userCoupons)
+	mockData.On("CouponTemplates").Return(f.templates)
+	f.srv = NewPromoCodeService(mockData, rdb, opts, nil)
+	return f
+}
+
+func redeemTemplate(perUserLimit int32) *do.CouponTemplateDO {
+	return &do.CouponTemplateDO{ID: 10, PerUserLimit: perUserLimit, ValidEndTime: time.Now().Add(24 * time.Hour)}
+}
+
+func TestRedeemCodePublicCodeOncePerUser(t *testing.T) {
+	ctx := context.Background()
+	promo := &do.PromoCodeDO{ID: 1, Code: "SUMMER2024", CouponTemplateID: 10, Kind: do.PromoCodeKindPublic, Status: do.PromoCodeStatusActive}
+	f := newRedeemFixture(promo, redeemTemplate(5), nil, nil)
+
+	uc, err := f.srv.RedeemCode(ctx, &dto.RedeemPromoCodeDTO{UserID: 1001, Code: " summer2024 "})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1001), uc.UserID)
+	assert.Equal(t, 1, f.tx.committed)
+
+	_, err = f.srv.RedeemCode(ctx, &dto.RedeemPromoCodeDTO{UserID: 1001, Code: "SUMMER2024"})
+	assert.True(t, errors.IsCode(err, code.ErrPromoCodeRedeemed), "err=%v", err)
+	assert.Equal(t, 1, f.tx.rolledBack)
+
+	_, err = f.srv.RedeemCode(ctx, &dto.RedeemPromoCodeDTO{UserID: 1002, Code: "SUMMER2024"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, f.tx.committed)
+}
+
+func TestRedeemCodePerUserLimit(t *testing.T) {
+	ctx := context.Background()
+	promo := &do.PromoCodeDO{ID: 1, Code: "SUMMER2024", CouponTemplateID: 10, Kind: do.PromoCodeKindPublic, Status: do.PromoCodeStatusActive}
+	f := newRedeemFixture(promo, redeemTemplate(1), nil, nil)
+	f.userCoupons.owned[1001] = 1
+
+	_, err := f.srv.RedeemCode(ctx, &dto.RedeemPromoCodeDTO{UserID: 1001, Code: "SUMMER2024"})
+	assert.True(t, errors.IsCode(err, code.ErrResourceLimitExceeded), "err=%v", err)
+	assert.Equal(t, 1, f.tx.rolledBack)
+	assert.Zero(t, f.tx.committed)
+	assert.False(t, f.codes.redemptions[1001])
+}
+
+func TestRedeemCodeTotalLimit(t *testing.T) {
+	ctx := context.Background()
+
+	// 一次性兑换码被他人用完
+	unique := &do.PromoCodeDO{ID: 1, Code: "VIPABCDEF", CouponTemplateID: 10, Kind: do.PromoCodeKindUnique,
+		Status: do.PromoCodeStatusActive, MaxRedemptions: 1}
+	f := newRedeemFixture(unique, redeemTemplate(5), nil, nil)
+	_, err := f.srv.RedeemCode(ctx, &dto.RedeemPromoCodeDTO{UserID: 1001, Code: "VIPABCDEF"})
+	require.NoError(t, err)
+	_, err = f.srv.RedeemCode(ctx, &dto.RedeemPromoCodeDTO{UserID: 1002, Code: "VIPABCDEF"})
+	assert.True(t, errors.IsCode(err, code.ErrPromoCodeExhausted), "err=%v", err)
+
+	// 模板发放总量已满
+	public := &do.PromoCodeDO{ID: 2, Code: "SUMMER2024", CouponTemplateID: 10, Kind: do.PromoCodeKindPublic, Status: do.PromoCodeStatusActive}
+	f = newRedeemFixture(public, redeemTemplate(5), nil, nil)
+	f.templates.soldOut = true
+	_, err = f.srv.RedeemCode(ctx, &dto.RedeemPromoCodeDTO{UserID: 1001, Code: "SUMMER2024"})
+	assert.True(t, errors.IsCode(err, code.ErrResourceNotAvailable), "err=%v", err)
+	assert.Equal(t, 1, f.tx.rolledBack)
+	assert.Zero(t, f.userCoupons.owned[1001])
+}
+
+// newPromoTestRedis 连接本地Redis测试库，不可用时跳过
+func newPromoTestRedis(t *testing.T) *redis.Client {
+	client := redis.NewClient(&redis.Options{
+		Addr: "localhost:6379",
+		DB:   15, // 使用测试数据库
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skipf("redis not available: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+// testUserID 每个用例使用独立的用户ID，避免计数key互相影响
+func testUserID(t *testing.T, rdb *redis.Client) int64 {
+	userID := rand.Int63n(1<<40) + 1
+	t.Cleanup(func() {
+		rdb.Del(context.Background(),
+			fmt.Sprintf("%s%d", promoCodeRateKeyPrefix, userID),
+			fmt.Sprintf("%s%d", promoCodeFailKeyPrefix, userID))
+	})
+	return userID
+}
+
+func TestRedeemCodeRateLimit(t *testing.T) {
+	ctx := context.Background()
+	rdb := newPromoTestRedis(t)
+	userID := testUserID(t, rdb)
+	promo := &do.PromoCodeDO{ID: 1, Code: "SUMMER2024", CouponTemplateID: 10, Kind: do.PromoCodeKindPublic, Status: do.PromoCodeStatusActive}
+	f := newRedeemFixture(promo, redeemTemplate(5), rdb, &config.PromoCodeOptions{
+		RedeemRateLimit:  2,
+		RedeemRateWindow: time.Minute,
+	})
+
+	for i := 0; i < 2; i++ {
+		_, err := f.srv.RedeemCode(ctx, &dto.RedeemPromoCodeDTO{UserID: userID, Code: "NOSUCHCODE"})
+		assert.True(t, errors.IsCode(err, code.ErrPromoCodeInvalid), "err=%v", err)
+	}
+	_, err := f.srv.RedeemCode(ctx, &dto.RedeemPromoCodeDTO{UserID: userID, Code: "SUMMER2024"})
+	assert.True(t, errors.IsCode(err, code.ErrPromoCodeRateLimited), "err=%v", err)
+
+	ttl, err := rdb.TTL(ctx, fmt.Sprintf("%s%d", promoCodeRateKeyPrefix, userID)).Result()
+	require.NoError(t, err)
+	assert.True(t, ttl > 0 && ttl <= time.Minute, "ttl=%v", ttl)
+}
+
+func TestRedeemCodeFailureWindow(t *testing.T) {
+	ctx := context.Background()
+	rdb := newPromoTestRedis(t)
+	userID := testUserID(t, rdb)
+	promo := &do.PromoCodeDO{ID: 1, Code: "SUMMER2024", CouponTemplateID: 10, Kind: do.PromoCodeKindPublic, Status: do.PromoCodeStatusActive}
+	f := newRedeemFixture(promo, redeemTemplate(5), rdb, &config.PromoCodeOptions{
+		RedeemFailLimit:  2,
+		RedeemFailWindow: time.Minute,
+	})
+
+	// 格式错误与不存在的兑换码都计入失败次数
+	_, err := f.srv.RedeemCode(ctx, &dto.RedeemPromoCodeDTO{UserID: userID, Code: "x"})
+	assert.True(t, errors.IsCode(err, code.ErrPromoCodeInvalid), "err=%v", err)
+	_, err = f.srv.RedeemCode(ctx, &dto.RedeemPromoCodeDTO{UserID: userID, Code: "NOSUCHCODE"})
+	assert.True(t, errors.IsCode(err, code.ErrPromoCodeInvalid), "err=%v", err)
+
+	// 达到失败上限后有效兑换码也被拒绝
+	_, err = f.srv.RedeemCode(ctx, &dto.RedeemPromoCodeDTO{UserID: userID, Code: "SUMMER2024"})
+	assert.True(t, errors.IsCode(err, code.ErrPromoCodeRateLimited), "err=%v", err)
+	assert.Zero(t, f.tx.committed)
+
+	// 窗口过期后恢复
+	require.NoError(t, rdb.Del(ctx, fmt.Sprintf("%s%d", promoCodeFailKeyPrefix, userID)).Err())
+	_, err = f.srv.RedeemCode(ctx, &dto.RedeemPromoCodeDTO{UserID: userID, Code: "SUMMER2024"})
+	assert.NoError(t, err)
+}
+
+func TestNewPromoCode(t *testing.T) {
+	seen := make(map[string]struct{})
+	for i := 0; i < 1000; i++ {
+		c, err := newPromoCode("VIP", 12)
+		assert.NoError(t, err)
+		assert.Len(t, c, 15)
+		assert.True(t, strings.HasPrefix(c, "VIP"))
+		assert.True(t, promoCodePattern.MatchString(c))
+		for _, r := range c[3:] {
+			assert.True(t, strings.ContainsRune(promoCodeAlphabet, r), "unexpected char %q", r)
+		}
+		seen[c] = struct{}{}
+	}
+	assert.Len(t, seen, 1000)
+}
+
+func TestNormalizePromoCode(t *testing.T) {
+	assert.Equal(t, "SUMMER2024", NormalizePromoCode("  summer2024 \n"))
+	assert.False(t, promoCodePattern.MatchString(NormalizePromoCode("abc")))
+	assert.False(t, promoCodePattern.MatchString(NormalizePromoCode("SUMMER-2024")))
+}
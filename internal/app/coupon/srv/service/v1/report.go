@@ -0,0 +1,257 @@
+package v1
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"emshop/internal/app/coupon/srv/config"
+	"emshop/internal/app/coupon/srv/data/v1/interfaces"
+	"emshop/internal/app/coupon/srv/domain/do"
+	"emshop/internal/app/coupon/srv/domain/dto"
+	"emshop/internal/app/pkg/code"
+	"emshop/pkg/delayjob"
+	"emshop/pkg/errors"
+	"emshop/pkg/log"
+)
+
+const (
+	// JobTypeReportAggregate 周期性预聚合优惠券与秒杀每日统计
+	JobTypeReportAggregate = "coupon.report.aggregate"
+
+	reportDateLayout          = "2006-01-02"
+	defaultReportMaxRangeDays = 366
+)
+
+// CouponReportSrv 优惠券效果报表服务
+//
+// 报表只读取预聚合的每日统计表，统计表由定时任务按天整体重算写入，
+// 订单取消释放的优惠券在下一次重算当天时从核销中剔除
+type CouponReportSrv interface {
+	// Aggregate 重新聚合指定日期的统计
+	Aggregate(ctx context.Context, day time.Time) error
+	// Rebuild 重新聚合[from, to]内每一天的统计，返回处理天数
+	Rebuild(ctx context.Context, from, to time.Time) (int, error)
+	// CouponReport 查询优惠券模板效果报表
+	CouponReport(ctx context.Context, req *dto.CouponReportQueryDTO) (*dto.CouponReportDTO, error)
+	// FlashSaleReport 查询秒杀活动每日售罄情况
+	FlashSaleReport(ctx context.Context, req *dto.FlashSaleReportQueryDTO) ([]*dto.FlashSaleStatDTO, error)
+	// RegisterJobs 注册预聚合任务
+	RegisterJobs(scheduler *delayjob.Scheduler)
+}
+
+type couponReportService struct {
+	data interfaces.DataFactory
+	opts *config.ReportOptions
+}
+
+// NewCouponReportService 创建优惠券报表服务
+func NewCouponReportService(data interfaces.DataFactory, opts *config.ReportOptions) CouponReportSrv {
+	if opts == nil {
+		opts = &config.ReportOptions{}
+	}
+	return &couponReportService{
+		data: data,
+		opts: opts,
+	}
+}
+
+// startOfDay 返回本地时区当天零点
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.In(time.Local).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+}
+
+// reportRange 规整查询日期并检查范围，返回两端的零点
+func (rs *couponReportService) reportRange(from, to time.Time) (time.Time, time.Time, error) {
+	if from.IsZero() || to.IsZero() {
+		return time.Time{}, time.Time{}, errors.WithCode(code.ErrInvalidRequest, "开始日期和结束日期不能为空")
+	}
+	from, to = startOfDay(from), startOfDay(to)
+	if to.Before(from) {
+		return time.Time{}, time.Time{}, errors.WithCode(code.ErrInvalidRequest, "结束日期不能早于开始日期")
+	}
+	maxDays := rs.opts.MaxRangeDays
+	if maxDays <= 0 {
+		maxDays = defaultReportMaxRangeDays
+	}
+	if days := int(to.Sub(from).Hours()/24) + 1; days > maxDays {
+		return time.Time{}, time.Time{}, errors.WithCode(code.ErrInvalidRequest, "日期范围不能超过%d天", maxDays)
+	}
+	return from, to, nil
+}
+
+// Aggregate 聚合一天的优惠券与秒杀统计并整体替换当天记录
+func (rs *couponReportService) Aggregate(ctx context.Context, day time.Time) error {
+	start := startOfDay(day)
+	end := start.AddDate(0, 0, 1)
+
+	couponStats, err := rs.data.CouponReports().AggregateCouponDay(ctx, rs.data.DB(), start, end)
+	if err != nil {
+		return errors.WithCode(code.ErrDatabase, "聚合优惠券统计失败: %v", err)
+	}
+	if err := rs.data.CouponReports().ReplaceCouponStats(ctx, rs.data.DB(), start, couponStats); err != nil {
+		return errors.WithCode(code.ErrDatabase, "写入优惠券统计失败: %v", err)
+	}
+
+	flashSaleStats, err := rs.data.CouponReports().AggregateFlashSaleDay(ctx, rs.data.DB(), start, end)
+	if err != nil {
+		return errors.WithCode(code.ErrDatabase, "聚合秒杀统计失败: %v", err)
+	}
+	if err := rs.data.CouponReports().ReplaceFlashSaleStats(ctx, rs.data.DB(), start, flashSaleStats); err != nil {
+		return errors.WithCode(code.ErrDatabase, "写入秒杀统计失败: %v", err)
+	}
+	return nil
+}
+
+// Rebuild 用于上线前的历史数据回填或修复统计
+func (rs *couponReportService) Rebuild(ctx context.Context, from, to time.Time) (int, error) {
+	from, to, err := rs.reportRange(from, to)
+	if err != nil {
+		return 0, err
+	}
+	days := 0
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		if err := rs.Aggregate(ctx, day); err != nil {
+			return days, err
+		}
+		days++
+	}
+	log.InfofC(ctx, "重建优惠券报表完成: from=%s, to=%s, days=%d", from.Format(reportDateLayout), to.Format(reportDateLayout), days)
+	return days, nil
+}
+
+// CouponReport 查询模板效果报表，未指定模板时按天返回全部模板的汇总并附带各模板区间合计
+func (rs *couponReportService) CouponReport(ctx context.Context, req *dto.CouponReportQueryDTO) (*dto.CouponReportDTO, error) {
+	from, to, err := rs.reportRange(req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	days, err := rs.data.CouponReports().ListCouponStats(ctx, rs.data.DB(), req.CouponTemplateID, from, to)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, "查询优惠券统计失败: %v", err)
+	}
+
+	total := &do.CouponDailyStatDO{CouponTemplateID: req.CouponTemplateID}
+	report := &dto.CouponReportDTO{
+		Days: make([]*dto.CouponStatDTO, 0, len(days)),
+	}
+	for _, day := range days {
+		report.Days = append(report.Days, couponStatToDTO(day, day.StatDate.Format(reportDateLayout)))
+		addCouponStat(total, day)
+	}
+	report.Total = couponStatToDTO(total, "")
+
+	if req.CouponTemplateID > 0 {
+		report.Templates = []*dto.CouponStatDTO{couponStatToDTO(total, "")}
+		return report, nil
+	}
+
+	templates, err := rs.data.CouponReports().SumCouponStatsByTemplate(ctx, rs.data.DB(), from, to)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, "汇总优惠券统计失败: %v", err)
+	}
+	report.Templates = make([]*dto.CouponStatDTO, 0, len(templates))
+	for _, t := range templates {
+		report.Templates = append(report.Templates, couponStatToDTO(t, ""))
+	}
+	return report, nil
+}
+
+// FlashSaleReport 查询秒杀活动每日售出与累计售罄率
+func (rs *couponReportService) FlashSaleReport(ctx context.Context, req *dto.FlashSaleReportQueryDTO) ([]*dto.FlashSaleStatDTO, error) {
+	from, to, err := rs.reportRange(req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := rs.data.CouponReports().ListFlashSaleStats(ctx, rs.data.DB(), req.FlashSaleID, from, to)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, "查询秒杀统计失败: %v", err)
+	}
+	result := make([]*dto.FlashSaleStatDTO, 0, len(stats))
+	for _, s := range stats {
+		result = append(result, &dto.FlashSaleStatDTO{
+			Date:             s.StatDate.Format(reportDateLayout),
+			FlashSaleID:      s.FlashSaleID,
+			CouponTemplateID: s.CouponTemplateID,
+			TotalStock:       s.TotalStock,
+			SoldCount:        s.SoldCount,
+			FailedCount:      s.FailedCount,
+			CumulativeSold:   s.CumulativeSold,
+			SellThroughRate:  ratio(float64(s.CumulativeSold), float64(s.TotalStock)),
+		})
+	}
+	return result, nil
+}
+
+// RegisterJobs 注册预聚合任务，每次重算当天及之前LookbackDays天
+func (rs *couponReportService) RegisterJobs(scheduler *delayjob.Scheduler) {
+	if rs.opts.AggregateInterval <= 0 {
+		return
+	}
+	scheduler.Every(JobTypeReportAggregate, rs.opts.AggregateInterval, func(ctx context.Context, job *delayjob.Job) error {
+		today := startOfDay(time.Now())
+		for i := rs.opts.LookbackDays; i >= 0; i-- {
+			if err := rs.Aggregate(ctx, today.AddDate(0, 0, -i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// addCouponStat 累加统计，汇总记录的订单已按天去重，跨天累加不会重复计入同一订单
+func addCouponStat(total, s *do.CouponDailyStatDO) {
+	total.IssuedCount += s.IssuedCount
+	total.RedeemedCount += s.RedeemedCount
+	total.ExpiredCount += s.ExpiredCount
+	total.OrderCount += s.OrderCount
+	total.OriginalAmount += s.OriginalAmount
+	total.DiscountAmount += s.DiscountAmount
+	total.GMV += s.GMV
+}
+
+func couponStatToDTO(s *do.CouponDailyStatDO, date string) *dto.CouponStatDTO {
+	return &dto.CouponStatDTO{
+		Date:             date,
+		CouponTemplateID: s.CouponTemplateID,
+		IssuedCount:      s.IssuedCount,
+		RedeemedCount:    s.RedeemedCount,
+		ExpiredCount:     s.ExpiredCount,
+		RedemptionRate:   ratio(float64(s.RedeemedCount), float64(s.IssuedCount)),
+		OrderCount:       s.OrderCount,
+		OriginalAmount:   roundCent(s.OriginalAmount),
+		DiscountAmount:   roundCent(s.DiscountAmount),
+		GMV:              roundCent(s.GMV),
+		AvgOrderAmount:   roundCent(ratio(s.GMV, float64(s.OrderCount))),
+	}
+}
+
+// ratio 分母为0时返回0，比率保留4位小数
+func ratio(numerator, denominator float64) float64 {
+	if denominator <= 0 {
+		return 0
+	}
+	return math.Round(numerator/denominator*10000) / 10000
+}
+
+func roundCent(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// splitDiscount 将订单优惠金额按优惠券数量平摊到分，余数计入最后一张
+func splitDiscount(total float64, n int) []float64 {
+	shares := make([]float64, n)
+	if n == 0 {
+		return shares
+	}
+	cents := int64(math.Round(total * 100))
+	each := cents / int64(n)
+	for i := range shares {
+		shares[i] = float64(each) / 100
+	}
+	shares[n-1] = float64(cents-each*int64(n-1)) / 100
+	return shares
+}
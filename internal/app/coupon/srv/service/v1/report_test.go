@@ -0,0 +1,54 @@
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emshop/internal/app/coupon/srv/config"
+	"emshop/internal/app/coupon/srv/domain/do"
+	"emshop/internal/app/coupon/srv/domain/dto"
+	"emshop/internal/app/pkg/code"
+	"emshop/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitDiscount(t *testing.T) {
+	assert.Equal(t, []float64{3.33, 3.33, 3.34}, splitDiscount(10, 3))
+	assert.Equal(t, []float64{5.5}, splitDiscount(5.5, 1))
+	assert.Empty(t, splitDiscount(5, 0))
+}
+
+func TestReportRangeValidation(t *testing.T) {
+	mockData := new(MockDataFactory)
+	srv := NewCouponReportService(mockData, &config.ReportOptions{MaxRangeDays: 31})
+
+	day := time.Date(2024, 3, 10, 15, 0, 0, 0, time.Local)
+	cases := []*dto.CouponReportQueryDTO{
+		{From: time.Time{}, To: day},
+		{From: day, To: day.AddDate(0, 0, -1)},
+		{From: day, To: day.AddDate(0, 0, 31)},
+	}
+	for _, req := range cases {
+		_, err := srv.CouponReport(context.Background(), req)
+		assert.True(t, errors.IsCode(err, code.ErrInvalidRequest), "req=%+v, err=%v", req, err)
+	}
+	mockData.AssertNotCalled(t, "CouponReports")
+}
+
+func TestCouponStatToDTORates(t *testing.T) {
+	stat := couponStatToDTO(&do.CouponDailyStatDO{
+		CouponTemplateID: 3,
+		IssuedCount:      3,
+		RedeemedCount:    1,
+		OrderCount:       2,
+		GMV:              301,
+	}, "2024-03-10")
+	assert.Equal(t, 0.3333, stat.RedemptionRate)
+	assert.Equal(t, 150.5, stat.AvgOrderAmount)
+
+	empty := couponStatToDTO(&do.CouponDailyStatDO{}, "")
+	assert.Zero(t, empty.RedemptionRate)
+	assert.Zero(t, empty.AvgOrderAmount)
+}
@@ -0,0 +1,443 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"emshop/internal/app/coupon/srv/config"
+	"emshop/internal/app/coupon/srv/data/v1/interfaces"
+	"emshop/internal/app/coupon/srv/domain/do"
+	"emshop/internal/app/coupon/srv/domain/dto"
+	"emshop/internal/app/coupon/srv/pkg/cache"
+	"emshop/internal/app/coupon/srv/pkg/risk"
+	"emshop/internal/app/coupon/srv/pkg/scripts"
+	"emshop/internal/app/pkg/code"
+	v1 "emshop/pkg/common/meta/v1"
+	"emshop/pkg/errors"
+	"emshop/pkg/log"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	defaultRiskWindow               = time.Minute
+	defaultRiskCaptchaFailureWindow = time.Hour
+	defaultRiskPageSize             = 20
+	maxRiskPageSize                 = 200
+	// riskRecordConcurrency 放行决策异步写入的最大并发，超出时丢弃记录
+	riskRecordConcurrency = 32
+	riskRecordTimeout     = 3 * time.Second
+)
+
+// RiskSrv 领券、秒杀与短信发送的风控评分服务
+//
+// 设备、IP、号段请求频次与验证码失败次数在Redis中按固定窗口统计，注册时间与共用地址账号数由网关采集；
+// Redis不可用时相应信号按0计算，不阻断正常请求
+type RiskSrv interface {
+	// Enabled 是否开启风控，关闭时评分直接放行且不记录
+	Enabled() bool
+	// Evaluate 统计本次请求并评分，验证码与拒绝决策同步写入决策记录，放行决策异步抽样写入
+	Evaluate(ctx context.Context, req *dto.RiskEvaluateDTO) (*dto.RiskDecisionDTO, error)
+	// Check 评分并将验证码、拒绝动作转换为业务错误，供领券和秒杀入口调用
+	Check(ctx context.Context, req *dto.RiskEvaluateDTO) error
+	// ListDecisions 分页查询决策记录
+	ListDecisions(ctx context.Context, req *dto.ListRiskDecisionsDTO) (*dto.RiskDecisionListDTO, error)
+}
+
+type riskService struct {
+	data         interfaces.DataFactory
+	redisClient  *redis.Client
+	keyFormatter *scripts.RedisKeyFormatter
+	opts         *config.RiskOptions
+	recorders    chan struct{}
+	activities   interface {
+		GetFlashSaleActivity(ctx context.Context, activityID int64) (*cache.FlashSaleActivity, error)
+	}
+}
+
+// NewRiskService 创建风控评分服务，activities用于读取秒杀开始时间，为空时从数据库读取
+func NewRiskService(data interfaces.DataFactory, redisClient *redis.Client, opts *config.RiskOptions, activities interface {
+	GetFlashSaleActivity(ctx context.Context, activityID int64) (*cache.FlashSaleActivity, error)
+}) RiskSrv {
+	if opts == nil {
+		opts = &config.RiskOptions{}
+	}
+	return &riskService{
+		data:         data,
+		redisClient:  redisClient,
+		keyFormatter: scripts.NewRedisKeyFormatter(),
+		opts:         opts,
+		recorders:    make(chan struct{}, riskRecordConcurrency),
+		activities:   activities,
+	}
+}
+
+func (rs *riskService) Enabled() bool {
+	return rs.opts.Enabled
+}
+
+// riskSignalsSnapshot 写入决策记录的信号快照
+type riskSignalsSnapshot struct {
+	DeviceCount        int64  `json:"device_count"`
+	IPCount            int64  `json:"ip_count"`
+	MobilePrefixCount  int64  `json:"mobile_prefix_count"`
+	RegisteredAt       int64  `json:"registered_at,omitempty"`
+	Reference          int64  `json:"reference"`
+	SharedAddressUsers int32  `json:"shared_address_users"`
+	CaptchaFailures    int64  `json:"captcha_failures"`
+	CaptchaResult      int32  `json:"captcha_result"`
+	Degraded           string `json:"degraded,omitempty"` // 信号采集失败原因
+}
+
+func (rs *riskService) Evaluate(ctx context.Context, req *dto.RiskEvaluateDTO) (*dto.RiskDecisionDTO, error) {
+	if req == nil || req.Scene == "" {
+		return nil, errors.WithCode(code.ErrInvalidRequest, "风控场景不能为空")
+	}
+	rc := req.Context
+	if rc == nil {
+		rc = &dto.RiskContextDTO{}
+	}
+	now := time.Now()
+	result := &dto.RiskDecisionDTO{
+		Scene:     req.Scene,
+		TargetID:  req.TargetID,
+		UserID:    req.UserID,
+		Mobile:    rc.Mobile,
+		DeviceID:  rc.DeviceID,
+		ClientIP:  rc.ClientIP,
+		Action:    string(risk.ActionAllow),
+		CreatedAt: now,
+	}
+	if !rs.opts.Enabled {
+		return result, nil
+	}
+
+	signals := risk.Signals{
+		RegisteredAt:       rc.RegisteredAt,
+		Reference:          rs.reference(ctx, req, now),
+		SharedAddressUsers: rc.SharedAddressUsers,
+		CaptchaPassed:      rc.CaptchaResult == dto.CaptchaPassed,
+	}
+	snapshot := riskSignalsSnapshot{
+		Reference:          signals.Reference.Unix(),
+		SharedAddressUsers: rc.SharedAddressUsers,
+		CaptchaResult:      rc.CaptchaResult,
+	}
+	if !rc.RegisteredAt.IsZero() {
+		snapshot.RegisteredAt = rc.RegisteredAt.Unix()
+	}
+
+	var degraded []string
+	if err := rs.countVelocity(ctx, req.Scene, rc, now, &signals); err != nil {
+		log.WarnfC(ctx, "风控频次统计失败，按0计算: scene=%s, err=%v", req.Scene, err)
+		degraded = append(degraded, "velocity")
+	}
+	failures, err := rs.captchaFailures(ctx, req.UserID, rc)
+	if err != nil {
+		log.WarnfC(ctx, "风控验证码失败次数统计失败，按0计算: scene=%s, err=%v", req.Scene, err)
+		degraded = append(degraded, "captcha")
+	}
+	signals.CaptchaFailures = failures
+	snapshot.DeviceCount = signals.DeviceCount
+	snapshot.IPCount = signals.IPCount
+	snapshot.MobilePrefixCount = signals.MobilePrefixCount
+	snapshot.CaptchaFailures = failures
+	snapshot.Degraded = strings.Join(degraded, ",")
+
+	decision := riskPolicy(rs.opts, req.Scene).Evaluate(signals)
+	result.Score = decision.Score
+	result.Action = string(decision.Action)
+	result.Reasons = decision.Reasons
+	if raw, err := json.Marshal(snapshot); err == nil {
+		result.Signals = string(raw)
+	}
+
+	record := &do.RiskDecisionDO{
+		Scene:     result.Scene,
+		TargetID:  result.TargetID,
+		UserID:    result.UserID,
+		Mobile:    result.Mobile,
+		DeviceID:  result.DeviceID,
+		ClientIP:  result.ClientIP,
+		Score:     result.Score,
+		Action:    result.Action,
+		Reasons:   strings.Join(result.Reasons, ","),
+		Signals:   result.Signals,
+		CreatedAt: now,
+	}
+	// 决策记录只用于复核，写入失败不影响本次决策；放行决策占绝大多数，
+	// 异步写入命中信号的记录并抽样写入其余记录，避免每个请求都写一次MySQL
+	switch {
+	case decision.Action != risk.ActionAllow:
+		if err := rs.data.RiskDecisions().Create(ctx, rs.data.DB(), record); err != nil {
+			log.ErrorfC(ctx, "写入风控决策记录失败: scene=%s, userID=%d, err=%v", req.Scene, req.UserID, err)
+		} else {
+			result.ID = record.ID
+		}
+	case len(decision.Reasons) > 0 || rs.sampleAllowed():
+		rs.recordAsync(ctx, record)
+	}
+
+	if decision.Action != risk.ActionAllow {
+		log.InfofC(ctx, "风控拦截: scene=%s, userID=%d, device=%s, ip=%s, score=%d, action=%s, reasons=%v",
+			req.Scene, req.UserID, rc.DeviceID, rc.ClientIP, decision.Score, decision.Action, decision.Reasons)
+	}
+	return result, nil
+}
+
+// sampleAllowed 按抽样比例决定是否记录未命中任何信号的放行决策
+func (rs *riskService) sampleAllowed() bool {
+	return rs.opts.AllowSampleRate > 0 && rand.Float64() < rs.opts.AllowSampleRate
+}
+
+// recordAsync 在后台写入放行决策，写入并发已满时丢弃
+func (rs *riskService) recordAsync(ctx context.Context, record *do.RiskDecisionDO) {
+	select {
+	case rs.recorders <- struct{}{}:
+	default:
+		log.WarnfC(ctx, "风控决策记录写入繁忙，丢弃放行记录: scene=%s, userID=%d", record.Scene, record.UserID)
+		return
+	}
+	go func() {
+		defer func() { <-rs.recorders }()
+		wctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), riskRecordTimeout)
+		defer cancel()
+		if err := rs.data.RiskDecisions().Create(wctx, rs.data.DB(), record); err != nil {
+			log.ErrorfC(ctx, "写入风控决策记录失败: scene=%s, userID=%d, err=%v", record.Scene, record.UserID, err)
+		}
+	}()
+}
+
+func (rs *riskService) Check(ctx context.Context, req *dto.RiskEvaluateDTO) error {
+	if !rs.opts.Enabled {
+		return nil
+	}
+	decision, err := rs.Evaluate(ctx, req)
+	if err != nil {
+		return err
+	}
+	switch risk.Action(decision.Action) {
+	case risk.ActionCaptcha:
+		return errors.WithCode(code.ErrRiskCaptchaRequired, "操作过于频繁，请完成验证码后重试")
+	case risk.ActionBlock:
+		return errors.WithCode(code.ErrRiskBlocked, "请求存在风险，已被拒绝")
+	}
+	return nil
+}
+
+func (rs *riskService) ListDecisions(ctx context.Context, req *dto.ListRiskDecisionsDTO) (*dto.RiskDecisionListDTO, error) {
+	if req.Action != "" {
+		if _, ok := risk.ParseAction(req.Action); !ok {
+			return nil, errors.WithCode(code.ErrInvalidRequest, "未知的风控动作: %s", req.Action)
+		}
+	}
+	page, pageSize := int(req.Page), int(req.PageSize)
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = defaultRiskPageSize
+	}
+	if pageSize > maxRiskPageSize {
+		pageSize = maxRiskPageSize
+	}
+
+	filter := &do.RiskDecisionFilter{
+		Scene:    req.Scene,
+		Action:   strings.ToLower(req.Action),
+		UserID:   req.UserID,
+		DeviceID: req.DeviceID,
+		ClientIP: req.ClientIP,
+		From:     req.From,
+		To:       req.To,
+	}
+	list, err := rs.data.RiskDecisions().List(ctx, rs.data.DB(), filter, v1.ListMeta{Page: page, PageSize: pageSize})
+	if err != nil {
+		return nil, errors.WithCode(code.ErrDatabase, "查询风控决策记录失败: %v", err)
+	}
+
+	items := make([]*dto.RiskDecisionDTO, 0, len(list.Items))
+	for _, item := range list.Items {
+		items = append(items, &dto.RiskDecisionDTO{
+			ID:        item.ID,
+			Scene:     item.Scene,
+			TargetID:  item.TargetID,
+			UserID:    item.UserID,
+			Mobile:    item.Mobile,
+			DeviceID:  item.DeviceID,
+			ClientIP:  item.ClientIP,
+			Score:     item.Score,
+			Action:    item.Action,
+			Reasons:   splitReasons(item.Reasons),
+			Signals:   item.Signals,
+			CreatedAt: item.CreatedAt,
+		})
+	}
+	return &dto.RiskDecisionListDTO{TotalCount: list.TotalCount, Items: items}, nil
+}
+
+// reference 新账号判断的参考时间，秒杀取活动开始时间
+func (rs *riskService) reference(ctx context.Context, req *dto.RiskEvaluateDTO, now time.Time) time.Time {
+	if req.Scene != do.RiskSceneFlashSale || req.TargetID <= 0 {
+		return now
+	}
+	if rs.activities != nil {
+		if activity, err := rs.activities.GetFlashSaleActivity(ctx, req.TargetID); err == nil && activity != nil {
+			return activity.StartTime
+		}
+	}
+	activityDO, err := rs.data.FlashSales().Get(ctx, rs.data.DB(), req.TargetID)
+	if err != nil || activityDO == nil {
+		return now
+	}
+	return activityDO.StartTime
+}
+
+// countVelocity 在当前窗口内累加设备、IP与号段请求数
+func (rs *riskService) countVelocity(ctx context.Context, scene string, rc *dto.RiskContextDTO, now time.Time, signals *risk.Signals) error {
+	window := rs.opts.Window
+	if window <= 0 {
+		window = defaultRiskWindow
+	}
+	bucket := velocityBucket(now, window)
+
+	type counter struct {
+		target *int64
+		cmd    *redis.IntCmd
+	}
+	var counters []counter
+	pipe := rs.redisClient.Pipeline()
+	add := func(dimension, value string, target *int64) {
+		if value == "" {
+			return
+		}
+		key := rs.keyFormatter.RiskVelocityKey(scene, dimension, value, bucket)
+		counters = append(counters, counter{target: target, cmd: pipe.Incr(ctx, key)})
+		pipe.Expire(ctx, key, window)
+	}
+	add("device", rc.DeviceID, &signals.DeviceCount)
+	add("ip", rc.ClientIP, &signals.IPCount)
+	add("mobile", risk.MobilePrefix(rc.Mobile, rs.opts.MobilePrefixLength), &signals.MobilePrefixCount)
+	if len(counters) == 0 {
+		return nil
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+	for _, c := range counters {
+		*c.target = c.cmd.Val()
+	}
+	return nil
+}
+
+// captchaFailures 返回用户、设备与手机号中最多的验证码失败次数，本次失败时先累加
+func (rs *riskService) captchaFailures(ctx context.Context, userID int64, rc *dto.RiskContextDTO) (int64, error) {
+	subjects := captchaSubjects(userID, rc)
+	if len(subjects) == 0 {
+		return 0, nil
+	}
+	window := rs.opts.CaptchaFailureWindow
+	if window <= 0 {
+		window = defaultRiskCaptchaFailureWindow
+	}
+
+	pipe := rs.redisClient.Pipeline()
+	cmds := make([]*redis.StringCmd, 0, len(subjects))
+	incrs := make([]*redis.IntCmd, 0, len(subjects))
+	for _, subject := range subjects {
+		key := rs.keyFormatter.RiskCaptchaFailureKey(subject)
+		if rc.CaptchaResult == dto.CaptchaFailed {
+			incrs = append(incrs, pipe.Incr(ctx, key))
+			pipe.Expire(ctx, key, window)
+		} else {
+			cmds = append(cmds, pipe.Get(ctx, key))
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return 0, err
+	}
+
+	var failures int64
+	for _, cmd := range incrs {
+		if cmd.Val() > failures {
+			failures = cmd.Val()
+		}
+	}
+	for _, cmd := range cmds {
+		if n, err := cmd.Int64(); err == nil && n > failures {
+			failures = n
+		}
+	}
+	return failures, nil
+}
+
+// riskPolicy 按场景合并评分策略，场景未配置的分数沿用全局配置
+func riskPolicy(opts *config.RiskOptions, scene string) *risk.Policy {
+	policy := &risk.Policy{
+		DeviceLimit:          opts.DeviceLimit,
+		DeviceWeight:         opts.DeviceWeight,
+		IPLimit:              opts.IPLimit,
+		IPWeight:             opts.IPWeight,
+		MobilePrefixLimit:    opts.MobilePrefixLimit,
+		MobilePrefixWeight:   opts.MobilePrefixWeight,
+		NewAccountAge:        opts.NewAccountAge,
+		NewAccountWeight:     opts.NewAccountWeight,
+		SharedAddressLimit:   opts.SharedAddressLimit,
+		SharedAddressWeight:  opts.SharedAddressWeight,
+		CaptchaFailureLimit:  opts.CaptchaFailureLimit,
+		CaptchaFailureWeight: opts.CaptchaFailureWeight,
+		CaptchaScore:         opts.CaptchaScore,
+		BlockScore:           opts.BlockScore,
+	}
+	sceneOpts := opts.Scenes[scene]
+	if sceneOpts == nil {
+		return policy
+	}
+	if sceneOpts.CaptchaScore > 0 {
+		policy.CaptchaScore = sceneOpts.CaptchaScore
+	}
+	if sceneOpts.BlockScore > 0 {
+		policy.BlockScore = sceneOpts.BlockScore
+	}
+	if sceneOpts.MaxAction != "" {
+		if action, ok := risk.ParseAction(sceneOpts.MaxAction); ok {
+			policy.MaxAction = action
+		} else {
+			log.Warnf("风控场景%s的max_action无效: %s", scene, sceneOpts.MaxAction)
+		}
+	}
+	return policy
+}
+
+// velocityBucket 固定统计窗口序号
+func velocityBucket(now time.Time, window time.Duration) int64 {
+	return now.UnixNano() / int64(window)
+}
+
+// captchaSubjects 验证码失败次数的统计主体
+func captchaSubjects(userID int64, rc *dto.RiskContextDTO) []string {
+	var subjects []string
+	if userID > 0 {
+		subjects = append(subjects, "u:"+strconv.FormatInt(userID, 10))
+	}
+	if rc.DeviceID != "" {
+		subjects = append(subjects, "d:"+rc.DeviceID)
+	}
+	if rc.Mobile != "" {
+		subjects = append(subjects, "m:"+rc.Mobile)
+	}
+	return subjects
+}
+
+// splitReasons 解析逗号分隔的命中信号
+func splitReasons(reasons string) []string {
+	if reasons == "" {
+		return nil
+	}
+	return strings.Split(reasons, ",")
+}
@@ -0,0 +1,63 @@
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emshop/internal/app/coupon/srv/config"
+	"emshop/internal/app/coupon/srv/domain/do"
+	"emshop/internal/app/coupon/srv/domain/dto"
+	"emshop/internal/app/coupon/srv/pkg/risk"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRiskPolicySceneOverride(t *testing.T) {
+	opts := config.New().Business.Risk
+	opts.Scenes = map[string]*config.RiskSceneOptions{
+		do.RiskSceneSms:       {CaptchaScore: 20},
+		do.RiskSceneFlashSale: {MaxAction: "captcha"},
+		"bad":                 {MaxAction: "deny"},
+	}
+
+	base := riskPolicy(opts, do.RiskSceneReceiveCoupon)
+	assert.Equal(t, 40, base.CaptchaScore)
+	assert.Equal(t, 80, base.BlockScore)
+	assert.Equal(t, risk.Action(""), base.MaxAction)
+
+	sms := riskPolicy(opts, do.RiskSceneSms)
+	assert.Equal(t, 20, sms.CaptchaScore)
+	assert.Equal(t, 80, sms.BlockScore, "未配置的分数沿用全局配置")
+
+	assert.Equal(t, risk.ActionCaptcha, riskPolicy(opts, do.RiskSceneFlashSale).MaxAction)
+	assert.Equal(t, risk.Action(""), riskPolicy(opts, "bad").MaxAction, "无效动作被忽略")
+}
+
+func TestVelocityBucket(t *testing.T) {
+	base := time.Unix(1_700_000_040, 0)
+	assert.Equal(t, velocityBucket(base, time.Minute), velocityBucket(base.Add(59*time.Second), time.Minute))
+	assert.NotEqual(t, velocityBucket(base, time.Minute), velocityBucket(base.Add(time.Minute), time.Minute))
+}
+
+func TestCaptchaSubjects(t *testing.T) {
+	assert.Empty(t, captchaSubjects(0, &dto.RiskContextDTO{}))
+	assert.Equal(t, []string{"u:7", "d:dev", "m:13800138000"},
+		captchaSubjects(7, &dto.RiskContextDTO{DeviceID: "dev", Mobile: "13800138000"}))
+}
+
+func TestSplitReasons(t *testing.T) {
+	assert.Nil(t, splitReasons(""))
+	assert.Equal(t, []string{risk.SignalDeviceVelocity, risk.SignalNewAccount},
+		splitReasons(risk.SignalDeviceVelocity+","+risk.SignalNewAccount))
+}
+
+func TestRiskDisabledAllows(t *testing.T) {
+	srv := NewRiskService(nil, nil, &config.RiskOptions{}, nil)
+	assert.NoError(t, srv.Check(context.Background(), &dto.RiskEvaluateDTO{Scene: do.RiskSceneReceiveCoupon, UserID: 1}))
+
+	decision, err := srv.Evaluate(context.Background(), &dto.RiskEvaluateDTO{Scene: do.RiskSceneSms})
+	assert.NoError(t, err)
+	assert.Equal(t, string(risk.ActionAllow), decision.Action)
+	assert.Zero(t, decision.ID)
+}
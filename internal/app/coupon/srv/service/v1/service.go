@@ -6,6 +6,7 @@ import (
 	"emshop/internal/app/coupon/srv/consumer"
 	"emshop/internal/app/coupon/srv/data/v1/interfaces"
 	"emshop/internal/app/coupon/srv/pkg/cache"
+	"emshop/internal/app/coupon/srv/pkg/calculator"
 	"emshop/internal/app/pkg/options"
 	"emshop/pkg/log"
 	v1 "emshop/pkg/common/meta/v1"
@@ -17,6 +18,16 @@ type Service struct {
 	CouponSrv           CouponSrv
 	FlashSaleSrv        FlashSaleSrv
 	FlashSaleCore       FlashSaleSrvCore  // 新的秒杀核心服务
+	FlashSaleGoods      FlashSaleGoodsSrv // 商品秒杀
+	ExpirySrv           CouponExpirySrv   // 优惠券到期处理
+	FlashSaleLifecycle  FlashSaleLifecycleSrv // 秒杀活动自动启停
+	RuleSrv             CouponRuleSrv         // 业务规则配置
+	PromoCodeSrv        PromoCodeSrv          // 兑换码
+	CampaignSrv         CouponCampaignSrv     // 定向发券活动
+	CampaignProducer    consumer.CampaignBatchProducer // 发券批次消息生产者
+	ReportSrv           CouponReportSrv       // 效果报表
+	WaitingRoom         FlashSaleWaitingRoom  // 秒杀排队
+	RiskSrv             RiskSrv               // 风控评分
 	DTMManager          *CouponDTMManager
 	CacheManager        cache.CacheManager
 	EventProducer       consumer.FlashSaleEventProducer // RocketMQ事件生产者
@@ -33,6 +44,7 @@ func NewService(
 	rocketmqOpts *options.RocketMQOptions,
 	cacheConfig *cache.CacheConfig,
 	bizOpts *config.BusinessOptions,
+	orders FlashSaleOrderCloser,
 ) *Service {
 	// 创建缓存适配器，将数据层接口适配为缓存仓库接口
 	cacheRepository := newCacheRepositoryAdapter(data)
@@ -108,16 +120,94 @@ func NewService(
 		log.Info("使用事务消息生产者作为主要事件生产者")
 	}
 	
+	// 业务规则引擎在计算引擎与规则配置服务间共享，规则参数变更后对计算立即生效
+	ruleEngine := calculator.NewBusinessRuleEngine()
+
+	var flashSaleOpts *config.FlashSaleOptions
+	stockShards := 1
+	if bizOpts != nil && bizOpts.FlashSale != nil {
+		flashSaleOpts = bizOpts.FlashSale
+		stockShards = flashSaleOpts.StockShards
+	}
+
+	flashSaleSrv := newFlashSaleService(data, redisClient, cacheManager, stockShards)
 	service := &Service{
-		CouponSrv:           NewCouponService(data, redisClient, dtmOpts, cacheManager),
-		FlashSaleSrv:        NewFlashSaleService(data, redisClient, cacheManager),
-		FlashSaleCore:       NewFlashSaleSrvCore(data, redisClient, cacheManager, finalEventProducer),
+		CouponSrv:           NewCouponService(data, redisClient, dtmOpts, cacheManager, ruleEngine),
+		RuleSrv:             NewCouponRuleService(data, ruleEngine),
+		FlashSaleSrv:        flashSaleSrv,
+		FlashSaleCore:       NewFlashSaleSrvCore(data, redisClient, cacheManager, finalEventProducer, stockShards),
 		CacheManager:        cacheManager,
 		EventProducer:       eventProducer,
 		TransactionProducer: transactionProducer,
 		RetryManager:        retryManager,
 	}
 
+	var expiryOpts *config.ExpiryOptions
+	if bizOpts != nil {
+		expiryOpts = bizOpts.Expiry
+	}
+	// 缓存管理器初始化失败时为nil指针，需避免传入非nil接口
+	var expiryCache interface{ InvalidateCache(keys ...string) }
+	if cacheManager != nil {
+		expiryCache = cacheManager
+	}
+	service.ExpirySrv = NewCouponExpiryService(data, expiryOpts, expiryCache)
+
+	var promoCodeOpts *config.PromoCodeOptions
+	if bizOpts != nil {
+		promoCodeOpts = bizOpts.PromoCode
+	}
+	service.PromoCodeSrv = NewPromoCodeService(data, redisClient, promoCodeOpts, expiryCache)
+
+	var campaignOpts *config.CampaignOptions
+	if bizOpts != nil {
+		campaignOpts = bizOpts.Campaign
+	}
+	// 生产者初始化失败时活动仍可创建和追加目标，只是不会投递
+	var campaignProducer consumer.CampaignBatchProducer
+	if rocketmqOpts != nil && campaignOpts != nil && campaignOpts.Topic != "" {
+		producer, err := consumer.NewCampaignBatchProducer(
+			rocketmqOpts.NameServers,
+			"coupon-campaign-producer-group",
+			campaignOpts.Topic,
+		)
+		if err != nil {
+			log.Errorf("初始化发券批次生产者失败: %v", err)
+		} else {
+			campaignProducer = producer
+			service.CampaignProducer = producer
+		}
+	}
+	service.CampaignSrv = NewCouponCampaignService(data, campaignProducer, campaignOpts, expiryCache)
+
+	var reportOpts *config.ReportOptions
+	if bizOpts != nil {
+		reportOpts = bizOpts.Report
+	}
+	service.ReportSrv = NewCouponReportService(data, reportOpts)
+
+	service.FlashSaleGoods = NewFlashSaleGoodsService(data, flashSaleSrv, orders, flashSaleOpts)
+
+	service.FlashSaleLifecycle = NewFlashSaleLifecycle(data, redisClient, service.FlashSaleCore, flashSaleOpts)
+
+	var waitingRoomOpts *config.WaitingRoomOptions
+	if bizOpts != nil {
+		waitingRoomOpts = bizOpts.WaitingRoom
+	}
+	var activityCache interface {
+		GetFlashSaleActivity(ctx context.Context, activityID int64) (*cache.FlashSaleActivity, error)
+	}
+	if cacheManager != nil {
+		activityCache = cacheManager
+	}
+	service.WaitingRoom = NewFlashSaleWaitingRoom(data, redisClient, waitingRoomOpts, activityCache)
+
+	var riskOpts *config.RiskOptions
+	if bizOpts != nil {
+		riskOpts = bizOpts.Risk
+	}
+	service.RiskSrv = NewRiskService(data, redisClient, riskOpts, activityCache)
+
 	if bizOpts != nil && bizOpts.FlashSale != nil && bizOpts.FlashSale.EnableAsync && finalEventProducer != nil {
 		service.asyncFlashSale = true
 	}
@@ -151,6 +241,13 @@ func (s *Service) Shutdown() error {
 		}
 	}
 	
+	// 关闭发券批次生产者
+	if s.CampaignProducer != nil {
+		if err := s.CampaignProducer.Shutdown(); err != nil {
+			log.Errorf("关闭发券批次生产者失败: %v", err)
+		}
+	}
+	
 	// 关闭重试管理器
 	if s.RetryManager != nil {
 		if err := s.RetryManager.Shutdown(); err != nil {
@@ -43,6 +43,36 @@ func (m *MockDataFactory) CouponConfigs() interfaces.CouponConfigDataInterface {
 	return args.Get(0).(interfaces.CouponConfigDataInterface)
 }
 
+func (m *MockDataFactory) CouponReminders() interfaces.CouponReminderDataInterface {
+	args := m.Called()
+	return args.Get(0).(interfaces.CouponReminderDataInterface)
+}
+
+func (m *MockDataFactory) PromoCodes() interfaces.PromoCodeDataInterface {
+	args := m.Called()
+	return args.Get(0).(interfaces.PromoCodeDataInterface)
+}
+
+func (m *MockDataFactory) CouponCampaigns() interfaces.CouponCampaignDataInterface {
+	args := m.Called()
+	return args.Get(0).(interfaces.CouponCampaignDataInterface)
+}
+
+func (m *MockDataFactory) CampaignTargets() interfaces.CampaignTargetDataInterface {
+	args := m.Called()
+	return args.Get(0).(interfaces.CampaignTargetDataInterface)
+}
+
+func (m *MockDataFactory) CouponReports() interfaces.CouponReportDataInterface {
+	args := m.Called()
+	return args.Get(0).(interfaces.CouponReportDataInterface)
+}
+
+func (m *MockDataFactory) RiskDecisions() interfaces.RiskDecisionDataInterface {
+	args := m.Called()
+	return args.Get(0).(interfaces.RiskDecisionDataInterface)
+}
+
 func (m *MockDataFactory) FlashSales() interfaces.FlashSaleDataInterface {
 	args := m.Called()
 	return args.Get(0).(interfaces.FlashSaleDataInterface)
@@ -53,6 +83,11 @@ func (m *MockDataFactory) FlashSaleRecords() interfaces.FlashSaleRecordDataInter
 	return args.Get(0).(interfaces.FlashSaleRecordDataInterface)
 }
 
+func (m *MockDataFactory) FlashSaleReservations() interfaces.FlashSaleReservationDataInterface {
+	args := m.Called()
+	return args.Get(0).(interfaces.FlashSaleReservationDataInterface)
+}
+
 func (m *MockDataFactory) DB() *gorm.DB {
 	args := m.Called()
 	return args.Get(0).(*gorm.DB)
@@ -123,6 +158,11 @@ func (m *MockCouponTemplateData) CheckTemplateAvailability(ctx context.Context,
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockCouponTemplateData) IncrUsedCountIfAvailable(ctx context.Context, db *gorm.DB, templateID int64) (bool, error) {
+	args := m.Called(ctx, db, templateID)
+	return args.Bool(0), args.Error(1)
+}
+
 // MockFlashSaleEventProducer 模拟事件生产者
 type MockFlashSaleEventProducer struct {
 	mock.Mock
@@ -217,7 +257,7 @@ func TestService_Creation(t *testing.T) {
 	// 测试服务创建
 	bizConfig := &config.BusinessOptions{FlashSale: &config.FlashSaleOptions{EnableAsync: false}}
 
-	service := NewService(mockData, redisClient, dtmOpts, rocketmqOpts, cacheConfig, bizConfig)
+	service := NewService(mockData, redisClient, dtmOpts, rocketmqOpts, cacheConfig, bizConfig, nil)
 	
 	// 断言
 	assert.NotNil(t, service)
@@ -277,7 +317,7 @@ func TestCouponService_CreateTemplate(t *testing.T) {
 	mockTemplateData.On("Create", mock.Anything, mock.Anything, mock.AnythingOfType("*do.CouponTemplateDO")).Return(nil)
 	
 	// 创建服务
-	couponSrv := NewCouponService(mockData, nil, nil, mockCacheManager)
+	couponSrv := NewCouponService(mockData, nil, nil, mockCacheManager, nil)
 	
 	// 断言Mock调用
 	assert.NotNil(t, couponSrv)
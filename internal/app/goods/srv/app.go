@@ -1,16 +1,19 @@
 package srv
 
 import (
-	"github.com/hashicorp/consul/api"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
 	"emshop/internal/app/goods/srv/config"
 	"emshop/internal/app/goods/srv/consumer"
 	"emshop/internal/app/pkg/options"
+	"emshop/internal/app/pkg/discovery"
 	gapp "emshop/gin-micro/app"
 	"emshop/pkg/app"
+	"emshop/pkg/dlq"
 	"emshop/pkg/log"
 
 	"emshop/gin-micro/registry"
-	"emshop/gin-micro/registry/consul"
 )
 
 func NewApp(basename string) *app.App {
@@ -25,26 +28,10 @@ func NewApp(basename string) *app.App {
 }
 
 func NewRegistrar(registry *options.RegistryOptions, dev bool) registry.Registrar {
-    c := api.DefaultConfig()
-    c.Address = registry.Address
-    c.Scheme = registry.Scheme
-    cli, err := api.NewClient(c)
+    r, err := discovery.NewRegistrar(registry, dev)
     if err != nil {
         panic(err)
     }
-    opts := []consul.Option{consul.WithHealthCheck(true)}
-    if registry.HealthCheckInterval > 0 {
-        opts = append(opts, consul.WithHealthCheckInterval(registry.HealthCheckInterval))
-    }
-    if registry.CheckTimeout > 0 {
-        opts = append(opts, consul.WithCheckTimeout(registry.CheckTimeout))
-    }
-    if dev {
-        opts = append(opts, consul.WithDeregisterCriticalServiceAfter(60))
-    } else if registry.DeregisterCriticalAfter > 0 {
-        opts = append(opts, consul.WithDeregisterCriticalServiceAfter(registry.DeregisterCriticalAfter))
-    }
-    r := consul.New(cli, opts...)
     return r
 }
 
@@ -72,6 +59,17 @@ func NewGoodsApp(cfg *config.Config) (*gapp.App, error) {
 	
 	canalConsumer := consumer.NewCanalConsumer(canalConsumerConfig, factoryManager.GetSyncManager())
 
+	// 配置死信命名空间后，最后一次重投仍失败的消息写入共享死信存储
+	if cfg.DLQ.Namespace != "" {
+		redisClient := redis.NewClient(&redis.Options{
+			Addr:     fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port),
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.Database,
+		})
+		store := dlq.NewRedisStore(redisClient, cfg.DLQ.StoreOptions(cfg.DLQ.Namespace))
+		canalConsumer.SetDeadLetter(dlq.NewRecorder(store, cfg.RocketMQ.ConsumerGroup, cfg.RocketMQ.MaxReconsume))
+	}
+
 	// 在后台启动Canal消费者
 	go func() {
 		log.Info("starting canal consumer in background")
@@ -82,6 +80,8 @@ func NewGoodsApp(cfg *config.Config) (*gapp.App, error) {
 
 	return gapp.New(
 		gapp.WithName(cfg.Server.Name),
+		gapp.WithVersion(cfg.Server.Version),
+		gapp.WithMetadata(cfg.Server.Metadata),
 		gapp.WithRPCServer(rpcServer),
 		gapp.WithRegistrar(register),
 	), nil
@@ -15,6 +15,8 @@ type Config struct {
 	Telemetry    *options.TelemetryOptions `json:"telemetry" mapstructure:"telemetry"`
 	MySQLOptions *options.MySQLOptions     `json:"mysql" mapstructure:"mysql"`
 	RocketMQ     *options.RocketMQOptions  `json:"rocketmq" mapstructure:"rocketmq"`
+	Redis        *options.RedisOptions     `json:"redis" mapstructure:"redis"`
+	DLQ          *options.DLQOptions       `json:"dlq" mapstructure:"dlq"`
 }
 
 func (c *Config) Validate() []error {
@@ -26,6 +28,8 @@ func (c *Config) Validate() []error {
 	errors = append(errors, c.MySQLOptions.Validate()...)
 	errors = append(errors, c.EsOptions.Validate()...)
 	errors = append(errors, c.RocketMQ.Validate()...)
+	errors = append(errors, c.Redis.Validate()...)
+	errors = append(errors, c.DLQ.Validate()...)
 	return errors
 }
 
@@ -37,6 +41,8 @@ func (c *Config) Flags() (fss cliflag.NamedFlagSets) {
 	c.MySQLOptions.AddFlags(fss.FlagSet("mysql"))
 	c.EsOptions.AddFlags(fss.FlagSet("es"))
 	c.RocketMQ.AddFlags(fss.FlagSet("rocketmq"))
+	c.Redis.AddFlags(fss.FlagSet("redis"))
+	c.DLQ.AddFlags(fss.FlagSet("dlq"))
 	return fss
 }
 
@@ -50,5 +56,7 @@ func New() *Config {
 		MySQLOptions: options.NewMySQLOptions(),
 		EsOptions:    options.NewEsOptions(),
 		RocketMQ:     options.NewRocketMQOptions(),
+		Redis:        options.NewRedisOptions(),
+		DLQ:          options.NewDLQOptions(),
 	}
 }
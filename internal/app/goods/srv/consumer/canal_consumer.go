@@ -12,6 +12,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"emshop/internal/app/goods/srv/data/v1/sync"
+	"emshop/pkg/dlq"
 	"emshop/pkg/log"
 )
 
@@ -41,6 +42,7 @@ type CanalConsumer struct {
 	config      *CanalConsumerConfig
 	consumer    rocketmq.PushConsumer
 	syncManager sync.DataSyncManagerInterface
+	recorder    *dlq.Recorder
 	
 	// 监控指标
 	messageTotal prometheus.Counter
@@ -68,6 +70,11 @@ func NewCanalConsumer(config *CanalConsumerConfig, syncManager sync.DataSyncMana
 	}
 }
 
+// SetDeadLetter 设置最后一次重投仍失败时写入的死信记录器
+func (c *CanalConsumer) SetDeadLetter(recorder *dlq.Recorder) {
+	c.recorder = recorder
+}
+
 // Start 启动消费者
 func (c *CanalConsumer) Start() error {
 	log.Info("starting canal consumer")
@@ -86,7 +93,7 @@ func (c *CanalConsumer) Start() error {
 	c.consumer = pushConsumer
 	
 	// 订阅主题
-	err = c.consumer.Subscribe(c.config.Topic, consumer.MessageSelector{}, c.handleMessage)
+	err = c.consumer.Subscribe(c.config.Topic, consumer.MessageSelector{}, c.consume)
 	if err != nil {
 		return fmt.Errorf("failed to subscribe topic %s: %w", c.config.Topic, err)
 	}
@@ -117,49 +124,51 @@ func (c *CanalConsumer) Stop() error {
 	return nil
 }
 
-// handleMessage 处理RocketMQ消息
-func (c *CanalConsumer) handleMessage(ctx context.Context, msgs ...*primitive.MessageExt) (consumer.ConsumeResult, error) {
+// consume 处理RocketMQ消息，失败的消息交由RocketMQ重投，最后一次重投仍失败时写入死信
+func (c *CanalConsumer) consume(ctx context.Context, msgs ...*primitive.MessageExt) (consumer.ConsumeResult, error) {
 	startTime := time.Now()
 	defer func() {
 		c.syncLatency.Observe(time.Since(startTime).Seconds())
 	}()
 	
-	for _, msg := range msgs {
-		c.messageTotal.Inc()
-		
-		// 解析Canal消息
-		var canalMsg CanalMessage
-		if err := json.Unmarshal(msg.Body, &canalMsg); err != nil {
-			c.errorTotal.Inc()
-			log.Errorf("failed to unmarshal canal message: %v, message: %s", err, string(msg.Body))
-			continue // 跳过无法解析的消息，避免重复消费
-		}
-		
-		// 处理消息
-		if err := c.processMessage(ctx, &canalMsg); err != nil {
-			c.errorTotal.Inc()
-			log.Errorf("failed to process canal message: %v, message: %+v", err, canalMsg)
-			return consumer.ConsumeRetryLater, err
-		}
-		
-		log.Debugf("successfully processed canal message: table=%s, type=%s, data_count=%d", 
-			canalMsg.Table, canalMsg.Type, len(canalMsg.Data))
+	return c.recorder.Wrap(c.handleMessage)(ctx, msgs...)
+}
+
+// handleMessage 处理单条RocketMQ消息
+func (c *CanalConsumer) handleMessage(ctx context.Context, msg *primitive.MessageExt) error {
+	c.messageTotal.Inc()
+	
+	// 解析Canal消息
+	var canalMsg CanalMessage
+	if err := json.Unmarshal(msg.Body, &canalMsg); err != nil {
+		c.errorTotal.Inc()
+		log.ErrorfC(ctx, "failed to unmarshal canal message: %v, message: %s", err, string(msg.Body))
+		return dlq.Permanent(err) // 无法解析的消息不再重投
 	}
 	
-	return consumer.ConsumeSuccess, nil
+	// 处理消息
+	if err := c.processMessage(ctx, &canalMsg); err != nil {
+		c.errorTotal.Inc()
+		log.ErrorfC(ctx, "failed to process canal message: %v, message: %+v", err, canalMsg)
+		return err
+	}
+	
+	log.DebugfC(ctx, "successfully processed canal message: table=%s, type=%s, data_count=%d", 
+		canalMsg.Table, canalMsg.Type, len(canalMsg.Data))
+	return nil
 }
 
 // processMessage 处理Canal消息
 func (c *CanalConsumer) processMessage(ctx context.Context, msg *CanalMessage) error {
 	// 只处理emshop_goods_srv数据库的消息
 	if msg.Database != "emshop_goods_srv" {
-		log.Debugf("ignoring message from database: %s", msg.Database)
+		log.DebugfC(ctx, "ignoring message from database: %s", msg.Database)
 		return nil
 	}
 	
 	// 忽略DDL操作
 	if msg.IsDdl {
-		log.Debugf("ignoring DDL operation for table: %s", msg.Table)
+		log.DebugfC(ctx, "ignoring DDL operation for table: %s", msg.Table)
 		return nil
 	}
 	
@@ -176,14 +185,14 @@ func (c *CanalConsumer) processMessage(ctx context.Context, msg *CanalMessage) e
 	case "banner":
 		return c.handleBannerChange(ctx, msg)
 	default:
-		log.Debugf("ignoring message for unsupported table: %s", msg.Table)
+		log.DebugfC(ctx, "ignoring message for unsupported table: %s", msg.Table)
 		return nil
 	}
 }
 
 // handleGoodsChange 处理商品表变更
 func (c *CanalConsumer) handleGoodsChange(ctx context.Context, msg *CanalMessage) error {
-	log.Debugf("processing goods change: type=%s, data_count=%d", msg.Type, len(msg.Data))
+	log.DebugfC(ctx, "processing goods change: type=%s, data_count=%d", msg.Type, len(msg.Data))
 	
 	switch msg.Type {
 	case "INSERT", "UPDATE":
@@ -204,17 +213,17 @@ func (c *CanalConsumer) handleGoodsChange(ctx context.Context, msg *CanalMessage
 					if parsed, err := parseUint64(v); err == nil {
 						goodsID = parsed
 					} else {
-						log.Errorf("failed to parse goods id from string: %s", v)
+						log.ErrorfC(ctx, "failed to parse goods id from string: %s", v)
 						continue
 					}
 				default:
-					log.Errorf("unsupported goods id type: %T, value: %v", v, v)
+					log.ErrorfC(ctx, "unsupported goods id type: %T, value: %v", v, v)
 					continue
 				}
 				
-				log.Debugf("syncing goods to search: id=%d, operation=%s", goodsID, msg.Type)
+				log.DebugfC(ctx, "syncing goods to search: id=%d, operation=%s", goodsID, msg.Type)
 				if err := c.syncManager.SyncToSearch(ctx, "goods", goodsID); err != nil {
-					log.Errorf("failed to sync goods %d to search: %v", goodsID, err)
+					log.ErrorfC(ctx, "failed to sync goods %d to search: %v", goodsID, err)
 					return err
 				}
 			}
@@ -238,20 +247,20 @@ func (c *CanalConsumer) handleGoodsChange(ctx context.Context, msg *CanalMessage
 				case int:
 					goodsID = uint64(v)
 				default:
-					log.Errorf("unsupported goods id type for deletion: %T, value: %v", v, v)
+					log.ErrorfC(ctx, "unsupported goods id type for deletion: %T, value: %v", v, v)
 					continue
 				}
 				
-				log.Debugf("removing goods from search: id=%d", goodsID)
+				log.DebugfC(ctx, "removing goods from search: id=%d", goodsID)
 				if err := c.syncManager.RemoveFromSearch(ctx, "goods", goodsID); err != nil {
-					log.Errorf("failed to remove goods %d from search: %v", goodsID, err)
+					log.ErrorfC(ctx, "failed to remove goods %d from search: %v", goodsID, err)
 					return err
 				}
 			}
 		}
 		
 	default:
-		log.Warnf("unsupported operation type: %s", msg.Type)
+		log.WarnfC(ctx, "unsupported operation type: %s", msg.Type)
 	}
 	
 	return nil
@@ -259,7 +268,7 @@ func (c *CanalConsumer) handleGoodsChange(ctx context.Context, msg *CanalMessage
 
 // handleBrandsChange 处理品牌表变更 - 可能影响商品搜索
 func (c *CanalConsumer) handleBrandsChange(ctx context.Context, msg *CanalMessage) error {
-	log.Debugf("processing brands change: type=%s, data_count=%d", msg.Type, len(msg.Data))
+	log.DebugfC(ctx, "processing brands change: type=%s, data_count=%d", msg.Type, len(msg.Data))
 	
 	// 品牌变更可能影响相关商品的搜索结果
 	// 这里可以实现更复杂的逻辑，比如找到相关商品并重新同步
@@ -270,7 +279,7 @@ func (c *CanalConsumer) handleBrandsChange(ctx context.Context, msg *CanalMessag
 
 // handleCategoryChange 处理分类表变更
 func (c *CanalConsumer) handleCategoryChange(ctx context.Context, msg *CanalMessage) error {
-	log.Debugf("processing category change: type=%s, data_count=%d", msg.Type, len(msg.Data))
+	log.DebugfC(ctx, "processing category change: type=%s, data_count=%d", msg.Type, len(msg.Data))
 	
 	// 分类变更可能影响相关商品的搜索结果
 	// 暂时记录日志，后续可以根据业务需求扩展
@@ -280,7 +289,7 @@ func (c *CanalConsumer) handleCategoryChange(ctx context.Context, msg *CanalMess
 
 // handleCategoryBrandChange 处理分类品牌关联表变更
 func (c *CanalConsumer) handleCategoryBrandChange(ctx context.Context, msg *CanalMessage) error {
-	log.Debugf("processing category_brand change: type=%s, data_count=%d", msg.Type, len(msg.Data))
+	log.DebugfC(ctx, "processing category_brand change: type=%s, data_count=%d", msg.Type, len(msg.Data))
 	
 	// 分类品牌关联变更可能影响相关商品的搜索结果
 	// 暂时记录日志，后续可以根据业务需求扩展
@@ -290,7 +299,7 @@ func (c *CanalConsumer) handleCategoryBrandChange(ctx context.Context, msg *Cana
 
 // handleBannerChange 处理banner表变更
 func (c *CanalConsumer) handleBannerChange(ctx context.Context, msg *CanalMessage) error {
-	log.Debugf("processing banner change: type=%s, data_count=%d", msg.Type, len(msg.Data))
+	log.DebugfC(ctx, "processing banner change: type=%s, data_count=%d", msg.Type, len(msg.Data))
 	
 	// Banner变更通常不需要同步到商品搜索
 	// 这里可以实现banner相关的同步逻辑
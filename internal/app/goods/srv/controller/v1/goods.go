@@ -95,7 +95,7 @@ func validateBannerRequest(request *proto.BannerRequest) error {
 func (gs *goodsServer) GoodsList(ctx context.Context, request *proto.GoodsFilterRequest) (*proto.GoodsListResponse, error) {
 	// Input validation
 	if err := validateGoodsFilterRequest(request); err != nil {
-		log.Errorf("invalid goods filter request: %v", err)
+		log.ErrorfC(ctx, "invalid goods filter request: %v", err)
 		return nil, err
 	}
 
@@ -110,7 +110,7 @@ func (gs *goodsServer) GoodsList(ctx context.Context, request *proto.GoodsFilter
 	
 	list, err := gs.srv.Goods().List(ctx, v12.ListMeta{Page: page, PageSize: pageSize}, request, []string{})
 	if err != nil {
-		log.Errorf("get goods list error: %v", err.Error())
+		log.ErrorfC(ctx, "get goods list error: %v", err.Error())
 		return nil, err
 	}
 	var ret proto.GoodsListResponse
@@ -140,7 +140,7 @@ func (gs *goodsServer) BatchGetGoods(ctx context.Context, info *proto.BatchGoods
 	
 	get, err := gs.srv.Goods().BatchGet(ctx, ids)
 	if err != nil {
-		log.Errorf("batch get goods error: %v", err)
+		log.ErrorfC(ctx, "batch get goods error: %v", err)
 		return nil, err
 	}
 	var ret proto.GoodsListResponse
@@ -153,7 +153,7 @@ func (gs *goodsServer) BatchGetGoods(ctx context.Context, info *proto.BatchGoods
 func (gs *goodsServer) CreateGoods(ctx context.Context, info *proto.CreateGoodsInfo) (*proto.GoodsInfoResponse, error) {
 	// Input validation
 	if err := validateCreateGoodsInfo(info); err != nil {
-		log.Errorf("invalid create goods info: %v", err)
+		log.ErrorfC(ctx, "invalid create goods info: %v", err)
 		return nil, err
 	}
 
@@ -178,7 +178,7 @@ func (gs *goodsServer) CreateGoods(ctx context.Context, info *proto.CreateGoodsI
 	// 创建商品（业务层会验证分类和品牌）
 	createdGoods, err := gs.srv.Goods().Create(ctx, goodsDTO)
 	if err != nil {
-		log.Errorf("create goods error: %v", err)
+		log.ErrorfC(ctx, "create goods error: %v", err)
 		return nil, err
 	}
 
@@ -195,7 +195,7 @@ func (gs *goodsServer) DeleteGoods(ctx context.Context, info *proto.DeleteGoodsI
 
 	err := gs.srv.Goods().Delete(ctx, uint64(info.Id))
 	if err != nil {
-		log.Errorf("delete goods error: %v", err)
+		log.ErrorfC(ctx, "delete goods error: %v", err)
 		return nil, err
 	}
 
@@ -208,7 +208,7 @@ func (gs *goodsServer) UpdateGoods(ctx context.Context, info *proto.CreateGoodsI
 		return nil, errors.WithCode(ErrInvalidParameter, "invalid goods ID")
 	}
 	if err := validateCreateGoodsInfo(info); err != nil {
-		log.Errorf("invalid update goods info: %v", err)
+		log.ErrorfC(ctx, "invalid update goods info: %v", err)
 		return nil, err
 	}
 
@@ -234,7 +234,7 @@ func (gs *goodsServer) UpdateGoods(ctx context.Context, info *proto.CreateGoodsI
 	// 更新商品
 	err := gs.srv.Goods().Update(ctx, goodsDTO)
 	if err != nil {
-		log.Errorf("update goods error: %v", err)
+		log.ErrorfC(ctx, "update goods error: %v", err)
 		return nil, err
 	}
 
@@ -249,7 +249,7 @@ func (gs *goodsServer) GetGoodsDetail(ctx context.Context, request *proto.GoodIn
 
 	goods, err := gs.srv.Goods().Get(ctx, uint64(request.Id))
 	if err != nil {
-		log.Errorf("get goods detail error: %v", err)
+		log.ErrorfC(ctx, "get goods detail error: %v", err)
 		return nil, err
 	}
 
@@ -259,14 +259,14 @@ func (gs *goodsServer) GetGoodsDetail(ctx context.Context, request *proto.GoodIn
 func (gs *goodsServer) GetAllCategorysList(ctx context.Context, empty *emptypb.Empty) (*proto.CategoryListResponse, error) {
 	categories, err := gs.srv.Category().ListAll(ctx, []string{})
 	if err != nil {
-		log.Errorf("get all categories error: %v", err)
+		log.ErrorfC(ctx, "get all categories error: %v", err)
 		return nil, err
 	}
 
 	// 首先扁平化所有嵌套的分类数据
 	allCategories := flattenCategories(categories.Items)
 	
-	log.Infof("GetAllCategorysList: flattened %d categories from %d root categories", len(allCategories), len(categories.Items))
+	log.InfofC(ctx, "GetAllCategorysList: flattened %d categories from %d root categories", len(allCategories), len(categories.Items))
 
 	response := &proto.CategoryListResponse{
 		Total: int32(len(allCategories)), // 使用扁平化后的总数
@@ -299,14 +299,14 @@ func (gs *goodsServer) GetAllCategorysList(ctx context.Context, empty *emptypb.E
 		response.Data = append(response.Data, children...)
 	}
 
-	log.Infof("GetAllCategorysList: returning %d total categories in response", len(response.Data))
+	log.InfofC(ctx, "GetAllCategorysList: returning %d total categories in response", len(response.Data))
 	return response, nil
 }
 
 func (gs *goodsServer) GetSubCategory(ctx context.Context, request *proto.CategoryListRequest) (*proto.SubCategoryListResponse, error) {
 	subCategories, err := gs.srv.Category().GetSubCategories(ctx, request.Id)
 	if err != nil {
-		log.Errorf("get sub categories error: %v", err)
+		log.ErrorfC(ctx, "get sub categories error: %v", err)
 		return nil, err
 	}
 
@@ -337,7 +337,7 @@ func (gs *goodsServer) GetSubCategory(ctx context.Context, request *proto.Catego
 func (gs *goodsServer) GetCategoryTree(ctx context.Context, empty *emptypb.Empty) (*proto.CategoryTreeResponse, error) {
 	categories, err := gs.srv.Category().ListAll(ctx, []string{})
 	if err != nil {
-		log.Errorf("get category tree error: %v", err)
+		log.ErrorfC(ctx, "get category tree error: %v", err)
 		return nil, err
 	}
 	
@@ -352,7 +352,7 @@ func (gs *goodsServer) GetCategoryTree(ctx context.Context, empty *emptypb.Empty
 		Stats:      stats,
 	}
 	
-	log.Infof("GetCategoryTree: returning %d root categories with %d total categories", 
+	log.InfofC(ctx, "GetCategoryTree: returning %d root categories with %d total categories", 
 		len(treeNodes), stats.TotalCount)
 	
 	return response, nil
@@ -439,7 +439,7 @@ func flattenCategories(categories []*dto.CategoryDTO) []*dto.CategoryDTO {
 func (gs *goodsServer) CreateCategory(ctx context.Context, request *proto.CategoryInfoRequest) (*proto.CategoryInfoResponse, error) {
 	// Input validation
 	if err := validateCategoryInfoRequest(request); err != nil {
-		log.Errorf("invalid create category request: %v", err)
+		log.ErrorfC(ctx, "invalid create category request: %v", err)
 		return nil, err
 	}
 
@@ -454,7 +454,7 @@ func (gs *goodsServer) CreateCategory(ctx context.Context, request *proto.Catego
 
 	err := gs.srv.Category().Create(ctx, categoryDTO)
 	if err != nil {
-		log.Errorf("create category error: %v", err)
+		log.ErrorfC(ctx, "create category error: %v", err)
 		return nil, err
 	}
 
@@ -464,7 +464,7 @@ func (gs *goodsServer) CreateCategory(ctx context.Context, request *proto.Catego
 func (gs *goodsServer) DeleteCategory(ctx context.Context, request *proto.DeleteCategoryRequest) (*emptypb.Empty, error) {
 	err := gs.srv.Category().Delete(ctx, request.Id)
 	if err != nil {
-		log.Errorf("delete category error: %v", err)
+		log.ErrorfC(ctx, "delete category error: %v", err)
 		return nil, err
 	}
 	return &emptypb.Empty{}, nil
@@ -474,7 +474,7 @@ func (gs *goodsServer) UpdateCategory(ctx context.Context, request *proto.Catego
 	// 先获取现有分类信息
 	existing, err := gs.srv.Category().Get(ctx, request.Id)
 	if err != nil {
-		log.Errorf("get existing category error: %v", err)
+		log.ErrorfC(ctx, "get existing category error: %v", err)
 		return nil, err
 	}
 
@@ -492,7 +492,7 @@ func (gs *goodsServer) UpdateCategory(ctx context.Context, request *proto.Catego
 
 	err = gs.srv.Category().Update(ctx, categoryDTO)
 	if err != nil {
-		log.Errorf("update category error: %v", err)
+		log.ErrorfC(ctx, "update category error: %v", err)
 		return nil, err
 	}
 
@@ -531,7 +531,7 @@ func (gs *goodsServer) BrandList(ctx context.Context, request *proto.BrandFilter
 	
 	brands, err := gs.srv.Brand().List(ctx, listMeta, []string{})
 	if err != nil {
-		log.Errorf("get brands list error: %v", err)
+		log.ErrorfC(ctx, "get brands list error: %v", err)
 		return nil, err
 	}
 
@@ -553,7 +553,7 @@ func (gs *goodsServer) BrandList(ctx context.Context, request *proto.BrandFilter
 func (gs *goodsServer) CreateBrand(ctx context.Context, request *proto.BrandRequest) (*proto.BrandInfoResponse, error) {
 	// Input validation
 	if err := validateBrandRequest(request); err != nil {
-		log.Errorf("invalid create brand request: %v", err)
+		log.ErrorfC(ctx, "invalid create brand request: %v", err)
 		return nil, err
 	}
 
@@ -566,7 +566,7 @@ func (gs *goodsServer) CreateBrand(ctx context.Context, request *proto.BrandRequ
 
 	err := gs.srv.Brand().Create(ctx, brandDTO)
 	if err != nil {
-		log.Errorf("create brand error: %v", err)
+		log.ErrorfC(ctx, "create brand error: %v", err)
 		return nil, err
 	}
 
@@ -576,7 +576,7 @@ func (gs *goodsServer) CreateBrand(ctx context.Context, request *proto.BrandRequ
 func (gs *goodsServer) DeleteBrand(ctx context.Context, request *proto.BrandRequest) (*emptypb.Empty, error) {
 	err := gs.srv.Brand().Delete(ctx, request.Id)
 	if err != nil {
-		log.Errorf("delete brand error: %v", err)
+		log.ErrorfC(ctx, "delete brand error: %v", err)
 		return nil, err
 	}
 	return &emptypb.Empty{}, nil
@@ -593,7 +593,7 @@ func (gs *goodsServer) UpdateBrand(ctx context.Context, request *proto.BrandRequ
 
 	err := gs.srv.Brand().Update(ctx, brandDTO)
 	if err != nil {
-		log.Errorf("update brand error: %v", err)
+		log.ErrorfC(ctx, "update brand error: %v", err)
 		return nil, err
 	}
 
@@ -603,7 +603,7 @@ func (gs *goodsServer) UpdateBrand(ctx context.Context, request *proto.BrandRequ
 func (gs *goodsServer) BannerList(ctx context.Context, empty *emptypb.Empty) (*proto.BannerListResponse, error) {
 	banners, err := gs.srv.Banner().List(ctx, []string{})
 	if err != nil {
-		log.Errorf("get banners list error: %v", err)
+		log.ErrorfC(ctx, "get banners list error: %v", err)
 		return nil, err
 	}
 
@@ -626,7 +626,7 @@ func (gs *goodsServer) BannerList(ctx context.Context, empty *emptypb.Empty) (*p
 func (gs *goodsServer) CreateBanner(ctx context.Context, request *proto.BannerRequest) (*proto.BannerResponse, error) {
 	// Input validation
 	if err := validateBannerRequest(request); err != nil {
-		log.Errorf("invalid create banner request: %v", err)
+		log.ErrorfC(ctx, "invalid create banner request: %v", err)
 		return nil, err
 	}
 
@@ -640,7 +640,7 @@ func (gs *goodsServer) CreateBanner(ctx context.Context, request *proto.BannerRe
 
 	err := gs.srv.Banner().Create(ctx, bannerDTO)
 	if err != nil {
-		log.Errorf("create banner error: %v", err)
+		log.ErrorfC(ctx, "create banner error: %v", err)
 		return nil, err
 	}
 
@@ -650,7 +650,7 @@ func (gs *goodsServer) CreateBanner(ctx context.Context, request *proto.BannerRe
 func (gs *goodsServer) DeleteBanner(ctx context.Context, request *proto.BannerRequest) (*emptypb.Empty, error) {
 	err := gs.srv.Banner().Delete(ctx, request.Id)
 	if err != nil {
-		log.Errorf("delete banner error: %v", err)
+		log.ErrorfC(ctx, "delete banner error: %v", err)
 		return nil, err
 	}
 	return &emptypb.Empty{}, nil
@@ -668,7 +668,7 @@ func (gs *goodsServer) UpdateBanner(ctx context.Context, request *proto.BannerRe
 
 	err := gs.srv.Banner().Update(ctx, bannerDTO)
 	if err != nil {
-		log.Errorf("update banner error: %v", err)
+		log.ErrorfC(ctx, "update banner error: %v", err)
 		return nil, err
 	}
 
@@ -687,7 +687,7 @@ func (gs *goodsServer) CategoryBrandList(ctx context.Context, request *proto.Cat
 	
 	categoryBrands, err := gs.srv.CategoryBrand().List(ctx, v12.ListMeta{Page: page, PageSize: pageSize}, []string{})
 	if err != nil {
-		log.Errorf("get category brands list error: %v", err)
+		log.ErrorfC(ctx, "get category brands list error: %v", err)
 		return nil, err
 	}
 
@@ -719,7 +719,7 @@ func (gs *goodsServer) CategoryBrandList(ctx context.Context, request *proto.Cat
 func (gs *goodsServer) GetCategoryBrandList(ctx context.Context, request *proto.CategoryInfoRequest) (*proto.BrandListResponse, error) {
 	brands, err := gs.srv.CategoryBrand().GetBrandsByCategory(ctx, request.Id)
 	if err != nil {
-		log.Errorf("get brands by category error: %v", err)
+		log.ErrorfC(ctx, "get brands by category error: %v", err)
 		return nil, err
 	}
 
@@ -748,7 +748,7 @@ func (gs *goodsServer) CreateCategoryBrand(ctx context.Context, request *proto.C
 
 	err := gs.srv.CategoryBrand().Create(ctx, categoryBrandDTO)
 	if err != nil {
-		log.Errorf("create category brand error: %v", err)
+		log.ErrorfC(ctx, "create category brand error: %v", err)
 		return nil, err
 	}
 
@@ -758,7 +758,7 @@ func (gs *goodsServer) CreateCategoryBrand(ctx context.Context, request *proto.C
 func (gs *goodsServer) DeleteCategoryBrand(ctx context.Context, request *proto.CategoryBrandRequest) (*emptypb.Empty, error) {
 	err := gs.srv.CategoryBrand().Delete(ctx, request.Id)
 	if err != nil {
-		log.Errorf("delete category brand error: %v", err)
+		log.ErrorfC(ctx, "delete category brand error: %v", err)
 		return nil, err
 	}
 	return &emptypb.Empty{}, nil
@@ -775,7 +775,7 @@ func (gs *goodsServer) UpdateCategoryBrand(ctx context.Context, request *proto.C
 
 	err := gs.srv.CategoryBrand().Update(ctx, categoryBrandDTO)
 	if err != nil {
-		log.Errorf("update category brand error: %v", err)
+		log.ErrorfC(ctx, "update category brand error: %v", err)
 		return nil, err
 	}
 
@@ -783,7 +783,7 @@ func (gs *goodsServer) UpdateCategoryBrand(ctx context.Context, request *proto.C
 }
 
 func (gs *goodsServer) SyncGoodsData(ctx context.Context, request *proto.SyncDataRequest) (*proto.SyncDataResponse, error) {
-	log.Infof("sync goods data request: forceSync=%v, goodsIds=%v", request.ForceSync, request.GoodsIds)
+	log.InfofC(ctx, "sync goods data request: forceSync=%v, goodsIds=%v", request.ForceSync, request.GoodsIds)
 
 	// 转换goodsIds到uint64
 	var goodsIds []uint64
@@ -793,7 +793,7 @@ func (gs *goodsServer) SyncGoodsData(ctx context.Context, request *proto.SyncDat
 
 	result, err := gs.srv.DataSync().SyncGoodsData(ctx, request.ForceSync, goodsIds)
 	if err != nil {
-		log.Errorf("sync goods data error: %v", err)
+		log.ErrorfC(ctx, "sync goods data error: %v", err)
 		return &proto.SyncDataResponse{
 			Success: false,
 			Message: err.Error(),
@@ -1,7 +1,10 @@
 package elasticsearch
 
 import (
+	"context"
+	"fmt"
 	"github.com/olivere/elastic/v7"
+	"emshop/gin-micro/core/health"
 	"emshop/internal/app/goods/srv/data/v1/interfaces"
 	"emshop/internal/app/pkg/options"
 	"emshop/pkg/db"
@@ -46,6 +49,16 @@ func NewElasticsearchFactory(opts *options.EsOptions) (SearchFactory, error) {
 			return
 		}
 		searchFactory = &esSearchFactory{esClient: esClient}
+		health.Register("elasticsearch", func(ctx context.Context) error {
+			resp, err := esClient.ClusterHealth().Do(ctx)
+			if err != nil {
+				return err
+			}
+			if resp.Status == "red" {
+				return fmt.Errorf("cluster %s status red", resp.ClusterName)
+			}
+			return nil
+		})
 	})
 	if searchFactory == nil || err != nil {
 		return nil, errors.New("failed to get es client")
@@ -29,7 +29,7 @@ func (dsm *DataSyncManager) SyncToSearch(ctx context.Context, entityType string,
 	case "goods":
 		return dsm.syncGoodsToSearch(ctx, entityID)
 	default:
-		log.Warnf("unsupported entity type for search sync: %s", entityType)
+		log.WarnfC(ctx, "unsupported entity type for search sync: %s", entityType)
 		return nil
 	}
 }
@@ -37,7 +37,7 @@ func (dsm *DataSyncManager) SyncToSearch(ctx context.Context, entityType string,
 // SyncToCache 同步数据到缓存（预留接口）
 func (dsm *DataSyncManager) SyncToCache(ctx context.Context, entityType string, entityID uint64) error {
 	// TODO: 实现缓存同步逻辑
-	log.Debugf("cache sync not implemented for entity: %s, id: %d", entityType, entityID)
+	log.DebugfC(ctx, "cache sync not implemented for entity: %s, id: %d", entityType, entityID)
 	return nil
 }
 
@@ -46,7 +46,7 @@ func (dsm *DataSyncManager) syncGoodsToSearch(ctx context.Context, goodsID uint6
 	// 从主数据库获取商品信息
 	goods, err := dsm.dataFactory.Goods().Get(ctx, dsm.dataFactory.DB(), goodsID)
 	if err != nil {
-		log.Errorf("failed to get goods from database: %v", err)
+		log.ErrorfC(ctx, "failed to get goods from database: %v", err)
 		return err
 	}
 
@@ -71,19 +71,19 @@ func (dsm *DataSyncManager) syncGoodsToSearch(ctx context.Context, goodsID uint6
 	// 同步到搜索引擎 - 先尝试更新，如果失败则创建
 	err = dsm.searchFactory.Goods().Update(ctx, searchGoods)
 	if err != nil {
-		log.Debugf("update failed for goods %d, trying create: %v", goodsID, err)
+		log.DebugfC(ctx, "update failed for goods %d, trying create: %v", goodsID, err)
 		// 如果更新失败（通常是文档不存在），尝试创建
 		err = dsm.searchFactory.Goods().Create(ctx, searchGoods)
 		if err != nil {
-			log.Errorf("failed to sync goods to search engine: %v", err)
+			log.ErrorfC(ctx, "failed to sync goods to search engine: %v", err)
 			return err
 		}
-		log.Debugf("created goods %d in search engine", goodsID)
+		log.DebugfC(ctx, "created goods %d in search engine", goodsID)
 	} else {
-		log.Debugf("updated goods %d in search engine", goodsID)
+		log.DebugfC(ctx, "updated goods %d in search engine", goodsID)
 	}
 
-	log.Debugf("successfully synced goods %d to search engine", goodsID)
+	log.DebugfC(ctx, "successfully synced goods %d to search engine", goodsID)
 	return nil
 }
 
@@ -93,7 +93,7 @@ func (dsm *DataSyncManager) RemoveFromSearch(ctx context.Context, entityType str
 	case "goods":
 		return dsm.searchFactory.Goods().Delete(ctx, entityID)
 	default:
-		log.Warnf("unsupported entity type for search removal: %s", entityType)
+		log.WarnfC(ctx, "unsupported entity type for search removal: %s", entityType)
 		return nil
 	}
 }
@@ -120,13 +120,13 @@ func (dsm *DataSyncManager) SyncAllGoodsToSearch(ctx context.Context, forceSync
 		// 获取所有商品ID - 使用专用方法
 		goodsIds, err := dsm.dataFactory.Goods().GetAllGoodsIDs(ctx, dsm.dataFactory.DB())
 		if err != nil {
-			log.Errorf("failed to get all goods IDs: %v", err)
+			log.ErrorfC(ctx, "failed to get all goods IDs: %v", err)
 			return nil, err
 		}
 		goodsList = goodsIds
 	}
 
-	log.Infof("starting sync %d goods to search engine", len(goodsList))
+	log.InfofC(ctx, "starting sync %d goods to search engine", len(goodsList))
 
 	// 批量同步
 	for _, goodsID := range goodsList {
@@ -135,13 +135,13 @@ func (dsm *DataSyncManager) SyncAllGoodsToSearch(ctx context.Context, forceSync
 			result.FailedCount++
 			errorMsg := fmt.Sprintf("failed to sync goods %d: %v", goodsID, err)
 			result.Errors = append(result.Errors, errorMsg)
-			log.Errorf(errorMsg)
+			log.ErrorC(ctx, errorMsg)
 			continue
 		}
 		result.SyncedCount++
 	}
 
-	log.Infof("sync completed: synced=%d, failed=%d", result.SyncedCount, result.FailedCount)
+	log.InfofC(ctx, "sync completed: synced=%d, failed=%d", result.SyncedCount, result.FailedCount)
 	return result, nil
 }
 
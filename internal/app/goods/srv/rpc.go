@@ -34,10 +34,17 @@ func NewGoodsRPCServer(cfg *config.Config) (*rpcserver.Server, *dataV1.FactoryMa
 	srvFactory := v1.NewService(factoryManager)
 	goodsServer := v12.NewGoodsServer(srvFactory)
 	rpcAddr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
+	grpcOpts, err := cfg.Server.GRPCServerOptions()
+	if err != nil {
+		return nil, nil, err
+	}
 	grpcServer := rpcserver.NewServer(
 		rpcserver.WithAddress(rpcAddr),
 		rpcserver.WithMetrics(cfg.Server.EnableMetrics),
 		rpcserver.WithTimeout(15*time.Second),
+		rpcserver.WithOptions(grpcOpts...),
+		rpcserver.WithAdaptiveLimit(cfg.Server.Limiter.AdaptiveLimiter(), nil),
+		rpcserver.WithLogAdmin(cfg.Server.LogAdminSecret),
 	)
 
 	gpb.RegisterGoodsServer(grpcServer.Server, goodsServer)
@@ -26,7 +26,7 @@ func (b *banner) List(ctx context.Context, orderby []string) (*dto.BannerDTOList
 	// 获取所有轮播图，不需要分页
 	banners, err := dataFactory.Banners().List(ctx, dataFactory.DB(), orderby, metav1.ListMeta{Page: 1, PageSize: 100})
 	if err != nil {
-		log.Errorf("get banners list error: %v", err)
+		log.ErrorfC(ctx, "get banners list error: %v", err)
 		return nil, err
 	}
 
@@ -49,7 +49,7 @@ func (b *banner) Get(ctx context.Context, ID int32) (*dto.BannerDTO, error) {
 	dataFactory := b.srv.factoryManager.GetDataFactory()
 	banner, err := dataFactory.Banners().Get(ctx, dataFactory.DB(), uint64(ID))
 	if err != nil {
-		log.Errorf("get banner error: %v", err)
+		log.ErrorfC(ctx, "get banner error: %v", err)
 		return nil, err
 	}
 
@@ -69,7 +69,7 @@ func (b *banner) Create(ctx context.Context, banner *dto.BannerDTO) error {
 
 	err := dataFactory.Banners().Create(ctx, dataFactory.DB(), bannerDO)
 	if err != nil {
-		log.Errorf("create banner error: %v", err)
+		log.ErrorfC(ctx, "create banner error: %v", err)
 		return err
 	}
 
@@ -83,7 +83,7 @@ func (b *banner) Update(ctx context.Context, banner *dto.BannerDTO) error {
 	// 检查轮播图是否存在
 	existing, err := dataFactory.Banners().Get(ctx, dataFactory.DB(), uint64(banner.ID))
 	if err != nil {
-		log.Errorf("banner not found: %v", err)
+		log.ErrorfC(ctx, "banner not found: %v", err)
 		return errors.WithCode(code.ErrBannerNotFound, "轮播图不存在")
 	}
 
@@ -94,7 +94,7 @@ func (b *banner) Update(ctx context.Context, banner *dto.BannerDTO) error {
 
 	err = dataFactory.Banners().Update(ctx, dataFactory.DB(), existing)
 	if err != nil {
-		log.Errorf("update banner error: %v", err)
+		log.ErrorfC(ctx, "update banner error: %v", err)
 		return err
 	}
 
@@ -107,13 +107,13 @@ func (b *banner) Delete(ctx context.Context, ID int32) error {
 	// 检查轮播图是否存在
 	_, err := dataFactory.Banners().Get(ctx, dataFactory.DB(), uint64(ID))
 	if err != nil {
-		log.Errorf("banner not found: %v", err)
+		log.ErrorfC(ctx, "banner not found: %v", err)
 		return errors.WithCode(code.ErrBannerNotFound, "轮播图不存在")
 	}
 
 	err = dataFactory.Banners().Delete(ctx, dataFactory.DB(), uint64(ID))
 	if err != nil {
-		log.Errorf("delete banner error: %v", err)
+		log.ErrorfC(ctx, "delete banner error: %v", err)
 		return err
 	}
 
@@ -25,7 +25,7 @@ func (b *brand) List(ctx context.Context, opts metav1.ListMeta, orderby []string
 	dataFactory := b.srv.factoryManager.GetDataFactory()
 	brands, err := dataFactory.Brands().List(ctx, dataFactory.DB(), orderby, opts)
 	if err != nil {
-		log.Errorf("get brands list error: %v", err)
+		log.ErrorfC(ctx, "get brands list error: %v", err)
 		return nil, err
 	}
 
@@ -48,7 +48,7 @@ func (b *brand) Get(ctx context.Context, ID int32) (*dto.BrandDTO, error) {
 	dataFactory := b.srv.factoryManager.GetDataFactory()
 	brand, err := dataFactory.Brands().Get(ctx, dataFactory.DB(), uint64(ID))
 	if err != nil {
-		log.Errorf("get brand error: %v", err)
+		log.ErrorfC(ctx, "get brand error: %v", err)
 		return nil, err
 	}
 
@@ -65,7 +65,7 @@ func (b *brand) Create(ctx context.Context, brand *dto.BrandDTO) error {
 	if err == nil {
 		for _, existing := range existingBrands.Items {
 			if existing.Name == brand.Name {
-				log.Errorf("brand name already exists: %s", brand.Name)
+				log.ErrorfC(ctx, "brand name already exists: %s", brand.Name)
 				return errors.WithCode(code.ErrBrandNotFound, "品牌名称已存在")
 			}
 		}
@@ -78,7 +78,7 @@ func (b *brand) Create(ctx context.Context, brand *dto.BrandDTO) error {
 
 	err = dataFactory.Brands().Create(ctx, dataFactory.DB(), brandDO)
 	if err != nil {
-		log.Errorf("create brand error: %v", err)
+		log.ErrorfC(ctx, "create brand error: %v", err)
 		return err
 	}
 
@@ -92,7 +92,7 @@ func (b *brand) Update(ctx context.Context, brand *dto.BrandDTO) error {
 	// 检查品牌是否存在
 	existing, err := dataFactory.Brands().Get(ctx, dataFactory.DB(), uint64(brand.ID))
 	if err != nil {
-		log.Errorf("brand not found: %v", err)
+		log.ErrorfC(ctx, "brand not found: %v", err)
 		return errors.WithCode(code.ErrBrandNotFound, "品牌不存在")
 	}
 
@@ -102,7 +102,7 @@ func (b *brand) Update(ctx context.Context, brand *dto.BrandDTO) error {
 		if err == nil {
 			for _, existingBrand := range existingBrands.Items {
 				if existingBrand.Name == brand.Name && existingBrand.ID != brand.ID {
-					log.Errorf("brand name already exists: %s", brand.Name)
+					log.ErrorfC(ctx, "brand name already exists: %s", brand.Name)
 					return errors.WithCode(code.ErrBrandNotFound, "品牌名称已存在")
 				}
 			}
@@ -115,7 +115,7 @@ func (b *brand) Update(ctx context.Context, brand *dto.BrandDTO) error {
 
 	err = dataFactory.Brands().Update(ctx, dataFactory.DB(), existing)
 	if err != nil {
-		log.Errorf("update brand error: %v", err)
+		log.ErrorfC(ctx, "update brand error: %v", err)
 		return err
 	}
 
@@ -128,7 +128,7 @@ func (b *brand) Delete(ctx context.Context, ID int32) error {
 	// 检查品牌是否存在
 	_, err := dataFactory.Brands().Get(ctx, dataFactory.DB(), uint64(ID))
 	if err != nil {
-		log.Errorf("brand not found: %v", err)
+		log.ErrorfC(ctx, "brand not found: %v", err)
 		return errors.WithCode(code.ErrBrandNotFound, "品牌不存在")
 	}
 
@@ -137,7 +137,7 @@ func (b *brand) Delete(ctx context.Context, ID int32) error {
 
 	err = dataFactory.Brands().Delete(ctx, dataFactory.DB(), uint64(ID))
 	if err != nil {
-		log.Errorf("delete brand error: %v", err)
+		log.ErrorfC(ctx, "delete brand error: %v", err)
 		return err
 	}
 
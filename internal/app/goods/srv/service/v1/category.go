@@ -25,7 +25,7 @@ func (c *category) List(ctx context.Context, opts metav1.ListMeta, orderby []str
 	dataFactory := c.srv.factoryManager.GetDataFactory()
 	categories, err := dataFactory.Categorys().List(ctx, dataFactory.DB(), orderby, opts)
 	if err != nil {
-		log.Errorf("get categories list error: %v", err)
+		log.ErrorfC(ctx, "get categories list error: %v", err)
 		return nil, err
 	}
 
@@ -49,7 +49,7 @@ func (c *category) ListAll(ctx context.Context, orderby []string) (*dto.Category
 	// 获取一级分类（包括子分类）
 	categories, err := dataFactory.Categorys().GetByLevel(ctx, dataFactory.DB(), 1)
 	if err != nil {
-		log.Errorf("get all categories error: %v", err)
+		log.ErrorfC(ctx, "get all categories error: %v", err)
 		return nil, err
 	}
 
@@ -94,7 +94,7 @@ func (c *category) GetByLevel(ctx context.Context, level int32) (*dto.CategoryDT
 	dataFactory := c.srv.factoryManager.GetDataFactory()
 	categories, err := dataFactory.Categorys().GetByLevel(ctx, dataFactory.DB(), int(level))
 	if err != nil {
-		log.Errorf("get categories by level error: %v", err)
+		log.ErrorfC(ctx, "get categories by level error: %v", err)
 		return nil, err
 	}
 
@@ -119,13 +119,13 @@ func (c *category) GetSubCategories(ctx context.Context, parentID int32) (*dto.C
 	// 先检查父分类是否存在
 	parent, err := dataFactory.Categorys().Get(ctx, dataFactory.DB(), uint64(parentID))
 	if err != nil {
-		log.Errorf("parent category not found: %v", err)
+		log.ErrorfC(ctx, "parent category not found: %v", err)
 		return nil, errors.WithCode(code.ErrCategoryNotFound, "父分类不存在")
 	}
 
 	categories, err := dataFactory.Categorys().GetSubCategories(ctx, dataFactory.DB(), uint64(parentID))
 	if err != nil {
-		log.Errorf("get sub categories error: %v", err)
+		log.ErrorfC(ctx, "get sub categories error: %v", err)
 		return nil, err
 	}
 
@@ -151,7 +151,7 @@ func (c *category) Get(ctx context.Context, ID int32) (*dto.CategoryDTO, error)
 	dataFactory := c.srv.factoryManager.GetDataFactory()
 	category, err := dataFactory.Categorys().Get(ctx, dataFactory.DB(), uint64(ID))
 	if err != nil {
-		log.Errorf("get category error: %v", err)
+		log.ErrorfC(ctx, "get category error: %v", err)
 		return nil, err
 	}
 
@@ -167,7 +167,7 @@ func (c *category) Create(ctx context.Context, category *dto.CategoryDTO) error
 	if category.Level != 1 && category.ParentCategoryID != 0 {
 		_, err := dataFactory.Categorys().Get(ctx, dataFactory.DB(), uint64(category.ParentCategoryID))
 		if err != nil {
-			log.Errorf("parent category not found: %v", err)
+			log.ErrorfC(ctx, "parent category not found: %v", err)
 			return errors.WithCode(code.ErrCategoryNotFound, "父分类不存在")
 		}
 	}
@@ -181,7 +181,7 @@ func (c *category) Create(ctx context.Context, category *dto.CategoryDTO) error
 
 	err := dataFactory.Categorys().Create(ctx, dataFactory.DB(), categoryDO)
 	if err != nil {
-		log.Errorf("create category error: %v", err)
+		log.ErrorfC(ctx, "create category error: %v", err)
 		return err
 	}
 
@@ -195,7 +195,7 @@ func (c *category) Update(ctx context.Context, category *dto.CategoryDTO) error
 	// 检查分类是否存在
 	existing, err := dataFactory.Categorys().Get(ctx, dataFactory.DB(), uint64(category.ID))
 	if err != nil {
-		log.Errorf("category not found: %v", err)
+		log.ErrorfC(ctx, "category not found: %v", err)
 		return errors.WithCode(code.ErrCategoryNotFound, "分类不存在")
 	}
 
@@ -203,7 +203,7 @@ func (c *category) Update(ctx context.Context, category *dto.CategoryDTO) error
 	if category.Level != 1 && category.ParentCategoryID != 0 {
 		_, err := dataFactory.Categorys().Get(ctx, dataFactory.DB(), uint64(category.ParentCategoryID))
 		if err != nil {
-			log.Errorf("parent category not found: %v", err)
+			log.ErrorfC(ctx, "parent category not found: %v", err)
 			return errors.WithCode(code.ErrCategoryNotFound, "父分类不存在")
 		}
 	}
@@ -216,7 +216,7 @@ func (c *category) Update(ctx context.Context, category *dto.CategoryDTO) error
 
 	err = dataFactory.Categorys().Update(ctx, dataFactory.DB(), existing)
 	if err != nil {
-		log.Errorf("update category error: %v", err)
+		log.ErrorfC(ctx, "update category error: %v", err)
 		return err
 	}
 
@@ -229,7 +229,7 @@ func (c *category) Delete(ctx context.Context, ID int32) error {
 	// 检查分类是否存在
 	_, err := dataFactory.Categorys().Get(ctx, dataFactory.DB(), uint64(ID))
 	if err != nil {
-		log.Errorf("category not found: %v", err)
+		log.ErrorfC(ctx, "category not found: %v", err)
 		return errors.WithCode(code.ErrCategoryNotFound, "分类不存在")
 	}
 
@@ -241,7 +241,7 @@ func (c *category) Delete(ctx context.Context, ID int32) error {
 
 	err = dataFactory.Categorys().Delete(ctx, dataFactory.DB(), uint64(ID))
 	if err != nil {
-		log.Errorf("delete category error: %v", err)
+		log.ErrorfC(ctx, "delete category error: %v", err)
 		return err
 	}
 
@@ -25,7 +25,7 @@ func (cb *categoryBrand) List(ctx context.Context, opts metav1.ListMeta, orderby
 	dataFactory := cb.srv.factoryManager.GetDataFactory()
 	categoryBrands, err := dataFactory.CategoryBrands().List(ctx, dataFactory.DB(), orderby, opts)
 	if err != nil {
-		log.Errorf("get category brands list error: %v", err)
+		log.ErrorfC(ctx, "get category brands list error: %v", err)
 		return nil, err
 	}
 
@@ -38,13 +38,13 @@ func (cb *categoryBrand) List(ctx context.Context, opts metav1.ListMeta, orderby
 		// 获取关联的分类和品牌信息
 		category, err := dataFactory.Categorys().Get(ctx, dataFactory.DB(), uint64(item.CategoryID))
 		if err != nil {
-			log.Warnf("get category error: %v", err)
+			log.WarnfC(ctx, "get category error: %v", err)
 			continue
 		}
 
 		brand, err := dataFactory.Brands().Get(ctx, dataFactory.DB(), uint64(item.BrandsID))
 		if err != nil {
-			log.Warnf("get brand error: %v", err)
+			log.WarnfC(ctx, "get brand error: %v", err)
 			continue
 		}
 
@@ -68,14 +68,14 @@ func (cb *categoryBrand) GetBrandsByCategory(ctx context.Context, categoryID int
 	// 先检查分类是否存在
 	_, err := dataFactory.Categorys().Get(ctx, dataFactory.DB(), uint64(categoryID))
 	if err != nil {
-		log.Errorf("category not found: %v", err)
+		log.ErrorfC(ctx, "category not found: %v", err)
 		return nil, errors.WithCode(code.ErrCategoryNotFound, "分类不存在")
 	}
 
 	// 获取该分类下的所有品牌关系
 	categoryBrands, err := dataFactory.CategoryBrands().GetByCategory(ctx, dataFactory.DB(), uint64(categoryID))
 	if err != nil {
-		log.Errorf("get brands by category error: %v", err)
+		log.ErrorfC(ctx, "get brands by category error: %v", err)
 		return nil, err
 	}
 
@@ -87,7 +87,7 @@ func (cb *categoryBrand) GetBrandsByCategory(ctx context.Context, categoryID int
 	for _, item := range categoryBrands.Items {
 		brand, err := dataFactory.Brands().Get(ctx, dataFactory.DB(), uint64(item.BrandsID))
 		if err != nil {
-			log.Warnf("get brand error: %v", err)
+			log.WarnfC(ctx, "get brand error: %v", err)
 			continue
 		}
 
@@ -104,20 +104,20 @@ func (cb *categoryBrand) Get(ctx context.Context, ID int32) (*dto.CategoryBrandD
 	dataFactory := cb.srv.factoryManager.GetDataFactory()
 	categoryBrand, err := dataFactory.CategoryBrands().Get(ctx, dataFactory.DB(), uint64(ID))
 	if err != nil {
-		log.Errorf("get category brand error: %v", err)
+		log.ErrorfC(ctx, "get category brand error: %v", err)
 		return nil, err
 	}
 
 	// 获取关联的分类和品牌信息
 	category, err := dataFactory.Categorys().Get(ctx, dataFactory.DB(), uint64(categoryBrand.CategoryID))
 	if err != nil {
-		log.Errorf("get category error: %v", err)
+		log.ErrorfC(ctx, "get category error: %v", err)
 		return nil, err
 	}
 
 	brand, err := dataFactory.Brands().Get(ctx, dataFactory.DB(), uint64(categoryBrand.BrandsID))
 	if err != nil {
-		log.Errorf("get brand error: %v", err)
+		log.ErrorfC(ctx, "get brand error: %v", err)
 		return nil, err
 	}
 
@@ -136,14 +136,14 @@ func (cb *categoryBrand) Create(ctx context.Context, categoryBrand *dto.Category
 	// 验证分类是否存在
 	_, err := dataFactory.Categorys().Get(ctx, dataFactory.DB(), uint64(categoryBrand.CategoryID))
 	if err != nil {
-		log.Errorf("category not found: %v", err)
+		log.ErrorfC(ctx, "category not found: %v", err)
 		return errors.WithCode(code.ErrCategoryNotFound, "分类不存在")
 	}
 
 	// 验证品牌是否存在
 	_, err = dataFactory.Brands().Get(ctx, dataFactory.DB(), uint64(categoryBrand.BrandsID))
 	if err != nil {
-		log.Errorf("brand not found: %v", err)
+		log.ErrorfC(ctx, "brand not found: %v", err)
 		return errors.WithCode(code.ErrBrandNotFound, "品牌不存在")
 	}
 
@@ -152,7 +152,7 @@ func (cb *categoryBrand) Create(ctx context.Context, categoryBrand *dto.Category
 	if err == nil {
 		for _, existing := range existingRelations.Items {
 			if existing.BrandsID == categoryBrand.BrandsID {
-				log.Errorf("category brand relation already exists: category=%d, brand=%d", categoryBrand.CategoryID, categoryBrand.BrandsID)
+				log.ErrorfC(ctx, "category brand relation already exists: category=%d, brand=%d", categoryBrand.CategoryID, categoryBrand.BrandsID)
 				return errors.WithCode(code.ErrCategoryBrandNotFound, "分类品牌关系已存在")
 			}
 		}
@@ -165,7 +165,7 @@ func (cb *categoryBrand) Create(ctx context.Context, categoryBrand *dto.Category
 
 	err = dataFactory.CategoryBrands().Create(ctx, dataFactory.DB(), categoryBrandDO)
 	if err != nil {
-		log.Errorf("create category brand error: %v", err)
+		log.ErrorfC(ctx, "create category brand error: %v", err)
 		return err
 	}
 
@@ -179,21 +179,21 @@ func (cb *categoryBrand) Update(ctx context.Context, categoryBrand *dto.Category
 	// 检查关系是否存在
 	existing, err := dataFactory.CategoryBrands().Get(ctx, dataFactory.DB(), uint64(categoryBrand.ID))
 	if err != nil {
-		log.Errorf("category brand not found: %v", err)
+		log.ErrorfC(ctx, "category brand not found: %v", err)
 		return errors.WithCode(code.ErrCategoryBrandNotFound, "分类品牌关系不存在")
 	}
 
 	// 验证分类是否存在
 	_, err = dataFactory.Categorys().Get(ctx, dataFactory.DB(), uint64(categoryBrand.CategoryID))
 	if err != nil {
-		log.Errorf("category not found: %v", err)
+		log.ErrorfC(ctx, "category not found: %v", err)
 		return errors.WithCode(code.ErrCategoryNotFound, "分类不存在")
 	}
 
 	// 验证品牌是否存在
 	_, err = dataFactory.Brands().Get(ctx, dataFactory.DB(), uint64(categoryBrand.BrandsID))
 	if err != nil {
-		log.Errorf("brand not found: %v", err)
+		log.ErrorfC(ctx, "brand not found: %v", err)
 		return errors.WithCode(code.ErrBrandNotFound, "品牌不存在")
 	}
 
@@ -203,7 +203,7 @@ func (cb *categoryBrand) Update(ctx context.Context, categoryBrand *dto.Category
 		if err == nil {
 			for _, existingRelation := range existingRelations.Items {
 				if existingRelation.BrandsID == categoryBrand.BrandsID && existingRelation.ID != categoryBrand.ID {
-					log.Errorf("category brand relation already exists: category=%d, brand=%d", categoryBrand.CategoryID, categoryBrand.BrandsID)
+					log.ErrorfC(ctx, "category brand relation already exists: category=%d, brand=%d", categoryBrand.CategoryID, categoryBrand.BrandsID)
 					return errors.WithCode(code.ErrCategoryBrandNotFound, "分类品牌关系已存在")
 				}
 			}
@@ -216,7 +216,7 @@ func (cb *categoryBrand) Update(ctx context.Context, categoryBrand *dto.Category
 
 	err = dataFactory.CategoryBrands().Update(ctx, dataFactory.DB(), existing)
 	if err != nil {
-		log.Errorf("update category brand error: %v", err)
+		log.ErrorfC(ctx, "update category brand error: %v", err)
 		return err
 	}
 
@@ -229,13 +229,13 @@ func (cb *categoryBrand) Delete(ctx context.Context, ID int32) error {
 	// 检查关系是否存在
 	_, err := dataFactory.CategoryBrands().Get(ctx, dataFactory.DB(), uint64(ID))
 	if err != nil {
-		log.Errorf("category brand not found: %v", err)
+		log.ErrorfC(ctx, "category brand not found: %v", err)
 		return errors.WithCode(code.ErrCategoryBrandNotFound, "分类品牌关系不存在")
 	}
 
 	err = dataFactory.CategoryBrands().Delete(ctx, dataFactory.DB(), uint64(ID))
 	if err != nil {
-		log.Errorf("delete category brand error: %v", err)
+		log.ErrorfC(ctx, "delete category brand error: %v", err)
 		return err
 	}
 
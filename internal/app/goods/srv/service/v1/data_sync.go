@@ -18,16 +18,16 @@ func newDataSync(srv *service) *dataSyncService {
 }
 
 func (ds *dataSyncService) SyncGoodsData(ctx context.Context, forceSync bool, goodsIds []uint64) (*sync.SyncResult, error) {
-	log.Infof("starting data sync: forceSync=%v, goodsIds=%v", forceSync, goodsIds)
+	log.InfofC(ctx, "starting data sync: forceSync=%v, goodsIds=%v", forceSync, goodsIds)
 	
 	syncManager := ds.factoryManager.GetSyncManager()
 	result, err := syncManager.SyncAllGoodsToSearch(ctx, forceSync, goodsIds)
 	if err != nil {
-		log.Errorf("data sync failed: %v", err)
+		log.ErrorfC(ctx, "data sync failed: %v", err)
 		return nil, err
 	}
 	
-	log.Infof("data sync completed: synced=%d, failed=%d", result.SyncedCount, result.FailedCount)
+	log.InfofC(ctx, "data sync completed: synced=%d, failed=%d", result.SyncedCount, result.FailedCount)
 	return result, nil
 }
 
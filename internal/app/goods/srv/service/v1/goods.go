@@ -44,7 +44,7 @@ func newGoods(srv *service) *goodsService {
 }
 
 func (gs *goodsService) List(ctx context.Context, opts metav1.ListMeta, req *proto.GoodsFilterRequest, orderby []string) (*dto.GoodsDTOList, error) {
-	log.Debugf("Listing goods with search conditions: keywords=%v, brand=%v", req.KeyWords, req.Brand)
+	log.DebugfC(ctx, "Listing goods with search conditions: keywords=%v, brand=%v", req.KeyWords, req.Brand)
 	
 	// 检查是否有搜索条件
 	hasSearchConditions := false
@@ -68,7 +68,7 @@ func (gs *goodsService) List(ctx context.Context, opts metav1.ListMeta, req *pro
 		// 使用预加载的categoryDAO
 		category, err := gs.categoryDAO.Get(ctx, gs.db, uint64(*req.TopCategory))
 		if err != nil {
-			log.Errorf("categoryData.Get err: %v", err)
+			log.ErrorfC(ctx, "categoryData.Get err: %v", err)
 			return nil, err
 		}
 		// 树形结构遍历
@@ -79,16 +79,16 @@ func (gs *goodsService) List(ctx context.Context, opts metav1.ListMeta, req *pro
 
 	// 如果没有搜索条件，直接查询MySQL - 使用预加载的DAO
 	if !hasSearchConditions {
-		log.Debugf("No search conditions, querying MySQL directly")
+		log.DebugfC(ctx, "No search conditions, querying MySQL directly")
 		goods, err := gs.goodsDAO.List(ctx, gs.db, orderby, opts)
 		if err != nil {
-			log.Errorf("Failed to list goods from MySQL: %v", err)
+			log.ErrorfC(ctx, "Failed to list goods from MySQL: %v", err)
 			return nil, err
 		}
 		
 		// 业务逻辑层：数据转换
 		ret := gs.convertToGoodsDTOList(goods)
-		log.Debugf("Successfully listed %d goods from MySQL, total: %d", len(ret.Items), ret.TotalCount)
+		log.DebugfC(ctx, "Successfully listed %d goods from MySQL, total: %d", len(ret.Items), ret.TotalCount)
 		return ret, nil
 	}
 
@@ -135,18 +135,18 @@ func (gs *goodsService) List(ctx context.Context, opts metav1.ListMeta, req *pro
 	}
 
 	// 调试输出
-	log.Debugf("ES Search Request: %+v", searchReq)
-	log.Debugf("CategoryIDs: %v", searchReq.CategoryIDs)
+	log.DebugfC(ctx, "ES Search Request: %+v", searchReq)
+	log.DebugfC(ctx, "CategoryIDs: %v", searchReq.CategoryIDs)
 
 	// 通过搜索引擎查询（使用工厂管理器获取搜索功能）
 	dataFactory := gs.factoryManager.GetDataFactory()
 	goodsList, err := dataFactory.Search().Goods().Search(ctx, searchReq)
 	if err != nil {
-		log.Errorf("ES search failed: %v", err)
+		log.ErrorfC(ctx, "ES search failed: %v", err)
 		return nil, err
 	}
 
-	log.Debugf("ES search results: total=%d, items=%d", goodsList.TotalCount, len(goodsList.Items))
+	log.DebugfC(ctx, "ES search results: total=%d, items=%d", goodsList.TotalCount, len(goodsList.Items))
 
 	// 提取商品ID列表
 	goodsIDs := make([]uint64, 0, len(goodsList.Items))
@@ -157,7 +157,7 @@ func (gs *goodsService) List(ctx context.Context, opts metav1.ListMeta, req *pro
 	// 通过ID批量查询MySQL数据 - 使用预加载的DAO
 	goods, err := gs.goodsDAO.ListByIDs(ctx, gs.db, goodsIDs, orderby)
 	if err != nil {
-		log.Errorf("Failed to list goods by IDs from MySQL: %v", err)
+		log.ErrorfC(ctx, "Failed to list goods by IDs from MySQL: %v", err)
 		return nil, err
 	}
 	
@@ -172,7 +172,7 @@ func (gs *goodsService) List(ctx context.Context, opts metav1.ListMeta, req *pro
 		})
 	}
 	
-	log.Debugf("Successfully searched and listed %d goods, total: %d", len(ret.Items), ret.TotalCount)
+	log.DebugfC(ctx, "Successfully searched and listed %d goods, total: %d", len(ret.Items), ret.TotalCount)
 	return ret, nil
 }
 
@@ -191,34 +191,34 @@ func (gs *goodsService) convertToGoodsDTOList(goods *do.GoodsDOList) *dto.GoodsD
 }
 
 func (gs *goodsService) Get(ctx context.Context, ID uint64) (*dto.GoodsDTO, error) {
-	log.Debugf("Getting goods by ID: %d", ID)
+	log.DebugfC(ctx, "Getting goods by ID: %d", ID)
 	
 	// 直接使用预加载的DAO
 	goods, err := gs.goodsDAO.Get(ctx, gs.db, ID)
 	if err != nil {
-		log.Errorf("Failed to get goods by ID %d: %v", ID, err)
+		log.ErrorfC(ctx, "Failed to get goods by ID %d: %v", ID, err)
 		return nil, err
 	}
 	
-	log.Debugf("Successfully got goods by ID: %d", ID)
+	log.DebugfC(ctx, "Successfully got goods by ID: %d", ID)
 	return &dto.GoodsDTO{
 		GoodsDO: *goods,
 	}, nil
 }
 
 func (gs *goodsService) Create(ctx context.Context, goods *dto.GoodsDTO) (*dto.GoodsDTO, error) {
-	log.Debugf("Creating goods: name=%s, brandID=%d, categoryID=%d", goods.Name, goods.BrandsID, goods.CategoryID)
+	log.DebugfC(ctx, "Creating goods: name=%s, brandID=%d, categoryID=%d", goods.Name, goods.BrandsID, goods.CategoryID)
 	
 	// 验证品牌和分类是否存在 - 使用预加载的DAO
 	_, err := gs.brandDAO.Get(ctx, gs.db, uint64(goods.BrandsID))
 	if err != nil {
-		log.Errorf("Brand not found: ID=%d, error=%v", goods.BrandsID, err)
+		log.ErrorfC(ctx, "Brand not found: ID=%d, error=%v", goods.BrandsID, err)
 		return nil, err
 	}
 
 	_, err = gs.categoryDAO.Get(ctx, gs.db, uint64(goods.CategoryID))
 	if err != nil {
-		log.Errorf("Category not found: ID=%d, error=%v", goods.CategoryID, err)
+		log.ErrorfC(ctx, "Category not found: ID=%d, error=%v", goods.CategoryID, err)
 		return nil, err
 	}
 
@@ -228,14 +228,14 @@ func (gs *goodsService) Create(ctx context.Context, goods *dto.GoodsDTO) (*dto.G
 	defer func() {
 		if r := recover(); r != nil {
 			txn.Rollback()
-			log.Errorf("goodsService.Create panic: %v", r)
+			log.ErrorfC(ctx, "goodsService.Create panic: %v", r)
 			return
 		}
 	}()
 
 	err = dataFactory.Goods().Create(ctx, txn, &goods.GoodsDO)
 	if err != nil {
-		log.Errorf("data.Create err: %v", err)
+		log.ErrorfC(ctx, "data.Create err: %v", err)
 		txn.Rollback()
 		return nil, err
 	}
@@ -243,7 +243,7 @@ func (gs *goodsService) Create(ctx context.Context, goods *dto.GoodsDTO) (*dto.G
 	// 检查是否启用服务层ES同步
 	esOptions := gs.factoryManager.GetEsOptions()
 	if esOptions.EnableServiceSync {
-		log.Debugf("Service-level ES sync enabled, syncing to Elasticsearch")
+		log.DebugfC(ctx, "Service-level ES sync enabled, syncing to Elasticsearch")
 		// 构建搜索对象
 		searchDO := do.GoodsSearchDO{
 			ID:          goods.ID,
@@ -268,7 +268,7 @@ func (gs *goodsService) Create(ctx context.Context, goods *dto.GoodsDTO) (*dto.G
 			return nil, err
 		}
 	} else {
-		log.Debugf("Service-level ES sync disabled, relying on Canal for synchronization")
+		log.DebugfC(ctx, "Service-level ES sync disabled, relying on Canal for synchronization")
 	}
 	
 	txn.Commit()
@@ -283,18 +283,18 @@ func (gs *goodsService) Create(ctx context.Context, goods *dto.GoodsDTO) (*dto.G
 }
 
 func (gs *goodsService) Update(ctx context.Context, goods *dto.GoodsDTO) error {
-	log.Debugf("Updating goods: ID=%d, name=%s, brandID=%d, categoryID=%d", goods.ID, goods.Name, goods.BrandsID, goods.CategoryID)
+	log.DebugfC(ctx, "Updating goods: ID=%d, name=%s, brandID=%d, categoryID=%d", goods.ID, goods.Name, goods.BrandsID, goods.CategoryID)
 	
 	// 验证品牌和分类是否存在 - 使用预加载的DAO
 	_, err := gs.brandDAO.Get(ctx, gs.db, uint64(goods.BrandsID))
 	if err != nil {
-		log.Errorf("Brand not found: ID=%d, error=%v", goods.BrandsID, err)
+		log.ErrorfC(ctx, "Brand not found: ID=%d, error=%v", goods.BrandsID, err)
 		return err
 	}
 
 	_, err = gs.categoryDAO.Get(ctx, gs.db, uint64(goods.CategoryID))
 	if err != nil {
-		log.Errorf("Category not found: ID=%d, error=%v", goods.CategoryID, err)
+		log.ErrorfC(ctx, "Category not found: ID=%d, error=%v", goods.CategoryID, err)
 		return err
 	}
 
@@ -304,7 +304,7 @@ func (gs *goodsService) Update(ctx context.Context, goods *dto.GoodsDTO) error {
 	defer func() {
 		if r := recover(); r != nil {
 			txn.Rollback()
-			log.Errorf("goodsService.Update panic: %v", r)
+			log.ErrorfC(ctx, "goodsService.Update panic: %v", r)
 			return
 		}
 	}()
@@ -312,7 +312,7 @@ func (gs *goodsService) Update(ctx context.Context, goods *dto.GoodsDTO) error {
 	// 更新MySQL数据
 	err = dataFactory.Goods().Update(ctx, txn, &goods.GoodsDO)
 	if err != nil {
-		log.Errorf("data.Update err: %v", err)
+		log.ErrorfC(ctx, "data.Update err: %v", err)
 		txn.Rollback()
 		return err
 	}
@@ -320,7 +320,7 @@ func (gs *goodsService) Update(ctx context.Context, goods *dto.GoodsDTO) error {
 	// 检查是否启用服务层ES同步
 	esOptions := gs.factoryManager.GetEsOptions()
 	if esOptions.EnableServiceSync {
-		log.Debugf("Service-level ES sync enabled, updating Elasticsearch")
+		log.DebugfC(ctx, "Service-level ES sync enabled, updating Elasticsearch")
 		// 更新ES数据
 		searchDO := do.GoodsSearchDO{
 			ID:          goods.ID,
@@ -345,16 +345,16 @@ func (gs *goodsService) Update(ctx context.Context, goods *dto.GoodsDTO) error {
 			return err
 		}
 	} else {
-		log.Debugf("Service-level ES sync disabled, relying on Canal for synchronization")
+		log.DebugfC(ctx, "Service-level ES sync disabled, relying on Canal for synchronization")
 	}
 
 	txn.Commit()
-	log.Infof("Successfully updated goods: ID=%d", goods.ID)
+	log.InfofC(ctx, "Successfully updated goods: ID=%d", goods.ID)
 	return nil
 }
 
 func (gs *goodsService) Delete(ctx context.Context, ID uint64) error {
-	log.Debugf("Deleting goods: ID=%d", ID)
+	log.DebugfC(ctx, "Deleting goods: ID=%d", ID)
 	
 	// 开启事务 - 使用保留的工厂管理器
 	dataFactory := gs.factoryManager.GetDataFactory()
@@ -362,7 +362,7 @@ func (gs *goodsService) Delete(ctx context.Context, ID uint64) error {
 	defer func() {
 		if err := recover(); err != nil {
 			txn.Rollback()
-			log.Errorf("goodsService.Delete panic: %v", err)
+			log.ErrorfC(ctx, "goodsService.Delete panic: %v", err)
 			return
 		}
 	}()
@@ -370,7 +370,7 @@ func (gs *goodsService) Delete(ctx context.Context, ID uint64) error {
 	// 删除MySQL数据
 	err := dataFactory.Goods().Delete(ctx, txn, ID)
 	if err != nil {
-		log.Errorf("data.DeleteInTxn err: %v", err)
+		log.ErrorfC(ctx, "data.DeleteInTxn err: %v", err)
 		txn.Rollback()
 		return err
 	}
@@ -378,7 +378,7 @@ func (gs *goodsService) Delete(ctx context.Context, ID uint64) error {
 	// 检查是否启用服务层ES同步
 	esOptions := gs.factoryManager.GetEsOptions()
 	if esOptions.EnableServiceSync {
-		log.Debugf("Service-level ES sync enabled, deleting from Elasticsearch")
+		log.DebugfC(ctx, "Service-level ES sync enabled, deleting from Elasticsearch")
 		// 删除ES数据
 		err = dataFactory.Search().Goods().Delete(ctx, ID)
 		if err != nil {
@@ -386,11 +386,11 @@ func (gs *goodsService) Delete(ctx context.Context, ID uint64) error {
 			return err
 		}
 	} else {
-		log.Debugf("Service-level ES sync disabled, relying on Canal for synchronization")
+		log.DebugfC(ctx, "Service-level ES sync disabled, relying on Canal for synchronization")
 	}
 
 	txn.Commit()
-	log.Infof("Successfully deleted goods: ID=%d", ID)
+	log.InfofC(ctx, "Successfully deleted goods: ID=%d", ID)
 	return nil
 }
 
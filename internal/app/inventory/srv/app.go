@@ -5,15 +5,15 @@ import (
 
 	"emshop/internal/app/inventory/srv/config"
 	gapp "emshop/gin-micro/app"
+	"emshop/gin-micro/core/health"
 	"emshop/internal/app/pkg/options"
+	"emshop/internal/app/pkg/discovery"
 	"emshop/pkg/app"
 	"emshop/pkg/log"
 	"emshop/pkg/storage"
 
-	"github.com/hashicorp/consul/api"
 
 	"emshop/gin-micro/registry"
-	"emshop/gin-micro/registry/consul"
 )
 
 func NewApp(basename string) *app.App {
@@ -28,26 +28,10 @@ func NewApp(basename string) *app.App {
 }
 
 func NewRegistrar(registry *options.RegistryOptions, dev bool) registry.Registrar {
-    c := api.DefaultConfig()
-    c.Address = registry.Address
-    c.Scheme = registry.Scheme
-    cli, err := api.NewClient(c)
+    r, err := discovery.NewRegistrar(registry, dev)
     if err != nil {
         panic(err)
     }
-    opts := []consul.Option{consul.WithHealthCheck(true)}
-    if registry.HealthCheckInterval > 0 {
-        opts = append(opts, consul.WithHealthCheckInterval(registry.HealthCheckInterval))
-    }
-    if registry.CheckTimeout > 0 {
-        opts = append(opts, consul.WithCheckTimeout(registry.CheckTimeout))
-    }
-    if dev {
-        opts = append(opts, consul.WithDeregisterCriticalServiceAfter(60))
-    } else if registry.DeregisterCriticalAfter > 0 {
-        opts = append(opts, consul.WithDeregisterCriticalServiceAfter(registry.DeregisterCriticalAfter))
-    }
-    r := consul.New(cli, opts...)
     return r
 }
 
@@ -60,35 +44,26 @@ func NewInventoryApp(cfg *config.Config) (*gapp.App, error) {
     register := NewRegistrar(cfg.Registry, cfg.Log.Development)
 
 	//连接redis
-	redisConfig := &storage.Config{
-		Host:                  cfg.RedisOptions.Host,
-		Port:                  cfg.RedisOptions.Port,
-		Addrs:                 cfg.RedisOptions.Addrs,
-		MasterName:            cfg.RedisOptions.MasterName,
-		Username:              cfg.RedisOptions.Username,
-		Password:              cfg.RedisOptions.Password,
-		Database:              cfg.RedisOptions.Database,
-		MaxIdle:               cfg.RedisOptions.MaxIdle,
-		MaxActive:             cfg.RedisOptions.MaxActive,
-		Timeout:               cfg.RedisOptions.Timeout,
-		EnableCluster:         cfg.RedisOptions.EnableCluster,
-		UseSSL:                cfg.RedisOptions.UseSSL,
-		SSLInsecureSkipVerify: cfg.RedisOptions.SSLInsecureSkipVerify,
-		EnableTracing:         cfg.RedisOptions.EnableTracing,
-	}
-	go storage.ConnectToRedis(context.Background(), redisConfig)
+	go storage.ConnectToRedis(context.Background(), cfg.RedisOptions.StorageConfig())
+	health.Register("redis", storage.Ping)
 
 	//生成rpc服务
-	rpcServer, err := NewInventoryRPCServer(cfg)
+	rpcServer, outboxWorker, err := NewInventoryRPCServer(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	return gapp.New(
+	opts := []gapp.Option{
 		gapp.WithName(cfg.Server.Name),
+		gapp.WithVersion(cfg.Server.Version),
+		gapp.WithMetadata(cfg.Server.Metadata),
 		gapp.WithRPCServer(rpcServer),
 		gapp.WithRegistrar(register),
-	), nil
+	}
+	if outboxWorker != nil {
+		opts = append(opts, gapp.WithServer(outboxWorker))
+	}
+	return gapp.New(opts...), nil
 }
 
 func run(cfg *config.Config) app.RunFunc {
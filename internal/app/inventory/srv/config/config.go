@@ -14,6 +14,7 @@ type Config struct {
 	Telemetry    *options.TelemetryOptions `json:"telemetry" mapstructure:"telemetry"`
 	Registry     *options.RegistryOptions  `json:"consul" mapstructure:"consul"`
 	RedisOptions *options.RedisOptions     `json:"redis" mapstructure:"redis"`
+	Outbox       *options.OutboxOptions    `json:"outbox" mapstructure:"outbox"`
 }
 
 func New() *Config {
@@ -25,6 +26,7 @@ func New() *Config {
 		Telemetry:    options.NewTelemetryOptions(),
 		Registry:     options.NewRegistryOptions(),
 		RedisOptions: options.NewRedisOptions(),
+		Outbox:       options.NewOutboxOptions(),
 	}
 }
 
@@ -36,6 +38,7 @@ func (o *Config) Flags() (fss cliflag.NamedFlagSets) {
 	o.Registry.AddFlags(fss.FlagSet("registry"))
 	o.MySQLOptions.AddFlags(fss.FlagSet("mysql"))
 	o.RedisOptions.AddFlags(fss.FlagSet("redis"))
+	o.Outbox.AddFlags(fss.FlagSet("outbox"))
 
 	return fss
 }
@@ -55,5 +58,6 @@ func (o *Config) Validate() []error {
 	errs = append(errs, o.Telemetry.Validate()...)
 	errs = append(errs, o.Registry.Validate()...)
 	errs = append(errs, o.RedisOptions.Validate()...)
+	errs = append(errs, o.Outbox.Validate()...)
 	return errs
 }
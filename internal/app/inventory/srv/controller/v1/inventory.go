@@ -66,7 +66,7 @@ func (is *inventoryServer) Sell(ctx context.Context, info *invpb.SellInfo) (*emp
 //	@return *emptypb.Empty 
 //	@return error 
 func (is *inventoryServer) Reback(ctx context.Context, info *invpb.SellInfo) (*emptypb.Empty, error) {
-	log.Infof("订单%s归还库存", info.OrderSn)
+	log.InfofC(ctx, "订单%s归还库存", info.OrderSn)
 	var detail []do.GoodsDetail
 	for _, v := range info.GoodsInfo {
 		detail = append(detail, do.GoodsDetail{Goods: v.GoodsId, Num: v.Num})
@@ -78,6 +78,42 @@ func (is *inventoryServer) Reback(ctx context.Context, info *invpb.SellInfo) (*e
 	return &emptypb.Empty{}, nil
 }
 
+// TrySell TCC Try：冻结库存
+func (is *inventoryServer) TrySell(ctx context.Context, info *invpb.SellInfo) (*emptypb.Empty, error) {
+	err := is.srv.Inventorys().TrySell(ctx, info.OrderSn, sellDetails(info))
+	if err != nil {
+		if errors.IsCode(err, code.ErrInvNotEnough) {
+			return nil, status.Errorf(codes.Aborted, "%s", err.Error())
+		}
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ConfirmSell TCC Confirm：确认扣减冻结的库存
+func (is *inventoryServer) ConfirmSell(ctx context.Context, info *invpb.SellInfo) (*emptypb.Empty, error) {
+	if err := is.srv.Inventorys().ConfirmSell(ctx, info.OrderSn, sellDetails(info)); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// CancelSell TCC Cancel：取消冻结
+func (is *inventoryServer) CancelSell(ctx context.Context, info *invpb.SellInfo) (*emptypb.Empty, error) {
+	if err := is.srv.Inventorys().CancelSell(ctx, info.OrderSn, sellDetails(info)); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func sellDetails(info *invpb.SellInfo) []do.GoodsDetail {
+	detail := make([]do.GoodsDetail, 0, len(info.GoodsInfo))
+	for _, v := range info.GoodsInfo {
+		detail = append(detail, do.GoodsDetail{Goods: v.GoodsId, Num: v.Num})
+	}
+	return detail
+}
+
 func NewInventoryServer(srv v1.ServiceFactory) *inventoryServer {
 	return &inventoryServer{srv: srv}
 }
@@ -70,7 +70,7 @@ func (i *inventorys) Get(ctx context.Context, db *gorm.DB, goodsID uint64) (*do.
 	inv := do.InventoryDO{}
 	err := db.Where("goods = ?", goodsID).First(&inv).Error
 	if err != nil {
-		log.Errorf("get inv err: %v", err)
+		log.ErrorfC(ctx, "get inv err: %v", err)
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.WithCode(code2.ErrInventoryNotFound, "%s", err.Error())
 		}
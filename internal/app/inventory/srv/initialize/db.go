@@ -4,6 +4,7 @@ import (
 	"emshop/internal/app/inventory/srv/domain/do"
 	"emshop/internal/app/inventory/srv/global"
 	gormtrace "emshop/pkg/observability/gormtrace"
+	"emshop/pkg/outbox"
 	"fmt"
 	"log"
 	"os"
@@ -59,6 +60,7 @@ func InitDB() {
 		&do.InventoryNewDO{},
 		&do.StockSellDetailDO{},
 		&do.DeliveryDO{},
+		&outbox.Event{},
 	)
 	if err != nil {
 		panic(fmt.Sprintf("failed to migrate database: %v", err))
@@ -36,7 +36,7 @@ func MainExample() {
 	initialize.InitFactory()
 
 	// 3. 创建服务实例
-	service := v1.NewService(global.FactoryManager.GetDataFactory(), global.Config.RedisOptions)
+	service := v1.NewService(global.FactoryManager.GetDataFactory(), global.Config.RedisOptions, nil)
 
 	// 4. 演示库存功能
 	demonstrateInventoryFeatures(service)
@@ -11,10 +11,12 @@ import (
 	"fmt"
 
 	"emshop/pkg/log"
+	"emshop/pkg/outbox"
 	"time"
 )
 
-func NewInventoryRPCServer(cfg *config.Config) (*rpcserver.Server, error) {
+// NewInventoryRPCServer 创建库存rpc服务，启用发件箱时一并返回需随应用启停的投递Worker
+func NewInventoryRPCServer(cfg *config.Config) (*rpcserver.Server, *outbox.Worker, error) {
 	//初始化open-telemetry的exporter
 	trace.InitAgent(trace.Options{
 		cfg.Telemetry.Name,
@@ -28,17 +30,28 @@ func NewInventoryRPCServer(cfg *config.Config) (*rpcserver.Server, error) {
 	if err != nil {
 		log.Fatal(err.Error())
 	}
-	invService := v13.NewService(factoryManager.GetDataFactory(), cfg.RedisOptions)
+	events, worker, err := cfg.Outbox.NewWorker(factoryManager.GetDataFactory().DB())
+	if err != nil {
+		return nil, nil, err
+	}
+	invService := v13.NewService(factoryManager.GetDataFactory(), cfg.RedisOptions, events)
 	invServer := v12.NewInventoryServer(invService)
 	rpcAddr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
+	grpcOpts, err := cfg.Server.GRPCServerOptions()
+	if err != nil {
+		return nil, nil, err
+	}
 	grpcServer := rpcserver.NewServer(
 		rpcserver.WithAddress(rpcAddr),
 		rpcserver.WithMetrics(cfg.Server.EnableMetrics),
 		rpcserver.WithTimeout(15*time.Second),
+		rpcserver.WithOptions(grpcOpts...),
+		rpcserver.WithAdaptiveLimit(cfg.Server.Limiter.AdaptiveLimiter(), nil),
+		rpcserver.WithLogAdmin(cfg.Server.LogAdminSecret),
 	)
 	gpb.RegisterInventoryServer(grpcServer.Server, invServer)
 	//r := gin.Default()
 	//upb.RegisterUserServerHTTPServer(userver, r)
 	//r.Run(":8075")
-	return grpcServer, nil
+	return grpcServer, worker, nil
 }
@@ -0,0 +1,45 @@
+package v1
+
+import (
+	"time"
+
+	"emshop/internal/app/inventory/srv/domain/do"
+)
+
+// 库存领域事件，以订单号为聚合保证同一订单的事件有序，消费方按事件ID去重
+const (
+	aggregateStockOrder = "stock_order"
+
+	EventInventorySold      = "inventory.sold"      // 库存已扣减
+	EventInventoryReverted  = "inventory.reverted"  // 已扣减库存已归还
+	EventInventoryFrozen    = "inventory.frozen"    // TCC Try：库存已冻结
+	EventInventoryConfirmed = "inventory.confirmed" // TCC Confirm：冻结库存已扣减
+	EventInventoryCancelled = "inventory.cancelled" // TCC Cancel：冻结已取消
+	EventInventoryReserved  = "inventory.reserved"  // Saga：库存已预留
+	EventInventoryReleased  = "inventory.released"  // Saga补偿：预留已释放
+)
+
+// InventoryEvent 库存事件内容
+type InventoryEvent struct {
+	OrderSn    string                `json:"order_sn"`
+	Goods      []InventoryEventGoods `json:"goods"`
+	OccurredAt time.Time             `json:"occurred_at"`
+}
+
+// InventoryEventGoods 事件涉及的商品及数量
+type InventoryEventGoods struct {
+	GoodsID int32 `json:"goods_id"`
+	Num     int32 `json:"num"`
+}
+
+func newInventoryEvent(ordersn string, details []do.GoodsDetail) *InventoryEvent {
+	goods := make([]InventoryEventGoods, 0, len(details))
+	for _, d := range details {
+		goods = append(goods, InventoryEventGoods{GoodsID: d.Goods, Num: d.Num})
+	}
+	return &InventoryEvent{
+		OrderSn:    ordersn,
+		Goods:      goods,
+		OccurredAt: time.Now(),
+	}
+}
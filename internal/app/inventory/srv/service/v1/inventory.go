@@ -8,6 +8,7 @@ import (
 	"emshop/internal/app/pkg/code"
 	"emshop/internal/app/pkg/options"
 	"emshop/pkg/errors"
+	"emshop/pkg/outbox"
 	"sort"
 	"gorm.io/gorm"
 
@@ -57,38 +58,39 @@ type inventoryService struct {
 	data         mysql.DataFactory
 	redisOptions *options.RedisOptions
 	pool         redsyncredis.Pool
+	events       *outbox.Outbox
 }
 
 func (is *inventoryService) Create(ctx context.Context, inv *dto.InventoryDTO) error {
-	log.Debugf("Creating inventory: goodsID=%d, stocks=%d", inv.Goods, inv.Stocks)
+	log.DebugfC(ctx, "Creating inventory: goodsID=%d, stocks=%d", inv.Goods, inv.Stocks)
 	
 	// 直接使用预加载的DAO
 	err := is.inventoryDAO.Create(ctx, is.db, &inv.InventoryDO)
 	if err != nil {
-		log.Errorf("Failed to create inventory: goodsID=%d, err=%v", inv.Goods, err)
+		log.ErrorfC(ctx, "Failed to create inventory: goodsID=%d, err=%v", inv.Goods, err)
 		return err
 	}
 	
-	log.Infof("Successfully created inventory: goodsID=%d, stocks=%d", inv.Goods, inv.Stocks)
+	log.InfofC(ctx, "Successfully created inventory: goodsID=%d, stocks=%d", inv.Goods, inv.Stocks)
 	return nil
 }
 
 func (is *inventoryService) Get(ctx context.Context, goodsID uint64) (*dto.InventoryDTO, error) {
-	log.Debugf("Getting inventory for goods: %d", goodsID)
+	log.DebugfC(ctx, "Getting inventory for goods: %d", goodsID)
 	
 	// 直接使用预加载的DAO
 	inv, err := is.inventoryDAO.Get(ctx, is.db, goodsID)
 	if err != nil {
-		log.Errorf("Failed to get inventory for goods %d: %v", goodsID, err)
+		log.ErrorfC(ctx, "Failed to get inventory for goods %d: %v", goodsID, err)
 		return nil, err
 	}
 	
-	log.Debugf("Successfully got inventory: goodsID=%d, stocks=%d", goodsID, inv.Stocks)
+	log.DebugfC(ctx, "Successfully got inventory: goodsID=%d, stocks=%d", goodsID, inv.Stocks)
 	return &dto.InventoryDTO{InventoryDO: *inv}, nil
 }
 
 func (is *inventoryService) Sell(ctx context.Context, ordersn string, details []do.GoodsDetail) error {
-	log.Infof("订单%s扣减库存", ordersn)
+	log.InfofC(ctx, "订单%s扣减库存", ordersn)
 	//解决了空悬挂的问题
 	//先查询刚才插入的记录是否存在，如果存在则说明已经cancel就不能执行了
 
@@ -102,7 +104,7 @@ func (is *inventoryService) Sell(ctx context.Context, ordersn string, details []
 	defer func() {
 		if err := recover(); err != nil {
 			_ = txn.Rollback()
-			log.Error("事务进行中出现异常，回滚")
+			log.ErrorC(ctx, "事务进行中出现异常，回滚")
 			return
 		}
 	}()
@@ -118,7 +120,7 @@ func (is *inventoryService) Sell(ctx context.Context, ordersn string, details []
 		mutex := rs.NewMutex(fmt.Sprintf("goods_%d", goodsInfo.Goods))
 		if err := mutex.Lock(); err != nil {
 			txn.Rollback()
-			log.Errorf("订单%s获取商品%d锁失败: %v", ordersn, goodsInfo.Goods, err)
+			log.ErrorfC(ctx, "订单%s获取商品%d锁失败: %v", ordersn, goodsInfo.Goods, err)
 			return errors.WithCode(code.ErrConnectDB, "获取分布式锁失败")
 		}
 
@@ -127,7 +129,7 @@ func (is *inventoryService) Sell(ctx context.Context, ordersn string, details []
 		if err != nil {
 			mutex.Unlock()
 			txn.Rollback()
-			log.Errorf("订单%s获取库存失败", ordersn)
+			log.ErrorfC(ctx, "订单%s获取库存失败", ordersn)
 			return err
 		}
 
@@ -135,7 +137,7 @@ func (is *inventoryService) Sell(ctx context.Context, ordersn string, details []
 		if inv.Stocks < goodsInfo.Num {
 			mutex.Unlock()
 			txn.Rollback() //回滚
-			log.Errorf("商品%d库存%d不足, 现有库存: %d", goodsInfo.Goods, goodsInfo.Num, inv.Stocks)
+			log.ErrorfC(ctx, "商品%d库存%d不足, 现有库存: %d", goodsInfo.Goods, goodsInfo.Num, inv.Stocks)
 			return errors.WithCode(code.ErrInvNotEnough, "库存不足")
 		}
 
@@ -143,29 +145,35 @@ func (is *inventoryService) Sell(ctx context.Context, ordersn string, details []
 		if err != nil {
 			mutex.Unlock()
 			txn.Rollback() //回滚
-			log.Errorf("订单%s扣减库存失败", ordersn)
+			log.ErrorfC(ctx, "订单%s扣减库存失败", ordersn)
 			return err
 		}
 
 		// 释放锁
 		if ok, err := mutex.Unlock(); !ok || err != nil {
-			log.Errorf("订单%s释放商品%d锁失败: %v", ordersn, goodsInfo.Goods, err)
+			log.ErrorfC(ctx, "订单%s释放商品%d锁失败: %v", ordersn, goodsInfo.Goods, err)
 		}
 	}
 
 	err := is.data.Inventorys().CreateStockSellDetail(ctx, txn, &sellDetail)
 	if err != nil {
 		txn.Rollback() //回滚
-		log.Errorf("订单%s创建扣减库存记录失败", ordersn)
+		log.ErrorfC(ctx, "订单%s创建扣减库存记录失败", ordersn)
 		return err
 	}
 
+	if err := is.events.Add(ctx, txn, aggregateStockOrder, ordersn, EventInventorySold, newInventoryEvent(ordersn, detail)); err != nil {
+		txn.Rollback()
+		log.ErrorfC(ctx, "订单%s写入库存扣减事件失败: %v", ordersn, err)
+		return errors.WithCode(code.ErrConnectDB, "写入库存扣减事件失败")
+	}
+
 	txn.Commit()
 	return nil
 }
 
 func (is *inventoryService) Reback(ctx context.Context, ordersn string, details []do.GoodsDetail) error {
-	log.Infof("订单%s归还库存", ordersn)
+	log.InfofC(ctx, "订单%s归还库存", ordersn)
 
 	rs := redsync.New(is.pool)
 
@@ -173,7 +181,7 @@ func (is *inventoryService) Reback(ctx context.Context, ordersn string, details
 	defer func() {
 		if err := recover(); err != nil {
 			_ = txn.Rollback()
-			log.Error("事务进行中出现异常，回滚")
+			log.ErrorC(ctx, "事务进行中出现异常，回滚")
 			return
 		}
 	}()
@@ -184,7 +192,7 @@ func (is *inventoryService) Reback(ctx context.Context, ordersn string, details
 	mutex := rs.NewMutex(orderLockPrefix + ordersn)
 	if err := mutex.Lock(); err != nil {
 		txn.Rollback() //回滚
-		log.Errorf("订单%s获取锁失败", ordersn)
+		log.ErrorfC(ctx, "订单%s获取锁失败", ordersn)
 		return err
 	}
 	sellDetail, err := is.data.Inventorys().GetSellDetail(ctx, txn, ordersn)
@@ -192,21 +200,21 @@ func (is *inventoryService) Reback(ctx context.Context, ordersn string, details
 		txn.Rollback()
 		_, err := mutex.Unlock()
 		if err != nil {
-			log.Errorf("订单%s释放锁出现异常", ordersn)
+			log.ErrorfC(ctx, "订单%s释放锁出现异常", ordersn)
 			return err
 		}
 		if errors.IsCode(err, code.ErrInvSellDetailNotFound) {
 			//空回滚
-			log.Errorf("订单%s扣减库存记录不存在, 忽略", ordersn)
+			log.ErrorfC(ctx, "订单%s扣减库存记录不存在, 忽略", ordersn)
 			//我应该记录一条数据去记录，说 ordersn已经被cancel了
 			return nil
 		}
-		log.Errorf("订单%s获取扣减库存记录失败", ordersn)
+		log.ErrorfC(ctx, "订单%s获取扣减库存记录失败", ordersn)
 		return err
 	}
 
 	if sellDetail.Status == 2 {
-		log.Infof("订单%s扣减库存记录已经归还, 忽略", ordersn)
+		log.InfofC(ctx, "订单%s扣减库存记录已经归还, 忽略", ordersn)
 		return nil
 	}
 
@@ -218,7 +226,7 @@ func (is *inventoryService) Reback(ctx context.Context, ordersn string, details
 		inv, err := is.inventoryDAO.Get(ctx, is.db, uint64(goodsInfo.Goods))
 		if err != nil {
 			txn.Rollback() //回滚
-			log.Errorf("订单%s获取库存失败", ordersn)
+			log.ErrorfC(ctx, "订单%s获取库存失败", ordersn)
 			return err
 		}
 		inv.Stocks += goodsInfo.Num
@@ -226,7 +234,7 @@ func (is *inventoryService) Reback(ctx context.Context, ordersn string, details
 		err = is.data.Inventorys().Increase(ctx, txn, uint64(goodsInfo.Goods), int(goodsInfo.Num))
 		if err != nil {
 			txn.Rollback() //回滚
-			log.Errorf("订单%s归还库存失败", ordersn)
+			log.ErrorfC(ctx, "订单%s归还库存失败", ordersn)
 			return err
 		}
 	}
@@ -234,17 +242,23 @@ func (is *inventoryService) Reback(ctx context.Context, ordersn string, details
 	err = is.data.Inventorys().UpdateStockSellDetailStatus(ctx, txn, ordersn, 2)
 	if err != nil {
 		txn.Rollback() //回滚
-		log.Errorf("订单%s更新扣减库存记录失败", ordersn)
+		log.ErrorfC(ctx, "订单%s更新扣减库存记录失败", ordersn)
 		return err
 	}
 
+	if err := is.events.Add(ctx, txn, aggregateStockOrder, ordersn, EventInventoryReverted, newInventoryEvent(ordersn, detail)); err != nil {
+		txn.Rollback()
+		log.ErrorfC(ctx, "订单%s写入库存归还事件失败: %v", ordersn, err)
+		return errors.WithCode(code.ErrConnectDB, "写入库存归还事件失败")
+	}
+
 	txn.Commit()
 	return nil
 }
 
 // TrySell 冻结库存 - TCC分布式事务Try阶段
 func (is *inventoryService) TrySell(ctx context.Context, ordersn string, details []do.GoodsDetail) error {
-	log.Infof("订单%s冻结库存", ordersn)
+	log.InfofC(ctx, "订单%s冻结库存", ordersn)
 
 	rs := redsync.New(is.pool)
 	var detail = do.GoodsDetailList(details)
@@ -254,7 +268,7 @@ func (is *inventoryService) TrySell(ctx context.Context, ordersn string, details
 	defer func() {
 		if err := recover(); err != nil {
 			_ = txn.Rollback()
-			log.Error("事务进行中出现异常，回滚")
+			log.ErrorC(ctx, "事务进行中出现异常，回滚")
 			return
 		}
 	}()
@@ -269,7 +283,7 @@ func (is *inventoryService) TrySell(ctx context.Context, ordersn string, details
 		mutex := rs.NewMutex(fmt.Sprintf("goods_%d", goodsInfo.Goods))
 		if err := mutex.Lock(); err != nil {
 			txn.Rollback()
-			log.Errorf("订单%s获取商品%d锁失败: %v", ordersn, goodsInfo.Goods, err)
+			log.ErrorfC(ctx, "订单%s获取商品%d锁失败: %v", ordersn, goodsInfo.Goods, err)
 			return errors.WithCode(code.ErrInventoryNotFound, "获取分布式锁失败")
 		}
 
@@ -295,7 +309,7 @@ func (is *inventoryService) TrySell(ctx context.Context, ordersn string, details
 		// 或者通过创建冻结记录来实现
 
 		if ok, err := mutex.Unlock(); !ok || err != nil {
-			log.Errorf("订单%s释放商品%d锁失败: %v", ordersn, goodsInfo.Goods, err)
+			log.ErrorfC(ctx, "订单%s释放商品%d锁失败: %v", ordersn, goodsInfo.Goods, err)
 		}
 	}
 
@@ -305,28 +319,40 @@ func (is *inventoryService) TrySell(ctx context.Context, ordersn string, details
 		return errors.WithCode(code.ErrInventoryNotFound, "创建出库单失败")
 	}
 
+	if err := is.events.Add(ctx, txn, aggregateStockOrder, ordersn, EventInventoryFrozen, newInventoryEvent(ordersn, detail)); err != nil {
+		txn.Rollback()
+		log.ErrorfC(ctx, "订单%s写入库存冻结事件失败: %v", ordersn, err)
+		return errors.WithCode(code.ErrConnectDB, "写入库存冻结事件失败")
+	}
+
 	txn.Commit()
 	return nil
 }
 
 // ConfirmSell 确认扣减 - TCC分布式事务Confirm阶段
 func (is *inventoryService) ConfirmSell(ctx context.Context, ordersn string, details []do.GoodsDetail) error {
-	log.Infof("订单%s确认扣减库存", ordersn)
+	log.InfofC(ctx, "订单%s确认扣减库存", ordersn)
 
 	txn := is.data.Begin()
 	defer func() {
 		if err := recover(); err != nil {
 			_ = txn.Rollback()
-			log.Error("事务进行中出现异常，回滚")
+			log.ErrorC(ctx, "事务进行中出现异常，回滚")
 			return
 		}
 	}()
 
-	// 更新出库单状态为支付成功
-	if err := txn.Model(&do.DeliveryDO{}).Where("order_sn = ?", ordersn).Update("status", "2").Error; err != nil {
+	// 更新出库单状态为支付成功，只处理等待支付的出库单，重复确认或已取消时不再扣减
+	result := txn.Model(&do.DeliveryDO{}).Where("order_sn = ? AND status = ?", ordersn, "1").Update("status", "2")
+	if result.Error != nil {
 		txn.Rollback()
 		return errors.WithCode(code.ErrInventoryNotFound, "更新出库单状态失败")
 	}
+	if result.RowsAffected == 0 {
+		txn.Rollback()
+		log.InfofC(ctx, "订单%s出库单不是等待支付状态，跳过确认", ordersn)
+		return nil
+	}
 
 	// 执行实际的库存扣减
 	for _, goodsInfo := range details {
@@ -337,29 +363,45 @@ func (is *inventoryService) ConfirmSell(ctx context.Context, ordersn string, det
 		}
 	}
 
+	if err := is.events.Add(ctx, txn, aggregateStockOrder, ordersn, EventInventoryConfirmed, newInventoryEvent(ordersn, details)); err != nil {
+		txn.Rollback()
+		log.ErrorfC(ctx, "订单%s写入库存确认事件失败: %v", ordersn, err)
+		return errors.WithCode(code.ErrConnectDB, "写入库存确认事件失败")
+	}
+
 	txn.Commit()
 	return nil
 }
 
 // CancelSell 取消冻结 - TCC分布式事务Cancel阶段
 func (is *inventoryService) CancelSell(ctx context.Context, ordersn string, details []do.GoodsDetail) error {
-	log.Infof("订单%s取消库存冻结", ordersn)
+	log.InfofC(ctx, "订单%s取消库存冻结", ordersn)
 
 	txn := is.data.Begin()
 	defer func() {
 		if err := recover(); err != nil {
 			_ = txn.Rollback()
-			log.Error("事务进行中出现异常，回滚")
+			log.ErrorC(ctx, "事务进行中出现异常，回滚")
 			return
 		}
 	}()
 
-	// 更新出库单状态为失败
-	if err := txn.Model(&do.DeliveryDO{}).Where("order_sn = ?", ordersn).Update("status", "3").Error; err != nil {
+	// 更新出库单状态为失败，已确认的出库单不能取消
+	result := txn.Model(&do.DeliveryDO{}).Where("order_sn = ? AND status = ?", ordersn, "1").Update("status", "3")
+	if result.Error != nil {
 		txn.Rollback()
 		return errors.WithCode(code.ErrInventoryNotFound, "更新出库单状态失败")
 	}
 
+	// 只有实际取消了冻结才发布事件，空回滚和重复取消不产生事件
+	if result.RowsAffected > 0 {
+		if err := is.events.Add(ctx, txn, aggregateStockOrder, ordersn, EventInventoryCancelled, newInventoryEvent(ordersn, details)); err != nil {
+			txn.Rollback()
+			log.ErrorfC(ctx, "订单%s写入库存冻结取消事件失败: %v", ordersn, err)
+			return errors.WithCode(code.ErrConnectDB, "写入库存冻结取消事件失败")
+		}
+	}
+
 	// 释放冻结的库存
 	// TODO: 这里需要根据具体的冻结实现来释放库存
 
@@ -369,10 +411,10 @@ func (is *inventoryService) CancelSell(ctx context.Context, ordersn string, deta
 
 // ReserveStock 预留库存 - Saga分布式事务（用于订单提交阶段）
 func (is *inventoryService) ReserveStock(ctx context.Context, ordersn string, details []do.GoodsDetail) error {
-	log.Infof("订单%s预留库存", ordersn)
+	log.InfofC(ctx, "订单%s预留库存", ordersn)
 
 	if len(details) == 0 {
-		log.Infof("订单%s没有商品，跳过库存预留", ordersn)
+		log.InfofC(ctx, "订单%s没有商品，跳过库存预留", ordersn)
 		return nil
 	}
 
@@ -384,7 +426,7 @@ func (is *inventoryService) ReserveStock(ctx context.Context, ordersn string, de
 	defer func() {
 		if err := recover(); err != nil {
 			_ = txn.Rollback()
-			log.Error("事务进行中出现异常，回滚")
+			log.ErrorC(ctx, "事务进行中出现异常，回滚")
 			return
 		}
 	}()
@@ -393,7 +435,7 @@ func (is *inventoryService) ReserveStock(ctx context.Context, ordersn string, de
 	existingReservation, err := is.data.Inventorys().GetSellDetail(ctx, txn, ordersn)
 	if err == nil && existingReservation != nil {
 		if existingReservation.Status == 1 {
-			log.Infof("订单%s库存已预留，跳过", ordersn)
+			log.InfofC(ctx, "订单%s库存已预留，跳过", ordersn)
 			txn.Rollback() // 不需要修改，回滚事务
 			return nil
 		}
@@ -414,7 +456,7 @@ func (is *inventoryService) ReserveStock(ctx context.Context, ordersn string, de
 		mutex := rs.NewMutex(fmt.Sprintf("goods_%d", goodsInfo.Goods))
 		if err := mutex.Lock(); err != nil {
 			txn.Rollback()
-			log.Errorf("订单%s获取商品%d锁失败: %v", ordersn, goodsInfo.Goods, err)
+			log.ErrorfC(ctx, "订单%s获取商品%d锁失败: %v", ordersn, goodsInfo.Goods, err)
 			return errors.WithCode(code.ErrConnectDB, "获取分布式锁失败")
 		}
 
@@ -424,7 +466,7 @@ func (is *inventoryService) ReserveStock(ctx context.Context, ordersn string, de
 		if err != nil {
 			mutex.Unlock()
 			txn.Rollback()
-			log.Errorf("订单%s获取商品%d库存失败: %v", ordersn, goodsInfo.Goods, err)
+			log.ErrorfC(ctx, "订单%s获取商品%d库存失败: %v", ordersn, goodsInfo.Goods, err)
 			return err
 		}
 
@@ -432,7 +474,7 @@ func (is *inventoryService) ReserveStock(ctx context.Context, ordersn string, de
 		if inv.Stocks < goodsInfo.Num {
 			mutex.Unlock()
 			txn.Rollback()
-			log.Errorf("订单%s商品%d库存不足：需要%d，现有%d", ordersn, goodsInfo.Goods, goodsInfo.Num, inv.Stocks)
+			log.ErrorfC(ctx, "订单%s商品%d库存不足：需要%d，现有%d", ordersn, goodsInfo.Goods, goodsInfo.Num, inv.Stocks)
 			return errors.WithCode(code.ErrInvNotEnough, "库存不足")
 		}
 
@@ -440,31 +482,37 @@ func (is *inventoryService) ReserveStock(ctx context.Context, ordersn string, de
 		// 实际的库存扣减将在支付成功后的ConfirmSell中执行
 
 		if ok, err := mutex.Unlock(); !ok || err != nil {
-			log.Errorf("订单%s释放商品%d锁失败: %v", ordersn, goodsInfo.Goods, err)
+			log.ErrorfC(ctx, "订单%s释放商品%d锁失败: %v", ordersn, goodsInfo.Goods, err)
 		}
 	}
 
 	// 保存预留记录
 	if err := is.data.Inventorys().CreateStockSellDetail(ctx, txn, &reservationDetail); err != nil {
 		txn.Rollback()
-		log.Errorf("订单%s创建库存预留记录失败: %v", ordersn, err)
+		log.ErrorfC(ctx, "订单%s创建库存预留记录失败: %v", ordersn, err)
 		return errors.WithCode(code.ErrConnectDB, "创建库存预留记录失败")
 	}
 
+	if err := is.events.Add(ctx, txn, aggregateStockOrder, ordersn, EventInventoryReserved, newInventoryEvent(ordersn, detail)); err != nil {
+		txn.Rollback()
+		log.ErrorfC(ctx, "订单%s写入库存预留事件失败: %v", ordersn, err)
+		return errors.WithCode(code.ErrConnectDB, "写入库存预留事件失败")
+	}
+
 	txn.Commit()
-	log.Infof("订单%s库存预留成功", ordersn)
+	log.InfofC(ctx, "订单%s库存预留成功", ordersn)
 	return nil
 }
 
 // ReleaseReserved 释放预留库存 - Saga分布式事务补偿（用于订单提交失败时）
 func (is *inventoryService) ReleaseReserved(ctx context.Context, ordersn string, details []do.GoodsDetail) error {
-	log.Infof("订单%s释放预留库存", ordersn)
+	log.InfofC(ctx, "订单%s释放预留库存", ordersn)
 
 	txn := is.data.Begin()
 	defer func() {
 		if err := recover(); err != nil {
 			_ = txn.Rollback()
-			log.Error("事务进行中出现异常，回滚")
+			log.ErrorC(ctx, "事务进行中出现异常，回滚")
 			return
 		}
 	}()
@@ -475,17 +523,17 @@ func (is *inventoryService) ReleaseReserved(ctx context.Context, ordersn string,
 		txn.Rollback()
 		if errors.IsCode(err, code.ErrInvSellDetailNotFound) {
 			// 没有预留记录，说明预留操作未成功，直接返回成功（幂等性）
-			log.Infof("订单%s没有库存预留记录，忽略释放操作", ordersn)
+			log.InfofC(ctx, "订单%s没有库存预留记录，忽略释放操作", ordersn)
 			return nil
 		}
-		log.Errorf("订单%s查询预留记录失败: %v", ordersn, err)
+		log.ErrorfC(ctx, "订单%s查询预留记录失败: %v", ordersn, err)
 		return err
 	}
 
 	// 检查状态，避免重复释放
 	if reservationDetail.Status == 0 {
 		// 已释放
-		log.Infof("订单%s库存预留已释放，忽略", ordersn)
+		log.InfofC(ctx, "订单%s库存预留已释放，忽略", ordersn)
 		txn.Rollback()
 		return nil
 	}
@@ -493,12 +541,18 @@ func (is *inventoryService) ReleaseReserved(ctx context.Context, ordersn string,
 	// 更新预留记录状态为已释放
 	if err := is.data.Inventorys().UpdateStockSellDetailStatus(ctx, txn, ordersn, 0); err != nil {
 		txn.Rollback()
-		log.Errorf("订单%s更新预留记录状态失败: %v", ordersn, err)
+		log.ErrorfC(ctx, "订单%s更新预留记录状态失败: %v", ordersn, err)
 		return errors.WithCode(code.ErrConnectDB, "更新预留记录状态失败")
 	}
 
+	if err := is.events.Add(ctx, txn, aggregateStockOrder, ordersn, EventInventoryReleased, newInventoryEvent(ordersn, details)); err != nil {
+		txn.Rollback()
+		log.ErrorfC(ctx, "订单%s写入库存预留释放事件失败: %v", ordersn, err)
+		return errors.WithCode(code.ErrConnectDB, "写入库存预留释放事件失败")
+	}
+
 	txn.Commit()
-	log.Infof("订单%s释放预留库存成功", ordersn)
+	log.InfofC(ctx, "订单%s释放预留库存成功", ordersn)
 	return nil
 }
 
@@ -512,6 +566,7 @@ func newInventoryService(s *service) *inventoryService {
 		data:         s.data,
 		redisOptions: s.redisOptions,
 		pool:         s.pool,
+		events:       s.events,
 	}
 }
 
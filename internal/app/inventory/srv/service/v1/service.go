@@ -3,6 +3,7 @@ package v1
 import (
 	"emshop/internal/app/inventory/srv/data/v1/mysql"
 	"emshop/internal/app/pkg/options"
+	"emshop/pkg/outbox"
 	"fmt"
 
 	goredislib "github.com/go-redis/redis/v8"
@@ -20,6 +21,7 @@ type service struct {
 
 	redisOptions *options.RedisOptions
 	pool         redsyncredis.Pool
+	events       *outbox.Outbox
 }
 
 func (s *service) Inventorys() InventorySrv {
@@ -27,13 +29,13 @@ func (s *service) Inventorys() InventorySrv {
 	return newInventoryService(s)
 }
 
-func NewService(store mysql.DataFactory, redisOptions *options.RedisOptions) *service {
+func NewService(store mysql.DataFactory, redisOptions *options.RedisOptions, events *outbox.Outbox) *service {
 	client := goredislib.NewClient(&goredislib.Options{
 		Addr: fmt.Sprintf("%s:%d", redisOptions.Host, redisOptions.Port),
 	})
 	pool := goredis.NewPool(client) // or, pool := redigo.NewPool(...)
 
-	return &service{data: store, redisOptions: redisOptions, pool: pool}
+	return &service{data: store, redisOptions: redisOptions, pool: pool, events: events}
 }
 
 var _ ServiceFactory = &service{}
@@ -32,7 +32,7 @@ func init() {
 }
 
 func TestInventoryService_Create(t *testing.T) {
-	service := v1.NewService(global.FactoryManager.GetDataFactory(), global.Config.RedisOptions)
+	service := v1.NewService(global.FactoryManager.GetDataFactory(), global.Config.RedisOptions, nil)
 
 	inv := &dto.InventoryDTO{}
 	inv.Goods = 1001
@@ -47,7 +47,7 @@ func TestInventoryService_Create(t *testing.T) {
 }
 
 func TestInventoryService_Get(t *testing.T) {
-	service := v1.NewService(global.FactoryManager.GetDataFactory(), global.Config.RedisOptions)
+	service := v1.NewService(global.FactoryManager.GetDataFactory(), global.Config.RedisOptions, nil)
 
 	inv, err := service.Inventorys().Get(context.Background(), 1001)
 	if err != nil {
@@ -58,7 +58,7 @@ func TestInventoryService_Get(t *testing.T) {
 }
 
 func TestInventoryService_Sell(t *testing.T) {
-	service := v1.NewService(global.FactoryManager.GetDataFactory(), global.Config.RedisOptions)
+	service := v1.NewService(global.FactoryManager.GetDataFactory(), global.Config.RedisOptions, nil)
 
 	detail := []do.GoodsDetail{
 		{Goods: 1001, Num: 10},
@@ -74,7 +74,7 @@ func TestInventoryService_Sell(t *testing.T) {
 }
 
 func TestInventoryService_TCC(t *testing.T) {
-	service := v1.NewService(global.FactoryManager.GetDataFactory(), global.Config.RedisOptions)
+	service := v1.NewService(global.FactoryManager.GetDataFactory(), global.Config.RedisOptions, nil)
 
 	detail := []do.GoodsDetail{
 		{Goods: 1001, Num: 5},
@@ -102,7 +102,7 @@ func TestInventoryService_TCC(t *testing.T) {
 }
 
 func TestInventoryService_TCCCancel(t *testing.T) {
-	service := v1.NewService(global.FactoryManager.GetDataFactory(), global.Config.RedisOptions)
+	service := v1.NewService(global.FactoryManager.GetDataFactory(), global.Config.RedisOptions, nil)
 
 	detail := []do.GoodsDetail{
 		{Goods: 1001, Num: 3},
@@ -0,0 +1,143 @@
+package srv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"emshop/internal/app/logistics/srv/config"
+	"emshop/internal/app/logistics/srv/pkg/carrier"
+	service "emshop/internal/app/logistics/srv/service/v1"
+	"emshop/internal/app/pkg/options"
+	"emshop/pkg/delayjob"
+	"emshop/pkg/log"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+// NewCarrierRegistry 按配置注册承运商，未配置的物流公司使用内置模拟承运商
+func NewCarrierRegistry(opts *config.CarrierOptions) *carrier.Registry {
+	registry := carrier.NewRegistry(func(company int32) carrier.Carrier {
+		return carrier.NewMockCarrier(company, opts.MockStep)
+	})
+	for _, ep := range opts.Endpoints {
+		registry.Register(carrier.NewHTTPCarrier(carrier.HTTPConfig{
+			Company:            ep.Company,
+			Endpoint:           ep.URL,
+			AppKey:             ep.AppKey,
+			Secret:             ep.Secret,
+			Push:               ep.Push,
+			Timeout:            ep.Timeout,
+			SignatureTolerance: opts.SignatureTolerance,
+		}))
+		log.Infof("接入承运商: 物流公司=%d, 地址=%s, 推送=%v", ep.Company, ep.URL, ep.Push)
+	}
+	return registry
+}
+
+// JobTypeTrackPoll 承运商轨迹轮询周期任务
+const JobTypeTrackPoll = "logistics.track.poll"
+
+const (
+	// trackPollCursorKey 轨迹轮询的续扫游标，单次执行未遍历完的在途订单由下一次从游标处继续
+	trackPollCursorKey = "logistics:track-poll:cursor"
+	// trackPollLockKey 轨迹轮询执行锁，上一次执行未结束时跳过本次
+	trackPollLockKey = "logistics:track-poll:lock"
+)
+
+var releaseTrackPollLock = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then return redis.call('DEL', KEYS[1]) end
+return 0
+`)
+
+// NewTrackPollScheduler 创建延时任务调度器并注册轨迹轮询周期任务，多副本间同一时间点只有一个副本轮询
+func NewTrackPollScheduler(redisOpts *options.RedisOptions, jobOpts *options.DelayJobOptions, logisticsSrv service.LogisticsSrv, opts *config.CarrierOptions) *delayjob.Scheduler {
+	if opts.PollInterval <= 0 {
+		log.Info("承运商轨迹轮询已关闭")
+		return nil
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%d", redisOpts.Host, redisOpts.Port),
+		Password: redisOpts.Password,
+		DB:       redisOpts.Database,
+	})
+	namespace := jobOpts.Namespace
+	if namespace == "" {
+		namespace = "logistics"
+	}
+	scheduler := delayjob.New(delayjob.NewRedisStore(client, jobOpts.StoreOptions(namespace)), jobOpts.SchedulerOptions()...)
+	poller := &trackPoller{client: client, logisticsSrv: logisticsSrv, batchSize: opts.PollBatchSize}
+	scheduler.Every(JobTypeTrackPoll, opts.PollInterval, poller.run)
+	return scheduler
+}
+
+// trackPoller 分批轮询承运商轨迹，每批完成后保存游标，超时中断的遍历由下一个时间点续扫
+type trackPoller struct {
+	client       *redis.Client
+	logisticsSrv service.LogisticsSrv
+	batchSize    int
+}
+
+func (p *trackPoller) run(ctx context.Context, job *delayjob.Job) error {
+	// 锁的有效期与本次执行的超时一致，执行方宕机后锁自动释放
+	ttl := time.Minute
+	if deadline, ok := ctx.Deadline(); ok {
+		ttl = time.Until(deadline)
+	}
+	if ttl <= 0 {
+		return ctx.Err()
+	}
+	token := uuid.NewString()
+	locked, err := p.client.SetNX(ctx, trackPollLockKey, token, ttl).Result()
+	if err != nil {
+		return err
+	}
+	if !locked {
+		log.InfoC(ctx, "上一次承运商轨迹轮询尚未结束，跳过本次")
+		return nil
+	}
+	defer func() {
+		releaseCtx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		_ = releaseTrackPollLock.Run(releaseCtx, p.client, []string{trackPollLockKey}, token).Err()
+	}()
+
+	afterID, err := p.client.Get(ctx, trackPollCursorKey).Int64()
+	if err != nil && err != redis.Nil {
+		return err
+	}
+	total := 0
+	for {
+		n, next, err := p.logisticsSrv.PollCarrierTracks(ctx, afterID, p.batchSize)
+		total += n
+		if next != afterID {
+			if err := p.saveCursor(next); err != nil {
+				log.WarnfC(ctx, "保存轨迹轮询游标失败: %v", err)
+			}
+		}
+		if err != nil {
+			log.ErrorfC(ctx, "轮询承运商轨迹失败，下次从游标%d继续: %v", next, err)
+			return err
+		}
+		if next == 0 {
+			break
+		}
+		afterID = next
+	}
+	if total > 0 {
+		log.InfofC(ctx, "轮询承运商轨迹完成，新增%d条", total)
+	}
+	return nil
+}
+
+// saveCursor 保存续扫游标，遍历完成时删除游标，下一次从头开始
+func (p *trackPoller) saveCursor(afterID int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if afterID == 0 {
+		return p.client.Del(ctx, trackPollCursorKey).Err()
+	}
+	return p.client.Set(ctx, trackPollCursorKey, afterID, 0).Err()
+}
@@ -1,9 +1,14 @@
 package config
 
 import (
+	"fmt"
+	"time"
+
 	"emshop/internal/app/pkg/options"
 	cliflag "emshop/pkg/common/cli/flag"
 	"emshop/pkg/log"
+
+	"github.com/spf13/pflag"
 )
 
 // Config 物流服务配置结构
@@ -20,6 +25,12 @@ type Config struct {
 	Registry *options.RegistryOptions `json:"registry" mapstructure:"registry"`
 	// 链路追踪配置
 	Telemetry *options.TelemetryOptions `json:"telemetry" mapstructure:"telemetry"`
+	// 承运商对接配置
+	Carrier *CarrierOptions `json:"carrier" mapstructure:"carrier"`
+	// 物流事件发件箱配置
+	Outbox *options.OutboxOptions `json:"outbox" mapstructure:"outbox"`
+	// 延时任务调度配置，承载承运商轨迹轮询
+	DelayJob *options.DelayJobOptions `json:"delayjob" mapstructure:"delayjob"`
 }
 
 // Validate 验证所有配置选项的有效性
@@ -31,6 +42,9 @@ func (c *Config) Validate() []error {
 	errors = append(errors, c.Redis.Validate()...)
 	errors = append(errors, c.Registry.Validate()...)
 	errors = append(errors, c.Telemetry.Validate()...)
+	errors = append(errors, c.Carrier.Validate()...)
+	errors = append(errors, c.Outbox.Validate()...)
+	errors = append(errors, c.DelayJob.Validate()...)
 	return errors
 }
 
@@ -42,6 +56,9 @@ func (c *Config) Flags() (fss cliflag.NamedFlagSets) {
 	c.Redis.AddFlags(fss.FlagSet("redis"))
 	c.Registry.AddFlags(fss.FlagSet("registry"))
 	c.Telemetry.AddFlags(fss.FlagSet("telemetry"))
+	c.Carrier.AddFlags(fss.FlagSet("carrier"))
+	c.Outbox.AddFlags(fss.FlagSet("outbox"))
+	c.DelayJob.AddFlags(fss.FlagSet("delayjob"))
 	return fss
 }
 
@@ -54,5 +71,70 @@ func New() *Config {
 		Redis:        options.NewRedisOptions(),
 		Registry:     options.NewRegistryOptions(),
 		Telemetry:    options.NewTelemetryOptions(),
+		Carrier:      NewCarrierOptions(),
+		Outbox:       options.NewOutboxOptions(),
+		DelayJob: func() *options.DelayJobOptions {
+			opt := options.NewDelayJobOptions()
+			opt.Namespace = "logistics"
+			return opt
+		}(),
+	}
+}
+
+// CarrierOptions 承运商对接配置，未在Endpoints中配置的物流公司使用内置模拟承运商
+type CarrierOptions struct {
+	// 轨迹轮询间隔，0表示不轮询
+	PollInterval time.Duration `json:"poll-interval" mapstructure:"poll-interval"`
+	// 每批轮询的物流订单数
+	PollBatchSize int `json:"poll-batch-size" mapstructure:"poll-batch-size"`
+	// 模拟承运商相邻轨迹节点间隔
+	MockStep time.Duration `json:"mock-step" mapstructure:"mock-step"`
+	// 推送签名时间戳允许偏差
+	SignatureTolerance time.Duration `json:"signature-tolerance" mapstructure:"signature-tolerance"`
+	// 各物流公司开放接口配置
+	Endpoints []CarrierEndpoint `json:"endpoints" mapstructure:"endpoints"`
+}
+
+// CarrierEndpoint 物流公司开放接口配置
+type CarrierEndpoint struct {
+	Company int32         `json:"company" mapstructure:"company"`
+	URL     string        `json:"url" mapstructure:"url"`
+	AppKey  string        `json:"app-key" mapstructure:"app-key"`
+	Secret  string        `json:"secret" mapstructure:"secret"`
+	Push    bool          `json:"push" mapstructure:"push"`
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+}
+
+// NewCarrierOptions 创建默认承运商配置
+func NewCarrierOptions() *CarrierOptions {
+	return &CarrierOptions{
+		PollInterval:       5 * time.Minute,
+		PollBatchSize:      100,
+		MockStep:           2 * time.Hour,
+		SignatureTolerance: 5 * time.Minute,
 	}
+}
+
+// Validate 验证承运商配置
+func (o *CarrierOptions) Validate() []error {
+	var errs []error
+	seen := make(map[int32]bool)
+	for _, ep := range o.Endpoints {
+		if ep.Company <= 0 || ep.URL == "" || ep.Secret == "" {
+			errs = append(errs, fmt.Errorf("carrier endpoint for company %d requires url and secret", ep.Company))
+		}
+		if seen[ep.Company] {
+			errs = append(errs, fmt.Errorf("duplicate carrier endpoint for company %d", ep.Company))
+		}
+		seen[ep.Company] = true
+	}
+	return errs
+}
+
+// AddFlags 添加承运商相关命令行参数
+func (o *CarrierOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&o.PollInterval, "carrier.poll-interval", o.PollInterval, "Interval of polling tracks from carriers without push support, 0 disables polling.")
+	fs.IntVar(&o.PollBatchSize, "carrier.poll-batch-size", o.PollBatchSize, "Number of logistics orders polled per batch.")
+	fs.DurationVar(&o.MockStep, "carrier.mock-step", o.MockStep, "Interval between simulated track events of the built-in mock carrier.")
+	fs.DurationVar(&o.SignatureTolerance, "carrier.signature-tolerance", o.SignatureTolerance, "Allowed clock skew of carrier push signatures.")
 }
\ No newline at end of file
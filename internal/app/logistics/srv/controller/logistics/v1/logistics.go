@@ -24,7 +24,7 @@ func NewLogisticsController(logisticsSrv v1.LogisticsSrv) *LogisticsController {
 
 // CreateLogisticsOrder 创建物流订单
 func (lc *LogisticsController) CreateLogisticsOrder(ctx context.Context, req *logisticspb.CreateLogisticsOrderRequest) (*logisticspb.CreateLogisticsOrderResponse, error) {
-	log.Infof("收到创建物流订单请求: 订单号=%s", req.OrderSn)
+	log.InfofC(ctx, "收到创建物流订单请求: 订单号=%s", req.OrderSn)
 
 	// 转换为DTO
 	items := make([]dto.OrderItemDTO, len(req.Items))
@@ -35,6 +35,7 @@ func (lc *LogisticsController) CreateLogisticsOrder(ctx context.Context, req *lo
 			Quantity: item.Quantity,
 			Weight:   item.Weight,
 			Volume:   item.Volume,
+			ShipFree: item.ShipFree,
 		}
 	}
 
@@ -51,12 +52,16 @@ func (lc *LogisticsController) CreateLogisticsOrder(ctx context.Context, req *lo
 		ReceiverAddress:  req.ReceiverAddress,
 		Items:            items,
 		Remark:           req.Remark,
+
+		OrderAmount:       req.OrderAmount,
+		FreeShipCoupon:    req.FreeShipCoupon,
+		FreeShipCouponCap: req.FreeShipCouponCap,
 	}
 
 	// 调用服务层
 	resp, err := lc.logisticsSrv.CreateLogisticsOrder(ctx, reqDTO)
 	if err != nil {
-		log.Errorf("创建物流订单失败: %v", err)
+		log.ErrorfC(ctx, "创建物流订单失败: %v", err)
 		return nil, err
 	}
 
@@ -213,6 +218,20 @@ func (lc *LogisticsController) CalculateShippingFee(ctx context.Context, req *lo
 		TotalVolume:     req.TotalVolume,
 		GoodsValue:      req.GoodsValue,
 		NeedInsurance:   req.NeedInsurance,
+
+		LogisticsCompany:  req.LogisticsCompany,
+		OrderAmount:       req.OrderAmount,
+		FreeShipCoupon:    req.FreeShipCoupon,
+		FreeShipCouponCap: req.FreeShipCouponCap,
+	}
+	for _, item := range req.Items {
+		reqDTO.Items = append(reqDTO.Items, dto.ShippingFeeItemDTO{
+			GoodsID:  item.GoodsId,
+			Quantity: item.Quantity,
+			Weight:   item.Weight,
+			Volume:   item.Volume,
+			ShipFree: item.ShipFree,
+		})
 	}
 
 	fee, err := lc.logisticsSrv.CalculateShippingFee(ctx, reqDTO)
@@ -221,10 +240,18 @@ func (lc *LogisticsController) CalculateShippingFee(ctx context.Context, req *lo
 	}
 
 	return &logisticspb.CalculateShippingFeeResponse{
-		ShippingFee:   fee.ShippingFee,
-		InsuranceFee:  fee.InsuranceFee,
-		TotalFee:      fee.TotalFee,
-		EstimatedDays: fee.EstimatedDays,
+		ShippingFee:      fee.ShippingFee,
+		InsuranceFee:     fee.InsuranceFee,
+		TotalFee:         fee.TotalFee,
+		EstimatedDays:    fee.EstimatedDays,
+		BaseFee:          fee.BaseFee,
+		RemoteSurcharge:  fee.RemoteSurcharge,
+		ChargeableWeight: fee.ChargeableWeight,
+		FromZone:         fee.FromZone,
+		ToZone:           fee.ToZone,
+		FreeShipping:     fee.FreeShipping,
+		FreeReason:       fee.FreeReason,
+		RateVersion:      fee.RateVersion,
 	}, nil
 }
 
@@ -284,10 +311,78 @@ func (lc *LogisticsController) GetCouriers(ctx context.Context, req *logisticspb
 func (lc *LogisticsController) CancelLogisticsOrder(ctx context.Context, request *logisticspb.CancelLogisticsOrderRequest) (*emptypb.Empty, error) {
 	err := lc.logisticsSrv.CancelLogisticsOrder(ctx, request.OrderSn, request.Reason)
 	if err != nil {
-		log.Errorf("取消物流订单失败: %v", err)
+		log.ErrorfC(ctx, "取消物流订单失败: %v", err)
 		return nil, err
 	}
 	
-	log.Infof("成功取消物流订单, 订单号: %s", request.OrderSn)
+	log.InfofC(ctx, "成功取消物流订单, 订单号: %s", request.OrderSn)
+	return &emptypb.Empty{}, nil
+}
+
+// ListShippingRateConfigs 查询运费配置版本列表
+func (lc *LogisticsController) ListShippingRateConfigs(ctx context.Context, req *logisticspb.ListShippingRateConfigsRequest) (*logisticspb.ListShippingRateConfigsResponse, error) {
+	list, err := lc.logisticsSrv.ListShippingRateConfigs(ctx, int(req.Page), int(req.PageSize))
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*logisticspb.ShippingRateConfig, len(list.Items))
+	for i, item := range list.Items {
+		items[i] = toShippingRateConfigPB(item)
+	}
+	return &logisticspb.ListShippingRateConfigsResponse{
+		Total: list.Total,
+		Items: items,
+	}, nil
+}
+
+// GetShippingRateConfig 查询运费配置，version为0时返回生效版本
+func (lc *LogisticsController) GetShippingRateConfig(ctx context.Context, req *logisticspb.GetShippingRateConfigRequest) (*logisticspb.ShippingRateConfig, error) {
+	cfg, err := lc.logisticsSrv.GetShippingRateConfig(ctx, req.Version)
+	if err != nil {
+		return nil, err
+	}
+	return toShippingRateConfigPB(cfg), nil
+}
+
+// CreateShippingRateConfig 创建运费配置版本
+func (lc *LogisticsController) CreateShippingRateConfig(ctx context.Context, req *logisticspb.CreateShippingRateConfigRequest) (*logisticspb.ShippingRateConfig, error) {
+	cfg, err := lc.logisticsSrv.CreateShippingRateConfig(ctx, &dto.CreateShippingRateConfigDTO{
+		Content:  req.Content,
+		Operator: req.Operator,
+		Remark:   req.Remark,
+		Activate: req.Activate,
+	})
+	if err != nil {
+		log.ErrorfC(ctx, "创建运费配置失败: %v", err)
+		return nil, err
+	}
+	return toShippingRateConfigPB(cfg), nil
+}
+
+// ActivateShippingRateConfig 生效运费配置版本
+func (lc *LogisticsController) ActivateShippingRateConfig(ctx context.Context, req *logisticspb.ActivateShippingRateConfigRequest) (*emptypb.Empty, error) {
+	if err := lc.logisticsSrv.ActivateShippingRateConfig(ctx, req.Version, req.Operator); err != nil {
+		log.ErrorfC(ctx, "生效运费配置失败: %v", err)
+		return nil, err
+	}
 	return &emptypb.Empty{}, nil
-}
\ No newline at end of file
+}
+
+func toShippingRateConfigPB(cfg *dto.ShippingRateConfigDTO) *logisticspb.ShippingRateConfig {
+	pb := &logisticspb.ShippingRateConfig{
+		Id:       cfg.ID,
+		Version:  cfg.Version,
+		Content:  cfg.Content,
+		Status:   cfg.Status,
+		Operator: cfg.Operator,
+		Remark:   cfg.Remark,
+	}
+	if !cfg.CreatedAt.IsZero() {
+		pb.CreatedAt = cfg.CreatedAt.Unix()
+	}
+	if cfg.ActivatedAt != nil {
+		pb.ActivatedAt = cfg.ActivatedAt.Unix()
+	}
+	return pb
+}
@@ -0,0 +1,48 @@
+package v1
+
+import (
+	"io"
+	"strconv"
+
+	"emshop/gin-micro/code"
+	"emshop/internal/app/logistics/srv/service/v1"
+	"emshop/pkg/common/core"
+	"emshop/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxPushBodySize 承运商推送报文上限
+const maxPushBodySize = 1 << 20
+
+// WebhookController 承运商轨迹推送控制器
+type WebhookController struct {
+	logisticsSrv v1.LogisticsSrv
+}
+
+// NewWebhookController 创建承运商推送控制器
+func NewWebhookController(logisticsSrv v1.LogisticsSrv) *WebhookController {
+	return &WebhookController{logisticsSrv: logisticsSrv}
+}
+
+// CarrierPush 接收承运商轨迹推送 POST /v1/carriers/:company/tracks
+func (wc *WebhookController) CarrierPush(ctx *gin.Context) {
+	company, err := strconv.ParseInt(ctx.Param("company"), 10, 32)
+	if err != nil || company <= 0 {
+		core.WriteResponse(ctx, errors.WithCode(code.ErrBind, "物流公司编号格式不正确"), nil)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(ctx.Request.Body, maxPushBodySize))
+	if err != nil {
+		core.WriteResponse(ctx, errors.WithCode(code.ErrBind, "读取推送内容失败"), nil)
+		return
+	}
+
+	accepted, err := wc.logisticsSrv.HandleCarrierPush(ctx, int32(company), ctx.Request.Header, body)
+	if err != nil {
+		core.WriteResponse(ctx, err, nil)
+		return
+	}
+	core.WriteResponse(ctx, nil, gin.H{"accepted": accepted})
+}
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"emshop/gin-micro/core/health"
 	"emshop/internal/app/logistics/srv/data/v1/interfaces"
 	"emshop/internal/app/logistics/srv/data/v1/mysql"
 	"emshop/internal/app/pkg/options"
@@ -94,6 +95,7 @@ func createDBConnection(opts *options.MySQLOptions) (*gorm.DB, error) {
 	sqlDB.SetMaxIdleConns(opts.MaxIdleConnections)
 	sqlDB.SetMaxOpenConns(opts.MaxOpenConnections)
 	sqlDB.SetConnMaxLifetime(opts.MaxConnectionLifetime)
+	health.Register("mysql", health.PingCheck(sqlDB))
 
 	log.Infof("Successfully connected to MySQL database: %s", opts.Database)
 	return db, nil
@@ -36,6 +36,9 @@ type LogisticsOrdersRepo interface {
 	// 根据状态查询物流订单
 	FindByStatus(ctx context.Context, db *gorm.DB, status int32) ([]*do.LogisticsOrderDO, error)
 	
+	// 按ID游标分批查询指定状态的物流订单
+	FindByStatusesAfter(ctx context.Context, db *gorm.DB, statuses []int32, afterID int64, limit int) ([]*do.LogisticsOrderDO, error)
+	
 	// 更新物流订单 (通用更新方法)
 	Update(ctx context.Context, db *gorm.DB, order *do.LogisticsOrderDO) error
 }
@@ -45,6 +48,9 @@ type LogisticsTracksRepo interface {
 	// 创建轨迹记录
 	Create(ctx context.Context, db *gorm.DB, track *do.LogisticsTrackDO) error
 	
+	// 创建承运商轨迹记录，EventKey已存在时跳过并返回false
+	CreateIfAbsent(ctx context.Context, db *gorm.DB, track *do.LogisticsTrackDO) (bool, error)
+	
 	// 批量创建轨迹记录
 	BatchCreate(ctx context.Context, db *gorm.DB, tracks []*do.LogisticsTrackDO) error
 	
@@ -82,6 +88,27 @@ type LogisticsCouriersRepo interface {
 	List(ctx context.Context, db *gorm.DB, offset, limit int, company *int32, area *string) ([]*do.LogisticsCourierDO, int64, error)
 }
 
+// ShippingRateConfigsRepo 运费配置数据访问接口
+type ShippingRateConfigsRepo interface {
+	// 创建配置版本
+	Create(ctx context.Context, db *gorm.DB, cfg *do.ShippingRateConfigDO) error
+	
+	// 根据版本号查询
+	GetByVersion(ctx context.Context, db *gorm.DB, version int32) (*do.ShippingRateConfigDO, error)
+	
+	// 查询当前生效版本
+	GetActive(ctx context.Context, db *gorm.DB) (*do.ShippingRateConfigDO, error)
+	
+	// 获取最大版本号
+	MaxVersion(ctx context.Context, db *gorm.DB) (int32, error)
+	
+	// 生效指定版本，原生效版本归档
+	Activate(ctx context.Context, db *gorm.DB, version int32, activatedAt time.Time) error
+	
+	// 分页查询配置版本（按版本倒序）
+	List(ctx context.Context, db *gorm.DB, offset, limit int) ([]*do.ShippingRateConfigDO, int64, error)
+}
+
 // DataFactory 数据访问工厂接口
 type DataFactory interface {
 	// 获取数据库连接
@@ -94,4 +121,5 @@ type DataFactory interface {
 	LogisticsOrders() LogisticsOrdersRepo
 	LogisticsTracks() LogisticsTracksRepo
 	LogisticsCouriers() LogisticsCouriersRepo
+	ShippingRateConfigs() ShippingRateConfigsRepo
 }
\ No newline at end of file
@@ -40,4 +40,9 @@ func (f *dataFactory) LogisticsTracks() interfaces.LogisticsTracksRepo {
 // LogisticsCouriers 获取配送员仓储接口
 func (f *dataFactory) LogisticsCouriers() interfaces.LogisticsCouriersRepo {
 	return NewLogisticsCouriersRepo()
-}
\ No newline at end of file
+}
+
+// ShippingRateConfigs 获取运费配置仓储接口
+func (f *dataFactory) ShippingRateConfigs() interfaces.ShippingRateConfigsRepo {
+	return NewShippingRateConfigsRepo()
+}
@@ -156,6 +156,17 @@ func (r *logisticsOrdersRepo) FindByStatus(ctx context.Context, db *gorm.DB, sta
 	return orders, nil
 }
 
+// FindByStatusesAfter 按ID游标分批查询指定状态的物流订单
+func (r *logisticsOrdersRepo) FindByStatusesAfter(ctx context.Context, db *gorm.DB, statuses []int32, afterID int64, limit int) ([]*do.LogisticsOrderDO, error) {
+	var orders []*do.LogisticsOrderDO
+	err := db.WithContext(ctx).Where("logistics_status IN ? AND id > ?", statuses, afterID).
+		Order("id ASC").Limit(limit).Find(&orders).Error
+	if err != nil {
+		return nil, errors.WithCode(code.ErrConnectDB, "根据状态查询物流订单失败: %v", err)
+	}
+	return orders, nil
+}
+
 // Update 更新物流订单 (通用更新方法)
 func (r *logisticsOrdersRepo) Update(ctx context.Context, db *gorm.DB, order *do.LogisticsOrderDO) error {
 	err := db.WithContext(ctx).Save(order).Error
@@ -7,6 +7,7 @@ import (
 	"emshop/internal/app/pkg/code"
 	"emshop/pkg/errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type logisticsTracksRepo struct{}
@@ -24,6 +25,15 @@ func (r *logisticsTracksRepo) Create(ctx context.Context, db *gorm.DB, track *do
 	return nil
 }
 
+// CreateIfAbsent 创建承运商轨迹记录，EventKey已存在时跳过并返回false
+func (r *logisticsTracksRepo) CreateIfAbsent(ctx context.Context, db *gorm.DB, track *do.LogisticsTrackDO) (bool, error) {
+	result := db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(track)
+	if result.Error != nil {
+		return false, errors.WithCode(code.ErrConnectDB, "创建物流轨迹失败: %v", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
 // BatchCreate 批量创建轨迹记录
 func (r *logisticsTracksRepo) BatchCreate(ctx context.Context, db *gorm.DB, tracks []*do.LogisticsTrackDO) error {
 	if len(tracks) == 0 {
@@ -0,0 +1,104 @@
+package mysql
+
+import (
+	"context"
+	"emshop/internal/app/logistics/srv/data/v1/interfaces"
+	"emshop/internal/app/logistics/srv/domain/do"
+	"emshop/internal/app/pkg/code"
+	"emshop/pkg/errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type shippingRateConfigsRepo struct{}
+
+// NewShippingRateConfigsRepo 创建运费配置仓储实例
+func NewShippingRateConfigsRepo() interfaces.ShippingRateConfigsRepo {
+	return &shippingRateConfigsRepo{}
+}
+
+// Create 创建配置版本
+func (r *shippingRateConfigsRepo) Create(ctx context.Context, db *gorm.DB, cfg *do.ShippingRateConfigDO) error {
+	if err := db.WithContext(ctx).Create(cfg).Error; err != nil {
+		return errors.WithCode(code.ErrConnectDB, "创建运费配置失败: %v", err)
+	}
+	return nil
+}
+
+// GetByVersion 根据版本号查询
+func (r *shippingRateConfigsRepo) GetByVersion(ctx context.Context, db *gorm.DB, version int32) (*do.ShippingRateConfigDO, error) {
+	var cfg do.ShippingRateConfigDO
+	err := db.WithContext(ctx).Where("version = ?", version).First(&cfg).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.WithCode(code.ErrShippingRateConfigNotFound, "运费配置版本%d不存在", version)
+		}
+		return nil, errors.WithCode(code.ErrConnectDB, "查询运费配置失败: %v", err)
+	}
+	return &cfg, nil
+}
+
+// GetActive 查询当前生效版本
+func (r *shippingRateConfigsRepo) GetActive(ctx context.Context, db *gorm.DB) (*do.ShippingRateConfigDO, error) {
+	var cfg do.ShippingRateConfigDO
+	err := db.WithContext(ctx).Where("status = ?", do.RateConfigStatusActive).
+		Order("version DESC").First(&cfg).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.WithCode(code.ErrShippingRateConfigNotFound, "暂无生效的运费配置")
+		}
+		return nil, errors.WithCode(code.ErrConnectDB, "查询生效运费配置失败: %v", err)
+	}
+	return &cfg, nil
+}
+
+// MaxVersion 获取最大版本号
+func (r *shippingRateConfigsRepo) MaxVersion(ctx context.Context, db *gorm.DB) (int32, error) {
+	var version int32
+	err := db.WithContext(ctx).Model(&do.ShippingRateConfigDO{}).
+		Select("COALESCE(MAX(version), 0)").Scan(&version).Error
+	if err != nil {
+		return 0, errors.WithCode(code.ErrConnectDB, "查询运费配置版本失败: %v", err)
+	}
+	return version, nil
+}
+
+// Activate 生效指定版本，原生效版本归档
+func (r *shippingRateConfigsRepo) Activate(ctx context.Context, db *gorm.DB, version int32, activatedAt time.Time) error {
+	err := db.WithContext(ctx).Model(&do.ShippingRateConfigDO{}).
+		Where("status = ? AND version <> ?", do.RateConfigStatusActive, version).
+		Update("status", do.RateConfigStatusArchived).Error
+	if err != nil {
+		return errors.WithCode(code.ErrConnectDB, "归档运费配置失败: %v", err)
+	}
+
+	result := db.WithContext(ctx).Model(&do.ShippingRateConfigDO{}).
+		Where("version = ?", version).
+		Updates(map[string]interface{}{
+			"status":       do.RateConfigStatusActive,
+			"activated_at": activatedAt,
+		})
+	if result.Error != nil {
+		return errors.WithCode(code.ErrConnectDB, "生效运费配置失败: %v", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return errors.WithCode(code.ErrShippingRateConfigNotFound, "运费配置版本%d不存在", version)
+	}
+	return nil
+}
+
+// List 分页查询配置版本（按版本倒序）
+func (r *shippingRateConfigsRepo) List(ctx context.Context, db *gorm.DB, offset, limit int) ([]*do.ShippingRateConfigDO, int64, error) {
+	var configs []*do.ShippingRateConfigDO
+	var total int64
+
+	query := db.WithContext(ctx).Model(&do.ShippingRateConfigDO{})
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, errors.WithCode(code.ErrConnectDB, "查询运费配置总数失败: %v", err)
+	}
+	if err := query.Offset(offset).Limit(limit).Order("version DESC").Find(&configs).Error; err != nil {
+		return nil, 0, errors.WithCode(code.ErrConnectDB, "查询运费配置失败: %v", err)
+	}
+	return configs, total, nil
+}
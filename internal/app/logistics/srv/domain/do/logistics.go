@@ -92,6 +92,8 @@ type LogisticsTrackDO struct {
 	Description    string    `gorm:"column:description;type:text;not null"`
 	TrackTime      time.Time `gorm:"column:track_time;not null;index"`
 	OperatorName   string    `gorm:"column:operator_name;size:64"`
+	// EventKey 承运商轨迹去重键，本地生成的轨迹为空
+	EventKey       *string   `gorm:"column:event_key;size:64;uniqueIndex"`
 	CreatedAt      time.Time `gorm:"column:created_at;autoCreateTime"`
 }
 
@@ -125,4 +127,31 @@ type OrderItem struct {
 	Quantity int32   `json:"quantity"`
 	Weight   float64 `json:"weight"`
 	Volume   float64 `json:"volume"`
-}
\ No newline at end of file
+}
+
+// RateConfigStatus 运费配置状态
+type RateConfigStatus int32
+
+const (
+	RateConfigStatusDraft    RateConfigStatus = 1 // 草稿
+	RateConfigStatusActive   RateConfigStatus = 2 // 生效中
+	RateConfigStatusArchived RateConfigStatus = 3 // 已归档
+)
+
+// ShippingRateConfigDO 运费规则配置数据对象，每次修改生成新版本
+type ShippingRateConfigDO struct {
+	ID          int64      `gorm:"primaryKey;autoIncrement;column:id"`
+	Version     int32      `gorm:"column:version;not null;uniqueIndex"`
+	Content     string     `gorm:"column:content;type:mediumtext;not null"`
+	Status      int32      `gorm:"column:status;not null;default:1;index"`
+	Operator    string     `gorm:"column:operator;size:64"`
+	Remark      string     `gorm:"column:remark;size:255"`
+	ActivatedAt *time.Time `gorm:"column:activated_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+}
+
+// TableName 指定表名
+func (ShippingRateConfigDO) TableName() string {
+	return "logistics_rate_configs"
+}
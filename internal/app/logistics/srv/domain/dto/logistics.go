@@ -23,6 +23,11 @@ type CreateLogisticsOrderDTO struct {
 	Items            []OrderItemDTO `json:"items"`
 	
 	Remark           string      `json:"remark"`
+
+	// 运费计算
+	OrderAmount       float64 `json:"order_amount"`
+	FreeShipCoupon    bool    `json:"free_ship_coupon"`
+	FreeShipCouponCap float64 `json:"free_ship_coupon_cap"`
 }
 
 // OrderItemDTO 订单商品项
@@ -32,6 +37,7 @@ type OrderItemDTO struct {
 	Quantity int32   `json:"quantity"`
 	Weight   float64 `json:"weight"`  // 重量(kg)
 	Volume   float64 `json:"volume"`  // 体积(cm³)
+	ShipFree bool    `json:"ship_free"` // 是否包邮商品
 }
 
 // LogisticsOrderDTO 物流订单响应
@@ -125,6 +131,21 @@ type CalculateShippingFeeDTO struct {
 	TotalVolume     float64 `json:"total_volume"`
 	GoodsValue      float64 `json:"goods_value"`
 	NeedInsurance   bool    `json:"need_insurance"`
+
+	LogisticsCompany  int32                `json:"logistics_company"`
+	Items             []ShippingFeeItemDTO `json:"items"`
+	OrderAmount       float64              `json:"order_amount"`
+	FreeShipCoupon    bool                 `json:"free_ship_coupon"`
+	FreeShipCouponCap float64              `json:"free_ship_coupon_cap"`
+}
+
+// ShippingFeeItemDTO 运费计算商品项
+type ShippingFeeItemDTO struct {
+	GoodsID  int32   `json:"goods_id"`
+	Quantity int32   `json:"quantity"`
+	Weight   float64 `json:"weight"`
+	Volume   float64 `json:"volume"`
+	ShipFree bool    `json:"ship_free"`
 }
 
 // ShippingFeeDTO 运费计算响应
@@ -133,6 +154,15 @@ type ShippingFeeDTO struct {
 	InsuranceFee   float64 `json:"insurance_fee"`
 	TotalFee       float64 `json:"total_fee"`
 	EstimatedDays  int32   `json:"estimated_days"`
+
+	BaseFee          float64 `json:"base_fee"`
+	RemoteSurcharge  float64 `json:"remote_surcharge"`
+	ChargeableWeight float64 `json:"chargeable_weight"`
+	FromZone         string  `json:"from_zone"`
+	ToZone           string  `json:"to_zone"`
+	FreeShipping     bool    `json:"free_shipping"`
+	FreeReason       string  `json:"free_reason"`
+	RateVersion      int32   `json:"rate_version"`
 }
 
 // LogisticsCompanyDTO 物流公司信息
@@ -155,4 +185,30 @@ type CourierDTO struct {
 type GetCouriersDTO struct {
 	LogisticsCompany *int32  `json:"logistics_company,omitempty"`
 	ServiceArea      *string `json:"service_area,omitempty"`
-}
\ No newline at end of file
+}
+
+// ShippingRateConfigDTO 运费配置版本
+type ShippingRateConfigDTO struct {
+	ID          int64      `json:"id"`
+	Version     int32      `json:"version"`
+	Content     string     `json:"content"`
+	Status      int32      `json:"status"`
+	Operator    string     `json:"operator"`
+	Remark      string     `json:"remark"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ActivatedAt *time.Time `json:"activated_at,omitempty"`
+}
+
+// ShippingRateConfigListDTO 运费配置版本列表
+type ShippingRateConfigListDTO struct {
+	Total int64                    `json:"total"`
+	Items []*ShippingRateConfigDTO `json:"items"`
+}
+
+// CreateShippingRateConfigDTO 创建运费配置请求
+type CreateShippingRateConfigDTO struct {
+	Content  string `json:"content" binding:"required"`
+	Operator string `json:"operator"`
+	Remark   string `json:"remark"`
+	Activate bool   `json:"activate"`
+}
@@ -0,0 +1,26 @@
+package srv
+
+import (
+	restserver "emshop/gin-micro/server/rest-server"
+	"emshop/internal/app/logistics/srv/config"
+	v1 "emshop/internal/app/logistics/srv/controller/logistics/v1"
+	service "emshop/internal/app/logistics/srv/service/v1"
+)
+
+// NewLogisticsHTTPServer 创建物流HTTP服务，用于接收承运商轨迹推送
+func NewLogisticsHTTPServer(cfg *config.Config, logisticsSrv service.LogisticsSrv) *restserver.Server {
+	httpServer := restserver.NewServer(
+		restserver.WithPort(cfg.Server.HttpPort),
+		restserver.WithServiceName(cfg.Server.Name),
+		restserver.WithMetrics(cfg.Server.EnableMetrics),
+		restserver.WithEnableProfiling(cfg.Server.EnableProfiling),
+		restserver.WithAdaptiveLimit(cfg.Server.Limiter.AdaptiveLimiter(), nil),
+		restserver.WithLogAdmin(cfg.Server.LogAdminSecret),
+		restserver.WithHealthz(cfg.Server.EnableHealthCheck),
+	)
+
+	webhook := v1.NewWebhookController(logisticsSrv)
+	httpServer.POST("/v1/carriers/:company/tracks", webhook.CarrierPush)
+
+	return httpServer
+}
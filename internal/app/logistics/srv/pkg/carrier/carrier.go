@@ -0,0 +1,148 @@
+package carrier
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrWaybillNotFound 承运商侧不存在该运单
+	ErrWaybillNotFound = errors.New("carrier: waybill not found")
+	// ErrCancelRejected 运单已揽收等原因承运商拒绝取消
+	ErrCancelRejected = errors.New("carrier: cancel rejected")
+	// ErrPushNotSupported 承运商不支持轨迹推送
+	ErrPushNotSupported = errors.New("carrier: push not supported")
+)
+
+// TrackStatus 承运商轨迹节点状态
+type TrackStatus string
+
+const (
+	StatusCollected  TrackStatus = "COLLECTED"  // 已揽收
+	StatusInTransit  TrackStatus = "IN_TRANSIT" // 运输中
+	StatusDelivering TrackStatus = "DELIVERING" // 派送中
+	StatusSigned     TrackStatus = "SIGNED"     // 已签收
+	StatusRejected   TrackStatus = "REJECTED"   // 拒收
+	StatusReturned   TrackStatus = "RETURNED"   // 已退回
+	StatusCanceled   TrackStatus = "CANCELED"   // 已取消
+)
+
+// WaybillRequest 下单请求
+type WaybillRequest struct {
+	LogisticsSn     string  `json:"logistics_sn"`
+	OrderSn         string  `json:"order_sn"`
+	ShippingMethod  int32   `json:"shipping_method"`
+	SenderName      string  `json:"sender_name"`
+	SenderPhone     string  `json:"sender_phone"`
+	SenderAddress   string  `json:"sender_address"`
+	ReceiverName    string  `json:"receiver_name"`
+	ReceiverPhone   string  `json:"receiver_phone"`
+	ReceiverAddress string  `json:"receiver_address"`
+	Weight          float64 `json:"weight"`
+}
+
+// Waybill 承运商返回的运单
+type Waybill struct {
+	TrackingNumber string `json:"tracking_number"`
+}
+
+// TrackEvent 承运商轨迹节点
+type TrackEvent struct {
+	// EventID 承运商侧的节点ID，可为空
+	EventID        string      `json:"event_id"`
+	TrackingNumber string      `json:"tracking_number"`
+	Status         TrackStatus `json:"status"`
+	Location       string      `json:"location"`
+	Description    string      `json:"description"`
+	Operator       string      `json:"operator"`
+	Time           time.Time   `json:"time"`
+}
+
+// DedupKey 轨迹节点去重键，推送重试与轮询重叠时同一节点得到相同的键
+func (e *TrackEvent) DedupKey(company int32) string {
+	var raw string
+	if e.EventID != "" {
+		raw = fmt.Sprintf("%d|%s|%s", company, e.TrackingNumber, e.EventID)
+	} else {
+		raw = fmt.Sprintf("%d|%s|%d|%s|%s", company, e.TrackingNumber, e.Time.Unix(), e.Status, e.Description)
+	}
+	sum := sha1.Sum([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// Carrier 承运商适配器，每个物流公司一个实现
+type Carrier interface {
+	// Company 对应的物流公司
+	Company() int32
+
+	// CreateWaybill 下单获取运单号
+	CreateWaybill(ctx context.Context, req *WaybillRequest) (*Waybill, error)
+
+	// QueryTracks 查询运单全部轨迹节点（按时间升序）
+	QueryTracks(ctx context.Context, trackingNumber string) ([]TrackEvent, error)
+
+	// CancelWaybill 取消运单
+	CancelWaybill(ctx context.Context, trackingNumber string) error
+
+	// SupportsPush 是否支持轨迹推送，不支持时由轮询补齐轨迹
+	SupportsPush() bool
+
+	// ParsePush 校验推送签名并解析轨迹节点
+	ParsePush(header http.Header, body []byte) (*PushPayload, error)
+}
+
+// PushPayload 承运商推送内容
+type PushPayload struct {
+	// PushID 推送批次ID，承运商重试时保持不变
+	PushID string       `json:"push_id"`
+	Events []TrackEvent `json:"events"`
+}
+
+// Registry 承运商注册表，未单独配置的物流公司使用兜底承运商
+type Registry struct {
+	mu       sync.RWMutex
+	carriers map[int32]Carrier
+	fallback func(company int32) Carrier
+}
+
+// NewRegistry 创建注册表，fallback为未配置物流公司创建兜底承运商
+func NewRegistry(fallback func(company int32) Carrier) *Registry {
+	return &Registry{
+		carriers: make(map[int32]Carrier),
+		fallback: fallback,
+	}
+}
+
+// Register 注册承运商，同一物流公司后注册的覆盖先注册的
+func (r *Registry) Register(c Carrier) {
+	r.mu.Lock()
+	r.carriers[c.Company()] = c
+	r.mu.Unlock()
+}
+
+// Get 获取物流公司对应的承运商
+func (r *Registry) Get(company int32) (Carrier, error) {
+	r.mu.RLock()
+	c, ok := r.carriers[company]
+	r.mu.RUnlock()
+	if ok {
+		return c, nil
+	}
+	if r.fallback == nil {
+		return nil, fmt.Errorf("carrier: company %d not registered", company)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if c, ok = r.carriers[company]; !ok {
+		c = r.fallback(company)
+		r.carriers[company] = c
+	}
+	return c, nil
+}
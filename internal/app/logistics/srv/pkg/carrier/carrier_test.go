@@ -0,0 +1,211 @@
+package carrier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testSecret = "s3cret"
+
+// fakeCarrier 本地模拟的承运商开放接口
+type fakeCarrier struct {
+	mu        sync.Mutex
+	waybills  map[string][]TrackEvent
+	collected map[string]bool
+	seq       int
+}
+
+func newFakeCarrier(t *testing.T) (*fakeCarrier, *httptest.Server) {
+	f := &fakeCarrier{waybills: map[string][]TrackEvent{}, collected: map[string]bool{}}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := Verify(r.Header, testSecret, time.Now(), time.Minute, body); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		switch {
+		case r.Method == http.MethodPost && len(parts) == 1 && parts[0] == "waybills":
+			var req WaybillRequest
+			if err := json.Unmarshal(body, &req); err != nil || req.LogisticsSn == "" {
+				http.Error(w, "bad request", http.StatusBadRequest)
+				return
+			}
+			f.seq++
+			no := fmt.Sprintf("FK%08d", f.seq)
+			f.waybills[no] = nil
+			_ = json.NewEncoder(w).Encode(Waybill{TrackingNumber: no})
+		case len(parts) == 3 && parts[0] == "waybills":
+			events, ok := f.waybills[parts[1]]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			if parts[2] == "tracks" {
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"events": events})
+				return
+			}
+			if f.collected[parts[1]] {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			delete(f.waybills, parts[1])
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return f, srv
+}
+
+func (f *fakeCarrier) collect(no string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.collected[no] = true
+	f.waybills[no] = append(f.waybills[no], TrackEvent{
+		EventID:  "e1",
+		Status:   StatusCollected,
+		Location: "上海浦东网点",
+		Time:     time.Now().Truncate(time.Second),
+	})
+}
+
+func TestHTTPCarrier(t *testing.T) {
+	fake, srv := newFakeCarrier(t)
+	c := NewHTTPCarrier(HTTPConfig{Company: 5, Endpoint: srv.URL + "/", AppKey: "emshop", Secret: testSecret})
+	ctx := context.Background()
+
+	wb, err := c.CreateWaybill(ctx, &WaybillRequest{LogisticsSn: "LG1", ReceiverAddress: "北京市"})
+	if err != nil {
+		t.Fatalf("CreateWaybill: %v", err)
+	}
+
+	fake.collect(wb.TrackingNumber)
+	events, err := c.QueryTracks(ctx, wb.TrackingNumber)
+	if err != nil {
+		t.Fatalf("QueryTracks: %v", err)
+	}
+	if len(events) != 1 || events[0].Status != StatusCollected || events[0].TrackingNumber != wb.TrackingNumber {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+
+	if err := c.CancelWaybill(ctx, wb.TrackingNumber); !errors.Is(err, ErrCancelRejected) {
+		t.Errorf("cancel collected waybill: got %v, want ErrCancelRejected", err)
+	}
+	if _, err := c.QueryTracks(ctx, "UNKNOWN"); !errors.Is(err, ErrWaybillNotFound) {
+		t.Errorf("query unknown waybill: got %v, want ErrWaybillNotFound", err)
+	}
+
+	wb2, _ := c.CreateWaybill(ctx, &WaybillRequest{LogisticsSn: "LG2"})
+	if err := c.CancelWaybill(ctx, wb2.TrackingNumber); err != nil {
+		t.Errorf("cancel new waybill: %v", err)
+	}
+
+	bad := NewHTTPCarrier(HTTPConfig{Company: 5, Endpoint: srv.URL, Secret: "wrong"})
+	if _, err := bad.CreateWaybill(ctx, &WaybillRequest{LogisticsSn: "LG3"}); err == nil {
+		t.Error("request with wrong secret should be rejected")
+	}
+}
+
+func TestParsePush(t *testing.T) {
+	now := time.Now()
+	c := NewHTTPCarrier(HTTPConfig{Company: 6, Secret: testSecret, Push: true, SignatureTolerance: 5 * time.Minute})
+	c.now = func() time.Time { return now }
+
+	body, _ := json.Marshal(PushPayload{PushID: "p1", Events: []TrackEvent{{TrackingNumber: "JD1", Status: StatusSigned}}})
+	header := http.Header{}
+	SetSignature(header, "jd", testSecret, now, body)
+
+	payload, err := c.ParsePush(header, body)
+	if err != nil {
+		t.Fatalf("ParsePush: %v", err)
+	}
+	if payload.PushID != "p1" || len(payload.Events) != 1 {
+		t.Errorf("unexpected payload: %+v", payload)
+	}
+
+	tampered := bytes.Replace(body, []byte("SIGNED"), []byte("REJECTED"), 1)
+	if _, err := c.ParsePush(header, tampered); !errors.Is(err, ErrSignatureInvalid) {
+		t.Errorf("tampered body: got %v, want ErrSignatureInvalid", err)
+	}
+
+	stale := http.Header{}
+	SetSignature(stale, "jd", testSecret, now.Add(-time.Hour), body)
+	if _, err := c.ParsePush(stale, body); !errors.Is(err, ErrSignatureExpired) {
+		t.Errorf("stale push: got %v, want ErrSignatureExpired", err)
+	}
+
+	noPush := NewHTTPCarrier(HTTPConfig{Company: 6, Secret: testSecret})
+	if _, err := noPush.ParsePush(header, body); !errors.Is(err, ErrPushNotSupported) {
+		t.Errorf("carrier without push: got %v", err)
+	}
+}
+
+func TestMockCarrierTimeline(t *testing.T) {
+	base := time.Unix(1750000000, 0)
+	now := base
+	m := NewMockCarrier(5, time.Hour)
+	m.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	wb, _ := m.CreateWaybill(ctx, &WaybillRequest{})
+	if !strings.HasPrefix(wb.TrackingNumber, "SF1750000000") {
+		t.Fatalf("unexpected tracking number %s", wb.TrackingNumber)
+	}
+
+	if events, _ := m.QueryTracks(ctx, wb.TrackingNumber); len(events) != 0 {
+		t.Errorf("no events expected before collection, got %d", len(events))
+	}
+
+	now = base.Add(3*time.Hour + time.Minute)
+	events, err := m.QueryTracks(ctx, wb.TrackingNumber)
+	if err != nil {
+		t.Fatalf("QueryTracks: %v", err)
+	}
+	if len(events) != 3 || events[2].Status != StatusInTransit {
+		t.Errorf("want 3 events ending in transit, got %+v", events)
+	}
+	if err := m.CancelWaybill(ctx, wb.TrackingNumber); !errors.Is(err, ErrCancelRejected) {
+		t.Errorf("cancel after collection: got %v", err)
+	}
+
+	now = base.Add(10 * time.Hour)
+	events, _ = m.QueryTracks(ctx, wb.TrackingNumber)
+	if last := events[len(events)-1]; last.Status != StatusSigned {
+		t.Errorf("final status = %s, want SIGNED", last.Status)
+	}
+
+	if _, err := m.QueryTracks(ctx, "SF123"); !errors.Is(err, ErrWaybillNotFound) {
+		t.Errorf("legacy tracking number: got %v", err)
+	}
+}
+
+func TestDedupKey(t *testing.T) {
+	at := time.Unix(1750000000, 0)
+	a := TrackEvent{TrackingNumber: "SF1", Status: StatusInTransit, Description: "到达", Time: at}
+	b := a
+	b.Location = "不同的展示字段"
+	if a.DedupKey(5) != b.DedupKey(5) {
+		t.Error("same node should share dedup key")
+	}
+	if a.DedupKey(5) == a.DedupKey(6) {
+		t.Error("dedup key should include company")
+	}
+	withID := TrackEvent{EventID: "x", TrackingNumber: "SF1"}
+	if withID.DedupKey(5) == a.DedupKey(5) {
+		t.Error("event id should take precedence")
+	}
+}
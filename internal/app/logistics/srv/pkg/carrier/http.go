@@ -0,0 +1,158 @@
+package carrier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// HTTPConfig 基于HTTP开放接口的承运商配置
+type HTTPConfig struct {
+	Company  int32
+	Endpoint string // 开放接口地址，如 https://open.carrier.com/api
+	AppKey   string
+	Secret   string // 请求与推送的签名密钥
+	Push     bool   // 是否开通轨迹推送
+	Timeout  time.Duration
+	// SignatureTolerance 推送时间戳允许偏差，0表示不校验
+	SignatureTolerance time.Duration
+}
+
+// HTTPCarrier 通用HTTP承运商适配器
+//
+// 接口约定:
+//
+//	POST {endpoint}/waybills                    下单，返回 {"tracking_number": "..."}
+//	GET  {endpoint}/waybills/{no}/tracks        查询轨迹，返回 {"events": [...]}
+//	POST {endpoint}/waybills/{no}/cancel        取消运单，409表示已揽收不可取消
+//
+// 运单不存在时返回404；所有请求与推送均按 Sign 签名
+type HTTPCarrier struct {
+	cfg    HTTPConfig
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewHTTPCarrier 创建HTTP承运商适配器
+func NewHTTPCarrier(cfg HTTPConfig) *HTTPCarrier {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+	cfg.Endpoint = strings.TrimRight(cfg.Endpoint, "/")
+	return &HTTPCarrier{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+		now:    time.Now,
+	}
+}
+
+// Company 对应的物流公司
+func (h *HTTPCarrier) Company() int32 {
+	return h.cfg.Company
+}
+
+// SupportsPush 是否开通轨迹推送
+func (h *HTTPCarrier) SupportsPush() bool {
+	return h.cfg.Push
+}
+
+// CreateWaybill 下单获取运单号
+func (h *HTTPCarrier) CreateWaybill(ctx context.Context, req *WaybillRequest) (*Waybill, error) {
+	var waybill Waybill
+	if err := h.do(ctx, http.MethodPost, "/waybills", req, &waybill); err != nil {
+		return nil, err
+	}
+	if waybill.TrackingNumber == "" {
+		return nil, fmt.Errorf("carrier %d: empty tracking number", h.cfg.Company)
+	}
+	return &waybill, nil
+}
+
+// QueryTracks 查询运单轨迹
+func (h *HTTPCarrier) QueryTracks(ctx context.Context, trackingNumber string) ([]TrackEvent, error) {
+	var resp struct {
+		Events []TrackEvent `json:"events"`
+	}
+	path := "/waybills/" + url.PathEscape(trackingNumber) + "/tracks"
+	if err := h.do(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return nil, err
+	}
+	for i := range resp.Events {
+		if resp.Events[i].TrackingNumber == "" {
+			resp.Events[i].TrackingNumber = trackingNumber
+		}
+	}
+	return resp.Events, nil
+}
+
+// CancelWaybill 取消运单
+func (h *HTTPCarrier) CancelWaybill(ctx context.Context, trackingNumber string) error {
+	path := "/waybills/" + url.PathEscape(trackingNumber) + "/cancel"
+	return h.do(ctx, http.MethodPost, path, nil, nil)
+}
+
+// ParsePush 校验推送签名并解析轨迹节点
+func (h *HTTPCarrier) ParsePush(header http.Header, body []byte) (*PushPayload, error) {
+	if !h.cfg.Push {
+		return nil, ErrPushNotSupported
+	}
+	if err := Verify(header, h.cfg.Secret, h.now(), h.cfg.SignatureTolerance, body); err != nil {
+		return nil, err
+	}
+	var payload PushPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("carrier %d: decode push: %w", h.cfg.Company, err)
+	}
+	return &payload, nil
+}
+
+func (h *HTTPCarrier) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, h.cfg.Endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	SetSignature(req.Header, h.cfg.AppKey, h.cfg.Secret, h.now(), body)
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("carrier %d: %s %s: %w", h.cfg.Company, method, path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("carrier %d: read response: %w", h.cfg.Company, err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrWaybillNotFound
+	case resp.StatusCode == http.StatusConflict:
+		return ErrCancelRejected
+	case resp.StatusCode/100 != 2:
+		return fmt.Errorf("carrier %d: %s %s: status %d: %s", h.cfg.Company, method, path, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("carrier %d: decode response: %w", h.cfg.Company, err)
+	}
+	return nil
+}
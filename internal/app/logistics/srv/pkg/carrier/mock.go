@@ -0,0 +1,171 @@
+package carrier
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultMockStep 模拟承运商相邻轨迹节点的间隔
+const DefaultMockStep = 2 * time.Hour
+
+// mockEpoch 模拟运单号时间戳下限(2024-01-01)
+const mockEpoch = 1704067200
+
+// 模拟运单号前缀
+var mockPrefixes = map[int32]string{
+	1: "YT",
+	2: "ST",
+	3: "ZT",
+	4: "YD",
+	5: "SF",
+	6: "JD",
+	7: "EMS",
+}
+
+// mockStage 模拟轨迹节点，offset为相对下单时间的间隔数
+type mockStage struct {
+	offset      int
+	status      TrackStatus
+	location    string
+	description string
+}
+
+var mockStages = []mockStage{
+	{1, StatusCollected, "始发网点", "快递员已揽收"},
+	{2, StatusInTransit, "始发转运中心", "快件已到达始发转运中心"},
+	{3, StatusInTransit, "目的转运中心", "快件已到达目的转运中心"},
+	{4, StatusDelivering, "目的网点", "快件正在派送中"},
+	{5, StatusSigned, "收件地址", "快件已签收"},
+}
+
+// MockCarrier 内置模拟承运商，不支持推送
+//
+// 运单号内嵌下单时间，轨迹按时间推进计算得出，重启后仍可查询
+type MockCarrier struct {
+	company int32
+	step    time.Duration
+	now     func() time.Time
+
+	mu       sync.Mutex
+	canceled map[string]time.Time
+}
+
+// NewMockCarrier 创建模拟承运商，step为相邻轨迹节点间隔
+func NewMockCarrier(company int32, step time.Duration) *MockCarrier {
+	if step <= 0 {
+		step = DefaultMockStep
+	}
+	return &MockCarrier{
+		company:  company,
+		step:     step,
+		now:      time.Now,
+		canceled: make(map[string]time.Time),
+	}
+}
+
+// Company 对应的物流公司
+func (m *MockCarrier) Company() int32 {
+	return m.company
+}
+
+// SupportsPush 模拟承运商不推送，由轮询拉取
+func (m *MockCarrier) SupportsPush() bool {
+	return false
+}
+
+// CreateWaybill 生成运单号: 前缀 + 下单时间戳(10位) + 3位随机数
+func (m *MockCarrier) CreateWaybill(ctx context.Context, req *WaybillRequest) (*Waybill, error) {
+	prefix, ok := mockPrefixes[m.company]
+	if !ok {
+		prefix = "EX"
+	}
+	n, _ := rand.Int(rand.Reader, big.NewInt(1000))
+	return &Waybill{
+		TrackingNumber: fmt.Sprintf("%s%010d%03d", prefix, m.now().Unix(), n.Int64()),
+	}, nil
+}
+
+// QueryTracks 按当前时间推算已产生的轨迹节点
+func (m *MockCarrier) QueryTracks(ctx context.Context, trackingNumber string) ([]TrackEvent, error) {
+	createdAt, err := m.createdAt(trackingNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	canceledAt, canceled := m.canceled[trackingNumber]
+	m.mu.Unlock()
+	if canceled {
+		return []TrackEvent{{
+			EventID:        "CANCELED",
+			TrackingNumber: trackingNumber,
+			Status:         StatusCanceled,
+			Location:       "系统",
+			Description:    "运单已取消",
+			Time:           canceledAt,
+		}}, nil
+	}
+
+	now := m.now()
+	events := make([]TrackEvent, 0, len(mockStages))
+	for _, stage := range mockStages {
+		at := createdAt.Add(time.Duration(stage.offset) * m.step)
+		if at.After(now) {
+			break
+		}
+		events = append(events, TrackEvent{
+			EventID:        strconv.Itoa(stage.offset),
+			TrackingNumber: trackingNumber,
+			Status:         stage.status,
+			Location:       stage.location,
+			Description:    stage.description,
+			Operator:       "模拟承运商",
+			Time:           at,
+		})
+	}
+	return events, nil
+}
+
+// CancelWaybill 揽收前可取消
+func (m *MockCarrier) CancelWaybill(ctx context.Context, trackingNumber string) error {
+	createdAt, err := m.createdAt(trackingNumber)
+	if err != nil {
+		return err
+	}
+	now := m.now()
+	if !now.Before(createdAt.Add(m.step)) {
+		return ErrCancelRejected
+	}
+
+	m.mu.Lock()
+	if _, ok := m.canceled[trackingNumber]; !ok {
+		m.canceled[trackingNumber] = now
+	}
+	m.mu.Unlock()
+	return nil
+}
+
+// ParsePush 模拟承运商不支持推送
+func (m *MockCarrier) ParsePush(header http.Header, body []byte) (*PushPayload, error) {
+	return nil, ErrPushNotSupported
+}
+
+// createdAt 从运单号中解析下单时间
+func (m *MockCarrier) createdAt(trackingNumber string) (time.Time, error) {
+	if len(trackingNumber) < 13 {
+		return time.Time{}, ErrWaybillNotFound
+	}
+	digits := trackingNumber[len(trackingNumber)-13 : len(trackingNumber)-3]
+	ts, err := strconv.ParseInt(digits, 10, 64)
+	// 历史随机运单号解析出的时间不可信，视为不存在
+	if err != nil || ts < mockEpoch || ts > m.now().Unix()+60 {
+		return time.Time{}, ErrWaybillNotFound
+	}
+	return time.Unix(ts, 0), nil
+}
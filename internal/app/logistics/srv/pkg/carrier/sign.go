@@ -0,0 +1,62 @@
+package carrier
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// 签名相关请求头，请求与推送使用同一套签名方式
+const (
+	HeaderAppKey    = "X-Carrier-App-Key"
+	HeaderTimestamp = "X-Carrier-Timestamp"
+	HeaderSignature = "X-Carrier-Signature"
+)
+
+var (
+	// ErrSignatureInvalid 签名缺失或不匹配
+	ErrSignatureInvalid = errors.New("carrier: invalid signature")
+	// ErrSignatureExpired 时间戳超出允许偏差，防止重放
+	ErrSignatureExpired = errors.New("carrier: signature expired")
+)
+
+// Sign 计算签名: hex(HMAC-SHA256(secret, timestamp + "\n" + body))
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("\n"))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SetSignature 为请求头写入签名
+func SetSignature(header http.Header, appKey, secret string, now time.Time, body []byte) {
+	ts := now.Unix()
+	header.Set(HeaderAppKey, appKey)
+	header.Set(HeaderTimestamp, strconv.FormatInt(ts, 10))
+	header.Set(HeaderSignature, Sign(secret, ts, body))
+}
+
+// Verify 校验请求头中的签名，tolerance为0时不校验时间戳
+func Verify(header http.Header, secret string, now time.Time, tolerance time.Duration, body []byte) error {
+	sig := header.Get(HeaderSignature)
+	ts, err := strconv.ParseInt(header.Get(HeaderTimestamp), 10, 64)
+	if sig == "" || err != nil {
+		return ErrSignatureInvalid
+	}
+	if tolerance > 0 {
+		skew := now.Sub(time.Unix(ts, 0))
+		if skew > tolerance || skew < -tolerance {
+			return ErrSignatureExpired
+		}
+	}
+	expected := Sign(secret, ts, body)
+	if !hmac.Equal([]byte(expected), []byte(sig)) {
+		return ErrSignatureInvalid
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package rate
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// AnyZone 通配区域，价目表中表示任意始发地/目的地
+const AnyZone = "*"
+
+// Config 运费规则配置，按版本整体存储在 logistics_rate_configs.content 中
+type Config struct {
+	// 区域划分，按省/市归集地址
+	Zones []Zone `json:"zones"`
+	// 各物流公司的价目表
+	RateCards []RateCard `json:"rate_cards"`
+	// 偏远地区附加费
+	RemoteAreas []RemoteArea `json:"remote_areas"`
+	// 包邮规则
+	FreeShipping FreeShippingRule `json:"free_shipping"`
+	// 配送方式费用系数，key为配送方式，缺省为1
+	MethodMultipliers map[int32]float64 `json:"method_multipliers"`
+	// 配送方式时效增减天数，key为配送方式
+	MethodExtraDays map[int32]int32 `json:"method_extra_days"`
+}
+
+// Zone 区域定义，市级匹配优先于省级匹配
+type Zone struct {
+	Code      string   `json:"code"`
+	Name      string   `json:"name"`
+	Provinces []string `json:"provinces"`
+	Cities    []string `json:"cities"`
+}
+
+// RateCard 价目表，首重+续重+体积重计费
+type RateCard struct {
+	// 物流公司，0表示通用价目
+	Company int32 `json:"company"`
+	// 始发区域，"*"或空表示任意
+	FromZone string `json:"from_zone"`
+	// 目的区域，"*"或空表示任意
+	ToZone string `json:"to_zone"`
+	// 首重(kg)及首重费用
+	FirstWeight float64 `json:"first_weight"`
+	FirstFee    float64 `json:"first_fee"`
+	// 续重单位(kg)及每单位费用
+	ExtraWeightUnit float64 `json:"extra_weight_unit"`
+	ExtraFee        float64 `json:"extra_fee"`
+	// 体积重换算系数(cm³/kg)，0表示不计算体积重
+	VolumetricDivisor float64 `json:"volumetric_divisor"`
+	// 标准配送预计天数
+	EstimatedDays int32 `json:"estimated_days"`
+}
+
+// RemoteArea 偏远地区附加费，City为空时对整个省份生效
+type RemoteArea struct {
+	Province  string  `json:"province"`
+	City      string  `json:"city"`
+	Surcharge float64 `json:"surcharge"`
+}
+
+// FreeShippingRule 包邮规则
+type FreeShippingRule struct {
+	// 满额包邮门槛，0表示不启用
+	ThresholdAmount float64 `json:"threshold_amount"`
+	// 订单商品全部为包邮商品(GoodsDO.ShipFree)时免运费
+	HonorGoodsShipFree bool `json:"honor_goods_ship_free"`
+	// 是否认可包邮券(CouponTypeFreeShip)
+	HonorCoupon bool `json:"honor_coupon"`
+	// 偏远地区不参与满额包邮和商品包邮
+	ExcludeRemote bool `json:"exclude_remote"`
+	// 包邮时是否同时免除偏远附加费
+	WaiveSurcharge bool `json:"waive_surcharge"`
+}
+
+// Parse 解析并校验配置内容
+func Parse(content []byte) (*Config, error) {
+	var cfg Config
+	if err := json.Unmarshal(content, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid rate config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Validate 校验配置的完整性
+func (c *Config) Validate() error {
+	if len(c.RateCards) == 0 {
+		return fmt.Errorf("rate config must contain at least one rate card")
+	}
+
+	zones := make(map[string]struct{}, len(c.Zones))
+	for _, z := range c.Zones {
+		if z.Code == "" || z.Code == AnyZone {
+			return fmt.Errorf("invalid zone code %q", z.Code)
+		}
+		if _, ok := zones[z.Code]; ok {
+			return fmt.Errorf("duplicate zone code %q", z.Code)
+		}
+		if len(z.Provinces) == 0 && len(z.Cities) == 0 {
+			return fmt.Errorf("zone %s has no provinces or cities", z.Code)
+		}
+		zones[z.Code] = struct{}{}
+	}
+
+	hasFallback := false
+	for i, card := range c.RateCards {
+		for _, zc := range []string{card.FromZone, card.ToZone} {
+			if zc == "" || zc == AnyZone {
+				continue
+			}
+			if _, ok := zones[zc]; !ok {
+				return fmt.Errorf("rate card %d references unknown zone %q", i, zc)
+			}
+		}
+		if card.FirstWeight <= 0 || card.FirstFee < 0 || card.ExtraFee < 0 {
+			return fmt.Errorf("rate card %d has invalid weight or fee", i)
+		}
+		if card.ExtraFee > 0 && card.ExtraWeightUnit <= 0 {
+			return fmt.Errorf("rate card %d has extra fee without extra weight unit", i)
+		}
+		if card.VolumetricDivisor < 0 {
+			return fmt.Errorf("rate card %d has negative volumetric divisor", i)
+		}
+		if card.Company == 0 && isAny(card.FromZone) && isAny(card.ToZone) {
+			hasFallback = true
+		}
+	}
+	if !hasFallback {
+		return fmt.Errorf("rate config must contain a fallback card (company 0, any zone to any zone)")
+	}
+
+	for _, ra := range c.RemoteAreas {
+		if ra.Province == "" && ra.City == "" {
+			return fmt.Errorf("remote area must specify province or city")
+		}
+		if ra.Surcharge < 0 {
+			return fmt.Errorf("remote area %s%s has negative surcharge", ra.Province, ra.City)
+		}
+	}
+	if c.FreeShipping.ThresholdAmount < 0 {
+		return fmt.Errorf("free shipping threshold must not be negative")
+	}
+	for method, m := range c.MethodMultipliers {
+		if m <= 0 {
+			return fmt.Errorf("method %d multiplier must be positive", method)
+		}
+	}
+	return nil
+}
+
+// DefaultConfig 内置默认配置，数据库中尚无生效版本时使用
+func DefaultConfig() *Config {
+	return &Config{
+		Zones: []Zone{
+			{Code: "JJH", Name: "江浙沪", Provinces: []string{"上海", "江苏", "浙江"}},
+			{Code: "JJJ", Name: "京津冀", Provinces: []string{"北京", "天津", "河北"}},
+			{Code: "HN", Name: "华南", Provinces: []string{"广东", "广西", "福建", "海南"}},
+			{Code: "REMOTE", Name: "偏远地区", Provinces: []string{"新疆", "西藏", "青海", "内蒙古"}},
+		},
+		RateCards: []RateCard{
+			{Company: 0, FromZone: AnyZone, ToZone: AnyZone, FirstWeight: 1, FirstFee: 10, ExtraWeightUnit: 1, ExtraFee: 3, VolumetricDivisor: 8000, EstimatedDays: 2},
+			{Company: 0, FromZone: "JJH", ToZone: "JJH", FirstWeight: 1, FirstFee: 8, ExtraWeightUnit: 1, ExtraFee: 2, VolumetricDivisor: 8000, EstimatedDays: 1},
+			{Company: 0, FromZone: "JJJ", ToZone: "JJJ", FirstWeight: 1, FirstFee: 8, ExtraWeightUnit: 1, ExtraFee: 2, VolumetricDivisor: 8000, EstimatedDays: 1},
+			{Company: 0, FromZone: "HN", ToZone: "HN", FirstWeight: 1, FirstFee: 8, ExtraWeightUnit: 1, ExtraFee: 2, VolumetricDivisor: 8000, EstimatedDays: 1},
+			{Company: 0, FromZone: AnyZone, ToZone: "REMOTE", FirstWeight: 1, FirstFee: 18, ExtraWeightUnit: 1, ExtraFee: 8, VolumetricDivisor: 8000, EstimatedDays: 4},
+			{Company: 5, FromZone: AnyZone, ToZone: AnyZone, FirstWeight: 1, FirstFee: 18, ExtraWeightUnit: 1, ExtraFee: 5, VolumetricDivisor: 6000, EstimatedDays: 1},
+			{Company: 6, FromZone: AnyZone, ToZone: AnyZone, FirstWeight: 1, FirstFee: 15, ExtraWeightUnit: 1, ExtraFee: 4, VolumetricDivisor: 6000, EstimatedDays: 1},
+		},
+		RemoteAreas: []RemoteArea{
+			{Province: "新疆", Surcharge: 15},
+			{Province: "西藏", Surcharge: 20},
+			{Province: "青海", Surcharge: 10},
+			{Province: "内蒙古", Surcharge: 8},
+		},
+		FreeShipping: FreeShippingRule{
+			ThresholdAmount:    99,
+			HonorGoodsShipFree: true,
+			HonorCoupon:        true,
+			ExcludeRemote:      true,
+		},
+		MethodMultipliers: map[int32]float64{
+			2: 2.0, // 急速配送
+			3: 0.8, // 经济配送
+		},
+		MethodExtraDays: map[int32]int32{
+			1: 1, // 标准配送
+			3: 3, // 经济配送
+		},
+	}
+}
+
+func isAny(zone string) bool {
+	return zone == "" || zone == AnyZone
+}
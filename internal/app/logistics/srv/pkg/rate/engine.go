@@ -0,0 +1,268 @@
+package rate
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// shippingMethodSelfPickup 自提配送方式，不收取运费
+const shippingMethodSelfPickup int32 = 4
+
+// Item 计费商品项
+type Item struct {
+	GoodsID  int32
+	Quantity int32
+	Weight   float64 // 单件重量(kg)
+	Volume   float64 // 单件体积(cm³)
+	ShipFree bool    // 商品是否包邮
+}
+
+// QuoteRequest 运费报价请求
+type QuoteRequest struct {
+	Company         int32
+	ShippingMethod  int32
+	SenderAddress   string
+	ReceiverAddress string
+	// 总重量(kg)/总体积(cm³)，Items非空时以Items汇总为准
+	TotalWeight float64
+	TotalVolume float64
+	Items       []Item
+	// 订单商品金额
+	OrderAmount float64
+	// 包邮券及其最高抵扣金额(0表示不限)，目前只有后台运费试算传入，下单链路不使用包邮券
+	FreeShipCoupon    bool
+	FreeShipCouponCap float64
+}
+
+// Quote 运费报价结果
+type Quote struct {
+	FromZone         string
+	ToZone           string
+	ChargeableWeight float64
+	BaseFee          float64 // 首重续重运费(已乘配送方式系数)
+	RemoteSurcharge  float64
+	ShippingFee      float64 // 实收运费
+	FreeShipping     bool
+	FreeReason       string
+	EstimatedDays    int32
+	Version          int32
+}
+
+// Engine 运费计算引擎，实例创建后只读，可并发使用
+type Engine struct {
+	cfg     *Config
+	version int32
+}
+
+// NewEngine 基于配置创建计算引擎，version为配置版本号(0表示内置默认配置)
+func NewEngine(cfg *Config, version int32) (*Engine, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("rate config is nil")
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &Engine{cfg: cfg, version: version}, nil
+}
+
+// Version 返回引擎使用的配置版本
+func (e *Engine) Version() int32 {
+	return e.version
+}
+
+// Config 返回引擎使用的配置
+func (e *Engine) Config() *Config {
+	return e.cfg
+}
+
+// Quote 计算运费
+func (e *Engine) Quote(req *QuoteRequest) (*Quote, error) {
+	weight, volume := req.TotalWeight, req.TotalVolume
+	if len(req.Items) > 0 {
+		weight, volume = 0, 0
+		for _, item := range req.Items {
+			weight += item.Weight * float64(item.Quantity)
+			volume += item.Volume * float64(item.Quantity)
+		}
+	}
+	if weight < 0 || volume < 0 {
+		return nil, fmt.Errorf("weight and volume must not be negative")
+	}
+
+	q := &Quote{
+		FromZone: e.ZoneOf(req.SenderAddress),
+		ToZone:   e.ZoneOf(req.ReceiverAddress),
+		Version:  e.version,
+	}
+
+	card := e.matchCard(req.Company, q.FromZone, q.ToZone)
+	if card == nil {
+		return nil, fmt.Errorf("no rate card for company %d from %s to %s", req.Company, q.FromZone, q.ToZone)
+	}
+
+	q.ChargeableWeight = chargeableWeight(card, weight, volume)
+	q.EstimatedDays = card.EstimatedDays + e.cfg.MethodExtraDays[req.ShippingMethod]
+	if q.EstimatedDays < 1 {
+		q.EstimatedDays = 1
+	}
+
+	if req.ShippingMethod == shippingMethodSelfPickup {
+		q.FreeShipping = true
+		q.FreeReason = "自提"
+		return q, nil
+	}
+
+	multiplier := 1.0
+	if m, ok := e.cfg.MethodMultipliers[req.ShippingMethod]; ok {
+		multiplier = m
+	}
+	q.BaseFee = round2(baseFee(card, q.ChargeableWeight) * multiplier)
+	q.RemoteSurcharge = e.remoteSurcharge(req.ReceiverAddress)
+
+	e.applyFreeShipping(req, q)
+	return q, nil
+}
+
+// ZoneOf 解析地址所属区域，市级匹配优先，未匹配时返回"*"
+func (e *Engine) ZoneOf(address string) string {
+	if address == "" {
+		return AnyZone
+	}
+	for _, z := range e.cfg.Zones {
+		for _, city := range z.Cities {
+			if city != "" && strings.Contains(address, city) {
+				return z.Code
+			}
+		}
+	}
+	for _, z := range e.cfg.Zones {
+		for _, province := range z.Provinces {
+			if province != "" && strings.Contains(address, province) {
+				return z.Code
+			}
+		}
+	}
+	return AnyZone
+}
+
+// matchCard 选择最匹配的价目表：物流公司 > 始发区域 > 目的区域
+func (e *Engine) matchCard(company int32, from, to string) *RateCard {
+	var best *RateCard
+	bestScore := -1
+	for i := range e.cfg.RateCards {
+		card := &e.cfg.RateCards[i]
+		score := 0
+		switch card.Company {
+		case company:
+			if company != 0 {
+				score += 4
+			}
+		case 0:
+		default:
+			continue
+		}
+		if !isAny(card.FromZone) {
+			if card.FromZone != from {
+				continue
+			}
+			score += 2
+		}
+		if !isAny(card.ToZone) {
+			if card.ToZone != to {
+				continue
+			}
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = card, score
+		}
+	}
+	return best
+}
+
+// remoteSurcharge 计算偏远附加费，市级配置优先于省级
+func (e *Engine) remoteSurcharge(address string) float64 {
+	provinceFee := 0.0
+	for _, ra := range e.cfg.RemoteAreas {
+		if ra.City != "" {
+			if strings.Contains(address, ra.City) {
+				return ra.Surcharge
+			}
+			continue
+		}
+		if provinceFee == 0 && strings.Contains(address, ra.Province) {
+			provinceFee = ra.Surcharge
+		}
+	}
+	return provinceFee
+}
+
+// applyFreeShipping 应用包邮规则并计算实收运费
+func (e *Engine) applyFreeShipping(req *QuoteRequest, q *Quote) {
+	rule := e.cfg.FreeShipping
+	remote := q.RemoteSurcharge > 0
+	fee := q.BaseFee
+	surcharge := q.RemoteSurcharge
+
+	waive := func(reason string) {
+		q.FreeShipping = true
+		q.FreeReason = reason
+		fee = 0
+		if rule.WaiveSurcharge {
+			surcharge = 0
+		}
+	}
+
+	switch {
+	case !(rule.ExcludeRemote && remote) && rule.HonorGoodsShipFree && allShipFree(req.Items):
+		waive("商品包邮")
+	case !(rule.ExcludeRemote && remote) && rule.ThresholdAmount > 0 && req.OrderAmount >= rule.ThresholdAmount:
+		waive(fmt.Sprintf("满%.2f元包邮", rule.ThresholdAmount))
+	case rule.HonorCoupon && req.FreeShipCoupon:
+		if req.FreeShipCouponCap > 0 && req.FreeShipCouponCap < fee {
+			// 包邮券抵扣不足时仅抵扣封顶金额
+			fee = round2(fee - req.FreeShipCouponCap)
+			q.FreeReason = "包邮券部分抵扣"
+		} else {
+			waive("包邮券")
+		}
+	}
+
+	q.ShippingFee = round2(fee + surcharge)
+}
+
+func allShipFree(items []Item) bool {
+	if len(items) == 0 {
+		return false
+	}
+	for _, item := range items {
+		if !item.ShipFree {
+			return false
+		}
+	}
+	return true
+}
+
+func chargeableWeight(card *RateCard, weight, volume float64) float64 {
+	w := weight
+	if card.VolumetricDivisor > 0 {
+		if vw := volume / card.VolumetricDivisor; vw > w {
+			w = vw
+		}
+	}
+	return round2(w)
+}
+
+func baseFee(card *RateCard, weight float64) float64 {
+	fee := card.FirstFee
+	if weight > card.FirstWeight && card.ExtraWeightUnit > 0 {
+		units := math.Ceil((weight - card.FirstWeight) / card.ExtraWeightUnit)
+		fee += units * card.ExtraFee
+	}
+	return fee
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
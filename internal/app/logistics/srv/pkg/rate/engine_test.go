@@ -0,0 +1,152 @@
+package rate
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func newDefaultEngine(t *testing.T) *Engine {
+	t.Helper()
+	e, err := NewEngine(DefaultConfig(), 0)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	return e
+}
+
+func TestZoneOf(t *testing.T) {
+	e := newDefaultEngine(t)
+	cases := map[string]string{
+		"上海市浦东新区张江路1号": "JJH",
+		"北京市朝阳区建国路88号": "JJJ",
+		"新疆乌鲁木齐市天山区":   "REMOTE",
+		"四川省成都市锦江区":    AnyZone,
+		"":             AnyZone,
+	}
+	for addr, want := range cases {
+		if got := e.ZoneOf(addr); got != want {
+			t.Errorf("ZoneOf(%q) = %q, want %q", addr, got, want)
+		}
+	}
+}
+
+func TestQuoteFirstAndExtraWeight(t *testing.T) {
+	e := newDefaultEngine(t)
+	q, err := e.Quote(&QuoteRequest{
+		ShippingMethod:  1,
+		SenderAddress:   "上海市",
+		ReceiverAddress: "浙江省杭州市",
+		TotalWeight:     2.3,
+	})
+	if err != nil {
+		t.Fatalf("Quote: %v", err)
+	}
+	// 同区域价目: 首重8元 + ceil(1.3)=2个续重单位 * 2元
+	if q.BaseFee != 12 || q.ShippingFee != 12 {
+		t.Errorf("fee = %v/%v, want 12/12", q.BaseFee, q.ShippingFee)
+	}
+	if q.FromZone != "JJH" || q.ToZone != "JJH" {
+		t.Errorf("zones = %s->%s", q.FromZone, q.ToZone)
+	}
+	if q.EstimatedDays != 2 {
+		t.Errorf("estimated days = %d, want 2", q.EstimatedDays)
+	}
+}
+
+func TestQuoteCarrierAndVolumetric(t *testing.T) {
+	e := newDefaultEngine(t)
+	q, err := e.Quote(&QuoteRequest{
+		Company:         5,
+		ShippingMethod:  1,
+		SenderAddress:   "上海市",
+		ReceiverAddress: "浙江省杭州市",
+		TotalWeight:     1,
+		TotalVolume:     18000, // 顺丰体积重 18000/6000 = 3kg
+	})
+	if err != nil {
+		t.Fatalf("Quote: %v", err)
+	}
+	if q.ChargeableWeight != 3 {
+		t.Errorf("chargeable weight = %v, want 3", q.ChargeableWeight)
+	}
+	// 顺丰价目优先于通用同区域价目: 18 + 2*5
+	if q.BaseFee != 28 {
+		t.Errorf("base fee = %v, want 28", q.BaseFee)
+	}
+}
+
+func TestQuoteFreeShipping(t *testing.T) {
+	e := newDefaultEngine(t)
+
+	q, _ := e.Quote(&QuoteRequest{
+		ShippingMethod:  1,
+		ReceiverAddress: "江苏省南京市",
+		Items:           []Item{{GoodsID: 1, Quantity: 2, Weight: 1, ShipFree: true}},
+	})
+	if !q.FreeShipping || q.ShippingFee != 0 {
+		t.Errorf("goods ship free not honoured: %+v", q)
+	}
+
+	q, _ = e.Quote(&QuoteRequest{
+		ShippingMethod:  1,
+		ReceiverAddress: "江苏省南京市",
+		TotalWeight:     1,
+		OrderAmount:     120,
+	})
+	if !q.FreeShipping || q.ShippingFee != 0 {
+		t.Errorf("threshold free shipping not applied: %+v", q)
+	}
+
+	// 偏远地区不参与满额包邮，但包邮券仍然有效，附加费照收
+	q, _ = e.Quote(&QuoteRequest{
+		ShippingMethod:  1,
+		ReceiverAddress: "新疆乌鲁木齐市",
+		TotalWeight:     1,
+		OrderAmount:     120,
+	})
+	if q.FreeShipping || q.ShippingFee != 18+15 {
+		t.Errorf("remote area should be excluded: %+v", q)
+	}
+	q, _ = e.Quote(&QuoteRequest{
+		ShippingMethod:  1,
+		ReceiverAddress: "新疆乌鲁木齐市",
+		TotalWeight:     1,
+		FreeShipCoupon:  true,
+	})
+	if !q.FreeShipping || q.ShippingFee != 15 {
+		t.Errorf("free ship coupon should waive base fee only: %+v", q)
+	}
+
+	q, _ = e.Quote(&QuoteRequest{
+		ShippingMethod:    1,
+		ReceiverAddress:   "四川省成都市",
+		TotalWeight:       1,
+		FreeShipCoupon:    true,
+		FreeShipCouponCap: 4,
+	})
+	if q.FreeShipping || q.ShippingFee != 6 {
+		t.Errorf("coupon cap should apply: %+v", q)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	raw, err := json.Marshal(DefaultConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Parse(raw); err != nil {
+		t.Fatalf("default config should be valid: %v", err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.RateCards = cfg.RateCards[1:]
+	if err := cfg.Validate(); err == nil {
+		t.Error("config without fallback card should be rejected")
+	}
+
+	cfg = DefaultConfig()
+	cfg.RateCards[1].ToZone = "UNKNOWN"
+	if err := cfg.Validate(); err == nil {
+		t.Error("config referencing unknown zone should be rejected")
+	}
+}
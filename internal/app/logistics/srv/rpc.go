@@ -2,42 +2,29 @@ package srv
 
 import (
 	logisticspb "emshop/api/logistics/v1"
-	"emshop/gin-micro/core/trace"
 	"emshop/gin-micro/server/rpc-server"
 	"emshop/internal/app/logistics/srv/config"
 	v1 "emshop/internal/app/logistics/srv/controller/logistics/v1"
-	datav1 "emshop/internal/app/logistics/srv/data/v1"
 	service "emshop/internal/app/logistics/srv/service/v1"
 	"emshop/pkg/log"
 	"fmt"
 )
 
-func NewLogisticsRPCServer(cfg *config.Config) (*rpcserver.Server, error) {
-	//初始化open-telemetry的exporter
-	trace.InitAgent(trace.Options{
-		Name:     cfg.Telemetry.Name,
-		Endpoint: cfg.Telemetry.Endpoint,
-		Sampler:  cfg.Telemetry.Sampler,
-		Batcher:  cfg.Telemetry.Batcher,
-	})
-
-	// 创建数据工厂管理器
-	factoryManager, err := datav1.NewFactoryManager(cfg.MySQLOptions)
-	if err != nil {
-		log.Fatal(err.Error())
-		return nil, err
-	}
-
-	// 创建业务服务层
-	logisticsSrv := service.NewLogisticsService(factoryManager.GetDataFactory(), cfg.Redis)
-
+func NewLogisticsRPCServer(cfg *config.Config, logisticsSrv service.LogisticsSrv) (*rpcserver.Server, error) {
 	// 创建控制器
 	logisticsServer := v1.NewLogisticsController(logisticsSrv)
 
 	rpcAddr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
+	grpcOpts, err := cfg.Server.GRPCServerOptions()
+	if err != nil {
+		return nil, err
+	}
 	grpcServer := rpcserver.NewServer(
 		rpcserver.WithAddress(rpcAddr),
 		rpcserver.WithMetrics(cfg.Server.EnableMetrics),
+		rpcserver.WithOptions(grpcOpts...),
+		rpcserver.WithAdaptiveLimit(cfg.Server.Limiter.AdaptiveLimiter(), nil),
+		rpcserver.WithLogAdmin(cfg.Server.LogAdminSecret),
 	)
 
 	logisticspb.RegisterLogisticsServer(grpcServer.Server, logisticsServer)
@@ -0,0 +1,272 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	"emshop/internal/app/logistics/srv/domain/do"
+	"emshop/internal/app/logistics/srv/pkg/carrier"
+	"emshop/internal/app/pkg/code"
+	"emshop/pkg/errors"
+	"emshop/pkg/log"
+)
+
+// trackingStatuses 需要继续跟踪轨迹的物流状态
+var trackingStatuses = []int32{
+	int32(do.LogisticsStatusShipped),
+	int32(do.LogisticsStatusInTransit),
+	int32(do.LogisticsStatusDelivering),
+	int32(do.LogisticsStatusRejected),
+	int32(do.LogisticsStatusReturning),
+}
+
+// carrierStatusMapping 承运商轨迹状态到物流状态的映射
+var carrierStatusMapping = map[carrier.TrackStatus]do.LogisticsStatus{
+	carrier.StatusCollected:  do.LogisticsStatusShipped,
+	carrier.StatusInTransit:  do.LogisticsStatusInTransit,
+	carrier.StatusDelivering: do.LogisticsStatusDelivering,
+	carrier.StatusSigned:     do.LogisticsStatusDelivered,
+	carrier.StatusRejected:   do.LogisticsStatusRejected,
+	carrier.StatusReturned:   do.LogisticsStatusReturned,
+}
+
+// statusRank 物流状态推进顺序，只允许向后推进
+var statusRank = map[do.LogisticsStatus]int{
+	do.LogisticsStatusPending:    1,
+	do.LogisticsStatusShipped:    2,
+	do.LogisticsStatusInTransit:  3,
+	do.LogisticsStatusDelivering: 4,
+	do.LogisticsStatusRejected:   5,
+	do.LogisticsStatusReturning:  6,
+	do.LogisticsStatusDelivered:  7,
+	do.LogisticsStatusReturned:   7,
+}
+
+// HandleCarrierPush 处理承运商轨迹推送，返回新增的轨迹条数
+//
+// 推送重试或与轮询重叠的轨迹按去重键跳过，未知运单忽略，保证承运商重试时结果一致
+func (ls *logisticsService) HandleCarrierPush(ctx context.Context, company int32, header http.Header, body []byte) (int, error) {
+	c, err := ls.carriers.Get(company)
+	if err != nil {
+		return 0, errors.WithCode(code.ErrLogisticsCompanyNotSupported, "物流公司%d未接入", company)
+	}
+
+	payload, err := c.ParsePush(header, body)
+	switch {
+	case errors.Is(err, carrier.ErrSignatureInvalid), errors.Is(err, carrier.ErrSignatureExpired):
+		log.WarnfC(ctx, "承运商推送签名校验失败: company=%d, err=%v", company, err)
+		return 0, errors.WithCode(code.ErrCarrierSignatureInvalid, "推送签名校验失败")
+	case errors.Is(err, carrier.ErrPushNotSupported):
+		return 0, errors.WithCode(code.ErrLogisticsCompanyNotSupported, "物流公司%d未开通轨迹推送", company)
+	case err != nil:
+		return 0, errors.WithCode(code.ErrLogisticsTrackInvalid, "推送内容解析失败: %v", err)
+	}
+
+	grouped := make(map[string][]carrier.TrackEvent)
+	for _, ev := range payload.Events {
+		grouped[ev.TrackingNumber] = append(grouped[ev.TrackingNumber], ev)
+	}
+
+	total := 0
+	for trackingNumber, events := range grouped {
+		order, err := ls.data.LogisticsOrders().GetByTrackingNumber(ctx, ls.data.DB(), trackingNumber)
+		if err != nil {
+			if errors.IsCode(err, code.ErrLogisticsOrderNotFound) {
+				log.WarnfC(ctx, "承运商推送了未知运单: company=%d, 运单号=%s, push=%s", company, trackingNumber, payload.PushID)
+				continue
+			}
+			return total, err
+		}
+		if order.LogisticsCompany != company {
+			log.WarnfC(ctx, "运单%s不属于物流公司%d，忽略推送", trackingNumber, company)
+			continue
+		}
+
+		n, err := ls.applyCarrierEvents(ctx, order, events)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+
+	log.InfofC(ctx, "处理承运商推送: company=%d, push=%s, 节点数=%d, 新增=%d", company, payload.PushID, len(payload.Events), total)
+	return total, nil
+}
+
+// SyncCarrierTracks 主动向承运商查询并同步物流轨迹，返回新增的轨迹条数
+func (ls *logisticsService) SyncCarrierTracks(ctx context.Context, logisticsSn string) (int, error) {
+	order, err := ls.data.LogisticsOrders().GetByLogisticsSn(ctx, ls.data.DB(), logisticsSn)
+	if err != nil {
+		return 0, err
+	}
+	return ls.syncCarrierTracks(ctx, order)
+}
+
+// PollCarrierTracks 轮询一批不支持推送的承运商的在途物流订单，从afterID之后开始同步轨迹，
+// 返回新增的轨迹条数和下一批的游标，在途订单遍历完成时游标为0
+func (ls *logisticsService) PollCarrierTracks(ctx context.Context, afterID int64, batchSize int) (int, int64, error) {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	orders, err := ls.data.LogisticsOrders().FindByStatusesAfter(ctx, ls.data.DB(), trackingStatuses, afterID, batchSize)
+	if err != nil {
+		return 0, afterID, err
+	}
+	total := 0
+	for _, order := range orders {
+		if ctx.Err() != nil {
+			return total, afterID, ctx.Err()
+		}
+		afterID = order.ID
+		c, err := ls.carriers.Get(order.LogisticsCompany)
+		if err != nil || c.SupportsPush() {
+			continue
+		}
+		n, err := ls.syncCarrierTracks(ctx, order)
+		if err != nil {
+			// 单个运单失败不影响本轮其他运单
+			log.WarnfC(ctx, "轮询物流轨迹失败: 物流单号=%s, err=%v", order.LogisticsSn, err)
+			continue
+		}
+		total += n
+	}
+	if len(orders) < batchSize {
+		return total, 0, nil
+	}
+	return total, afterID, nil
+}
+
+func (ls *logisticsService) syncCarrierTracks(ctx context.Context, order *do.LogisticsOrderDO) (int, error) {
+	c, err := ls.carriers.Get(order.LogisticsCompany)
+	if err != nil {
+		return 0, errors.WithCode(code.ErrLogisticsCompanyNotSupported, "物流公司%d未接入", order.LogisticsCompany)
+	}
+	events, err := c.QueryTracks(ctx, order.TrackingNumber)
+	if err != nil {
+		if errors.Is(err, carrier.ErrWaybillNotFound) {
+			return 0, nil
+		}
+		return 0, errors.WithCode(code.ErrLogisticsCompanyServiceUnavailable, "查询承运商轨迹失败: %v", err)
+	}
+	return ls.applyCarrierEvents(ctx, order, events)
+}
+
+// applyCarrierEvents 追加承运商轨迹并推进物流状态
+func (ls *logisticsService) applyCarrierEvents(ctx context.Context, order *do.LogisticsOrderDO, events []carrier.TrackEvent) (int, error) {
+	if len(events) == 0 {
+		return 0, nil
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Time.Before(events[j].Time) })
+
+	tx := ls.data.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			panic(r)
+		}
+	}()
+
+	created := 0
+	current := do.LogisticsStatus(order.LogisticsStatus)
+	target := current
+	var targetAt time.Time
+	for i := range events {
+		ev := &events[i]
+		if ev.Time.IsZero() {
+			ev.Time = time.Now()
+		}
+		operator := ev.Operator
+		if operator == "" {
+			operator = logisticsCompanies[order.LogisticsCompany].CompanyName
+		}
+		key := ev.DedupKey(order.LogisticsCompany)
+		ok, err := ls.data.LogisticsTracks().CreateIfAbsent(ctx, tx, &do.LogisticsTrackDO{
+			LogisticsSn:    order.LogisticsSn,
+			TrackingNumber: order.TrackingNumber,
+			Location:       ev.Location,
+			Description:    ev.Description,
+			TrackTime:      ev.Time,
+			OperatorName:   operator,
+			EventKey:       &key,
+		})
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+		if ok {
+			created++
+		}
+
+		if next, mapped := carrierStatusMapping[ev.Status]; mapped && statusRank[next] > statusRank[target] {
+			target, targetAt = next, ev.Time
+		}
+	}
+
+	if target != current && !isTerminalStatus(current) {
+		var err error
+		if current == do.LogisticsStatusPending && target != do.LogisticsStatusShipped {
+			// 未经发货操作直接收到在途轨迹时补记发货时间
+			err = ls.data.LogisticsOrders().UpdateShipmentInfo(ctx, tx, order.LogisticsSn, &events[0].Time)
+		}
+		switch {
+		case err != nil:
+		case target == do.LogisticsStatusShipped:
+			err = ls.data.LogisticsOrders().UpdateShipmentInfo(ctx, tx, order.LogisticsSn, &targetAt)
+		case target == do.LogisticsStatusDelivered:
+			err = ls.data.LogisticsOrders().UpdateDeliveryInfo(ctx, tx, order.LogisticsSn, &targetAt)
+		default:
+			err = ls.data.LogisticsOrders().UpdateStatus(ctx, tx, order.LogisticsSn, int32(target))
+		}
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+		log.InfofC(ctx, "承运商轨迹推进物流状态: 物流单号=%s, %d -> %d", order.LogisticsSn, current, target)
+		order.LogisticsStatus = int32(target)
+		switch target {
+		case do.LogisticsStatusShipped:
+			order.ShippedAt = &targetAt
+		case do.LogisticsStatusDelivered:
+			order.DeliveredAt = &targetAt
+		}
+		if err := ls.events.Add(ctx, tx, aggregateLogisticsOrder, order.LogisticsSn, EventLogisticsStatusChanged, newLogisticsEvent(order, int32(current))); err != nil {
+			tx.Rollback()
+			order.LogisticsStatus = int32(current)
+			log.ErrorfC(ctx, "写入物流状态事件失败: 物流单号=%s, err=%v", order.LogisticsSn, err)
+			return 0, errors.WithCode(code.ErrConnectDB, "写入物流状态事件失败")
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return 0, errors.WithCode(code.ErrConnectDB, "提交事务失败")
+	}
+	return created, nil
+}
+
+// isTerminalStatus 终态的物流订单不再随承运商轨迹变更
+func isTerminalStatus(status do.LogisticsStatus) bool {
+	switch status {
+	case do.LogisticsStatusDelivered, do.LogisticsStatusReturned, do.LogisticsStatusCanceled:
+		return true
+	}
+	return false
+}
+
+// carrierWaybillRequest 组装承运商下单请求
+func carrierWaybillRequest(order *do.LogisticsOrderDO, weight float64) *carrier.WaybillRequest {
+	return &carrier.WaybillRequest{
+		LogisticsSn:     order.LogisticsSn,
+		OrderSn:         order.OrderSn,
+		ShippingMethod:  order.ShippingMethod,
+		SenderName:      order.SenderName,
+		SenderPhone:     order.SenderPhone,
+		SenderAddress:   order.SenderAddress,
+		ReceiverName:    order.ReceiverName,
+		ReceiverPhone:   order.ReceiverPhone,
+		ReceiverAddress: order.ReceiverAddress,
+		Weight:          weight,
+	}
+}
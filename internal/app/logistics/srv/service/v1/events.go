@@ -0,0 +1,45 @@
+package v1
+
+import (
+	"time"
+
+	"emshop/internal/app/logistics/srv/domain/do"
+)
+
+// 物流领域事件，经发件箱投递，消费方按事件ID去重
+const (
+	aggregateLogisticsOrder = "logistics_order"
+
+	EventLogisticsCreated       = "logistics.created"        // 物流订单已创建
+	EventLogisticsStatusChanged = "logistics.status_changed" // 物流状态变更（发货、在途、签收等）
+	EventLogisticsCancelled     = "logistics.cancelled"      // 物流订单已取消
+)
+
+// LogisticsEvent 物流事件内容，携带变更前后的状态
+type LogisticsEvent struct {
+	LogisticsSn      string     `json:"logistics_sn"`
+	OrderSn          string     `json:"order_sn"`
+	UserID           int32      `json:"user_id"`
+	LogisticsCompany int32      `json:"logistics_company"`
+	TrackingNumber   string     `json:"tracking_number"`
+	PreviousStatus   int32      `json:"previous_status,omitempty"`
+	Status           int32      `json:"status"`
+	ShippedAt        *time.Time `json:"shipped_at,omitempty"`
+	DeliveredAt      *time.Time `json:"delivered_at,omitempty"`
+	OccurredAt       time.Time  `json:"occurred_at"`
+}
+
+func newLogisticsEvent(order *do.LogisticsOrderDO, previous int32) *LogisticsEvent {
+	return &LogisticsEvent{
+		LogisticsSn:      order.LogisticsSn,
+		OrderSn:          order.OrderSn,
+		UserID:           order.UserID,
+		LogisticsCompany: order.LogisticsCompany,
+		TrackingNumber:   order.TrackingNumber,
+		PreviousStatus:   previous,
+		Status:           order.LogisticsStatus,
+		ShippedAt:        order.ShippedAt,
+		DeliveredAt:      order.DeliveredAt,
+		OccurredAt:       time.Now(),
+	}
+}
@@ -6,16 +6,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
-	"strings"
+	"net/http"
 	"time"
 
 	"emshop/internal/app/logistics/srv/data/v1/interfaces"
 	"emshop/internal/app/logistics/srv/domain/do"
 	"emshop/internal/app/logistics/srv/domain/dto"
+	"emshop/internal/app/logistics/srv/pkg/carrier"
+	"emshop/internal/app/logistics/srv/pkg/rate"
 	"emshop/internal/app/pkg/code"
 	"emshop/internal/app/pkg/options"
 	"emshop/pkg/errors"
 	"emshop/pkg/log"
+	"emshop/pkg/outbox"
 )
 
 // LogisticsSrv 物流服务接口
@@ -37,18 +40,35 @@ type LogisticsSrv interface {
 	
 	// DTM分布式事务补偿
 	CancelLogisticsOrder(ctx context.Context, orderSn, reason string) error
+
+	// 运费配置管理
+	ListShippingRateConfigs(ctx context.Context, page, pageSize int) (*dto.ShippingRateConfigListDTO, error)
+	GetShippingRateConfig(ctx context.Context, version int32) (*dto.ShippingRateConfigDTO, error)
+	CreateShippingRateConfig(ctx context.Context, req *dto.CreateShippingRateConfigDTO) (*dto.ShippingRateConfigDTO, error)
+	ActivateShippingRateConfig(ctx context.Context, version int32, operator string) error
+
+	// 承运商轨迹同步
+	HandleCarrierPush(ctx context.Context, company int32, header http.Header, body []byte) (int, error)
+	SyncCarrierTracks(ctx context.Context, logisticsSn string) (int, error)
+	PollCarrierTracks(ctx context.Context, afterID int64, batchSize int) (int, int64, error)
 }
 
 type logisticsService struct {
 	data         interfaces.DataFactory
 	redisOptions *options.RedisOptions
+	rates        *rateEngineCache
+	carriers     *carrier.Registry
+	events       *outbox.Outbox
 }
 
 // NewLogisticsService 创建物流服务实例
-func NewLogisticsService(data interfaces.DataFactory, redisOpts *options.RedisOptions) LogisticsSrv {
+func NewLogisticsService(data interfaces.DataFactory, redisOpts *options.RedisOptions, carriers *carrier.Registry, events *outbox.Outbox) LogisticsSrv {
 	return &logisticsService{
 		data:         data,
 		redisOptions: redisOpts,
+		rates:        &rateEngineCache{},
+		carriers:     carriers,
+		events:       events,
 	}
 }
 
@@ -70,24 +90,9 @@ func (ls *logisticsService) generateLogisticsSn() string {
 	return fmt.Sprintf("LG%s%04d", timestamp, n.Int64())
 }
 
-// generateTrackingNumber 生成快递单号
-func (ls *logisticsService) generateTrackingNumber(company int32) string {
-	n, _ := rand.Int(rand.Reader, big.NewInt(999999999999))
-	switch company {
-	case 5: // 顺丰
-		return fmt.Sprintf("SF%012d", n.Int64())
-	case 6: // 京东
-		return fmt.Sprintf("JD%013d", n.Int64())
-	case 7: // 邮政
-		return fmt.Sprintf("EMS%011d", n.Int64())
-	default:
-		return fmt.Sprintf("EX%012d", n.Int64())
-	}
-}
-
 // CreateLogisticsOrder 创建物流订单
 func (ls *logisticsService) CreateLogisticsOrder(ctx context.Context, req *dto.CreateLogisticsOrderDTO) (*dto.LogisticsOrderDTO, error) {
-	log.Infof("创建物流订单: 订单号=%s, 用户ID=%d", req.OrderSn, req.UserID)
+	log.InfofC(ctx, "创建物流订单: 订单号=%s, 用户ID=%d", req.OrderSn, req.UserID)
 
 	// 检查该订单是否已经创建物流订单
 	existingOrder, err := ls.data.LogisticsOrders().GetByOrderSn(ctx, ls.data.DB(), req.OrderSn)
@@ -98,17 +103,37 @@ func (ls *logisticsService) CreateLogisticsOrder(ctx context.Context, req *dto.C
 		return nil, errors.WithCode(code.ErrLogisticsOrderExists, "该订单已存在物流订单")
 	}
 
-	// 生成物流单号和快递单号
+	c, err := ls.carriers.Get(req.LogisticsCompany)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrLogisticsCompanyNotSupported, "物流公司%d未接入", req.LogisticsCompany)
+	}
+
+	// 生成物流单号
 	logisticsSn := ls.generateLogisticsSn()
-	trackingNumber := ls.generateTrackingNumber(req.LogisticsCompany)
+	totalWeight := ls.calculateTotalWeight(req.Items)
 
 	// 计算运费
+	feeItems := make([]dto.ShippingFeeItemDTO, len(req.Items))
+	for i, item := range req.Items {
+		feeItems[i] = dto.ShippingFeeItemDTO{
+			GoodsID:  item.GoodsID,
+			Quantity: item.Quantity,
+			Weight:   item.Weight,
+			Volume:   item.Volume,
+			ShipFree: item.ShipFree,
+		}
+	}
 	feeReq := &dto.CalculateShippingFeeDTO{
-		SenderAddress:   req.SenderAddress,
-		ReceiverAddress: req.ReceiverAddress,
-		ShippingMethod:  req.ShippingMethod,
-		TotalWeight:     ls.calculateTotalWeight(req.Items),
-		TotalVolume:     ls.calculateTotalVolume(req.Items),
+		SenderAddress:     req.SenderAddress,
+		ReceiverAddress:   req.ReceiverAddress,
+		ShippingMethod:    req.ShippingMethod,
+		LogisticsCompany:  req.LogisticsCompany,
+		TotalWeight:       totalWeight,
+		TotalVolume:       ls.calculateTotalVolume(req.Items),
+		Items:             feeItems,
+		OrderAmount:       req.OrderAmount,
+		FreeShipCoupon:    req.FreeShipCoupon,
+		FreeShipCouponCap: req.FreeShipCouponCap,
 	}
 	feeResp, err := ls.CalculateShippingFee(ctx, feeReq)
 	if err != nil {
@@ -128,7 +153,6 @@ func (ls *logisticsService) CreateLogisticsOrder(ctx context.Context, req *dto.C
 		UserID:              req.UserID,
 		LogisticsCompany:    req.LogisticsCompany,
 		ShippingMethod:      req.ShippingMethod,
-		TrackingNumber:      trackingNumber,
 		LogisticsStatus:     int32(do.LogisticsStatusPending),
 		SenderName:          req.SenderName,
 		SenderPhone:         req.SenderPhone,
@@ -143,6 +167,15 @@ func (ls *logisticsService) CreateLogisticsOrder(ctx context.Context, req *dto.C
 		Remark:              req.Remark,
 	}
 
+	// 向承运商下单获取快递单号
+	waybill, err := c.CreateWaybill(ctx, carrierWaybillRequest(order, totalWeight))
+	if err != nil {
+		log.ErrorfC(ctx, "承运商下单失败: 物流公司=%d, 订单号=%s, err=%v", req.LogisticsCompany, req.OrderSn, err)
+		return nil, errors.WithCode(code.ErrLogisticsCompanyServiceUnavailable, "承运商下单失败")
+	}
+	trackingNumber := waybill.TrackingNumber
+	order.TrackingNumber = trackingNumber
+
 	// 开启事务
 	tx := ls.data.Begin()
 	defer func() {
@@ -155,6 +188,7 @@ func (ls *logisticsService) CreateLogisticsOrder(ctx context.Context, req *dto.C
 	// 创建物流订单
 	if err := ls.data.LogisticsOrders().Create(ctx, tx, order); err != nil {
 		tx.Rollback()
+		ls.cancelWaybillQuietly(ctx, c, trackingNumber)
 		return nil, errors.WithCode(code.ErrCreateLogisticsOrderFailed, "创建物流订单失败")
 	}
 
@@ -168,16 +202,24 @@ func (ls *logisticsService) CreateLogisticsOrder(ctx context.Context, req *dto.C
 		OperatorName:   "系统",
 	}
 	if err := ls.data.LogisticsTracks().Create(ctx, tx, initialTrack); err != nil {
-		log.Warnf("创建初始物流轨迹失败: %v", err)
+		log.WarnfC(ctx, "创建初始物流轨迹失败: %v", err)
 		// 轨迹失败不影响主流程
 	}
 
+	if err := ls.events.Add(ctx, tx, aggregateLogisticsOrder, logisticsSn, EventLogisticsCreated, newLogisticsEvent(order, 0)); err != nil {
+		tx.Rollback()
+		ls.cancelWaybillQuietly(ctx, c, trackingNumber)
+		log.ErrorfC(ctx, "写入物流创建事件失败: 物流单号=%s, err=%v", logisticsSn, err)
+		return nil, errors.WithCode(code.ErrConnectDB, "写入物流创建事件失败")
+	}
+
 	// 提交事务
 	if err := tx.Commit().Error; err != nil {
+		ls.cancelWaybillQuietly(ctx, c, trackingNumber)
 		return nil, errors.WithCode(code.ErrConnectDB, "提交事务失败")
 	}
 
-	log.Infof("物流订单创建成功: 物流单号=%s, 快递单号=%s", logisticsSn, trackingNumber)
+	log.InfofC(ctx, "物流订单创建成功: 物流单号=%s, 快递单号=%s", logisticsSn, trackingNumber)
 
 	return &dto.LogisticsOrderDTO{
 		LogisticsSn:         logisticsSn,
@@ -277,14 +319,43 @@ func (ls *logisticsService) GetLogisticsTracks(ctx context.Context, req *dto.Get
 
 // UpdateLogisticsStatus 更新物流状态
 func (ls *logisticsService) UpdateLogisticsStatus(ctx context.Context, req *dto.UpdateLogisticsStatusDTO) error {
-	log.Infof("更新物流状态: 物流单号=%s, 新状态=%d", req.LogisticsSn, req.NewStatus)
-	
-	return ls.data.LogisticsOrders().UpdateStatus(ctx, ls.data.DB(), req.LogisticsSn, req.NewStatus)
+	log.InfofC(ctx, "更新物流状态: 物流单号=%s, 新状态=%d", req.LogisticsSn, req.NewStatus)
+
+	order, err := ls.data.LogisticsOrders().GetByLogisticsSn(ctx, ls.data.DB(), req.LogisticsSn)
+	if err != nil {
+		return err
+	}
+
+	tx := ls.data.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			panic(r)
+		}
+	}()
+
+	if err := ls.data.LogisticsOrders().UpdateStatus(ctx, tx, req.LogisticsSn, req.NewStatus); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	previous := order.LogisticsStatus
+	order.LogisticsStatus = req.NewStatus
+	if err := ls.events.Add(ctx, tx, aggregateLogisticsOrder, req.LogisticsSn, EventLogisticsStatusChanged, newLogisticsEvent(order, previous)); err != nil {
+		tx.Rollback()
+		log.ErrorfC(ctx, "写入物流状态事件失败: 物流单号=%s, err=%v", req.LogisticsSn, err)
+		return errors.WithCode(code.ErrConnectDB, "写入物流状态事件失败")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return errors.WithCode(code.ErrConnectDB, "提交事务失败")
+	}
+	return nil
 }
 
 // SimulateShipment 模拟发货
 func (ls *logisticsService) SimulateShipment(ctx context.Context, req *dto.SimulateShipmentDTO) error {
-	log.Infof("模拟发货: 物流单号=%s", req.LogisticsSn)
+	log.InfofC(ctx, "模拟发货: 物流单号=%s", req.LogisticsSn)
 
 	// 获取物流订单
 	order, err := ls.data.LogisticsOrders().GetByLogisticsSn(ctx, ls.data.DB(), req.LogisticsSn)
@@ -328,7 +399,16 @@ func (ls *logisticsService) SimulateShipment(ctx context.Context, req *dto.Simul
 	}
 	
 	if err := ls.data.LogisticsTracks().Create(ctx, tx, shipmentTrack); err != nil {
-		log.Warnf("创建发货轨迹失败: %v", err)
+		log.WarnfC(ctx, "创建发货轨迹失败: %v", err)
+	}
+
+	previous := order.LogisticsStatus
+	order.LogisticsStatus = int32(do.LogisticsStatusShipped)
+	order.ShippedAt = &now
+	if err := ls.events.Add(ctx, tx, aggregateLogisticsOrder, req.LogisticsSn, EventLogisticsStatusChanged, newLogisticsEvent(order, previous)); err != nil {
+		tx.Rollback()
+		log.ErrorfC(ctx, "写入物流发货事件失败: 物流单号=%s, err=%v", req.LogisticsSn, err)
+		return errors.WithCode(code.ErrConnectDB, "写入物流发货事件失败")
 	}
 
 	// 提交事务
@@ -336,15 +416,13 @@ func (ls *logisticsService) SimulateShipment(ctx context.Context, req *dto.Simul
 		return errors.WithCode(code.ErrConnectDB, "提交事务失败")
 	}
 
-	// 异步生成轨迹
-	go ls.generateLogisticsTracks(req.LogisticsSn)
-
+	// 后续轨迹由承运商推送或轮询同步
 	return nil
 }
 
 // SimulateDelivery 模拟签收
 func (ls *logisticsService) SimulateDelivery(ctx context.Context, req *dto.SimulateDeliveryDTO) error {
-	log.Infof("模拟签收: 物流单号=%s", req.LogisticsSn)
+	log.InfofC(ctx, "模拟签收: 物流单号=%s", req.LogisticsSn)
 
 	// 获取物流订单
 	order, err := ls.data.LogisticsOrders().GetByLogisticsSn(ctx, ls.data.DB(), req.LogisticsSn)
@@ -388,7 +466,16 @@ func (ls *logisticsService) SimulateDelivery(ctx context.Context, req *dto.Simul
 	}
 	
 	if err := ls.data.LogisticsTracks().Create(ctx, tx, deliveryTrack); err != nil {
-		log.Warnf("创建签收轨迹失败: %v", err)
+		log.WarnfC(ctx, "创建签收轨迹失败: %v", err)
+	}
+
+	previous := order.LogisticsStatus
+	order.LogisticsStatus = int32(do.LogisticsStatusDelivered)
+	order.DeliveredAt = &now
+	if err := ls.events.Add(ctx, tx, aggregateLogisticsOrder, req.LogisticsSn, EventLogisticsStatusChanged, newLogisticsEvent(order, previous)); err != nil {
+		tx.Rollback()
+		log.ErrorfC(ctx, "写入物流签收事件失败: 物流单号=%s, err=%v", req.LogisticsSn, err)
+		return errors.WithCode(code.ErrConnectDB, "写入物流签收事件失败")
 	}
 
 	// 提交事务
@@ -401,28 +488,55 @@ func (ls *logisticsService) SimulateDelivery(ctx context.Context, req *dto.Simul
 
 // CalculateShippingFee 计算运费
 func (ls *logisticsService) CalculateShippingFee(ctx context.Context, req *dto.CalculateShippingFeeDTO) (*dto.ShippingFeeDTO, error) {
-	// 模拟运费计算逻辑
-	distance := ls.calculateDistance(req.SenderAddress, req.ReceiverAddress)
-	baseFee := ls.calculateBaseFee(distance, req.TotalWeight)
-	
-	// 配送方式加成
-	methodMultiplier := ls.getMethodMultiplier(req.ShippingMethod)
-	shippingFee := baseFee * methodMultiplier
-	
+	engine := ls.rateEngine(ctx)
+
+	items := make([]rate.Item, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = rate.Item{
+			GoodsID:  item.GoodsID,
+			Quantity: item.Quantity,
+			Weight:   item.Weight,
+			Volume:   item.Volume,
+			ShipFree: item.ShipFree,
+		}
+	}
+
+	quote, err := engine.Quote(&rate.QuoteRequest{
+		Company:           req.LogisticsCompany,
+		ShippingMethod:    req.ShippingMethod,
+		SenderAddress:     req.SenderAddress,
+		ReceiverAddress:   req.ReceiverAddress,
+		TotalWeight:       req.TotalWeight,
+		TotalVolume:       req.TotalVolume,
+		Items:             items,
+		OrderAmount:       req.OrderAmount,
+		FreeShipCoupon:    req.FreeShipCoupon,
+		FreeShipCouponCap: req.FreeShipCouponCap,
+	})
+	if err != nil {
+		log.ErrorfC(ctx, "运费计算失败: %v", err)
+		return nil, errors.WithCode(code.ErrShippingFeeCalculationFailed, "运费计算失败: %v", err)
+	}
+
 	// 保价费计算
 	var insuranceFee float64
 	if req.NeedInsurance {
 		insuranceFee = req.GoodsValue * 0.005 // 0.5%保价费率
 	}
-	
-	// 预计天数
-	estimatedDays := ls.getEstimatedDays(req.ShippingMethod, distance)
-	
+
 	return &dto.ShippingFeeDTO{
-		ShippingFee:   shippingFee,
-		InsuranceFee:  insuranceFee,
-		TotalFee:      shippingFee + insuranceFee,
-		EstimatedDays: estimatedDays,
+		ShippingFee:      quote.ShippingFee,
+		InsuranceFee:     insuranceFee,
+		TotalFee:         quote.ShippingFee + insuranceFee,
+		EstimatedDays:    quote.EstimatedDays,
+		BaseFee:          quote.BaseFee,
+		RemoteSurcharge:  quote.RemoteSurcharge,
+		ChargeableWeight: quote.ChargeableWeight,
+		FromZone:         quote.FromZone,
+		ToZone:           quote.ToZone,
+		FreeShipping:     quote.FreeShipping,
+		FreeReason:       quote.FreeReason,
+		RateVersion:      quote.Version,
 	}, nil
 }
 
@@ -502,73 +616,21 @@ func (ls *logisticsService) calculateEstimatedDelivery(method int32) time.Time {
 	return time.Now().Add(time.Duration(hours) * time.Hour)
 }
 
-func (ls *logisticsService) calculateDistance(senderAddr, receiverAddr string) float64 {
-	// 简单的距离计算模拟（根据地址关键词判断）
-	if strings.Contains(senderAddr, "北京") && strings.Contains(receiverAddr, "北京") {
-		return 50 // 同城
-	} else if strings.Contains(senderAddr, "北京") || strings.Contains(receiverAddr, "北京") {
-		return 800 // 跨省
-	}
-	return 200 // 默认距离
-}
-
-func (ls *logisticsService) calculateBaseFee(distance, weight float64) float64 {
-	baseFee := 8.0 // 首重费用
-	if weight > 1.0 {
-		baseFee += (weight - 1.0) * 2.0 // 续重费用
-	}
-	if distance > 100 {
-		baseFee += (distance - 100) * 0.01 // 远距离加费
-	}
-	return baseFee
-}
-
-func (ls *logisticsService) getMethodMultiplier(method int32) float64 {
-	switch method {
-	case 2: // 急速配送
-		return 2.0
-	case 3: // 经济配送
-		return 0.8
-	default:
-		return 1.0
+// cancelWaybillQuietly 本地落库失败时撤销承运商运单，失败仅记录日志
+func (ls *logisticsService) cancelWaybillQuietly(ctx context.Context, c carrier.Carrier, trackingNumber string) {
+	if err := c.CancelWaybill(ctx, trackingNumber); err != nil {
+		log.WarnfC(ctx, "撤销承运商运单失败: 快递单号=%s, err=%v", trackingNumber, err)
 	}
 }
 
-func (ls *logisticsService) getEstimatedDays(method int32, distance float64) int32 {
-	baseDays := int32(1)
-	if distance > 500 {
-		baseDays = 3
-	} else if distance > 100 {
-		baseDays = 2
-	}
-	
-	switch method {
-	case 2: // 急速配送
-		return baseDays
-	case 3: // 经济配送
-		return baseDays + 2
-	default:
-		return baseDays + 1
-	}
-}
-
-// generateLogisticsTracks 异步生成模拟物流轨迹
-func (ls *logisticsService) generateLogisticsTracks(logisticsSn string) {
-	// 这是一个简化的轨迹生成，实际项目中可以使用更复杂的模拟逻辑
-	time.Sleep(2 * time.Hour) // 模拟2小时后到达集散中心
-	
-	// 可以在这里添加更多轨迹生成逻辑
-	log.Infof("异步生成物流轨迹: %s", logisticsSn)
-}
-
 // CancelLogisticsOrder 取消物流订单 - DTM分布式事务补偿方法
 func (ls *logisticsService) CancelLogisticsOrder(ctx context.Context, orderSn, reason string) error {
-	log.Infof("开始取消物流订单, 订单号: %s, 原因: %s", orderSn, reason)
+	log.InfofC(ctx, "开始取消物流订单, 订单号: %s, 原因: %s", orderSn, reason)
 	
 	// 查找物流订单
 	logisticsInfo, err := ls.data.LogisticsOrders().GetByOrderSn(ctx, ls.data.DB(), orderSn)
 	if err != nil {
-		log.Warnf("未找到物流订单 %s: %v", orderSn, err)
+		log.WarnfC(ctx, "未找到物流订单 %s: %v", orderSn, err)
 		// 对于补偿方法，如果记录不存在，不应该报错
 		return nil
 	}
@@ -576,17 +638,49 @@ func (ls *logisticsService) CancelLogisticsOrder(ctx context.Context, orderSn, r
 	// 检查当前状态是否允许取消
 	if logisticsInfo.LogisticsStatus == int32(do.LogisticsStatusShipped) ||
 	   logisticsInfo.LogisticsStatus == int32(do.LogisticsStatusDelivered) {
-		log.Warnf("物流订单 %s 状态为 %d，无法取消", orderSn, logisticsInfo.LogisticsStatus)
+		log.WarnfC(ctx, "物流订单 %s 状态为 %d，无法取消", orderSn, logisticsInfo.LogisticsStatus)
 		return errors.WithCode(code.ErrLogisticsCancelFailed, "物流订单已发货或已签收，无法取消")
 	}
+	if logisticsInfo.LogisticsStatus == int32(do.LogisticsStatusCanceled) {
+		return nil // 已取消，幂等性
+	}
+
+	// 通知承运商取消运单
+	if c, err := ls.carriers.Get(logisticsInfo.LogisticsCompany); err == nil && logisticsInfo.TrackingNumber != "" {
+		err = c.CancelWaybill(ctx, logisticsInfo.TrackingNumber)
+		switch {
+		case errors.Is(err, carrier.ErrCancelRejected):
+			log.WarnfC(ctx, "承运商拒绝取消运单 %s，快件可能已揽收", logisticsInfo.TrackingNumber)
+			return errors.WithCode(code.ErrLogisticsCancelFailed, "快件已揽收，无法取消")
+		case err != nil && !errors.Is(err, carrier.ErrWaybillNotFound):
+			log.ErrorfC(ctx, "承运商取消运单失败: %v", err)
+			return errors.WithCode(code.ErrLogisticsCompanyServiceUnavailable, "承运商取消运单失败")
+		}
+	}
 	
+	tx := ls.data.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			panic(r)
+		}
+	}()
+
 	// 更新状态为已取消
+	previous := logisticsInfo.LogisticsStatus
 	logisticsInfo.LogisticsStatus = int32(do.LogisticsStatusCanceled)
 	logisticsInfo.Remark = fmt.Sprintf("%s [补偿取消: %s]", logisticsInfo.Remark, reason)
 	
-	err = ls.data.LogisticsOrders().Update(ctx, ls.data.DB(), logisticsInfo)
+	err = ls.data.LogisticsOrders().Update(ctx, tx, logisticsInfo)
 	if err != nil {
-		log.Errorf("更新物流订单状态失败: %v", err)
+		tx.Rollback()
+		log.ErrorfC(ctx, "更新物流订单状态失败: %v", err)
+		return errors.WithCode(code.ErrLogisticsOrderUpdateFailed, "取消物流订单失败")
+	}
+
+	if err := ls.events.Add(ctx, tx, aggregateLogisticsOrder, logisticsInfo.LogisticsSn, EventLogisticsCancelled, newLogisticsEvent(logisticsInfo, previous)); err != nil {
+		tx.Rollback()
+		log.ErrorfC(ctx, "写入物流取消事件失败: 物流单号=%s, err=%v", logisticsInfo.LogisticsSn, err)
 		return errors.WithCode(code.ErrLogisticsOrderUpdateFailed, "取消物流订单失败")
 	}
 	
@@ -599,12 +693,16 @@ func (ls *logisticsService) CancelLogisticsOrder(ctx context.Context, orderSn, r
 		OperatorName: "系统自动",
 	}
 	
-	err = ls.data.LogisticsTracks().Create(ctx, ls.data.DB(), track)
+	err = ls.data.LogisticsTracks().Create(ctx, tx, track)
 	if err != nil {
-		log.Errorf("创建取消轨迹失败: %v", err)
+		log.ErrorfC(ctx, "创建取消轨迹失败: %v", err)
 		// 轨迹记录失败不影响主流程
 	}
+
+	if err := tx.Commit().Error; err != nil {
+		return errors.WithCode(code.ErrConnectDB, "提交事务失败")
+	}
 	
-	log.Infof("成功取消物流订单 %s", orderSn)
+	log.InfofC(ctx, "成功取消物流订单 %s", orderSn)
 	return nil
 }
\ No newline at end of file
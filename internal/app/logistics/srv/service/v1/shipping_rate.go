@@ -0,0 +1,224 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"emshop/internal/app/logistics/srv/domain/do"
+	"emshop/internal/app/logistics/srv/domain/dto"
+	"emshop/internal/app/logistics/srv/pkg/rate"
+	"emshop/internal/app/pkg/code"
+	"emshop/pkg/errors"
+	"emshop/pkg/log"
+)
+
+// rateEngineRefreshInterval 生效配置的检查间隔，多副本部署时其他副本在此间隔内感知配置变更
+const rateEngineRefreshInterval = 30 * time.Second
+
+// rateEngineCache 运费计算引擎缓存，按生效版本重建
+type rateEngineCache struct {
+	mu        sync.RWMutex
+	engine    *rate.Engine
+	checkedAt time.Time
+}
+
+// invalidate 使缓存失效，下次计算时重新加载生效配置
+func (c *rateEngineCache) invalidate() {
+	c.mu.Lock()
+	c.checkedAt = time.Time{}
+	c.mu.Unlock()
+}
+
+// rateEngine 获取当前生效的运费计算引擎
+func (ls *logisticsService) rateEngine(ctx context.Context) *rate.Engine {
+	c := ls.rates
+	c.mu.RLock()
+	engine, checkedAt := c.engine, c.checkedAt
+	c.mu.RUnlock()
+	if engine != nil && time.Since(checkedAt) < rateEngineRefreshInterval {
+		return engine
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.engine != nil && time.Since(c.checkedAt) < rateEngineRefreshInterval {
+		return c.engine
+	}
+	c.checkedAt = time.Now()
+
+	active, err := ls.data.ShippingRateConfigs().GetActive(ctx, ls.data.DB())
+	switch {
+	case err != nil && errors.IsCode(err, code.ErrShippingRateConfigNotFound):
+		if c.engine == nil || c.engine.Version() != 0 {
+			c.engine, _ = rate.NewEngine(rate.DefaultConfig(), 0)
+			log.InfofC(ctx, "暂无生效的运费配置，使用内置默认配置")
+		}
+		return c.engine
+	case err != nil:
+		log.WarnfC(ctx, "加载生效运费配置失败，沿用当前配置: %v", err)
+		if c.engine == nil {
+			c.engine, _ = rate.NewEngine(rate.DefaultConfig(), 0)
+		}
+		return c.engine
+	}
+
+	if c.engine != nil && c.engine.Version() == active.Version {
+		return c.engine
+	}
+
+	cfg, err := rate.Parse([]byte(active.Content))
+	if err == nil {
+		var next *rate.Engine
+		if next, err = rate.NewEngine(cfg, active.Version); err == nil {
+			log.InfofC(ctx, "运费配置已切换到版本 %d", active.Version)
+			c.engine = next
+			return c.engine
+		}
+	}
+	log.ErrorfC(ctx, "运费配置版本 %d 无效，沿用当前配置: %v", active.Version, err)
+	if c.engine == nil {
+		c.engine, _ = rate.NewEngine(rate.DefaultConfig(), 0)
+	}
+	return c.engine
+}
+
+// ListShippingRateConfigs 分页查询运费配置版本
+func (ls *logisticsService) ListShippingRateConfigs(ctx context.Context, page, pageSize int) (*dto.ShippingRateConfigListDTO, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	configs, total, err := ls.data.ShippingRateConfigs().List(ctx, ls.data.DB(), (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*dto.ShippingRateConfigDTO, len(configs))
+	for i, cfg := range configs {
+		items[i] = toShippingRateConfigDTO(cfg)
+	}
+	return &dto.ShippingRateConfigListDTO{Total: total, Items: items}, nil
+}
+
+// GetShippingRateConfig 查询运费配置，version为0时返回当前生效配置
+func (ls *logisticsService) GetShippingRateConfig(ctx context.Context, version int32) (*dto.ShippingRateConfigDTO, error) {
+	if version > 0 {
+		cfg, err := ls.data.ShippingRateConfigs().GetByVersion(ctx, ls.data.DB(), version)
+		if err != nil {
+			return nil, err
+		}
+		return toShippingRateConfigDTO(cfg), nil
+	}
+
+	cfg, err := ls.data.ShippingRateConfigs().GetActive(ctx, ls.data.DB())
+	if err == nil {
+		return toShippingRateConfigDTO(cfg), nil
+	}
+	if !errors.IsCode(err, code.ErrShippingRateConfigNotFound) {
+		return nil, err
+	}
+
+	// 尚无生效版本时返回内置默认配置，便于管理端以此为模板编辑
+	content, _ := json.Marshal(rate.DefaultConfig())
+	return &dto.ShippingRateConfigDTO{
+		Version: 0,
+		Content: string(content),
+		Status:  int32(do.RateConfigStatusActive),
+		Remark:  "内置默认配置",
+	}, nil
+}
+
+// CreateShippingRateConfig 创建新的运费配置版本
+func (ls *logisticsService) CreateShippingRateConfig(ctx context.Context, req *dto.CreateShippingRateConfigDTO) (*dto.ShippingRateConfigDTO, error) {
+	if _, err := rate.Parse([]byte(req.Content)); err != nil {
+		return nil, errors.WithCode(code.ErrShippingRateConfigInvalid, "%v", err)
+	}
+
+	tx := ls.data.Begin()
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			panic(r)
+		}
+	}()
+
+	maxVersion, err := ls.data.ShippingRateConfigs().MaxVersion(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	cfg := &do.ShippingRateConfigDO{
+		Version:  maxVersion + 1,
+		Content:  req.Content,
+		Status:   int32(do.RateConfigStatusDraft),
+		Operator: req.Operator,
+		Remark:   req.Remark,
+	}
+	if err := ls.data.ShippingRateConfigs().Create(ctx, tx, cfg); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if req.Activate {
+		now := time.Now()
+		if err := ls.data.ShippingRateConfigs().Activate(ctx, tx, cfg.Version, now); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		cfg.Status = int32(do.RateConfigStatusActive)
+		cfg.ActivatedAt = &now
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return nil, errors.WithCode(code.ErrConnectDB, "提交事务失败")
+	}
+
+	if req.Activate {
+		ls.rates.invalidate()
+	}
+	log.InfofC(ctx, "创建运费配置版本 %d, 操作人=%s, 立即生效=%v", cfg.Version, req.Operator, req.Activate)
+	return toShippingRateConfigDTO(cfg), nil
+}
+
+// ActivateShippingRateConfig 生效指定运费配置版本（也用于回滚到历史版本）
+func (ls *logisticsService) ActivateShippingRateConfig(ctx context.Context, version int32, operator string) error {
+	cfg, err := ls.data.ShippingRateConfigs().GetByVersion(ctx, ls.data.DB(), version)
+	if err != nil {
+		return err
+	}
+	if _, err := rate.Parse([]byte(cfg.Content)); err != nil {
+		return errors.WithCode(code.ErrShippingRateConfigInvalid, "%v", err)
+	}
+
+	tx := ls.data.Begin()
+	if err := ls.data.ShippingRateConfigs().Activate(ctx, tx, version, time.Now()); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return errors.WithCode(code.ErrConnectDB, "提交事务失败")
+	}
+
+	ls.rates.invalidate()
+	log.InfofC(ctx, "运费配置版本 %d 已生效, 操作人=%s", version, operator)
+	return nil
+}
+
+func toShippingRateConfigDTO(cfg *do.ShippingRateConfigDO) *dto.ShippingRateConfigDTO {
+	return &dto.ShippingRateConfigDTO{
+		ID:          cfg.ID,
+		Version:     cfg.Version,
+		Content:     cfg.Content,
+		Status:      cfg.Status,
+		Operator:    cfg.Operator,
+		Remark:      cfg.Remark,
+		CreatedAt:   cfg.CreatedAt,
+		ActivatedAt: cfg.ActivatedAt,
+	}
+}
@@ -1,15 +1,17 @@
 package srv
 
 import (
-	"github.com/hashicorp/consul/api"
+	"emshop/gin-micro/core/trace"
 	"emshop/internal/app/logistics/srv/config"
+	datav1 "emshop/internal/app/logistics/srv/data/v1"
+	service "emshop/internal/app/logistics/srv/service/v1"
 	"emshop/internal/app/pkg/options"
+	"emshop/internal/app/pkg/discovery"
 	gapp "emshop/gin-micro/app"
 	"emshop/pkg/app"
 	"emshop/pkg/log"
 
 	"emshop/gin-micro/registry"
-	"emshop/gin-micro/registry/consul"
 )
 
 func NewApp(basename string) *app.App {
@@ -24,26 +26,10 @@ func NewApp(basename string) *app.App {
 }
 
 func NewRegistrar(registry *options.RegistryOptions, dev bool) registry.Registrar {
-    c := api.DefaultConfig()
-    c.Address = registry.Address
-    c.Scheme = registry.Scheme
-    cli, err := api.NewClient(c)
+    r, err := discovery.NewRegistrar(registry, dev)
     if err != nil {
         panic(err)
     }
-    opts := []consul.Option{consul.WithHealthCheck(true)}
-    if registry.HealthCheckInterval > 0 {
-        opts = append(opts, consul.WithHealthCheckInterval(registry.HealthCheckInterval))
-    }
-    if registry.CheckTimeout > 0 {
-        opts = append(opts, consul.WithCheckTimeout(registry.CheckTimeout))
-    }
-    if dev {
-        opts = append(opts, consul.WithDeregisterCriticalServiceAfter(60))
-    } else if registry.DeregisterCriticalAfter > 0 {
-        opts = append(opts, consul.WithDeregisterCriticalServiceAfter(registry.DeregisterCriticalAfter))
-    }
-    r := consul.New(cli, opts...)
     return r
 }
 
@@ -55,17 +41,58 @@ func NewLogisticsApp(cfg *config.Config) (*gapp.App, error) {
 	//服务注册
     register := NewRegistrar(cfg.Registry, cfg.Log.Development)
 
+	//初始化open-telemetry的exporter
+	trace.InitAgent(trace.Options{
+		Name:     cfg.Telemetry.Name,
+		Endpoint: cfg.Telemetry.Endpoint,
+		Sampler:  cfg.Telemetry.Sampler,
+		Batcher:  cfg.Telemetry.Batcher,
+	})
+
+	// 创建数据工厂管理器
+	factoryManager, err := datav1.NewFactoryManager(cfg.MySQLOptions)
+	if err != nil {
+		log.Fatal(err.Error())
+		return nil, err
+	}
+
+	// 创建业务服务层
+	carriers := NewCarrierRegistry(cfg.Carrier)
+	events, outboxWorker, err := cfg.Outbox.NewWorker(factoryManager.GetDataFactory().DB())
+	if err != nil {
+		return nil, err
+	}
+	logisticsSrv := service.NewLogisticsService(factoryManager.GetDataFactory(), cfg.Redis, carriers, events)
+
 	//生成rpc服务
-	rpcServer, err := NewLogisticsRPCServer(cfg)
+	rpcServer, err := NewLogisticsRPCServer(cfg, logisticsSrv)
 	if err != nil {
 		return nil, err
 	}
 
-	return gapp.New(
+	//生成http服务，接收承运商推送
+	httpServer := NewLogisticsHTTPServer(cfg, logisticsSrv)
+
+	opts := []gapp.Option{
 		gapp.WithName(cfg.Server.Name),
+		gapp.WithVersion(cfg.Server.Version),
+		gapp.WithMetadata(cfg.Server.Metadata),
 		gapp.WithRPCServer(rpcServer),
+		gapp.WithRestServer(httpServer),
 		gapp.WithRegistrar(register),
-	), nil
+	}
+
+	// 物流事件投递器随应用启停
+	if outboxWorker != nil {
+		opts = append(opts, gapp.WithServer(outboxWorker))
+	}
+
+	// 不支持推送的承运商轨迹由延时任务周期轮询，随应用启停
+	if scheduler := NewTrackPollScheduler(cfg.Redis, cfg.DelayJob, logisticsSrv, cfg.Carrier); scheduler != nil {
+		opts = append(opts, gapp.WithServer(scheduler))
+	}
+
+	return gapp.New(opts...), nil
 }
 
 func run(cfg *config.Config) app.RunFunc {
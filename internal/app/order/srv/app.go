@@ -3,16 +3,15 @@ package srv
 import (
     gapp "emshop/gin-micro/app"
     "emshop/gin-micro/registry"
-    "emshop/gin-micro/registry/consul"
     rpcserver "emshop/gin-micro/server/rpc-server"
     "emshop/gin-micro/server/rpc-server/selector"
     "emshop/gin-micro/server/rpc-server/selector/p2c"
     "emshop/internal/app/order/srv/config"
     "emshop/internal/app/pkg/options"
+    "emshop/internal/app/pkg/discovery"
     "emshop/pkg/app"
     "emshop/pkg/log"
 
-	"github.com/hashicorp/consul/api"
 )
 
 func NewApp(basename string) *app.App {
@@ -27,19 +26,10 @@ func NewApp(basename string) *app.App {
 }
 
 func NewRegistrar(registry *options.RegistryOptions, dev bool) registry.Registrar {
-    c := api.DefaultConfig()
-    c.Address = registry.Address
-    c.Scheme = registry.Scheme
-    cli, err := api.NewClient(c)
+    r, err := discovery.NewRegistrar(registry, dev)
     if err != nil {
         panic(err)
     }
-    // 默认启用健康检查；开发环境缩短严重错误注销时间以加速剔除失效实例
-    opts := []consul.Option{consul.WithHealthCheck(true)}
-    if dev {
-        opts = append(opts, consul.WithDeregisterCriticalServiceAfter(60))
-    }
-    r := consul.New(cli, opts...)
     return r
 }
 
@@ -56,16 +46,23 @@ func NeworderApp(cfg *config.Config) (*gapp.App, error) {
     register := NewRegistrar(cfg.Registry, cfg.Log.Development)
 
 	//生成rpc服务
-	rpcServer, err := NewOrderRPCServer(cfg)
+	rpcServer, outboxWorker, err := NewOrderRPCServer(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	return gapp.New(
+	opts := []gapp.Option{
 		gapp.WithName(cfg.Server.Name),
+		gapp.WithVersion(cfg.Server.Version),
+		gapp.WithMetadata(cfg.Server.Metadata),
 		gapp.WithRPCServer(rpcServer),
 		gapp.WithRegistrar(register),
-	), nil
+	}
+	// 发件箱投递器随应用启停，退出时投递完当前批次再关闭生产者
+	if outboxWorker != nil {
+		opts = append(opts, gapp.WithServer(outboxWorker))
+	}
+	return gapp.New(opts...), nil
 }
 
 func run(cfg *config.Config) app.RunFunc {
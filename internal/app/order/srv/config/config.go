@@ -15,6 +15,7 @@ type Config struct {
 	Telemetry    *options.TelemetryOptions `json:"telemetry" mapstructure:"telemetry"`
 	Registry     *options.RegistryOptions  `json:"consul" mapstructure:"consul"`
 	Dtm          *options.DtmOptions       `json:"dtm" mapstructure:"dtm"` // 分布式事务
+	Outbox       *options.OutboxOptions    `json:"outbox" mapstructure:"outbox"`
 }
 
 func New() *Config {
@@ -26,6 +27,7 @@ func New() *Config {
         Telemetry:    options.NewTelemetryOptions(),
         Registry:     options.NewRegistryOptions(),
         Dtm:          options.NewDtmOptions(),
+        Outbox:       options.NewOutboxOptions(),
     }
 }
 
@@ -36,6 +38,7 @@ func (o *Config) Flags() (fss cliflag.NamedFlagSets) {
 	o.Telemetry.AddFlags(fss.FlagSet("telemetry"))
 	o.Registry.AddFlags(fss.FlagSet("registry"))
 	o.MySQLOptions.AddFlags(fss.FlagSet("mysql"))
+	o.Outbox.AddFlags(fss.FlagSet("outbox"))
 	return fss
 }
 
@@ -53,5 +56,6 @@ func (o *Config) Validate() []error {
 	errs = append(errs, o.Server.Validate()...)
 	errs = append(errs, o.Telemetry.Validate()...)
 	errs = append(errs, o.Registry.Validate()...)
+	errs = append(errs, o.Outbox.Validate()...)
 	return errs
 }
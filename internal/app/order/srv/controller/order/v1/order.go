@@ -2,6 +2,7 @@ package order
 
 import (
 	"context"
+	"time"
 	"google.golang.org/protobuf/types/known/emptypb"
 	pb "emshop/api/order/v1"
 	"emshop/internal/app/order/srv/domain/do"
@@ -202,7 +203,7 @@ func (os *orderServer) SubmitOrder(ctx context.Context, request *pb.OrderRequest
 	}
 	err := os.srv.Orders().Submit(ctx, &orderDTO)
 	if err != nil {
-		log.Errorf("新建订单失败: %v", err)
+		log.ErrorfC(ctx, "新建订单失败: %v", err)
 		return nil, err
 	}
 	//另外一款解决ioc的库，wire
@@ -251,6 +252,32 @@ func (os *orderServer) OrderList(ctx context.Context, request *pb.OrderFilterReq
 	return response, nil
 }
 
+// GetDailyOrderStats 按天统计订单数与金额
+func (os *orderServer) GetDailyOrderStats(ctx context.Context, request *pb.DailyOrderStatsRequest) (*pb.DailyOrderStatsResponse, error) {
+	stats, err := os.srv.Orders().DailyStats(ctx, time.Unix(request.Start, 0), time.Unix(request.End, 0))
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*pb.DailyOrderStat, 0, len(stats))
+	for _, s := range stats {
+		items = append(items, &pb.DailyOrderStat{Date: s.Date, OrderCount: s.OrderCount, Amount: s.Amount})
+	}
+	return &pb.DailyOrderStatsResponse{Items: items}, nil
+}
+
+// ListOrderedUserIds 查询指定时间后下过单的用户ID
+func (os *orderServer) ListOrderedUserIds(ctx context.Context, request *pb.ListOrderedUserIdsRequest) (*pb.OrderedUserIdsResponse, error) {
+	var since time.Time
+	if request.Since > 0 {
+		since = time.Unix(request.Since, 0)
+	}
+	userIDs, err := os.srv.Orders().ListOrderedUserIDs(ctx, since, request.AfterUserId, int(request.Limit))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.OrderedUserIdsResponse{UserIds: userIDs}, nil
+}
+
 func (os *orderServer) OrderDetail(ctx context.Context, request *pb.OrderRequest) (*pb.OrderInfoDetailResponse, error) {
 	// 处理 OrderSn 参数
 	var orderSn string
@@ -309,11 +336,11 @@ func (os *orderServer) UpdateOrderStatus(ctx context.Context, status *pb.OrderSt
 func (os *orderServer) UpdatePaymentStatus(ctx context.Context, request *pb.UpdatePaymentStatusRequest) (*emptypb.Empty, error) {
 	err := os.srv.Orders().UpdatePaidStatus(ctx, request.OrderSn, request.PaymentSn)
 	if err != nil {
-		log.Errorf("更新订单支付状态失败: %v", err)
+		log.ErrorfC(ctx, "更新订单支付状态失败: %v", err)
 		return nil, err
 	}
 	
-	log.Infof("成功更新订单支付状态, 订单号: %s, 支付单号: %s", request.OrderSn, request.PaymentSn)
+	log.InfofC(ctx, "成功更新订单支付状态, 订单号: %s, 支付单号: %s", request.OrderSn, request.PaymentSn)
 	return &emptypb.Empty{}, nil
 }
 
@@ -321,12 +348,87 @@ func (os *orderServer) UpdatePaymentStatus(ctx context.Context, request *pb.Upda
 func (os *orderServer) RevertPaymentStatus(ctx context.Context, request *pb.RevertPaymentStatusRequest) (*emptypb.Empty, error) {
 	err := os.srv.Orders().RevertPaidStatus(ctx, request.OrderSn)
 	if err != nil {
-		log.Errorf("回滚订单支付状态失败: %v", err)
+		log.ErrorfC(ctx, "回滚订单支付状态失败: %v", err)
 		return nil, err
 	}
 	
-	log.Infof("成功回滚订单支付状态, 订单号: %s", request.OrderSn)
+	log.InfofC(ctx, "成功回滚订单支付状态, 订单号: %s", request.OrderSn)
 	return &emptypb.Empty{}, nil
 }
 
+// CreateFlashSaleOrder 冻结库存并以秒杀价创建待支付订单
+func (os *orderServer) CreateFlashSaleOrder(ctx context.Context, request *pb.FlashSaleOrderRequest) (*pb.OrderInfoResponse, error) {
+	req := request.GetOrder()
+	if req == nil {
+		req = &pb.OrderRequest{}
+	}
+	orderGoods := make([]*do.OrderGoodsDO, len(req.OrderItems))
+	salePrices := make(map[int32]float32, len(req.OrderItems))
+	for i, item := range req.OrderItems {
+		orderGoods[i] = &do.OrderGoodsDO{
+			Goods: item.GoodsId,
+			Nums:  item.Nums,
+		}
+		salePrices[item.GoodsId] = request.SalePrice
+	}
+
+	orderInfo := do.OrderInfoDO{
+		User:        req.UserId,
+		OrderGoods:  orderGoods,
+		FlashSaleID: request.FlashSaleId,
+	}
+	if request.PayDeadline > 0 {
+		deadline := time.Unix(request.PayDeadline, 0)
+		orderInfo.PayDeadline = &deadline
+	}
+	if req.Address != nil {
+		orderInfo.Address = *req.Address
+	}
+	if req.Name != nil {
+		orderInfo.SignerName = *req.Name
+	}
+	if req.Mobile != nil {
+		orderInfo.SingerMobile = *req.Mobile
+	}
+	if req.Post != nil {
+		orderInfo.Post = *req.Post
+	}
+	if req.OrderSn != nil {
+		orderInfo.OrderSn = *req.OrderSn
+	}
+
+	order := &dto.OrderDTO{
+		OrderInfoDO: orderInfo,
+		SalePrices:  salePrices,
+	}
+	if err := os.srv.Orders().CreateFlashSaleOrder(ctx, order); err != nil {
+		log.ErrorfC(ctx, "创建秒杀订单失败: %v", err)
+		return nil, err
+	}
+
+	return &pb.OrderInfoResponse{
+		Id:      int32(order.ID),
+		UserId:  order.User,
+		OrderSn: order.OrderSn,
+		PayType: order.PayType,
+		Status:  order.Status,
+		Post:    order.Post,
+		Total:   order.OrderMount,
+		Address: order.Address,
+		Name:    order.SignerName,
+		Mobile:  order.SingerMobile,
+		AddTime: order.CreatedAt.Format("2006-01-02 15:04:05"),
+	}, nil
+}
+
+// CloseFlashSaleOrder 关闭超时未支付的秒杀订单
+func (os *orderServer) CloseFlashSaleOrder(ctx context.Context, request *pb.CloseFlashSaleOrderRequest) (*pb.CloseFlashSaleOrderResponse, error) {
+	paid, err := os.srv.Orders().CloseFlashSaleOrder(ctx, request.OrderSn)
+	if err != nil {
+		log.ErrorfC(ctx, "关闭秒杀订单失败: %v", err)
+		return nil, err
+	}
+	return &pb.CloseFlashSaleOrderResponse{Paid: paid}, nil
+}
+
 var _ pb.OrderServer = &orderServer{}
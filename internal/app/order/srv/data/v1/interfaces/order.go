@@ -4,6 +4,7 @@ import (
 	"context"
 	"emshop/internal/app/order/srv/domain/do"
 	metav1 "emshop/pkg/common/meta/v1"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -17,4 +18,10 @@ type OrderStore interface {
 	Create(ctx context.Context, db *gorm.DB, order *do.OrderInfoDO) error
 
 	Update(ctx context.Context, db *gorm.DB, order *do.OrderInfoDO) error
+
+	// ListUserIDsSince 查询since之后下过单的用户ID，按用户ID升序返回afterUserID之后的limit个
+	ListUserIDsSince(ctx context.Context, db *gorm.DB, since time.Time, afterUserID int32, limit int) ([]int32, error)
+
+	// DailyStats 按下单日期统计[start, end)内未关闭订单的数量与金额
+	DailyStats(ctx context.Context, db *gorm.DB, start, end time.Time) ([]*do.OrderDailyStat, error)
 }
\ No newline at end of file
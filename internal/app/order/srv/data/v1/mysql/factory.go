@@ -5,6 +5,7 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"log"
+	"emshop/gin-micro/core/health"
 	proto "emshop/api/goods/v1"
 	proto2 "emshop/api/inventory/v1"
 	"emshop/internal/app/order/srv/data/v1/interfaces"
@@ -141,6 +142,7 @@ func NewMySQLFactory(mysqlOpts *options.MySQLOptions, goodsClient proto.GoodsCli
 		sqlDB.SetMaxOpenConns(mysqlOpts.MaxOpenConnections)
 		sqlDB.SetMaxIdleConns(mysqlOpts.MaxIdleConnections)
 		sqlDB.SetConnMaxLifetime(mysqlOpts.MaxConnectionLifetime)
+		health.Register("mysql", health.PingCheck(sqlDB))
 	})
 
 	if factory == nil || err != nil {
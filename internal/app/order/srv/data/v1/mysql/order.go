@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"context"
+	"time"
 	code2 "emshop/gin-micro/code"
 	"emshop/pkg/errors"
 
@@ -68,4 +69,31 @@ func (o *orders) Update(ctx context.Context, db *gorm.DB, order *do.OrderInfoDO)
 	return db.Model(order).Save(order).Error
 }
 
-var _ interfaces.OrderStore = &orders{}
\ No newline at end of file
+var _ interfaces.OrderStore = &orders{}
+
+func (o *orders) ListUserIDsSince(ctx context.Context, db *gorm.DB, since time.Time, afterUserID int32, limit int) ([]int32, error) {
+	query := db.WithContext(ctx).Model(&do.OrderInfoDO{}).Where("user > ?", afterUserID)
+	if !since.IsZero() {
+		query = query.Where("add_time >= ?", since)
+	}
+
+	var userIDs []int32
+	if err := query.Distinct().Order("user ASC").Limit(limit).Pluck("user", &userIDs).Error; err != nil {
+		return nil, errors.WithCode(code2.ErrDatabase, "%s", err.Error())
+	}
+	return userIDs, nil
+}
+
+func (o *orders) DailyStats(ctx context.Context, db *gorm.DB, start, end time.Time) ([]*do.OrderDailyStat, error) {
+	var stats []*do.OrderDailyStat
+	err := db.WithContext(ctx).Model(&do.OrderInfoDO{}).
+		Select("DATE_FORMAT(add_time, '%Y-%m-%d') AS date, COUNT(*) AS order_count, COALESCE(SUM(order_mount), 0) AS amount").
+		Where("add_time >= ? AND add_time < ? AND status <> ?", start, end, "TRADE_CLOSED").
+		Group("date").
+		Order("date ASC").
+		Scan(&stats).Error
+	if err != nil {
+		return nil, errors.WithCode(code2.ErrDatabase, "%s", err.Error())
+	}
+	return stats, nil
+}
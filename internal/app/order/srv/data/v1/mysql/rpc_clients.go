@@ -6,15 +6,16 @@ import (
 
 	gpbv1 "emshop/api/goods/v1"
 	proto "emshop/api/inventory/v1"
-	"emshop/gin-micro/registry/consul"
 	"emshop/gin-micro/server/rpc-server"
 	"emshop/gin-micro/server/rpc-server/client-interceptors"
+	"emshop/gin-micro/server/rpc-server/selector"
 	"emshop/internal/app/pkg/options"
+	"emshop/internal/app/pkg/discovery"
 
-	cosulAPI "github.com/hashicorp/consul/api"
 
 	"emshop/gin-micro/registry"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 )
 
 const (
@@ -22,32 +23,80 @@ const (
 	ginvserviceName  = "discovery:///emshop-inventory-srv"
 )
 
+// 下单链路的重试预算：重试不超过请求量的 20%，每秒保底 5 次
+var orderRetryBudget = clientinterceptors.NewRetryBudget(0.2, 5)
+
+// goodsCallPolicies 商品查询是只读调用，瞬时失败时重试，慢节点时对冲到其他节点
+func goodsCallPolicies() *clientinterceptors.CallPolicies {
+	read := &clientinterceptors.MethodPolicy{
+		ReadOnly: true,
+		Retry: &clientinterceptors.RetryPolicy{
+			MaxAttempts:    3,
+			RetryableCodes: []codes.Code{codes.Unavailable, codes.ResourceExhausted},
+			InitialBackoff: 50 * time.Millisecond,
+			MaxBackoff:     500 * time.Millisecond,
+			Multiplier:     2,
+		},
+		Hedge: &clientinterceptors.HedgePolicy{
+			MaxAttempts:   2,
+			Delay:         200 * time.Millisecond,
+			NonFatalCodes: []codes.Code{codes.Unavailable},
+		},
+	}
+	return &clientinterceptors.CallPolicies{
+		Methods: map[string]*clientinterceptors.MethodPolicy{
+			gpbv1.Goods_BatchGetGoods_FullMethodName:  read,
+			gpbv1.Goods_GetGoodsDetail_FullMethodName: read,
+		},
+		Budget: orderRetryBudget,
+	}
+}
+
+// inventoryCallPolicies 库存查询可重试；扣减、归还等写操作不是幂等的，不配置重试
+func inventoryCallPolicies() *clientinterceptors.CallPolicies {
+	return &clientinterceptors.CallPolicies{
+		Methods: map[string]*clientinterceptors.MethodPolicy{
+			proto.Inventory_InvDetail_FullMethodName: {
+				ReadOnly: true,
+				Retry: &clientinterceptors.RetryPolicy{
+					MaxAttempts:    3,
+					RetryableCodes: []codes.Code{codes.Unavailable},
+					InitialBackoff: 50 * time.Millisecond,
+					MaxBackoff:     500 * time.Millisecond,
+					Multiplier:     2,
+				},
+			},
+		},
+		Budget: orderRetryBudget,
+	}
+}
+
 func NewDiscovery(opts *options.RegistryOptions) registry.Discovery {
-	c := cosulAPI.DefaultConfig()
-	c.Address = opts.Address
-	c.Scheme = opts.Scheme
-	cli, err := cosulAPI.NewClient(c)
+	r, err := discovery.NewDiscovery(opts)
 	if err != nil {
 		panic(err)
 	}
-	r := consul.New(cli, consul.WithHealthCheck(true))
 	return r
 }
 
 func GetGoodsClient(opts *options.RegistryOptions) gpbv1.GoodsClient {
 	discovery := NewDiscovery(opts)
-	goodsClient := NewGoodsServiceClient(discovery)
+	goodsClient := NewGoodsServiceClient(discovery, opts.Routing.NodeFilters()...)
 	return goodsClient
 }
 
-func NewGoodsServiceClient(r registry.Discovery) gpbv1.GoodsClient {
+func NewGoodsServiceClient(r registry.Discovery, filters ...selector.NodeFilter) gpbv1.GoodsClient {
 	conn, err := rpcserver.DialInsecure(
 		context.Background(),
 		rpcserver.WithBalancerName("p2c"),
 		rpcserver.WithEndpoint(goodsserviceName),
+		rpcserver.WithHealthCheck(),
 		rpcserver.WithDiscovery(r),
+		rpcserver.WithNodeFilter(filters...),
 		rpcserver.WithClientTimeout(10*time.Second),
 		rpcserver.WithClientOptions(grpc.WithNoProxy()),
+		rpcserver.WithCallPolicies(goodsCallPolicies()),
+		rpcserver.WithCircuitBreaker(selector.DefaultBreakerOptions()),
 		rpcserver.WithClientUnaryInterceptor(clientinterceptors.UnaryTracingInterceptor),
 	)
 	if err != nil {
@@ -59,17 +108,21 @@ func NewGoodsServiceClient(r registry.Discovery) gpbv1.GoodsClient {
 
 func GetInventoryClient(opts *options.RegistryOptions) proto.InventoryClient {
 	discovery := NewDiscovery(opts)
-	invClient := NewInventoryServiceClient(discovery)
+	invClient := NewInventoryServiceClient(discovery, opts.Routing.NodeFilters()...)
 	return invClient
 }
 
-func NewInventoryServiceClient(r registry.Discovery) proto.InventoryClient {
+func NewInventoryServiceClient(r registry.Discovery, filters ...selector.NodeFilter) proto.InventoryClient {
 	conn, err := rpcserver.DialInsecure(
 		context.Background(),
 		rpcserver.WithBalancerName("p2c"),
 		rpcserver.WithEndpoint(ginvserviceName),
+		rpcserver.WithHealthCheck(),
 		rpcserver.WithDiscovery(r),
+		rpcserver.WithNodeFilter(filters...),
 		rpcserver.WithClientOptions(grpc.WithNoProxy()),
+		rpcserver.WithCallPolicies(inventoryCallPolicies()),
+		rpcserver.WithCircuitBreaker(selector.DefaultBreakerOptions()),
 		rpcserver.WithClientUnaryInterceptor(clientinterceptors.UnaryTracingInterceptor),
 	)
 	if err != nil {
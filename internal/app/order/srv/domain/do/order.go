@@ -39,6 +39,10 @@ type OrderInfoDO struct {
 	PaymentSn     string      `gorm:"type:varchar(64);index;comment:支付单号"`
 	PaidAt        *time.Time  `gorm:"type:timestamp;comment:支付时间"`
 
+	// 商品秒杀订单
+	FlashSaleID int64      `gorm:"type:bigint;default:0;index;comment:秒杀活动ID,普通订单为0"`
+	PayDeadline *time.Time `gorm:"type:datetime;comment:秒杀订单支付截止时间"`
+
 	Address      string  `gorm:"type:varchar(100)"`
 	SignerName   string  `gorm:"type:varchar(20)"`
 	SingerMobile string  `gorm:"type:varchar(11)"`
@@ -67,6 +71,13 @@ func (OrderGoodsDO) TableName() string {
 	return "ordergoods"
 }
 
+// OrderDailyStat 按天统计的订单数与金额
+type OrderDailyStat struct {
+	Date       string
+	OrderCount int64
+	Amount     float64
+}
+
 type OrderInfoDOList struct {
 	TotalCount int64          `json:"totalCount,omitempty"`
 	Items      []*OrderInfoDO `json:"items"`
@@ -4,6 +4,9 @@ import "emshop/internal/app/order/srv/domain/do"
 
 type OrderDTO struct {
 	do.OrderInfoDO
+
+	// SalePrices 秒杀商品价格，创建订单时替代商品售价
+	SalePrices map[int32]float32 `json:"-"`
 }
 
 type OrderDTOList struct {
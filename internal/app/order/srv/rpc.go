@@ -13,9 +13,11 @@ import (
     v13 "emshop/internal/app/order/srv/service/v1"
 
     "emshop/pkg/log"
+    "emshop/pkg/outbox"
 )
 
-func NewOrderRPCServer(cfg *config.Config) (*rpcserver.Server, error) {
+// NewOrderRPCServer 创建订单rpc服务，启用发件箱时一并返回需随应用启停的投递Worker
+func NewOrderRPCServer(cfg *config.Config) (*rpcserver.Server, *outbox.Worker, error) {
 	//初始化open-telemetry的exporter
 	trace.InitAgent(trace.Options{
 		cfg.Telemetry.Name,
@@ -29,15 +31,27 @@ func NewOrderRPCServer(cfg *config.Config) (*rpcserver.Server, error) {
 		log.Fatal(err.Error())
 	}
 
-    orderSrvFactory := v13.NewService(factoryManager.GetDataFactory(), cfg.Dtm, cfg.Registry)
+	events, worker, err := cfg.Outbox.NewWorker(factoryManager.GetDataFactory().DB())
+	if err != nil {
+		return nil, nil, err
+	}
+
+    orderSrvFactory := v13.NewService(factoryManager.GetDataFactory(), cfg.Dtm, cfg.Registry, events)
 	orderServer := order.NewOrderServer(orderSrvFactory)
 	rpcAddr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
+	grpcOpts, err := cfg.Server.GRPCServerOptions()
+	if err != nil {
+		return nil, nil, err
+	}
     grpcServer := rpcserver.NewServer(
         rpcserver.WithAddress(rpcAddr),
         rpcserver.WithMetrics(cfg.Server.EnableMetrics),
         // Saga下单会级联调用库存/商品，适当放宽超时
         rpcserver.WithTimeout(15*time.Second),
+        rpcserver.WithOptions(grpcOpts...),
+        rpcserver.WithAdaptiveLimit(cfg.Server.Limiter.AdaptiveLimiter(), nil),
+        rpcserver.WithLogAdmin(cfg.Server.LogAdminSecret),
     )
 	gpb.RegisterOrderServer(grpcServer.Server, orderServer)
-	return grpcServer, nil
+	return grpcServer, worker, nil
 }
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"time"
+
+	"emshop/internal/app/order/srv/domain/do"
+)
+
+// 订单领域事件，经发件箱投递，消费方按事件ID去重
+const (
+	aggregateOrder = "order"
+
+	EventOrderCreated         = "order.created"          // 订单已创建
+	EventOrderPaid            = "order.paid"             // 订单已支付
+	EventOrderPaymentReverted = "order.payment_reverted" // 支付回滚，订单关闭
+	EventOrderClosed          = "order.closed"           // 未支付订单超时关闭
+)
+
+// OrderEvent 订单事件内容，携带变更后的状态
+type OrderEvent struct {
+	OrderSn       string    `json:"order_sn"`
+	UserID        int32     `json:"user_id"`
+	Status        string    `json:"status"`
+	PaymentStatus int32     `json:"payment_status"`
+	PaymentSn     string    `json:"payment_sn,omitempty"`
+	Amount        float32   `json:"amount"`
+	FlashSaleID   int64     `json:"flash_sale_id,omitempty"`
+	OccurredAt    time.Time `json:"occurred_at"`
+}
+
+func newOrderEvent(order *do.OrderInfoDO) *OrderEvent {
+	return &OrderEvent{
+		OrderSn:       order.OrderSn,
+		UserID:        order.User,
+		Status:        order.Status,
+		PaymentStatus: order.PaymentStatus,
+		PaymentSn:     order.PaymentSn,
+		Amount:        order.OrderMount,
+		FlashSaleID:   order.FlashSaleID,
+		OccurredAt:    time.Now(),
+	}
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+
+	proto "emshop/api/inventory/v1"
+	"emshop/internal/app/order/srv/domain/do"
+	"emshop/internal/app/order/srv/domain/dto"
+	"emshop/internal/app/pkg/code"
+	"emshop/pkg/errors"
+	"emshop/pkg/log"
+
+	"gorm.io/gorm"
+)
+
+// 商品秒杀订单
+//
+// 秒杀名额由优惠券服务在Redis中预扣，抢到名额后以预占单号创建待支付订单：库存通过TCC Try冻结，
+// 支付成功后Confirm扣减，超过支付时间由预占单结算任务调用CloseFlashSaleOrder关闭订单并Cancel冻结。
+// 支付与关闭都以订单状态的条件更新为准，二者只会有一个成功。
+
+// CreateFlashSaleOrder 冻结库存并以秒杀价创建待支付订单，订单已存在时直接返回
+func (os *orderService) CreateFlashSaleOrder(ctx context.Context, order *dto.OrderDTO) error {
+	if order.FlashSaleID == 0 || order.OrderSn == "" || len(order.OrderGoods) == 0 {
+		return errors.WithCode(code.ErrSubmitOrder, "秒杀订单缺少活动、订单号或商品")
+	}
+
+	existing, err := os.ordersDAO.Get(ctx, os.db, order.OrderSn)
+	if err == nil {
+		log.InfofC(ctx, "秒杀订单已存在，跳过创建: orderSn=%s", order.OrderSn)
+		order.OrderInfoDO = *existing
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		log.ErrorfC(ctx, "查询秒杀订单失败: orderSn=%s, err=%v", order.OrderSn, err)
+		return err
+	}
+
+	sellInfo := flashSaleSellInfo(&order.OrderInfoDO)
+	if _, err := os.data.Inventorys().TrySell(ctx, sellInfo); err != nil {
+		log.ErrorfC(ctx, "秒杀订单冻结库存失败: orderSn=%s, err=%v", order.OrderSn, err)
+		return err
+	}
+
+	order.Status = "WAIT_BUYER_PAY"
+	if err := os.Create(ctx, order); err != nil {
+		if _, cancelErr := os.data.Inventorys().CancelSell(ctx, sellInfo); cancelErr != nil {
+			log.ErrorfC(ctx, "秒杀订单创建失败后取消冻结失败: orderSn=%s, err=%v", order.OrderSn, cancelErr)
+		}
+		return err
+	}
+
+	log.InfofC(ctx, "秒杀订单创建成功: orderSn=%s, flashSaleID=%d, amount=%.2f", order.OrderSn, order.FlashSaleID, order.OrderMount)
+	return nil
+}
+
+// CloseFlashSaleOrder 关闭未支付的秒杀订单并取消库存冻结，订单已支付时返回paid=true
+//
+// 订单不存在说明创建失败，库存未冻结，同样视为已关闭；重复关闭会再次取消冻结，由库存服务保证幂等
+func (os *orderService) CloseFlashSaleOrder(ctx context.Context, orderSn string) (bool, error) {
+	order, err := os.ordersDAO.Get(ctx, os.db, orderSn)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.InfofC(ctx, "秒杀订单不存在，视为已关闭: orderSn=%s", orderSn)
+			return false, nil
+		}
+		log.ErrorfC(ctx, "查询秒杀订单失败: orderSn=%s, err=%v", orderSn, err)
+		return false, err
+	}
+	if order.FlashSaleID == 0 {
+		return false, errors.WithCode(code.ErrSubmitOrder, "订单%s不是秒杀订单", orderSn)
+	}
+	if order.PaymentStatus == do.PaymentStatusPaid {
+		return true, nil
+	}
+
+	// 关闭订单与关闭事件在同一事务中提交
+	var closed bool
+	err = os.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&do.OrderInfoDO{}).
+			Where("order_sn = ? AND status = ? AND payment_status <> ?", orderSn, "WAIT_BUYER_PAY", do.PaymentStatusPaid).
+			Updates(map[string]interface{}{
+				"status":         "TRADE_CLOSED",
+				"payment_status": do.PaymentStatusExpired,
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		closed = true
+		order.Status = "TRADE_CLOSED"
+		order.PaymentStatus = do.PaymentStatusExpired
+		return os.events.Add(ctx, tx, aggregateOrder, orderSn, EventOrderClosed, newOrderEvent(order))
+	})
+	if err != nil {
+		log.ErrorfC(ctx, "关闭秒杀订单失败: orderSn=%s, err=%v", orderSn, err)
+		return false, errors.WithCode(code.ErrConnectDB, "关闭秒杀订单失败")
+	}
+	if !closed {
+		// 与支付并发或已关闭过，以最新状态为准
+		latest, err := os.ordersDAO.Get(ctx, os.db, orderSn)
+		if err != nil {
+			return false, err
+		}
+		if latest.PaymentStatus == do.PaymentStatusPaid {
+			return true, nil
+		}
+		if latest.Status != "TRADE_CLOSED" {
+			return false, errors.WithCode(code.ErrSubmitOrder, "秒杀订单%s状态为%s，暂不能关闭", orderSn, latest.Status)
+		}
+	}
+
+	if _, err := os.data.Inventorys().CancelSell(ctx, flashSaleSellInfo(order)); err != nil {
+		log.ErrorfC(ctx, "秒杀订单取消库存冻结失败: orderSn=%s, err=%v", orderSn, err)
+		return false, err
+	}
+	log.InfofC(ctx, "秒杀订单已超时关闭: orderSn=%s", orderSn)
+	return false, nil
+}
+
+// confirmFlashSaleStock 秒杀订单支付成功后确认扣减冻结的库存，普通订单直接返回
+func (os *orderService) confirmFlashSaleStock(ctx context.Context, order *do.OrderInfoDO) error {
+	if order.FlashSaleID == 0 {
+		return nil
+	}
+	if _, err := os.data.Inventorys().ConfirmSell(ctx, flashSaleSellInfo(order)); err != nil {
+		log.ErrorfC(ctx, "秒杀订单确认扣减库存失败: orderSn=%s, err=%v", order.OrderSn, err)
+		return err
+	}
+	return nil
+}
+
+func flashSaleSellInfo(order *do.OrderInfoDO) *proto.SellInfo {
+	goodsInfo := make([]*proto.GoodsInvInfo, 0, len(order.OrderGoods))
+	for _, value := range order.OrderGoods {
+		goodsInfo = append(goodsInfo, &proto.GoodsInvInfo{
+			GoodsId: value.Goods,
+			Num:     value.Nums,
+		})
+	}
+	return &proto.SellInfo{
+		GoodsInfo: goodsInfo,
+		OrderSn:   order.OrderSn,
+	}
+}
@@ -11,10 +11,12 @@ import (
     "emshop/internal/app/order/srv/domain/do"
     "emshop/internal/app/order/srv/domain/dto"
     "emshop/internal/app/pkg/code"
+    code2 "emshop/gin-micro/code"
     "emshop/internal/app/pkg/options"
     v1 "emshop/pkg/common/meta/v1"
     "emshop/pkg/errors"
     "emshop/pkg/log"
+    "emshop/pkg/outbox"
     "gorm.io/gorm"
 
     "github.com/dtm-labs/client/dtmgrpc"
@@ -25,6 +27,8 @@ import (
 type OrderSrv interface {
 	Get(ctx context.Context, orderSn string) (*dto.OrderDTO, error)
 	List(ctx context.Context, userID uint64, meta v1.ListMeta, orderby []string) (*dto.OrderDTOList, error)
+	ListOrderedUserIDs(ctx context.Context, since time.Time, afterUserID int32, limit int) ([]int32, error) // 营销活动圈选用户
+	DailyStats(ctx context.Context, start, end time.Time) ([]*do.OrderDailyStat, error)                      // 优惠券效果报表
 	Submit(ctx context.Context, order *dto.OrderDTO) error
 	Create(ctx context.Context, order *dto.OrderDTO) error
 	CreateCom(ctx context.Context, order *dto.OrderDTO) error //这是create的补偿
@@ -39,6 +43,10 @@ type OrderSrv interface {
 	// Payment status management (for DTM Saga integration)
 	UpdatePaidStatus(ctx context.Context, orderSn string, paymentSn string) error  // 更新订单为已支付
 	RevertPaidStatus(ctx context.Context, orderSn string) error                     // 回滚支付状态（补偿）
+
+	// 商品秒杀订单
+	CreateFlashSaleOrder(ctx context.Context, order *dto.OrderDTO) error         // 冻结库存并以秒杀价创建待支付订单
+	CloseFlashSaleOrder(ctx context.Context, orderSn string) (paid bool, err error) // 关闭未支付的秒杀订单并取消冻结
 }
 
 type orderService struct {
@@ -51,6 +59,7 @@ type orderService struct {
     data    mysql.DataFactory
     dtmOpts *options.DtmOptions
     regOpts *options.RegistryOptions
+    events  *outbox.Outbox
 }
 
 // CreateCom 是Create的补偿方法， 主要是回滚订单的创建
@@ -67,7 +76,7 @@ func (os *orderService) CreateCom(ctx context.Context, order *dto.OrderDTO) erro
 
 // Create 创建订单
 func (os *orderService) Create(ctx context.Context, order *dto.OrderDTO) error {
-	log.Debugf("Creating order: orderSn=%s, user=%d, goodsCount=%d", order.OrderSn, order.User, len(order.OrderGoods))
+	log.DebugfC(ctx, "Creating order: orderSn=%s, user=%d, goodsCount=%d", order.OrderSn, order.User, len(order.OrderGoods))
 	/*
 		1. 生成orderinfo表
 		2. 生成ordergoods表
@@ -82,11 +91,11 @@ func (os *orderService) Create(ctx context.Context, order *dto.OrderDTO) error {
 	// RPC调用获取商品信息 - 保留复杂业务逻辑
 	goods, err := os.data.Goods().BatchGetGoods(ctx, &proto2.BatchGoodsIdInfo{Id: goodsids})
 	if err != nil {
-		log.Errorf("批量获取商品信息失败，orderSn=%s, goodids: %v, err:%v", order.OrderSn, goodsids, err)
+		log.ErrorfC(ctx, "批量获取商品信息失败，orderSn=%s, goodids: %v, err:%v", order.OrderSn, goodsids, err)
 		return err
 	}
 	if len(goods.Data) != len(goodsids) {
-		log.Errorf("批量获取商品信息失败，orderSn=%s, goodids: %v, 返回值：%v, err:%v", order.OrderSn, goodsids, goods.Data, err)
+		log.ErrorfC(ctx, "批量获取商品信息失败，orderSn=%s, goodids: %v, 返回值：%v, err:%v", order.OrderSn, goodsids, goods.Data, err)
 		return errors.WithCode(code.ErrGoodsNotFound, "商品不存在或者部分不存在")
 	}
 	var goodsMap = make(map[int32]*proto2.GoodsInfoResponse)
@@ -96,18 +105,27 @@ func (os *orderService) Create(ctx context.Context, order *dto.OrderDTO) error {
 
 	var orderAmount float32
 	for _, value := range order.OrderGoods {
-		orderAmount += goodsMap[value.Goods].ShopPrice * float32(value.Nums)
+		// 秒杀商品以秒杀价成交
+		price := goodsMap[value.Goods].ShopPrice
+		if salePrice, ok := order.SalePrices[value.Goods]; ok {
+			price = salePrice
+		}
+		orderAmount += price * float32(value.Nums)
 		value.GoodsName = goodsMap[value.Goods].Name
-		value.GoodsPrice = goodsMap[value.Goods].ShopPrice
+		value.GoodsPrice = price
 		value.GoodsImage = goodsMap[value.Goods].GoodsFrontImage
 	}
+	// 秒杀订单按秒杀价记录订单金额，供支付与超时关闭使用；普通订单的金额仍沿用原有处理，不在此处改写
+	if order.FlashSaleID > 0 {
+		order.OrderMount = orderAmount
+	}
 
 	// 事务操作保留原有逻辑（分布式事务场景）
 	txn := os.data.Begin()
 	defer func() {
 		if err := recover(); err != nil {
 			_ = txn.Rollback()
-			log.Errorf("创建订单事务异常，orderSn=%s, err=%v", order.OrderSn, err)
+			log.ErrorfC(ctx, "创建订单事务异常，orderSn=%s, err=%v", order.OrderSn, err)
 			return
 		}
 	}()
@@ -115,43 +133,52 @@ func (os *orderService) Create(ctx context.Context, order *dto.OrderDTO) error {
 	err = os.data.Orders().Create(ctx, txn, &order.OrderInfoDO)
 	if err != nil {
 		txn.Rollback()
-		log.Errorf("创建订单失败，orderSn=%s, err:%v", order.OrderSn, err)
+		log.ErrorfC(ctx, "创建订单失败，orderSn=%s, err:%v", order.OrderSn, err)
 		return err //这个不是abort 也就是说会不停的重试
 	}
 
-	err = os.data.ShoppingCarts().DeleteByGoodsIDs(ctx, txn, uint64(order.User), goodsids)
-	if err != nil {
+	if err := os.events.Add(ctx, txn, aggregateOrder, order.OrderSn, EventOrderCreated, newOrderEvent(&order.OrderInfoDO)); err != nil {
 		txn.Rollback()
-		log.Errorf("删除购物车失败，orderSn=%s, goodids:%v, err:%v", order.OrderSn, goodsids, err)
+		log.ErrorfC(ctx, "写入订单创建事件失败，orderSn=%s, err:%v", order.OrderSn, err)
 		return err
 	}
 
+	// 秒杀订单不经过购物车
+	if order.FlashSaleID == 0 {
+		err = os.data.ShoppingCarts().DeleteByGoodsIDs(ctx, txn, uint64(order.User), goodsids)
+		if err != nil {
+			txn.Rollback()
+			log.ErrorfC(ctx, "删除购物车失败，orderSn=%s, goodids:%v, err:%v", order.OrderSn, goodsids, err)
+			return err
+		}
+	}
+
 	txn.Commit()
-	log.Infof("订单创建成功：orderSn=%s, amount=%.2f", order.OrderSn, orderAmount)
+	log.InfofC(ctx, "订单创建成功：orderSn=%s, amount=%.2f", order.OrderSn, orderAmount)
 	return nil
 }
 
 func (os *orderService) Get(ctx context.Context, orderSn string) (*dto.OrderDTO, error) {
-	log.Debugf("Getting order by orderSn: %s", orderSn)
+	log.DebugfC(ctx, "Getting order by orderSn: %s", orderSn)
 	
 	// 直接使用预加载的DAO
 	order, err := os.ordersDAO.Get(ctx, os.db, orderSn)
 	if err != nil {
-		log.Errorf("Failed to get order by orderSn %s: %v", orderSn, err)
+		log.ErrorfC(ctx, "Failed to get order by orderSn %s: %v", orderSn, err)
 		return nil, err
 	}
 	
-	log.Debugf("Successfully got order: orderSn=%s", orderSn)
-	return &dto.OrderDTO{*order}, nil
+	log.DebugfC(ctx, "Successfully got order: orderSn=%s", orderSn)
+	return &dto.OrderDTO{OrderInfoDO: *order}, nil
 }
 
 func (os *orderService) List(ctx context.Context, userID uint64, meta v1.ListMeta, orderby []string) (*dto.OrderDTOList, error) {
-	log.Debugf("Listing orders for user: %d, page: %d, pageSize: %d", userID, meta.Page, meta.PageSize)
+	log.DebugfC(ctx, "Listing orders for user: %d, page: %d, pageSize: %d", userID, meta.Page, meta.PageSize)
 	
 	// 直接使用预加载的DAO
 	orders, err := os.ordersDAO.List(ctx, os.db, userID, meta, orderby)
 	if err != nil {
-		log.Errorf("Failed to list orders for user %d: %v", userID, err)
+		log.ErrorfC(ctx, "Failed to list orders for user %d: %v", userID, err)
 		return nil, err
 	}
 	
@@ -159,27 +186,53 @@ func (os *orderService) List(ctx context.Context, userID uint64, meta v1.ListMet
 	ret.TotalCount = orders.TotalCount
 	for _, value := range orders.Items {
 		ret.Items = append(ret.Items, &dto.OrderDTO{
-			*value,
+			OrderInfoDO: *value,
 		})
 	}
 	
-	log.Debugf("Successfully listed %d orders for user %d, total: %d", len(ret.Items), userID, ret.TotalCount)
+	log.DebugfC(ctx, "Successfully listed %d orders for user %d, total: %d", len(ret.Items), userID, ret.TotalCount)
 	return &ret, nil
 }
 
+// DailyStats 按天统计[start, end)内未关闭订单的数量与金额
+func (os *orderService) DailyStats(ctx context.Context, start, end time.Time) ([]*do.OrderDailyStat, error) {
+	if !end.After(start) {
+		return nil, errors.WithCode(code2.ErrValidation, "结束时间必须晚于开始时间")
+	}
+	stats, err := os.ordersDAO.DailyStats(ctx, os.db, start, end)
+	if err != nil {
+		log.ErrorfC(ctx, "Failed to get daily order stats from %v to %v: %v", start, end, err)
+		return nil, err
+	}
+	return stats, nil
+}
+
+// ListOrderedUserIDs 查询since之后下过单的用户ID
+func (os *orderService) ListOrderedUserIDs(ctx context.Context, since time.Time, afterUserID int32, limit int) ([]int32, error) {
+	if limit <= 0 || limit > 5000 {
+		limit = 5000
+	}
+	userIDs, err := os.ordersDAO.ListUserIDsSince(ctx, os.db, since, afterUserID, limit)
+	if err != nil {
+		log.ErrorfC(ctx, "Failed to list ordered user ids since %v: %v", since, err)
+		return nil, err
+	}
+	return userIDs, nil
+}
+
 // Submit 提交订单， 这里是基于可靠消息最终一致性的思想， saga事务来解决订单生成的问题
 func (os *orderService) Submit(ctx context.Context, order *dto.OrderDTO) error {
-	log.Debugf("Submitting order: orderSn=%s, user=%d", order.OrderSn, order.User)
+	log.DebugfC(ctx, "Submitting order: orderSn=%s, user=%d", order.OrderSn, order.User)
 	
 	//先从购物车中获取商品信息 - 使用预加载的DAO
 	list, err := os.shoppingCartsDAO.List(ctx, os.db, uint64(order.User), true, v1.ListMeta{}, []string{})
 	if err != nil {
-		log.Errorf("获取购物车信息失败，orderSn=%s, user=%d, err:%v", order.OrderSn, order.User, err)
+		log.ErrorfC(ctx, "获取购物车信息失败，orderSn=%s, user=%d, err:%v", order.OrderSn, order.User, err)
 		return err
 	}
 
 	if len(list.Items) == 0 {
-		log.Errorf("购物车中没有商品，无法下单")
+		log.ErrorfC(ctx, "购物车中没有商品，无法下单")
 		return errors.WithCode(code.ErrNoGoodsSelect, "没有选择商品")
 	}
 
@@ -223,12 +276,12 @@ func (os *orderService) Submit(ctx context.Context, order *dto.OrderDTO) error {
     // 解析直连地址，避免 DTM 端缺少 discovery resolver
     qsBusi, err := os.resolveDirectAddr(ctx, "emshop-inventory-srv")
     if err != nil {
-        log.Errorf("解析库存服务地址失败: %v", err)
+        log.ErrorfC(ctx, "解析库存服务地址失败: %v", err)
         return err
     }
     gBusi, err := os.resolveDirectAddr(ctx, "emshop-order-srv")
     if err != nil {
-        log.Errorf("解析订单服务地址失败: %v", err)
+        log.ErrorfC(ctx, "解析订单服务地址失败: %v", err)
         return err
     }
 	// saga事务分为正向和补偿两个阶段
@@ -296,27 +349,27 @@ var _ = fmt.Sprintf // silence unused import if any
 
 
 func (os *orderService) Update(ctx context.Context, order *dto.OrderDTO) error {
-	log.Debugf("Updating order: orderSn=%s, user=%d", order.OrderSn, order.User)
+	log.DebugfC(ctx, "Updating order: orderSn=%s, user=%d", order.OrderSn, order.User)
 	
 	// 直接使用预加载的DAO
 	err := os.ordersDAO.Update(ctx, nil, &order.OrderInfoDO)
 	if err != nil {
-		log.Errorf("Failed to update order: orderSn=%s, err=%v", order.OrderSn, err)
+		log.ErrorfC(ctx, "Failed to update order: orderSn=%s, err=%v", order.OrderSn, err)
 		return err
 	}
 	
-	log.Infof("Successfully updated order: orderSn=%s", order.OrderSn)
+	log.InfofC(ctx, "Successfully updated order: orderSn=%s", order.OrderSn)
 	return nil
 }
 
 // Cart operations implementation
 func (os *orderService) CartItemList(ctx context.Context, userID uint64, meta v1.ListMeta) (*dto.ShopCartDTOList, error) {
-	log.Debugf("Getting cart item list for user: %d, page: %d, pageSize: %d", userID, meta.Page, meta.PageSize)
+	log.DebugfC(ctx, "Getting cart item list for user: %d, page: %d, pageSize: %d", userID, meta.Page, meta.PageSize)
 	
 	// 直接使用预加载的DAO
 	shopCartDOList, err := os.shoppingCartsDAO.List(ctx, os.db, userID, false, meta, []string{})
 	if err != nil {
-		log.Errorf("Failed to get cart item list for user %d: %v", userID, err)
+		log.ErrorfC(ctx, "Failed to get cart item list for user %d: %v", userID, err)
 		return nil, err
 	}
 	
@@ -331,90 +384,93 @@ func (os *orderService) CartItemList(ctx context.Context, userID uint64, meta v1
 		}
 	}
 	
-	log.Debugf("Successfully got %d cart items for user %d, total: %d", len(result.Items), userID, result.TotalCount)
+	log.DebugfC(ctx, "Successfully got %d cart items for user %d, total: %d", len(result.Items), userID, result.TotalCount)
 	return result, nil
 }
 
 func (os *orderService) CreateCartItem(ctx context.Context, cartItem *dto.ShopCartDTO) error {
-	log.Debugf("Creating cart item: user=%d, goods=%d, nums=%d", cartItem.User, cartItem.Goods, cartItem.Nums)
+	log.DebugfC(ctx, "Creating cart item: user=%d, goods=%d, nums=%d", cartItem.User, cartItem.Goods, cartItem.Nums)
 	
 	// Check if the cart item already exists - 使用预加载的DAO
 	existingItem, err := os.shoppingCartsDAO.Get(ctx, os.db, uint64(cartItem.User), uint64(cartItem.Goods))
 	if err == nil {
 		// Item exists, update the quantity
 		existingItem.Nums += cartItem.Nums
-		log.Debugf("Cart item exists, updating quantity: user=%d, goods=%d, newNums=%d", cartItem.User, cartItem.Goods, existingItem.Nums)
+		log.DebugfC(ctx, "Cart item exists, updating quantity: user=%d, goods=%d, newNums=%d", cartItem.User, cartItem.Goods, existingItem.Nums)
 		err = os.shoppingCartsDAO.UpdateNum(ctx, os.db, existingItem)
 		if err != nil {
-			log.Errorf("Failed to update cart item quantity: user=%d, goods=%d, err=%v", cartItem.User, cartItem.Goods, err)
+			log.ErrorfC(ctx, "Failed to update cart item quantity: user=%d, goods=%d, err=%v", cartItem.User, cartItem.Goods, err)
 			return err
 		}
-		log.Infof("Successfully updated cart item: user=%d, goods=%d", cartItem.User, cartItem.Goods)
+		log.InfofC(ctx, "Successfully updated cart item: user=%d, goods=%d", cartItem.User, cartItem.Goods)
 		return nil
 	}
 	
 	// Item doesn't exist, create new - 使用预加载的DAO
 	err = os.shoppingCartsDAO.Create(ctx, os.db, &cartItem.ShoppingCartDO)
 	if err != nil {
-		log.Errorf("Failed to create cart item: user=%d, goods=%d, err=%v", cartItem.User, cartItem.Goods, err)
+		log.ErrorfC(ctx, "Failed to create cart item: user=%d, goods=%d, err=%v", cartItem.User, cartItem.Goods, err)
 		return err
 	}
 	
-	log.Infof("Successfully created cart item: user=%d, goods=%d", cartItem.User, cartItem.Goods)
+	log.InfofC(ctx, "Successfully created cart item: user=%d, goods=%d", cartItem.User, cartItem.Goods)
 	return nil
 }
 
 func (os *orderService) UpdateCartItem(ctx context.Context, cartItem *dto.ShopCartDTO) error {
-	log.Debugf("Updating cart item: user=%d, goods=%d, nums=%d", cartItem.User, cartItem.Goods, cartItem.Nums)
+	log.DebugfC(ctx, "Updating cart item: user=%d, goods=%d, nums=%d", cartItem.User, cartItem.Goods, cartItem.Nums)
 	
 	// 直接使用预加载的DAO
 	err := os.shoppingCartsDAO.UpdateNum(ctx, os.db, &cartItem.ShoppingCartDO)
 	if err != nil {
-		log.Errorf("Failed to update cart item: user=%d, goods=%d, err=%v", cartItem.User, cartItem.Goods, err)
+		log.ErrorfC(ctx, "Failed to update cart item: user=%d, goods=%d, err=%v", cartItem.User, cartItem.Goods, err)
 		return err
 	}
 	
-	log.Infof("Successfully updated cart item: user=%d, goods=%d", cartItem.User, cartItem.Goods)
+	log.InfofC(ctx, "Successfully updated cart item: user=%d, goods=%d", cartItem.User, cartItem.Goods)
 	return nil
 }
 
 func (os *orderService) DeleteCartItem(ctx context.Context, userID, goodsID uint64) error {
-	log.Debugf("Deleting cart item: user=%d, goods=%d", userID, goodsID)
+	log.DebugfC(ctx, "Deleting cart item: user=%d, goods=%d", userID, goodsID)
 	
 	// 使用预加载的DAO获取购物车项
 	cartItem, err := os.shoppingCartsDAO.Get(ctx, os.db, userID, goodsID)
 	if err != nil {
-		log.Errorf("Failed to get cart item for deletion: user=%d, goods=%d, err=%v", userID, goodsID, err)
+		log.ErrorfC(ctx, "Failed to get cart item for deletion: user=%d, goods=%d, err=%v", userID, goodsID, err)
 		return err
 	}
 	
 	// 使用预加载的DAO删除
 	err = os.shoppingCartsDAO.Delete(ctx, os.db, uint64(cartItem.ID))
 	if err != nil {
-		log.Errorf("Failed to delete cart item: user=%d, goods=%d, err=%v", userID, goodsID, err)
+		log.ErrorfC(ctx, "Failed to delete cart item: user=%d, goods=%d, err=%v", userID, goodsID, err)
 		return err
 	}
 	
-	log.Infof("Successfully deleted cart item: user=%d, goods=%d", userID, goodsID)
+	log.InfofC(ctx, "Successfully deleted cart item: user=%d, goods=%d", userID, goodsID)
 	return nil
 }
 
 // UpdatePaidStatus 更新订单为已支付状态 - DTM Saga正向操作
 func (os *orderService) UpdatePaidStatus(ctx context.Context, orderSn string, paymentSn string) error {
-	log.Infof("更新订单支付状态：订单号=%s, 支付单号=%s", orderSn, paymentSn)
+	log.InfofC(ctx, "更新订单支付状态：订单号=%s, 支付单号=%s", orderSn, paymentSn)
 
 	// 查询订单 - 使用预加载的DAO
 	order, err := os.ordersDAO.Get(ctx, os.db, orderSn)
 	if err != nil {
-		log.Errorf("查询订单失败: orderSn=%s, err=%v", orderSn, err)
+		log.ErrorfC(ctx, "查询订单失败: orderSn=%s, err=%v", orderSn, err)
 		return err
 	}
 
 	// 检查订单状态是否允许支付
 	if order.PaymentStatus == do.PaymentStatusPaid {
-		// 已经是已支付状态，幂等处理
-		log.Infof("订单%s已经是已支付状态，跳过", orderSn)
-		return nil
+		// 已经是已支付状态，幂等处理；秒杀订单补做库存确认
+		log.InfofC(ctx, "订单%s已经是已支付状态，跳过", orderSn)
+		return os.confirmFlashSaleStock(ctx, order)
+	}
+	if order.FlashSaleID > 0 && order.Status == "TRADE_CLOSED" {
+		return errors.WithCode(code.ErrOrderClosed, "秒杀订单已超时关闭")
 	}
 
 	// 开启事务
@@ -438,42 +494,61 @@ func (os *orderService) UpdatePaidStatus(ctx context.Context, orderSn string, pa
 	updates["paid_at"] = &now
 	updates["pay_time"] = &now
 
-	if err := tx.Model(&do.OrderInfoDO{}).Where("order_sn = ?", orderSn).Updates(updates).Error; err != nil {
+	// 秒杀订单与超时关闭竞争，只有未关闭的订单可以支付
+	query := tx.Model(&do.OrderInfoDO{}).Where("order_sn = ?", orderSn)
+	if order.FlashSaleID > 0 {
+		query = query.Where("status <> ?", "TRADE_CLOSED")
+	}
+	result := query.Updates(updates)
+	if result.Error != nil {
 		tx.Rollback()
-		log.Errorf("更新订单支付状态失败: %v", err)
+		log.ErrorfC(ctx, "更新订单支付状态失败: %v", result.Error)
 		return errors.WithCode(code.ErrConnectDB, "更新订单支付状态失败")
 	}
+	if result.RowsAffected == 0 && order.FlashSaleID > 0 {
+		tx.Rollback()
+		return errors.WithCode(code.ErrOrderClosed, "秒杀订单已超时关闭")
+	}
+
+	order.Status = "TRADE_SUCCESS"
+	order.PaymentStatus = do.PaymentStatusPaid
+	order.PaymentSn = paymentSn
+	if err := os.events.Add(ctx, tx, aggregateOrder, orderSn, EventOrderPaid, newOrderEvent(order)); err != nil {
+		tx.Rollback()
+		log.ErrorfC(ctx, "写入订单支付事件失败: orderSn=%s, err=%v", orderSn, err)
+		return errors.WithCode(code.ErrConnectDB, "写入订单支付事件失败")
+	}
 
 	// 提交事务
 	if err := tx.Commit().Error; err != nil {
-		log.Errorf("提交事务失败: %v", err)
+		log.ErrorfC(ctx, "提交事务失败: %v", err)
 		return errors.WithCode(code.ErrConnectDB, "提交事务失败")
 	}
 
-	log.Infof("订单支付状态更新成功：订单号=%s", orderSn)
-	return nil
+	log.InfofC(ctx, "订单支付状态更新成功：订单号=%s", orderSn)
+	return os.confirmFlashSaleStock(ctx, order)
 }
 
 // RevertPaidStatus 回滚支付状态 - DTM Saga补偿操作
 func (os *orderService) RevertPaidStatus(ctx context.Context, orderSn string) error {
-	log.Infof("回滚订单支付状态：订单号=%s", orderSn)
+	log.InfofC(ctx, "回滚订单支付状态：订单号=%s", orderSn)
 
 	// 查询订单 - 使用预加载的DAO
 	order, err := os.ordersDAO.Get(ctx, os.db, orderSn)
 	if err != nil {
 		if errors.IsCode(err, code.ErrNoGoodsSelect) {
 			// 订单不存在，认为回滚成功（幂等性）
-			log.Infof("订单%s不存在，回滚操作成功", orderSn)
+			log.InfofC(ctx, "订单%s不存在，回滚操作成功", orderSn)
 			return nil
 		}
-		log.Errorf("查询订单失败: orderSn=%s, err=%v", orderSn, err)
+		log.ErrorfC(ctx, "查询订单失败: orderSn=%s, err=%v", orderSn, err)
 		return err
 	}
 
 	// 检查是否需要回滚
 	if order.PaymentStatus == do.PaymentStatusNone || order.PaymentStatus == do.PaymentStatusCreated {
 		// 已经是未支付状态，幂等处理
-		log.Infof("订单%s已经是未支付状态，跳过回滚", orderSn)
+		log.InfofC(ctx, "订单%s已经是未支付状态，跳过回滚", orderSn)
 		return nil
 	}
 
@@ -497,17 +572,26 @@ func (os *orderService) RevertPaidStatus(ctx context.Context, orderSn string) er
 
 	if err := tx.Model(&do.OrderInfoDO{}).Where("order_sn = ?", orderSn).Updates(updates).Error; err != nil {
 		tx.Rollback()
-		log.Errorf("回滚订单支付状态失败: %v", err)
+		log.ErrorfC(ctx, "回滚订单支付状态失败: %v", err)
 		return errors.WithCode(code.ErrConnectDB, "回滚订单支付状态失败")
 	}
 
+	order.Status = "TRADE_CLOSED"
+	order.PaymentStatus = do.PaymentStatusNone
+	order.PaymentSn = ""
+	if err := os.events.Add(ctx, tx, aggregateOrder, orderSn, EventOrderPaymentReverted, newOrderEvent(order)); err != nil {
+		tx.Rollback()
+		log.ErrorfC(ctx, "写入订单支付回滚事件失败: orderSn=%s, err=%v", orderSn, err)
+		return errors.WithCode(code.ErrConnectDB, "写入订单支付回滚事件失败")
+	}
+
 	// 提交事务
 	if err := tx.Commit().Error; err != nil {
-		log.Errorf("提交事务失败: %v", err)
+		log.ErrorfC(ctx, "提交事务失败: %v", err)
 		return errors.WithCode(code.ErrConnectDB, "提交事务失败")
 	}
 
-	log.Infof("订单支付状态回滚成功：订单号=%s", orderSn)
+	log.InfofC(ctx, "订单支付状态回滚成功：订单号=%s", orderSn)
 	return nil
 }
 
@@ -522,6 +606,7 @@ func newOrderService(sv *service) *orderService {
         data:    sv.data,
         dtmOpts: sv.dtmopts,
         regOpts: sv.regopts,
+        events:  sv.events,
     }
 }
 
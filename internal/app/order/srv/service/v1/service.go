@@ -3,6 +3,7 @@ package service
 import (
 	"emshop/internal/app/order/srv/data/v1/mysql"
 	"emshop/internal/app/pkg/options"
+	"emshop/pkg/outbox"
 )
 
 type ServiceFactory interface {
@@ -13,12 +14,14 @@ type service struct {
 	data    mysql.DataFactory
 	dtmopts *options.DtmOptions
 	regopts *options.RegistryOptions
+	events  *outbox.Outbox
 }
 
 func (s *service) Orders() OrderSrv { return newOrderService(s) }
 
 var _ ServiceFactory = &service{}
 
-func NewService(data mysql.DataFactory, dtmopts *options.DtmOptions, regopts *options.RegistryOptions) *service {
-    return &service{data: data, dtmopts: dtmopts, regopts: regopts}
+// NewService 创建订单服务，events为nil时不写入领域事件
+func NewService(data mysql.DataFactory, dtmopts *options.DtmOptions, regopts *options.RegistryOptions, events *outbox.Outbox) *service {
+    return &service{data: data, dtmopts: dtmopts, regopts: regopts, events: events}
 }
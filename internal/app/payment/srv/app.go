@@ -3,16 +3,15 @@ package srv
 import (
     gapp "emshop/gin-micro/app"
     "emshop/gin-micro/registry"
-    "emshop/gin-micro/registry/consul"
     rpcserver "emshop/gin-micro/server/rpc-server"
     "emshop/gin-micro/server/rpc-server/selector"
     "emshop/gin-micro/server/rpc-server/selector/p2c"
     "emshop/internal/app/payment/srv/config"
     "emshop/internal/app/pkg/options"
+    "emshop/internal/app/pkg/discovery"
     "emshop/pkg/app"
     "emshop/pkg/log"
 
-	"github.com/hashicorp/consul/api"
 )
 
 func NewApp(basename string) *app.App {
@@ -27,26 +26,10 @@ func NewApp(basename string) *app.App {
 }
 
 func NewRegistrar(registry *options.RegistryOptions, dev bool) registry.Registrar {
-    c := api.DefaultConfig()
-    c.Address = registry.Address
-    c.Scheme = registry.Scheme
-    cli, err := api.NewClient(c)
+    r, err := discovery.NewRegistrar(registry, dev)
     if err != nil {
         panic(err)
     }
-    opts := []consul.Option{consul.WithHealthCheck(true)}
-    if registry.HealthCheckInterval > 0 {
-        opts = append(opts, consul.WithHealthCheckInterval(registry.HealthCheckInterval))
-    }
-    if registry.CheckTimeout > 0 {
-        opts = append(opts, consul.WithCheckTimeout(registry.CheckTimeout))
-    }
-    if dev {
-        opts = append(opts, consul.WithDeregisterCriticalServiceAfter(60))
-    } else if registry.DeregisterCriticalAfter > 0 {
-        opts = append(opts, consul.WithDeregisterCriticalServiceAfter(registry.DeregisterCriticalAfter))
-    }
-    r := consul.New(cli, opts...)
     return r
 }
 
@@ -63,16 +46,21 @@ func NewPaymentApp(cfg *config.Config) (*gapp.App, error) {
     register := NewRegistrar(cfg.Registry, cfg.Log.Development)
 
 	//生成rpc服务
-	rpcServer, err := NewPaymentRPCServer(cfg)
+	rpcServer, outboxWorker, err := NewPaymentRPCServer(cfg)
 	if err != nil {
 		return nil, err
 	}
 
 	opts := []gapp.Option{
 		gapp.WithName(cfg.Server.Name),
+		gapp.WithVersion(cfg.Server.Version),
+		gapp.WithMetadata(cfg.Server.Metadata),
 		gapp.WithRegistrar(register),
 		gapp.WithRPCServer(rpcServer),
 	}
+	if outboxWorker != nil {
+		opts = append(opts, gapp.WithServer(outboxWorker))
+	}
 	return gapp.New(opts...), nil
 }
 
@@ -10,6 +10,7 @@ import (
 	"emshop/gin-micro/registry"
 	rpcserver "emshop/gin-micro/server/rpc-server"
 	clientinterceptors "emshop/gin-micro/server/rpc-server/client-interceptors"
+	"emshop/gin-micro/server/rpc-server/selector"
 	"emshop/pkg/log"
 	"google.golang.org/grpc"
 )
@@ -22,11 +23,11 @@ type ServiceClients struct {
 }
 
 // NewServiceClients 创建支付服务的客户端集合
-func NewServiceClients(discovery registry.Discovery) *ServiceClients {
+func NewServiceClients(discovery registry.Discovery, filters ...selector.NodeFilter) *ServiceClients {
 	return &ServiceClients{
-		orderClient:     NewOrderServiceClient(discovery),
-		inventoryClient: NewInventoryServiceClient(discovery),
-		logisticsClient: NewLogisticsServiceClient(discovery),
+		orderClient:     NewOrderServiceClient(discovery, filters...),
+		inventoryClient: NewInventoryServiceClient(discovery, filters...),
+		logisticsClient: NewLogisticsServiceClient(discovery, filters...),
 	}
 }
 
@@ -53,13 +54,15 @@ const (
 )
 
 // NewOrderServiceClient 创建订单服务的 gRPC 客户端
-func NewOrderServiceClient(r registry.Discovery) opbv1.OrderClient {
+func NewOrderServiceClient(r registry.Discovery, filters ...selector.NodeFilter) opbv1.OrderClient {
 	log.Infof("Initializing gRPC connection to order service: %s", orderServiceName)
 	conn, err := rpcserver.DialInsecure(
 		context.Background(),
 		rpcserver.WithBalancerName("p2c"),
 		rpcserver.WithEndpoint(orderServiceName),
+		rpcserver.WithHealthCheck(),
 		rpcserver.WithDiscovery(r),
+		rpcserver.WithNodeFilter(filters...),
 		rpcserver.WithClientTimeout(10*time.Second),
 		rpcserver.WithClientOptions(grpc.WithNoProxy()),
 		rpcserver.WithClientUnaryInterceptor(clientinterceptors.UnaryTracingInterceptor),
@@ -73,13 +76,15 @@ func NewOrderServiceClient(r registry.Discovery) opbv1.OrderClient {
 }
 
 // NewInventoryServiceClient 创建库存服务的 gRPC 客户端
-func NewInventoryServiceClient(r registry.Discovery) ipbv1.InventoryClient {
+func NewInventoryServiceClient(r registry.Discovery, filters ...selector.NodeFilter) ipbv1.InventoryClient {
 	log.Infof("Initializing gRPC connection to inventory service: %s", inventoryServiceName)
 	conn, err := rpcserver.DialInsecure(
 		context.Background(),
 		rpcserver.WithBalancerName("p2c"),
 		rpcserver.WithEndpoint(inventoryServiceName),
+		rpcserver.WithHealthCheck(),
 		rpcserver.WithDiscovery(r),
+		rpcserver.WithNodeFilter(filters...),
 		rpcserver.WithClientTimeout(10*time.Second),
 		rpcserver.WithClientOptions(grpc.WithNoProxy()),
 		rpcserver.WithClientUnaryInterceptor(clientinterceptors.UnaryTracingInterceptor),
@@ -93,13 +98,15 @@ func NewInventoryServiceClient(r registry.Discovery) ipbv1.InventoryClient {
 }
 
 // NewLogisticsServiceClient 创建物流服务的 gRPC 客户端
-func NewLogisticsServiceClient(r registry.Discovery) lpbv1.LogisticsClient {
+func NewLogisticsServiceClient(r registry.Discovery, filters ...selector.NodeFilter) lpbv1.LogisticsClient {
 	log.Infof("Initializing gRPC connection to logistics service: %s", logisticsServiceName)
 	conn, err := rpcserver.DialInsecure(
 		context.Background(),
 		rpcserver.WithBalancerName("p2c"),
 		rpcserver.WithEndpoint(logisticsServiceName),
+		rpcserver.WithHealthCheck(),
 		rpcserver.WithDiscovery(r),
+		rpcserver.WithNodeFilter(filters...),
 		rpcserver.WithClientTimeout(10*time.Second),
 		rpcserver.WithClientOptions(grpc.WithNoProxy()),
 		rpcserver.WithClientUnaryInterceptor(clientinterceptors.UnaryTracingInterceptor),
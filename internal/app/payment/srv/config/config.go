@@ -16,6 +16,9 @@ type Config struct {
 	Registry     *options.RegistryOptions  `json:"registry" mapstructure:"registry"`
 	Dtm          *options.DtmOptions       `json:"dtm" mapstructure:"dtm"`       // 分布式事务
 	Redis        *options.RedisOptions     `json:"redis" mapstructure:"redis"`   // Redis配置(分布式锁)
+	Outbox       *options.OutboxOptions    `json:"outbox" mapstructure:"outbox"` // 支付事件发件箱
+
+	Idempotency *options.IdempotencyOptions `json:"idempotency" mapstructure:"idempotency"` // 幂等键去重
 }
 
 func New() *Config {
@@ -28,6 +31,8 @@ func New() *Config {
 		Registry:     options.NewRegistryOptions(),
 		Dtm:          options.NewDtmOptions(),
 		Redis:        options.NewRedisOptions(),
+		Outbox:       options.NewOutboxOptions(),
+		Idempotency:  options.NewIdempotencyOptions(),
 	}
 }
 
@@ -40,6 +45,8 @@ func (o *Config) Flags() (fss cliflag.NamedFlagSets) {
 	o.MySQLOptions.AddFlags(fss.FlagSet("mysql"))
 	o.Dtm.AddFlags(fss.FlagSet("dtm"))
 	o.Redis.AddFlags(fss.FlagSet("redis"))
+	o.Outbox.AddFlags(fss.FlagSet("outbox"))
+	o.Idempotency.AddFlags(fss.FlagSet("idempotency"))
 	return fss
 }
 
@@ -59,5 +66,7 @@ func (o *Config) Validate() []error {
 	errs = append(errs, o.Registry.Validate()...)
 	errs = append(errs, o.Dtm.Validate()...)
 	errs = append(errs, o.Redis.Validate()...)
+	errs = append(errs, o.Outbox.Validate()...)
+	errs = append(errs, o.Idempotency.Validate()...)
 	return errs
 }
\ No newline at end of file
@@ -1,6 +1,7 @@
 package mysql
 
 import (
+	"emshop/gin-micro/core/health"
 	"emshop/internal/app/payment/srv/data/v1/interfaces"
 	"emshop/internal/app/pkg/options"
 	gormtrace "emshop/pkg/observability/gormtrace"
@@ -72,6 +73,7 @@ func (f *dataFactory) initMySQL(mysqlOpts *options.MySQLOptions) error {
 	sqlDB.SetMaxIdleConns(mysqlOpts.MaxIdleConnections)
 	sqlDB.SetMaxOpenConns(mysqlOpts.MaxOpenConnections)
 	sqlDB.SetConnMaxLifetime(mysqlOpts.MaxConnectionLifetime)
+	health.Register("mysql", health.PingCheck(sqlDB))
 	
 	f.db = db
 	
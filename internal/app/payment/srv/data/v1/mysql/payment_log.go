@@ -28,7 +28,7 @@ func (p *paymentLogData) Create(ctx context.Context, db *gorm.DB, paymentLog *do
 	}
 	
 	if err := db.WithContext(ctx).Create(paymentLog).Error; err != nil {
-		log.Errorf("创建支付日志失败: %v", err)
+		log.ErrorfC(ctx, "创建支付日志失败: %v", err)
 		return errors.WithCode(code.ErrConnectDB, "创建支付日志失败")
 	}
 	
@@ -48,7 +48,7 @@ func (p *paymentLogData) List(ctx context.Context, db *gorm.DB, paymentSn string
 	
 	// 获取总数
 	if err := query.Count(&ret.TotalCount).Error; err != nil {
-		log.Errorf("查询支付日志总数失败: %v", err)
+		log.ErrorfC(ctx, "查询支付日志总数失败: %v", err)
 		return nil, errors.WithCode(code.ErrConnectDB, "查询支付日志总数失败")
 	}
 	
@@ -63,7 +63,7 @@ func (p *paymentLogData) List(ctx context.Context, db *gorm.DB, paymentSn string
 	
 	// 查询数据
 	if err := query.Find(&ret.Items).Error; err != nil {
-		log.Errorf("查询支付日志列表失败: %v", err)
+		log.ErrorfC(ctx, "查询支付日志列表失败: %v", err)
 		return nil, errors.WithCode(code.ErrConnectDB, "查询支付日志列表失败")
 	}
 	
@@ -81,7 +81,7 @@ func (p *paymentLogData) GetByAction(ctx context.Context, db *gorm.DB, paymentSn
 		Where("payment_sn = ? AND action = ?", paymentSn, action).
 		Order("created_at DESC").
 		Find(&logs).Error; err != nil {
-		log.Errorf("查询支付日志失败: %v", err)
+		log.ErrorfC(ctx, "查询支付日志失败: %v", err)
 		return nil, errors.WithCode(code.ErrConnectDB, "查询支付日志失败")
 	}
 	
@@ -29,7 +29,7 @@ func (p *paymentOrderData) Create(ctx context.Context, db *gorm.DB, payment *do.
 	}
 	
 	if err := db.WithContext(ctx).Create(payment).Error; err != nil {
-		log.Errorf("创建支付订单失败: %v", err)
+		log.ErrorfC(ctx, "创建支付订单失败: %v", err)
 		return errors.WithCode(code.ErrConnectDB, "创建支付订单失败")
 	}
 	
@@ -43,7 +43,7 @@ func (p *paymentOrderData) Update(ctx context.Context, db *gorm.DB, payment *do.
 	}
 	
 	if err := db.WithContext(ctx).Save(payment).Error; err != nil {
-		log.Errorf("更新支付订单失败: %v", err)
+		log.ErrorfC(ctx, "更新支付订单失败: %v", err)
 		return errors.WithCode(code.ErrConnectDB, "更新支付订单失败")
 	}
 	
@@ -57,7 +57,7 @@ func (p *paymentOrderData) Delete(ctx context.Context, db *gorm.DB, paymentSn st
 	}
 	
 	if err := db.WithContext(ctx).Where("payment_sn = ?", paymentSn).Delete(&do.PaymentOrderDO{}).Error; err != nil {
-		log.Errorf("删除支付订单失败: %v", err)
+		log.ErrorfC(ctx, "删除支付订单失败: %v", err)
 		return errors.WithCode(code.ErrConnectDB, "删除支付订单失败")
 	}
 	
@@ -75,7 +75,7 @@ func (p *paymentOrderData) Get(ctx context.Context, db *gorm.DB, paymentSn strin
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.WithCode(code.ErrPaymentNotFound, "支付订单不存在")
 		}
-		log.Errorf("查询支付订单失败: %v", err)
+		log.ErrorfC(ctx, "查询支付订单失败: %v", err)
 		return nil, errors.WithCode(code.ErrConnectDB, "查询支付订单失败")
 	}
 	
@@ -93,7 +93,7 @@ func (p *paymentOrderData) GetByOrderSn(ctx context.Context, db *gorm.DB, orderS
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.WithCode(code.ErrPaymentNotFound, "支付订单不存在")
 		}
-		log.Errorf("查询支付订单失败: %v", err)
+		log.ErrorfC(ctx, "查询支付订单失败: %v", err)
 		return nil, errors.WithCode(code.ErrConnectDB, "查询支付订单失败")
 	}
 	
@@ -116,7 +116,7 @@ func (p *paymentOrderData) List(ctx context.Context, db *gorm.DB, userID int32,
 	
 	// 获取总数
 	if err := query.Count(&ret.TotalCount).Error; err != nil {
-		log.Errorf("查询支付订单总数失败: %v", err)
+		log.ErrorfC(ctx, "查询支付订单总数失败: %v", err)
 		return nil, errors.WithCode(code.ErrConnectDB, "查询支付订单总数失败")
 	}
 	
@@ -136,7 +136,7 @@ func (p *paymentOrderData) List(ctx context.Context, db *gorm.DB, userID int32,
 	
 	// 查询数据
 	if err := query.Find(&ret.Items).Error; err != nil {
-		log.Errorf("查询支付订单列表失败: %v", err)
+		log.ErrorfC(ctx, "查询支付订单列表失败: %v", err)
 		return nil, errors.WithCode(code.ErrConnectDB, "查询支付订单列表失败")
 	}
 	
@@ -160,7 +160,7 @@ func (p *paymentOrderData) UpdateStatus(ctx context.Context, db *gorm.DB, paymen
 	}
 	
 	if err := db.WithContext(ctx).Model(&do.PaymentOrderDO{}).Where("payment_sn = ?", paymentSn).Updates(updates).Error; err != nil {
-		log.Errorf("更新支付状态失败: %v", err)
+		log.ErrorfC(ctx, "更新支付状态失败: %v", err)
 		return errors.WithCode(code.ErrConnectDB, "更新支付状态失败")
 	}
 	
@@ -186,7 +186,7 @@ func (p *paymentOrderData) UpdatePaidInfo(ctx context.Context, db *gorm.DB, paym
 	}
 	
 	if err := db.WithContext(ctx).Model(&do.PaymentOrderDO{}).Where("payment_sn = ?", paymentSn).Updates(updates).Error; err != nil {
-		log.Errorf("更新支付信息失败: %v", err)
+		log.ErrorfC(ctx, "更新支付信息失败: %v", err)
 		return errors.WithCode(code.ErrConnectDB, "更新支付信息失败")
 	}
 	
@@ -203,7 +203,7 @@ func (p *paymentOrderData) FindExpiredPayments(ctx context.Context, db *gorm.DB,
 	if err := db.WithContext(ctx).
 		Where("payment_status = ? AND expired_at < ?", do.PaymentStatusPending, beforeTime).
 		Find(&payments).Error; err != nil {
-		log.Errorf("查找过期支付订单失败: %v", err)
+		log.ErrorfC(ctx, "查找过期支付订单失败: %v", err)
 		return nil, errors.WithCode(code.ErrConnectDB, "查找过期支付订单失败")
 	}
 	
@@ -218,7 +218,7 @@ func (p *paymentOrderData) CountByStatus(ctx context.Context, db *gorm.DB, statu
 	
 	var count int64
 	if err := db.WithContext(ctx).Model(&do.PaymentOrderDO{}).Where("payment_status = ?", status).Count(&count).Error; err != nil {
-		log.Errorf("统计支付订单数量失败: %v", err)
+		log.ErrorfC(ctx, "统计支付订单数量失败: %v", err)
 		return 0, errors.WithCode(code.ErrConnectDB, "统计支付订单数量失败")
 	}
 	
@@ -28,7 +28,7 @@ func (s *stockReservationData) Create(ctx context.Context, db *gorm.DB, reservat
 	}
 	
 	if err := db.WithContext(ctx).Create(reservation).Error; err != nil {
-		log.Errorf("创建库存预留记录失败: %v", err)
+		log.ErrorfC(ctx, "创建库存预留记录失败: %v", err)
 		return errors.WithCode(code.ErrStockReservationFailed, "创建库存预留记录失败")
 	}
 	
@@ -42,7 +42,7 @@ func (s *stockReservationData) Update(ctx context.Context, db *gorm.DB, reservat
 	}
 	
 	if err := db.WithContext(ctx).Save(reservation).Error; err != nil {
-		log.Errorf("更新库存预留记录失败: %v", err)
+		log.ErrorfC(ctx, "更新库存预留记录失败: %v", err)
 		return errors.WithCode(code.ErrConnectDB, "更新库存预留记录失败")
 	}
 	
@@ -60,7 +60,7 @@ func (s *stockReservationData) Get(ctx context.Context, db *gorm.DB, orderSn str
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.WithCode(code.ErrStockReservationNotFound, "库存预留记录不存在")
 		}
-		log.Errorf("查询库存预留记录失败: %v", err)
+		log.ErrorfC(ctx, "查询库存预留记录失败: %v", err)
 		return nil, errors.WithCode(code.ErrConnectDB, "查询库存预留记录失败")
 	}
 	
@@ -75,7 +75,7 @@ func (s *stockReservationData) GetByOrderSn(ctx context.Context, db *gorm.DB, or
 	
 	var reservations []*do.StockReservationDO
 	if err := db.WithContext(ctx).Where("order_sn = ?", orderSn).Find(&reservations).Error; err != nil {
-		log.Errorf("查询订单库存预留记录失败: %v", err)
+		log.ErrorfC(ctx, "查询订单库存预留记录失败: %v", err)
 		return nil, errors.WithCode(code.ErrConnectDB, "查询订单库存预留记录失败")
 	}
 	
@@ -93,7 +93,7 @@ func (s *stockReservationData) BatchCreate(ctx context.Context, db *gorm.DB, res
 	}
 	
 	if err := db.WithContext(ctx).CreateInBatches(reservations, 100).Error; err != nil {
-		log.Errorf("批量创建库存预留记录失败: %v", err)
+		log.ErrorfC(ctx, "批量创建库存预留记录失败: %v", err)
 		return errors.WithCode(code.ErrStockReservationFailed, "批量创建库存预留记录失败")
 	}
 	
@@ -122,7 +122,7 @@ func (s *stockReservationData) BatchUpdateStatus(ctx context.Context, db *gorm.D
 	if err := db.WithContext(ctx).Model(&do.StockReservationDO{}).
 		Where("order_sn = ?", orderSn).
 		Updates(updates).Error; err != nil {
-		log.Errorf("批量更新库存预留状态失败: %v", err)
+		log.ErrorfC(ctx, "批量更新库存预留状态失败: %v", err)
 		return errors.WithCode(code.ErrConnectDB, "批量更新库存预留状态失败")
 	}
 	
@@ -151,7 +151,7 @@ func (s *stockReservationData) UpdateStatus(ctx context.Context, db *gorm.DB, or
 	if err := db.WithContext(ctx).Model(&do.StockReservationDO{}).
 		Where("order_sn = ? AND goods_id = ?", orderSn, goodsID).
 		Updates(updates).Error; err != nil {
-		log.Errorf("更新库存预留状态失败: %v", err)
+		log.ErrorfC(ctx, "更新库存预留状态失败: %v", err)
 		return errors.WithCode(code.ErrConnectDB, "更新库存预留状态失败")
 	}
 	
@@ -168,7 +168,7 @@ func (s *stockReservationData) FindExpiredReservations(ctx context.Context, db *
 	if err := db.WithContext(ctx).
 		Where("status = ? AND reserved_at < ?", do.StockReservationStatusReserved, beforeTime).
 		Find(&reservations).Error; err != nil {
-		log.Errorf("查找过期库存预留记录失败: %v", err)
+		log.ErrorfC(ctx, "查找过期库存预留记录失败: %v", err)
 		return nil, errors.WithCode(code.ErrConnectDB, "查找过期库存预留记录失败")
 	}
 	
@@ -183,7 +183,7 @@ func (s *stockReservationData) CountByStatus(ctx context.Context, db *gorm.DB, s
 	
 	var count int64
 	if err := db.WithContext(ctx).Model(&do.StockReservationDO{}).Where("status = ?", status).Count(&count).Error; err != nil {
-		log.Errorf("统计库存预留记录数量失败: %v", err)
+		log.ErrorfC(ctx, "统计库存预留记录数量失败: %v", err)
 		return 0, errors.WithCode(code.ErrConnectDB, "统计库存预留记录数量失败")
 	}
 	
@@ -1,9 +1,14 @@
 package srv
 
 import (
+	"context"
+	"emshop/gin-micro/core/health"
 	gpb "emshop/api/payment/v1"
+	"emshop/gin-micro/core/idempotency"
+	"emshop/gin-micro/core/limit"
 	"emshop/gin-micro/core/trace"
 	"emshop/gin-micro/server/rpc-server"
+	srvintc "emshop/gin-micro/server/rpc-server/server-interceptors"
 	"emshop/internal/app/payment/srv/config"
 	"emshop/internal/app/payment/srv/controller/payment/v1"
 	v1data "emshop/internal/app/payment/srv/data/v1/mysql"
@@ -11,9 +16,12 @@ import (
 	"fmt"
 
 	"emshop/pkg/log"
+	"emshop/pkg/outbox"
+	"emshop/pkg/storage"
 )
 
-func NewPaymentRPCServer(cfg *config.Config) (*rpcserver.Server, error) {
+// NewPaymentRPCServer 创建支付rpc服务，启用发件箱时一并返回需随应用启停的投递Worker
+func NewPaymentRPCServer(cfg *config.Config) (*rpcserver.Server, *outbox.Worker, error) {
 	//初始化open-telemetry的exporter
 	trace.InitAgent(trace.Options{
 		cfg.Telemetry.Name,
@@ -26,22 +34,62 @@ func NewPaymentRPCServer(cfg *config.Config) (*rpcserver.Server, error) {
 	dataFactory, err := v1data.NewDataFactory(cfg.MySQLOptions)
 	if err != nil {
 		log.Fatal(err.Error())
-		return nil, err
+		return nil, nil, err
+	}
+
+	events, worker, err := cfg.Outbox.NewWorker(dataFactory.DB())
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// 初始化服务工厂
-	paymentSrvFactory := v1service.NewService(dataFactory, cfg.Dtm, cfg.Redis)
+	paymentSrvFactory := v1service.NewService(dataFactory, cfg.Dtm, cfg.Redis, events)
 
 	// 创建gRPC服务器
 	paymentServer := payment.NewPaymentServer(paymentSrvFactory)
 	rpcAddr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
-	grpcServer := rpcserver.NewServer(
+	grpcOpts, err := cfg.Server.GRPCServerOptions()
+	if err != nil {
+		return nil, nil, err
+	}
+	serverOpts := []rpcserver.ServerOption{
 		rpcserver.WithAddress(rpcAddr),
 		rpcserver.WithMetrics(cfg.Server.EnableMetrics),
-	)
+		rpcserver.WithOptions(grpcOpts...),
+		rpcserver.WithAdaptiveLimit(cfg.Server.Limiter.AdaptiveLimiter(), paymentPriority),
+		rpcserver.WithLogAdmin(cfg.Server.LogAdminSecret),
+	}
+	// 调用方超时重试创建支付时携带相同的幂等键，直接返回首次创建的支付单
+	if cfg.Idempotency.Enabled {
+		go storage.ConnectToRedis(context.Background(), cfg.Redis.StorageConfig())
+		health.Register("redis", storage.Ping, health.Optional())
+		serverOpts = append(serverOpts, rpcserver.WithUnaryInterceptor(
+			srvintc.UnaryIdempotencyInterceptor(&srvintc.IdempotencyConfig{
+				Store:   idempotency.NewRedisStore(cfg.Idempotency.KeyPrefix),
+				Methods: cfg.Idempotency.Methods,
+				TTL:     cfg.Idempotency.TTL,
+				LockTTL: cfg.Idempotency.LockTTL,
+			}),
+		))
+	}
+	grpcServer := rpcserver.NewServer(serverOpts...)
 
 	// 注册服务
 	gpb.RegisterPaymentServer(grpcServer.Server, paymentServer)
 
-	return grpcServer, nil
+	return grpcServer, worker, nil
+}
+
+// paymentPriority 支付结果回调和补偿在过载时最后被拒绝，避免订单状态长时间悬而未决
+func paymentPriority(ctx context.Context, fullMethod string) limit.Priority {
+	switch fullMethod {
+	case gpb.Payment_ConfirmPayment_FullMethodName,
+		gpb.Payment_SimulatePaymentSuccess_FullMethodName,
+		gpb.Payment_SimulatePaymentFailure_FullMethodName,
+		gpb.Payment_CancelPayment_FullMethodName,
+		gpb.Payment_RefundPayment_FullMethodName,
+		gpb.Payment_ReleaseReserved_FullMethodName:
+		return limit.PriorityHigh
+	}
+	return srvintc.DefaultPriority(ctx, fullMethod)
 }
@@ -29,24 +29,24 @@ func NewDTMManager(dtmOpts *options.DtmOptions) *DTMManager {
 // ProcessOrderSubmission 处理订单提交分布式事务
 // 流程: 创建订单(待支付状态) → 创建支付订单 → 预留库存
 func (dm *DTMManager) ProcessOrderSubmission(ctx context.Context, req *OrderSubmissionRequest) error {
-	log.Infof("开始订单提交分布式事务, 订单号: %s", req.OrderSn)
+	log.InfofC(ctx, "开始订单提交分布式事务, 订单号: %s", req.OrderSn)
 
 	// TODO: 完整的DTM实现需要正确的protobuf消息类型
 	// 当前版本只做日志记录，实际DTM集成需要更多配置
 	
 	// 模拟事务步骤
-	log.Infof("步骤1: 创建订单(待支付状态) - 订单号: %s", req.OrderSn)
-	log.Infof("步骤2: 创建支付订单 - 金额: %.2f", req.Amount)
-	log.Infof("步骤3: 预留库存 - 商品数量: %d", len(req.GoodsDetail))
+	log.InfofC(ctx, "步骤1: 创建订单(待支付状态) - 订单号: %s", req.OrderSn)
+	log.InfofC(ctx, "步骤2: 创建支付订单 - 金额: %.2f", req.Amount)
+	log.InfofC(ctx, "步骤3: 预留库存 - 商品数量: %d", len(req.GoodsDetail))
 
-	log.Infof("订单提交分布式事务结构验证成功, 订单号: %s", req.OrderSn)
+	log.InfofC(ctx, "订单提交分布式事务结构验证成功, 订单号: %s", req.OrderSn)
 	return nil
 }
 
 // ProcessPaymentSuccess 处理支付成功分布式事务
 // 流程: 确认支付 → 更新订单状态为已支付 → 确认扣减库存 → 创建物流订单
 func (dm *DTMManager) ProcessPaymentSuccess(ctx context.Context, req *PaymentSuccessRequest) error {
-	log.Infof("开始支付成功分布式事务, 支付单号: %s", req.PaymentSn)
+	log.InfofC(ctx, "开始支付成功分布式事务, 支付单号: %s", req.PaymentSn)
 
 	// TODO: 完整的DTM实现需要正确的protobuf消息类型
 	// 当前版本只做日志记录，实际DTM集成需要更多配置
@@ -57,12 +57,12 @@ func (dm *DTMManager) ProcessPaymentSuccess(ctx context.Context, req *PaymentSuc
 		thirdPartySn = *req.ThirdPartySn
 	}
 	
-	log.Infof("步骤1: 确认支付成功 - 第三方支付单号: %s", thirdPartySn)
-	log.Infof("步骤2: 更新订单状态为已支付 - 订单号: %s", req.OrderSn)
-	log.Infof("步骤3: 确认扣减库存 - 订单号: %s", req.OrderSn)
-	log.Infof("步骤4: 创建物流订单 - 收货人: %s, 地址: %s", req.ReceiverName, req.ReceiverAddress)
+	log.InfofC(ctx, "步骤1: 确认支付成功 - 第三方支付单号: %s", thirdPartySn)
+	log.InfofC(ctx, "步骤2: 更新订单状态为已支付 - 订单号: %s", req.OrderSn)
+	log.InfofC(ctx, "步骤3: 确认扣减库存 - 订单号: %s", req.OrderSn)
+	log.InfofC(ctx, "步骤4: 创建物流订单 - 收货人: %s, 地址: %s", req.ReceiverName, req.ReceiverAddress)
 
-	log.Infof("支付成功分布式事务结构验证成功, 支付单号: %s", req.PaymentSn)
+	log.InfofC(ctx, "支付成功分布式事务结构验证成功, 支付单号: %s", req.PaymentSn)
 	return nil
 }
 
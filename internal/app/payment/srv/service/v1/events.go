@@ -0,0 +1,44 @@
+package v1
+
+import (
+	"time"
+
+	"emshop/internal/app/payment/srv/domain/do"
+)
+
+// 支付领域事件，经发件箱投递，消费方按事件ID去重
+const (
+	aggregatePayment = "payment"
+
+	EventPaymentCreated   = "payment.created"   // 支付单已创建
+	EventPaymentPaid      = "payment.paid"      // 支付成功
+	EventPaymentFailed    = "payment.failed"    // 支付失败
+	EventPaymentCancelled = "payment.cancelled" // 支付单已取消
+	EventPaymentRefunded  = "payment.refunded"  // 已退款
+)
+
+// PaymentEvent 支付事件内容，携带变更后的状态
+type PaymentEvent struct {
+	PaymentSn     string           `json:"payment_sn"`
+	OrderSn       string           `json:"order_sn"`
+	UserID        int32            `json:"user_id"`
+	Amount        float64          `json:"amount"`
+	PaymentMethod do.PaymentMethod `json:"payment_method"`
+	PaymentStatus do.PaymentStatus `json:"payment_status"`
+	ThirdPartySn  *string          `json:"third_party_sn,omitempty"`
+	RefundAmount  float64          `json:"refund_amount,omitempty"`
+	OccurredAt    time.Time        `json:"occurred_at"`
+}
+
+func newPaymentEvent(payment *do.PaymentOrderDO, status do.PaymentStatus) *PaymentEvent {
+	return &PaymentEvent{
+		PaymentSn:     payment.PaymentSn,
+		OrderSn:       payment.OrderSn,
+		UserID:        payment.UserID,
+		Amount:        payment.Amount,
+		PaymentMethod: payment.PaymentMethod,
+		PaymentStatus: status,
+		ThirdPartySn:  payment.ThirdPartySn,
+		OccurredAt:    time.Now(),
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"emshop/internal/app/pkg/options"
 	"emshop/pkg/errors"
 	"emshop/pkg/log"
+	"emshop/pkg/outbox"
 	"fmt"
 	"time"
 	"crypto/rand"
@@ -46,15 +47,17 @@ type paymentService struct {
 	dtmOpts      *options.DtmOptions
 	redisOptions *options.RedisOptions
 	dtmManager   *DTMManager
+	events       *outbox.Outbox
 }
 
 // NewPaymentService 创建支付服务实例
-func NewPaymentService(data interfaces.DataFactory, dtmOpts *options.DtmOptions, redisOpts *options.RedisOptions) PaymentSrv {
+func NewPaymentService(data interfaces.DataFactory, dtmOpts *options.DtmOptions, redisOpts *options.RedisOptions, events *outbox.Outbox) PaymentSrv {
 	return &paymentService{
 		data:         data,
 		dtmOpts:      dtmOpts,
 		redisOptions: redisOpts,
 		dtmManager:   NewDTMManager(dtmOpts),
+		events:       events,
 	}
 }
 
@@ -67,7 +70,7 @@ func (ps *paymentService) generatePaymentSn() string {
 
 // CreatePayment 创建支付订单
 func (ps *paymentService) CreatePayment(ctx context.Context, req *dto.CreatePaymentDTO) (*dto.PaymentStatusDTO, error) {
-	log.Infof("创建支付订单: 订单号=%s, 用户ID=%d, 金额=%f", req.OrderSn, req.UserID, req.Amount)
+	log.InfofC(ctx, "创建支付订单: 订单号=%s, 用户ID=%d, 金额=%f", req.OrderSn, req.UserID, req.Amount)
 
 	// 参数验证
 	if req.Amount <= 0 {
@@ -129,16 +132,22 @@ func (ps *paymentService) CreatePayment(ctx context.Context, req *dto.CreatePaym
 		OperatorType: "system",
 	}
 	if err := ps.data.PaymentLogs().Create(ctx, tx, paymentLog); err != nil {
-		log.Warnf("创建支付日志失败: %v", err)
+		log.WarnfC(ctx, "创建支付日志失败: %v", err)
 		// 日志失败不影响主流程
 	}
 
+	if err := ps.events.Add(ctx, tx, aggregatePayment, paymentSn, EventPaymentCreated, newPaymentEvent(payment, do.PaymentStatusPending)); err != nil {
+		tx.Rollback()
+		log.ErrorfC(ctx, "写入支付创建事件失败: 支付单号=%s, err=%v", paymentSn, err)
+		return nil, errors.WithCode(code.ErrConnectDB, "写入支付创建事件失败")
+	}
+
 	// 提交事务
 	if err := tx.Commit().Error; err != nil {
 		return nil, errors.WithCode(code.ErrConnectDB, "提交事务失败")
 	}
 
-	log.Infof("支付订单创建成功: 支付单号=%s", paymentSn)
+	log.InfofC(ctx, "支付订单创建成功: 支付单号=%s", paymentSn)
 
 	// 返回支付状态
 	return &dto.PaymentStatusDTO{
@@ -153,14 +162,14 @@ func (ps *paymentService) CreatePayment(ctx context.Context, req *dto.CreatePaym
 
 // CancelPayment 取消支付订单（补偿操作）
 func (ps *paymentService) CancelPayment(ctx context.Context, paymentSn string) error {
-	log.Infof("取消支付订单: 支付单号=%s", paymentSn)
+	log.InfofC(ctx, "取消支付订单: 支付单号=%s", paymentSn)
 
 	// 查询支付订单
 	payment, err := ps.data.PaymentOrders().Get(ctx, ps.data.DB(), paymentSn)
 	if err != nil {
 		if errors.IsCode(err, code.ErrPaymentNotFound) {
 			// 支付订单不存在，认为取消成功（幂等性）
-			log.Infof("支付订单不存在，取消操作成功: %s", paymentSn)
+			log.InfofC(ctx, "支付订单不存在，取消操作成功: %s", paymentSn)
 			return nil
 		}
 		return err
@@ -202,7 +211,13 @@ func (ps *paymentService) CancelPayment(ctx context.Context, paymentSn string) e
 		OperatorType: "system",
 	}
 	if err := ps.data.PaymentLogs().Create(ctx, tx, paymentLog); err != nil {
-		log.Warnf("创建支付日志失败: %v", err)
+		log.WarnfC(ctx, "创建支付日志失败: %v", err)
+	}
+
+	if err := ps.events.Add(ctx, tx, aggregatePayment, paymentSn, EventPaymentCancelled, newPaymentEvent(payment, do.PaymentStatusCancelled)); err != nil {
+		tx.Rollback()
+		log.ErrorfC(ctx, "写入支付取消事件失败: 支付单号=%s, err=%v", paymentSn, err)
+		return errors.WithCode(code.ErrConnectDB, "写入支付取消事件失败")
 	}
 
 	// 提交事务
@@ -210,13 +225,13 @@ func (ps *paymentService) CancelPayment(ctx context.Context, paymentSn string) e
 		return errors.WithCode(code.ErrConnectDB, "提交事务失败")
 	}
 
-	log.Infof("支付订单取消成功: 支付单号=%s", paymentSn)
+	log.InfofC(ctx, "支付订单取消成功: 支付单号=%s", paymentSn)
 	return nil
 }
 
 // ConfirmPayment 确认支付成功
 func (ps *paymentService) ConfirmPayment(ctx context.Context, req *dto.ConfirmPaymentDTO) error {
-	log.Infof("确认支付成功: 支付单号=%s", req.PaymentSn)
+	log.InfofC(ctx, "确认支付成功: 支付单号=%s", req.PaymentSn)
 
 	// 查询支付订单
 	payment, err := ps.data.PaymentOrders().Get(ctx, ps.data.DB(), req.PaymentSn)
@@ -272,7 +287,17 @@ func (ps *paymentService) ConfirmPayment(ctx context.Context, req *dto.ConfirmPa
 		OperatorType: "system",
 	}
 	if err := ps.data.PaymentLogs().Create(ctx, tx, paymentLog); err != nil {
-		log.Warnf("创建支付日志失败: %v", err)
+		log.WarnfC(ctx, "创建支付日志失败: %v", err)
+	}
+
+	if req.ThirdPartySn != nil {
+		payment.ThirdPartySn = req.ThirdPartySn
+		payment.PaidAt = &now
+	}
+	if err := ps.events.Add(ctx, tx, aggregatePayment, req.PaymentSn, EventPaymentPaid, newPaymentEvent(payment, do.PaymentStatusPaid)); err != nil {
+		tx.Rollback()
+		log.ErrorfC(ctx, "写入支付成功事件失败: 支付单号=%s, err=%v", req.PaymentSn, err)
+		return errors.WithCode(code.ErrConnectDB, "写入支付成功事件失败")
 	}
 
 	// 提交事务
@@ -280,13 +305,13 @@ func (ps *paymentService) ConfirmPayment(ctx context.Context, req *dto.ConfirmPa
 		return errors.WithCode(code.ErrConnectDB, "提交事务失败")
 	}
 
-	log.Infof("确认支付成功完成: 支付单号=%s", req.PaymentSn)
+	log.InfofC(ctx, "确认支付成功完成: 支付单号=%s", req.PaymentSn)
 	return nil
 }
 
 // RefundPayment 退款（补偿操作）
 func (ps *paymentService) RefundPayment(ctx context.Context, req *dto.RefundPaymentDTO) error {
-	log.Infof("执行退款: 支付单号=%s, 退款金额=%f", req.PaymentSn, req.RefundAmount)
+	log.InfofC(ctx, "执行退款: 支付单号=%s, 退款金额=%f", req.PaymentSn, req.RefundAmount)
 
 	// 查询支付订单
 	payment, err := ps.data.PaymentOrders().Get(ctx, ps.data.DB(), req.PaymentSn)
@@ -347,7 +372,15 @@ func (ps *paymentService) RefundPayment(ctx context.Context, req *dto.RefundPaym
 		OperatorType: "system",
 	}
 	if err := ps.data.PaymentLogs().Create(ctx, tx, paymentLog); err != nil {
-		log.Warnf("创建支付日志失败: %v", err)
+		log.WarnfC(ctx, "创建支付日志失败: %v", err)
+	}
+
+	refunded := newPaymentEvent(payment, do.PaymentStatusRefunded)
+	refunded.RefundAmount = req.RefundAmount
+	if err := ps.events.Add(ctx, tx, aggregatePayment, req.PaymentSn, EventPaymentRefunded, refunded); err != nil {
+		tx.Rollback()
+		log.ErrorfC(ctx, "写入退款事件失败: 支付单号=%s, err=%v", req.PaymentSn, err)
+		return errors.WithCode(code.ErrConnectDB, "写入退款事件失败")
 	}
 
 	// 提交事务
@@ -355,7 +388,7 @@ func (ps *paymentService) RefundPayment(ctx context.Context, req *dto.RefundPaym
 		return errors.WithCode(code.ErrConnectDB, "提交事务失败")
 	}
 
-	log.Infof("退款成功: 支付单号=%s", req.PaymentSn)
+	log.InfofC(ctx, "退款成功: 支付单号=%s", req.PaymentSn)
 	return nil
 }
 
@@ -379,7 +412,7 @@ func (ps *paymentService) GetPaymentStatus(ctx context.Context, paymentSn string
 
 // SimulatePaymentSuccess 模拟支付成功
 func (ps *paymentService) SimulatePaymentSuccess(ctx context.Context, paymentSn string, thirdPartySn *string) error {
-	log.Infof("模拟支付成功: 支付单号=%s", paymentSn)
+	log.InfofC(ctx, "模拟支付成功: 支付单号=%s", paymentSn)
 	
 	return ps.ConfirmPayment(ctx, &dto.ConfirmPaymentDTO{
 		PaymentSn:    paymentSn,
@@ -389,7 +422,7 @@ func (ps *paymentService) SimulatePaymentSuccess(ctx context.Context, paymentSn
 
 // SimulatePaymentFailure 模拟支付失败
 func (ps *paymentService) SimulatePaymentFailure(ctx context.Context, paymentSn string) error {
-	log.Infof("模拟支付失败: 支付单号=%s", paymentSn)
+	log.InfofC(ctx, "模拟支付失败: 支付单号=%s", paymentSn)
 
 	// 查询支付订单
 	payment, err := ps.data.PaymentOrders().Get(ctx, ps.data.DB(), paymentSn)
@@ -433,7 +466,13 @@ func (ps *paymentService) SimulatePaymentFailure(ctx context.Context, paymentSn
 		OperatorType: "system",
 	}
 	if err := ps.data.PaymentLogs().Create(ctx, tx, paymentLog); err != nil {
-		log.Warnf("创建支付日志失败: %v", err)
+		log.WarnfC(ctx, "创建支付日志失败: %v", err)
+	}
+
+	if err := ps.events.Add(ctx, tx, aggregatePayment, paymentSn, EventPaymentFailed, newPaymentEvent(payment, do.PaymentStatusFailed)); err != nil {
+		tx.Rollback()
+		log.ErrorfC(ctx, "写入支付失败事件失败: 支付单号=%s, err=%v", paymentSn, err)
+		return errors.WithCode(code.ErrConnectDB, "写入支付失败事件失败")
 	}
 
 	// 提交事务
@@ -441,13 +480,13 @@ func (ps *paymentService) SimulatePaymentFailure(ctx context.Context, paymentSn
 		return errors.WithCode(code.ErrConnectDB, "提交事务失败")
 	}
 
-	log.Infof("模拟支付失败完成: 支付单号=%s", paymentSn)
+	log.InfofC(ctx, "模拟支付失败完成: 支付单号=%s", paymentSn)
 	return nil
 }
 
 // ReserveStock 预留库存（用于订单提交事务）
 func (ps *paymentService) ReserveStock(ctx context.Context, req *dto.ReserveStockDTO) error {
-	log.Infof("预留库存: 订单号=%s, 商品数量=%d", req.OrderSn, len(req.GoodsInfo))
+	log.InfofC(ctx, "预留库存: 订单号=%s, 商品数量=%d", req.OrderSn, len(req.GoodsInfo))
 
 	if len(req.GoodsInfo) == 0 {
 		return nil
@@ -463,7 +502,7 @@ func (ps *paymentService) ReserveStock(ctx context.Context, req *dto.ReserveStoc
 		// 已经预留过，检查状态
 		for _, reservation := range existingReservations {
 			if reservation.Status == do.StockReservationStatusReserved {
-				log.Infof("库存已预留，操作成功: 订单号=%s", req.OrderSn)
+				log.InfofC(ctx, "库存已预留，操作成功: 订单号=%s", req.OrderSn)
 				return nil // 幂等性
 			}
 		}
@@ -502,13 +541,13 @@ func (ps *paymentService) ReserveStock(ctx context.Context, req *dto.ReserveStoc
 		return errors.WithCode(code.ErrConnectDB, "提交事务失败")
 	}
 
-	log.Infof("库存预留成功: 订单号=%s", req.OrderSn)
+	log.InfofC(ctx, "库存预留成功: 订单号=%s", req.OrderSn)
 	return nil
 }
 
 // ReleaseReserved 释放预留库存（补偿操作）
 func (ps *paymentService) ReleaseReserved(ctx context.Context, req *dto.ReleaseReservedDTO) error {
-	log.Infof("释放预留库存: 订单号=%s, 商品数量=%d", req.OrderSn, len(req.GoodsInfo))
+	log.InfofC(ctx, "释放预留库存: 订单号=%s, 商品数量=%d", req.OrderSn, len(req.GoodsInfo))
 
 	if len(req.GoodsInfo) == 0 {
 		return nil
@@ -519,7 +558,7 @@ func (ps *paymentService) ReleaseReserved(ctx context.Context, req *dto.ReleaseR
 	if err != nil {
 		if errors.IsCode(err, code.ErrStockReservationNotFound) {
 			// 没有预留记录，认为释放成功（幂等性）
-			log.Infof("没有预留记录，释放操作成功: 订单号=%s", req.OrderSn)
+			log.InfofC(ctx, "没有预留记录，释放操作成功: 订单号=%s", req.OrderSn)
 			return nil
 		}
 		return err
@@ -534,7 +573,7 @@ func (ps *paymentService) ReleaseReserved(ctx context.Context, req *dto.ReleaseR
 		}
 	}
 	if allReleased {
-		log.Infof("库存已释放，操作成功: 订单号=%s", req.OrderSn)
+		log.InfofC(ctx, "库存已释放，操作成功: 订单号=%s", req.OrderSn)
 		return nil
 	}
 
@@ -558,7 +597,7 @@ func (ps *paymentService) ReleaseReserved(ctx context.Context, req *dto.ReleaseR
 		return errors.WithCode(code.ErrConnectDB, "提交事务失败")
 	}
 
-	log.Infof("释放预留库存成功: 订单号=%s", req.OrderSn)
+	log.InfofC(ctx, "释放预留库存成功: 订单号=%s", req.OrderSn)
 	return nil
 }
 
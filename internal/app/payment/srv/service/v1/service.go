@@ -3,6 +3,7 @@ package v1
 import (
 	"emshop/internal/app/payment/srv/data/v1/interfaces"
 	"emshop/internal/app/pkg/options"
+	"emshop/pkg/outbox"
 )
 
 // Service 支付服务工厂
@@ -11,8 +12,8 @@ type Service struct {
 }
 
 // NewService 创建支付服务工厂
-func NewService(data interfaces.DataFactory, dtmOpts *options.DtmOptions, redisOpts *options.RedisOptions) *Service {
+func NewService(data interfaces.DataFactory, dtmOpts *options.DtmOptions, redisOpts *options.RedisOptions, events *outbox.Outbox) *Service {
 	return &Service{
-		PaymentSrv: NewPaymentService(data, dtmOpts, redisOpts),
+		PaymentSrv: NewPaymentService(data, dtmOpts, redisOpts, events),
 	}
 }
\ No newline at end of file
@@ -8,6 +8,11 @@ package code
 func init() {
 	register(ErrConnectDB, 500, "Init db error")
 	register(ErrConnectGRPC, 500, "Connect to grpc error")
+	register(ErrDeadLetterNamespaceNotFound, 404, "Dead-letter namespace not found")
+	register(ErrDeadLetterNotFound, 404, "Dead-letter message not found")
+	register(ErrDeadLetterStateInvalid, 409, "Operation not allowed in current dead-letter state")
+	register(ErrDeadLetterReplayDisabled, 422, "Dead-letter replay not configured")
+	register(ErrDeadLetterStoreUnavailable, 503, "Dead-letter store unavailable")
 	register(ErrGoodsNotFound, 404, "Goods not found")
 	register(ErrCategoryNotFound, 404, "Category not found")
 	register(ErrBrandNotFound, 404, "Brand not found")
@@ -19,6 +24,10 @@ func init() {
 	register(ErrInventoryNotFound, 404, "Inventory not found")
 	register(ErrInvSellDetailNotFound, 400, "Inventory sell detail not found")
 	register(ErrInvNotEnough, 400, "Inventory not enough")
+	register(ErrJobNamespaceNotFound, 404, "Job namespace not found")
+	register(ErrJobNotFound, 404, "Job not found")
+	register(ErrJobStateInvalid, 409, "Operation not allowed in current job state")
+	register(ErrJobStoreUnavailable, 503, "Job store unavailable")
 	register(ErrLogisticsOrderNotFound, 404, "Logistics order not found")
 	register(ErrLogisticsOrderExists, 400, "Logistics order already exists")
 	register(ErrCreateLogisticsOrderFailed, 500, "Create logistics order failed")
@@ -44,9 +53,13 @@ func init() {
 	register(ErrLogisticsServiceUnavailable, 500, "Logistics service unavailable")
 	register(ErrLogisticsDataIntegrityError, 500, "Logistics data integrity error")
 	register(ErrLogisticsOperationTimeout, 500, "Logistics operation timeout")
+	register(ErrShippingRateConfigNotFound, 404, "Shipping rate config not found")
+	register(ErrShippingRateConfigInvalid, 400, "Invalid shipping rate config")
+	register(ErrCarrierSignatureInvalid, 401, "Invalid carrier push signature")
 	register(ErrShopCartItemNotFound, 404, "ShopCart item not found")
 	register(ErrSubmitOrder, 400, "Submit order error")
 	register(ErrNoGoodsSelect, 404, "No Goods selected")
+	register(ErrOrderClosed, 409, "Order has been closed")
 	register(ErrPaymentNotFound, 404, "Payment order not found")
 	register(ErrPaymentExists, 400, "Payment order already exists")
 	register(ErrPaymentStatusInvalid, 400, "Payment status invalid")
@@ -93,4 +106,14 @@ func init() {
 	register(101016, 409, "Flash sale stock is empty")       // 库存冲突
 	register(101017, 429, "User flash sale participation limit exceeded") // 限流场景
 	register(101018, 409, "User has already participated in this flash sale") // 冲突状态
+	register(101019, 404, "Promo code is invalid")
+	register(101020, 409, "Promo code has been fully redeemed")           // 冲突状态
+	register(101021, 409, "Promo code already redeemed by user")          // 冲突状态
+	register(101022, 429, "Too many promo code redemption attempts")      // 限流场景
+	register(101023, 409, "Campaign status does not allow this operation") // 冲突状态
+	register(101024, 403, "Flash sale admission token is missing or invalid")
+	register(101025, 503, "Flash sale waiting room is full")              // 排队已满，稍后重试
+	register(101026, 403, "Captcha verification required by risk control") // 完成验证码后重试
+	register(101027, 403, "Request blocked by risk control")
+	register(101028, 404, "Flash sale reservation not found")
 }
@@ -54,4 +54,34 @@ const (
 
 	// ErrFlashSaleAlreadyParticipated - 400: User has already participated in this flash sale.
 	ErrFlashSaleAlreadyParticipated
+
+	// ErrPromoCodeInvalid - 404: Promo code is invalid.
+	ErrPromoCodeInvalid
+
+	// ErrPromoCodeExhausted - 409: Promo code has been fully redeemed.
+	ErrPromoCodeExhausted
+
+	// ErrPromoCodeRedeemed - 409: Promo code already redeemed by user.
+	ErrPromoCodeRedeemed
+
+	// ErrPromoCodeRateLimited - 429: Too many promo code redemption attempts.
+	ErrPromoCodeRateLimited
+
+	// ErrCampaignStateConflict - 409: Campaign status does not allow this operation.
+	ErrCampaignStateConflict
+
+	// ErrFlashSaleAdmissionRequired - 403: Flash sale admission token is missing or invalid.
+	ErrFlashSaleAdmissionRequired
+
+	// ErrFlashSaleQueueFull - 503: Flash sale waiting room is full.
+	ErrFlashSaleQueueFull
+
+	// ErrRiskCaptchaRequired - 403: Captcha verification required by risk control.
+	ErrRiskCaptchaRequired
+
+	// ErrRiskBlocked - 403: Request blocked by risk control.
+	ErrRiskBlocked
+
+	// ErrFlashSaleReservationNotFound - 404: Flash sale reservation not found.
+	ErrFlashSaleReservationNotFound
 )
\ No newline at end of file
@@ -0,0 +1,18 @@
+package code
+
+const (
+	// ErrDeadLetterNamespaceNotFound - 404: Dead-letter namespace not found.
+	ErrDeadLetterNamespaceNotFound int = iota + 101201
+
+	// ErrDeadLetterNotFound - 404: Dead-letter message not found.
+	ErrDeadLetterNotFound
+
+	// ErrDeadLetterStateInvalid - 409: Operation not allowed in current dead-letter state.
+	ErrDeadLetterStateInvalid
+
+	// ErrDeadLetterReplayDisabled - 422: Dead-letter replay not configured.
+	ErrDeadLetterReplayDisabled
+
+	// ErrDeadLetterStoreUnavailable - 503: Dead-letter store unavailable.
+	ErrDeadLetterStoreUnavailable
+)
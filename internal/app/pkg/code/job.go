@@ -0,0 +1,15 @@
+package code
+
+const (
+	// ErrJobNamespaceNotFound - 404: Job namespace not found.
+	ErrJobNamespaceNotFound int = iota + 101101
+
+	// ErrJobNotFound - 404: Job not found.
+	ErrJobNotFound
+
+	// ErrJobStateInvalid - 409: Operation not allowed in current job state.
+	ErrJobStateInvalid
+
+	// ErrJobStoreUnavailable - 503: Job store unavailable.
+	ErrJobStoreUnavailable
+)
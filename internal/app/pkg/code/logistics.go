@@ -84,5 +84,14 @@ const (
 
 	// ErrLogisticsOrderUpdateFailed - 500: Logistics order update failed.
 	ErrLogisticsOrderUpdateFailed
+
+	// ErrShippingRateConfigNotFound - 404: Shipping rate config not found.
+	ErrShippingRateConfigNotFound
+
+	// ErrShippingRateConfigInvalid - 400: Invalid shipping rate config.
+	ErrShippingRateConfigInvalid
+
+	// ErrCarrierSignatureInvalid - 401: Invalid carrier push signature.
+	ErrCarrierSignatureInvalid
 )
 
@@ -9,4 +9,7 @@ const (
 
 	// ErrOrderNotFound - 404: No Goods selected.
 	ErrNoGoodsSelect
+
+	// ErrOrderClosed - 409: Order has been closed.
+	ErrOrderClosed
 )
@@ -0,0 +1,123 @@
+// Package discovery 根据 RegistryOptions 创建服务注册与发现的实现，各服务共用
+package discovery
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"emshop/gin-micro/registry"
+	"emshop/gin-micro/registry/consul"
+	"emshop/gin-micro/registry/etcd"
+	"emshop/gin-micro/registry/file"
+	"emshop/gin-micro/registry/memory"
+	"emshop/internal/app/pkg/options"
+
+	"github.com/hashicorp/consul/api"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// 内存注册中心只在单个进程内有效，注册和发现共用同一个实例
+var sharedMemory = memory.New()
+
+// NewRegistrar 创建服务注册器，开发环境缩短失效实例的剔除时间
+func NewRegistrar(opts *options.RegistryOptions, dev bool) (registry.Registrar, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("registry配置为空")
+	}
+	switch opts.Type {
+	case "", options.RegistryConsul:
+		return newConsul(opts, dev)
+	case options.RegistryEtcd:
+		return newEtcd(opts, dev)
+	case options.RegistryFile:
+		return file.New(opts.File)
+	case options.RegistryMemory:
+		return sharedMemory, nil
+	default:
+		return nil, fmt.Errorf("unsupported registry type %q", opts.Type)
+	}
+}
+
+// NewDiscovery 创建服务发现
+func NewDiscovery(opts *options.RegistryOptions) (registry.Discovery, error) {
+	if opts == nil {
+		return nil, fmt.Errorf("registry配置为空")
+	}
+	switch opts.Type {
+	case "", options.RegistryConsul:
+		cli, err := newConsulClient(opts)
+		if err != nil {
+			return nil, err
+		}
+		return consul.New(cli, consul.WithHealthCheck(true)), nil
+	case options.RegistryEtcd:
+		return newEtcd(opts, false)
+	case options.RegistryFile:
+		return file.New(opts.File)
+	case options.RegistryMemory:
+		return sharedMemory, nil
+	default:
+		return nil, fmt.Errorf("unsupported registry type %q", opts.Type)
+	}
+}
+
+func newConsulClient(opts *options.RegistryOptions) (*api.Client, error) {
+	c := api.DefaultConfig()
+	if opts.Address != "" {
+		c.Address = opts.Address
+	}
+	if opts.Scheme != "" {
+		c.Scheme = opts.Scheme
+	}
+	cli, err := api.NewClient(c)
+	if err != nil {
+		return nil, fmt.Errorf("创建Consul客户端失败: %w", err)
+	}
+	return cli, nil
+}
+
+func newConsul(opts *options.RegistryOptions, dev bool) (*consul.Registry, error) {
+	cli, err := newConsulClient(opts)
+	if err != nil {
+		return nil, err
+	}
+	copts := []consul.Option{consul.WithHealthCheck(true)}
+	if opts.HealthCheckInterval > 0 {
+		copts = append(copts, consul.WithHealthCheckInterval(opts.HealthCheckInterval))
+	}
+	if opts.CheckTimeout > 0 {
+		copts = append(copts, consul.WithCheckTimeout(opts.CheckTimeout))
+	}
+	if dev {
+		copts = append(copts, consul.WithDeregisterCriticalServiceAfter(60))
+	} else if opts.DeregisterCriticalAfter > 0 {
+		copts = append(copts, consul.WithDeregisterCriticalServiceAfter(opts.DeregisterCriticalAfter))
+	}
+	return consul.New(cli, copts...), nil
+}
+
+func newEtcd(opts *options.RegistryOptions, dev bool) (*etcd.Registry, error) {
+	var endpoints []string
+	for _, ep := range strings.Split(opts.Address, ",") {
+		if ep = strings.TrimSpace(ep); ep != "" {
+			endpoints = append(endpoints, ep)
+		}
+	}
+	timeout := 5 * time.Second
+	if opts.CheckTimeout > 0 {
+		timeout = time.Duration(opts.CheckTimeout) * time.Second
+	}
+	cli, err := clientv3.New(clientv3.Config{
+		Endpoints:   endpoints,
+		DialTimeout: timeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("创建etcd客户端失败: %w", err)
+	}
+	var eopts []etcd.Option
+	if dev {
+		eopts = append(eopts, etcd.WithRegisterTTL(5*time.Second))
+	}
+	return etcd.New(cli, eopts...), nil
+}
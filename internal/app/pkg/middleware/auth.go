@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"strconv"
+
 	"github.com/gin-gonic/gin"
 	"emshop/internal/app/pkg/options"
 	"emshop/gin-micro/server/rest-server/middlewares"
@@ -46,6 +48,7 @@ func (b *businessJWTStrategy) AuthFunc() gin.HandlerFunc {
 
 		// 设置业务上下文
 		c.Set(jwtpkg.KeyUserID, int(claims.ID))
+		middlewares.SetLogValues(c, log.KeyUserID, strconv.FormatUint(uint64(claims.ID), 10))
 		c.Set(jwtpkg.KeyUserRole, int(claims.AuthorityId))
 		c.Next()
 	}
@@ -71,7 +74,7 @@ func (a *adminAuthStrategy) AuthFunc() gin.HandlerFunc {
 		// 使用框架层的通用token提取工具
 		token := middlewares.ExtractToken(c)
 		if token == "" {
-			log.Warn("Admin access denied: missing token")
+			log.WarnC(c, "Admin access denied: missing token")
 			core.WriteResponse(c, errors.WithCode(code.ErrInvalidAuthHeader, "管理员认证失败：缺少认证令牌"), nil)
 			c.Abort()
 			return
@@ -80,7 +83,7 @@ func (a *adminAuthStrategy) AuthFunc() gin.HandlerFunc {
 		// 使用业务JWT工具解析
 		claims, err := a.jwtTool.ParseToken(token)
 		if err != nil {
-			log.Warnf("Admin access denied: invalid token - %v", err)
+			log.WarnfC(c, "Admin access denied: invalid token - %v", err)
 			core.WriteResponse(c, errors.WithCode(code.ErrSignatureInvalid, "管理员认证失败：无效的认证令牌"), nil)
 			c.Abort()
 			return
@@ -89,17 +92,18 @@ func (a *adminAuthStrategy) AuthFunc() gin.HandlerFunc {
 		// 验证管理员权限：角色必须 >= 管理员
 		userRole := int(claims.AuthorityId)
 		if userRole < jwtpkg.RoleAdmin {
-			log.Warnf("Admin access denied: insufficient privileges - userID: %d, role: %d", claims.ID, userRole)
+			log.WarnfC(c, "Admin access denied: insufficient privileges - userID: %d, role: %d", claims.ID, userRole)
 			core.WriteResponse(c, errors.WithCode(code.ErrPermissionDenied, "权限不足：需要管理员权限"), nil)
 			c.Abort()
 			return
 		}
 
 		// 记录管理员访问日志
-		log.Infof("Admin access granted - userID: %d, role: %d, path: %s", claims.ID, userRole, c.Request.URL.Path)
+		log.InfofC(c, "Admin access granted - userID: %d, role: %d, path: %s", claims.ID, userRole, c.Request.URL.Path)
 
 		// 设置业务上下文
 		c.Set(jwtpkg.KeyUserID, int(claims.ID))
+		middlewares.SetLogValues(c, log.KeyUserID, strconv.FormatUint(uint64(claims.ID), 10))
 		c.Set(jwtpkg.KeyUserRole, userRole)
 		c.Next()
 	}
@@ -125,7 +129,7 @@ func (s *superAdminAuthStrategy) AuthFunc() gin.HandlerFunc {
 		// 使用框架层的通用token提取工具
 		token := middlewares.ExtractToken(c)
 		if token == "" {
-			log.Warn("Super admin access denied: missing token")
+			log.WarnC(c, "Super admin access denied: missing token")
 			core.WriteResponse(c, errors.WithCode(code.ErrInvalidAuthHeader, "超级管理员认证失败：缺少认证令牌"), nil)
 			c.Abort()
 			return
@@ -134,7 +138,7 @@ func (s *superAdminAuthStrategy) AuthFunc() gin.HandlerFunc {
 		// 使用业务JWT工具解析
 		claims, err := s.jwtTool.ParseToken(token)
 		if err != nil {
-			log.Warnf("Super admin access denied: invalid token - %v", err)
+			log.WarnfC(c, "Super admin access denied: invalid token - %v", err)
 			core.WriteResponse(c, errors.WithCode(code.ErrSignatureInvalid, "超级管理员认证失败：无效的认证令牌"), nil)
 			c.Abort()
 			return
@@ -143,17 +147,18 @@ func (s *superAdminAuthStrategy) AuthFunc() gin.HandlerFunc {
 		// 验证超级管理员权限：角色必须 >= 超级管理员
 		userRole := int(claims.AuthorityId)
 		if userRole < jwtpkg.RoleSuper {
-			log.Warnf("Super admin access denied: insufficient privileges - userID: %d, role: %d", claims.ID, userRole)
+			log.WarnfC(c, "Super admin access denied: insufficient privileges - userID: %d, role: %d", claims.ID, userRole)
 			core.WriteResponse(c, errors.WithCode(code.ErrPermissionDenied, "权限不足：需要超级管理员权限"), nil)
 			c.Abort()
 			return
 		}
 
 		// 记录超级管理员访问日志
-		log.Infof("Super admin access granted - userID: %d, role: %d, path: %s", claims.ID, userRole, c.Request.URL.Path)
+		log.InfofC(c, "Super admin access granted - userID: %d, role: %d, path: %s", claims.ID, userRole, c.Request.URL.Path)
 
 		// 设置业务上下文
 		c.Set(jwtpkg.KeyUserID, int(claims.ID))
+		middlewares.SetLogValues(c, log.KeyUserID, strconv.FormatUint(uint64(claims.ID), 10))
 		c.Set(jwtpkg.KeyUserRole, userRole)
 		c.Next()
 	}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"context"
+	"strconv"
+
+	"emshop/gin-micro/core/idempotency"
+	"emshop/gin-micro/server/rest-server/middlewares"
+	"emshop/internal/app/pkg/options"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Idempotency 创建网关幂等键中间件，需在注册路由前全局安装
+//
+// 幂等记录保存在 storage.ConnectToRedis 连接的Redis中，Redis不可用时不做去重。
+// 同一幂等键按登录用户隔离，未登录的请求按客户端IP隔离。
+func Idempotency(opts *options.IdempotencyOptions, jwtOpts *options.JwtOptions) gin.HandlerFunc {
+	routes := make([]middlewares.IdempotencyRoute, 0, len(opts.Routes))
+	for _, r := range opts.Routes {
+		routes = append(routes, middlewares.IdempotencyRoute{Method: r.Method, Path: r.Path})
+	}
+
+	userID := requestUserID(jwtOpts)
+	return middlewares.Idempotency(&middlewares.IdempotencyConfig{
+		Store:   idempotency.NewRedisStore(opts.KeyPrefix),
+		Routes:  routes,
+		TTL:     opts.TTL,
+		LockTTL: opts.LockTTL,
+		Scope: func(c *gin.Context) string {
+			if id := userID(c); id != "" {
+				return "user:" + id
+			}
+			return "ip:" + c.ClientIP()
+		},
+	})
+}
+
+// IdempotentContext 把请求头中的幂等键转发到下游 gRPC 调用的元数据，
+// 与网关去重一致按登录用户隔离，未登录的请求按客户端IP隔离。请求未携带幂等键时原样返回。
+func IdempotentContext(c *gin.Context) context.Context {
+	key := c.GetHeader(idempotency.HeaderKey)
+	if key == "" {
+		return c
+	}
+	scope := "ip:" + c.ClientIP()
+	if uid, ok := GetUserIDFromContext(c); ok && uid > 0 {
+		scope = "user:" + strconv.Itoa(uid)
+	}
+	return idempotency.NewOutgoingContext(c, scope, key)
+}
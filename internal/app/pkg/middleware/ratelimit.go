@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"strconv"
+
+	"emshop/gin-micro/core/limit"
+	"emshop/gin-micro/server/rest-server/middlewares"
+	jwtpkg "emshop/internal/app/pkg/jwt"
+	"emshop/internal/app/pkg/options"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit 创建网关调用方限流中间件，需在注册路由前全局安装
+//
+// 计数保存在 storage.ConnectToRedis 连接的Redis中，多个网关实例共享额度，Redis不可用时降级为进程内限流。
+// 中间件在路由的认证中间件之前执行，user 维度直接解析请求携带的令牌，令牌无效时跳过该策略交给认证中间件拒绝。
+func RateLimit(opts *options.RateLimitOptions, jwtOpts *options.JwtOptions) gin.HandlerFunc {
+	policies := make([]middlewares.RateLimitPolicy, 0, len(opts.Policies))
+	for _, p := range opts.Policies {
+		policies = append(policies, middlewares.RateLimitPolicy{
+			Method: p.Method,
+			Path:   p.Path,
+			Key:    p.Key,
+			Limit:  p.Limit,
+			Window: p.Window,
+		})
+	}
+
+	return middlewares.RateLimit(&middlewares.RateLimitConfig{
+		Store:    limit.NewFallbackRateStore(limit.NewRedisRateStore(opts.KeyPrefix), limit.NewLocalRateStore()),
+		Policies: policies,
+		KeyFuncs: map[string]middlewares.RateLimitKeyFunc{
+			middlewares.RateLimitKeyUser: requestUserID(jwtOpts),
+		},
+	})
+}
+
+// requestUserID 读取登录用户ID，供在认证中间件之前执行的全局中间件使用
+//
+// 认证中间件已执行时直接读取上下文，否则解析请求携带的令牌，令牌无效时返回空字符串。
+func requestUserID(jwtOpts *options.JwtOptions) middlewares.RateLimitKeyFunc {
+	jwtTool := jwtpkg.NewEmshopJWT(jwtOpts.Key)
+	return func(c *gin.Context) string {
+		if id, ok := GetUserIDFromContext(c); ok {
+			return strconv.Itoa(id)
+		}
+		token := middlewares.ExtractToken(c)
+		if token == "" {
+			return ""
+		}
+		claims, err := jwtTool.ParseToken(token)
+		if err != nil {
+			return ""
+		}
+		return strconv.FormatUint(uint64(claims.ID), 10)
+	}
+}
@@ -0,0 +1,77 @@
+package options
+
+import (
+	"fmt"
+	"time"
+
+	"emshop/pkg/delayjob"
+
+	"github.com/spf13/pflag"
+)
+
+// DelayJobOptions 延时任务调度配置
+type DelayJobOptions struct {
+	// Namespace 本服务任务所在的命名空间，默认使用服务名
+	Namespace    string        `mapstructure:"namespace" json:"namespace"`
+	PollInterval time.Duration `mapstructure:"poll-interval" json:"poll-interval"`
+	Lease        time.Duration `mapstructure:"lease" json:"lease"`
+	Concurrency  int           `mapstructure:"concurrency" json:"concurrency"`
+	MaxAttempts  int           `mapstructure:"max-attempts" json:"max-attempts"`
+	Retention    time.Duration `mapstructure:"retention" json:"retention"`
+	HistoryMax   int           `mapstructure:"history-max" json:"history-max"`
+	// Namespaces 管理端可查看的命名空间列表
+	Namespaces []string `mapstructure:"namespaces" json:"namespaces"`
+}
+
+func NewDelayJobOptions() *DelayJobOptions {
+	return &DelayJobOptions{
+		PollInterval: time.Second,
+		Lease:        time.Minute,
+		Concurrency:  8,
+		MaxAttempts:  5,
+		Retention:    7 * 24 * time.Hour,
+		HistoryMax:   20,
+		Namespaces:   []string{},
+	}
+}
+
+func (o *DelayJobOptions) Validate() []error {
+	errs := []error{}
+	if o.Lease < time.Second {
+		errs = append(errs, fmt.Errorf("delayjob.lease must be at least 1s"))
+	}
+	if o.Concurrency <= 0 {
+		errs = append(errs, fmt.Errorf("delayjob.concurrency must be greater than 0"))
+	}
+	return errs
+}
+
+func (o *DelayJobOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Namespace, "delayjob.namespace", o.Namespace, "Namespace of delayed jobs, defaults to the service name.")
+	fs.DurationVar(&o.PollInterval, "delayjob.poll-interval", o.PollInterval, "Interval for polling due jobs.")
+	fs.DurationVar(&o.Lease, "delayjob.lease", o.Lease, "Lease duration of an acquired job, expired leases are taken over by other replicas.")
+	fs.IntVar(&o.Concurrency, "delayjob.concurrency", o.Concurrency, "Max number of jobs executed concurrently.")
+	fs.IntVar(&o.MaxAttempts, "delayjob.max-attempts", o.MaxAttempts, "Default max attempts of a job.")
+	fs.DurationVar(&o.Retention, "delayjob.retention", o.Retention, "Retention of finished jobs and their history.")
+	fs.IntVar(&o.HistoryMax, "delayjob.history-max", o.HistoryMax, "Max attempt records kept per job.")
+	fs.StringSliceVar(&o.Namespaces, "delayjob.namespaces", o.Namespaces, "Namespaces visible to the admin job endpoints.")
+}
+
+// StoreOptions 转换为Redis存储配置
+func (o *DelayJobOptions) StoreOptions(namespace string) delayjob.RedisOptions {
+	return delayjob.RedisOptions{
+		Namespace:  namespace,
+		Retention:  o.Retention,
+		HistoryMax: o.HistoryMax,
+	}
+}
+
+// SchedulerOptions 转换为调度器选项
+func (o *DelayJobOptions) SchedulerOptions() []delayjob.Option {
+	return []delayjob.Option{
+		delayjob.WithPollInterval(o.PollInterval),
+		delayjob.WithLease(o.Lease),
+		delayjob.WithConcurrency(o.Concurrency),
+		delayjob.WithMaxAttempts(o.MaxAttempts),
+	}
+}
@@ -0,0 +1,52 @@
+package options
+
+import (
+	"fmt"
+	"time"
+
+	"emshop/pkg/dlq"
+
+	"github.com/spf13/pflag"
+)
+
+// DLQOptions 死信存储配置
+type DLQOptions struct {
+	// Namespace 本服务死信所在的命名空间，默认使用服务名
+	Namespace string        `mapstructure:"namespace" json:"namespace"`
+	Retention time.Duration `mapstructure:"retention" json:"retention"`
+	// Namespaces 管理端可查看的命名空间列表
+	Namespaces []string `mapstructure:"namespaces" json:"namespaces"`
+	// NameServers 管理端重放死信使用的RocketMQ地址，为空时只读
+	NameServers []string `mapstructure:"name-servers" json:"name-servers"`
+}
+
+func NewDLQOptions() *DLQOptions {
+	return &DLQOptions{
+		Retention:   7 * 24 * time.Hour,
+		Namespaces:  []string{},
+		NameServers: []string{},
+	}
+}
+
+func (o *DLQOptions) Validate() []error {
+	errs := []error{}
+	if o.Retention < time.Minute {
+		errs = append(errs, fmt.Errorf("dlq.retention must be at least 1m"))
+	}
+	return errs
+}
+
+func (o *DLQOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Namespace, "dlq.namespace", o.Namespace, "Namespace of dead-letter messages, defaults to the service name.")
+	fs.DurationVar(&o.Retention, "dlq.retention", o.Retention, "Retention of dead-letter messages.")
+	fs.StringSliceVar(&o.Namespaces, "dlq.namespaces", o.Namespaces, "Namespaces visible to the admin dead-letter endpoints.")
+	fs.StringSliceVar(&o.NameServers, "dlq.name-servers", o.NameServers, "RocketMQ name servers used to replay dead-letter messages.")
+}
+
+// StoreOptions 转换为Redis存储配置
+func (o *DLQOptions) StoreOptions(namespace string) dlq.RedisOptions {
+	return dlq.RedisOptions{
+		Namespace: namespace,
+		Retention: o.Retention,
+	}
+}
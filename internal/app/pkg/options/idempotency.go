@@ -0,0 +1,61 @@
+package options
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// IdempotencyRouteOptions 网关需要支持幂等键的路由
+type IdempotencyRouteOptions struct {
+	Method string `json:"method" mapstructure:"method"`
+	// 路由模板，与注册路由一致，如 /v1/orders
+	Path string `json:"path" mapstructure:"path"`
+}
+
+// IdempotencyOptions 幂等键配置，网关按 Idempotency-Key 请求头去重，gRPC 服务按 idempotency-key 元数据去重
+type IdempotencyOptions struct {
+	Enabled   bool   `json:"enabled" mapstructure:"enabled"`
+	KeyPrefix string `json:"key-prefix" mapstructure:"key-prefix"`
+	// 已完成响应的保存时间
+	TTL time.Duration `json:"ttl" mapstructure:"ttl"`
+	// 处理中记录的过期时间，应大于接口超时时间
+	LockTTL time.Duration `json:"lock-ttl" mapstructure:"lock-ttl"`
+	// 网关路由
+	Routes []IdempotencyRouteOptions `json:"routes" mapstructure:"routes"`
+	// gRPC 完整方法名，如 /Payment/CreatePayment
+	Methods []string `json:"methods" mapstructure:"methods"`
+}
+
+func NewIdempotencyOptions() *IdempotencyOptions {
+	return &IdempotencyOptions{
+		Enabled:   false,
+		KeyPrefix: "idempotency:",
+		TTL:       24 * time.Hour,
+		LockTTL:   time.Minute,
+	}
+}
+
+func (o *IdempotencyOptions) Validate() []error {
+	errs := []error{}
+	if !o.Enabled {
+		return errs
+	}
+	if o.TTL <= 0 || o.LockTTL <= 0 {
+		errs = append(errs, fmt.Errorf("idempotency.ttl and idempotency.lock-ttl must be greater than 0"))
+	}
+	for i, r := range o.Routes {
+		if r.Method == "" || r.Path == "" {
+			errs = append(errs, fmt.Errorf("idempotency.routes[%d]: method and path cannot be empty", i))
+		}
+	}
+	return errs
+}
+
+func (o *IdempotencyOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.Enabled, "idempotency.enabled", o.Enabled, "Enable Idempotency-Key deduplication, routes and methods are configured in the config file.")
+	fs.StringVar(&o.KeyPrefix, "idempotency.key-prefix", o.KeyPrefix, "Redis key prefix of idempotency records.")
+	fs.DurationVar(&o.TTL, "idempotency.ttl", o.TTL, "How long completed responses are kept for replay.")
+	fs.DurationVar(&o.LockTTL, "idempotency.lock-ttl", o.LockTTL, "How long an in-flight request holds its key, should exceed the request timeout.")
+}
@@ -0,0 +1,83 @@
+package options
+
+import (
+	"fmt"
+
+	"emshop/gin-micro/core/limit"
+	"emshop/pkg/sentinel"
+
+	"github.com/spf13/pflag"
+)
+
+// LimitOptions 服务端限流配置，Sentinel规则在 server.limit 开启时生效，自适应限流可以单独开启
+type LimitOptions struct {
+	// Sentinel规则来源，nacos 或 file
+	RuleSource string `json:"rule-source" mapstructure:"rule-source"`
+	// 本地规则文件目录和文件名前缀，如 configs/sentinel 下的 coupon-flow-rules.json 前缀为 coupon
+	RulesDir    string `json:"rules-dir" mapstructure:"rules-dir"`
+	RulesPrefix string `json:"rules-prefix" mapstructure:"rules-prefix"`
+	// 是否开启自适应并发限流，根据请求耗时动态调整并发上限，过载时按优先级拒绝请求
+	Adaptive bool `json:"adaptive" mapstructure:"adaptive"`
+	// 自适应限流的初始并发上限和调整范围
+	InitialLimit int `json:"initial-limit" mapstructure:"initial-limit"`
+	MinLimit     int `json:"min-limit" mapstructure:"min-limit"`
+	MaxLimit     int `json:"max-limit" mapstructure:"max-limit"`
+}
+
+func NewLimitOptions() *LimitOptions {
+	def := limit.DefaultOptions()
+	return &LimitOptions{
+		RuleSource:   sentinel.SourceNacos,
+		RulesDir:     "configs/sentinel",
+		InitialLimit: def.InitialLimit,
+		MinLimit:     def.MinLimit,
+		MaxLimit:     def.MaxLimit,
+	}
+}
+
+func (o *LimitOptions) Validate() []error {
+	errs := []error{}
+	switch o.RuleSource {
+	case sentinel.SourceNacos:
+	case sentinel.SourceFile:
+		if o.RulesDir == "" {
+			errs = append(errs, fmt.Errorf("server.limiter.rules-dir cannot be empty when rule-source is file"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("server.limiter.rule-source must be one of nacos, file"))
+	}
+	if o.Adaptive && (o.MinLimit <= 0 || o.MaxLimit < o.MinLimit) {
+		errs = append(errs, fmt.Errorf("server.limiter.min-limit must be greater than 0 and not greater than max-limit"))
+	}
+	return errs
+}
+
+func (o *LimitOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.RuleSource, "server.limiter.rule-source", o.RuleSource, "Where sentinel rules are loaded from, one of nacos, file.")
+	fs.StringVar(&o.RulesDir, "server.limiter.rules-dir", o.RulesDir, "Directory of sentinel rule files, watched for changes when rule-source is file.")
+	fs.StringVar(&o.RulesPrefix, "server.limiter.rules-prefix", o.RulesPrefix, "File name prefix of sentinel rule files, e.g. coupon for coupon-flow-rules.json.")
+	fs.BoolVar(&o.Adaptive, "server.limiter.adaptive", o.Adaptive, "Enable the latency based adaptive concurrency limiter.")
+	fs.IntVar(&o.InitialLimit, "server.limiter.initial-limit", o.InitialLimit, "Initial concurrency limit of the adaptive limiter.")
+	fs.IntVar(&o.MinLimit, "server.limiter.min-limit", o.MinLimit, "Lower bound of the adaptive concurrency limit.")
+	fs.IntVar(&o.MaxLimit, "server.limiter.max-limit", o.MaxLimit, "Upper bound of the adaptive concurrency limit.")
+}
+
+// FileRuleSource 创建本地规则文件数据源，未配置前缀时使用 prefix
+func (o *LimitOptions) FileRuleSource(prefix string) *sentinel.FileRuleSource {
+	if o.RulesPrefix != "" {
+		prefix = o.RulesPrefix
+	}
+	return sentinel.NewFileRuleSource(o.RulesDir, prefix)
+}
+
+// AdaptiveLimiter 创建自适应限流器，未开启时返回 nil
+func (o *LimitOptions) AdaptiveLimiter() *limit.Limiter {
+	if o == nil || !o.Adaptive {
+		return nil
+	}
+	opts := limit.DefaultOptions()
+	opts.InitialLimit = o.InitialLimit
+	opts.MinLimit = o.MinLimit
+	opts.MaxLimit = o.MaxLimit
+	return limit.NewLimiter(opts)
+}
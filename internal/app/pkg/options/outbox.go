@@ -0,0 +1,84 @@
+package options
+
+import (
+	"fmt"
+	"time"
+
+	"emshop/pkg/outbox"
+
+	"github.com/spf13/pflag"
+	"gorm.io/gorm"
+)
+
+// OutboxOptions 事务性发件箱配置
+type OutboxOptions struct {
+	// Enabled 关闭时业务变更不写入领域事件
+	Enabled       bool          `mapstructure:"enabled" json:"enabled"`
+	NameServers   []string      `mapstructure:"nameservers" json:"nameservers"`
+	Topic         string        `mapstructure:"topic" json:"topic"`
+	ProducerGroup string        `mapstructure:"producer-group" json:"producer-group"`
+	PollInterval  time.Duration `mapstructure:"poll-interval" json:"poll-interval"`
+	BatchSize     int           `mapstructure:"batch-size" json:"batch-size"`
+	MaxAttempts   int           `mapstructure:"max-attempts" json:"max-attempts"`
+	Retention     time.Duration `mapstructure:"retention" json:"retention"`
+}
+
+func NewOutboxOptions() *OutboxOptions {
+	return &OutboxOptions{
+		Enabled:      false,
+		NameServers:  []string{"127.0.0.1:9876"},
+		PollInterval: time.Second,
+		BatchSize:    100,
+		MaxAttempts:  10,
+		Retention:    3 * 24 * time.Hour,
+	}
+}
+
+func (o *OutboxOptions) Validate() []error {
+	errs := []error{}
+	if !o.Enabled {
+		return errs
+	}
+	if len(o.NameServers) == 0 {
+		errs = append(errs, fmt.Errorf("outbox.nameservers cannot be empty"))
+	}
+	if o.Topic == "" {
+		errs = append(errs, fmt.Errorf("outbox.topic cannot be empty"))
+	}
+	if o.ProducerGroup == "" {
+		errs = append(errs, fmt.Errorf("outbox.producer-group cannot be empty"))
+	}
+	if o.BatchSize <= 0 {
+		errs = append(errs, fmt.Errorf("outbox.batch-size must be greater than 0"))
+	}
+	return errs
+}
+
+func (o *OutboxOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.Enabled, "outbox.enabled", o.Enabled, "Write domain events to the transactional outbox and relay them to RocketMQ.")
+	fs.StringSliceVar(&o.NameServers, "outbox.nameservers", o.NameServers, "RocketMQ name servers used by the outbox relay.")
+	fs.StringVar(&o.Topic, "outbox.topic", o.Topic, "Topic of domain events.")
+	fs.StringVar(&o.ProducerGroup, "outbox.producer-group", o.ProducerGroup, "Producer group of the outbox relay.")
+	fs.DurationVar(&o.PollInterval, "outbox.poll-interval", o.PollInterval, "Interval for polling pending events.")
+	fs.IntVar(&o.BatchSize, "outbox.batch-size", o.BatchSize, "Max number of events locked per poll.")
+	fs.IntVar(&o.MaxAttempts, "outbox.max-attempts", o.MaxAttempts, "Max publish attempts of an event before it is marked failed.")
+	fs.DurationVar(&o.Retention, "outbox.retention", o.Retention, "Retention of published events.")
+}
+
+// RelayOptions 转换为投递器选项
+func (o *OutboxOptions) RelayOptions() []outbox.Option {
+	return []outbox.Option{
+		outbox.WithPollInterval(o.PollInterval),
+		outbox.WithBatchSize(o.BatchSize),
+		outbox.WithMaxAttempts(o.MaxAttempts),
+		outbox.WithRetention(o.Retention),
+	}
+}
+
+// NewWorker 按配置创建发件箱与投递器，未启用时都返回nil；Worker需交给应用随启停管理
+func (o *OutboxOptions) NewWorker(db *gorm.DB) (*outbox.Outbox, *outbox.Worker, error) {
+	if o == nil || !o.Enabled {
+		return nil, nil, nil
+	}
+	return outbox.NewWorker(db, o.NameServers, o.ProducerGroup, o.Topic, o.RelayOptions()...)
+}
@@ -0,0 +1,56 @@
+package options
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// RateLimitPolicyOptions 单条路由限流策略
+type RateLimitPolicyOptions struct {
+	// 请求方法，为空时匹配所有方法
+	Method string `json:"method" mapstructure:"method"`
+	// 路由模板，与注册路由一致，如 /v1/base/send_sms
+	Path string `json:"path" mapstructure:"path"`
+	// 限流维度：ip、user、mobile、apikey
+	Key string `json:"key" mapstructure:"key"`
+	// 每个维度取值在 window 内最多请求 limit 次，Redis 计数时窗口按整秒计算
+	Limit  int           `json:"limit" mapstructure:"limit"`
+	Window time.Duration `json:"window" mapstructure:"window"`
+}
+
+// RateLimitOptions 网关调用方限流配置，计数保存在Redis，Redis不可用时降级为进程内限流
+type RateLimitOptions struct {
+	Enabled   bool                     `json:"enabled" mapstructure:"enabled"`
+	KeyPrefix string                   `json:"key-prefix" mapstructure:"key-prefix"`
+	Policies  []RateLimitPolicyOptions `json:"policies" mapstructure:"policies"`
+}
+
+func NewRateLimitOptions() *RateLimitOptions {
+	return &RateLimitOptions{
+		Enabled:   false,
+		KeyPrefix: "ratelimit:",
+	}
+}
+
+func (o *RateLimitOptions) Validate() []error {
+	errs := []error{}
+	if !o.Enabled {
+		return errs
+	}
+	for i, p := range o.Policies {
+		if p.Path == "" || p.Key == "" {
+			errs = append(errs, fmt.Errorf("ratelimit.policies[%d]: path and key cannot be empty", i))
+		}
+		if p.Limit <= 0 || p.Window <= 0 {
+			errs = append(errs, fmt.Errorf("ratelimit.policies[%d]: limit and window must be greater than 0", i))
+		}
+	}
+	return errs
+}
+
+func (o *RateLimitOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.Enabled, "ratelimit.enabled", o.Enabled, "Enable per-caller rate limiting, policies are configured in the config file.")
+	fs.StringVar(&o.KeyPrefix, "ratelimit.key-prefix", o.KeyPrefix, "Redis key prefix of rate limit counters.")
+}
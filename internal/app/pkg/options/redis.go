@@ -2,6 +2,9 @@ package options
 
 import (
 	"time"
+
+	"emshop/pkg/storage"
+
 	"github.com/spf13/pflag"
 )
 
@@ -163,3 +166,23 @@ func NewBusinessOptions() *BusinessOptions {
 		Cache: *NewCacheOptions(),
 	}
 }
+
+// StorageConfig 转换为 storage.ConnectToRedis 使用的连接配置
+func (o *RedisOptions) StorageConfig() *storage.Config {
+	return &storage.Config{
+		Host:                  o.Host,
+		Port:                  o.Port,
+		Addrs:                 o.Addrs,
+		MasterName:            o.MasterName,
+		Username:              o.Username,
+		Password:              o.Password,
+		Database:              o.Database,
+		MaxIdle:               o.MaxIdle,
+		MaxActive:             o.MaxActive,
+		Timeout:               o.Timeout,
+		EnableCluster:         o.EnableCluster,
+		UseSSL:                o.UseSSL,
+		SSLInsecureSkipVerify: o.SSLInsecureSkipVerify,
+		EnableTracing:         o.EnableTracing,
+	}
+}
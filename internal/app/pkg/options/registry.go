@@ -1,12 +1,25 @@
 package options
 
 import (
+	"fmt"
+
 	"emshop/pkg/errors"
 
 	"github.com/spf13/pflag"
 )
 
+// 注册中心类型
+const (
+    RegistryConsul = "consul"
+    RegistryEtcd   = "etcd"
+    RegistryFile   = "file"
+    RegistryMemory = "memory"
+)
+
 type RegistryOptions struct {
+    // Type 注册中心类型：consul、etcd、file、memory（仅限单进程测试）
+    Type    string `mapstructure:"type" json:"type,omitempty"`
+    // Address consul地址；etcd时为逗号分隔的多个endpoint
     Address string `mapstructure:"address" json:"address,omitempty"`
     Scheme  string `mapstructure:"scheme" json:"scheme,omitempty"`
     // gRPC/TCP health check interval in seconds
@@ -15,27 +28,50 @@ type RegistryOptions struct {
     DeregisterCriticalAfter int `mapstructure:"deregister-critical-after" json:"deregister-critical-after,omitempty"`
     // Health check timeout in seconds (applies to both gRPC and TCP checks)
     CheckTimeout int `mapstructure:"check-timeout" json:"check-timeout,omitempty"`
+    // File 文件注册中心的YAML文件路径
+    File string `mapstructure:"file" json:"file,omitempty"`
+    // Routing 调用下游服务时的节点路由配置
+    Routing *RoutingOptions `mapstructure:"routing" json:"routing,omitempty"`
 }
 
 func NewRegistryOptions() *RegistryOptions {
     return &RegistryOptions{
+        Type:    RegistryConsul,
         Address: "127.0.0.1:8500", // 默认consul的地址
         Scheme:  "http",
         HealthCheckInterval:       10,
         DeregisterCriticalAfter:   600,
         CheckTimeout:              5,
+        Routing:                   NewRoutingOptions(),
     }
 }
 
 func (o *RegistryOptions) Validate() []error {
 	errs := []error{}
-	if o.Address == "" || o.Scheme == "" {
-		errs = append(errs, errors.New("address an scheme is empty"))
+	switch o.Type {
+	case "", RegistryConsul:
+		if o.Address == "" || o.Scheme == "" {
+			errs = append(errs, errors.New("address an scheme is empty"))
+		}
+	case RegistryEtcd:
+		if o.Address == "" {
+			errs = append(errs, errors.New("etcd address is empty"))
+		}
+	case RegistryFile:
+		if o.File == "" {
+			errs = append(errs, errors.New("registry file is empty"))
+		}
+	case RegistryMemory:
+	default:
+		errs = append(errs, fmt.Errorf("unsupported registry type %q", o.Type))
 	}
 	return errs
 }
 
 func (o *RegistryOptions) AddFlags(fs *pflag.FlagSet) {
+    fs.StringVar(&o.Type, "registry.type", o.Type, "registry type, one of consul, etcd, file, memory")
+    fs.StringVar(&o.File, "registry.file", o.File, "yaml file path used by the file registry")
+
     fs.StringVar(&o.Address, "consul.address", o.Address, ""+
         "consul address, if left , default is 127.0.0.1:8500")
 
@@ -45,4 +81,8 @@ func (o *RegistryOptions) AddFlags(fs *pflag.FlagSet) {
     fs.IntVar(&o.HealthCheckInterval, "consul.health-check-interval", o.HealthCheckInterval, "health check interval seconds for consul registered services")
     fs.IntVar(&o.DeregisterCriticalAfter, "consul.deregister-critical-after", o.DeregisterCriticalAfter, "seconds after which consul deregisters critical services")
     fs.IntVar(&o.CheckTimeout, "consul.check-timeout", o.CheckTimeout, "health check timeout seconds (applies to gRPC/TCP checks)")
+
+    if o.Routing != nil {
+        o.Routing.AddFlags(fs)
+    }
 }
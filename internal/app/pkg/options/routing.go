@@ -0,0 +1,60 @@
+package options
+
+import (
+	"sort"
+
+	"emshop/gin-micro/server/rpc-server/selector"
+	"emshop/gin-micro/server/rpc-server/selector/filter"
+
+	"github.com/spf13/pflag"
+)
+
+// RoutingOptions 调用下游服务时的节点路由配置，按版本、元数据、可用区和灰度比例筛选节点
+type RoutingOptions struct {
+	// Version 只调用该版本的节点
+	Version string `mapstructure:"version" json:"version,omitempty"`
+	// Metadata 只调用元数据全部匹配的节点，如 tenant
+	Metadata map[string]string `mapstructure:"metadata" json:"metadata,omitempty"`
+	// Zone 优先调用同可用区的节点，本区没有节点时跨区
+	Zone string `mapstructure:"zone" json:"zone,omitempty"`
+	// Canary 灰度版本到流量百分比的映射，按请求的路由键(用户ID)保持粘性
+	Canary map[string]int `mapstructure:"canary" json:"canary,omitempty"`
+}
+
+func NewRoutingOptions() *RoutingOptions {
+	return &RoutingOptions{}
+}
+
+func (o *RoutingOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Version, "registry.routing.version", o.Version, "only call downstream nodes of this version")
+	fs.StringToStringVar(&o.Metadata, "registry.routing.metadata", o.Metadata, "only call downstream nodes whose metadata match, e.g. tenant=default")
+	fs.StringVar(&o.Zone, "registry.routing.zone", o.Zone, "prefer downstream nodes in this zone")
+	fs.StringToIntVar(&o.Canary, "registry.routing.canary", o.Canary, "percentage of traffic routed to canary versions, e.g. v2=10")
+}
+
+// NodeFilters 转换为客户端的节点过滤器，未配置时返回nil
+func (o *RoutingOptions) NodeFilters() []selector.NodeFilter {
+	if o == nil {
+		return nil
+	}
+	var filters []selector.NodeFilter
+	if o.Version != "" {
+		filters = append(filters, filter.Version(o.Version))
+	}
+	keys := make([]string, 0, len(o.Metadata))
+	for k := range o.Metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		filters = append(filters, filter.Metadata(k, o.Metadata[k]))
+	}
+	if len(o.Canary) > 0 {
+		filters = append(filters, filter.Canary(o.Canary))
+	}
+	// 可用区优先放在最后，在其他条件筛选出的节点中再按可用区选择
+	if o.Zone != "" {
+		filters = append(filters, filter.Zone(o.Zone))
+	}
+	return filters
+}
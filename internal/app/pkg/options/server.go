@@ -1,6 +1,14 @@
 package options
 
-import "github.com/spf13/pflag"
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+)
 
 // ServerOptions 服务器配置选项结构体
 // 这个结构体展示了pflag的核心使用模式：
@@ -25,8 +33,26 @@ type ServerOptions struct {
 	HttpPort int `json:"http-port,omitempty" mapstructure:"http-port"`
 	// 服务名称
     Name string `json:"name,omitempty" mapstructure:"name"`
+	// 服务版本，注册到服务中心供客户端按版本灰度路由
+	Version string `json:"version,omitempty" mapstructure:"version"`
+	// 服务元数据，如 zone、tenant，注册到服务中心供客户端按元数据路由
+	Metadata map[string]string `json:"metadata,omitempty" mapstructure:"metadata"`
     // 中间件列表
     Middlewares []string `json:"middlewares,omitempty" mapstructure:"middlewares"`
+	// gRPC单条消息的接收/发送上限（字节），0表示使用gRPC默认值
+	MaxRecvMsgSize int `json:"max-recv-msg-size,omitempty" mapstructure:"max-recv-msg-size"`
+	MaxSendMsgSize int `json:"max-send-msg-size,omitempty" mapstructure:"max-send-msg-size"`
+	// gRPC服务端keepalive探测间隔和等待应答的超时时间，0表示使用gRPC默认值
+	KeepaliveTime    time.Duration `json:"keepalive-time,omitempty" mapstructure:"keepalive-time"`
+	KeepaliveTimeout time.Duration `json:"keepalive-timeout,omitempty" mapstructure:"keepalive-timeout"`
+	// gRPC TLS证书和私钥文件，同时配置时启用TLS
+	CertFile string `json:"cert-file,omitempty" mapstructure:"cert-file"`
+	KeyFile  string `json:"key-file,omitempty" mapstructure:"key-file"`
+	// 日志管理密钥，不为空时HTTP服务添加 /debug/log 接口、gRPC服务注册日志管理服务，
+	// 运行时修改日志级别，并支持通过调试令牌为单个请求开启调试日志
+	LogAdminSecret string `json:"log-admin-secret,omitempty" mapstructure:"log-admin-secret"`
+	// 限流配置：Sentinel规则来源和自适应并发限流
+	Limiter *LimitOptions `json:"limiter" mapstructure:"limiter"`
 }
 
 // NewServerOptions 创建带默认值的ServerOptions实例
@@ -40,6 +66,7 @@ func NewServerOptions() *ServerOptions {
 		Port:              8078,
 		HttpPort:          8079,
         Name:              "emshop-user-srv",
+		Limiter:           NewLimitOptions(),
     }
 }
 
@@ -47,7 +74,15 @@ func NewServerOptions() *ServerOptions {
 // 在配置绑定后调用，确保配置值符合要求
 func (so *ServerOptions) Validate() []error {
 	errs := []error{}
-	// TODO: 添加具体的验证逻辑
+	if so.MaxRecvMsgSize < 0 || so.MaxSendMsgSize < 0 {
+		errs = append(errs, fmt.Errorf("server.max-recv-msg-size and server.max-send-msg-size cannot be negative"))
+	}
+	if (so.CertFile == "") != (so.KeyFile == "") {
+		errs = append(errs, fmt.Errorf("server.cert-file and server.key-file must be set together"))
+	}
+	if so.Limiter != nil {
+		errs = append(errs, so.Limiter.Validate()...)
+	}
 	return errs
 }
 
@@ -66,8 +101,47 @@ func (so *ServerOptions) AddFlags(fs *pflag.FlagSet) {
 	// StringVar: 绑定字符串类型字段到命令行标志
     fs.StringVar(&so.Host, "server.host", so.Host, "server host default is 127.0.0.1")
     fs.StringVar(&so.Name, "server.name", so.Name, "server name default is emshop-user-srv")
+	fs.StringVar(&so.Version, "server.version", so.Version, "server version registered for version-based routing")
+	fs.StringToStringVar(&so.Metadata, "server.metadata", so.Metadata, "server metadata registered for routing, e.g. zone=sh-a,tenant=default")
 
 	// IntVar: 绑定整数类型字段到命令行标志
 	fs.IntVar(&so.Port, "server.port", so.Port, "server port default is 8078")
 	fs.IntVar(&so.HttpPort, "server.http-port", so.HttpPort, "server http port default is 8079")
+
+	fs.IntVar(&so.MaxRecvMsgSize, "server.max-recv-msg-size", so.MaxRecvMsgSize, "max grpc message size in bytes the server can receive, 0 uses the grpc default")
+	fs.IntVar(&so.MaxSendMsgSize, "server.max-send-msg-size", so.MaxSendMsgSize, "max grpc message size in bytes the server can send, 0 uses the grpc default")
+	fs.DurationVar(&so.KeepaliveTime, "server.keepalive-time", so.KeepaliveTime, "ping clients after this idle duration, 0 uses the grpc default")
+	fs.DurationVar(&so.KeepaliveTimeout, "server.keepalive-timeout", so.KeepaliveTimeout, "close the connection if a keepalive ping is not acked within this duration")
+	fs.StringVar(&so.CertFile, "server.cert-file", so.CertFile, "grpc tls certificate file, enables tls together with server.key-file")
+	fs.StringVar(&so.KeyFile, "server.key-file", so.KeyFile, "grpc tls private key file")
+	fs.StringVar(&so.LogAdminSecret, "server.log-admin-secret", so.LogAdminSecret, "secret of the runtime log level admin endpoints and debug tokens, empty disables them")
+
+	if so.Limiter != nil {
+		so.Limiter.AddFlags(fs)
+	}
+}
+
+// GRPCServerOptions 转换为gRPC服务器选项
+func (so *ServerOptions) GRPCServerOptions() ([]grpc.ServerOption, error) {
+	var opts []grpc.ServerOption
+	if so.MaxRecvMsgSize > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(so.MaxRecvMsgSize))
+	}
+	if so.MaxSendMsgSize > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(so.MaxSendMsgSize))
+	}
+	if so.KeepaliveTime > 0 || so.KeepaliveTimeout > 0 {
+		opts = append(opts, grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    so.KeepaliveTime,
+			Timeout: so.KeepaliveTimeout,
+		}))
+	}
+	if so.CertFile != "" && so.KeyFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(so.CertFile, so.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load grpc tls credentials: %w", err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+	return opts, nil
 }
@@ -4,19 +4,18 @@ package srv
 import (
 	gapp "emshop/gin-micro/app"
 	"emshop/gin-micro/registry"
-	"emshop/gin-micro/registry/consul"
 	rpcserver "emshop/gin-micro/server/rpc-server"
 	"emshop/internal/app/pkg/options"
+	"emshop/internal/app/pkg/discovery"
 	"emshop/internal/app/user/srv/config"
 	"emshop/pkg/app"
 	"emshop/pkg/log"
 
 	"github.com/google/wire"
-	"github.com/hashicorp/consul/api"
 )
 
 // wire provider 获取wire的注入依赖
-var ProviderSet = wire.NewSet(NewUserApp, NewRegistrar, NewUserRPCServer, NewNacosDataSource)
+var ProviderSet = wire.NewSet(NewUserApp, NewRegistrar, NewUserRPCServer, NewRuleSource)
 
 func NewApp(basename string) *app.App {
 	cfg := config.New()
@@ -33,30 +32,10 @@ func NewApp(basename string) *app.App {
 //	@param registry 
 //	@return registry.Registrar 
 func NewRegistrar(registry *options.RegistryOptions, dev bool) registry.Registrar {
-	// 创建客户端配置
-	c := api.DefaultConfig()
-	c.Address = registry.Address
-	c.Scheme = registry.Scheme
-
-	// 创建客户端实例
-	cli, err := api.NewClient(c)
-	if err != nil {
-		panic(err)
-	}
-	// 创建自定义consul注册器实例
-	opts := []consul.Option{consul.WithHealthCheck(true)}
-	if registry.HealthCheckInterval > 0 {
-		opts = append(opts, consul.WithHealthCheckInterval(registry.HealthCheckInterval))
-	}
-	if registry.CheckTimeout > 0 {
-		opts = append(opts, consul.WithCheckTimeout(registry.CheckTimeout))
-	}
-	if dev {
-		opts = append(opts, consul.WithDeregisterCriticalServiceAfter(60))
-	} else if registry.DeregisterCriticalAfter > 0 {
-		opts = append(opts, consul.WithDeregisterCriticalServiceAfter(registry.DeregisterCriticalAfter))
+	r, err := discovery.NewRegistrar(registry, dev)
+	if err != nil {
+		panic(err)
 	}
-	r := consul.New(cli, opts...)
 	return r
 }
 
@@ -68,6 +47,8 @@ func NewUserApp(logOpts *log.Options, register registry.Registrar,
 
 	return gapp.New(
 		gapp.WithName(serverOpts.Name),
+		gapp.WithVersion(serverOpts.Version),
+		gapp.WithMetadata(serverOpts.Metadata),
 		gapp.WithRPCServer(rpcServer),
 		gapp.WithRegistrar(register),
 	), nil
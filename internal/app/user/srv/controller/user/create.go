@@ -11,7 +11,7 @@ import (
 
 // controller层应该是很薄的一层， 参数校验，日志打印，错误处理，调用service层
 func (u *userServer) CreateUser(ctx context.Context, request *upbv1.CreateUserInfo) (*upbv1.UserInfoResponse, error) {
-	log.Infof("create user function called.")
+	log.InfofC(ctx, "create user function called.")
 
 	userDO := do.UserDO{
 		Mobile:   request.Mobile,
@@ -22,7 +22,7 @@ func (u *userServer) CreateUser(ctx context.Context, request *upbv1.CreateUserIn
 
 	err := u.srv.Create(ctx, &userDTO)
 	if err != nil {
-		log.Errorf("create user: %v, error: %v", userDTO, err)
+		log.ErrorfC(ctx, "create user: %v, error: %v", userDTO, err)
 		return nil, err
 	}
 
@@ -0,0 +1,27 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	upbv1 "emshop/api/user/v1"
+	"emshop/pkg/log"
+)
+
+// ListUserIds 按注册时间分页查询用户ID
+func (us *userServer) ListUserIds(ctx context.Context, request *upbv1.ListUserIdsRequest) (*upbv1.UserIdsResponse, error) {
+	var from, to time.Time
+	if request.RegisteredFrom > 0 {
+		from = time.Unix(request.RegisteredFrom, 0)
+	}
+	if request.RegisteredTo > 0 {
+		to = time.Unix(request.RegisteredTo, 0)
+	}
+
+	ids, err := us.srv.ListIDs(ctx, from, to, request.AfterId, int(request.Limit))
+	if err != nil {
+		log.ErrorfC(ctx, "list user ids error: %v", err)
+		return nil, err
+	}
+	return &upbv1.UserIdsResponse{Ids: ids}, nil
+}
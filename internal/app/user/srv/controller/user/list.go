@@ -20,6 +20,9 @@ func DTOToResponse(userDTO dto.UserDTO) *upbv1.UserInfoResponse {
 		Role:     int32(userDTO.Role),
 		Mobile:   userDTO.Mobile,
 	}
+	if !userDTO.CreatedAt.IsZero() {
+		userInfoRsp.CreatedAt = userDTO.CreatedAt.Unix()
+	}
 	// Birthday是time.Time类型，不能直接赋值nil
 	if userDTO.Birthday != nil {
 		userInfoRsp.BirthDay = uint64(userDTO.Birthday.Unix())
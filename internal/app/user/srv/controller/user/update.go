@@ -13,7 +13,7 @@ import (
 )
 
 func (u *userServer) UpdateUser(ctx context.Context, request *upbv1.UpdateUserInfo) (*emptypb.Empty, error) {
-	log.Infof("update user function called.")
+	log.InfofC(ctx, "update user function called.")
 
 	userDO := do.UserDO{
 		BaseModel: db.BaseModel{
@@ -37,7 +37,7 @@ func (u *userServer) UpdateUser(ctx context.Context, request *upbv1.UpdateUserIn
 
 	err := u.srv.Update(ctx, &userDTO)
 	if err != nil {
-		log.Errorf("update user: %v, error: %v", userDTO, err)
+		log.ErrorfC(ctx, "update user: %v, error: %v", userDTO, err)
 		return nil, err
 	}
 	return &emptypb.Empty{}, nil
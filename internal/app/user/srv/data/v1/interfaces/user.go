@@ -5,6 +5,7 @@ import (
 	"emshop/internal/app/user/srv/domain/do"
 	metav1 "emshop/pkg/common/meta/v1"
 	"gorm.io/gorm"
+	"time"
 )
 
 // UserStore 用户存储接口
@@ -14,4 +15,6 @@ type UserStore interface {
 	List(ctx context.Context, db *gorm.DB, orderby []string, opts metav1.ListMeta) (*do.UserDOList, error)
 	Create(ctx context.Context, db *gorm.DB, user *do.UserDO) error
 	Update(ctx context.Context, db *gorm.DB, user *do.UserDO) error
+	// ListIDs 按注册时间筛选用户ID，按ID升序返回afterID之后的limit个，零值时间表示不限
+	ListIDs(ctx context.Context, db *gorm.DB, registeredFrom, registeredTo time.Time, afterID int32, limit int) ([]int32, error)
 }
\ No newline at end of file
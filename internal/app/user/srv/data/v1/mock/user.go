@@ -6,6 +6,7 @@ import (
 	"emshop/internal/app/user/srv/domain/do"
 	metav1 "emshop/pkg/common/meta/v1"
 	"gorm.io/gorm"
+	"time"
 )
 
 type users struct{}
@@ -66,4 +67,11 @@ func (u *users) Create(ctx context.Context, db *gorm.DB, user *do.UserDO) error
 
 func (u *users) Update(ctx context.Context, db *gorm.DB, user *do.UserDO) error {
 	return nil
-}
\ No newline at end of file
+}
+
+func (u *users) ListIDs(ctx context.Context, db *gorm.DB, registeredFrom, registeredTo time.Time, afterID int32, limit int) ([]int32, error) {
+	if afterID > 0 {
+		return nil, nil
+	}
+	return []int32{1, 2}, nil
+}
@@ -5,6 +5,7 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"log"
+	"emshop/gin-micro/core/health"
 	"emshop/internal/app/user/srv/data/v1/interfaces"
 	"emshop/internal/app/pkg/code"
 	"emshop/internal/app/pkg/options"
@@ -115,6 +116,7 @@ func NewMySQLFactory(mysqlOpts *options.MySQLOptions) (DataFactory, error) {
 		sqlDB.SetMaxOpenConns(mysqlOpts.MaxOpenConnections)
 		sqlDB.SetMaxIdleConns(mysqlOpts.MaxIdleConnections)
 		sqlDB.SetConnMaxLifetime(mysqlOpts.MaxConnectionLifetime)
+		health.Register("mysql", health.PingCheck(sqlDB))
 	})
 
 	if factory == nil || err != nil {
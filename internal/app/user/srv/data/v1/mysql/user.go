@@ -3,6 +3,7 @@ package mysql
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -93,4 +94,20 @@ func (u *users) List(ctx context.Context, db *gorm.DB, orderby []string, opts me
 		return nil, errors.WithCode(code2.ErrDatabase, "%s", err.Error())
 	}
 	return ret, nil
-}
\ No newline at end of file
+}
+
+func (u *users) ListIDs(ctx context.Context, db *gorm.DB, registeredFrom, registeredTo time.Time, afterID int32, limit int) ([]int32, error) {
+	query := db.WithContext(ctx).Model(&do.UserDO{}).Where("id > ?", afterID)
+	if !registeredFrom.IsZero() {
+		query = query.Where("add_time >= ?", registeredFrom)
+	}
+	if !registeredTo.IsZero() {
+		query = query.Where("add_time < ?", registeredTo)
+	}
+
+	var ids []int32
+	if err := query.Order("id ASC").Limit(limit).Pluck("id", &ids).Error; err != nil {
+		return nil, errors.WithCode(code2.ErrDatabase, "%s", err.Error())
+	}
+	return ids, nil
+}
@@ -15,7 +15,12 @@ import (
 	"github.com/nacos-group/nacos-sdk-go/common/constant"
 )
 
-func NewNacosDataSource(opts *options.NacosOptions) (*nacos.NacosDataSource, error) {
+// NewRuleSource 创建Sentinel规则数据源，server.limiter.rule-source 为 file 时从本地目录加载，否则从Nacos加载
+func NewRuleSource(opts *options.NacosOptions, serverOpts *options.ServerOptions) (sentinel.RuleSource, error) {
+	if serverOpts.Limiter != nil && serverOpts.Limiter.RuleSource == sentinel.SourceFile {
+		return serverOpts.Limiter.FileRuleSource("user"), nil
+	}
+
 	//nacos server地址
 	sc := []constant.ServerConfig{
 		{
@@ -50,7 +55,7 @@ func NewNacosDataSource(opts *options.NacosOptions) (*nacos.NacosDataSource, err
 	return nds, nil
 }
 
-func NewUserRPCServer(telemetry *options.TelemetryOptions, serverOpts *options.ServerOptions, userver upb.UserServer, dataNacos *nacos.NacosDataSource) (*rpcserver.Server, error) {
+func NewUserRPCServer(telemetry *options.TelemetryOptions, serverOpts *options.ServerOptions, userver upb.UserServer, rules sentinel.RuleSource) (*rpcserver.Server, error) {
 	//初始化open-telemetry的exporter
 	trace.InitAgent(trace.Options{
 		Name:     telemetry.Name,
@@ -65,8 +70,16 @@ func NewUserRPCServer(telemetry *options.TelemetryOptions, serverOpts *options.S
 	opts = append(opts,
 		rpcserver.WithAddress(rpcAddr),
 		rpcserver.WithMetrics(serverOpts.EnableMetrics),
+		rpcserver.WithAdaptiveLimit(serverOpts.Limiter.AdaptiveLimiter(), nil),
+		rpcserver.WithLogAdmin(serverOpts.LogAdminSecret),
 	)
 
+	grpcOpts, err := serverOpts.GRPCServerOptions()
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, rpcserver.WithOptions(grpcOpts...))
+
 	if serverOpts.EnableLimit {
 		// 使用新的统一Sentinel拦截器
 		fallbackHandler := sentinel.NewBusinessFallbackHandler("emshop-user-srv", false)
@@ -75,9 +88,10 @@ func NewUserRPCServer(telemetry *options.TelemetryOptions, serverOpts *options.S
 
 		sentinelInterceptor := sentinel.NewUnaryServerInterceptor(interceptorConfig)
 		opts = append(opts, rpcserver.WithUnaryInterceptor(sentinelInterceptor))
+		opts = append(opts, rpcserver.WithStreamInterceptor(sentinel.NewStreamServerInterceptor(interceptorConfig)))
 
-		//初始化nacos
-		err := dataNacos.Initialize()
+		//加载限流规则
+		err := rules.Initialize()
 		if err != nil {
 			return nil, err
 		}
@@ -13,6 +13,7 @@ import (
 	"emshop/pkg/errors"
 	"emshop/pkg/log"
 	"gorm.io/gorm"
+	"time"
 )
 
 
@@ -31,6 +32,7 @@ type UserSrv interface {
 	Update(ctx context.Context, user *dto.UserDTO) error
 	GetByID(ctx context.Context, ID uint64) (*dto.UserDTO, error)
 	GetByMobile(ctx context.Context, mobile string) (*dto.UserDTO, error)
+	ListIDs(ctx context.Context, registeredFrom, registeredTo time.Time, afterID int32, limit int) ([]int32, error)
 }
 
 
@@ -53,7 +55,7 @@ var _ UserSrv = &userService{}
 
 
 func (u *userService) Create(ctx context.Context, user *dto.UserDTO) error {
-	log.Debugf("Creating user with mobile: %s", user.Mobile)
+	log.DebugfC(ctx, "Creating user with mobile: %s", user.Mobile)
 	
 	// 检查用户是否存在 - 直接使用预加载的DAO
 	_, err := u.userDAO.GetByMobile(ctx, u.db, user.Mobile)
@@ -61,7 +63,7 @@ func (u *userService) Create(ctx context.Context, user *dto.UserDTO) error {
 		// 密码加密逻辑在service层
 		encryptedPassword, err := password.EncryptPassword(user.Password)
 		if err != nil {
-			log.Errorf("Password encryption failed for user %s: %v", user.Mobile, err)
+			log.ErrorfC(ctx, "Password encryption failed for user %s: %v", user.Mobile, err)
 			return errors.WithCode(code.ErrEncryptionFailed, "密码加密失败")
 		}
 		
@@ -70,41 +72,41 @@ func (u *userService) Create(ctx context.Context, user *dto.UserDTO) error {
 		
 		// 直接创建用户 - 使用预加载的DAO和DB
 		if err := u.userDAO.Create(ctx, u.db, &user.UserDO); err != nil {
-			log.Errorf("Failed to create user %s: %v", user.Mobile, err)
+			log.ErrorfC(ctx, "Failed to create user %s: %v", user.Mobile, err)
 			return err
 		}
 		
-		log.Infof("Successfully created user: %s", user.Mobile)
+		log.InfofC(ctx, "Successfully created user: %s", user.Mobile)
 		return nil
 	}
 
 	// 用户已存在或其他数据访问错误
 	if err != nil {
-		log.Errorf("Database error while checking user %s: %v", user.Mobile, err)
+		log.ErrorfC(ctx, "Database error while checking user %s: %v", user.Mobile, err)
 		return err
 	}
 	
-	log.Warnf("User already exists: %s", user.Mobile)
+	log.WarnfC(ctx, "User already exists: %s", user.Mobile)
 	return errors.WithCode(code.ErrUserAlreadyExists, "用户已经存在")
 }
 
 func (u *userService) Update(ctx context.Context, user *dto.UserDTO) error {
-	log.Debugf("Updating user ID: %d", user.ID)
+	log.DebugfC(ctx, "Updating user ID: %d", user.ID)
 	
 	// 先查询用户是否存在 - 直接使用预加载的DAO
 	_, err := u.userDAO.Get(ctx, u.db, uint64(user.ID))
 	if err != nil {
-		log.Errorf("User not found for update, ID: %d, error: %v", user.ID, err)
+		log.ErrorfC(ctx, "User not found for update, ID: %d, error: %v", user.ID, err)
 		return err
 	}
 
 	// 直接更新用户
 	if err := u.userDAO.Update(ctx, u.db, &user.UserDO); err != nil {
-		log.Errorf("Failed to update user ID: %d, error: %v", user.ID, err)
+		log.ErrorfC(ctx, "Failed to update user ID: %d, error: %v", user.ID, err)
 		return err
 	}
 	
-	log.Infof("Successfully updated user ID: %d", user.ID)
+	log.InfofC(ctx, "Successfully updated user ID: %d", user.ID)
 	return nil
 }
 
@@ -112,11 +114,11 @@ func (u *userService) GetByID(ctx context.Context, ID uint64) (*dto.UserDTO, err
 	// 直接使用预加载的DAO - 无需每次获取工厂
 	userDO, err := u.userDAO.Get(ctx, u.db, ID)
 	if err != nil {
-		log.Errorf("Failed to get user by ID: %d, error: %v", ID, err)
+		log.ErrorfC(ctx, "Failed to get user by ID: %d, error: %v", ID, err)
 		return nil, err
 	}
 
-	log.Debugf("Successfully retrieved user ID: %d", ID)
+	log.DebugfC(ctx, "Successfully retrieved user ID: %d", ID)
 	return &dto.UserDTO{UserDO: *userDO}, nil
 }
 
@@ -124,32 +126,45 @@ func (u *userService) GetByMobile(ctx context.Context, mobile string) (*dto.User
 	// 直接使用预加载的DAO - 无需每次获取工厂
 	userDO, err := u.userDAO.GetByMobile(ctx, u.db, mobile)
 	if err != nil {
-		log.Errorf("Failed to get user by mobile: %s, error: %v", mobile, err)
+		log.ErrorfC(ctx, "Failed to get user by mobile: %s, error: %v", mobile, err)
 		return nil, err
 	}
 
-	log.Debugf("Successfully retrieved user by mobile: %s", mobile)
+	log.DebugfC(ctx, "Successfully retrieved user by mobile: %s", mobile)
 	return &dto.UserDTO{UserDO: *userDO}, nil
 }
 
 
 func (u *userService) List(ctx context.Context, orderby []string, opts metav1.ListMeta) (*dto.UserDTOList, error) {
-	log.Debugf("Listing users with page: %d, size: %d", opts.Page, opts.PageSize)
+	log.DebugfC(ctx, "Listing users with page: %d, size: %d", opts.Page, opts.PageSize)
 	
 	// 数据访问层：直接使用预加载的DAO，无需每次获取工厂
 	doList, err := u.userDAO.List(ctx, u.db, orderby, opts)
 	if err != nil {
-		log.Errorf("Failed to list users: %v", err)
+		log.ErrorfC(ctx, "Failed to list users: %v", err)
 		return nil, err
 	}
 
 	// 业务逻辑层：数据转换逻辑分离到专门方法
 	userDTOList := u.convertToUserDTOList(doList)
 	
-	log.Debugf("Successfully listed %d users, total: %d", len(userDTOList.Items), userDTOList.TotalCount)
+	log.DebugfC(ctx, "Successfully listed %d users, total: %d", len(userDTOList.Items), userDTOList.TotalCount)
 	return userDTOList, nil
 }
 
+// ListIDs 按注册时间分页查询用户ID，用于营销活动圈选用户
+func (u *userService) ListIDs(ctx context.Context, registeredFrom, registeredTo time.Time, afterID int32, limit int) ([]int32, error) {
+	if limit <= 0 || limit > 5000 {
+		limit = 5000
+	}
+	ids, err := u.userDAO.ListIDs(ctx, u.db, registeredFrom, registeredTo, afterID, limit)
+	if err != nil {
+		log.ErrorfC(ctx, "Failed to list user ids: %v", err)
+		return nil, err
+	}
+	return ids, nil
+}
+
 // convertToUserDTOList 将DO列表转换为DTO列表 - 分离业务逻辑
 func (u *userService) convertToUserDTOList(doList *do.UserDOList) *dto.UserDTOList {
 	userDTOList := &dto.UserDTOList{
@@ -167,14 +182,14 @@ func (u *userService) convertToUserDTOList(doList *do.UserDOList) *dto.UserDTOLi
 
 // CreateWithTransaction 演示复杂事务操作 - 使用保留的工厂引用
 func (u *userService) CreateWithTransaction(ctx context.Context, user *dto.UserDTO) error {
-	log.Debugf("Creating user with transaction: %s", user.Mobile)
+	log.DebugfC(ctx, "Creating user with transaction: %s", user.Mobile)
 	
 	// 对于事务操作，使用保留的工厂获取事务DB
 	txDB := u.dataFactory.Begin()
 	defer func() {
 		if r := recover(); r != nil {
 			txDB.Rollback()
-			log.Errorf("Transaction panic during user creation: %v", r)
+			log.ErrorfC(ctx, "Transaction panic during user creation: %v", r)
 		}
 	}()
 	
@@ -192,7 +207,7 @@ func (u *userService) CreateWithTransaction(ctx context.Context, user *dto.UserD
 		// 创建用户（使用事务DB）
 		if err := u.userDAO.Create(ctx, txDB, &user.UserDO); err != nil {
 			txDB.Rollback()
-			log.Errorf("Failed to create user in transaction: %v", err)
+			log.ErrorfC(ctx, "Failed to create user in transaction: %v", err)
 			return err
 		}
 		
@@ -201,11 +216,11 @@ func (u *userService) CreateWithTransaction(ctx context.Context, user *dto.UserD
 		
 		// 提交事务
 		if err := txDB.Commit().Error; err != nil {
-			log.Errorf("Failed to commit user creation transaction: %v", err)
+			log.ErrorfC(ctx, "Failed to commit user creation transaction: %v", err)
 			return err
 		}
 		
-		log.Infof("Successfully created user with transaction: %s", user.Mobile)
+		log.InfofC(ctx, "Successfully created user with transaction: %s", user.Mobile)
 		return nil
 	}
 	
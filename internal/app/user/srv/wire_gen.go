@@ -26,11 +26,11 @@ func initApp(nacosOptions *options.NacosOptions, logOptions *log.Options, server
 	}
 	userSrv := v1_2.NewUserService(factoryManager)
 	userServer := user.NewUserServer(userSrv)
-	nacosDataSource, err := NewNacosDataSource(nacosOptions)
+	ruleSource, err := NewRuleSource(nacosOptions, serverOptions)
 	if err != nil {
 		return nil, err
 	}
-	server, err := NewUserRPCServer(telemetryOptions, serverOptions, userServer, nacosDataSource)
+	server, err := NewUserRPCServer(telemetryOptions, serverOptions, userServer, ruleSource)
 	if err != nil {
 		return nil, err
 	}
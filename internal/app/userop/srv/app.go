@@ -2,11 +2,12 @@ package srv
 
 import (
 	gapp "emshop/gin-micro/app"
+	"emshop/gin-micro/core/health"
 	"emshop/gin-micro/core/trace"
 	"emshop/gin-micro/registry"
-	"emshop/gin-micro/registry/consul"
 	rpcserver "emshop/gin-micro/server/rpc-server"
 	"emshop/internal/app/pkg/options"
+	"emshop/internal/app/pkg/discovery"
 	"emshop/internal/app/userop/srv/config"
 	datav1 "emshop/internal/app/userop/srv/data/v1"
 	"emshop/internal/app/userop/srv/domain/do"
@@ -18,7 +19,6 @@ import (
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 
-	"github.com/hashicorp/consul/api"
 )
 
 func NewApp(basename string) *app.App {
@@ -33,26 +33,10 @@ func NewApp(basename string) *app.App {
 
 // NewRegistrar 创建服务注册器
 func NewRegistrar(registry *options.RegistryOptions, dev bool) registry.Registrar {
-    c := api.DefaultConfig()
-    c.Address = registry.Address
-    c.Scheme = registry.Scheme
-    cli, err := api.NewClient(c)
+    r, err := discovery.NewRegistrar(registry, dev)
     if err != nil {
         panic(err)
     }
-    opts := []consul.Option{consul.WithHealthCheck(true)}
-    if registry.HealthCheckInterval > 0 {
-        opts = append(opts, consul.WithHealthCheckInterval(registry.HealthCheckInterval))
-    }
-    if registry.CheckTimeout > 0 {
-        opts = append(opts, consul.WithCheckTimeout(registry.CheckTimeout))
-    }
-    if dev {
-        opts = append(opts, consul.WithDeregisterCriticalServiceAfter(60))
-    } else if registry.DeregisterCriticalAfter > 0 {
-        opts = append(opts, consul.WithDeregisterCriticalServiceAfter(registry.DeregisterCriticalAfter))
-    }
-    r := consul.New(cli, opts...)
     return r
 }
 
@@ -71,6 +55,9 @@ func NewDatabase(mysqlOpts *options.MySQLOptions) (*gorm.DB, error) {
 	}
 
 	gormtrace.Enable(db, mysqlOpts.Database)
+	if sqlDB, err := db.DB(); err == nil {
+		health.Register("mysql", health.PingCheck(sqlDB))
+	}
 
 	// 自动迁移数据表
 	if err = db.AutoMigrate(
@@ -94,9 +81,16 @@ func NewUserOpRPCServer(telemetry *options.TelemetryOptions, serverOpts *options
 		Batcher:  telemetry.Batcher,
 	})
 	rpcAddr := fmt.Sprintf("%s:%d", serverOpts.Host, serverOpts.Port)
+	grpcOpts, err := serverOpts.GRPCServerOptions()
+	if err != nil {
+		log.Fatalf("加载gRPC服务器选项失败: %v", err)
+	}
 	grpcServer := rpcserver.NewServer(
 		rpcserver.WithAddress(rpcAddr),
 		rpcserver.WithMetrics(serverOpts.EnableMetrics),
+		rpcserver.WithOptions(grpcOpts...),
+		rpcserver.WithAdaptiveLimit(serverOpts.Limiter.AdaptiveLimiter(), nil),
+		rpcserver.WithLogAdmin(serverOpts.LogAdminSecret),
 	)
 	RegisterGRPCServer(grpcServer.Server, srv)
 	return grpcServer
@@ -108,6 +102,8 @@ func NewUserOpApp(serverOpts *options.ServerOptions, register registry.Registrar
 
 	return gapp.New(
 		gapp.WithName(serverOpts.Name),
+		gapp.WithVersion(serverOpts.Version),
+		gapp.WithMetadata(serverOpts.Metadata),
 		gapp.WithRPCServer(rpcServer),
 		gapp.WithRegistrar(register),
 	), nil
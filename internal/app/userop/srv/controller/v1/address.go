@@ -22,11 +22,11 @@ func NewAddressController(service servicev1.Service) *AddressController {
 
 // GetAddressList 获取地址列表
 func (c *AddressController) GetAddressList(ctx context.Context, req *pb.AddressRequest) (*pb.AddressListResponse, error) {
-	log.Infof("GetAddressList request: user_id=%d", req.UserId)
+	log.InfofC(ctx, "GetAddressList request: user_id=%d", req.UserId)
 
 	addresses, total, err := c.service.AddressService().GetAddressList(ctx, req.UserId)
 	if err != nil {
-		log.Errorf("get address list failed: %v", err)
+		log.ErrorfC(ctx, "get address list failed: %v", err)
 		return nil, err
 	}
 
@@ -52,7 +52,7 @@ func (c *AddressController) GetAddressList(ctx context.Context, req *pb.AddressR
 
 // CreateAddress 创建地址
 func (c *AddressController) CreateAddress(ctx context.Context, req *pb.AddressRequest) (*pb.AddressResponse, error) {
-	log.Infof("CreateAddress request: user_id=%d", req.UserId)
+	log.InfofC(ctx, "CreateAddress request: user_id=%d", req.UserId)
 
 	createReq := &servicev1.AddressCreateRequest{
 		UserID:       req.UserId,
@@ -66,7 +66,7 @@ func (c *AddressController) CreateAddress(ctx context.Context, req *pb.AddressRe
 
 	address, err := c.service.AddressService().CreateAddress(ctx, createReq)
 	if err != nil {
-		log.Errorf("create address failed: %v", err)
+		log.ErrorfC(ctx, "create address failed: %v", err)
 		return nil, err
 	}
 
@@ -84,7 +84,7 @@ func (c *AddressController) CreateAddress(ctx context.Context, req *pb.AddressRe
 
 // UpdateAddress 更新地址
 func (c *AddressController) UpdateAddress(ctx context.Context, req *pb.AddressRequest) (*pb.AddressResponse, error) {
-	log.Infof("UpdateAddress request: id=%d, user_id=%d", req.Id, req.UserId)
+	log.InfofC(ctx, "UpdateAddress request: id=%d, user_id=%d", req.Id, req.UserId)
 
 	updateReq := &servicev1.AddressUpdateRequest{
 		ID:           req.Id,
@@ -99,7 +99,7 @@ func (c *AddressController) UpdateAddress(ctx context.Context, req *pb.AddressRe
 
 	err := c.service.AddressService().UpdateAddress(ctx, updateReq)
 	if err != nil {
-		log.Errorf("update address failed: %v", err)
+		log.ErrorfC(ctx, "update address failed: %v", err)
 		return nil, err
 	}
 
@@ -108,11 +108,11 @@ func (c *AddressController) UpdateAddress(ctx context.Context, req *pb.AddressRe
 
 // DeleteAddress 删除地址
 func (c *AddressController) DeleteAddress(ctx context.Context, req *pb.DeleteAddressRequest) (*pb.AddressResponse, error) {
-	log.Infof("DeleteAddress request: id=%d", req.Id)
+	log.InfofC(ctx, "DeleteAddress request: id=%d", req.Id)
 
 	err := c.service.AddressService().DeleteAddress(ctx, req.Id, req.UserId)
 	if err != nil {
-		log.Errorf("delete address failed: %v", err)
+		log.ErrorfC(ctx, "delete address failed: %v", err)
 		return nil, err
 	}
 
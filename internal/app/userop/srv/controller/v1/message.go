@@ -22,11 +22,11 @@ func NewMessageController(service servicev1.Service) *MessageController {
 
 // MessageList 获取留言列表
 func (c *MessageController) MessageList(ctx context.Context, req *pb.MessageRequest) (*pb.MessageListResponse, error) {
-	log.Infof("MessageList request: user_id=%d", req.UserId)
+	log.InfofC(ctx, "MessageList request: user_id=%d", req.UserId)
 
 	messages, total, err := c.service.MessageService().GetMessageList(ctx, req.UserId)
 	if err != nil {
-		log.Errorf("get message list failed: %v", err)
+		log.ErrorfC(ctx, "get message list failed: %v", err)
 		return nil, err
 	}
 
@@ -50,7 +50,7 @@ func (c *MessageController) MessageList(ctx context.Context, req *pb.MessageRequ
 
 // CreateMessage 创建留言
 func (c *MessageController) CreateMessage(ctx context.Context, req *pb.MessageRequest) (*pb.MessageResponse, error) {
-	log.Infof("CreateMessage request: user_id=%d, message_type=%d", req.UserId, req.MessageType)
+	log.InfofC(ctx, "CreateMessage request: user_id=%d, message_type=%d", req.UserId, req.MessageType)
 
 	createReq := &servicev1.MessageCreateRequest{
 		UserID:      req.UserId,
@@ -62,7 +62,7 @@ func (c *MessageController) CreateMessage(ctx context.Context, req *pb.MessageRe
 
 	message, err := c.service.MessageService().CreateMessage(ctx, createReq)
 	if err != nil {
-		log.Errorf("create message failed: %v", err)
+		log.ErrorfC(ctx, "create message failed: %v", err)
 		return nil, err
 	}
 
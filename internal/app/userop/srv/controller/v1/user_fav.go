@@ -22,11 +22,11 @@ func NewUserFavController(service servicev1.Service) *UserFavController {
 
 // UserFavList 获取用户收藏列表
 func (c *UserFavController) UserFavList(ctx context.Context, req *pb.UserFavListRequest) (*pb.UserFavListResponse, error) {
-	log.Infof("UserFavList request: user_id=%d", req.UserId)
+	log.InfofC(ctx, "UserFavList request: user_id=%d", req.UserId)
 
 	favs, total, err := c.service.UserFavService().GetUserFavList(ctx, req.UserId, 0)
 	if err != nil {
-		log.Errorf("get user fav list failed: %v", err)
+		log.ErrorfC(ctx, "get user fav list failed: %v", err)
 		return nil, err
 	}
 
@@ -46,11 +46,11 @@ func (c *UserFavController) UserFavList(ctx context.Context, req *pb.UserFavList
 
 // CreateUserFav 创建用户收藏
 func (c *UserFavController) CreateUserFav(ctx context.Context, req *pb.UserFavRequest) (*pb.UserFavResponse, error) {
-	log.Infof("CreateUserFav request: user_id=%d, goods_id=%d", req.UserId, req.GoodsId)
+	log.InfofC(ctx, "CreateUserFav request: user_id=%d, goods_id=%d", req.UserId, req.GoodsId)
 
 	userFav, err := c.service.UserFavService().CreateUserFav(ctx, req.UserId, req.GoodsId)
 	if err != nil {
-		log.Errorf("create user fav failed: %v", err)
+		log.ErrorfC(ctx, "create user fav failed: %v", err)
 		return nil, err
 	}
 
@@ -62,11 +62,11 @@ func (c *UserFavController) CreateUserFav(ctx context.Context, req *pb.UserFavRe
 
 // DeleteUserFav 删除用户收藏
 func (c *UserFavController) DeleteUserFav(ctx context.Context, req *pb.UserFavRequest) (*pb.UserFavResponse, error) {
-	log.Infof("DeleteUserFav request: user_id=%d, goods_id=%d", req.UserId, req.GoodsId)
+	log.InfofC(ctx, "DeleteUserFav request: user_id=%d, goods_id=%d", req.UserId, req.GoodsId)
 
 	err := c.service.UserFavService().DeleteUserFav(ctx, req.UserId, req.GoodsId)
 	if err != nil {
-		log.Errorf("delete user fav failed: %v", err)
+		log.ErrorfC(ctx, "delete user fav failed: %v", err)
 		return nil, err
 	}
 
@@ -75,11 +75,11 @@ func (c *UserFavController) DeleteUserFav(ctx context.Context, req *pb.UserFavRe
 
 // GetUserFavDetail 获取用户收藏详情（检查是否收藏）
 func (c *UserFavController) GetUserFavDetail(ctx context.Context, req *pb.UserFavRequest) (*pb.UserFavResponse, error) {
-	log.Infof("GetUserFavDetail request: user_id=%d, goods_id=%d", req.UserId, req.GoodsId)
+	log.InfofC(ctx, "GetUserFavDetail request: user_id=%d, goods_id=%d", req.UserId, req.GoodsId)
 
 	userFav, err := c.service.UserFavService().GetUserFavDetail(ctx, req.UserId, req.GoodsId)
 	if err != nil {
-		log.Errorf("get user fav detail failed: %v", err)
+		log.ErrorfC(ctx, "get user fav detail failed: %v", err)
 		return nil, err
 	}
 
@@ -25,11 +25,11 @@ func NewUserOpController(service servicev1.Service) *UserOpController {
 
 // UserFavList 获取用户收藏列表
 func (c *UserOpController) UserFavList(ctx context.Context, req *pb.UserFavListRequest) (*pb.UserFavListResponse, error) {
-	log.Infof("UserFavList request: user_id=%d", req.UserId)
+	log.InfofC(ctx, "UserFavList request: user_id=%d", req.UserId)
 
 	favs, total, err := c.service.UserFavService().GetUserFavList(ctx, req.UserId, 0)
 	if err != nil {
-		log.Errorf("get user fav list failed: %v", err)
+		log.ErrorfC(ctx, "get user fav list failed: %v", err)
 		return nil, err
 	}
 
@@ -47,13 +47,23 @@ func (c *UserOpController) UserFavList(ctx context.Context, req *pb.UserFavListR
 	}, nil
 }
 
+// ListFavUserIds 查询收藏了指定商品的用户ID
+func (c *UserOpController) ListFavUserIds(ctx context.Context, req *pb.ListFavUserIdsRequest) (*pb.FavUserIdsResponse, error) {
+	userIDs, err := c.service.UserFavService().ListUserIDsByGoods(ctx, req.GoodsIds, req.AfterUserId, int(req.Limit))
+	if err != nil {
+		log.ErrorfC(ctx, "list fav user ids failed: %v", err)
+		return nil, err
+	}
+	return &pb.FavUserIdsResponse{UserIds: userIDs}, nil
+}
+
 // CreateUserFav 创建用户收藏
 func (c *UserOpController) CreateUserFav(ctx context.Context, req *pb.UserFavRequest) (*pb.UserFavResponse, error) {
-	log.Infof("CreateUserFav request: user_id=%d, goods_id=%d", req.UserId, req.GoodsId)
+	log.InfofC(ctx, "CreateUserFav request: user_id=%d, goods_id=%d", req.UserId, req.GoodsId)
 
 	userFav, err := c.service.UserFavService().CreateUserFav(ctx, req.UserId, req.GoodsId)
 	if err != nil {
-		log.Errorf("create user fav failed: %v", err)
+		log.ErrorfC(ctx, "create user fav failed: %v", err)
 		return nil, err
 	}
 
@@ -65,11 +75,11 @@ func (c *UserOpController) CreateUserFav(ctx context.Context, req *pb.UserFavReq
 
 // DeleteUserFav 删除用户收藏
 func (c *UserOpController) DeleteUserFav(ctx context.Context, req *pb.UserFavRequest) (*emptypb.Empty, error) {
-	log.Infof("DeleteUserFav request: user_id=%d, goods_id=%d", req.UserId, req.GoodsId)
+	log.InfofC(ctx, "DeleteUserFav request: user_id=%d, goods_id=%d", req.UserId, req.GoodsId)
 
 	err := c.service.UserFavService().DeleteUserFav(ctx, req.UserId, req.GoodsId)
 	if err != nil {
-		log.Errorf("delete user fav failed: %v", err)
+		log.ErrorfC(ctx, "delete user fav failed: %v", err)
 		return nil, err
 	}
 
@@ -78,11 +88,11 @@ func (c *UserOpController) DeleteUserFav(ctx context.Context, req *pb.UserFavReq
 
 // GetUserFavDetail 获取用户收藏详情（检查是否收藏）
 func (c *UserOpController) GetUserFavDetail(ctx context.Context, req *pb.UserFavRequest) (*pb.UserFavResponse, error) {
-	log.Infof("GetUserFavDetail request: user_id=%d, goods_id=%d", req.UserId, req.GoodsId)
+	log.InfofC(ctx, "GetUserFavDetail request: user_id=%d, goods_id=%d", req.UserId, req.GoodsId)
 
 	userFav, err := c.service.UserFavService().GetUserFavDetail(ctx, req.UserId, req.GoodsId)
 	if err != nil {
-		log.Errorf("get user fav detail failed: %v", err)
+		log.ErrorfC(ctx, "get user fav detail failed: %v", err)
 		return nil, err
 	}
 
@@ -96,11 +106,11 @@ func (c *UserOpController) GetUserFavDetail(ctx context.Context, req *pb.UserFav
 
 // GetAddressList 获取地址列表
 func (c *UserOpController) GetAddressList(ctx context.Context, req *pb.AddressRequest) (*pb.AddressListResponse, error) {
-	log.Infof("GetAddressList request: user_id=%d", req.UserId)
+	log.InfofC(ctx, "GetAddressList request: user_id=%d", req.UserId)
 
 	addresses, total, err := c.service.AddressService().GetAddressList(ctx, req.UserId)
 	if err != nil {
-		log.Errorf("get address list failed: %v", err)
+		log.ErrorfC(ctx, "get address list failed: %v", err)
 		return nil, err
 	}
 
@@ -124,9 +134,19 @@ func (c *UserOpController) GetAddressList(ctx context.Context, req *pb.AddressRe
 	}, nil
 }
 
+// CountAddressSharedUsers 统计与用户共用收货地址的其他用户数
+func (c *UserOpController) CountAddressSharedUsers(ctx context.Context, req *pb.AddressSharedUsersRequest) (*pb.AddressSharedUsersResponse, error) {
+	count, err := c.service.AddressService().CountSharedUsers(ctx, req.UserId)
+	if err != nil {
+		log.ErrorfC(ctx, "count shared address users failed: %v", err)
+		return nil, err
+	}
+	return &pb.AddressSharedUsersResponse{Count: count}, nil
+}
+
 // CreateAddress 创建地址
 func (c *UserOpController) CreateAddress(ctx context.Context, req *pb.AddressRequest) (*pb.AddressResponse, error) {
-	log.Infof("CreateAddress request: user_id=%d", req.UserId)
+	log.InfofC(ctx, "CreateAddress request: user_id=%d", req.UserId)
 
 	createReq := &servicev1.AddressCreateRequest{
 		UserID:       req.UserId,
@@ -140,7 +160,7 @@ func (c *UserOpController) CreateAddress(ctx context.Context, req *pb.AddressReq
 
 	address, err := c.service.AddressService().CreateAddress(ctx, createReq)
 	if err != nil {
-		log.Errorf("create address failed: %v", err)
+		log.ErrorfC(ctx, "create address failed: %v", err)
 		return nil, err
 	}
 
@@ -158,7 +178,7 @@ func (c *UserOpController) CreateAddress(ctx context.Context, req *pb.AddressReq
 
 // UpdateAddress 更新地址
 func (c *UserOpController) UpdateAddress(ctx context.Context, req *pb.AddressRequest) (*emptypb.Empty, error) {
-	log.Infof("UpdateAddress request: id=%d, user_id=%d", req.Id, req.UserId)
+	log.InfofC(ctx, "UpdateAddress request: id=%d, user_id=%d", req.Id, req.UserId)
 
 	updateReq := &servicev1.AddressUpdateRequest{
 		ID:           req.Id,
@@ -173,7 +193,7 @@ func (c *UserOpController) UpdateAddress(ctx context.Context, req *pb.AddressReq
 
 	err := c.service.AddressService().UpdateAddress(ctx, updateReq)
 	if err != nil {
-		log.Errorf("update address failed: %v", err)
+		log.ErrorfC(ctx, "update address failed: %v", err)
 		return nil, err
 	}
 
@@ -182,11 +202,11 @@ func (c *UserOpController) UpdateAddress(ctx context.Context, req *pb.AddressReq
 
 // DeleteAddress 删除地址
 func (c *UserOpController) DeleteAddress(ctx context.Context, req *pb.DeleteAddressRequest) (*emptypb.Empty, error) {
-	log.Infof("DeleteAddress request: id=%d", req.Id)
+	log.InfofC(ctx, "DeleteAddress request: id=%d", req.Id)
 
 	err := c.service.AddressService().DeleteAddress(ctx, req.Id, req.UserId)
 	if err != nil {
-		log.Errorf("delete address failed: %v", err)
+		log.ErrorfC(ctx, "delete address failed: %v", err)
 		return nil, err
 	}
 
@@ -197,11 +217,11 @@ func (c *UserOpController) DeleteAddress(ctx context.Context, req *pb.DeleteAddr
 
 // MessageList 获取留言列表
 func (c *UserOpController) MessageList(ctx context.Context, req *pb.MessageRequest) (*pb.MessageListResponse, error) {
-	log.Infof("MessageList request: user_id=%d", req.UserId)
+	log.InfofC(ctx, "MessageList request: user_id=%d", req.UserId)
 
 	messages, total, err := c.service.MessageService().GetMessageList(ctx, req.UserId)
 	if err != nil {
-		log.Errorf("get message list failed: %v", err)
+		log.ErrorfC(ctx, "get message list failed: %v", err)
 		return nil, err
 	}
 
@@ -225,7 +245,7 @@ func (c *UserOpController) MessageList(ctx context.Context, req *pb.MessageReque
 
 // CreateMessage 创建留言
 func (c *UserOpController) CreateMessage(ctx context.Context, req *pb.MessageRequest) (*pb.MessageResponse, error) {
-	log.Infof("CreateMessage request: user_id=%d, message_type=%d", req.UserId, req.MessageType)
+	log.InfofC(ctx, "CreateMessage request: user_id=%d, message_type=%d", req.UserId, req.MessageType)
 
 	createReq := &servicev1.MessageCreateRequest{
 		UserID:      req.UserId,
@@ -237,7 +257,7 @@ func (c *UserOpController) CreateMessage(ctx context.Context, req *pb.MessageReq
 
 	message, err := c.service.MessageService().CreateMessage(ctx, createReq)
 	if err != nil {
-		log.Errorf("create message failed: %v", err)
+		log.ErrorfC(ctx, "create message failed: %v", err)
 		return nil, err
 	}
 
@@ -23,4 +23,7 @@ type AddressStore interface {
 	
 	// GetAddressByID 根据ID获取地址
 	GetAddressByID(ctx context.Context, db *gorm.DB, addressID int32, userID int32) (*do.Address, error)
+
+	// CountSharedUsers 统计与用户有相同收货地址的其他用户数
+	CountSharedUsers(ctx context.Context, db *gorm.DB, userID int32) (int64, error)
 }
\ No newline at end of file
@@ -20,4 +20,7 @@ type UserFavStore interface {
 	
 	// GetUserFavDetail 获取用户收藏详情（检查是否收藏）
 	GetUserFavDetail(ctx context.Context, db *gorm.DB, userID int32, goodsID int32) (*do.UserFav, error)
+
+	// ListUserIDsByGoods 查询收藏了任一商品的用户ID，按用户ID升序返回afterUserID之后的limit个
+	ListUserIDsByGoods(ctx context.Context, db *gorm.DB, goodsIDs []int32, afterUserID int32, limit int) ([]int32, error)
 }
\ No newline at end of file
@@ -28,7 +28,7 @@ func (r *addressRepository) GetAddressList(ctx context.Context, db *gorm.DB, use
 
 	result := db.WithContext(ctx).Where("user = ?", userID).Find(&addresses)
 	if result.Error != nil {
-		log.Errorf("get address list failed: %v", result.Error)
+		log.ErrorfC(ctx, "get address list failed: %v", result.Error)
 		return nil, 0, errors.WithCode(code2.ErrDatabase, "获取地址列表失败: %v", result.Error)
 	}
 
@@ -57,11 +57,11 @@ func (r *addressRepository) GetAddressList(ctx context.Context, db *gorm.DB, use
 // CreateAddress 创建地址
 func (r *addressRepository) CreateAddress(ctx context.Context, db *gorm.DB, address *do.Address) (*do.Address, error) {
 	if err := db.WithContext(ctx).Create(address).Error; err != nil {
-		log.Errorf("create address failed: %v", err)
+		log.ErrorfC(ctx, "create address failed: %v", err)
 		return nil, errors.WithCode(code2.ErrDatabase, "创建地址失败: %v", err)
 	}
 
-	log.Infof("created address %d for user %d successfully", address.ID, address.User)
+	log.InfofC(ctx, "created address %d for user %d successfully", address.ID, address.User)
 	return address, nil
 }
 
@@ -74,7 +74,7 @@ func (r *addressRepository) UpdateAddress(ctx context.Context, db *gorm.DB, addr
 		if result.Error == gorm.ErrRecordNotFound {
 			return status.Errorf(codes.NotFound, "地址不存在")
 		}
-		log.Errorf("find address failed: %v", result.Error)
+		log.ErrorfC(ctx, "find address failed: %v", result.Error)
 		return errors.WithCode(code2.ErrDatabase, "查找地址失败: %v", result.Error)
 	}
 
@@ -100,11 +100,11 @@ func (r *addressRepository) UpdateAddress(ctx context.Context, db *gorm.DB, addr
 	}
 
 	if err := db.WithContext(ctx).Model(&existingAddress).Updates(updates).Error; err != nil {
-		log.Errorf("update address failed: %v", err)
+		log.ErrorfC(ctx, "update address failed: %v", err)
 		return errors.WithCode(code2.ErrDatabase, "更新地址失败: %v", err)
 	}
 
-	log.Infof("updated address %d for user %d successfully", address.ID, address.User)
+	log.InfofC(ctx, "updated address %d for user %d successfully", address.ID, address.User)
 	return nil
 }
 
@@ -112,7 +112,7 @@ func (r *addressRepository) UpdateAddress(ctx context.Context, db *gorm.DB, addr
 func (r *addressRepository) DeleteAddress(ctx context.Context, db *gorm.DB, addressID int32, userID int32) error {
 	result := db.WithContext(ctx).Where("id = ? AND user = ?", addressID, userID).Delete(&do.Address{})
 	if result.Error != nil {
-		log.Errorf("delete address failed: %v", result.Error)
+		log.ErrorfC(ctx, "delete address failed: %v", result.Error)
 		return errors.WithCode(code2.ErrDatabase, "删除地址失败: %v", result.Error)
 	}
 
@@ -120,7 +120,7 @@ func (r *addressRepository) DeleteAddress(ctx context.Context, db *gorm.DB, addr
 		return status.Errorf(codes.NotFound, "地址不存在")
 	}
 
-	log.Infof("deleted address %d for user %d successfully", addressID, userID)
+	log.InfofC(ctx, "deleted address %d for user %d successfully", addressID, userID)
 	return nil
 }
 
@@ -132,9 +132,23 @@ func (r *addressRepository) GetAddressByID(ctx context.Context, db *gorm.DB, add
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, status.Errorf(codes.NotFound, "地址不存在")
 		}
-		log.Errorf("get address by id failed: %v", result.Error)
+		log.ErrorfC(ctx, "get address by id failed: %v", result.Error)
 		return nil, errors.WithCode(code2.ErrDatabase, "获取地址详情失败: %v", result.Error)
 	}
 
 	return &address, nil
-}
\ No newline at end of file
+}
+
+// CountSharedUsers 统计与用户有相同收货地址的其他用户数
+func (r *addressRepository) CountSharedUsers(ctx context.Context, db *gorm.DB, userID int32) (int64, error) {
+	var count int64
+	err := db.WithContext(ctx).Table("address AS a").
+		Joins("JOIN address AS b ON b.province = a.province AND b.city = a.city AND b.district = a.district AND b.address = a.address AND b.user <> a.user AND b.deleted_at IS NULL").
+		Where("a.user = ? AND a.deleted_at IS NULL", userID).
+		Distinct("b.user").Count(&count).Error
+	if err != nil {
+		log.ErrorfC(ctx, "count shared address users failed: %v", err)
+		return 0, errors.WithCode(code2.ErrDatabase, "统计共用地址用户失败: %v", err)
+	}
+	return count, nil
+}
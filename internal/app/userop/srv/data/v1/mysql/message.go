@@ -26,7 +26,7 @@ func (r *messageRepository) GetMessageList(ctx context.Context, db *gorm.DB, use
 
 	result := db.WithContext(ctx).Where("user = ?", userID).Find(&messages)
 	if result.Error != nil {
-		log.Errorf("get message list failed: %v", result.Error)
+		log.ErrorfC(ctx, "get message list failed: %v", result.Error)
 		return nil, 0, errors.WithCode(code2.ErrDatabase, "获取留言列表失败: %v", result.Error)
 	}
 
@@ -52,10 +52,10 @@ func (r *messageRepository) GetMessageList(ctx context.Context, db *gorm.DB, use
 // CreateMessage 创建留言
 func (r *messageRepository) CreateMessage(ctx context.Context, db *gorm.DB, message *do.LeavingMessages) (*do.LeavingMessages, error) {
 	if err := db.WithContext(ctx).Create(message).Error; err != nil {
-		log.Errorf("create message failed: %v", err)
+		log.ErrorfC(ctx, "create message failed: %v", err)
 		return nil, errors.WithCode(code2.ErrDatabase, "创建留言失败: %v", err)
 	}
 
-	log.Infof("created message %d for user %d successfully", message.ID, message.User)
+	log.InfofC(ctx, "created message %d for user %d successfully", message.ID, message.User)
 	return message, nil
 }
\ No newline at end of file
@@ -38,13 +38,13 @@ func (r *userFavRepository) GetUserFavList(ctx context.Context, db *gorm.DB, use
 
 	// 获取总数
 	if err := query.Count(&total).Error; err != nil {
-		log.Errorf("count user favorites failed: %v", err)
+		log.ErrorfC(ctx, "count user favorites failed: %v", err)
 		return nil, 0, errors.WithCode(code2.ErrDatabase, "获取收藏数量失败: %v", err)
 	}
 
 	// 获取列表
 	if err := query.Find(&userFavs).Error; err != nil {
-		log.Errorf("get user favorites failed: %v", err)
+		log.ErrorfC(ctx, "get user favorites failed: %v", err)
 		return nil, 0, errors.WithCode(code2.ErrDatabase, "获取收藏列表失败: %v", err)
 	}
 
@@ -68,16 +68,16 @@ func (r *userFavRepository) CreateUserFav(ctx context.Context, db *gorm.DB, user
 	var existingFav do.UserFav
 	result := db.WithContext(ctx).Where("user = ? AND goods = ? AND deleted_at IS NULL", userFav.User, userFav.Goods).First(&existingFav)
 	if result.Error == nil {
-		log.Warnf("user %d already favorited goods %d", userFav.User, userFav.Goods)
+		log.WarnfC(ctx, "user %d already favorited goods %d", userFav.User, userFav.Goods)
 		return &existingFav, nil // 已存在，返回现有记录
 	}
 
 	if err := db.WithContext(ctx).Create(userFav).Error; err != nil {
-		log.Errorf("create user favorite failed: %v", err)
+		log.ErrorfC(ctx, "create user favorite failed: %v", err)
 		return nil, errors.WithCode(code2.ErrDatabase, "添加收藏失败: %v", err)
 	}
 
-	log.Infof("user %d favorited goods %d successfully", userFav.User, userFav.Goods)
+	log.InfofC(ctx, "user %d favorited goods %d successfully", userFav.User, userFav.Goods)
 	return userFav, nil
 }
 
@@ -85,16 +85,16 @@ func (r *userFavRepository) CreateUserFav(ctx context.Context, db *gorm.DB, user
 func (r *userFavRepository) DeleteUserFav(ctx context.Context, db *gorm.DB, userID int32, goodsID int32) error {
 	result := db.WithContext(ctx).Unscoped().Where("goods = ? AND user = ?", goodsID, userID).Delete(&do.UserFav{})
 	if result.Error != nil {
-		log.Errorf("delete user favorite failed: %v", result.Error)
+		log.ErrorfC(ctx, "delete user favorite failed: %v", result.Error)
 		return errors.WithCode(code2.ErrDatabase, "删除收藏失败: %v", result.Error)
 	}
 
 	if result.RowsAffected == 0 {
-		log.Warnf("favorite not found for user %d and goods %d", userID, goodsID)
+		log.WarnfC(ctx, "favorite not found for user %d and goods %d", userID, goodsID)
 		return status.Errorf(codes.NotFound, "收藏记录不存在")
 	}
 
-	log.Infof("user %d removed favorite for goods %d successfully", userID, goodsID)
+	log.InfofC(ctx, "user %d removed favorite for goods %d successfully", userID, goodsID)
 	return nil
 }
 
@@ -106,9 +106,27 @@ func (r *userFavRepository) GetUserFavDetail(ctx context.Context, db *gorm.DB, u
 		if result.Error == gorm.ErrRecordNotFound {
 			return nil, status.Errorf(codes.NotFound, "收藏记录不存在")
 		}
-		log.Errorf("get user favorite detail failed: %v", result.Error)
+		log.ErrorfC(ctx, "get user favorite detail failed: %v", result.Error)
 		return nil, errors.WithCode(code2.ErrDatabase, "获取收藏详情失败: %v", result.Error)
 	}
 
 	return &userFav, nil
-}
\ No newline at end of file
+}
+
+// ListUserIDsByGoods 查询收藏了任一商品的用户ID
+func (r *userFavRepository) ListUserIDsByGoods(ctx context.Context, db *gorm.DB, goodsIDs []int32, afterUserID int32, limit int) ([]int32, error) {
+	if len(goodsIDs) == 0 {
+		return nil, nil
+	}
+
+	var userIDs []int32
+	err := db.WithContext(ctx).Model(&do.UserFav{}).
+		Where("deleted_at IS NULL AND goods IN ? AND user > ?", goodsIDs, afterUserID).
+		Distinct().Order("user ASC").Limit(limit).
+		Pluck("user", &userIDs).Error
+	if err != nil {
+		log.ErrorfC(ctx, "list fav user ids failed: %v", err)
+		return nil, errors.WithCode(code2.ErrDatabase, "查询收藏用户失败: %v", err)
+	}
+	return userIDs, nil
+}
@@ -17,6 +17,7 @@ type AddressService interface {
 	CreateAddress(ctx context.Context, req *AddressCreateRequest) (*do.Address, error)
 	UpdateAddress(ctx context.Context, req *AddressUpdateRequest) error
 	DeleteAddress(ctx context.Context, addressID int32, userID int32) error
+	CountSharedUsers(ctx context.Context, userID int32) (int32, error)
 }
 
 // AddressCreateRequest 创建地址请求
@@ -73,21 +74,21 @@ func NewAddressService(dataFactory datav1.DataFactory) AddressService {
 }
 
 func (s *addressService) GetAddressList(ctx context.Context, userID int32) ([]*dto.AddressDTO, int64, error) {
-	log.Debugf("Getting address list for user: %d", userID)
+	log.DebugfC(ctx, "Getting address list for user: %d", userID)
 	
 	// 直接使用预加载的DAO
 	addressList, total, err := s.addressDAO.GetAddressList(ctx, s.db, userID)
 	if err != nil {
-		log.Errorf("Failed to get address list for user %d: %v", userID, err)
+		log.ErrorfC(ctx, "Failed to get address list for user %d: %v", userID, err)
 		return nil, 0, err
 	}
 	
-	log.Debugf("Successfully got address list for user %d, total: %d", userID, total)
+	log.DebugfC(ctx, "Successfully got address list for user %d, total: %d", userID, total)
 	return addressList, total, nil
 }
 
 func (s *addressService) CreateAddress(ctx context.Context, req *AddressCreateRequest) (*do.Address, error) {
-	log.Debugf("Creating address for user: %d, address: %s", req.UserID, req.Address)
+	log.DebugfC(ctx, "Creating address for user: %d, address: %s", req.UserID, req.Address)
 	
 	address := &do.Address{
 		User:         req.UserID,
@@ -102,16 +103,16 @@ func (s *addressService) CreateAddress(ctx context.Context, req *AddressCreateRe
 	// 直接使用预加载的DAO
 	createdAddress, err := s.addressDAO.CreateAddress(ctx, s.db, address)
 	if err != nil {
-		log.Errorf("Failed to create address for user %d: %v", req.UserID, err)
+		log.ErrorfC(ctx, "Failed to create address for user %d: %v", req.UserID, err)
 		return nil, err
 	}
 	
-	log.Infof("Successfully created address for user %d, addressID: %d", req.UserID, createdAddress.ID)
+	log.InfofC(ctx, "Successfully created address for user %d, addressID: %d", req.UserID, createdAddress.ID)
 	return createdAddress, nil
 }
 
 func (s *addressService) UpdateAddress(ctx context.Context, req *AddressUpdateRequest) error {
-	log.Debugf("Updating address: ID=%d, userID=%d", req.ID, req.UserID)
+	log.DebugfC(ctx, "Updating address: ID=%d, userID=%d", req.ID, req.UserID)
 	
 	address := &do.Address{
 		BaseModel: do.BaseModel{
@@ -129,24 +130,34 @@ func (s *addressService) UpdateAddress(ctx context.Context, req *AddressUpdateRe
 	// 直接使用预加载的DAO
 	err := s.addressDAO.UpdateAddress(ctx, s.db, address)
 	if err != nil {
-		log.Errorf("Failed to update address: ID=%d, userID=%d, error=%v", req.ID, req.UserID, err)
+		log.ErrorfC(ctx, "Failed to update address: ID=%d, userID=%d, error=%v", req.ID, req.UserID, err)
 		return err
 	}
 	
-	log.Infof("Successfully updated address: ID=%d, userID=%d", req.ID, req.UserID)
+	log.InfofC(ctx, "Successfully updated address: ID=%d, userID=%d", req.ID, req.UserID)
 	return nil
 }
 
 func (s *addressService) DeleteAddress(ctx context.Context, addressID int32, userID int32) error {
-	log.Debugf("Deleting address: ID=%d, userID=%d", addressID, userID)
+	log.DebugfC(ctx, "Deleting address: ID=%d, userID=%d", addressID, userID)
 	
 	// 直接使用预加载的DAO
 	err := s.addressDAO.DeleteAddress(ctx, s.db, addressID, userID)
 	if err != nil {
-		log.Errorf("Failed to delete address: ID=%d, userID=%d, error=%v", addressID, userID, err)
+		log.ErrorfC(ctx, "Failed to delete address: ID=%d, userID=%d, error=%v", addressID, userID, err)
 		return err
 	}
 	
-	log.Infof("Successfully deleted address: ID=%d, userID=%d", addressID, userID)
+	log.InfofC(ctx, "Successfully deleted address: ID=%d, userID=%d", addressID, userID)
 	return nil
-}
\ No newline at end of file
+}
+
+// CountSharedUsers 统计与用户共用收货地址的其他用户数，用于风控识别批量注册的账号
+func (s *addressService) CountSharedUsers(ctx context.Context, userID int32) (int32, error) {
+	count, err := s.addressDAO.CountSharedUsers(ctx, s.db, userID)
+	if err != nil {
+		log.ErrorfC(ctx, "Failed to count shared address users for user %d: %v", userID, err)
+		return 0, err
+	}
+	return int32(count), nil
+}
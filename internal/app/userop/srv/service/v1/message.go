@@ -57,21 +57,21 @@ func NewMessageService(dataFactory datav1.DataFactory) MessageService {
 }
 
 func (s *messageService) GetMessageList(ctx context.Context, userID int32) ([]*dto.MessageDTO, int64, error) {
-	log.Debugf("Getting message list for user: %d", userID)
+	log.DebugfC(ctx, "Getting message list for user: %d", userID)
 	
 	// 直接使用预加载的DAO
 	messageList, total, err := s.messageDAO.GetMessageList(ctx, s.db, userID)
 	if err != nil {
-		log.Errorf("Failed to get message list for user %d: %v", userID, err)
+		log.ErrorfC(ctx, "Failed to get message list for user %d: %v", userID, err)
 		return nil, 0, err
 	}
 	
-	log.Debugf("Successfully got message list for user %d, total: %d", userID, total)
+	log.DebugfC(ctx, "Successfully got message list for user %d, total: %d", userID, total)
 	return messageList, total, nil
 }
 
 func (s *messageService) CreateMessage(ctx context.Context, req *MessageCreateRequest) (*do.LeavingMessages, error) {
-	log.Debugf("Creating message for user: %d, subject: %s", req.UserID, req.Subject)
+	log.DebugfC(ctx, "Creating message for user: %d, subject: %s", req.UserID, req.Subject)
 	
 	message := &do.LeavingMessages{
 		User:        req.UserID,
@@ -84,10 +84,10 @@ func (s *messageService) CreateMessage(ctx context.Context, req *MessageCreateRe
 	// 直接使用预加载的DAO
 	createdMessage, err := s.messageDAO.CreateMessage(ctx, s.db, message)
 	if err != nil {
-		log.Errorf("Failed to create message for user %d: %v", req.UserID, err)
+		log.ErrorfC(ctx, "Failed to create message for user %d: %v", req.UserID, err)
 		return nil, err
 	}
 	
-	log.Infof("Successfully created message for user %d, messageID: %d", req.UserID, createdMessage.ID)
+	log.InfofC(ctx, "Successfully created message for user %d, messageID: %d", req.UserID, createdMessage.ID)
 	return createdMessage, nil
 }
\ No newline at end of file
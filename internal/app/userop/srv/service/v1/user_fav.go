@@ -17,6 +17,7 @@ type UserFavService interface {
 	CreateUserFav(ctx context.Context, userID int32, goodsID int32) (*do.UserFav, error)
 	DeleteUserFav(ctx context.Context, userID int32, goodsID int32) error
 	GetUserFavDetail(ctx context.Context, userID int32, goodsID int32) (*do.UserFav, error)
+	ListUserIDsByGoods(ctx context.Context, goodsIDs []int32, afterUserID int32, limit int) ([]int32, error)
 }
 
 type userFavService struct {
@@ -51,21 +52,21 @@ func NewUserFavService(dataFactory datav1.DataFactory) UserFavService {
 }
 
 func (s *userFavService) GetUserFavList(ctx context.Context, userID int32, goodsID int32) ([]*dto.UserFavDTO, int64, error) {
-	log.Debugf("Getting user fav list: userID=%d, goodsID=%d", userID, goodsID)
+	log.DebugfC(ctx, "Getting user fav list: userID=%d, goodsID=%d", userID, goodsID)
 	
 	// 直接使用预加载的DAO
 	favList, total, err := s.userFavDAO.GetUserFavList(ctx, s.db, userID, goodsID)
 	if err != nil {
-		log.Errorf("Failed to get user fav list: userID=%d, goodsID=%d, error=%v", userID, goodsID, err)
+		log.ErrorfC(ctx, "Failed to get user fav list: userID=%d, goodsID=%d, error=%v", userID, goodsID, err)
 		return nil, 0, err
 	}
 	
-	log.Debugf("Successfully got user fav list: userID=%d, total=%d", userID, total)
+	log.DebugfC(ctx, "Successfully got user fav list: userID=%d, total=%d", userID, total)
 	return favList, total, nil
 }
 
 func (s *userFavService) CreateUserFav(ctx context.Context, userID int32, goodsID int32) (*do.UserFav, error) {
-	log.Debugf("Creating user fav: userID=%d, goodsID=%d", userID, goodsID)
+	log.DebugfC(ctx, "Creating user fav: userID=%d, goodsID=%d", userID, goodsID)
 	
 	userFav := &do.UserFav{
 		User:  userID,
@@ -75,38 +76,52 @@ func (s *userFavService) CreateUserFav(ctx context.Context, userID int32, goodsI
 	// 直接使用预加载的DAO
 	createdFav, err := s.userFavDAO.CreateUserFav(ctx, s.db, userFav)
 	if err != nil {
-		log.Errorf("Failed to create user fav: userID=%d, goodsID=%d, error=%v", userID, goodsID, err)
+		log.ErrorfC(ctx, "Failed to create user fav: userID=%d, goodsID=%d, error=%v", userID, goodsID, err)
 		return nil, err
 	}
 	
-	log.Infof("Successfully created user fav: userID=%d, goodsID=%d", userID, goodsID)
+	log.InfofC(ctx, "Successfully created user fav: userID=%d, goodsID=%d", userID, goodsID)
 	return createdFav, nil
 }
 
 func (s *userFavService) DeleteUserFav(ctx context.Context, userID int32, goodsID int32) error {
-	log.Debugf("Deleting user fav: userID=%d, goodsID=%d", userID, goodsID)
+	log.DebugfC(ctx, "Deleting user fav: userID=%d, goodsID=%d", userID, goodsID)
 	
 	// 直接使用预加载的DAO
 	err := s.userFavDAO.DeleteUserFav(ctx, s.db, userID, goodsID)
 	if err != nil {
-		log.Errorf("Failed to delete user fav: userID=%d, goodsID=%d, error=%v", userID, goodsID, err)
+		log.ErrorfC(ctx, "Failed to delete user fav: userID=%d, goodsID=%d, error=%v", userID, goodsID, err)
 		return err
 	}
 	
-	log.Infof("Successfully deleted user fav: userID=%d, goodsID=%d", userID, goodsID)
+	log.InfofC(ctx, "Successfully deleted user fav: userID=%d, goodsID=%d", userID, goodsID)
 	return nil
 }
 
 func (s *userFavService) GetUserFavDetail(ctx context.Context, userID int32, goodsID int32) (*do.UserFav, error) {
-	log.Debugf("Getting user fav detail: userID=%d, goodsID=%d", userID, goodsID)
+	log.DebugfC(ctx, "Getting user fav detail: userID=%d, goodsID=%d", userID, goodsID)
 	
 	// 直接使用预加载的DAO
 	favDetail, err := s.userFavDAO.GetUserFavDetail(ctx, s.db, userID, goodsID)
 	if err != nil {
-		log.Errorf("Failed to get user fav detail: userID=%d, goodsID=%d, error=%v", userID, goodsID, err)
+		log.ErrorfC(ctx, "Failed to get user fav detail: userID=%d, goodsID=%d, error=%v", userID, goodsID, err)
 		return nil, err
 	}
 	
-	log.Debugf("Successfully got user fav detail: userID=%d, goodsID=%d", userID, goodsID)
+	log.DebugfC(ctx, "Successfully got user fav detail: userID=%d, goodsID=%d", userID, goodsID)
 	return favDetail, nil
-}
\ No newline at end of file
+}
+
+// ListUserIDsByGoods 查询收藏了指定商品的用户ID，用于营销活动圈选用户
+func (s *userFavService) ListUserIDsByGoods(ctx context.Context, goodsIDs []int32, afterUserID int32, limit int) ([]int32, error) {
+	if limit <= 0 || limit > 5000 {
+		limit = 5000
+	}
+
+	userIDs, err := s.userFavDAO.ListUserIDsByGoods(ctx, s.db, goodsIDs, afterUserID, limit)
+	if err != nil {
+		log.ErrorfC(ctx, "Failed to list fav user ids: goods=%d, error=%v", len(goodsIDs), err)
+		return nil, err
+	}
+	return userIDs, nil
+}
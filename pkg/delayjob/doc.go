@@ -0,0 +1,16 @@
+// Package delayjob 提供各服务共用的持久化延时任务调度。
+//
+// 任务保存在Redis有序集合中(测试或单副本可使用内存存储)，多副本通过租约争用到期任务，
+// 失败后按指数退避重试，每次执行记录保存在任务历史中供管理端查看。
+//
+//	sched := delayjob.New(delayjob.NewRedisStore(client, delayjob.RedisOptions{Namespace: "coupon"}))
+//	sched.Register("coupon.expire", func(ctx context.Context, job *delayjob.Job) error {
+//		var req ExpireRequest
+//		if err := job.Bind(&req); err != nil {
+//			return delayjob.Permanent(err)
+//		}
+//		return expire(ctx, req)
+//	})
+//	sched.Schedule(ctx, "coupon.expire", req, delayjob.At(validEnd), delayjob.WithJobID("coupon.expire:123"))
+//	go sched.Start(ctx)
+package delayjob
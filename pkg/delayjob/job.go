@@ -0,0 +1,106 @@
+package delayjob
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Status 任务状态
+type Status string
+
+const (
+	StatusPending   Status = "pending"   // 等待执行(含等待重试)
+	StatusRunning   Status = "running"   // 已被某个副本租用执行中
+	StatusSucceeded Status = "succeeded" // 执行成功
+	StatusFailed    Status = "failed"    // 重试耗尽或不可重试的失败
+	StatusCanceled  Status = "canceled"  // 执行前被取消
+)
+
+// Finished 是否为终态
+func (s Status) Finished() bool {
+	return s == StatusSucceeded || s == StatusFailed || s == StatusCanceled
+}
+
+var (
+	// ErrJobNotFound 任务不存在或已过保留期
+	ErrJobNotFound = errors.New("delayjob: job not found")
+	// ErrLeaseLost 租约已过期并被其他副本重新获取，本次执行结果作废
+	ErrLeaseLost = errors.New("delayjob: lease lost")
+	// ErrInvalidState 当前状态不允许该操作
+	ErrInvalidState = errors.New("delayjob: invalid job state")
+)
+
+// Job 延时任务
+type Job struct {
+	ID          string    `json:"id"`
+	Type        string    `json:"type"`
+	Payload     string    `json:"payload"`
+	RunAt       time.Time `json:"runAt"`
+	Status      Status    `json:"status"`
+	Attempts    int       `json:"attempts"`
+	MaxAttempts int       `json:"maxAttempts"`
+	LastError   string    `json:"lastError,omitempty"`
+	Owner       string    `json:"owner,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+
+	// token 本次租约标识，提交结果时校验
+	token string
+	// leaseUntil 租约到期时间
+	leaseUntil time.Time
+}
+
+// Bind 将任务参数反序列化到v
+func (j *Job) Bind(v interface{}) error {
+	return json.Unmarshal([]byte(j.Payload), v)
+}
+
+// Attempt 一次执行记录
+type Attempt struct {
+	Attempt    int       `json:"attempt"`
+	Owner      string    `json:"owner"`
+	StartedAt  time.Time `json:"startedAt"`
+	FinishedAt time.Time `json:"finishedAt"`
+	Status     Status    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Outcome 一次执行的结果
+type Outcome struct {
+	Attempt Attempt
+	// Status 执行后的任务状态: StatusPending表示等待重试
+	Status Status
+	// NextRunAt 等待重试时的下次执行时间
+	NextRunAt time.Time
+}
+
+// ListOptions 任务查询条件
+type ListOptions struct {
+	Type   string
+	Status Status
+	Offset int
+	Limit  int
+}
+
+// permanentError 不可重试的错误
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent 包装处理器返回的错误，任务直接失败不再重试
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent 是否为不可重试的错误
+func IsPermanent(err error) bool {
+	var pe *permanentError
+	return errors.As(err, &pe)
+}
@@ -0,0 +1,193 @@
+package delayjob
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// memoryStore 进程内存储，用于单副本部署和测试
+type memoryStore struct {
+	mu         sync.Mutex
+	jobs       map[string]*Job
+	history    map[string][]Attempt
+	historyMax int
+	retention  time.Duration
+}
+
+// NewMemoryStore 创建进程内存储，重启后任务丢失
+func NewMemoryStore() Store {
+	return &memoryStore{
+		jobs:       make(map[string]*Job),
+		history:    make(map[string][]Attempt),
+		historyMax: defaultHistoryMax,
+		retention:  defaultRetention,
+	}
+}
+
+func (m *memoryStore) Enqueue(ctx context.Context, job *Job) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if old, ok := m.jobs[job.ID]; ok && old.Status != StatusCanceled {
+		return false, nil
+	}
+	cp := *job
+	cp.Status = StatusPending
+	cp.token = ""
+	m.jobs[job.ID] = &cp
+	delete(m.history, job.ID)
+	return true, nil
+}
+
+func (m *memoryStore) Acquire(ctx context.Context, owner string, now time.Time, lease time.Duration, limit int) ([]*Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	due := make([]*Job, 0)
+	for id, job := range m.jobs {
+		if job.Status.Finished() && now.Sub(job.UpdatedAt) > m.retention {
+			delete(m.jobs, id)
+			delete(m.history, id)
+			continue
+		}
+		if (job.Status == StatusPending && !job.RunAt.After(now)) ||
+			(job.Status == StatusRunning && !job.leaseUntil.After(now)) {
+			due = append(due, job)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return dueAt(due[i]).Before(dueAt(due[j])) })
+
+	acquired := make([]*Job, 0, limit)
+	for _, job := range due {
+		if len(acquired) >= limit {
+			break
+		}
+		if job.Status == StatusRunning && job.Attempts >= job.MaxAttempts {
+			// 最后一次执行租约过期，视为失败
+			job.Status = StatusFailed
+			job.LastError = "lease expired"
+			job.UpdatedAt = now
+			continue
+		}
+		job.Attempts++
+		job.Status = StatusRunning
+		job.Owner = owner
+		job.token = fmt.Sprintf("%s:%d", owner, job.Attempts)
+		job.leaseUntil = now.Add(lease)
+		job.UpdatedAt = now
+		cp := *job
+		acquired = append(acquired, &cp)
+	}
+	return acquired, nil
+}
+
+func (m *memoryStore) Finish(ctx context.Context, job *Job, outcome *Outcome) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cur, ok := m.jobs[job.ID]
+	if !ok || cur.Status != StatusRunning || cur.token != job.token {
+		return ErrLeaseLost
+	}
+	cur.Status = outcome.Status
+	cur.LastError = outcome.Attempt.Error
+	cur.UpdatedAt = outcome.Attempt.FinishedAt
+	cur.token = ""
+	if outcome.Status == StatusPending {
+		cur.RunAt = outcome.NextRunAt
+	}
+	h := append([]Attempt{outcome.Attempt}, m.history[job.ID]...)
+	if len(h) > m.historyMax {
+		h = h[:m.historyMax]
+	}
+	m.history[job.ID] = h
+	return nil
+}
+
+func (m *memoryStore) Cancel(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.jobs[id]
+	if !ok {
+		return ErrJobNotFound
+	}
+	if job.Status != StatusPending {
+		return ErrInvalidState
+	}
+	job.Status = StatusCanceled
+	job.UpdatedAt = time.Now()
+	return nil
+}
+
+func (m *memoryStore) Retry(ctx context.Context, id string, runAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.jobs[id]
+	if !ok {
+		return ErrJobNotFound
+	}
+	if job.Status != StatusFailed && job.Status != StatusCanceled {
+		return ErrInvalidState
+	}
+	job.Status = StatusPending
+	job.Attempts = 0
+	job.RunAt = runAt
+	job.UpdatedAt = time.Now()
+	return nil
+}
+
+func (m *memoryStore) Get(ctx context.Context, id string) (*Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job, ok := m.jobs[id]
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	cp := *job
+	return &cp, nil
+}
+
+func (m *memoryStore) History(ctx context.Context, id string) ([]Attempt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.jobs[id]; !ok {
+		return nil, ErrJobNotFound
+	}
+	return append([]Attempt(nil), m.history[id]...), nil
+}
+
+func (m *memoryStore) List(ctx context.Context, opts ListOptions) ([]*Job, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	matched := make([]*Job, 0)
+	for _, job := range m.jobs {
+		if matches(job, opts) {
+			cp := *job
+			matched = append(matched, &cp)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].CreatedAt.After(matched[j].CreatedAt) })
+
+	total := int64(len(matched))
+	if opts.Offset >= len(matched) {
+		return []*Job{}, total, nil
+	}
+	end := len(matched)
+	if opts.Limit > 0 && opts.Offset+opts.Limit < end {
+		end = opts.Offset + opts.Limit
+	}
+	return matched[opts.Offset:end], total, nil
+}
+
+func dueAt(job *Job) time.Time {
+	if job.Status == StatusRunning {
+		return job.leaseUntil
+	}
+	return job.RunAt
+}
+
+func matches(job *Job, opts ListOptions) bool {
+	return (opts.Type == "" || job.Type == opts.Type) && (opts.Status == "" || job.Status == opts.Status)
+}
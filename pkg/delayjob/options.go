@@ -0,0 +1,134 @@
+package delayjob
+
+import "time"
+
+type options struct {
+	owner        string
+	pollInterval time.Duration
+	lease        time.Duration
+	concurrency  int
+	timeout      time.Duration
+	maxAttempts  int
+	backoff      func(attempt int) time.Duration
+}
+
+// Option 调度器选项
+type Option func(o *options)
+
+// WithOwner 设置副本标识，默认为主机名加随机后缀
+func WithOwner(owner string) Option {
+	return func(o *options) {
+		if owner != "" {
+			o.owner = owner
+		}
+	}
+}
+
+// WithPollInterval 设置到期任务的检查间隔
+func WithPollInterval(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.pollInterval = d
+		}
+	}
+}
+
+// WithLease 设置任务租约时长，处理超时不得超过租约
+func WithLease(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.lease = d
+		}
+	}
+}
+
+// WithConcurrency 设置最大并发执行数
+func WithConcurrency(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.concurrency = n
+		}
+	}
+}
+
+// WithTimeout 设置默认处理超时
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.timeout = d
+		}
+	}
+}
+
+// WithMaxAttempts 设置默认最大执行次数
+func WithMaxAttempts(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.maxAttempts = n
+		}
+	}
+}
+
+// WithBackoff 设置重试退避策略
+func WithBackoff(backoff func(attempt int) time.Duration) Option {
+	return func(o *options) {
+		if backoff != nil {
+			o.backoff = backoff
+		}
+	}
+}
+
+// HandlerOption 任务类型选项
+type HandlerOption func(e *handlerEntry)
+
+// HandlerTimeout 设置该类型任务的处理超时
+func HandlerTimeout(d time.Duration) HandlerOption {
+	return func(e *handlerEntry) {
+		if d > 0 {
+			e.timeout = d
+		}
+	}
+}
+
+// HandlerMaxAttempts 设置该类型任务的默认最大执行次数
+func HandlerMaxAttempts(n int) HandlerOption {
+	return func(e *handlerEntry) {
+		if n > 0 {
+			e.maxAttempts = n
+		}
+	}
+}
+
+// ScheduleOption 投递选项
+type ScheduleOption func(j *Job)
+
+// At 指定执行时间
+func At(t time.Time) ScheduleOption {
+	return func(j *Job) {
+		j.RunAt = t
+	}
+}
+
+// After 指定延迟执行
+func After(d time.Duration) ScheduleOption {
+	return func(j *Job) {
+		j.RunAt = time.Now().Add(d)
+	}
+}
+
+// WithJobID 指定任务ID，用于去重和取消，如 "coupon-expire:123"
+//
+// 同ID的任务未结束或已执行结束(成功、失败)且仍在保留期内时，重复投递会被忽略；
+// 只有已取消的任务允许以同ID重新投递，失败的任务通过 Store.Retry 重新执行
+func WithJobID(id string) ScheduleOption {
+	return func(j *Job) {
+		j.ID = id
+	}
+}
+
+// MaxAttempts 指定本任务的最大执行次数
+func MaxAttempts(n int) ScheduleOption {
+	return func(j *Job) {
+		j.MaxAttempts = n
+	}
+}
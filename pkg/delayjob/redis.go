@@ -0,0 +1,374 @@
+package delayjob
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Redis键布局(均带{namespace}哈希标签，集群模式下位于同一slot):
+//
+//	delayjob:{ns}:due        ZSET 待执行任务，score为执行时间；执行中任务的score为租约到期时间
+//	delayjob:{ns}:index      ZSET 全部任务，score为创建时间，用于管理端分页
+//	delayjob:{ns}:expiring   ZSET 已结束任务，score为保留期截止时间，用于清理index
+//	delayjob:{ns}:job:{id}   HASH 任务详情，结束后按保留期过期，过期前同ID的投递视为重复
+//	delayjob:{ns}:hist:{id}  LIST 执行记录，最近的在前
+var (
+	enqueueScript = redis.NewScript(`
+local st = redis.call('HGET', KEYS[3], 'status')
+if st and st ~= 'canceled' then return 0 end
+redis.call('DEL', KEYS[3], KEYS[4])
+redis.call('HSET', KEYS[3], 'id', ARGV[1], 'type', ARGV[2], 'payload', ARGV[3], 'status', 'pending',
+  'attempts', 0, 'max_attempts', ARGV[5], 'run_at', ARGV[4], 'created_at', ARGV[6], 'updated_at', ARGV[6],
+  'last_error', '', 'owner', '', 'token', '')
+redis.call('ZADD', KEYS[1], ARGV[4], ARGV[1])
+redis.call('ZADD', KEYS[2], ARGV[6], ARGV[1])
+redis.call('ZREM', KEYS[5], ARGV[1])
+return 1
+`)
+
+	acquireScript = redis.NewScript(`
+local now = ARGV[1]
+local expired = redis.call('ZRANGEBYSCORE', KEYS[3], '-inf', now, 'LIMIT', 0, 100)
+for _, id in ipairs(expired) do
+  if redis.call('EXISTS', ARGV[5] .. id) == 0 then redis.call('ZREM', KEYS[2], id) end
+  redis.call('ZREM', KEYS[3], id)
+end
+
+local ids = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', now, 'LIMIT', 0, tonumber(ARGV[2]))
+local acquired = {}
+for _, id in ipairs(ids) do
+  local key = ARGV[5] .. id
+  local st = redis.call('HGET', key, 'status')
+  if st == 'pending' or st == 'running' then
+    local attempts = tonumber(redis.call('HGET', key, 'attempts'))
+    local max = tonumber(redis.call('HGET', key, 'max_attempts'))
+    if st == 'running' and attempts >= max then
+      redis.call('ZREM', KEYS[1], id)
+      redis.call('HSET', key, 'status', 'failed', 'last_error', 'lease expired', 'updated_at', now, 'token', '')
+      redis.call('EXPIRE', key, ARGV[7])
+      redis.call('EXPIRE', ARGV[6] .. id, ARGV[7])
+      redis.call('ZADD', KEYS[3], now + ARGV[7] * 1000, id)
+    else
+      attempts = attempts + 1
+      redis.call('HSET', key, 'status', 'running', 'attempts', attempts, 'owner', ARGV[4],
+        'token', ARGV[4] .. ':' .. attempts, 'lease_until', ARGV[3], 'updated_at', now)
+      redis.call('ZADD', KEYS[1], ARGV[3], id)
+      table.insert(acquired, id)
+    end
+  else
+    redis.call('ZREM', KEYS[1], id)
+  end
+end
+return acquired
+`)
+
+	finishScript = redis.NewScript(`
+if redis.call('HGET', KEYS[2], 'status') ~= 'running' or redis.call('HGET', KEYS[2], 'token') ~= ARGV[1] then
+  return 0
+end
+redis.call('HSET', KEYS[2], 'status', ARGV[2], 'last_error', ARGV[4], 'updated_at', ARGV[5], 'token', '')
+redis.call('LPUSH', KEYS[3], ARGV[6])
+redis.call('LTRIM', KEYS[3], 0, tonumber(ARGV[7]) - 1)
+if ARGV[2] == 'pending' then
+  redis.call('HSET', KEYS[2], 'run_at', ARGV[3])
+  redis.call('ZADD', KEYS[1], ARGV[3], ARGV[9])
+else
+  redis.call('ZREM', KEYS[1], ARGV[9])
+  redis.call('EXPIRE', KEYS[2], ARGV[8])
+  redis.call('EXPIRE', KEYS[3], ARGV[8])
+  redis.call('ZADD', KEYS[4], ARGV[5] + ARGV[8] * 1000, ARGV[9])
+end
+return 1
+`)
+
+	cancelScript = redis.NewScript(`
+local st = redis.call('HGET', KEYS[2], 'status')
+if not st then return -1 end
+if st ~= 'pending' then return 0 end
+redis.call('ZREM', KEYS[1], ARGV[1])
+redis.call('HSET', KEYS[2], 'status', 'canceled', 'updated_at', ARGV[2])
+redis.call('EXPIRE', KEYS[2], ARGV[3])
+redis.call('EXPIRE', KEYS[3], ARGV[3])
+redis.call('ZADD', KEYS[4], ARGV[2] + ARGV[3] * 1000, ARGV[1])
+return 1
+`)
+
+	retryScript = redis.NewScript(`
+local st = redis.call('HGET', KEYS[2], 'status')
+if not st then return -1 end
+if st ~= 'failed' and st ~= 'canceled' then return 0 end
+redis.call('HSET', KEYS[2], 'status', 'pending', 'attempts', 0, 'run_at', ARGV[2], 'updated_at', ARGV[3])
+redis.call('PERSIST', KEYS[2])
+redis.call('PERSIST', KEYS[3])
+redis.call('ZADD', KEYS[1], ARGV[2], ARGV[1])
+redis.call('ZREM', KEYS[4], ARGV[1])
+return 1
+`)
+)
+
+// RedisOptions Redis存储配置
+type RedisOptions struct {
+	// Namespace 命名空间，通常为服务名，不同服务的任务互不可见
+	Namespace string
+	// Retention 已结束任务及执行记录的保留时长
+	Retention time.Duration
+	// HistoryMax 每个任务保留的执行记录条数
+	HistoryMax int
+}
+
+type redisStore struct {
+	client     redis.UniversalClient
+	prefix     string
+	retention  time.Duration
+	historyMax int
+}
+
+// NewRedisStore 创建基于Redis有序集合的存储
+func NewRedisStore(client redis.UniversalClient, opts RedisOptions) Store {
+	if opts.Namespace == "" {
+		opts.Namespace = "default"
+	}
+	if opts.Retention <= 0 {
+		opts.Retention = defaultRetention
+	}
+	if opts.HistoryMax <= 0 {
+		opts.HistoryMax = defaultHistoryMax
+	}
+	return &redisStore{
+		client:     client,
+		prefix:     fmt.Sprintf("delayjob:{%s}:", opts.Namespace),
+		retention:  opts.Retention,
+		historyMax: opts.HistoryMax,
+	}
+}
+
+func (r *redisStore) dueKey() string           { return r.prefix + "due" }
+func (r *redisStore) indexKey() string         { return r.prefix + "index" }
+func (r *redisStore) expiringKey() string      { return r.prefix + "expiring" }
+func (r *redisStore) jobKey(id string) string  { return r.prefix + "job:" + id }
+func (r *redisStore) histKey(id string) string { return r.prefix + "hist:" + id }
+
+func (r *redisStore) Enqueue(ctx context.Context, job *Job) (bool, error) {
+	res, err := enqueueScript.Run(ctx, r.client,
+		[]string{r.dueKey(), r.indexKey(), r.jobKey(job.ID), r.histKey(job.ID), r.expiringKey()},
+		job.ID, job.Type, job.Payload, toMillis(job.RunAt), job.MaxAttempts, toMillis(job.CreatedAt),
+	).Int()
+	if err != nil {
+		return false, err
+	}
+	return res == 1, nil
+}
+
+func (r *redisStore) Acquire(ctx context.Context, owner string, now time.Time, lease time.Duration, limit int) ([]*Job, error) {
+	ids, err := acquireScript.Run(ctx, r.client,
+		[]string{r.dueKey(), r.indexKey(), r.expiringKey()},
+		toMillis(now), limit, toMillis(now.Add(lease)), owner,
+		r.prefix+"job:", r.prefix+"hist:", int64(r.retention/time.Second),
+	).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+	return r.load(ctx, ids)
+}
+
+func (r *redisStore) Finish(ctx context.Context, job *Job, outcome *Outcome) error {
+	attempt, err := json.Marshal(outcome.Attempt)
+	if err != nil {
+		return err
+	}
+	res, err := finishScript.Run(ctx, r.client,
+		[]string{r.dueKey(), r.jobKey(job.ID), r.histKey(job.ID), r.expiringKey()},
+		job.token, string(outcome.Status), toMillis(outcome.NextRunAt), outcome.Attempt.Error,
+		toMillis(outcome.Attempt.FinishedAt), attempt, r.historyMax, int64(r.retention/time.Second), job.ID,
+	).Int()
+	if err != nil {
+		return err
+	}
+	if res == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (r *redisStore) Cancel(ctx context.Context, id string) error {
+	res, err := cancelScript.Run(ctx, r.client,
+		[]string{r.dueKey(), r.jobKey(id), r.histKey(id), r.expiringKey()},
+		id, toMillis(time.Now()), int64(r.retention/time.Second),
+	).Int()
+	return stateResult(res, err)
+}
+
+func (r *redisStore) Retry(ctx context.Context, id string, runAt time.Time) error {
+	res, err := retryScript.Run(ctx, r.client,
+		[]string{r.dueKey(), r.jobKey(id), r.histKey(id), r.expiringKey()},
+		id, toMillis(runAt), toMillis(time.Now()),
+	).Int()
+	return stateResult(res, err)
+}
+
+func (r *redisStore) Get(ctx context.Context, id string) (*Job, error) {
+	jobs, err := r.load(ctx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	if len(jobs) == 0 {
+		return nil, ErrJobNotFound
+	}
+	return jobs[0], nil
+}
+
+func (r *redisStore) History(ctx context.Context, id string) ([]Attempt, error) {
+	exists, err := r.client.Exists(ctx, r.jobKey(id)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if exists == 0 {
+		return nil, ErrJobNotFound
+	}
+	raw, err := r.client.LRange(ctx, r.histKey(id), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	attempts := make([]Attempt, 0, len(raw))
+	for _, item := range raw {
+		var a Attempt
+		if err := json.Unmarshal([]byte(item), &a); err == nil {
+			attempts = append(attempts, a)
+		}
+	}
+	return attempts, nil
+}
+
+// listScanBatch 带过滤条件分页时每次从索引读取的条数
+const listScanBatch = 500
+
+func (r *redisStore) List(ctx context.Context, opts ListOptions) ([]*Job, int64, error) {
+	if opts.Limit <= 0 {
+		opts.Limit = 20
+	}
+
+	// 无过滤条件时直接按索引分页
+	if opts.Type == "" && opts.Status == "" {
+		total, err := r.client.ZCard(ctx, r.indexKey()).Result()
+		if err != nil {
+			return nil, 0, err
+		}
+		ids, err := r.client.ZRevRange(ctx, r.indexKey(), int64(opts.Offset), int64(opts.Offset+opts.Limit-1)).Result()
+		if err != nil {
+			return nil, 0, err
+		}
+		jobs, err := r.load(ctx, ids)
+		return jobs, total, err
+	}
+
+	var (
+		page    = make([]*Job, 0, opts.Limit)
+		matched int64
+	)
+	for start := int64(0); ; start += listScanBatch {
+		ids, err := r.client.ZRevRange(ctx, r.indexKey(), start, start+listScanBatch-1).Result()
+		if err != nil {
+			return nil, 0, err
+		}
+		jobs, err := r.load(ctx, ids)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, job := range jobs {
+			if !matches(job, opts) {
+				continue
+			}
+			if matched >= int64(opts.Offset) && len(page) < opts.Limit {
+				page = append(page, job)
+			}
+			matched++
+		}
+		if len(ids) < listScanBatch {
+			return page, matched, nil
+		}
+	}
+}
+
+// load 批量读取任务，已过期的任务从索引中移除
+func (r *redisStore) load(ctx context.Context, ids []string) ([]*Job, error) {
+	if len(ids) == 0 {
+		return []*Job{}, nil
+	}
+	pipe := r.client.Pipeline()
+	cmds := make([]*redis.StringStringMapCmd, len(ids))
+	for i, id := range ids {
+		cmds[i] = pipe.HGetAll(ctx, r.jobKey(id))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	jobs := make([]*Job, 0, len(ids))
+	var missing []interface{}
+	for i, cmd := range cmds {
+		fields := cmd.Val()
+		if len(fields) == 0 {
+			missing = append(missing, ids[i])
+			continue
+		}
+		jobs = append(jobs, decodeJob(fields))
+	}
+	if len(missing) > 0 {
+		r.client.ZRem(ctx, r.indexKey(), missing...)
+	}
+	return jobs, nil
+}
+
+func decodeJob(fields map[string]string) *Job {
+	atoi := func(k string) int {
+		v, _ := strconv.Atoi(fields[k])
+		return v
+	}
+	return &Job{
+		ID:          fields["id"],
+		Type:        fields["type"],
+		Payload:     fields["payload"],
+		RunAt:       fromMillis(fields["run_at"]),
+		Status:      Status(fields["status"]),
+		Attempts:    atoi("attempts"),
+		MaxAttempts: atoi("max_attempts"),
+		LastError:   fields["last_error"],
+		Owner:       fields["owner"],
+		CreatedAt:   fromMillis(fields["created_at"]),
+		UpdatedAt:   fromMillis(fields["updated_at"]),
+		token:       fields["token"],
+		leaseUntil:  fromMillis(fields["lease_until"]),
+	}
+}
+
+func stateResult(res int, err error) error {
+	switch {
+	case err != nil:
+		return err
+	case res < 0:
+		return ErrJobNotFound
+	case res == 0:
+		return ErrInvalidState
+	}
+	return nil
+}
+
+func toMillis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func fromMillis(s string) time.Time {
+	ms, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || ms == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
@@ -0,0 +1,88 @@
+package delayjob
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRedisStore 连接本地Redis测试库，不可用时跳过
+func newTestRedisStore(t *testing.T) Store {
+	client := redis.NewClient(&redis.Options{
+		Addr: "localhost:6379",
+		DB:   15, // 使用测试数据库
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skipf("redis not available: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	return NewRedisStore(client, RedisOptions{Namespace: "test-" + uuid.NewString()[:8], Retention: time.Minute})
+}
+
+func TestRedisStoreLifecycle(t *testing.T) {
+	store := newTestRedisStore(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	job := &Job{ID: "j1", Type: "t", Payload: `{"a":1}`, RunAt: now, MaxAttempts: 2, CreatedAt: now}
+	created, err := store.Enqueue(ctx, job)
+	require.NoError(t, err)
+	assert.True(t, created)
+	created, err = store.Enqueue(ctx, job)
+	require.NoError(t, err)
+	assert.False(t, created)
+
+	first, err := store.Acquire(ctx, "a", now, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, first, 1)
+	assert.Equal(t, `{"a":1}`, first[0].Payload)
+
+	none, err := store.Acquire(ctx, "b", now.Add(30*time.Second), time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, none)
+
+	second, err := store.Acquire(ctx, "b", now.Add(2*time.Minute), time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, second, 1)
+	assert.Equal(t, 2, second[0].Attempts)
+	assert.ErrorIs(t, store.Finish(ctx, first[0], &Outcome{Status: StatusSucceeded}), ErrLeaseLost)
+
+	require.NoError(t, store.Finish(ctx, second[0], &Outcome{
+		Status:  StatusFailed,
+		Attempt: Attempt{Attempt: 2, Owner: "b", Status: StatusFailed, Error: "boom", FinishedAt: time.Now()},
+	}))
+	got, err := store.Get(ctx, "j1")
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, got.Status)
+	assert.Equal(t, "boom", got.LastError)
+	created, err = store.Enqueue(ctx, job)
+	require.NoError(t, err)
+	assert.False(t, created, "finished job is kept as a tombstone")
+
+	history, err := store.History(ctx, "j1")
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, "b", history[0].Owner)
+
+	require.NoError(t, store.Retry(ctx, "j1", time.Now()))
+	assert.ErrorIs(t, store.Retry(ctx, "j1", time.Now()), ErrInvalidState)
+	require.NoError(t, store.Cancel(ctx, "j1"))
+	assert.ErrorIs(t, store.Cancel(ctx, "missing"), ErrJobNotFound)
+
+	jobs, total, err := store.List(ctx, ListOptions{Status: StatusCanceled})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "j1", jobs[0].ID)
+
+	created, err = store.Enqueue(ctx, job)
+	require.NoError(t, err)
+	assert.True(t, created, "canceled job can be scheduled again")
+}
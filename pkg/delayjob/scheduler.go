@@ -0,0 +1,344 @@
+package delayjob
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+
+	"emshop/pkg/log"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultRetention   = 7 * 24 * time.Hour
+	defaultHistoryMax  = 20
+	defaultMaxAttempts = 5
+)
+
+// Handler 任务处理函数，返回Permanent包装的错误时不再重试
+type Handler func(ctx context.Context, job *Job) error
+
+type handlerEntry struct {
+	handler     Handler
+	timeout     time.Duration
+	maxAttempts int
+}
+
+// Scheduler 延时任务调度器
+//
+// 多副本部署时各副本共享同一存储，任务按租约获取，同一时刻只会被一个副本执行；
+// 副本宕机后租约到期，任务会被其他副本重新获取。处理函数应保证幂等。
+// 实现了 gin-micro 的 server.Server 接口
+type Scheduler struct {
+	store    Store
+	opts     options
+	mu       sync.RWMutex
+	handlers map[string]*handlerEntry
+	periodic map[string]time.Duration
+
+	lifecycle sync.Mutex
+	cancel    context.CancelFunc
+	stopped   chan struct{}
+	wg        sync.WaitGroup
+}
+
+// New 创建调度器
+func New(store Store, opts ...Option) *Scheduler {
+	o := options{
+		pollInterval: time.Second,
+		lease:        time.Minute,
+		concurrency:  8,
+		timeout:      30 * time.Second,
+		maxAttempts:  defaultMaxAttempts,
+		backoff:      ExponentialBackoff(5*time.Second, 10*time.Minute),
+	}
+	if host, err := os.Hostname(); err == nil {
+		o.owner = fmt.Sprintf("%s-%s", host, uuid.NewString()[:8])
+	} else {
+		o.owner = uuid.NewString()
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.timeout >= o.lease {
+		o.timeout = o.lease / 2
+	}
+	return &Scheduler{
+		store:    store,
+		opts:     o,
+		handlers: make(map[string]*handlerEntry),
+		periodic: make(map[string]time.Duration),
+	}
+}
+
+// Store 返回调度器使用的存储
+func (s *Scheduler) Store() Store {
+	return s.store
+}
+
+// Register 注册任务类型的处理函数
+func (s *Scheduler) Register(jobType string, h Handler, opts ...HandlerOption) {
+	entry := &handlerEntry{handler: h, timeout: s.opts.timeout, maxAttempts: s.opts.maxAttempts}
+	for _, opt := range opts {
+		opt(entry)
+	}
+	if entry.timeout >= s.opts.lease {
+		entry.timeout = s.opts.lease / 2
+	}
+	s.mu.Lock()
+	s.handlers[jobType] = entry
+	s.mu.Unlock()
+}
+
+// Every 注册周期任务，按interval对齐的时间点执行
+//
+// 每个时间点以 "类型@毫秒时间戳" 作为任务ID投递，多副本同时投递也只会执行一次。
+// 调度循环每次轮询时补投下一个时间点，投递失败在下次轮询重试，不依赖上一次执行结束，
+// 副本宕机、处理超时只影响当次执行。周期任务只执行一次不重试，失败由下一个时间点补上，
+// 避免重试与下一个时间点重叠执行
+func (s *Scheduler) Every(jobType string, interval time.Duration, h Handler, opts ...HandlerOption) {
+	s.Register(jobType, h, append(opts, HandlerMaxAttempts(1))...)
+	s.mu.Lock()
+	s.periodic[jobType] = interval
+	s.mu.Unlock()
+}
+
+// schedulePeriodic 投递各周期任务的下一个时间点，scheduled记录本副本已投递成功的时间点，
+// 同一时间点只投递一次，投递失败的下次调用重试
+func (s *Scheduler) schedulePeriodic(ctx context.Context, scheduled map[string]int64) {
+	s.mu.RLock()
+	periodic := make(map[string]time.Duration, len(s.periodic))
+	for jobType, interval := range s.periodic {
+		periodic[jobType] = interval
+	}
+	s.mu.RUnlock()
+
+	now := time.Now()
+	for jobType, interval := range periodic {
+		next := now.Truncate(interval).Add(interval)
+		if scheduled[jobType] == toMillis(next) {
+			continue
+		}
+		if err := s.scheduleNext(ctx, jobType, next); err != nil {
+			if ctx.Err() == nil {
+				log.Warnf("[delayjob] 投递周期任务失败，下次轮询重试: type=%s, err=%v", jobType, err)
+			}
+			continue
+		}
+		scheduled[jobType] = toMillis(next)
+	}
+}
+
+func (s *Scheduler) scheduleNext(ctx context.Context, jobType string, next time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	_, err := s.Schedule(ctx, jobType, nil, At(next), MaxAttempts(1),
+		WithJobID(fmt.Sprintf("%s@%d", jobType, toMillis(next))))
+	return err
+}
+
+// Schedule 投递任务，payload序列化为JSON；指定WithJobID时同ID的任务在保留期内不会重复投递
+func (s *Scheduler) Schedule(ctx context.Context, jobType string, payload interface{}, opts ...ScheduleOption) (*Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("delayjob: marshal payload: %w", err)
+	}
+
+	now := time.Now()
+	job := &Job{
+		Type:      jobType,
+		Payload:   string(data),
+		RunAt:     now,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	for _, opt := range opts {
+		opt(job)
+	}
+	if job.ID == "" {
+		job.ID = uuid.NewString()
+	}
+	if job.MaxAttempts <= 0 {
+		job.MaxAttempts = s.opts.maxAttempts
+		s.mu.RLock()
+		if entry, ok := s.handlers[jobType]; ok {
+			job.MaxAttempts = entry.maxAttempts
+		}
+		s.mu.RUnlock()
+	}
+
+	created, err := s.store.Enqueue(ctx, job)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		log.Debugf("[delayjob] 任务已存在，跳过投递: type=%s, id=%s", jobType, job.ID)
+	}
+	job.Status = StatusPending
+	return job, nil
+}
+
+// Cancel 取消等待中的任务
+func (s *Scheduler) Cancel(ctx context.Context, id string) error {
+	return s.store.Cancel(ctx, id)
+}
+
+// Start 启动调度循环，阻塞直到ctx取消或调用Stop
+func (s *Scheduler) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	stopped := make(chan struct{})
+	s.lifecycle.Lock()
+	s.cancel, s.stopped = cancel, stopped
+	s.lifecycle.Unlock()
+	defer close(stopped)
+
+	log.Infof("[delayjob] 调度器启动: owner=%s, 并发=%d, 租约=%s", s.opts.owner, s.opts.concurrency, s.opts.lease)
+
+	scheduled := make(map[string]int64)
+	s.schedulePeriodic(ctx, scheduled)
+
+	sem := make(chan struct{}, s.opts.concurrency)
+	ticker := time.NewTicker(s.opts.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.wg.Wait()
+			return nil
+		case <-ticker.C:
+		}
+
+		s.schedulePeriodic(ctx, scheduled)
+
+		free := s.opts.concurrency - len(sem)
+		if free <= 0 {
+			continue
+		}
+		jobs, err := s.store.Acquire(ctx, s.opts.owner, time.Now(), s.opts.lease, free)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Errorf("[delayjob] 获取到期任务失败: %v", err)
+			}
+			continue
+		}
+		for _, job := range jobs {
+			sem <- struct{}{}
+			s.wg.Add(1)
+			go func(job *Job) {
+				defer func() {
+					<-sem
+					s.wg.Done()
+				}()
+				s.execute(job)
+			}(job)
+		}
+	}
+}
+
+// Stop 停止调度并等待执行中的任务完成
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.lifecycle.Lock()
+	cancel, stopped := s.cancel, s.stopped
+	s.lifecycle.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	select {
+	case <-stopped:
+		log.Info("[delayjob] 调度器已停止")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RunOnce 获取并同步执行一批到期任务，返回执行的任务数，用于测试和手动触发
+func (s *Scheduler) RunOnce(ctx context.Context) (int, error) {
+	jobs, err := s.store.Acquire(ctx, s.opts.owner, time.Now(), s.opts.lease, s.opts.concurrency)
+	if err != nil {
+		return 0, err
+	}
+	for _, job := range jobs {
+		s.execute(job)
+	}
+	return len(jobs), nil
+}
+
+func (s *Scheduler) execute(job *Job) {
+	s.mu.RLock()
+	entry, ok := s.handlers[job.Type]
+	s.mu.RUnlock()
+
+	started := time.Now()
+	var err error
+	if !ok {
+		err = fmt.Errorf("no handler registered for job type %q", job.Type)
+	} else {
+		err = s.invoke(entry, job)
+	}
+	finished := time.Now()
+
+	outcome := &Outcome{
+		Attempt: Attempt{
+			Attempt:    job.Attempts,
+			Owner:      s.opts.owner,
+			StartedAt:  started,
+			FinishedAt: finished,
+		},
+	}
+	switch {
+	case err == nil:
+		outcome.Status = StatusSucceeded
+	case IsPermanent(err) || job.Attempts >= job.MaxAttempts:
+		outcome.Status = StatusFailed
+		outcome.Attempt.Error = err.Error()
+		log.Errorf("[delayjob] 任务执行失败: type=%s, id=%s, 第%d次, err=%v", job.Type, job.ID, job.Attempts, err)
+	default:
+		outcome.Status = StatusPending
+		outcome.NextRunAt = finished.Add(s.opts.backoff(job.Attempts))
+		outcome.Attempt.Error = err.Error()
+		log.Warnf("[delayjob] 任务执行失败，%s后重试: type=%s, id=%s, 第%d次, err=%v",
+			outcome.NextRunAt.Sub(finished).Round(time.Second), job.Type, job.ID, job.Attempts, err)
+	}
+	outcome.Attempt.Status = outcome.Status
+
+	// 使用独立的context提交结果，避免调度器停止时丢失已完成的执行结果
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.store.Finish(ctx, job, outcome); err != nil {
+		log.Warnf("[delayjob] 提交任务结果失败: type=%s, id=%s, err=%v", job.Type, job.ID, err)
+	}
+}
+
+func (s *Scheduler) invoke(entry *handlerEntry, job *Job) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), entry.timeout)
+	defer cancel()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return entry.handler(ctx, job)
+}
+
+// ExponentialBackoff 指数退避，带±20%抖动
+func ExponentialBackoff(base, max time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		d := base
+		for i := 1; i < attempt && d < max; i++ {
+			d *= 2
+		}
+		if d > max {
+			d = max
+		}
+		jitter := time.Duration(rand.Int63n(int64(d)/5*2+1)) - d/5
+		return d + jitter
+	}
+}
@@ -0,0 +1,324 @@
+package delayjob
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func noBackoff(int) time.Duration { return 0 }
+
+func TestScheduleAndRun(t *testing.T) {
+	ctx := context.Background()
+	s := New(NewMemoryStore(), WithBackoff(noBackoff))
+
+	var got struct{ OrderSn string }
+	s.Register("order.close", func(ctx context.Context, job *Job) error {
+		return job.Bind(&got)
+	})
+
+	job, err := s.Schedule(ctx, "order.close", map[string]string{"OrderSn": "SN001"})
+	require.NoError(t, err)
+
+	n, err := s.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, "SN001", got.OrderSn)
+
+	stored, err := s.Store().Get(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusSucceeded, stored.Status)
+	assert.Equal(t, 1, stored.Attempts)
+
+	history, err := s.Store().History(ctx, job.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, StatusSucceeded, history[0].Status)
+}
+
+func TestDelayedJobNotDueYet(t *testing.T) {
+	ctx := context.Background()
+	s := New(NewMemoryStore())
+	s.Register("noop", func(ctx context.Context, job *Job) error { return nil })
+
+	_, err := s.Schedule(ctx, "noop", nil, After(time.Hour))
+	require.NoError(t, err)
+
+	n, err := s.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestRetryUntilExhausted(t *testing.T) {
+	ctx := context.Background()
+	s := New(NewMemoryStore(), WithBackoff(noBackoff))
+
+	var calls int32
+	s.Register("flaky", func(ctx context.Context, job *Job) error {
+		atomic.AddInt32(&calls, 1)
+		return errors.New("downstream unavailable")
+	}, HandlerMaxAttempts(3))
+
+	job, err := s.Schedule(ctx, "flaky", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, job.MaxAttempts)
+
+	for i := 0; i < 5; i++ {
+		_, err := s.RunOnce(ctx)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	stored, err := s.Store().Get(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, stored.Status)
+	assert.Equal(t, "downstream unavailable", stored.LastError)
+
+	history, err := s.Store().History(ctx, job.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.Equal(t, 3, history[0].Attempt)
+	assert.Equal(t, StatusFailed, history[0].Status)
+	assert.Equal(t, StatusPending, history[1].Status)
+
+	// 管理端手动重试
+	require.NoError(t, s.Store().Retry(ctx, job.ID, time.Now()))
+	n, err := s.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestPermanentErrorAndPanic(t *testing.T) {
+	ctx := context.Background()
+	s := New(NewMemoryStore(), WithBackoff(noBackoff))
+	s.Register("bad", func(ctx context.Context, job *Job) error {
+		return Permanent(errors.New("invalid payload"))
+	})
+	s.Register("panic", func(ctx context.Context, job *Job) error {
+		panic("boom")
+	})
+
+	bad, err := s.Schedule(ctx, "bad", nil)
+	require.NoError(t, err)
+	p, err := s.Schedule(ctx, "panic", nil, MaxAttempts(1))
+	require.NoError(t, err)
+	_, err = s.RunOnce(ctx)
+	require.NoError(t, err)
+
+	stored, _ := s.Store().Get(ctx, bad.ID)
+	assert.Equal(t, StatusFailed, stored.Status)
+	assert.Equal(t, 1, stored.Attempts)
+
+	stored, _ = s.Store().Get(ctx, p.ID)
+	assert.Equal(t, StatusFailed, stored.Status)
+	assert.Contains(t, stored.LastError, "boom")
+}
+
+func TestUnknownTypeIsRetried(t *testing.T) {
+	ctx := context.Background()
+	s := New(NewMemoryStore(), WithBackoff(noBackoff))
+
+	job, err := s.Schedule(ctx, "registered.later", nil)
+	require.NoError(t, err)
+	_, err = s.RunOnce(ctx)
+	require.NoError(t, err)
+
+	stored, _ := s.Store().Get(ctx, job.ID)
+	assert.Equal(t, StatusPending, stored.Status)
+
+	// 处理器在其他副本或新版本中注册后可继续执行
+	s.Register("registered.later", func(ctx context.Context, job *Job) error { return nil })
+	_, err = s.RunOnce(ctx)
+	require.NoError(t, err)
+	stored, _ = s.Store().Get(ctx, job.ID)
+	assert.Equal(t, StatusSucceeded, stored.Status)
+}
+
+func TestDedupAndCancel(t *testing.T) {
+	ctx := context.Background()
+	s := New(NewMemoryStore())
+	s.Register("coupon.expire", func(ctx context.Context, job *Job) error { return nil })
+
+	_, err := s.Schedule(ctx, "coupon.expire", 1, WithJobID("coupon.expire:1"), After(time.Hour))
+	require.NoError(t, err)
+	_, err = s.Schedule(ctx, "coupon.expire", 1, WithJobID("coupon.expire:1"), After(2*time.Hour))
+	require.NoError(t, err)
+
+	jobs, total, err := s.Store().List(ctx, ListOptions{Type: "coupon.expire"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), jobs[0].RunAt, time.Minute)
+
+	require.NoError(t, s.Cancel(ctx, "coupon.expire:1"))
+	assert.ErrorIs(t, s.Cancel(ctx, "coupon.expire:1"), ErrInvalidState)
+	assert.ErrorIs(t, s.Cancel(ctx, "missing"), ErrJobNotFound)
+
+	// 取消后允许以同ID重新投递
+	_, err = s.Schedule(ctx, "coupon.expire", 1, WithJobID("coupon.expire:1"))
+	require.NoError(t, err)
+	n, err := s.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	// 执行结束的任务在保留期内作为墓碑，同ID重复投递不会再次执行
+	_, err = s.Schedule(ctx, "coupon.expire", 1, WithJobID("coupon.expire:1"))
+	require.NoError(t, err)
+	n, err = s.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+	stored, err := s.Store().Get(ctx, "coupon.expire:1")
+	require.NoError(t, err)
+	assert.Equal(t, StatusSucceeded, stored.Status)
+}
+
+func TestLeaseExpiredTakeover(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	job := &Job{ID: "j1", Type: "t", RunAt: time.Now(), MaxAttempts: 3, CreatedAt: time.Now()}
+	_, err := store.Enqueue(ctx, job)
+	require.NoError(t, err)
+
+	now := time.Now()
+	first, err := store.Acquire(ctx, "replica-a", now, time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, first, 1)
+
+	// 租约内其他副本获取不到
+	none, err := store.Acquire(ctx, "replica-b", now.Add(30*time.Second), time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, none)
+
+	// 副本A宕机，租约过期后由副本B接管
+	second, err := store.Acquire(ctx, "replica-b", now.Add(2*time.Minute), time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, second, 1)
+	assert.Equal(t, 2, second[0].Attempts)
+
+	// 副本A恢复后提交的结果作废
+	err = store.Finish(ctx, first[0], &Outcome{Status: StatusSucceeded})
+	assert.ErrorIs(t, err, ErrLeaseLost)
+	require.NoError(t, store.Finish(ctx, second[0], &Outcome{Status: StatusSucceeded}))
+}
+
+func TestStartStop(t *testing.T) {
+	s := New(NewMemoryStore(), WithPollInterval(10*time.Millisecond), WithConcurrency(4))
+
+	var wg sync.WaitGroup
+	wg.Add(10)
+	s.Register("count", func(ctx context.Context, job *Job) error {
+		wg.Done()
+		return nil
+	})
+	for i := 0; i < 10; i++ {
+		_, err := s.Schedule(context.Background(), "count", i)
+		require.NoError(t, err)
+	}
+
+	go func() { _ = s.Start(context.Background()) }()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("jobs were not executed in time")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, s.Stop(ctx))
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	b := ExponentialBackoff(time.Second, 10*time.Second)
+	for attempt, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 10: 10 * time.Second} {
+		d := b(attempt)
+		assert.InDelta(t, float64(want), float64(d), float64(want)/5+1, "attempt %d", attempt)
+	}
+}
+
+func TestEveryDedupAcrossReplicas(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	a := New(store, WithOwner("a"))
+	b := New(store, WithOwner("b"))
+
+	var runs int32
+	h := func(ctx context.Context, job *Job) error {
+		atomic.AddInt32(&runs, 1)
+		return nil
+	}
+	interval := 100 * time.Millisecond
+	a.Every("sweep", interval, h)
+	b.Every("sweep", interval, h)
+	scheduledA, scheduledB := make(map[string]int64), make(map[string]int64)
+	a.schedulePeriodic(ctx, scheduledA)
+	b.schedulePeriodic(ctx, scheduledB)
+
+	_, total, err := store.List(ctx, ListOptions{Type: "sweep"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+
+	// 到期后只执行一次，下一次轮询投递下一个时间点
+	time.Sleep(interval + 20*time.Millisecond)
+	na, err := a.RunOnce(ctx)
+	require.NoError(t, err)
+	nb, err := b.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, na+nb)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&runs))
+
+	a.schedulePeriodic(ctx, scheduledA)
+	jobs, total, err := store.List(ctx, ListOptions{Type: "sweep", Status: StatusPending})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	assert.True(t, jobs[0].RunAt.After(time.Now()))
+}
+
+func TestEveryContinuesAfterFailedTick(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	s := New(store, WithBackoff(noBackoff), WithMaxAttempts(5))
+
+	var runs int32
+	s.Every("sweep", 50*time.Millisecond, func(ctx context.Context, job *Job) error {
+		atomic.AddInt32(&runs, 1)
+		return errors.New("db unavailable")
+	}, HandlerMaxAttempts(3))
+	scheduled := make(map[string]int64)
+	s.schedulePeriodic(ctx, scheduled)
+
+	// 失败的时间点不重试，直接标记失败
+	time.Sleep(70 * time.Millisecond)
+	n, err := s.RunOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	failed, total, err := store.List(ctx, ListOptions{Type: "sweep", Status: StatusFailed})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), total)
+	assert.Equal(t, 1, failed[0].MaxAttempts)
+
+	// 上一个时间点失败或副本宕机不影响下一个时间点的投递
+	s.schedulePeriodic(ctx, scheduled)
+	time.Sleep(60 * time.Millisecond)
+	n, err = s.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&runs))
+
+	// 重复调用不会重复投递同一时间点
+	s.schedulePeriodic(ctx, scheduled)
+	s.schedulePeriodic(ctx, scheduled)
+	_, total, err = store.List(ctx, ListOptions{Type: "sweep", Status: StatusPending})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+}
@@ -0,0 +1,34 @@
+package delayjob
+
+import (
+	"context"
+	"time"
+)
+
+// Store 任务持久化存储，所有方法需保证多副本并发安全
+type Store interface {
+	// Enqueue 入队任务，同ID任务未结束或已结束但仍在保留期内时不重复入队并返回false，已取消的任务除外
+	Enqueue(ctx context.Context, job *Job) (bool, error)
+
+	// Acquire 租用最多limit个到期任务，租约到期前其他副本不会再获取；
+	// 租约过期仍未提交结果的任务(如副本宕机)会被重新获取
+	Acquire(ctx context.Context, owner string, now time.Time, lease time.Duration, limit int) ([]*Job, error)
+
+	// Finish 提交执行结果，租约已被他人获取时返回ErrLeaseLost
+	Finish(ctx context.Context, job *Job, outcome *Outcome) error
+
+	// Cancel 取消等待中的任务
+	Cancel(ctx context.Context, id string) error
+
+	// Retry 将失败或已取消的任务重新入队
+	Retry(ctx context.Context, id string, runAt time.Time) error
+
+	// Get 查询任务
+	Get(ctx context.Context, id string) (*Job, error)
+
+	// History 查询任务执行记录（最近的在前）
+	History(ctx context.Context, id string) ([]Attempt, error)
+
+	// List 分页查询任务（创建时间倒序）
+	List(ctx context.Context, opts ListOptions) ([]*Job, int64, error)
+}
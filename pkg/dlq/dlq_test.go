@@ -0,0 +1,147 @@
+package dlq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/apache/rocketmq-client-go/v2/consumer"
+	"github.com/apache/rocketmq-client-go/v2/primitive"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestMessage(id string, reconsume int32) *primitive.MessageExt {
+	msg := &primitive.MessageExt{
+		Message:        primitive.Message{Topic: "orders", Body: []byte(`{"id":1}`)},
+		MsgId:          id,
+		ReconsumeTimes: reconsume,
+	}
+	msg.WithTag("created")
+	msg.WithProperty("trace_id", "t-1")
+	return msg
+}
+
+func TestClassify(t *testing.T) {
+	var v struct{}
+	decodeErr := json.Unmarshal([]byte("{"), &v)
+
+	assert.Equal(t, ClassDecode, Classify(fmt.Errorf("parse: %w", decodeErr)))
+	assert.Equal(t, ClassTimeout, Classify(context.DeadlineExceeded))
+	assert.Equal(t, ClassUnavailable, Classify(status.Error(codes.Unavailable, "down")))
+	assert.Equal(t, "database", Classify(WithClass(errors.New("deadlock"), "database")))
+	assert.Equal(t, ClassUnknown, Classify(errors.New("boom")))
+	assert.True(t, IsPermanent(decodeErr))
+	assert.True(t, IsPermanent(Permanent(errors.New("bad"))))
+	assert.False(t, IsPermanent(errors.New("boom")))
+}
+
+func TestRecorderWrap(t *testing.T) {
+	store := NewMemoryStore()
+	recorder := NewRecorder(store, "g", 3)
+	ctx := context.Background()
+	failing := recorder.Wrap(func(ctx context.Context, msg *primitive.MessageExt) error {
+		return errors.New("boom")
+	})
+
+	// 未到最后一次重投时交给RocketMQ重投
+	res, err := failing(ctx, newTestMessage("m1", 1))
+	assert.Error(t, err)
+	assert.Equal(t, consumer.ConsumeRetryLater, res)
+	_, err = store.Get(ctx, "m1")
+	assert.ErrorIs(t, err, ErrMessageNotFound)
+
+	// 最后一次重投失败后进入死信并确认
+	res, err = failing(ctx, newTestMessage("m1", 2))
+	require.NoError(t, err)
+	assert.Equal(t, consumer.ConsumeSuccess, res)
+	msg, err := store.Get(ctx, "m1")
+	require.NoError(t, err)
+	assert.Equal(t, "g", msg.Group)
+	assert.Equal(t, "created", msg.Tags)
+	assert.Equal(t, ClassUnknown, msg.Class)
+	assert.Equal(t, StatusPending, msg.Status)
+
+	// 不可重试的错误直接进入死信
+	permanent := recorder.Wrap(func(ctx context.Context, msg *primitive.MessageExt) error {
+		return Permanent(errors.New("bad payload"))
+	})
+	res, err = permanent(ctx, newTestMessage("m2", 0))
+	require.NoError(t, err)
+	assert.Equal(t, consumer.ConsumeSuccess, res)
+
+	var nilRecorder *Recorder
+	res, _ = nilRecorder.Wrap(func(ctx context.Context, msg *primitive.MessageExt) error {
+		return Permanent(errors.New("bad payload"))
+	})(ctx, newTestMessage("m3", 0))
+	assert.Equal(t, consumer.ConsumeRetryLater, res)
+}
+
+type fakeSender struct {
+	sent []*primitive.Message
+	err  error
+}
+
+func (f *fakeSender) SendSync(ctx context.Context, msgs ...*primitive.Message) (*primitive.SendResult, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.sent = append(f.sent, msgs...)
+	return &primitive.SendResult{MsgID: "new"}, nil
+}
+
+func TestManagerReplayAndDiscard(t *testing.T) {
+	store := NewMemoryStore()
+	sender := &fakeSender{}
+	manager := NewManager(store, sender)
+	ctx := context.Background()
+	recorder := NewRecorder(store, "g", 1)
+	require.NoError(t, recorder.Record(ctx, newTestMessage("m1", 0), errors.New("boom")))
+	require.NoError(t, recorder.Record(ctx, newTestMessage("m2", 0), context.DeadlineExceeded))
+
+	sender.err = errors.New("broker down")
+	assert.Error(t, manager.Replay(ctx, "m1", "admin:1"))
+	msg, _ := store.Get(ctx, "m1")
+	assert.Equal(t, StatusPending, msg.Status)
+
+	sender.err = nil
+	require.NoError(t, manager.Replay(ctx, "m1", "admin:1"))
+	require.Len(t, sender.sent, 1)
+	assert.Equal(t, "%RETRY%g", sender.sent[0].Topic)
+	assert.Equal(t, "orders", sender.sent[0].GetProperty(primitive.PropertyRetryTopic))
+	assert.Equal(t, "created", sender.sent[0].GetTags())
+	assert.Equal(t, "t-1", sender.sent[0].GetProperty("trace_id"))
+	assert.Equal(t, "m1", sender.sent[0].GetProperty(PropertyReplayOf))
+	assert.ErrorIs(t, manager.Replay(ctx, "m1", "admin:1"), ErrInvalidState)
+
+	require.NoError(t, manager.Discard(ctx, "m2", "admin:1"))
+	assert.ErrorIs(t, manager.Discard(ctx, "m2", "admin:1"), ErrInvalidState)
+	assert.ErrorIs(t, manager.Discard(ctx, "missing", "admin:1"), ErrMessageNotFound)
+
+	// 重放后再次失败覆盖原死信
+	replayed := newTestMessage("m9", 0)
+	replayed.WithProperty(PropertyReplayOf, "m1")
+	require.NoError(t, recorder.Record(ctx, replayed, errors.New("boom again")))
+	msg, _ = store.Get(ctx, "m1")
+	assert.Equal(t, StatusPending, msg.Status)
+	assert.Equal(t, "boom again", msg.Error)
+
+	stats, err := manager.Stats(ctx, ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	assert.Equal(t, ClassUnknown, stats[0].Class)
+	assert.Equal(t, int64(1), stats[0].Pending)
+	assert.Equal(t, ClassTimeout, stats[1].Class)
+	assert.Equal(t, int64(1), stats[1].Discarded)
+
+	list, total, err := manager.List(ctx, ListOptions{Status: StatusPending})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "m1", list[0].ID)
+
+	assert.ErrorIs(t, NewManager(store, nil).Replay(ctx, "m1", ""), ErrReplayDisabled)
+}
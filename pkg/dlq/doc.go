@@ -0,0 +1,17 @@
+// Package dlq 提供各服务RocketMQ消费者共用的死信记录、查看与重放。
+//
+// 消费者用Recorder包装单条消息的处理函数：处理失败时返回ConsumeRetryLater交给RocketMQ重投，
+// 达到最大重投次数或返回不可重试的错误(Permanent、消息体无法解析)时写入死信存储并确认消费，
+// 避免毒消息阻塞队列。管理端通过Manager查看死信、按错误分类统计、重放给原消费者组或丢弃。
+//
+//	recorder := dlq.NewRecorder(dlq.NewRedisStore(client, dlq.RedisOptions{Namespace: "goods"}), "goods-canal-group", 16)
+//	consumer.Subscribe(topic, consumer.MessageSelector{}, recorder.Wrap(func(ctx context.Context, msg *primitive.MessageExt) error {
+//		var event CanalMessage
+//		if err := json.Unmarshal(msg.Body, &event); err != nil {
+//			return dlq.Permanent(err)
+//		}
+//		return handle(ctx, &event)
+//	}))
+//
+// 重放的消息带有PropertyReplayOf属性，再次失败时覆盖原死信记录而不是新增一条。
+package dlq
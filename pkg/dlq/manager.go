@@ -0,0 +1,118 @@
+package dlq
+
+import (
+	"context"
+
+	"emshop/pkg/log"
+
+	"github.com/apache/rocketmq-client-go/v2/primitive"
+)
+
+// retryTopicPrefix RocketMQ消费者组重试主题前缀，集群模式的消费者自动订阅%RETRY%<group>
+const retryTopicPrefix = "%RETRY%"
+
+// Sender 重放死信使用的生产者，rocketmq.Producer满足该接口
+type Sender interface {
+	SendSync(ctx context.Context, msgs ...*primitive.Message) (*primitive.SendResult, error)
+}
+
+// systemProperties RocketMQ投递时附加的系统属性，重放时由生产者重新生成
+var systemProperties = map[string]struct{}{
+	primitive.PropertyKeys:                          {},
+	primitive.PropertyTags:                          {},
+	primitive.PropertyUniqueClientMessageIdKeyIndex: {},
+	primitive.PropertyMinOffset:                     {},
+	primitive.PropertyMaxOffset:                     {},
+	primitive.PropertyRealTopic:                     {},
+	primitive.PropertyRealQueueId:                   {},
+	primitive.PropertyRetryTopic:                    {},
+	primitive.PropertyDelayTimeLevel:                {},
+	primitive.PropertyConsumeStartTime:              {},
+	primitive.PropertyTransactionPrepared:           {},
+	primitive.PropertyProducerGroup:                 {},
+	primitive.PropertyWaitStoreMsgOk:                {},
+}
+
+// Manager 管理端死信操作
+type Manager struct {
+	store  Store
+	sender Sender
+}
+
+// NewManager 创建死信管理，sender为nil时不支持重放
+func NewManager(store Store, sender Sender) *Manager {
+	return &Manager{store: store, sender: sender}
+}
+
+// List 分页查询死信
+func (m *Manager) List(ctx context.Context, opts ListOptions) ([]*Message, int64, error) {
+	return m.store.List(ctx, opts)
+}
+
+// Get 查询死信详情及消息体
+func (m *Manager) Get(ctx context.Context, id string) (*Message, error) {
+	return m.store.Get(ctx, id)
+}
+
+// Stats 按错误分类统计
+func (m *Manager) Stats(ctx context.Context, opts ListOptions) ([]ClassStats, error) {
+	return m.store.Stats(ctx, opts)
+}
+
+// Replay 将待处理的死信重新投递给原消费者组
+//
+// 投递到消费者组的重试主题，只有消费失败的组重新消费，订阅原主题的其他组不会收到重复消息；
+// 未记录消费者组时投递到原主题。先将状态置为已重放防止重复投递，投递失败时恢复为待处理
+func (m *Manager) Replay(ctx context.Context, id, operator string) error {
+	if m.sender == nil {
+		return ErrReplayDisabled
+	}
+	msg, err := m.store.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := m.store.Transit(ctx, id, StatusPending, StatusReplayed, operator); err != nil {
+		return err
+	}
+
+	topic := msg.Topic
+	if msg.Group != "" {
+		topic = retryTopicPrefix + msg.Group
+	}
+	replay := primitive.NewMessage(topic, []byte(msg.Body))
+	for k, v := range msg.Properties {
+		if _, ok := systemProperties[k]; !ok {
+			replay.WithProperty(k, v)
+		}
+	}
+	if msg.Group != "" {
+		// 消费者按该属性将重试消息的主题恢复为原主题
+		replay.WithProperty(primitive.PropertyRetryTopic, msg.Topic)
+	}
+	if msg.Tags != "" {
+		replay.WithTag(msg.Tags)
+	}
+	if msg.Keys != "" {
+		replay.WithKeys([]string{msg.Keys})
+	}
+	replay.WithProperty(PropertyReplayOf, msg.ID)
+
+	result, err := m.sender.SendSync(ctx, replay)
+	if err != nil {
+		if revertErr := m.store.Transit(ctx, id, StatusReplayed, StatusPending, operator); revertErr != nil {
+			log.Errorf("重放失败后恢复死信状态失败: id=%s, err=%v", id, revertErr)
+		}
+		return err
+	}
+	log.Infof("死信已重放: id=%s, topic=%s, newMsgID=%s, operator=%s", id, topic, result.MsgID, operator)
+	return nil
+}
+
+// Discard 丢弃待处理的死信
+func (m *Manager) Discard(ctx context.Context, id, operator string) error {
+	if err := m.store.Transit(ctx, id, StatusPending, StatusDiscarded, operator); err != nil {
+		return err
+	}
+	log.Infof("死信已丢弃: id=%s, operator=%s", id, operator)
+	return nil
+}
@@ -0,0 +1,92 @@
+package dlq
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// memoryStore 进程内存储，用于单副本部署和测试
+type memoryStore struct {
+	mu   sync.Mutex
+	msgs map[string]*Message
+}
+
+// NewMemoryStore 创建进程内存储，重启后死信丢失
+func NewMemoryStore() Store {
+	return &memoryStore{msgs: make(map[string]*Message)}
+}
+
+func (m *memoryStore) Save(ctx context.Context, msg *Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *msg
+	cp.Status = StatusPending
+	cp.Operator = ""
+	cp.UpdatedAt = cp.FailedAt
+	m.msgs[msg.ID] = &cp
+	return nil
+}
+
+func (m *memoryStore) Get(ctx context.Context, id string) (*Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	msg, ok := m.msgs[id]
+	if !ok {
+		return nil, ErrMessageNotFound
+	}
+	cp := *msg
+	return &cp, nil
+}
+
+func (m *memoryStore) List(ctx context.Context, opts ListOptions) ([]*Message, int64, error) {
+	if opts.Limit <= 0 {
+		opts.Limit = 20
+	}
+	all := m.filter(opts)
+	total := int64(len(all))
+	if opts.Offset >= len(all) {
+		return []*Message{}, total, nil
+	}
+	end := opts.Offset + opts.Limit
+	if end > len(all) {
+		end = len(all)
+	}
+	return all[opts.Offset:end], total, nil
+}
+
+func (m *memoryStore) Transit(ctx context.Context, id string, from, to Status, operator string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	msg, ok := m.msgs[id]
+	if !ok {
+		return ErrMessageNotFound
+	}
+	if msg.Status != from {
+		return ErrInvalidState
+	}
+	msg.Status = to
+	msg.Operator = operator
+	msg.UpdatedAt = time.Now()
+	return nil
+}
+
+func (m *memoryStore) Stats(ctx context.Context, opts ListOptions) ([]ClassStats, error) {
+	return collectStats(m.filter(opts)), nil
+}
+
+// filter 按条件过滤并按失败时间倒序
+func (m *memoryStore) filter(opts ListOptions) []*Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	all := make([]*Message, 0, len(m.msgs))
+	for _, msg := range m.msgs {
+		if matches(msg, opts) {
+			cp := *msg
+			all = append(all, &cp)
+		}
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].FailedAt.After(all[j].FailedAt) })
+	return all
+}
@@ -0,0 +1,168 @@
+package dlq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Status 死信状态
+type Status string
+
+const (
+	StatusPending   Status = "pending"   // 待处理
+	StatusReplayed  Status = "replayed"  // 已重放给原消费者组
+	StatusDiscarded Status = "discarded" // 已丢弃
+)
+
+// 错误分类，用于管理端按原因统计
+const (
+	ClassDecode      = "decode"      // 消息体无法解析
+	ClassTimeout     = "timeout"     // 处理超时
+	ClassUnavailable = "unavailable" // 下游服务或存储不可用
+	ClassUnknown     = "unknown"     // 其他错误
+)
+
+// PropertyReplayOf 重放消息携带的原死信ID
+const PropertyReplayOf = "DLQ_REPLAY_OF"
+
+var (
+	// ErrMessageNotFound 死信不存在或已过保留期
+	ErrMessageNotFound = errors.New("dlq: message not found")
+	// ErrInvalidState 当前状态不允许该操作
+	ErrInvalidState = errors.New("dlq: invalid message state")
+	// ErrReplayDisabled 未配置重放使用的生产者
+	ErrReplayDisabled = errors.New("dlq: replay not configured")
+)
+
+// Message 死信记录
+type Message struct {
+	// ID 原始消息ID，同一消息再次进入死信时覆盖
+	ID             string            `json:"id"`
+	Group          string            `json:"group"`
+	Topic          string            `json:"topic"`
+	Tags           string            `json:"tags,omitempty"`
+	Keys           string            `json:"keys,omitempty"`
+	Body           string            `json:"body"`
+	Properties     map[string]string `json:"properties,omitempty"`
+	Class          string            `json:"class"`
+	Error          string            `json:"error"`
+	ReconsumeTimes int32             `json:"reconsumeTimes"`
+	Status         Status            `json:"status"`
+	Operator       string            `json:"operator,omitempty"`
+	FailedAt       time.Time         `json:"failedAt"`
+	UpdatedAt      time.Time         `json:"updatedAt"`
+}
+
+// ListOptions 死信查询条件，空字段不过滤
+type ListOptions struct {
+	Group  string
+	Topic  string
+	Class  string
+	Status Status
+	Offset int
+	Limit  int
+}
+
+func (o ListOptions) filtered() bool {
+	return o.Group != "" || o.Topic != "" || o.Class != "" || o.Status != ""
+}
+
+func matches(m *Message, opts ListOptions) bool {
+	return (opts.Group == "" || m.Group == opts.Group) &&
+		(opts.Topic == "" || m.Topic == opts.Topic) &&
+		(opts.Class == "" || m.Class == opts.Class) &&
+		(opts.Status == "" || m.Status == opts.Status)
+}
+
+// ClassStats 按错误分类的死信统计
+type ClassStats struct {
+	Class     string `json:"class"`
+	Pending   int64  `json:"pending"`
+	Replayed  int64  `json:"replayed"`
+	Discarded int64  `json:"discarded"`
+	// LastError 该分类最近一次的错误信息
+	LastError string    `json:"lastError"`
+	LastAt    time.Time `json:"lastAt"`
+}
+
+// permanentError 不可重试的错误
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent 包装处理函数返回的错误，消息不再重投直接进入死信
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent 是否为不可重试的错误，消息体无法解析也视为不可重试
+func IsPermanent(err error) bool {
+	var pe *permanentError
+	return errors.As(err, &pe) || Classify(err) == ClassDecode
+}
+
+// classedError 携带业务自定义分类的错误
+type classedError struct {
+	class string
+	err   error
+}
+
+func (e *classedError) Error() string { return e.err.Error() }
+func (e *classedError) Unwrap() error { return e.err }
+
+// WithClass 为错误指定分类，如"database"
+func WithClass(err error, class string) error {
+	if err == nil {
+		return nil
+	}
+	return &classedError{class: class, err: err}
+}
+
+// Classify 识别错误分类
+func Classify(err error) string {
+	if err == nil {
+		return ""
+	}
+	var ce *classedError
+	if errors.As(err, &ce) {
+		return ce.class
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+		return ClassDecode
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ClassTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return ClassTimeout
+		}
+		return ClassUnavailable
+	}
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.DeadlineExceeded:
+			return ClassTimeout
+		case codes.Unavailable:
+			return ClassUnavailable
+		}
+	}
+	return ClassUnknown
+}
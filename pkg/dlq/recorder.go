@@ -0,0 +1,109 @@
+package dlq
+
+import (
+	"context"
+	"time"
+
+	"emshop/pkg/log"
+
+	"github.com/apache/rocketmq-client-go/v2/consumer"
+	"github.com/apache/rocketmq-client-go/v2/primitive"
+)
+
+// defaultMaxReconsume 与RocketMQ推模式消费者默认的最大重投次数一致
+const defaultMaxReconsume = 16
+
+// Handler 处理单条消息，返回Permanent错误时不再重投
+type Handler func(ctx context.Context, msg *primitive.MessageExt) error
+
+// Recorder 将消费失败的消息写入死信存储
+type Recorder struct {
+	store        Store
+	group        string
+	maxReconsume int32
+}
+
+// NewRecorder 创建死信记录器，maxReconsume需与消费者的最大重投次数一致，<=0时使用RocketMQ默认值
+func NewRecorder(store Store, group string, maxReconsume int32) *Recorder {
+	if maxReconsume <= 0 {
+		maxReconsume = defaultMaxReconsume
+	}
+	return &Recorder{
+		store:        store,
+		group:        group,
+		maxReconsume: maxReconsume,
+	}
+}
+
+// Wrap 包装为推模式消费函数
+//
+// 一批消息中有需要重投的消息时整批返回ConsumeRetryLater，已处理的消息会被重复投递，处理函数需保证幂等；
+// 最后一次重投仍失败或错误不可重试时写入死信并确认，写入失败时继续重投。
+// Recorder为nil时不记录死信，失败的消息交由RocketMQ重投。
+func (r *Recorder) Wrap(handle Handler) func(context.Context, ...*primitive.MessageExt) (consumer.ConsumeResult, error) {
+	return func(ctx context.Context, msgs ...*primitive.MessageExt) (consumer.ConsumeResult, error) {
+		for _, msg := range msgs {
+			err := handle(ctx, msg)
+			if err == nil {
+				continue
+			}
+			if r == nil || (!IsPermanent(err) && msg.ReconsumeTimes+1 < r.maxReconsume) {
+				log.Warnf("消息处理失败，等待重投: topic=%s, msgID=%s, reconsume=%d, err=%v",
+					msg.Topic, msg.MsgId, msg.ReconsumeTimes, err)
+				return consumer.ConsumeRetryLater, err
+			}
+			if recErr := r.Record(ctx, msg, err); recErr != nil {
+				log.Errorf("写入死信失败，等待重投: topic=%s, msgID=%s, err=%v", msg.Topic, msg.MsgId, recErr)
+				return consumer.ConsumeRetryLater, err
+			}
+		}
+		return consumer.ConsumeSuccess, nil
+	}
+}
+
+// Record 将消息写入死信
+func (r *Recorder) Record(ctx context.Context, msg *primitive.MessageExt, cause error) error {
+	return r.Save(ctx, NewMessage(r.group, msg, cause))
+}
+
+// Save 写入已构造的死信，用于需要调整属性的场景(如去掉自定义重试计数)，未指定消费组时使用记录器的消费组
+func (r *Recorder) Save(ctx context.Context, m *Message) error {
+	if m.Group == "" {
+		m.Group = r.group
+	}
+	if err := r.store.Save(ctx, m); err != nil {
+		return err
+	}
+	log.Errorf("消息进入死信: group=%s, topic=%s, id=%s, class=%s, err=%s", m.Group, m.Topic, m.ID, m.Class, m.Error)
+	return nil
+}
+
+// NewMessage 由消费失败的消息构造死信，重放消息沿用原死信ID
+func NewMessage(group string, msg *primitive.MessageExt, cause error) *Message {
+	id := msg.GetProperty(PropertyReplayOf)
+	if id == "" {
+		id = msg.MsgId
+	}
+	props := make(map[string]string, len(msg.GetProperties()))
+	for k, v := range msg.GetProperties() {
+		props[k] = v
+	}
+	var errMsg string
+	if cause != nil {
+		errMsg = cause.Error()
+	}
+	return &Message{
+		ID:             id,
+		Group:          group,
+		Topic:          msg.Topic,
+		Tags:           msg.GetTags(),
+		Keys:           msg.GetKeys(),
+		Body:           string(msg.Body),
+		Properties:     props,
+		Class:          Classify(cause),
+		Error:          errMsg,
+		ReconsumeTimes: msg.ReconsumeTimes,
+		Status:         StatusPending,
+		FailedAt:       time.Now(),
+	}
+}
@@ -0,0 +1,251 @@
+package dlq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Redis键布局(均带{namespace}哈希标签，集群模式下位于同一slot):
+//
+//	dlq:{ns}:index     ZSET 全部死信，score为失败时间，用于分页
+//	dlq:{ns}:msg:{id}  HASH 死信详情，按保留期过期
+var (
+	saveScript = redis.NewScript(`
+redis.call('DEL', KEYS[2])
+redis.call('HSET', KEYS[2], 'id', ARGV[1], 'group', ARGV[2], 'topic', ARGV[3], 'tags', ARGV[4], 'keys', ARGV[5],
+  'body', ARGV[6], 'properties', ARGV[7], 'class', ARGV[8], 'error', ARGV[9], 'reconsume_times', ARGV[10],
+  'status', 'pending', 'operator', '', 'failed_at', ARGV[11], 'updated_at', ARGV[11])
+redis.call('EXPIRE', KEYS[2], ARGV[12])
+redis.call('ZADD', KEYS[1], ARGV[11], ARGV[1])
+return 1
+`)
+
+	transitScript = redis.NewScript(`
+local st = redis.call('HGET', KEYS[1], 'status')
+if not st then return -1 end
+if st ~= ARGV[1] then return 0 end
+redis.call('HSET', KEYS[1], 'status', ARGV[2], 'operator', ARGV[3], 'updated_at', ARGV[4])
+return 1
+`)
+)
+
+// defaultRetention 死信默认保留时长
+const defaultRetention = 7 * 24 * time.Hour
+
+// RedisOptions Redis存储配置
+type RedisOptions struct {
+	// Namespace 命名空间，通常为服务名，不同服务的死信互不可见
+	Namespace string
+	// Retention 死信保留时长，重新失败时重新计算
+	Retention time.Duration
+}
+
+type redisStore struct {
+	client    redis.UniversalClient
+	prefix    string
+	retention time.Duration
+}
+
+// NewRedisStore 创建基于Redis的死信存储
+func NewRedisStore(client redis.UniversalClient, opts RedisOptions) Store {
+	if opts.Namespace == "" {
+		opts.Namespace = "default"
+	}
+	if opts.Retention <= 0 {
+		opts.Retention = defaultRetention
+	}
+	return &redisStore{
+		client:    client,
+		prefix:    fmt.Sprintf("dlq:{%s}:", opts.Namespace),
+		retention: opts.Retention,
+	}
+}
+
+func (r *redisStore) indexKey() string        { return r.prefix + "index" }
+func (r *redisStore) msgKey(id string) string { return r.prefix + "msg:" + id }
+
+func (r *redisStore) Save(ctx context.Context, msg *Message) error {
+	props, err := json.Marshal(msg.Properties)
+	if err != nil {
+		return err
+	}
+	return saveScript.Run(ctx, r.client,
+		[]string{r.indexKey(), r.msgKey(msg.ID)},
+		msg.ID, msg.Group, msg.Topic, msg.Tags, msg.Keys, msg.Body, string(props),
+		msg.Class, msg.Error, msg.ReconsumeTimes, toMillis(msg.FailedAt), int64(r.retention/time.Second),
+	).Err()
+}
+
+func (r *redisStore) Get(ctx context.Context, id string) (*Message, error) {
+	msgs, err := r.load(ctx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) == 0 {
+		return nil, ErrMessageNotFound
+	}
+	return msgs[0], nil
+}
+
+// listScanBatch 带过滤条件分页时每次从索引读取的条数
+const listScanBatch = 500
+
+func (r *redisStore) List(ctx context.Context, opts ListOptions) ([]*Message, int64, error) {
+	if opts.Limit <= 0 {
+		opts.Limit = 20
+	}
+
+	// 无过滤条件时直接按索引分页
+	if !opts.filtered() {
+		total, err := r.client.ZCard(ctx, r.indexKey()).Result()
+		if err != nil {
+			return nil, 0, err
+		}
+		ids, err := r.client.ZRevRange(ctx, r.indexKey(), int64(opts.Offset), int64(opts.Offset+opts.Limit-1)).Result()
+		if err != nil {
+			return nil, 0, err
+		}
+		msgs, err := r.load(ctx, ids)
+		return msgs, total, err
+	}
+
+	var (
+		page    = make([]*Message, 0, opts.Limit)
+		matched int64
+	)
+	err := r.scan(ctx, func(msgs []*Message) {
+		for _, msg := range msgs {
+			if !matches(msg, opts) {
+				continue
+			}
+			if matched >= int64(opts.Offset) && len(page) < opts.Limit {
+				page = append(page, msg)
+			}
+			matched++
+		}
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return page, matched, nil
+}
+
+func (r *redisStore) Transit(ctx context.Context, id string, from, to Status, operator string) error {
+	res, err := transitScript.Run(ctx, r.client,
+		[]string{r.msgKey(id)},
+		string(from), string(to), operator, toMillis(time.Now()),
+	).Int()
+	switch {
+	case err != nil:
+		return err
+	case res < 0:
+		return ErrMessageNotFound
+	case res == 0:
+		return ErrInvalidState
+	}
+	return nil
+}
+
+func (r *redisStore) Stats(ctx context.Context, opts ListOptions) ([]ClassStats, error) {
+	var all []*Message
+	err := r.scan(ctx, func(msgs []*Message) {
+		for _, msg := range msgs {
+			if matches(msg, opts) {
+				all = append(all, msg)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return collectStats(all), nil
+}
+
+// scan 按失败时间倒序分批遍历全部死信
+func (r *redisStore) scan(ctx context.Context, fn func([]*Message)) error {
+	for start := int64(0); ; start += listScanBatch {
+		ids, err := r.client.ZRevRange(ctx, r.indexKey(), start, start+listScanBatch-1).Result()
+		if err != nil {
+			return err
+		}
+		msgs, err := r.load(ctx, ids)
+		if err != nil {
+			return err
+		}
+		fn(msgs)
+		if len(ids) < listScanBatch {
+			return nil
+		}
+	}
+}
+
+// load 批量读取死信，已过期的记录从索引中移除
+func (r *redisStore) load(ctx context.Context, ids []string) ([]*Message, error) {
+	if len(ids) == 0 {
+		return []*Message{}, nil
+	}
+	pipe := r.client.Pipeline()
+	cmds := make([]*redis.StringStringMapCmd, len(ids))
+	for i, id := range ids {
+		cmds[i] = pipe.HGetAll(ctx, r.msgKey(id))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	msgs := make([]*Message, 0, len(ids))
+	var missing []interface{}
+	for i, cmd := range cmds {
+		fields := cmd.Val()
+		if len(fields) == 0 {
+			missing = append(missing, ids[i])
+			continue
+		}
+		msgs = append(msgs, decodeMessage(fields))
+	}
+	if len(missing) > 0 {
+		r.client.ZRem(ctx, r.indexKey(), missing...)
+	}
+	return msgs, nil
+}
+
+func decodeMessage(fields map[string]string) *Message {
+	reconsume, _ := strconv.ParseInt(fields["reconsume_times"], 10, 32)
+	msg := &Message{
+		ID:             fields["id"],
+		Group:          fields["group"],
+		Topic:          fields["topic"],
+		Tags:           fields["tags"],
+		Keys:           fields["keys"],
+		Body:           fields["body"],
+		Class:          fields["class"],
+		Error:          fields["error"],
+		ReconsumeTimes: int32(reconsume),
+		Status:         Status(fields["status"]),
+		Operator:       fields["operator"],
+		FailedAt:       fromMillis(fields["failed_at"]),
+		UpdatedAt:      fromMillis(fields["updated_at"]),
+	}
+	_ = json.Unmarshal([]byte(fields["properties"]), &msg.Properties)
+	return msg
+}
+
+func toMillis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+func fromMillis(s string) time.Time {
+	ms, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || ms == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
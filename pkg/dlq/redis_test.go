@@ -0,0 +1,65 @@
+package dlq
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRedisStore 连接本地Redis测试库，不可用时跳过
+func newTestRedisStore(t *testing.T) Store {
+	client := redis.NewClient(&redis.Options{
+		Addr: "localhost:6379",
+		DB:   15, // 使用测试数据库
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skipf("redis not available: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	return NewRedisStore(client, RedisOptions{Namespace: "test-" + uuid.NewString()[:8], Retention: time.Minute})
+}
+
+func TestRedisStore(t *testing.T) {
+	store := newTestRedisStore(t)
+	ctx := context.Background()
+
+	first := NewMessage("g", newTestMessage("m1", 3), errors.New("boom"))
+	first.FailedAt = time.Now().Add(-time.Minute)
+	require.NoError(t, store.Save(ctx, first))
+	require.NoError(t, store.Save(ctx, NewMessage("g", newTestMessage("m2", 3), context.DeadlineExceeded)))
+
+	msg, err := store.Get(ctx, "m1")
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":1}`, msg.Body)
+	assert.Equal(t, "t-1", msg.Properties["trace_id"])
+	assert.Equal(t, int32(3), msg.ReconsumeTimes)
+	assert.Equal(t, StatusPending, msg.Status)
+
+	list, total, err := store.List(ctx, ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, "m2", list[0].ID)
+
+	require.NoError(t, store.Transit(ctx, "m1", StatusPending, StatusDiscarded, "admin:1"))
+	assert.ErrorIs(t, store.Transit(ctx, "m1", StatusPending, StatusReplayed, "admin:1"), ErrInvalidState)
+	assert.ErrorIs(t, store.Transit(ctx, "missing", StatusPending, StatusReplayed, ""), ErrMessageNotFound)
+
+	list, total, err = store.List(ctx, ListOptions{Class: ClassTimeout})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, "m2", list[0].ID)
+
+	stats, err := store.Stats(ctx, ListOptions{Group: "g"})
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	assert.Equal(t, ClassTimeout, stats[0].Class)
+	assert.Equal(t, int64(1), stats[1].Discarded)
+}
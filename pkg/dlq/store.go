@@ -0,0 +1,60 @@
+package dlq
+
+import (
+	"context"
+	"sort"
+)
+
+// Store 死信持久化存储，所有方法需保证多副本并发安全
+type Store interface {
+	// Save 写入死信，同ID的记录被覆盖并重置为待处理
+	Save(ctx context.Context, msg *Message) error
+
+	// Get 查询死信
+	Get(ctx context.Context, id string) (*Message, error)
+
+	// List 分页查询死信（失败时间倒序）
+	List(ctx context.Context, opts ListOptions) ([]*Message, int64, error)
+
+	// Transit 仅当状态为from时更新为to，记录不存在返回ErrMessageNotFound，状态不符返回ErrInvalidState
+	Transit(ctx context.Context, id string, from, to Status, operator string) error
+
+	// Stats 按错误分类统计
+	Stats(ctx context.Context, opts ListOptions) ([]ClassStats, error)
+}
+
+// collectStats 汇总消息的分类统计，按待处理数量倒序
+func collectStats(msgs []*Message) []ClassStats {
+	byClass := make(map[string]*ClassStats)
+	for _, m := range msgs {
+		s, ok := byClass[m.Class]
+		if !ok {
+			s = &ClassStats{Class: m.Class}
+			byClass[m.Class] = s
+		}
+		switch m.Status {
+		case StatusPending:
+			s.Pending++
+		case StatusReplayed:
+			s.Replayed++
+		case StatusDiscarded:
+			s.Discarded++
+		}
+		if m.FailedAt.After(s.LastAt) {
+			s.LastAt = m.FailedAt
+			s.LastError = m.Error
+		}
+	}
+
+	stats := make([]ClassStats, 0, len(byClass))
+	for _, s := range byClass {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Pending != stats[j].Pending {
+			return stats[i].Pending > stats[j].Pending
+		}
+		return stats[i].Class < stats[j].Class
+	})
+	return stats
+}
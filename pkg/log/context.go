@@ -0,0 +1,86 @@
+package log
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// 链路字段，使用带 context 的日志方法时自动输出
+const (
+	KeyTraceID = "trace_id"
+	KeySpanID  = "span_id"
+)
+
+// PropagatedKeys 跨服务传递的日志字段，gRPC 拦截器通过元数据在服务之间传递
+var PropagatedKeys = []string{KeyRequestID, KeyUserID, KeyUsername, KeyOrderSN, KeyPaymentSN, KeyDebug}
+
+// MetadataKey 返回日志字段在 gRPC 元数据中的键
+func MetadataKey(key string) string {
+	return "x-emshop-log-" + strings.ToLower(key)
+}
+
+type valuesKey struct{}
+
+// WithValues 在上下文中附加日志字段，参数为键值对，相同的键覆盖旧值，值为空时忽略
+//
+// 使用 InfoC、ErrorfC 等带 context 的方法记录日志时，上下文中的字段会自动输出。
+func WithValues(ctx context.Context, keysAndValues ...string) context.Context {
+	old, _ := ctx.Value(valuesKey{}).([]string)
+	values := make([]string, len(old), len(old)+len(keysAndValues))
+	copy(values, old)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		key, value := keysAndValues[i], keysAndValues[i+1]
+		if value == "" {
+			continue
+		}
+		replaced := false
+		for j := 0; j < len(values); j += 2 {
+			if values[j] == key {
+				values[j+1] = value
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			values = append(values, key, value)
+		}
+	}
+	return context.WithValue(ctx, valuesKey{}, values)
+}
+
+// Value 返回上下文中的日志字段
+func Value(ctx context.Context, key string) string {
+	values, _ := ctx.Value(valuesKey{}).([]string)
+	for i := 0; i < len(values); i += 2 {
+		if values[i] == key {
+			return values[i+1]
+		}
+	}
+	return ""
+}
+
+// Values 返回上下文中的全部日志字段，格式为键值对
+func Values(ctx context.Context) []string {
+	values, _ := ctx.Value(valuesKey{}).([]string)
+	return values
+}
+
+// contextFields 上下文中的日志字段以及链路ID
+func contextFields(ctx context.Context, withTraceID bool) []Field {
+	values := Values(ctx)
+	fields := make([]Field, 0, len(values)/2+2)
+	for i := 0; i < len(values); i += 2 {
+		fields = append(fields, zap.String(values[i], values[i+1]))
+	}
+	if withTraceID {
+		if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+			fields = append(fields,
+				zap.String(KeyTraceID, sc.TraceID().String()),
+				zap.String(KeySpanID, sc.SpanID().String()))
+		}
+	}
+	return fields
+}
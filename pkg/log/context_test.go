@@ -0,0 +1,48 @@
+package log
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithValues(t *testing.T) {
+	ctx := WithValues(context.Background(), KeyRequestID, "r1", KeyUserID, "")
+	child := WithValues(ctx, KeyOrderSN, "o1", KeyRequestID, "r2")
+
+	require.Equal(t, []string{KeyRequestID, "r1"}, Values(ctx))
+	require.Equal(t, []string{KeyRequestID, "r2", KeyOrderSN, "o1"}, Values(child))
+	require.Equal(t, "o1", Value(child, KeyOrderSN))
+	require.Equal(t, "", Value(child, KeyUserID))
+}
+
+func TestContextFields(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	l := &Logger{Logger: zap.New(core), skipCaller: zap.New(core), withTraceID: true, minLevel: zapcore.DebugLevel}
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "op")
+	defer span.End()
+	ctx = WithValues(ctx, KeyRequestID, "r1", KeyOrderSN, "o1")
+
+	l.ErrorfContext(ctx, "create order %s failed", "o1")
+	entry := logs.All()[0]
+	require.Equal(t, zapcore.ErrorLevel, entry.Level)
+	fields := entry.ContextMap()
+	require.Equal(t, "r1", fields[KeyRequestID])
+	require.Equal(t, "o1", fields[KeyOrderSN])
+	require.Equal(t, span.SpanContext().TraceID().String(), fields[KeyTraceID])
+	require.Equal(t, span.SpanContext().SpanID().String(), fields[KeySpanID])
+
+	// gin 处理函数直接传入 *gin.Context
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/", nil).WithContext(WithValues(context.Background(), KeyUserID, "7"))
+	l.WarnContext(c, "hello")
+	require.Equal(t, "7", logs.All()[1].ContextMap()[KeyUserID])
+}
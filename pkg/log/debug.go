@@ -0,0 +1,52 @@
+package log
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// KeyDebug 日志上下文中开启调试日志的字段，随 gRPC 元数据传递到下游服务
+const KeyDebug = "debug"
+
+// WithDebug 为上下文开启调试日志，使用带 context 的方法记录日志时不受日志级别限制
+func WithDebug(ctx context.Context) context.Context {
+	return WithValues(ctx, KeyDebug, "true")
+}
+
+// DebugForced 上下文是否开启了调试日志
+func DebugForced(ctx context.Context) bool {
+	return Value(ctx, KeyDebug) != ""
+}
+
+// SignDebugToken 生成调试令牌，格式为 过期时间戳.签名，请求携带有效令牌时只为该请求开启调试日志
+func SignDebugToken(secret string, expireAt time.Time) string {
+	expire := strconv.FormatInt(expireAt.Unix(), 10)
+	return expire + "." + debugTokenSignature(secret, expire)
+}
+
+// VerifyDebugToken 校验调试令牌的签名和过期时间
+func VerifyDebugToken(secret, token string, now time.Time) bool {
+	if secret == "" {
+		return false
+	}
+	expire, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return false
+	}
+	expireAt, err := strconv.ParseInt(expire, 10, 64)
+	if err != nil || now.Unix() > expireAt {
+		return false
+	}
+	return hmac.Equal([]byte(sig), []byte(debugTokenSignature(secret, expire)))
+}
+
+func debugTokenSignature(secret, expire string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("emshop-debug-log:" + expire))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,236 @@
+package log
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// LevelSetting logger 当前生效的日志级别
+type LevelSetting struct {
+	// Name logger 名称，为空表示根 logger
+	Name  string `json:"name"`
+	Level string `json:"level"`
+	// ExpireAt 运行时修改的级别恢复时间，为空表示不会自动恢复
+	ExpireAt *time.Time `json:"expire_at,omitempty"`
+}
+
+// ParseLevel 解析 debug、info、warn、error 等级别名称
+func ParseLevel(text string) (Level, error) {
+	return zapcore.ParseLevel(text)
+}
+
+// SetLevel 运行时修改日志级别，name 为空表示根 logger，子 logger 未单独设置时跟随上级
+//
+// ttl 大于 0 时到期自动恢复为修改前的配置，返回恢复时间；ttl 为 0 时一直生效直到 ResetLevel。
+func SetLevel(name string, level Level, ttl time.Duration) time.Time {
+	mu.Lock()
+	defer mu.Unlock()
+	return std.levels.set(name, level, ttl)
+}
+
+// ResetLevel 撤销运行时对日志级别的修改，恢复为配置文件中的级别
+func ResetLevel(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+	std.levels.reset(name)
+}
+
+// Levels 返回根 logger 和所有运行时修改过的 logger 的日志级别
+func Levels() []LevelSetting {
+	mu.Lock()
+	defer mu.Unlock()
+	return std.levels.list()
+}
+
+// levelOverride 运行时设置的级别
+type levelOverride struct {
+	level    zapcore.Level
+	expireAt time.Time
+	timer    *time.Timer
+}
+
+// levelSnapshot 级别的只读快照，记录日志时无锁读取
+type levelSnapshot struct {
+	base zapcore.Level
+	// min 所有级别中最低的，用于快速判断
+	min    zapcore.Level
+	levels map[string]zapcore.Level
+}
+
+// levelRegistry 按 logger 名称保存日志级别，修改时重建快照
+type levelRegistry struct {
+	mu        sync.Mutex
+	base      zapcore.Level
+	overrides map[string]*levelOverride
+	snapshot  atomic.Pointer[levelSnapshot]
+}
+
+func newLevelRegistry(base zapcore.Level) *levelRegistry {
+	r := &levelRegistry{base: base, overrides: map[string]*levelOverride{}}
+	r.rebuild()
+	return r
+}
+
+// enabled 按名称查找级别，没有单独设置时逐级查找上级 logger，最后使用根 logger 的级别
+func (r *levelRegistry) enabled(name string, lvl zapcore.Level) bool {
+	s := r.snapshot.Load()
+	if lvl < s.min {
+		return false
+	}
+	if len(s.levels) == 0 {
+		return lvl >= s.base
+	}
+	for n := name; ; {
+		if l, ok := s.levels[n]; ok {
+			return lvl >= l
+		}
+		if n == "" {
+			break
+		}
+		if i := strings.LastIndexByte(n, '.'); i >= 0 {
+			n = n[:i]
+		} else {
+			n = ""
+		}
+	}
+	return lvl >= s.base
+}
+
+// minLevel 任意 logger 可能输出的最低级别
+func (r *levelRegistry) minLevel() zapcore.Level {
+	return r.snapshot.Load().min
+}
+
+func (r *levelRegistry) set(name string, level zapcore.Level, ttl time.Duration) time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if old, ok := r.overrides[name]; ok && old.timer != nil {
+		old.timer.Stop()
+	}
+	o := &levelOverride{level: level}
+	if ttl > 0 {
+		o.expireAt = time.Now().Add(ttl)
+		o.timer = time.AfterFunc(ttl, func() { r.expire(name, o) })
+	}
+	r.overrides[name] = o
+	r.rebuild()
+	return o.expireAt
+}
+
+func (r *levelRegistry) reset(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if old, ok := r.overrides[name]; ok {
+		if old.timer != nil {
+			old.timer.Stop()
+		}
+		delete(r.overrides, name)
+		r.rebuild()
+	}
+}
+
+// expire 到期恢复，期间被重新设置过的不处理
+func (r *levelRegistry) expire(name string, o *levelOverride) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.overrides[name] == o {
+		delete(r.overrides, name)
+		r.rebuild()
+	}
+}
+
+func (r *levelRegistry) list() []LevelSetting {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	settings := []LevelSetting{{Name: "", Level: r.base.String()}}
+	names := make([]string, 0, len(r.overrides))
+	for name := range r.overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		o := r.overrides[name]
+		s := LevelSetting{Name: name, Level: o.level.String()}
+		if o.timer != nil {
+			expireAt := o.expireAt
+			s.ExpireAt = &expireAt
+		}
+		if name == "" {
+			settings[0] = s
+			continue
+		}
+		settings = append(settings, s)
+	}
+	return settings
+}
+
+func (r *levelRegistry) rebuild() {
+	s := &levelSnapshot{base: r.base, min: r.base}
+	if len(r.overrides) > 0 {
+		s.levels = make(map[string]zapcore.Level, len(r.overrides))
+		for name, o := range r.overrides {
+			s.levels[name] = o.level
+			if o.level < s.min {
+				s.min = o.level
+			}
+		}
+	}
+	r.snapshot.Store(s)
+}
+
+// levelCore 按 logger 名称过滤日志，底层 core 使用 debug 级别构建
+//
+// force 为 true 时不过滤，用于按请求开启调试日志。
+type levelCore struct {
+	zapcore.Core
+	levels *levelRegistry
+	force  bool
+}
+
+func (c *levelCore) Enabled(lvl zapcore.Level) bool {
+	return c.force || lvl >= c.levels.minLevel()
+}
+
+// Level 实现 zapcore.LevelOf，避免返回底层 core 的 debug 级别
+func (c *levelCore) Level() zapcore.Level {
+	if c.force {
+		return zapcore.DebugLevel
+	}
+	return c.levels.minLevel()
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), levels: c.levels, force: c.force}
+}
+
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.force && !c.levels.enabled(ent.LoggerName, ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
+// forceCore 返回不按级别过滤的 core
+func forceCore(core zapcore.Core) zapcore.Core {
+	if c, ok := core.(*levelCore); ok {
+		return &levelCore{Core: c.Core, levels: c.levels, force: true}
+	}
+	return core
+}
+
+// wrapLevelCore 构建 logger 时使用，按名称的级别设置由 levels 决定
+func wrapLevelCore(levels *levelRegistry) zap.Option {
+	return zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &levelCore{Core: core, levels: levels}
+	})
+}
@@ -0,0 +1,116 @@
+package log
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newLevelTestLogger(base zapcore.Level) (*Logger, *observer.ObservedLogs) {
+	levels := newLevelRegistry(base)
+	core, logs := observer.New(zapcore.DebugLevel)
+	zl := zap.New(core, wrapLevelCore(levels))
+	return &Logger{
+		Logger:     zl,
+		skipCaller: zl,
+		forced:     zl.WithOptions(zap.WrapCore(forceCore)),
+		levels:     levels,
+		minLevel:   base,
+	}, logs
+}
+
+func TestLevelRegistry(t *testing.T) {
+	l, logs := newLevelTestLogger(zapcore.InfoLevel)
+	order := l.Named("order")
+	create := order.Named("create")
+
+	create.Debug("hidden")
+	require.Equal(t, 0, logs.Len())
+
+	// 子 logger 跟随上级的设置
+	l.levels.set("order", zapcore.DebugLevel, 0)
+	create.Debug("shown")
+	l.Debug("root hidden")
+	require.Equal(t, 1, logs.Len())
+	require.Equal(t, "order.create", logs.All()[0].LoggerName)
+
+	l.levels.set("order.create", zapcore.ErrorLevel, 0)
+	create.Warn("hidden")
+	order.Info("shown")
+	require.Equal(t, 2, logs.Len())
+
+	l.levels.reset("order.create")
+	l.levels.reset("order")
+	create.Debug("hidden")
+	require.Equal(t, 2, logs.Len())
+	require.Equal(t, []LevelSetting{{Name: "", Level: "info"}}, l.levels.list())
+}
+
+func TestLevelTTL(t *testing.T) {
+	l, logs := newLevelTestLogger(zapcore.InfoLevel)
+	expireAt := l.levels.set("", zapcore.DebugLevel, 50*time.Millisecond)
+	require.False(t, expireAt.IsZero())
+
+	settings := l.levels.list()
+	require.Len(t, settings, 1)
+	require.Equal(t, "debug", settings[0].Level)
+	require.NotNil(t, settings[0].ExpireAt)
+
+	l.Debug("shown")
+	require.Equal(t, 1, logs.Len())
+	require.Eventually(t, func() bool { return !l.Core().Enabled(zapcore.DebugLevel) }, time.Second, 10*time.Millisecond)
+	l.Debug("hidden")
+	require.Equal(t, 1, logs.Len())
+
+	// 重新设置后旧的定时器不再生效
+	l.levels.set("", zapcore.DebugLevel, 20*time.Millisecond)
+	l.levels.set("", zapcore.DebugLevel, 0)
+	time.Sleep(50 * time.Millisecond)
+	require.True(t, l.Core().Enabled(zapcore.DebugLevel))
+}
+
+func TestDebugForced(t *testing.T) {
+	l, logs := newLevelTestLogger(zapcore.WarnLevel)
+	ctx := WithValues(context.Background(), KeyRequestID, "r1")
+
+	l.InfoContext(ctx, "hidden")
+	require.Equal(t, 0, logs.Len())
+
+	l.DebugfContext(WithDebug(ctx), "order %s", "o1")
+	require.Equal(t, 1, logs.Len())
+	fields := logs.All()[0].ContextMap()
+	require.Equal(t, "r1", fields[KeyRequestID])
+	require.Equal(t, "true", fields[KeyDebug])
+
+	l.Ctx(WithDebug(ctx)).Info("shown")
+	require.Equal(t, 2, logs.Len())
+}
+
+func TestDebugToken(t *testing.T) {
+	now := time.Now()
+	token := SignDebugToken("s1", now.Add(time.Minute))
+	require.True(t, VerifyDebugToken("s1", token, now))
+	require.False(t, VerifyDebugToken("s2", token, now))
+	require.False(t, VerifyDebugToken("s1", token, now.Add(2*time.Minute)))
+	require.False(t, VerifyDebugToken("", token, now))
+	require.False(t, VerifyDebugToken("s1", "garbage", now))
+}
+
+func TestSampled(t *testing.T) {
+	l, logs := newLevelTestLogger(zapcore.InfoLevel)
+	s := l.Named("flashsale").Sampled(time.Hour, 2, 10)
+	for i := 0; i < 30; i++ {
+		s.Sugar().Infof("reserve %d", i)
+	}
+	// 前 2 条，之后第 12、22 条
+	require.Equal(t, 4, logs.Len())
+
+	s.Warn("not sampled")
+	s.DebugContext(WithDebug(context.Background()), "not sampled")
+	require.Equal(t, 6, logs.Len())
+}
@@ -81,8 +81,10 @@ func New(opts *Options) *Logger {
 		EncodeCaller:   zapcore.ShortCallerEncoder,
 	}
 
+	// 底层按 debug 级别构建，实际级别由 levelCore 按 logger 名称判断，运行时可以修改
+	levels := newLevelRegistry(zapLevel)
 	loggerConfig := &zap.Config{
-		Level:             zap.NewAtomicLevelAt(zapLevel),
+		Level:             zap.NewAtomicLevelAt(zapcore.DebugLevel),
 		Development:       opts.Development,
 		DisableCaller:     opts.DisableCaller,
 		DisableStacktrace: opts.DisableStacktrace,
@@ -97,15 +99,18 @@ func New(opts *Options) *Logger {
 	}
 
 	var err error
-	l, err := loggerConfig.Build(zap.AddStacktrace(zapcore.PanicLevel), zap.AddCallerSkip(1))
+	l, err := loggerConfig.Build(zap.AddStacktrace(zapcore.PanicLevel), zap.AddCallerSkip(1), wrapLevelCore(levels))
 	if err != nil {
 		panic(err)
 	}
+	skipCaller := l.WithOptions(zap.AddCallerSkip(1))
 	logger := &Logger{
 		Logger: l,
 
 		//有时我们稍微封装了一下记录日志的方法，但是我们希望输出的文件名和行号是调用封装函数的位置。这时可以使用zap.AddCallerSkip(skip int)向上跳 1 层：
-		skipCaller: l.WithOptions(zap.AddCallerSkip(1)),
+		skipCaller: skipCaller,
+		forced:     skipCaller.WithOptions(zap.WrapCore(forceCore)),
+		levels:     levels,
 
 		minLevel:         zapLevel,
 		errorStatusLevel: zap.ErrorLevel,
@@ -123,6 +128,15 @@ func ZapLogger() *zap.Logger {
 	return std.Logger
 }
 
+// Named 返回指定名称的子 logger，需要在 Init 之后调用
+//
+// 返回的 logger 由业务代码直接调用，去掉包级函数多出的一层调用，输出的调用位置才正确。
+func Named(name string) *Logger {
+	mu.Lock()
+	defer mu.Unlock()
+	return std.Named(name).WithOptions(zap.AddCallerSkip(-1))
+}
+
 // CheckIntLevel used for other log wrapper such as klog which return if logging a
 // message at the specified level is enabled.
 func CheckIntLevel(level int32) bool {
@@ -244,7 +258,7 @@ func Fatal(msg string, fields ...Field) {
 }
 
 func FatalC(ctx context.Context, msg string, fields ...Field) {
-	std.PanicContext(ctx, msg, fields...)
+	std.FatalContext(ctx, msg, fields...)
 }
 
 // Fatalf method output fatal level log.
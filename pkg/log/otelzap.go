@@ -38,6 +38,9 @@ var (
 type Logger struct {
 	*zap.Logger
 	skipCaller *zap.Logger
+	// forced 不受日志级别限制，请求开启调试日志时使用
+	forced *zap.Logger
+	levels *levelRegistry
 
 	withTraceID bool
 
@@ -75,10 +78,24 @@ func (l *Logger) WithOptions(opts ...zap.Option) *Logger {
 	clone := *l
 	clone.Logger = l.Logger.WithOptions(opts...)
 	clone.skipCaller = l.skipCaller.WithOptions(opts...)
+	if l.forced != nil {
+		clone.forced = l.forced.WithOptions(opts...)
+	}
 	clone.extraFields = append(clone.extraFields, extraFields...)
 	return &clone
 }
 
+// Named 返回指定名称的子 logger，名称以点号分隔，可以通过 SetLevel 单独修改级别
+func (l *Logger) Named(name string) *Logger {
+	clone := *l
+	clone.Logger = l.Logger.Named(name)
+	clone.skipCaller = l.skipCaller.Named(name)
+	if l.forced != nil {
+		clone.forced = l.forced.Named(name)
+	}
+	return &clone
+}
+
 // Sugar wraps the Logger to provide a more ergonomic, but slightly slower,
 // API. Sugaring a Logger is quite inexpensive, so it's reasonable for a
 // single application to use both Loggers and SugaredLoggers, converting
@@ -110,52 +127,52 @@ func (l *Logger) Ctx(ctx context.Context) LoggerWithCtx {
 
 func (l *Logger) DebugContext(ctx context.Context, msg string, fields ...zapcore.Field) {
 	fields = l.logFields(ctx, zap.DebugLevel, msg, fields)
-	l.skipCaller.Debug(msg, fields...)
+	l.loggerFor(ctx).Debug(msg, fields...)
 }
 
 func (l *Logger) DebugfContext(ctx context.Context, format string, v ...interface{}) {
 	msg := fmt.Sprintf(format, v...)
 	fields := l.logFields(ctx, zap.DebugLevel, format, []zapcore.Field{})
-	l.skipCaller.Debug(msg, fields...)
+	l.loggerFor(ctx).Debug(msg, fields...)
 }
 
 func (l *Logger) DebugwContext(ctx context.Context, format string, v ...interface{}) {
 	msg := fmt.Sprintf(format, v...)
 	fields := l.logFields(ctx, zap.DebugLevel, format, []zapcore.Field{})
-	l.skipCaller.Debug(msg, fields...)
+	l.loggerFor(ctx).Debug(msg, fields...)
 }
 
 func (l *Logger) InfoContext(ctx context.Context, msg string, fields ...zapcore.Field) {
 	fields = l.logFields(ctx, zap.InfoLevel, msg, fields)
-	l.skipCaller.Info(msg, fields...)
+	l.loggerFor(ctx).Info(msg, fields...)
 }
 
 func (l *Logger) InfofContext(ctx context.Context, format string, v ...interface{}) {
 	msg := fmt.Sprintf(format, v...)
 	fields := l.logFields(ctx, zap.InfoLevel, msg, []zapcore.Field{})
-	l.skipCaller.Info(msg, fields...)
+	l.loggerFor(ctx).Info(msg, fields...)
 }
 
 func (l *Logger) WarnContext(ctx context.Context, msg string, fields ...zapcore.Field) {
 	fields = l.logFields(ctx, zap.WarnLevel, msg, fields)
-	l.skipCaller.Warn(msg, fields...)
+	l.loggerFor(ctx).Warn(msg, fields...)
 }
 
 func (l *Logger) WarnfContext(ctx context.Context, format string, v ...interface{}) {
 	msg := fmt.Sprintf(format, v...)
 	fields := l.logFields(ctx, zap.WarnLevel, msg, []zapcore.Field{})
-	l.skipCaller.Info(msg, fields...)
+	l.loggerFor(ctx).Warn(msg, fields...)
 }
 
 func (l *Logger) ErrorContext(ctx context.Context, msg string, fields ...zapcore.Field) {
 	fields = l.logFields(ctx, zap.ErrorLevel, msg, fields)
-	l.skipCaller.Error(msg, fields...)
+	l.loggerFor(ctx).Error(msg, fields...)
 }
 
 func (l *Logger) ErrorfContext(ctx context.Context, format string, v ...interface{}) {
 	msg := fmt.Sprintf(format, v...)
 	fields := l.logFields(ctx, zap.ErrorLevel, msg, []zapcore.Field{})
-	l.skipCaller.Info(msg, fields...)
+	l.loggerFor(ctx).Error(msg, fields...)
 }
 
 func (l *Logger) Flush() {
@@ -164,53 +181,48 @@ func (l *Logger) Flush() {
 
 func (l *Logger) DPanicContext(ctx context.Context, msg string, fields ...zapcore.Field) {
 	fields = l.logFields(ctx, zap.DPanicLevel, msg, fields)
-	l.skipCaller.DPanic(msg, fields...)
+	l.loggerFor(ctx).DPanic(msg, fields...)
 }
 
 func (l *Logger) DPanicfContext(ctx context.Context, format string, v ...interface{}) {
 	msg := fmt.Sprintf(format, v...)
 	fields := l.logFields(ctx, zap.DPanicLevel, msg, []zapcore.Field{})
-	l.skipCaller.Info(msg, fields...)
+	l.loggerFor(ctx).DPanic(msg, fields...)
 }
 
 func (l *Logger) PanicContext(ctx context.Context, msg string, fields ...zapcore.Field) {
 	fields = l.logFields(ctx, zap.PanicLevel, msg, fields)
-	l.skipCaller.Panic(msg, fields...)
+	l.loggerFor(ctx).Panic(msg, fields...)
 }
 
 func (l *Logger) PanicfContext(ctx context.Context, format string, v ...interface{}) {
 	msg := fmt.Sprintf(format, v...)
 	fields := l.logFields(ctx, zap.PanicLevel, msg, []zapcore.Field{})
-	l.skipCaller.Info(msg, fields...)
+	l.loggerFor(ctx).Panic(msg, fields...)
 }
 
 func (l *Logger) FatalContext(ctx context.Context, msg string, fields ...zapcore.Field) {
 	fields = l.logFields(ctx, zap.FatalLevel, msg, fields)
-	l.skipCaller.Fatal(msg, fields...)
+	l.loggerFor(ctx).Fatal(msg, fields...)
 }
 
 func (l *Logger) FatalfContext(ctx context.Context, format string, v ...interface{}) {
 	msg := fmt.Sprintf(format, v...)
 	fields := l.logFields(ctx, zap.FatalLevel, msg, []zapcore.Field{})
-	l.skipCaller.Info(msg, fields...)
+	l.loggerFor(ctx).Fatal(msg, fields...)
 }
 
 func (l *Logger) logFields(ctx context.Context, lvl zapcore.Level, msg string, fields []zapcore.Field) []zapcore.Field {
-	if lvl < l.minLevel {
+	if ctx == nil {
 		return fields
 	}
-
-	switch ctx.(type) {
-	case *gin.Context:
-		requestID, _ := ctx.Value(KeyRequestID).(string)
-		username, _ := ctx.Value(KeyUsername).(string)
-		if requestID == "" {
-			fields = append(fields, zap.String(KeyRequestID, requestID))
-		}
-		if username == "" {
-			fields = append(fields, zap.String(KeyUsername, username))
-		}
-		ctx = ctx.(*gin.Context).Request.Context()
+	ctx = requestContext(ctx)
+	if lvl < zapcore.DPanicLevel && !l.Core().Enabled(lvl) && !DebugForced(ctx) {
+		return fields
+	}
+	fields = append(fields, contextFields(ctx, l.withTraceID)...)
+	if lvl < l.minLevel {
+		return fields
 	}
 
 	span := trace.SpanFromContext(ctx)
@@ -238,12 +250,23 @@ func (l *Logger) logFields(ctx context.Context, lvl zapcore.Level, msg string, f
 
 	l.log(span, lvl, msg, attrs)
 
-	if l.withTraceID {
-		traceID := span.SpanContext().TraceID().String()
-		fields = append(fields, zap.String("trace_id", traceID))
+	return fields
+}
+
+// requestContext gin 的上下文字段保存在请求的 context 中
+func requestContext(ctx context.Context) context.Context {
+	if c, ok := ctx.(*gin.Context); ok && c.Request != nil {
+		return c.Request.Context()
 	}
+	return ctx
+}
 
-	return fields
+// loggerFor 上下文开启调试日志时返回不受级别限制的 logger
+func (l *Logger) loggerFor(ctx context.Context) *zap.Logger {
+	if l.forced != nil && ctx != nil && DebugForced(requestContext(ctx)) {
+		return l.forced
+	}
+	return l.skipCaller
 }
 
 func (l *Logger) log(span trace.Span, lvl zapcore.Level, msg string, attrs []attribute.KeyValue) {
@@ -337,28 +360,28 @@ func (l LoggerWithCtx) Clone() LoggerWithCtx {
 // at the log site, as well as any fields accumulated on the logger.
 func (l LoggerWithCtx) Debug(msg string, fields ...zapcore.Field) {
 	fields = l.l.logFields(l.ctx, zap.DebugLevel, msg, fields)
-	l.l.skipCaller.Debug(msg, fields...)
+	l.l.loggerFor(l.ctx).Debug(msg, fields...)
 }
 
 // Info logs a message at InfoLevel. The message includes any fields passed
 // at the log site, as well as any fields accumulated on the logger.
 func (l LoggerWithCtx) Info(msg string, fields ...zapcore.Field) {
 	fields = l.l.logFields(l.ctx, zap.InfoLevel, msg, fields)
-	l.l.skipCaller.Info(msg, fields...)
+	l.l.loggerFor(l.ctx).Info(msg, fields...)
 }
 
 // Warn logs a message at WarnLevel. The message includes any fields passed
 // at the log site, as well as any fields accumulated on the logger.
 func (l LoggerWithCtx) Warn(msg string, fields ...zapcore.Field) {
 	fields = l.l.logFields(l.ctx, zap.WarnLevel, msg, fields)
-	l.l.skipCaller.Warn(msg, fields...)
+	l.l.loggerFor(l.ctx).Warn(msg, fields...)
 }
 
 // Error logs a message at ErrorLevel. The message includes any fields passed
 // at the log site, as well as any fields accumulated on the logger.
 func (l LoggerWithCtx) Error(msg string, fields ...zapcore.Field) {
 	fields = l.l.logFields(l.ctx, zap.ErrorLevel, msg, fields)
-	l.l.skipCaller.Error(msg, fields...)
+	l.l.loggerFor(l.ctx).Error(msg, fields...)
 }
 
 // DPanic logs a message at DPanicLevel. The message includes any fields
@@ -369,7 +392,7 @@ func (l LoggerWithCtx) Error(msg string, fields ...zapcore.Field) {
 // recoverable, but shouldn't ever happen.
 func (l LoggerWithCtx) DPanic(msg string, fields ...zapcore.Field) {
 	fields = l.l.logFields(l.ctx, zap.DPanicLevel, msg, fields)
-	l.l.skipCaller.DPanic(msg, fields...)
+	l.l.loggerFor(l.ctx).DPanic(msg, fields...)
 }
 
 // Panic logs a message at PanicLevel. The message includes any fields passed
@@ -378,7 +401,7 @@ func (l LoggerWithCtx) DPanic(msg string, fields ...zapcore.Field) {
 // The logger then panics, even if logging at PanicLevel is disabled.
 func (l LoggerWithCtx) Panic(msg string, fields ...zapcore.Field) {
 	fields = l.l.logFields(l.ctx, zap.PanicLevel, msg, fields)
-	l.l.skipCaller.Panic(msg, fields...)
+	l.l.loggerFor(l.ctx).Panic(msg, fields...)
 }
 
 // Fatal logs a message at FatalLevel. The message includes any fields passed
@@ -388,7 +411,7 @@ func (l LoggerWithCtx) Panic(msg string, fields ...zapcore.Field) {
 // disabled.
 func (l LoggerWithCtx) Fatal(msg string, fields ...zapcore.Field) {
 	fields = l.l.logFields(l.ctx, zap.FatalLevel, msg, fields)
-	l.l.skipCaller.Fatal(msg, fields...)
+	l.l.loggerFor(l.ctx).Fatal(msg, fields...)
 }
 
 //------------------------------------------------------------------------------
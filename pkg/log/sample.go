@@ -0,0 +1,75 @@
+package log
+
+import (
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Sampled 返回对 info 及以下级别采样的 logger，用于高频路径
+//
+// 每个 tick 内每个级别输出前 first 条，之后每 thereafter 条输出一条；warn 及以上级别和
+// 开启调试日志的请求不采样。与 zap 自带的采样不同，这里不区分日志内容，格式化后内容
+// 各不相同的日志也能被采样。
+func (l *Logger) Sampled(tick time.Duration, first, thereafter uint64) *Logger {
+	counts := &sampleCounts{tick: int64(tick), first: first, thereafter: thereafter}
+	wrap := zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		// 采样放在级别判断之后，被过滤的日志不计数
+		if c, ok := core.(*levelCore); ok {
+			return &levelCore{Core: &sampleCore{Core: c.Core, counts: counts}, levels: c.levels, force: c.force}
+		}
+		return &sampleCore{Core: core, counts: counts}
+	})
+	clone := *l
+	clone.Logger = l.Logger.WithOptions(wrap)
+	clone.skipCaller = l.skipCaller.WithOptions(wrap)
+	return &clone
+}
+
+type sampleCounter struct {
+	resetAt atomic.Int64
+	n       atomic.Uint64
+}
+
+type sampleCounts struct {
+	tick              int64
+	first, thereafter uint64
+	// debug、info 两个级别
+	counters [2]sampleCounter
+}
+
+func (s *sampleCounts) allow(lvl zapcore.Level, t time.Time) bool {
+	if lvl >= zapcore.WarnLevel || lvl < zapcore.DebugLevel {
+		return true
+	}
+	c := &s.counters[lvl-zapcore.DebugLevel]
+	now := t.UnixNano()
+	resetAt := c.resetAt.Load()
+	if now > resetAt && c.resetAt.CompareAndSwap(resetAt, now+s.tick) {
+		c.n.Store(1)
+		return s.first > 0
+	}
+	n := c.n.Add(1)
+	if n <= s.first {
+		return true
+	}
+	return s.thereafter > 0 && (n-s.first)%s.thereafter == 0
+}
+
+type sampleCore struct {
+	zapcore.Core
+	counts *sampleCounts
+}
+
+func (c *sampleCore) With(fields []zapcore.Field) zapcore.Core {
+	return &sampleCore{Core: c.Core.With(fields), counts: c.counts}
+}
+
+func (c *sampleCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.counts.allow(ent.Level, ent.Time) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
@@ -10,6 +10,8 @@ const (
 	KeyRequestID string = "requestID"
 	KeyUsername  string = "username"
 	KeyUserID    string = "userid"
+	KeyOrderSN   string = "orderSN"
+	KeyPaymentSN string = "paymentSN"
 )
 
 // Field is an alias for the field structure in the underlying log frame.
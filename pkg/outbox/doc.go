@@ -0,0 +1,16 @@
+// Package outbox 提供各服务共用的事务性发件箱。
+//
+// 业务变更与领域事件在同一个GORM事务中写入，事务提交后由Relay轮询发件箱表投递到RocketMQ，
+// 避免"提交成功但消息未发出"或"消息已发出但事务回滚"。同一聚合(如同一订单)的事件按写入顺序投递，
+// 投递失败时按指数退避重试；每个事件带有唯一的事件ID，消费方据此去重。
+//
+//	events, worker, _ := outbox.NewWorker(db, nameServers, "order-outbox", "order-events")
+//	app := gapp.New(gapp.WithRPCServer(rpcServer), gapp.WithServer(worker))
+//
+//	err := db.Transaction(func(tx *gorm.DB) error {
+//		if err := tx.Model(&Order{}).Where("order_sn = ?", sn).Update("status", "PAID").Error; err != nil {
+//			return err
+//		}
+//		return events.Add(ctx, tx, "order", sn, "order.paid", OrderPaid{OrderSn: sn})
+//	})
+package outbox
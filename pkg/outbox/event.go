@@ -0,0 +1,95 @@
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/apache/rocketmq-client-go/v2/primitive"
+	"github.com/google/uuid"
+)
+
+// Status 事件投递状态
+type Status string
+
+const (
+	StatusPending   Status = "pending"   // 待投递
+	StatusPublished Status = "published" // 已投递
+	StatusFailed    Status = "failed"    // 超过最大次数仍投递失败
+)
+
+// 投递到RocketMQ的消息属性
+const (
+	PropertyEventID       = "OUTBOX_EVENT_ID"
+	PropertyEventType     = "OUTBOX_EVENT_TYPE"
+	PropertyAggregateType = "OUTBOX_AGGREGATE_TYPE"
+	PropertyAggregateID   = "OUTBOX_AGGREGATE_ID"
+)
+
+// Event 发件箱中的领域事件
+type Event struct {
+	ID uint64 `gorm:"primaryKey;autoIncrement" json:"id"`
+	// EventID 全局唯一的事件ID，消费方据此去重
+	EventID       string `gorm:"type:varchar(64);uniqueIndex:uk_outbox_event_id;not null" json:"eventId"`
+	Topic         string `gorm:"type:varchar(128);not null" json:"topic"`
+	AggregateType string `gorm:"type:varchar(64);not null;index:idx_outbox_aggregate,priority:1" json:"aggregateType"`
+	// AggregateID 聚合标识(如订单号)，同一聚合的事件按写入顺序投递
+	AggregateID string `gorm:"type:varchar(64);not null;index:idx_outbox_aggregate,priority:2" json:"aggregateId"`
+	EventType   string `gorm:"type:varchar(64);not null" json:"eventType"`
+	Payload     string `gorm:"type:text" json:"payload"`
+	Status      Status `gorm:"type:varchar(16);not null;index:idx_outbox_due,priority:1" json:"status"`
+	Attempts    int    `gorm:"not null;default:0" json:"attempts"`
+	// NextAttemptAt 下次投递时间，事件被领取后推迟为租约到期时间
+	NextAttemptAt time.Time  `gorm:"index:idx_outbox_due,priority:2" json:"nextAttemptAt"`
+	LastError     string     `gorm:"type:varchar(512)" json:"lastError,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	PublishedAt   *time.Time `json:"publishedAt,omitempty"`
+}
+
+// TableName 发件箱表名，各服务在自己的库中建表
+func (Event) TableName() string {
+	return "outbox_events"
+}
+
+// NewEvent 构造待投递的事件，payload序列化为JSON
+func NewEvent(topic, aggregateType, aggregateID, eventType string, payload interface{}) (*Event, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("outbox: marshal payload: %w", err)
+	}
+	now := time.Now()
+	return &Event{
+		EventID:       uuid.NewString(),
+		Topic:         topic,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		EventType:     eventType,
+		Payload:       string(data),
+		Status:        StatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
+
+// OrderingKey 顺序键，同一键的事件投递到同一队列
+func (e *Event) OrderingKey() string {
+	return e.AggregateType + ":" + e.AggregateID
+}
+
+// Message 转换为RocketMQ消息，事件类型作为Tag，事件ID作为Key
+func (e *Event) Message() *primitive.Message {
+	msg := primitive.NewMessage(e.Topic, []byte(e.Payload))
+	msg.WithTag(e.EventType)
+	msg.WithKeys([]string{e.EventID})
+	msg.WithShardingKey(e.OrderingKey())
+	msg.WithProperty(PropertyEventID, e.EventID)
+	msg.WithProperty(PropertyEventType, e.EventType)
+	msg.WithProperty(PropertyAggregateType, e.AggregateType)
+	msg.WithProperty(PropertyAggregateID, e.AggregateID)
+	return msg
+}
+
+// EventID 读取消费到的消息的事件ID，用于幂等去重
+func EventID(msg *primitive.MessageExt) string {
+	return msg.GetProperty(PropertyEventID)
+}
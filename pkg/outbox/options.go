@@ -0,0 +1,80 @@
+package outbox
+
+import "time"
+
+type options struct {
+	pollInterval time.Duration
+	batchSize    int
+	maxAttempts  int
+	timeout      time.Duration
+	lease        time.Duration
+	retention    time.Duration
+	backoff      func(attempt int) time.Duration
+}
+
+// Option 投递器选项
+type Option func(o *options)
+
+// WithPollInterval 设置发件箱的轮询间隔
+func WithPollInterval(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.pollInterval = d
+		}
+	}
+}
+
+// WithBatchSize 设置每次领取的事件数
+func WithBatchSize(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.batchSize = n
+		}
+	}
+}
+
+// WithMaxAttempts 设置单个事件的最大投递次数，超过后标记为失败
+func WithMaxAttempts(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.maxAttempts = n
+		}
+	}
+}
+
+// WithTimeout 设置单次投递超时
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.timeout = d
+		}
+	}
+}
+
+// WithLease 设置领取事件的租约时长，投递器异常退出时租约到期后事件由其他副本重新领取；
+// 需大于一批事件的投递耗时，默认为批量乘以单次投递超时
+func WithLease(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.lease = d
+		}
+	}
+}
+
+// WithRetention 设置已投递事件的保留时长
+func WithRetention(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.retention = d
+		}
+	}
+}
+
+// WithBackoff 设置重试退避策略
+func WithBackoff(backoff func(attempt int) time.Duration) Option {
+	return func(o *options) {
+		if backoff != nil {
+			o.backoff = backoff
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package outbox
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Outbox 业务侧写入事件的入口
+type Outbox struct {
+	topic string
+}
+
+// New 创建发件箱，事件投递到topic
+func New(topic string) *Outbox {
+	return &Outbox{topic: topic}
+}
+
+// Add 在业务事务tx中写入事件，事务回滚时事件一并回滚
+//
+// Outbox为nil(未启用)时不写入
+func (o *Outbox) Add(ctx context.Context, tx *gorm.DB, aggregateType, aggregateID, eventType string, payload interface{}) error {
+	if o == nil {
+		return nil
+	}
+	event, err := NewEvent(o.topic, aggregateType, aggregateID, eventType, payload)
+	if err != nil {
+		return err
+	}
+	return Save(ctx, tx, event)
+}
+
+// Save 在业务事务tx中写入已构造的事件
+func Save(ctx context.Context, tx *gorm.DB, events ...*Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return tx.WithContext(ctx).Create(events).Error
+}
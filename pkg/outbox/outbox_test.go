@@ -0,0 +1,262 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/rocketmq-client-go/v2/primitive"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore 测试用的内存发件箱
+type memoryStore struct {
+	mu     sync.Mutex
+	seq    uint64
+	events map[uint64]*Event
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{events: make(map[uint64]*Event)}
+}
+
+func (s *memoryStore) add(t *testing.T, aggregateID, eventType string) *Event {
+	event, err := NewEvent("orders", "order", aggregateID, eventType, map[string]string{"orderSn": aggregateID})
+	require.NoError(t, err)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seq++
+	event.ID = s.seq
+	s.events[event.ID] = event
+	return event
+}
+
+func (s *memoryStore) get(id uint64) Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.events[id]
+}
+
+func (s *memoryStore) Claim(ctx context.Context, limit int, lease time.Duration) ([]*Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	var pending []*Event
+	for _, event := range s.events {
+		if event.Status == StatusPending {
+			pending = append(pending, event)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].ID < pending[j].ID })
+	blocked := make(map[string]bool)
+	var claimed []*Event
+	for _, event := range pending {
+		key := event.OrderingKey()
+		if blocked[key] || event.NextAttemptAt.After(now) {
+			blocked[key] = true
+			continue
+		}
+		if len(claimed) == limit {
+			break
+		}
+		copied := *event
+		claimed = append(claimed, &copied)
+		event.NextAttemptAt = now.Add(lease)
+	}
+	return claimed, nil
+}
+
+func (s *memoryStore) Save(ctx context.Context, events []*Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, event := range events {
+		s.events[event.ID] = event
+	}
+	return nil
+}
+
+func (s *memoryStore) Purge(ctx context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var n int64
+	for id, event := range s.events {
+		if event.Status == StatusPublished && event.PublishedAt.Before(before) {
+			delete(s.events, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// fakeSender 按事件类型注入投递失败
+type fakeSender struct {
+	mu   sync.Mutex
+	sent []*primitive.Message
+	fail map[string]bool
+}
+
+func (f *fakeSender) SendSync(ctx context.Context, msgs ...*primitive.Message) (*primitive.SendResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, msg := range msgs {
+		if f.fail[msg.GetTags()] {
+			return nil, errors.New("broker unavailable")
+		}
+	}
+	f.sent = append(f.sent, msgs...)
+	return &primitive.SendResult{Status: primitive.SendOK, MsgID: "msg"}, nil
+}
+
+func (f *fakeSender) tags() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	tags := make([]string, 0, len(f.sent))
+	for _, msg := range f.sent {
+		tags = append(tags, msg.GetProperty(PropertyAggregateID)+"/"+msg.GetTags())
+	}
+	return tags
+}
+
+func TestEventMessage(t *testing.T) {
+	event, err := NewEvent("orders", "order", "SN1", "order.paid", map[string]int{"amount": 10})
+	require.NoError(t, err)
+	assert.NotEmpty(t, event.EventID)
+	assert.Equal(t, StatusPending, event.Status)
+	assert.Equal(t, `{"amount":10}`, event.Payload)
+
+	msg := event.Message()
+	assert.Equal(t, "orders", msg.Topic)
+	assert.Equal(t, "order.paid", msg.GetTags())
+	assert.Equal(t, event.EventID, msg.GetKeys())
+	assert.Equal(t, "order:SN1", msg.GetShardingKey())
+	ext := &primitive.MessageExt{}
+	ext.WithProperties(msg.GetProperties())
+	assert.Equal(t, event.EventID, EventID(ext))
+
+	_, err = NewEvent("orders", "order", "SN1", "order.paid", make(chan int))
+	assert.Error(t, err)
+
+	var disabled *Outbox
+	assert.NoError(t, disabled.Add(context.Background(), nil, "order", "SN1", "order.paid", nil))
+}
+
+func TestRelayPublishesInOrder(t *testing.T) {
+	store := newMemoryStore()
+	sender := &fakeSender{fail: map[string]bool{"order.paid": true}}
+	relay := NewRelay(store, sender, WithMaxAttempts(2), WithBackoff(func(int) time.Duration { return time.Hour }))
+	ctx := context.Background()
+
+	created := store.add(t, "SN1", "order.created")
+	paid := store.add(t, "SN1", "order.paid")
+	shipped := store.add(t, "SN1", "order.shipped")
+	other := store.add(t, "SN2", "order.created")
+
+	n, err := relay.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	// SN1的支付事件投递失败，发货事件等待；SN2不受影响
+	assert.Equal(t, []string{"SN1/order.created", "SN2/order.created"}, sender.tags())
+	assert.Equal(t, StatusPublished, store.get(created.ID).Status)
+	assert.Equal(t, StatusPublished, store.get(other.ID).Status)
+
+	failed := store.get(paid.ID)
+	assert.Equal(t, StatusPending, failed.Status)
+	assert.Equal(t, 1, failed.Attempts)
+	assert.Equal(t, "broker unavailable", failed.LastError)
+	assert.True(t, failed.NextAttemptAt.After(time.Now()))
+	assert.Equal(t, 0, store.get(shipped.ID).Attempts)
+
+	// 重试时间未到，同一聚合的后续事件继续等待
+	n, err = relay.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.False(t, store.get(shipped.ID).NextAttemptAt.After(time.Now()))
+
+	// 重试时间到达后恢复投递，发货事件随后投出
+	store.events[paid.ID].NextAttemptAt = time.Now()
+	sender.fail = nil
+	_, err = relay.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SN1/order.created", "SN2/order.created", "SN1/order.paid", "SN1/order.shipped"}, sender.tags())
+	assert.Equal(t, StatusPublished, store.get(shipped.ID).Status)
+
+	purged, err := store.Purge(ctx, time.Now().Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), purged)
+}
+
+func TestRelayMarksFailedAfterMaxAttempts(t *testing.T) {
+	store := newMemoryStore()
+	sender := &fakeSender{fail: map[string]bool{"order.paid": true}}
+	relay := NewRelay(store, sender, WithMaxAttempts(2), WithBackoff(func(int) time.Duration { return 0 }))
+	ctx := context.Background()
+
+	paid := store.add(t, "SN1", "order.paid")
+	next := store.add(t, "SN1", "order.shipped")
+
+	_, err := relay.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, StatusPending, store.get(paid.ID).Status)
+
+	// 超过最大次数后标记失败，同一聚合的后续事件不再阻塞
+	_, err = relay.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, store.get(paid.ID).Status)
+	assert.Equal(t, 2, store.get(paid.ID).Attempts)
+	assert.Equal(t, StatusPublished, store.get(next.ID).Status)
+}
+
+func TestRelayClaimsOnlyDueEvents(t *testing.T) {
+	store := newMemoryStore()
+	sender := &fakeSender{fail: map[string]bool{"order.paid": true}}
+	relay := NewRelay(store, sender, WithBatchSize(1), WithBackoff(func(int) time.Duration { return time.Hour }))
+	ctx := context.Background()
+
+	paid := store.add(t, "SN1", "order.paid")
+	store.add(t, "SN1", "order.shipped")
+	other := store.add(t, "SN2", "order.created")
+
+	n, err := relay.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, StatusPending, store.get(paid.ID).Status)
+
+	// 等待重试的事件不占用批量，其他聚合的事件继续投递
+	n, err = relay.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, StatusPublished, store.get(other.ID).Status)
+	assert.Equal(t, []string{"SN2/order.created"}, sender.tags())
+}
+
+func TestRelaySkipsLeasedEvents(t *testing.T) {
+	store := newMemoryStore()
+	ctx := context.Background()
+	event := store.add(t, "SN1", "order.created")
+	later := store.add(t, "SN1", "order.paid")
+
+	// 另一个副本领取了第一个事件，租约期间同一聚合的事件都不会被领取
+	claimed, err := store.Claim(ctx, 1, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, event.ID, claimed[0].ID)
+
+	sender := &fakeSender{}
+	n, err := NewRelay(store, sender).RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.Equal(t, StatusPending, store.get(later.ID).Status)
+	assert.Empty(t, sender.tags())
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "short", truncate("short"))
+	long := truncate(strings.Repeat("失败", 200))
+	assert.LessOrEqual(t, len(long), maxErrorLength)
+	assert.True(t, strings.HasSuffix(long, "败") || strings.HasSuffix(long, "失"))
+}
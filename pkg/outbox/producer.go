@@ -0,0 +1,29 @@
+package outbox
+
+import (
+	"fmt"
+
+	"emshop/gin-micro/core/health"
+
+	rocketmq "github.com/apache/rocketmq-client-go/v2"
+	"github.com/apache/rocketmq-client-go/v2/producer"
+)
+
+// NewProducer 创建并启动投递使用的生产者，按事件的顺序键选择队列，同一聚合的事件进入同一队列
+func NewProducer(nameServers []string, group string) (rocketmq.Producer, error) {
+	p, err := rocketmq.NewProducer(
+		producer.WithNameServer(nameServers),
+		producer.WithGroupName(group),
+		producer.WithQueueSelector(producer.NewHashQueueSelector()),
+		producer.WithRetry(2),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("outbox: create producer: %w", err)
+	}
+	if err := p.Start(); err != nil {
+		return nil, fmt.Errorf("outbox: start producer: %w", err)
+	}
+	// 事件已落库，RocketMQ不可用时由投递任务重试，不影响就绪状态
+	health.Register("rocketmq", health.TCPCheck(nameServers...), health.Optional())
+	return p, nil
+}
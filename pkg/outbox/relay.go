@@ -0,0 +1,208 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"emshop/pkg/delayjob"
+	"emshop/pkg/log"
+
+	"github.com/apache/rocketmq-client-go/v2/primitive"
+)
+
+const maxErrorLength = 512
+
+// Sender 投递事件使用的生产者，rocketmq.Producer满足该接口
+type Sender interface {
+	SendSync(ctx context.Context, msgs ...*primitive.Message) (*primitive.SendResult, error)
+}
+
+// Relay 发件箱投递器，轮询待投递事件并发送到RocketMQ
+//
+// 同一聚合的事件按写入顺序投递：前一个事件投递失败等待重试期间，同一聚合的后续事件不会投递；
+// 事件超过最大次数标记为失败后，后续事件继续投递。多副本以租约领取事件，投递期间不持有数据库锁；
+// 投递成功但结果提交失败时事件会被重复投递，消费方需按事件ID去重。
+type Relay struct {
+	store  Store
+	sender Sender
+	opts   options
+
+	lifecycle sync.Mutex
+	cancel    context.CancelFunc
+	stopped   chan struct{}
+}
+
+// NewRelay 创建投递器，sender需使用按ShardingKey选择队列的生产者(见NewProducer)以保证聚合内有序
+func NewRelay(store Store, sender Sender, opts ...Option) *Relay {
+	o := options{
+		pollInterval: time.Second,
+		batchSize:    100,
+		maxAttempts:  10,
+		timeout:      3 * time.Second,
+		retention:    3 * 24 * time.Hour,
+		backoff:      delayjob.ExponentialBackoff(time.Second, 5*time.Minute),
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.lease == 0 {
+		o.lease = time.Duration(o.batchSize) * o.timeout
+	}
+	return &Relay{store: store, sender: sender, opts: o}
+}
+
+// Start 启动投递循环，阻塞直到ctx取消或调用Stop
+func (r *Relay) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	stopped := make(chan struct{})
+	r.lifecycle.Lock()
+	r.cancel, r.stopped = cancel, stopped
+	r.lifecycle.Unlock()
+	defer close(stopped)
+
+	log.Infof("[outbox] 投递器启动: 轮询间隔=%s, 批量=%d", r.opts.pollInterval, r.opts.batchSize)
+
+	ticker := time.NewTicker(r.opts.pollInterval)
+	defer ticker.Stop()
+	purgeEvery := time.Hour
+	if r.opts.retention < purgeEvery {
+		purgeEvery = r.opts.retention
+	}
+	lastPurge := time.Now()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		// 一批投递满时立即处理下一批，积压时不等待轮询间隔
+		for {
+			n, err := r.RunOnce(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Errorf("[outbox] 投递事件失败: %v", err)
+				}
+				break
+			}
+			if n < r.opts.batchSize || ctx.Err() != nil {
+				break
+			}
+		}
+
+		if time.Since(lastPurge) >= purgeEvery {
+			lastPurge = time.Now()
+			r.purge(ctx)
+		}
+	}
+}
+
+// Stop 停止投递并等待当前批次完成
+func (r *Relay) Stop(ctx context.Context) error {
+	r.lifecycle.Lock()
+	cancel, stopped := r.cancel, r.stopped
+	r.lifecycle.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	select {
+	case <-stopped:
+		log.Info("[outbox] 投递器已停止")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RunOnce 领取并投递一批到期事件，返回本批尝试投递的事件数，用于测试和手动触发
+func (r *Relay) RunOnce(ctx context.Context) (int, error) {
+	events, err := r.store.Claim(ctx, r.opts.batchSize, r.opts.lease)
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+	attempted := r.publish(ctx, events)
+
+	// Stop取消ctx时仍需保存本批结果，否则已投递的事件在租约到期后被重复投递
+	saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), r.opts.timeout)
+	defer cancel()
+	if err := r.store.Save(saveCtx, events); err != nil {
+		return attempted, err
+	}
+	return attempted, nil
+}
+
+// publish 按写入顺序投递事件并就地更新投递结果，返回尝试投递的事件数
+func (r *Relay) publish(ctx context.Context, events []*Event) int {
+	now := time.Now()
+	blocked := make(map[string]bool)
+	attempted := 0
+	for _, event := range events {
+		key := event.OrderingKey()
+		if blocked[key] || ctx.Err() != nil {
+			// 释放租约，前面的事件重试前不会再被领取
+			event.NextAttemptAt = now
+			continue
+		}
+
+		attempted++
+		event.Attempts++
+		err := r.send(ctx, event)
+		switch {
+		case err == nil:
+			published := time.Now()
+			event.Status = StatusPublished
+			event.PublishedAt = &published
+			event.LastError = ""
+		case event.Attempts >= r.opts.maxAttempts:
+			event.Status = StatusFailed
+			event.LastError = truncate(err.Error())
+			log.Errorf("[outbox] 事件投递失败，不再重试: id=%s, type=%s, aggregate=%s, 第%d次, err=%v",
+				event.EventID, event.EventType, key, event.Attempts, err)
+		default:
+			event.NextAttemptAt = now.Add(r.opts.backoff(event.Attempts))
+			event.LastError = truncate(err.Error())
+			blocked[key] = true
+			log.Warnf("[outbox] 事件投递失败，%s后重试: id=%s, type=%s, aggregate=%s, 第%d次, err=%v",
+				event.NextAttemptAt.Sub(now).Round(time.Second), event.EventID, event.EventType, key, event.Attempts, err)
+		}
+	}
+	return attempted
+}
+
+func (r *Relay) send(ctx context.Context, event *Event) error {
+	ctx, cancel := context.WithTimeout(ctx, r.opts.timeout)
+	defer cancel()
+	result, err := r.sender.SendSync(ctx, event.Message())
+	if err != nil {
+		return err
+	}
+	log.Debugf("[outbox] 事件已投递: id=%s, type=%s, msgID=%s", event.EventID, event.EventType, result.MsgID)
+	return nil
+}
+
+func (r *Relay) purge(ctx context.Context) {
+	n, err := r.store.Purge(ctx, time.Now().Add(-r.opts.retention))
+	if err != nil {
+		log.Warnf("[outbox] 清理已投递事件失败: %v", err)
+		return
+	}
+	if n > 0 {
+		log.Infof("[outbox] 已清理%d条已投递事件", n)
+	}
+}
+
+// truncate 截断错误信息，不拆分多字节字符
+func truncate(s string) string {
+	if len(s) <= maxErrorLength {
+		return s
+	}
+	end := maxErrorLength
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	return s[:end]
+}
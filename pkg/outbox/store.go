@@ -0,0 +1,127 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Store 发件箱存储
+type Store interface {
+	// Claim 领取最早的limit条到期事件，lease时长内其他副本不会再领取这些事件；
+	// 同一聚合中存在更早的未到期事件(等待重试或已被其他副本领取)时，后续事件不会被领取
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]*Event, error)
+
+	// Save 保存投递结果
+	Save(ctx context.Context, events []*Event) error
+
+	// Purge 删除before之前已投递的事件
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+// gormStore 基于业务库发件箱表的存储
+type gormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore 创建发件箱存储，db需与业务写入使用同一个库
+func NewGormStore(db *gorm.DB) Store {
+	return &gormStore{db: db}
+}
+
+// blocker 聚合中最早的未到期待投递事件
+type blocker struct {
+	AggregateType string
+	AggregateID   string
+	ID            uint64
+}
+
+// Claim 在短事务中锁定到期事件并把next_attempt_at推迟lease作为租约，提交后才投递，投递期间不持有行锁
+//
+// 候选事件先以 SELECT ... FOR UPDATE 锁定，多副本同时领取时后到的副本等待锁释放并读到已推迟的租约；
+// 阻塞聚合的查询在加锁之后执行，能看到其他副本刚提交的领取结果
+func (s *gormStore) Claim(ctx context.Context, limit int, lease time.Duration) ([]*Event, error) {
+	var claimed []*Event
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		var events []*Event
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("status = ? AND next_attempt_at <= ?", StatusPending, now).
+			Order("id ASC").
+			Limit(limit).
+			Find(&events).Error
+		if err != nil || len(events) == 0 {
+			return err
+		}
+
+		keys := make([][]interface{}, 0, len(events))
+		seen := make(map[string]bool, len(events))
+		for _, event := range events {
+			if key := event.OrderingKey(); !seen[key] {
+				seen[key] = true
+				keys = append(keys, []interface{}{event.AggregateType, event.AggregateID})
+			}
+		}
+		var blockers []blocker
+		err = tx.Model(&Event{}).
+			Select("aggregate_type, aggregate_id, MIN(id) AS id").
+			Where("status = ? AND next_attempt_at > ? AND id < ?", StatusPending, now, events[len(events)-1].ID).
+			Where("(aggregate_type, aggregate_id) IN ?", keys).
+			Group("aggregate_type, aggregate_id").
+			Scan(&blockers).Error
+		if err != nil {
+			return err
+		}
+		blockedFrom := make(map[string]uint64, len(blockers))
+		for _, b := range blockers {
+			blockedFrom[b.AggregateType+":"+b.AggregateID] = b.ID
+		}
+
+		ids := make([]uint64, 0, len(events))
+		for _, event := range events {
+			if from, ok := blockedFrom[event.OrderingKey()]; ok && event.ID > from {
+				continue
+			}
+			claimed = append(claimed, event)
+			ids = append(ids, event.ID)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		return tx.Model(&Event{}).Where("id IN ?", ids).Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+func (s *gormStore) Save(ctx context.Context, events []*Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, event := range events {
+			err := tx.Model(&Event{}).Where("id = ?", event.ID).Updates(map[string]interface{}{
+				"status":          event.Status,
+				"attempts":        event.Attempts,
+				"next_attempt_at": event.NextAttemptAt,
+				"last_error":      event.LastError,
+				"published_at":    event.PublishedAt,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *gormStore) Purge(ctx context.Context, before time.Time) (int64, error) {
+	result := s.db.WithContext(ctx).
+		Where("status = ? AND published_at < ?", StatusPublished, before).
+		Delete(&Event{})
+	return result.RowsAffected, result.Error
+}
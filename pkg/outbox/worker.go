@@ -0,0 +1,48 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+
+	"emshop/pkg/log"
+
+	rocketmq "github.com/apache/rocketmq-client-go/v2"
+	"gorm.io/gorm"
+)
+
+// Worker 投递器及其生产者，满足gin-micro的server.Server接口，交给应用随启停管理
+type Worker struct {
+	relay    *Relay
+	producer rocketmq.Producer
+}
+
+// NewWorker 创建投递到topic的发件箱，以及轮询db发件箱表投递事件的Worker
+func NewWorker(db *gorm.DB, nameServers []string, group, topic string, opts ...Option) (*Outbox, *Worker, error) {
+	producer, err := NewProducer(nameServers, group)
+	if err != nil {
+		return nil, nil, err
+	}
+	worker := &Worker{
+		relay:    NewRelay(NewGormStore(db), producer, opts...),
+		producer: producer,
+	}
+	log.Infof("[outbox] 发件箱已启用, topic: %s", topic)
+	return New(topic), worker, nil
+}
+
+// Start 启动投递，阻塞直到ctx取消或调用Stop
+func (w *Worker) Start(ctx context.Context) error {
+	return w.relay.Start(ctx)
+}
+
+// Stop 等待当前批次投递完成后关闭生产者
+func (w *Worker) Stop(ctx context.Context) error {
+	err := w.relay.Stop(ctx)
+	if shutdownErr := w.producer.Shutdown(); shutdownErr != nil {
+		log.Warnf("[outbox] 关闭生产者失败: %v", shutdownErr)
+		if err == nil {
+			err = fmt.Errorf("outbox: shutdown producer: %w", shutdownErr)
+		}
+	}
+	return err
+}
@@ -1,6 +1,7 @@
 package sentinel
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 
@@ -18,11 +19,23 @@ import (
 	"github.com/nacos-group/nacos-sdk-go/common/constant"
 )
 
+// 规则来源
+const (
+	SourceNacos = "nacos" // 从Nacos配置中心加载
+	SourceFile  = "file"  // 从本地目录加载
+)
+
 // Config Sentinel配置结构
 type Config struct {
 	// 是否启用Sentinel
 	Enabled bool `yaml:"enabled" json:"enabled"`
 
+	// 规则来源，nacos 或 file，默认 nacos
+	Source string `yaml:"source" json:"source"`
+
+	// 本地规则文件配置，Source 为 file 时生效
+	File FileConfig `yaml:"file" json:"file"`
+
 	// Nacos配置
 	Nacos NacosConfig `yaml:"nacos" json:"nacos"`
 
@@ -44,6 +57,14 @@ type NacosConfig struct {
 	Password  string `yaml:"password" json:"password"`
 }
 
+// FileConfig 本地规则文件配置
+type FileConfig struct {
+	// 规则文件所在目录
+	Dir string `yaml:"dir" json:"dir"`
+	// 规则文件名前缀，如 coupon 对应 coupon-flow-rules.json
+	Prefix string `yaml:"prefix" json:"prefix"`
+}
+
 // AppConfig 应用配置
 type AppConfig struct {
 	Name     string `yaml:"name" json:"name"`
@@ -66,14 +87,14 @@ type RulesConfig struct {
 // Manager Sentinel管理器
 type Manager struct {
 	config      *Config
-	dataSources []*nacos.NacosDataSource
+	dataSources []RuleSource
 }
 
 // NewManager 创建Sentinel管理器
 func NewManager(config *Config) *Manager {
 	return &Manager{
 		config:      config,
-		dataSources: make([]*nacos.NacosDataSource, 0),
+		dataSources: make([]RuleSource, 0),
 	}
 }
 
@@ -90,6 +111,17 @@ func (m *Manager) Initialize() error {
 		return fmt.Errorf("初始化Sentinel失败: %w", err)
 	}
 
+	// 本地规则文件不依赖Nacos
+	if m.config.Source == SourceFile {
+		source := NewFileRuleSource(m.config.File.Dir, m.config.File.Prefix)
+		if err := source.Initialize(); err != nil {
+			return fmt.Errorf("初始化本地规则文件失败: %w", err)
+		}
+		m.dataSources = append(m.dataSources, source)
+		log.Info("Sentinel初始化成功")
+		return nil
+	}
+
 	// 创建Nacos客户端
 	nacosClient, err := m.createNacosClient()
 	if err != nil {
@@ -264,8 +296,11 @@ func (m *Manager) Shutdown() error {
 	return nil
 }
 
-// 规则解析器
+// 规则解析器，内容为空时返回 nil，由对应的 Updater 清空规则
 func flowRulesParser(src []byte) (interface{}, error) {
+	if len(bytes.TrimSpace(src)) == 0 {
+		return nil, nil
+	}
 	var rules []*flow.Rule
 	if err := json.Unmarshal(src, &rules); err != nil {
 		return nil, err
@@ -274,6 +309,9 @@ func flowRulesParser(src []byte) (interface{}, error) {
 }
 
 func circuitBreakerRulesParser(src []byte) (interface{}, error) {
+	if len(bytes.TrimSpace(src)) == 0 {
+		return nil, nil
+	}
 	var rules []*circuitbreaker.Rule
 	if err := json.Unmarshal(src, &rules); err != nil {
 		return nil, err
@@ -281,15 +319,38 @@ func circuitBreakerRulesParser(src []byte) (interface{}, error) {
 	return rules, nil
 }
 
+// hotspotRulesParser 热点参数的 specificItems 同时支持 Sentinel 的
+// [{"valKind":1,"valStr":"vip","threshold":50}] 和简写的 {"vip":50}，简写形式的参数值按字符串处理
 func hotspotRulesParser(src []byte) (interface{}, error) {
-	var rules []*hotspot.Rule
-	if err := json.Unmarshal(src, &rules); err != nil {
+	if len(bytes.TrimSpace(src)) == 0 {
+		return nil, nil
+	}
+	if rules, err := datasource.HotSpotParamRuleJsonArrayParser(src); err == nil {
+		return rules, nil
+	}
+	var items []struct {
+		hotspot.Rule
+		SpecificItems map[string]int64 `json:"specificItems"`
+	}
+	if err := json.Unmarshal(src, &items); err != nil {
 		return nil, err
 	}
+	rules := make([]*hotspot.Rule, 0, len(items))
+	for i := range items {
+		rule := items[i].Rule
+		rule.SpecificItems = make(map[interface{}]int64, len(items[i].SpecificItems))
+		for k, v := range items[i].SpecificItems {
+			rule.SpecificItems[k] = v
+		}
+		rules = append(rules, &rule)
+	}
 	return rules, nil
 }
 
 func systemRulesParser(src []byte) (interface{}, error) {
+	if len(bytes.TrimSpace(src)) == 0 {
+		return nil, nil
+	}
 	var rules []*system.Rule
 	if err := json.Unmarshal(src, &rules); err != nil {
 		return nil, err
@@ -301,6 +362,11 @@ func systemRulesParser(src []byte) (interface{}, error) {
 func DefaultConfig(serviceName string) *Config {
 	return &Config{
 		Enabled: true,
+		Source:  SourceNacos,
+		File: FileConfig{
+			Dir:    "configs/sentinel",
+			Prefix: serviceName,
+		},
 		Nacos: NacosConfig{
 			Host:      "127.0.0.1",
 			Port:      8848,
@@ -0,0 +1,154 @@
+package sentinel
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"emshop/pkg/log"
+
+	"github.com/alibaba/sentinel-golang/ext/datasource"
+	"github.com/fsnotify/fsnotify"
+)
+
+// RuleSource 规则数据源，Nacos数据源和本地文件数据源都实现了该接口
+type RuleSource interface {
+	Initialize() error
+	Close() error
+}
+
+// 编译时检查接口实现
+var _ RuleSource = &FileRuleSource{}
+
+// FileRuleSource 从本地目录加载规则并在文件变化时热更新
+//
+// 目录下按 <prefix>-flow-rules.json、<prefix>-circuit-breaker-rules.json、
+// <prefix>-hotspot-rules.json、<prefix>-system-rules.json 查找规则文件，格式与 configs/sentinel 下的示例一致。
+// 文件不存在时不加载对应规则，之后创建的文件会被自动加载，删除文件会清空对应规则。
+type FileRuleSource struct {
+	dir      string
+	handlers map[string]datasource.PropertyHandler // 文件名 -> 规则处理器
+
+	lock    sync.Mutex
+	last    map[string][]byte // 已加载的文件内容，内容不变时不重复加载规则
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+}
+
+// NewFileRuleSource 创建本地文件规则数据源，prefix 一般为服务名，如 coupon
+func NewFileRuleSource(dir, prefix string) *FileRuleSource {
+	return &FileRuleSource{
+		dir: dir,
+		handlers: map[string]datasource.PropertyHandler{
+			prefix + "-flow-rules.json":            datasource.NewFlowRulesHandler(flowRulesParser),
+			prefix + "-circuit-breaker-rules.json": datasource.NewCircuitBreakerRulesHandler(circuitBreakerRulesParser),
+			prefix + "-hotspot-rules.json":         datasource.NewHotSpotParamRulesHandler(hotspotRulesParser),
+			prefix + "-system-rules.json":          datasource.NewSystemRulesHandler(systemRulesParser),
+		},
+		last: make(map[string][]byte),
+		done: make(chan struct{}),
+	}
+}
+
+// Initialize 加载所有规则文件并开始监听目录
+func (s *FileRuleSource) Initialize() error {
+	dir, err := filepath.Abs(s.dir)
+	if err != nil {
+		return err
+	}
+	s.dir = dir
+	// 监听目录而不是文件本身，文件被编辑器或原子替换重建后仍能收到事件
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := fw.Add(s.dir); err != nil {
+		_ = fw.Close()
+		return fmt.Errorf("watch sentinel rules dir %s: %w", s.dir, err)
+	}
+	s.watcher = fw
+
+	for name := range s.handlers {
+		if err := s.load(name); err != nil {
+			_ = fw.Close()
+			return err
+		}
+	}
+	go s.run()
+	return nil
+}
+
+// Close 停止监听规则目录，已加载的规则保持不变
+func (s *FileRuleSource) Close() error {
+	select {
+	case <-s.done:
+		return nil
+	default:
+		close(s.done)
+	}
+	if s.watcher == nil {
+		return nil
+	}
+	return s.watcher.Close()
+}
+
+// run 监听规则文件变化并重新加载
+func (s *FileRuleSource) run() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case ev, ok := <-s.watcher.Events:
+			if !ok {
+				return
+			}
+			name := filepath.Base(ev.Name)
+			if _, ok := s.handlers[name]; !ok || filepath.Dir(filepath.Clean(ev.Name)) != s.dir {
+				continue
+			}
+			// 文件写到一半时解析会失败，保留旧规则等待下一次写入事件
+			if err := s.load(name); err != nil {
+				log.Errorf("[sentinel] reload %s failed: %v", ev.Name, err)
+			}
+		case err, ok := <-s.watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Errorf("[sentinel] watch %s failed: %v", s.dir, err)
+		}
+	}
+}
+
+// load 读取规则文件并更新规则，文件不存在时清空对应规则
+func (s *FileRuleSource) load(name string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	path := filepath.Join(s.dir, name)
+	src, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	prev, loaded := s.last[name]
+	if loaded && bytes.Equal(prev, src) {
+		return nil
+	}
+	if src == nil && !loaded {
+		log.Warnf("[sentinel] rule file %s not found, skipped", path)
+		return nil
+	}
+
+	if err := s.handlers[name].Handle(src); err != nil {
+		return fmt.Errorf("load sentinel rules from %s: %w", path, err)
+	}
+	if src == nil {
+		delete(s.last, name)
+		log.Infof("[sentinel] rule file %s removed, rules cleared", path)
+		return nil
+	}
+	s.last[name] = src
+	log.Infof("[sentinel] rules loaded from %s", path)
+	return nil
+}
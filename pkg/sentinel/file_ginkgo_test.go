@@ -0,0 +1,102 @@
+package sentinel_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/alibaba/sentinel-golang/core/circuitbreaker"
+	"github.com/alibaba/sentinel-golang/core/flow"
+	"github.com/alibaba/sentinel-golang/core/hotspot"
+	"github.com/alibaba/sentinel-golang/core/system"
+
+	sentinel "emshop/pkg/sentinel"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FileRuleSource", func() {
+	var (
+		dir    string
+		source *sentinel.FileRuleSource
+	)
+
+	copyRules := func(kind string) {
+		data, err := os.ReadFile(filepath.Join("..", "..", "configs", "sentinel", "coupon-"+kind+"-rules.json"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.WriteFile(filepath.Join(dir, "coupon-"+kind+"-rules.json"), data, 0o644)).To(Succeed())
+	}
+
+	// replace 模拟编辑器保存：写临时文件后原子替换
+	replace := func(name, content string) {
+		tmp := filepath.Join(dir, "."+name+".tmp")
+		Expect(os.WriteFile(tmp, []byte(content), 0o644)).To(Succeed())
+		Expect(os.Rename(tmp, filepath.Join(dir, name))).To(Succeed())
+	}
+
+	flowThreshold := func(resource string) float64 {
+		rules := flow.GetRulesOfResource(resource)
+		if len(rules) == 0 {
+			return 0
+		}
+		return rules[0].Threshold
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		Expect(flow.ClearRules()).To(Succeed())
+		Expect(circuitbreaker.ClearRules()).To(Succeed())
+		Expect(hotspot.ClearRules()).To(Succeed())
+		Expect(system.ClearRules()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		if source != nil {
+			Expect(source.Close()).To(Succeed())
+		}
+	})
+
+	It("loads the sample rule files shipped in configs/sentinel", func() {
+		for _, kind := range []string{"flow", "circuit-breaker", "hotspot", "system"} {
+			copyRules(kind)
+		}
+		source = sentinel.NewFileRuleSource(dir, "coupon")
+		Expect(source.Initialize()).To(Succeed())
+
+		Expect(flowThreshold("coupon-srv:IssueCoupon")).To(BeNumerically("==", 200))
+		Expect(circuitbreaker.GetRulesOfResource("coupon-srv:IssueCoupon")).NotTo(BeEmpty())
+		hot := hotspot.GetRulesOfResource("coupon-srv:GetUserCoupons")
+		Expect(hot).To(HaveLen(1))
+		Expect(hot[0].SpecificItems).To(HaveKeyWithValue("vip_user_123", int64(50)))
+		Expect(system.GetRules()).NotTo(BeEmpty())
+	})
+
+	It("hot-reloads changed, created and removed files", func() {
+		source = sentinel.NewFileRuleSource(dir, "coupon")
+		Expect(source.Initialize()).To(Succeed())
+		Expect(flow.GetRulesOfResource("coupon-srv:UseCoupon")).To(BeEmpty())
+
+		replace("coupon-flow-rules.json", `[{"resource":"coupon-srv:UseCoupon","threshold":10,"statIntervalInMs":1000}]`)
+		Eventually(func() float64 { return flowThreshold("coupon-srv:UseCoupon") }, 2*time.Second, 20*time.Millisecond).
+			Should(BeNumerically("==", 10))
+
+		replace("coupon-flow-rules.json", `[{"resource":"coupon-srv:UseCoupon","threshold":30,"statIntervalInMs":1000}]`)
+		Eventually(func() float64 { return flowThreshold("coupon-srv:UseCoupon") }, 2*time.Second, 20*time.Millisecond).
+			Should(BeNumerically("==", 30))
+
+		Expect(os.Remove(filepath.Join(dir, "coupon-flow-rules.json"))).To(Succeed())
+		Eventually(func() []flow.Rule { return flow.GetRulesOfResource("coupon-srv:UseCoupon") }, 2*time.Second, 20*time.Millisecond).
+			Should(BeEmpty())
+	})
+
+	It("keeps the previous rules when a file cannot be parsed", func() {
+		replace("coupon-flow-rules.json", `[{"resource":"coupon-srv:UseCoupon","threshold":10,"statIntervalInMs":1000}]`)
+		source = sentinel.NewFileRuleSource(dir, "coupon")
+		Expect(source.Initialize()).To(Succeed())
+
+		replace("coupon-flow-rules.json", `[{"resource":`)
+		Consistently(func() float64 { return flowThreshold("coupon-srv:UseCoupon") }, 200*time.Millisecond, 20*time.Millisecond).
+			Should(BeNumerically("==", 10))
+	})
+})
@@ -76,6 +76,15 @@ func shouldConnect() bool {
 	return true
 }
 
+// Ping 检查 ConnectToRedis 建立的连接，可作为健康检查项
+func Ping(ctx context.Context) error {
+	client := singleton(false)
+	if client == nil {
+		return ErrRedisIsDown
+	}
+	return client.Ping(ctx).Err()
+}
+
 // Connected returns true if we are connected to redis.
 func Connected() bool {
 	if v := redisUp.Load(); v != nil {
@@ -1,8 +1,12 @@
 // k6 run -e CONSUL_HTTP_ADDR=http://127.0.0.1:8500 -e CONSUL_SERVICE=emshop-coupon-srv -e FLASH_SALE_ID=101 -e USER_ID=20001 scripts/k6/grpc_coupon.js
+//
+// 秒杀开启排队(waiting_room.enabled)时，flashsale_participate 场景每次迭代使用不同用户：
+// 入队 -> 轮询排队状态直到放行 -> 携带放行令牌参与秒杀；WAITING_ROOM=off 时直接参与
 
 import http from 'k6/http';
 import grpc from 'k6/net/grpc';
 import { check, sleep } from 'k6';
+import { Counter, Trend } from 'k6/metrics';
 
 const client = new grpc.Client();
 client.load(['../../api/coupon/v1'], 'coupon.proto');
@@ -14,6 +18,17 @@ const FLASH_SALE_ID = Number(__ENV.FLASH_SALE_ID) || 1;
 const USER_ID = Number(__ENV.USER_ID) || 10001;
 const ORDER_AMOUNT = Number(__ENV.ORDER_AMOUNT) || 199.0;
 const COUPON_IDS = (__ENV.COUPON_IDS || '1').split(',').map((id) => Number(id.trim()) || 1);
+const WAITING_ROOM = (__ENV.WAITING_ROOM || 'on') !== 'off';
+const QUEUE_POLL_INTERVAL = Number(__ENV.QUEUE_POLL_INTERVAL) || 1;
+const QUEUE_MAX_WAIT = Number(__ENV.QUEUE_MAX_WAIT) || 60;
+
+// 排队状态，与服务端 FlashSaleQueueStatusResponse.status 一致
+const QUEUE_WAITING = 1;
+const QUEUE_ADMITTED = 2;
+
+const queueWait = new Trend('flashsale_queue_wait_seconds');
+const queueRejected = new Counter('flashsale_queue_rejected');
+const queueTimeout = new Counter('flashsale_queue_timeout');
 
 export const options = {
   scenarios: {
@@ -107,11 +122,49 @@ export function flashSaleStock(data) {
   sleep(__ENV.SLEEP || 0.1);
 }
 
+// waitForAdmission 入队并轮询到放行，返回放行令牌；队列已满、活动关闭或超时返回null
+function waitForAdmission(userId) {
+  const req = { userId, flashSaleId: FLASH_SALE_ID };
+  const started = Date.now();
+  let res = client.invoke('proto.Coupon/EnterFlashSaleQueue', req);
+  if (!res || res.status !== grpc.StatusOK) {
+    if (res && res.status === grpc.StatusResourceExhausted) {
+      queueRejected.add(1);
+    }
+    check(res, { 'queue entered': (r) => r && r.status === grpc.StatusOK });
+    return null;
+  }
+
+  while (res.message.status === QUEUE_WAITING) {
+    if ((Date.now() - started) / 1000 > QUEUE_MAX_WAIT) {
+      queueTimeout.add(1);
+      return null;
+    }
+    sleep(QUEUE_POLL_INTERVAL);
+    res = client.invoke('proto.Coupon/GetFlashSaleQueueStatus', req);
+    if (!res || res.status !== grpc.StatusOK) {
+      return null;
+    }
+  }
+  queueWait.add((Date.now() - started) / 1000);
+  return res.message.status === QUEUE_ADMITTED ? (res.message.admissionToken || '') : null;
+}
+
 export function flashSaleParticipate(data) {
   withClient(data.target, () => {
+    // 排队时每次迭代模拟一个新用户，否则同一用户只会命中限购
+    const userId = WAITING_ROOM ? USER_ID + __VU * 1000000 + __ITER : USER_ID;
+    let admissionToken = '';
+    if (WAITING_ROOM) {
+      admissionToken = waitForAdmission(userId);
+      if (admissionToken === null) {
+        return;
+      }
+    }
     const res = client.invoke('proto.Coupon/ParticipateFlashSale', {
-      userId: USER_ID,
+      userId,
       flashSaleId: FLASH_SALE_ID,
+      admissionToken,
     });
     check(res, {
       'participation accepted': (r) => r && r.status === grpc.StatusOK,
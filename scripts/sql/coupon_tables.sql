@@ -73,7 +73,10 @@ CREATE TABLE coupon_usage_logs (
 -- 创建秒杀活动表
 CREATE TABLE flash_sale_activities (
     id BIGINT PRIMARY KEY AUTO_INCREMENT,
-    coupon_template_id BIGINT NOT NULL COMMENT '关联的优惠券模板ID',
+    activity_type TINYINT NOT NULL DEFAULT 1 COMMENT '活动类型: 1-秒杀优惠券, 2-商品秒杀',
+    coupon_template_id BIGINT NOT NULL DEFAULT 0 COMMENT '关联的优惠券模板ID,商品秒杀为0',
+    goods_id INT NOT NULL DEFAULT 0 COMMENT '秒杀商品ID',
+    sale_price DECIMAL(10,2) NOT NULL DEFAULT 0 COMMENT '秒杀价',
     name VARCHAR(100) NOT NULL COMMENT '秒杀活动名称',
     start_time TIMESTAMP NOT NULL COMMENT '秒杀开始时间',
     end_time TIMESTAMP NOT NULL COMMENT '秒杀结束时间',
@@ -86,11 +89,10 @@ CREATE TABLE flash_sale_activities (
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     
     INDEX idx_coupon_template_id (coupon_template_id),
+    INDEX idx_goods_id (goods_id),
     INDEX idx_status (status),
     INDEX idx_flash_sale_time (start_time, end_time),
-    INDEX idx_sort_order (sort_order),
-    
-    FOREIGN KEY (coupon_template_id) REFERENCES coupon_templates(id) ON DELETE CASCADE
+    INDEX idx_sort_order (sort_order)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='秒杀活动表';
 
 -- 创建秒杀参与记录表
@@ -113,6 +115,27 @@ CREATE TABLE flash_sale_records (
     FOREIGN KEY (user_coupon_id) REFERENCES user_coupons(id) ON DELETE SET NULL
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='秒杀参与记录表';
 
+-- 创建商品秒杀预占单表
+CREATE TABLE flash_sale_reservations (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    order_sn VARCHAR(30) NOT NULL COMMENT '预占单号,即订单号',
+    flash_sale_id BIGINT NOT NULL COMMENT '秒杀活动ID',
+    user_id BIGINT NOT NULL COMMENT '用户ID',
+    goods_id INT NOT NULL COMMENT '商品ID',
+    quantity INT NOT NULL DEFAULT 1 COMMENT '数量',
+    sale_price DECIMAL(10,2) NOT NULL COMMENT '秒杀价',
+    status TINYINT NOT NULL COMMENT '状态: 1-已预占, 2-已成交, 3-已归还',
+    expires_at TIMESTAMP NOT NULL COMMENT '支付截止时间',
+    settled_at TIMESTAMP NULL COMMENT '确认或归还时间',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    UNIQUE KEY uk_order_sn (order_sn),
+    INDEX idx_flash_sale_id (flash_sale_id),
+    INDEX idx_user_id (user_id),
+    INDEX idx_status_expires (status, expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='商品秒杀预占单表';
+
 -- 创建优惠券配置表
 CREATE TABLE coupon_configs (
     id BIGINT PRIMARY KEY AUTO_INCREMENT,
@@ -125,6 +148,145 @@ CREATE TABLE coupon_configs (
     INDEX idx_config_key (config_key)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='优惠券配置表';
 
+-- 创建优惠券到期提醒表
+CREATE TABLE coupon_reminders (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    user_coupon_id BIGINT NOT NULL COMMENT '用户优惠券ID',
+    user_id BIGINT NOT NULL COMMENT '用户ID',
+    coupon_template_id BIGINT NOT NULL COMMENT '优惠券模板ID',
+    coupon_name VARCHAR(100) NOT NULL DEFAULT '' COMMENT '优惠券名称',
+    coupon_code VARCHAR(32) NOT NULL COMMENT '优惠券码',
+    expired_at TIMESTAMP NOT NULL COMMENT '过期时间',
+    remaining_days INT NOT NULL COMMENT '生成提醒时剩余天数',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    UNIQUE KEY idx_user_coupon_id (user_coupon_id),
+    INDEX idx_user_id (user_id),
+    INDEX idx_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='优惠券到期提醒表';
+
+-- 创建优惠券兑换码表
+CREATE TABLE coupon_promo_codes (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    code VARCHAR(32) NOT NULL COMMENT '兑换码',
+    coupon_template_id BIGINT NOT NULL COMMENT '优惠券模板ID',
+    batch_no VARCHAR(32) NOT NULL DEFAULT '' COMMENT '生成批次号',
+    kind TINYINT NOT NULL COMMENT '类型:1一次性兑换码,2公开兑换码',
+    max_redemptions INT NOT NULL DEFAULT 1 COMMENT '最大兑换次数,0表示不限',
+    redeemed_count INT NOT NULL DEFAULT 0 COMMENT '已兑换次数',
+    status TINYINT NOT NULL DEFAULT 1 COMMENT '状态:1可兑换,2已停用',
+    operator VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建人',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    
+    UNIQUE KEY idx_code (code),
+    INDEX idx_template_batch (coupon_template_id, batch_no)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='优惠券兑换码表';
+
+-- 创建兑换码兑换记录表
+CREATE TABLE coupon_promo_code_redemptions (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    promo_code_id BIGINT NOT NULL COMMENT '兑换码ID',
+    user_id BIGINT NOT NULL COMMENT '用户ID',
+    user_coupon_id BIGINT NOT NULL COMMENT '发放的用户优惠券ID',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    UNIQUE KEY idx_code_user (promo_code_id, user_id),
+    INDEX idx_user_id (user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='兑换码兑换记录表';
+
+-- 定向发券活动表
+CREATE TABLE coupon_campaigns (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(100) NOT NULL COMMENT '活动名称',
+    coupon_template_id BIGINT NOT NULL COMMENT '优惠券模板ID',
+    segment_type VARCHAR(32) NOT NULL COMMENT '目标用户圈选方式: user_ids/registered/inactive/fav_category',
+    segment_params TEXT COMMENT '圈选参数(JSON)',
+    status TINYINT NOT NULL DEFAULT 1 COMMENT '状态: 1-草稿 2-发放中 3-已暂停 4-已完成 5-已取消',
+    batch_size INT NOT NULL COMMENT '每批发放数量',
+    batch_interval_seconds INT NOT NULL DEFAULT 0 COMMENT '批次间隔(秒)',
+    total_targets BIGINT NOT NULL DEFAULT 0 COMMENT '目标用户数',
+    operator VARCHAR(64) NOT NULL DEFAULT '' COMMENT '创建人',
+    last_dispatched_at TIMESTAMP NULL COMMENT '最近一次投递时间',
+    started_at TIMESTAMP NULL COMMENT '首次开始时间',
+    finished_at TIMESTAMP NULL COMMENT '完成或取消时间',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    
+    INDEX idx_template_id (coupon_template_id),
+    INDEX idx_status (status)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='定向发券活动表';
+
+-- 发券活动目标用户表
+CREATE TABLE coupon_campaign_targets (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    campaign_id BIGINT NOT NULL COMMENT '活动ID',
+    user_id BIGINT NOT NULL COMMENT '用户ID',
+    status TINYINT NOT NULL DEFAULT 1 COMMENT '发放状态: 1-待发放 2-已投递 3-已发放 4-跳过 5-失败',
+    user_coupon_id BIGINT NOT NULL DEFAULT 0 COMMENT '发放的用户优惠券ID',
+    reason VARCHAR(255) NOT NULL DEFAULT '' COMMENT '跳过或失败原因',
+    dispatched_at TIMESTAMP NULL COMMENT '投递时间',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    
+    UNIQUE KEY idx_campaign_user (campaign_id, user_id),
+    INDEX idx_campaign_status (campaign_id, status)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='发券活动目标用户表';
+
+-- 优惠券模板每日统计表(coupon_template_id=0为全部模板汇总)
+CREATE TABLE coupon_daily_stats (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    stat_date DATE NOT NULL COMMENT '统计日期',
+    coupon_template_id BIGINT NOT NULL COMMENT '优惠券模板ID，0为汇总',
+    issued_count BIGINT NOT NULL DEFAULT 0 COMMENT '发放数量',
+    redeemed_count BIGINT NOT NULL DEFAULT 0 COMMENT '核销数量',
+    expired_count BIGINT NOT NULL DEFAULT 0 COMMENT '过期数量',
+    order_count BIGINT NOT NULL DEFAULT 0 COMMENT '用券订单数',
+    original_amount DECIMAL(14,2) NOT NULL DEFAULT 0 COMMENT '用券订单原始金额',
+    discount_amount DECIMAL(14,2) NOT NULL DEFAULT 0 COMMENT '优惠金额',
+    gmv DECIMAL(14,2) NOT NULL DEFAULT 0 COMMENT '用券订单实付金额',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    
+    UNIQUE KEY uk_date_template (stat_date, coupon_template_id),
+    INDEX idx_template_id (coupon_template_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='优惠券模板每日统计表';
+
+-- 秒杀活动每日统计表
+CREATE TABLE flash_sale_daily_stats (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    stat_date DATE NOT NULL COMMENT '统计日期',
+    flash_sale_id BIGINT NOT NULL COMMENT '秒杀活动ID',
+    coupon_template_id BIGINT NOT NULL DEFAULT 0 COMMENT '优惠券模板ID',
+    total_stock INT NOT NULL DEFAULT 0 COMMENT '秒杀总库存',
+    sold_count BIGINT NOT NULL DEFAULT 0 COMMENT '当日抢购成功数',
+    failed_count BIGINT NOT NULL DEFAULT 0 COMMENT '当日抢购失败数',
+    cumulative_sold BIGINT NOT NULL DEFAULT 0 COMMENT '截至当日累计售出',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    
+    UNIQUE KEY uk_date_flash_sale (stat_date, flash_sale_id),
+    INDEX idx_flash_sale_id (flash_sale_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='秒杀活动每日统计表';
+
+-- 风控决策记录表
+CREATE TABLE coupon_risk_decisions (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    scene VARCHAR(32) NOT NULL COMMENT '场景: receive_coupon/flash_sale/sms',
+    target_id BIGINT NOT NULL DEFAULT 0 COMMENT '优惠券模板ID或秒杀活动ID',
+    user_id BIGINT NOT NULL DEFAULT 0 COMMENT '用户ID，短信场景为0',
+    mobile VARCHAR(20) NOT NULL DEFAULT '' COMMENT '手机号',
+    device_id VARCHAR(64) NOT NULL DEFAULT '' COMMENT '设备ID',
+    client_ip VARCHAR(64) NOT NULL DEFAULT '' COMMENT '客户端IP',
+    score INT NOT NULL DEFAULT 0 COMMENT '风险分',
+    action VARCHAR(16) NOT NULL COMMENT '动作: allow/captcha/block',
+    reasons VARCHAR(255) NOT NULL DEFAULT '' COMMENT '命中信号，逗号分隔',
+    signals VARCHAR(1024) NOT NULL DEFAULT '' COMMENT '信号快照JSON',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    
+    INDEX idx_created_at (created_at),
+    INDEX idx_user_id (user_id),
+    INDEX idx_scene_action (scene, action)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='风控决策记录表';
+
 -- 插入基础配置数据
 INSERT INTO coupon_configs (config_key, config_value, description) VALUES
 ('max_stack_coupons', '3', '最大叠加优惠券数量'),
@@ -60,8 +60,10 @@ CREATE TABLE logistics_tracks (
     description TEXT NOT NULL COMMENT '轨迹描述',
     track_time TIMESTAMP NOT NULL COMMENT '轨迹时间',
     operator_name VARCHAR(64) COMMENT '操作员姓名',
+    event_key VARCHAR(64) NULL COMMENT '承运商轨迹去重键，本地生成的轨迹为空',
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '记录创建时间',
     
+    UNIQUE KEY uk_event_key (event_key),
     INDEX idx_logistics_sn (logistics_sn),
     INDEX idx_tracking_number (tracking_number),
     INDEX idx_track_time (track_time)
@@ -97,5 +99,21 @@ INSERT INTO logistics_couriers (courier_code, courier_name, phone, logistics_com
 ('JD001', '徐小东', '13800138009', 6, '武汉市洪山区'),
 ('EMS001', '孙小梅', '13800138010', 7, '南京市鼓楼区');
 
+-- 5. 运费规则配置表 (logistics_rate_configs)
+-- content 为 JSON：区域划分(zones)、物流公司价目(rate_cards)、偏远附加费(remote_areas)、包邮规则(free_shipping)
+CREATE TABLE logistics_rate_configs (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT COMMENT '主键ID',
+    version INT NOT NULL UNIQUE COMMENT '配置版本号',
+    content MEDIUMTEXT NOT NULL COMMENT '配置内容JSON',
+    status TINYINT NOT NULL DEFAULT 1 COMMENT '状态：1-草稿，2-生效中，3-已归档',
+    operator VARCHAR(64) COMMENT '操作人',
+    remark VARCHAR(255) COMMENT '备注',
+    activated_at TIMESTAMP NULL COMMENT '生效时间',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间',
+
+    INDEX idx_status (status)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='运费规则配置表';
+
 -- 创建成功提示
 SELECT 'Logistics database and tables created successfully!' AS message;
\ No newline at end of file
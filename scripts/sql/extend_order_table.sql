@@ -20,6 +20,8 @@ BEGIN
     ALTER TABLE orderinfo ADD COLUMN cancelled_at TIMESTAMP NULL COMMENT '取消时间';
     ALTER TABLE orderinfo ADD COLUMN cancel_reason TEXT COMMENT '取消原因';
     ALTER TABLE orderinfo ADD COLUMN auto_complete_at TIMESTAMP NULL COMMENT '自动完成时间';
+    ALTER TABLE orderinfo ADD COLUMN flash_sale_id BIGINT DEFAULT 0 COMMENT '秒杀活动ID,普通订单为0';
+    ALTER TABLE orderinfo ADD COLUMN pay_deadline DATETIME NULL COMMENT '秒杀订单支付截止时间';
 END$$
 DELIMITER ;
 
@@ -37,6 +39,7 @@ BEGIN
     ALTER TABLE orderinfo ADD INDEX idx_logistics_status (logistics_status);
     ALTER TABLE orderinfo ADD INDEX idx_shipped_at (shipped_at);
     ALTER TABLE orderinfo ADD INDEX idx_delivered_at (delivered_at);
+    ALTER TABLE orderinfo ADD INDEX idx_flash_sale_id (flash_sale_id);
 END$$
 DELIMITER ;
 
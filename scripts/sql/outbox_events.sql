@@ -0,0 +1,21 @@
+-- 事务性发件箱表
+-- 在订单、支付、库存、物流各服务的数据库中分别执行，库存服务启动时也会自动迁移
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id BIGINT UNSIGNED PRIMARY KEY AUTO_INCREMENT,
+    event_id VARCHAR(64) NOT NULL COMMENT '事件ID，消费方据此去重',
+    topic VARCHAR(128) NOT NULL COMMENT '投递的RocketMQ主题',
+    aggregate_type VARCHAR(64) NOT NULL COMMENT '聚合类型',
+    aggregate_id VARCHAR(64) NOT NULL COMMENT '聚合ID，同一聚合的事件按写入顺序投递',
+    event_type VARCHAR(64) NOT NULL COMMENT '事件类型，投递时作为消息Tag',
+    payload TEXT COMMENT '事件内容(JSON)',
+    status VARCHAR(16) NOT NULL COMMENT '状态: pending-待投递, published-已投递, failed-投递失败',
+    attempts INT NOT NULL DEFAULT 0 COMMENT '已投递次数',
+    next_attempt_at DATETIME(3) NULL COMMENT '下次投递时间，领取后推迟为租约到期时间',
+    last_error VARCHAR(512) COMMENT '最近一次投递错误',
+    created_at DATETIME(3) NULL,
+    published_at DATETIME(3) NULL COMMENT '投递成功时间',
+
+    UNIQUE KEY uk_outbox_event_id (event_id),
+    INDEX idx_outbox_aggregate (aggregate_type, aggregate_id),
+    INDEX idx_outbox_due (status, next_attempt_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='事务性发件箱表';